@@ -0,0 +1,83 @@
+package analytics
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	SinkLog       = "log"
+	SinkSegment   = "segment"
+	SinkPublisher = "publisher"
+
+	defaultBatchSize       = 100
+	defaultFlushInterval   = 5 * time.Second
+	defaultQueueSize       = 1000
+	defaultTimeout         = 10 * time.Second
+	defaultTopic           = "analytics"
+	defaultSegmentEndpoint = "https://api.segment.io/v1/batch"
+)
+
+// Config configures the Tracker and its sink.
+type Config struct {
+	Enabled bool `config:"enabled"` // Enable/disable the tracking
+	// Sink selects where the events are sent: log, segment or publisher, default: log
+	Sink          string        `config:"sink"`
+	BatchSize     int           `config:"batch_size"`     // Events sent at once, default: 100
+	FlushInterval time.Duration `config:"flush_interval"` // Period of the send of a partial batch, default: 5s
+	QueueSize     int           `config:"queue_size"`     // Events waiting to be batched, default: 1000
+	Timeout       time.Duration `config:"timeout"`        // Timeout of the send of a batch, default: 10s
+	// Segment configures the segment sink
+	Segment SegmentConfig `config:"segment"`
+	// Topic is the topic of the publisher sink, default: analytics
+	Topic string `config:"topic"`
+}
+
+type SegmentConfig struct {
+	// WriteKey is the write key of the Segment source, e.g. env://SEGMENT_WRITE_KEY
+	WriteKey string `config:"write_key"`
+	// Endpoint is the batch endpoint, default: https://api.segment.io/v1/batch
+	Endpoint string `config:"endpoint"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Sink == "" {
+		c.Sink = SinkLog
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = defaultFlushInterval
+	}
+	if c.QueueSize == 0 {
+		c.QueueSize = defaultQueueSize
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.Segment.Endpoint == "" {
+		c.Segment.Endpoint = defaultSegmentEndpoint
+	}
+	if c.Topic == "" {
+		c.Topic = defaultTopic
+	}
+}
+
+// Validate checks the sink and its configuration.
+func (c *Config) Validate() error {
+	switch c.Sink {
+	case SinkLog, SinkPublisher:
+	case SinkSegment:
+		if c.Segment.WriteKey == "" {
+			return ErrWriteKeyRequired
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidSink, c.Sink)
+	}
+	if c.BatchSize < 0 || c.QueueSize < 0 || c.FlushInterval < 0 {
+		return ErrInvalidBatching
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package analytics
+
+import "errors"
+
+var (
+	ErrInvalidSink      = errors.New("invalid analytics sink (must be 'log', 'segment' or 'publisher')")
+	ErrWriteKeyRequired = errors.New("the segment sink requires a write key")
+	ErrInvalidBatching  = errors.New("batch_size, queue_size and flush_interval must not be negative")
+	ErrMissingPublisher = errors.New("the publisher sink requires an analytics.Publisher")
+
+	ErrSend = errors.New("failed to send analytics events")
+)
@@ -0,0 +1,74 @@
+package analytics
+
+import (
+	"net/http"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Module provides the Tracker configured from app.analytics, a Nop when the tracking is
+// disabled. The tracked events are sent before the application stops. The publisher sink
+// requires an analytics.Publisher, and a Sink provided to the graph replaces the configured
+// one.
+//
+//	fx.New(
+//	    logger.Module,
+//	    analytics.Module,
+//	    fx.Provide(usecase.NewPlaceOrderUseCase), // takes an analytics.Tracker
+//	)
+var Module = fx.Module(
+	"analytics",
+	config.Provide[Config]("app.analytics"),
+	fx.Provide(NewWithLifecycle),
+)
+
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    config.Config[Config]
+	Logger    logger.Logger
+	Sink      Sink            `optional:"true"`
+	Publisher Publisher       `optional:"true"`
+	Clock     clock.Clock     `optional:"true"`
+	IDs       ident.Generator `optional:"true"`
+}
+
+// NewWithLifecycle creates the Tracker, and closes it on stop, sending the queued events.
+func NewWithLifecycle(p NewWithLifecycleParams) (Tracker, error) {
+	cfg := p.Config.Get()
+	if !cfg.Enabled {
+		return Nop{}, nil
+	}
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	sink := p.Sink
+	if sink == nil {
+		switch cfg.Sink {
+		case SinkSegment:
+			sink = NewSegmentSink(cfg.Segment.WriteKey, cfg.Segment.Endpoint, http.DefaultClient)
+		case SinkPublisher:
+			if p.Publisher == nil {
+				return nil, ErrMissingPublisher
+			}
+			sink = NewPublisherSink(p.Publisher, cfg.Topic)
+		default:
+			sink = NewLogSink(p.Logger)
+		}
+	}
+
+	client, err := New(cfg, sink, p.Logger, WithClock(p.Clock), WithIDGenerator(p.IDs))
+	if err != nil {
+		return nil, err
+	}
+	p.Lifecycle.Append(fx.Hook{OnStop: client.Close})
+	return client, nil
+}
@@ -0,0 +1,32 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// LogSink is the Sink logging each message at info level on a logger named "analytics", e.g.
+// in development or until an analytics service is set up.
+type LogSink struct {
+	logger logger.Logger
+}
+
+// NewLogSink creates a LogSink logging with a child of log.
+func NewLogSink(log logger.Logger) *LogSink {
+	return &LogSink{logger: log.Named("analytics")}
+}
+
+// Write implements Sink.
+func (s *LogSink) Write(_ context.Context, messages []Message) error {
+	for _, message := range messages {
+		s.logger.Info(string(message.Event),
+			logger.Stringer("message_id", message.ID),
+			logger.String("user_id", message.UserID),
+			logger.String("tenant_id", message.TenantID),
+			logger.Time("timestamp", message.Timestamp),
+			logger.Any("properties", message.Properties),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package analytics
+
+import (
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+type options struct {
+	clock clock.Clock
+	ids   ident.Generator
+}
+
+// Option configures the Client created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{clock: clock.New(), ids: ident.New()}
+}
+
+// WithClock sets the clock of the event timestamps and the flush interval, e.g. a clock.Fake
+// in tests. Defaults to the time package when not provided.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithIDGenerator sets the generator of the message IDs, e.g. an ident.Sequence in tests.
+// Defaults to UUIDv7 when not provided.
+func WithIDGenerator(ids ident.Generator) Option {
+	return func(o *options) {
+		if ids != nil {
+			o.ids = ids
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Publisher sends a message to a topic of a message broker (Kafka, NATS, SQS, ...). The
+// key orders the messages of the same user on brokers that partition by key.
+type Publisher interface {
+	Publish(ctx context.Context, topic, key string, payload []byte) error
+}
+
+// PublisherSink is the Sink publishing each message as JSON, e.g. to a Kafka topic consumed
+// by the data warehouse.
+type PublisherSink struct {
+	publisher Publisher
+	topic     string
+}
+
+// NewPublisherSink creates a PublisherSink publishing to topic.
+func NewPublisherSink(publisher Publisher, topic string) *PublisherSink {
+	return &PublisherSink{publisher: publisher, topic: topic}
+}
+
+// Write implements Sink. The key is the user ID, or the message ID for an anonymous event.
+// Every message is published, and their errors joined.
+func (s *PublisherSink) Write(ctx context.Context, messages []Message) error {
+	var errs []error
+	for _, message := range messages {
+		payload, err := json.Marshal(message)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("analytics: encode message: %w", err))
+			continue
+		}
+		key := message.UserID
+		if key == "" {
+			key = message.ID.String()
+		}
+		if err = s.publisher.Publish(ctx, s.topic, key, payload); err != nil {
+			errs = append(errs, fmt.Errorf("analytics: publish message: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const segmentLibrary = "bricks-analytics"
+
+// SegmentSink sends the messages as track calls to the batch endpoint of Segment.
+type SegmentSink struct {
+	writeKey string
+	endpoint string
+	client   *http.Client
+}
+
+// NewSegmentSink creates a SegmentSink for the source of writeKey, posting to endpoint.
+func NewSegmentSink(writeKey, endpoint string, client *http.Client) *SegmentSink {
+	return &SegmentSink{writeKey: writeKey, endpoint: endpoint, client: client}
+}
+
+// Write implements Sink. The tenant is the group of the call, and a message without user
+// is anonymous, identified by its ID.
+func (s *SegmentSink) Write(ctx context.Context, messages []Message) error {
+	batch := segmentBatch{
+		Batch:  make([]segmentTrack, 0, len(messages)),
+		SentAt: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	for _, message := range messages {
+		track := segmentTrack{
+			Type:       "track",
+			MessageID:  message.ID.String(),
+			Event:      string(message.Event),
+			Properties: message.Properties,
+			UserID:     message.UserID,
+			Timestamp:  message.Timestamp.Format(time.RFC3339Nano),
+			Context:    segmentContext{GroupID: message.TenantID, Library: segmentLib{Name: segmentLibrary}},
+		}
+		if track.UserID == "" {
+			track.AnonymousID = track.MessageID
+		}
+		batch.Batch = append(batch.Batch, track)
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("analytics: encode batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(s.writeKey, "")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSend, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: %s: %s", ErrSend, resp.Status, bytes.TrimSpace(message))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// segmentBatch is the payload of the batch endpoint, see
+// https://segment.com/docs/connections/sources/catalog/libraries/server/http-api/#batch.
+type segmentBatch struct {
+	Batch  []segmentTrack `json:"batch"`
+	SentAt string         `json:"sentAt"`
+}
+
+type segmentTrack struct {
+	Type        string         `json:"type"`
+	MessageID   string         `json:"messageId"`
+	Event       string         `json:"event"`
+	Properties  map[string]any `json:"properties,omitempty"`
+	UserID      string         `json:"userId,omitempty"`
+	AnonymousID string         `json:"anonymousId,omitempty"`
+	Timestamp   string         `json:"timestamp"`
+	Context     segmentContext `json:"context"`
+}
+
+type segmentContext struct {
+	GroupID string     `json:"groupId,omitempty"`
+	Library segmentLib `json:"library"`
+}
+
+type segmentLib struct {
+	Name string `json:"name"`
+}
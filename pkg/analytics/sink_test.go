@@ -0,0 +1,123 @@
+package analytics_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/analytics"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+type publishedMessage struct {
+	topic, key string
+	payload    []byte
+}
+
+type recordingPublisher struct {
+	messages []publishedMessage
+	err      error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic, key string, payload []byte) error {
+	p.messages = append(p.messages, publishedMessage{topic: topic, key: key, payload: payload})
+	return p.err
+}
+
+func TestSegmentSink_Write(t *testing.T) {
+	t.Run("posts the messages as track calls", func(t *testing.T) {
+		// Arrange
+		var writeKey string
+		var body map[string][]map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeKey, _, _ = r.BasicAuth()
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		sink := analytics.NewSegmentSink("write-key", server.URL, server.Client())
+		timestamp := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+		// Act
+		err := sink.Write(context.Background(), []analytics.Message{
+			{ID: ident.SequenceID(1), Event: "Order Placed", UserID: "user-1", TenantID: "tenant-1", Timestamp: timestamp},
+			{ID: ident.SequenceID(2), Event: "Cart Viewed", Properties: analytics.Properties{"items": 3}},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "write-key", writeKey)
+		require.Len(t, body["batch"], 2)
+		placed, viewed := body["batch"][0], body["batch"][1]
+		assert.Equal(t, "track", placed["type"])
+		assert.Equal(t, "Order Placed", placed["event"])
+		assert.Equal(t, "user-1", placed["userId"])
+		assert.Equal(t, "2026-03-04T05:06:07Z", placed["timestamp"])
+		assert.Equal(t, "tenant-1", placed["context"].(map[string]any)["groupId"])
+		assert.Equal(t, ident.SequenceID(2).String(), viewed["anonymousId"])
+		assert.Equal(t, map[string]any{"items": float64(3)}, viewed["properties"])
+	})
+
+	t.Run("returns ErrSend on a rejected batch", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "invalid write key", http.StatusUnauthorized)
+		}))
+		defer server.Close()
+		sink := analytics.NewSegmentSink("write-key", server.URL, server.Client())
+
+		// Act
+		err := sink.Write(context.Background(), []analytics.Message{{ID: ident.SequenceID(1), Event: "Order Placed"}})
+
+		// Assert
+		require.ErrorIs(t, err, analytics.ErrSend)
+		assert.Contains(t, err.Error(), "invalid write key")
+	})
+}
+
+func TestPublisherSink_Write(t *testing.T) {
+	t.Run("publishes each message keyed by user", func(t *testing.T) {
+		// Arrange
+		publisher := &recordingPublisher{}
+		sink := analytics.NewPublisherSink(publisher, "analytics")
+
+		// Act
+		err := sink.Write(context.Background(), []analytics.Message{
+			{ID: ident.SequenceID(1), Event: "Order Placed", UserID: "user-1"},
+			{ID: ident.SequenceID(2), Event: "Cart Viewed"},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, publisher.messages, 2)
+		assert.Equal(t, "analytics", publisher.messages[0].topic)
+		assert.Equal(t, "user-1", publisher.messages[0].key)
+		assert.Equal(t, ident.SequenceID(2).String(), publisher.messages[1].key)
+		var message analytics.Message
+		require.NoError(t, json.Unmarshal(publisher.messages[0].payload, &message))
+		assert.Equal(t, analytics.Event("Order Placed"), message.Event)
+	})
+
+	t.Run("publishes every message and joins the errors", func(t *testing.T) {
+		// Arrange
+		publisher := &recordingPublisher{err: errors.New("broker unavailable")}
+		sink := analytics.NewPublisherSink(publisher, "analytics")
+
+		// Act
+		err := sink.Write(context.Background(), []analytics.Message{
+			{ID: ident.SequenceID(1), Event: "Order Placed"},
+			{ID: ident.SequenceID(2), Event: "Cart Viewed"},
+		})
+
+		// Assert
+		require.ErrorContains(t, err, "broker unavailable")
+		assert.Len(t, publisher.messages, 2)
+	})
+}
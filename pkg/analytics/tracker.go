@@ -0,0 +1,209 @@
+// Package analytics tracks the business events of the application, e.g. "Order Placed", and
+// sends them in batches to an analytics sink, apart from the operational logs.
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Event is the name of a business event, declared once by the product team:
+//
+//	const OrderPlaced analytics.Event = "Order Placed"
+type Event string
+
+// Properties are the attributes of a tracked event.
+type Properties map[string]any
+
+// Message is a tracked event, completed with the context of the call.
+type Message struct {
+	ID         uuid.UUID  `json:"id"`
+	Event      Event      `json:"event"`
+	Properties Properties `json:"properties,omitempty"`
+	UserID     string     `json:"user_id,omitempty"`
+	TenantID   string     `json:"tenant_id,omitempty"`
+	Timestamp  time.Time  `json:"timestamp"`
+}
+
+// Tracker tracks the business events.
+type Tracker interface {
+	// Track queues event with properties, the user and tenant IDs taken from ctx
+	Track(ctx context.Context, event Event, properties Properties)
+	// Flush waits until the tracked events are sent or ctx is done
+	Flush(ctx context.Context) error
+}
+
+// Sink sends a batch of messages to an analytics service.
+type Sink interface {
+	Write(ctx context.Context, messages []Message) error
+}
+
+// Nop is a Tracker dropping the events, e.g. when the tracking is disabled.
+type Nop struct{}
+
+func (Nop) Track(context.Context, Event, Properties) {}
+
+func (Nop) Flush(context.Context) error { return nil }
+
+// Client is the Tracker sending the events to a Sink in batches, from a background queue.
+type Client struct {
+	cfg   Config
+	sink  Sink
+	log   logger.Logger
+	clock clock.Clock
+	ids   ident.Generator
+
+	mu     sync.Mutex
+	closed bool
+
+	queue chan queued
+	done  chan struct{}
+}
+
+// queued is a message to batch, or a flush marker closed once the messages queued before
+// it are sent.
+type queued struct {
+	message Message
+	flushed chan struct{}
+}
+
+// New creates a Client sending the events to sink, logging the dropped events and the failed
+// sends with log. Close it to send the last batch and stop its background queue.
+func New(cfg Config, sink Sink, log logger.Logger, opts ...Option) (*Client, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Client{
+		cfg:   cfg,
+		sink:  sink,
+		log:   log,
+		clock: o.clock,
+		ids:   o.ids,
+		queue: make(chan queued, cfg.QueueSize),
+		done:  make(chan struct{}),
+	}
+	go c.run()
+	return c, nil
+}
+
+// Track implements Tracker. The event is dropped with a warning when the queue is full, so
+// the tracking never slows down the business flow.
+func (c *Client) Track(ctx context.Context, event Event, properties Properties) {
+	message := Message{
+		ID:         c.ids.NewID(),
+		Event:      event,
+		Properties: properties,
+		Timestamp:  c.clock.Now().UTC(),
+	}
+	message.UserID, _ = ctxmeta.UserID(ctx)
+	message.TenantID, _ = ctxmeta.TenantID(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.queue <- queued{message: message}:
+	default:
+		c.log.Warn("analytics queue full, event dropped", logger.String("event", string(event)))
+	}
+}
+
+// Flush implements Tracker: the partial batch is sent without waiting for the flush interval.
+func (c *Client) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	// Sent under the lock, so Close does not close the queue meanwhile
+	select {
+	case c.queue <- queued{flushed: flushed}:
+		c.mu.Unlock()
+	case <-ctx.Done():
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close sends the queued events, waiting until ctx is done, and stops the queue.
+func (c *Client) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.queue)
+	c.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) run() {
+	defer close(c.done)
+	ticker := c.clock.NewTicker(c.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Message, 0, c.cfg.BatchSize)
+	send := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+		defer cancel()
+		if err := c.sink.Write(ctx, batch); err != nil {
+			c.log.Error("failed to send analytics events", logger.Int("events", len(batch)), logger.Error(err))
+		}
+		batch = make([]Message, 0, c.cfg.BatchSize)
+	}
+
+	for {
+		select {
+		case item, ok := <-c.queue:
+			if !ok {
+				send()
+				return
+			}
+			if item.flushed != nil {
+				send()
+				close(item.flushed)
+				continue
+			}
+			batch = append(batch, item.message)
+			if len(batch) >= c.cfg.BatchSize {
+				send()
+			}
+		case <-ticker.C():
+			send()
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package analytics_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/analytics"
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+const orderPlaced analytics.Event = "Order Placed"
+
+var testLogger = logger.MustNewWithOptions(logger.WithLevel("fatal"))
+
+type recordingSink struct {
+	mu      sync.Mutex
+	batches [][]analytics.Message
+}
+
+func (s *recordingSink) Write(_ context.Context, messages []analytics.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, messages)
+	return nil
+}
+
+func (s *recordingSink) Batches() [][]analytics.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]analytics.Message(nil), s.batches...)
+}
+
+func newClient(t *testing.T, cfg analytics.Config, opts ...analytics.Option) (*analytics.Client, *recordingSink) {
+	t.Helper()
+	sink := &recordingSink{}
+	client, err := analytics.New(cfg, sink, testLogger, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close(context.Background()) })
+	return client, sink
+}
+
+func TestNew(t *testing.T) {
+	t.Run("rejects an invalid sink", func(t *testing.T) {
+		// Act
+		_, err := analytics.New(analytics.Config{Sink: "mixpanel"}, &recordingSink{}, testLogger)
+
+		// Assert
+		require.ErrorIs(t, err, analytics.ErrInvalidSink)
+	})
+
+	t.Run("requires the write key of the segment sink", func(t *testing.T) {
+		// Act
+		_, err := analytics.New(analytics.Config{Sink: analytics.SinkSegment}, &recordingSink{}, testLogger)
+
+		// Assert
+		require.ErrorIs(t, err, analytics.ErrWriteKeyRequired)
+	})
+}
+
+func TestClient_Track(t *testing.T) {
+	t.Run("completes the message with the context", func(t *testing.T) {
+		// Arrange
+		now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+		client, sink := newClient(t, analytics.Config{},
+			analytics.WithClock(clock.NewFake(now)),
+			analytics.WithIDGenerator(ident.NewSequence()),
+		)
+		ctx := ctxmeta.WithUserID(context.Background(), "user-1")
+		ctx = ctxmeta.WithTenantID(ctx, "tenant-1")
+
+		// Act
+		client.Track(ctx, orderPlaced, analytics.Properties{"total": 42.5})
+		require.NoError(t, client.Flush(context.Background()))
+
+		// Assert
+		batches := sink.Batches()
+		require.Len(t, batches, 1)
+		assert.Equal(t, []analytics.Message{{
+			ID:         ident.SequenceID(1),
+			Event:      orderPlaced,
+			Properties: analytics.Properties{"total": 42.5},
+			UserID:     "user-1",
+			TenantID:   "tenant-1",
+			Timestamp:  now,
+		}}, batches[0])
+	})
+
+	t.Run("sends a full batch without waiting", func(t *testing.T) {
+		// Arrange
+		client, sink := newClient(t, analytics.Config{BatchSize: 2, FlushInterval: time.Hour})
+
+		// Act
+		for range 3 {
+			client.Track(context.Background(), orderPlaced, nil)
+		}
+
+		// Assert
+		assert.Eventually(t, func() bool { return len(sink.Batches()) == 1 }, time.Second, time.Millisecond)
+		assert.Len(t, sink.Batches()[0], 2)
+		require.NoError(t, client.Close(context.Background()))
+		batches := sink.Batches()
+		require.Len(t, batches, 2)
+		assert.Len(t, batches[1], 1)
+	})
+
+	t.Run("sends a partial batch on the flush interval", func(t *testing.T) {
+		// Arrange
+		clk := clock.NewFake(time.Now())
+		client, sink := newClient(t, analytics.Config{FlushInterval: 5 * time.Second}, analytics.WithClock(clk))
+		client.Track(context.Background(), orderPlaced, nil)
+		require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+
+		// Act & Assert
+		// The tick may be received before the queued event, so the interval is advanced again
+		assert.Eventually(t, func() bool {
+			clk.Advance(5 * time.Second)
+			return len(sink.Batches()) == 1
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("drops the events once closed", func(t *testing.T) {
+		// Arrange
+		client, sink := newClient(t, analytics.Config{})
+		require.NoError(t, client.Close(context.Background()))
+
+		// Act
+		client.Track(context.Background(), orderPlaced, nil)
+
+		// Assert
+		require.NoError(t, client.Flush(context.Background()))
+		assert.Empty(t, sink.Batches())
+	})
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+	"github.com/cristiano-pacheco/bricks/pkg/otel/trace"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+)
+
+// builtinModules lists the built-in modules in composition order: infrastructure comes
+// first so the application modules can depend on it.
+var builtinModules = []namedModules{
+	{name: ModuleTracing, modules: []fx.Option{trace.Module}},
+	{name: ModuleMetrics, modules: []fx.Option{metrics.Module}},
+	{name: ModuleDatabase, modules: []fx.Option{database.Module}},
+	{name: ModuleRedis, modules: []fx.Option{redis.Module}},
+	{name: ModuleHTTP, modules: []fx.Option{validator.Module, response.Module, chi.Module}},
+}
+
+// New creates the fx application of a service: the logger configured at "app.logger",
+// which also logs the fx events, and the modules selected by opts and the app.modules
+// config section.
+//
+//	func main() {
+//	    app.New(
+//	        app.WithHTTP(),
+//	        app.WithDatabase(),
+//	        app.WithModules(orders.Module),
+//	    ).Run()
+//	}
+func New(opts ...Option) *fx.App {
+	appOptions := newOptions(opts)
+
+	return fx.New(
+		logger.Module,
+		logger.FxLogger,
+		fx.StartTimeout(appOptions.startTimeout),
+		fx.StopTimeout(appOptions.stopTimeout),
+		modules(appOptions),
+	)
+}
+
+// Modules returns the modules selected by opts and the app.modules config section without
+// the logger, for runners that provide it themselves such as the serve and worker commands
+// of pkg/cli. The start and stop timeouts are left to the runner.
+func Modules(opts ...Option) fx.Option {
+	return modules(newOptions(opts))
+}
+
+func newOptions(opts []Option) options {
+	appOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&appOptions)
+	}
+	return appOptions
+}
+
+func modules(o options) fx.Option {
+	cfg, err := config.New[Config](append(slices.Clone(o.configOpts), config.WithPath("app"))...)
+	if err != nil {
+		return fx.Error(fmt.Errorf("load app config: %w", err))
+	}
+
+	enabled, disabled, err := resolveModules(o, cfg.Get().Modules)
+	if err != nil {
+		return fx.Error(err)
+	}
+
+	fxOptions := make([]fx.Option, 0, len(enabled)+len(o.modules)+2)
+	if len(o.configOpts) > 0 {
+		fxOptions = append(fxOptions, config.SupplyOptions(o.configOpts...))
+	}
+	var enabledNames, disabledNames []string
+	for _, m := range enabled {
+		fxOptions = append(fxOptions, m.modules...)
+		enabledNames = append(enabledNames, m.name)
+	}
+	for _, m := range disabled {
+		disabledNames = append(disabledNames, m.name)
+	}
+	fxOptions = append(fxOptions, o.modules...)
+	fxOptions = append(fxOptions, fx.Invoke(func(log logger.Logger) {
+		log.Named("app").Info("app modules",
+			logger.String("enabled", strings.Join(enabledNames, ",")),
+			logger.String("disabled", strings.Join(disabledNames, ",")),
+		)
+	}))
+
+	return fx.Options(fxOptions...)
+}
+
+// resolveModules splits the built-in and named modules into enabled and disabled ones.
+// The config overrides the selection made in code.
+func resolveModules(o options, overrides map[string]bool) ([]namedModules, []namedModules, error) {
+	all := make([]namedModules, 0, len(builtinModules)+len(o.named))
+	selected := make(map[string]bool, len(builtinModules)+len(o.named))
+	for _, m := range builtinModules {
+		all = append(all, m)
+		selected[m.name] = o.builtins[m.name]
+	}
+	for _, m := range o.named {
+		if _, exists := selected[m.name]; exists {
+			return nil, nil, fmt.Errorf("%w: %s", ErrDuplicateModule, m.name)
+		}
+		all = append(all, m)
+		selected[m.name] = true
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if _, exists := selected[name]; !exists {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownModule, name)
+		}
+		selected[name] = overrides[name]
+	}
+
+	var enabled, disabled []namedModules
+	for _, m := range all {
+		if selected[m.name] {
+			enabled = append(enabled, m)
+		} else {
+			disabled = append(disabled, m)
+		}
+	}
+	return enabled, disabled, nil
+}
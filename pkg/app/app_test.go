@@ -0,0 +1,209 @@
+package app_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/app"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func writeConfig(t *testing.T, modules string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	content := "app:\n  logger:\n    level: error\n    encoding: json\n    output_paths: [stderr]\n" + modules
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "base.yaml"), []byte(content), 0o600))
+	t.Setenv("APP_CONFIG_DIR", dir)
+	t.Setenv("APP_ENV", "")
+}
+
+func TestNew(t *testing.T) {
+	t.Run("runs the logger and the application modules", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "")
+		var started, stopped bool
+		fxApp := app.New(
+			app.WithStartTimeout(5*time.Second),
+			app.WithModules(fx.Invoke(func(lc fx.Lifecycle, _ logger.Logger) {
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						started = true
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						stopped = true
+						return nil
+					},
+				})
+			})),
+		)
+		require.NoError(t, fxApp.Err())
+
+		// Act
+		startErr := fxApp.Start(context.Background())
+		stopErr := fxApp.Stop(context.Background())
+
+		// Assert
+		require.NoError(t, startErr)
+		require.NoError(t, stopErr)
+		assert.True(t, started)
+		assert.True(t, stopped)
+	})
+
+	t.Run("returns the error of an invalid graph", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "")
+
+		// Act
+		fxApp := app.New(app.WithModules(fx.Invoke(func(*gorm.DB) {})))
+
+		// Assert
+		require.Error(t, fxApp.Err())
+	})
+}
+
+func TestModules(t *testing.T) {
+	t.Run("provides the selected infrastructure", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "")
+		modules := app.Modules(
+			app.WithHTTP(),
+			app.WithDatabase(),
+			app.WithRedis(),
+			app.WithMetrics(),
+			app.WithTracing(),
+			app.WithModules(fx.Invoke(func(*chi.Server, response.ErrorHandler, *gorm.DB, redis.UniversalClient) {})),
+		)
+
+		// Act
+		err := fx.ValidateApp(fx.NopLogger, logger.Module, modules)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("omits the infrastructure that is not selected", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "")
+		modules := app.Modules(app.WithModules(fx.Invoke(func(*gorm.DB) {})))
+
+		// Act
+		err := fx.ValidateApp(fx.NopLogger, logger.Module, modules)
+
+		// Assert
+		require.Error(t, err)
+	})
+
+	t.Run("does not include the logger", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "")
+		modules := app.Modules(app.WithHTTP(), app.WithModules(fx.Invoke(func(response.ErrorHandler) {})))
+
+		// Act
+		err := fx.ValidateApp(fx.NopLogger, modules)
+
+		// Assert
+		require.ErrorContains(t, err, "logger.Logger")
+	})
+}
+
+func TestModules_Config(t *testing.T) {
+	t.Run("enables built-in modules not selected in code", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "  modules:\n    redis: true\n")
+		modules := app.Modules(app.WithModules(fx.Invoke(func(redis.UniversalClient) {})))
+
+		// Act
+		err := fx.ValidateApp(fx.NopLogger, logger.Module, modules)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("disables modules selected in code", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "  modules:\n    database: false\n")
+		modules := app.Modules(app.WithDatabase(), app.WithModules(fx.Invoke(func(*gorm.DB) {})))
+
+		// Act
+		err := fx.ValidateApp(fx.NopLogger, logger.Module, modules)
+
+		// Assert
+		require.ErrorContains(t, err, "gorm.DB")
+	})
+
+	t.Run("enables and disables named modules", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "  modules:\n    worker: false\n")
+		var apiRan, workerRan bool
+		fxApp := fx.New(fx.NopLogger, logger.Module, app.Modules(
+			app.WithNamedModules("api", fx.Invoke(func() { apiRan = true })),
+			app.WithNamedModules("worker", fx.Invoke(func() { workerRan = true })),
+		))
+
+		// Act
+		err := fxApp.Err()
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, apiRan)
+		assert.False(t, workerRan)
+	})
+
+	t.Run("returns an error for unknown modules", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "  modules:\n    workers: true\n")
+
+		// Act
+		err := fx.ValidateApp(fx.NopLogger, logger.Module, app.Modules())
+
+		// Assert
+		require.ErrorIs(t, err, app.ErrUnknownModule)
+	})
+
+	t.Run("returns an error for duplicate named modules", func(t *testing.T) {
+		// Arrange
+		writeConfig(t, "")
+
+		// Act
+		err := fx.ValidateApp(fx.NopLogger, logger.Module, app.Modules(app.WithNamedModules(app.ModuleHTTP)))
+
+		// Assert
+		require.ErrorIs(t, err, app.ErrDuplicateModule)
+	})
+}
+
+func TestModules_ConfigOptions(t *testing.T) {
+	// Arrange
+	writeConfig(t, "")
+	t.Setenv("MYSVC_CONFIG_DIR", os.Getenv("APP_CONFIG_DIR"))
+	t.Setenv("APP_CONFIG_DIR", t.TempDir())
+	var supplied config.Options
+
+	// Act
+	fxApp := fx.New(
+		fx.NopLogger,
+		logger.Module,
+		app.Modules(
+			app.WithConfigOptions(config.WithEnvPrefix("MYSVC_")),
+			app.WithModules(fx.Populate(&supplied)),
+		),
+	)
+
+	// Assert
+	require.NoError(t, fxApp.Err())
+	assert.Len(t, supplied, 1)
+}
@@ -0,0 +1,8 @@
+package app
+
+// Config is the application configuration loaded from "app".
+type Config struct {
+	// Modules enables or disables modules by name, overriding the options given in code.
+	// Built-in modules not selected in code can be enabled; named modules can be disabled.
+	Modules map[string]bool `config:"modules"`
+}
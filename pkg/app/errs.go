@@ -0,0 +1,11 @@
+package app
+
+import "errors"
+
+var (
+	// ErrUnknownModule indicates that the app.modules config section names a module that is not registered
+	ErrUnknownModule = errors.New("unknown app module")
+
+	// ErrDuplicateModule indicates that a named module is registered more than once
+	ErrDuplicateModule = errors.New("duplicate app module")
+)
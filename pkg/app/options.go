@@ -0,0 +1,124 @@
+package app
+
+import (
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+// Names of the built-in modules, used as keys of the app.modules config section.
+const (
+	ModuleTracing  = "tracing"
+	ModuleMetrics  = "metrics"
+	ModuleDatabase = "database"
+	ModuleRedis    = "redis"
+	ModuleHTTP     = "http"
+)
+
+type namedModules struct {
+	name    string
+	modules []fx.Option
+}
+
+type options struct {
+	builtins     map[string]bool
+	named        []namedModules
+	modules      []fx.Option
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	configOpts   []config.Option
+}
+
+// Option selects the modules composed by New and Modules.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		builtins:     make(map[string]bool),
+		startTimeout: fx.DefaultTimeout,
+		stopTimeout:  fx.DefaultTimeout,
+	}
+}
+
+// WithHTTP adds the chi HTTP server configured at "app.http", the response error handler
+// and the validator. Routes provided to the "routes" group are registered automatically
+// and the server answers /healthz.
+func WithHTTP() Option {
+	return func(o *options) {
+		o.builtins[ModuleHTTP] = true
+	}
+}
+
+// WithDatabase adds the *gorm.DB connected to the database configured at "app.database".
+func WithDatabase() Option {
+	return func(o *options) {
+		o.builtins[ModuleDatabase] = true
+	}
+}
+
+// WithRedis adds the redis.UniversalClient connected to the Redis configured at "app.redis".
+func WithRedis() Option {
+	return func(o *options) {
+		o.builtins[ModuleRedis] = true
+	}
+}
+
+// WithMetrics adds the Prometheus registry and the use case metrics configured at "app.metrics".
+// With WithHTTP the registry is served on the metrics endpoint.
+func WithMetrics() Option {
+	return func(o *options) {
+		o.builtins[ModuleMetrics] = true
+	}
+}
+
+// WithTracing initializes the OpenTelemetry tracing configured at "app.open-telemetry".
+func WithTracing() Option {
+	return func(o *options) {
+		o.builtins[ModuleTracing] = true
+	}
+}
+
+// WithModules adds the application modules, e.g. the fx modules of the service domains.
+func WithModules(modules ...fx.Option) Option {
+	return func(o *options) {
+		o.modules = append(o.modules, modules...)
+	}
+}
+
+// WithNamedModules adds application modules that the app.modules config section can
+// disable by name, e.g. the queue consumers under "worker".
+func WithNamedModules(name string, modules ...fx.Option) Option {
+	return func(o *options) {
+		o.named = append(o.named, namedModules{name: name, modules: modules})
+	}
+}
+
+// WithStartTimeout limits the time the application waits for the fx start hooks.
+// Defaults to fx.DefaultTimeout.
+func WithStartTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		if timeout > 0 {
+			o.startTimeout = timeout
+		}
+	}
+}
+
+// WithStopTimeout limits the time the application waits for the fx stop hooks.
+// Defaults to fx.DefaultTimeout.
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		if timeout > 0 {
+			o.stopTimeout = timeout
+		}
+	}
+}
+
+// WithConfigOptions sets the options of the config loads: the app config and, through
+// config.SupplyOptions, the configs of the modules, e.g. config.WithEnvPrefix("MYSVC_").
+func WithConfigOptions(opts ...config.Option) Option {
+	return func(o *options) {
+		o.configOpts = append(o.configOpts, opts...)
+	}
+}
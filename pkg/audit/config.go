@@ -0,0 +1,44 @@
+package audit
+
+import "fmt"
+
+const (
+	SinkDatabase  = "database"
+	SinkPublisher = "publisher"
+
+	defaultTable = "audit_entries"
+	defaultTopic = "audit"
+)
+
+// Config configures the Recorder and its sinks.
+type Config struct {
+	// Sinks lists where the entries are written: database and/or publisher
+	Sinks []string `config:"sinks"`
+	// Table is the table of the database sink
+	Table string `config:"table"`
+	// Topic is the topic of the publisher sink
+	Topic string `config:"topic"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if len(c.Sinks) == 0 {
+		c.Sinks = []string{SinkDatabase}
+	}
+	if c.Table == "" {
+		c.Table = defaultTable
+	}
+	if c.Topic == "" {
+		c.Topic = defaultTopic
+	}
+}
+
+// Validate checks the configured sinks.
+func (c *Config) Validate() error {
+	for _, sink := range c.Sinks {
+		if sink != SinkDatabase && sink != SinkPublisher {
+			return fmt.Errorf("%w: %s", ErrInvalidSink, sink)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redact"
+)
+
+// Entry is an audited action: who did what to which entity, and what changed.
+type Entry struct {
+	ID         uuid.UUID `json:"id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// TenantID and ActorID default to the ctxmeta tenant and user of the context
+	TenantID string `json:"tenant_id,omitempty"`
+	ActorID  string `json:"actor_id,omitempty"`
+	// Action is what happened, e.g. "order.refunded"
+	Action     string `json:"action"`
+	EntityType string `json:"entity_type,omitempty"`
+	EntityID   string `json:"entity_id,omitempty"`
+	// Fields are the changed fields; Before and After hold their values, see WithDiff
+	Fields []string        `json:"fields,omitempty"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+	// RequestID and CorrelationID default to the ctxmeta IDs of the context
+	RequestID     string         `json:"request_id,omitempty"`
+	CorrelationID string         `json:"correlation_id,omitempty"`
+	Metadata      map[string]any `json:"metadata,omitempty"`
+}
+
+// WithDiff returns a copy of the entry with the fields that differ between the JSON of
+// before and after, and their values. A nil before records a creation, a nil after a
+// deletion, with every field. Values that are not JSON objects are recorded whole. The
+// fields tagged `redact:"..."` are masked with redact.Value before the diff.
+//
+//	entry, err := audit.Entry{Action: "order.updated", EntityType: "order", EntityID: id}.
+//	    WithDiff(previous, order)
+func (e Entry) WithDiff(before, after any) (Entry, error) {
+	beforeJSON, err := marshal(before)
+	if err != nil {
+		return e, err
+	}
+	afterJSON, err := marshal(after)
+	if err != nil {
+		return e, err
+	}
+
+	beforeFields, beforeIsObject := fields(beforeJSON)
+	afterFields, afterIsObject := fields(afterJSON)
+	if !beforeIsObject || !afterIsObject {
+		if !bytes.Equal(beforeJSON, afterJSON) {
+			e.Before, e.After = beforeJSON, afterJSON
+		}
+		return e, nil
+	}
+
+	changedBefore := map[string]json.RawMessage{}
+	changedAfter := map[string]json.RawMessage{}
+	names := slices.Collect(maps.Keys(beforeFields))
+	for name := range afterFields {
+		if _, found := beforeFields[name]; !found {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	e.Fields = nil
+	for _, name := range names {
+		beforeValue, afterValue := beforeFields[name], afterFields[name]
+		if bytes.Equal(beforeValue, afterValue) {
+			continue
+		}
+		e.Fields = append(e.Fields, name)
+		if beforeValue != nil {
+			changedBefore[name] = beforeValue
+		}
+		if afterValue != nil {
+			changedAfter[name] = afterValue
+		}
+	}
+
+	if e.Before, err = marshalChanges(changedBefore, before == nil); err != nil {
+		return e, err
+	}
+	if e.After, err = marshalChanges(changedAfter, after == nil); err != nil {
+		return e, err
+	}
+	return e, nil
+}
+
+func marshal(value any) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(redact.Value(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiff, err)
+	}
+	return data, nil
+}
+
+// fields returns the compacted fields of a JSON object; a missing value is an empty object.
+func fields(data json.RawMessage) (map[string]json.RawMessage, bool) {
+	if data == nil {
+		return map[string]json.RawMessage{}, true
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		return nil, false
+	}
+	for name, value := range object {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, value); err == nil {
+			object[name] = compacted.Bytes()
+		}
+	}
+	return object, true
+}
+
+func marshalChanges(changes map[string]json.RawMessage, missing bool) (json.RawMessage, error) {
+	if missing || len(changes) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiff, err)
+	}
+	return data, nil
+}
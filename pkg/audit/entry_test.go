@@ -0,0 +1,104 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/audit"
+)
+
+type order struct {
+	ID     string   `json:"id"`
+	Status string   `json:"status"`
+	Total  int      `json:"total"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+func TestEntry_WithDiff(t *testing.T) {
+	t.Run("records the changed fields only", func(t *testing.T) {
+		// Arrange
+		before := order{ID: "42", Status: "paid", Total: 100}
+		after := order{ID: "42", Status: "refunded", Total: 100, Tags: []string{"vip"}}
+
+		// Act
+		entry, err := audit.Entry{Action: "order.refunded"}.WithDiff(before, after)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"status", "tags"}, entry.Fields)
+		assert.JSONEq(t, `{"status":"paid"}`, string(entry.Before))
+		assert.JSONEq(t, `{"status":"refunded","tags":["vip"]}`, string(entry.After))
+	})
+
+	t.Run("masks the redacted fields", func(t *testing.T) {
+		// Arrange
+		type customer struct {
+			Name  string `json:"name"`
+			Email string `json:"email" redact:"email"`
+		}
+		before := customer{Name: "Jane", Email: "jane@example.com"}
+		after := customer{Name: "Jane", Email: "mary@example.com"}
+
+		// Act
+		entry, err := audit.Entry{Action: "customer.updated"}.WithDiff(before, after)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email"}, entry.Fields)
+		assert.JSONEq(t, `{"email":"j***@example.com"}`, string(entry.Before))
+		assert.JSONEq(t, `{"email":"m***@example.com"}`, string(entry.After))
+	})
+
+	t.Run("records every field of a creation", func(t *testing.T) {
+		// Act
+		entry, err := audit.Entry{Action: "order.created"}.WithDiff(nil, order{ID: "42", Status: "new"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "status", "total"}, entry.Fields)
+		assert.Nil(t, entry.Before)
+		assert.JSONEq(t, `{"id":"42","status":"new","total":0}`, string(entry.After))
+	})
+
+	t.Run("records every field of a deletion", func(t *testing.T) {
+		// Act
+		entry, err := audit.Entry{Action: "order.deleted"}.WithDiff(&order{ID: "42"}, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":"42","status":"","total":0}`, string(entry.Before))
+		assert.Nil(t, entry.After)
+	})
+
+	t.Run("records nothing when nothing changed", func(t *testing.T) {
+		// Act
+		entry, err := audit.Entry{Action: "order.saved"}.WithDiff(order{ID: "42"}, order{ID: "42"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, entry.Fields)
+		assert.Nil(t, entry.Before)
+		assert.Nil(t, entry.After)
+	})
+
+	t.Run("records values that are not objects whole", func(t *testing.T) {
+		// Act
+		entry, err := audit.Entry{Action: "limit.changed"}.WithDiff(10, 20)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, entry.Fields)
+		assert.Equal(t, "10", string(entry.Before))
+		assert.Equal(t, "20", string(entry.After))
+	})
+
+	t.Run("reports a value that cannot be encoded", func(t *testing.T) {
+		// Act
+		_, err := audit.Entry{Action: "x"}.WithDiff(nil, make(chan int))
+
+		// Assert
+		require.ErrorIs(t, err, audit.ErrDiff)
+	})
+}
@@ -0,0 +1,11 @@
+package audit
+
+import "errors"
+
+var (
+	ErrMissingAction    = errors.New("audit entry requires an action")
+	ErrInvalidSink      = errors.New("invalid audit sink (must be 'database' or 'publisher')")
+	ErrMissingDatabase  = errors.New("the database sink requires a *gorm.DB")
+	ErrMissingPublisher = errors.New("the publisher sink requires an audit.Publisher")
+	ErrDiff             = errors.New("failed to diff the audited entity")
+)
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+// Module provides the Recorder writing to the configured sinks, and the GormStore to
+// query the entries. It loads the config from "app.audit"; the database sink requires
+// database.Module and the publisher sink an audit.Publisher. The audit_entries migration
+// joins the "migration_filesystems" group.
+//
+//	fx.New(
+//	    database.Module,
+//	    audit.Module,
+//	    fx.Provide(usecase.NewRefundUseCase), // takes an audit.Recorder
+//	)
+var Module = fx.Module(
+	"audit",
+	config.Provide[Config]("app.audit"),
+	fx.Provide(
+		NewRecorderWithParams,
+		NewGormStoreWithConfig,
+		fx.Annotate(Migrations, fx.ResultTags(`group:"migration_filesystems"`)),
+	),
+)
+
+// RecorderParams for dependency injection
+type RecorderParams struct {
+	fx.In
+
+	Config    config.Config[Config]
+	DB        *gorm.DB        `optional:"true"`
+	Publisher Publisher       `optional:"true"`
+	Clock     clock.Clock     `optional:"true"`
+	IDs       ident.Generator `optional:"true"`
+}
+
+// NewRecorderWithParams creates the Recorder with the configured sinks.
+func NewRecorderWithParams(params RecorderParams) (Recorder, error) {
+	cfg := params.Config.Get()
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		switch name {
+		case SinkDatabase:
+			if params.DB == nil {
+				return nil, ErrMissingDatabase
+			}
+			sinks = append(sinks, NewGormStore(params.DB, cfg.Table))
+		case SinkPublisher:
+			if params.Publisher == nil {
+				return nil, ErrMissingPublisher
+			}
+			sinks = append(sinks, NewPublisherSink(params.Publisher, cfg.Topic))
+		}
+	}
+	return NewRecorder(sinks, WithClock(params.Clock), WithIDGenerator(params.IDs)), nil
+}
+
+// NewGormStoreWithConfig creates the GormStore of the configured table, to list the entries.
+func NewGormStoreWithConfig(db *gorm.DB, cfg config.Config[Config]) *GormStore {
+	auditConfig := cfg.Get()
+	auditConfig.SetDefaults()
+	return NewGormStore(db, auditConfig.Table)
+}
@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/paginator"
+)
+
+// Record is the GORM model of an Entry in the audit_entries table (see Migrations).
+type Record struct {
+	ID            uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	OccurredAt    time.Time       `gorm:"not null"`
+	TenantID      string          `gorm:"not null"`
+	ActorID       string          `gorm:"not null"`
+	Action        string          `gorm:"not null"`
+	EntityType    string          `gorm:"not null"`
+	EntityID      string          `gorm:"not null"`
+	Fields        json.RawMessage `gorm:"type:jsonb"`
+	Before        json.RawMessage `gorm:"type:jsonb"`
+	After         json.RawMessage `gorm:"type:jsonb"`
+	RequestID     string          `gorm:"not null"`
+	CorrelationID string          `gorm:"not null"`
+	Metadata      json.RawMessage `gorm:"type:jsonb"`
+}
+
+// Filter selects the entries listed by GormStore.List; empty fields match any entry.
+type Filter struct {
+	TenantID   string
+	ActorID    string
+	Action     string
+	EntityType string
+	EntityID   string
+	// From and To bound OccurredAt: From included, To excluded
+	From time.Time
+	To   time.Time
+}
+
+// GormStore is the Sink storing the entries in the database, and lists them back.
+type GormStore struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormStore creates a GormStore writing to table.
+func NewGormStore(db *gorm.DB, table string) *GormStore {
+	return &GormStore{db: db, table: table}
+}
+
+// Write implements Sink. The entry joins the transaction of the context, so it is
+// committed, or rolled back, with the audited change.
+func (s *GormStore) Write(ctx context.Context, entry Entry) error {
+	record, err := toRecord(entry)
+	if err != nil {
+		return err
+	}
+	if err = database.DB(ctx, s.db).Table(s.table).Create(&record).Error; err != nil {
+		return fmt.Errorf("audit: write entry: %w", err)
+	}
+	return nil
+}
+
+// List returns a page of the entries matching filter, newest first.
+func (s *GormStore) List(
+	ctx context.Context,
+	filter Filter,
+	params paginator.Params,
+) ([]Entry, paginator.Metadata, error) {
+	query := database.DB(ctx, s.db).Table(s.table).Scopes(filter.scope)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, paginator.Metadata{}, fmt.Errorf("audit: count entries: %w", err)
+	}
+
+	query = query.Order("occurred_at DESC").Order("id DESC")
+	if params.Limit() > 0 {
+		query = query.Offset(params.Offset()).Limit(params.Limit())
+	}
+	var records []Record
+	if err := query.Find(&records).Error; err != nil {
+		return nil, paginator.Metadata{}, fmt.Errorf("audit: list entries: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(records))
+	for _, record := range records {
+		entry, err := record.toEntry()
+		if err != nil {
+			return nil, paginator.Metadata{}, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, paginator.Metadata{
+		TotalCount: total,
+		Page:       params.Page,
+		PerPage:    params.PerPage,
+		TotalPages: paginator.TotalPages(total, params.PerPage),
+	}, nil
+}
+
+func (f Filter) scope(db *gorm.DB) *gorm.DB {
+	for column, value := range map[string]string{
+		"tenant_id":   f.TenantID,
+		"actor_id":    f.ActorID,
+		"action":      f.Action,
+		"entity_type": f.EntityType,
+		"entity_id":   f.EntityID,
+	} {
+		if value != "" {
+			db = db.Where(column+" = ?", value)
+		}
+	}
+	if !f.From.IsZero() {
+		db = db.Where("occurred_at >= ?", f.From)
+	}
+	if !f.To.IsZero() {
+		db = db.Where("occurred_at < ?", f.To)
+	}
+	return db
+}
+
+func toRecord(entry Entry) (Record, error) {
+	record := Record{
+		ID:            entry.ID,
+		OccurredAt:    entry.OccurredAt,
+		TenantID:      entry.TenantID,
+		ActorID:       entry.ActorID,
+		Action:        entry.Action,
+		EntityType:    entry.EntityType,
+		EntityID:      entry.EntityID,
+		Before:        entry.Before,
+		After:         entry.After,
+		RequestID:     entry.RequestID,
+		CorrelationID: entry.CorrelationID,
+	}
+	var err error
+	if entry.Fields != nil {
+		if record.Fields, err = json.Marshal(entry.Fields); err != nil {
+			return Record{}, fmt.Errorf("audit: encode fields: %w", err)
+		}
+	}
+	if entry.Metadata != nil {
+		if record.Metadata, err = json.Marshal(entry.Metadata); err != nil {
+			return Record{}, fmt.Errorf("audit: encode metadata: %w", err)
+		}
+	}
+	return record, nil
+}
+
+func (r Record) toEntry() (Entry, error) {
+	entry := Entry{
+		ID:            r.ID,
+		OccurredAt:    r.OccurredAt,
+		TenantID:      r.TenantID,
+		ActorID:       r.ActorID,
+		Action:        r.Action,
+		EntityType:    r.EntityType,
+		EntityID:      r.EntityID,
+		Before:        r.Before,
+		After:         r.After,
+		RequestID:     r.RequestID,
+		CorrelationID: r.CorrelationID,
+	}
+	if len(r.Fields) > 0 {
+		if err := json.Unmarshal(r.Fields, &entry.Fields); err != nil {
+			return Entry{}, fmt.Errorf("audit: decode fields: %w", err)
+		}
+	}
+	if len(r.Metadata) > 0 {
+		if err := json.Unmarshal(r.Metadata, &entry.Metadata); err != nil {
+			return Entry{}, fmt.Errorf("audit: decode metadata: %w", err)
+		}
+	}
+	return entry, nil
+}
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id UUID PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    actor_id TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL DEFAULT '',
+    entity_id TEXT NOT NULL DEFAULT '',
+    fields JSONB,
+    before JSONB,
+    after JSONB,
+    request_id TEXT NOT NULL DEFAULT '',
+    correlation_id TEXT NOT NULL DEFAULT '',
+    metadata JSONB
+);
+
+CREATE INDEX IF NOT EXISTS audit_entries_entity_idx ON audit_entries (entity_type, entity_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS audit_entries_actor_idx ON audit_entries (actor_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS audit_entries_tenant_idx ON audit_entries (tenant_id, occurred_at DESC);
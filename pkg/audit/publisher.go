@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Publisher sends a message to a topic of a message broker (Kafka, NATS, SQS, ...). The
+// key orders the messages of the same entity on brokers that partition by key.
+type Publisher interface {
+	Publish(ctx context.Context, topic, key string, payload []byte) error
+}
+
+// PublisherSink is the Sink publishing each entry as JSON, e.g. to feed a SIEM or a
+// separate audit service.
+type PublisherSink struct {
+	publisher Publisher
+	topic     string
+}
+
+// NewPublisherSink creates a PublisherSink publishing to topic.
+func NewPublisherSink(publisher Publisher, topic string) *PublisherSink {
+	return &PublisherSink{publisher: publisher, topic: topic}
+}
+
+// Write implements Sink. The key is the entity type and ID, or the entry ID when the
+// entry has no entity.
+func (s *PublisherSink) Write(ctx context.Context, entry Entry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("audit: encode entry: %w", err)
+	}
+
+	key := entry.ID.String()
+	if entry.EntityType != "" || entry.EntityID != "" {
+		key = entry.EntityType + ":" + entry.EntityID
+	}
+	if err = s.publisher.Publish(ctx, s.topic, key, payload); err != nil {
+		return fmt.Errorf("audit: publish entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/audit"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+func TestPublisherSink(t *testing.T) {
+	t.Run("publishes the entry keyed by its entity", func(t *testing.T) {
+		// Arrange
+		publisherMock := mocks.NewMockPublisher(t)
+		var payload []byte
+		publisherMock.On("Publish", mock.Anything, "audit", "order:42", mock.Anything).
+			Run(func(args mock.Arguments) { payload = args.Get(3).([]byte) }).
+			Return(nil)
+		sut := audit.NewPublisherSink(publisherMock, "audit")
+
+		// Act
+		err := sut.Write(context.Background(), audit.Entry{
+			ID:         ident.SequenceID(1),
+			Action:     "order.refunded",
+			EntityType: "order",
+			EntityID:   "42",
+			Fields:     []string{"status"},
+			After:      json.RawMessage(`{"status":"refunded"}`),
+		})
+
+		// Assert
+		require.NoError(t, err)
+		var published audit.Entry
+		require.NoError(t, json.Unmarshal(payload, &published))
+		assert.Equal(t, "order.refunded", published.Action)
+		assert.JSONEq(t, `{"status":"refunded"}`, string(published.After))
+	})
+
+	t.Run("keys an entry without entity by its ID", func(t *testing.T) {
+		// Arrange
+		publisherMock := mocks.NewMockPublisher(t)
+		publisherMock.On("Publish", mock.Anything, "audit", ident.SequenceID(1).String(), mock.Anything).Return(nil)
+		sut := audit.NewPublisherSink(publisherMock, "audit")
+
+		// Act
+		err := sut.Write(context.Background(), audit.Entry{ID: ident.SequenceID(1), Action: "user.logged_in"})
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
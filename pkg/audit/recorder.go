@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/redact"
+)
+
+// Recorder records the audit trail.
+type Recorder interface {
+	// Record completes entry from the context and writes it to the sinks.
+	Record(ctx context.Context, entry Entry) error
+}
+
+// Sink stores or forwards the recorded entries.
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+}
+
+type recorder struct {
+	sinks []Sink
+	clock clock.Clock
+	ids   ident.Generator
+}
+
+type options struct {
+	clock clock.Clock
+	ids   ident.Generator
+}
+
+// Option configures the Recorder created by NewRecorder.
+type Option func(*options)
+
+// WithClock sets the clock of the entry times, e.g. a clock.Fake in tests. Defaults to
+// the time package when not provided.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithIDGenerator sets the generator of the entry IDs, e.g. an ident.Sequence in tests.
+// Defaults to UUIDv7 when not provided.
+func WithIDGenerator(ids ident.Generator) Option {
+	return func(o *options) {
+		if ids != nil {
+			o.ids = ids
+		}
+	}
+}
+
+// NewRecorder creates a Recorder writing every entry to each sink, in order.
+func NewRecorder(sinks []Sink, opts ...Option) Recorder {
+	recorderOptions := options{clock: clock.New(), ids: ident.New()}
+	for _, opt := range opts {
+		opt(&recorderOptions)
+	}
+	return &recorder{sinks: sinks, clock: recorderOptions.clock, ids: recorderOptions.ids}
+}
+
+// Record implements Recorder. The ID, time, tenant, actor, request and correlation IDs
+// are filled in when empty, and the secrets of the metadata redacted. Every sink is
+// written, and their errors joined.
+func (r *recorder) Record(ctx context.Context, entry Entry) error {
+	if entry.Action == "" {
+		return ErrMissingAction
+	}
+	if entry.ID == uuid.Nil {
+		entry.ID = r.ids.NewID()
+	}
+	if entry.OccurredAt.IsZero() {
+		entry.OccurredAt = r.clock.Now().UTC()
+	}
+	fill(ctx, &entry.TenantID, ctxmeta.TenantID)
+	fill(ctx, &entry.ActorID, ctxmeta.UserID)
+	fill(ctx, &entry.RequestID, ctxmeta.RequestID)
+	fill(ctx, &entry.CorrelationID, ctxmeta.CorrelationID)
+	entry.Metadata = redact.Value(entry.Metadata)
+
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Write(ctx, entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func fill(ctx context.Context, field *string, value func(context.Context) (string, bool)) {
+	if *field != "" {
+		return
+	}
+	if v, ok := value(ctx); ok {
+		*field = v
+	}
+}
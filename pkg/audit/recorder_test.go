@@ -0,0 +1,106 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/audit"
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+var testNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+type RecorderTestSuite struct {
+	suite.Suite
+	sut       audit.Recorder
+	sinkMock  *mocks.MockSink
+	otherSink *mocks.MockSink
+	written   []audit.Entry
+}
+
+func TestRecorderSuite(t *testing.T) {
+	suite.Run(t, new(RecorderTestSuite))
+}
+
+func (s *RecorderTestSuite) SetupTest() {
+	s.sinkMock = mocks.NewMockSink(s.T())
+	s.otherSink = mocks.NewMockSink(s.T())
+	s.written = nil
+	s.sut = audit.NewRecorder([]audit.Sink{s.sinkMock, s.otherSink},
+		audit.WithClock(clock.NewFake(testNow)),
+		audit.WithIDGenerator(ident.NewSequence()),
+	)
+}
+
+func (s *RecorderTestSuite) capture(args mock.Arguments) {
+	s.written = append(s.written, args.Get(1).(audit.Entry))
+}
+
+func (s *RecorderTestSuite) TestRecord_FillsTheEntryFromTheContext() {
+	// Arrange
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+	ctx = ctxmeta.WithUserID(ctx, "user-1")
+	ctx = ctxmeta.WithRequestID(ctx, "req-1")
+	ctx = ctxmeta.WithCorrelationID(ctx, "corr-1")
+	s.sinkMock.On("Write", ctx, mock.Anything).Run(s.capture).Return(nil)
+	s.otherSink.On("Write", ctx, mock.Anything).Return(nil)
+
+	// Act
+	err := s.sut.Record(ctx, audit.Entry{Action: "order.refunded", EntityType: "order", EntityID: "42"})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(s.written, 1)
+	entry := s.written[0]
+	s.Equal(ident.SequenceID(1), entry.ID)
+	s.Equal(testNow, entry.OccurredAt)
+	s.Equal("acme", entry.TenantID)
+	s.Equal("user-1", entry.ActorID)
+	s.Equal("req-1", entry.RequestID)
+	s.Equal("corr-1", entry.CorrelationID)
+}
+
+func (s *RecorderTestSuite) TestRecord_KeepsTheFieldsSetByTheCaller() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-1")
+	s.sinkMock.On("Write", ctx, mock.Anything).Run(s.capture).Return(nil)
+	s.otherSink.On("Write", ctx, mock.Anything).Return(nil)
+
+	// Act
+	err := s.sut.Record(ctx, audit.Entry{Action: "order.refunded", ActorID: "system"})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("system", s.written[0].ActorID)
+}
+
+func (s *RecorderTestSuite) TestRecord_WritesEverySinkAndJoinsTheErrors() {
+	// Arrange
+	ctx := context.Background()
+	sinkErr := errors.New("broker unavailable")
+	s.sinkMock.On("Write", ctx, mock.Anything).Return(sinkErr)
+	s.otherSink.On("Write", ctx, mock.Anything).Return(nil)
+
+	// Act
+	err := s.sut.Record(ctx, audit.Entry{Action: "order.refunded"})
+
+	// Assert
+	s.Require().ErrorIs(err, sinkErr)
+	s.otherSink.AssertNumberOfCalls(s.T(), "Write", 1)
+}
+
+func (s *RecorderTestSuite) TestRecord_RequiresAnAction() {
+	// Act
+	err := s.sut.Record(context.Background(), audit.Entry{EntityType: "order"})
+
+	// Assert
+	s.Require().ErrorIs(err, audit.ErrMissingAction)
+}
@@ -0,0 +1,103 @@
+package authz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Authorizer decides whether a subject may perform an action on a resource, from the
+// permissions of its roles.
+type Authorizer struct {
+	source  Source
+	options options
+}
+
+// NewAuthorizer creates an Authorizer granting the permissions of source.
+func NewAuthorizer(source Source, opts ...Option) *Authorizer {
+	authorizerOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&authorizerOptions)
+	}
+	return &Authorizer{source: source, options: authorizerOptions}
+}
+
+// Can reports whether subject may perform action on resource. The decision depends on
+// the roles of the subject only, so it is cached per set of roles.
+func (a *Authorizer) Can(ctx context.Context, subject Subject, action, resource string) (bool, error) {
+	key := decisionKey(subject.Roles, action, resource)
+	if a.options.cache != nil {
+		allowed, found, err := a.options.cache.Get(ctx, key)
+		if err == nil && found {
+			return allowed, nil
+		}
+		// A cache failure falls back to the source
+	}
+
+	permissions, err := a.source.Permissions(ctx, subject.Roles)
+	if err != nil {
+		return false, fmt.Errorf("authz: %w", err)
+	}
+	allowed := slices.ContainsFunc(permissions, func(p Permission) bool {
+		return p.Allows(action, resource)
+	})
+
+	if a.options.cache != nil {
+		_ = a.options.cache.Set(ctx, key, allowed, a.options.cacheTTL)
+	}
+	return allowed, nil
+}
+
+// Authorize checks that the subject of ctx may perform action on resource, returning
+// ErrUnauthenticated without subject and ErrForbidden when it may not. It implements
+// ucdecorator.Authorizer.
+func (a *Authorizer) Authorize(ctx context.Context, action, resource string) error {
+	subject, ok := SubjectFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	allowed, err := a.Can(ctx, subject, action, resource)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Require is a middleware letting through the requests whose subject may perform action
+// on resource; the others answer ErrUnauthenticated or ErrForbidden.
+//
+//	router.With(authorizer.Require("delete", "orders")).Delete("/orders/{id}", handler.Delete)
+func (a *Authorizer) Require(action, resource string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := a.Authorize(r.Context(), action, resource); err != nil {
+				a.options.errorHandler(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// InvalidateCache drops the cached decisions, e.g. after the permissions of a role changed.
+func (a *Authorizer) InvalidateCache(ctx context.Context) error {
+	if a.options.cache == nil {
+		return nil
+	}
+	return a.options.cache.Clear(ctx)
+}
+
+// decisionKey hashes the sorted roles, so the subjects with the same roles share their
+// decisions whatever their order, and the keys stay short.
+func decisionKey(roles []string, action, resource string) string {
+	sorted := slices.Sorted(slices.Values(roles))
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\x00")))
+	return hex.EncodeToString(sum[:8]) + ":" + resource + ":" + action
+}
@@ -0,0 +1,188 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/authz"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+var (
+	editor = authz.Subject{ID: "user-1", Roles: []string{"editor"}}
+	viewer = authz.Subject{ID: "user-2", Roles: []string{"viewer"}}
+)
+
+type AuthorizerTestSuite struct {
+	suite.Suite
+	sut       *authz.Authorizer
+	cacheMock *mocks.MockDecisionCache
+}
+
+func TestAuthorizerSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizerTestSuite))
+}
+
+func (s *AuthorizerTestSuite) SetupTest() {
+	source, err := authz.NewStaticSource([]authz.Role{
+		{Name: "viewer", Permissions: []authz.Permission{{Resource: "orders", Action: "read"}}},
+		{
+			Name:        "editor",
+			Permissions: []authz.Permission{{Resource: "orders", Action: "update"}},
+			Inherits:    []string{"viewer"},
+		},
+	})
+	s.Require().NoError(err)
+	s.cacheMock = mocks.NewMockDecisionCache(s.T())
+	s.sut = authz.NewAuthorizer(source)
+}
+
+func (s *AuthorizerTestSuite) TestCan_GrantsTheInheritedPermissions() {
+	// Act
+	canRead, readErr := s.sut.Can(context.Background(), editor, "read", "orders")
+	canUpdate, updateErr := s.sut.Can(context.Background(), viewer, "update", "orders")
+
+	// Assert
+	s.Require().NoError(readErr)
+	s.Require().NoError(updateErr)
+	s.True(canRead)
+	s.False(canUpdate)
+}
+
+func (s *AuthorizerTestSuite) TestCan_CachedDecision_SkipsTheSource() {
+	// Arrange
+	sourceMock := mocks.NewMockSource(s.T())
+	sut := authz.NewAuthorizer(sourceMock, authz.WithDecisionCache(s.cacheMock, time.Minute))
+	s.cacheMock.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(true, true, nil)
+
+	// Act
+	allowed, err := sut.Can(context.Background(), viewer, "delete", "orders")
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(allowed)
+	sourceMock.AssertNotCalled(s.T(), "Permissions")
+}
+
+func (s *AuthorizerTestSuite) TestCan_CacheMiss_CachesTheDecision() {
+	// Arrange
+	sut := authz.NewAuthorizer(staticSource(s), authz.WithDecisionCache(s.cacheMock, time.Minute))
+	var getKey, setKey string
+	s.cacheMock.On("Get", mock.Anything, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { getKey = args.String(1) }).
+		Return(false, false, nil)
+	s.cacheMock.On("Set", mock.Anything, mock.AnythingOfType("string"), false, time.Minute).
+		Run(func(args mock.Arguments) { setKey = args.String(1) }).
+		Return(nil)
+
+	// Act
+	allowed, err := sut.Can(context.Background(), viewer, "update", "orders")
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(allowed)
+	s.Equal(getKey, setKey)
+}
+
+func (s *AuthorizerTestSuite) TestCan_SameRoles_ShareTheCacheKey() {
+	// Arrange
+	sut := authz.NewAuthorizer(staticSource(s), authz.WithDecisionCache(s.cacheMock, time.Minute))
+	var keys []string
+	s.cacheMock.On("Get", mock.Anything, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { keys = append(keys, args.String(1)) }).
+		Return(true, true, nil)
+
+	// Act
+	_, _ = sut.Can(context.Background(), authz.Subject{ID: "a", Roles: []string{"editor", "viewer"}}, "read", "orders")
+	_, _ = sut.Can(context.Background(), authz.Subject{ID: "b", Roles: []string{"viewer", "editor"}}, "read", "orders")
+
+	// Assert
+	s.Require().Len(keys, 2)
+	s.Equal(keys[0], keys[1])
+}
+
+func (s *AuthorizerTestSuite) TestCan_SourceFailure_ReturnsTheError() {
+	// Arrange
+	sourceMock := mocks.NewMockSource(s.T())
+	sourceMock.On("Permissions", mock.Anything, []string{"viewer"}).Return(nil, errors.New("connection refused"))
+	sut := authz.NewAuthorizer(sourceMock)
+
+	// Act
+	_, err := sut.Can(context.Background(), viewer, "read", "orders")
+
+	// Assert
+	s.Require().ErrorContains(err, "connection refused")
+}
+
+func (s *AuthorizerTestSuite) TestAuthorize_UsesTheSubjectOfTheContext() {
+	// Arrange
+	ctx := authz.WithSubject(context.Background(), viewer)
+
+	// Act
+	allowedErr := s.sut.Authorize(ctx, "read", "orders")
+	deniedErr := s.sut.Authorize(ctx, "update", "orders")
+	anonymousErr := s.sut.Authorize(context.Background(), "read", "orders")
+
+	// Assert
+	s.Require().NoError(allowedErr)
+	s.Require().ErrorIs(deniedErr, authz.ErrForbidden)
+	s.Require().ErrorIs(anonymousErr, authz.ErrUnauthenticated)
+}
+
+func (s *AuthorizerTestSuite) TestRequire_AnswersTheStatusOfTheDecision() {
+	// Arrange
+	handler := s.sut.Require("update", "orders")(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	cases := map[int]*http.Request{
+		http.StatusNoContent:    requestAs(editor),
+		http.StatusForbidden:    requestAs(viewer),
+		http.StatusUnauthorized: httptest.NewRequest(http.MethodPut, "/orders/1", nil),
+	}
+
+	for status, req := range cases {
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		s.Equal(status, rec.Code)
+	}
+}
+
+func (s *AuthorizerTestSuite) TestRequire_WritesTheErrsEnvelope() {
+	// Arrange
+	handler := s.sut.Require("update", "orders")(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, requestAs(viewer))
+
+	// Assert
+	s.Equal(http.StatusForbidden, rec.Code)
+	s.Equal("application/json", rec.Header().Get("Content-Type"))
+	s.JSONEq(`{"error":{"code":"FORBIDDEN","message":"You are not allowed to perform this action"}}`, rec.Body.String())
+}
+
+func staticSource(s *AuthorizerTestSuite) *authz.StaticSource {
+	source, err := authz.NewStaticSource([]authz.Role{
+		{Name: "viewer", Permissions: []authz.Permission{{Resource: "orders", Action: "read"}}},
+	})
+	s.Require().NoError(err)
+	return source
+}
+
+func requestAs(subject authz.Subject) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/orders/1", nil)
+	return req.WithContext(authz.WithSubject(req.Context(), subject))
+}
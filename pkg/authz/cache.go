@@ -0,0 +1,73 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// DecisionCache keeps the decisions of the Authorizer, so the Source is not queried for
+// every request.
+type DecisionCache interface {
+	// Get returns the cached decision of key, found is false on a miss.
+	Get(ctx context.Context, key string) (allowed bool, found bool, err error)
+	// Set caches the decision of key for ttl.
+	Set(ctx context.Context, key string, allowed bool, ttl time.Duration) error
+	// Clear drops every decision, e.g. after the permissions changed.
+	Clear(ctx context.Context) error
+}
+
+// RedisDecisionCache is the DecisionCache of the redis Client, shared by the instances:
+// the keys are namespaced and the commands recorded in the client metrics.
+type RedisDecisionCache struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisDecisionCache creates a RedisDecisionCache keeping the decisions under prefix + key.
+func NewRedisDecisionCache(client *redis.Client, prefix string) *RedisDecisionCache {
+	return &RedisDecisionCache{client: client, prefix: prefix}
+}
+
+// Get implements DecisionCache.
+func (c *RedisDecisionCache) Get(ctx context.Context, key string) (bool, bool, error) {
+	var get *goredis.StringCmd
+	err := c.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		get = p.Get(ctx, c.prefix+key)
+		return nil
+	})
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return false, false, err
+	}
+
+	value, err := get.Result()
+	if errors.Is(err, goredis.Nil) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return value == "1", true, nil
+}
+
+// Set implements DecisionCache.
+func (c *RedisDecisionCache) Set(ctx context.Context, key string, allowed bool, ttl time.Duration) error {
+	value := "0"
+	if allowed {
+		value = "1"
+	}
+	return c.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, c.prefix+key, value, ttl)
+		return nil
+	})
+}
+
+// Clear implements DecisionCache.
+func (c *RedisDecisionCache) Clear(ctx context.Context) error {
+	_, err := c.client.DeleteKeys(ctx, c.prefix+"*")
+	return err
+}
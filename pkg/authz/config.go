@@ -0,0 +1,88 @@
+package authz
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	SourceStatic   = "static"
+	SourceDatabase = "database"
+
+	defaultTable       = "role_permissions"
+	defaultCacheTTL    = time.Minute
+	defaultCachePrefix = "authz:"
+)
+
+// Config configures the Authorizer.
+type Config struct {
+	// Source selects where the permissions are read: static (the roles below) or database
+	Source string `config:"source"`
+	// Roles are the static roles and their permissions
+	Roles []RoleConfig `config:"roles"`
+	// Table is the table of the database source, with the role, resource and action columns
+	Table string `config:"table"`
+	// Cache configures the decision cache in Redis
+	Cache CacheConfig `config:"cache"`
+}
+
+// RoleConfig is a static role.
+type RoleConfig struct {
+	// Name of the role, as carried by the subjects
+	Name string `config:"name"`
+	// Permissions granted to the role: "resource:action", with "*" as a wildcard
+	Permissions []string `config:"permissions"`
+	// Inherits lists the roles whose permissions the role also has
+	Inherits []string `config:"inherits"`
+}
+
+// CacheConfig configures the decision cache.
+type CacheConfig struct {
+	// Enabled caches the decisions in Redis, shared by the instances
+	Enabled bool `config:"enabled"`
+	// TTL is how long a decision is kept, and so how long a permission change takes to apply
+	TTL time.Duration `config:"ttl"`
+	// Prefix is prepended to the decision keys, after the redis client namespace
+	Prefix string `config:"prefix"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Source == "" {
+		c.Source = SourceStatic
+	}
+	if c.Table == "" {
+		c.Table = defaultTable
+	}
+	if c.Cache.TTL <= 0 {
+		c.Cache.TTL = defaultCacheTTL
+	}
+	if c.Cache.Prefix == "" {
+		c.Cache.Prefix = defaultCachePrefix
+	}
+}
+
+// Validate checks the configured source.
+func (c *Config) Validate() error {
+	if c.Source != SourceStatic && c.Source != SourceDatabase {
+		return fmt.Errorf("%w: %s", ErrInvalidSource, c.Source)
+	}
+	return nil
+}
+
+// StaticRoles parses the configured roles.
+func (c *Config) StaticRoles() ([]Role, error) {
+	roles := make([]Role, 0, len(c.Roles))
+	for _, roleConfig := range c.Roles {
+		role := Role{Name: roleConfig.Name, Inherits: roleConfig.Inherits}
+		for _, value := range roleConfig.Permissions {
+			permission, err := ParsePermission(value)
+			if err != nil {
+				return nil, fmt.Errorf("role %s: %w", roleConfig.Name, err)
+			}
+			role.Permissions = append(role.Permissions, permission)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
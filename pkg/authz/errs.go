@@ -0,0 +1,22 @@
+package authz
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+var (
+	ErrInvalidSource     = errors.New("invalid authz source (must be 'static' or 'database')")
+	ErrInvalidPermission = errors.New("invalid permission (must be 'resource:action')")
+	ErrUnknownRole       = errors.New("unknown inherited role")
+	ErrRoleCycle         = errors.New("role inheritance cycle")
+	ErrMissingDatabase   = errors.New("the database source requires a *gorm.DB")
+	ErrMissingRedis      = errors.New("the decision cache requires a *redis.Client")
+
+	// ErrUnauthenticated is returned when the context has no subject
+	ErrUnauthenticated = errs.New("UNAUTHENTICATED", "Authentication required", http.StatusUnauthorized, nil)
+	// ErrForbidden is returned when the subject is not allowed to perform the action
+	ErrForbidden = errs.New("FORBIDDEN", "You are not allowed to perform this action", http.StatusForbidden, nil)
+)
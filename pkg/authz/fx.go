@@ -0,0 +1,77 @@
+package authz
+
+import (
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+)
+
+// Module provides the Authorizer, also as the ucdecorator.Authorizer of the use cases
+// wrapped WithPermission. It loads the config from "app.authz"; the database source
+// requires database.Module and the decision cache redis.ClientModule.
+//
+//	fx.New(
+//	    authz.Module,
+//	    fx.Invoke(func(server *chi.Server, authorizer *authz.Authorizer) {
+//	        server.Router().With(authorizer.Require("read", "orders")).Get("/orders", list)
+//	    }),
+//	)
+var Module = fx.Module(
+	"authz",
+	config.Provide[Config]("app.authz"),
+	fx.Provide(
+		fx.Annotate(
+			NewAuthorizerWithParams,
+			fx.As(fx.Self()),
+			fx.As(new(ucdecorator.Authorizer)),
+		),
+	),
+)
+
+// AuthorizerParams for dependency injection
+type AuthorizerParams struct {
+	fx.In
+
+	Config       config.Config[Config]
+	DB           *gorm.DB              `optional:"true"`
+	Redis        *redis.Client         `optional:"true"`
+	ErrorHandler response.ErrorHandler `optional:"true"`
+}
+
+// NewAuthorizerWithParams creates the Authorizer with the configured source and cache.
+func NewAuthorizerWithParams(params AuthorizerParams) (*Authorizer, error) {
+	cfg := params.Config.Get()
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var source Source
+	if cfg.Source == SourceDatabase {
+		if params.DB == nil {
+			return nil, ErrMissingDatabase
+		}
+		source = NewGormSource(params.DB, cfg.Table)
+	} else {
+		roles, err := cfg.StaticRoles()
+		if err != nil {
+			return nil, err
+		}
+		if source, err = NewStaticSource(roles); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []Option{WithErrorHandler(params.ErrorHandler)}
+	if cfg.Cache.Enabled {
+		if params.Redis == nil {
+			return nil, ErrMissingRedis
+		}
+		opts = append(opts, WithDecisionCache(NewRedisDecisionCache(params.Redis, cfg.Cache.Prefix), cfg.Cache.TTL))
+	}
+	return NewAuthorizer(source, opts...), nil
+}
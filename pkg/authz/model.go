@@ -0,0 +1,65 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Wildcard matches any action or resource in a Permission.
+const Wildcard = "*"
+
+// Permission grants an action on a resource. Either may be the Wildcard.
+type Permission struct {
+	Resource string
+	Action   string
+}
+
+// ParsePermission parses "resource:action", e.g. "orders:read", "orders:*" or "*".
+func ParsePermission(value string) (Permission, error) {
+	if value == Wildcard {
+		return Permission{Resource: Wildcard, Action: Wildcard}, nil
+	}
+	resource, action, found := strings.Cut(value, ":")
+	if !found || resource == "" || action == "" {
+		return Permission{}, fmt.Errorf("%w: %q", ErrInvalidPermission, value)
+	}
+	return Permission{Resource: resource, Action: action}, nil
+}
+
+// Allows reports whether the permission grants action on resource.
+func (p Permission) Allows(action, resource string) bool {
+	return (p.Resource == Wildcard || p.Resource == resource) && (p.Action == Wildcard || p.Action == action)
+}
+
+// String returns the "resource:action" form of the permission.
+func (p Permission) String() string {
+	return p.Resource + ":" + p.Action
+}
+
+// Role groups permissions. A role has the permissions of the roles it inherits.
+type Role struct {
+	Name        string
+	Permissions []Permission
+	Inherits    []string
+}
+
+// Subject is who performs the action: a user or a service, and its roles.
+type Subject struct {
+	ID    string
+	Roles []string
+}
+
+type contextKey struct{}
+
+// WithSubject returns a copy of ctx carrying subject, e.g. set by the authentication
+// middleware from the token claims.
+func WithSubject(ctx context.Context, subject Subject) context.Context {
+	return context.WithValue(ctx, contextKey{}, subject)
+}
+
+// SubjectFromContext returns the subject of ctx.
+func SubjectFromContext(ctx context.Context) (Subject, bool) {
+	subject, ok := ctx.Value(contextKey{}).(Subject)
+	return subject, ok
+}
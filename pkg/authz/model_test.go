@@ -0,0 +1,102 @@
+package authz_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/authz"
+)
+
+func TestParsePermission(t *testing.T) {
+	t.Run("parses resource and action with wildcards", func(t *testing.T) {
+		// Act
+		orders, ordersErr := authz.ParsePermission("orders:*")
+		all, allErr := authz.ParsePermission("*")
+
+		// Assert
+		require.NoError(t, ordersErr)
+		require.NoError(t, allErr)
+		assert.True(t, orders.Allows("delete", "orders"))
+		assert.False(t, orders.Allows("delete", "invoices"))
+		assert.True(t, all.Allows("delete", "invoices"))
+		assert.Equal(t, "orders:*", orders.String())
+	})
+
+	t.Run("rejects a permission without action", func(t *testing.T) {
+		for _, value := range []string{"orders", "orders:", ":read", ""} {
+			// Act
+			_, err := authz.ParsePermission(value)
+
+			// Assert
+			require.ErrorIs(t, err, authz.ErrInvalidPermission, value)
+		}
+	})
+}
+
+func TestNewStaticSource(t *testing.T) {
+	t.Run("rejects an inheritance cycle", func(t *testing.T) {
+		// Act
+		_, err := authz.NewStaticSource([]authz.Role{
+			{Name: "a", Inherits: []string{"b"}},
+			{Name: "b", Inherits: []string{"a"}},
+		})
+
+		// Assert
+		require.ErrorIs(t, err, authz.ErrRoleCycle)
+	})
+
+	t.Run("rejects an unknown inherited role", func(t *testing.T) {
+		// Act
+		_, err := authz.NewStaticSource([]authz.Role{{Name: "a", Inherits: []string{"missing"}}})
+
+		// Assert
+		require.ErrorIs(t, err, authz.ErrUnknownRole)
+	})
+
+	t.Run("ignores the unknown roles of a subject", func(t *testing.T) {
+		// Arrange
+		source, err := authz.NewStaticSource([]authz.Role{{Name: "a"}})
+		require.NoError(t, err)
+
+		// Act
+		permissions, err := source.Permissions(context.Background(), []string{"missing"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, permissions)
+	})
+}
+
+func TestConfig_StaticRoles(t *testing.T) {
+	t.Run("parses the permissions of the roles", func(t *testing.T) {
+		// Arrange
+		cfg := authz.Config{Roles: []authz.RoleConfig{
+			{Name: "admin", Permissions: []string{"*"}},
+			{Name: "support", Permissions: []string{"orders:read", "customers:read"}, Inherits: []string{"viewer"}},
+		}}
+
+		// Act
+		roles, err := cfg.StaticRoles()
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, roles, 2)
+		assert.Equal(t, []authz.Permission{{Resource: "*", Action: "*"}}, roles[0].Permissions)
+		assert.Equal(t, []string{"viewer"}, roles[1].Inherits)
+		assert.Len(t, roles[1].Permissions, 2)
+	})
+
+	t.Run("rejects an invalid permission", func(t *testing.T) {
+		// Arrange
+		cfg := authz.Config{Roles: []authz.RoleConfig{{Name: "admin", Permissions: []string{"everything"}}}}
+
+		// Act
+		_, err := cfg.StaticRoles()
+
+		// Assert
+		require.ErrorIs(t, err, authz.ErrInvalidPermission)
+	})
+}
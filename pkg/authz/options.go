@@ -0,0 +1,46 @@
+package authz
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+type options struct {
+	cache        DecisionCache
+	cacheTTL     time.Duration
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Option configures the Authorizer created by NewAuthorizer.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{cacheTTL: defaultCacheTTL, errorHandler: response.WriteError}
+}
+
+// WithDecisionCache caches the decisions in cache for ttl. Without it, every check asks
+// the Source.
+func WithDecisionCache(cache DecisionCache, ttl time.Duration) Option {
+	return func(o *options) {
+		if cache != nil {
+			o.cache = cache
+		}
+		if ttl > 0 {
+			o.cacheTTL = ttl
+		}
+	}
+}
+
+// WithErrorHandler writes the middleware errors with the response.ErrorHandler.
+// Defaults to response.WriteError, the errs envelope with the status of the error.
+func WithErrorHandler(handler response.ErrorHandler) Option {
+	return func(o *options) {
+		if handler != nil {
+			o.errorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+				handler.ErrorCtx(r.Context(), w, err)
+			}
+		}
+	}
+}
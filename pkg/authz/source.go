@@ -0,0 +1,108 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Source returns the permissions granted to roles.
+type Source interface {
+	// Permissions returns the permissions of roles, inherited ones included. Unknown roles
+	// have no permissions.
+	Permissions(ctx context.Context, roles []string) ([]Permission, error)
+}
+
+// StaticSource holds the roles of the configuration in memory.
+type StaticSource struct {
+	permissions map[string][]Permission
+}
+
+// NewStaticSource resolves the inheritance of roles and creates the StaticSource.
+func NewStaticSource(roles []Role) (*StaticSource, error) {
+	byName := make(map[string]Role, len(roles))
+	for _, role := range roles {
+		byName[role.Name] = role
+	}
+
+	permissions := make(map[string][]Permission, len(roles))
+	for _, role := range roles {
+		resolved, err := resolve(byName, role.Name, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		permissions[role.Name] = resolved
+	}
+	return &StaticSource{permissions: permissions}, nil
+}
+
+func resolve(roles map[string]Role, name string, visiting map[string]bool) ([]Permission, error) {
+	if visiting[name] {
+		return nil, fmt.Errorf("%w: %s", ErrRoleCycle, name)
+	}
+	role, ok := roles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRole, name)
+	}
+
+	visiting[name] = true
+	defer delete(visiting, name)
+	permissions := append([]Permission(nil), role.Permissions...)
+	for _, parent := range role.Inherits {
+		inherited, err := resolve(roles, parent, visiting)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, inherited...)
+	}
+	return permissions, nil
+}
+
+// Permissions implements Source.
+func (s *StaticSource) Permissions(_ context.Context, roles []string) ([]Permission, error) {
+	var permissions []Permission
+	for _, role := range roles {
+		permissions = append(permissions, s.permissions[role]...)
+	}
+	return permissions, nil
+}
+
+// GormSource reads the permissions of the roles from a table with the role, resource and
+// action columns, so they can be changed at runtime:
+//
+//	CREATE TABLE role_permissions (
+//	    role     TEXT NOT NULL,
+//	    resource TEXT NOT NULL,
+//	    action   TEXT NOT NULL,
+//	    PRIMARY KEY (role, resource, action)
+//	);
+//
+// Inheritance is not resolved: grant the permissions to each role, or combine the roles
+// of the subject.
+type GormSource struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormSource creates a GormSource reading table.
+func NewGormSource(db *gorm.DB, table string) *GormSource {
+	return &GormSource{db: db, table: table}
+}
+
+// Permissions implements Source.
+func (s *GormSource) Permissions(ctx context.Context, roles []string) ([]Permission, error) {
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	var permissions []Permission
+	err := s.db.WithContext(ctx).
+		Table(s.table).
+		Select("resource", "action").
+		Where("role IN ?", roles).
+		Scan(&permissions).Error
+	if err != nil {
+		return nil, fmt.Errorf("load permissions: %w", err)
+	}
+	return permissions, nil
+}
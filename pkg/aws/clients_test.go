@@ -0,0 +1,102 @@
+package aws_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sestypes "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+)
+
+func TestNewS3_AddressesTheBucketsByPath(t *testing.T) {
+	// Arrange
+	var path string
+	sut := aws.NewS3(newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+
+	// Act
+	object, err := sut.GetObject(context.Background(), &s3.GetObjectInput{
+		Bucket: awssdk.String("orders"),
+		Key:    awssdk.String("2026/10/order.json"),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	body, _ := io.ReadAll(object.Body)
+	require.NoError(t, object.Body.Close())
+	assert.JSONEq(t, `{"id":1}`, string(body))
+	assert.Equal(t, "/orders/2026/10/order.json", path)
+}
+
+func TestClients_EndpointOverrides(t *testing.T) {
+	// Arrange
+	var calls []string
+	record := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, name+" "+r.Header.Get("X-Amz-Target")+r.URL.Path)
+			switch {
+			case r.URL.Path == "/v2/email/outbound-emails":
+				_, _ = w.Write([]byte(`{"MessageId":"ses-1"}`))
+			case r.Header.Get("X-Amz-Target") == "":
+				_, _ = w.Write([]byte(`<PublishResponse><PublishResult><MessageId>sns-1</MessageId>` +
+					`</PublishResult></PublishResponse>`))
+			default:
+				_, _ = w.Write([]byte(`{}`))
+			}
+		}
+	}
+	session, err := aws.NewSession(aws.Config{
+		Region:   "us-east-1",
+		Endpoint: newTestServer(t, record("default")),
+		Endpoints: map[string]string{
+			"ses": newTestServer(t, record("ses")),
+			"kms": newTestServer(t, record("kms")),
+		},
+	}, aws.WithCredentials(testCredentials), aws.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	_, snsErr := aws.NewSNS(session).Publish(ctx, &sns.PublishInput{
+		TopicArn: awssdk.String("arn:aws:sns:us-east-1:123456789012:orders"),
+		Message:  awssdk.String("hello"),
+	})
+	_, sesErr := aws.NewSES(session).SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: awssdk.String("shop@example.com"),
+		Content:          &sestypes.EmailContent{Raw: &sestypes.RawMessage{Data: []byte("Subject: Hi\r\n\r\nHello")}},
+	})
+	_, kmsErr := aws.NewKMS(session).DescribeKey(ctx, &kms.DescribeKeyInput{KeyId: awssdk.String("alias/orders")})
+	_, secretsErr := aws.NewSecretsManager(session).DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: awssdk.String("orders/db"),
+	})
+	_, ssmErr := aws.NewSSM(session).DescribeParameters(ctx, &ssm.DescribeParametersInput{})
+
+	// Assert
+	require.NoError(t, snsErr)
+	require.NoError(t, sesErr)
+	require.NoError(t, kmsErr)
+	require.NoError(t, secretsErr)
+	require.NoError(t, ssmErr)
+	assert.Equal(t, []string{
+		"default /",
+		"ses /v2/email/outbound-emails",
+		"kms TrentService.DescribeKey/",
+		"default secretsmanager.DescribeSecret/",
+		"default AmazonSSM.DescribeParameters/",
+	}, calls)
+}
@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"time"
+)
+
+const (
+	defaultTimeout         = 30 * time.Second
+	defaultMaxAttempts     = 3
+	defaultMaxBackoff      = 20 * time.Second
+	defaultRoleSessionName = "bricks"
+	defaultRoleDuration    = time.Hour
+
+	// minRoleDuration and maxRoleDuration bound the duration of the AssumeRole sessions
+	minRoleDuration = 15 * time.Minute
+	maxRoleDuration = 12 * time.Hour
+)
+
+// Config configures the Session shared by the AWS clients. The region and the credentials
+// not set come from the default chain of the SDK, e.g. AWS_REGION and AWS_ACCESS_KEY_ID.
+type Config struct {
+	Region string `config:"region"`
+	// Profile is the profile of the shared config and credentials files; empty is
+	// AWS_PROFILE, else the default profile
+	Profile         string `config:"profile"`
+	AccessKeyID     string `config:"access_key_id"`
+	SecretAccessKey string `config:"secret_access_key"`
+	SessionToken    string `config:"session_token"`
+	// Endpoint overrides the endpoint of every service, e.g. http://localhost:4566 for
+	// LocalStack
+	Endpoint string `config:"endpoint"`
+	// Endpoints overrides the endpoint of a service by name: s3, sqs, sns, ses,
+	// secretsmanager, ssm, kms or sts; it takes precedence over Endpoint
+	Endpoints  map[string]string `config:"endpoints"`
+	AssumeRole AssumeRoleConfig  `config:"assume_role"`
+	Retry      RetryConfig       `config:"retry"`
+	// Timeout bounds each attempt of a call, the reading of an S3 object included; it is
+	// the timeout of the HTTP client of the SDK, not of the one of WithHTTPClient
+	Timeout time.Duration `config:"timeout"`
+}
+
+// AssumeRoleConfig makes the Session call the services with the temporary credentials of
+// a role, assumed with STS using the credentials of the Config or of the default chain.
+// Empty RoleARN disables it.
+type AssumeRoleConfig struct {
+	RoleARN string `config:"role_arn"`
+	// SessionName identifies the session in CloudTrail
+	SessionName string `config:"session_name"`
+	// ExternalID is required by the trust policy of roles of other accounts
+	ExternalID string `config:"external_id"`
+	// Duration is the lifetime of the credentials, from 15m to 12h; they are renewed
+	// before they expire
+	Duration time.Duration `config:"duration"`
+}
+
+// RetryConfig is the policy of the standard retryer of the SDK: the throttled calls, the
+// 5xx responses and the network errors are retried with exponential backoff and full
+// jitter.
+type RetryConfig struct {
+	// MaxAttempts is the number of attempts of a call, first one included; 1 disables the
+	// retries
+	MaxAttempts int `config:"max_attempts"`
+	// MaxBackoff caps the delay before a retry
+	MaxBackoff time.Duration `config:"max_backoff"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.AssumeRole.SessionName == "" {
+		c.AssumeRole.SessionName = defaultRoleSessionName
+	}
+	if c.AssumeRole.Duration == 0 {
+		c.AssumeRole.Duration = defaultRoleDuration
+	}
+	if c.Retry.MaxAttempts <= 0 {
+		c.Retry.MaxAttempts = defaultMaxAttempts
+	}
+	if c.Retry.MaxBackoff <= 0 {
+		c.Retry.MaxBackoff = defaultMaxBackoff
+	}
+}
+
+// Validate checks the role duration. The region is checked once the SDK configuration is
+// loaded, as it may come from the environment.
+func (c *Config) Validate() error {
+	if c.AssumeRole.RoleARN != "" &&
+		(c.AssumeRole.Duration < minRoleDuration || c.AssumeRole.Duration > maxRoleDuration) {
+		return ErrInvalidRoleDuration
+	}
+	return nil
+}
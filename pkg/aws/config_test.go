@@ -0,0 +1,67 @@
+package aws_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+)
+
+func TestConfig_SetDefaults(t *testing.T) {
+	// Arrange
+	cfg := aws.Config{Region: "us-east-1"}
+
+	// Act
+	cfg.SetDefaults()
+
+	// Assert
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+	assert.Equal(t, 3, cfg.Retry.MaxAttempts)
+	assert.Equal(t, 20*time.Second, cfg.Retry.MaxBackoff)
+	assert.Equal(t, "bricks", cfg.AssumeRole.SessionName)
+	assert.Equal(t, time.Hour, cfg.AssumeRole.Duration)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     aws.Config
+		wantErr error
+	}{
+		{
+			name: "valid",
+			cfg:  aws.Config{Region: "us-east-1"},
+		},
+		{
+			name: "region of the environment",
+			cfg:  aws.Config{},
+		},
+		{
+			name: "role duration out of bounds",
+			cfg: aws.Config{
+				Region:     "us-east-1",
+				AssumeRole: aws.AssumeRoleConfig{RoleARN: "arn:aws:iam::123456789012:role/orders", Duration: 13 * time.Hour},
+			},
+			wantErr: aws.ErrInvalidRoleDuration,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.cfg.SetDefaults()
+
+			// Act
+			err := tt.cfg.Validate()
+
+			// Assert
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
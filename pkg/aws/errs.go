@@ -0,0 +1,11 @@
+package aws
+
+import (
+	"errors"
+)
+
+var (
+	ErrMissingRegion       = errors.New("AWS region is required")
+	ErrInvalidRoleDuration = errors.New("assume role duration must be between 15m and 12h")
+	ErrInvalidSecret       = errors.New("secret merged at the config root must be a JSON object")
+)
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+// Module provides the *Session configured under "app.aws". Add the modules of the clients
+// the application calls.
+//
+// Usage in your application:
+//
+//	fx.New(
+//	    aws.Module,
+//	    aws.S3Module,
+//	    aws.SecretsManagerModule,
+//	    fx.Invoke(func(s3 *s3.Client, secrets *secretsmanager.Client) {}),
+//	)
+var Module = fx.Module("aws",
+	config.Provide[Config]("app.aws"),
+	fx.Provide(NewSessionWithParams),
+)
+
+// S3Module provides the *s3.Client of the *Session.
+var S3Module = fx.Provide(NewS3)
+
+// SQSModule provides the *sqs.Client of the *Session.
+var SQSModule = fx.Provide(NewSQS)
+
+// SNSModule provides the *sns.Client of the *Session.
+var SNSModule = fx.Provide(NewSNS)
+
+// SESModule provides the *sesv2.Client of the *Session.
+var SESModule = fx.Provide(NewSES)
+
+// SecretsManagerModule provides the *secretsmanager.Client of the *Session.
+var SecretsManagerModule = fx.Provide(NewSecretsManager)
+
+// SSMModule provides the *ssm.Client of the *Session.
+var SSMModule = fx.Provide(NewSSM)
+
+// KMSModule provides the *kms.Client of the *Session.
+var KMSModule = fx.Provide(NewKMS)
+
+// Params for dependency injection
+type Params struct {
+	fx.In
+	Config  config.Config[Config]
+	Options []Option `optional:"true"`
+}
+
+// NewSessionWithParams creates the *Session of the loaded configuration.
+func NewSessionWithParams(params Params) (*Session, error) {
+	return NewSession(params.Config.Get(), params.Options...)
+}
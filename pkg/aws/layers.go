@@ -0,0 +1,246 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+// ParameterStoreLayer is a config.Layer of the parameters under a path of the Parameter
+// Store. The name of a parameter relative to the path is its key, with "/" nesting the
+// keys: under /orders/production, /orders/production/app/database/host is app.database.host.
+// The values of StringList parameters are lists.
+type ParameterStoreLayer struct {
+	ssm   ssm.GetParametersByPathAPIClient
+	path  string
+	cache layerCache
+}
+
+var _ config.Layer = (*ParameterStoreLayer)(nil)
+
+// NewParameterStoreLayer creates the layer of the parameters under path, read with client,
+// e.g. the *ssm.Client of NewSSM. The parameters are loaded once, or again after refresh
+// when it is not zero, e.g. to feed config.Watch.
+func NewParameterStoreLayer(
+	client ssm.GetParametersByPathAPIClient,
+	path string,
+	refresh time.Duration,
+) *ParameterStoreLayer {
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return &ParameterStoreLayer{
+		ssm:   client,
+		path:  path,
+		cache: layerCache{clock: clock.New(), refresh: refresh},
+	}
+}
+
+// Name returns "ssm:" and the path of the layer.
+func (l *ParameterStoreLayer) Name() string {
+	return "ssm:" + l.path
+}
+
+// Load returns the parameters under the path as nested keys.
+func (l *ParameterStoreLayer) Load(ctx context.Context) (map[string]any, error) {
+	return l.cache.load(ctx, l.fetch)
+}
+
+// fetch reads the parameters under the path, recursively, with the values of the
+// SecureString parameters decrypted, following the pages of the results.
+func (l *ParameterStoreLayer) fetch(ctx context.Context) (map[string]any, error) {
+	pages := ssm.NewGetParametersByPathPaginator(l.ssm, &ssm.GetParametersByPathInput{
+		Path:           awssdk.String(l.path),
+		Recursive:      awssdk.Bool(true),
+		WithDecryption: awssdk.Bool(true),
+	})
+	data := make(map[string]any)
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range page.Parameters {
+			key := strings.Trim(strings.TrimPrefix(awssdk.ToString(p.Name), l.path), "/")
+			if key == "" {
+				continue
+			}
+			var value any = awssdk.ToString(p.Value)
+			if p.Type == ssmtypes.ParameterTypeStringList {
+				var items []any
+				for _, item := range strings.Split(awssdk.ToString(p.Value), ",") {
+					items = append(items, item)
+				}
+				value = items
+			}
+			setKey(data, strings.Split(key, "/"), value)
+		}
+	}
+	return data, nil
+}
+
+// GetSecretValueAPIClient is the client of the Secrets Manager GetSecretValue operation,
+// e.g. the *secretsmanager.Client of NewSecretsManager.
+type GetSecretValueAPIClient interface {
+	GetSecretValue(
+		ctx context.Context,
+		params *secretsmanager.GetSecretValueInput,
+		optFns ...func(*secretsmanager.Options),
+	) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// SecretsManagerLayer is a config.Layer of secrets of Secrets Manager, each mounted at a
+// config key. The keys of a JSON object secret are nested under its key, other secrets are
+// the value of their key.
+type SecretsManagerLayer struct {
+	secretsManager GetSecretValueAPIClient
+	// secrets maps the config keys to the IDs of their secrets
+	secrets map[string]string
+	cache   layerCache
+}
+
+var _ config.Layer = (*SecretsManagerLayer)(nil)
+
+// NewSecretsManagerLayer creates the layer of secrets, which maps the config keys, e.g.
+// "app.database", to the IDs of their secrets, their names or ARNs. The secret of the ""
+// key must be a JSON object, merged at the root. The secrets are loaded once, or again
+// after refresh when it is not zero, e.g. to feed config.Watch.
+func NewSecretsManagerLayer(
+	secretsManager GetSecretValueAPIClient,
+	secrets map[string]string,
+	refresh time.Duration,
+) *SecretsManagerLayer {
+	return &SecretsManagerLayer{
+		secretsManager: secretsManager,
+		secrets:        secrets,
+		cache:          layerCache{clock: clock.New(), refresh: refresh},
+	}
+}
+
+// Name returns "secretsmanager:" and the sorted IDs of the secrets of the layer.
+func (l *SecretsManagerLayer) Name() string {
+	ids := make([]string, 0, len(l.secrets))
+	for _, id := range l.secrets {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return "secretsmanager:" + strings.Join(ids, ",")
+}
+
+// Load returns the secrets mounted at their keys.
+func (l *SecretsManagerLayer) Load(ctx context.Context) (map[string]any, error) {
+	return l.cache.load(ctx, l.fetch)
+}
+
+func (l *SecretsManagerLayer) fetch(ctx context.Context) (map[string]any, error) {
+	keys := make([]string, 0, len(l.secrets))
+	for key := range l.secrets {
+		keys = append(keys, key)
+	}
+	// parent keys first, so the secrets of nested keys are merged over their parents
+	slices.SortFunc(keys, func(a, b string) int {
+		if a == "" || b == "" {
+			return len(a) - len(b)
+		}
+		if depth := strings.Count(a, ".") - strings.Count(b, "."); depth != 0 {
+			return depth
+		}
+		return strings.Compare(a, b)
+	})
+
+	data := make(map[string]any)
+	for _, key := range keys {
+		secret, err := l.secretsManager.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: awssdk.String(l.secrets[key]),
+		})
+		if err != nil {
+			return nil, err
+		}
+		text := awssdk.ToString(secret.SecretString)
+		if text == "" {
+			text = string(secret.SecretBinary)
+		}
+		var value any = text
+		var object map[string]any
+		if json.Unmarshal([]byte(text), &object) == nil && object != nil {
+			value = object
+		} else if key == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSecret, l.secrets[key])
+		}
+		var path []string
+		if key != "" {
+			path = strings.Split(key, ".")
+		}
+		setKey(data, path, value)
+	}
+	return data, nil
+}
+
+// setKey sets value at the nested key of path in data, merging maps into the maps already
+// set. A map value at the root, an empty path, is merged into data.
+func setKey(data map[string]any, path []string, value any) {
+	if len(path) == 0 {
+		if object, ok := value.(map[string]any); ok {
+			for key, v := range object {
+				setKey(data, []string{key}, v)
+			}
+		}
+		return
+	}
+	for _, key := range path[:len(path)-1] {
+		child, ok := data[key].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			data[key] = child
+		}
+		data = child
+	}
+	key := path[len(path)-1]
+	object, isObject := value.(map[string]any)
+	current, hasObject := data[key].(map[string]any)
+	if isObject && hasObject {
+		setKey(current, nil, object)
+		return
+	}
+	data[key] = value
+}
+
+// layerCache keeps the keys of a layer until they are older than refresh, when refresh is
+// not zero.
+type layerCache struct {
+	clock    clock.Clock
+	refresh  time.Duration
+	mu       sync.Mutex
+	data     map[string]any
+	loadedAt time.Time
+}
+
+func (c *layerCache) load(
+	ctx context.Context,
+	fetch func(context.Context) (map[string]any, error),
+) (map[string]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data != nil && (c.refresh == 0 || c.clock.Since(c.loadedAt) < c.refresh) {
+		return c.data, nil
+	}
+	data, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.data, c.loadedAt = data, c.clock.Now()
+	return data, nil
+}
@@ -0,0 +1,177 @@
+package aws_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+)
+
+// fakeSSM returns a page of parameters per call, and records the requests.
+type fakeSSM struct {
+	pages    [][]ssmtypes.Parameter
+	requests []*ssm.GetParametersByPathInput
+}
+
+func (f *fakeSSM) GetParametersByPath(
+	_ context.Context,
+	params *ssm.GetParametersByPathInput,
+	_ ...func(*ssm.Options),
+) (*ssm.GetParametersByPathOutput, error) {
+	f.requests = append(f.requests, params)
+	page, _ := strconv.Atoi(awssdk.ToString(params.NextToken))
+	output := &ssm.GetParametersByPathOutput{Parameters: f.pages[page]}
+	if page+1 < len(f.pages) {
+		output.NextToken = awssdk.String(strconv.Itoa(page + 1))
+	}
+	return output, nil
+}
+
+func parameter(name string, parameterType ssmtypes.ParameterType, value string) ssmtypes.Parameter {
+	return ssmtypes.Parameter{Name: awssdk.String(name), Type: parameterType, Value: awssdk.String(value)}
+}
+
+func TestParameterStoreLayer(t *testing.T) {
+	t.Run("nests the parameters under the path", func(t *testing.T) {
+		// Arrange
+		client := &fakeSSM{pages: [][]ssmtypes.Parameter{
+			{
+				parameter("/orders/production/app/database/host", ssmtypes.ParameterTypeString, "orders-db.internal"),
+				parameter("/orders/production/app/features", ssmtypes.ParameterTypeStringList, "checkout,refunds"),
+			},
+			{
+				parameter("/orders/production/app/database/password", ssmtypes.ParameterTypeSecureString, "s3cr3t"),
+			},
+		}}
+		sut := aws.NewParameterStoreLayer(client, "/orders/production/", 0)
+
+		// Act
+		data, err := sut.Load(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "ssm:/orders/production", sut.Name())
+		assert.Equal(t, map[string]any{
+			"app": map[string]any{
+				"database": map[string]any{"host": "orders-db.internal", "password": "s3cr3t"},
+				"features": []any{"checkout", "refunds"},
+			},
+		}, data)
+		require.Len(t, client.requests, 2)
+		assert.Equal(t, "/orders/production", awssdk.ToString(client.requests[0].Path))
+		assert.True(t, awssdk.ToBool(client.requests[0].Recursive))
+		assert.True(t, awssdk.ToBool(client.requests[0].WithDecryption))
+	})
+
+	t.Run("caches the parameters until the refresh", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			refresh   time.Duration
+			wantCalls int
+		}{
+			{name: "no refresh", refresh: 0, wantCalls: 1},
+			{name: "refresh not reached", refresh: time.Hour, wantCalls: 1},
+			{name: "refresh reached", refresh: time.Nanosecond, wantCalls: 2},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				client := &fakeSSM{pages: [][]ssmtypes.Parameter{
+					{parameter("/orders/port", ssmtypes.ParameterTypeString, "8080")},
+				}}
+				sut := aws.NewParameterStoreLayer(client, "/orders", tt.refresh)
+				ctx := context.Background()
+
+				// Act
+				_, firstErr := sut.Load(ctx)
+				data, secondErr := sut.Load(ctx)
+
+				// Assert
+				require.NoError(t, firstErr)
+				require.NoError(t, secondErr)
+				assert.Equal(t, map[string]any{"port": "8080"}, data)
+				assert.Len(t, client.requests, tt.wantCalls)
+			})
+		}
+	})
+}
+
+// fakeSecretsManager returns the secrets by ID, and ResourceNotFoundException for the others.
+type fakeSecretsManager map[string]string
+
+func (f fakeSecretsManager) GetSecretValue(
+	_ context.Context,
+	params *secretsmanager.GetSecretValueInput,
+	_ ...func(*secretsmanager.Options),
+) (*secretsmanager.GetSecretValueOutput, error) {
+	secret, ok := f[awssdk.ToString(params.SecretId)]
+	if !ok {
+		return nil, &smtypes.ResourceNotFoundException{Message: awssdk.String("Secrets Manager can't find it.")}
+	}
+	return &secretsmanager.GetSecretValueOutput{Name: params.SecretId, SecretString: awssdk.String(secret)}, nil
+}
+
+func TestSecretsManagerLayer(t *testing.T) {
+	client := fakeSecretsManager{
+		"orders/shared":   `{"app":{"name":"orders","database":{"user":"orders"}}}`,
+		"orders/db":       `{"password":"s3cr3t"}`,
+		"orders/api-key":  `k3y`,
+		"orders/not-json": `plain`,
+	}
+
+	t.Run("mounts the secrets at their keys", func(t *testing.T) {
+		// Arrange
+		sut := aws.NewSecretsManagerLayer(client, map[string]string{
+			"":                 "orders/shared",
+			"app.database":     "orders/db",
+			"app.payments.key": "orders/api-key",
+		}, 0)
+
+		// Act
+		data, err := sut.Load(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "secretsmanager:orders/api-key,orders/db,orders/shared", sut.Name())
+		assert.Equal(t, map[string]any{
+			"app": map[string]any{
+				"name":     "orders",
+				"database": map[string]any{"user": "orders", "password": "s3cr3t"},
+				"payments": map[string]any{"key": "k3y"},
+			},
+		}, data)
+	})
+
+	t.Run("rejects a secret at the root that is not a JSON object", func(t *testing.T) {
+		// Arrange
+		sut := aws.NewSecretsManagerLayer(client, map[string]string{"": "orders/not-json"}, 0)
+
+		// Act
+		_, err := sut.Load(context.Background())
+
+		// Assert
+		require.ErrorIs(t, err, aws.ErrInvalidSecret)
+	})
+
+	t.Run("returns the error of a missing secret", func(t *testing.T) {
+		// Arrange
+		sut := aws.NewSecretsManagerLayer(client, map[string]string{"app.database": "orders/missing"}, 0)
+
+		// Act
+		_, err := sut.Load(context.Background())
+
+		// Assert
+		var notFound *smtypes.ResourceNotFoundException
+		require.ErrorAs(t, err, &notFound)
+	})
+}
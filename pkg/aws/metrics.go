@@ -0,0 +1,155 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	requestsMetricName = "aws_requests_total"
+	durationMetricName = "aws_request_duration_seconds"
+	retriesMetricName  = "aws_request_retries_total"
+
+	// instrumentationID is the ID of the middleware in the stacks of the SDK clients
+	instrumentationID = "bricks.Instrumentation"
+)
+
+// instrumentation is the middleware of the SDK clients recording a span and the metrics of
+// each call, its retries included.
+type instrumentation struct {
+	tracer   trace.Tracer
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+}
+
+func newInstrumentation(registerer prometheus.Registerer, tracer trace.Tracer) (*instrumentation, error) {
+	requests, err := metrics.Register(registerer, prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: requestsMetricName,
+			Help: "Total AWS API calls by service, operation and status code",
+		},
+		[]string{"service", "operation", "code"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := metrics.Register(registerer, prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    durationMetricName,
+			Help:    "Duration of the AWS API calls in seconds, retries included",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "operation"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	retries, err := metrics.Register(registerer, prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: retriesMetricName,
+			Help: "Total retried attempts of the AWS API calls by service and operation",
+		},
+		[]string{"service", "operation"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentation{tracer: tracer, requests: requests, duration: duration, retries: retries}, nil
+}
+
+// register adds the instrumentation to the initialize step of stack, after the service
+// metadata is set and before the retry loop of the finalize step.
+func (i *instrumentation) register(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(instrumentationID, i.handle), middleware.After)
+}
+
+func (i *instrumentation) handle(
+	ctx context.Context,
+	in middleware.InitializeInput,
+	next middleware.InitializeHandler,
+) (middleware.InitializeOutput, middleware.Metadata, error) {
+	// The service IDs are e.g. S3 or Secrets Manager; the labels are e.g. s3 or secretsmanager
+	service := strings.ToLower(strings.ReplaceAll(awsmiddleware.GetServiceID(ctx), " ", ""))
+	operation := awsmiddleware.GetOperationName(ctx)
+	ctx, span := i.tracer.Start(ctx, service+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "aws-api"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", operation),
+			attribute.String("cloud.region", awsmiddleware.GetRegion(ctx)),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	elapsed := time.Since(start)
+
+	if attempts, ok := retry.GetAttemptResults(metadata); ok && len(attempts.Results) > 1 {
+		for attempt, result := range attempts.Results[:len(attempts.Results)-1] {
+			i.retries.WithLabelValues(service, operation).Inc()
+			if result.Err != nil {
+				span.AddEvent("retry", trace.WithAttributes(
+					attribute.Int("attempt", attempt+1),
+					attribute.String("error", result.Err.Error()),
+				))
+			}
+		}
+	}
+	code := "error"
+	if status := statusCode(metadata, err); status != 0 {
+		code = strconv.Itoa(status)
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	if requestID := requestID(metadata, err); requestID != "" {
+		span.SetAttributes(attribute.String("aws.request_id", requestID))
+	}
+	i.requests.WithLabelValues(service, operation, code).Inc()
+	i.duration.WithLabelValues(service, operation).Observe(elapsed.Seconds())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return out, metadata, err
+}
+
+// statusCode returns the HTTP status of the last response of a call, 0 when no response
+// came.
+func statusCode(metadata middleware.Metadata, err error) int {
+	var responseErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &responseErr) {
+		return responseErr.HTTPStatusCode()
+	}
+	if resp, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok {
+		return resp.StatusCode
+	}
+	return 0
+}
+
+func requestID(metadata middleware.Metadata, err error) string {
+	var responseErr interface{ ServiceRequestID() string }
+	if errors.As(err, &responseErr) {
+		return responseErr.ServiceRequestID()
+	}
+	requestID, _ := awsmiddleware.GetRequestIDMetadata(metadata)
+	return requestID
+}
+
+// register registers collector, or returns the collector already registered under its
+// name, so several sessions can share a registerer.
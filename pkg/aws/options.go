@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"net/http"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type options struct {
+	httpClient     *http.Client
+	credentials    awssdk.CredentialsProvider
+	loadOptions    []func(*awsconfig.LoadOptions) error
+	registerer     prometheus.Registerer
+	tracerProvider trace.TracerProvider
+}
+
+// Option configures the Session created by NewSession.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		registerer:     prometheus.DefaultRegisterer,
+		tracerProvider: otel.GetTracerProvider(),
+	}
+}
+
+// WithHTTPClient sets the HTTP client calling the APIs, replacing the client of the SDK
+// bounded by the configured timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		if client != nil {
+			o.httpClient = client
+		}
+	}
+}
+
+// WithCredentials sets the provider of the credentials, replacing the keys of the config
+// and the default chain of the SDK. With a role to assume, they are the credentials
+// assuming it.
+func WithCredentials(provider awssdk.CredentialsProvider) Option {
+	return func(o *options) {
+		if provider != nil {
+			o.credentials = provider
+		}
+	}
+}
+
+// WithLoadOptions adds options to the loading of the SDK configuration, applied after the
+// ones of the config, e.g. awsconfig.WithCustomCABundle.
+func WithLoadOptions(opts ...func(*awsconfig.LoadOptions) error) Option {
+	return func(o *options) {
+		o.loadOptions = append(o.loadOptions, opts...)
+	}
+}
+
+// WithRegisterer sets the registerer the call metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithTracerProvider sets the provider of the call spans. Defaults to the global one,
+// set by the otel/trace package.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		if provider != nil {
+			o.tracerProvider = provider
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Package aws is the AWS bootstrap shared by the services: a Session loading the AWS SDK
+// configuration of the region, the credentials chain, an optional role to assume, the
+// endpoint overrides and the retry policy, with metrics and traces of the calls, and the
+// SDK clients of S3, SQS, SNS, SES, Secrets Manager, SSM and KMS built on it.
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+)
+
+const tracerName = "github.com/cristiano-pacheco/bricks/pkg/aws"
+
+// Session is the AWS SDK configuration shared by the clients of the services. It is safe
+// for concurrent use.
+type Session struct {
+	cfg Config
+	sdk awssdk.Config
+}
+
+// NewSession loads the AWS SDK configuration of cfg. The region and the credentials not
+// set in cfg come from the default chain of the SDK: the environment, the shared config
+// and credentials files, the web identity token of EKS, the ECS task role or the EC2
+// instance profile. With a role to assume, the credentials of the role are requested on
+// the first call.
+func NewSession(cfg Config, opts ...Option) (*Session, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	instrumentation, err := newInstrumentation(o.registerer, o.tracerProvider.Tracer(tracerName))
+	if err != nil {
+		return nil, err
+	}
+
+	var httpClient awsconfig.HTTPClient = awshttp.NewBuildableClient().WithTimeout(cfg.Timeout)
+	if o.httpClient != nil {
+		httpClient = o.httpClient
+	}
+	loadOptions := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithHTTPClient(httpClient),
+		awsconfig.WithRetryer(func() awssdk.Retryer {
+			return retry.NewStandard(func(so *retry.StandardOptions) {
+				so.MaxAttempts = cfg.Retry.MaxAttempts
+				so.MaxBackoff = cfg.Retry.MaxBackoff
+			})
+		}),
+		awsconfig.WithAPIOptions([]func(*middleware.Stack) error{instrumentation.register}),
+	}
+	if cfg.Region != "" {
+		loadOptions = append(loadOptions, awsconfig.WithRegion(cfg.Region))
+	}
+	if cfg.Profile != "" {
+		loadOptions = append(loadOptions, awsconfig.WithSharedConfigProfile(cfg.Profile))
+	}
+	if cfg.Endpoint != "" {
+		loadOptions = append(loadOptions, awsconfig.WithBaseEndpoint(cfg.Endpoint))
+	}
+	credentialsProvider := o.credentials
+	if credentialsProvider == nil && cfg.AccessKeyID != "" {
+		credentialsProvider = credentials.NewStaticCredentialsProvider(
+			cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken)
+	}
+	if credentialsProvider != nil {
+		loadOptions = append(loadOptions, awsconfig.WithCredentialsProvider(credentialsProvider))
+	}
+	loadOptions = append(loadOptions, o.loadOptions...)
+
+	sdk, err := awsconfig.LoadDefaultConfig(context.Background(), loadOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+	if sdk.Region == "" {
+		return nil, ErrMissingRegion
+	}
+
+	s := &Session{cfg: cfg, sdk: sdk}
+	if role := cfg.AssumeRole; role.RoleARN != "" {
+		stsClient := sts.NewFromConfig(sdk, func(o *sts.Options) { o.BaseEndpoint = s.baseEndpoint("sts") })
+		s.sdk.Credentials = awssdk.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, role.RoleARN,
+			func(ro *stscreds.AssumeRoleOptions) {
+				ro.RoleSessionName = role.SessionName
+				ro.Duration = role.Duration
+				if role.ExternalID != "" {
+					ro.ExternalID = awssdk.String(role.ExternalID)
+				}
+			}))
+	}
+	return s, nil
+}
+
+// Config returns the AWS SDK configuration of the Session, e.g. for the clients of the
+// services the Session does not cover. Pass the Endpoint of the service to the client as
+// its BaseEndpoint.
+func (s *Session) Config() awssdk.Config {
+	return s.sdk.Copy()
+}
+
+// Region returns the region of the Session.
+func (s *Session) Region() string {
+	return s.sdk.Region
+}
+
+// Endpoint returns the endpoint override of service, e.g. s3 or secretsmanager: its entry
+// in Endpoints, else Endpoint. It is empty when the SDK resolves the regional endpoint.
+func (s *Session) Endpoint(service string) string {
+	if endpoint := s.cfg.Endpoints[service]; endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	return strings.TrimSuffix(s.cfg.Endpoint, "/")
+}
+
+// baseEndpoint returns the Endpoint of service as the BaseEndpoint of its client, nil for
+// the regional endpoint.
+func (s *Session) baseEndpoint(service string) *string {
+	if endpoint := s.Endpoint(service); endpoint != "" {
+		return awssdk.String(endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package aws_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/smithy-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+)
+
+var testCredentials = credentials.NewStaticCredentialsProvider("key", "secret", "")
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func newTestSession(t *testing.T, handler http.HandlerFunc, opts ...aws.Option) *aws.Session {
+	t.Helper()
+	cfg := aws.Config{
+		Region:   "us-east-1",
+		Endpoint: newTestServer(t, handler),
+		Retry:    aws.RetryConfig{MaxBackoff: time.Millisecond},
+	}
+	opts = append([]aws.Option{
+		aws.WithCredentials(testCredentials),
+		aws.WithRegisterer(prometheus.NewRegistry()),
+	}, opts...)
+	session, err := aws.NewSession(cfg, opts...)
+	require.NoError(t, err)
+	return session
+}
+
+func sendMessage(session *aws.Session) error {
+	_, err := aws.NewSQS(session).SendMessage(context.Background(), &sqs.SendMessageInput{
+		QueueUrl:    awssdk.String("https://sqs.us-east-1.amazonaws.com/123456789012/billing"),
+		MessageBody: awssdk.String("hello"),
+	})
+	return err
+}
+
+func TestNewSession(t *testing.T) {
+	t.Run("signs the calls with the credentials", func(t *testing.T) {
+		// Arrange
+		var authorization string
+		sut := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"MessageId":"msg-1"}`))
+		})
+
+		// Act
+		err := sendMessage(sut)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=key/"))
+		assert.Contains(t, authorization, "/us-east-1/sqs/aws4_request")
+	})
+
+	t.Run("retries throttled calls", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		registry := prometheus.NewRegistry()
+		sut := newTestSession(t, func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"com.amazonaws.sqs#ThrottlingException","message":"Rate exceeded"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"MessageId":"msg-1"}`))
+		}, aws.WithRegisterer(registry))
+
+		// Act
+		err := sendMessage(sut)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+		err = testutil.GatherAndCompare(registry, strings.NewReader(`
+			# HELP aws_request_retries_total Total retried attempts of the AWS API calls by service and operation
+			# TYPE aws_request_retries_total counter
+			aws_request_retries_total{operation="SendMessage",service="sqs"} 2
+			# HELP aws_requests_total Total AWS API calls by service, operation and status code
+			# TYPE aws_requests_total counter
+			aws_requests_total{code="200",operation="SendMessage",service="sqs"} 1
+		`), "aws_request_retries_total", "aws_requests_total")
+		require.NoError(t, err)
+	})
+
+	t.Run("returns the error of the last attempt", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		sut := newTestSession(t, func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.Header().Set("X-Amzn-Requestid", "req-1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		// Act
+		err := sendMessage(sut)
+
+		// Assert
+		var responseErr *awshttp.ResponseError
+		require.ErrorAs(t, err, &responseErr)
+		assert.Equal(t, http.StatusServiceUnavailable, responseErr.HTTPStatusCode())
+		assert.Equal(t, "req-1", responseErr.ServiceRequestID())
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		// Arrange
+		var calls atomic.Int32
+		sut := newTestSession(t, func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The key does not exist.</Message></Error>`))
+		})
+
+		// Act
+		_, err := aws.NewS3(sut).GetObject(context.Background(), &s3.GetObjectInput{
+			Bucket: awssdk.String("orders"),
+			Key:    awssdk.String("missing.json"),
+		})
+
+		// Assert
+		var apiErr smithy.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "NoSuchKey", apiErr.ErrorCode())
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("traces the calls", func(t *testing.T) {
+		// Arrange
+		exporter := tracetest.NewInMemoryExporter()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		sut := newTestSession(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("X-Amzn-Requestid", "req-1")
+			_, _ = w.Write([]byte(`{"MessageId":"msg-1"}`))
+		}, aws.WithTracerProvider(provider))
+
+		// Act
+		err := sendMessage(sut)
+
+		// Assert
+		require.NoError(t, err)
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "sqs.SendMessage", spans[0].Name)
+		attributes := map[string]any{}
+		for _, attribute := range spans[0].Attributes {
+			attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+		}
+		assert.Equal(t, "us-east-1", attributes["cloud.region"])
+		assert.Equal(t, int64(http.StatusOK), attributes["http.response.status_code"])
+		assert.Equal(t, "req-1", attributes["aws.request_id"])
+	})
+
+	t.Run("assumes the role", func(t *testing.T) {
+		// Arrange
+		var authorization string
+		var assumed atomic.Int32
+		endpoint := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			if r.Form.Get("Action") == "AssumeRole" {
+				assumed.Add(1)
+				assert.Equal(t, "arn:aws:iam::123456789012:role/orders", r.Form.Get("RoleArn"))
+				assert.Equal(t, "bricks", r.Form.Get("RoleSessionName"))
+				assert.Equal(t, "tenant-1", r.Form.Get("ExternalId"))
+				assert.Equal(t, "3600", r.Form.Get("DurationSeconds"))
+				_, _ = w.Write([]byte(`<AssumeRoleResponse><AssumeRoleResult><Credentials>
+					<AccessKeyId>role-key</AccessKeyId><SecretAccessKey>role-secret</SecretAccessKey>
+					<SessionToken>role-token</SessionToken><Expiration>2099-01-01T00:00:00Z</Expiration>
+					</Credentials></AssumeRoleResult></AssumeRoleResponse>`))
+				return
+			}
+			authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"MessageId":"msg-1"}`))
+		})
+		sut, err := aws.NewSession(aws.Config{
+			Region:   "us-east-1",
+			Endpoint: endpoint,
+			AssumeRole: aws.AssumeRoleConfig{
+				RoleARN:    "arn:aws:iam::123456789012:role/orders",
+				ExternalID: "tenant-1",
+			},
+		}, aws.WithCredentials(testCredentials), aws.WithRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+
+		// Act
+		firstErr := sendMessage(sut)
+		secondErr := sendMessage(sut)
+
+		// Assert
+		require.NoError(t, firstErr)
+		require.NoError(t, secondErr)
+		assert.Equal(t, int32(1), assumed.Load())
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=role-key/"))
+	})
+}
+
+func TestNewSession_Region(t *testing.T) {
+	isolateEnvironment := func(t *testing.T) {
+		t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+		t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+		t.Setenv("AWS_REGION", "")
+		t.Setenv("AWS_DEFAULT_REGION", "")
+		t.Setenv("AWS_PROFILE", "")
+	}
+
+	t.Run("takes the region of the environment", func(t *testing.T) {
+		// Arrange
+		isolateEnvironment(t)
+		t.Setenv("AWS_REGION", "eu-west-1")
+
+		// Act
+		sut, err := aws.NewSession(aws.Config{}, aws.WithRegisterer(prometheus.NewRegistry()))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "eu-west-1", sut.Region())
+		assert.Equal(t, "eu-west-1", sut.Config().Region)
+	})
+
+	t.Run("requires a region", func(t *testing.T) {
+		// Arrange
+		isolateEnvironment(t)
+
+		// Act
+		_, err := aws.NewSession(aws.Config{}, aws.WithRegisterer(prometheus.NewRegistry()))
+
+		// Assert
+		require.ErrorIs(t, err, aws.ErrMissingRegion)
+	})
+}
+
+func TestSession_Endpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     aws.Config
+		service string
+		want    string
+	}{
+		{
+			name:    "regional endpoint",
+			cfg:     aws.Config{Region: "eu-west-1"},
+			service: "secretsmanager",
+			want:    "",
+		},
+		{
+			name:    "overriding endpoint",
+			cfg:     aws.Config{Region: "eu-west-1", Endpoint: "http://localhost:4566/"},
+			service: "s3",
+			want:    "http://localhost:4566",
+		},
+		{
+			name: "endpoint of the service",
+			cfg: aws.Config{
+				Region:    "eu-west-1",
+				Endpoint:  "http://localhost:4566",
+				Endpoints: map[string]string{"s3": "http://localhost:9000"},
+			},
+			service: "s3",
+			want:    "http://localhost:9000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			sut, err := aws.NewSession(tt.cfg, aws.WithRegisterer(prometheus.NewRegistry()))
+			require.NoError(t, err)
+
+			// Act
+			endpoint := sut.Endpoint(tt.service)
+
+			// Assert
+			assert.Equal(t, tt.want, endpoint)
+		})
+	}
+}
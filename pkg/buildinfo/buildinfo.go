@@ -0,0 +1,94 @@
+// Package buildinfo describes the running binary: its version, commit and build date, set
+// with -ldflags at build time, so every service reports them the same way.
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Version, Commit and Date are set at build time:
+//
+//	go build -ldflags "-X github.com/cristiano-pacheco/bricks/pkg/buildinfo.Version=1.2.3 \
+//	    -X github.com/cristiano-pacheco/bricks/pkg/buildinfo.Commit=$(git rev-parse HEAD) \
+//	    -X github.com/cristiano-pacheco/bricks/pkg/buildinfo.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version string
+	Commit  string
+	Date    string
+)
+
+// develVersion is the module version of a binary built from a source tree.
+const develVersion = "(devel)"
+
+// Info describes the running binary.
+// It implements http.Handler and writes itself as JSON, e.g. on /version.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build information set with -ldflags. Empty values fall back to the module
+// version, VCS revision and VCS commit time embedded by the Go toolchain.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, Date: Date, GoVersion: runtime.Version()}
+
+	embedded, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "" && embedded.Main.Version != develVersion {
+		info.Version = embedded.Main.Version
+	}
+	for _, setting := range embedded.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == "":
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time" && info.Date == "":
+			info.Date = setting.Value
+		}
+	}
+	return info
+}
+
+// String returns the version, commit and build date, with placeholders for the unknown ones,
+// e.g. "1.2.3 (commit 4f2c1e9, built 2026-01-02)".
+func (i Info) String() string {
+	return fmt.Sprintf("%s (commit %s, built %s)",
+		valueOr(i.Version, "dev"), valueOr(i.Commit, "none"), valueOr(i.Date, "unknown"))
+}
+
+// LogFields returns the version and commit as log fields.
+func (i Info) LogFields() []logger.Field {
+	return []logger.Field{
+		logger.String("version", valueOr(i.Version, "dev")),
+		logger.String("commit", valueOr(i.Commit, "none")),
+	}
+}
+
+// DecorateLogger adds the version and commit to every entry of log. With FX:
+//
+//	fx.Decorate(buildinfo.Get().DecorateLogger)
+func (i Info) DecorateLogger(log logger.Logger) logger.Logger {
+	return log.With(i.LogFields()...)
+}
+
+func (i Info) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(i)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,123 @@
+package buildinfo_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/buildinfo"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("returns the values set with ldflags", func(t *testing.T) {
+		// Arrange
+		t.Cleanup(buildinfo.SetVersion("1.2.3", "abc123", "2026-01-02"))
+
+		// Act
+		info := buildinfo.Get()
+
+		// Assert
+		assert.Equal(t, buildinfo.Info{
+			Version: "1.2.3", Commit: "abc123", Date: "2026-01-02", GoVersion: runtime.Version(),
+		}, info)
+	})
+
+	t.Run("leaves the version of a source tree build empty", func(t *testing.T) {
+		// Act
+		info := buildinfo.Get()
+
+		// Assert
+		assert.Empty(t, info.Version)
+		assert.Equal(t, runtime.Version(), info.GoVersion)
+	})
+}
+
+func TestInfo_String(t *testing.T) {
+	tests := []struct {
+		name string
+		info buildinfo.Info
+		want string
+	}{
+		{
+			"complete",
+			buildinfo.Info{Version: "1.2.3", Commit: "abc123", Date: "2026-01-02"},
+			"1.2.3 (commit abc123, built 2026-01-02)",
+		},
+		{"unknown", buildinfo.Info{}, "dev (commit none, built unknown)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := tt.info.String()
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInfo_ServeHTTP(t *testing.T) {
+	// Arrange
+	info := buildinfo.Info{Version: "1.2.3", Commit: "abc123", Date: "2026-01-02", GoVersion: "go1.26.2"}
+	recorder := httptest.NewRecorder()
+
+	// Act
+	info.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"version":"1.2.3","commit":"abc123","date":"2026-01-02","go_version":"go1.26.2"}`,
+		recorder.Body.String())
+}
+
+func TestInfo_DecorateLogger(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "log.json")
+	log, err := logger.NewWithOptions(logger.WithOutputPaths(path))
+	require.NoError(t, err)
+	info := buildinfo.Info{Version: "1.2.3", Commit: "abc123"}
+
+	// Act
+	info.DecorateLogger(log).Info("started")
+
+	// Assert
+	require.NoError(t, log.Sync())
+	out, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(out, &entry))
+	assert.Equal(t, "1.2.3", entry["version"])
+	assert.Equal(t, "abc123", entry["commit"])
+}
+
+func TestNewGauge(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	info := buildinfo.Info{Version: "1.2.3", Commit: "abc123", GoVersion: "go1.26.2"}
+
+	// Act
+	require.NoError(t, registry.Register(buildinfo.NewGauge(info)))
+
+	// Assert
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "build_info", families[0].GetName())
+	metric := families[0].GetMetric()[0]
+	assert.InDelta(t, 1, metric.GetGauge().GetValue(), 0)
+	labels := make(map[string]string)
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	assert.Equal(t, map[string]string{"version": "1.2.3", "commit": "abc123", "go_version": "go1.26.2"}, labels)
+}
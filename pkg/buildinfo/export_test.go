@@ -0,0 +1,10 @@
+package buildinfo
+
+// SetVersion sets the values set with ldflags and returns a function restoring them.
+func SetVersion(version, commit, date string) func() {
+	previous := [3]string{Version, Commit, Date}
+	Version, Commit, Date = version, commit, date
+	return func() {
+		Version, Commit, Date = previous[0], previous[1], previous[2]
+	}
+}
@@ -0,0 +1,21 @@
+package buildinfo
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const metricName = "build_info"
+
+// NewGauge returns the build_info gauge, always 1, labeled with the version, commit and
+// go_version of info. Register it on the application registry, e.g. the one of metrics.Module.
+func NewGauge(info Info) prometheus.Gauge {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricName,
+		Help: "Build information of the running binary, always 1",
+		ConstLabels: prometheus.Labels{
+			"version":    info.Version,
+			"commit":     info.Commit,
+			"go_version": info.GoVersion,
+		},
+	})
+	gauge.Set(1)
+	return gauge
+}
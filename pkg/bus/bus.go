@@ -0,0 +1,159 @@
+// Package bus dispatches the commands and queries of the application to their use cases
+// by input type, so the HTTP handlers, gRPC services and message consumers call any use
+// case the same way. The handlers are ucdecorator use cases: wrapped by the ucdecorator
+// Factory, they keep their transaction, logging, metrics and tracing decorators.
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+)
+
+// Kinds of the messages.
+const (
+	KindCommand = "command"
+	KindQuery   = "query"
+)
+
+// Message is a command or a query being dispatched, as seen by middleware.
+type Message struct {
+	// Kind is KindCommand or KindQuery
+	Kind string
+	// Name is the Go type name of the input, e.g. "usecase.PlaceOrderInput"
+	Name string
+	// Input is the dispatched command or query
+	Input any
+}
+
+// HandleFunc handles a message, returning the result of its use case. Middleware wraps it.
+type HandleFunc func(ctx context.Context, message Message) (any, error)
+
+// Middleware wraps the dispatch of every message of a bus, e.g. for validation or auditing.
+// The decorators of a single use case belong to ucdecorator.
+type Middleware func(next HandleFunc) HandleFunc
+
+// Handler is a use case registered on a bus for its input type.
+type Handler struct {
+	kind       string
+	inputType  reflect.Type
+	resultType reflect.Type
+	handle     HandleFunc
+}
+
+// Command returns the Handler dispatching the commands of type C to useCase.
+func Command[C any, R any](useCase ucdecorator.UseCase[C, R]) Handler {
+	return newHandler(KindCommand, useCase)
+}
+
+// Query returns the Handler dispatching the queries of type Q to useCase.
+func Query[Q any, R any](useCase ucdecorator.UseCase[Q, R]) Handler {
+	return newHandler(KindQuery, useCase)
+}
+
+func newHandler[T any, R any](kind string, useCase ucdecorator.UseCase[T, R]) Handler {
+	return Handler{
+		kind:       kind,
+		inputType:  reflect.TypeFor[T](),
+		resultType: reflect.TypeFor[R](),
+		handle: func(ctx context.Context, message Message) (any, error) {
+			input, _ := message.Input.(T)
+			return useCase.Execute(ctx, input)
+		},
+	}
+}
+
+// dispatcher routes the messages of one kind to their handlers by input type.
+type dispatcher struct {
+	kind        string
+	middlewares []Middleware
+
+	mu       sync.RWMutex
+	handlers map[reflect.Type]Handler
+}
+
+func newDispatcher(kind string, opts []Option) *dispatcher {
+	busOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&busOptions)
+	}
+	return &dispatcher{
+		kind:        kind,
+		middlewares: busOptions.middlewares,
+		handlers:    make(map[reflect.Type]Handler),
+	}
+}
+
+func (d *dispatcher) register(handlers []Handler) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, handler := range handlers {
+		if handler.kind != d.kind {
+			return fmt.Errorf("%w: %s handler of %s on the %s bus", ErrHandlerKind, handler.kind,
+				typeName(handler.inputType), d.kind)
+		}
+		if _, found := d.handlers[handler.inputType]; found {
+			return fmt.Errorf("%w: %s", ErrDuplicateHandler, typeName(handler.inputType))
+		}
+
+		handle := handler.handle
+		for i := len(d.middlewares) - 1; i >= 0; i-- {
+			handle = d.middlewares[i](handle)
+		}
+		handler.handle = handle
+		d.handlers[handler.inputType] = handler
+	}
+	return nil
+}
+
+func (d *dispatcher) dispatch(ctx context.Context, inputType reflect.Type, input any) (any, Handler, error) {
+	d.mu.RLock()
+	handler, found := d.handlers[inputType]
+	d.mu.RUnlock()
+	if !found {
+		return nil, Handler{}, fmt.Errorf("%w: %s %s", ErrHandlerNotFound, d.kind, typeName(inputType))
+	}
+	result, err := handler.handle(ctx, Message{Kind: d.kind, Name: typeName(inputType), Input: input})
+	return result, handler, err
+}
+
+// dispatchAny dispatches input by its dynamic type.
+func (d *dispatcher) dispatchAny(ctx context.Context, input any) (any, error) {
+	if input == nil {
+		return nil, ErrNilInput
+	}
+	result, _, err := d.dispatch(ctx, reflect.TypeOf(input), input)
+	return result, err
+}
+
+func (d *dispatcher) has(inputType reflect.Type) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, found := d.handlers[inputType]
+	return found
+}
+
+// dispatchTyped dispatches input to the handler of T, converting its result to R.
+func dispatchTyped[T any, R any](ctx context.Context, d *dispatcher, input T) (R, error) {
+	var zero R
+	result, handler, err := d.dispatch(ctx, reflect.TypeFor[T](), input)
+	if handler.handle == nil {
+		return zero, err
+	}
+	if handler.resultType != reflect.TypeFor[R]() {
+		return zero, fmt.Errorf("%w: %s returns %s, not %s", ErrResultType, typeName(handler.inputType),
+			handler.resultType, reflect.TypeFor[R]())
+	}
+	typed, _ := result.(R)
+	return typed, err
+}
+
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.String()
+}
@@ -0,0 +1,208 @@
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/cristiano-pacheco/bricks/pkg/bus"
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+)
+
+type PlaceOrderInput struct {
+	SKU string
+}
+
+type PlaceOrderOutput struct {
+	OrderID string
+}
+
+type PlaceOrderUseCase struct {
+	err error
+}
+
+func (uc *PlaceOrderUseCase) Execute(_ context.Context, input PlaceOrderInput) (PlaceOrderOutput, error) {
+	if uc.err != nil {
+		return PlaceOrderOutput{}, uc.err
+	}
+	return PlaceOrderOutput{OrderID: "order-" + input.SKU}, nil
+}
+
+type OrderCountInput struct{}
+
+type OrderCountUseCase struct{}
+
+func (uc *OrderCountUseCase) Execute(context.Context, OrderCountInput) (int, error) {
+	return 42, nil
+}
+
+func newCommandBus(t *testing.T, opts ...bus.Option) *bus.CommandBus {
+	t.Helper()
+	commands := bus.NewCommandBus(opts...)
+	require.NoError(t, commands.Register(bus.Command[PlaceOrderInput, PlaceOrderOutput](&PlaceOrderUseCase{})))
+	return commands
+}
+
+func TestSend(t *testing.T) {
+	t.Run("dispatches the command to its use case", func(t *testing.T) {
+		// Arrange
+		commands := newCommandBus(t)
+
+		// Act
+		output, err := bus.Send[PlaceOrderInput, PlaceOrderOutput](context.Background(), commands,
+			PlaceOrderInput{SKU: "ABC"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, PlaceOrderOutput{OrderID: "order-ABC"}, output)
+	})
+
+	t.Run("returns the error of the use case", func(t *testing.T) {
+		// Arrange
+		errOutOfStock := errors.New("out of stock")
+		commands := bus.NewCommandBus()
+		require.NoError(t, commands.Register(
+			bus.Command[PlaceOrderInput, PlaceOrderOutput](&PlaceOrderUseCase{err: errOutOfStock}),
+		))
+
+		// Act
+		_, err := bus.Send[PlaceOrderInput, PlaceOrderOutput](context.Background(), commands, PlaceOrderInput{})
+
+		// Assert
+		require.ErrorIs(t, err, errOutOfStock)
+	})
+
+	t.Run("rejects an unregistered command", func(t *testing.T) {
+		// Arrange
+		commands := bus.NewCommandBus()
+
+		// Act
+		_, err := bus.Send[PlaceOrderInput, PlaceOrderOutput](context.Background(), commands, PlaceOrderInput{})
+
+		// Assert
+		require.ErrorIs(t, err, bus.ErrHandlerNotFound)
+	})
+
+	t.Run("rejects another result type", func(t *testing.T) {
+		// Arrange
+		commands := newCommandBus(t)
+
+		// Act
+		_, err := bus.Send[PlaceOrderInput, string](context.Background(), commands, PlaceOrderInput{})
+
+		// Assert
+		require.ErrorIs(t, err, bus.ErrResultType)
+	})
+}
+
+func TestAsk(t *testing.T) {
+	// Arrange
+	queries := bus.NewQueryBus()
+	require.NoError(t, queries.Register(bus.Query[OrderCountInput, int](&OrderCountUseCase{})))
+
+	// Act
+	count, err := bus.Ask[OrderCountInput, int](context.Background(), queries, OrderCountInput{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 42, count)
+	assert.True(t, bus.Answers[OrderCountInput](queries))
+	assert.False(t, bus.Answers[PlaceOrderInput](queries))
+}
+
+func TestCommandBus_Dispatch(t *testing.T) {
+	// Arrange
+	commands := newCommandBus(t)
+	var input any = PlaceOrderInput{SKU: "ABC"}
+
+	// Act
+	output, err := commands.Dispatch(context.Background(), input)
+	_, errNil := commands.Dispatch(context.Background(), nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, PlaceOrderOutput{OrderID: "order-ABC"}, output)
+	require.ErrorIs(t, errNil, bus.ErrNilInput)
+}
+
+func TestCommandBus_Register(t *testing.T) {
+	t.Run("rejects a duplicate handler", func(t *testing.T) {
+		// Arrange
+		commands := newCommandBus(t)
+
+		// Act
+		err := commands.Register(bus.Command[PlaceOrderInput, PlaceOrderOutput](&PlaceOrderUseCase{}))
+
+		// Assert
+		require.ErrorIs(t, err, bus.ErrDuplicateHandler)
+	})
+
+	t.Run("rejects a query handler", func(t *testing.T) {
+		// Arrange
+		commands := bus.NewCommandBus()
+
+		// Act
+		err := commands.Register(bus.Query[OrderCountInput, int](&OrderCountUseCase{}))
+
+		// Assert
+		require.ErrorIs(t, err, bus.ErrHandlerKind)
+		assert.False(t, bus.Handles[OrderCountInput](commands))
+	})
+}
+
+func TestWithMiddleware(t *testing.T) {
+	// Arrange
+	var calls []string
+	middleware := func(name string) bus.Middleware {
+		return func(next bus.HandleFunc) bus.HandleFunc {
+			return func(ctx context.Context, message bus.Message) (any, error) {
+				calls = append(calls, fmt.Sprintf("%s %s %s", name, message.Kind, message.Name))
+				return next(ctx, message)
+			}
+		}
+	}
+	commands := newCommandBus(t, bus.WithMiddleware(middleware("outer"), nil, middleware("inner")))
+
+	// Act
+	_, err := bus.Send[PlaceOrderInput, PlaceOrderOutput](context.Background(), commands, PlaceOrderInput{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer command bus_test.PlaceOrderInput", "inner command bus_test.PlaceOrderInput"}, calls)
+}
+
+func TestModule(t *testing.T) {
+	// Arrange
+	var commands *bus.CommandBus
+	var queries *bus.QueryBus
+	app := fxtest.New(t,
+		bus.Module,
+		fx.Provide(
+			fx.Annotate(
+				func() *PlaceOrderUseCase { return &PlaceOrderUseCase{} },
+				fx.As(new(ucdecorator.UseCase[PlaceOrderInput, PlaceOrderOutput])),
+			),
+			fx.Annotate(
+				func() *OrderCountUseCase { return &OrderCountUseCase{} },
+				fx.As(new(ucdecorator.UseCase[OrderCountInput, int])),
+			),
+		),
+		bus.ProvideCommand[PlaceOrderInput, PlaceOrderOutput](),
+		bus.ProvideQuery[OrderCountInput, int](),
+		fx.Populate(&commands, &queries),
+	)
+
+	// Act
+	app.RequireStart()
+	defer app.RequireStop()
+
+	// Assert
+	assert.True(t, bus.Handles[PlaceOrderInput](commands))
+	assert.True(t, bus.Answers[OrderCountInput](queries))
+}
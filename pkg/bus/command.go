@@ -0,0 +1,40 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+)
+
+// CommandBus dispatches the commands, the inputs changing the state of the application, to
+// their use cases.
+type CommandBus struct {
+	dispatcher *dispatcher
+}
+
+// NewCommandBus creates an empty CommandBus.
+func NewCommandBus(opts ...Option) *CommandBus {
+	return &CommandBus{dispatcher: newDispatcher(KindCommand, opts)}
+}
+
+// Register registers command handlers, one per command type.
+func (b *CommandBus) Register(handlers ...Handler) error {
+	return b.dispatcher.register(handlers)
+}
+
+// Dispatch dispatches command by its dynamic type, e.g. decoded from a message by a
+// consumer, and returns the result of its use case.
+func (b *CommandBus) Dispatch(ctx context.Context, command any) (any, error) {
+	return b.dispatcher.dispatchAny(ctx, command)
+}
+
+// Handles reports whether a handler is registered for the commands of type C.
+func Handles[C any](b *CommandBus) bool {
+	return b.dispatcher.has(reflect.TypeFor[C]())
+}
+
+// Send dispatches command to the use case registered for C, and returns its result.
+//
+//	order, err := bus.Send[usecase.PlaceOrderInput, usecase.PlaceOrderOutput](ctx, commands, input)
+func Send[C any, R any](ctx context.Context, b *CommandBus, command C) (R, error) {
+	return dispatchTyped[C, R](ctx, b.dispatcher, command)
+}
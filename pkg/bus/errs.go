@@ -0,0 +1,17 @@
+package bus
+
+import "errors"
+
+var (
+	// ErrHandlerNotFound is returned when no handler is registered for the input type.
+	ErrHandlerNotFound = errors.New("no handler registered for the message")
+	// ErrDuplicateHandler is returned when a handler is already registered for the input type.
+	ErrDuplicateHandler = errors.New("handler already registered for the message")
+	// ErrHandlerKind is returned when a query handler is registered on the CommandBus, or
+	// a command handler on the QueryBus.
+	ErrHandlerKind = errors.New("handler registered on the wrong bus")
+	// ErrResultType is returned by Send and Ask when the handler returns another result type.
+	ErrResultType = errors.New("unexpected result type of the handler")
+	// ErrNilInput is returned when the dispatched message is nil.
+	ErrNilInput = errors.New("message is nil")
+)
@@ -0,0 +1,83 @@
+package bus
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+)
+
+// Module provides the CommandBus with the handlers of the "bus_commands" group and the
+// QueryBus with the handlers of the "bus_queries" group. The use cases are provided with
+// ucdecorator.Provide, so the bus dispatches to the decorated use cases:
+//
+//	fx.New(
+//	    ucdecorator.Module,
+//	    bus.Module,
+//	    ucdecorator.Provide[usecase.PlaceOrderInput, usecase.PlaceOrderOutput](usecase.NewPlaceOrderUseCase),
+//	    bus.ProvideCommand[usecase.PlaceOrderInput, usecase.PlaceOrderOutput](),
+//	    ucdecorator.Provide[usecase.OrderListInput, usecase.OrderListOutput](
+//	        usecase.NewOrderListUseCase, ucdecorator.WithoutTransaction(),
+//	    ),
+//	    bus.ProvideQuery[usecase.OrderListInput, usecase.OrderListOutput](),
+//	)
+var Module = fx.Module(
+	"bus",
+	fx.Provide(
+		NewCommandBusWithParams,
+		NewQueryBusWithParams,
+	),
+)
+
+// ProvideCommand provides the UseCase[C, R] of the graph as the handler of the commands of
+// type C, in the "bus_commands" group.
+func ProvideCommand[C any, R any]() fx.Option {
+	return fx.Provide(fx.Annotate(
+		func(useCase ucdecorator.UseCase[C, R]) Handler {
+			return Command(useCase)
+		},
+		fx.ResultTags(`group:"bus_commands"`),
+	))
+}
+
+// ProvideQuery provides the UseCase[Q, R] of the graph as the handler of the queries of
+// type Q, in the "bus_queries" group.
+func ProvideQuery[Q any, R any]() fx.Option {
+	return fx.Provide(fx.Annotate(
+		func(useCase ucdecorator.UseCase[Q, R]) Handler {
+			return Query(useCase)
+		},
+		fx.ResultTags(`group:"bus_queries"`),
+	))
+}
+
+// CommandBusParams for dependency injection
+type CommandBusParams struct {
+	fx.In
+
+	Handlers []Handler `group:"bus_commands"`
+}
+
+// NewCommandBusWithParams creates the CommandBus with the grouped command handlers.
+func NewCommandBusWithParams(p CommandBusParams) (*CommandBus, error) {
+	commands := NewCommandBus()
+	if err := commands.Register(p.Handlers...); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// QueryBusParams for dependency injection
+type QueryBusParams struct {
+	fx.In
+
+	Handlers []Handler `group:"bus_queries"`
+}
+
+// NewQueryBusWithParams creates the QueryBus with the grouped query handlers.
+func NewQueryBusWithParams(p QueryBusParams) (*QueryBus, error) {
+	queries := NewQueryBus()
+	if err := queries.Register(p.Handlers...); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
@@ -0,0 +1,24 @@
+package bus
+
+type options struct {
+	middlewares []Middleware
+}
+
+// Option configures the bus created by NewCommandBus or NewQueryBus.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{}
+}
+
+// WithMiddleware appends middleware wrapping every handler of the bus, the first one being
+// the outermost.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *options) {
+		for _, middleware := range middlewares {
+			if middleware != nil {
+				o.middlewares = append(o.middlewares, middleware)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+)
+
+// QueryBus dispatches the queries, the inputs reading the state of the application, to
+// their use cases.
+type QueryBus struct {
+	dispatcher *dispatcher
+}
+
+// NewQueryBus creates an empty QueryBus.
+func NewQueryBus(opts ...Option) *QueryBus {
+	return &QueryBus{dispatcher: newDispatcher(KindQuery, opts)}
+}
+
+// Register registers query handlers, one per query type.
+func (b *QueryBus) Register(handlers ...Handler) error {
+	return b.dispatcher.register(handlers)
+}
+
+// Dispatch dispatches query by its dynamic type, and returns the result of its use case.
+func (b *QueryBus) Dispatch(ctx context.Context, query any) (any, error) {
+	return b.dispatcher.dispatchAny(ctx, query)
+}
+
+// Answers reports whether a handler is registered for the queries of type Q.
+func Answers[Q any](b *QueryBus) bool {
+	return b.dispatcher.has(reflect.TypeFor[Q]())
+}
+
+// Ask dispatches query to the use case registered for Q, and returns its result.
+//
+//	orders, err := bus.Ask[usecase.OrderListInput, usecase.OrderListOutput](ctx, queries, input)
+func Ask[Q any, R any](ctx context.Context, b *QueryBus, query Q) (R, error) {
+	return dispatchTyped[Q, R](ctx, b.dispatcher, query)
+}
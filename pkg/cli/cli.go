@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/migration"
+)
+
+const (
+	envVar       = "APP_ENV"
+	configDirVar = "APP_CONFIG_DIR"
+)
+
+// App describes the application run by the CLI.
+// The serve, worker and migrate commands are added when their fields are set;
+// the version command is always available.
+type App struct {
+	// Name is the binary name shown in the help, e.g. "orders"
+	Name string
+	// Description is the short description shown in the help
+	Description string
+
+	// Version, Commit and BuildDate are printed by the version command,
+	// typically set with -ldflags at build time
+	Version   string
+	Commit    string
+	BuildDate string
+
+	// Serve are the fx options of the serve command, e.g. the HTTP server modules
+	Serve []fx.Option
+	// Worker are the fx options of the worker command, e.g. queue consumers
+	Worker []fx.Option
+	// Migrations are applied by the migrate command to the database configured at app.database
+	Migrations []migration.FileSystem
+}
+
+// CLI is the command runner of an application.
+type CLI struct {
+	app     App
+	options options
+	root    *cobra.Command
+}
+
+// New creates the CLI of app with its built-in commands.
+func New(app App, opts ...Option) *CLI {
+	cliOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&cliOptions)
+	}
+
+	c := &CLI{app: app, options: cliOptions}
+	c.root = c.rootCommand()
+
+	c.root.AddCommand(c.versionCommand())
+	if len(app.Serve) > 0 {
+		c.root.AddCommand(c.fxCommand("serve", "Run the application server", app.Serve))
+	}
+	if len(app.Worker) > 0 {
+		c.root.AddCommand(c.fxCommand("worker", "Run the background workers", app.Worker))
+	}
+	if len(app.Migrations) > 0 {
+		c.root.AddCommand(c.migrateCommand())
+	}
+
+	return c
+}
+
+// AddCommand adds application specific commands, e.g. a data backfill.
+func (c *CLI) AddCommand(cmds ...*cobra.Command) *CLI {
+	c.root.AddCommand(cmds...)
+	return c
+}
+
+// Root returns the root command, e.g. to add persistent flags.
+func (c *CLI) Root() *cobra.Command {
+	return c.root
+}
+
+// Execute runs the command given by the process arguments and returns the exit code.
+// SIGINT and SIGTERM cancel the command context, so running commands shut down gracefully.
+//
+//	func main() {
+//	    os.Exit(cli.New(app).Execute())
+//	}
+func (c *CLI) Execute() int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := c.Run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintln(c.root.ErrOrStderr(), "Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// Run runs the command given by args until it returns or ctx is done.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	c.root.SetArgs(args)
+	return c.root.ExecuteContext(ctx)
+}
+
+func (c *CLI) rootCommand() *cobra.Command {
+	var env, configDir string
+
+	root := &cobra.Command{
+		Use:           c.app.Name,
+		Short:         c.app.Description,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		// The --env and --config-dir flags override the variables read by pkg/config
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if cmd.Flags().Changed("env") {
+				if err := os.Setenv(envVar, env); err != nil {
+					return fmt.Errorf("set %s: %w", envVar, err)
+				}
+			}
+			if cmd.Flags().Changed("config-dir") {
+				if err := os.Setenv(configDirVar, configDir); err != nil {
+					return fmt.Errorf("set %s: %w", configDirVar, err)
+				}
+			}
+			return nil
+		},
+	}
+	root.SetOut(c.options.out)
+	root.SetErr(c.options.err)
+	root.PersistentFlags().StringVar(&env, "env", "", "config environment, overrides "+envVar)
+	root.PersistentFlags().StringVar(&configDir, "config-dir", "", "config directory, overrides "+configDirVar)
+
+	return root
+}
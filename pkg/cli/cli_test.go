@@ -0,0 +1,198 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/buildinfo"
+	"github.com/cristiano-pacheco/bricks/pkg/cli"
+)
+
+func writeLoggerConfig(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	content := "app:\n  logger:\n    level: error\n    encoding: json\n    output_paths: [stderr]\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "base.yaml"), []byte(content), 0o600))
+	return dir
+}
+
+func TestCLI_Version(t *testing.T) {
+	t.Run("prints the app version", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+		c := cli.New(cli.App{Name: "orders", Version: "1.2.3", Commit: "abc123", BuildDate: "2026-01-02"},
+			cli.WithOutput(&out, &out))
+
+		// Act
+		err := c.Run(context.Background(), []string{"version"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "orders 1.2.3 (commit abc123, built 2026-01-02)\n", out.String())
+	})
+
+	t.Run("prints placeholders when build info is missing", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+		c := cli.New(cli.App{Name: "orders"}, cli.WithOutput(&out, &out))
+
+		// Act
+		err := c.Run(context.Background(), []string{"version"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "orders dev (commit none, built unknown)\n", out.String())
+	})
+}
+
+func TestCLI_Commands(t *testing.T) {
+	t.Run("omits commands that are not configured", func(t *testing.T) {
+		// Arrange
+		var out bytes.Buffer
+		c := cli.New(cli.App{Name: "orders"}, cli.WithOutput(&out, &out))
+
+		// Act
+		serveErr := c.Run(context.Background(), []string{"serve"})
+		workerErr := c.Run(context.Background(), []string{"worker"})
+		migrateErr := c.Run(context.Background(), []string{"migrate"})
+
+		// Assert
+		require.ErrorContains(t, serveErr, `unknown command "serve"`)
+		require.ErrorContains(t, workerErr, `unknown command "worker"`)
+		require.ErrorContains(t, migrateErr, `unknown command "migrate"`)
+	})
+
+	t.Run("runs added commands", func(t *testing.T) {
+		// Arrange
+		var ran bool
+		c := cli.New(cli.App{Name: "orders"}).AddCommand(&cobra.Command{
+			Use: "backfill",
+			RunE: func(*cobra.Command, []string) error {
+				ran = true
+				return nil
+			},
+		})
+
+		// Act
+		err := c.Run(context.Background(), []string{"backfill"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, ran)
+	})
+}
+
+func TestCLI_Serve(t *testing.T) {
+	t.Run("runs the fx app until the context is canceled", func(t *testing.T) {
+		// Arrange
+		t.Setenv("APP_CONFIG_DIR", "")
+		t.Setenv("APP_ENV", "")
+		dir := writeLoggerConfig(t)
+		started := make(chan struct{})
+		var stopped bool
+		c := cli.New(cli.App{
+			Name: "orders",
+			Serve: []fx.Option{
+				fx.Invoke(func(lc fx.Lifecycle) {
+					lc.Append(fx.Hook{
+						OnStart: func(context.Context) error {
+							close(started)
+							return nil
+						},
+						OnStop: func(context.Context) error {
+							stopped = true
+							return nil
+						},
+					})
+				}),
+			},
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+
+		// Act
+		go func() {
+			errCh <- c.Run(ctx, []string{"serve", "--config-dir", dir, "--env", "test"})
+		}()
+		<-started
+		cancel()
+
+		// Assert
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("serve did not stop")
+		}
+		assert.True(t, stopped)
+		assert.Equal(t, dir, os.Getenv("APP_CONFIG_DIR"))
+		assert.Equal(t, "test", os.Getenv("APP_ENV"))
+	})
+
+	t.Run("returns an error when the app shuts down with a non-zero exit code", func(t *testing.T) {
+		// Arrange
+		t.Setenv("APP_CONFIG_DIR", writeLoggerConfig(t))
+		c := cli.New(cli.App{
+			Name: "orders",
+			Worker: []fx.Option{
+				fx.Invoke(func(shutdowner fx.Shutdowner) error {
+					return shutdowner.Shutdown(fx.ExitCode(3))
+				}),
+			},
+		})
+
+		// Act
+		err := c.Run(context.Background(), []string{"worker"})
+
+		// Assert
+		require.EqualError(t, err, "worker shut down with exit code 3")
+	})
+
+	t.Run("supplies the build information of the app", func(t *testing.T) {
+		// Arrange
+		t.Setenv("APP_CONFIG_DIR", writeLoggerConfig(t))
+		var info buildinfo.Info
+		c := cli.New(cli.App{
+			Name:    "orders",
+			Version: "1.2.3",
+			Worker: []fx.Option{
+				fx.Invoke(func(i buildinfo.Info, shutdowner fx.Shutdowner) error {
+					info = i
+					return shutdowner.Shutdown()
+				}),
+			},
+		})
+
+		// Act
+		err := c.Run(context.Background(), []string{"worker"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", info.Version)
+	})
+
+	t.Run("returns an error when the fx graph is invalid", func(t *testing.T) {
+		// Arrange
+		t.Setenv("APP_CONFIG_DIR", writeLoggerConfig(t))
+		c := cli.New(cli.App{
+			Name:  "orders",
+			Serve: []fx.Option{fx.Invoke(func(*bytes.Buffer) {})},
+		})
+
+		// Act
+		err := c.Run(context.Background(), []string{"serve"})
+
+		// Assert
+		require.ErrorContains(t, err, "build serve")
+	})
+}
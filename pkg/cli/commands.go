@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/buildinfo"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/migration"
+)
+
+func (c *CLI) versionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the version",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", c.app.Name, c.buildInfo())
+			return err
+		},
+	}
+}
+
+// buildInfo returns the build information of the app, the values of App overriding the
+// ones of buildinfo.Get.
+func (c *CLI) buildInfo() buildinfo.Info {
+	info := buildinfo.Get()
+	info.Version = valueOr(c.app.Version, info.Version)
+	info.Commit = valueOr(c.app.Commit, info.Commit)
+	info.Date = valueOr(c.app.BuildDate, info.Date)
+	return info
+}
+
+// fxCommand runs an fx application with logger.Module until the command context is done
+// or the application calls fx.Shutdowner. The build information is supplied to the
+// application and added to every log entry.
+func (c *CLI) fxCommand(name, short string, fxOptions []fx.Option) *cobra.Command {
+	return &cobra.Command{
+		Use:   name,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return c.runFx(cmd.Context(), name, fxOptions)
+		},
+	}
+}
+
+func (c *CLI) runFx(ctx context.Context, name string, fxOptions []fx.Option) error {
+	app := fx.New(
+		logger.Module,
+		logger.FxLogger,
+		fx.Supply(c.buildInfo()),
+		fx.Decorate(c.buildInfo().DecorateLogger),
+		fx.Options(fxOptions...),
+	)
+	if err := app.Err(); err != nil {
+		return fmt.Errorf("build %s: %w", name, err)
+	}
+
+	// A shutdown signal during start stops the app once it has started, not half way
+	startCtx, cancelStart := context.WithTimeout(context.WithoutCancel(ctx), c.options.startTimeout)
+	defer cancelStart()
+	if err := app.Start(startCtx); err != nil {
+		return fmt.Errorf("start %s: %w", name, err)
+	}
+
+	var exitCode int
+	select {
+	case <-ctx.Done():
+	case signal := <-app.Wait():
+		exitCode = signal.ExitCode
+	}
+
+	// The command context is already canceled on shutdown, stop hooks get their own deadline
+	stopCtx, cancelStop := context.WithTimeout(context.WithoutCancel(ctx), c.options.stopTimeout)
+	defer cancelStop()
+	if err := app.Stop(stopCtx); err != nil {
+		return fmt.Errorf("stop %s: %w", name, err)
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("%s shut down with exit code %d", name, exitCode)
+	}
+	return nil
+}
+
+func (c *CLI) migrateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply the pending database migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := config.New[database.Config](
+				append(slices.Clone(c.options.configOptions), config.WithPath(c.options.databaseConfigPath))...,
+			)
+			if err != nil {
+				return fmt.Errorf("load database config: %w", err)
+			}
+			dsn, err := cfg.Get().PostgresDSN()
+			if err != nil {
+				return fmt.Errorf("build dsn: %w", err)
+			}
+
+			if err = migration.NewRunner(c.app.Migrations).Up(dsn); err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "migrations applied")
+			return err
+		},
+	}
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
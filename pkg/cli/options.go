@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+const (
+	defaultStartTimeout = 15 * time.Second
+	defaultStopTimeout  = 15 * time.Second
+)
+
+type options struct {
+	startTimeout       time.Duration
+	stopTimeout        time.Duration
+	databaseConfigPath string
+	configOptions      []config.Option
+	out                io.Writer
+	err                io.Writer
+}
+
+// Option configures the CLI created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		startTimeout:       defaultStartTimeout,
+		stopTimeout:        defaultStopTimeout,
+		databaseConfigPath: "app.database",
+		out:                os.Stdout,
+		err:                os.Stderr,
+	}
+}
+
+// WithStartTimeout limits the time the serve and worker commands wait for the fx
+// start hooks. Defaults to 15s.
+func WithStartTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		if timeout > 0 {
+			o.startTimeout = timeout
+		}
+	}
+}
+
+// WithStopTimeout limits the time the serve and worker commands wait for the fx
+// stop hooks after a shutdown signal. Defaults to 15s.
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		if timeout > 0 {
+			o.stopTimeout = timeout
+		}
+	}
+}
+
+// WithDatabaseConfigPath sets the config path of the database.Config used by the migrate
+// command. Defaults to "app.database".
+func WithDatabaseConfigPath(path string) Option {
+	return func(o *options) {
+		o.databaseConfigPath = path
+	}
+}
+
+// WithConfigOptions sets the options of the database config load of the migrate command,
+// e.g. config.WithEnvPrefix("MYSVC_"). Pass them to the app with app.WithConfigOptions too.
+func WithConfigOptions(opts ...config.Option) Option {
+	return func(o *options) {
+		o.configOptions = append(o.configOptions, opts...)
+	}
+}
+
+// WithOutput sets the writers of the command output and errors. Defaults to stdout and stderr.
+func WithOutput(out, err io.Writer) Option {
+	return func(o *options) {
+		o.out = out
+		o.err = err
+	}
+}
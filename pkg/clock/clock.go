@@ -0,0 +1,83 @@
+package clock
+
+import "time"
+
+// Clock tells the time and creates timers. Components take a Clock instead of calling
+// the time package, so tests can control the time with a Fake.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// After returns a channel receiving the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer returns a Timer firing once d has elapsed.
+	NewTimer(d time.Duration) Timer
+	// NewTicker returns a Ticker firing every d. It panics if d is not positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a single event, like *time.Timer.
+type Timer interface {
+	// C returns the channel receiving the time when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer already fired
+	// or was stopped.
+	Stop() bool
+	// Reset changes the timer to fire once d has elapsed. It returns whether the timer
+	// was active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker delivers ticks at intervals, like *time.Ticker.
+type Ticker interface {
+	// C returns the channel receiving the ticks.
+	C() <-chan time.Time
+	// Stop turns off the ticker; no more ticks are sent.
+	Stop()
+	// Reset stops the ticker and resets its period to d.
+	Reset(d time.Duration)
+}
+
+// New returns the Clock of the time package.
+func New() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
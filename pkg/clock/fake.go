@@ -0,0 +1,191 @@
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves with Advance and Set. Timers and tickers fire
+// when the time reaches them, in deadline order; like the time package, a tick is
+// dropped when the previous one was not received.
+//
+//	clk := clock.NewFake(time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC))
+//	timer := clk.NewTimer(time.Minute)
+//	clk.Advance(time.Minute)
+//	<-timer.C() // 10:01
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// NewFake returns a Fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the time of the clock.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the time elapsed since t on the clock.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After returns a channel receiving the time of the clock once it advanced by d.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// NewTimer returns a Timer firing once the clock advanced by d.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	waiter := &fakeWaiter{clock: f, c: make(chan time.Time, 1)}
+	f.schedule(waiter, d)
+	return fakeTimer{waiter}
+}
+
+// NewTicker returns a Ticker firing every time the clock advanced by d.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	waiter := &fakeWaiter{clock: f, c: make(chan time.Time, 1), period: d}
+	f.schedule(waiter, d)
+	return fakeTicker{waiter}
+}
+
+// Advance moves the clock forward by d and fires the timers and tickers reached.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.fire()
+}
+
+// Set moves the clock to t and fires the timers and tickers reached. Setting an earlier
+// time fires nothing.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+	f.fire()
+}
+
+// Waiters returns the number of active timers and tickers, e.g. to wait until a
+// goroutine under test created its timer before advancing the clock.
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// schedule activates waiter to fire after d. f.mu is held.
+func (f *Fake) schedule(waiter *fakeWaiter, d time.Duration) {
+	waiter.deadline = f.now.Add(d)
+	if !slices.Contains(f.waiters, waiter) {
+		f.waiters = append(f.waiters, waiter)
+	}
+	f.fire()
+}
+
+// unschedule deactivates waiter and reports whether it was active. f.mu is held.
+func (f *Fake) unschedule(waiter *fakeWaiter) bool {
+	index := slices.Index(f.waiters, waiter)
+	if index < 0 {
+		return false
+	}
+	f.waiters = slices.Delete(f.waiters, index, index+1)
+	return true
+}
+
+// fire sends the time to the waiters whose deadline is reached, earliest first. f.mu
+// is held.
+func (f *Fake) fire() {
+	slices.SortStableFunc(f.waiters, func(a, b *fakeWaiter) int {
+		return a.deadline.Compare(b.deadline)
+	})
+	active := f.waiters[:0]
+	for _, waiter := range f.waiters {
+		if waiter.deadline.After(f.now) {
+			active = append(active, waiter)
+			continue
+		}
+		select {
+		case waiter.c <- f.now:
+		default:
+		}
+		if waiter.period > 0 {
+			for !waiter.deadline.After(f.now) {
+				waiter.deadline = waiter.deadline.Add(waiter.period)
+			}
+			active = append(active, waiter)
+		}
+	}
+	clear(f.waiters[len(active):])
+	f.waiters = active
+}
+
+// fakeWaiter is a timer, or a ticker when period is set, of a Fake clock.
+type fakeWaiter struct {
+	clock    *Fake
+	c        chan time.Time
+	deadline time.Time
+	period   time.Duration
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *fakeWaiter) stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.unschedule(w)
+}
+
+func (w *fakeWaiter) reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := slices.Contains(w.clock.waiters, w)
+	if w.period > 0 {
+		w.period = d
+	}
+	w.clock.schedule(w, d)
+	return active
+}
+
+type fakeTimer struct {
+	*fakeWaiter
+}
+
+func (t fakeTimer) Stop() bool {
+	return t.stop()
+}
+
+func (t fakeTimer) Reset(d time.Duration) bool {
+	return t.reset(d)
+}
+
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+func (t fakeTicker) Stop() {
+	t.stop()
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+	t.reset(d)
+}
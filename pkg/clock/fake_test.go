@@ -0,0 +1,148 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+var start = time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+
+func received(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFake(t *testing.T) {
+	t.Run("moves only with Advance and Set", func(t *testing.T) {
+		// Arrange
+		sut := clock.NewFake(start)
+
+		// Act
+		sut.Advance(time.Minute)
+		advanced := sut.Now()
+		sut.Set(start.Add(time.Hour))
+
+		// Assert
+		assert.Equal(t, start.Add(time.Minute), advanced)
+		assert.Equal(t, start.Add(time.Hour), sut.Now())
+		assert.Equal(t, time.Hour, sut.Since(start))
+	})
+
+	t.Run("fires a timer once its deadline is reached", func(t *testing.T) {
+		// Arrange
+		sut := clock.NewFake(start)
+		timer := sut.NewTimer(time.Minute)
+		after := sut.After(2 * time.Minute)
+
+		// Act
+		sut.Advance(30 * time.Second)
+		_, early := received(timer.C())
+		sut.Advance(time.Minute)
+
+		// Assert
+		assert.False(t, early)
+		fired, ok := received(timer.C())
+		require.True(t, ok)
+		assert.Equal(t, start.Add(90*time.Second), fired)
+		_, afterFired := received(after)
+		assert.False(t, afterFired)
+		assert.Equal(t, 1, sut.Waiters())
+	})
+
+	t.Run("does not fire a stopped timer", func(t *testing.T) {
+		// Arrange
+		sut := clock.NewFake(start)
+		timer := sut.NewTimer(time.Minute)
+
+		// Act
+		stopped := timer.Stop()
+		sut.Advance(time.Minute)
+
+		// Assert
+		assert.True(t, stopped)
+		assert.False(t, timer.Stop())
+		_, fired := received(timer.C())
+		assert.False(t, fired)
+		assert.Zero(t, sut.Waiters())
+	})
+
+	t.Run("resets a timer from the current time", func(t *testing.T) {
+		// Arrange
+		sut := clock.NewFake(start)
+		timer := sut.NewTimer(time.Minute)
+		sut.Advance(30 * time.Second)
+
+		// Act
+		active := timer.Reset(time.Minute)
+		sut.Advance(45 * time.Second)
+		_, early := received(timer.C())
+		sut.Advance(15 * time.Second)
+
+		// Assert
+		assert.True(t, active)
+		assert.False(t, early)
+		fired, ok := received(timer.C())
+		require.True(t, ok)
+		assert.Equal(t, start.Add(90*time.Second), fired)
+	})
+
+	t.Run("ticks every period and drops the ticks not received", func(t *testing.T) {
+		// Arrange
+		sut := clock.NewFake(start)
+		ticker := sut.NewTicker(time.Minute)
+
+		// Act
+		sut.Advance(time.Minute)
+		sut.Advance(time.Minute)
+		first, ok := received(ticker.C())
+		_, dropped := received(ticker.C())
+		sut.Advance(time.Minute)
+		third, thirdOK := received(ticker.C())
+		ticker.Stop()
+		sut.Advance(time.Minute)
+
+		// Assert
+		require.True(t, ok)
+		assert.Equal(t, start.Add(time.Minute), first)
+		assert.False(t, dropped)
+		require.True(t, thirdOK)
+		assert.Equal(t, start.Add(3*time.Minute), third)
+		_, afterStop := received(ticker.C())
+		assert.False(t, afterStop)
+	})
+
+	t.Run("panics on a non-positive ticker interval", func(t *testing.T) {
+		// Arrange
+		sut := clock.NewFake(start)
+
+		// Act & Assert
+		assert.Panics(t, func() { sut.NewTicker(0) })
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("returns the time package clock", func(t *testing.T) {
+		// Arrange
+		sut := clock.New()
+
+		// Act
+		timer := sut.NewTimer(time.Millisecond)
+
+		// Assert
+		assert.WithinDuration(t, time.Now(), sut.Now(), time.Second)
+		select {
+		case <-timer.C():
+		case <-time.After(time.Second):
+			t.Fatal("timer did not fire")
+		}
+	})
+}
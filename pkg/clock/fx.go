@@ -0,0 +1,11 @@
+package clock
+
+import "go.uber.org/fx"
+
+// Module provides the Clock of the time package. Tests replace it with a Fake:
+//
+//	fx.Replace(fx.Annotate(clock.NewFake(start), fx.As(new(clock.Clock))))
+var Module = fx.Module(
+	"clock",
+	fx.Provide(New),
+)
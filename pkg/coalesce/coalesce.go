@@ -0,0 +1,154 @@
+package coalesce
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+// sweepInterval is how often the expired results are dropped.
+const sweepInterval = time.Minute
+
+// Group deduplicates the concurrent calls of an expensive operation with the same key, e.g.
+// a database query or an upstream HTTP call: the first call runs the function, the calls
+// arriving meanwhile wait for it and share its result.
+type Group[T any] struct {
+	name    string
+	ttl     time.Duration
+	timeout time.Duration
+	clock   clock.Clock
+	metrics *groupMetrics
+
+	mu        sync.Mutex
+	calls     map[string]*call[T]
+	results   map[string]result[T]
+	nextSweep time.Time
+}
+
+// call is a running function call.
+type call[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// result is a shared successful result.
+type result[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// NewGroup creates a Group; name labels its metrics, e.g. "product_prices".
+func NewGroup[T any](name string, opts ...Option) (*Group[T], error) {
+	if name == "" {
+		return nil, ErrMissingName
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	metrics, err := newGroupMetrics(options.registerer)
+	if err != nil {
+		return nil, fmt.Errorf("coalesce: register metrics: %w", err)
+	}
+	return &Group[T]{
+		name:    name,
+		ttl:     options.ttl,
+		timeout: options.timeout,
+		clock:   options.clock,
+		metrics: metrics,
+		calls:   make(map[string]*call[T]),
+		results: make(map[string]result[T]),
+	}, nil
+}
+
+// Do returns the result of fn for key, running fn only when no call of key is running and,
+// with WithTTL, no result of key is shared.
+//
+// fn runs with a context keeping the values of the ctx of the first caller but not its
+// cancellation, so a caller going away does not fail the others. Each caller stops waiting
+// when its own ctx is done, returning its error. A panic of fn is returned to the callers
+// as ErrPanic.
+//
+//	prices, err := group.Do(ctx, "prices:"+sku, func(ctx context.Context) ([]Price, error) {
+//	    return repo.FindPrices(ctx, sku)
+//	})
+func (g *Group[T]) Do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	g.mu.Lock()
+	now := g.clock.Now()
+	g.sweep(now)
+	if shared, ok := g.results[key]; ok && now.Before(shared.expiresAt) {
+		g.mu.Unlock()
+		g.metrics.observe(g.name, resultCached)
+		return shared.value, nil
+	}
+	running, ok := g.calls[key]
+	if ok {
+		g.mu.Unlock()
+		g.metrics.observe(g.name, resultShared)
+		return g.wait(ctx, running)
+	}
+	running = &call[T]{done: make(chan struct{})}
+	g.calls[key] = running
+	g.mu.Unlock()
+
+	g.metrics.observe(g.name, resultExecuted)
+	go g.run(context.WithoutCancel(ctx), key, running, fn)
+	return g.wait(ctx, running)
+}
+
+// Forget drops the shared result of key, so the next call runs the function, e.g. after
+// the data changed. A running call is not affected.
+func (g *Group[T]) Forget(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.results, key)
+}
+
+func (g *Group[T]) run(ctx context.Context, key string, running *call[T], fn func(context.Context) (T, error)) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			running.err = fmt.Errorf("%w: %s: %v", ErrPanic, key, recovered)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		if running.err == nil && g.ttl > 0 {
+			g.results[key] = result[T]{value: running.value, expiresAt: g.clock.Now().Add(g.ttl)}
+		}
+		g.mu.Unlock()
+		close(running.done)
+	}()
+
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+	running.value, running.err = fn(ctx)
+}
+
+func (g *Group[T]) wait(ctx context.Context, running *call[T]) (T, error) {
+	select {
+	case <-running.done:
+		return running.value, running.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// sweep drops the expired results; g.mu must be held.
+func (g *Group[T]) sweep(now time.Time) {
+	if now.Before(g.nextSweep) {
+		return
+	}
+	for key, shared := range g.results {
+		if !now.Before(shared.expiresAt) {
+			delete(g.results, key)
+		}
+	}
+	g.nextSweep = now.Add(sweepInterval)
+}
@@ -0,0 +1,212 @@
+package coalesce_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/coalesce"
+)
+
+type GroupTestSuite struct {
+	suite.Suite
+	sut      *coalesce.Group[string]
+	clock    *clock.Fake
+	registry *prometheus.Registry
+	calls    atomic.Int32
+}
+
+func TestGroupSuite(t *testing.T) {
+	suite.Run(t, new(GroupTestSuite))
+}
+
+func (s *GroupTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	s.registry = prometheus.NewRegistry()
+	s.calls.Store(0)
+	var err error
+	s.sut, err = coalesce.NewGroup[string]("prices",
+		coalesce.WithTTL(time.Minute),
+		coalesce.WithClock(s.clock),
+		coalesce.WithRegisterer(s.registry),
+	)
+	s.Require().NoError(err)
+}
+
+func (s *GroupTestSuite) fetch(value string) func(context.Context) (string, error) {
+	return func(context.Context) (string, error) {
+		s.calls.Add(1)
+		return value, nil
+	}
+}
+
+func (s *GroupTestSuite) count(result string) float64 {
+	families, err := s.registry.Gather()
+	s.Require().NoError(err)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if metric.GetLabel()[1].GetValue() == result {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func (s *GroupTestSuite) TestDo_ConcurrentCalls_RunTheFunctionOnce() {
+	// Arrange
+	release := make(chan struct{})
+	fn := func(context.Context) (string, error) {
+		s.calls.Add(1)
+		<-release
+		return "9.90", nil
+	}
+	const callers = 10
+	var wg sync.WaitGroup
+	values := make([]string, callers)
+
+	// Act
+	for i := range callers {
+		wg.Go(func() {
+			values[i], _ = s.sut.Do(context.Background(), "sku-1", fn)
+		})
+	}
+	s.Eventually(func() bool { return s.count("shared") == callers-1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assert
+	s.Equal(int32(1), s.calls.Load())
+	for _, value := range values {
+		s.Equal("9.90", value)
+	}
+	s.InDelta(1, s.count("executed"), 0)
+}
+
+func (s *GroupTestSuite) TestDo_SharesTheResultForTheTTL() {
+	// Arrange
+	_, err := s.sut.Do(context.Background(), "sku-1", s.fetch("9.90"))
+	s.Require().NoError(err)
+	s.clock.Advance(59 * time.Second)
+
+	// Act
+	cached, err := s.sut.Do(context.Background(), "sku-1", s.fetch("10.90"))
+	s.clock.Advance(time.Second)
+	expired, expiredErr := s.sut.Do(context.Background(), "sku-1", s.fetch("10.90"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().NoError(expiredErr)
+	s.Equal("9.90", cached)
+	s.Equal("10.90", expired)
+	s.Equal(int32(2), s.calls.Load())
+	s.InDelta(1, s.count("cached"), 0)
+}
+
+func (s *GroupTestSuite) TestDo_DoesNotShareErrors() {
+	// Arrange
+	fetchErr := errors.New("database unavailable")
+	_, err := s.sut.Do(context.Background(), "sku-1", func(context.Context) (string, error) {
+		return "", fetchErr
+	})
+
+	// Act
+	value, retryErr := s.sut.Do(context.Background(), "sku-1", s.fetch("9.90"))
+
+	// Assert
+	s.Require().ErrorIs(err, fetchErr)
+	s.Require().NoError(retryErr)
+	s.Equal("9.90", value)
+}
+
+func (s *GroupTestSuite) TestDo_CanceledCaller_DoesNotCancelTheOthers() {
+	// Arrange
+	release := make(chan struct{})
+	var fnErr error
+	fn := func(ctx context.Context) (string, error) {
+		<-release
+		fnErr = ctx.Err()
+		return "9.90", nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := s.sut.Do(ctx, "sku-1", fn)
+		firstErr <- err
+	}()
+	s.Eventually(func() bool { return s.count("executed") == 1 }, time.Second, time.Millisecond)
+	second := make(chan string, 1)
+	go func() {
+		value, _ := s.sut.Do(context.Background(), "sku-1", fn)
+		second <- value
+	}()
+	s.Eventually(func() bool { return s.count("shared") == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	cancel()
+	err := <-firstErr
+	close(release)
+
+	// Assert
+	s.Require().ErrorIs(err, context.Canceled)
+	s.Equal("9.90", <-second)
+	s.NoError(fnErr)
+}
+
+func (s *GroupTestSuite) TestDo_Panic_ReturnsErrPanic() {
+	// Act
+	_, err := s.sut.Do(context.Background(), "sku-1", func(context.Context) (string, error) {
+		panic("boom")
+	})
+
+	// Assert
+	s.Require().ErrorIs(err, coalesce.ErrPanic)
+}
+
+func (s *GroupTestSuite) TestForget_DropsTheSharedResult() {
+	// Arrange
+	_, err := s.sut.Do(context.Background(), "sku-1", s.fetch("9.90"))
+	s.Require().NoError(err)
+
+	// Act
+	s.sut.Forget("sku-1")
+	value, err := s.sut.Do(context.Background(), "sku-1", s.fetch("10.90"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("10.90", value)
+}
+
+func (s *GroupTestSuite) TestNewGroup_WithoutName_ReturnsAnError() {
+	// Act
+	_, err := coalesce.NewGroup[string]("")
+
+	// Assert
+	s.Require().ErrorIs(err, coalesce.ErrMissingName)
+}
+
+func TestWithTimeout_BoundsTheSharedCall(t *testing.T) {
+	// Arrange
+	group, err := coalesce.NewGroup[int]("reports",
+		coalesce.WithTimeout(time.Millisecond),
+		coalesce.WithRegisterer(prometheus.NewRegistry()),
+	)
+	require.NoError(t, err)
+
+	// Act
+	_, err = group.Do(context.Background(), "daily", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	// Assert
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,10 @@
+package coalesce
+
+import "errors"
+
+var (
+	// ErrPanic is returned to the callers of a function that panicked
+	ErrPanic = errors.New("coalesce: function panicked")
+	// ErrMissingName is returned by NewGroup without a group name
+	ErrMissingName = errors.New("coalesce: group name is required")
+)
@@ -0,0 +1,37 @@
+package coalesce
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	callsMetricName = "coalesce_calls_total"
+
+	resultExecuted = "executed"
+	resultShared   = "shared"
+	resultCached   = "cached"
+)
+
+type groupMetrics struct {
+	calls *prometheus.CounterVec
+}
+
+func newGroupMetrics(registerer prometheus.Registerer) (*groupMetrics, error) {
+	calls, err := metrics.Register(registerer, prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: callsMetricName,
+			Help: "Total coalesced calls by group and result (executed, shared, cached)",
+		},
+		[]string{"group", "result"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &groupMetrics{calls: calls}, nil
+}
+
+func (m *groupMetrics) observe(group, result string) {
+	m.calls.WithLabelValues(group, result).Inc()
+}
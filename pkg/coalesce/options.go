@@ -0,0 +1,62 @@
+package coalesce
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+type options struct {
+	ttl        time.Duration
+	timeout    time.Duration
+	clock      clock.Clock
+	registerer prometheus.Registerer
+}
+
+// Option configures the Group created by NewGroup.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		clock:      clock.New(),
+		registerer: prometheus.DefaultRegisterer,
+	}
+}
+
+// WithTTL shares the successful results of a key for ttl after the call, so the calls of
+// the key in the next ttl return the result without running the function. Without it,
+// only the concurrent calls are shared.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithTimeout bounds the shared calls: the function runs detached from the cancellation of
+// its callers (see Group.Do), so it only stops at this timeout. Default: no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithClock sets the clock of the TTLs.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithRegisterer sets the registerer the call metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
@@ -1,26 +1,54 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/v2"
 )
 
-const envValuePrefix = "env://"
+const (
+	envValuePrefix      = "env://"
+	defaultEnvPrefix    = "APP_"
+	defaultEnvDelimiter = "__"
+)
 
 type Config[T any] struct {
-	value T
+	value      T
+	provenance map[string]Provenance
+	values     map[string]any
 }
 
 func (v Config[T]) Get() T {
 	return v.value
 }
 
+// Of returns a Config holding value, for configs built in code and tests of constructors
+// taking a Config[T]. It has no provenance nor untyped values.
+func Of[T any](value T) Config[T] {
+	return Config[T]{value: value}
+}
+
+// Lookup returns the value of a flattened key (e.g. "database.host"), relative to the path
+// the config was loaded from, for untyped access next to Get.
+func (v Config[T]) Lookup(key string) (any, bool) {
+	value, ok := v.values[key]
+	return value, ok
+}
+
+// Keys returns the flattened keys of the config, sorted.
+func (v Config[T]) Keys() []string {
+	return slices.Sorted(maps.Keys(v.values))
+}
+
 // Option customizes config loading behavior.
 type Option func(*loadOptions)
 
@@ -31,32 +59,86 @@ func WithPath(path string) Option {
 	}
 }
 
+// WithEnvPrefix sets the prefix of the environment variables read by the loader, default: APP_.
+// It applies to the environment (<prefix>ENV), the config directory (<prefix>CONFIG_DIR) and
+// the overrides of config keys (see WithEnvDelimiter).
+func WithEnvPrefix(prefix string) Option {
+	return func(opts *loadOptions) {
+		opts.envPrefix = prefix
+	}
+}
+
+// WithEnvDelimiter sets the separator of nested keys in override variable names, default: __.
+// With the defaults, APP_APP__DATABASE__HOST overrides app.database.host.
+func WithEnvDelimiter(delimiter string) Option {
+	return func(opts *loadOptions) {
+		opts.envDelimiter = delimiter
+	}
+}
+
+// WithDotEnv loads the .env and .env.<environment> files of the working directory before
+// reading the environment. Variables already set in the process are kept, and
+// .env.<environment> wins over .env, so the committed defaults never override real values.
+func WithDotEnv() Option {
+	return func(opts *loadOptions) {
+		opts.dotEnv = true
+	}
+}
+
 type loadOptions struct {
-	keyPath string
+	keyPath      string
+	envPrefix    string
+	envDelimiter string
+	dotEnv       bool
+	layers       []Layer
 }
 
 // New loads and unmarshals configuration into T.
 // Environment is always resolved automatically using APP_ENV (default: local).
 // Config directory is resolved using APP_CONFIG_DIR (default: config).
+// The remote layers of WithLayer merge over the YAML files. Environment variables then
+// override config keys: APP_<KEY> with nested keys separated by __ for keys present in the
+// YAML files, and the variables named by `env` struct tags of T.
 //
 // Example:
 //
 //	cfg, err := config.New[DatabaseConfig](config.WithPath("app.database"))
 func New[T any](options ...Option) (Config[T], error) {
+	return load[T](context.Background(), options)
+}
+
+// load is New with the context of the remote layers.
+func load[T any](ctx context.Context, options []Option) (Config[T], error) {
 	var result T
 	opts := resolveOptions(options)
-	environment := getEnvironment()
-	configDir, configErr := getConfigDir()
+	if opts.dotEnv {
+		if err := loadDotEnv(".", opts.envPrefix); err != nil {
+			return Config[T]{}, fmt.Errorf("failed to load .env files: %w", err)
+		}
+	}
+	environment := getEnvironment(opts.envPrefix)
+	configDir, configErr := getConfigDir(opts.envPrefix)
 	if configErr != nil {
 		return Config[T]{}, configErr
 	}
-	k, err := loadKoanf(configDir, environment)
+	tracker := make(provenanceTracker)
+	k, err := loadKoanf(configDir, environment, tracker)
 	if err != nil {
 		return Config[T]{}, fmt.Errorf("failed to create config (env=%s): %w", environment, err)
 	}
+	if layerErr := loadLayers(ctx, k, opts.layers, tracker); layerErr != nil {
+		return Config[T]{}, fmt.Errorf("failed to apply config layers (env=%s): %w", environment, layerErr)
+	}
+	if envErr := applyEnvOverrides(k, opts.envPrefix, opts.envDelimiter, tracker); envErr != nil {
+		return Config[T]{}, fmt.Errorf("failed to apply env overrides (env=%s): %w", environment, envErr)
+	}
+	if envErr := applyEnvTags(k, opts.keyPath, reflect.TypeFor[T](), tracker); envErr != nil {
+		return Config[T]{}, fmt.Errorf("failed to apply env tags (env=%s): %w", environment, envErr)
+	}
+	provenance, values := tracker.build(k, opts.keyPath)
 	if opts.keyPath != "" {
 		if !k.Exists(opts.keyPath) {
-			return Config[T]{}, fmt.Errorf("config key '%s' not found", opts.keyPath)
+			return Config[T]{}, fmt.Errorf("%w: '%s'", ErrKeyNotFound, opts.keyPath)
 		}
 		if unmarshalErr := unmarshalKey(k, opts.keyPath, &result); unmarshalErr != nil {
 			return Config[T]{}, fmt.Errorf(
@@ -66,34 +148,35 @@ func New[T any](options ...Option) (Config[T], error) {
 				unmarshalErr,
 			)
 		}
-		return Config[T]{value: result}, nil
+		return Config[T]{value: result, provenance: provenance, values: values}, nil
 	}
 	if unmarshalErr := unmarshalKey(k, "", &result); unmarshalErr != nil {
 		return Config[T]{}, fmt.Errorf("failed to unmarshal config (env=%s): %w", environment, unmarshalErr)
 	}
-	return Config[T]{value: result}, nil
+	return Config[T]{value: result, provenance: provenance, values: values}, nil
 }
 
 // Internal helpers.
-func loadKoanf(configDir, environment string) (*koanf.Koanf, error) {
+func loadKoanf(configDir, environment string, tracker provenanceTracker) (*koanf.Koanf, error) {
 	environment = strings.ToLower(strings.TrimSpace(environment))
 
 	k := koanf.New(".")
 
 	// Load base configuration first
-	if err := loadConfigFile(k, configDir, "base"); err != nil {
+	if err := loadConfigFile(k, configDir, "base", tracker); err != nil {
 		return nil, fmt.Errorf("failed to load base config: %w", err)
 	}
 
 	// Load environment-specific configuration (optional)
-	if err := loadConfigFile(k, configDir, environment); err != nil && !errors.Is(err, ErrConfigFileNotFound) {
+	err := loadConfigFile(k, configDir, environment, tracker)
+	if err != nil && !errors.Is(err, ErrConfigFileNotFound) {
 		return nil, fmt.Errorf("failed to load %s.yaml config: %w", environment, err)
 	}
 
 	return k, nil
 }
 
-func loadConfigFile(k *koanf.Koanf, configDir, name string) error {
+func loadConfigFile(k *koanf.Koanf, configDir, name string, tracker provenanceTracker) error {
 	configPath := filepath.Join(configDir, name+".yaml")
 
 	// Check if file exists
@@ -111,10 +194,26 @@ func loadConfigFile(k *koanf.Koanf, configDir, name string) error {
 		return fmt.Errorf("failed to parse config file %s: %w", configPath, err)
 	}
 
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve config file path %s: %w", configPath, err)
+	}
+	data, origins, err := resolveIncludes(data, filepath.Dir(absPath), []string{absPath})
+	if err != nil {
+		return fmt.Errorf("failed to resolve includes for config file %s: %w", configPath, err)
+	}
+	refs := envRefs(data)
+
 	resolvedData, err := resolveEnvValues(data)
 	if err != nil {
 		return fmt.Errorf("failed to resolve env values for config file %s: %w", configPath, err)
 	}
+	for key, origin := range origins {
+		if relative, relErr := filepath.Rel(configDir, origin); relErr == nil {
+			origins[key] = relative
+		}
+	}
+	tracker.recordFile(resolvedData, name+".yaml", origins, refs)
 
 	if loadErr := k.Load(&yamlProvider{data: resolvedData}, nil); loadErr != nil {
 		return fmt.Errorf("failed to load config file %s: %w", configPath, loadErr)
@@ -183,29 +282,35 @@ func unmarshalKey(k *koanf.Koanf, key string, target interface{}) error {
 }
 
 func resolveOptions(options []Option) loadOptions {
-	opts := loadOptions{}
+	opts := loadOptions{
+		envPrefix:    defaultEnvPrefix,
+		envDelimiter: defaultEnvDelimiter,
+	}
 	for _, option := range options {
 		if option != nil {
 			option(&opts)
 		}
 	}
+	if opts.envDelimiter == "" {
+		opts.envDelimiter = defaultEnvDelimiter
+	}
 	return opts
 }
 
-func getEnvironment() string {
-	if env := os.Getenv("APP_ENV"); env != "" {
+func getEnvironment(envPrefix string) string {
+	if env := os.Getenv(envPrefix + "ENV"); env != "" {
 		return strings.ToLower(strings.TrimSpace(env))
 	}
 	return "local"
 }
 
-func getConfigDir() (string, error) {
+func getConfigDir(envPrefix string) (string, error) {
 	rootDir, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("failed to resolve root directory: %w", err)
 	}
 
-	configDir := strings.TrimSpace(os.Getenv("APP_CONFIG_DIR"))
+	configDir := strings.TrimSpace(os.Getenv(envPrefix + "CONFIG_DIR"))
 	if configDir == "" {
 		configDir = "config"
 	}
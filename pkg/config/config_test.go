@@ -1061,3 +1061,33 @@ app:
 		assert.Equal(t, 5432, cfg.Get().App.Database.Port)                  // Remains from base (not overridden)
 	})
 }
+
+func TestOf(t *testing.T) {
+	t.Run("should hold the value without loading", func(t *testing.T) {
+		// Act
+		cfg := config.Of(TestConfig{})
+
+		// Assert
+		assert.Equal(t, TestConfig{}, cfg.Get())
+		assert.Empty(t, cfg.Keys())
+	})
+}
+
+func TestLookup(t *testing.T) {
+	t.Run("should expose untyped values next to the typed value", func(t *testing.T) {
+		// Arrange
+		t.Setenv("APP_ENV", "local")
+
+		// Act
+		cfg, err := loadConfig[TestConfig](testConfigDir)
+		require.NoError(t, err)
+		host, ok := cfg.Lookup("app.database.host")
+		_, missing := cfg.Lookup("app.database.missing")
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, cfg.Get().App.Database.Host, host)
+		assert.False(t, missing)
+		assert.Contains(t, cfg.Keys(), "app.database.host")
+	})
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const dotEnvFile = ".env"
+
+// loadDotEnv sets the variables of the dir/.env.<environment> and dir/.env files that are not
+// already set, so the process environment wins over .env.<environment>, which wins over .env.
+// The environment is read after .env, which may set it. Missing files are skipped.
+func loadDotEnv(dir, envPrefix string) error {
+	base, err := readDotEnv(filepath.Join(dir, dotEnvFile))
+	if err != nil {
+		return err
+	}
+
+	environment := os.Getenv(envPrefix + "ENV")
+	if environment == "" {
+		environment = base[envPrefix+"ENV"]
+	}
+	environment = strings.ToLower(strings.TrimSpace(environment))
+	if environment == "" {
+		environment = "local"
+	}
+	specific, err := readDotEnv(filepath.Join(dir, dotEnvFile+"."+environment))
+	if err != nil {
+		return err
+	}
+
+	for _, values := range []map[string]string{specific, base} {
+		for name, value := range values {
+			if _, set := os.LookupEnv(name); set {
+				continue
+			}
+			if setErr := os.Setenv(name, value); setErr != nil {
+				return fmt.Errorf("failed to set %s: %w", name, setErr)
+			}
+		}
+	}
+	return nil
+}
+
+func readDotEnv(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- path is the .env file of the working directory
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	values, err := parseDotEnv(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// parseDotEnv parses KEY=VALUE lines. Blank lines, # comments and an export prefix are
+// ignored; double-quoted values support escapes such as \n, single-quoted values are
+// literal, and unquoted values end at an inline " #" comment.
+func parseDotEnv(content []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("%w: line %d", ErrInvalidDotEnv, lineNumber)
+		}
+
+		parsed, err := parseDotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidDotEnv, lineNumber, err)
+		}
+		values[name] = parsed
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func parseDotEnvValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := closingQuote(value)
+		if end < 0 {
+			return "", errors.New("unterminated double quote")
+		}
+		return strconv.Unquote(value[:end+1])
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", errors.New("unterminated single quote")
+		}
+		return value[1 : end+1], nil
+	default:
+		if comment := strings.Index(value, " #"); comment >= 0 {
+			value = value[:comment]
+		}
+		return strings.TrimSpace(value), nil
+	}
+}
+
+// closingQuote returns the index of the unescaped double quote closing value, or -1.
+func closingQuote(value string) int {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
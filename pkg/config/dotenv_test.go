@@ -0,0 +1,115 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unsetAfter unsets the variables set by the .env loading, which t.Setenv does not track.
+func unsetAfter(t *testing.T, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		_, set := os.LookupEnv(name)
+		require.False(t, set, "%s must not be set by the test environment", name)
+	}
+	t.Cleanup(func() {
+		for _, name := range names {
+			_ = os.Unsetenv(name)
+		}
+	})
+}
+
+func TestParseDotEnv(t *testing.T) {
+	t.Run("should parse values, quotes, comments and export", func(t *testing.T) {
+		// Arrange
+		content := []byte(`
+# database
+DB_HOST=localhost
+export DB_PORT = 5432
+DB_NAME=app # inline comment
+DB_PASSWORD="p#ss \"quoted\"\nline"
+DB_USER='literal \n'
+EMPTY=
+`)
+
+		// Act
+		values, err := config.ParseDotEnv(content)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"DB_HOST":     "localhost",
+			"DB_PORT":     "5432",
+			"DB_NAME":     "app",
+			"DB_PASSWORD": "p#ss \"quoted\"\nline",
+			"DB_USER":     `literal \n`,
+			"EMPTY":       "",
+		}, values)
+	})
+
+	t.Run("should return an error for invalid lines", func(t *testing.T) {
+		for _, content := range []string{"NO_EQUALS", "=value", `QUOTE="open`, "BAD KEY=1"} {
+			// Act
+			_, err := config.ParseDotEnv([]byte(content))
+
+			// Assert
+			require.ErrorIs(t, err, config.ErrInvalidDotEnv, content)
+		}
+	})
+}
+
+func TestLoadDotEnv(t *testing.T) {
+	t.Run("should keep process values and prefer the environment file", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"),
+			[]byte("APP_ENV=staging\nDOTENV_HOST=base\nDOTENV_PORT=1\nDOTENV_USER=base\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".env.staging"),
+			[]byte("DOTENV_HOST=staging\n"), 0644))
+		unsetAfter(t, "APP_ENV", "DOTENV_HOST", "DOTENV_USER")
+		t.Setenv("DOTENV_PORT", "2")
+
+		// Act
+		err := config.LoadDotEnv(dir, "APP_")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "staging", os.Getenv("APP_ENV"))
+		assert.Equal(t, "staging", os.Getenv("DOTENV_HOST"))
+		assert.Equal(t, "2", os.Getenv("DOTENV_PORT"))
+		assert.Equal(t, "base", os.Getenv("DOTENV_USER"))
+	})
+
+	t.Run("should skip missing files", func(t *testing.T) {
+		// Act
+		err := config.LoadDotEnv(t.TempDir(), "APP_")
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
+
+func TestWithDotEnv(t *testing.T) {
+	t.Run("should resolve env references from the .env file", func(t *testing.T) {
+		// Arrange
+		tmpDir := tempConfigDir(t)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "base.yaml"),
+			[]byte("app:\n  database:\n    host: env://DOTENV_DB_HOST\n"), 0644))
+		require.NoError(t, os.WriteFile(".env", []byte("DOTENV_DB_HOST=db.local\n"), 0644))
+		t.Cleanup(func() { _ = os.Remove(".env") })
+		unsetAfter(t, "DOTENV_DB_HOST")
+		t.Setenv("APP_ENV", "local")
+
+		// Act
+		cfg, err := loadConfig[TestConfig](tmpDir, config.WithDotEnv())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "db.local", cfg.Get().App.Database.Host)
+	})
+}
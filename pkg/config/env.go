@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+)
+
+const (
+	configTag = "config"
+	envTag    = "env"
+)
+
+// applyEnvOverrides sets the config keys named by the variables <prefix><KEY>, where KEY is
+// the key path in upper case with delimiter between the nested keys. Only leaf keys present
+// in the YAML files are overridden, so unrelated variables sharing the prefix (e.g. APP_ENV)
+// are ignored. The names are matched against the keys, so a delimiter the keys contain, e.g.
+// _ in max_conns, still addresses them; a variable naming two keys, e.g. APP_A_B_C with
+// a.b_c and a_b.c, fails with ErrAmbiguousEnvOverride.
+func applyEnvOverrides(k *koanf.Koanf, prefix, delimiter string, tracker provenanceTracker) error {
+	leaves := make(map[string][]string)
+	for _, key := range k.Keys() {
+		name := strings.ToUpper(strings.ReplaceAll(key, ".", delimiter))
+		leaves[name] = append(leaves[name], key)
+	}
+
+	for _, entry := range os.Environ() {
+		name, value, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		keys := leaves[strings.ToUpper(strings.TrimPrefix(name, prefix))]
+		if len(keys) == 0 {
+			continue
+		}
+		if len(keys) > 1 {
+			return fmt.Errorf("%w: %s names %s", ErrAmbiguousEnvOverride, name, strings.Join(keys, ", "))
+		}
+		if err := k.Set(keys[0], value); err != nil {
+			return fmt.Errorf("failed to override %s from %s: %w", keys[0], name, err)
+		}
+		tracker.record(Provenance{Key: keys[0], Value: value, Source: SourceEnv, Variable: name})
+	}
+	return nil
+}
+
+// applyEnvTags sets the config keys of the fields of t tagged `env:"VAR"` to the value of VAR
+// when it is set, whether or not the key is present in the YAML files. keyPath is the path
+// t is unmarshaled from.
+func applyEnvTags(k *koanf.Koanf, keyPath string, t reflect.Type, tracker provenanceTracker) error {
+	return walkEnvTags(t, keyPath, make(map[reflect.Type]bool), func(key, variable string) error {
+		value, ok := os.LookupEnv(variable)
+		if !ok {
+			return nil
+		}
+		if err := k.Set(key, value); err != nil {
+			return fmt.Errorf("failed to set %s from %s: %w", key, variable, err)
+		}
+		tracker.record(Provenance{Key: key, Value: value, Source: SourceEnv, Variable: variable})
+		return nil
+	})
+}
+
+func walkEnvTags(
+	t reflect.Type,
+	path string,
+	visiting map[reflect.Type]bool,
+	set func(key, variable string) error,
+) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get(configTag), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		key := name
+		if path != "" {
+			key = path + "." + name
+		}
+
+		if variable := field.Tag.Get(envTag); variable != "" {
+			if err := set(key, variable); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := walkEnvTags(field.Type, key, visiting, set); err != nil {
+			return err
+		}
+	}
+	return nil
+}
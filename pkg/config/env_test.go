@@ -0,0 +1,143 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envOverrideConfig = `
+app:
+  name: "EnvApp"
+  port: 3000
+  database:
+    host: "localhost"
+    timeout: 5s
+`
+
+type EnvConfig struct {
+	Name     string `config:"name"`
+	Port     int    `config:"port"`
+	Database struct {
+		Host    string        `config:"host"`
+		URL     string        `config:"url" env:"DATABASE_URL"`
+		Timeout time.Duration `config:"timeout"`
+	} `config:"database"`
+}
+
+func writeEnvConfig(t *testing.T) string {
+	t.Helper()
+	tmpDir := tempConfigDir(t)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "base.yaml"), []byte(envOverrideConfig), 0644))
+	t.Setenv("APP_ENV", "local")
+	return tmpDir
+}
+
+func TestEnvOverrides(t *testing.T) {
+	t.Run("should override existing keys with the default prefix and delimiter", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeEnvConfig(t)
+		t.Setenv("APP_APP__PORT", "8080")
+		t.Setenv("APP_APP__DATABASE__TIMEOUT", "30s")
+		t.Setenv("APP_APP__UNKNOWN", "ignored")
+
+		// Act
+		cfg, err := loadConfig[EnvConfig](tmpDir, config.WithPath("app"))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 8080, cfg.Get().Port)
+		assert.Equal(t, 30*time.Second, cfg.Get().Database.Timeout)
+		assert.Equal(t, "EnvApp", cfg.Get().Name)
+	})
+
+	t.Run("should use a custom prefix and delimiter", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeEnvConfig(t)
+		t.Setenv("MYSVC_CONFIG_DIR", normalizeConfigDir(tmpDir))
+		t.Setenv("MYSVC_APP_DATABASE_HOST", "db.internal")
+		t.Setenv("APP_APP__DATABASE__HOST", "ignored")
+
+		// Act
+		cfg, err := config.New[EnvConfig](
+			config.WithPath("app"),
+			config.WithEnvPrefix("MYSVC_"),
+			config.WithEnvDelimiter("_"),
+		)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "db.internal", cfg.Get().Database.Host)
+	})
+
+	t.Run("should override the keys containing the delimiter", func(t *testing.T) {
+		// Arrange
+		tmpDir := tempConfigDir(t)
+		yaml := "app:\n  database:\n    max_conns: 10\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "base.yaml"), []byte(yaml), 0644))
+		t.Setenv("MYSVC_CONFIG_DIR", normalizeConfigDir(tmpDir))
+		t.Setenv("MYSVC_APP_DATABASE_MAX_CONNS", "50")
+
+		// Act
+		cfg, err := config.New[map[string]any](
+			config.WithEnvPrefix("MYSVC_"),
+			config.WithEnvDelimiter("_"),
+		)
+
+		// Assert
+		require.NoError(t, err)
+		value, _ := cfg.Lookup("app.database.max_conns")
+		assert.Equal(t, "50", value)
+	})
+
+	t.Run("should fail when a variable names several keys", func(t *testing.T) {
+		// Arrange
+		tmpDir := tempConfigDir(t)
+		yaml := "app:\n  database:\n    max_conns: 10\n  database_max:\n    conns: 5\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "base.yaml"), []byte(yaml), 0644))
+		t.Setenv("MYSVC_CONFIG_DIR", normalizeConfigDir(tmpDir))
+		t.Setenv("MYSVC_APP_DATABASE_MAX_CONNS", "50")
+
+		// Act
+		_, err := config.New[map[string]any](
+			config.WithEnvPrefix("MYSVC_"),
+			config.WithEnvDelimiter("_"),
+		)
+
+		// Assert
+		require.ErrorIs(t, err, config.ErrAmbiguousEnvOverride)
+	})
+}
+
+func TestEnvTags(t *testing.T) {
+	t.Run("should set tagged fields missing from the yaml", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeEnvConfig(t)
+		t.Setenv("DATABASE_URL", "postgres://db.internal/app")
+
+		// Act
+		cfg, err := loadConfig[EnvConfig](tmpDir, config.WithPath("app"))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://db.internal/app", cfg.Get().Database.URL)
+		assert.Equal(t, "localhost", cfg.Get().Database.Host)
+	})
+
+	t.Run("should leave tagged fields alone when the variable is unset", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeEnvConfig(t)
+
+		// Act
+		cfg, err := loadConfig[EnvConfig](tmpDir, config.WithPath("app"))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Get().Database.URL)
+	})
+}
@@ -12,6 +12,21 @@ var (
 	// ErrConfigFileNotFound indicates that a required config file was not found
 	ErrConfigFileNotFound = errors.New("config file not found")
 
+	// ErrKeyNotFound indicates that the key of WithPath is missing from the config
+	ErrKeyNotFound = errors.New("config key not found")
+
 	// ErrUnmarshalFailed indicates that unmarshaling config to struct failed
 	ErrUnmarshalFailed = errors.New("failed to unmarshal config")
+
+	// ErrInvalidDotEnv indicates that a .env file has an invalid line
+	ErrInvalidDotEnv = errors.New("invalid .env file")
+
+	// ErrInvalidInclude indicates that an $include directive is not a path or a list of paths
+	ErrInvalidInclude = errors.New("invalid $include directive")
+
+	// ErrIncludeCycle indicates that YAML files include each other
+	ErrIncludeCycle = errors.New("$include cycle")
+
+	// ErrAmbiguousEnvOverride indicates that an override variable names several config keys
+	ErrAmbiguousEnvOverride = errors.New("environment variable overrides several config keys")
 )
@@ -0,0 +1,7 @@
+package config
+
+// ParseDotEnv exposes parseDotEnv for tests.
+var ParseDotEnv = parseDotEnv
+
+// LoadDotEnv exposes loadDotEnv for tests.
+var LoadDotEnv = loadDotEnv
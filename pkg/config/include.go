@@ -0,0 +1,156 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+const includeKey = "$include"
+
+// resolveIncludes replaces the $include directives of data, read from a file of dir, with the
+// content of the included files. A directive names one file or a list of files, relative to
+// the including file; they are merged in order, and the keys next to the directive win over
+// them. stack holds the files being included, to detect cycles.
+//
+// It also returns the included file each flattened key comes from; the keys of the file
+// itself are missing from it.
+func resolveIncludes(
+	data map[string]interface{},
+	dir string,
+	stack []string,
+) (map[string]interface{}, map[string]string, error) {
+	merged := make(map[string]interface{})
+	origins := make(map[string]string)
+	if directive, ok := data[includeKey]; ok {
+		paths, err := includePaths(directive)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, path := range paths {
+			included, includedPath, includedOrigins, includeErr := loadInclude(path, dir, stack)
+			if includeErr != nil {
+				return nil, nil, includeErr
+			}
+			mergeMaps(merged, included)
+			for key := range flatten(included) {
+				origins[key] = cmp.Or(includedOrigins[key], includedPath)
+			}
+		}
+	}
+
+	rest := make(map[string]interface{}, len(data))
+	restOrigins := make(map[string]string)
+	for key, value := range data {
+		if key == includeKey {
+			continue
+		}
+		resolved, nestedOrigins, err := resolveValueIncludes(value, dir, stack)
+		if err != nil {
+			return nil, nil, err
+		}
+		rest[key] = resolved
+		for nestedKey, origin := range nestedOrigins {
+			restOrigins[key+"."+nestedKey] = origin
+		}
+	}
+	mergeMaps(merged, rest)
+	for key := range flatten(rest) {
+		if origin, ok := restOrigins[key]; ok {
+			origins[key] = origin
+		} else {
+			delete(origins, key)
+		}
+	}
+	return merged, origins, nil
+}
+
+func resolveValueIncludes(value interface{}, dir string, stack []string) (interface{}, map[string]string, error) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return resolveIncludes(typed, dir, stack)
+	case []interface{}:
+		resolved := make([]interface{}, len(typed))
+		for idx, item := range typed {
+			next, _, err := resolveValueIncludes(item, dir, stack)
+			if err != nil {
+				return nil, nil, err
+			}
+			resolved[idx] = next
+		}
+		return resolved, nil, nil
+	default:
+		return value, nil, nil
+	}
+}
+
+func includePaths(directive interface{}) ([]string, error) {
+	switch typed := directive.(type) {
+	case string:
+		return []string{typed}, nil
+	case []interface{}:
+		paths := make([]string, 0, len(typed))
+		for _, item := range typed {
+			path, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidInclude, directive)
+			}
+			paths = append(paths, path)
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInclude, directive)
+	}
+}
+
+// loadInclude returns the content of the included file, its absolute path and the origins of
+// its keys (see resolveIncludes).
+func loadInclude(
+	path, dir string,
+	stack []string,
+) (map[string]interface{}, string, map[string]string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, "", nil, fmt.Errorf("%w: empty path", ErrInvalidInclude)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	if slices.Contains(stack, path) {
+		return nil, "", nil, fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(append(stack, path), " -> "))
+	}
+
+	// Not ErrConfigFileNotFound: a missing include is an error even in an optional file
+	content, err := os.ReadFile(path) // #nosec G304 -- included files are part of the trusted config
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to read included file %s: %w", path, err)
+	}
+	data, err := yaml.Parser().Unmarshal(content)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to parse included file %s: %w", path, err)
+	}
+	included, origins, err := resolveIncludes(data, filepath.Dir(path), append(slices.Clone(stack), path))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return included, path, origins, nil
+}
+
+// mergeMaps merges src into dst, recursively for nested maps; the values of src win.
+func mergeMaps(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeMaps(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
@@ -0,0 +1,128 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestIncludes(t *testing.T) {
+	t.Run("should merge included files under the directive", func(t *testing.T) {
+		// Arrange
+		tmpDir := tempConfigDir(t)
+		writeFiles(t, tmpDir, map[string]string{
+			"base.yaml": `
+app:
+  $include: common/app.yaml
+  name: "Orders"
+  database:
+    $include: [common/database.yaml]
+    host: "orders-db"
+`,
+			"common/app.yaml": `
+name: "Shared"
+debug: true
+features: [a, b]
+`,
+			"common/database.yaml": `
+$include: defaults.yaml
+host: "shared-db"
+`,
+			"common/defaults.yaml": `
+port: 5432
+`,
+		})
+		t.Setenv("APP_ENV", "local")
+
+		// Act
+		cfg, err := loadConfig[TestConfig](tmpDir)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Orders", cfg.Get().App.Name)
+		assert.True(t, cfg.Get().App.Debug)
+		assert.Equal(t, []string{"a", "b"}, cfg.Get().App.Features)
+		assert.Equal(t, "orders-db", cfg.Get().App.Database.Host)
+		assert.Equal(t, 5432, cfg.Get().App.Database.Port)
+	})
+
+	t.Run("should resolve includes in environment files", func(t *testing.T) {
+		// Arrange
+		tmpDir := tempConfigDir(t)
+		writeFiles(t, tmpDir, map[string]string{
+			"base.yaml":        "app:\n  port: 3000\n",
+			"production.yaml":  "app:\n  $include: common/prod.yaml\n",
+			"common/prod.yaml": "port: 443\n",
+		})
+		t.Setenv("APP_ENV", "production")
+
+		// Act
+		cfg, err := loadConfig[TestConfig](tmpDir)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 443, cfg.Get().App.Port)
+	})
+
+	t.Run("should detect include cycles", func(t *testing.T) {
+		// Arrange
+		tmpDir := tempConfigDir(t)
+		writeFiles(t, tmpDir, map[string]string{
+			"base.yaml": "app:\n  $include: a.yaml\n",
+			"a.yaml":    "$include: b.yaml\n",
+			"b.yaml":    "$include: a.yaml\n",
+		})
+		t.Setenv("APP_ENV", "local")
+
+		// Act
+		_, err := loadConfig[TestConfig](tmpDir)
+
+		// Assert
+		require.ErrorIs(t, err, config.ErrIncludeCycle)
+	})
+
+	t.Run("should fail on a missing included file", func(t *testing.T) {
+		// Arrange
+		tmpDir := tempConfigDir(t)
+		writeFiles(t, tmpDir, map[string]string{
+			"base.yaml":       "app:\n  port: 3000\n",
+			"production.yaml": "app:\n  $include: missing.yaml\n",
+		})
+		t.Setenv("APP_ENV", "production")
+
+		// Act
+		_, err := loadConfig[TestConfig](tmpDir)
+
+		// Assert
+		require.Error(t, err)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("should reject invalid directives", func(t *testing.T) {
+		// Arrange
+		tmpDir := tempConfigDir(t)
+		writeFiles(t, tmpDir, map[string]string{
+			"base.yaml": "app:\n  $include: {file: a.yaml}\n",
+		})
+		t.Setenv("APP_ENV", "local")
+
+		// Act
+		_, err := loadConfig[TestConfig](tmpDir)
+
+		// Assert
+		require.ErrorIs(t, err, config.ErrInvalidInclude)
+	})
+}
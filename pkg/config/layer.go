@@ -0,0 +1,48 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// Layer is a remote source of config keys, e.g. AWS SSM Parameter Store. The layers are
+// merged over the YAML files, in the order they are added, and under the environment
+// variable overrides.
+type Layer interface {
+	// Name identifies the layer in the provenance, e.g. "ssm:/orders/production"
+	Name() string
+	// Load returns the keys of the layer as nested maps. It is called by every New, so
+	// layers calling a remote service cache their keys.
+	Load(ctx context.Context) (map[string]any, error)
+}
+
+// WithLayer merges the keys of layer over the YAML files. Supply it with SupplyOptions to
+// apply it to the configs of the bricks modules:
+//
+//	config.SupplyOptions(config.WithLayer(parameters))
+func WithLayer(layer Layer) Option {
+	return func(opts *loadOptions) {
+		if layer != nil {
+			opts.layers = append(opts.layers, layer)
+		}
+	}
+}
+
+// loadLayers merges the keys of layers into k, in order.
+func loadLayers(ctx context.Context, k *koanf.Koanf, layers []Layer, tracker provenanceTracker) error {
+	for _, layer := range layers {
+		data, err := layer.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load config layer %s: %w", layer.Name(), err)
+		}
+		for key, value := range flatten(data) {
+			tracker.record(Provenance{Key: key, Value: value, Source: SourceRemote, Layer: layer.Name()})
+		}
+		if loadErr := k.Load(&yamlProvider{data: data}, nil); loadErr != nil {
+			return fmt.Errorf("failed to merge config layer %s: %w", layer.Name(), loadErr)
+		}
+	}
+	return nil
+}
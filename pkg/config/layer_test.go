@@ -0,0 +1,85 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLayer is a Layer whose keys the tests change. Every Load is sent on loads when set.
+type fakeLayer struct {
+	mu    sync.Mutex
+	data  map[string]any
+	err   error
+	loads chan struct{}
+}
+
+func (l *fakeLayer) Name() string {
+	return "fake:/orders"
+}
+
+func (l *fakeLayer) Load(context.Context) (map[string]any, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loads != nil {
+		select {
+		case l.loads <- struct{}{}:
+		default:
+		}
+	}
+	return l.data, l.err
+}
+
+func (l *fakeLayer) set(data map[string]any, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.data, l.err = data, err
+}
+
+func TestWithLayer(t *testing.T) {
+	t.Run("should merge the layer over the files and under the env overrides", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeProvenanceConfig(t)
+		t.Setenv("APP_APP__NAME", "FromEnv")
+		layer := &fakeLayer{data: map[string]any{
+			"app": map[string]any{
+				"name":     "FromLayer",
+				"port":     "8443",
+				"database": map[string]any{"host": "orders-db.internal"},
+			},
+		}}
+
+		// Act
+		cfg, err := loadConfig[TestConfig](tmpDir, config.WithLayer(layer))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "FromEnv", cfg.Get().App.Name)
+		assert.Equal(t, 8443, cfg.Get().App.Port)
+		assert.Equal(t, "orders-db.internal", cfg.Get().App.Database.Host)
+		assert.Equal(t, 5432, cfg.Get().App.Database.Port)
+		host, ok := cfg.Explain("app.database.host")
+		require.True(t, ok)
+		assert.Equal(t, config.SourceRemote, host.Source)
+		assert.Equal(t, "app.database.host=orders-db.internal from fake:/orders (overrides common/database.yaml)",
+			host.String())
+	})
+
+	t.Run("should return the error of the layer", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeProvenanceConfig(t)
+		layer := &fakeLayer{err: errors.New("access denied")}
+
+		// Act
+		_, err := loadConfig[TestConfig](tmpDir, config.WithLayer(layer))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fake:/orders: access denied")
+	})
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// Source is the kind of layer that supplied a config value.
+type Source string
+
+const (
+	// SourceFile is a YAML file: base.yaml, <environment>.yaml or an included file.
+	SourceFile Source = "file"
+	// SourceEnv is an environment variable overriding the key, by prefix or `env` tag.
+	SourceEnv Source = "env"
+	// SourceRemote is a remote layer, see WithLayer.
+	SourceRemote Source = "remote"
+)
+
+// Provenance describes where the value of a config key comes from.
+type Provenance struct {
+	Key   string
+	Value any
+	// Source is the kind of layer that supplied Value.
+	Source Source
+	// File is the YAML file of Value, relative to the config directory, for SourceFile.
+	File string
+	// Variable is the environment variable of Value: the overriding variable for SourceEnv,
+	// the env:// reference for SourceFile.
+	Variable string
+	// Layer is the name of the remote layer of Value, for SourceRemote.
+	Layer string
+	// Overrides are the values of the lower layers replaced by Value, the highest first.
+	Overrides []Provenance
+}
+
+// String describes the provenance, e.g. "app.port=443 from production.yaml (overrides base.yaml)".
+func (p Provenance) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s=%v from %s", p.Key, p.Value, p.origin())
+	if len(p.Overrides) > 0 {
+		overridden := make([]string, len(p.Overrides))
+		for i, override := range p.Overrides {
+			overridden[i] = override.origin()
+		}
+		fmt.Fprintf(&b, " (overrides %s)", strings.Join(overridden, ", "))
+	}
+	return b.String()
+}
+
+func (p Provenance) origin() string {
+	switch {
+	case p.Source == SourceEnv:
+		return "env " + p.Variable
+	case p.Source == SourceRemote:
+		return p.Layer
+	case p.Variable != "":
+		return p.File + " (" + envValuePrefix + p.Variable + ")"
+	default:
+		return p.File
+	}
+}
+
+// Change is a key whose value differs between two configs, see Config.Diff. From or To is
+// nil when the key is missing from that config.
+type Change struct {
+	Key  string
+	From any
+	To   any
+}
+
+// Explain returns where the effective value of key comes from. key is relative to the path
+// the config was loaded from (e.g. "database.host" with WithPath("app")).
+func (v Config[T]) Explain(key string) (Provenance, bool) {
+	p, ok := v.provenance[key]
+	return p, ok
+}
+
+// Diff returns the keys whose values differ in other, sorted by key. Values are compared by
+// their text, so 443 and "443" are equal.
+func (v Config[T]) Diff(other Config[T]) []Change {
+	var changes []Change
+	all := maps.Clone(v.values)
+	if all == nil {
+		all = make(map[string]any)
+	}
+	maps.Copy(all, other.values)
+	keys := slices.Sorted(maps.Keys(all))
+
+	for _, key := range keys {
+		from, inFrom := v.values[key]
+		to, inTo := other.values[key]
+		if inFrom && inTo && fmt.Sprint(from) == fmt.Sprint(to) {
+			continue
+		}
+		changes = append(changes, Change{Key: key, From: from, To: to})
+	}
+	return changes
+}
+
+// provenanceTracker records, for each flattened key, the layers that set it in order.
+type provenanceTracker map[string][]Provenance
+
+func (t provenanceTracker) record(p Provenance) {
+	t[p.Key] = append(t[p.Key], p)
+}
+
+// recordFile records the keys of a file layer. origins holds the included file of the keys
+// that do not come from file itself, refs the env:// reference of the keys that use one.
+func (t provenanceTracker) recordFile(data map[string]any, file string, origins, refs map[string]string) {
+	for key, value := range flatten(data) {
+		t.record(Provenance{
+			Key:      key,
+			Value:    value,
+			Source:   SourceFile,
+			File:     cmp.Or(origins[key], file),
+			Variable: refs[key],
+		})
+	}
+}
+
+// build returns the effective provenance of the keys of k under keyPath, relative to it, and
+// their values.
+func (t provenanceTracker) build(k *koanf.Koanf, keyPath string) (map[string]Provenance, map[string]any) {
+	provenance := make(map[string]Provenance)
+	values := make(map[string]any)
+	prefix := ""
+	if keyPath != "" {
+		prefix = keyPath + "."
+	}
+	for key, value := range k.All() {
+		relative, ok := strings.CutPrefix(key, prefix)
+		if !ok || relative == "" {
+			continue
+		}
+		values[relative] = value
+
+		history := t[key]
+		if len(history) == 0 {
+			continue
+		}
+		effective := history[len(history)-1]
+		effective.Key = relative
+		effective.Value = value
+		effective.Overrides = nil
+		for i := len(history) - 2; i >= 0; i-- {
+			override := history[i]
+			override.Key = relative
+			effective.Overrides = append(effective.Overrides, override)
+		}
+		provenance[relative] = effective
+	}
+	return provenance, values
+}
+
+// envRefs returns the variable of the flattened keys of data whose value is an env:// reference.
+func envRefs(data map[string]any) map[string]string {
+	refs := make(map[string]string)
+	for key, value := range flatten(data) {
+		if text, ok := value.(string); ok && strings.HasPrefix(text, envValuePrefix) {
+			refs[key] = strings.TrimPrefix(text, envValuePrefix)
+		}
+	}
+	return refs
+}
+
+// flatten returns the leaf values of data by their dot-separated key.
+func flatten(data map[string]any) map[string]any {
+	k := koanf.New(".")
+	// Loading a map cannot fail
+	_ = k.Load(&yamlProvider{data: data}, nil)
+	return k.All()
+}
@@ -0,0 +1,92 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProvenanceConfig(t *testing.T) string {
+	t.Helper()
+	tmpDir := tempConfigDir(t)
+	writeFiles(t, tmpDir, map[string]string{
+		"base.yaml": `
+app:
+  name: "Orders"
+  port: 3000
+  debug: true
+  database:
+    $include: common/database.yaml
+    password: env://PROVENANCE_DB_PASSWORD
+`,
+		"common/database.yaml": "host: shared-db\nport: 5432\n",
+		"production.yaml":      "app:\n  port: 443\n  debug: false\n",
+	})
+	return tmpDir
+}
+
+func TestExplain(t *testing.T) {
+	t.Run("should report the layer of each key", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeProvenanceConfig(t)
+		t.Setenv("APP_ENV", "production")
+		t.Setenv("PROVENANCE_DB_PASSWORD", "secret")
+		t.Setenv("APP_APP__NAME", "FromEnv")
+
+		// Act
+		cfg, err := loadConfig[TestConfig](tmpDir, config.WithPath("app"))
+		require.NoError(t, err)
+		name, nameOK := cfg.Explain("name")
+		port, portOK := cfg.Explain("port")
+		host, hostOK := cfg.Explain("database.host")
+		password, passwordOK := cfg.Explain("database.password")
+		_, missingOK := cfg.Explain("missing")
+
+		// Assert
+		require.True(t, nameOK)
+		assert.Equal(t, config.SourceEnv, name.Source)
+		assert.Equal(t, "APP_APP__NAME", name.Variable)
+		assert.Equal(t, "FromEnv", name.Value)
+		require.Len(t, name.Overrides, 1)
+		assert.Equal(t, "base.yaml", name.Overrides[0].File)
+
+		require.True(t, portOK)
+		assert.Equal(t, "production.yaml", port.File)
+		assert.Equal(t, "port=443 from production.yaml (overrides base.yaml)", port.String())
+
+		require.True(t, hostOK)
+		assert.Equal(t, "common/database.yaml", host.File)
+
+		require.True(t, passwordOK)
+		assert.Equal(t, "base.yaml", password.File)
+		assert.Equal(t, "PROVENANCE_DB_PASSWORD", password.Variable)
+		assert.Equal(t, "database.password=secret from base.yaml (env://PROVENANCE_DB_PASSWORD)", password.String())
+
+		assert.False(t, missingOK)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("should list the keys that differ between environments", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeProvenanceConfig(t)
+		t.Setenv("APP_ENV", "local")
+		local, err := loadConfig[TestConfig](tmpDir, config.WithPath("app"))
+		require.NoError(t, err)
+		t.Setenv("APP_ENV", "production")
+		t.Setenv("APP_APP__DATABASE__PORT", "5432")
+		production, err := loadConfig[TestConfig](tmpDir, config.WithPath("app"))
+		require.NoError(t, err)
+
+		// Act
+		changes := local.Diff(production)
+
+		// Assert
+		assert.Equal(t, []config.Change{
+			{Key: "debug", From: true, To: false},
+			{Key: "port", From: 3000, To: 443},
+		}, changes)
+	})
+}
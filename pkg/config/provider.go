@@ -1,9 +1,34 @@
 package config
 
 import (
+	"errors"
+	"slices"
+
 	"go.uber.org/fx"
 )
 
+// Options are the loading options applied before the ones of every Provide and
+// ProvideOptional of the app, supplied with SupplyOptions.
+type Options []Option
+
+// SupplyOptions supplies the options of the configs loaded through Provide and
+// ProvideOptional, including the ones of the bricks modules, e.g. the env prefix or the
+// remote layers:
+//
+//	fx.New(
+//	    config.SupplyOptions(config.WithEnvPrefix("MYSVC_")),
+//	    logger.Module,
+//	)
+func SupplyOptions(options ...Option) fx.Option {
+	return fx.Supply(Options(options))
+}
+
+// ProvideParams are the dependencies of the configs provided with Provide and ProvideOptional.
+type ProvideParams struct {
+	fx.In
+	Options Options `optional:"true"`
+}
+
 // Provide creates an fx.Provide option for loading config at the specified path.
 // This helper reduces boilerplate when creating config providers.
 //
@@ -12,8 +37,36 @@ import (
 //	fx.Module("mymodule",
 //	    config.Provide[MyConfig]("app.mymodule"),
 //	)
-func Provide[T any](path string) fx.Option {
-	return fx.Provide(func() (Config[T], error) {
-		return New[T](WithPath(path))
+func Provide[T any](path string, options ...Option) fx.Option {
+	return fx.Provide(func(p ProvideParams) (Config[T], error) {
+		return New[T](slices.Concat(p.Options, []Option{WithPath(path)}, options)...)
 	})
 }
+
+// ProvideOptional is Provide for the modules working without their config: when the key
+// at path is missing, it provides the zero value of T, for the module to apply its defaults.
+//
+//	fx.Module("metrics",
+//	    config.ProvideOptional[Config]("app.metrics"),
+//	)
+func ProvideOptional[T any](path string, options ...Option) fx.Option {
+	return fx.Provide(func(p ProvideParams) (Config[T], error) {
+		cfg, err := New[T](slices.Concat(p.Options, []Option{WithPath(path)}, options)...)
+		if errors.Is(err, ErrKeyNotFound) {
+			var zero T
+			return Of(zero), nil
+		}
+		return cfg, err
+	})
+}
+
+// Supply provides a Config[T] holding value instead of loading it, e.g. to configure a
+// bricks module in code or in tests.
+//
+//	fx.New(
+//	    config.Supply(logger.Config{Level: "debug"}),
+//	    logger.Module,
+//	)
+func Supply[T any](value T) fx.Option {
+	return fx.Supply(Of(value))
+}
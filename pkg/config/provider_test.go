@@ -0,0 +1,42 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+type NamedConfig struct {
+	Name string `config:"name"`
+}
+
+func TestSupplyOptions(t *testing.T) {
+	// Arrange
+	tmpDir := tempConfigDir(t)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "base.yaml"), []byte("app:\n  name: FromYAML\n"), 0644))
+	t.Setenv("APP_CONFIG_DIR", normalizeConfigDir(tmpDir))
+	t.Setenv("MYSVC_CONFIG_DIR", normalizeConfigDir(tmpDir))
+	t.Setenv("MYSVC_APP__NAME", "FromEnv")
+	var supplied, plain config.Config[NamedConfig]
+
+	// Act
+	suppliedApp := fx.New(
+		fx.NopLogger,
+		config.SupplyOptions(config.WithEnvPrefix("MYSVC_")),
+		config.Provide[NamedConfig]("app"),
+		fx.Populate(&supplied),
+	)
+	plainApp := fx.New(fx.NopLogger, config.Provide[NamedConfig]("app"), fx.Populate(&plain))
+
+	// Assert
+	require.NoError(t, suppliedApp.Err())
+	require.NoError(t, plainApp.Err())
+	assert.Equal(t, "FromEnv", supplied.Get().Name)
+	assert.Equal(t, "FromYAML", plain.Get().Name)
+}
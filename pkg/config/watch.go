@@ -0,0 +1,49 @@
+package config
+
+import (
+	"context"
+	"time"
+)
+
+// Watch loads the config, then reloads it every interval until ctx is done, calling
+// onChange with the reloaded config and its changes whenever they differ from the previous
+// load, e.g. once a remote layer refreshed its keys. A failed reload keeps the previous
+// config and is passed to onError when not nil. It returns the error of the first load,
+// or nil once ctx is done.
+//
+//	go config.Watch(ctx, time.Minute, func(cfg config.Config[FeatureConfig], changes []config.Change) {
+//	    features.Store(cfg.Get())
+//	}, nil, config.WithPath("app.features"))
+func Watch[T any](
+	ctx context.Context,
+	interval time.Duration,
+	onChange func(Config[T], []Change),
+	onError func(error),
+	options ...Option,
+) error {
+	current, err := load[T](ctx, options)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		next, reloadErr := load[T](ctx, options)
+		if reloadErr != nil {
+			if onError != nil && ctx.Err() == nil {
+				onError(reloadErr)
+			}
+			continue
+		}
+		if changes := current.Diff(next); len(changes) > 0 {
+			current = next
+			onChange(next, changes)
+		}
+	}
+}
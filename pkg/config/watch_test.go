@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	t.Run("should notify the changes of the reloads", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeProvenanceConfig(t)
+		t.Setenv("APP_CONFIG_DIR", normalizeConfigDir(tmpDir))
+		layer := &fakeLayer{data: map[string]any{"app": map[string]any{"port": 8443}}, loads: make(chan struct{}, 1)}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		changed := make(chan []config.Change, 1)
+		reloadErrs := make(chan error, 1)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- config.Watch(ctx, 5*time.Millisecond, func(cfg config.Config[TestConfig], changes []config.Change) {
+				if cfg.Get().App.Port == 9443 {
+					changed <- changes
+				}
+			}, func(err error) {
+				select {
+				case reloadErrs <- err:
+				default:
+				}
+			}, config.WithLayer(layer))
+		}()
+
+		// Act
+		<-layer.loads
+		layer.set(nil, errors.New("throttled"))
+		reloadErr := <-reloadErrs
+		layer.set(map[string]any{"app": map[string]any{"port": 9443}}, nil)
+		changes := <-changed
+		cancel()
+
+		// Assert
+		require.NoError(t, <-done)
+		assert.Contains(t, reloadErr.Error(), "throttled")
+		assert.Equal(t, []config.Change{{Key: "app.port", From: 8443, To: 9443}}, changes)
+	})
+
+	t.Run("should return the error of the first load", func(t *testing.T) {
+		// Arrange
+		tmpDir := writeProvenanceConfig(t)
+		t.Setenv("APP_CONFIG_DIR", normalizeConfigDir(tmpDir))
+		layer := &fakeLayer{err: errors.New("access denied")}
+
+		// Act
+		err := config.Watch(context.Background(), time.Millisecond, func(config.Config[TestConfig], []config.Change) {},
+			nil, config.WithLayer(layer))
+
+		// Assert
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,31 @@
+package crypto
+
+// Config configures the Encryptor and the loading of its keys.
+type Config struct {
+	// Algorithm encrypts new data: aes-gcm or xchacha20-poly1305
+	Algorithm string `config:"algorithm"`
+	// PrimaryKey is the ID of the key encrypting new data; defaults to the first key
+	PrimaryKey string `config:"primary_key"`
+	// Keys is the keyring: the primary key and the older keys still decrypting
+	Keys []KeyConfig `config:"keys"`
+}
+
+// KeyConfig locates the material of a key: 32 bytes, base64 encoded, read from exactly
+// one source.
+type KeyConfig struct {
+	// ID identifies the key in the ciphertexts, e.g. "2026-10"
+	ID string `config:"id"`
+	// Env is the environment variable holding the key
+	Env string `config:"env"`
+	// File is the file holding the key, e.g. a mounted secret
+	File string `config:"file"`
+	// Wrapped is the key encrypted by the KMS (envelope encryption)
+	Wrapped string `config:"wrapped"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Algorithm == "" {
+		c.Algorithm = AlgorithmAESGCM
+	}
+}
@@ -0,0 +1,251 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	AlgorithmAESGCM            = "aes-gcm"
+	AlgorithmXChaCha20Poly1305 = "xchacha20-poly1305"
+
+	// KeySize is the size of the keys, 256 bits for both algorithms
+	KeySize = 32
+
+	formatVersion    = 1
+	algorithmAES     = 1
+	algorithmXChaCha = 2
+	maxKeyIDLength   = 255
+	// headerSize is the version, algorithm and key ID length bytes
+	headerSize = 3
+)
+
+// Key is an encryption key, identified in the ciphertexts by its ID.
+type Key struct {
+	ID       string
+	Material []byte
+}
+
+// Encryptor encrypts with AEADs (AES-256-GCM or XChaCha20-Poly1305) and a keyring.
+// Each ciphertext records the algorithm and the ID of its key, so the keys rotate
+// without re-encrypting the data at once: new data is encrypted with the primary key,
+// and the data encrypted with the other keys of the ring still decrypts.
+//
+// The ciphertext is: version (1 byte), algorithm (1 byte), key ID length (1 byte),
+// key ID, nonce and the sealed data. The header is authenticated with the data.
+type Encryptor struct {
+	aeads     map[string]keyAEADs
+	primary   string
+	algorithm byte
+}
+
+// keyAEADs holds the AEADs of a key, by algorithm.
+type keyAEADs struct {
+	aes       cipher.AEAD
+	xchacha20 cipher.AEAD
+}
+
+type options struct {
+	primary   string
+	algorithm string
+}
+
+// Option configures the Encryptor created by NewEncryptor.
+type Option func(*options)
+
+// WithPrimaryKey sets the ID of the key encrypting new data. Defaults to the first key.
+func WithPrimaryKey(id string) Option {
+	return func(o *options) {
+		if id != "" {
+			o.primary = id
+		}
+	}
+}
+
+// WithAlgorithm sets the algorithm encrypting new data: AlgorithmAESGCM (default) or
+// AlgorithmXChaCha20Poly1305. Data encrypted with the other algorithm still decrypts.
+func WithAlgorithm(algorithm string) Option {
+	return func(o *options) {
+		if algorithm != "" {
+			o.algorithm = algorithm
+		}
+	}
+}
+
+// NewEncryptor creates an Encryptor with the keyring keys.
+//
+//	enc, err := crypto.NewEncryptor([]crypto.Key{
+//	    {ID: "2026-10", Material: newKey},
+//	    {ID: "2025-01", Material: oldKey}, // still decrypts
+//	})
+func NewEncryptor(keys []Key, opts ...Option) (*Encryptor, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	encryptorOptions := options{primary: keys[0].ID, algorithm: AlgorithmAESGCM}
+	for _, opt := range opts {
+		opt(&encryptorOptions)
+	}
+
+	e := &Encryptor{aeads: make(map[string]keyAEADs, len(keys)), primary: encryptorOptions.primary}
+	switch encryptorOptions.algorithm {
+	case AlgorithmAESGCM:
+		e.algorithm = algorithmAES
+	case AlgorithmXChaCha20Poly1305:
+		e.algorithm = algorithmXChaCha
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAlgorithm, encryptorOptions.algorithm)
+	}
+
+	for _, key := range keys {
+		if key.ID == "" || len(key.ID) > maxKeyIDLength || len(key.Material) != KeySize {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidKey, key.ID)
+		}
+		if _, exists := e.aeads[key.ID]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateKey, key.ID)
+		}
+		block, err := aes.NewCipher(key.Material)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+		}
+		xchacha20, err := chacha20poly1305.NewX(key.Material)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+		}
+		e.aeads[key.ID] = keyAEADs{aes: gcm, xchacha20: xchacha20}
+	}
+	if _, exists := e.aeads[e.primary]; !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPrimaryKey, e.primary)
+	}
+	return e, nil
+}
+
+// PrimaryKeyID returns the ID of the key encrypting new data.
+func (e *Encryptor) PrimaryKeyID() string {
+	return e.primary
+}
+
+// Encrypt encrypts plaintext with the primary key. The associated data, e.g. the ID of
+// the row, is authenticated but not stored: decrypting requires the same one.
+func (e *Encryptor) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	aead := e.aeads[e.primary].by(e.algorithm)
+	header := make([]byte, 0, headerSize+len(e.primary))
+	header = append(header, formatVersion, e.algorithm, byte(len(e.primary)))
+	header = append(header, e.primary...)
+
+	out := make([]byte, len(header)+aead.NonceSize(), len(header)+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	copy(out, header)
+	nonce := out[len(header):]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return aead.Seal(out, nonce, plaintext, additionalData(header, associatedData)), nil
+}
+
+// Decrypt decrypts a ciphertext made by Encrypt with any key of the ring.
+func (e *Encryptor) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	header, algorithm, keyID, err := parseHeader(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	aeads, exists := e.aeads[keyID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	aead := aeads.by(algorithm)
+	rest := ciphertext[len(header):]
+	if len(rest) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData(header, associatedData))
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+// EncryptString encrypts plaintext into base64, e.g. for text columns.
+func (e *Encryptor) EncryptString(plaintext string) (string, error) {
+	ciphertext, err := e.Encrypt([]byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptString decrypts a ciphertext made by EncryptString.
+func (e *Encryptor) DecryptString(ciphertext string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+	plaintext, err := e.Decrypt(decoded, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// KeyID returns the ID of the key of a ciphertext.
+func KeyID(ciphertext []byte) (string, error) {
+	_, _, keyID, err := parseHeader(ciphertext)
+	return keyID, err
+}
+
+// NeedsRotation reports whether the ciphertext was made with another key or algorithm
+// than the current ones, and should be re-encrypted with Rotate.
+func (e *Encryptor) NeedsRotation(ciphertext []byte) bool {
+	_, algorithm, keyID, err := parseHeader(ciphertext)
+	return err == nil && (keyID != e.primary || algorithm != e.algorithm)
+}
+
+// Rotate re-encrypts the ciphertext with the primary key and the current algorithm. It
+// returns the ciphertext unchanged when it already uses them.
+func (e *Encryptor) Rotate(ciphertext, associatedData []byte) ([]byte, error) {
+	plaintext, err := e.Decrypt(ciphertext, associatedData)
+	if err != nil {
+		return nil, err
+	}
+	if !e.NeedsRotation(ciphertext) {
+		return ciphertext, nil
+	}
+	return e.Encrypt(plaintext, associatedData)
+}
+
+func (a keyAEADs) by(algorithm byte) cipher.AEAD {
+	if algorithm == algorithmXChaCha {
+		return a.xchacha20
+	}
+	return a.aes
+}
+
+func parseHeader(ciphertext []byte) ([]byte, byte, string, error) {
+	if len(ciphertext) < headerSize || ciphertext[0] != formatVersion {
+		return nil, 0, "", ErrInvalidCiphertext
+	}
+	algorithm := ciphertext[1]
+	if algorithm != algorithmAES && algorithm != algorithmXChaCha {
+		return nil, 0, "", ErrInvalidCiphertext
+	}
+	end := headerSize + int(ciphertext[2])
+	if ciphertext[2] == 0 || len(ciphertext) < end {
+		return nil, 0, "", ErrInvalidCiphertext
+	}
+	return ciphertext[:end], algorithm, string(ciphertext[headerSize:end]), nil
+}
+
+func additionalData(header, associatedData []byte) []byte {
+	data := make([]byte, 0, len(header)+len(associatedData))
+	return append(append(data, header...), associatedData...)
+}
@@ -0,0 +1,169 @@
+package crypto_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/crypto"
+)
+
+var (
+	oldKey = crypto.Key{ID: "2025-01", Material: bytes.Repeat([]byte{1}, crypto.KeySize)}
+	newKey = crypto.Key{ID: "2026-10", Material: bytes.Repeat([]byte{2}, crypto.KeySize)}
+)
+
+type EncryptorTestSuite struct {
+	suite.Suite
+	sut *crypto.Encryptor
+}
+
+func TestEncryptorSuite(t *testing.T) {
+	suite.Run(t, new(EncryptorTestSuite))
+}
+
+func (s *EncryptorTestSuite) SetupTest() {
+	var err error
+	s.sut, err = crypto.NewEncryptor([]crypto.Key{newKey, oldKey})
+	s.Require().NoError(err)
+}
+
+func (s *EncryptorTestSuite) TestEncrypt_RoundTripsWithTheAssociatedData() {
+	// Act
+	ciphertext, err := s.sut.Encrypt([]byte("ana@example.com"), []byte("customer:42"))
+	s.Require().NoError(err)
+	plaintext, decryptErr := s.sut.Decrypt(ciphertext, []byte("customer:42"))
+	_, otherDataErr := s.sut.Decrypt(ciphertext, []byte("customer:43"))
+
+	// Assert
+	s.Require().NoError(decryptErr)
+	s.Equal("ana@example.com", string(plaintext))
+	s.Require().ErrorIs(otherDataErr, crypto.ErrDecryptionFailed)
+	keyID, err := crypto.KeyID(ciphertext)
+	s.Require().NoError(err)
+	s.Equal("2026-10", keyID)
+}
+
+func (s *EncryptorTestSuite) TestEncrypt_UsesARandomNonce() {
+	// Act
+	first, err := s.sut.EncryptString("ana@example.com")
+	s.Require().NoError(err)
+	second, err := s.sut.EncryptString("ana@example.com")
+	s.Require().NoError(err)
+
+	// Assert
+	s.NotEqual(first, second)
+	plaintext, err := s.sut.DecryptString(second)
+	s.Require().NoError(err)
+	s.Equal("ana@example.com", plaintext)
+}
+
+func (s *EncryptorTestSuite) TestDecrypt_RejectsTamperedCiphertexts() {
+	// Arrange
+	ciphertext, err := s.sut.Encrypt([]byte("ana@example.com"), nil)
+	s.Require().NoError(err)
+
+	// Act
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)-1] ^= 1
+	_, tamperedErr := s.sut.Decrypt(tampered, nil)
+	_, truncatedErr := s.sut.Decrypt(ciphertext[:5], nil)
+	_, garbageErr := s.sut.DecryptString("not base64!")
+
+	// Assert
+	s.Require().ErrorIs(tamperedErr, crypto.ErrDecryptionFailed)
+	s.Require().ErrorIs(truncatedErr, crypto.ErrInvalidCiphertext)
+	s.Require().ErrorIs(garbageErr, crypto.ErrInvalidCiphertext)
+}
+
+func (s *EncryptorTestSuite) TestDecrypt_UnknownKey_ReturnsError() {
+	// Arrange
+	other, err := crypto.NewEncryptor([]crypto.Key{{ID: "other", Material: newKey.Material}})
+	s.Require().NoError(err)
+	ciphertext, err := other.Encrypt([]byte("ana@example.com"), nil)
+	s.Require().NoError(err)
+
+	// Act
+	_, err = s.sut.Decrypt(ciphertext, nil)
+
+	// Assert
+	s.Require().ErrorIs(err, crypto.ErrUnknownKey)
+}
+
+func (s *EncryptorTestSuite) TestRotate_ReEncryptsWithThePrimaryKey() {
+	// Arrange
+	previous, err := crypto.NewEncryptor([]crypto.Key{oldKey})
+	s.Require().NoError(err)
+	ciphertext, err := previous.Encrypt([]byte("ana@example.com"), nil)
+	s.Require().NoError(err)
+
+	// Act
+	needed := s.sut.NeedsRotation(ciphertext)
+	rotated, err := s.sut.Rotate(ciphertext, nil)
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(needed)
+	s.False(s.sut.NeedsRotation(rotated))
+	keyID, err := crypto.KeyID(rotated)
+	s.Require().NoError(err)
+	s.Equal("2026-10", keyID)
+	plaintext, err := s.sut.Decrypt(rotated, nil)
+	s.Require().NoError(err)
+	s.Equal("ana@example.com", string(plaintext))
+	unchanged, err := s.sut.Rotate(rotated, nil)
+	s.Require().NoError(err)
+	s.Equal(rotated, unchanged)
+}
+
+func (s *EncryptorTestSuite) TestXChaCha20Poly1305_DecryptsWithEitherAlgorithm() {
+	// Arrange
+	sut, err := crypto.NewEncryptor(
+		[]crypto.Key{oldKey, newKey},
+		crypto.WithPrimaryKey("2026-10"),
+		crypto.WithAlgorithm(crypto.AlgorithmXChaCha20Poly1305),
+	)
+	s.Require().NoError(err)
+	aesCiphertext, err := s.sut.Encrypt([]byte("aes"), nil)
+	s.Require().NoError(err)
+
+	// Act
+	ciphertext, err := sut.Encrypt([]byte("xchacha"), nil)
+	s.Require().NoError(err)
+	plaintext, decryptErr := s.sut.Decrypt(ciphertext, nil)
+	aesPlaintext, aesErr := sut.Decrypt(aesCiphertext, nil)
+
+	// Assert
+	s.Require().NoError(decryptErr)
+	s.Equal("xchacha", string(plaintext))
+	s.Require().NoError(aesErr)
+	s.Equal("aes", string(aesPlaintext))
+	s.True(sut.NeedsRotation(aesCiphertext))
+	s.Equal("2026-10", sut.PrimaryKeyID())
+}
+
+func (s *EncryptorTestSuite) TestNewEncryptor_InvalidKeyring_ReturnsError() {
+	tests := []struct {
+		name string
+		keys []crypto.Key
+		opts []crypto.Option
+		want error
+	}{
+		{"no keys", nil, nil, crypto.ErrNoKeys},
+		{"short key", []crypto.Key{{ID: "k", Material: []byte("short")}}, nil, crypto.ErrInvalidKey},
+		{"empty id", []crypto.Key{{Material: newKey.Material}}, nil, crypto.ErrInvalidKey},
+		{"duplicate id", []crypto.Key{newKey, newKey}, nil, crypto.ErrDuplicateKey},
+		{"unknown primary", []crypto.Key{newKey}, []crypto.Option{crypto.WithPrimaryKey("x")}, crypto.ErrUnknownPrimaryKey},
+		{"algorithm", []crypto.Key{newKey}, []crypto.Option{crypto.WithAlgorithm("des")}, crypto.ErrInvalidAlgorithm},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act
+			_, err := crypto.NewEncryptor(tt.keys, tt.opts...)
+
+			// Assert
+			s.Require().ErrorIs(err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package crypto
+
+import "errors"
+
+var (
+	ErrNoKeys             = errors.New("at least one encryption key is required")
+	ErrInvalidKey         = errors.New("invalid encryption key (must be 32 bytes with an id of 1 to 255 bytes)")
+	ErrDuplicateKey       = errors.New("duplicate encryption key id")
+	ErrUnknownPrimaryKey  = errors.New("primary encryption key not found")
+	ErrUnknownKey         = errors.New("encryption key not found")
+	ErrInvalidAlgorithm   = errors.New("invalid encryption algorithm (must be 'aes-gcm' or 'xchacha20-poly1305')")
+	ErrInvalidCiphertext  = errors.New("invalid ciphertext")
+	ErrDecryptionFailed   = errors.New("decryption failed")
+	ErrInvalidKeySource   = errors.New("encryption key requires exactly one of env, file and wrapped")
+	ErrMissingKeyMaterial = errors.New("encryption key material not found")
+	ErrMissingKMS         = errors.New("wrapped encryption key requires a crypto.KMS")
+)
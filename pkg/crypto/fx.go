@@ -0,0 +1,38 @@
+package crypto
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+// keyLoadTimeout bounds the reads of the key material, e.g. the KMS calls
+const keyLoadTimeout = 30 * time.Second
+
+// Module provides the *Encryptor configured from app.crypto and registers its GORM
+// serializer, so the fields tagged `gorm:"serializer:encrypted"` are encrypted. The
+// wrapped keys are unwrapped with the crypto.KMS of the application.
+var Module = fx.Module(
+	"crypto",
+	config.Provide[Config]("app.crypto"),
+	fx.Provide(NewEncryptorWithParams),
+	fx.Invoke(RegisterSerializer),
+)
+
+// EncryptorParams for dependency injection
+type EncryptorParams struct {
+	fx.In
+
+	Config config.Config[Config]
+	KMS    KMS `optional:"true"`
+}
+
+// NewEncryptorWithParams loads the configured keys and creates the Encryptor.
+func NewEncryptorWithParams(params EncryptorParams) (*Encryptor, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyLoadTimeout)
+	defer cancel()
+	return NewEncryptorFromConfig(ctx, params.Config.Get(), params.KMS)
+}
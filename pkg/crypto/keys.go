@@ -0,0 +1,89 @@
+package crypto
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KMS unwraps the data keys stored encrypted by a key management service (AWS KMS,
+// Google Cloud KMS, Vault transit, ...), so the key material never sits in clear in the
+// configuration.
+type KMS interface {
+	// Decrypt returns the plaintext of a ciphertext encrypted by the KMS.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// LoadKeys reads the material of the configured keys. The kms is only required by the
+// wrapped keys.
+func LoadKeys(ctx context.Context, configs []KeyConfig, kms KMS) ([]Key, error) {
+	keys := make([]Key, 0, len(configs))
+	for _, cfg := range configs {
+		material, err := loadKey(ctx, cfg, kms)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", cfg.ID, err)
+		}
+		keys = append(keys, Key{ID: cfg.ID, Material: material})
+	}
+	return keys, nil
+}
+
+func loadKey(ctx context.Context, cfg KeyConfig, kms KMS) ([]byte, error) {
+	sources := 0
+	for _, source := range []string{cfg.Env, cfg.File, cfg.Wrapped} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, ErrInvalidKeySource
+	}
+
+	switch {
+	case cfg.Env != "":
+		value, ok := os.LookupEnv(cfg.Env)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: environment variable %s", ErrMissingKeyMaterial, cfg.Env)
+		}
+		return decodeKey(value)
+	case cfg.File != "":
+		content, err := os.ReadFile(cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMissingKeyMaterial, err)
+		}
+		return decodeKey(string(content))
+	default:
+		if kms == nil {
+			return nil, ErrMissingKMS
+		}
+		wrapped, err := base64.StdEncoding.DecodeString(strings.TrimSpace(cfg.Wrapped))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+		}
+		material, err := kms.Decrypt(ctx, wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("unwrap key: %w", err)
+		}
+		return material, nil
+	}
+}
+
+func decodeKey(value string) ([]byte, error) {
+	material, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+	return material, nil
+}
+
+// NewEncryptorFromConfig loads the keys of cfg and creates the Encryptor.
+func NewEncryptorFromConfig(ctx context.Context, cfg Config, kms KMS) (*Encryptor, error) {
+	cfg.SetDefaults()
+	keys, err := LoadKeys(ctx, cfg.Keys, kms)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncryptor(keys, WithPrimaryKey(cfg.PrimaryKey), WithAlgorithm(cfg.Algorithm))
+}
@@ -0,0 +1,103 @@
+package crypto_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/crypto"
+)
+
+// reverseKMS "unwraps" keys by reversing their bytes.
+type reverseKMS struct{}
+
+func (reverseKMS) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) != crypto.KeySize {
+		return nil, errors.New("kms: invalid ciphertext")
+	}
+	plaintext := bytes.Clone(ciphertext)
+	for i, j := 0, len(plaintext)-1; i < j; i, j = i+1, j-1 {
+		plaintext[i], plaintext[j] = plaintext[j], plaintext[i]
+	}
+	return plaintext, nil
+}
+
+func encodedKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, crypto.KeySize))
+}
+
+func TestLoadKeys(t *testing.T) {
+	t.Run("reads the keys from the env, files and the kms", func(t *testing.T) {
+		// Arrange
+		t.Setenv("TEST_CRYPTO_KEY", encodedKey(1))
+		file := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(file, []byte(encodedKey(2)+"\n"), 0o600))
+		wrapped := append(bytes.Repeat([]byte{4}, crypto.KeySize-1), 3)
+
+		// Act
+		keys, err := crypto.LoadKeys(context.Background(), []crypto.KeyConfig{
+			{ID: "env", Env: "TEST_CRYPTO_KEY"},
+			{ID: "file", File: file},
+			{ID: "kms", Wrapped: base64.StdEncoding.EncodeToString(wrapped)},
+		}, reverseKMS{})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+		assert.Equal(t, crypto.Key{ID: "env", Material: bytes.Repeat([]byte{1}, crypto.KeySize)}, keys[0])
+		assert.Equal(t, crypto.Key{ID: "file", Material: bytes.Repeat([]byte{2}, crypto.KeySize)}, keys[1])
+		assert.Equal(t, byte(3), keys[2].Material[0])
+	})
+
+	t.Run("rejects invalid sources", func(t *testing.T) {
+		tests := []struct {
+			name string
+			cfg  crypto.KeyConfig
+			kms  crypto.KMS
+			want error
+		}{
+			{"no source", crypto.KeyConfig{ID: "k"}, nil, crypto.ErrInvalidKeySource},
+			{"two sources", crypto.KeyConfig{ID: "k", Env: "A", File: "b"}, nil, crypto.ErrInvalidKeySource},
+			{"missing env", crypto.KeyConfig{ID: "k", Env: "TEST_CRYPTO_MISSING"}, nil, crypto.ErrMissingKeyMaterial},
+			{"missing file", crypto.KeyConfig{ID: "k", File: "/nonexistent"}, nil, crypto.ErrMissingKeyMaterial},
+			{"wrapped without kms", crypto.KeyConfig{ID: "k", Wrapped: encodedKey(1)}, nil, crypto.ErrMissingKMS},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Act
+				_, err := crypto.LoadKeys(context.Background(), []crypto.KeyConfig{tt.cfg}, tt.kms)
+
+				// Assert
+				require.ErrorIs(t, err, tt.want)
+			})
+		}
+	})
+}
+
+func TestNewEncryptorFromConfig(t *testing.T) {
+	t.Run("creates the encryptor of the keyring", func(t *testing.T) {
+		// Arrange
+		t.Setenv("TEST_CRYPTO_OLD", encodedKey(1))
+		t.Setenv("TEST_CRYPTO_NEW", encodedKey(2))
+
+		// Act
+		sut, err := crypto.NewEncryptorFromConfig(context.Background(), crypto.Config{
+			PrimaryKey: "new",
+			Keys: []crypto.KeyConfig{
+				{ID: "old", Env: "TEST_CRYPTO_OLD"},
+				{ID: "new", Env: "TEST_CRYPTO_NEW"},
+			},
+		}, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "new", sut.PrimaryKeyID())
+	})
+}
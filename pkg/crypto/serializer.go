@@ -0,0 +1,80 @@
+package crypto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName is the name of the GORM serializer of the encrypted columns.
+const SerializerName = "encrypted"
+
+// Serializer is a GORM serializer storing the fields encrypted, as the base64 of the
+// encrypted JSON of the value, so any field type can be encrypted:
+//
+//	type Customer struct {
+//	    ID        uint
+//	    Email     string  `gorm:"serializer:encrypted"`
+//	    TaxNumber *string `gorm:"serializer:encrypted"`
+//	}
+//
+// Nil values are stored as NULL. Values are written with the primary key, so saving a
+// row rotates its columns.
+type Serializer struct {
+	encryptor *Encryptor
+}
+
+// NewSerializer creates the serializer encrypting with encryptor.
+func NewSerializer(encryptor *Encryptor) *Serializer {
+	return &Serializer{encryptor: encryptor}
+}
+
+// RegisterSerializer registers the serializer of encryptor as "encrypted". GORM
+// serializers are global, so the last registered encryptor is used.
+func RegisterSerializer(encryptor *Encryptor) {
+	schema.RegisterSerializer(SerializerName, NewSerializer(encryptor))
+}
+
+// Scan implements schema.SerializerInterface.
+func (s *Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	fieldValue := reflect.New(field.FieldType)
+
+	var encoded string
+	switch value := dbValue.(type) {
+	case nil:
+	case string:
+		encoded = value
+	case []byte:
+		encoded = string(value)
+	default:
+		return fmt.Errorf("%w: unsupported column value %T", ErrInvalidCiphertext, dbValue)
+	}
+
+	if encoded != "" {
+		plaintext, err := s.encryptor.DecryptString(encoded)
+		if err != nil {
+			return fmt.Errorf("decrypt %s: %w", field.Name, err)
+		}
+		if err = json.Unmarshal([]byte(plaintext), fieldValue.Interface()); err != nil {
+			return fmt.Errorf("decode %s: %w", field.Name, err)
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+// Value implements schema.SerializerValuerInterface.
+func (s *Serializer) Value(_ context.Context, field *schema.Field, _ reflect.Value, fieldValue any) (any, error) {
+	plaintext, err := json.Marshal(fieldValue)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", field.Name, err)
+	}
+	if string(plaintext) == "null" {
+		return nil, nil
+	}
+	return s.encryptor.EncryptString(string(plaintext))
+}
@@ -0,0 +1,97 @@
+package crypto_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm/schema"
+
+	"github.com/cristiano-pacheco/bricks/pkg/crypto"
+)
+
+type customer struct {
+	ID        uint
+	Email     string  `gorm:"serializer:encrypted"`
+	TaxNumber *string `gorm:"serializer:encrypted"`
+}
+
+type SerializerTestSuite struct {
+	suite.Suite
+	sut       *crypto.Serializer
+	encryptor *crypto.Encryptor
+	schema    *schema.Schema
+}
+
+func TestSerializerSuite(t *testing.T) {
+	suite.Run(t, new(SerializerTestSuite))
+}
+
+func (s *SerializerTestSuite) SetupTest() {
+	var err error
+	s.encryptor, err = crypto.NewEncryptor([]crypto.Key{newKey, oldKey})
+	s.Require().NoError(err)
+	crypto.RegisterSerializer(s.encryptor)
+	s.sut = crypto.NewSerializer(s.encryptor)
+	s.schema, err = schema.Parse(&customer{}, &sync.Map{}, schema.NamingStrategy{})
+	s.Require().NoError(err)
+}
+
+func (s *SerializerTestSuite) TestValue_EncryptsAndScanDecrypts() {
+	// Arrange
+	field := s.schema.LookUpField("email")
+	var loaded customer
+
+	// Act
+	stored, err := s.sut.Value(context.Background(), field, reflect.Value{}, "ana@example.com")
+	s.Require().NoError(err)
+	scanErr := s.sut.Scan(context.Background(), field, reflect.ValueOf(&loaded), stored)
+
+	// Assert
+	s.Require().NoError(scanErr)
+	s.NotContains(stored, "ana@example.com")
+	plaintext, err := s.encryptor.DecryptString(stored.(string))
+	s.Require().NoError(err)
+	s.JSONEq(`"ana@example.com"`, plaintext)
+	s.Equal("ana@example.com", loaded.Email)
+}
+
+func (s *SerializerTestSuite) TestValue_Nil_StoresNull() {
+	// Arrange
+	field := s.schema.LookUpField("tax_number")
+	taxNumber := "123"
+	loaded := customer{TaxNumber: &taxNumber}
+
+	// Act
+	stored, err := s.sut.Value(context.Background(), field, reflect.Value{}, (*string)(nil))
+	s.Require().NoError(err)
+	scanErr := s.sut.Scan(context.Background(), field, reflect.ValueOf(&loaded), nil)
+
+	// Assert
+	s.Require().NoError(scanErr)
+	s.Nil(stored)
+	s.Nil(loaded.TaxNumber)
+}
+
+func (s *SerializerTestSuite) TestScan_InvalidCiphertext_ReturnsError() {
+	// Arrange
+	field := s.schema.LookUpField("email")
+	var loaded customer
+
+	// Act
+	err := s.sut.Scan(context.Background(), field, reflect.ValueOf(&loaded), []byte("plain text"))
+
+	// Assert
+	s.Require().ErrorIs(err, crypto.ErrInvalidCiphertext)
+}
+
+func (s *SerializerTestSuite) TestRegisterSerializer_RegistersTheEncryptedSerializer() {
+	// Act
+	registered, ok := schema.GetSerializer(crypto.SerializerName)
+
+	// Assert
+	s.True(ok)
+	s.IsType(&crypto.Serializer{}, registered)
+}
@@ -0,0 +1,70 @@
+// Package ctxmeta carries request metadata such as the tenant, user, request and
+// correlation ID through context.Context so that every brick reads it from the same place.
+package ctxmeta
+
+import "context"
+
+type (
+	tenantIDKey      struct{}
+	userIDKey        struct{}
+	apiKeyIDKey      struct{}
+	requestIDKey     struct{}
+	correlationIDKey struct{}
+)
+
+// WithTenantID returns a copy of ctx carrying the tenant ID.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+// TenantID returns the tenant ID stored in ctx, if any.
+func TenantID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, tenantIDKey{})
+}
+
+// WithUserID returns a copy of ctx carrying the authenticated user ID.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserID returns the user ID stored in ctx, if any.
+func UserID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, userIDKey{})
+}
+
+// WithAPIKeyID returns a copy of ctx carrying the ID of the API key the request was
+// authenticated with, never the key itself.
+func WithAPIKeyID(ctx context.Context, apiKeyID string) context.Context {
+	return context.WithValue(ctx, apiKeyIDKey{}, apiKeyID)
+}
+
+// APIKeyID returns the API key ID stored in ctx, if any.
+func APIKeyID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, apiKeyIDKey{})
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID stored in ctx, if any.
+func RequestID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, requestIDKey{})
+}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID, shared by every
+// request and message of the same business operation across services.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationID returns the correlation ID stored in ctx, if any.
+func CorrelationID(ctx context.Context) (string, bool) {
+	return stringValue(ctx, correlationIDKey{})
+}
+
+func stringValue(ctx context.Context, key any) (string, bool) {
+	value, ok := ctx.Value(key).(string)
+	return value, ok && value != ""
+}
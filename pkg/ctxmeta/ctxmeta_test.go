@@ -0,0 +1,48 @@
+package ctxmeta_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextMetadata(t *testing.T) {
+	t.Run("returns stored values", func(t *testing.T) {
+		// Arrange
+		ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+		ctx = ctxmeta.WithUserID(ctx, "user-1")
+		ctx = ctxmeta.WithRequestID(ctx, "req-1")
+		ctx = ctxmeta.WithAPIKeyID(ctx, "key-1")
+
+		// Act
+		tenantID, tenantOK := ctxmeta.TenantID(ctx)
+		userID, userOK := ctxmeta.UserID(ctx)
+		apiKeyID, apiKeyOK := ctxmeta.APIKeyID(ctx)
+		requestID, requestOK := ctxmeta.RequestID(ctx)
+
+		// Assert
+		assert.True(t, tenantOK)
+		assert.Equal(t, "acme", tenantID)
+		assert.True(t, userOK)
+		assert.Equal(t, "user-1", userID)
+		assert.True(t, apiKeyOK)
+		assert.Equal(t, "key-1", apiKeyID)
+		assert.True(t, requestOK)
+		assert.Equal(t, "req-1", requestID)
+	})
+
+	t.Run("reports missing and empty values", func(t *testing.T) {
+		// Arrange
+		ctx := ctxmeta.WithTenantID(context.Background(), "")
+
+		// Act
+		_, tenantOK := ctxmeta.TenantID(ctx)
+		_, userOK := ctxmeta.UserID(ctx)
+
+		// Assert
+		assert.False(t, tenantOK)
+		assert.False(t, userOK)
+	})
+}
@@ -0,0 +1,168 @@
+package ctxmeta
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Headers carrying the request and correlation IDs between services.
+const (
+	HeaderRequestID     = "X-Request-ID"
+	HeaderCorrelationID = "X-Correlation-ID"
+	// HeaderTraceparent is the W3C Trace Context header; its trace ID is used as the
+	// correlation ID when the caller sends no X-Correlation-ID.
+	HeaderTraceparent = "traceparent"
+)
+
+// maxIDLength bounds the IDs accepted from headers, since they end up in every log line.
+const maxIDLength = 128
+
+// Carrier reads and writes the headers of a request or message. http.Header implements
+// it; use MapCarrier for message headers stored in a map.
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+// MapCarrier adapts message headers stored in a map[string]string to Carrier.
+type MapCarrier map[string]string
+
+// Get implements Carrier.
+func (c MapCarrier) Get(key string) string {
+	return c[key]
+}
+
+// Set implements Carrier.
+func (c MapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Inject writes the request and correlation IDs of ctx into carrier, keeping the headers
+// already set by the caller.
+//
+//	ctxmeta.Inject(ctx, req.Header)
+//	ctxmeta.Inject(ctx, ctxmeta.MapCarrier(msg.Headers))
+func Inject(ctx context.Context, carrier Carrier) {
+	if requestID, ok := RequestID(ctx); ok && carrier.Get(HeaderRequestID) == "" {
+		carrier.Set(HeaderRequestID, requestID)
+	}
+	if correlationID, ok := CorrelationID(ctx); ok && carrier.Get(HeaderCorrelationID) == "" {
+		carrier.Set(HeaderCorrelationID, correlationID)
+	}
+}
+
+// Extract returns a copy of ctx carrying the request and correlation IDs read from
+// carrier. The correlation ID falls back to the traceparent trace ID, then to the
+// request ID. Malformed values are ignored.
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	requestID := sanitizeID(carrier.Get(HeaderRequestID))
+	if requestID != "" {
+		ctx = WithRequestID(ctx, requestID)
+	}
+
+	correlationID := sanitizeID(carrier.Get(HeaderCorrelationID))
+	if correlationID == "" {
+		correlationID = traceID(carrier.Get(HeaderTraceparent))
+	}
+	if correlationID == "" {
+		correlationID = requestID
+	}
+	if correlationID != "" {
+		ctx = WithCorrelationID(ctx, correlationID)
+	}
+	return ctx
+}
+
+// NewID returns a random 128-bit ID in hex, the format of generated request IDs.
+func NewID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // never fails, see crypto/rand.Read
+	return hex.EncodeToString(b)
+}
+
+// Middleware stores the request and correlation IDs of the incoming request in its
+// context, generating the request ID when the client sends none, and echoes the request
+// ID in the X-Request-ID response header.
+//
+//	router.Use(ctxmeta.Middleware)
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := Extract(r.Context(), r.Header)
+		requestID, ok := RequestID(ctx)
+		if !ok {
+			requestID = NewID()
+			ctx = WithRequestID(ctx, requestID)
+		}
+		if _, ok = CorrelationID(ctx); !ok {
+			ctx = WithCorrelationID(ctx, requestID)
+		}
+
+		w.Header().Set(HeaderRequestID, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Transport is an http.RoundTripper adding the request and correlation IDs of the
+// request context to outbound requests.
+type Transport struct {
+	// Base performs the requests; http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// NewTransport returns a Transport wrapping base.
+//
+//	client := &http.Client{Transport: ctxmeta.NewTransport(nil)}
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	_, hasRequestID := RequestID(req.Context())
+	_, hasCorrelationID := CorrelationID(req.Context())
+	if !hasRequestID && !hasCorrelationID {
+		return base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	Inject(req.Context(), req.Header)
+	return base.RoundTrip(req)
+}
+
+// traceID returns the trace ID of a version 00 traceparent header, or "" when it is
+// malformed or all zeros.
+func traceID(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 {
+		return ""
+	}
+	id := parts[1]
+	if _, err := hex.DecodeString(id); err != nil || strings.Trim(id, "0") == "" || strings.ToLower(id) != id {
+		return ""
+	}
+	return id
+}
+
+// sanitizeID returns id when it is a printable ASCII value of acceptable length, so
+// clients cannot inject control characters into logs.
+func sanitizeID(id string) string {
+	id = strings.TrimSpace(id)
+	if len(id) > maxIDLength {
+		return ""
+	}
+	for i := range len(id) {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return ""
+		}
+	}
+	return id
+}
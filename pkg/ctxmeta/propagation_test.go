@@ -0,0 +1,173 @@
+package ctxmeta_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	t.Run("reads the request and correlation IDs", func(t *testing.T) {
+		// Arrange
+		header := http.Header{}
+		header.Set(ctxmeta.HeaderRequestID, "req-1")
+		header.Set(ctxmeta.HeaderCorrelationID, "corr-1")
+
+		// Act
+		ctx := ctxmeta.Extract(context.Background(), header)
+
+		// Assert
+		requestID, _ := ctxmeta.RequestID(ctx)
+		correlationID, _ := ctxmeta.CorrelationID(ctx)
+		assert.Equal(t, "req-1", requestID)
+		assert.Equal(t, "corr-1", correlationID)
+	})
+
+	t.Run("falls back to the traceparent trace ID", func(t *testing.T) {
+		// Arrange
+		carrier := ctxmeta.MapCarrier{
+			ctxmeta.HeaderRequestID:   "req-1",
+			ctxmeta.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		}
+
+		// Act
+		ctx := ctxmeta.Extract(context.Background(), carrier)
+
+		// Assert
+		correlationID, _ := ctxmeta.CorrelationID(ctx)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", correlationID)
+	})
+
+	t.Run("falls back to the request ID", func(t *testing.T) {
+		// Arrange
+		carrier := ctxmeta.MapCarrier{
+			ctxmeta.HeaderRequestID:   "req-1",
+			ctxmeta.HeaderTraceparent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		}
+
+		// Act
+		ctx := ctxmeta.Extract(context.Background(), carrier)
+
+		// Assert
+		correlationID, _ := ctxmeta.CorrelationID(ctx)
+		assert.Equal(t, "req-1", correlationID)
+	})
+
+	t.Run("ignores malformed IDs", func(t *testing.T) {
+		// Arrange
+		carrier := ctxmeta.MapCarrier{
+			ctxmeta.HeaderRequestID:     "req\n1",
+			ctxmeta.HeaderCorrelationID: strings.Repeat("a", 129),
+		}
+
+		// Act
+		ctx := ctxmeta.Extract(context.Background(), carrier)
+
+		// Assert
+		_, requestOK := ctxmeta.RequestID(ctx)
+		_, correlationOK := ctxmeta.CorrelationID(ctx)
+		assert.False(t, requestOK)
+		assert.False(t, correlationOK)
+	})
+}
+
+func TestInject(t *testing.T) {
+	t.Run("writes the IDs of ctx", func(t *testing.T) {
+		// Arrange
+		ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+		ctx = ctxmeta.WithCorrelationID(ctx, "corr-1")
+		carrier := ctxmeta.MapCarrier{}
+
+		// Act
+		ctxmeta.Inject(ctx, carrier)
+
+		// Assert
+		assert.Equal(t, ctxmeta.MapCarrier{
+			ctxmeta.HeaderRequestID:     "req-1",
+			ctxmeta.HeaderCorrelationID: "corr-1",
+		}, carrier)
+	})
+
+	t.Run("keeps the headers set by the caller", func(t *testing.T) {
+		// Arrange
+		ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+		header := http.Header{}
+		header.Set(ctxmeta.HeaderRequestID, "custom")
+
+		// Act
+		ctxmeta.Inject(ctx, header)
+
+		// Assert
+		assert.Equal(t, "custom", header.Get(ctxmeta.HeaderRequestID))
+		assert.Empty(t, header.Get(ctxmeta.HeaderCorrelationID))
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("generates the request ID when missing", func(t *testing.T) {
+		// Arrange
+		var requestID, correlationID string
+		handler := ctxmeta.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			requestID, _ = ctxmeta.RequestID(r.Context())
+			correlationID, _ = ctxmeta.CorrelationID(r.Context())
+		}))
+		rr := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Assert
+		assert.Len(t, requestID, 32)
+		assert.Equal(t, requestID, correlationID)
+		assert.Equal(t, requestID, rr.Header().Get(ctxmeta.HeaderRequestID))
+	})
+
+	t.Run("keeps the request ID sent by the client", func(t *testing.T) {
+		// Arrange
+		var requestID string
+		handler := ctxmeta.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			requestID, _ = ctxmeta.RequestID(r.Context())
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(ctxmeta.HeaderRequestID, "req-1")
+		rr := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, "req-1", requestID)
+		assert.Equal(t, "req-1", rr.Header().Get(ctxmeta.HeaderRequestID))
+	})
+}
+
+func TestTransport(t *testing.T) {
+	// Arrange
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: ctxmeta.NewTransport(nil)}
+	ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+	ctx = ctxmeta.WithCorrelationID(ctx, "corr-1")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	// Act
+	resp, err := client.Do(req)
+
+	// Assert
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "req-1", received.Get(ctxmeta.HeaderRequestID))
+	assert.Equal(t, "corr-1", received.Get(ctxmeta.HeaderCorrelationID))
+	assert.Empty(t, req.Header.Get(ctxmeta.HeaderRequestID), "the original request is not modified")
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+// Audit column names filled by the AuditPlugin.
+const (
+	ColumnCreatedAt = "created_at"
+	ColumnUpdatedAt = "updated_at"
+	ColumnCreatedBy = "created_by"
+	ColumnUpdatedBy = "updated_by"
+	ColumnDeletedAt = "deleted_at"
+)
+
+// AuditColumns are the conventional audit columns, embedded in models:
+//
+//	type Order struct {
+//	    ID    uint64
+//	    Total int64
+//	    database.AuditColumns
+//	}
+//
+// The *By columns are NULL when the context carries no user.
+type AuditColumns struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CreatedBy *string
+	UpdatedBy *string
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// AuditPlugin is the GORM plugin that fills the audit columns of the models that have them.
+//
+// On create it sets created_by and updated_by to the ctxmeta user, and on update it sets
+// updated_by. created_at and updated_at are set when GORM does not already manage them.
+// deleted_at is managed by GORM's soft delete with gorm.DeletedAt.
+type AuditPlugin struct {
+	now func() time.Time
+}
+
+// NewAuditPlugin creates the AuditPlugin.
+func NewAuditPlugin() *AuditPlugin {
+	return &AuditPlugin{now: time.Now}
+}
+
+// Name implements gorm.Plugin.
+func (p *AuditPlugin) Name() string {
+	return "bricks:audit"
+}
+
+// Initialize implements gorm.Plugin.
+func (p *AuditPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("bricks:audit_create", p.beforeCreate); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:update").Register("bricks:audit_update", p.beforeUpdate)
+}
+
+func (p *AuditPlugin) beforeCreate(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt.Schema == nil {
+		return
+	}
+
+	now := p.now()
+	actor, hasActor := ctxmeta.UserID(stmt.Context)
+	for _, column := range []string{ColumnCreatedAt, ColumnUpdatedAt} {
+		if field := stmt.Schema.LookUpField(column); field != nil && !managedTime(field) {
+			setZeroFields(stmt, field, now)
+		}
+	}
+	if !hasActor {
+		return
+	}
+	for _, column := range []string{ColumnCreatedBy, ColumnUpdatedBy} {
+		if field := stmt.Schema.LookUpField(column); field != nil {
+			setZeroFields(stmt, field, actor)
+		}
+	}
+}
+
+func (p *AuditPlugin) beforeUpdate(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt.Schema == nil {
+		return
+	}
+
+	if field := stmt.Schema.LookUpField(ColumnUpdatedAt); field != nil && !managedTime(field) {
+		setColumn(stmt, field, p.now())
+	}
+	if actor, ok := ctxmeta.UserID(stmt.Context); ok {
+		if field := stmt.Schema.LookUpField(ColumnUpdatedBy); field != nil {
+			setColumn(stmt, field, actor)
+		}
+	}
+}
+
+// managedTime reports whether GORM already fills the timestamp field.
+func managedTime(field *schema.Field) bool {
+	return field.AutoCreateTime != 0 || field.AutoUpdateTime != 0
+}
+
+// setZeroFields sets the field of every created row where it is zero.
+func setZeroFields(stmt *gorm.Statement, field *schema.Field, value any) {
+	setFields(stmt, field, value, true)
+}
+
+// setFields sets the field of every created row, or only where it is zero.
+func setFields(stmt *gorm.Statement, field *schema.Field, value any, onlyZero bool) {
+	set := func(row reflect.Value) {
+		if _, isZero := field.ValueOf(stmt.Context, row); isZero || !onlyZero {
+			_ = stmt.AddError(field.Set(stmt.Context, row, value))
+		}
+	}
+
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range stmt.ReflectValue.Len() {
+			set(reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		set(stmt.ReflectValue)
+	default:
+	}
+}
+
+// setColumn sets the updated column and keeps it in an explicit column selection,
+// like GORM does for updated_at.
+func setColumn(stmt *gorm.Statement, field *schema.Field, value any) {
+	stmt.SetColumn(field.DBName, value, true)
+	if len(stmt.Selects) > 0 && !slices.Contains(stmt.Selects, "*") &&
+		!slices.Contains(stmt.Selects, field.DBName) && !slices.Contains(stmt.Selects, field.Name) {
+		stmt.Selects = append(stmt.Selects, field.DBName)
+	}
+}
+
+// IncludeDeleted is a query scope that includes the soft-deleted rows.
+//
+//	db.Scopes(database.IncludeDeleted).Find(&orders)
+func IncludeDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// OnlyDeleted is a query scope that selects only the soft-deleted rows.
+func OnlyDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where(clause.Neq{Column: deletedAtColumn(), Value: nil})
+}
+
+// ExcludeDeleted is a query scope that excludes the soft-deleted rows, for queries that
+// are unscoped or on models without gorm.DeletedAt.
+func ExcludeDeleted(db *gorm.DB) *gorm.DB {
+	return db.Where(clause.Eq{Column: deletedAtColumn(), Value: nil})
+}
+
+func deletedAtColumn() clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: ColumnDeletedAt}
+}
@@ -0,0 +1,182 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+type order struct {
+	ID    int64
+	Total int64
+	database.AuditColumns
+}
+
+type ledgerEntry struct {
+	ID        int64
+	Amount    int64
+	CreatedBy string
+}
+
+type AuditPluginTestSuite struct {
+	suite.Suite
+	statements []string
+	db         *gorm.DB
+}
+
+func TestAuditPluginTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditPluginTestSuite))
+}
+
+// sqlRecorder keeps the statements of a dry run session
+type sqlRecorder struct {
+	logger.Interface
+	statements *[]string
+}
+
+func (r sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	statement, _ := fc()
+	*r.statements = append(*r.statements, statement)
+}
+
+func (s *AuditPluginTestSuite) SetupTest() {
+	s.statements = nil
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{
+			DryRun:                 true,
+			DisableAutomaticPing:   true,
+			SkipDefaultTransaction: true,
+			Logger:                 sqlRecorder{Interface: logger.Discard, statements: &s.statements},
+		},
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(db.Use(database.NewAuditPlugin()))
+	s.db = db
+}
+
+func (s *AuditPluginTestSuite) TestCreate_WithUser_SetsCreatedByAndUpdatedBy() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-1")
+	entity := &order{Total: 10}
+
+	// Act
+	err := s.db.WithContext(ctx).Create(entity).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().NotNil(entity.CreatedBy)
+	s.Require().NotNil(entity.UpdatedBy)
+	s.Equal("user-1", *entity.CreatedBy)
+	s.Equal("user-1", *entity.UpdatedBy)
+	s.False(entity.CreatedAt.IsZero())
+}
+
+func (s *AuditPluginTestSuite) TestCreate_Batch_SetsEveryRow() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-1")
+	entities := []order{{Total: 10}, {Total: 20}}
+
+	// Act
+	err := s.db.WithContext(ctx).Create(&entities).Error
+
+	// Assert
+	s.Require().NoError(err)
+	for _, entity := range entities {
+		s.Require().NotNil(entity.CreatedBy)
+		s.Equal("user-1", *entity.CreatedBy)
+	}
+}
+
+func (s *AuditPluginTestSuite) TestCreate_WithoutUser_LeavesActorNull() {
+	// Arrange
+	entity := &order{Total: 10}
+
+	// Act
+	err := s.db.WithContext(context.Background()).Create(entity).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Nil(entity.CreatedBy)
+	s.Nil(entity.UpdatedBy)
+}
+
+func (s *AuditPluginTestSuite) TestCreate_KeepsExplicitActor() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-1")
+	importer := "importer"
+	entity := &order{Total: 10, AuditColumns: database.AuditColumns{CreatedBy: &importer}}
+
+	// Act
+	err := s.db.WithContext(ctx).Create(entity).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("importer", *entity.CreatedBy)
+	s.Equal("user-1", *entity.UpdatedBy)
+}
+
+func (s *AuditPluginTestSuite) TestCreate_StringActorColumn_SetsValue() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-1")
+	entity := &ledgerEntry{Amount: 10}
+
+	// Act
+	err := s.db.WithContext(ctx).Create(entity).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("user-1", entity.CreatedBy)
+}
+
+func (s *AuditPluginTestSuite) TestUpdateColumn_WithUser_SetsUpdatedBy() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-2")
+
+	// Act
+	err := s.db.WithContext(ctx).Model(&order{ID: 1}).Update("total", 30).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(s.statements, 1)
+	s.Contains(s.statements[0], `"updated_by"='user-2'`)
+}
+
+func (s *AuditPluginTestSuite) TestUpdates_SelectedColumns_KeepsUpdatedBy() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-2")
+
+	// Act
+	err := s.db.WithContext(ctx).Model(&order{ID: 1}).Select("total").Updates(&order{Total: 30}).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(s.statements, 1)
+	s.Contains(s.statements[0], `"total"=30`)
+	s.Contains(s.statements[0], `"updated_by"='user-2'`)
+}
+
+func (s *AuditPluginTestSuite) TestScopes_FilterSoftDeletedRows() {
+	// Arrange
+	var orders []order
+
+	// Act
+	s.db.Scopes(database.IncludeDeleted).Find(&orders)
+	s.db.Scopes(database.OnlyDeleted).Find(&orders)
+	s.db.Unscoped().Scopes(database.ExcludeDeleted).Find(&orders)
+
+	// Assert
+	s.Equal([]string{
+		`SELECT * FROM "orders"`,
+		`SELECT * FROM "orders" WHERE "orders"."deleted_at" IS NOT NULL`,
+		`SELECT * FROM "orders" WHERE "orders"."deleted_at" IS NULL`,
+	}, s.statements)
+}
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// DefaultBulkBatchSize is the batch size of BulkInsert and BulkUpsert when none is given
+	DefaultBulkBatchSize = 1000
+
+	// maxBindParameters is the maximum number of bind parameters of a Postgres statement
+	maxBindParameters = 65535
+
+	bulkOperationInsert = "insert"
+	bulkOperationUpsert = "upsert"
+)
+
+// ProgressFunc reports the rows processed so far out of total, zero when unknown.
+type ProgressFunc func(done, total int64)
+
+type bulkOptions struct {
+	onConflict *clause.OnConflict
+	progress   ProgressFunc
+	metrics    *BulkMetrics
+}
+
+// BulkOption configures BulkInsert, BulkUpsert, CopyFrom and Iterate; the conflict
+// options only apply to the inserts.
+type BulkOption func(*bulkOptions)
+
+// OnConflictDoNothing skips the rows conflicting on columns, or on any constraint
+// without columns. BulkInsert then returns the number of rows actually inserted.
+func OnConflictDoNothing(columns ...string) BulkOption {
+	return func(o *bulkOptions) {
+		o.onConflict = &clause.OnConflict{Columns: conflictColumns(columns), DoNothing: true}
+	}
+}
+
+// OnConflictUpdate updates columns of the rows conflicting on conflict, instead of
+// every column as BulkUpsert does by default.
+func OnConflictUpdate(conflict []string, columns ...string) BulkOption {
+	return func(o *bulkOptions) {
+		o.onConflict = &clause.OnConflict{Columns: conflictColumns(conflict), DoUpdates: clause.AssignmentColumns(columns)}
+	}
+}
+
+// WithProgress calls fn after each batch, e.g. to log the progress of a long import.
+// CopyFrom calls it every DefaultBulkBatchSize rows from the goroutine reading the source.
+func WithProgress(fn ProgressFunc) BulkOption {
+	return func(o *bulkOptions) {
+		if fn != nil {
+			o.progress = fn
+		}
+	}
+}
+
+// WithBulkMetrics records the written rows and the duration of the operation in metrics.
+func WithBulkMetrics(metrics *BulkMetrics) BulkOption {
+	return func(o *bulkOptions) {
+		if metrics != nil {
+			o.metrics = metrics
+		}
+	}
+}
+
+func resolveBulkOptions(opts []BulkOption) bulkOptions {
+	var bulk bulkOptions
+	for _, opt := range opts {
+		opt(&bulk)
+	}
+	return bulk
+}
+
+// BulkInsert inserts rows in batches of batchSize rows, one INSERT statement each, and
+// returns the number of rows inserted. A batchSize of zero or less uses
+// DefaultBulkBatchSize; larger batches are split to stay under the 65535 bind parameters
+// of a Postgres statement.
+//
+// The batches join the transaction of the context. Without one, each batch commits on
+// its own: after an error, the rows of the previous batches stay inserted and their count
+// is returned with the error. Run it in TxManager.WithinTransaction for all or nothing.
+//
+//	inserted, err := database.BulkInsert(ctx, db, products, 500,
+//	    database.OnConflictDoNothing("sku"),
+//	    database.WithProgress(func(done, total int64) { log.Info("import", "done", done, "total", total) }),
+//	)
+func BulkInsert[T any](ctx context.Context, db *gorm.DB, rows []T, batchSize int, opts ...BulkOption) (int64, error) {
+	return bulkCreate(ctx, db, rows, batchSize, bulkOperationInsert, resolveBulkOptions(opts))
+}
+
+// BulkUpsert inserts rows like BulkInsert, updating every other column of the rows
+// conflicting on conflictColumns, e.g. the primary key. OnConflictUpdate restricts the
+// updated columns.
+func BulkUpsert[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	rows []T,
+	batchSize int,
+	conflict []string,
+	opts ...BulkOption,
+) (int64, error) {
+	bulk := bulkOptions{onConflict: &clause.OnConflict{Columns: conflictColumns(conflict), UpdateAll: true}}
+	for _, opt := range opts {
+		opt(&bulk)
+	}
+	return bulkCreate(ctx, db, rows, batchSize, bulkOperationUpsert, bulk)
+}
+
+func bulkCreate[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	rows []T,
+	batchSize int,
+	operation string,
+	bulk bulkOptions,
+) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	tx := DB(ctx, db)
+	table, fields, err := modelOf[T](tx)
+	if err != nil {
+		return 0, err
+	}
+	batchSize = boundBatchSize(batchSize, fields)
+	if bulk.onConflict != nil {
+		tx = tx.Clauses(*bulk.onConflict)
+	}
+
+	start := time.Now()
+	var written int64
+	total := int64(len(rows))
+	for offset := 0; offset < len(rows); offset += batchSize {
+		batch := rows[offset:min(offset+batchSize, len(rows))]
+		result := tx.Create(&batch)
+		if result.Error != nil {
+			bulk.metrics.observe(operation, table, written, start)
+			return written, fmt.Errorf("bulk %s into %s: %w", operation, table, result.Error)
+		}
+		written += result.RowsAffected
+		if bulk.progress != nil {
+			bulk.progress(int64(offset+len(batch)), total)
+		}
+	}
+	bulk.metrics.observe(operation, table, written, start)
+	return written, nil
+}
+
+// modelOf returns the table and the number of columns of T.
+func modelOf[T any](db *gorm.DB) (string, int, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return "", 0, fmt.Errorf("bulk: parse model: %w", err)
+	}
+	table := stmt.Schema.Table
+	if db.Statement.Table != "" {
+		table = db.Statement.Table
+	}
+	return table, len(stmt.Schema.DBNames), nil
+}
+
+// boundBatchSize returns batchSize, or the default, lowered so a batch of rows with
+// fields columns stays under the bind parameters limit.
+func boundBatchSize(batchSize, fields int) int {
+	if batchSize <= 0 {
+		batchSize = DefaultBulkBatchSize
+	}
+	if fields > 0 && batchSize*fields > maxBindParameters {
+		batchSize = maxBindParameters / fields
+	}
+	return max(batchSize, 1)
+}
+
+func conflictColumns(names []string) []clause.Column {
+	columns := make([]clause.Column, 0, len(names))
+	for _, name := range names {
+		columns = append(columns, clause.Column{Name: strings.TrimSpace(name)})
+	}
+	return columns
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	bulkRowsMetricName     = "database_bulk_rows_total"
+	bulkDurationMetricName = "database_bulk_duration_seconds"
+)
+
+// BulkMetrics records the rows processed by BulkInsert, BulkUpsert, CopyFrom and Iterate, labeled
+// with the operation and the table.
+type BulkMetrics struct {
+	rows     *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewBulkMetrics creates the BulkMetrics and registers them on registerer.
+func NewBulkMetrics(registerer prometheus.Registerer) (*BulkMetrics, error) {
+	labels := []string{"operation", "table"}
+	metrics := &BulkMetrics{
+		rows: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: bulkRowsMetricName,
+			Help: "Total rows processed by the bulk operations",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    bulkDurationMetricName,
+			Help:    "Duration of the bulk operations in seconds",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+		}, labels),
+	}
+	for _, collector := range []prometheus.Collector{metrics.rows, metrics.duration} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register database bulk metrics: %w", err)
+		}
+	}
+	return metrics, nil
+}
+
+// observe records an operation; a nil BulkMetrics records nothing.
+func (m *BulkMetrics) observe(operation, table string, rows int64, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.rows.WithLabelValues(operation, table).Add(float64(rows))
+	m.duration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,164 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+type product struct {
+	ID    int64
+	SKU   string
+	Name  string
+	Price int64
+}
+
+type BulkTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	statements []string
+}
+
+func TestBulkTestSuite(t *testing.T) {
+	suite.Run(t, new(BulkTestSuite))
+}
+
+func (s *BulkTestSuite) SetupTest() {
+	s.statements = nil
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{
+			DryRun:                 true,
+			DisableAutomaticPing:   true,
+			SkipDefaultTransaction: true,
+			Logger:                 sqlRecorder{Interface: logger.Discard, statements: &s.statements},
+		},
+	)
+	s.Require().NoError(err)
+	s.db = db
+}
+
+func (s *BulkTestSuite) products(n int) []product {
+	rows := make([]product, n)
+	for i := range rows {
+		rows[i] = product{ID: int64(i + 1), SKU: "sku", Name: "name", Price: 100}
+	}
+	return rows
+}
+
+func (s *BulkTestSuite) TestBulkInsert_InsertsInBatchesAndReportsTheProgress() {
+	// Arrange
+	var progress [][2]int64
+
+	// Act
+	_, err := database.BulkInsert(context.Background(), s.db, s.products(5), 2,
+		database.WithProgress(func(done, total int64) {
+			progress = append(progress, [2]int64{done, total})
+		}))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Len(s.statements, 3)
+	s.Contains(s.statements[0], `INSERT INTO "products"`)
+	s.Equal([][2]int64{{2, 5}, {4, 5}, {5, 5}}, progress)
+}
+
+func (s *BulkTestSuite) TestBulkInsert_SkipsTheConflictingRows() {
+	// Act
+	_, err := database.BulkInsert(context.Background(), s.db, s.products(2), 0, database.OnConflictDoNothing("sku"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(s.statements, 1)
+	s.Contains(s.statements[0], `ON CONFLICT ("sku") DO NOTHING`)
+}
+
+func (s *BulkTestSuite) TestBulkInsert_SplitsTheBatchesOverTheBindParametersLimit() {
+	// Arrange
+	rows := s.products(20_000)
+
+	// Act
+	_, err := database.BulkInsert(context.Background(), s.db, rows, 20_000)
+
+	// Assert
+	s.Require().NoError(err)
+	// 4 columns per row: at most 16383 rows per statement
+	s.Len(s.statements, 2)
+}
+
+func (s *BulkTestSuite) TestBulkUpsert_UpdatesTheOtherColumns() {
+	// Act
+	_, err := database.BulkUpsert(context.Background(), s.db, s.products(2), 100, []string{"sku"})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(s.statements, 1)
+	s.Contains(s.statements[0], `ON CONFLICT ("sku") DO UPDATE SET`)
+	s.Contains(s.statements[0], `"price"="excluded"."price"`)
+}
+
+func (s *BulkTestSuite) TestBulkUpsert_UpdatesOnlyTheGivenColumns() {
+	// Act
+	_, err := database.BulkUpsert(context.Background(), s.db, s.products(2), 100, nil,
+		database.OnConflictUpdate([]string{"sku"}, "price"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(s.statements, 1)
+	s.Contains(s.statements[0], `ON CONFLICT ("sku") DO UPDATE SET "price"="excluded"."price"`)
+	s.NotContains(s.statements[0], `"name"="excluded"."name"`)
+}
+
+func (s *BulkTestSuite) TestBulkInsert_RecordsTheMetrics() {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	metrics, err := database.NewBulkMetrics(registry)
+	s.Require().NoError(err)
+
+	// Act
+	_, err = database.BulkInsert(context.Background(), s.db, s.products(3), 2, database.WithBulkMetrics(metrics))
+
+	// Assert
+	s.Require().NoError(err)
+	count, err := testutil.GatherAndCount(registry, "database_bulk_duration_seconds")
+	s.Require().NoError(err)
+	s.Equal(1, count)
+}
+
+func (s *BulkTestSuite) TestBulkInsert_NoRows_DoesNothing() {
+	// Act
+	inserted, err := database.BulkInsert(context.Background(), s.db, []product{}, 10)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Zero(inserted)
+	s.Empty(s.statements)
+}
+
+func (s *BulkTestSuite) TestIterate_ReadsTheBatchesByPrimaryKey() {
+	// Arrange
+	called := false
+
+	// Act
+	processed, err := database.Iterate(context.Background(), s.db,
+		func(db *gorm.DB) *gorm.DB { return db.Where("price > ?", 10) },
+		50,
+		func(context.Context, []product) error {
+			called = true
+			return nil
+		})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Zero(processed)
+	s.False(called)
+	s.Equal([]string{`SELECT * FROM "products" WHERE price > 10 ORDER BY "products"."id" LIMIT 50`}, s.statements)
+}
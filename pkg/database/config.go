@@ -51,6 +51,9 @@ type Config struct {
 	IdleInTransaction  int // in milliseconds
 	ConnectTimeout     int // in seconds
 	PreferSimpleProtol bool
+
+	// Multi-tenancy
+	Tenancy TenancyConfig
 }
 
 // Validate validates the database configuration
@@ -70,7 +73,7 @@ func (c Config) Validate() error {
 	if c.Port > math.MaxUint16 {
 		return fmt.Errorf("%w: %d", ErrInvalidPortNumber, c.Port)
 	}
-	return nil
+	return c.Tenancy.Validate()
 }
 
 // DSN generates a GORM-compatible DSN string
@@ -210,6 +213,7 @@ func (c Config) Clone() Config {
 		IdleInTransaction:           c.IdleInTransaction,
 		ConnectTimeout:              c.ConnectTimeout,
 		PreferSimpleProtol:          c.PreferSimpleProtol,
+		Tenancy:                     c.Tenancy,
 	}
 }
 
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+const bulkOperationCopy = "copy"
+
+// CopySource yields the rows of CopyFrom; it is the pgx.CopyFromSource, so the pgx
+// helpers such as pgx.CopyFromRows also fit.
+type CopySource = pgx.CopyFromSource
+
+// CopyFromSlice returns the CopySource of rows, with values returning the column values
+// of a row in the order of the CopyFrom columns.
+func CopyFromSlice[T any](rows []T, values func(row T) ([]any, error)) CopySource {
+	return pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+		return values(rows[i])
+	})
+}
+
+// CopyFrom loads the rows of source into columns of table with the Postgres COPY
+// protocol, the fastest way to load millions of rows, and returns the number of rows
+// copied. Qualify the table with its schema with the schema tenancy strategy.
+//
+// COPY runs on a connection of its own and is all or nothing. It cannot join the
+// transaction of the context: CopyFrom returns ErrCopyInTransaction when there is one.
+//
+//	copied, err := database.CopyFrom(ctx, db, "events", []string{"id", "type", "payload"},
+//	    database.CopyFromSlice(events, func(e Event) ([]any, error) {
+//	        return []any{e.ID, e.Type, e.Payload}, nil
+//	    }))
+func CopyFrom(
+	ctx context.Context,
+	db *gorm.DB,
+	table string,
+	columns []string,
+	source CopySource,
+	opts ...BulkOption,
+) (int64, error) {
+	if _, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return 0, ErrCopyInTransaction
+	}
+	bulk := resolveBulkOptions(opts)
+	if bulk.progress != nil {
+		source = &progressSource{CopySource: source, progress: bulk.progress}
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return 0, fmt.Errorf("copy into %s: %w", table, err)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("copy into %s: %w", table, err)
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	copied, err := copyOnConn(ctx, conn, table, columns, source)
+	bulk.metrics.observe(bulkOperationCopy, table, copied, start)
+	if err != nil {
+		return copied, fmt.Errorf("copy into %s: %w", table, err)
+	}
+	if progress, ok := source.(*progressSource); ok {
+		progress.report()
+	}
+	return copied, nil
+}
+
+func copyOnConn(ctx context.Context, conn *sql.Conn, table string, columns []string, source CopySource) (int64, error) {
+	var copied int64
+	err := conn.Raw(func(driverConn any) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("%w: %T", ErrCopyUnsupported, driverConn)
+		}
+		var err error
+		copied, err = pgxConn.Conn().CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, source)
+		return err
+	})
+	return copied, err
+}
+
+// progressSource reports the progress of CopyFrom every DefaultBulkBatchSize rows read.
+type progressSource struct {
+	CopySource
+
+	progress ProgressFunc
+	read     int64
+	reported int64
+}
+
+func (s *progressSource) Next() bool {
+	if !s.CopySource.Next() {
+		return false
+	}
+	s.read++
+	if s.read%DefaultBulkBatchSize == 0 {
+		s.report()
+	}
+	return true
+}
+
+// report reports the rows read, unless they already were.
+func (s *progressSource) report() {
+	if s.reported == s.read {
+		return
+	}
+	s.reported = s.read
+	s.progress(s.read, 0)
+}
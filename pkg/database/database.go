@@ -9,6 +9,9 @@ import (
 	"go.uber.org/fx"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+
+	// Maps gorm.ErrRecordNotFound to errs.ErrRecordNotFound
+	_ "github.com/cristiano-pacheco/bricks/pkg/errs/errsgorm"
 )
 
 const (
@@ -22,7 +25,7 @@ const (
 )
 
 // New creates a new database connection with automatic retry and connection pool configuration.
-func New(cfg Config) (*gorm.DB, error) {
+func New(cfg Config, opts ...Option) (*gorm.DB, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
 	}
@@ -36,19 +39,36 @@ func New(cfg Config) (*gorm.DB, error) {
 		return nil, errConnectionPool
 	}
 
+	dbOptions := resolveOptions(opts)
+	plugins := dbOptions.plugins
+	if cfg.Tenancy.Strategy != TenancyNone {
+		plugins = append(plugins, NewTenancyPlugin(cfg.Tenancy))
+	}
+	if dbOptions.healthMonitor != nil {
+		plugins = append(plugins, newHealthMonitor(cfg, dbOptions.healthMonitor))
+	}
+	for _, plugin := range plugins {
+		if errPlugin := db.Use(plugin); errPlugin != nil {
+			return nil, fmt.Errorf("failed to register plugin %s: %w", plugin.Name(), errPlugin)
+		}
+	}
+
 	return db, nil
 }
 
 // NewWithLifecycle creates a new database connection with fx.Lifecycle management.
-// The connection is automatically closed when the application stops.
-func NewWithLifecycle(cfg Config, lc fx.Lifecycle) (*gorm.DB, error) {
-	db, err := New(cfg)
+// The connection and its health monitor are automatically closed when the application stops.
+func NewWithLifecycle(cfg Config, lc fx.Lifecycle, opts ...Option) (*gorm.DB, error) {
+	db, err := New(cfg, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	lc.Append(fx.Hook{
 		OnStop: func(_ context.Context) error {
+			if monitor, ok := HealthMonitorFor(db); ok {
+				monitor.Stop()
+			}
 			sqlDB, errGetSQLDB := db.DB()
 			if errGetSQLDB != nil {
 				return fmt.Errorf("failed to get underlying sql.DB: %w", errGetSQLDB)
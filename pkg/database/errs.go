@@ -26,6 +26,24 @@ var (
 
 	// ErrMissingPort indicates that the database port is required but not provided
 	ErrMissingPort = errors.New("database port is required")
+
+	// ErrInvalidTenancyStrategy indicates that the tenancy strategy is not supported
+	ErrInvalidTenancyStrategy = errors.New("invalid tenancy strategy")
+
+	// ErrMissingTenant indicates that a tenant scoped query has no tenant in its context
+	ErrMissingTenant = errors.New("tenant is required")
+
+	// ErrInvalidTenant indicates that the tenant ID cannot name a schema
+	ErrInvalidTenant = errors.New("invalid tenant")
+
+	// ErrDatabaseUnavailable indicates that the health monitor reports the database down
+	ErrDatabaseUnavailable = errors.New("database unavailable")
+
+	// ErrCopyInTransaction indicates that CopyFrom was called with a transaction in its context
+	ErrCopyInTransaction = errors.New("copy cannot join the transaction of the context")
+
+	// ErrCopyUnsupported indicates that the connection is not a pgx connection supporting COPY
+	ErrCopyUnsupported = errors.New("copy requires a pgx connection")
 )
 
 // ConnectionError wraps connection errors with additional context
@@ -0,0 +1,9 @@
+package database
+
+import "time"
+
+func NewTestHealthMonitor(cfg Config, interval time.Duration, opts ...HealthMonitorOption) *HealthMonitor {
+	return newHealthMonitor(cfg, append([]HealthMonitorOption{func(o *healthMonitorOptions) {
+		o.interval = interval
+	}}, opts...))
+}
@@ -0,0 +1,22 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// ErrInvalidValue is returned by the value parsers of the fields.
+var ErrInvalidValue = errors.New("invalid filter value")
+
+// invalidFilter returns the 400 INVALID_FILTER error of the query parameter param.
+func invalidFilter(param, message string) error {
+	return errs.New(
+		"INVALID_FILTER",
+		fmt.Sprintf("request contains an invalid filter %q: %s", param, message),
+		http.StatusBadRequest,
+		[]errs.Detail{{Field: param, Message: message}},
+	)
+}
@@ -0,0 +1,177 @@
+// Package filter builds the conditions of dynamic queries as GORM clause expressions:
+// the columns are quoted and the values bound as parameters, so neither can inject SQL.
+package filter
+
+import (
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Expr is a filter condition. Any GORM clause expression is an Expr.
+type Expr = clause.Expression
+
+// likeEscaper escapes the LIKE wildcards, with the default backslash escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Eq matches the rows whose column equals value; a nil value matches NULL.
+func Eq(column string, value any) Expr {
+	return clause.Eq{Column: columnOf(column), Value: value}
+}
+
+// Neq matches the rows whose column differs from value.
+func Neq(column string, value any) Expr {
+	return clause.Neq{Column: columnOf(column), Value: value}
+}
+
+// Gt matches the rows whose column is greater than value.
+func Gt(column string, value any) Expr {
+	return clause.Gt{Column: columnOf(column), Value: value}
+}
+
+// Gte matches the rows whose column is greater than or equal to value.
+func Gte(column string, value any) Expr {
+	return clause.Gte{Column: columnOf(column), Value: value}
+}
+
+// Lt matches the rows whose column is less than value.
+func Lt(column string, value any) Expr {
+	return clause.Lt{Column: columnOf(column), Value: value}
+}
+
+// Lte matches the rows whose column is less than or equal to value.
+func Lte(column string, value any) Expr {
+	return clause.Lte{Column: columnOf(column), Value: value}
+}
+
+// Between matches the rows whose column is between from and to, both included.
+func Between(column string, from, to any) Expr {
+	return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []any{columnOf(column), from, to}}
+}
+
+// In matches the rows whose column is one of values, a slice. An empty slice matches no row.
+func In(column string, values any) Expr {
+	return clause.IN{Column: columnOf(column), Values: toSlice(values)}
+}
+
+// NotIn matches the rows whose column is none of values, a slice.
+func NotIn(column string, values any) Expr {
+	return clause.Not(In(column, values))
+}
+
+// Like matches the rows whose column matches the LIKE pattern, case sensitive. The %
+// and _ of pattern are wildcards: escape the user input with EscapeLike, or use
+// Contains and StartsWith.
+func Like(column, pattern string) Expr {
+	return clause.Expr{SQL: "? LIKE ?", Vars: []any{columnOf(column), pattern}}
+}
+
+// ILike matches the rows whose column matches the LIKE pattern, case insensitive.
+func ILike(column, pattern string) Expr {
+	return clause.Expr{SQL: "? ILIKE ?", Vars: []any{columnOf(column), pattern}}
+}
+
+// Contains matches the rows whose column contains text, case insensitive. The wildcards
+// of text match literally.
+func Contains(column, text string) Expr {
+	return ILike(column, "%"+EscapeLike(text)+"%")
+}
+
+// StartsWith matches the rows whose column starts with text, case insensitive. The
+// wildcards of text match literally.
+func StartsWith(column, text string) Expr {
+	return ILike(column, EscapeLike(text)+"%")
+}
+
+// IsNull matches the rows whose column is NULL.
+func IsNull(column string) Expr {
+	return clause.Eq{Column: columnOf(column), Value: nil}
+}
+
+// NotNull matches the rows whose column is not NULL.
+func NotNull(column string) Expr {
+	return clause.Neq{Column: columnOf(column), Value: nil}
+}
+
+// And matches the rows matching all the exprs. Nil exprs are skipped.
+func And(exprs ...Expr) Expr {
+	return clause.And(compact(exprs)...)
+}
+
+// Or matches the rows matching any of the exprs. Nil exprs are skipped.
+func Or(exprs ...Expr) Expr {
+	return clause.Or(compact(exprs)...)
+}
+
+// Not matches the rows not matching expr.
+func Not(expr Expr) Expr {
+	return clause.Not(expr)
+}
+
+// If returns expr when cond is true and nil otherwise, for the optional search criteria:
+//
+//	filter.If(status != "", filter.Eq("status", status))
+func If(cond bool, expr Expr) Expr {
+	if !cond {
+		return nil
+	}
+	return expr
+}
+
+// EscapeLike escapes the %, _ and \ of text, to match them literally in a LIKE pattern.
+func EscapeLike(text string) string {
+	return likeEscaper.Replace(text)
+}
+
+// Scope returns the GORM scope applying all the exprs, skipping the nil ones. It fits
+// db.Scopes and repository.Filter:
+//
+//	users, meta, err := repo.List(ctx, params, repository.Filter(filter.Scope(
+//	    filter.Eq("status", "active"),
+//	    filter.If(search != "", filter.Contains("name", search)),
+//	)))
+func Scope(exprs ...Expr) func(*gorm.DB) *gorm.DB {
+	conditions := compact(exprs)
+	return func(db *gorm.DB) *gorm.DB {
+		if len(conditions) == 0 {
+			return db
+		}
+		return db.Where(clause.Where{Exprs: conditions})
+	}
+}
+
+// columnOf returns the column of name, qualified by its table when name is "table.column",
+// and by the table of the statement otherwise.
+func columnOf(name string) clause.Column {
+	if table, column, ok := strings.Cut(name, "."); ok {
+		return clause.Column{Table: table, Name: column}
+	}
+	return clause.Column{Table: clause.CurrentTable, Name: name}
+}
+
+func toSlice(values any) []any {
+	if values, ok := values.([]any); ok {
+		return values
+	}
+	value := reflect.ValueOf(values)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return []any{values}
+	}
+	slice := make([]any, value.Len())
+	for i := range slice {
+		slice[i] = value.Index(i).Interface()
+	}
+	return slice
+}
+
+func compact(exprs []Expr) []Expr {
+	kept := make([]Expr, 0, len(exprs))
+	for _, expr := range exprs {
+		if expr != nil {
+			kept = append(kept, expr)
+		}
+	}
+	return kept
+}
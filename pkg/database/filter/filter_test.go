@@ -0,0 +1,152 @@
+package filter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database/filter"
+)
+
+type product struct {
+	ID     int64
+	Name   string
+	Status string
+}
+
+// sqlRecorder keeps the statements of a dry run session
+type sqlRecorder struct {
+	logger.Interface
+	statements *[]string
+}
+
+func (r sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	statement, _ := fc()
+	*r.statements = append(*r.statements, statement)
+}
+
+type FilterTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	statements []string
+}
+
+func TestFilterSuite(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
+
+func (s *FilterTestSuite) SetupTest() {
+	s.statements = nil
+	s.db = openDryRun(s.T(), &s.statements)
+}
+
+func (s *FilterTestSuite) query(exprs ...filter.Expr) string {
+	return querySQL(s.T(), s.db, &s.statements, exprs)
+}
+
+// openDryRun opens a session recording the statements instead of running them
+func openDryRun(t *testing.T, statements *[]string) *gorm.DB {
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{
+			DryRun:                 true,
+			DisableAutomaticPing:   true,
+			SkipDefaultTransaction: true,
+			Logger:                 sqlRecorder{Interface: logger.Discard, statements: statements},
+		},
+	)
+	require.NoError(t, err)
+	return db
+}
+
+// querySQL returns the SQL of the products matching exprs
+func querySQL(t *testing.T, db *gorm.DB, statements *[]string, exprs []filter.Expr) string {
+	var products []product
+	require.NoError(t, db.Scopes(filter.Scope(exprs...)).Find(&products).Error)
+	require.Len(t, *statements, 1)
+	return (*statements)[0]
+}
+
+func (s *FilterTestSuite) TestScope_Comparisons_QuotesTheColumnsAndBindsTheValues() {
+	// Act
+	sql := s.query(
+		filter.Eq("status", "active"),
+		filter.Gte("id", 10),
+		filter.Lt("products.id", 20),
+		filter.In("id", []int{1, 2, 3}),
+	)
+
+	// Assert
+	s.Equal(`SELECT * FROM "products" WHERE "products"."status" = 'active' AND "products"."id" >= 10 `+
+		`AND "products"."id" < 20 AND "products"."id" IN (1,2,3)`, sql)
+}
+
+func (s *FilterTestSuite) TestScope_Value_CannotInjectSQL() {
+	// Act
+	sql := s.query(filter.Eq("name", "x' OR '1'='1"))
+
+	// Assert
+	s.Equal(`SELECT * FROM "products" WHERE "products"."name" = 'x'' OR ''1''=''1'`, sql)
+}
+
+func (s *FilterTestSuite) TestScope_Column_CannotInjectSQL() {
+	// Act
+	sql := s.query(filter.Eq(`name" = '' OR 1=1 --`, "x"))
+
+	// Assert
+	s.Equal(`SELECT * FROM "products" WHERE "products"."name"" = '' OR 1=1 --" = 'x'`, sql)
+}
+
+func (s *FilterTestSuite) TestScope_Contains_EscapesTheWildcards() {
+	// Act
+	sql := s.query(filter.Contains("name", "50%_off"))
+
+	// Assert
+	s.Equal(`SELECT * FROM "products" WHERE "products"."name" ILIKE '%50\%\_off%'`, sql)
+}
+
+func (s *FilterTestSuite) TestScope_Or_GroupsTheConditions() {
+	// Act
+	sql := s.query(
+		filter.Eq("status", "active"),
+		filter.Or(filter.StartsWith("name", "acme"), filter.IsNull("name")),
+	)
+
+	// Assert
+	s.Equal(`SELECT * FROM "products" WHERE "products"."status" = 'active' `+
+		`AND ("products"."name" ILIKE 'acme%' OR "products"."name" IS NULL)`, sql)
+}
+
+func (s *FilterTestSuite) TestScope_If_SkipsTheFalseConditions() {
+	// Act
+	sql := s.query(
+		filter.If(false, filter.Eq("status", "active")),
+		filter.If(true, filter.NotNull("name")),
+	)
+
+	// Assert
+	s.Equal(`SELECT * FROM "products" WHERE "products"."name" IS NOT NULL`, sql)
+}
+
+func (s *FilterTestSuite) TestScope_Between_BindsBothBounds() {
+	// Act
+	sql := s.query(filter.Between("id", 1, 9), filter.NotIn("status", []string{"archived", "draft"}))
+
+	// Assert
+	s.Equal(`SELECT * FROM "products" WHERE ("products"."id" BETWEEN 1 AND 9) `+
+		`AND "products"."status" NOT IN ('archived','draft')`, sql)
+}
+
+func (s *FilterTestSuite) TestScope_NoConditions_LeavesTheQueryUnchanged() {
+	// Act
+	sql := s.query()
+
+	// Assert
+	s.Equal(`SELECT * FROM "products"`, sql)
+}
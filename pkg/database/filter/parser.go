@@ -0,0 +1,226 @@
+package filter
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Operator is the comparison of a query parameter, between brackets after its name:
+// price[gte]=10. A parameter without operator compares with OpEq.
+type Operator string
+
+const (
+	OpEq         Operator = "eq"
+	OpNeq        Operator = "neq"
+	OpGt         Operator = "gt"
+	OpGte        Operator = "gte"
+	OpLt         Operator = "lt"
+	OpLte        Operator = "lte"
+	OpIn         Operator = "in"
+	OpContains   Operator = "contains"
+	OpStartsWith Operator = "starts_with"
+	// OpNull matches the NULL column with true and the others with false
+	OpNull Operator = "null"
+)
+
+// Field is a query parameter a Parser accepts.
+type Field struct {
+	// Name is the name of the query parameter
+	Name string
+	// Column is the filtered column. Default: Name
+	Column string
+	// Operators lists the accepted operators. Default: OpEq
+	Operators []Operator
+	// Parse converts the value of the parameter, e.g. Int or Time. Default: the string as is
+	Parse func(value string) (any, error)
+}
+
+// Parser parses the filters of the query parameters, restricted to its fields: the
+// clients choose the values and the operators, never the columns nor the SQL.
+type Parser struct {
+	fields map[string]Field
+}
+
+// NewParser creates the Parser of fields.
+//
+//	parser := filter.NewParser(
+//	    filter.Field{Name: "status", Operators: []filter.Operator{filter.OpEq, filter.OpIn}},
+//	    filter.Field{Name: "name", Operators: []filter.Operator{filter.OpContains}},
+//	    filter.Field{Name: "price", Column: "price_cents", Operators: []filter.Operator{filter.OpGte, filter.OpLte},
+//	        Parse: filter.Int},
+//	)
+func NewParser(fields ...Field) *Parser {
+	parser := &Parser{fields: make(map[string]Field, len(fields))}
+	for _, field := range fields {
+		if field.Column == "" {
+			field.Column = field.Name
+		}
+		if len(field.Operators) == 0 {
+			field.Operators = []Operator{OpEq}
+		}
+		if field.Parse == nil {
+			field.Parse = String
+		}
+		parser.fields[field.Name] = field
+	}
+	return parser
+}
+
+// Parse returns the conditions of the query parameters of the fields, to apply with
+// Scope. The other parameters, e.g. page and sort, are ignored. A disallowed operator
+// or an invalid value returns an INVALID_FILTER errs.Error with status 400.
+//
+//	?status[in]=active,trial&name[contains]=acme&price[gte]=1000
+func (p *Parser) Parse(query url.Values) ([]Expr, error) {
+	params := make([]string, 0, len(query))
+	for param := range query {
+		params = append(params, param)
+	}
+	// Sorted, so the conditions, and the SQL, are the same for the same query
+	slices.Sort(params)
+
+	var exprs []Expr
+	for _, param := range params {
+		// The fields are looked up first, so foreign parameters such as ids[]=1 are ignored
+		name, _, _ := strings.Cut(param, "[")
+		field, ok := p.fields[name]
+		if !ok {
+			continue
+		}
+		operator, err := splitParam(param)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(field.Operators, operator) {
+			return nil, invalidFilter(param, fmt.Sprintf("operator %q is not allowed", operator))
+		}
+		for _, value := range query[param] {
+			expr, exprErr := field.expr(operator, value)
+			if exprErr != nil {
+				return nil, invalidFilter(param, exprErr.Error())
+			}
+			exprs = append(exprs, expr)
+		}
+	}
+	return exprs, nil
+}
+
+// splitParam returns the operator of "name[operator]", OpEq without brackets.
+func splitParam(param string) (Operator, error) {
+	_, rest, ok := strings.Cut(param, "[")
+	if !ok {
+		return OpEq, nil
+	}
+	operator, ok := strings.CutSuffix(rest, "]")
+	if !ok || operator == "" {
+		return "", invalidFilter(param, "malformed operator")
+	}
+	return Operator(operator), nil
+}
+
+func (f Field) expr(operator Operator, value string) (Expr, error) {
+	switch operator {
+	case OpIn:
+		values := strings.Split(value, ",")
+		parsed := make([]any, 0, len(values))
+		for _, item := range values {
+			v, err := f.Parse(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			parsed = append(parsed, v)
+		}
+		return In(f.Column, parsed), nil
+	case OpContains:
+		return Contains(f.Column, value), nil
+	case OpStartsWith:
+		return StartsWith(f.Column, value), nil
+	case OpNull:
+		isNull, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, ErrInvalidValue
+		}
+		if isNull {
+			return IsNull(f.Column), nil
+		}
+		return NotNull(f.Column), nil
+	}
+
+	parsed, err := f.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	switch operator {
+	case OpNeq:
+		return Neq(f.Column, parsed), nil
+	case OpGt:
+		return Gt(f.Column, parsed), nil
+	case OpGte:
+		return Gte(f.Column, parsed), nil
+	case OpLt:
+		return Lt(f.Column, parsed), nil
+	case OpLte:
+		return Lte(f.Column, parsed), nil
+	default:
+		return Eq(f.Column, parsed), nil
+	}
+}
+
+// String is the default value parser: the value as is.
+func String(value string) (any, error) {
+	return value, nil
+}
+
+// Int parses an integer value.
+func Int(value string) (any, error) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidValue
+	}
+	return n, nil
+}
+
+// Float parses a decimal value.
+func Float(value string) (any, error) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, ErrInvalidValue
+	}
+	return n, nil
+}
+
+// Bool parses a boolean value: true, false, 1 or 0.
+func Bool(value string) (any, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, ErrInvalidValue
+	}
+	return b, nil
+}
+
+// Time parses an RFC 3339 time or a 2006-01-02 date, at midnight UTC.
+func Time(value string) (any, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, ErrInvalidValue
+	}
+	return t, nil
+}
+
+// UUID parses a UUID value.
+func UUID(value string) (any, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, ErrInvalidValue
+	}
+	return id, nil
+}
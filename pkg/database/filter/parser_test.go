@@ -0,0 +1,121 @@
+package filter_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database/filter"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+type ParserTestSuite struct {
+	suite.Suite
+	sut        *filter.Parser
+	db         *gorm.DB
+	statements []string
+}
+
+func TestParserSuite(t *testing.T) {
+	suite.Run(t, new(ParserTestSuite))
+}
+
+func (s *ParserTestSuite) SetupTest() {
+	s.statements = nil
+	s.db = openDryRun(s.T(), &s.statements)
+	s.sut = filter.NewParser(
+		filter.Field{Name: "status", Operators: []filter.Operator{filter.OpEq, filter.OpIn}},
+		filter.Field{Name: "name", Operators: []filter.Operator{filter.OpContains, filter.OpNull}},
+		filter.Field{
+			Name:      "min_id",
+			Column:    "id",
+			Operators: []filter.Operator{filter.OpGte},
+			Parse:     filter.Int,
+		},
+	)
+}
+
+func (s *ParserTestSuite) query(exprs ...filter.Expr) string {
+	return querySQL(s.T(), s.db, &s.statements, exprs)
+}
+
+func (s *ParserTestSuite) parse(rawQuery string) ([]filter.Expr, error) {
+	query, err := url.ParseQuery(rawQuery)
+	s.Require().NoError(err)
+	return s.sut.Parse(query)
+}
+
+func (s *ParserTestSuite) TestParse_AllowedFilters_BuildsTheConditions() {
+	// Act
+	exprs, err := s.parse("status[in]=active,trial&name[contains]=acme&min_id[gte]=10&page=2")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(`SELECT * FROM "products" WHERE "products"."id" >= 10 AND "products"."name" ILIKE '%acme%' `+
+		`AND "products"."status" IN ('active','trial')`, s.query(exprs...))
+}
+
+func (s *ParserTestSuite) TestParse_WithoutOperator_ComparesWithEq() {
+	// Act
+	exprs, err := s.parse("status=active")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(`SELECT * FROM "products" WHERE "products"."status" = 'active'`, s.query(exprs...))
+}
+
+func (s *ParserTestSuite) TestParse_Null_MatchesTheNullColumns() {
+	// Act
+	exprs, err := s.parse("name[null]=false")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(`SELECT * FROM "products" WHERE "products"."name" IS NOT NULL`, s.query(exprs...))
+}
+
+func (s *ParserTestSuite) TestParse_UnknownParameters_AreIgnored() {
+	// Act
+	exprs, err := s.parse("secret=1&page=2")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Empty(exprs)
+}
+
+func (s *ParserTestSuite) TestParse_ForeignBracketParameters_AreIgnored() {
+	// Act
+	exprs, err := s.parse("ids[]=1&tags[]=a&foo[=b&status=active")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(`SELECT * FROM "products" WHERE "products"."status" = 'active'`, s.query(exprs...))
+}
+
+func (s *ParserTestSuite) TestParse_InvalidFilters_ReturnBadRequest() {
+	tests := []struct {
+		name     string
+		rawQuery string
+	}{
+		{name: "disallowed operator", rawQuery: "status[neq]=active"},
+		{name: "malformed operator", rawQuery: "status[eq=active"},
+		{name: "invalid value", rawQuery: "min_id[gte]=ten"},
+		{name: "invalid null flag", rawQuery: "name[null]=maybe"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act
+			exprs, err := s.parse(tt.rawQuery)
+
+			// Assert
+			s.Nil(exprs)
+			var appErr *errs.Error
+			s.Require().True(errors.As(err, &appErr))
+			s.Equal("INVALID_FILTER", appErr.Code)
+			s.Equal(http.StatusBadRequest, appErr.Status)
+		})
+	}
+}
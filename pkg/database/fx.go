@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// Module provides the *gorm.DB and its TxManager with automatic config loading and fx lifecycle.
+// The connection automatically:
+// - Loads config from "app.database"
+// - Applies the options of the "database_options" group, e.g. WithAuditColumns
+// - Records the WithHealthMonitor metrics on the prometheus.Registerer, when one is provided
+// - Connects with retries when the application is built
+// - Closes on application stop
+//
+// Usage in your application:
+//
+//	fx.New(
+//	    database.Module,
+//	    fx.Provide(fx.Annotate(database.WithAuditColumns, fx.ResultTags(`group:"database_options"`))),
+//	    fx.Invoke(func(*gorm.DB) {}),
+//	)
+var Module = fx.Module(
+	"database",
+	config.Provide[Config]("app.database"),
+	fx.Provide(
+		NewWithConfigLifecycle,
+		fx.Annotate(NewTxManager, fx.As(new(TxManager))),
+	),
+)
+
+// Params for dependency injection
+type Params struct {
+	fx.In
+	Config  config.Config[Config]
+	LC      fx.Lifecycle
+	Options []Option `group:"database_options"`
+	// Registerer is the default registerer of the WithHealthMonitor metrics, e.g. from metrics.Module
+	Registerer prometheus.Registerer `optional:"true"`
+}
+
+// NewWithConfigLifecycle creates a new database connection from the loaded config
+// with fx.Lifecycle management.
+func NewWithConfigLifecycle(params Params) (*gorm.DB, error) {
+	opts := append(params.Options, withHealthDefaults(params.Registerer))
+	return NewWithLifecycle(params.Config.Get(), params.LC, opts...)
+}
+
+// ListenerModule provides the LISTEN/NOTIFY Listener of the "app.database" config, started
+// with the application and stopped with it. Register its channels before the start, e.g.
+// in the constructors taking the *Listener, or while it runs.
+//
+//	fx.New(
+//	    database.Module,
+//	    database.ListenerModule,
+//	    fx.Invoke(func(listener *database.Listener, authorizer *authz.Authorizer) {
+//	        listener.Listen("roles_changed", func(ctx context.Context, _ database.Notification) error {
+//	            return authorizer.InvalidateCache(ctx)
+//	        })
+//	    }),
+//	)
+var ListenerModule = fx.Provide(NewListenerWithLifecycle)
+
+// ListenerParams for dependency injection
+type ListenerParams struct {
+	fx.In
+	Config config.Config[Config]
+	LC     fx.Lifecycle
+	// Logger logs the connection losses and handler errors when provided
+	Logger *slog.Logger `optional:"true"`
+}
+
+// NewListenerWithLifecycle creates the Listener of the config, running from the start to
+// the stop of the application.
+func NewListenerWithLifecycle(params ListenerParams) *Listener {
+	listener := NewListener(params.Config.Get(), WithListenerLogger(params.Logger))
+	params.LC.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			listener.Start()
+			return nil
+		},
+		OnStop: listener.Stop,
+	})
+	return listener
+}
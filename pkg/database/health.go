@@ -0,0 +1,357 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const (
+	healthMonitorPluginName = "bricks:health_monitor"
+
+	defaultHealthInterval         = 15 * time.Second
+	defaultHealthPingTimeout      = 5 * time.Second
+	defaultHealthFailureThreshold = 3
+	// database/sql keeps 2 idle connections when SetMaxIdleConns is not called
+	defaultMaxIdleConnections = 2
+
+	upMetricName           = "database_up"
+	pingDurationMetricName = "database_ping_duration_seconds"
+	poolResetsMetricName   = "database_pool_resets_total"
+)
+
+// HealthState is the connectivity state reported by the HealthMonitor.
+type HealthState string
+
+const (
+	// HealthUp means the last ping succeeded
+	HealthUp HealthState = "up"
+	// HealthDegraded means the last pings failed, fewer times in a row than the failure threshold
+	HealthDegraded HealthState = "degraded"
+	// HealthDown means the pings failed at least the failure threshold times in a row
+	HealthDown HealthState = "down"
+)
+
+type healthMonitorOptions struct {
+	interval         time.Duration
+	pingTimeout      time.Duration
+	failureThreshold int
+	resetAfter       int
+	logger           *slog.Logger
+	registerer       prometheus.Registerer
+}
+
+// HealthMonitorOption configures the monitor enabled by WithHealthMonitor.
+type HealthMonitorOption func(*healthMonitorOptions)
+
+func defaultHealthMonitorOptions() healthMonitorOptions {
+	return healthMonitorOptions{
+		interval:         defaultHealthInterval,
+		pingTimeout:      defaultHealthPingTimeout,
+		failureThreshold: defaultHealthFailureThreshold,
+		logger:           slog.Default(),
+	}
+}
+
+// WithPingTimeout limits the time of each ping. Defaults to 5s.
+func WithPingTimeout(timeout time.Duration) HealthMonitorOption {
+	return func(o *healthMonitorOptions) {
+		if timeout > 0 {
+			o.pingTimeout = timeout
+		}
+	}
+}
+
+// WithFailureThreshold sets the number of failed pings in a row after which the
+// database is reported down instead of degraded. Defaults to 3.
+func WithFailureThreshold(failures int) HealthMonitorOption {
+	return func(o *healthMonitorOptions) {
+		if failures > 0 {
+			o.failureThreshold = failures
+		}
+	}
+}
+
+// WithPoolResetAfter resets the connection pool after the given number of failed pings
+// in a row, and again every as many failures while the database stays unreachable.
+// Resetting closes the idle connections, so the next queries dial new ones instead of
+// reusing connections broken by a failover or a network change. Disabled by default.
+func WithPoolResetAfter(failures int) HealthMonitorOption {
+	return func(o *healthMonitorOptions) {
+		if failures > 0 {
+			o.resetAfter = failures
+		}
+	}
+}
+
+// WithHealthLogger sets the logger of the connectivity changes. Defaults to slog.Default().
+func WithHealthLogger(logger *slog.Logger) HealthMonitorOption {
+	return func(o *healthMonitorOptions) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithHealthRegisterer records the ping results and the connection pool statistics
+// on registerer. No metrics are recorded when not provided.
+func WithHealthRegisterer(registerer prometheus.Registerer) HealthMonitorOption {
+	return func(o *healthMonitorOptions) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// HealthMonitor pings the database in the background and tracks its connectivity.
+// It is enabled with WithHealthMonitor and retrieved with HealthMonitorFor.
+type HealthMonitor struct {
+	opts     healthMonitorOptions
+	dbName   string
+	maxIdle  int
+	sqlDB    *sql.DB
+	metrics  *healthMetrics
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+
+	mu        sync.RWMutex
+	state     HealthState
+	failures  int
+	lastErr   error
+	downSince time.Time
+}
+
+func newHealthMonitor(cfg Config, opts []HealthMonitorOption) *HealthMonitor {
+	monitorOptions := defaultHealthMonitorOptions()
+	for _, opt := range opts {
+		opt(&monitorOptions)
+	}
+
+	maxIdle := cfg.MaxIdleConnections
+	if maxIdle <= 0 {
+		maxIdle = defaultMaxIdleConnections
+	}
+	return &HealthMonitor{
+		opts:    monitorOptions,
+		dbName:  cfg.Name,
+		maxIdle: maxIdle,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		// New has already pinged the database when the monitor starts
+		state: HealthUp,
+	}
+}
+
+// HealthMonitorFor returns the HealthMonitor of db, and whether it is enabled.
+func HealthMonitorFor(db *gorm.DB) (*HealthMonitor, bool) {
+	monitor, ok := db.Config.Plugins[healthMonitorPluginName].(*HealthMonitor)
+	return monitor, ok
+}
+
+// Name implements gorm.Plugin.
+func (m *HealthMonitor) Name() string {
+	return healthMonitorPluginName
+}
+
+// Initialize implements gorm.Plugin. It registers the metrics and starts the monitor.
+func (m *HealthMonitor) Initialize(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	m.sqlDB = sqlDB
+
+	if m.opts.registerer != nil {
+		if m.metrics, err = newHealthMetrics(m.opts.registerer, sqlDB, m.dbName); err != nil {
+			return err
+		}
+		m.metrics.up.Set(1)
+	}
+
+	go m.run()
+	return nil
+}
+
+// State returns the current connectivity state.
+func (m *HealthMonitor) State() HealthState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// Check returns an ErrDatabaseUnavailable error wrapping the last ping error when the
+// database is down, and nil when it is up or degraded. It matches the checker shape of
+// health endpoints, e.g. a readiness probe.
+func (m *HealthMonitor) Check(_ context.Context) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.state != HealthDown {
+		return nil
+	}
+	return fmt.Errorf("%w: %d failed pings: %w", ErrDatabaseUnavailable, m.failures, m.lastErr)
+}
+
+// Probe pings the database once, updates the state and returns the ping error.
+// The monitor probes at every interval; Probe checks on demand.
+func (m *HealthMonitor) Probe(ctx context.Context) error {
+	pingCtx, cancel := context.WithTimeout(ctx, m.opts.pingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := m.sqlDB.PingContext(pingCtx)
+	elapsed := time.Since(start)
+
+	if m.metrics != nil {
+		m.metrics.pingDuration.Observe(elapsed.Seconds())
+	}
+	if err != nil {
+		m.recordFailure(err)
+		return err
+	}
+	m.recordSuccess()
+	return nil
+}
+
+// Stop stops the monitor. It is called when the application stops with NewWithLifecycle.
+func (m *HealthMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+	<-m.done
+}
+
+func (m *HealthMonitor) run() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.opts.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			_ = m.Probe(context.Background())
+		}
+	}
+}
+
+func (m *HealthMonitor) recordSuccess() {
+	m.mu.Lock()
+	previous, failures, downSince := m.state, m.failures, m.downSince
+	m.state, m.failures, m.lastErr, m.downSince = HealthUp, 0, nil, time.Time{}
+	m.mu.Unlock()
+
+	if m.metrics != nil {
+		m.metrics.up.Set(1)
+	}
+	if previous != HealthUp {
+		m.opts.logger.Info("database connectivity restored",
+			"database", m.dbName,
+			"previous_state", string(previous),
+			"failed_pings", failures,
+			"downtime", time.Since(downSince),
+		)
+	}
+}
+
+func (m *HealthMonitor) recordFailure(err error) {
+	m.mu.Lock()
+	previous := m.state
+	m.failures++
+	m.lastErr = err
+	if previous == HealthUp {
+		m.downSince = time.Now()
+	}
+	m.state = HealthDegraded
+	if m.failures >= m.opts.failureThreshold {
+		m.state = HealthDown
+	}
+	state, failures := m.state, m.failures
+	m.mu.Unlock()
+
+	if m.metrics != nil {
+		m.metrics.up.Set(0)
+	}
+
+	attrs := []any{
+		"database", m.dbName,
+		"state", string(state),
+		"failed_pings", failures,
+		"error", err,
+	}
+	switch {
+	case state == HealthDown && previous != HealthDown:
+		m.opts.logger.Error("database connectivity lost", attrs...)
+	case state != previous:
+		m.opts.logger.Warn("database connectivity degraded", attrs...)
+	default:
+		m.opts.logger.Debug("database ping failed", attrs...)
+	}
+
+	if m.opts.resetAfter > 0 && failures%m.opts.resetAfter == 0 {
+		m.resetPool(failures)
+	}
+}
+
+// resetPool closes the idle connections, so the next queries dial new ones.
+func (m *HealthMonitor) resetPool(failures int) {
+	m.sqlDB.SetMaxIdleConns(0)
+	m.sqlDB.SetMaxIdleConns(m.maxIdle)
+
+	if m.metrics != nil {
+		m.metrics.poolResets.Inc()
+	}
+	m.opts.logger.Warn("database connection pool reset",
+		"database", m.dbName,
+		"failed_pings", failures,
+	)
+}
+
+type healthMetrics struct {
+	up           prometheus.Gauge
+	pingDuration prometheus.Histogram
+	poolResets   prometheus.Counter
+}
+
+func newHealthMetrics(registerer prometheus.Registerer, sqlDB *sql.DB, dbName string) (*healthMetrics, error) {
+	labels := prometheus.Labels{"db_name": dbName}
+	metrics := &healthMetrics{
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        upMetricName,
+			Help:        "Whether the last database ping succeeded (1) or failed (0)",
+			ConstLabels: labels,
+		}),
+		pingDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:        pingDurationMetricName,
+			Help:        "Duration of the database health pings in seconds",
+			ConstLabels: labels,
+			Buckets:     []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+		}),
+		poolResets: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        poolResetsMetricName,
+			Help:        "Total resets of the database connection pool after failed pings",
+			ConstLabels: labels,
+		}),
+	}
+
+	// The go_sql_* pool statistics: open, in use and idle connections, waits and closes
+	collectorList := []prometheus.Collector{
+		metrics.up, metrics.pingDuration, metrics.poolResets,
+		collectors.NewDBStatsCollector(sqlDB, dbName),
+	}
+	for _, collector := range collectorList {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register database health metrics: %w", err)
+		}
+	}
+	return metrics, nil
+}
@@ -0,0 +1,241 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+var errPingRefused = errors.New("connection refused")
+
+// pingConnector opens connections whose Ping returns the current error of the connector.
+type pingConnector struct {
+	mu      sync.Mutex
+	pingErr error
+}
+
+func (c *pingConnector) setPingErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pingErr = err
+}
+
+func (c *pingConnector) Connect(context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &pingConn{connector: c}, nil
+}
+
+func (c *pingConnector) Driver() driver.Driver {
+	return nil
+}
+
+type pingConn struct {
+	connector *pingConnector
+}
+
+func (c *pingConn) Ping(context.Context) error {
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	return c.connector.pingErr
+}
+
+func (c *pingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, io.EOF
+}
+
+func (c *pingConn) Close() error {
+	return nil
+}
+
+func (c *pingConn) Begin() (driver.Tx, error) {
+	return nil, io.EOF
+}
+
+type HealthMonitorTestSuite struct {
+	suite.Suite
+	connector *pingConnector
+	registry  *prometheus.Registry
+	db        *gorm.DB
+	sut       *database.HealthMonitor
+}
+
+func TestHealthMonitorTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthMonitorTestSuite))
+}
+
+func (s *HealthMonitorTestSuite) SetupTest() {
+	s.connector = &pingConnector{}
+	s.registry = prometheus.NewRegistry()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(s.connector)}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	s.Require().NoError(err)
+	s.db = db
+
+	s.sut = database.NewTestHealthMonitor(database.Config{Name: "orders"}, time.Hour,
+		database.WithFailureThreshold(2),
+		database.WithPoolResetAfter(3),
+		database.WithHealthRegisterer(s.registry),
+		database.WithHealthLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+	s.Require().NoError(s.db.Use(s.sut))
+}
+
+func (s *HealthMonitorTestSuite) TearDownTest() {
+	s.sut.Stop()
+}
+
+func (s *HealthMonitorTestSuite) metricValue(name string) float64 {
+	families, err := s.registry.Gather()
+	s.Require().NoError(err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		metric := family.GetMetric()[0]
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			return metric.GetCounter().GetValue()
+		case dto.MetricType_GAUGE:
+			return metric.GetGauge().GetValue()
+		case dto.MetricType_HISTOGRAM:
+			return float64(metric.GetHistogram().GetSampleCount())
+		default:
+			s.FailNow("unexpected metric type", name)
+		}
+	}
+	s.FailNow("metric not found", name)
+	return 0
+}
+
+func (s *HealthMonitorTestSuite) TestHealthMonitorFor_ReturnsTheRegisteredMonitor() {
+	// Act
+	monitor, ok := database.HealthMonitorFor(s.db)
+
+	// Assert
+	s.True(ok)
+	s.Same(s.sut, monitor)
+}
+
+func (s *HealthMonitorTestSuite) TestProbe_PingSucceeds_StaysUp() {
+	// Act
+	err := s.sut.Probe(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(database.HealthUp, s.sut.State())
+	s.Require().NoError(s.sut.Check(context.Background()))
+	s.InDelta(1, s.metricValue("database_up"), 0)
+	s.InDelta(1, s.metricValue("database_ping_duration_seconds"), 0)
+}
+
+func (s *HealthMonitorTestSuite) TestProbe_PingFailsOnce_IsDegraded() {
+	// Arrange
+	s.connector.setPingErr(errPingRefused)
+
+	// Act
+	err := s.sut.Probe(context.Background())
+
+	// Assert
+	s.Require().Error(err)
+	s.Equal(database.HealthDegraded, s.sut.State())
+	s.Require().NoError(s.sut.Check(context.Background()))
+	s.InDelta(0, s.metricValue("database_up"), 0)
+}
+
+func (s *HealthMonitorTestSuite) TestProbe_PingFailsThresholdTimes_IsDown() {
+	// Arrange
+	s.connector.setPingErr(errPingRefused)
+
+	// Act
+	_ = s.sut.Probe(context.Background())
+	_ = s.sut.Probe(context.Background())
+
+	// Assert
+	s.Equal(database.HealthDown, s.sut.State())
+	err := s.sut.Check(context.Background())
+	s.Require().ErrorIs(err, database.ErrDatabaseUnavailable)
+	s.Require().ErrorIs(err, errPingRefused)
+}
+
+func (s *HealthMonitorTestSuite) TestProbe_PingSucceedsAgain_IsRestored() {
+	// Arrange
+	s.connector.setPingErr(errPingRefused)
+	_ = s.sut.Probe(context.Background())
+	_ = s.sut.Probe(context.Background())
+	s.connector.setPingErr(nil)
+
+	// Act
+	err := s.sut.Probe(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(database.HealthUp, s.sut.State())
+	s.Require().NoError(s.sut.Check(context.Background()))
+	s.InDelta(1, s.metricValue("database_up"), 0)
+}
+
+func (s *HealthMonitorTestSuite) TestProbe_PingFailsResetAfterTimes_ResetsThePool() {
+	// Arrange
+	s.connector.setPingErr(errPingRefused)
+
+	// Act
+	for range 6 {
+		_ = s.sut.Probe(context.Background())
+	}
+
+	// Assert
+	s.InDelta(2, s.metricValue("database_pool_resets_total"), 0)
+}
+
+func (s *HealthMonitorTestSuite) TestInitialize_RecordsThePoolStatistics() {
+	// Act
+	openConnections := s.metricValue("go_sql_open_connections")
+
+	// Assert
+	s.InDelta(0, openConnections, 0)
+}
+
+func TestHealthMonitor_Run(t *testing.T) {
+	t.Run("probes the database at every interval", func(t *testing.T) {
+		// Arrange
+		connector := &pingConnector{pingErr: errPingRefused}
+		db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(connector)}), &gorm.Config{
+			DisableAutomaticPing: true,
+			Logger:               logger.Discard,
+		})
+		require.NoError(t, err)
+		sut := database.NewTestHealthMonitor(database.Config{Name: "orders"}, 10*time.Millisecond,
+			database.WithFailureThreshold(1),
+			database.WithHealthLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		)
+
+		// Act
+		require.NoError(t, db.Use(sut))
+		defer sut.Stop()
+
+		// Assert
+		require.Eventually(t, func() bool {
+			return sut.State() == database.HealthDown
+		}, time.Second, 5*time.Millisecond)
+	})
+}
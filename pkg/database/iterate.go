@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const bulkOperationIterate = "iterate"
+
+// Iterate streams the rows of T matching query to fn in batches of batchSize rows, so
+// exports and backfills never hold the whole table in memory, and returns the number of
+// rows passed to fn. A nil query reads every row; a batchSize of zero or less uses
+// DefaultBulkBatchSize.
+//
+// The batches are read with keyset pagination on the primary key of T, which must have
+// one: query must not order nor offset the rows. Each batch is a new slice fn may keep.
+// The reads join the transaction of the context and stop at the first error of fn or
+// when ctx is done, returning the error. WithProgress is called after each batch with a
+// zero total and WithBulkMetrics records each batch as an "iterate" operation.
+//
+//	exported, err := database.Iterate(ctx, db,
+//	    func(db *gorm.DB) *gorm.DB { return db.Where("status = ?", "active") },
+//	    500,
+//	    func(ctx context.Context, batch []Product) error { return writer.Write(batch) },
+//	)
+func Iterate[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	query func(*gorm.DB) *gorm.DB,
+	batchSize int,
+	fn func(ctx context.Context, batch []T) error,
+	opts ...BulkOption,
+) (int64, error) {
+	bulk := resolveBulkOptions(opts)
+	tx := DB(ctx, db).Model(new(T))
+	table, _, err := modelOf[T](tx)
+	if err != nil {
+		return 0, err
+	}
+	if query != nil {
+		tx = tx.Scopes(query)
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBulkBatchSize
+	}
+
+	var (
+		rows      []T
+		processed int64
+	)
+	start := time.Now()
+	result := tx.FindInBatches(&rows, batchSize, func(_ *gorm.DB, _ int) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch := rows
+		// The next batch is read into a new slice, leaving batch to fn
+		rows = nil
+		if err := fn(ctx, batch); err != nil {
+			return err
+		}
+		processed += int64(len(batch))
+		bulk.metrics.observe(bulkOperationIterate, table, int64(len(batch)), start)
+		if bulk.progress != nil {
+			bulk.progress(processed, 0)
+		}
+		start = time.Now()
+		return nil
+	})
+	if result.Error != nil {
+		return processed, fmt.Errorf("iterate %s: %w", table, result.Error)
+	}
+	return processed, nil
+}
@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultListenerReconnectDelay = 1 * time.Second
+	listenerConnectTimeout        = 10 * time.Second
+)
+
+// Notification is a Postgres notification received by the Listener.
+type Notification struct {
+	Channel string
+	Payload string
+	// PID is the process ID of the notifying backend
+	PID uint32
+}
+
+// NotificationHandler handles the notifications of a channel. Its errors are logged.
+type NotificationHandler func(ctx context.Context, notification Notification) error
+
+type listenerOptions struct {
+	reconnectDelay time.Duration
+	logger         *slog.Logger
+	onReconnect    []func(ctx context.Context)
+}
+
+// ListenerOption configures the Listener created by NewListener.
+type ListenerOption func(*listenerOptions)
+
+// WithReconnectDelay sets the first delay before reconnecting, doubled after each failed
+// attempt up to 30s. Default: 1s.
+func WithReconnectDelay(delay time.Duration) ListenerOption {
+	return func(o *listenerOptions) {
+		if delay > 0 {
+			o.reconnectDelay = delay
+		}
+	}
+}
+
+// WithListenerLogger sets the logger of the connection losses and handler errors.
+// Default: slog.Default().
+func WithListenerLogger(logger *slog.Logger) ListenerOption {
+	return func(o *listenerOptions) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithOnReconnect calls fn after each reconnection. The notifications sent while the
+// Listener was disconnected are lost: fn catches up, e.g. clears a cache or polls a table.
+func WithOnReconnect(fn func(ctx context.Context)) ListenerOption {
+	return func(o *listenerOptions) {
+		if fn != nil {
+			o.onReconnect = append(o.onReconnect, fn)
+		}
+	}
+}
+
+// Listener subscribes to Postgres channels with LISTEN on a dedicated connection, outside
+// the pool of the *gorm.DB, and dispatches their notifications to the handlers. It
+// reconnects when the connection is lost and listens to the channels again.
+type Listener struct {
+	connect  func(ctx context.Context) (*pgx.Conn, error)
+	options  listenerOptions
+	mu       sync.Mutex
+	handlers map[string][]NotificationHandler
+	// subscribed is signaled when a channel is added, to LISTEN to it on the connection
+	subscribed chan struct{}
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewListener creates a Listener connecting with the DSN of cfg. Register the channels
+// with Listen and run it with Run, or Start and Stop.
+func NewListener(cfg Config, opts ...ListenerOption) *Listener {
+	dsn := cfg.DSN()
+	listenerOpts := listenerOptions{reconnectDelay: defaultListenerReconnectDelay, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(&listenerOpts)
+	}
+	return &Listener{
+		connect: func(ctx context.Context) (*pgx.Conn, error) {
+			return pgx.Connect(ctx, dsn)
+		},
+		options:    listenerOpts,
+		handlers:   map[string][]NotificationHandler{},
+		subscribed: make(chan struct{}, 1),
+	}
+}
+
+// Listen calls handler with the notifications of channel. It can be called before or
+// while the Listener runs; the handlers of a channel are called in registration order.
+//
+//	listener.Listen("roles_changed", func(ctx context.Context, _ database.Notification) error {
+//	    return authorizer.InvalidateCache(ctx)
+//	})
+func (l *Listener) Listen(channel string, handler NotificationHandler) {
+	l.mu.Lock()
+	l.handlers[channel] = append(l.handlers[channel], handler)
+	l.mu.Unlock()
+
+	select {
+	case l.subscribed <- struct{}{}:
+	default:
+	}
+}
+
+// Run listens until ctx is done, reconnecting with an exponential backoff when the
+// connection is lost. The handlers run one at a time, on the goroutine of Run.
+func (l *Listener) Run(ctx context.Context) error {
+	var failures int
+	var connectedBefore bool
+	for {
+		connected, err := l.session(ctx, connectedBefore)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if connected {
+			connectedBefore = true
+			failures = 0
+		}
+		failures++
+
+		delay := calculateBackoff(failures, l.options.reconnectDelay)
+		l.options.logger.Warn("database listener disconnected",
+			"error", err,
+			"retry_in", delay,
+		)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Start runs the Listener in the background until Stop.
+func (l *Listener) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	l.done = make(chan struct{})
+	go func() {
+		defer close(l.done)
+		_ = l.Run(ctx)
+	}()
+}
+
+// Stop stops the Listener started by Start and waits for its handler to return, or for
+// ctx to be done.
+func (l *Listener) Stop(ctx context.Context) error {
+	if l.cancel == nil {
+		return nil
+	}
+	l.cancel()
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// session connects, listens to the channels and dispatches the notifications until the
+// connection fails or ctx is done. It reports whether it connected and listened.
+func (l *Listener) session(ctx context.Context, reconnected bool) (bool, error) {
+	connectCtx, cancel := context.WithTimeout(ctx, listenerConnectTimeout)
+	conn, err := l.connect(connectCtx)
+	cancel()
+	if err != nil {
+		return false, fmt.Errorf("connect: %w", err)
+	}
+	defer func() {
+		closeCtx, closeCancel := context.WithTimeout(context.Background(), time.Second)
+		defer closeCancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	listening := map[string]bool{}
+	if err = l.listenAll(ctx, conn, listening); err != nil {
+		return false, err
+	}
+	if reconnected {
+		l.options.logger.Info("database listener reconnected")
+		for _, fn := range l.options.onReconnect {
+			fn(ctx)
+		}
+	}
+
+	for {
+		// The channels added by Listen since the last wait
+		if err = l.listenAll(ctx, conn, listening); err != nil {
+			return true, err
+		}
+		notification, waitErr := l.wait(ctx, conn)
+		if ctx.Err() != nil {
+			return true, nil
+		}
+		if waitErr != nil {
+			return true, waitErr
+		}
+		if notification == nil {
+			continue
+		}
+		l.dispatch(ctx, Notification{
+			Channel: notification.Channel,
+			Payload: notification.Payload,
+			PID:     notification.PID,
+		})
+	}
+}
+
+// wait returns the next notification, or nil when a channel is added while waiting.
+func (l *Listener) wait(ctx context.Context, conn *pgx.Conn) (*pgconn.Notification, error) {
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-l.subscribed:
+			cancel()
+		case <-stop:
+		}
+	}()
+
+	notification, err := conn.WaitForNotification(waitCtx)
+	if err != nil && waitCtx.Err() != nil && ctx.Err() == nil && !conn.IsClosed() {
+		// Interrupted by Listen: the connection stays usable
+		return nil, nil
+	}
+	return notification, err
+}
+
+// listenAll sends LISTEN for the registered channels not listened to yet on conn.
+func (l *Listener) listenAll(ctx context.Context, conn *pgx.Conn, listening map[string]bool) error {
+	l.mu.Lock()
+	channels := make([]string, 0, len(l.handlers))
+	for channel := range l.handlers {
+		if !listening[channel] {
+			channels = append(channels, channel)
+		}
+	}
+	l.mu.Unlock()
+
+	for _, channel := range channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return fmt.Errorf("listen to %s: %w", channel, err)
+		}
+		listening[channel] = true
+	}
+	return nil
+}
+
+func (l *Listener) dispatch(ctx context.Context, notification Notification) {
+	l.mu.Lock()
+	handlers := l.handlers[notification.Channel]
+	l.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, notification); err != nil {
+			l.options.logger.Error("database notification handler failed",
+				"channel", notification.Channel,
+				"error", err,
+			)
+		}
+	}
+}
+
+// Notify sends payload on channel with pg_notify. In the transaction of the context, the
+// notification is delivered when the transaction commits, and dropped on rollback.
+func Notify(ctx context.Context, db *gorm.DB, channel, payload string) error {
+	if err := DB(ctx, db).Exec("SELECT pg_notify(?, ?)", channel, payload).Error; err != nil {
+		return fmt.Errorf("notify %s: %w", channel, err)
+	}
+	return nil
+}
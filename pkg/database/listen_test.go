@@ -0,0 +1,67 @@
+package database_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+func TestNotify(t *testing.T) {
+	t.Run("sends the payload with pg_notify", func(t *testing.T) {
+		// Arrange
+		var statements []string
+		db, err := gorm.Open(
+			postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+			&gorm.Config{
+				DryRun:               true,
+				DisableAutomaticPing: true,
+				Logger:               sqlRecorder{Interface: logger.Discard, statements: &statements},
+			},
+		)
+		require.NoError(t, err)
+
+		// Act
+		err = database.Notify(context.Background(), db, "roles_changed", "admin")
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, statements, 1)
+		assert.Equal(t, "SELECT pg_notify('roles_changed', 'admin')", statements[0])
+	})
+}
+
+func TestListener_Run(t *testing.T) {
+	t.Run("stops while reconnecting when the context is done", func(t *testing.T) {
+		// Arrange
+		listener := database.NewListener(
+			database.Config{Host: "127.0.0.1", Port: 1, User: "test", Name: "test"},
+			database.WithReconnectDelay(time.Hour),
+			database.WithListenerLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		)
+		listener.Listen("roles_changed", func(context.Context, database.Notification) error { return nil })
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- listener.Run(ctx) }()
+
+		// Act
+		cancel()
+
+		// Assert
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run did not return after the context was canceled")
+		}
+	})
+}
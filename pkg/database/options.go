@@ -0,0 +1,62 @@
+package database
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+type options struct {
+	plugins       []gorm.Plugin
+	healthMonitor []HealthMonitorOption
+}
+
+// Option configures the connection created by New.
+type Option func(*options)
+
+func resolveOptions(opts []Option) options {
+	var dbOptions options
+	for _, opt := range opts {
+		opt(&dbOptions)
+	}
+	return dbOptions
+}
+
+// WithAuditColumns registers the AuditPlugin, which fills the created_by and updated_by
+// columns from the ctxmeta user and the timestamps GORM does not already manage.
+func WithAuditColumns() Option {
+	return WithPlugins(NewAuditPlugin())
+}
+
+// WithPlugins registers GORM plugins on the connection.
+func WithPlugins(plugins ...gorm.Plugin) Option {
+	return func(o *options) {
+		o.plugins = append(o.plugins, plugins...)
+	}
+}
+
+// WithHealthMonitor pings the database every interval in the background, defaulting to 15s.
+// The monitor logs the connectivity changes, reports the database degraded after a failed
+// ping and down after WithFailureThreshold failures in a row, and optionally records
+// metrics and resets the connection pool. Retrieve it with HealthMonitorFor.
+func WithHealthMonitor(interval time.Duration, opts ...HealthMonitorOption) Option {
+	return func(o *options) {
+		o.healthMonitor = append(o.healthMonitor, func(monitorOptions *healthMonitorOptions) {
+			if interval > 0 {
+				monitorOptions.interval = interval
+			}
+		})
+		o.healthMonitor = append(o.healthMonitor, opts...)
+	}
+}
+
+// withHealthDefaults sets the registerer of the health monitor when none is given,
+// e.g. the application registry provided by fx.
+func withHealthDefaults(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if o.healthMonitor != nil && registerer != nil {
+			o.healthMonitor = append([]HealthMonitorOption{WithHealthRegisterer(registerer)}, o.healthMonitor...)
+		}
+	}
+}
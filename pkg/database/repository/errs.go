@@ -0,0 +1,23 @@
+package repository
+
+import "errors"
+
+var (
+	// ErrInvalidEntity indicates that the entity type cannot be parsed as a GORM model
+	ErrInvalidEntity = errors.New("invalid repository entity")
+
+	// ErrMissingPrimaryKey indicates that the entity has no primary key field
+	ErrMissingPrimaryKey = errors.New("repository entity has no primary key")
+
+	// ErrInvalidVersionColumn indicates that the version column is not an integer field
+	ErrInvalidVersionColumn = errors.New("repository version column must be an integer")
+
+	// ErrInvalidSort indicates that a list is sorted by a column that is not sortable
+	ErrInvalidSort = errors.New("invalid sort column")
+
+	// ErrSoftDeleteUnsupported indicates that the entity has no gorm.DeletedAt field
+	ErrSoftDeleteUnsupported = errors.New("repository entity does not support soft delete")
+
+	// ErrZeroID indicates that the entity to update has no primary key value
+	ErrZeroID = errors.New("repository entity has a zero primary key")
+)
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const defaultVersionColumn = "version"
+
+type options struct {
+	versionColumn   string
+	sortableColumns []string
+	defaultSort     []Sort
+}
+
+// Option configures the Repository created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		versionColumn: defaultVersionColumn,
+	}
+}
+
+// WithVersionColumn sets the integer column used for optimistic locking.
+// Defaults to "version"; an empty column disables optimistic locking.
+func WithVersionColumn(column string) Option {
+	return func(o *options) {
+		o.versionColumn = column
+	}
+}
+
+// WithSortableColumns restricts the columns List can be sorted by.
+// Defaults to all the columns of the entity.
+func WithSortableColumns(columns ...string) Option {
+	return func(o *options) {
+		o.sortableColumns = columns
+	}
+}
+
+// WithDefaultSort sets the order of List when no OrderBy is given.
+// Defaults to the primary key ascending, so pages are stable.
+func WithDefaultSort(sorts ...Sort) Option {
+	return func(o *options) {
+		o.defaultSort = sorts
+	}
+}
+
+// Sort orders a list by a column.
+type Sort struct {
+	Column string
+	Desc   bool
+}
+
+// ParseSort parses a comma separated list of columns, descending when prefixed with "-",
+// e.g. the "name,-created_at" sort query parameter.
+func ParseSort(value string) []Sort {
+	var sorts []Sort
+	for column := range strings.SplitSeq(value, ",") {
+		column = strings.TrimSpace(column)
+		desc := strings.HasPrefix(column, "-")
+		column = strings.TrimPrefix(column, "-")
+		if column == "" {
+			continue
+		}
+		sorts = append(sorts, Sort{Column: column, Desc: desc})
+	}
+	return sorts
+}
+
+// Scope filters a list, e.g. func(db *gorm.DB) *gorm.DB { return db.Where("status = ?", status) }.
+type Scope = func(*gorm.DB) *gorm.DB
+
+type listOptions struct {
+	sorts          []Sort
+	scopes         []Scope
+	includeDeleted bool
+	onlyDeleted    bool
+}
+
+// ListOption configures a List call.
+type ListOption func(*listOptions)
+
+// OrderBy sorts the list by the given columns, replacing the default sort.
+func OrderBy(sorts ...Sort) ListOption {
+	return func(o *listOptions) {
+		o.sorts = append(o.sorts, sorts...)
+	}
+}
+
+// Filter applies the scopes to the list and its total count.
+func Filter(scopes ...Scope) ListOption {
+	return func(o *listOptions) {
+		o.scopes = append(o.scopes, scopes...)
+	}
+}
+
+// IncludeDeleted lists the soft-deleted rows next to the others.
+func IncludeDeleted() ListOption {
+	return func(o *listOptions) {
+		o.includeDeleted = true
+	}
+}
+
+// OnlyDeleted lists only the soft-deleted rows.
+func OnlyDeleted() ListOption {
+	return func(o *listOptions) {
+		o.onlyDeleted = true
+	}
+}
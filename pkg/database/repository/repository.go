@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/paginator"
+)
+
+// Repository provides the CRUD operations of the GORM model T with primary key type ID.
+//
+// Updates use optimistic locking when T has a version column, and deletes are soft
+// when T has a gorm.DeletedAt field.
+type Repository[T any, ID comparable] struct {
+	db          *gorm.DB
+	primaryKey  *schema.Field
+	version     *schema.Field
+	deletedAt   *schema.Field
+	omitUpdate  []string
+	sortable    []string
+	defaultSort []Sort
+}
+
+// New creates the repository of T. It fails when T is not a GORM model with a primary key.
+func New[T any, ID comparable](db *gorm.DB, opts ...Option) (*Repository[T, ID], error) {
+	repoOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&repoOptions)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEntity, err)
+	}
+	modelSchema := stmt.Schema
+
+	repo := &Repository[T, ID]{
+		db:          db,
+		primaryKey:  modelSchema.PrioritizedPrimaryField,
+		sortable:    repoOptions.sortableColumns,
+		defaultSort: repoOptions.defaultSort,
+	}
+	if repo.primaryKey == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingPrimaryKey, modelSchema.Name)
+	}
+
+	if repoOptions.versionColumn != "" {
+		repo.version = modelSchema.LookUpField(repoOptions.versionColumn)
+		if repo.version != nil && !isInteger(repo.version.FieldType) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidVersionColumn, repoOptions.versionColumn)
+		}
+	}
+
+	for _, field := range modelSchema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if field.FieldType == reflect.TypeFor[gorm.DeletedAt]() {
+			repo.deletedAt = field
+		}
+		// Update keeps the creation audit columns and the deletion state of the stored row
+		if field.AutoCreateTime != 0 || field.DBName == database.ColumnCreatedBy || field == repo.deletedAt {
+			repo.omitUpdate = append(repo.omitUpdate, field.DBName)
+		}
+	}
+
+	if len(repo.sortable) == 0 {
+		repo.sortable = modelSchema.DBNames
+	}
+	if len(repo.defaultSort) == 0 {
+		repo.defaultSort = []Sort{{Column: repo.primaryKey.DBName}}
+	}
+
+	return repo, nil
+}
+
+// Create inserts the entity. A zero version starts at 1.
+func (r *Repository[T, ID]) Create(ctx context.Context, entity *T) error {
+	if r.version != nil {
+		version := r.version.ReflectValueOf(ctx, reflect.ValueOf(entity).Elem())
+		if version.IsZero() {
+			setInteger(version, 1)
+		}
+	}
+
+	return database.DB(ctx, r.db).Create(entity).Error
+}
+
+// Get returns the entity with the given id, or errs.ErrRecordNotFound.
+// Soft-deleted entities are not found.
+func (r *Repository[T, ID]) Get(ctx context.Context, id ID) (*T, error) {
+	var entity T
+	err := database.DB(ctx, r.db).Where(r.primaryKeyEq(id)).Take(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errs.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+// Update saves all the fields of the entity except its creation time.
+//
+// With a version column the row is only updated when its version still matches the entity,
+// which then gets the incremented version; otherwise errs.ErrPreconditionFailed is returned.
+// A missing row returns errs.ErrRecordNotFound.
+func (r *Repository[T, ID]) Update(ctx context.Context, entity *T) error {
+	value := reflect.ValueOf(entity).Elem()
+	if _, isZero := r.primaryKey.ValueOf(ctx, value); isZero {
+		return ErrZeroID
+	}
+
+	tx := database.DB(ctx, r.db).Model(entity).Select("*").Omit(r.omitUpdate...)
+
+	var version reflect.Value
+	var current int64
+	if r.version != nil {
+		version = r.version.ReflectValueOf(ctx, value)
+		current = integer(version)
+		tx = tx.Where(clause.Eq{Column: r.column(r.version), Value: current})
+		setInteger(version, current+1)
+	}
+
+	result := tx.Updates(entity)
+	if result.Error == nil && result.RowsAffected > 0 {
+		return nil
+	}
+
+	if r.version != nil {
+		setInteger(version, current)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if r.version == nil {
+		return errs.ErrRecordNotFound
+	}
+
+	var count int64
+	id, _ := r.primaryKey.ValueOf(ctx, value)
+	if err := database.DB(ctx, r.db).Model(new(T)).Where(r.primaryKeyEq(id)).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return errs.ErrRecordNotFound
+	}
+	return errs.ErrPreconditionFailed
+}
+
+// Delete deletes the entity with the given id, softly when T has a gorm.DeletedAt field.
+// A missing row returns errs.ErrRecordNotFound.
+func (r *Repository[T, ID]) Delete(ctx context.Context, id ID) error {
+	return r.delete(database.DB(ctx, r.db), id)
+}
+
+// HardDelete removes the entity with the given id, even when it is soft-deleted.
+func (r *Repository[T, ID]) HardDelete(ctx context.Context, id ID) error {
+	return r.delete(database.DB(ctx, r.db).Unscoped(), id)
+}
+
+// Restore undeletes the soft-deleted entity with the given id.
+// It returns errs.ErrRecordNotFound when there is no soft-deleted entity with that id.
+func (r *Repository[T, ID]) Restore(ctx context.Context, id ID) error {
+	if r.deletedAt == nil {
+		return ErrSoftDeleteUnsupported
+	}
+
+	result := database.DB(ctx, r.db).Unscoped().Model(new(T)).
+		Where(r.primaryKeyEq(id)).
+		Where(clause.Neq{Column: r.column(r.deletedAt), Value: nil}).
+		Update(r.deletedAt.DBName, nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrRecordNotFound
+	}
+	return nil
+}
+
+// List returns a page of entities and its metadata. A zero PerPage returns all the entities.
+//
+//	users, meta, err := repo.List(ctx, params,
+//	    repository.OrderBy(repository.ParseSort(query.Get("sort"))...),
+//	    repository.Filter(func(db *gorm.DB) *gorm.DB { return db.Where("active") }),
+//	)
+func (r *Repository[T, ID]) List(
+	ctx context.Context,
+	params paginator.Params,
+	opts ...ListOption,
+) ([]T, paginator.Metadata, error) {
+	var listOpts listOptions
+	for _, opt := range opts {
+		opt(&listOpts)
+	}
+
+	sorts := listOpts.sorts
+	if len(sorts) == 0 {
+		sorts = r.defaultSort
+	}
+	orderBy, err := r.orderBy(sorts)
+	if err != nil {
+		return nil, paginator.Metadata{}, err
+	}
+
+	query := database.DB(ctx, r.db).Model(new(T)).Scopes(listOpts.scopes...)
+	if listOpts.includeDeleted || listOpts.onlyDeleted {
+		if r.deletedAt == nil {
+			return nil, paginator.Metadata{}, ErrSoftDeleteUnsupported
+		}
+		query = query.Unscoped()
+	}
+	if listOpts.onlyDeleted {
+		query = query.Where(clause.Neq{Column: r.column(r.deletedAt), Value: nil})
+	}
+
+	var total int64
+	if err = query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, paginator.Metadata{}, err
+	}
+
+	query = query.Clauses(orderBy)
+	if params.Limit() > 0 {
+		query = query.Offset(params.Offset()).Limit(params.Limit())
+	}
+
+	var entities []T
+	if err = query.Find(&entities).Error; err != nil {
+		return nil, paginator.Metadata{}, err
+	}
+
+	return entities, paginator.Metadata{
+		TotalCount: total,
+		Page:       params.Page,
+		PerPage:    params.PerPage,
+		TotalPages: paginator.TotalPages(total, params.PerPage),
+	}, nil
+}
+
+func (r *Repository[T, ID]) delete(db *gorm.DB, id ID) error {
+	result := db.Where(r.primaryKeyEq(id)).Delete(new(T))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *Repository[T, ID]) orderBy(sorts []Sort) (clause.OrderBy, error) {
+	columns := make([]clause.OrderByColumn, 0, len(sorts))
+	for _, sort := range sorts {
+		if !slices.Contains(r.sortable, sort.Column) {
+			return clause.OrderBy{}, fmt.Errorf("%w: %s", ErrInvalidSort, sort.Column)
+		}
+		columns = append(columns, clause.OrderByColumn{
+			Column: clause.Column{Table: clause.CurrentTable, Name: sort.Column},
+			Desc:   sort.Desc,
+		})
+	}
+	return clause.OrderBy{Columns: columns}, nil
+}
+
+func (r *Repository[T, ID]) primaryKeyEq(id any) clause.Eq {
+	return clause.Eq{Column: r.column(r.primaryKey), Value: id}
+}
+
+func (r *Repository[T, ID]) column(field *schema.Field) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+}
+
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func integer(value reflect.Value) int64 {
+	if value.CanInt() {
+		return value.Int()
+	}
+	return int64(value.Uint()) //nolint:gosec // versions stay far below the int64 range
+}
+
+func setInteger(value reflect.Value, n int64) {
+	if value.CanInt() {
+		value.SetInt(n)
+		return
+	}
+	value.SetUint(uint64(n)) //nolint:gosec // versions are never negative
+}
@@ -0,0 +1,253 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database/repository"
+	"github.com/cristiano-pacheco/bricks/pkg/paginator"
+)
+
+type widget struct {
+	ID        int64
+	Name      string
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+type note struct {
+	ID   string
+	Body string
+}
+
+type RepositoryTestSuite struct {
+	suite.Suite
+	statements []string
+	db         *gorm.DB
+	sut        *repository.Repository[widget, int64]
+}
+
+func TestRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RepositoryTestSuite))
+}
+
+// sqlRecorder keeps the statements of a dry run session
+type sqlRecorder struct {
+	logger.Interface
+	statements *[]string
+}
+
+func (r sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	statement, _ := fc()
+	*r.statements = append(*r.statements, statement)
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.statements = nil
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{
+			DryRun:                 true,
+			DisableAutomaticPing:   true,
+			SkipDefaultTransaction: true,
+			Logger:                 sqlRecorder{Interface: logger.Discard, statements: &s.statements},
+		},
+	)
+	s.Require().NoError(err)
+	s.db = db
+	s.sut, err = repository.New[widget, int64](db)
+	s.Require().NoError(err)
+}
+
+func (s *RepositoryTestSuite) TestGet_FiltersByPrimaryKeyAndExcludesDeleted() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	_, _ = s.sut.Get(ctx, 7)
+
+	// Assert
+	s.Equal(`SELECT * FROM "widgets" WHERE "widgets"."id" = 7 AND "widgets"."deleted_at" IS NULL LIMIT 1`, s.statements[0])
+}
+
+func (s *RepositoryTestSuite) TestCreate_StartsVersionAtOne() {
+	// Arrange
+	entity := &widget{Name: "bolt"}
+
+	// Act
+	err := s.sut.Create(context.Background(), entity)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(1, entity.Version)
+}
+
+func (s *RepositoryTestSuite) TestUpdate_ChecksAndIncrementsVersion() {
+	// Arrange
+	entity := &widget{ID: 7, Name: "bolt", Version: 3}
+
+	// Act
+	_ = s.sut.Update(context.Background(), entity)
+
+	// Assert
+	s.Contains(s.statements[0], `UPDATE "widgets" SET "name"='bolt',"version"=4,"updated_at"=`)
+	s.Contains(s.statements[0], `WHERE "widgets"."version" = 3 AND "widgets"."deleted_at" IS NULL AND "id" = 7`)
+	s.NotContains(s.statements[0], "created_at")
+}
+
+func (s *RepositoryTestSuite) TestUpdate_ZeroID_ReturnsError() {
+	// Arrange
+	entity := &widget{Name: "bolt"}
+
+	// Act
+	err := s.sut.Update(context.Background(), entity)
+
+	// Assert
+	s.Require().ErrorIs(err, repository.ErrZeroID)
+}
+
+func (s *RepositoryTestSuite) TestDelete_SoftDeletes() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	_ = s.sut.Delete(ctx, 7)
+
+	// Assert
+	s.Contains(s.statements[0], `UPDATE "widgets" SET "deleted_at"=`)
+	s.Contains(s.statements[0], `WHERE "widgets"."id" = 7 AND "widgets"."deleted_at" IS NULL`)
+}
+
+func (s *RepositoryTestSuite) TestHardDelete_RemovesRow() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	_ = s.sut.HardDelete(ctx, 7)
+
+	// Assert
+	s.Equal(`DELETE FROM "widgets" WHERE "widgets"."id" = 7`, s.statements[0])
+}
+
+func (s *RepositoryTestSuite) TestList_PaginatesWithDefaultSort() {
+	// Arrange
+	params := paginator.Params{Page: 3, PerPage: 20}
+
+	// Act
+	_, meta, err := s.sut.List(context.Background(), params)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(`SELECT count(*) FROM "widgets" WHERE "widgets"."deleted_at" IS NULL`, s.statements[0])
+	s.Equal(`SELECT * FROM "widgets" WHERE "widgets"."deleted_at" IS NULL ORDER BY "widgets"."id" LIMIT 20 OFFSET 40`,
+		s.statements[1])
+	s.Equal(3, meta.Page)
+	s.Equal(20, meta.PerPage)
+}
+
+func (s *RepositoryTestSuite) TestList_SortsAndFilters() {
+	// Arrange
+	params := paginator.Params{}
+
+	// Act
+	_, _, err := s.sut.List(context.Background(), params,
+		repository.OrderBy(repository.ParseSort("name,-created_at")...),
+		repository.Filter(func(db *gorm.DB) *gorm.DB { return db.Where("name LIKE ?", "b%") }),
+		repository.OnlyDeleted(),
+	)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(
+		`SELECT * FROM "widgets" WHERE "widgets"."deleted_at" IS NOT NULL AND name LIKE 'b%' `+
+			`ORDER BY "widgets"."name","widgets"."created_at" DESC`,
+		s.statements[1],
+	)
+}
+
+func (s *RepositoryTestSuite) TestList_UnknownSortColumn_ReturnsError() {
+	// Arrange
+	sort := repository.Sort{Column: "name; DROP TABLE widgets"}
+
+	// Act
+	_, _, err := s.sut.List(context.Background(), paginator.Params{}, repository.OrderBy(sort))
+
+	// Assert
+	s.Require().ErrorIs(err, repository.ErrInvalidSort)
+}
+
+func (s *RepositoryTestSuite) TestList_NotSortableColumn_ReturnsError() {
+	// Arrange
+	sut, err := repository.New[widget, int64](s.db, repository.WithSortableColumns("name"))
+	s.Require().NoError(err)
+
+	// Act
+	_, _, err = sut.List(context.Background(), paginator.Params{},
+		repository.OrderBy(repository.Sort{Column: "version"}))
+
+	// Assert
+	s.Require().ErrorIs(err, repository.ErrInvalidSort)
+}
+
+func (s *RepositoryTestSuite) TestWithoutSoftDelete_RestoreAndDeletedListsFail() {
+	// Arrange
+	sut, err := repository.New[note, string](s.db)
+	s.Require().NoError(err)
+
+	// Act
+	restoreErr := sut.Restore(context.Background(), "n1")
+	_, _, listErr := sut.List(context.Background(), paginator.Params{}, repository.IncludeDeleted())
+
+	// Assert
+	s.Require().ErrorIs(restoreErr, repository.ErrSoftDeleteUnsupported)
+	s.Require().ErrorIs(listErr, repository.ErrSoftDeleteUnsupported)
+}
+
+func TestNew(t *testing.T) {
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true},
+	)
+	require.NoError(t, err)
+
+	t.Run("rejects a non integer version column", func(t *testing.T) {
+		// Act
+		_, err := repository.New[widget, int64](db, repository.WithVersionColumn("name"))
+
+		// Assert
+		require.ErrorIs(t, err, repository.ErrInvalidVersionColumn)
+	})
+
+	t.Run("rejects entities without primary key", func(t *testing.T) {
+		// Arrange
+		type event struct {
+			Name string
+		}
+
+		// Act
+		_, err := repository.New[event, int64](db)
+
+		// Assert
+		require.ErrorIs(t, err, repository.ErrMissingPrimaryKey)
+	})
+}
+
+func TestParseSort(t *testing.T) {
+	t.Run("parses ascending and descending columns", func(t *testing.T) {
+		// Act
+		sorts := repository.ParseSort(" name, -created_at ,,")
+
+		// Assert
+		assert.Equal(t, []repository.Sort{{Column: "name"}, {Column: "created_at", Desc: true}}, sorts)
+	})
+}
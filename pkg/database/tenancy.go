@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+// Tenancy strategies.
+const (
+	// TenancyNone disables multi-tenancy
+	TenancyNone = ""
+	// TenancyRow scopes the models with a tenant column to the ctxmeta tenant
+	TenancyRow = "row"
+	// TenancySchema runs the queries in the schema of the ctxmeta tenant
+	TenancySchema = "schema"
+)
+
+const (
+	defaultTenantColumn = "tenant_id"
+	defaultSchemaPrefix = "tenant_"
+	tenancyPluginName   = "bricks:tenancy"
+)
+
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// TenancyConfig configures multi-tenancy.
+type TenancyConfig struct {
+	// Strategy is TenancyNone, TenancyRow or TenancySchema
+	Strategy string
+	// Column is the tenant column of the row strategy, default "tenant_id"
+	Column string
+	// SchemaPrefix is prepended to the tenant ID to name its schema, default "tenant_"
+	SchemaPrefix string
+}
+
+// Validate validates the tenancy configuration
+func (c TenancyConfig) Validate() error {
+	switch c.Strategy {
+	case TenancyNone, TenancyRow, TenancySchema:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidTenancyStrategy, c.Strategy)
+	}
+}
+
+func (c TenancyConfig) column() string {
+	if c.Column == "" {
+		return defaultTenantColumn
+	}
+	return c.Column
+}
+
+func (c TenancyConfig) schemaPrefix() string {
+	if c.SchemaPrefix == "" {
+		return defaultSchemaPrefix
+	}
+	return c.SchemaPrefix
+}
+
+type withoutTenantKey struct{}
+
+// WithoutTenant returns a context whose queries are not scoped to a tenant, e.g. for
+// migrations, back-office reports or jobs across all tenants.
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutTenantKey{}, true)
+}
+
+func isWithoutTenant(ctx context.Context) bool {
+	without, _ := ctx.Value(withoutTenantKey{}).(bool)
+	return without
+}
+
+// TenancyPlugin is the GORM plugin that applies the tenancy strategy to the queries,
+// based on the tenant carried by ctxmeta.
+//
+// With TenancyRow, the models that have the tenant column are filtered by the tenant on
+// query, update and delete, and get the tenant of the context on create.
+//
+// With TenancySchema, the model tables are qualified with the tenant schema, and
+// TxManager transactions switch their search_path to it so raw SQL resolves too.
+//
+// Queries of tenant scoped models fail with ErrMissingTenant when the context carries
+// no tenant, unless it comes from WithoutTenant.
+type TenancyPlugin struct {
+	cfg TenancyConfig
+}
+
+// NewTenancyPlugin creates the TenancyPlugin of cfg.
+func NewTenancyPlugin(cfg TenancyConfig) *TenancyPlugin {
+	return &TenancyPlugin{cfg: cfg}
+}
+
+// Name implements gorm.Plugin.
+func (p *TenancyPlugin) Name() string {
+	return tenancyPluginName
+}
+
+// Initialize implements gorm.Plugin.
+func (p *TenancyPlugin) Initialize(db *gorm.DB) error {
+	if err := p.cfg.Validate(); err != nil {
+		return err
+	}
+
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("bricks:tenancy_create", p.beforeCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("bricks:tenancy_query", p.scope); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("bricks:tenancy_update", p.scope); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("bricks:tenancy_delete", p.scope); err != nil {
+		return err
+	}
+	return callbacks.Row().Before("gorm:row").Register("bricks:tenancy_row", p.scope)
+}
+
+// SchemaFor returns the schema of the tenant carried by ctx, and whether there is one.
+func (p *TenancyPlugin) SchemaFor(ctx context.Context) (string, bool, error) {
+	if p.cfg.Strategy != TenancySchema || isWithoutTenant(ctx) {
+		return "", false, nil
+	}
+	tenantID, ok := ctxmeta.TenantID(ctx)
+	if !ok {
+		return "", false, ErrMissingTenant
+	}
+	if !tenantIDPattern.MatchString(tenantID) {
+		return "", false, fmt.Errorf("%w: %q", ErrInvalidTenant, tenantID)
+	}
+	return p.cfg.schemaPrefix() + tenantID, true, nil
+}
+
+func (p *TenancyPlugin) beforeCreate(db *gorm.DB) {
+	stmt := db.Statement
+	if p.cfg.Strategy == TenancySchema {
+		p.qualifyTable(db)
+		return
+	}
+
+	field, tenantID, ok := p.rowTenant(db)
+	if !ok {
+		return
+	}
+	// The tenant of the context wins, so rows can't be created for another tenant
+	setFields(stmt, field, tenantID, false)
+}
+
+func (p *TenancyPlugin) scope(db *gorm.DB) {
+	if p.cfg.Strategy == TenancySchema {
+		p.qualifyTable(db)
+		return
+	}
+
+	field, tenantID, ok := p.rowTenant(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenantID},
+	}})
+}
+
+// rowTenant returns the tenant column and the tenant of a row scoped statement.
+func (p *TenancyPlugin) rowTenant(db *gorm.DB) (*schema.Field, string, bool) {
+	stmt := db.Statement
+	if p.cfg.Strategy != TenancyRow || stmt.Schema == nil || stmt.SQL.Len() > 0 || isWithoutTenant(stmt.Context) {
+		return nil, "", false
+	}
+	field := stmt.Schema.LookUpField(p.cfg.column())
+	if field == nil {
+		return nil, "", false
+	}
+
+	tenantID, ok := ctxmeta.TenantID(stmt.Context)
+	if !ok {
+		_ = db.AddError(fmt.Errorf("%w: %s", ErrMissingTenant, stmt.Schema.Table))
+		return nil, "", false
+	}
+	return field, tenantID, true
+}
+
+// qualifyTable runs the statement on the table of the tenant schema.
+func (p *TenancyPlugin) qualifyTable(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt.Schema == nil || stmt.TableExpr != nil || stmt.SQL.Len() > 0 {
+		return
+	}
+	tenantSchema, ok, err := p.SchemaFor(stmt.Context)
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("%w: %s", err, stmt.Schema.Table))
+		return
+	}
+	if ok {
+		stmt.Table = tenantSchema + "." + stmt.Schema.Table
+	}
+}
+
+func tenancyPlugin(db *gorm.DB) (*TenancyPlugin, bool) {
+	plugin, ok := db.Config.Plugins[tenancyPluginName].(*TenancyPlugin)
+	return plugin, ok
+}
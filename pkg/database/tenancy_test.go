@@ -0,0 +1,184 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+type invoice struct {
+	ID       int64
+	TenantID string
+	Total    int64
+}
+
+type plan struct {
+	ID   int64
+	Name string
+}
+
+type TenancyPluginTestSuite struct {
+	suite.Suite
+	statements []string
+}
+
+func TestTenancyPluginTestSuite(t *testing.T) {
+	suite.Run(t, new(TenancyPluginTestSuite))
+}
+
+func (s *TenancyPluginTestSuite) SetupTest() {
+	s.statements = nil
+}
+
+func (s *TenancyPluginTestSuite) openDB(cfg database.TenancyConfig) *gorm.DB {
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=test dbname=test sslmode=disable"}),
+		&gorm.Config{
+			DryRun:                 true,
+			DisableAutomaticPing:   true,
+			SkipDefaultTransaction: true,
+			Logger:                 sqlRecorder{Interface: logger.Discard, statements: &s.statements},
+		},
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(db.Use(database.NewTenancyPlugin(cfg)))
+	return db
+}
+
+func (s *TenancyPluginTestSuite) TestRow_Query_FiltersByTenant() {
+	// Arrange
+	db := s.openDB(database.TenancyConfig{Strategy: database.TenancyRow})
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+	var invoices []invoice
+
+	// Act
+	err := db.WithContext(ctx).Where("total > ?", 10).Find(&invoices).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{`SELECT * FROM "invoices" WHERE total > 10 AND "invoices"."tenant_id" = 'acme'`}, s.statements)
+}
+
+func (s *TenancyPluginTestSuite) TestRow_UpdateAndDelete_FilterByTenant() {
+	// Arrange
+	db := s.openDB(database.TenancyConfig{Strategy: database.TenancyRow})
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+
+	// Act
+	updateErr := db.WithContext(ctx).Model(&invoice{ID: 1}).Update("total", 20).Error
+	deleteErr := db.WithContext(ctx).Delete(&invoice{ID: 1}).Error
+
+	// Assert
+	s.Require().NoError(updateErr)
+	s.Require().NoError(deleteErr)
+	s.Equal([]string{
+		`UPDATE "invoices" SET "total"=20 WHERE "invoices"."tenant_id" = 'acme' AND "id" = 1`,
+		`DELETE FROM "invoices" WHERE "invoices"."tenant_id" = 'acme' AND "invoices"."id" = 1`,
+	}, s.statements)
+}
+
+func (s *TenancyPluginTestSuite) TestRow_Create_SetsContextTenant() {
+	// Arrange
+	db := s.openDB(database.TenancyConfig{Strategy: database.TenancyRow})
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+	entities := []invoice{{Total: 10}, {Total: 20, TenantID: "globex"}}
+
+	// Act
+	err := db.WithContext(ctx).Create(&entities).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("acme", entities[0].TenantID)
+	s.Equal("acme", entities[1].TenantID)
+}
+
+func (s *TenancyPluginTestSuite) TestRow_MissingTenant_ReturnsError() {
+	// Arrange
+	db := s.openDB(database.TenancyConfig{Strategy: database.TenancyRow})
+	var invoices []invoice
+
+	// Act
+	err := db.WithContext(context.Background()).Find(&invoices).Error
+
+	// Assert
+	s.Require().ErrorIs(err, database.ErrMissingTenant)
+	s.Empty(s.statements)
+}
+
+func (s *TenancyPluginTestSuite) TestRow_WithoutTenant_SkipsScope() {
+	// Arrange
+	db := s.openDB(database.TenancyConfig{Strategy: database.TenancyRow})
+	var invoices []invoice
+
+	// Act
+	err := db.WithContext(database.WithoutTenant(context.Background())).Find(&invoices).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{`SELECT * FROM "invoices"`}, s.statements)
+}
+
+func (s *TenancyPluginTestSuite) TestRow_ModelWithoutTenantColumn_IsNotScoped() {
+	// Arrange
+	db := s.openDB(database.TenancyConfig{Strategy: database.TenancyRow})
+	var plans []plan
+
+	// Act
+	err := db.WithContext(context.Background()).Find(&plans).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{`SELECT * FROM "plans"`}, s.statements)
+}
+
+func (s *TenancyPluginTestSuite) TestSchema_QualifiesTables() {
+	// Arrange
+	db := s.openDB(database.TenancyConfig{Strategy: database.TenancySchema})
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+	var plans []plan
+
+	// Act
+	err := db.WithContext(ctx).Where(&plan{Name: "pro"}).Find(&plans).Error
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{`SELECT * FROM "tenant_acme"."plans" WHERE "tenant_acme"."plans"."name" = 'pro'`}, s.statements)
+}
+
+func (s *TenancyPluginTestSuite) TestSchema_InvalidTenant_ReturnsError() {
+	// Arrange
+	db := s.openDB(database.TenancyConfig{Strategy: database.TenancySchema})
+	ctx := ctxmeta.WithTenantID(context.Background(), `acme"; DROP SCHEMA public; --`)
+	var plans []plan
+
+	// Act
+	err := db.WithContext(ctx).Find(&plans).Error
+
+	// Assert
+	s.Require().ErrorIs(err, database.ErrInvalidTenant)
+	s.Empty(s.statements)
+}
+
+func TestTenancyConfig_Validate(t *testing.T) {
+	t.Run("accepts the supported strategies", func(t *testing.T) {
+		for _, strategy := range []string{database.TenancyNone, database.TenancyRow, database.TenancySchema} {
+			require.NoError(t, database.TenancyConfig{Strategy: strategy}.Validate())
+		}
+	})
+
+	t.Run("rejects unknown strategies", func(t *testing.T) {
+		// Act
+		err := database.TenancyConfig{Strategy: "database"}.Validate()
+
+		// Assert
+		require.ErrorIs(t, err, database.ErrInvalidTenancyStrategy)
+	})
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type txContextKey struct{}
+
+// TxManager runs functions in a database transaction carried by their context.
+type TxManager interface {
+	// WithinTransaction runs fn in a transaction, committed when fn returns nil and rolled back
+	// when it returns an error or panics. When ctx already carries a transaction, fn joins it.
+	// With schema tenancy the transaction uses the search_path of the ctxmeta tenant.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// GormTxManager is the TxManager of a *gorm.DB.
+type GormTxManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager creates the TxManager of db.
+func NewTxManager(db *gorm.DB) *GormTxManager {
+	return &GormTxManager{db: db}
+}
+
+// WithinTransaction implements TxManager.
+func (m *GormTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := setTenantSearchPath(ctx, tx); err != nil {
+			return err
+		}
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// DB returns the transaction carried by ctx, or db bound to ctx when there is none.
+// Repositories use it so their queries join the transaction of the use case.
+//
+//	func (r *OrderRepository) Save(ctx context.Context, order *Order) error {
+//	    return database.DB(ctx, r.db).Save(order).Error
+//	}
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// setTenantSearchPath switches the search_path of the transaction to the tenant schema,
+// so raw SQL resolves the tenant tables.
+func setTenantSearchPath(ctx context.Context, tx *gorm.DB) error {
+	plugin, ok := tenancyPlugin(tx)
+	if !ok {
+		return nil
+	}
+	tenantSchema, ok, err := plugin.SchemaFor(ctx)
+	if err != nil || !ok {
+		return err
+	}
+
+	var quoted strings.Builder
+	tx.QuoteTo(&quoted, tenantSchema)
+	if err = tx.Exec("SET LOCAL search_path TO " + quoted.String() + ", public").Error; err != nil {
+		return fmt.Errorf("set tenant search_path: %w", err)
+	}
+	return nil
+}
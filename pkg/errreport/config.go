@@ -0,0 +1,76 @@
+package errreport
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DriverSentry = "sentry"
+
+	defaultSampleRate = 1.0
+	defaultTimeout    = 5 * time.Second
+	defaultQueueSize  = 100
+)
+
+// defaultScrubKeys are the key fragments whose values are filtered out of the events.
+var defaultScrubKeys = []string{
+	"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey",
+	"credential", "private_key", "session", "card", "iban",
+}
+
+// Config configures the error reporting.
+type Config struct {
+	Enabled bool   `config:"enabled"` // Enable/disable the error reporting
+	Driver  string `config:"driver"`  // Error tracking service, sentry, default: sentry
+	// DSN is the Sentry project DSN, e.g. env://SENTRY_DSN
+	DSN         string `config:"dsn"`
+	Environment string `config:"environment"` // Environment of the events, e.g. production
+	Release     string `config:"release"`     // Release of the events, e.g. orders@1.4.0
+	ServerName  string `config:"server_name"` // Server name of the events, default: the hostname
+	// SampleRate is the fraction of the events reported, 0.0 to 1.0, default: 1.0
+	SampleRate float64 `config:"sample_rate"`
+	// ScrubKeys are the key fragments of the tags, extras and headers filtered out of the
+	// events, added to the default ones (password, secret, token, authorization, ...)
+	ScrubKeys []string `config:"scrub_keys"`
+	// ScrubEmails masks the email addresses of the messages and extras
+	ScrubEmails bool `config:"scrub_emails"`
+	// LogLevel forwards the log entries from this level, error, dpanic, panic or fatal, with
+	// ForwardLogs; default: "" (none)
+	LogLevel  string        `config:"log_level"`
+	Timeout   time.Duration `config:"timeout"`    // Timeout of the send of an event, default: 5s
+	QueueSize int           `config:"queue_size"` // Events waiting to be sent, default: 100
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = DriverSentry
+	}
+	if c.SampleRate == 0 {
+		c.SampleRate = defaultSampleRate
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.QueueSize == 0 {
+		c.QueueSize = defaultQueueSize
+	}
+}
+
+// Validate checks the driver, the sample rate and the log level. The DSN is checked by New,
+// as a Driver given with WithDriver does not need it.
+func (c *Config) Validate() error {
+	if c.Driver != DriverSentry {
+		return fmt.Errorf("%w: %s", ErrInvalidDriver, c.Driver)
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return ErrInvalidSampleRate
+	}
+	switch c.LogLevel {
+	case "", LevelError, "dpanic", "panic", LevelFatal:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidLogLevel, c.LogLevel)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package errreport
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// contextTags are the log fields of logger.ContextFields reported as tags.
+var contextTags = []string{"request_id", "correlation_id", "tenant_id"}
+
+// core is a zapcore.Core reporting the log entries from a level.
+type core struct {
+	reporter Reporter
+	level    zapcore.Level
+	fields   []zapcore.Field
+}
+
+// NewCore returns a zapcore.Core reporting the entries from level with reporter: the error
+// field is the error of the event, the request, correlation and tenant IDs are tags, the user
+// ID is the user and the other fields are extras.
+func NewCore(reporter Reporter, level zapcore.Level) zapcore.Core {
+	return &core{reporter: reporter, level: level}
+}
+
+// DecorateLogger returns a child of log forwarding its entries from level to reporter, or
+// log itself when it is not a *logger.ZapLogger.
+func DecorateLogger(log logger.Logger, reporter Reporter, level zapcore.Level) logger.Logger {
+	zapLogger, ok := log.(*logger.ZapLogger)
+	if !ok {
+		return log
+	}
+	return zapLogger.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(c, NewCore(reporter, level))
+	})
+}
+
+func (c *core) Enabled(level zapcore.Level) bool {
+	return level >= c.level
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{reporter: c.reporter, level: c.level, fields: append(c.fields[:len(c.fields):len(c.fields)], fields...)}
+}
+
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	event := Event{
+		Time:    entry.Time,
+		Level:   LevelError,
+		Message: entry.Message,
+		Tags:    make(map[string]string),
+	}
+	if entry.Level > zapcore.ErrorLevel {
+		event.Level = LevelFatal
+	}
+	if entry.LoggerName != "" {
+		event.Tags["logger"] = entry.LoggerName
+	}
+
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range append(c.fields[:len(c.fields):len(c.fields)], fields...) {
+		if err, ok := field.Interface.(error); ok && field.Type == zapcore.ErrorType {
+			event.Err = err
+			continue
+		}
+		field.AddTo(encoder)
+	}
+	for _, key := range contextTags {
+		if value, ok := encoder.Fields[key].(string); ok {
+			event.Tags[key] = value
+			delete(encoder.Fields, key)
+		}
+	}
+	if userID, ok := encoder.Fields["user_id"].(string); ok {
+		event.UserID = userID
+		delete(encoder.Fields, "user_id")
+	}
+	if len(encoder.Fields) > 0 {
+		event.Extra = encoder.Fields
+	}
+
+	c.reporter.Report(context.Background(), event)
+	return nil
+}
+
+func (c *core) Sync() error {
+	return nil
+}
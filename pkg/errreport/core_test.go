@@ -0,0 +1,43 @@
+package errreport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+func TestDecorateLogger(t *testing.T) {
+	// Arrange
+	client, driver := newClient(t, errreport.Config{})
+	log := errreport.DecorateLogger(
+		logger.MustNewWithOptions(logger.WithLevel("fatal")), client, zapcore.ErrorLevel,
+	)
+	loggedErr := errors.New("payment declined")
+
+	// Act
+	log.Warn("slow query", logger.String("table", "orders"))
+	log.Error("charge failed",
+		logger.Error(loggedErr),
+		logger.String("request_id", "req-1"),
+		logger.String("user_id", "user-1"),
+		logger.String("order_id", "42"),
+	)
+	require.NoError(t, client.Flush(context.Background()))
+
+	// Assert
+	events := driver.Events()
+	require.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, "charge failed", event.Message)
+	assert.Equal(t, loggedErr, event.Err)
+	assert.Equal(t, "req-1", event.Tags["request_id"])
+	assert.Equal(t, "user-1", event.UserID)
+	assert.Equal(t, map[string]any{"order_id": "42"}, event.Extra)
+}
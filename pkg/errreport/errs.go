@@ -0,0 +1,13 @@
+package errreport
+
+import "errors"
+
+var (
+	ErrInvalidDriver     = errors.New("invalid error reporting driver (must be 'sentry')")
+	ErrDSNRequired       = errors.New("DSN is required when error reporting is enabled")
+	ErrInvalidDSN        = errors.New("invalid Sentry DSN")
+	ErrInvalidSampleRate = errors.New("SampleRate must be between 0.0 and 1.0")
+	ErrInvalidLogLevel   = errors.New("invalid forwarded log level (must be error, dpanic, panic or fatal)")
+
+	ErrSend = errors.New("failed to send error event")
+)
@@ -0,0 +1,5 @@
+package errreport
+
+func (c *Client) SetSample(sample func() float64) {
+	c.sample = sample
+}
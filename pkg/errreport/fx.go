@@ -0,0 +1,61 @@
+package errreport
+
+import (
+	"go.uber.org/fx"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Module provides the Reporter configured from app.errreport, a Nop when the error reporting
+// is disabled. The reported events are sent before the application stops. The chi server
+// reports the recovered panics and the ucdecorator factory the failed use cases with it.
+// A Driver provided to the graph replaces the driver of the configured service.
+var Module = fx.Module(
+	"errreport",
+	config.Provide[Config]("app.errreport"),
+	fx.Provide(NewWithLifecycle),
+)
+
+// ForwardLogs forwards the log entries from app.errreport.log_level to the Reporter. It must
+// be given to the root fx.New next to Module, as fx.Decorate only applies to the module it is
+// declared in:
+//
+//	fx.New(logger.Module, errreport.Module, errreport.ForwardLogs, ...)
+var ForwardLogs = fx.Decorate(forwardLogs)
+
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    config.Config[Config]
+	Driver    Driver `optional:"true"`
+}
+
+// NewWithLifecycle creates the Reporter, and closes it on stop, sending the queued events.
+func NewWithLifecycle(p NewWithLifecycleParams) (Reporter, error) {
+	cfg := p.Config.Get()
+	if !cfg.Enabled {
+		return Nop{}, nil
+	}
+
+	client, err := New(cfg, WithDriver(p.Driver))
+	if err != nil {
+		return nil, err
+	}
+	p.Lifecycle.Append(fx.Hook{OnStop: client.Close})
+	return client, nil
+}
+
+func forwardLogs(log logger.Logger, reporter Reporter, cfg config.Config[Config]) (logger.Logger, error) {
+	reportConfig := cfg.Get()
+	if !reportConfig.Enabled || reportConfig.LogLevel == "" {
+		return log, nil
+	}
+	level, err := zapcore.ParseLevel(reportConfig.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+	return DecorateLogger(log, reporter, level), nil
+}
@@ -0,0 +1,34 @@
+package errreport
+
+import "log/slog"
+
+type options struct {
+	driver Driver
+	logger *slog.Logger
+}
+
+// Option configures the Client created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{logger: slog.Default()}
+}
+
+// WithDriver sets the driver sending the events, e.g. of another error tracking service.
+// Defaults to the driver of Config.Driver.
+func WithDriver(driver Driver) Option {
+	return func(o *options) {
+		if driver != nil {
+			o.driver = driver
+		}
+	}
+}
+
+// WithLogger sets the logger of the failures to send the events. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
@@ -0,0 +1,365 @@
+// Package errreport reports the unexpected errors and panics of the application to an error
+// tracking service, Sentry, with sampling and PII scrubbing.
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+// Levels of the events.
+const (
+	LevelWarning = "warning"
+	LevelError   = "error"
+	LevelFatal   = "fatal"
+)
+
+const (
+	// dedupWindow is the time an error value is remembered: the same error reported by a
+	// hook and logged at error level is reported once
+	dedupWindow = time.Second
+	dedupSize   = 64
+	maxFrames   = 64
+)
+
+// Event is an error reported to the error tracking service.
+type Event struct {
+	ID        string
+	Time      time.Time
+	Level     string // warning, error or fatal, default: error
+	Message   string
+	Err       error
+	Exception *Exception // Type and message of Err, set by the reporter
+	Frames    []Frame    // Stack trace, captured by the reporter when empty
+	Tags      map[string]string
+	Extra     map[string]any
+	UserID    string
+	Request   *Request
+
+	Environment string
+	Release     string
+	ServerName  string
+}
+
+// Exception is the type and message of the error of an event.
+type Exception struct {
+	Type  string
+	Value string
+}
+
+// Frame is a stack frame of an event.
+type Frame struct {
+	Function string
+	Module   string
+	File     string
+	Line     int
+	InApp    bool
+}
+
+// Request is the HTTP request of an event.
+type Request struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+}
+
+// NewRequest returns the Request of r, its headers to be scrubbed by the reporter.
+func NewRequest(r *http.Request) *Request {
+	headers := make(map[string]string, len(r.Header))
+	for name := range r.Header {
+		headers[name] = r.Header.Get(name)
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return &Request{Method: r.Method, URL: scheme + "://" + r.Host + r.URL.RequestURI(), Headers: headers}
+}
+
+// Reporter reports the events to an error tracking service.
+type Reporter interface {
+	// Report reports event in the background
+	Report(ctx context.Context, event Event)
+	// Flush waits until the reported events are sent or ctx is done
+	Flush(ctx context.Context) error
+}
+
+// Driver sends an event to an error tracking service.
+type Driver interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// Nop is a Reporter dropping the events, e.g. when the error reporting is disabled.
+type Nop struct{}
+
+func (Nop) Report(context.Context, Event) {}
+
+func (Nop) Flush(context.Context) error { return nil }
+
+type reportedError struct {
+	err error
+	at  time.Time
+}
+
+// Client is the Reporter sending the events with a Driver, from a background queue.
+type Client struct {
+	cfg      Config
+	driver   Driver
+	log      *slog.Logger
+	scrubber scrubber
+	sample   func() float64
+
+	mu       sync.Mutex
+	closed   bool
+	reported []reportedError
+	next     int
+
+	queue chan queued
+	done  chan struct{}
+}
+
+// queued is an event to send, or a flush marker closed once the events queued before it
+// are sent.
+type queued struct {
+	event   Event
+	flushed chan struct{}
+}
+
+// New creates a Client sending the events with the driver of cfg. Close it to stop its
+// background queue.
+func New(cfg Config, opts ...Option) (*Client, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	driver := o.driver
+	if driver == nil {
+		if cfg.DSN == "" {
+			return nil, ErrDSNRequired
+		}
+		sentry, err := NewSentryDriver(cfg.DSN, http.DefaultClient)
+		if err != nil {
+			return nil, err
+		}
+		driver = sentry
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName, _ = os.Hostname()
+	}
+
+	c := &Client{
+		cfg:      cfg,
+		driver:   driver,
+		log:      o.logger,
+		scrubber: newScrubber(cfg.ScrubKeys, cfg.ScrubEmails),
+		sample:   rand.Float64,
+		reported: make([]reportedError, dedupSize),
+		queue:    make(chan queued, cfg.QueueSize),
+		done:     make(chan struct{}),
+	}
+	go c.run()
+	return c, nil
+}
+
+// Report samples, enriches and scrubs event, then queues it. The request, correlation and
+// tenant IDs of ctx are added as tags and its user ID as the user; the event is dropped when
+// the queue is full.
+func (c *Client) Report(ctx context.Context, event Event) {
+	if event.Err != nil && c.wasReported(event.Err) {
+		return
+	}
+	if c.sample() >= c.cfg.SampleRate {
+		return
+	}
+	if len(event.Frames) == 0 {
+		event.Frames = callers(3)
+	}
+	c.enrich(ctx, &event)
+	c.scrubber.scrub(&event)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.queue <- queued{event: event}:
+	default:
+		c.log.WarnContext(ctx, "error report queue full, event dropped", "event_id", event.ID)
+	}
+}
+
+// Flush waits until the queued events are sent or ctx is done.
+func (c *Client) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	// Sent under the lock, so Close does not close the queue meanwhile
+	select {
+	case c.queue <- queued{flushed: flushed}:
+		c.mu.Unlock()
+	case <-ctx.Done():
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close sends the queued events, waiting until ctx is done, and stops the queue.
+func (c *Client) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.queue)
+	c.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) run() {
+	defer close(c.done)
+	for item := range c.queue {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+		if err := c.driver.Send(ctx, item.event); err != nil {
+			// Warn, so the failure is not forwarded back by ForwardLogs
+			c.log.Warn("failed to send error event", "event_id", item.event.ID, "err", err)
+		}
+		cancel()
+	}
+}
+
+// wasReported reports whether err was reported within the dedup window, and remembers it.
+// Only the comparable errors are remembered, e.g. the pointers returned by errors.New.
+func (c *Client) wasReported(err error) bool {
+	if !reflect.TypeOf(err).Comparable() {
+		return false
+	}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, reported := range c.reported {
+		if reported.err == err && now.Sub(reported.at) < dedupWindow {
+			return true
+		}
+	}
+	c.reported[c.next] = reportedError{err: err, at: now}
+	c.next = (c.next + 1) % len(c.reported)
+	return false
+}
+
+func (c *Client) enrich(ctx context.Context, event *Event) {
+	event.ID = strings.ReplaceAll(uuid.NewString(), "-", "")
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.Level == "" {
+		event.Level = LevelError
+	}
+	if event.Err != nil {
+		event.Exception = &Exception{Type: fmt.Sprintf("%T", event.Err), Value: event.Err.Error()}
+	}
+	if event.Message == "" && event.Err != nil {
+		event.Message = event.Err.Error()
+	}
+	event.Environment = c.cfg.Environment
+	event.Release = c.cfg.Release
+	event.ServerName = c.cfg.ServerName
+
+	if event.Tags == nil {
+		event.Tags = make(map[string]string)
+	}
+	for key, value := range map[string]func(context.Context) (string, bool){
+		"request_id":     ctxmeta.RequestID,
+		"correlation_id": ctxmeta.CorrelationID,
+		"tenant_id":      ctxmeta.TenantID,
+	} {
+		if id, ok := value(ctx); ok {
+			event.Tags[key] = id
+		}
+	}
+	if userID, ok := ctxmeta.UserID(ctx); ok && event.UserID == "" {
+		event.UserID = userID
+	}
+}
+
+// callers returns the stack trace of the caller skip levels up, the outermost frame first.
+// In a deferred recover, it includes the frames of the panic.
+func callers(skip int) []Frame {
+	pcs := make([]uintptr, maxFrames)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var stack []Frame
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			stack = append(stack, newFrame(frame))
+		}
+		if !more {
+			break
+		}
+	}
+	for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+		stack[i], stack[j] = stack[j], stack[i]
+	}
+	return stack
+}
+
+func newFrame(frame runtime.Frame) Frame {
+	module, function := splitFunction(frame.Function)
+	return Frame{
+		Function: function,
+		Module:   module,
+		File:     frame.File,
+		Line:     frame.Line,
+		InApp: !strings.HasPrefix(module, "runtime") && !strings.Contains(frame.File, "/go/pkg/mod/") &&
+			!strings.HasPrefix(module, "github.com/cristiano-pacheco/bricks/pkg/errreport"),
+	}
+}
+
+// splitFunction splits github.com/org/repo/pkg.(*T).Method into its package and function.
+func splitFunction(name string) (string, string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+1+dot+1:]
+}
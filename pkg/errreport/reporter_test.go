@@ -0,0 +1,178 @@
+package errreport_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+)
+
+type recordingDriver struct {
+	mu     sync.Mutex
+	events []errreport.Event
+}
+
+func (d *recordingDriver) Send(_ context.Context, event errreport.Event) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, event)
+	return nil
+}
+
+func (d *recordingDriver) Events() []errreport.Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]errreport.Event(nil), d.events...)
+}
+
+func newClient(t *testing.T, cfg errreport.Config) (*errreport.Client, *recordingDriver) {
+	t.Helper()
+	driver := &recordingDriver{}
+	client, err := errreport.New(cfg, errreport.WithDriver(driver))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close(context.Background()) })
+	return client, driver
+}
+
+func TestNew(t *testing.T) {
+	t.Run("requires the DSN without a custom driver", func(t *testing.T) {
+		// Act
+		_, err := errreport.New(errreport.Config{Enabled: true})
+
+		// Assert
+		require.ErrorIs(t, err, errreport.ErrDSNRequired)
+	})
+
+	t.Run("rejects an invalid DSN", func(t *testing.T) {
+		// Act
+		_, err := errreport.New(errreport.Config{Enabled: true, DSN: "https://sentry.io/"})
+
+		// Assert
+		require.ErrorIs(t, err, errreport.ErrInvalidDSN)
+	})
+
+	t.Run("rejects an invalid sample rate", func(t *testing.T) {
+		// Act
+		_, err := errreport.New(errreport.Config{Enabled: true, SampleRate: 1.5}, errreport.WithDriver(&recordingDriver{}))
+
+		// Assert
+		require.ErrorIs(t, err, errreport.ErrInvalidSampleRate)
+	})
+}
+
+func TestClient_Report(t *testing.T) {
+	t.Run("enriches the event with the context and the config", func(t *testing.T) {
+		// Arrange
+		client, driver := newClient(t, errreport.Config{Environment: "production", Release: "orders@1.4.0"})
+		ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+		ctx = ctxmeta.WithUserID(ctx, "user-1")
+		reportedErr := errors.New("connection refused")
+
+		// Act
+		client.Report(ctx, errreport.Event{Err: reportedErr})
+		require.NoError(t, client.Flush(context.Background()))
+
+		// Assert
+		events := driver.Events()
+		require.Len(t, events, 1)
+		event := events[0]
+		assert.Len(t, event.ID, 32)
+		assert.Equal(t, errreport.LevelError, event.Level)
+		assert.Equal(t, "connection refused", event.Message)
+		assert.Equal(t, &errreport.Exception{Type: "*errors.errorString", Value: "connection refused"}, event.Exception)
+		assert.Equal(t, "req-1", event.Tags["request_id"])
+		assert.Equal(t, "user-1", event.UserID)
+		assert.Equal(t, "production", event.Environment)
+		assert.Equal(t, "orders@1.4.0", event.Release)
+		assert.NotEmpty(t, event.ServerName)
+		require.NotEmpty(t, event.Frames)
+		assert.Equal(t, "TestClient_Report.func1", event.Frames[len(event.Frames)-1].Function)
+	})
+
+	t.Run("scrubs the secrets and the email addresses", func(t *testing.T) {
+		// Arrange
+		client, driver := newClient(t, errreport.Config{ScrubKeys: []string{"ssn"}, ScrubEmails: true})
+		req := httptest.NewRequest("GET", "/orders?access_token=abc&page=2", nil)
+		req.Header.Set("Authorization", "Bearer abc")
+		req.Header.Set("Accept", "application/json")
+
+		// Act
+		client.Report(context.Background(), errreport.Event{
+			Message: "no order for jane@example.com",
+			Tags:    map[string]string{"customer_ssn": "123-45-6789"},
+			Extra:   map[string]any{"payload": map[string]any{"password": "hunter2", "count": 2}},
+			Request: errreport.NewRequest(req),
+		})
+		require.NoError(t, client.Flush(context.Background()))
+
+		// Assert
+		events := driver.Events()
+		require.Len(t, events, 1)
+		event := events[0]
+		assert.Equal(t, "no order for [Filtered]", event.Message)
+		assert.Equal(t, "[Filtered]", event.Tags["customer_ssn"])
+		assert.Equal(t, map[string]any{"password": "[Filtered]", "count": 2}, event.Extra["payload"])
+		assert.Equal(t, "[Filtered]", event.Request.Headers["Authorization"])
+		assert.Equal(t, "application/json", event.Request.Headers["Accept"])
+		assert.Equal(t, "http://example.com/orders?access_token=%5BFiltered%5D&page=2", event.Request.URL)
+	})
+
+	t.Run("reports the same error once within the dedup window", func(t *testing.T) {
+		// Arrange
+		client, driver := newClient(t, errreport.Config{})
+		reportedErr := errors.New("connection refused")
+
+		// Act
+		client.Report(context.Background(), errreport.Event{Err: reportedErr})
+		client.Report(context.Background(), errreport.Event{Err: reportedErr, Message: "logged"})
+		client.Report(context.Background(), errreport.Event{Err: errors.New("connection refused")})
+		require.NoError(t, client.Flush(context.Background()))
+
+		// Assert
+		assert.Len(t, driver.Events(), 2)
+	})
+
+	t.Run("drops the events out of the sample", func(t *testing.T) {
+		// Arrange
+		client, driver := newClient(t, errreport.Config{SampleRate: 0.25})
+		samples := []float64{0.1, 0.5}
+		client.SetSample(func() float64 {
+			sample := samples[0]
+			samples = samples[1:]
+			return sample
+		})
+
+		// Act
+		client.Report(context.Background(), errreport.Event{Message: "sampled"})
+		client.Report(context.Background(), errreport.Event{Message: "dropped"})
+		require.NoError(t, client.Flush(context.Background()))
+
+		// Assert
+		events := driver.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "sampled", events[0].Message)
+	})
+
+	t.Run("drops the events once closed", func(t *testing.T) {
+		// Arrange
+		client, driver := newClient(t, errreport.Config{})
+		client.Report(context.Background(), errreport.Event{Message: "sent"})
+		require.NoError(t, client.Close(context.Background()))
+
+		// Act
+		client.Report(context.Background(), errreport.Event{Message: "dropped"})
+
+		// Assert
+		events := driver.Events()
+		require.Len(t, events, 1)
+		assert.Equal(t, "sent", events[0].Message)
+		assert.NoError(t, client.Flush(context.Background()))
+	})
+}
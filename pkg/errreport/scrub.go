@@ -0,0 +1,105 @@
+package errreport
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const filteredValue = "[Filtered]"
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// scrubber filters the secrets and the PII out of the events.
+type scrubber struct {
+	keys   []string
+	emails bool
+}
+
+func newScrubber(keys []string, emails bool) scrubber {
+	all := make([]string, 0, len(defaultScrubKeys)+len(keys))
+	all = append(all, defaultScrubKeys...)
+	for _, key := range keys {
+		all = append(all, strings.ToLower(key))
+	}
+	return scrubber{keys: all, emails: emails}
+}
+
+// scrub filters the values of the secret keys of the tags, extras, headers and query
+// parameters, and masks the email addresses when enabled.
+func (s scrubber) scrub(event *Event) {
+	event.Message = s.text(event.Message)
+	if event.Exception != nil {
+		event.Exception.Value = s.text(event.Exception.Value)
+	}
+	for key, value := range event.Tags {
+		event.Tags[key] = s.value(key, value).(string)
+	}
+	for key, value := range event.Extra {
+		event.Extra[key] = s.value(key, value)
+	}
+	if event.Request != nil {
+		for name, value := range event.Request.Headers {
+			event.Request.Headers[name] = s.value(name, value).(string)
+		}
+		event.Request.URL = s.url(event.Request.URL)
+	}
+}
+
+func (s scrubber) value(key string, value any) any {
+	if s.isSecret(key) {
+		return filteredValue
+	}
+	switch typed := value.(type) {
+	case string:
+		return s.text(typed)
+	case map[string]any:
+		scrubbed := make(map[string]any, len(typed))
+		for k, v := range typed {
+			scrubbed[k] = s.value(k, v)
+		}
+		return scrubbed
+	default:
+		return value
+	}
+}
+
+func (s scrubber) isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range s.keys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s scrubber) text(text string) string {
+	if !s.emails {
+		return text
+	}
+	return emailPattern.ReplaceAllString(text, filteredValue)
+}
+
+// url filters the secret query parameters and the password of rawURL.
+func (s scrubber) url(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for key := range query {
+		if s.isSecret(key) {
+			query.Set(key, filteredValue)
+		} else if s.emails {
+			for i, value := range query[key] {
+				query[key][i] = s.text(value)
+			}
+		}
+	}
+	u.RawQuery = query.Encode()
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), filteredValue)
+	}
+	return u.String()
+}
@@ -0,0 +1,194 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const sentryClient = "bricks-errreport/1.0"
+
+// SentryDriver sends the events to the envelope endpoint of a Sentry project.
+type SentryDriver struct {
+	dsn      string
+	endpoint string
+	auth     string
+	client   *http.Client
+}
+
+// NewSentryDriver creates a SentryDriver for the project of dsn, e.g.
+// https://public-key@o123.ingest.sentry.io/456.
+func NewSentryDriver(dsn string, client *http.Client) (*SentryDriver, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDSN, err)
+	}
+	key := u.User.Username()
+	// The project ID is the last segment of the path, after an optional prefix
+	path := strings.TrimSuffix(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	prefix, projectID := path[:max(slash, 0)], path[slash+1:]
+	if key == "" || projectID == "" || u.Host == "" {
+		return nil, ErrInvalidDSN
+	}
+
+	return &SentryDriver{
+		dsn:      dsn,
+		endpoint: fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, projectID),
+		auth:     fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, key),
+		client:   client,
+	}, nil
+}
+
+// Send posts event in an envelope.
+func (d *SentryDriver) Send(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(newSentryEvent(event))
+	if err != nil {
+		return err
+	}
+	header, err := json.Marshal(map[string]string{
+		"event_id": event.ID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339),
+		"dsn":      d.dsn,
+	})
+	if err != nil {
+		return err
+	}
+	itemHeader, err := json.Marshal(map[string]any{"type": "event", "length": len(payload)})
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	for _, line := range [][]byte{header, itemHeader, payload} {
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", d.auth)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSend, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: %s: %s", ErrSend, resp.Status, bytes.TrimSpace(message))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// sentryEvent is the event payload of Sentry, see https://develop.sentry.dev/sdk/data-model/event-payloads/.
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Message     *sentryMessage    `json:"message,omitempty"`
+	Exception   *sentryExceptions `json:"exception,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	User        *sentryUser       `json:"user,omitempty"`
+	Request     *sentryRequest    `json:"request,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+}
+
+type sentryMessage struct {
+	Formatted string `json:"formatted"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+type sentryUser struct {
+	ID string `json:"id"`
+}
+
+type sentryRequest struct {
+	Method  string            `json:"method,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+func newSentryEvent(event Event) sentryEvent {
+	payload := sentryEvent{
+		EventID:     event.ID,
+		Timestamp:   event.Time.UTC().Format(time.RFC3339Nano),
+		Level:       event.Level,
+		Platform:    "go",
+		Tags:        event.Tags,
+		Extra:       event.Extra,
+		Environment: event.Environment,
+		Release:     event.Release,
+		ServerName:  event.ServerName,
+	}
+	if event.Message != "" {
+		payload.Message = &sentryMessage{Formatted: event.Message}
+	}
+
+	frames := make([]sentryFrame, 0, len(event.Frames))
+	for _, frame := range event.Frames {
+		frames = append(frames, sentryFrame{
+			Function: frame.Function,
+			Module:   frame.Module,
+			AbsPath:  frame.File,
+			Lineno:   frame.Line,
+			InApp:    frame.InApp,
+		})
+	}
+	exception := sentryException{Type: "message", Value: event.Message}
+	if event.Exception != nil {
+		exception.Type, exception.Value = event.Exception.Type, event.Exception.Value
+	}
+	if len(frames) > 0 {
+		exception.Stacktrace = &sentryStacktrace{Frames: frames}
+	}
+	if event.Exception != nil || len(frames) > 0 {
+		payload.Exception = &sentryExceptions{Values: []sentryException{exception}}
+	}
+
+	if event.UserID != "" {
+		payload.User = &sentryUser{ID: event.UserID}
+	}
+	if event.Request != nil {
+		payload.Request = &sentryRequest{
+			Method:  event.Request.Method,
+			URL:     event.Request.URL,
+			Headers: event.Request.Headers,
+		}
+	}
+	return payload
+}
@@ -0,0 +1,82 @@
+package errreport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+)
+
+func TestSentryDriver_Send(t *testing.T) {
+	t.Run("posts the event in an envelope to the project", func(t *testing.T) {
+		// Arrange
+		var path, auth string
+		var lines [][]byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, auth = r.URL.Path, r.Header.Get("X-Sentry-Auth")
+			body, _ := io.ReadAll(r.Body)
+			lines = bytes.Split(bytes.TrimSpace(body), []byte("\n"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		dsn := strings.Replace(server.URL, "://", "://public-key@", 1) + "/sentry/456"
+		driver, err := errreport.NewSentryDriver(dsn, server.Client())
+		require.NoError(t, err)
+
+		// Act
+		err = driver.Send(context.Background(), errreport.Event{
+			ID:        "0123456789abcdef0123456789abcdef",
+			Time:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Level:     errreport.LevelFatal,
+			Message:   "panic recovered: boom",
+			Exception: &errreport.Exception{Type: "*errors.errorString", Value: "boom"},
+			Frames:    []errreport.Frame{{Function: "main", Module: "main", Line: 10, InApp: true}},
+			UserID:    "user-1",
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "/sentry/api/456/envelope/", path)
+		assert.Contains(t, auth, "sentry_key=public-key")
+		require.Len(t, lines, 3)
+		var header map[string]string
+		require.NoError(t, json.Unmarshal(lines[0], &header))
+		assert.Equal(t, "0123456789abcdef0123456789abcdef", header["event_id"])
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(lines[2], &payload))
+		assert.Equal(t, "fatal", payload["level"])
+		assert.Equal(t, "2026-01-02T03:04:05Z", payload["timestamp"])
+		assert.Equal(t, map[string]any{"id": "user-1"}, payload["user"])
+		exception := payload["exception"].(map[string]any)["values"].([]any)[0].(map[string]any)
+		assert.Equal(t, "*errors.errorString", exception["type"])
+		assert.Equal(t, "boom", exception["value"])
+	})
+
+	t.Run("returns ErrSend on a rejected event", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+		driver, err := errreport.NewSentryDriver(strings.Replace(server.URL, "://", "://key@", 1)+"/1", server.Client())
+		require.NoError(t, err)
+
+		// Act
+		err = driver.Send(context.Background(), errreport.Event{ID: "1", Err: errors.New("boom")})
+
+		// Assert
+		require.ErrorIs(t, err, errreport.ErrSend)
+		assert.Contains(t, err.Error(), "rate limited")
+	})
+}
@@ -0,0 +1,24 @@
+package errs
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClientClosed is the cancel cause of the request contexts whose client disconnected,
+// set by the chi server.
+var ErrClientClosed = errors.New("client closed the request")
+
+// IsClientClosed reports whether err results from the client closing the request rather than
+// from a failure of the server: ctx was canceled with the ErrClientClosed cause and err is
+// not an *Error (the domain errors keep their meaning).
+//
+// Any other canceled ctx, e.g. of a scheduled job or a message consumer on shutdown, or a
+// timed out one, is not a client disconnect: its errors are real failures.
+func IsClientClosed(ctx context.Context, err error) bool {
+	if err == nil || !errors.Is(context.Cause(ctx), ErrClientClosed) {
+		return false
+	}
+	var typed *Error
+	return !errors.As(err, &typed)
+}
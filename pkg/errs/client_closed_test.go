@@ -0,0 +1,42 @@
+package errs_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+func TestIsClientClosed(t *testing.T) {
+	disconnected, disconnect := context.WithCancelCause(context.Background())
+	disconnect(errs.ErrClientClosed)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{name: "no error", ctx: disconnected, err: nil, want: false},
+		{name: "disconnected client", ctx: disconnected, err: fmt.Errorf("find order: %w", context.Canceled), want: true},
+		{name: "driver error of a disconnected client", ctx: disconnected, err: errors.New("conn closed"), want: true},
+		{name: "domain error of a disconnected client", ctx: disconnected, err: errs.ErrRecordNotFound, want: false},
+		{name: "canceled job or consumer", ctx: canceled, err: context.Canceled, want: false},
+		{name: "server error", ctx: context.Background(), err: errors.New("boom"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := errs.IsClientClosed(tt.ctx, tt.err)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,12 +1,27 @@
 package errs
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
 )
 
+// StatusClientClosedRequest is the non-standard status of requests canceled by the client.
+const StatusClientClosedRequest = 499
+
 var (
-	ErrRecordNotFound = New("RECORD_NOT_FOUND", "Record not found", http.StatusNotFound, nil)
+	ErrInternal           = New("INTERNAL", "Internal server error", http.StatusInternalServerError, nil)
+	ErrRecordNotFound     = New("RECORD_NOT_FOUND", "Record not found", http.StatusNotFound, nil)
+	ErrPreconditionFailed = New(
+		"PRECONDITION_FAILED", "Resource has been modified", http.StatusPreconditionFailed, nil,
+	)
+	ErrPreconditionRequired = New(
+		"PRECONDITION_REQUIRED", "If-Match header is required", http.StatusPreconditionRequired, nil,
+	)
+	ErrTimeout         = New("TIMEOUT", "The request timed out", http.StatusGatewayTimeout, nil)
+	ErrRequestCanceled = New("REQUEST_CANCELED", "The request was canceled", StatusClientClosedRequest, nil)
 )
 
 type Error struct {
@@ -14,12 +29,20 @@ type Error struct {
 	Code          string   `json:"code"`
 	Message       string   `json:"message"`
 	Details       []Detail `json:"details,omitempty"`
+	RequestID     string   `json:"request_id,omitempty"`
 	OriginalError error    `json:"-"`
 }
 
+// Detail describes the error of one field. For validation errors, Path is the JSON path of
+// the field in the payload (e.g. items[2].price), Value the rejected value (redacted for
+// sensitive fields), Rule the violated validation tag and Params its parameters.
 type Detail struct {
-	Field   string `json:"field,omitempty"`
-	Message string `json:"message,omitempty"`
+	Field   string   `json:"field,omitempty"`
+	Path    string   `json:"path,omitempty"`
+	Message string   `json:"message,omitempty"`
+	Value   any      `json:"value,omitempty"`
+	Rule    string   `json:"rule,omitempty"`
+	Params  []string `json:"params,omitempty"`
 }
 
 // Error implements the error interface
@@ -35,3 +58,15 @@ func New(code, message string, status int, details []Detail) *Error {
 		Details: details,
 	}
 }
+
+// WithRequestID returns a copy of e carrying the request ID stored in ctx (see ctxmeta),
+// so clients can quote it when reporting the error. e is returned as is when ctx has none.
+func (e *Error) WithRequestID(ctx context.Context) *Error {
+	requestID, ok := ctxmeta.RequestID(ctx)
+	if !ok {
+		return e
+	}
+	withID := *e
+	withID.RequestID = requestID
+	return &withID
+}
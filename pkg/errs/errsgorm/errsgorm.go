@@ -0,0 +1,24 @@
+// Package errsgorm maps the gorm errors to their errs counterpart. Importing it registers
+// Map with errs.RegisterMapper; pkg/database imports it.
+package errsgorm
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+func init() {
+	errs.RegisterMapper(Map)
+}
+
+// Map returns errs.ErrRecordNotFound (404) for gorm.ErrRecordNotFound, false for the other
+// errors.
+func Map(err error) (*errs.Error, bool) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errs.ErrRecordNotFound, true
+	}
+	return nil, false
+}
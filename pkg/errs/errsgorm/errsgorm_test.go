@@ -0,0 +1,41 @@
+package errsgorm_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/errs/errsgorm"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected *errs.Error
+		ok       bool
+	}{
+		{"record not found", fmt.Errorf("find: %w", gorm.ErrRecordNotFound), errs.ErrRecordNotFound, true},
+		{"other", gorm.ErrInvalidTransaction, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, ok := errsgorm.Map(tt.err)
+
+			// Assert
+			assert.Equal(t, tt.ok, ok)
+			assert.Same(t, tt.expected, result)
+		})
+	}
+}
+
+func TestImport_RegistersTheMapper(t *testing.T) {
+	// Assert
+	assert.Same(t, errs.ErrRecordNotFound, errs.From(fmt.Errorf("find: %w", gorm.ErrRecordNotFound)))
+	assert.Same(t, errs.ErrInternal, errs.From(errors.New("boom")))
+}
@@ -0,0 +1,24 @@
+// Package errsredis maps the go-redis errors to their errs counterpart. Importing it
+// registers Map with errs.RegisterMapper; pkg/redis imports it.
+package errsredis
+
+import (
+	"errors"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+func init() {
+	errs.RegisterMapper(Map)
+}
+
+// Map returns errs.ErrRecordNotFound (404) for redis.Nil, the reply of a missing key, false
+// for the other errors.
+func Map(err error) (*errs.Error, bool) {
+	if errors.Is(err, goredis.Nil) {
+		return errs.ErrRecordNotFound, true
+	}
+	return nil, false
+}
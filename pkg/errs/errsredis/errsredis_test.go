@@ -0,0 +1,41 @@
+package errsredis_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/errs/errsredis"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected *errs.Error
+		ok       bool
+	}{
+		{"nil reply", fmt.Errorf("get: %w", goredis.Nil), errs.ErrRecordNotFound, true},
+		{"other", goredis.ErrClosed, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, ok := errsredis.Map(tt.err)
+
+			// Assert
+			assert.Equal(t, tt.ok, ok)
+			assert.Same(t, tt.expected, result)
+		})
+	}
+}
+
+func TestImport_RegistersTheMapper(t *testing.T) {
+	// Assert
+	assert.Same(t, errs.ErrRecordNotFound, errs.From(goredis.Nil))
+	assert.Same(t, errs.ErrInternal, errs.From(errors.New("boom")))
+}
@@ -0,0 +1,131 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// Mapper returns the errs counterpart of the errors of a library, false for the others.
+type Mapper func(err error) (*Error, bool)
+
+var (
+	mappersMu sync.RWMutex
+	mappers   []Mapper
+)
+
+// RegisterMapper adds mapper to the ones Map tries, in registration order, so the errors of
+// a library are mapped without the errs package depending on it. It is meant to be called
+// from the init function of the mapping package, as errsgorm and errsredis do.
+func RegisterMapper(mapper Mapper) {
+	mappersMu.Lock()
+	defer mappersMu.Unlock()
+	mappers = append(mappers, mapper)
+}
+
+// BadRequest returns a 400 error.
+func BadRequest(code, message string) *Error {
+	return New(code, message, http.StatusBadRequest, nil)
+}
+
+// Unauthorized returns a 401 error.
+func Unauthorized(code, message string) *Error {
+	return New(code, message, http.StatusUnauthorized, nil)
+}
+
+// Forbidden returns a 403 error.
+func Forbidden(code, message string) *Error {
+	return New(code, message, http.StatusForbidden, nil)
+}
+
+// NotFound returns a 404 error.
+func NotFound(code, message string) *Error {
+	return New(code, message, http.StatusNotFound, nil)
+}
+
+// Conflict returns a 409 error.
+func Conflict(code, message string) *Error {
+	return New(code, message, http.StatusConflict, nil)
+}
+
+// UnprocessableEntity returns a 422 error with the given field details.
+func UnprocessableEntity(code, message string, details ...Detail) *Error {
+	return New(code, message, http.StatusUnprocessableEntity, details)
+}
+
+// TooManyRequests returns a 429 error.
+func TooManyRequests(code, message string) *Error {
+	return New(code, message, http.StatusTooManyRequests, nil)
+}
+
+// Internal returns a 500 error.
+func Internal(code, message string) *Error {
+	return New(code, message, http.StatusInternalServerError, nil)
+}
+
+// ServiceUnavailable returns a 503 error.
+func ServiceUnavailable(code, message string) *Error {
+	return New(code, message, http.StatusServiceUnavailable, nil)
+}
+
+// Map returns the *Error in the chain of err or, for the common infrastructure errors, its
+// errs counterpart:
+//
+//   - context.DeadlineExceeded: ErrTimeout (504)
+//   - context.Canceled: ErrRequestCanceled (499)
+//   - the errors of the registered mappers, e.g. gorm.ErrRecordNotFound and redis.Nil:
+//     ErrRecordNotFound (404) once errsgorm and errsredis are imported
+//
+// It returns false for nil and the other errors.
+func Map(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed, true
+	}
+
+	mappersMu.RLock()
+	defer mappersMu.RUnlock()
+	for _, mapper := range mappers {
+		if mapped, ok := mapper(err); ok {
+			return mapped, true
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout, true
+	case errors.Is(err, context.Canceled):
+		return ErrRequestCanceled, true
+	}
+	return nil, false
+}
+
+// From returns the errs counterpart of err as Map does, ErrInternal for the other errors
+// and nil for nil.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	if mapped, ok := Map(err); ok {
+		return mapped
+	}
+	return ErrInternal
+}
+
+// StatusOf returns the HTTP status of err: 200 for nil, the status of its errs counterpart
+// (see Map), and 500 otherwise.
+func StatusOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	mapped := From(err)
+	if mapped.Status == 0 {
+		return http.StatusInternalServerError
+	}
+	return mapped.Status
+}
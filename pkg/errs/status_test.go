@@ -0,0 +1,96 @@
+package errs_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstructors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *errs.Error
+		status int
+	}{
+		{"BadRequest", errs.BadRequest("C", "m"), http.StatusBadRequest},
+		{"Unauthorized", errs.Unauthorized("C", "m"), http.StatusUnauthorized},
+		{"Forbidden", errs.Forbidden("C", "m"), http.StatusForbidden},
+		{"NotFound", errs.NotFound("C", "m"), http.StatusNotFound},
+		{"Conflict", errs.Conflict("C", "m"), http.StatusConflict},
+		{"UnprocessableEntity", errs.UnprocessableEntity("C", "m"), http.StatusUnprocessableEntity},
+		{"TooManyRequests", errs.TooManyRequests("C", "m"), http.StatusTooManyRequests},
+		{"Internal", errs.Internal("C", "m"), http.StatusInternalServerError},
+		{"ServiceUnavailable", errs.ServiceUnavailable("C", "m"), http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Assert
+			assert.Equal(t, tt.status, tt.err.Status)
+			assert.Equal(t, "C", tt.err.Code)
+			assert.Equal(t, "m", tt.err.Message)
+		})
+	}
+}
+
+var (
+	errOutOfStock  = errors.New("out of stock")
+	errsOutOfStock = errs.Conflict("OUT_OF_STOCK", "The product is out of stock")
+)
+
+func init() {
+	errs.RegisterMapper(func(err error) (*errs.Error, bool) {
+		if errors.Is(err, errOutOfStock) {
+			return errsOutOfStock, true
+		}
+		return nil, false
+	})
+}
+
+func TestFrom(t *testing.T) {
+	conflict := errs.Conflict("ORDER_EXISTS", "Order already exists")
+	tests := []struct {
+		name     string
+		err      error
+		expected *errs.Error
+	}{
+		{"nil", nil, nil},
+		{"wrapped errs.Error", fmt.Errorf("create order: %w", conflict), conflict},
+		{"registered mapper", fmt.Errorf("reserve: %w", errOutOfStock), errsOutOfStock},
+		{"deadline exceeded", context.DeadlineExceeded, errs.ErrTimeout},
+		{"canceled", context.Canceled, errs.ErrRequestCanceled},
+		{"unknown", errors.New("boom"), errs.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result := errs.From(tt.err)
+
+			// Assert
+			assert.Same(t, tt.expected, result)
+		})
+	}
+}
+
+func TestMap_UnknownError_ReturnsFalse(t *testing.T) {
+	// Act
+	result, ok := errs.Map(errors.New("boom"))
+
+	// Assert
+	assert.False(t, ok)
+	assert.Nil(t, result)
+}
+
+func TestStatusOf(t *testing.T) {
+	// Assert
+	assert.Equal(t, http.StatusOK, errs.StatusOf(nil))
+	assert.Equal(t, http.StatusTooManyRequests, errs.StatusOf(errs.TooManyRequests("SLOW_DOWN", "Slow down")))
+	assert.Equal(t, http.StatusConflict, errs.StatusOf(errOutOfStock))
+	assert.Equal(t, http.StatusGatewayTimeout, errs.StatusOf(context.DeadlineExceeded))
+	assert.Equal(t, http.StatusInternalServerError, errs.StatusOf(errs.New("C", "m", 0, nil)))
+	assert.Equal(t, http.StatusInternalServerError, errs.StatusOf(errors.New("boom")))
+}
@@ -0,0 +1,228 @@
+package eventbus
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Delivery is an event being handled by one handler, as seen by middleware.
+type Delivery struct {
+	// Event is the event name (see Named)
+	Event string
+	// Handler is the handler name (see WithName)
+	Handler string
+	// Payload is the published event
+	Payload any
+}
+
+// HandleFunc handles a delivery. Middleware wraps it.
+type HandleFunc func(ctx context.Context, delivery Delivery) error
+
+// Middleware wraps the handling of every delivery, e.g. for logging, metrics or recovery.
+type Middleware func(next HandleFunc) HandleFunc
+
+// Named lets an event choose its name; otherwise the Go type name (e.g. "orders.OrderPlaced") is used.
+type Named interface {
+	EventName() string
+}
+
+// Bus dispatches in-process events to the handlers subscribed to their type.
+type Bus struct {
+	options options
+
+	mu            sync.RWMutex
+	subscriptions map[reflect.Type][]subscription
+	sequence      int
+	closed        bool
+
+	queue   chan job
+	workers sync.WaitGroup
+	pending sync.WaitGroup
+}
+
+type subscription struct {
+	name   string
+	order  int
+	mode   Mode
+	handle HandleFunc
+}
+
+type job struct {
+	ctx      context.Context
+	delivery Delivery
+	handle   HandleFunc
+}
+
+// New creates a Bus and starts its async workers. Call Shutdown to stop them.
+func New(opts ...Option) *Bus {
+	busOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&busOptions)
+	}
+
+	b := &Bus{
+		options:       busOptions,
+		subscriptions: map[reflect.Type][]subscription{},
+		queue:         make(chan job, busOptions.queueSize),
+	}
+
+	for range busOptions.workers {
+		b.workers.Add(1)
+		go b.work()
+	}
+
+	return b
+}
+
+// Subscribe registers handler for events of type T.
+func Subscribe[T any](b *Bus, handler func(ctx context.Context, event T) error, opts ...SubscribeOption) {
+	eventType := reflect.TypeFor[T]()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	subOptions := subscribeOptions{
+		name: fmt.Sprintf("%s#%d", typeName(eventType), b.sequence),
+		mode: b.options.mode,
+	}
+	for _, opt := range opts {
+		opt(&subOptions)
+	}
+
+	handle := func(ctx context.Context, delivery Delivery) error {
+		event, _ := delivery.Payload.(T)
+		return handler(ctx, event)
+	}
+	for i := len(b.options.middlewares) - 1; i >= 0; i-- {
+		handle = b.options.middlewares[i](handle)
+	}
+
+	// Copy so Publish calls iterating the previous slice are not affected by the sort
+	subs := append(slices.Clone(b.subscriptions[eventType]), subscription{
+		name:   subOptions.name,
+		order:  subOptions.order,
+		mode:   subOptions.mode,
+		handle: handle,
+	})
+	slices.SortStableFunc(subs, func(a, b subscription) int {
+		return cmp.Compare(a.order, b.order)
+	})
+	b.subscriptions[eventType] = subs
+}
+
+// Publish dispatches event to the handlers subscribed to T, in order.
+// Sync handlers run before Publish returns and the first error stops the dispatch.
+// Async handlers are queued; Publish blocks while the queue is full until ctx is done.
+// Async handlers receive a context that is not canceled with ctx.
+func Publish[T any](ctx context.Context, b *Bus, event T) error {
+	eventType := reflect.TypeFor[T]()
+
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrBusClosed
+	}
+	subs := b.subscriptions[eventType]
+	// Track async deliveries before releasing the lock so Shutdown waits for them
+	for _, sub := range subs {
+		if sub.mode == ModeAsync {
+			b.pending.Add(1)
+		}
+	}
+	b.mu.RUnlock()
+
+	name := eventName(event, eventType)
+	asyncCtx := context.WithoutCancel(ctx)
+
+	var syncErr error
+	for _, sub := range subs {
+		delivery := Delivery{Event: name, Handler: sub.name, Payload: event}
+
+		if sub.mode == ModeAsync {
+			if syncErr != nil {
+				b.pending.Done()
+				continue
+			}
+			if err := b.enqueue(ctx, job{ctx: asyncCtx, delivery: delivery, handle: sub.handle}); err != nil {
+				b.pending.Done()
+				syncErr = err
+			}
+			continue
+		}
+
+		if syncErr != nil {
+			continue
+		}
+		if err := sub.handle(ctx, delivery); err != nil {
+			syncErr = fmt.Errorf("handle %s with %s: %w", name, sub.name, err)
+		}
+	}
+
+	return syncErr
+}
+
+// Shutdown stops accepting events and waits until the queued async deliveries are handled
+// or ctx is done.
+func (b *Bus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(b.queue)
+		b.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("shutdown event bus: %w", ctx.Err())
+	}
+}
+
+func (b *Bus) enqueue(ctx context.Context, j job) error {
+	select {
+	case b.queue <- j:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("enqueue %s for %s: %w", j.delivery.Event, j.delivery.Handler, ctx.Err())
+	}
+}
+
+func (b *Bus) work() {
+	defer b.workers.Done()
+
+	for j := range b.queue {
+		if err := j.handle(j.ctx, j.delivery); err != nil {
+			b.options.onError(j.ctx, j.delivery, err)
+		}
+		b.pending.Done()
+	}
+}
+
+func eventName(event any, eventType reflect.Type) string {
+	if named, ok := event.(Named); ok {
+		return named.EventName()
+	}
+	return typeName(eventType)
+}
+
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.String()
+}
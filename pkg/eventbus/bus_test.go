@@ -0,0 +1,197 @@
+package eventbus_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/eventbus"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+type OrderPlaced struct {
+	OrderID string
+}
+
+type UserRegistered struct {
+	UserID string
+}
+
+func (UserRegistered) EventName() string {
+	return "users.registered"
+}
+
+func shutdown(t *testing.T, bus *eventbus.Bus) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, bus.Shutdown(ctx))
+}
+
+func TestPublish_Sync(t *testing.T) {
+	t.Run("runs handlers of the event type by order", func(t *testing.T) {
+		// Arrange
+		bus := eventbus.New()
+		defer shutdown(t, bus)
+
+		var calls []string
+		eventbus.Subscribe(bus, func(_ context.Context, e OrderPlaced) error {
+			calls = append(calls, "second:"+e.OrderID)
+			return nil
+		})
+		eventbus.Subscribe(bus, func(_ context.Context, e OrderPlaced) error {
+			calls = append(calls, "first:"+e.OrderID)
+			return nil
+		}, eventbus.WithOrder(-1))
+		eventbus.Subscribe(bus, func(_ context.Context, _ UserRegistered) error {
+			calls = append(calls, "other")
+			return nil
+		})
+
+		// Act
+		err := eventbus.Publish(context.Background(), bus, OrderPlaced{OrderID: "42"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first:42", "second:42"}, calls)
+	})
+
+	t.Run("stops at the first handler error", func(t *testing.T) {
+		// Arrange
+		bus := eventbus.New()
+		defer shutdown(t, bus)
+
+		handlerErr := errors.New("stock unavailable")
+		called := false
+		eventbus.Subscribe(bus, func(context.Context, OrderPlaced) error {
+			return handlerErr
+		}, eventbus.WithName("reserve-stock"))
+		eventbus.Subscribe(bus, func(context.Context, OrderPlaced) error {
+			called = true
+			return nil
+		})
+
+		// Act
+		err := eventbus.Publish(context.Background(), bus, OrderPlaced{OrderID: "42"})
+
+		// Assert
+		require.ErrorIs(t, err, handlerErr)
+		assert.Contains(t, err.Error(), "eventbus_test.OrderPlaced with reserve-stock")
+		assert.False(t, called)
+	})
+
+	t.Run("publishing without handlers is a no-op", func(t *testing.T) {
+		// Arrange
+		bus := eventbus.New()
+		defer shutdown(t, bus)
+
+		// Act
+		err := eventbus.Publish(context.Background(), bus, OrderPlaced{})
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
+
+func TestPublish_Async(t *testing.T) {
+	t.Run("runs handlers on workers with a context that outlives the publisher", func(t *testing.T) {
+		// Arrange
+		bus := eventbus.New(eventbus.WithMode(eventbus.ModeAsync))
+
+		var handled atomic.Int32
+		var ctxErr error
+		var mu sync.Mutex
+		eventbus.Subscribe(bus, func(ctx context.Context, _ OrderPlaced) error {
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			ctxErr = ctx.Err()
+			mu.Unlock()
+			handled.Add(1)
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// Act
+		err := eventbus.Publish(ctx, bus, OrderPlaced{OrderID: "42"})
+		cancel()
+		shutdown(t, bus)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), handled.Load())
+		mu.Lock()
+		defer mu.Unlock()
+		assert.NoError(t, ctxErr)
+	})
+
+	t.Run("reports async handler errors to the error handler", func(t *testing.T) {
+		// Arrange
+		reported := make(chan eventbus.Delivery, 1)
+		bus := eventbus.New(eventbus.WithErrorHandler(func(_ context.Context, delivery eventbus.Delivery, _ error) {
+			reported <- delivery
+		}))
+		defer shutdown(t, bus)
+
+		eventbus.Subscribe(bus, func(context.Context, UserRegistered) error {
+			return errors.New("smtp down")
+		}, eventbus.WithDispatch(eventbus.ModeAsync), eventbus.WithName("send-welcome-email"))
+
+		// Act
+		err := eventbus.Publish(context.Background(), bus, UserRegistered{UserID: "u1"})
+
+		// Assert
+		require.NoError(t, err)
+		select {
+		case delivery := <-reported:
+			assert.Equal(t, "users.registered", delivery.Event)
+			assert.Equal(t, "send-welcome-email", delivery.Handler)
+			assert.Equal(t, UserRegistered{UserID: "u1"}, delivery.Payload)
+		case <-time.After(time.Second):
+			t.Fatal("async error was not reported")
+		}
+	})
+
+	t.Run("rejects events after shutdown", func(t *testing.T) {
+		// Arrange
+		bus := eventbus.New()
+		shutdown(t, bus)
+
+		// Act
+		err := eventbus.Publish(context.Background(), bus, OrderPlaced{})
+
+		// Assert
+		require.ErrorIs(t, err, eventbus.ErrBusClosed)
+	})
+}
+
+func TestRegisterSubscribers(t *testing.T) {
+	// Arrange
+	bus := eventbus.New()
+	defer shutdown(t, bus)
+
+	var order []string
+	first := mocks.NewMockOrderedSubscriber(t)
+	first.EXPECT().Order().Return(-10)
+	first.EXPECT().Subscribe(bus).Run(func(*eventbus.Bus) { order = append(order, "first") })
+
+	plain := mocks.NewMockSubscriber(t)
+	plain.EXPECT().Subscribe(bus).Run(func(*eventbus.Bus) { order = append(order, "plain") })
+
+	last := mocks.NewMockOrderedSubscriber(t)
+	last.EXPECT().Order().Return(10)
+	last.EXPECT().Subscribe(bus).Run(func(*eventbus.Bus) { order = append(order, "last") })
+
+	// Act
+	eventbus.RegisterSubscribers(bus, []eventbus.Subscriber{last, plain, first})
+
+	// Assert
+	assert.Equal(t, []string{"first", "plain", "last"}, order)
+}
@@ -0,0 +1,44 @@
+package eventbus
+
+const (
+	ModeSync  Mode = "sync"
+	ModeAsync Mode = "async"
+
+	defaultWorkers   = 4
+	defaultQueueSize = 1024
+)
+
+// Mode is how handlers are dispatched.
+// Sync handlers run inside Publish; async handlers run on the bus workers.
+type Mode string
+
+// Config configures the Bus created by the FX module.
+type Config struct {
+	// Mode is the default dispatch mode of subscriptions without WithMode
+	Mode Mode `config:"mode"`
+	// Workers is the number of goroutines running async handlers
+	Workers int `config:"workers"`
+	// QueueSize is the number of async deliveries buffered before Publish blocks
+	QueueSize int `config:"queue_size"`
+}
+
+// Validate checks the configured mode.
+func (c *Config) Validate() error {
+	if c.Mode != ModeSync && c.Mode != ModeAsync {
+		return ErrInvalidMode
+	}
+	return nil
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Mode == "" {
+		c.Mode = ModeSync
+	}
+	if c.Workers <= 0 {
+		c.Workers = defaultWorkers
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = defaultQueueSize
+	}
+}
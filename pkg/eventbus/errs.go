@@ -0,0 +1,9 @@
+package eventbus
+
+import "errors"
+
+var (
+	ErrBusClosed    = errors.New("event bus is closed")
+	ErrHandlerPanic = errors.New("event handler panicked")
+	ErrInvalidMode  = errors.New("invalid event bus mode (must be 'sync' or 'async')")
+)
@@ -0,0 +1,76 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Module provides the *Bus configured from app.eventbus with recovery, logging and
+// (when a prometheus.Registerer is available, e.g. from metrics.Module) metrics middleware.
+// Subscribers from the "event_subscribers" group are registered by ascending order, and
+// queued async deliveries are drained on stop.
+//
+// Register a subscriber:
+//
+//	fx.Provide(
+//	    fx.Annotate(
+//	        NewSendWelcomeEmailSubscriber,
+//	        fx.As(new(eventbus.Subscriber)),
+//	        fx.ResultTags(`group:"event_subscribers"`),
+//	    ),
+//	)
+var Module = fx.Module(
+	"eventbus",
+	config.Provide[Config]("app.eventbus"),
+	fx.Provide(NewWithLifecycle),
+)
+
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle   fx.Lifecycle
+	Config      config.Config[Config]
+	Logger      logger.Logger
+	Registerer  prometheus.Registerer `optional:"true"`
+	Subscribers []Subscriber          `group:"event_subscribers"`
+}
+
+// NewWithLifecycle creates the Bus, registers the grouped subscribers and shuts the bus down on stop.
+func NewWithLifecycle(p NewWithLifecycleParams) (*Bus, error) {
+	cfg := p.Config.Get()
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, cfg.Mode)
+	}
+
+	middlewares := []Middleware{RecoveryMiddleware(), LoggingMiddleware(p.Logger)}
+	if p.Registerer != nil {
+		metricsMiddleware, err := MetricsMiddleware(p.Registerer)
+		if err != nil {
+			return nil, err
+		}
+		middlewares = append(middlewares, metricsMiddleware)
+	}
+
+	bus := New(
+		WithMode(cfg.Mode),
+		WithWorkers(cfg.Workers),
+		WithQueueSize(cfg.QueueSize),
+		WithMiddleware(middlewares...),
+	)
+	RegisterSubscribers(bus, p.Subscribers)
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return bus.Shutdown(ctx)
+		},
+	})
+
+	return bus, nil
+}
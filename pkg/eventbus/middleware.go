@@ -0,0 +1,102 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	handledMetricName  = "eventbus_handled_total"
+	durationMetricName = "eventbus_handler_duration_seconds"
+
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+// RecoveryMiddleware turns handler panics into errors wrapping ErrHandlerPanic,
+// so a failing handler cannot crash an async worker.
+func RecoveryMiddleware() Middleware {
+	return func(next HandleFunc) HandleFunc {
+		return func(ctx context.Context, delivery Delivery) (err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					err = fmt.Errorf("%w: %v", ErrHandlerPanic, recovered)
+				}
+			}()
+			return next(ctx, delivery)
+		}
+	}
+}
+
+// LoggingMiddleware logs failed deliveries as errors and successful ones at debug level.
+func LoggingMiddleware(log logger.Logger) Middleware {
+	return func(next HandleFunc) HandleFunc {
+		return func(ctx context.Context, delivery Delivery) error {
+			start := time.Now()
+			err := next(ctx, delivery)
+
+			fields := append([]logger.Field{
+				logger.String("event", delivery.Event),
+				logger.String("handler", delivery.Handler),
+				logger.Duration("duration", time.Since(start)),
+			}, logger.ContextFields(ctx)...)
+			if err != nil {
+				log.Error("event handler failed", append(fields, logger.Error(err))...)
+				return err
+			}
+			log.Debug("event handled", fields...)
+			return nil
+		}
+	}
+}
+
+// MetricsMiddleware records eventbus_handled_total{event,handler,result} and
+// eventbus_handler_duration_seconds{event,handler}. Identical collectors already
+// registered on registerer are reused.
+func MetricsMiddleware(registerer prometheus.Registerer) (Middleware, error) {
+	handled := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: handledMetricName,
+			Help: "Total events handled by handler and result (success, error)",
+		},
+		[]string{"event", "handler", "result"},
+	)
+	duration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    durationMetricName,
+			Help:    "Duration of event handlers in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"event", "handler"},
+	)
+
+	handled, err := metrics.Register(registerer, handled)
+	if err != nil {
+		return nil, err
+	}
+	duration, err = metrics.Register(registerer, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next HandleFunc) HandleFunc {
+		return func(ctx context.Context, delivery Delivery) error {
+			start := time.Now()
+			err := next(ctx, delivery)
+
+			result := resultSuccess
+			if err != nil {
+				result = resultError
+			}
+			handled.WithLabelValues(delivery.Event, delivery.Handler, result).Inc()
+			duration.WithLabelValues(delivery.Event, delivery.Handler).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}, nil
+}
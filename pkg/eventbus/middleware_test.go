@@ -0,0 +1,97 @@
+package eventbus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/eventbus"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	// Arrange
+	bus := eventbus.New(eventbus.WithMiddleware(eventbus.RecoveryMiddleware()))
+	defer shutdown(t, bus)
+
+	eventbus.Subscribe(bus, func(context.Context, OrderPlaced) error {
+		panic("boom")
+	})
+
+	// Act
+	err := eventbus.Publish(context.Background(), bus, OrderPlaced{})
+
+	// Assert
+	require.ErrorIs(t, err, eventbus.ErrHandlerPanic)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	// Arrange
+	log := mocks.NewMockLogger(t)
+	bus := eventbus.New(eventbus.WithMiddleware(eventbus.LoggingMiddleware(log)))
+	defer shutdown(t, bus)
+
+	eventbus.Subscribe(bus, func(context.Context, OrderPlaced) error { return nil }, eventbus.WithName("ok"))
+	eventbus.Subscribe(bus, func(context.Context, UserRegistered) error {
+		return errors.New("failed")
+	}, eventbus.WithName("failing"))
+
+	log.EXPECT().Debug("event handled", mock.Anything, mock.Anything, mock.Anything).Once()
+	log.EXPECT().Error("event handler failed", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once()
+
+	// Act
+	okErr := eventbus.Publish(context.Background(), bus, OrderPlaced{})
+	failErr := eventbus.Publish(context.Background(), bus, UserRegistered{})
+
+	// Assert
+	require.NoError(t, okErr)
+	require.Error(t, failErr)
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	metricsMiddleware, err := eventbus.MetricsMiddleware(registry)
+	require.NoError(t, err)
+
+	bus := eventbus.New(eventbus.WithMiddleware(metricsMiddleware))
+	defer shutdown(t, bus)
+
+	eventbus.Subscribe(bus, func(context.Context, OrderPlaced) error { return nil }, eventbus.WithName("notify"))
+
+	// Act
+	require.NoError(t, eventbus.Publish(context.Background(), bus, OrderPlaced{}))
+	require.NoError(t, eventbus.Publish(context.Background(), bus, OrderPlaced{}))
+	_, reuseErr := eventbus.MetricsMiddleware(registry)
+
+	// Assert
+	require.NoError(t, reuseErr)
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	var handled float64
+	for _, family := range families {
+		if family.GetName() != "eventbus_handled_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			handled += metric.GetCounter().GetValue()
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			assert.Equal(t, map[string]string{
+				"event":   "eventbus_test.OrderPlaced",
+				"handler": "notify",
+				"result":  "success",
+			}, labels)
+		}
+	}
+	assert.InDelta(t, 2, handled, 0)
+}
@@ -0,0 +1,95 @@
+package eventbus
+
+import "context"
+
+type options struct {
+	mode        Mode
+	workers     int
+	queueSize   int
+	middlewares []Middleware
+	onError     func(ctx context.Context, delivery Delivery, err error)
+}
+
+// Option configures the Bus created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		mode:      ModeSync,
+		workers:   defaultWorkers,
+		queueSize: defaultQueueSize,
+		onError:   func(context.Context, Delivery, error) {},
+	}
+}
+
+// WithMode sets the default dispatch mode of subscriptions. Defaults to ModeSync.
+func WithMode(mode Mode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
+}
+
+// WithWorkers sets the number of goroutines running async handlers. Defaults to 4.
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		if workers > 0 {
+			o.workers = workers
+		}
+	}
+}
+
+// WithQueueSize sets the number of async deliveries buffered before Publish blocks. Defaults to 1024.
+func WithQueueSize(size int) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.queueSize = size
+		}
+	}
+}
+
+// WithMiddleware wraps every handler. The first middleware is the outermost.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// WithErrorHandler receives the errors of async handlers, which cannot be returned by Publish.
+func WithErrorHandler(fn func(ctx context.Context, delivery Delivery, err error)) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.onError = fn
+		}
+	}
+}
+
+type subscribeOptions struct {
+	name  string
+	order int
+	mode  Mode
+}
+
+// SubscribeOption configures a subscription.
+type SubscribeOption func(*subscribeOptions)
+
+// WithName names the handler in logs and metrics. Defaults to the event name with a sequence number.
+func WithName(name string) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.name = name
+	}
+}
+
+// WithOrder sets the position of the handler; lower orders run first and equal orders keep
+// the subscription order.
+func WithOrder(order int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.order = order
+	}
+}
+
+// WithDispatch overrides the bus dispatch mode for the handler.
+func WithDispatch(mode Mode) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.mode = mode
+	}
+}
@@ -0,0 +1,37 @@
+package eventbus
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Subscriber registers its handlers on the bus.
+// Provide subscribers to the "event_subscribers" group to have the FX module register them.
+type Subscriber interface {
+	Subscribe(bus *Bus)
+}
+
+// OrderedSubscriber is a Subscriber registered before the ones with a higher order.
+// Subscribers without an order use 0.
+type OrderedSubscriber interface {
+	Subscriber
+	Order() int
+}
+
+// RegisterSubscribers registers the subscribers by ascending order, keeping the given order for ties.
+func RegisterSubscribers(bus *Bus, subscribers []Subscriber) {
+	sorted := slices.Clone(subscribers)
+	slices.SortStableFunc(sorted, func(a, b Subscriber) int {
+		return cmp.Compare(subscriberOrder(a), subscriberOrder(b))
+	})
+	for _, subscriber := range sorted {
+		subscriber.Subscribe(bus)
+	}
+}
+
+func subscriberOrder(subscriber Subscriber) int {
+	if ordered, ok := subscriber.(OrderedSubscriber); ok {
+		return ordered.Order()
+	}
+	return 0
+}
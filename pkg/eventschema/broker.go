@@ -0,0 +1,54 @@
+package eventschema
+
+import (
+	"context"
+	"fmt"
+)
+
+// Publisher sends a message to a topic of a message broker (Kafka, NATS, SQS, ...). The
+// key orders the messages of the same entity on brokers that partition by key.
+type Publisher interface {
+	Publish(ctx context.Context, topic, key string, payload []byte) error
+}
+
+// EventPublisher publishes the events in their Envelope, validated against their latest
+// version.
+type EventPublisher struct {
+	publisher Publisher
+	registry  *Registry
+}
+
+// NewPublisher creates an EventPublisher encoding the events with registry.
+func NewPublisher(publisher Publisher, registry *Registry) *EventPublisher {
+	return &EventPublisher{publisher: publisher, registry: registry}
+}
+
+// Publish encodes payload as the event type name and publishes it to topic. An invalid
+// payload is not published: ErrInvalidPayload is returned.
+func (p *EventPublisher) Publish(ctx context.Context, topic, key, name string, payload any) error {
+	message, err := p.registry.Encode(name, payload)
+	if err != nil {
+		return err
+	}
+	if err = p.publisher.Publish(ctx, topic, key, message); err != nil {
+		return fmt.Errorf("eventschema: publish %s: %w", name, err)
+	}
+	return nil
+}
+
+// Handler returns the handler of the raw messages of a broker consumer, decoding them with
+// registry to the latest version of T before calling handle. A message that cannot be
+// decoded, e.g. ErrInvalidPayload, fails without calling handle: send it to a dead letter
+// queue rather than retrying it.
+func Handler[T any](
+	registry *Registry,
+	handle func(ctx context.Context, event T) error,
+) func(context.Context, []byte) error {
+	return func(ctx context.Context, message []byte) error {
+		event, err := Unmarshal[T](registry, message)
+		if err != nil {
+			return err
+		}
+		return handle(ctx, event)
+	}
+}
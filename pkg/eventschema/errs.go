@@ -0,0 +1,26 @@
+package eventschema
+
+import "errors"
+
+var (
+	ErrMissingName      = errors.New("event name is required")
+	ErrInvalidVersion   = errors.New("event version must be 1 or more")
+	ErrDuplicateVersion = errors.New("event version already registered")
+	ErrInvalidSchema    = errors.New("invalid event schema")
+
+	// ErrUnknownEvent is returned for an event type that is not registered.
+	ErrUnknownEvent = errors.New("unknown event type")
+	// ErrUnknownVersion is returned for a version, or a version of the upcasting chain, that
+	// is not registered.
+	ErrUnknownVersion = errors.New("unknown event version")
+	// ErrUnsupportedVersion is returned for a version newer than the latest registered one:
+	// the producer was deployed before the consumer.
+	ErrUnsupportedVersion = errors.New("unsupported event version")
+	// ErrInvalidEnvelope is returned for a message that is not an envelope.
+	ErrInvalidEnvelope = errors.New("invalid event envelope")
+	// ErrInvalidPayload is returned, wrapping a *ValidationError, for a payload not matching
+	// the schema of its version.
+	ErrInvalidPayload = errors.New("event payload does not match its schema")
+	// ErrUpcast is returned when an upcaster failed.
+	ErrUpcast = errors.New("failed to upcast event")
+)
@@ -0,0 +1,49 @@
+package eventschema
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+)
+
+// Module provides the Registry holding the definitions of the "event_schemas" group.
+//
+//	fx.New(
+//	    eventschema.Module,
+//	    eventschema.ProvideDefinition(eventschema.Definition{Name: "orders.order_placed", Version: 1, Schema: v1}),
+//	    eventschema.ProvideDefinition(eventschema.Definition{Name: "orders.order_placed", Version: 2, Schema: v2,
+//	        Upcast: upcastOrderPlacedV2}),
+//	)
+var Module = fx.Module(
+	"eventschema",
+	fx.Provide(NewWithParams),
+)
+
+// ProvideDefinition provides def in the "event_schemas" group.
+func ProvideDefinition(def Definition) fx.Option {
+	return fx.Provide(fx.Annotate(
+		func() Definition {
+			return def
+		},
+		fx.ResultTags(`group:"event_schemas"`),
+	))
+}
+
+// RegistryParams for dependency injection
+type RegistryParams struct {
+	fx.In
+
+	Definitions []Definition          `group:"event_schemas"`
+	Registerer  prometheus.Registerer `optional:"true"`
+}
+
+// NewWithParams creates the Registry and registers the grouped definitions.
+func NewWithParams(p RegistryParams) (*Registry, error) {
+	registry, err := New(WithRegisterer(p.Registerer))
+	if err != nil {
+		return nil, err
+	}
+	if err = registry.Register(p.Definitions...); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
@@ -0,0 +1,43 @@
+package eventschema
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	rejectedMetricName = "eventschema_rejected_total"
+	upcastMetricName   = "eventschema_upcast_total"
+)
+
+type registryMetrics struct {
+	rejected *prometheus.CounterVec
+	upcast   *prometheus.CounterVec
+}
+
+func newRegistryMetrics(registerer prometheus.Registerer) (*registryMetrics, error) {
+	rejected := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: rejectedMetricName,
+			Help: "Total event payloads not matching their schema by event, version and direction (publish, consume)",
+		},
+		[]string{"event", "version", "direction"},
+	)
+	upcast := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: upcastMetricName,
+			Help: "Total consumed events upcast to their latest version, by event and original version",
+		},
+		[]string{"event", "version"},
+	)
+
+	rejected, err := metrics.Register(registerer, rejected)
+	if err != nil {
+		return nil, err
+	}
+	if upcast, err = metrics.Register(registerer, upcast); err != nil {
+		return nil, err
+	}
+	return &registryMetrics{rejected: rejected, upcast: upcast}, nil
+}
@@ -0,0 +1,24 @@
+package eventschema
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type options struct {
+	registerer prometheus.Registerer
+}
+
+// Option configures the Registry created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{registerer: prometheus.DefaultRegisterer}
+}
+
+// WithRegisterer sets the registerer the eventschema metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Package eventschema versions the domain events crossing service boundaries: each version
+// of an event type is registered with its JSON Schema, the payloads are validated when
+// published and consumed, and the payloads of the older versions are upcast to the latest
+// one, so a consumer handles a single shape whatever the version of the producer.
+package eventschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Directions of the validation metrics.
+const (
+	directionPublish = "publish"
+	directionConsume = "consume"
+)
+
+// Envelope is the message of an event on the wire: the type and the version of the
+// payload, next to the payload itself.
+//
+//	{"type":"orders.order_placed","version":2,"data":{"order_id":"42","total":{"amount":990,"currency":"EUR"}}}
+type Envelope struct {
+	Type    string          `json:"type"`
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Upcaster converts the payload of the previous version of an event to its version, e.g.
+// renames a field or splits a value. The payload is an object decoded from JSON: its
+// numbers are float64.
+type Upcaster func(payload map[string]any) (map[string]any, error)
+
+// Definition is a version of an event type.
+type Definition struct {
+	// Name is the event type, e.g. orders.order_placed
+	Name string
+	// Version is the version of the payload, from 1
+	Version int
+	// Schema is the JSON Schema of the payload
+	Schema []byte
+	// Upcast converts the payload of Version-1 to Version. When nil, the payloads of
+	// Version-1 are valid payloads of Version, e.g. when an optional field was added.
+	Upcast Upcaster
+}
+
+type version struct {
+	schema *Schema
+	upcast Upcaster
+}
+
+type event struct {
+	versions map[int]version
+	latest   int
+}
+
+// Registry holds the versions of the event types, validating and upcasting their payloads.
+type Registry struct {
+	metrics *registryMetrics
+
+	mu     sync.RWMutex
+	events map[string]*event
+}
+
+// New creates an empty Registry.
+func New(opts ...Option) (*Registry, error) {
+	registryOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&registryOptions)
+	}
+	metrics, err := newRegistryMetrics(registryOptions.registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &Registry{metrics: metrics, events: make(map[string]*event)}, nil
+}
+
+// Register registers versions of event types, compiling their schemas.
+func (r *Registry) Register(definitions ...Definition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, def := range definitions {
+		if def.Name == "" {
+			return ErrMissingName
+		}
+		if def.Version < 1 {
+			return fmt.Errorf("%w: %s version %d", ErrInvalidVersion, def.Name, def.Version)
+		}
+		schema, err := CompileSchema(def.Schema)
+		if err != nil {
+			return fmt.Errorf("%s version %d: %w", def.Name, def.Version, err)
+		}
+
+		e, found := r.events[def.Name]
+		if !found {
+			e = &event{versions: make(map[int]version)}
+			r.events[def.Name] = e
+		}
+		if _, found = e.versions[def.Version]; found {
+			return fmt.Errorf("%w: %s version %d", ErrDuplicateVersion, def.Name, def.Version)
+		}
+		e.versions[def.Version] = version{schema: schema, upcast: def.Upcast}
+		e.latest = max(e.latest, def.Version)
+	}
+	return nil
+}
+
+// Latest returns the latest version of the event type name, and whether it is registered.
+func (r *Registry) Latest(name string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, found := r.events[name]
+	if !found {
+		return 0, false
+	}
+	return e.latest, true
+}
+
+// Versions returns the registered versions of every event type, in ascending order.
+func (r *Registry) Versions() map[string][]int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := make(map[string][]int, len(r.events))
+	for name, e := range r.events {
+		for v := range e.versions {
+			versions[name] = append(versions[name], v)
+		}
+		sort.Ints(versions[name])
+	}
+	return versions
+}
+
+// Encode validates payload against the latest version of the event type name, and returns
+// its Envelope as JSON. It returns ErrInvalidPayload when the payload does not match: the
+// drift is caught by the producer rather than by its consumers.
+func (r *Registry) Encode(name string, payload any) ([]byte, error) {
+	e, err := r.event(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("eventschema: encode %s: %w", name, err)
+	}
+	if err = e.versions[e.latest].schema.Validate(data); err != nil {
+		r.metrics.rejected.WithLabelValues(name, strconv.Itoa(e.latest), directionPublish).Inc()
+		return nil, fmt.Errorf("%w: %s version %d: %w", ErrInvalidPayload, name, e.latest, err)
+	}
+	return json.Marshal(Envelope{Type: name, Version: e.latest, Data: data})
+}
+
+// Decode decodes the Envelope of message, validates its payload against the schema of its
+// version, and upcasts it to the latest version, validated in turn. The returned Envelope
+// is of the latest version.
+func (r *Registry) Decode(message []byte) (Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+	if envelope.Type == "" || len(envelope.Data) == 0 {
+		return Envelope{}, fmt.Errorf("%w: type and data are required", ErrInvalidEnvelope)
+	}
+	e, err := r.event(envelope.Type)
+	if err != nil {
+		return Envelope{}, err
+	}
+	if envelope.Version > e.latest {
+		return Envelope{}, fmt.Errorf("%w: %s version %d, latest %d",
+			ErrUnsupportedVersion, envelope.Type, envelope.Version, e.latest)
+	}
+	current, found := e.versions[envelope.Version]
+	if !found {
+		return Envelope{}, fmt.Errorf("%w: %s version %d", ErrUnknownVersion, envelope.Type, envelope.Version)
+	}
+	if err = current.schema.Validate(envelope.Data); err != nil {
+		r.metrics.rejected.WithLabelValues(envelope.Type, strconv.Itoa(envelope.Version), directionConsume).Inc()
+		return Envelope{}, fmt.Errorf("%w: %s version %d: %w", ErrInvalidPayload, envelope.Type, envelope.Version, err)
+	}
+	if envelope.Version == e.latest {
+		return envelope, nil
+	}
+	return r.upcast(e, envelope)
+}
+
+// upcast converts the payload of envelope version by version to the latest one.
+func (r *Registry) upcast(e *event, envelope Envelope) (Envelope, error) {
+	from := envelope.Version
+	var payload map[string]any
+	if err := json.Unmarshal(envelope.Data, &payload); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %s version %d: the payload is not an object", ErrUpcast, envelope.Type, from)
+	}
+	for v := from + 1; v <= e.latest; v++ {
+		next, found := e.versions[v]
+		if !found {
+			return Envelope{}, fmt.Errorf("%w: %s version %d", ErrUnknownVersion, envelope.Type, v)
+		}
+		if next.upcast == nil {
+			continue
+		}
+		var err error
+		if payload, err = next.upcast(payload); err != nil {
+			return Envelope{}, fmt.Errorf("%w: %s version %d to %d: %w", ErrUpcast, envelope.Type, v-1, v, err)
+		}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("%w: %s: %w", ErrUpcast, envelope.Type, err)
+	}
+	if err = e.versions[e.latest].schema.Validate(data); err != nil {
+		r.metrics.rejected.WithLabelValues(envelope.Type, strconv.Itoa(e.latest), directionConsume).Inc()
+		return Envelope{}, fmt.Errorf("%w: %s upcast from version %d: %w", ErrInvalidPayload, envelope.Type, from, err)
+	}
+	r.metrics.upcast.WithLabelValues(envelope.Type, strconv.Itoa(from)).Inc()
+	return Envelope{Type: envelope.Type, Version: e.latest, Data: data}, nil
+}
+
+func (r *Registry) event(name string) (*event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, found := r.events[name]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, name)
+	}
+	return e, nil
+}
+
+// Unmarshal decodes message with r, and unmarshals its payload, of the latest version,
+// into a T.
+func Unmarshal[T any](r *Registry, message []byte) (T, error) {
+	var payload T
+	envelope, err := r.Decode(message)
+	if err != nil {
+		return payload, err
+	}
+	if err = json.Unmarshal(envelope.Data, &payload); err != nil {
+		return payload, fmt.Errorf("eventschema: decode %s: %w", envelope.Type, err)
+	}
+	return payload, nil
+}
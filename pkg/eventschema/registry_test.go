@@ -0,0 +1,247 @@
+package eventschema_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/cristiano-pacheco/bricks/pkg/eventschema"
+)
+
+const orderPlaced = "orders.order_placed"
+
+var (
+	orderPlacedV1 = eventschema.Definition{
+		Name:    orderPlaced,
+		Version: 1,
+		Schema: []byte(`{"type":"object","required":["order_id","total"],"properties":{` +
+			`"order_id":{"type":"string"},"total":{"type":"number"}}}`),
+	}
+	orderPlacedV2 = eventschema.Definition{
+		Name:    orderPlaced,
+		Version: 2,
+		Schema: []byte(`{"type":"object","required":["order_id","total"],"properties":{` +
+			`"order_id":{"type":"string"},"total":{"type":"object","required":["amount","currency"]}}}`),
+		Upcast: func(payload map[string]any) (map[string]any, error) {
+			total, ok := payload["total"].(float64)
+			if !ok {
+				return nil, errors.New("total is not a number")
+			}
+			payload["total"] = map[string]any{"amount": total * 100, "currency": "EUR"}
+			return payload, nil
+		},
+	}
+	// orderPlacedV3 adds an optional field: the payloads of version 2 are valid as they are
+	orderPlacedV3 = eventschema.Definition{
+		Name:    orderPlaced,
+		Version: 3,
+		Schema: []byte(`{"type":"object","required":["order_id","total"],"properties":{` +
+			`"order_id":{"type":"string"},"total":{"type":"object"},"coupon":{"type":"string"}}}`),
+	}
+)
+
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+type OrderPlaced struct {
+	OrderID string `json:"order_id"`
+	Total   Money  `json:"total"`
+	Coupon  string `json:"coupon,omitempty"`
+}
+
+type fakePublisher struct {
+	topic   string
+	key     string
+	payload []byte
+}
+
+func (p *fakePublisher) Publish(_ context.Context, topic, key string, payload []byte) error {
+	p.topic, p.key, p.payload = topic, key, payload
+	return nil
+}
+
+func newRegistry(t *testing.T, definitions ...eventschema.Definition) (*eventschema.Registry, *prometheus.Registry) {
+	t.Helper()
+	metrics := prometheus.NewRegistry()
+	registry, err := eventschema.New(eventschema.WithRegisterer(metrics))
+	require.NoError(t, err)
+	require.NoError(t, registry.Register(definitions...))
+	return registry, metrics
+}
+
+func TestRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition eventschema.Definition
+		err        error
+	}{
+		{name: "missing name", definition: eventschema.Definition{Version: 1, Schema: []byte(`{}`)},
+			err: eventschema.ErrMissingName},
+		{name: "invalid version", definition: eventschema.Definition{Name: orderPlaced, Schema: []byte(`{}`)},
+			err: eventschema.ErrInvalidVersion},
+		{name: "duplicate version", definition: orderPlacedV1, err: eventschema.ErrDuplicateVersion},
+		{name: "invalid schema", definition: eventschema.Definition{Name: orderPlaced, Version: 9, Schema: []byte(`[]`)},
+			err: eventschema.ErrInvalidSchema},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			registry, _ := newRegistry(t, orderPlacedV1)
+
+			// Act
+			err := registry.Register(tt.definition)
+
+			// Assert
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestRegistry_Encode(t *testing.T) {
+	t.Run("wraps the payload in an envelope of the latest version", func(t *testing.T) {
+		// Arrange
+		registry, _ := newRegistry(t, orderPlacedV1, orderPlacedV2)
+
+		// Act
+		message, err := registry.Encode(orderPlaced, OrderPlaced{OrderID: "42", Total: Money{990, "EUR"}})
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type":"orders.order_placed","version":2,`+
+			`"data":{"order_id":"42","total":{"amount":990,"currency":"EUR"}}}`, string(message))
+	})
+
+	t.Run("rejects a payload of a former version", func(t *testing.T) {
+		// Arrange
+		registry, metrics := newRegistry(t, orderPlacedV1, orderPlacedV2)
+
+		// Act
+		_, err := registry.Encode(orderPlaced, map[string]any{"order_id": "42", "total": 9.9})
+
+		// Assert
+		require.ErrorIs(t, err, eventschema.ErrInvalidPayload)
+		assert.InDelta(t, 1, counter(t, metrics, "eventschema_rejected_total"), 0)
+	})
+
+	t.Run("rejects an unknown event", func(t *testing.T) {
+		// Arrange
+		registry, _ := newRegistry(t, orderPlacedV1)
+
+		// Act
+		_, err := registry.Encode("orders.order_shipped", OrderPlaced{})
+
+		// Assert
+		require.ErrorIs(t, err, eventschema.ErrUnknownEvent)
+	})
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	t.Run("upcasts a former version to the latest one", func(t *testing.T) {
+		// Arrange
+		registry, metrics := newRegistry(t, orderPlacedV3, orderPlacedV1, orderPlacedV2)
+
+		// Act
+		envelope, err := registry.Decode([]byte(`{"type":"orders.order_placed","version":1,` +
+			`"data":{"order_id":"42","total":9.9}}`))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, envelope.Version)
+		assert.JSONEq(t, `{"order_id":"42","total":{"amount":990,"currency":"EUR"}}`, string(envelope.Data))
+		assert.InDelta(t, 1, counter(t, metrics, "eventschema_upcast_total"), 0)
+	})
+
+	tests := []struct {
+		name    string
+		message string
+		err     error
+	}{
+		{name: "not an envelope", message: `"order"`, err: eventschema.ErrInvalidEnvelope},
+		{name: "missing data", message: `{"type":"orders.order_placed","version":1}`, err: eventschema.ErrInvalidEnvelope},
+		{name: "unknown event", message: `{"type":"orders.order_shipped","version":1,"data":{}}`,
+			err: eventschema.ErrUnknownEvent},
+		{name: "newer version", message: `{"type":"orders.order_placed","version":4,"data":{}}`,
+			err: eventschema.ErrUnsupportedVersion},
+		{name: "unknown version", message: `{"type":"orders.order_placed","version":0,"data":{}}`,
+			err: eventschema.ErrUnknownVersion},
+		{name: "invalid payload", message: `{"type":"orders.order_placed","version":1,"data":{"order_id":"42"}}`,
+			err: eventschema.ErrInvalidPayload},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			registry, _ := newRegistry(t, orderPlacedV1, orderPlacedV2, orderPlacedV3)
+
+			// Act
+			_, err := registry.Decode([]byte(tt.message))
+
+			// Assert
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestEventPublisher_Publish(t *testing.T) {
+	// Arrange
+	registry, _ := newRegistry(t, orderPlacedV1, orderPlacedV2)
+	broker := &fakePublisher{}
+	publisher := eventschema.NewPublisher(broker, registry)
+	var handled OrderPlaced
+	handler := eventschema.Handler(registry, func(_ context.Context, event OrderPlaced) error {
+		handled = event
+		return nil
+	})
+
+	// Act
+	err := publisher.Publish(context.Background(), "orders", "42", orderPlaced,
+		OrderPlaced{OrderID: "42", Total: Money{990, "EUR"}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "orders", broker.topic)
+	assert.Equal(t, "42", broker.key)
+	require.NoError(t, handler(context.Background(), broker.payload))
+	assert.Equal(t, OrderPlaced{OrderID: "42", Total: Money{990, "EUR"}}, handled)
+}
+
+func TestModule(t *testing.T) {
+	// Arrange
+	var registry *eventschema.Registry
+	app := fxtest.New(t,
+		eventschema.Module,
+		fx.Supply(fx.Annotate(prometheus.NewRegistry(), fx.As(new(prometheus.Registerer)))),
+		eventschema.ProvideDefinition(orderPlacedV1),
+		eventschema.ProvideDefinition(orderPlacedV2),
+		fx.Populate(&registry),
+	)
+
+	// Act
+	app.RequireStart()
+	defer app.RequireStop()
+
+	// Assert
+	assert.Equal(t, map[string][]int{orderPlaced: {1, 2}}, registry.Versions())
+	latest, found := registry.Latest(orderPlaced)
+	assert.True(t, found)
+	assert.Equal(t, 2, latest)
+}
+
+func counter(t *testing.T, registry *prometheus.Registry, name string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,444 @@
+package eventschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Schema is a compiled JSON Schema. The validation keywords supported are type, enum,
+// const, properties, required, additionalProperties, items, minItems, maxItems, minLength,
+// maxLength, pattern, format (date-time, date, email and uuid), minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, allOf, anyOf, oneOf and not; the annotations ($schema,
+// title, description, ...) and the other keywords are ignored. $ref is not supported.
+type Schema struct {
+	// always is the result of a boolean schema, set when isBool
+	isBool bool
+	always bool
+
+	types      []string
+	enum       []any
+	constValue any
+	hasConst   bool
+
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+
+	items    *Schema
+	minItems *int
+	maxItems *int
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+	format    string
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+
+	allOf []*Schema
+	anyOf []*Schema
+	oneOf []*Schema
+	not   *Schema
+}
+
+// CompileSchema compiles the JSON Schema document.
+func CompileSchema(document []byte) (*Schema, error) {
+	var raw any
+	if err := json.Unmarshal(document, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+	schema, err := compile(raw, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+	return schema, nil
+}
+
+// ValidationError lists the violations of a schema, each prefixed with the JSON pointer
+// of the value, e.g. "/items/0/price: must be >= 0".
+type ValidationError struct {
+	Violations []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Violations, "; ")
+}
+
+// Validate validates the JSON document against the schema, returning a *ValidationError
+// listing its violations.
+func (s *Schema) Validate(document []byte) error {
+	var value any
+	if err := json.Unmarshal(document, &value); err != nil {
+		return &ValidationError{Violations: []string{"/: invalid JSON: " + err.Error()}}
+	}
+	return s.validateValue(value)
+}
+
+func (s *Schema) validateValue(value any) error {
+	var violations []string
+	s.validate(value, "", &violations)
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+func compile(raw any, path string) (*Schema, error) {
+	switch raw := raw.(type) {
+	case bool:
+		return &Schema{isBool: true, always: raw}, nil
+	case map[string]any:
+		return compileObject(raw, path)
+	default:
+		return nil, fmt.Errorf("%s: a schema is an object or a boolean", pointer(path))
+	}
+}
+
+func compileObject(raw map[string]any, path string) (*Schema, error) {
+	s := &Schema{}
+	var err error
+
+	switch types := raw["type"].(type) {
+	case nil:
+	case string:
+		s.types = []string{types}
+	case []any:
+		for _, t := range types {
+			name, ok := t.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: type must be a string or an array of strings", pointer(path))
+			}
+			s.types = append(s.types, name)
+		}
+	default:
+		return nil, fmt.Errorf("%s: type must be a string or an array of strings", pointer(path))
+	}
+	for _, t := range s.types {
+		if !slices.Contains([]string{"null", "boolean", "object", "array", "number", "integer", "string"}, t) {
+			return nil, fmt.Errorf("%s: unknown type %q", pointer(path), t)
+		}
+	}
+
+	if enum, found := raw["enum"]; found {
+		values, ok := enum.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: enum must be an array", pointer(path))
+		}
+		s.enum = values
+	}
+	s.constValue, s.hasConst = raw["const"]
+
+	if properties, found := raw["properties"]; found {
+		object, ok := properties.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: properties must be an object", pointer(path))
+		}
+		s.properties = make(map[string]*Schema, len(object))
+		for name, property := range object {
+			if s.properties[name], err = compile(property, path+"/properties/"+name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if required, found := raw["required"]; found {
+		names, ok := required.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: required must be an array of strings", pointer(path))
+		}
+		for _, name := range names {
+			property, isString := name.(string)
+			if !isString {
+				return nil, fmt.Errorf("%s: required must be an array of strings", pointer(path))
+			}
+			s.required = append(s.required, property)
+		}
+	}
+	if additional, found := raw["additionalProperties"]; found {
+		if s.additionalProperties, err = compile(additional, path+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if items, found := raw["items"]; found {
+		if s.items, err = compile(items, path+"/items"); err != nil {
+			return nil, err
+		}
+	}
+	if not, found := raw["not"]; found {
+		if s.not, err = compile(not, path+"/not"); err != nil {
+			return nil, err
+		}
+	}
+	for keyword, target := range map[string]*[]*Schema{"allOf": &s.allOf, "anyOf": &s.anyOf, "oneOf": &s.oneOf} {
+		if *target, err = compileList(raw, keyword, path); err != nil {
+			return nil, err
+		}
+	}
+
+	for keyword, target := range map[string]**int{
+		"minItems": &s.minItems, "maxItems": &s.maxItems, "minLength": &s.minLength, "maxLength": &s.maxLength,
+	} {
+		if *target, err = count(raw, keyword, path); err != nil {
+			return nil, err
+		}
+	}
+	for keyword, target := range map[string]**float64{
+		"minimum": &s.minimum, "maximum": &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMinimum, "exclusiveMaximum": &s.exclusiveMaximum,
+	} {
+		if *target, err = number(raw, keyword, path); err != nil {
+			return nil, err
+		}
+	}
+
+	if pattern, found := raw["pattern"]; found {
+		expression, ok := pattern.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: pattern must be a string", pointer(path))
+		}
+		if s.pattern, err = regexp.Compile(expression); err != nil {
+			return nil, fmt.Errorf("%s: pattern: %w", pointer(path), err)
+		}
+	}
+	if format, found := raw["format"]; found {
+		if s.format, _ = format.(string); s.format == "" {
+			return nil, fmt.Errorf("%s: format must be a string", pointer(path))
+		}
+	}
+	if _, found := raw["$ref"]; found {
+		return nil, fmt.Errorf("%s: $ref is not supported", pointer(path))
+	}
+	return s, nil
+}
+
+func compileList(raw map[string]any, keyword, path string) ([]*Schema, error) {
+	value, found := raw[keyword]
+	if !found {
+		return nil, nil
+	}
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("%s: %s must be a non-empty array", pointer(path), keyword)
+	}
+	schemas := make([]*Schema, 0, len(list))
+	for i, item := range list {
+		schema, err := compile(item, fmt.Sprintf("%s/%s/%d", path, keyword, i))
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+func count(raw map[string]any, keyword, path string) (*int, error) {
+	value, err := number(raw, keyword, path)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	if *value < 0 || *value != math.Trunc(*value) {
+		return nil, fmt.Errorf("%s: %s must be a non-negative integer", pointer(path), keyword)
+	}
+	n := int(*value)
+	return &n, nil
+}
+
+func number(raw map[string]any, keyword, path string) (*float64, error) {
+	value, found := raw[keyword]
+	if !found {
+		return nil, nil
+	}
+	n, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s must be a number", pointer(path), keyword)
+	}
+	return &n, nil
+}
+
+func (s *Schema) validate(value any, path string, violations *[]string) {
+	if s.isBool {
+		if !s.always {
+			*violations = append(*violations, pointer(path)+": not allowed")
+		}
+		return
+	}
+	fail := func(format string, args ...any) {
+		*violations = append(*violations, pointer(path)+": "+fmt.Sprintf(format, args...))
+	}
+
+	if len(s.types) > 0 && !slices.ContainsFunc(s.types, func(t string) bool { return hasType(value, t) }) {
+		fail("must be of type %s, got %s", strings.Join(s.types, " or "), typeOf(value))
+		return
+	}
+	if s.enum != nil && !slices.ContainsFunc(s.enum, func(allowed any) bool { return reflect.DeepEqual(allowed, value) }) {
+		fail("must be one of %s", mustJSON(s.enum))
+	}
+	if s.hasConst && !reflect.DeepEqual(s.constValue, value) {
+		fail("must be %s", mustJSON(s.constValue))
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		for _, name := range s.required {
+			if _, found := value[name]; !found {
+				fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, found := s.properties[name]; found {
+				property.validate(value[name], path+"/"+name, violations)
+			} else if s.additionalProperties != nil {
+				if s.additionalProperties.isBool && !s.additionalProperties.always {
+					fail("unknown property %q", name)
+					continue
+				}
+				s.additionalProperties.validate(value[name], path+"/"+name, violations)
+			}
+		}
+	case []any:
+		if s.minItems != nil && len(value) < *s.minItems {
+			fail("must have at least %d items", *s.minItems)
+		}
+		if s.maxItems != nil && len(value) > *s.maxItems {
+			fail("must have at most %d items", *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range value {
+				s.items.validate(item, fmt.Sprintf("%s/%d", path, i), violations)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(value)
+		if s.minLength != nil && length < *s.minLength {
+			fail("must be at least %d characters", *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			fail("must be at most %d characters", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(value) {
+			fail("must match %s", s.pattern)
+		}
+		if s.format != "" && !validFormat(s.format, value) {
+			fail("must be a valid %s", s.format)
+		}
+	case float64:
+		if s.minimum != nil && value < *s.minimum {
+			fail("must be >= %v", *s.minimum)
+		}
+		if s.maximum != nil && value > *s.maximum {
+			fail("must be <= %v", *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && value <= *s.exclusiveMinimum {
+			fail("must be > %v", *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && value >= *s.exclusiveMaximum {
+			fail("must be < %v", *s.exclusiveMaximum)
+		}
+	}
+
+	for _, schema := range s.allOf {
+		schema.validate(value, path, violations)
+	}
+	if len(s.anyOf) > 0 && !slices.ContainsFunc(s.anyOf, func(schema *Schema) bool { return schema.matches(value) }) {
+		fail("must match a schema of anyOf")
+	}
+	if len(s.oneOf) > 0 {
+		matched := 0
+		for _, schema := range s.oneOf {
+			if schema.matches(value) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail("must match exactly one schema of oneOf, matched %d", matched)
+		}
+	}
+	if s.not != nil && s.not.matches(value) {
+		fail("must not match the schema of not")
+	}
+}
+
+func (s *Schema) matches(value any) bool {
+	var violations []string
+	s.validate(value, "", &violations)
+	return len(violations) == 0
+}
+
+func hasType(value any, name string) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return typeOf(value) == name
+	}
+}
+
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func validFormat(format, value string) bool {
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "date":
+		_, err = time.Parse(time.DateOnly, value)
+	case "email":
+		var address *mail.Address
+		if address, err = mail.ParseAddress(value); err == nil && address.Address != value {
+			return false
+		}
+	case "uuid":
+		_, err = uuid.Parse(value)
+	}
+	// The other formats are annotations
+	return err == nil
+}
+
+func pointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func mustJSON(value any) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,103 @@
+package eventschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/eventschema"
+)
+
+const orderSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["order_id", "total"],
+	"additionalProperties": false,
+	"properties": {
+		"order_id": {"type": "string", "format": "uuid"},
+		"total": {"type": "integer", "minimum": 0},
+		"currency": {"enum": ["EUR", "USD"]},
+		"email": {"type": ["string", "null"], "format": "email"},
+		"placed_at": {"type": "string", "format": "date-time"},
+		"items": {
+			"type": "array",
+			"minItems": 1,
+			"items": {"type": "object", "properties": {"sku": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]+$"}}}
+		},
+		"note": {"type": "string", "maxLength": 5},
+		"channel": {"oneOf": [{"const": "web"}, {"const": "app"}]}
+	}
+}`
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := eventschema.CompileSchema([]byte(orderSchema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		document   string
+		violations []string
+	}{
+		{name: "valid", document: `{"order_id":"0192a0b8-62d4-7c3a-9a8e-5f6c7d8e9f01","total":990,"currency":"EUR",` +
+			`"email":null,"placed_at":"2026-03-04T05:06:07Z","items":[{"sku":"ABC-1"}],"channel":"web"}`},
+		{name: "missing property", document: `{"order_id":"0192a0b8-62d4-7c3a-9a8e-5f6c7d8e9f01"}`,
+			violations: []string{`/: missing required property "total"`}},
+		{name: "unknown property", document: `{"order_id":"0192a0b8-62d4-7c3a-9a8e-5f6c7d8e9f01","total":1,"extra":1}`,
+			violations: []string{`/: unknown property "extra"`}},
+		{name: "wrong types", document: `{"order_id":42,"total":9.9}`, violations: []string{
+			"/order_id: must be of type string, got number",
+			"/total: must be of type integer, got number",
+		}},
+		{name: "invalid values", document: `{"order_id":"42","total":-1,"currency":"BRL","email":"john",` +
+			`"placed_at":"yesterday","items":[],"note":"too long","channel":"fax"}`, violations: []string{
+			"/channel: must match exactly one schema of oneOf, matched 0",
+			`/currency: must be one of ["EUR","USD"]`,
+			"/email: must be a valid email",
+			"/items: must have at least 1 items",
+			"/note: must be at most 5 characters",
+			"/order_id: must be a valid uuid",
+			"/placed_at: must be a valid date-time",
+			"/total: must be >= 0",
+		}},
+		{name: "nested value", document: `{"order_id":"0192a0b8-62d4-7c3a-9a8e-5f6c7d8e9f01","total":1,` +
+			`"items":[{"sku":"ABC-1"},{"sku":"abc"}]}`, violations: []string{"/items/1/sku: must match ^[A-Z]{3}-[0-9]+$"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := schema.Validate([]byte(tt.document))
+
+			// Assert
+			if tt.violations == nil {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *eventschema.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.violations, validationErr.Violations)
+		})
+	}
+}
+
+func TestCompileSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{name: "invalid JSON", document: `{`},
+		{name: "unknown type", document: `{"type":"decimal"}`},
+		{name: "invalid pattern", document: `{"pattern":"["}`},
+		{name: "negative length", document: `{"minLength":-1}`},
+		{name: "reference", document: `{"$ref":"#/$defs/money"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := eventschema.CompileSchema([]byte(tt.document))
+
+			// Assert
+			require.ErrorIs(t, err, eventschema.ErrInvalidSchema)
+		})
+	}
+}
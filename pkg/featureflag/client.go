@@ -0,0 +1,133 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Client evaluates flags for the tenant and user stored in the context (see ctxmeta).
+// The default value is returned when the flag is unknown, cannot be resolved or has another type.
+type Client interface {
+	Bool(ctx context.Context, key string, defaultValue bool) bool
+	String(ctx context.Context, key string, defaultValue string) string
+	Int(ctx context.Context, key string, defaultValue int) int
+	Float(ctx context.Context, key string, defaultValue float64) float64
+}
+
+type client struct {
+	provider Provider
+	metrics  *evaluationMetrics
+}
+
+var _ Client = (*client)(nil)
+
+// NewClient creates a Client resolving flags with provider.
+// Evaluation metrics are registered against prometheus.DefaultRegisterer unless WithRegisterer is provided.
+func NewClient(provider Provider, opts ...Option) (Client, error) {
+	clientOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&clientOptions)
+	}
+
+	metrics, err := newEvaluationMetrics(clientOptions.registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{provider: provider, metrics: metrics}, nil
+}
+
+func (c *client) Bool(ctx context.Context, key string, defaultValue bool) bool {
+	return evaluate(ctx, c, key, defaultValue, toBool)
+}
+
+func (c *client) String(ctx context.Context, key string, defaultValue string) string {
+	return evaluate(ctx, c, key, defaultValue, toString)
+}
+
+func (c *client) Int(ctx context.Context, key string, defaultValue int) int {
+	return evaluate(ctx, c, key, defaultValue, toInt)
+}
+
+func (c *client) Float(ctx context.Context, key string, defaultValue float64) float64 {
+	return evaluate(ctx, c, key, defaultValue, toFloat)
+}
+
+func evaluate[T any](ctx context.Context, c *client, key string, defaultValue T, convert func(any) (T, bool)) T {
+	start := time.Now()
+
+	raw, err := c.provider.Resolve(ctx, key, defaultValue, TargetFromContext(ctx))
+	if err == nil {
+		value, ok := convert(raw)
+		if ok {
+			c.metrics.observe(key, resultResolved, time.Since(start))
+			return value
+		}
+		err = fmt.Errorf("%w: %T", ErrTypeMismatch, raw)
+	}
+
+	result := resultError
+	if errors.Is(err, ErrFlagNotFound) {
+		result = resultNotFound
+	}
+	c.metrics.observe(key, result, time.Since(start))
+	return defaultValue
+}
+
+func toBool(value any) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	default:
+		return false, false
+	}
+}
+
+func toString(value any) (string, bool) {
+	v, ok := value.(string)
+	return v, ok
+}
+
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		// JSON numbers decode as float64
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
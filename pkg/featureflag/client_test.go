@@ -0,0 +1,147 @@
+package featureflag_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/featureflag"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+type ClientTestSuite struct {
+	suite.Suite
+	sut          featureflag.Client
+	providerMock *mocks.MockProvider
+	registry     *prometheus.Registry
+}
+
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func (s *ClientTestSuite) SetupTest() {
+	s.providerMock = mocks.NewMockProvider(s.T())
+	s.registry = prometheus.NewRegistry()
+
+	sut, err := featureflag.NewClient(s.providerMock, featureflag.WithRegisterer(s.registry))
+	s.Require().NoError(err)
+	s.sut = sut
+}
+
+func (s *ClientTestSuite) evaluations(flag, result string) float64 {
+	families, err := s.registry.Gather()
+	s.Require().NoError(err)
+	for _, family := range families {
+		if family.GetName() != "feature_flag_evaluations_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["flag"] == flag && labels["result"] == result {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func (s *ClientTestSuite) TestBool_ResolvesForContextTarget() {
+	// Arrange
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+	ctx = ctxmeta.WithUserID(ctx, "user-1")
+	target := featureflag.Target{TenantID: "acme", UserID: "user-1"}
+	s.providerMock.EXPECT().Resolve(ctx, "new-checkout", false, target).Return(true, nil)
+
+	// Act
+	enabled := s.sut.Bool(ctx, "new-checkout", false)
+
+	// Assert
+	s.True(enabled)
+	s.InDelta(1, s.evaluations("new-checkout", "resolved"), 0)
+}
+
+func (s *ClientTestSuite) TestBool_ReturnsDefaultWhenFlagNotFound() {
+	// Arrange
+	ctx := context.Background()
+	s.providerMock.EXPECT().Resolve(ctx, "missing", true, featureflag.Target{}).Return(nil, featureflag.ErrFlagNotFound)
+
+	// Act
+	enabled := s.sut.Bool(ctx, "missing", true)
+
+	// Assert
+	s.True(enabled)
+	s.InDelta(1, s.evaluations("missing", "not_found"), 0)
+}
+
+func (s *ClientTestSuite) TestString_ReturnsDefaultOnProviderError() {
+	// Arrange
+	ctx := context.Background()
+	s.providerMock.EXPECT().Resolve(ctx, "theme", "light", mock.Anything).Return(nil, errors.New("connection refused"))
+
+	// Act
+	theme := s.sut.String(ctx, "theme", "light")
+
+	// Assert
+	s.Equal("light", theme)
+	s.InDelta(1, s.evaluations("theme", "error"), 0)
+}
+
+func (s *ClientTestSuite) TestInt_ConvertsJSONNumbers() {
+	// Arrange
+	ctx := context.Background()
+	s.providerMock.EXPECT().Resolve(ctx, "max-items", 10, mock.Anything).Return(float64(50), nil)
+
+	// Act
+	maxItems := s.sut.Int(ctx, "max-items", 10)
+
+	// Assert
+	s.Equal(50, maxItems)
+}
+
+func (s *ClientTestSuite) TestInt_ReturnsDefaultOnTypeMismatch() {
+	// Arrange
+	ctx := context.Background()
+	s.providerMock.EXPECT().Resolve(ctx, "max-items", 10, mock.Anything).Return("fifty", nil)
+
+	// Act
+	maxItems := s.sut.Int(ctx, "max-items", 10)
+
+	// Assert
+	s.Equal(10, maxItems)
+	s.InDelta(1, s.evaluations("max-items", "error"), 0)
+}
+
+func (s *ClientTestSuite) TestFloat_ConvertsIntegers() {
+	// Arrange
+	ctx := context.Background()
+	s.providerMock.EXPECT().Resolve(ctx, "discount", 0.0, mock.Anything).Return(5, nil)
+
+	// Act
+	discount := s.sut.Float(ctx, "discount", 0)
+
+	// Assert
+	s.InDelta(5.0, discount, 0)
+}
+
+func (s *ClientTestSuite) TestNewClient_ReusesRegisteredCollectors() {
+	// Arrange
+	ctx := context.Background()
+	s.providerMock.EXPECT().Resolve(ctx, "new-checkout", false, mock.Anything).Return(true, nil)
+
+	// Act
+	other, err := featureflag.NewClient(s.providerMock, featureflag.WithRegisterer(s.registry))
+	s.Require().NoError(err)
+	other.Bool(ctx, "new-checkout", false)
+
+	// Assert
+	s.InDelta(1, s.evaluations("new-checkout", "resolved"), 0)
+}
@@ -0,0 +1,55 @@
+package featureflag
+
+import "time"
+
+const (
+	ProviderStatic      = "static"
+	ProviderRedis       = "redis"
+	ProviderOpenFeature = "openfeature"
+
+	defaultRedisPrefix = "feature_flags:"
+)
+
+// Config configures the feature flag provider.
+type Config struct {
+	// Provider selects where flags are resolved: static, redis or openfeature
+	Provider string `config:"provider"`
+	// ReloadInterval re-reads the static flags from the config files; zero disables hot reload
+	ReloadInterval time.Duration `config:"reload_interval"`
+	// Flags are the static flag definitions
+	Flags map[string]Flag `config:"flags"`
+	// Redis configures the redis provider
+	Redis RedisConfig `config:"redis"`
+	// OpenFeature configures the OpenFeature bridge
+	OpenFeature OpenFeatureConfig `config:"openfeature"`
+}
+
+type RedisConfig struct {
+	// Prefix is prepended to the flag key, e.g. "feature_flags:new-checkout"
+	Prefix string `config:"prefix"`
+}
+
+type OpenFeatureConfig struct {
+	// Domain selects the OpenFeature provider bound with openfeature.SetNamedProvider; empty uses the default provider
+	Domain string `config:"domain"`
+}
+
+// Validate checks the configured provider.
+func (c *Config) Validate() error {
+	switch c.Provider {
+	case ProviderStatic, ProviderRedis, ProviderOpenFeature:
+		return nil
+	default:
+		return ErrInvalidProvider
+	}
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Provider == "" {
+		c.Provider = ProviderStatic
+	}
+	if c.Redis.Prefix == "" {
+		c.Redis.Prefix = defaultRedisPrefix
+	}
+}
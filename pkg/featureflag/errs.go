@@ -0,0 +1,11 @@
+package featureflag
+
+import "errors"
+
+var (
+	ErrFlagNotFound        = errors.New("feature flag not found")
+	ErrTypeMismatch        = errors.New("feature flag value has an unexpected type")
+	ErrInvalidProvider     = errors.New("invalid feature flag provider (must be 'static', 'redis' or 'openfeature')")
+	ErrRedisClientRequired = errors.New("redis client is required for the redis feature flag provider")
+	ErrDecodeFlag          = errors.New("failed to decode feature flag")
+)
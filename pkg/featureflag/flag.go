@@ -0,0 +1,80 @@
+package featureflag
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+const percentageBuckets = 100
+
+// Flag is a feature flag definition used by the static and redis providers.
+// Rules are evaluated in order; the first matching rule wins, otherwise Value is returned.
+type Flag struct {
+	Value any    `config:"value" json:"value"`
+	Rules []Rule `config:"rules" json:"rules,omitempty"`
+}
+
+// Rule targets tenants, users or a percentage of them.
+// All conditions set on a rule must match.
+type Rule struct {
+	Tenants []string `config:"tenants" json:"tenants,omitempty"`
+	Users   []string `config:"users" json:"users,omitempty"`
+	// Percentage rolls the rule out to a stable share (0-100) of users, or tenants when no user is known
+	Percentage int `config:"percentage" json:"percentage,omitempty"`
+	Value      any `config:"value" json:"value"`
+}
+
+// Target is who a flag is evaluated for.
+type Target struct {
+	TenantID string
+	UserID   string
+}
+
+// TargetFromContext builds the Target from the tenant and user stored with ctxmeta.
+func TargetFromContext(ctx context.Context) Target {
+	var target Target
+	target.TenantID, _ = ctxmeta.TenantID(ctx)
+	target.UserID, _ = ctxmeta.UserID(ctx)
+	return target
+}
+
+// Evaluate returns the value of the flag for the target.
+func (f Flag) Evaluate(key string, target Target) any {
+	for _, rule := range f.Rules {
+		if rule.matches(key, target) {
+			return rule.Value
+		}
+	}
+	return f.Value
+}
+
+func (r Rule) matches(key string, target Target) bool {
+	if len(r.Tenants) > 0 && !slices.Contains(r.Tenants, target.TenantID) {
+		return false
+	}
+	if len(r.Users) > 0 && !slices.Contains(r.Users, target.UserID) {
+		return false
+	}
+	if r.Percentage > 0 {
+		return inRollout(key, target, r.Percentage)
+	}
+	return len(r.Tenants) > 0 || len(r.Users) > 0
+}
+
+// inRollout hashes the flag key with the user (or tenant) so each flag rolls out to a different, stable share.
+func inRollout(key string, target Target, percentage int) bool {
+	subject := target.UserID
+	if subject == "" {
+		subject = target.TenantID
+	}
+	if subject == "" {
+		return false
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key + ":" + subject))
+	return int(h.Sum32()%percentageBuckets) < percentage
+}
@@ -0,0 +1,88 @@
+package featureflag_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/featureflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagEvaluate(t *testing.T) {
+	flag := featureflag.Flag{
+		Value: false,
+		Rules: []featureflag.Rule{
+			{Users: []string{"beta-user"}, Value: true},
+			{Tenants: []string{"acme"}, Value: true},
+			{Tenants: []string{"globex"}, Users: []string{"admin"}, Value: true},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		target featureflag.Target
+		want   any
+	}{
+		{name: "user rule matches", target: featureflag.Target{UserID: "beta-user"}, want: true},
+		{name: "tenant rule matches", target: featureflag.Target{TenantID: "acme", UserID: "someone"}, want: true},
+		{name: "all rule conditions must match", target: featureflag.Target{TenantID: "globex", UserID: "someone"}, want: false},
+		{name: "combined rule matches", target: featureflag.Target{TenantID: "globex", UserID: "admin"}, want: true},
+		{name: "no match returns value", target: featureflag.Target{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := flag.Evaluate("new-checkout", tt.target)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlagEvaluate_PercentageRollout(t *testing.T) {
+	t.Run("rolls out to a stable share of users", func(t *testing.T) {
+		// Arrange
+		flag := featureflag.Flag{Value: false, Rules: []featureflag.Rule{{Percentage: 30, Value: true}}}
+
+		// Act
+		enabled := 0
+		for i := range 1000 {
+			target := featureflag.Target{UserID: fmt.Sprintf("user-%d", i)}
+			if flag.Evaluate("new-checkout", target) == true {
+				enabled++
+			}
+		}
+
+		// Assert
+		assert.InDelta(t, 300, enabled, 60)
+		first := flag.Evaluate("new-checkout", featureflag.Target{UserID: "user-1"})
+		assert.Equal(t, first, flag.Evaluate("new-checkout", featureflag.Target{UserID: "user-1"}))
+	})
+
+	t.Run("does not match without a user or tenant", func(t *testing.T) {
+		// Arrange
+		flag := featureflag.Flag{Value: false, Rules: []featureflag.Rule{{Percentage: 100, Value: true}}}
+
+		// Act
+		got := flag.Evaluate("new-checkout", featureflag.Target{})
+
+		// Assert
+		assert.Equal(t, false, got)
+	})
+}
+
+func TestTargetFromContext(t *testing.T) {
+	// Arrange
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+	ctx = ctxmeta.WithUserID(ctx, "user-1")
+
+	// Act
+	target := featureflag.TargetFromContext(ctx)
+
+	// Assert
+	assert.Equal(t, featureflag.Target{TenantID: "acme", UserID: "user-1"}, target)
+}
@@ -0,0 +1,89 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+const configPath = "app.feature_flags"
+
+// Module provides the feature flag Provider selected by app.feature_flags.provider and the Client.
+// The redis provider requires redis.Module; evaluation metrics are registered on the
+// prometheus.Registerer from metrics.Module when available.
+var Module = fx.Module(
+	"featureflag",
+	config.Provide[Config](configPath),
+	fx.Provide(
+		NewProviderWithLifecycle,
+		NewClientWithParams,
+	),
+)
+
+type NewProviderParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    config.Config[Config]
+	Logger    logger.Logger
+	Redis     redis.UniversalClient `optional:"true"`
+	// ConfigOptions apply to the reloads of the flags when supplied with config.SupplyOptions.
+	ConfigOptions config.Options `optional:"true"`
+}
+
+// NewProviderWithLifecycle creates the configured Provider.
+// The static provider reloads its flags from the config files while the application runs
+// when reload_interval is set.
+func NewProviderWithLifecycle(p NewProviderParams) (Provider, error) {
+	cfg := p.Config.Get()
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, cfg.Provider)
+	}
+
+	switch cfg.Provider {
+	case ProviderRedis:
+		return NewRedisProvider(p.Redis, cfg.Redis.Prefix)
+	case ProviderOpenFeature:
+		return NewOpenFeatureProvider(openfeature.NewClient(cfg.OpenFeature.Domain)), nil
+	}
+
+	if cfg.ReloadInterval <= 0 {
+		return NewStaticProvider(cfg.Flags), nil
+	}
+
+	provider, err := NewConfigProvider(configPath, cfg.ReloadInterval, p.Logger, p.ConfigOptions...)
+	if err != nil {
+		return nil, err
+	}
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			provider.Start()
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			provider.Stop()
+			return nil
+		},
+	})
+	return provider, nil
+}
+
+type NewClientParams struct {
+	fx.In
+
+	Provider   Provider
+	Registerer prometheus.Registerer `optional:"true"`
+}
+
+// NewClientWithParams creates the Client, registering its metrics on the application registerer when provided.
+func NewClientWithParams(p NewClientParams) (Client, error) {
+	return NewClient(p.Provider, WithRegisterer(p.Registerer))
+}
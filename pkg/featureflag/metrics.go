@@ -0,0 +1,57 @@
+package featureflag
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	evaluationsMetricName        = "feature_flag_evaluations_total"
+	evaluationDurationMetricName = "feature_flag_evaluation_duration_seconds"
+
+	resultResolved = "resolved"
+	resultNotFound = "not_found"
+	resultError    = "error"
+)
+
+type evaluationMetrics struct {
+	evaluations *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+}
+
+func newEvaluationMetrics(registerer prometheus.Registerer) (*evaluationMetrics, error) {
+	evaluations := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: evaluationsMetricName,
+			Help: "Total feature flag evaluations by flag and result (resolved, not_found, error)",
+		},
+		[]string{"flag", "result"},
+	)
+	duration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    evaluationDurationMetricName,
+			Help:    "Duration of feature flag evaluations in seconds",
+			Buckets: []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1},
+		},
+		[]string{"flag"},
+	)
+
+	evaluations, err := metrics.Register(registerer, evaluations)
+	if err != nil {
+		return nil, err
+	}
+	duration, err = metrics.Register(registerer, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &evaluationMetrics{evaluations: evaluations, duration: duration}, nil
+}
+
+func (m *evaluationMetrics) observe(flag, result string, duration time.Duration) {
+	m.evaluations.WithLabelValues(flag, result).Inc()
+	m.duration.WithLabelValues(flag).Observe(duration.Seconds())
+}
@@ -0,0 +1,79 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// OpenFeatureProvider bridges to an OpenFeature client, so any OpenFeature provider
+// (flagd, LaunchDarkly, Unleash, ...) can back the typed evaluation API.
+// The targeting key is the user ID, or the tenant ID when no user is known.
+type OpenFeatureProvider struct {
+	client *openfeature.Client
+}
+
+var _ Provider = (*OpenFeatureProvider)(nil)
+
+// NewOpenFeatureProvider creates a bridge to client.
+func NewOpenFeatureProvider(client *openfeature.Client) *OpenFeatureProvider {
+	return &OpenFeatureProvider{client: client}
+}
+
+// Resolve evaluates the flag with the OpenFeature method matching the type of defaultValue.
+func (p *OpenFeatureProvider) Resolve(ctx context.Context, key string, defaultValue any, target Target) (any, error) {
+	evalCtx := evaluationContext(target)
+
+	var (
+		value   any
+		details openfeature.EvaluationDetails
+		err     error
+	)
+	switch def := defaultValue.(type) {
+	case bool:
+		var result openfeature.BooleanEvaluationDetails
+		result, err = p.client.BooleanValueDetails(ctx, key, def, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	case string:
+		var result openfeature.StringEvaluationDetails
+		result, err = p.client.StringValueDetails(ctx, key, def, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	case int:
+		var result openfeature.IntEvaluationDetails
+		result, err = p.client.IntValueDetails(ctx, key, int64(def), evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	case float64:
+		var result openfeature.FloatEvaluationDetails
+		result, err = p.client.FloatValueDetails(ctx, key, def, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	default:
+		var result openfeature.InterfaceEvaluationDetails
+		result, err = p.client.ObjectValueDetails(ctx, key, def, evalCtx)
+		value, details = result.Value, result.EvaluationDetails
+	}
+
+	if details.ErrorCode == openfeature.FlagNotFoundCode {
+		return nil, ErrFlagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("evaluate feature flag %q: %w", key, err)
+	}
+	return value, nil
+}
+
+func evaluationContext(target Target) openfeature.EvaluationContext {
+	attributes := map[string]any{}
+	if target.TenantID != "" {
+		attributes["tenant_id"] = target.TenantID
+	}
+	if target.UserID != "" {
+		attributes["user_id"] = target.UserID
+	}
+
+	targetingKey := target.UserID
+	if targetingKey == "" {
+		targetingKey = target.TenantID
+	}
+	return openfeature.NewEvaluationContext(targetingKey, attributes)
+}
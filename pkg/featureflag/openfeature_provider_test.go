@@ -0,0 +1,68 @@
+package featureflag_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-feature/go-sdk/openfeature/memprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/featureflag"
+)
+
+func TestOpenFeatureProvider(t *testing.T) {
+	const domain = "featureflag-test"
+	tenantEvaluator := func(
+		_ memprovider.InMemoryFlag,
+		flatCtx openfeature.FlattenedContext,
+	) (any, openfeature.ProviderResolutionDetail) {
+		if flatCtx["tenant_id"] == "acme" {
+			return true, openfeature.ProviderResolutionDetail{Reason: openfeature.TargetingMatchReason, Variant: "on"}
+		}
+		return false, openfeature.ProviderResolutionDetail{Reason: openfeature.DefaultReason, Variant: "off"}
+	}
+	require.NoError(t, openfeature.SetNamedProviderAndWait(domain, memprovider.NewInMemoryProvider(
+		map[string]memprovider.InMemoryFlag{
+			"new-checkout": {
+				State:            memprovider.Enabled,
+				DefaultVariant:   "off",
+				Variants:         map[string]any{"on": true, "off": false},
+				ContextEvaluator: &tenantEvaluator,
+			},
+			"theme": {
+				State:          memprovider.Enabled,
+				DefaultVariant: "dark",
+				Variants:       map[string]any{"dark": "dark"},
+			},
+		},
+	)))
+	provider := featureflag.NewOpenFeatureProvider(openfeature.NewClient(domain))
+
+	t.Run("passes the target to the evaluation context", func(t *testing.T) {
+		// Act
+		value, err := provider.Resolve(context.Background(), "new-checkout", false, featureflag.Target{TenantID: "acme"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, true, value)
+	})
+
+	t.Run("resolves with the type of the default value", func(t *testing.T) {
+		// Act
+		value, err := provider.Resolve(context.Background(), "theme", "light", featureflag.Target{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "dark", value)
+	})
+
+	t.Run("maps unknown flags to ErrFlagNotFound", func(t *testing.T) {
+		// Act
+		_, err := provider.Resolve(context.Background(), "missing", false, featureflag.Target{})
+
+		// Assert
+		require.ErrorIs(t, err, featureflag.ErrFlagNotFound)
+	})
+}
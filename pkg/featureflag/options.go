@@ -0,0 +1,26 @@
+package featureflag
+
+import "github.com/prometheus/client_golang/prometheus"
+
+type options struct {
+	registerer prometheus.Registerer
+}
+
+// Option configures the Client created by NewClient.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		registerer: prometheus.DefaultRegisterer,
+	}
+}
+
+// WithRegisterer sets the registerer the evaluation metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
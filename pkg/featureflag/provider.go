@@ -0,0 +1,9 @@
+package featureflag
+
+import "context"
+
+// Provider resolves the raw value of a flag for a target.
+// defaultValue carries the type the caller expects; providers return ErrFlagNotFound for unknown flags.
+type Provider interface {
+	Resolve(ctx context.Context, key string, defaultValue any, target Target) (any, error)
+}
@@ -0,0 +1,66 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// RedisProvider resolves flags stored as JSON Flag definitions under prefix+key,
+// so flags can be changed at runtime for every instance at once.
+type RedisProvider struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+var _ Provider = (*RedisProvider)(nil)
+
+// NewRedisProvider creates a provider reading flags from client.
+func NewRedisProvider(client redis.UniversalClient, prefix string) (*RedisProvider, error) {
+	if client == nil {
+		return nil, ErrRedisClientRequired
+	}
+	return &RedisProvider{client: client, prefix: prefix}, nil
+}
+
+// Resolve returns the flag value for the target.
+func (p *RedisProvider) Resolve(ctx context.Context, key string, _ any, target Target) (any, error) {
+	data, err := p.client.Get(ctx, p.prefix+key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, ErrFlagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get feature flag %q: %w", key, err)
+	}
+
+	var flag Flag
+	if err := json.Unmarshal(data, &flag); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrDecodeFlag, key, err)
+	}
+	return flag.Evaluate(key, target), nil
+}
+
+// Set stores the flag definition.
+func (p *RedisProvider) Set(ctx context.Context, key string, flag Flag) error {
+	data, err := json.Marshal(flag)
+	if err != nil {
+		return fmt.Errorf("encode feature flag %q: %w", key, err)
+	}
+	if err := p.client.Set(ctx, p.prefix+key, data, 0).Err(); err != nil {
+		return fmt.Errorf("set feature flag %q: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes the flag definition.
+func (p *RedisProvider) Delete(ctx context.Context, key string) error {
+	if err := p.client.Del(ctx, p.prefix+key).Err(); err != nil {
+		return fmt.Errorf("delete feature flag %q: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// StaticProvider resolves flags from in-memory definitions.
+// Built with NewConfigProvider, it re-reads the definitions from the config files on an interval.
+type StaticProvider struct {
+	flags    atomic.Pointer[map[string]Flag]
+	load     func() (map[string]Flag, error)
+	interval time.Duration
+	logger   logger.Logger
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ Provider = (*StaticProvider)(nil)
+
+// NewStaticProvider creates a provider with fixed flag definitions. Use Update to replace them.
+func NewStaticProvider(flags map[string]Flag) *StaticProvider {
+	p := &StaticProvider{}
+	p.Update(flags)
+	return p
+}
+
+// NewConfigProvider creates a provider that loads the flags from the "flags" key under path
+// (e.g. "app.feature_flags") and reloads them every interval once Start is called. The
+// options, e.g. the ones supplied with config.SupplyOptions, apply to every load.
+func NewConfigProvider(
+	path string,
+	interval time.Duration,
+	log logger.Logger,
+	options ...config.Option,
+) (*StaticProvider, error) {
+	p := &StaticProvider{
+		load: func() (map[string]Flag, error) {
+			cfg, err := config.New[Config](append(slices.Clone(options), config.WithPath(path))...)
+			if err != nil {
+				return nil, err
+			}
+			return cfg.Get().Flags, nil
+		},
+		interval: interval,
+		logger:   log,
+	}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Resolve returns the flag value for the target.
+func (p *StaticProvider) Resolve(_ context.Context, key string, _ any, target Target) (any, error) {
+	flag, ok := (*p.flags.Load())[key]
+	if !ok {
+		return nil, ErrFlagNotFound
+	}
+	return flag.Evaluate(key, target), nil
+}
+
+// Update replaces all flag definitions.
+func (p *StaticProvider) Update(flags map[string]Flag) {
+	if flags == nil {
+		flags = map[string]Flag{}
+	}
+	p.flags.Store(&flags)
+}
+
+// Reload reads the flag definitions again. Previous definitions are kept when loading fails.
+func (p *StaticProvider) Reload() error {
+	if p.load == nil {
+		return nil
+	}
+	flags, err := p.load()
+	if err != nil {
+		return fmt.Errorf("reload feature flags: %w", err)
+	}
+	p.Update(flags)
+	return nil
+}
+
+// Start begins reloading the flags in the background. It is a no-op without a reload interval.
+func (p *StaticProvider) Start() {
+	if p.load == nil || p.interval <= 0 || p.stop != nil {
+		return
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.watch()
+}
+
+// Stop stops the background reload and waits for it to finish.
+func (p *StaticProvider) Stop() {
+	if p.stop == nil {
+		return
+	}
+	p.stopOnce.Do(func() {
+		close(p.stop)
+		<-p.done
+	})
+}
+
+func (p *StaticProvider) watch() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			if err := p.Reload(); err != nil && p.logger != nil {
+				p.logger.Warn("failed to reload feature flags, keeping previous definitions", logger.Error(err))
+			}
+		}
+	}
+}
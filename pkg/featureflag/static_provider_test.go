@@ -0,0 +1,99 @@
+package featureflag_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/featureflag"
+)
+
+func writeFlagsConfig(t *testing.T, dir, value string) {
+	t.Helper()
+
+	content := "app:\n  feature_flags:\n    flags:\n      new-checkout:\n        value: " + value + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "base.yaml"), []byte(content), 0o600))
+}
+
+func TestStaticProvider(t *testing.T) {
+	t.Run("resolves and updates flags", func(t *testing.T) {
+		// Arrange
+		provider := featureflag.NewStaticProvider(map[string]featureflag.Flag{
+			"new-checkout": {Value: true},
+		})
+
+		// Act
+		before, err := provider.Resolve(context.Background(), "new-checkout", false, featureflag.Target{})
+		require.NoError(t, err)
+		provider.Update(map[string]featureflag.Flag{"new-checkout": {Value: false}})
+		after, afterErr := provider.Resolve(context.Background(), "new-checkout", false, featureflag.Target{})
+
+		// Assert
+		require.NoError(t, afterErr)
+		assert.Equal(t, true, before)
+		assert.Equal(t, false, after)
+	})
+
+	t.Run("returns ErrFlagNotFound for unknown flags", func(t *testing.T) {
+		// Arrange
+		provider := featureflag.NewStaticProvider(nil)
+
+		// Act
+		_, err := provider.Resolve(context.Background(), "missing", false, featureflag.Target{})
+
+		// Assert
+		require.ErrorIs(t, err, featureflag.ErrFlagNotFound)
+	})
+}
+
+func TestConfigProvider_HotReload(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	t.Setenv("APP_CONFIG_DIR", dir)
+	t.Setenv("APP_ENV", "test")
+	writeFlagsConfig(t, dir, "false")
+
+	provider, err := featureflag.NewConfigProvider("app.feature_flags", 10*time.Millisecond, nil)
+	require.NoError(t, err)
+
+	initial, err := provider.Resolve(context.Background(), "new-checkout", false, featureflag.Target{})
+	require.NoError(t, err)
+	require.Equal(t, false, initial)
+
+	// Act
+	provider.Start()
+	defer provider.Stop()
+	writeFlagsConfig(t, dir, "true")
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		value, resolveErr := provider.Resolve(context.Background(), "new-checkout", false, featureflag.Target{})
+		return resolveErr == nil && value == true
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConfigProvider_KeepsFlagsWhenReloadFails(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	t.Setenv("APP_CONFIG_DIR", dir)
+	t.Setenv("APP_ENV", "test")
+	writeFlagsConfig(t, dir, "true")
+
+	provider, err := featureflag.NewConfigProvider("app.feature_flags", 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "base.yaml"), []byte("app: [invalid"), 0o600))
+
+	// Act
+	reloadErr := provider.Reload()
+
+	// Assert
+	require.Error(t, reloadErr)
+	value, err := provider.Resolve(context.Background(), "new-checkout", false, featureflag.Target{})
+	require.NoError(t, err)
+	assert.Equal(t, true, value)
+}
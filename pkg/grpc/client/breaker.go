@@ -0,0 +1,99 @@
+package grpcclient
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+// breaker is the circuit breaker of a client: it opens after threshold consecutive
+// failures, refuses the calls for openTimeout, then lets one call probe the service and
+// closes again when the probe succeeds.
+type breaker struct {
+	threshold   int
+	openTimeout time.Duration
+	clock       clock.Clock
+	onChange    func(open bool)
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newBreaker(cfg BreakerConfig, clk clock.Clock, onChange func(open bool)) *breaker {
+	return &breaker{
+		threshold:   cfg.FailureThreshold,
+		openTimeout: cfg.OpenTimeout,
+		clock:       clk,
+		onChange:    onChange,
+	}
+}
+
+// allow reports whether a call may run; while half-open, only the probe may.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case stateOpen:
+		if b.clock.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = stateHalfOpen
+		return true
+	case stateHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the status code of an allowed call.
+func (b *breaker) record(code codes.Code) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case code == codes.Canceled:
+		// The caller went away, the call says nothing about the service
+		if b.state == stateHalfOpen {
+			b.state = stateOpen
+		}
+	case isFailure(code):
+		b.failures++
+		if b.state == stateHalfOpen || b.failures >= b.threshold {
+			if b.state == stateClosed {
+				b.onChange(true)
+			}
+			b.state = stateOpen
+			b.openedAt = b.clock.Now()
+		}
+	default:
+		b.failures = 0
+		if b.state != stateClosed {
+			b.state = stateClosed
+			b.onChange(false)
+		}
+	}
+}
+
+// isFailure reports whether code is a failure of the service rather than of the request,
+// e.g. UNAVAILABLE but not NOT_FOUND or INVALID_ARGUMENT.
+func isFailure(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
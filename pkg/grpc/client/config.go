@@ -0,0 +1,135 @@
+package grpcclient
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultTimeout          = 5 * time.Second
+	defaultMaxAttempts      = 3
+	defaultInitialBackoff   = 100 * time.Millisecond
+	defaultMaxBackoff       = 2 * time.Second
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+
+	// maxRetryAttempts is the cap gRPC puts on the attempts of a retry policy
+	maxRetryAttempts = 5
+)
+
+// Config configures the clients of the Factory.
+type Config struct {
+	// Clients are the clients by name, e.g. orders and payments
+	Clients map[string]ClientConfig `config:"clients"`
+}
+
+// ClientConfig configures the connection to a gRPC service.
+type ClientConfig struct {
+	// Target is the address of the service, e.g. "orders.internal:9090" or "dns:///orders:9090"
+	Target string `config:"target"`
+	// Timeout bounds each call, retries included, unless the context has an earlier
+	// deadline; negative for no timeout
+	Timeout time.Duration `config:"timeout"`
+	// Metadata is sent with every call, e.g. an API key
+	Metadata       map[string]string `config:"metadata"`
+	TLS            TLSConfig         `config:"tls"`
+	Retry          RetryConfig       `config:"retry"`
+	CircuitBreaker BreakerConfig     `config:"circuit_breaker"`
+}
+
+// TLSConfig secures the connection; without Enabled the connection is in plaintext.
+type TLSConfig struct {
+	Enabled bool `config:"enabled"`
+	// CAFile verifies the server certificate instead of the system roots
+	CAFile string `config:"ca_file"`
+	// CertFile and KeyFile are the client certificate of mutual TLS
+	CertFile string `config:"cert_file"`
+	KeyFile  string `config:"key_file"`
+	// ServerName overrides the name verified in the server certificate
+	ServerName         string `config:"server_name"`
+	InsecureSkipVerify bool   `config:"insecure_skip_verify"`
+}
+
+// RetryConfig is the retry policy of the calls, run by gRPC with exponential backoff.
+type RetryConfig struct {
+	// MaxAttempts is the number of attempts of a call, first one included; 1 disables the
+	// retries, gRPC allows at most 5
+	MaxAttempts    int           `config:"max_attempts"`
+	InitialBackoff time.Duration `config:"initial_backoff"`
+	MaxBackoff     time.Duration `config:"max_backoff"`
+	// Codes are the status codes retried, e.g. UNAVAILABLE
+	Codes []string `config:"codes"`
+}
+
+// BreakerConfig configures the circuit breaker, which refuses the calls for OpenTimeout
+// after FailureThreshold consecutive failures, then lets a single call probe the service.
+type BreakerConfig struct {
+	Disabled         bool          `config:"disabled"`
+	FailureThreshold int           `config:"failure_threshold"`
+	OpenTimeout      time.Duration `config:"open_timeout"`
+}
+
+// SetDefaults fills in the optional fields of every client.
+func (c *Config) SetDefaults() {
+	for name, client := range c.Clients {
+		client.SetDefaults()
+		c.Clients[name] = client
+	}
+}
+
+// Validate checks every client.
+func (c *Config) Validate() error {
+	for name, client := range c.Clients {
+		if err := client.Validate(); err != nil {
+			return fmt.Errorf("client %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// SetDefaults fills in the optional fields.
+func (c *ClientConfig) SetDefaults() {
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.Retry.MaxAttempts == 0 {
+		c.Retry.MaxAttempts = defaultMaxAttempts
+	}
+	if c.Retry.InitialBackoff == 0 {
+		c.Retry.InitialBackoff = defaultInitialBackoff
+	}
+	if c.Retry.MaxBackoff == 0 {
+		c.Retry.MaxBackoff = defaultMaxBackoff
+	}
+	if len(c.Retry.Codes) == 0 {
+		c.Retry.Codes = []string{"UNAVAILABLE"}
+	}
+	if c.CircuitBreaker.FailureThreshold == 0 {
+		c.CircuitBreaker.FailureThreshold = defaultFailureThreshold
+	}
+	if c.CircuitBreaker.OpenTimeout == 0 {
+		c.CircuitBreaker.OpenTimeout = defaultOpenTimeout
+	}
+}
+
+// Validate checks the target and the retry policy.
+func (c *ClientConfig) Validate() error {
+	if c.Target == "" {
+		return ErrMissingTarget
+	}
+	if c.Retry.MaxAttempts < 1 || c.Retry.MaxAttempts > maxRetryAttempts {
+		return fmt.Errorf("%w: max attempts must be between 1 and %d", ErrInvalidRetry, maxRetryAttempts)
+	}
+	if c.Retry.InitialBackoff <= 0 || c.Retry.MaxBackoff < c.Retry.InitialBackoff {
+		return fmt.Errorf("%w: backoffs must be positive, the max not below the initial", ErrInvalidRetry)
+	}
+	for _, name := range c.Retry.Codes {
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(`"` + name + `"`)); err != nil {
+			return fmt.Errorf("%w: unknown code %s", ErrInvalidRetry, name)
+		}
+	}
+	return nil
+}
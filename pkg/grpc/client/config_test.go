@@ -0,0 +1,72 @@
+package grpcclient_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	grpcclient "github.com/cristiano-pacheco/bricks/pkg/grpc/client"
+)
+
+func TestConfig_SetDefaults_FillsOptionalFields(t *testing.T) {
+	// Arrange
+	cfg := grpcclient.Config{Clients: map[string]grpcclient.ClientConfig{"orders": {Target: "orders:9090"}}}
+
+	// Act
+	cfg.SetDefaults()
+
+	// Assert
+	client := cfg.Clients["orders"]
+	assert.Equal(t, 5*time.Second, client.Timeout)
+	assert.Equal(t, 3, client.Retry.MaxAttempts)
+	assert.Equal(t, []string{"UNAVAILABLE"}, client.Retry.Codes)
+	assert.Equal(t, 5, client.CircuitBreaker.FailureThreshold)
+	assert.Equal(t, 30*time.Second, client.CircuitBreaker.OpenTimeout)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		client grpcclient.ClientConfig
+		err    error
+	}{
+		{"valid", grpcclient.ClientConfig{Target: "orders:9090"}, nil},
+		{"missing target", grpcclient.ClientConfig{}, grpcclient.ErrMissingTarget},
+		{
+			"too many attempts",
+			grpcclient.ClientConfig{Target: "orders:9090", Retry: grpcclient.RetryConfig{MaxAttempts: 6}},
+			grpcclient.ErrInvalidRetry,
+		},
+		{
+			"unknown code",
+			grpcclient.ClientConfig{Target: "orders:9090", Retry: grpcclient.RetryConfig{Codes: []string{"BROKEN"}}},
+			grpcclient.ErrInvalidRetry,
+		},
+		{
+			"max backoff below initial",
+			grpcclient.ClientConfig{Target: "orders:9090", Retry: grpcclient.RetryConfig{
+				InitialBackoff: time.Second, MaxBackoff: time.Millisecond,
+			}},
+			grpcclient.ErrInvalidRetry,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := grpcclient.Config{Clients: map[string]grpcclient.ClientConfig{"orders": tt.client}}
+			cfg.SetDefaults()
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package grpcclient
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrUnknownClient is returned by Factory.Conn for a name without config
+	ErrUnknownClient = errors.New("grpcclient: unknown client")
+	// ErrMissingTarget is returned when a client has no target
+	ErrMissingTarget = errors.New("grpcclient: target is required")
+	// ErrInvalidRetry is returned when the retry config is out of the gRPC bounds
+	ErrInvalidRetry = errors.New("grpcclient: invalid retry config")
+	// ErrInvalidTLS is returned when the TLS files cannot be loaded
+	ErrInvalidTLS = errors.New("grpcclient: invalid TLS config")
+	// ErrCircuitOpen is returned, with the UNAVAILABLE code, by the calls refused while the
+	// circuit breaker of the client is open
+	ErrCircuitOpen = status.Error(codes.Unavailable, "grpcclient: circuit breaker is open")
+)
@@ -0,0 +1,177 @@
+package grpcclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Factory creates the connections of the configured clients. A connection is created on
+// its first use and shared by the callers; gRPC connects it lazily and reconnects it on
+// failure.
+type Factory struct {
+	cfg     Config
+	options options
+	metrics *clientMetrics
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewFactory creates a Factory for the clients of cfg.
+func NewFactory(cfg Config, opts ...Option) (*Factory, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	metrics, err := newClientMetrics(options.registerer)
+	if err != nil {
+		return nil, fmt.Errorf("grpcclient: register metrics: %w", err)
+	}
+	return &Factory{
+		cfg:     cfg,
+		options: options,
+		metrics: metrics,
+		conns:   make(map[string]*grpc.ClientConn),
+	}, nil
+}
+
+// Conn returns the connection of the client name, to create the generated clients with.
+//
+//	conn, err := factory.Conn("orders")
+//	client := orderspb.NewOrderServiceClient(conn)
+func (f *Factory) Conn(name string) (*grpc.ClientConn, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if conn, ok := f.conns[name]; ok {
+		return conn, nil
+	}
+	cfg, ok := f.cfg.Clients[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownClient, name)
+	}
+	conn, err := f.dial(name, cfg)
+	if err != nil {
+		return nil, err
+	}
+	f.conns[name] = conn
+	return conn, nil
+}
+
+// Close closes the connections created by Conn.
+func (f *Factory) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var errs []error
+	for name, conn := range f.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("grpcclient: close %s: %w", name, err))
+		}
+		delete(f.conns, name)
+	}
+	return errors.Join(errs...)
+}
+
+func (f *Factory) dial(name string, cfg ClientConfig) (*grpc.ClientConn, error) {
+	creds, err := transportCredentials(cfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("grpcclient: client %s: %w", name, err)
+	}
+	chain := &interceptors{
+		name:       name,
+		timeout:    cfg.Timeout,
+		metadata:   cfg.Metadata,
+		tracer:     f.options.tracerProvider.Tracer(tracerName),
+		propagator: f.options.propagator,
+		clock:      f.options.clock,
+		metrics:    f.metrics,
+	}
+	if !cfg.CircuitBreaker.Disabled {
+		chain.breaker = newBreaker(cfg.CircuitBreaker, f.options.clock, func(open bool) {
+			f.metrics.setCircuitOpen(name, open)
+		})
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(chain.unary),
+		grpc.WithChainStreamInterceptor(chain.stream),
+	}
+	if cfg.Retry.MaxAttempts > 1 {
+		dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(retryServiceConfig(cfg.Retry)))
+	}
+	dialOptions = append(dialOptions, f.options.dialOptions...)
+
+	conn, err := grpc.NewClient(cfg.Target, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("grpcclient: client %s: %w", name, err)
+	}
+	return conn, nil
+}
+
+// retryServiceConfig returns the service config applying the retry policy to every method.
+func retryServiceConfig(cfg RetryConfig) string {
+	// The config only holds numbers and validated code names, so marshaling cannot fail
+	data, _ := json.Marshal(map[string]any{
+		"methodConfig": []map[string]any{{
+			"name": []map[string]any{{}},
+			"retryPolicy": map[string]any{
+				"maxAttempts":          cfg.MaxAttempts,
+				"initialBackoff":       seconds(cfg.InitialBackoff),
+				"maxBackoff":           seconds(cfg.MaxBackoff),
+				"backoffMultiplier":    2,
+				"retryableStatusCodes": cfg.Codes,
+			},
+		}},
+	})
+	return string(data)
+}
+
+// seconds formats d as the service config expects durations, e.g. "0.1s".
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+func transportCredentials(cfg TLSConfig) (credentials.TransportCredentials, error) {
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // opt-in, for local environments
+	}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: read CA file: %w", ErrInvalidTLS, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificate in CA file %s", ErrInvalidTLS, cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: load client certificate: %w", ErrInvalidTLS, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -0,0 +1,357 @@
+package grpcclient_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	grpcclient "github.com/cristiano-pacheco/bricks/pkg/grpc/client"
+)
+
+// fakeServer answers every call with the next queued status code, OK once the queue is
+// empty, and records the metadata of the calls.
+type fakeServer struct {
+	mu       sync.Mutex
+	codes    []codes.Code
+	calls    int
+	metadata []metadata.MD
+}
+
+func (s *fakeServer) fail(codes ...codes.Code) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.codes = append(s.codes, codes...)
+}
+
+func (s *fakeServer) handle(_ any, stream grpc.ServerStream) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.mu.Lock()
+	s.calls++
+	s.metadata = append(s.metadata, md)
+	code := codes.OK
+	if len(s.codes) > 0 {
+		code, s.codes = s.codes[0], s.codes[1:]
+	}
+	s.mu.Unlock()
+
+	if err := stream.RecvMsg(&healthpb.HealthCheckRequest{}); err != nil {
+		return err
+	}
+	if code != codes.OK {
+		return status.Error(code, "failed")
+	}
+	return stream.SendMsg(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+}
+
+func (s *fakeServer) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func (s *fakeServer) lastMetadata() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.metadata[len(s.metadata)-1]
+}
+
+func startServer(t *testing.T) (*fakeServer, grpc.DialOption) {
+	t.Helper()
+	server := &fakeServer{}
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(grpc.UnknownServiceHandler(server.handle))
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	})
+	return server, dialer
+}
+
+func newFactory(
+	t *testing.T,
+	cfg grpcclient.ClientConfig,
+	opts ...grpcclient.Option,
+) (healthpb.HealthClient, *fakeServer, *prometheus.Registry) {
+	t.Helper()
+	server, dialer := startServer(t)
+	cfg.Target = "passthrough:///bufnet"
+	registry := prometheus.NewRegistry()
+	opts = append([]grpcclient.Option{grpcclient.WithRegisterer(registry), grpcclient.WithDialOptions(dialer)}, opts...)
+	factory, err := grpcclient.NewFactory(grpcclient.Config{Clients: map[string]grpcclient.ClientConfig{
+		"health": cfg,
+	}}, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = factory.Close() })
+	conn, err := factory.Conn("health")
+	require.NoError(t, err)
+	return healthpb.NewHealthClient(conn), server, registry
+}
+
+func check(client healthpb.HealthClient) error {
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestFactory_Conn_UnknownClient_ReturnsError(t *testing.T) {
+	// Arrange
+	factory, err := grpcclient.NewFactory(grpcclient.Config{}, grpcclient.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	// Act
+	conn, err := factory.Conn("orders")
+
+	// Assert
+	require.ErrorIs(t, err, grpcclient.ErrUnknownClient)
+	assert.Nil(t, conn)
+}
+
+func TestFactory_Conn_SameName_ReturnsSameConnection(t *testing.T) {
+	// Arrange
+	factory, err := grpcclient.NewFactory(grpcclient.Config{Clients: map[string]grpcclient.ClientConfig{
+		"orders": {Target: "localhost:9090"},
+	}}, grpcclient.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = factory.Close() })
+
+	// Act
+	first, err := factory.Conn("orders")
+	require.NoError(t, err)
+	second, err := factory.Conn("orders")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+}
+
+func TestFactory_Conn_MissingCAFile_ReturnsError(t *testing.T) {
+	// Arrange
+	factory, err := grpcclient.NewFactory(grpcclient.Config{Clients: map[string]grpcclient.ClientConfig{
+		"orders": {Target: "localhost:9090", TLS: grpcclient.TLSConfig{Enabled: true, CAFile: "missing.pem"}},
+	}}, grpcclient.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	// Act
+	_, err = factory.Conn("orders")
+
+	// Assert
+	require.ErrorIs(t, err, grpcclient.ErrInvalidTLS)
+}
+
+func TestClient_Call_Success_RecordsMetrics(t *testing.T) {
+	// Arrange
+	client, _, registry := newFactory(t, grpcclient.ClientConfig{})
+
+	// Act
+	err := check(client)
+
+	// Assert
+	require.NoError(t, err)
+	count, err := testutil.GatherAndCount(registry, "grpc_client_requests_total")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	labels := map[string]string{}
+	for _, family := range families {
+		if family.GetName() != "grpc_client_requests_total" {
+			continue
+		}
+		for _, label := range family.GetMetric()[0].GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"client": "health",
+		"method": "/grpc.health.v1.Health/Check",
+		"code":   "OK",
+	}, labels)
+}
+
+func TestClient_Call_Unavailable_Retries(t *testing.T) {
+	// Arrange
+	client, server, _ := newFactory(t, grpcclient.ClientConfig{
+		Retry: grpcclient.RetryConfig{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+	server.fail(codes.Unavailable, codes.Unavailable)
+
+	// Act
+	err := check(client)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, server.callCount())
+}
+
+func TestClient_Call_NotRetryableCode_DoesNotRetry(t *testing.T) {
+	// Arrange
+	client, server, _ := newFactory(t, grpcclient.ClientConfig{
+		Retry: grpcclient.RetryConfig{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+	server.fail(codes.NotFound)
+
+	// Act
+	err := check(client)
+
+	// Assert
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, 1, server.callCount())
+}
+
+func TestClient_Call_NoDeadline_AppliesTimeout(t *testing.T) {
+	// Arrange
+	var deadline atomic.Bool
+	probe := grpc.WithChainUnaryInterceptor(func(
+		ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		_, ok := ctx.Deadline()
+		deadline.Store(ok)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+	client, _, _ := newFactory(t, grpcclient.ClientConfig{Timeout: time.Minute}, grpcclient.WithDialOptions(probe))
+
+	// Act
+	err := check(client)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, deadline.Load())
+}
+
+func TestClient_Call_PropagatesMetadata(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	client, server, _ := newFactory(t,
+		grpcclient.ClientConfig{Metadata: map[string]string{"x-api-key": "secret"}},
+		grpcclient.WithTracerProvider(provider),
+		grpcclient.WithPropagator(propagation.TraceContext{}),
+	)
+	ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+	ctx = ctxmeta.WithCorrelationID(ctx, "corr-1")
+
+	// Act
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+
+	// Assert
+	require.NoError(t, err)
+	md := server.lastMetadata()
+	assert.Equal(t, []string{"secret"}, md.Get("x-api-key"))
+	assert.Equal(t, []string{"req-1"}, md.Get(ctxmeta.HeaderRequestID))
+	assert.Equal(t, []string{"corr-1"}, md.Get(ctxmeta.HeaderCorrelationID))
+	require.Len(t, md.Get("traceparent"), 1)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "grpc.health.v1.Health/Check", spans[0].Name)
+	assert.Contains(t, md.Get("traceparent")[0], spans[0].SpanContext.TraceID().String())
+}
+
+func TestClient_Call_ConsecutiveFailures_OpensCircuit(t *testing.T) {
+	// Arrange
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, server, registry := newFactory(t, grpcclient.ClientConfig{
+		Retry:          grpcclient.RetryConfig{MaxAttempts: 1},
+		CircuitBreaker: grpcclient.BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute},
+	}, grpcclient.WithClock(clk))
+	server.fail(codes.Internal, codes.Internal)
+	require.Error(t, check(client))
+	require.Error(t, check(client))
+
+	// Act
+	err := check(client)
+
+	// Assert
+	require.ErrorIs(t, err, grpcclient.ErrCircuitOpen)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 2, server.callCount())
+	assert.InDelta(t, 1, gaugeValue(t, registry), 0)
+}
+
+func TestClient_Call_OpenTimeoutPassed_ProbeClosesCircuit(t *testing.T) {
+	// Arrange
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, server, registry := newFactory(t, grpcclient.ClientConfig{
+		Retry:          grpcclient.RetryConfig{MaxAttempts: 1},
+		CircuitBreaker: grpcclient.BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute},
+	}, grpcclient.WithClock(clk))
+	server.fail(codes.Unavailable)
+	require.Error(t, check(client))
+	require.ErrorIs(t, check(client), grpcclient.ErrCircuitOpen)
+	clk.Advance(time.Minute)
+
+	// Act
+	err := check(client)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, check(client))
+	assert.Equal(t, 3, server.callCount())
+	assert.InDelta(t, 0, gaugeValue(t, registry), 0)
+}
+
+func TestClient_Call_ClientErrors_DoNotOpenCircuit(t *testing.T) {
+	// Arrange
+	client, server, _ := newFactory(t, grpcclient.ClientConfig{
+		CircuitBreaker: grpcclient.BreakerConfig{FailureThreshold: 1},
+	})
+	server.fail(codes.NotFound, codes.InvalidArgument)
+	require.Error(t, check(client))
+	require.Error(t, check(client))
+
+	// Act
+	err := check(client)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, server.callCount())
+}
+
+func TestClient_Call_BreakerDisabled_NeverOpens(t *testing.T) {
+	// Arrange
+	client, server, _ := newFactory(t, grpcclient.ClientConfig{
+		Retry:          grpcclient.RetryConfig{MaxAttempts: 1},
+		CircuitBreaker: grpcclient.BreakerConfig{Disabled: true, FailureThreshold: 1},
+	})
+	server.fail(codes.Internal, codes.Internal)
+	require.Error(t, check(client))
+	require.Error(t, check(client))
+
+	// Act
+	err := check(client)
+
+	// Assert
+	require.NoError(t, err)
+}
+
+func gaugeValue(t *testing.T, registry *prometheus.Registry) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == "grpc_client_circuit_open" {
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatal("grpc_client_circuit_open not found")
+	return 0
+}
@@ -0,0 +1,50 @@
+package grpcclient
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+// Module provides the *Factory of the gRPC clients configured under "app.grpc.client".
+// The connections are closed on application stop.
+//
+// Usage in your application:
+//
+//	fx.New(
+//	    grpcclient.Module,
+//	    fx.Provide(func(f *grpcclient.Factory) (orderspb.OrderServiceClient, error) {
+//	        conn, err := f.Conn("orders")
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        return orderspb.NewOrderServiceClient(conn), nil
+//	    }),
+//	)
+var Module = fx.Module("grpcclient",
+	config.Provide[Config]("app.grpc.client"),
+	fx.Provide(NewFactoryWithFx),
+)
+
+// Params for dependency injection
+type Params struct {
+	fx.In
+	Config  config.Config[Config]
+	Options []Option `optional:"true"`
+}
+
+// NewFactoryWithFx creates the *Factory and closes its connections on application stop.
+func NewFactoryWithFx(lc fx.Lifecycle, params Params) (*Factory, error) {
+	factory, err := NewFactory(params.Config.Get(), params.Options...)
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			return factory.Close()
+		},
+	})
+	return factory, nil
+}
@@ -0,0 +1,156 @@
+package grpcclient
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+const tracerName = "github.com/cristiano-pacheco/bricks/pkg/grpc/client"
+
+// interceptors run around the calls of a client: timeout, circuit breaker, tracing,
+// metadata propagation and metrics.
+type interceptors struct {
+	name       string
+	timeout    time.Duration
+	metadata   map[string]string
+	breaker    *breaker
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	clock      clock.Clock
+	metrics    *clientMetrics
+}
+
+func (i *interceptors) unary(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if i.breaker != nil && !i.breaker.allow() {
+		i.metrics.observe(i.name, method, codes.Unavailable, 0)
+		return ErrCircuitOpen
+	}
+	if i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+	ctx, span := i.startSpan(ctx, method)
+	defer span.End()
+
+	start := i.clock.Now()
+	err := invoker(i.outgoing(ctx), method, req, reply, cc, opts...)
+	code := status.Code(err)
+	i.metrics.observe(i.name, method, code, i.clock.Since(start))
+	if i.breaker != nil {
+		i.breaker.record(code)
+	}
+	endSpan(span, code, err)
+	return err
+}
+
+// stream applies the circuit breaker, tracing and metadata to the opening of a stream; the
+// timeout would bound the whole stream, so it is left to the caller.
+func (i *interceptors) stream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	if i.breaker != nil && !i.breaker.allow() {
+		i.metrics.observe(i.name, method, codes.Unavailable, 0)
+		return nil, ErrCircuitOpen
+	}
+	ctx, span := i.startSpan(ctx, method)
+	defer span.End()
+
+	start := i.clock.Now()
+	clientStream, err := streamer(i.outgoing(ctx), desc, cc, method, opts...)
+	code := status.Code(err)
+	i.metrics.observe(i.name, method, code, i.clock.Since(start))
+	if i.breaker != nil {
+		i.breaker.record(code)
+	}
+	endSpan(span, code, err)
+	return clientStream, err
+}
+
+func (i *interceptors) startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	service, rpc, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return i.tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", rpc),
+			attribute.String("grpc.client", i.name),
+		),
+	)
+}
+
+func endSpan(span trace.Span, code codes.Code, err error) {
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	}
+}
+
+// outgoing returns ctx with the configured metadata, the request and correlation IDs and
+// the trace context added to its outgoing metadata.
+func (i *interceptors) outgoing(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	for key, value := range i.metadata {
+		if len(md.Get(key)) == 0 {
+			md.Set(key, value)
+		}
+	}
+	carrier := metadataCarrier(md)
+	ctxmeta.Inject(ctx, carrier)
+	i.propagator.Inject(ctx, carrier)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// metadataCarrier adapts metadata.MD to ctxmeta.Carrier and propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+// Get returns the first value of key.
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set replaces the values of key.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns the keys of the metadata.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,73 @@
+package grpcclient
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	requestsMetricName    = "grpc_client_requests_total"
+	durationMetricName    = "grpc_client_request_duration_seconds"
+	circuitOpenMetricName = "grpc_client_circuit_open"
+)
+
+type clientMetrics struct {
+	requests    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	circuitOpen *prometheus.GaugeVec
+}
+
+func newClientMetrics(registerer prometheus.Registerer) (*clientMetrics, error) {
+	requests, err := metrics.Register(registerer, prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: requestsMetricName,
+			Help: "Total gRPC client calls by client, method and status code",
+		},
+		[]string{"client", "method", "code"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := metrics.Register(registerer, prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    durationMetricName,
+			Help:    "Duration of the gRPC client calls in seconds, retries included",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"client", "method"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	circuitOpen, err := metrics.Register(registerer, prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: circuitOpenMetricName,
+			Help: "Whether the circuit breaker of a gRPC client is open (1) or not (0)",
+		},
+		[]string{"client"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &clientMetrics{requests: requests, duration: duration, circuitOpen: circuitOpen}, nil
+}
+
+func (m *clientMetrics) observe(client, method string, code codes.Code, elapsed time.Duration) {
+	m.requests.WithLabelValues(client, method, code.String()).Inc()
+	m.duration.WithLabelValues(client, method).Observe(elapsed.Seconds())
+}
+
+func (m *clientMetrics) setCircuitOpen(client string, open bool) {
+	value := 0.0
+	if open {
+		value = 1
+	}
+	m.circuitOpen.WithLabelValues(client).Set(value)
+}
+
+// register registers collector, or returns the collector already registered under its
+// name, so several factories can share a registerer.
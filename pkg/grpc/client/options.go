@@ -0,0 +1,78 @@
+package grpcclient
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+type options struct {
+	registerer     prometheus.Registerer
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+	clock          clock.Clock
+	dialOptions    []grpc.DialOption
+}
+
+// Option configures the Factory created by NewFactory.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		registerer:     prometheus.DefaultRegisterer,
+		tracerProvider: otel.GetTracerProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+		clock:          clock.New(),
+	}
+}
+
+// WithRegisterer sets the registerer the call metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithTracerProvider sets the provider of the client spans. Defaults to the global one,
+// set by the otel/trace package.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		if provider != nil {
+			o.tracerProvider = provider
+		}
+	}
+}
+
+// WithPropagator sets the propagator writing the trace context into the call metadata.
+// Defaults to the global one.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		if propagator != nil {
+			o.propagator = propagator
+		}
+	}
+}
+
+// WithClock sets the clock of the circuit breakers.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithDialOptions adds dial options to every connection, after the ones of the config,
+// e.g. extra interceptors or a custom dialer.
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
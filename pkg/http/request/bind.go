@@ -0,0 +1,215 @@
+package request
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+)
+
+const (
+	pathTag   = "path"
+	queryTag  = "query"
+	headerTag = "header"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+type bindOptions struct {
+	validator validator.Validator
+}
+
+// BindOption configures Bind.
+type BindOption func(*bindOptions)
+
+// WithValidator validates dst with v after binding, passing the request context to the rules.
+func WithValidator(v validator.Validator) BindOption {
+	return func(o *bindOptions) {
+		o.validator = v
+	}
+}
+
+// Bind populates the fields of dst from path params, query string and headers
+// according to the `path`, `query` and `header` struct tags:
+//
+//	type ListOrdersRequest struct {
+//		CustomerID uint64   `path:"customer_id"`
+//		Page       int      `query:"page"`
+//		Status     []string `query:"status"`
+//		TenantID   string   `header:"X-Tenant"`
+//	}
+//
+// Strings, booleans, numbers, time.Duration, time.Time (RFC 3339), encoding.TextUnmarshaler,
+// pointers and slices of those are supported. Slices take every value of a repeated query
+// param or header. Missing or empty values leave the field untouched.
+// Conversion failures are returned as a BAD_REQUEST errs.Error.
+func Bind(r *http.Request, dst any, opts ...BindOption) error {
+	options := bindOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		// This indicates a programming error, not a client error
+		panic(fmt.Sprintf("request: Bind destination must be a non-nil pointer to a struct, got %T", dst))
+	}
+
+	if err := bindStruct(r, rv.Elem()); err != nil {
+		return err
+	}
+
+	if options.validator != nil {
+		return options.validator.ValidateCtx(r.Context(), dst)
+	}
+
+	return nil
+}
+
+func bindStruct(r *http.Request, rv reflect.Value) error {
+	rt := rv.Type()
+	query := r.URL.Query()
+
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && fv.Kind() == reflect.Struct {
+			if err := bindStruct(r, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		source, name, values := lookupValues(r, query, field)
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setField(fv, values); err != nil {
+			return invalidParam(source, name)
+		}
+	}
+
+	return nil
+}
+
+// lookupValues returns the non-empty values for the first binding tag set on the field.
+func lookupValues(r *http.Request, query map[string][]string, field reflect.StructField) (string, string, []string) {
+	if name, ok := field.Tag.Lookup(pathTag); ok {
+		return pathTag, name, nonEmpty([]string{pathValue(r, name)})
+	}
+
+	if name, ok := field.Tag.Lookup(queryTag); ok {
+		return queryTag, name, nonEmpty(query[name])
+	}
+
+	if name, ok := field.Tag.Lookup(headerTag); ok {
+		return headerTag, name, nonEmpty(r.Header.Values(name))
+	}
+
+	return "", "", nil
+}
+
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func setField(fv reflect.Value, values []string) error {
+	if fv.Kind() == reflect.Slice && !fv.Type().Implements(textUnmarshalerType) {
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+
+	return setValue(fv, values[0])
+}
+
+func setValue(fv reflect.Value, value string) error {
+	if fv.Kind() == reflect.Pointer {
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+		return nil
+	}
+
+	if fv.CanAddr() && fv.Addr().Type().Implements(textUnmarshalerType) {
+		unmarshaler, _ := fv.Addr().Interface().(encoding.TextUnmarshaler)
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+
+	switch fv.Type() {
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	case timeType:
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package request_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	lib_validator "github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/request"
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+type Pagination struct {
+	Page    int `query:"page"`
+	PerPage int `query:"per_page"`
+}
+
+type ListOrdersRequest struct {
+	Pagination
+	CustomerID uint64        `path:"customer_id"`
+	Status     []string      `query:"status"`
+	Since      *time.Time    `query:"since"`
+	Timeout    time.Duration `query:"timeout"`
+	Archived   bool          `query:"archived"`
+	MinTotal   float64       `query:"min_total"`
+	TenantID   string        `header:"X-Tenant"`
+	Ignored    string
+}
+
+func newRequestWithPathParams(target string, params map[string]string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestBind(t *testing.T) {
+	t.Run("Binds path, query and header values", func(t *testing.T) {
+		// Arrange
+		r := newRequestWithPathParams(
+			"/customers/42/orders?page=2&per_page=50&status=paid&status=shipped"+
+				"&since=2026-01-02T15:04:05Z&timeout=5s&archived=true&min_total=9.90",
+			map[string]string{"customer_id": "42"},
+		)
+		r.Header.Set("X-Tenant", "acme")
+
+		var dst ListOrdersRequest
+
+		// Act
+		err := request.Bind(r, &dst)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, uint64(42), dst.CustomerID)
+		assert.Equal(t, 2, dst.Page)
+		assert.Equal(t, 50, dst.PerPage)
+		assert.Equal(t, []string{"paid", "shipped"}, dst.Status)
+		require.NotNil(t, dst.Since)
+		assert.Equal(t, time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), *dst.Since)
+		assert.Equal(t, 5*time.Second, dst.Timeout)
+		assert.True(t, dst.Archived)
+		assert.InDelta(t, 9.90, dst.MinTotal, 0.001)
+		assert.Equal(t, "acme", dst.TenantID)
+	})
+
+	t.Run("Missing values keep the current field value", func(t *testing.T) {
+		// Arrange
+		r := httptest.NewRequest(http.MethodGet, "/orders?page=", nil)
+		dst := ListOrdersRequest{Pagination: Pagination{Page: 1}}
+
+		// Act
+		err := request.Bind(r, &dst)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, dst.Page)
+		assert.Nil(t, dst.Since)
+		assert.Nil(t, dst.Status)
+	})
+
+	t.Run("Falls back to standard library path values", func(t *testing.T) {
+		// Arrange
+		r := httptest.NewRequest(http.MethodGet, "/customers/7/orders", nil)
+		r.SetPathValue("customer_id", "7")
+
+		var dst ListOrdersRequest
+
+		// Act
+		err := request.Bind(r, &dst)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), dst.CustomerID)
+	})
+
+	t.Run("Invalid value returns bad request error", func(t *testing.T) {
+		// Arrange
+		r := httptest.NewRequest(http.MethodGet, "/orders?page=abc", nil)
+
+		var dst ListOrdersRequest
+
+		// Act
+		err := request.Bind(r, &dst)
+
+		// Assert
+		var reqErr *errs.Error
+		require.True(t, errors.As(err, &reqErr))
+		assert.Equal(t, http.StatusBadRequest, reqErr.Status)
+		assert.Equal(t, "BAD_REQUEST", reqErr.Code)
+		assert.Equal(t, `request contains invalid value for query parameter "page"`, reqErr.Message)
+	})
+
+	t.Run("Overflowing value returns bad request error", func(t *testing.T) {
+		// Arrange
+		r := newRequestWithPathParams("/customers/-1/orders", map[string]string{"customer_id": "-1"})
+
+		var dst ListOrdersRequest
+
+		// Act
+		err := request.Bind(r, &dst)
+
+		// Assert
+		var reqErr *errs.Error
+		require.True(t, errors.As(err, &reqErr))
+		assert.Equal(t, `request contains invalid value for path parameter "customer_id"`, reqErr.Message)
+	})
+
+	t.Run("Non-pointer destination panics", func(t *testing.T) {
+		// Arrange
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		// Act & Assert
+		assert.Panics(t, func() {
+			_ = request.Bind(r, ListOrdersRequest{})
+		})
+	})
+}
+
+func TestBind_WithValidator(t *testing.T) {
+	type GetCustomerRequest struct {
+		CustomerID uint64 `path:"customer_id" validate:"required"`
+		TenantID   string `header:"X-Tenant" validate:"required"`
+	}
+
+	t.Run("Returns validation errors after binding", func(t *testing.T) {
+		// Arrange
+		v, err := validator.New()
+		require.NoError(t, err)
+
+		r := newRequestWithPathParams("/customers/42", map[string]string{"customer_id": "42"})
+
+		var dst GetCustomerRequest
+
+		// Act
+		err = request.Bind(r, &dst, request.WithValidator(v))
+
+		// Assert
+		var validationErrs lib_validator.ValidationErrors
+		require.True(t, errors.As(err, &validationErrs))
+		require.Len(t, validationErrs, 1)
+		assert.Equal(t, "TenantID", validationErrs[0].Field())
+		assert.Equal(t, uint64(42), dst.CustomerID)
+	})
+
+	t.Run("Passes the request context to the validator", func(t *testing.T) {
+		// Arrange
+		v := mocks.NewMockValidator(t)
+		r := newRequestWithPathParams("/customers/42", map[string]string{"customer_id": "42"})
+		r.Header.Set("X-Tenant", "acme")
+
+		var dst GetCustomerRequest
+		v.EXPECT().ValidateCtx(r.Context(), mock.Anything).Return(nil)
+
+		// Act
+		err := request.Bind(r, &dst, request.WithValidator(v))
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,331 @@
+package request
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+const (
+	// DefaultMaxMultipartSize is 32MB - can be overridden with MultipartOptions.MaxBodySize
+	DefaultMaxMultipartSize = 32 << 20
+	// DefaultMaxMultipartMemory is 1MB - the total size of non-file form values kept in memory
+	DefaultMaxMultipartMemory = 1 << 20
+
+	// sniffLen is the number of bytes http.DetectContentType considers
+	sniffLen = 512
+)
+
+// MultipartOptions configures ReadMultipart. Zero values fall back to the defaults.
+type MultipartOptions struct {
+	// MaxBodySize limits the whole request body. Defaults to DefaultMaxMultipartSize.
+	MaxBodySize int64
+	// MaxMemory limits the total size of non-file form values. Defaults to DefaultMaxMultipartMemory.
+	MaxMemory int64
+	// MaxFileSize limits each file. Defaults to MaxBodySize.
+	MaxFileSize int64
+	// MaxValueSize limits each non-file form value. Defaults to MaxMemory.
+	MaxValueSize int64
+	// FieldLimits overrides MaxFileSize or MaxValueSize for specific form fields.
+	FieldLimits map[string]int64
+	// AllowedContentTypes lists the accepted sniffed file content types, e.g. "image/png" or "image/*".
+	// Every content type is accepted when empty.
+	AllowedContentTypes []string
+	// Destination returns the writer each file is streamed to.
+	// When nil, files are written to temporary files in TempDir.
+	Destination func(file UploadedFile) (io.Writer, error)
+	// TempDir is the directory for temporary files. Defaults to os.TempDir().
+	TempDir string
+}
+
+// MultipartForm is the result of ReadMultipart.
+type MultipartForm struct {
+	Values url.Values
+	Files  []UploadedFile
+}
+
+// UploadedFile describes a file read by ReadMultipart.
+type UploadedFile struct {
+	// Field is the form field name
+	Field string
+	// Filename is the client provided file name, without directories
+	Filename string
+	// ContentType is sniffed from the file content
+	ContentType string
+	// DeclaredContentType is the Content-Type sent by the client for the part
+	DeclaredContentType string
+	// Size is the number of bytes written
+	Size int64
+	// Path is the temporary file path, empty when a Destination is set
+	Path string
+}
+
+// File returns the first file uploaded for the field.
+func (f *MultipartForm) File(field string) (UploadedFile, bool) {
+	for _, file := range f.Files {
+		if file.Field == field {
+			return file, true
+		}
+	}
+	return UploadedFile{}, false
+}
+
+// Cleanup removes the temporary files created by ReadMultipart.
+func (f *MultipartForm) Cleanup() error {
+	var err error
+	for _, file := range f.Files {
+		if file.Path == "" {
+			continue
+		}
+		if removeErr := os.Remove(file.Path); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+			err = errors.Join(err, removeErr)
+		}
+	}
+	return err
+}
+
+// ReadMultipart reads a multipart/form-data request, streaming files to opts.Destination
+// or to temporary files. Enforces body, value and file size limits and checks the sniffed
+// content type of each file against opts.AllowedContentTypes.
+// Call Cleanup on the returned form to remove temporary files.
+func ReadMultipart(w http.ResponseWriter, r *http.Request, opts MultipartOptions) (*MultipartForm, error) {
+	opts = opts.withDefaults()
+
+	// Security: Validate Content-Type before reading the body
+	if err := validateMultipartContentType(r.Header.Get("Content-Type")); err != nil {
+		return nil, err
+	}
+	// Security: Limit request body size to prevent DoS attacks
+	r.Body = http.MaxBytesReader(w, r.Body, opts.MaxBodySize)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, errs.New("BAD_REQUEST", "request body contains malformed multipart data", http.StatusBadRequest, nil)
+	}
+
+	form := &MultipartForm{Values: url.Values{}}
+	if err := readParts(reader, form, opts); err != nil {
+		_ = form.Cleanup()
+		return nil, err
+	}
+
+	return form, nil
+}
+
+func (o MultipartOptions) withDefaults() MultipartOptions {
+	if o.MaxBodySize <= 0 {
+		o.MaxBodySize = DefaultMaxMultipartSize
+	}
+	if o.MaxMemory <= 0 {
+		o.MaxMemory = DefaultMaxMultipartMemory
+	}
+	if o.MaxFileSize <= 0 {
+		o.MaxFileSize = o.MaxBodySize
+	}
+	if o.MaxValueSize <= 0 {
+		o.MaxValueSize = o.MaxMemory
+	}
+	return o
+}
+
+func (o MultipartOptions) limitFor(field string, defaultLimit int64) int64 {
+	if limit, ok := o.FieldLimits[field]; ok && limit > 0 {
+		return limit
+	}
+	return defaultLimit
+}
+
+func validateMultipartContentType(contentType string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		return errs.New(
+			"UNSUPPORTED_MEDIA_TYPE",
+			"Content-Type header is not multipart/form-data",
+			http.StatusUnsupportedMediaType,
+			nil,
+		)
+	}
+	return nil
+}
+
+func readParts(reader *multipart.Reader, form *MultipartForm, opts MultipartOptions) error {
+	var valuesSize int64
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return parseMultipartError(err)
+		}
+
+		field := part.FormName()
+		if field == "" {
+			_ = part.Close()
+			continue
+		}
+
+		if part.FileName() == "" {
+			value, err := readValuePart(part, opts.limitFor(field, opts.MaxValueSize))
+			_ = part.Close()
+			if err != nil {
+				return err
+			}
+
+			valuesSize += int64(len(value))
+			if valuesSize > opts.MaxMemory {
+				return errs.New(
+					"REQUEST_ENTITY_TOO_LARGE",
+					fmt.Sprintf("form values must not exceed %d bytes", opts.MaxMemory),
+					http.StatusRequestEntityTooLarge,
+					nil,
+				)
+			}
+
+			form.Values.Add(field, value)
+			continue
+		}
+
+		file, err := readFilePart(part, opts)
+		_ = part.Close()
+		if err != nil {
+			if file.Path != "" {
+				_ = os.Remove(file.Path)
+			}
+			return err
+		}
+		form.Files = append(form.Files, file)
+	}
+}
+
+func readValuePart(part *multipart.Part, limit int64) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(part, limit+1))
+	if err != nil {
+		return "", parseMultipartError(err)
+	}
+	if int64(len(data)) > limit {
+		return "", fieldTooLargeError(part.FormName(), limit)
+	}
+	return string(data), nil
+}
+
+func readFilePart(part *multipart.Part, opts MultipartOptions) (UploadedFile, error) {
+	file := UploadedFile{
+		Field:               part.FormName(),
+		Filename:            part.FileName(),
+		DeclaredContentType: part.Header.Get("Content-Type"),
+	}
+
+	// Security: Trust the content, not the client provided Content-Type
+	buffered := bufio.NewReaderSize(part, sniffLen)
+	head, err := buffered.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return file, parseMultipartError(err)
+	}
+
+	file.ContentType = baseMediaType(http.DetectContentType(head))
+	if !isContentTypeAllowed(file.ContentType, opts.AllowedContentTypes) {
+		return file, errs.New(
+			"UNSUPPORTED_MEDIA_TYPE",
+			fmt.Sprintf("file %q has unsupported content type %s", file.Field, file.ContentType),
+			http.StatusUnsupportedMediaType,
+			nil,
+		)
+	}
+
+	dst, closeFn, err := openDestination(&file, opts)
+	if err != nil {
+		return file, err
+	}
+
+	limit := opts.limitFor(file.Field, opts.MaxFileSize)
+	file.Size, err = io.Copy(dst, io.LimitReader(buffered, limit+1))
+	closeErr := closeFn()
+	if err != nil {
+		return file, parseMultipartError(err)
+	}
+	if file.Size > limit {
+		return file, fieldTooLargeError(file.Field, limit)
+	}
+	if closeErr != nil {
+		return file, fmt.Errorf("close upload destination: %w", closeErr)
+	}
+
+	return file, nil
+}
+
+// openDestination returns the writer for the file and a function that releases it.
+func openDestination(file *UploadedFile, opts MultipartOptions) (io.Writer, func() error, error) {
+	if opts.Destination != nil {
+		dst, err := opts.Destination(*file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open upload destination: %w", err)
+		}
+		return dst, func() error { return nil }, nil
+	}
+
+	tmp, err := os.CreateTemp(opts.TempDir, "upload-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("create upload temp file: %w", err)
+	}
+	file.Path = tmp.Name()
+	return tmp, tmp.Close, nil
+}
+
+func isContentTypeAllowed(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, pattern := range allowed {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(pattern, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+func baseMediaType(contentType string) string {
+	if idx := strings.IndexByte(contentType, ';'); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	return strings.TrimSpace(contentType)
+}
+
+func fieldTooLargeError(field string, limit int64) error {
+	return errs.New(
+		"REQUEST_ENTITY_TOO_LARGE",
+		fmt.Sprintf("form field %q must not exceed %d bytes", field, limit),
+		http.StatusRequestEntityTooLarge,
+		nil,
+	)
+}
+
+func parseMultipartError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return errs.New(
+			"REQUEST_ENTITY_TOO_LARGE",
+			fmt.Sprintf("request body must not exceed %d bytes", maxBytesError.Limit),
+			http.StatusRequestEntityTooLarge,
+			nil,
+		)
+	}
+	// Security: Don't expose internal error details
+	return errs.New("BAD_REQUEST", "request body contains malformed multipart data", http.StatusBadRequest, nil)
+}
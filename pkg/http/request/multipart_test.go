@@ -0,0 +1,203 @@
+package request_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/request"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type multipartFile struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+func newMultipartRequest(t *testing.T, values map[string]string, files ...multipartFile) *http.Request {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for field, value := range values {
+		require.NoError(t, writer.WriteField(field, value))
+	}
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.field, file.filename)
+		require.NoError(t, err)
+		_, err = part.Write(file.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func requireRequestError(t *testing.T, err error, status int, message string) {
+	t.Helper()
+
+	var reqErr *errs.Error
+	require.True(t, errors.As(err, &reqErr), "error = %v", err)
+	assert.Equal(t, status, reqErr.Status)
+	assert.Equal(t, message, reqErr.Message)
+}
+
+func TestReadMultipart(t *testing.T) {
+	t.Run("Reads values and streams files to temp files", func(t *testing.T) {
+		// Arrange
+		r := newMultipartRequest(t,
+			map[string]string{"title": "avatar"},
+			multipartFile{field: "image", filename: "../avatar.png", content: pngHeader},
+		)
+		opts := request.MultipartOptions{TempDir: t.TempDir()}
+
+		// Act
+		form, err := request.ReadMultipart(httptest.NewRecorder(), r, opts)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "avatar", form.Values.Get("title"))
+
+		file, ok := form.File("image")
+		require.True(t, ok)
+		assert.Equal(t, "avatar.png", file.Filename)
+		assert.Equal(t, "image/png", file.ContentType)
+		assert.Equal(t, "application/octet-stream", file.DeclaredContentType)
+		assert.Equal(t, int64(len(pngHeader)), file.Size)
+
+		content, err := os.ReadFile(file.Path)
+		require.NoError(t, err)
+		assert.Equal(t, pngHeader, content)
+
+		require.NoError(t, form.Cleanup())
+		assert.NoFileExists(t, file.Path)
+	})
+
+	t.Run("Streams files to the provided destination", func(t *testing.T) {
+		// Arrange
+		r := newMultipartRequest(t, nil, multipartFile{field: "doc", filename: "notes.txt", content: []byte("hello")})
+
+		var buf bytes.Buffer
+		var seen request.UploadedFile
+		opts := request.MultipartOptions{
+			Destination: func(file request.UploadedFile) (io.Writer, error) {
+				seen = file
+				return &buf, nil
+			},
+		}
+
+		// Act
+		form, err := request.ReadMultipart(httptest.NewRecorder(), r, opts)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "hello", buf.String())
+		assert.Equal(t, "text/plain", seen.ContentType)
+		require.Len(t, form.Files, 1)
+		assert.Empty(t, form.Files[0].Path)
+		assert.Equal(t, int64(5), form.Files[0].Size)
+	})
+
+	t.Run("Rejects non multipart Content-Type", func(t *testing.T) {
+		// Arrange
+		r := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("{}"))
+		r.Header.Set("Content-Type", "application/json")
+
+		// Act
+		form, err := request.ReadMultipart(httptest.NewRecorder(), r, request.MultipartOptions{})
+
+		// Assert
+		assert.Nil(t, form)
+		requireRequestError(t, err, http.StatusUnsupportedMediaType, "Content-Type header is not multipart/form-data")
+	})
+
+	t.Run("Rejects files with disallowed sniffed content type", func(t *testing.T) {
+		// Arrange
+		r := newMultipartRequest(t, nil,
+			multipartFile{field: "image", filename: "fake.png", content: []byte("<html><body>hi</body></html>")},
+		)
+		opts := request.MultipartOptions{AllowedContentTypes: []string{"image/*"}, TempDir: t.TempDir()}
+
+		// Act
+		_, err := request.ReadMultipart(httptest.NewRecorder(), r, opts)
+
+		// Assert
+		requireRequestError(t, err, http.StatusUnsupportedMediaType, `file "image" has unsupported content type text/html`)
+		entries, readErr := os.ReadDir(opts.TempDir)
+		require.NoError(t, readErr)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Rejects files exceeding the per-field limit and removes temp files", func(t *testing.T) {
+		// Arrange
+		r := newMultipartRequest(t, nil,
+			multipartFile{field: "first", filename: "a.bin", content: bytes.Repeat([]byte("a"), 10)},
+			multipartFile{field: "avatar", filename: "b.bin", content: bytes.Repeat([]byte("b"), 100)},
+		)
+		opts := request.MultipartOptions{
+			FieldLimits: map[string]int64{"avatar": 64},
+			TempDir:     t.TempDir(),
+		}
+
+		// Act
+		_, err := request.ReadMultipart(httptest.NewRecorder(), r, opts)
+
+		// Assert
+		requireRequestError(t, err, http.StatusRequestEntityTooLarge, `form field "avatar" must not exceed 64 bytes`)
+		entries, readErr := os.ReadDir(opts.TempDir)
+		require.NoError(t, readErr)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Rejects values exceeding the value limit", func(t *testing.T) {
+		// Arrange
+		r := newMultipartRequest(t, map[string]string{"bio": strings.Repeat("x", 20)})
+		opts := request.MultipartOptions{MaxValueSize: 10}
+
+		// Act
+		_, err := request.ReadMultipart(httptest.NewRecorder(), r, opts)
+
+		// Assert
+		requireRequestError(t, err, http.StatusRequestEntityTooLarge, `form field "bio" must not exceed 10 bytes`)
+	})
+
+	t.Run("Rejects values exceeding the memory limit", func(t *testing.T) {
+		// Arrange
+		r := newMultipartRequest(t, map[string]string{"a": "12345678", "b": "12345678"})
+		opts := request.MultipartOptions{MaxMemory: 10}
+
+		// Act
+		_, err := request.ReadMultipart(httptest.NewRecorder(), r, opts)
+
+		// Assert
+		requireRequestError(t, err, http.StatusRequestEntityTooLarge, "form values must not exceed 10 bytes")
+	})
+
+	t.Run("Rejects bodies exceeding the body limit", func(t *testing.T) {
+		// Arrange
+		r := newMultipartRequest(t, nil,
+			multipartFile{field: "file", filename: "big.bin", content: bytes.Repeat([]byte("z"), 4096)},
+		)
+		opts := request.MultipartOptions{MaxBodySize: 1024, TempDir: t.TempDir()}
+
+		// Act
+		_, err := request.ReadMultipart(httptest.NewRecorder(), r, opts)
+
+		// Assert
+		requireRequestError(t, err, http.StatusRequestEntityTooLarge, "request body must not exceed 1024 bytes")
+	})
+}
@@ -0,0 +1,89 @@
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// Param returns the path param name, read from chi with a fallback to r.PathValue like
+// Bind. A missing or empty param returns errs.ErrRecordNotFound (404): the path designates
+// no resource.
+func Param(r *http.Request, name string) (string, error) {
+	value := pathValue(r, name)
+	if value == "" {
+		return "", errs.ErrRecordNotFound
+	}
+	return value, nil
+}
+
+// ParamInt returns the path param name as an int. A value that is not an integer returns
+// a BAD_REQUEST errs.Error (400), a missing param errs.ErrRecordNotFound (404).
+//
+//	id, err := request.ParamInt(r, "id")
+//	if err != nil {
+//		errorHandler.ErrorCtx(r.Context(), w, err)
+//		return
+//	}
+func ParamInt(r *http.Request, name string) (int, error) {
+	return parseParam(r, name, func(value string) (int, error) {
+		return strconv.Atoi(strings.TrimSpace(value))
+	})
+}
+
+// ParamInt64 returns the path param name as an int64, with the errors of ParamInt.
+func ParamInt64(r *http.Request, name string) (int64, error) {
+	return parseParam(r, name, func(value string) (int64, error) {
+		return strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	})
+}
+
+// ParamUint64 returns the path param name as a uint64, with the errors of ParamInt.
+// Negative values are invalid.
+func ParamUint64(r *http.Request, name string) (uint64, error) {
+	return parseParam(r, name, func(value string) (uint64, error) {
+		return strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	})
+}
+
+// ParamUUID returns the path param name as a UUID, with the errors of ParamInt.
+func ParamUUID(r *http.Request, name string) (uuid.UUID, error) {
+	return parseParam(r, name, uuid.Parse)
+}
+
+func parseParam[T any](r *http.Request, name string, parse func(string) (T, error)) (T, error) {
+	var zero T
+	value, err := Param(r, name)
+	if err != nil {
+		return zero, err
+	}
+	parsed, err := parse(value)
+	if err != nil {
+		return zero, invalidParam(pathTag, name)
+	}
+	return parsed, nil
+}
+
+// pathValue returns the path param name from chi, else from the net/http mux.
+func pathValue(r *http.Request, name string) string {
+	if value := chi.URLParam(r, name); value != "" {
+		return value
+	}
+	return r.PathValue(name)
+}
+
+// invalidParam returns the BAD_REQUEST error of an invalid path, query or header value.
+func invalidParam(source, name string) *errs.Error {
+	return errs.New(
+		"BAD_REQUEST",
+		fmt.Sprintf("request contains invalid value for %s parameter %q", source, name),
+		http.StatusBadRequest,
+		nil,
+	)
+}
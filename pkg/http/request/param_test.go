@@ -0,0 +1,122 @@
+package request_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/request"
+)
+
+func TestParamInt(t *testing.T) {
+	t.Run("Valid value returns the integer", func(t *testing.T) {
+		// Arrange
+		r := newRequestWithPathParams("/orders/42", map[string]string{"id": "42"})
+
+		// Act
+		id, err := request.ParamInt(r, "id")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 42, id)
+	})
+
+	t.Run("Invalid value returns bad request error", func(t *testing.T) {
+		// Arrange
+		r := newRequestWithPathParams("/orders/abc", map[string]string{"id": "abc"})
+
+		// Act
+		_, err := request.ParamInt(r, "id")
+
+		// Assert
+		var reqErr *errs.Error
+		require.True(t, errors.As(err, &reqErr))
+		assert.Equal(t, http.StatusBadRequest, reqErr.Status)
+		assert.Equal(t, "BAD_REQUEST", reqErr.Code)
+		assert.Equal(t, `request contains invalid value for path parameter "id"`, reqErr.Message)
+	})
+
+	t.Run("Missing param returns not found error", func(t *testing.T) {
+		// Arrange
+		r := newRequestWithPathParams("/orders", nil)
+
+		// Act
+		_, err := request.ParamInt(r, "id")
+
+		// Assert
+		require.ErrorIs(t, err, errs.ErrRecordNotFound)
+	})
+
+	t.Run("Falls back to the net/http path value", func(t *testing.T) {
+		// Arrange
+		r := httptest.NewRequest(http.MethodGet, "/orders/7", nil)
+		r.SetPathValue("id", "7")
+
+		// Act
+		id, err := request.ParamInt(r, "id")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 7, id)
+	})
+}
+
+func TestParamUint64_NegativeValue_ReturnsBadRequestError(t *testing.T) {
+	// Arrange
+	r := newRequestWithPathParams("/orders/-1", map[string]string{"id": "-1"})
+
+	// Act
+	_, err := request.ParamUint64(r, "id")
+
+	// Assert
+	var reqErr *errs.Error
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, http.StatusBadRequest, reqErr.Status)
+}
+
+func TestParamInt64_Overflow_ReturnsBadRequestError(t *testing.T) {
+	// Arrange
+	r := newRequestWithPathParams("/orders/1", map[string]string{"id": "9223372036854775808"})
+
+	// Act
+	_, err := request.ParamInt64(r, "id")
+
+	// Assert
+	var reqErr *errs.Error
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, http.StatusBadRequest, reqErr.Status)
+}
+
+func TestParamUUID(t *testing.T) {
+	t.Run("Valid value returns the UUID", func(t *testing.T) {
+		// Arrange
+		want := uuid.MustParse("0b8a2d8e-6c6a-4f5e-9a43-2a1f5f1f1c3d")
+		r := newRequestWithPathParams("/orders/"+want.String(), map[string]string{"id": want.String()})
+
+		// Act
+		id, err := request.ParamUUID(r, "id")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, want, id)
+	})
+
+	t.Run("Invalid value returns bad request error", func(t *testing.T) {
+		// Arrange
+		r := newRequestWithPathParams("/orders/42", map[string]string{"id": "42"})
+
+		// Act
+		_, err := request.ParamUUID(r, "id")
+
+		// Assert
+		var reqErr *errs.Error
+		require.True(t, errors.As(err, &reqErr))
+		assert.Equal(t, http.StatusBadRequest, reqErr.Status)
+	})
+}
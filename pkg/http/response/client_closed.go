@@ -0,0 +1,29 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"syscall"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// IsClientClosed reports whether err results from the client closing the request rather than
+// from a failure of the server:
+//
+//   - the request context ctx was canceled with the errs.ErrClientClosed cause, which the chi
+//     server sets when the client disconnects, and err is not an *errs.Error (see
+//     errs.IsClientClosed)
+//   - err is a broken pipe or a reset connection, from writing the response to a gone client
+//
+// A timed out ctx is not a client disconnect. These errors should not be logged or counted as
+// server errors: they are answered with errs.ErrRequestCanceled (499), read by nobody.
+func IsClientClosed(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	return errs.IsClientClosed(ctx, err)
+}
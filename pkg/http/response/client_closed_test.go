@@ -0,0 +1,51 @@
+package response_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+func TestIsClientClosed(t *testing.T) {
+	canceled, cancel := context.WithCancelCause(context.Background())
+	cancel(errs.ErrClientClosed)
+	canceledInternally, cancelInternally := context.WithCancel(context.Background())
+	cancelInternally()
+	timedOut, cancelTimeout := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelTimeout()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{name: "no error", ctx: canceled, err: nil, want: false},
+		{name: "canceled request", ctx: canceled, err: fmt.Errorf("find order: %w", context.Canceled), want: true},
+		{name: "driver error of a canceled request", ctx: canceled, err: errors.New("conn closed"), want: true},
+		{name: "domain error of a canceled request", ctx: canceled, err: errs.ErrRecordNotFound, want: false},
+		{name: "canceled internal call", ctx: context.Background(), err: context.Canceled, want: false},
+		{name: "canceled without a disconnect", ctx: canceledInternally, err: context.Canceled, want: false},
+		{name: "timed out request", ctx: timedOut, err: context.DeadlineExceeded, want: false},
+		{name: "broken pipe", ctx: context.Background(), err: fmt.Errorf("write: %w", syscall.EPIPE), want: true},
+		{name: "connection reset", ctx: context.Background(), err: fmt.Errorf("write: %w", syscall.ECONNRESET), want: true},
+		{name: "server error", ctx: context.Background(), err: errors.New("boom"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := response.IsClientClosed(tt.ctx, tt.err)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package response
+
+import (
+	"encoding/csv"
+	"iter"
+	"net/http"
+)
+
+// csvFlushRows is the number of rows after which a CSV export is flushed to the client.
+const csvFlushRows = 1000
+
+// CSVStream sends rows as the CSV attachment name, with header as the first line and each
+// row converted by record, streaming them as they come, so an export of millions of rows
+// never holds them in memory:
+//
+//	rows := repo.IterateOrders(ctx, filter) // iter.Seq2[Order, error]
+//	err := response.CSVStream(w, "orders.csv", []string{"id", "total"}, rows, func(o Order) []string {
+//	    return []string{o.ID, o.Total.String()}
+//	})
+//
+// The status is sent before the first row: an error of rows stops the export, leaving a
+// truncated file, and is only returned, e.g. to be logged.
+func CSVStream[T any](
+	w http.ResponseWriter,
+	name string,
+	header []string,
+	rows iter.Seq2[T, error],
+	record func(row T) []string,
+) error {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", contentDisposition(FileOptions{Name: name}))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	controller := http.NewResponseController(w)
+	if len(header) > 0 {
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+	}
+	written := 0
+	for row, err := range rows {
+		if err != nil {
+			writer.Flush()
+			return err
+		}
+		if err = writer.Write(record(row)); err != nil {
+			return err
+		}
+		written++
+		if written%csvFlushRows == 0 {
+			writer.Flush()
+			// Not every writer can flush, e.g. in tests; the rows are then sent at the end
+			_ = controller.Flush()
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
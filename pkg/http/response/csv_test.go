@@ -0,0 +1,71 @@
+package response_test
+
+import (
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+var errQuery = errors.New("query failed")
+
+type exportedOrder struct {
+	ID    string
+	Total int
+}
+
+func orderRows(orders []exportedOrder, failAfter int) iter.Seq2[exportedOrder, error] {
+	return func(yield func(exportedOrder, error) bool) {
+		for i, o := range orders {
+			if i == failAfter {
+				yield(exportedOrder{}, errQuery)
+				return
+			}
+			if !yield(o, nil) {
+				return
+			}
+		}
+	}
+}
+
+func orderRecord(o exportedOrder) []string {
+	return []string{o.ID, strconv.Itoa(o.Total)}
+}
+
+func TestCSVStream(t *testing.T) {
+	orders := []exportedOrder{{ID: "o-1", Total: 10}, {ID: "o-2, gift", Total: 25}}
+
+	t.Run("writes the header and the rows as a CSV attachment", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.CSVStream(rr, "orders.csv", []string{"id", "total"}, orderRows(orders, -1), orderRecord)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=orders.csv", rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,total\no-1,10\n\"o-2, gift\",25\n", rr.Body.String())
+	})
+
+	t.Run("stops at the error of the rows", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.CSVStream(rr, "orders.csv", []string{"id", "total"}, orderRows(orders, 1), orderRecord)
+
+		// Assert
+		require.ErrorIs(t, err, errQuery)
+		assert.Equal(t, "id,total\no-1,10\n", rr.Body.String())
+	})
+}
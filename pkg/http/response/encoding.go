@@ -0,0 +1,142 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledBufferSize is the capacity above which an encoding buffer is dropped rather than
+// pooled, so a single large response does not pin its memory.
+const maxPooledBufferSize = 64 << 10
+
+// Encoder writes JSON values to a stream. *json.Encoder implements it, and so do the
+// encoders of the API compatible libraries such as sonic and jsoniter.
+type Encoder interface {
+	Encode(v any) error
+	SetEscapeHTML(on bool)
+	SetIndent(prefix, indent string)
+}
+
+// EncoderFunc creates the Encoder writing the response bodies to w.
+type EncoderFunc func(w io.Writer) Encoder
+
+// Config configures the JSON encoding of every response of the package, see Configure.
+type Config struct {
+	// DisableHTMLEscape writes <, > and & as is rather than as \u003c, \u003e and \u0026,
+	// which only matters for JSON embedded in HTML
+	DisableHTMLEscape bool
+	// Indent indents the bodies with this string, e.g. "  " in debug mode; empty writes compact bodies
+	Indent string
+}
+
+// Option configures the responses set by Configure.
+type Option func(*settings)
+
+// WithEncoder encodes the responses with the encoders created by fn instead of
+// encoding/json, e.g. with sonic:
+//
+//	response.WithEncoder(func(w io.Writer) response.Encoder {
+//	    return sonic.ConfigStd.NewEncoder(w)
+//	})
+func WithEncoder(fn EncoderFunc) Option {
+	return func(s *settings) {
+		if fn != nil {
+			s.newEncoder = fn
+		}
+	}
+}
+
+// WithRenderer shapes the bodies with renderer instead of the {"data": ...} envelope,
+// see Renderer.
+func WithRenderer(renderer Renderer) Option {
+	return func(s *settings) {
+		if renderer != nil {
+			s.renderer = renderer
+		}
+	}
+}
+
+// settings are the encoding and the shape of the responses.
+type settings struct {
+	cfg        Config
+	newEncoder EncoderFunc
+	renderer   Renderer
+}
+
+var (
+	current atomic.Pointer[settings]
+
+	bufferPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+)
+
+func init() {
+	Configure(Config{})
+}
+
+// Configure sets the JSON encoding and the shape of every response of the package: the
+// helpers such as JSON and the ErrorHandler. Call it once at startup; the default is
+// encoding/json with HTML escaping and compact bodies, in the {"data": ...} envelope.
+//
+//	response.Configure(response.Config{DisableHTMLEscape: true})
+func Configure(cfg Config, opts ...Option) {
+	s := &settings{cfg: cfg, newEncoder: newStdEncoder, renderer: EnvelopeRenderer{}}
+	for _, opt := range opts {
+		opt(s)
+	}
+	current.Store(s)
+}
+
+func newStdEncoder(w io.Writer) Encoder {
+	return json.NewEncoder(w)
+}
+
+// newEncoder returns an Encoder of the current encoding writing to w.
+func newEncoder(w io.Writer) Encoder {
+	s := current.Load()
+	enc := s.newEncoder(w)
+	enc.SetEscapeHTML(!s.cfg.DisableHTMLEscape)
+	if s.cfg.Indent != "" {
+		enc.SetIndent("", s.cfg.Indent)
+	}
+	return enc
+}
+
+// renderer returns the current Renderer.
+func renderer() Renderer {
+	return current.Load().renderer
+}
+
+// encode encodes v into a pooled buffer, to be given back with releaseBuffer.
+func encode(v any) (*bytes.Buffer, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	if err := newEncoder(buf).Encode(v); err != nil {
+		releaseBuffer(buf)
+		return nil, err
+	}
+	// Encode ends the value with a newline, which json.Marshal does not
+	if n := buf.Len(); n > 0 && buf.Bytes()[n-1] == '\n' {
+		buf.Truncate(n - 1)
+	}
+	return buf, nil
+}
+
+// marshal returns the encoding of v.
+func marshal(v any) ([]byte, error) {
+	buf, err := encode(v)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseBuffer(buf)
+	return slices.Clone(buf.Bytes()), nil
+}
+
+func releaseBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBufferSize {
+		bufferPool.Put(buf)
+	}
+}
@@ -0,0 +1,127 @@
+package response_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+// countingEncoder is an encoding/json Encoder counting its calls, standing for a library
+// such as sonic.
+type countingEncoder struct {
+	*json.Encoder
+	calls *int
+}
+
+func (e countingEncoder) Encode(v any) error {
+	*e.calls++
+	return e.Encoder.Encode(v)
+}
+
+// configure sets the encoding for the test and restores the default after it.
+func configure(t *testing.T, cfg response.Config, opts ...response.Option) {
+	t.Helper()
+	response.Configure(cfg, opts...)
+	t.Cleanup(func() { response.Configure(response.Config{}) })
+}
+
+func TestConfigure(t *testing.T) {
+	payload := map[string]string{"html": "<b>&</b>"}
+
+	t.Run("escapes HTML and writes compact bodies by default", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.JSONRaw(rr, http.StatusOK, payload, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"html":"\u003cb\u003e\u0026\u003c/b\u003e"}`, rr.Body.String())
+		assert.Equal(t, "44", rr.Header().Get("Content-Length"))
+	})
+
+	t.Run("writes HTML as is with DisableHTMLEscape", func(t *testing.T) {
+		// Arrange
+		configure(t, response.Config{DisableHTMLEscape: true})
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.JSONRaw(rr, http.StatusOK, payload, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"html":"<b>&</b>"}`, rr.Body.String())
+	})
+
+	t.Run("indents the bodies with Indent", func(t *testing.T) {
+		// Arrange
+		configure(t, response.Config{DisableHTMLEscape: true, Indent: "  "})
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.JSON(rr, http.StatusOK, payload, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "{\n  \"data\": {\n    \"html\": \"<b>&</b>\"\n  }\n}", rr.Body.String())
+	})
+
+	t.Run("encodes the responses and the errors with the encoder of WithEncoder", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		configure(t, response.Config{}, response.WithEncoder(func(w io.Writer) response.Encoder {
+			return countingEncoder{Encoder: json.NewEncoder(w), calls: &calls}
+		}))
+		handler := response.NewErrorHandler(nil, nil)
+
+		// Act
+		err := response.JSON(httptest.NewRecorder(), http.StatusOK, payload, nil)
+		handler.Error(httptest.NewRecorder(), errs.ErrTimeout)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestJSONStream(t *testing.T) {
+	t.Run("writes the envelope without Content-Length", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+		headers := http.Header{"X-Export": []string{"orders"}}
+
+		// Act
+		err := response.JSONStream(rr, http.StatusOK, []int{1, 2, 3}, headers)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "orders", rr.Header().Get("X-Export"))
+		assert.Empty(t, rr.Header().Get("Content-Length"))
+		assert.JSONEq(t, `{"data":[1,2,3]}`, rr.Body.String())
+	})
+
+	t.Run("returns the encoding error after the status", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.JSONRawStream(rr, http.StatusOK, make(chan int), nil)
+
+		// Assert
+		var unsupported *json.UnsupportedTypeError
+		require.True(t, errors.As(err, &unsupported))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -1,45 +1,89 @@
 package response
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"reflect"
 	"regexp"
 	"strings"
 
 	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 	"github.com/cristiano-pacheco/bricks/pkg/validator"
 	lib_validator "github.com/go-playground/validator/v10"
+	"go.uber.org/fx"
 )
 
 var camelToSnakeRe = regexp.MustCompile("([a-z0-9])([A-Z])")
 
-var genericError = Envelope{
-	"error": map[string]string{
-		"code":    "internal_server_error",
-		"message": "Internal server error",
-	},
-}
+// redactedValue replaces the rejected values of the redacted fields.
+const redactedValue = "[REDACTED]"
+
+// defaultRedactedFields are the field name fragments whose rejected values are redacted.
+var defaultRedactedFields = []string{"password", "secret", "token", "api_key", "card_number", "cvv"}
+
+// genericError answers the errors that are not an errs.Error, without their message.
+var genericError = errs.New("internal_server_error", "Internal server error", http.StatusInternalServerError, nil)
 
 type ErrorHandler interface {
 	Error(w http.ResponseWriter, err error)
+	ErrorCtx(ctx context.Context, w http.ResponseWriter, err error)
+}
+
+// ValidationTranslator translates a validation error of the given (snake_case) field into the locale
+// resolved from ctx. It returns false when no translation exists, so the validator message is used.
+type ValidationTranslator interface {
+	TranslateValidationError(ctx context.Context, field string, fieldErr lib_validator.FieldError) (string, bool)
 }
 
 type ErrorHandlerImpl struct {
-	validate validator.Validator
-	logger   logger.Logger
+	validate             validator.Validator
+	logger               logger.Logger
+	validationTranslator ValidationTranslator
+	redactedFields       []string
 }
 
 func NewErrorHandler(validate validator.Validator, log logger.Logger) *ErrorHandlerImpl {
 	return &ErrorHandlerImpl{
-		validate: validate,
-		logger:   log,
+		validate:       validate,
+		logger:         log,
+		redactedFields: defaultRedactedFields,
 	}
 }
 
+// NewErrorHandlerParams contains dependencies for creating an error handler with FX.
+type NewErrorHandlerParams struct {
+	fx.In
+	Validator validator.Validator
+	Logger    logger.Logger
+	// ValidationTranslator translates validation messages when provided (e.g. by i18n.Module).
+	ValidationTranslator ports.ValidationTranslatorService `optional:"true"`
+}
+
+// NewErrorHandlerWithParams creates an error handler from FX dependencies.
+func NewErrorHandlerWithParams(params NewErrorHandlerParams) *ErrorHandlerImpl {
+	handler := NewErrorHandler(params.Validator, params.Logger)
+	handler.SetValidationTranslator(params.ValidationTranslator)
+	return handler
+}
+
+// SetValidationTranslator sets the translator used for validation error messages. A nil translator
+// keeps the validator's English messages.
+func (h *ErrorHandlerImpl) SetValidationTranslator(translator ValidationTranslator) {
+	h.validationTranslator = translator
+}
+
+// SetRedactedFields sets the field name fragments (matched against the snake_case field name)
+// whose rejected values are replaced by [REDACTED] in validation error details. The default is
+// password, secret, token, api_key, card_number and cvv.
+func (h *ErrorHandlerImpl) SetRedactedFields(fields []string) {
+	h.redactedFields = fields
+}
+
 func (h *ErrorHandlerImpl) logError(msg string, err error) {
 	if h.logger != nil {
 		h.logger.Error(msg, logger.Error(err))
@@ -48,20 +92,39 @@ func (h *ErrorHandlerImpl) logError(msg string, err error) {
 	}
 }
 
+// WriteError writes err in the errs envelope, with the status of its errs counterpart and
+// 500 with a generic body otherwise, as an ErrorHandler without validator and logger does.
+// It is the default error writer of the middlewares taking an ErrorHandler option.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	NewErrorHandler(nil, nil).ErrorCtx(r.Context(), w, err)
+}
+
 func (h *ErrorHandlerImpl) Error(w http.ResponseWriter, err error) {
+	h.ErrorCtx(context.Background(), w, err)
+}
+
+// ErrorCtx writes the error like Error, translating validation messages into the locale resolved
+// from ctx (usually r.Context()) when a ValidationTranslator is set. The errors of a client
+// that closed the request (see IsClientClosed) are answered with errs.ErrRequestCanceled
+// (499) instead of 500, and the failure to write to the gone client is not logged.
+func (h *ErrorHandlerImpl) ErrorCtx(ctx context.Context, w http.ResponseWriter, err error) {
+	if IsClientClosed(ctx, err) {
+		h.writeClientClosed(ctx, w)
+		return
+	}
+
 	var validationErrors lib_validator.ValidationErrors
 	if errors.As(err, &validationErrors) {
 		details := make([]errs.Detail, 0, len(validationErrors))
 		for _, e := range validationErrors {
-			var msg string
-			if h.validate != nil {
-				msg = e.Translate(h.validate.Translator())
-			} else {
-				msg = fmt.Sprintf("%s: %s", camelToSnake(e.Field()), e.Tag())
-			}
+			field := camelToSnake(e.Field())
 			details = append(details, errs.Detail{
-				Field:   camelToSnake(e.Field()),
-				Message: msg,
+				Field:   field,
+				Path:    jsonPath(e.Namespace()),
+				Message: h.validationMessage(ctx, field, e),
+				Value:   h.rejectedValue(field, e),
+				Rule:    e.Tag(),
+				Params:  strings.Fields(e.Param()),
 			})
 		}
 
@@ -72,14 +135,14 @@ func (h *ErrorHandlerImpl) Error(w http.ResponseWriter, err error) {
 			details,
 		)
 
-		h.writeResponse(w, validationError.Status, Envelope{"error": validationError})
+		h.writeResponse(w, validationError.Status, validationError.WithRequestID(ctx))
 		return
 	}
 
-	rError := &errs.Error{}
-	ok := errors.As(err, &rError)
+	// errs.Error, or the errs counterpart of infrastructure errors such as gorm.ErrRecordNotFound
+	rError, ok := errs.Map(err)
 	if !ok {
-		h.writeResponse(w, http.StatusInternalServerError, genericError)
+		h.writeResponse(w, http.StatusInternalServerError, genericError.WithRequestID(ctx))
 		return
 	}
 
@@ -87,17 +150,42 @@ func (h *ErrorHandlerImpl) Error(w http.ResponseWriter, err error) {
 		rError.Status = http.StatusInternalServerError
 	}
 
-	h.writeResponse(w, rError.Status, Envelope{"error": rError})
+	h.writeResponse(w, rError.Status, rError.WithRequestID(ctx))
 }
 
-func (h *ErrorHandlerImpl) writeResponse(w http.ResponseWriter, status int, payload Envelope) {
-	body, err := json.Marshal(payload)
+// writeClientClosed answers a client that closed the request. The client is usually gone,
+// so the write errors are expected and ignored.
+func (h *ErrorHandlerImpl) writeClientClosed(ctx context.Context, w http.ResponseWriter) {
+	r := renderer()
+	body, _ := marshal(r.RenderError(errs.ErrRequestCanceled.WithRequestID(ctx)))
+	w.Header().Set("Content-Type", r.ContentType())
+	w.WriteHeader(errs.ErrRequestCanceled.Status)
+	_, _ = w.Write(body)
+}
+
+func (h *ErrorHandlerImpl) validationMessage(ctx context.Context, field string, e lib_validator.FieldError) string {
+	if h.validationTranslator != nil {
+		if msg, ok := h.validationTranslator.TranslateValidationError(ctx, field, e); ok {
+			return msg
+		}
+	}
+
+	if h.validate != nil {
+		return e.Translate(h.validate.Translator())
+	}
+
+	return fmt.Sprintf("%s: %s", field, e.Tag())
+}
+
+func (h *ErrorHandlerImpl) writeResponse(w http.ResponseWriter, status int, rError *errs.Error) {
+	r := renderer()
+	body, err := marshal(r.RenderError(rError))
 	if err != nil {
 		h.logError("failed to marshal error response", err)
 		h.writeGenericError(w)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", r.ContentType())
 	w.WriteHeader(status)
 	if _, err = w.Write(body); err != nil {
 		h.logError("failed to write error response", err)
@@ -105,18 +193,57 @@ func (h *ErrorHandlerImpl) writeResponse(w http.ResponseWriter, status int, payl
 }
 
 func (h *ErrorHandlerImpl) writeGenericError(w http.ResponseWriter) {
-	body, err := json.Marshal(genericError)
+	r := renderer()
+	body, err := marshal(r.RenderError(genericError))
 	if err != nil {
 		h.logError("failed to marshal generic error response", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", r.ContentType())
 	w.WriteHeader(http.StatusInternalServerError)
 	if _, err = w.Write(body); err != nil {
 		h.logError("failed to write generic error response", err)
 	}
 }
 
+// rejectedValue returns the scalar value rejected for field, redacted for the sensitive
+// fields. Structs, slices and maps are left out of the details.
+func (h *ErrorHandlerImpl) rejectedValue(field string, e lib_validator.FieldError) any {
+	switch reflect.ValueOf(e.Value()).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+	default:
+		return nil
+	}
+	for _, redacted := range h.redactedFields {
+		if strings.Contains(field, redacted) {
+			return redactedValue
+		}
+	}
+	return e.Value()
+}
+
+// jsonPath turns the validator namespace of a field (e.g. Order.Items[2].UnitPrice) into its
+// snake_case path in the payload, without the root struct (items[2].unit_price).
+func jsonPath(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return camelToSnake(namespace)
+	}
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		// Only the field name is converted, not the index or map key
+		name, index, _ := strings.Cut(segment, "[")
+		segments[i] = camelToSnake(name)
+		if index != "" {
+			segments[i] += "[" + index
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
 func camelToSnake(s string) string {
 	snake := camelToSnakeRe.ReplaceAllString(s, "${1}_${2}")
 	return strings.ToLower(snake)
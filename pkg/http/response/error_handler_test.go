@@ -1,17 +1,25 @@
 package response_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
 	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	_ "github.com/cristiano-pacheco/bricks/pkg/errs/errsgorm"
 	"github.com/cristiano-pacheco/bricks/pkg/http/response"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 	"github.com/cristiano-pacheco/bricks/pkg/validator"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 )
 
 type ErrorHandlerTestSuite struct {
@@ -169,3 +177,206 @@ func (s *ErrorHandlerTestSuite) TestError_ValidationErrors_WithNilValidator_Retu
 	s.Equal("email", firstDetail["field"])
 	s.Contains(firstDetail["message"], "email")
 }
+
+func (s *ErrorHandlerTestSuite) TestErrorCtx_ValidationErrors_UsesValidationTranslator() {
+	// Arrange
+	type invalidStruct struct {
+		FirstName string `validate:"required"`
+		Email     string `validate:"required"`
+	}
+	valErr := s.v.Validate(&invalidStruct{})
+	s.Require().Error(valErr)
+
+	ctx := context.Background()
+	translatorMock := mocks.NewMockValidationTranslator(s.T())
+	translatorMock.EXPECT().TranslateValidationError(ctx, "first_name", mock.Anything).
+		Return("first_name é obrigatório", true)
+	translatorMock.EXPECT().TranslateValidationError(ctx, "email", mock.Anything).Return("", false)
+	handler := response.NewErrorHandler(s.v, s.log)
+	handler.SetValidationTranslator(translatorMock)
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.ErrorCtx(ctx, rr, valErr)
+
+	// Assert
+	s.Equal(http.StatusUnprocessableEntity, rr.Code)
+	details, ok := s.parseError(rr)["details"].([]interface{})
+	s.Require().True(ok)
+	s.Require().Len(details, 2)
+	s.Equal("first_name é obrigatório", details[0].(map[string]interface{})["message"])
+	s.Equal("Email is a required field", details[1].(map[string]interface{})["message"])
+}
+
+func (s *ErrorHandlerTestSuite) TestNewErrorHandlerWithParams_ValidationTranslatorService_TranslatesMessages() {
+	// Arrange
+	type invalidStruct struct {
+		Email string `validate:"required"`
+	}
+	valErr := s.v.Validate(&invalidStruct{})
+	s.Require().Error(valErr)
+
+	ctx := context.Background()
+	translatorMock := mocks.NewMockValidationTranslatorService(s.T())
+	translatorMock.EXPECT().TranslateValidationError(ctx, "email", mock.Anything).Return("email é obrigatório", true)
+	handler := response.NewErrorHandlerWithParams(response.NewErrorHandlerParams{
+		Validator:            s.v,
+		Logger:               s.log,
+		ValidationTranslator: translatorMock,
+	})
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.ErrorCtx(ctx, rr, valErr)
+
+	// Assert
+	details, ok := s.parseError(rr)["details"].([]interface{})
+	s.Require().True(ok)
+	s.Require().Len(details, 1)
+	s.Equal("email é obrigatório", details[0].(map[string]interface{})["message"])
+}
+
+func (s *ErrorHandlerTestSuite) TestErrorCtx_WithRequestID_IncludesRequestID() {
+	// Arrange
+	ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+	rErr := errs.New("bad_request", "bad", http.StatusBadRequest, nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	s.sut.ErrorCtx(ctx, rr, rErr)
+
+	// Assert
+	s.Equal(http.StatusBadRequest, rr.Code)
+	s.Equal("req-1", s.parseError(rr)["request_id"])
+	s.Empty(rErr.RequestID, "the returned error is not modified")
+}
+
+func (s *ErrorHandlerTestSuite) TestErrorCtx_NonTypedErrorWithRequestID_IncludesRequestID() {
+	// Arrange
+	ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+	rr := httptest.NewRecorder()
+
+	// Act
+	s.sut.ErrorCtx(ctx, rr, errors.New("boom"))
+
+	// Assert
+	s.Equal(http.StatusInternalServerError, rr.Code)
+	body := s.parseError(rr)
+	s.Equal("internal_server_error", body["code"])
+	s.Equal("req-1", body["request_id"])
+}
+
+func (s *ErrorHandlerTestSuite) TestErrorCtx_InfrastructureError_UsesErrsCounterpart() {
+	// Arrange
+	rr := httptest.NewRecorder()
+
+	// Act
+	s.sut.ErrorCtx(context.Background(), rr, fmt.Errorf("find order: %w", gorm.ErrRecordNotFound))
+
+	// Assert
+	s.Equal(http.StatusNotFound, rr.Code)
+	s.Equal("RECORD_NOT_FOUND", s.parseError(rr)["code"])
+}
+
+func (s *ErrorHandlerTestSuite) TestErrorCtx_ClientClosedRequest_ReturnsRequestCanceled() {
+	// Arrange
+	ctx, cancel := context.WithCancelCause(ctxmeta.WithRequestID(context.Background(), "req-1"))
+	cancel(errs.ErrClientClosed)
+	rr := httptest.NewRecorder()
+
+	// Act
+	s.sut.ErrorCtx(ctx, rr, errors.New("conn closed"))
+
+	// Assert
+	s.Equal(errs.StatusClientClosedRequest, rr.Code)
+	body := s.parseError(rr)
+	s.Equal("REQUEST_CANCELED", body["code"])
+	s.Equal("req-1", body["request_id"])
+}
+
+func (s *ErrorHandlerTestSuite) TestError_ValidationErrors_DetailsPathValueAndRule() {
+	// Arrange
+	type item struct {
+		UnitPrice int `validate:"min=1"`
+	}
+	type order struct {
+		Items    []item `validate:"dive"`
+		Status   string `validate:"oneof=draft paid"`
+		Password string `validate:"min=12"`
+	}
+	valErr := s.v.Validate(&order{
+		Items:    []item{{UnitPrice: 5}, {UnitPrice: 0}},
+		Status:   "lost",
+		Password: "short",
+	})
+	s.Require().Error(valErr)
+	rr := httptest.NewRecorder()
+
+	// Act
+	s.sut.Error(rr, valErr)
+
+	// Assert
+	var body struct {
+		Error errs.Error `json:"error"`
+	}
+	s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &body))
+	s.Require().Len(body.Error.Details, 3)
+	price := body.Error.Details[0]
+	s.Equal("unit_price", price.Field)
+	s.Equal("items[1].unit_price", price.Path)
+	s.InDelta(0, price.Value, 0)
+	s.Equal("min", price.Rule)
+	s.Equal([]string{"1"}, price.Params)
+	status := body.Error.Details[1]
+	s.Equal("status", status.Path)
+	s.Equal("lost", status.Value)
+	s.Equal([]string{"draft", "paid"}, status.Params)
+	s.Equal("[REDACTED]", body.Error.Details[2].Value)
+}
+
+func (s *ErrorHandlerTestSuite) TestError_ValidationErrors_SetRedactedFields() {
+	// Arrange
+	type profile struct {
+		Document string `validate:"len=11"`
+	}
+	valErr := s.v.Validate(&profile{Document: "123"})
+	s.Require().Error(valErr)
+	handler := response.NewErrorHandler(s.v, s.log)
+	handler.SetRedactedFields([]string{"document"})
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.Error(rr, valErr)
+
+	// Assert
+	details := s.parseError(rr)["details"].([]interface{})
+	s.Equal("[REDACTED]", details[0].(map[string]interface{})["value"])
+}
+
+func TestWriteError_WritesTheErrsEnvelope(t *testing.T) {
+	// Arrange
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req = req.WithContext(ctxmeta.WithRequestID(req.Context(), "req-1"))
+
+	// Act
+	response.WriteError(rr, req, fmt.Errorf("authorize: %w", errs.Forbidden("FORBIDDEN", "Forbidden")))
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":{"code":"FORBIDDEN","message":"Forbidden","request_id":"req-1"}}`, rr.Body.String())
+}
+
+func TestWriteError_UntypedError_WritesTheGenericError(t *testing.T) {
+	// Arrange
+	rr := httptest.NewRecorder()
+
+	// Act
+	response.WriteError(rr, httptest.NewRequest(http.MethodGet, "/orders", nil), errors.New("redis down"))
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "redis down")
+	assert.Contains(t, rr.Body.String(), "internal_server_error")
+}
@@ -0,0 +1,120 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// JSONWithETag writes data like JSON with a strong ETag computed from the body.
+// For GET and HEAD requests whose If-None-Match matches the ETag it writes 304 Not Modified without a body.
+func JSONWithETag[T any](w http.ResponseWriter, r *http.Request, status int, data T) error {
+	return jsonWithETag(w, r, status, data, false)
+}
+
+// JSONWithWeakETag is like JSONWithETag but sends a weak ETag, for representations
+// that are semantically equivalent but not byte-for-byte identical (e.g. compressed by a proxy).
+func JSONWithWeakETag[T any](w http.ResponseWriter, r *http.Request, status int, data T) error {
+	return jsonWithETag(w, r, status, data, true)
+}
+
+func jsonWithETag[T any](w http.ResponseWriter, r *http.Request, status int, data T, weak bool) error {
+	rend := renderer()
+	body, err := marshal(rend.Render(Document{Data: data}))
+	if err != nil {
+		return err
+	}
+
+	etag := ETag(body)
+	if weak {
+		etag = WeakETag(body)
+	}
+	w.Header().Set("ETag", etag)
+
+	if (r.Method == http.MethodGet || r.Method == http.MethodHead) && NoneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", rend.ContentType())
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
+
+// ETag returns a strong ETag for body, e.g. "9f86d081884c7d65".
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// WeakETag returns a weak ETag for body, e.g. W/"9f86d081884c7d65".
+func WeakETag(body []byte) string {
+	return "W/" + ETag(body)
+}
+
+// NoneMatch reports whether the If-None-Match header of r matches etag, using weak comparison.
+func NoneMatch(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, candidate := range splitETags(header) {
+		if candidate == "*" || opaqueTag(candidate) == opaqueTag(etag) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIfMatch validates the If-Match precondition of a mutation against the current ETag
+// of the resource, using strong comparison. It returns errs.ErrPreconditionFailed when the
+// header is present and does not match; a missing header is accepted.
+func CheckIfMatch(r *http.Request, currentETag string) error {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil
+	}
+	for _, candidate := range splitETags(header) {
+		if candidate == "*" && currentETag != "" {
+			return nil
+		}
+		if !isWeak(candidate) && !isWeak(currentETag) && candidate == currentETag {
+			return nil
+		}
+	}
+	return errs.ErrPreconditionFailed
+}
+
+// RequireIfMatch is like CheckIfMatch but returns errs.ErrPreconditionRequired when the
+// If-Match header is missing, preventing lost updates from clients that skip the precondition.
+func RequireIfMatch(r *http.Request, currentETag string) error {
+	if r.Header.Get("If-Match") == "" {
+		return errs.ErrPreconditionRequired
+	}
+	return CheckIfMatch(r, currentETag)
+}
+
+func splitETags(header string) []string {
+	parts := strings.Split(header, ",")
+	etags := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			etags = append(etags, part)
+		}
+	}
+	return etags
+}
+
+func isWeak(etag string) bool {
+	return strings.HasPrefix(etag, "W/")
+}
+
+func opaqueTag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
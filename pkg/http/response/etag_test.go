@@ -0,0 +1,126 @@
+package response_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONWithETag_SetsETag(t *testing.T) {
+	// Arrange
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+
+	// Act
+	err := response.JSONWithETag(rr, r, http.StatusOK, map[string]string{"id": "1"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, response.ETag(rr.Body.Bytes()), rr.Header().Get("ETag"))
+	require.NotEmpty(t, rr.Body.String())
+}
+
+func TestJSONWithETag_IfNoneMatch(t *testing.T) {
+	data := map[string]string{"id": "1"}
+
+	first := httptest.NewRecorder()
+	require.NoError(t, response.JSONWithETag(first, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusOK, data))
+	etag := first.Header().Get("ETag")
+
+	tests := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "matching etag returns not modified", method: http.MethodGet, ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+		{name: "weak match returns not modified", method: http.MethodGet, ifNoneMatch: "W/" + etag, wantStatus: http.StatusNotModified},
+		{name: "etag in list returns not modified", method: http.MethodHead, ifNoneMatch: `"other", ` + etag, wantStatus: http.StatusNotModified},
+		{name: "wildcard returns not modified", method: http.MethodGet, ifNoneMatch: "*", wantStatus: http.StatusNotModified},
+		{name: "different etag returns body", method: http.MethodGet, ifNoneMatch: `"other"`, wantStatus: http.StatusOK},
+		{name: "unsafe method ignores if-none-match", method: http.MethodPut, ifNoneMatch: etag, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			rr := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, "/", nil)
+			r.Header.Set("If-None-Match", tt.ifNoneMatch)
+
+			// Act
+			err := response.JSONWithETag(rr, r, http.StatusOK, data)
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, rr.Code)
+			require.Equal(t, etag, rr.Header().Get("ETag"))
+			if tt.wantStatus == http.StatusNotModified {
+				require.Empty(t, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestJSONWithWeakETag_SetsWeakETag(t *testing.T) {
+	// Arrange
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act
+	err := response.JSONWithWeakETag(rr, r, http.StatusOK, []int{1, 2})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, response.WeakETag(rr.Body.Bytes()), rr.Header().Get("ETag"))
+	require.Contains(t, rr.Header().Get("ETag"), `W/"`)
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	current := response.ETag([]byte("v1"))
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		wantErr error
+	}{
+		{name: "missing header is accepted", ifMatch: ""},
+		{name: "matching etag is accepted", ifMatch: current},
+		{name: "wildcard is accepted", ifMatch: "*"},
+		{name: "etag in list is accepted", ifMatch: `"old", ` + current},
+		{name: "stale etag fails", ifMatch: response.ETag([]byte("v0")), wantErr: errs.ErrPreconditionFailed},
+		{name: "weak etag fails strong comparison", ifMatch: "W/" + current, wantErr: errs.ErrPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			r := httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			// Act
+			err := response.CheckIfMatch(r, current)
+
+			// Assert
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRequireIfMatch_MissingHeader(t *testing.T) {
+	// Arrange
+	r := httptest.NewRequest(http.MethodPatch, "/", nil)
+
+	// Act
+	err := response.RequireIfMatch(r, response.ETag([]byte("v1")))
+
+	// Assert
+	require.ErrorIs(t, err, errs.ErrPreconditionRequired)
+}
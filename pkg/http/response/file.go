@@ -0,0 +1,169 @@
+package response
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// sniffLen is the number of bytes http.DetectContentType looks at.
+const sniffLen = 512
+
+// FileOptions configures File and FileReader.
+type FileOptions struct {
+	// Name is the file name sent in Content-Disposition, whose extension also gives the MIME
+	// type; File defaults it to the base name of the path
+	Name string
+	// Inline lets the browser display the file rather than download it
+	Inline bool
+	// ContentType overrides the MIME type detected from the name, then from the content
+	ContentType string
+	// ModTime answers If-Modified-Since with 304; File defaults it to the file modification time
+	ModTime time.Time
+	// BytesPerSecond throttles the download, so a few large downloads do not saturate the
+	// network of the instance; 0 does not throttle
+	BytesPerSecond int64
+}
+
+// File sends the file at path as a download, with range requests support (resumed and
+// partial downloads), MIME detection and conditional requests. A missing file returns
+// errs.ErrRecordNotFound; once the response started, errors are not returned.
+//
+//	err := response.File(w, r, report.Path, response.FileOptions{Name: "report-2026-10.pdf"})
+func File(w http.ResponseWriter, r *http.Request, path string, opts FileOptions) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return errs.ErrRecordNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return errs.ErrRecordNotFound
+	}
+	if opts.Name == "" {
+		opts.Name = filepath.Base(path)
+	}
+	if opts.ModTime.IsZero() {
+		opts.ModTime = info.ModTime()
+	}
+	return FileReader(w, r, f, opts)
+}
+
+// FileReader sends content as a download like File. Range requests are only supported
+// when content is an io.ReadSeeker, e.g. an *os.File or a *bytes.Reader; other readers,
+// e.g. an object storage body, are streamed whole with chunked encoding.
+func FileReader(w http.ResponseWriter, r *http.Request, content io.Reader, opts FileOptions) error {
+	header := w.Header()
+	header.Set("Content-Disposition", contentDisposition(opts))
+	if opts.ContentType != "" {
+		header.Set("Content-Type", opts.ContentType)
+	}
+	if opts.BytesPerSecond > 0 {
+		w = &throttledWriter{ResponseWriter: w, ctx: r.Context(), bytesPerSecond: opts.BytesPerSecond}
+	}
+
+	if seeker, ok := content.(io.ReadSeeker); ok {
+		// ServeContent handles Range, If-Range, If-Modified-Since and the MIME detection
+		http.ServeContent(w, r, opts.Name, opts.ModTime, seeker)
+		return nil
+	}
+
+	if header.Get("Content-Type") == "" {
+		buffered := bufio.NewReaderSize(content, sniffLen)
+		header.Set("Content-Type", detectContentType(opts.Name, buffered))
+		content = buffered
+	}
+	if !opts.ModTime.IsZero() {
+		header.Set("Last-Modified", opts.ModTime.UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return nil
+	}
+	_, err := io.Copy(w, content)
+	return err
+}
+
+// contentDisposition returns the Content-Disposition of opts, encoding a non-ASCII name as
+// RFC 2231 requires.
+func contentDisposition(opts FileOptions) string {
+	disposition := "attachment"
+	if opts.Inline {
+		disposition = "inline"
+	}
+	if opts.Name == "" {
+		return disposition
+	}
+	return mime.FormatMediaType(disposition, map[string]string{"filename": opts.Name})
+}
+
+// detectContentType returns the MIME type of the extension of name, or else of the first
+// bytes of content.
+func detectContentType(name string, content *bufio.Reader) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType
+	}
+	head, _ := content.Peek(sniffLen)
+	return http.DetectContentType(head)
+}
+
+// throttledWriter writes at most bytesPerSecond, in chunks of a tenth of a second.
+type throttledWriter struct {
+	http.ResponseWriter
+	ctx            context.Context
+	bytesPerSecond int64
+	start          time.Time
+	written        int64
+}
+
+func (t *throttledWriter) Write(p []byte) (int, error) {
+	if t.start.IsZero() {
+		t.start = time.Now()
+	}
+	chunkSize := max(int(t.bytesPerSecond/10), 1)
+	total := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), chunkSize)]
+		n, err := t.ResponseWriter.Write(chunk)
+		total += n
+		t.written += int64(n)
+		if err != nil {
+			return total, err
+		}
+		p = p[n:]
+
+		// Wait until the bytes written so far fit the rate
+		due := t.start.Add(time.Duration(float64(t.written) / float64(t.bytesPerSecond) * float64(time.Second)))
+		if wait := time.Until(due); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-t.ctx.Done():
+				timer.Stop()
+				return total, t.ctx.Err()
+			}
+		}
+	}
+	return total, nil
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (t *throttledWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
@@ -0,0 +1,124 @@
+package response_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestFile(t *testing.T) {
+	t.Run("sends the file as an attachment with the MIME type of its extension", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "orders.json", `{"orders":[]}`)
+		rr := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/exports/1", nil)
+
+		// Act
+		err := response.File(rr, r, path, response.FileOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `attachment; filename=orders.json`, rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.NotEmpty(t, rr.Header().Get("Last-Modified"))
+		assert.JSONEq(t, `{"orders":[]}`, rr.Body.String())
+	})
+
+	t.Run("answers a range request with the partial content", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "report.txt", "0123456789")
+		rr := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/reports/1", nil)
+		r.Header.Set("Range", "bytes=2-5")
+
+		// Act
+		err := response.File(rr, r, path, response.FileOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusPartialContent, rr.Code)
+		assert.Equal(t, "bytes 2-5/10", rr.Header().Get("Content-Range"))
+		assert.Equal(t, "2345", rr.Body.String())
+	})
+
+	t.Run("encodes a non-ASCII name and shows the file inline", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "report.pdf", "%PDF-1.7")
+		rr := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/reports/1", nil)
+
+		// Act
+		err := response.File(rr, r, path, response.FileOptions{Name: "relatório.pdf", Inline: true})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `inline; filename*=utf-8''relat%C3%B3rio.pdf`, rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+	})
+
+	t.Run("returns ErrRecordNotFound for a missing file", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/reports/1", nil)
+
+		// Act
+		err := response.File(rr, r, filepath.Join(t.TempDir(), "missing.pdf"), response.FileOptions{})
+
+		// Assert
+		require.ErrorIs(t, err, errs.ErrRecordNotFound)
+		assert.Empty(t, rr.Header().Get("Content-Disposition"))
+	})
+}
+
+func TestFileReader(t *testing.T) {
+	t.Run("streams a reader whose type is sniffed from its content", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/exports/1", nil)
+		content := strings.NewReader("<html><body>report</body></html>")
+
+		// Act
+		err := response.FileReader(rr, r, struct{ *strings.Reader }{content}, response.FileOptions{Name: "report"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "<html><body>report</body></html>", rr.Body.String())
+	})
+
+	t.Run("throttles the download to the bytes per second", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/exports/1", nil)
+		content := bytes.NewReader(bytes.Repeat([]byte("a"), 200))
+		start := time.Now()
+
+		// Act
+		err := response.FileReader(rr, r, content, response.FileOptions{Name: "a.txt", BytesPerSecond: 2000})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 200, rr.Body.Len())
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+}
@@ -6,7 +6,7 @@ var Module = fx.Module(
 	"response",
 	fx.Provide(
 		fx.Annotate(
-			NewErrorHandler,
+			NewErrorHandlerWithParams,
 			fx.As(new(ErrorHandler)),
 		),
 	),
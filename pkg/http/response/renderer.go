@@ -0,0 +1,145 @@
+package response
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// Renderer shapes the bodies of the responses: the data of JSON, JSONStream, JSONDocument
+// and the ETag helpers, and the errors of the ErrorHandler. JSONRaw is never shaped. Set
+// it with Configure and WithRenderer to keep the contract of an existing API.
+type Renderer interface {
+	// ContentType returns the Content-Type of the bodies.
+	ContentType() string
+	// Render returns the body of doc.
+	Render(doc Document) any
+	// RenderError returns the body of err.
+	RenderError(err *errs.Error) any
+}
+
+// Document is the data of a response with its optional top-level members.
+type Document struct {
+	Data any
+	// Meta holds non-resource information, e.g. a paginator.Metadata
+	Meta any
+	// Links holds related URLs, e.g. "self" and "next"
+	Links map[string]string
+}
+
+// EnvelopeRenderer is the default Renderer: {"data": ..., "meta": ..., "links": ...}, meta
+// and links being left out when empty, and {"error": {...}}.
+type EnvelopeRenderer struct{}
+
+// ContentType implements Renderer.
+func (EnvelopeRenderer) ContentType() string {
+	return "application/json"
+}
+
+// Render implements Renderer.
+func (EnvelopeRenderer) Render(doc Document) any {
+	return documentMembers(doc)
+}
+
+// RenderError implements Renderer.
+func (EnvelopeRenderer) RenderError(err *errs.Error) any {
+	return Envelope{"error": err}
+}
+
+// RawRenderer writes the data and the errors without wrapper. Meta and links have no place
+// in a raw body and are dropped: send them as headers, e.g. Link and X-Total-Count.
+type RawRenderer struct{}
+
+// ContentType implements Renderer.
+func (RawRenderer) ContentType() string {
+	return "application/json"
+}
+
+// Render implements Renderer.
+func (RawRenderer) Render(doc Document) any {
+	return doc.Data
+}
+
+// RenderError implements Renderer.
+func (RawRenderer) RenderError(err *errs.Error) any {
+	return err
+}
+
+// JSONAPIRenderer writes JSON:API documents (https://jsonapi.org): the data, meta and
+// links members, and an errors array with one error per detail, pointing at the invalid
+// attribute. The data must already be resource objects with their type, id and attributes.
+type JSONAPIRenderer struct{}
+
+// jsonAPIError is an error object of JSON:API.
+type jsonAPIError struct {
+	Status string            `json:"status"`
+	Code   string            `json:"code"`
+	Title  string            `json:"title"`
+	Detail string            `json:"detail,omitempty"`
+	Source *jsonAPISource    `json:"source,omitempty"`
+	Meta   map[string]string `json:"meta,omitempty"`
+}
+
+type jsonAPISource struct {
+	Pointer string `json:"pointer"`
+}
+
+// ContentType implements Renderer.
+func (JSONAPIRenderer) ContentType() string {
+	return "application/vnd.api+json"
+}
+
+// Render implements Renderer.
+func (JSONAPIRenderer) Render(doc Document) any {
+	return documentMembers(doc)
+}
+
+// RenderError implements Renderer.
+func (JSONAPIRenderer) RenderError(err *errs.Error) any {
+	status := err.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	base := jsonAPIError{Status: strconv.Itoa(status), Code: err.Code, Title: err.Message}
+	if err.RequestID != "" {
+		base.Meta = map[string]string{"request_id": err.RequestID}
+	}
+	if len(err.Details) == 0 {
+		return Envelope{"errors": []jsonAPIError{base}}
+	}
+	errors := make([]jsonAPIError, len(err.Details))
+	for i, detail := range err.Details {
+		errors[i] = base
+		errors[i].Detail = detail.Message
+		if path := detailPath(detail); path != "" {
+			errors[i].Source = &jsonAPISource{Pointer: "/data/attributes/" + jsonPointer(path)}
+		}
+	}
+	return Envelope{"errors": errors}
+}
+
+func documentMembers(doc Document) Envelope {
+	envelope := Envelope{"data": doc.Data}
+	if doc.Meta != nil {
+		envelope["meta"] = doc.Meta
+	}
+	if len(doc.Links) > 0 {
+		envelope["links"] = doc.Links
+	}
+	return envelope
+}
+
+func detailPath(detail errs.Detail) string {
+	if detail.Path != "" {
+		return detail.Path
+	}
+	return detail.Field
+}
+
+// jsonPointer turns the path of a detail (items[2].price) into a JSON pointer (items/2/price).
+func jsonPointer(path string) string {
+	path = strings.NewReplacer("~", "~0", "/", "~1").Replace(path)
+	return strings.NewReplacer("[", "/", "]", "", ".", "/").Replace(path)
+}
@@ -0,0 +1,144 @@
+package response_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+type order struct {
+	ID string `json:"id"`
+}
+
+var invalidOrder = errs.New("INVALID_ARGUMENT", "request has invalid fields", http.StatusUnprocessableEntity,
+	[]errs.Detail{{Field: "price", Path: "items[2].price", Message: "price must be 1 or greater"}})
+
+func TestJSONDocument(t *testing.T) {
+	doc := response.Document{
+		Data:  []order{{ID: "o-1"}},
+		Meta:  map[string]int{"total_count": 41},
+		Links: map[string]string{"next": "/orders?page=2"},
+	}
+
+	t.Run("writes the meta and links members in the envelope", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.JSONDocument(rr, http.StatusOK, doc, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t,
+			`{"data":[{"id":"o-1"}],"meta":{"total_count":41},"links":{"next":"/orders?page=2"}}`,
+			rr.Body.String())
+	})
+
+	t.Run("leaves the empty members out", func(t *testing.T) {
+		// Arrange
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.JSONDocument(rr, http.StatusOK, response.Document{Data: order{ID: "o-1"}}, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data":{"id":"o-1"}}`, rr.Body.String())
+	})
+
+	t.Run("writes the data alone with the RawRenderer", func(t *testing.T) {
+		// Arrange
+		configure(t, response.Config{}, response.WithRenderer(response.RawRenderer{}))
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.JSONDocument(rr, http.StatusOK, doc, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"id":"o-1"}]`, rr.Body.String())
+	})
+
+	t.Run("writes a JSON:API document with the JSONAPIRenderer", func(t *testing.T) {
+		// Arrange
+		configure(t, response.Config{}, response.WithRenderer(response.JSONAPIRenderer{}))
+		rr := httptest.NewRecorder()
+
+		// Act
+		err := response.JSONDocument(rr, http.StatusOK, doc, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "application/vnd.api+json", rr.Header().Get("Content-Type"))
+		assert.JSONEq(t,
+			`{"data":[{"id":"o-1"}],"meta":{"total_count":41},"links":{"next":"/orders?page=2"}}`,
+			rr.Body.String())
+	})
+}
+
+func TestRenderer_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer response.Renderer
+		want     string
+	}{
+		{
+			name:     "envelope",
+			renderer: response.EnvelopeRenderer{},
+			want: `{"error":{"code":"INVALID_ARGUMENT","message":"request has invalid fields",` +
+				`"details":[{"field":"price","path":"items[2].price","message":"price must be 1 or greater"}]}}`,
+		},
+		{
+			name:     "raw",
+			renderer: response.RawRenderer{},
+			want: `{"code":"INVALID_ARGUMENT","message":"request has invalid fields",` +
+				`"details":[{"field":"price","path":"items[2].price","message":"price must be 1 or greater"}]}`,
+		},
+		{
+			name:     "JSON:API",
+			renderer: response.JSONAPIRenderer{},
+			want: `{"errors":[{"status":"422","code":"INVALID_ARGUMENT","title":"request has invalid fields",` +
+				`"detail":"price must be 1 or greater","source":{"pointer":"/data/attributes/items/2/price"}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			configure(t, response.Config{}, response.WithRenderer(tt.renderer))
+			rr := httptest.NewRecorder()
+
+			// Act
+			response.NewErrorHandler(nil, nil).Error(rr, invalidOrder)
+
+			// Assert
+			assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			assert.Equal(t, tt.renderer.ContentType(), rr.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.want, rr.Body.String())
+		})
+	}
+}
+
+func TestJSONAPIRenderer_RenderError(t *testing.T) {
+	t.Run("writes one error with the request ID for an error without details", func(t *testing.T) {
+		// Arrange
+		configure(t, response.Config{}, response.WithRenderer(response.JSONAPIRenderer{}))
+		ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+		rr := httptest.NewRecorder()
+
+		// Act
+		response.NewErrorHandler(nil, nil).ErrorCtx(ctx, rr, errs.ErrRecordNotFound)
+
+		// Assert
+		assert.JSONEq(t,
+			`{"errors":[{"status":"404","code":"RECORD_NOT_FOUND","title":"Record not found","meta":{"request_id":"req-1"}}]}`,
+			rr.Body.String())
+	})
+}
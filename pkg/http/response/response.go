@@ -1,28 +1,23 @@
 package response
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 )
 
-func JSON[T any](w http.ResponseWriter, status int, data T, headers http.Header) error {
-	body, err := json.Marshal(NewEnvelope(data))
-	if err != nil {
-		return err
-	}
+const contentTypeJSON = "application/json"
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
-	for key, values := range headers {
-		for _, value := range values {
-			w.Header().Add(key, value)
-		}
-	}
+func JSON[T any](w http.ResponseWriter, status int, data T, headers http.Header) error {
+	return JSONDocument(w, status, Document{Data: data}, headers)
+}
 
-	w.WriteHeader(status)
-	_, err = w.Write(body)
-	return err
+// JSONDocument writes doc shaped by the configured Renderer, e.g. with the pagination in
+// its meta member:
+//
+//	response.JSONDocument(w, http.StatusOK, response.Document{Data: orders, Meta: pagination}, nil)
+func JSONDocument(w http.ResponseWriter, status int, doc Document, headers http.Header) error {
+	r := renderer()
+	return writeJSON(w, status, r.Render(doc), r.ContentType(), headers)
 }
 
 func NoContent(w http.ResponseWriter) {
@@ -30,20 +25,50 @@ func NoContent(w http.ResponseWriter) {
 }
 
 func JSONRaw[T any](w http.ResponseWriter, status int, data T, headers http.Header) error {
-	body, err := json.Marshal(data)
+	return writeJSON(w, status, data, contentTypeJSON, headers)
+}
+
+// JSONStream writes data like JSON, encoding it straight to w instead of a buffer, so a
+// large payload is never held twice in memory. The body has no Content-Length, and an
+// encoding error is only returned: the status is already sent.
+func JSONStream[T any](w http.ResponseWriter, status int, data T, headers http.Header) error {
+	r := renderer()
+	return streamJSON(w, status, r.Render(Document{Data: data}), r.ContentType(), headers)
+}
+
+// JSONRawStream writes data like JSONRaw, encoding it straight to w (see JSONStream).
+func JSONRawStream[T any](w http.ResponseWriter, status int, data T, headers http.Header) error {
+	return streamJSON(w, status, data, contentTypeJSON, headers)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any, contentType string, headers http.Header) error {
+	body, err := encode(data)
 	if err != nil {
 		return err
 	}
+	defer releaseBuffer(body)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	addHeaders(w, headers)
+
+	w.WriteHeader(status)
+	_, err = w.Write(body.Bytes())
+	return err
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+func streamJSON(w http.ResponseWriter, status int, data any, contentType string, headers http.Header) error {
+	w.Header().Set("Content-Type", contentType)
+	addHeaders(w, headers)
+
+	w.WriteHeader(status)
+	return newEncoder(w).Encode(data)
+}
+
+func addHeaders(w http.ResponseWriter, headers http.Header) {
 	for key, values := range headers {
 		for _, value := range values {
 			w.Header().Add(key, value)
 		}
 	}
-
-	w.WriteHeader(status)
-	_, err = w.Write(body)
-	return err
 }
@@ -0,0 +1,210 @@
+package chi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"expvar"
+	"log/slog"
+	"net/http"
+	"net/http/pprof"
+	"net/url"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/redact"
+)
+
+// configRedactKeys are the key fragments redacted in the config dump on top of the ones of
+// pkg/redact (password, passwd, secret, token, authorization, cookie, api_key, apikey,
+// credential, private_key).
+var configRedactKeys = []string{"pass", "dsn", "privatekey", "accesskey", "access_key", "signingkey", "signing_key"}
+
+// AdminConfig configures the admin endpoints of the metrics server: pprof, expvar, GC,
+// log level, config dump, maintenance mode and flight recorder, behind a bearer token.
+type AdminConfig struct {
+	Enabled bool
+	// Token authenticates the admin requests (Authorization: Bearer <token>), required when
+	// enabled; read it from the environment, e.g. env://ADMIN_TOKEN
+	Token string
+	// RedactKeys are key fragments redacted in the config dump on top of the defaults: pass,
+	// secret, token, authorization, cookie, apikey, api_key, privatekey, private_key,
+	// accesskey, access_key, signingkey, signing_key, dsn and credential
+	RedactKeys []string
+}
+
+// Validate validates the admin configuration.
+func (c *AdminConfig) Validate() error {
+	if c.Enabled && c.Token == "" {
+		return ErrMissingAdminToken
+	}
+	return nil
+}
+
+// LogLevelController reads and changes the log level at runtime, e.g. *logger.ZapLogger.
+type LogLevelController interface {
+	Level() string
+	SetLevel(level string) error
+}
+
+// admin serves the admin endpoints.
+type admin struct {
+	token    []byte
+	redactor *redact.Redactor
+	levels   LogLevelController
+	logger   *slog.Logger
+	// configOptions apply to the load of the config dump
+	configOptions []config.Option
+}
+
+func newAdmin(cfg *AdminConfig, logger *slog.Logger) *admin {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	redactor := redact.New(redact.WithKeys(append(slices.Clone(configRedactKeys), cfg.RedactKeys...)...))
+	return &admin{token: []byte(cfg.Token), redactor: redactor, logger: logger}
+}
+
+// SetLogLevelController sets the controller of the /debug/loglevel admin endpoint. With FX,
+// the logger.Logger provided by logger.Module is used.
+func (s *Server) SetLogLevelController(levels LogLevelController) {
+	if s.admin != nil {
+		s.admin.levels = levels
+	}
+}
+
+// routes registers the admin endpoints on the metrics router, with the maintenance mode
+// switch and the flight recorder. pprof serves the named profiles under /debug/pprof/ only, so the endpoints live
+// under /debug.
+func (a *admin) routes(router chi.Router, maintenance, recorder http.Handler) {
+	router.Group(func(r chi.Router) {
+		r.Use(a.authenticate)
+		r.Handle(maintenancePath, maintenance)
+		r.Handle(recorderPath, recorder)
+		r.HandleFunc("/debug/pprof/*", pprof.Index)
+		r.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		r.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		r.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		r.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		r.Handle("/debug/vars", expvar.Handler())
+		r.Post("/debug/gc", a.gc)
+		r.Get("/debug/loglevel", a.logLevel)
+		r.Put("/debug/loglevel", a.setLogLevel)
+		r.Get("/debug/config", a.configDump)
+	})
+}
+
+func (a *admin) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), a.token) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// gc runs a garbage collection and returns the freed memory to the OS.
+func (a *admin) gc(w http.ResponseWriter, r *http.Request) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	debug.FreeOSMemory()
+	runtime.ReadMemStats(&after)
+	a.logger.InfoContext(r.Context(), "garbage collection triggered by admin",
+		"heap_alloc_before", before.HeapAlloc, "heap_alloc_after", after.HeapAlloc)
+	writeAdminJSON(w, http.StatusOK, map[string]uint64{
+		"heap_alloc_before": before.HeapAlloc,
+		"heap_alloc_after":  after.HeapAlloc,
+		"heap_released":     after.HeapReleased,
+	})
+}
+
+func (a *admin) logLevel(w http.ResponseWriter, _ *http.Request) {
+	if a.levels == nil {
+		http.Error(w, "no log level controller", http.StatusNotImplemented)
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, map[string]string{"level": a.levels.Level()})
+}
+
+func (a *admin) setLogLevel(w http.ResponseWriter, r *http.Request) {
+	if a.levels == nil {
+		http.Error(w, "no log level controller", http.StatusNotImplemented)
+		return
+	}
+	var body struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	previous := a.levels.Level()
+	if err := a.levels.SetLevel(body.Level); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.logger.InfoContext(r.Context(), "log level changed by admin", "from", previous, "to", body.Level)
+	writeAdminJSON(w, http.StatusOK, map[string]string{"level": a.levels.Level()})
+}
+
+// configDump returns the flattened config keys, as resolved from the config files and the
+// environment, with the secrets redacted.
+func (a *admin) configDump(w http.ResponseWriter, r *http.Request) {
+	loaded, err := config.New[map[string]any](a.configOptions...)
+	if err != nil {
+		a.logger.ErrorContext(r.Context(), "failed to load the config dump", "err", err)
+		http.Error(w, "failed to load the config", http.StatusInternalServerError)
+		return
+	}
+	keys := loaded.Keys()
+	dump := make(map[string]any, len(keys))
+	for _, key := range keys {
+		value, _ := loaded.Lookup(key)
+		dump[key] = stripUserinfo(value)
+	}
+	writeAdminJSON(w, http.StatusOK, a.redactor.Redact(dump))
+}
+
+// stripUserinfo removes the user and the password of the URLs of value, e.g. of a database,
+// in the lists and maps too.
+func stripUserinfo(value any) any {
+	switch typed := value.(type) {
+	case string:
+		if !strings.Contains(typed, "://") {
+			return typed
+		}
+		u, err := url.Parse(typed)
+		if err != nil || u.User == nil {
+			return typed
+		}
+		u.User = nil
+		return u.String()
+	case []any:
+		stripped := make([]any, len(typed))
+		for i, item := range typed {
+			stripped[i] = stripUserinfo(item)
+		}
+		return stripped
+	case map[string]any:
+		stripped := make(map[string]any, len(typed))
+		for key, item := range typed {
+			stripped[key] = stripUserinfo(item)
+		}
+		return stripped
+	default:
+		return value
+	}
+}
+
+func writeAdminJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,29 @@
+package chi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// markClientClosed cancels the request context with the errs.ErrClientClosed cause when
+// net/http cancels it while the request is served, i.e. when the client disconnects, so
+// errs.IsClientClosed tells the disconnects apart from the other cancellations.
+func markClientClosed(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parent := r.Context()
+		ctx, cancel := context.WithCancelCause(context.WithoutCancel(parent))
+		defer cancel(nil)
+		stop := context.AfterFunc(parent, func() {
+			cause := context.Cause(parent)
+			if errors.Is(cause, context.Canceled) {
+				cause = errs.ErrClientClosed
+			}
+			cancel(cause)
+		})
+		defer stop()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,49 @@
+package chi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+)
+
+func TestServer_MarksTheClientDisconnects(t *testing.T) {
+	// Arrange
+	server, err := chi.New(chi.Default())
+	require.NoError(t, err)
+	started := make(chan struct{})
+	clientClosed := make(chan bool, 1)
+	server.Router().Get("/export", func(_ http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		clientClosed <- errs.IsClientClosed(r.Context(), context.Canceled)
+	})
+	httpServer := httptest.NewServer(server.Router())
+	defer httpServer.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+"/export", nil)
+	require.NoError(t, err)
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	// Act
+	_, doErr := http.DefaultClient.Do(req)
+
+	// Assert
+	require.ErrorIs(t, doErr, context.Canceled)
+	select {
+	case closed := <-clientClosed:
+		assert.True(t, closed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request context was not canceled")
+	}
+}
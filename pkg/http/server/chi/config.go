@@ -2,7 +2,10 @@ package chi
 
 import (
 	"fmt"
+	"net/http"
 	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ipfilter"
 )
 
 const (
@@ -12,6 +15,7 @@ const (
 	defaultSwaggerPath = "/swagger/*"
 	healthCheckPath    = "/healthz"
 	metricsPath        = "/metrics"
+	buildInfoPath      = "/buildinfo"
 )
 
 // Config holds the configuration for the Chi HTTP server.
@@ -21,9 +25,27 @@ type Config struct {
 	WriteTimeout    time.Duration
 	IdleTimeout     time.Duration
 	ShutdownTimeout time.Duration
+	DrainTimeout    time.Duration // Grace period of the streams and WebSockets on shutdown, default: 5s
+	RequestTimeout  time.Duration // Deadline of the request contexts (504 when passed), 0 for none
 	MetricsPort     uint
 	CORS            *CORSConfig
 	Swagger         *SwaggerConfig
+	OpenAPI         *OpenAPIConfig
+	Static          *StaticConfig
+	Maintenance     *MaintenanceConfig
+	Recorder        *RecorderConfig
+	Admin           *AdminConfig // pprof, expvar and runtime controls on the metrics server
+	Websocket       *WebsocketConfig
+	Listener        *ListenerConfig // Unix socket or systemd socket instead of TCP on Port
+	// Listeners are additional listeners serving the router, by name, e.g. an "admin"
+	// listener bound to 127.0.0.1 (see OnListener and Server.UseListener)
+	Listeners map[string]ListenerConfig
+	// TrustedProxies are the addresses and CIDR ranges of the proxies whose forwarding
+	// headers set the client address. Empty trusts the headers of every client.
+	TrustedProxies []string
+	// MetricsDefaultRegistry serves prometheus.DefaultGatherer along with the gatherer of
+	// SetMetricsGatherer (e.g. of metrics.Module), without the families the gatherer has
+	MetricsDefaultRegistry bool
 }
 
 // SwaggerConfig holds configuration for the Swagger/OpenAPI documentation endpoint.
@@ -31,14 +53,22 @@ type Config struct {
 // Dir is the directory containing swagger files (e.g. doc.json from swag init).
 // When Dir is empty, embedded docs from the application's docs package are used
 // (the application must import its docs package, e.g. _ "myapp/docs").
+// When OpenAPI is enabled, the UI shows the generated document instead of the swaggo docs.
 type SwaggerConfig struct {
 	Enabled bool   // Whether to register the swagger route
 	Path    string // URL path prefix for swagger UI (e.g. /swagger), default: /swagger
 }
 
+// CORSOriginFunc reports whether the CORS requests of origin are allowed. r is the
+// request, preflight or actual, so the function can read its context and path.
+type CORSOriginFunc func(r *http.Request, origin string) bool
+
 // CORSConfig holds CORS configuration.
 type CORSConfig struct {
-	AllowedOrigins     []string
+	AllowedOrigins []string
+	// AllowOriginFunc validates the origins instead of AllowedOrigins, e.g. against the
+	// domains of the tenants. Set it in code or with Server.SetCORSOriginFunc.
+	AllowOriginFunc    CORSOriginFunc `config:"-"`
 	AllowedMethods     []string
 	AllowedHeaders     []string
 	ExposedHeaders     []string
@@ -46,6 +76,8 @@ type CORSConfig struct {
 	MaxAge             int
 	OptionsPassthrough bool
 	Debug              bool
+	// Groups overrides the policy of the routes under a path prefix, the longest matching
+	Groups []CORSGroupConfig
 }
 
 // Default returns a Config with sensible default values.
@@ -56,6 +88,7 @@ func Default() Config {
 		WriteTimeout:    DefaultWriteTimeout * time.Second,
 		IdleTimeout:     DefaultIdleTimeout * time.Second,
 		ShutdownTimeout: DefaultShutdownTimeout * time.Second,
+		DrainTimeout:    DefaultDrainTimeout * time.Second,
 		MetricsPort:     defaultMetricsPort,
 	}
 }
@@ -71,6 +104,30 @@ func (c Config) Validate() error {
 	if c.Port == c.MetricsPort {
 		return ErrPortsEqual
 	}
+	if _, err := ipfilter.ParsePrefixes(c.TrustedProxies); err != nil {
+		return err
+	}
+	if c.Admin != nil {
+		if err := c.Admin.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Listener != nil {
+		if err := c.Listener.Validate(); err != nil {
+			return err
+		}
+	}
+	for name, listener := range c.Listeners {
+		if name == DefaultListener {
+			return fmt.Errorf("%w: %s", ErrReservedListenerName, name)
+		}
+		if err := listener.Validate(); err != nil {
+			return fmt.Errorf("listener %s: %w", name, err)
+		}
+		if listener.network() == NetworkTCP && listener.Address == "" {
+			return fmt.Errorf("listener %s: %w", name, ErrMissingListenerAddress)
+		}
+	}
 	return nil
 }
 
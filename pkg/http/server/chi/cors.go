@@ -0,0 +1,97 @@
+package chi
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/cors"
+)
+
+// CORSGroupConfig overrides the CORS policy of the routes under Prefix. The group is a
+// whole policy: it inherits nothing from the global one.
+type CORSGroupConfig struct {
+	Prefix     string
+	CORSConfig `config:",squash"`
+}
+
+// corsPolicies applies the policy of the longest group prefix matching the request path,
+// or the global policy. It runs before the routing, so the preflight requests of every
+// route get the policy of their group.
+type corsPolicies struct {
+	global *corsPolicy
+	// groups is sorted by descending prefix length
+	groups []*corsPolicy
+}
+
+type corsPolicy struct {
+	prefix  string
+	config  CORSConfig
+	handler func(http.Handler) http.Handler
+}
+
+func newCORSPolicies(cfg *CORSConfig) *corsPolicies {
+	if cfg == nil {
+		return nil
+	}
+	policies := &corsPolicies{global: newCORSPolicy("", *cfg)}
+	for _, group := range cfg.Groups {
+		prefix := strings.TrimSuffix(group.Prefix, "/")
+		policies.groups = append(policies.groups, newCORSPolicy(prefix, group.CORSConfig))
+	}
+	slices.SortStableFunc(policies.groups, func(a, b *corsPolicy) int {
+		return cmp.Compare(len(b.prefix), len(a.prefix))
+	})
+	return policies
+}
+
+func newCORSPolicy(prefix string, cfg CORSConfig) *corsPolicy {
+	policy := &corsPolicy{prefix: prefix, config: cfg}
+	policy.build()
+	return policy
+}
+
+func (p *corsPolicy) build() {
+	p.handler = cors.Handler(cors.Options{
+		AllowedOrigins:     p.config.AllowedOrigins,
+		AllowOriginFunc:    p.config.AllowOriginFunc,
+		AllowedMethods:     p.config.AllowedMethods,
+		AllowedHeaders:     p.config.AllowedHeaders,
+		ExposedHeaders:     p.config.ExposedHeaders,
+		AllowCredentials:   p.config.AllowCredentials,
+		MaxAge:             p.config.MaxAge,
+		OptionsPassthrough: p.config.OptionsPassthrough,
+		Debug:              p.config.Debug,
+	})
+}
+
+func (p *corsPolicy) matches(path string) bool {
+	return path == p.prefix || strings.HasPrefix(path, p.prefix+"/")
+}
+
+// setOriginFunc validates the origins of the policies without their own AllowOriginFunc with fn.
+func (c *corsPolicies) setOriginFunc(fn CORSOriginFunc) {
+	for _, policy := range append([]*corsPolicy{c.global}, c.groups...) {
+		if policy.config.AllowOriginFunc == nil {
+			policy.config.AllowOriginFunc = fn
+			policy.build()
+		}
+	}
+}
+
+func (c *corsPolicies) policy(path string) *corsPolicy {
+	for _, group := range c.groups {
+		if group.matches(path) {
+			return group
+		}
+	}
+	return c.global
+}
+
+// Middleware applies the CORS policy of the request.
+func (c *corsPolicies) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.policy(r.URL.Path).handler(next).ServeHTTP(w, r)
+	})
+}
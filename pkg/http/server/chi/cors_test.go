@@ -0,0 +1,128 @@
+package chi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/stretchr/testify/suite"
+)
+
+type CORSTestSuite struct {
+	suite.Suite
+	sut *chi.Server
+}
+
+func TestCORSSuite(t *testing.T) {
+	suite.Run(t, new(CORSTestSuite))
+}
+
+func (s *CORSTestSuite) SetupTest() {
+	cfg := chi.Default()
+	cfg.CORS = &chi.CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		Groups: []chi.CORSGroupConfig{
+			{Prefix: "/public", CORSConfig: chi.CORSConfig{AllowedOrigins: []string{"*"}}},
+			{Prefix: "/public/admin/", CORSConfig: chi.CORSConfig{AllowedOrigins: []string{"https://admin.example.com"}}},
+		},
+	}
+	var err error
+	s.sut, err = chi.New(cfg)
+	s.Require().NoError(err)
+	for _, path := range []string{"/orders", "/public/catalog", "/public/admin/users", "/publication"} {
+		s.sut.Router().Get(path, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+}
+
+func (s *CORSTestSuite) preflight(path, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, path, nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rr := httptest.NewRecorder()
+	s.sut.Router().ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *CORSTestSuite) TestCORS_AppliesThePolicyOfTheLongestMatchingGroup() {
+	tests := []struct {
+		name    string
+		path    string
+		origin  string
+		allowed string
+	}{
+		{name: "global", path: "/orders", origin: "https://app.example.com", allowed: "https://app.example.com"},
+		{name: "global rejects", path: "/orders", origin: "https://evil.example.com", allowed: ""},
+		{name: "group", path: "/public/catalog", origin: "https://evil.example.com", allowed: "*"},
+		{name: "nested group", path: "/public/admin/users", origin: "https://admin.example.com",
+			allowed: "https://admin.example.com"},
+		{name: "nested group rejects", path: "/public/admin/users", origin: "https://other.example.com", allowed: ""},
+		{name: "prefix is a path segment", path: "/publication", origin: "https://evil.example.com", allowed: ""},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act
+			rr := s.preflight(tt.path, tt.origin)
+
+			// Assert
+			s.Equal(tt.allowed, rr.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
+
+func (s *CORSTestSuite) TestCORS_ActualRequest_ReachesTheHandler() {
+	// Arrange
+	req := httptest.NewRequest(http.MethodGet, "/public/catalog", nil)
+	req.Header.Set("Origin", "https://shop.example.com")
+	rr := httptest.NewRecorder()
+
+	// Act
+	s.sut.Router().ServeHTTP(rr, req)
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+	s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (s *CORSTestSuite) TestSetCORSOriginFunc_ValidatesTheOriginsOfEveryPolicy() {
+	// Arrange
+	var paths []string
+	s.sut.SetCORSOriginFunc(func(r *http.Request, origin string) bool {
+		paths = append(paths, r.URL.Path)
+		return strings.HasSuffix(origin, ".tenant.example.com")
+	})
+
+	// Act
+	allowed := s.preflight("/orders", "https://acme.tenant.example.com")
+	rejected := s.preflight("/public/catalog", "https://app.example.com")
+
+	// Assert
+	s.Equal("https://acme.tenant.example.com", allowed.Header().Get("Access-Control-Allow-Origin"))
+	s.Empty(rejected.Header().Get("Access-Control-Allow-Origin"))
+	s.Equal([]string{"/orders", "/public/catalog"}, paths)
+}
+
+func (s *CORSTestSuite) TestSetCORSOriginFunc_KeepsTheFunctionOfAGroup() {
+	// Arrange
+	cfg := chi.Default()
+	cfg.CORS = &chi.CORSConfig{
+		Groups: []chi.CORSGroupConfig{{Prefix: "/partners", CORSConfig: chi.CORSConfig{
+			AllowOriginFunc: func(_ *http.Request, origin string) bool { return origin == "https://partner.example.com" },
+		}}},
+	}
+	server, err := chi.New(cfg)
+	s.Require().NoError(err)
+	server.Router().Get("/partners/orders", func(w http.ResponseWriter, _ *http.Request) {})
+	server.SetCORSOriginFunc(func(*http.Request, string) bool { return false })
+	s.sut = server
+
+	// Act
+	rr := s.preflight("/partners/orders", "https://partner.example.com")
+
+	// Assert
+	s.Equal("https://partner.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,166 @@
+package chi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// DefaultDrainTimeout is the default grace period, in seconds, of the long-running
+// connections on shutdown.
+const DefaultDrainTimeout = 5
+
+// shuttingDownError answers the streams and WebSocket upgrades arriving during the shutdown.
+var shuttingDownError = errs.ServiceUnavailable("SHUTTING_DOWN", "The server is shutting down")
+
+// shutdownKey is the context key of the shutdown notification of a tracked connection.
+type shutdownKey struct{}
+
+// ShuttingDown returns a channel closed when the server starts shutting down, so the
+// handlers of Streaming routes and WebSockets can finish the message in progress and
+// return (e.g. after telling the client to reconnect) within the drain timeout. It returns
+// nil, which blocks forever, for the other requests.
+//
+//	case <-chi.ShuttingDown(ctx):
+//	    return sse.Send(w, "reconnect", nil)
+func ShuttingDown(ctx context.Context) <-chan struct{} {
+	notify, _ := ctx.Value(shutdownKey{}).(chan struct{})
+	return notify
+}
+
+// Streaming serves the long-running responses of the routes it wraps, e.g. Server-Sent
+// Events or large exports, which http.Server.Shutdown would otherwise wait for until its
+// deadline and then cut off mid-message. The routes have no request timeout. On shutdown,
+// ShuttingDown(ctx) is closed, then the request context is canceled with ErrServerShutdown
+// as cause after DrainTimeout; the new requests are answered 503.
+//
+//	server.Router().With(server.Streaming).Get("/events", h.Events)
+func (s *Server) Streaming(next http.Handler) http.Handler {
+	return s.Timeout(-1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithCancelCause(r.Context())
+		defer cancel(nil)
+		stop := func() { cancel(ErrServerShutdown) }
+		ctx, untrack, ok := s.drainer.track(ctx, &drainConn{close: stop, abort: stop})
+		if !ok {
+			s.timeouts.errorHandler.ErrorCtx(ctx, w, shuttingDownError)
+			return
+		}
+		defer untrack()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}))
+}
+
+// drainer tracks the long-running connections, streamed responses and WebSockets, to
+// notify them of the shutdown and close them after a grace period.
+type drainer struct {
+	timeout time.Duration
+	logger  *slog.Logger
+
+	mu       sync.Mutex
+	notify   chan struct{}
+	draining bool
+	conns    map[*drainConn]struct{}
+	active   sync.WaitGroup
+}
+
+// drainConn is a tracked connection: close ends it at the end of the grace period, abort
+// drops it at the shutdown deadline.
+type drainConn struct {
+	close func()
+	abort func()
+}
+
+func newDrainer(timeout time.Duration, logger *slog.Logger) *drainer {
+	if timeout == 0 {
+		timeout = DefaultDrainTimeout * time.Second
+	}
+	return &drainer{
+		timeout: max(timeout, 0),
+		logger:  logger,
+		notify:  make(chan struct{}),
+		conns:   make(map[*drainConn]struct{}),
+	}
+}
+
+// track registers conn and returns ctx with its shutdown notification and the function
+// untracking it. It reports false when the server is shutting down.
+func (d *drainer) track(ctx context.Context, conn *drainConn) (context.Context, func(), bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return ctx, nil, false
+	}
+	d.conns[conn] = struct{}{}
+	d.active.Add(1)
+	untrack := func() {
+		d.mu.Lock()
+		delete(d.conns, conn)
+		d.mu.Unlock()
+		d.active.Done()
+	}
+	return context.WithValue(ctx, shutdownKey{}, d.notify), untrack, true
+}
+
+func (d *drainer) isDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// drain notifies the connections of the shutdown and waits for them to end. The ones
+// still open after the drain timeout are closed, and the ones still open when ctx is done
+// are dropped.
+func (d *drainer) drain(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.draining {
+		d.draining = true
+		close(d.notify)
+	}
+	open := len(d.conns)
+	d.mu.Unlock()
+	if open > 0 {
+		d.logger.InfoContext(ctx, "draining connections", "connections", open, "timeout", d.timeout)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.active.Wait()
+		close(done)
+	}()
+	grace := time.NewTimer(d.timeout)
+	defer grace.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-grace.C:
+		d.each("closing connections after the drain timeout", func(conn *drainConn) { conn.close() })
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+		}
+	case <-ctx.Done():
+	}
+	d.each("dropping connections at the shutdown deadline", func(conn *drainConn) { conn.abort() })
+	return fmt.Errorf("drain connections: %w", ctx.Err())
+}
+
+func (d *drainer) each(msg string, fn func(conn *drainConn)) {
+	d.mu.Lock()
+	conns := make([]*drainConn, 0, len(d.conns))
+	for conn := range d.conns {
+		conns = append(conns, conn)
+	}
+	d.mu.Unlock()
+	if len(conns) > 0 {
+		d.logger.Warn(msg, "connections", len(conns))
+	}
+	for _, conn := range conns {
+		fn(conn)
+	}
+}
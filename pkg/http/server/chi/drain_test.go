@@ -0,0 +1,162 @@
+package chi_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/stretchr/testify/suite"
+)
+
+const testDrainTimeout = 50 * time.Millisecond
+
+type DrainTestSuite struct {
+	suite.Suite
+	sut    *chi.Server
+	server *httptest.Server
+}
+
+func TestDrainSuite(t *testing.T) {
+	suite.Run(t, new(DrainTestSuite))
+}
+
+func (s *DrainTestSuite) SetupTest() {
+	cfg := chi.Default()
+	cfg.RequestTimeout = testRequestTimeout
+	cfg.DrainTimeout = testDrainTimeout
+	var err error
+	s.sut, err = chi.New(cfg)
+	s.Require().NoError(err)
+	s.server = httptest.NewServer(s.sut.Router())
+}
+
+func (s *DrainTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// stream opens the event stream of path and returns its lines once the first one arrived.
+func (s *DrainTestSuite) stream(path string) <-chan string {
+	resp, err := http.Get(s.server.URL + path)
+	s.Require().NoError(err)
+	lines := make(chan string, 10)
+	scanner := bufio.NewScanner(resp.Body)
+	s.Require().True(scanner.Scan())
+	go func() {
+		defer resp.Body.Close()
+		defer close(lines)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
+
+// events streams a "ping" event, then runs done once the server shuts down or the
+// request context is canceled.
+func events(done func(w http.ResponseWriter)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "event: ping\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-chi.ShuttingDown(r.Context()):
+		case <-r.Context().Done():
+		}
+		done(w)
+	}
+}
+
+func (s *DrainTestSuite) TestShutdown_NotifiesTheStreams() {
+	// Arrange
+	s.sut.Router().With(s.sut.Streaming).Get("/events", events(func(w http.ResponseWriter) {
+		_, _ = fmt.Fprint(w, "event: reconnect\n")
+	}))
+	lines := s.stream("/events")
+
+	// Act
+	err := s.sut.Shutdown(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("event: reconnect", <-lines)
+}
+
+func (s *DrainTestSuite) TestShutdown_CancelsTheStreamsAfterTheDrainTimeout() {
+	// Arrange
+	cause := make(chan error, 1)
+	s.sut.Router().With(s.sut.Streaming).Get("/events", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, "event: ping\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		cause <- context.Cause(r.Context())
+	})
+	s.stream("/events")
+	start := time.Now()
+
+	// Act
+	err := s.sut.Shutdown(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.ErrorIs(<-cause, chi.ErrServerShutdown)
+	s.GreaterOrEqual(time.Since(start), testDrainTimeout)
+}
+
+func (s *DrainTestSuite) TestShutdown_DropsTheStreamsAtTheDeadline() {
+	// Arrange
+	release := make(chan struct{})
+	defer close(release)
+	s.sut.Router().With(s.sut.Streaming).Get("/events", events(func(http.ResponseWriter) {
+		<-release
+	}))
+	s.stream("/events")
+	ctx, cancel := context.WithTimeout(context.Background(), 2*testDrainTimeout)
+	defer cancel()
+
+	// Act
+	err := s.sut.Shutdown(ctx)
+
+	// Assert
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func (s *DrainTestSuite) TestStreaming_AnswersServiceUnavailableDuringTheShutdown() {
+	// Arrange
+	s.sut.Router().With(s.sut.Streaming).Get("/events", events(func(http.ResponseWriter) {}))
+	s.Require().NoError(s.sut.Shutdown(context.Background()))
+	rr := httptest.NewRecorder()
+
+	// Act
+	s.sut.Router().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	// Assert
+	s.Equal(http.StatusServiceUnavailable, rr.Code)
+	var body map[string]map[string]string
+	s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &body))
+	s.Equal("SHUTTING_DOWN", body["error"]["code"])
+}
+
+func (s *DrainTestSuite) TestStreaming_RemovesTheRequestTimeout() {
+	// Arrange
+	s.sut.Router().With(s.sut.Streaming).Get("/events", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(5 * testRequestTimeout):
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+		}
+	})
+	rr := httptest.NewRecorder()
+
+	// Act
+	s.sut.Router().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+}
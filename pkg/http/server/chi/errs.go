@@ -14,4 +14,32 @@ var (
 
 	// ErrPortsEqual indicates that the main port and metrics port cannot be the same
 	ErrPortsEqual = errors.New("metrics port must be different from main server port")
+
+	// ErrMaintenanceRedisRequired indicates that the maintenance Redis key is set without a Redis client
+	ErrMaintenanceRedisRequired = errors.New("maintenance redis key requires a redis client")
+
+	// ErrServerShutdown is the cause of the cancellation of the Streaming requests still
+	// running after the drain timeout
+	ErrServerShutdown = errors.New("server shutting down")
+
+	// ErrInvalidNetwork indicates that the listener network is not tcp, unix or systemd
+	ErrInvalidNetwork = errors.New("invalid listener network: must be tcp, unix or systemd")
+
+	// ErrMissingSocketPath indicates that the unix listener has no socket path
+	ErrMissingSocketPath = errors.New("unix listener requires a socket path")
+
+	// ErrInvalidSocketMode indicates that the unix socket mode is not octal permissions
+	ErrInvalidSocketMode = errors.New("invalid unix socket mode: must be octal permissions, e.g. 0660")
+
+	// ErrNoSystemdSocket indicates that systemd passed no socket, or none with the configured name
+	ErrNoSystemdSocket = errors.New("no socket passed by systemd")
+
+	// ErrReservedListenerName indicates that an additional listener is named after the server listener
+	ErrReservedListenerName = errors.New("listener name is reserved for the server listener")
+
+	// ErrMissingListenerAddress indicates that an additional tcp listener has no address
+	ErrMissingListenerAddress = errors.New("tcp listener requires an address")
+
+	// ErrMissingAdminToken indicates that the admin endpoints are enabled without a token
+	ErrMissingAdminToken = errors.New("admin endpoints require a token")
 )
@@ -0,0 +1,21 @@
+package chi
+
+import "net/http"
+
+// MetricsHandler returns the handler of the metrics server.
+func (s *Server) MetricsHandler() http.Handler {
+	return s.metricsServer.Handler
+}
+
+// Refresh reads the maintenance mode from the store.
+func (m *Maintenance) Refresh() {
+	m.refresh()
+}
+
+// SetListenFDsStart sets the first file descriptor of the systemd sockets and returns the
+// function restoring it.
+func SetListenFDsStart(fd int) func() {
+	previous := listenFDsStart
+	listenFDsStart = fd
+	return func() { listenFDsStart = previous }
+}
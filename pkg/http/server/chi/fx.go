@@ -12,6 +12,8 @@ import (
 // - Starts the HTTP server
 // - Gracefully shuts down on application stop
 //
+// It also provides the *Adapter of the typed handlers created by Handle.
+//
 // Usage in your application:
 //
 //	fx.Module(
@@ -23,6 +25,6 @@ import (
 var Module = fx.Module(
 	"httpserver-chi",
 	config.Provide[Config]("app.http"),
-	fx.Provide(NewWithLifecycle),
+	fx.Provide(NewWithLifecycle, NewAdapterWithParams),
 	fx.Invoke(func(*Server) {}), // Force server construction
 )
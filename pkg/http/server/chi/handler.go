@@ -0,0 +1,134 @@
+package chi
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/request"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+	"go.uber.org/fx"
+)
+
+// Adapter holds the dependencies of the typed handlers created by Handle.
+type Adapter struct {
+	errorHandler response.ErrorHandler
+	validator    validator.Validator
+}
+
+// NewAdapter creates an Adapter. A nil validator skips the validation; a nil
+// errorHandler writes the errors without translation.
+func NewAdapter(errorHandler response.ErrorHandler, validate validator.Validator) *Adapter {
+	if errorHandler == nil {
+		errorHandler = response.NewErrorHandler(validate, nil)
+	}
+	return &Adapter{errorHandler: errorHandler, validator: validate}
+}
+
+// NewAdapterParams contains dependencies for creating an Adapter with FX.
+type NewAdapterParams struct {
+	fx.In
+	// ErrorHandler writes the errors when provided (e.g. by response.Module).
+	ErrorHandler response.ErrorHandler `optional:"true"`
+	// Validator validates the inputs when provided (e.g. by validator.Module).
+	Validator validator.Validator `optional:"true"`
+}
+
+// NewAdapterWithParams creates an Adapter from FX dependencies.
+func NewAdapterWithParams(params NewAdapterParams) *Adapter {
+	return NewAdapter(params.ErrorHandler, params.Validator)
+}
+
+type handleOptions struct {
+	status      int
+	maxBodySize int64
+}
+
+// HandleOption configures a handler created by Handle.
+type HandleOption func(*handleOptions)
+
+// WithStatus sets the status of successful responses. Default: 200.
+// With http.StatusNoContent, the output is not written.
+func WithStatus(status int) HandleOption {
+	return func(o *handleOptions) {
+		o.status = status
+	}
+}
+
+// WithMaxBodySize sets the maximum size of the JSON body. Default: request.DefaultMaxBodySize.
+func WithMaxBodySize(maxBytes int64) HandleOption {
+	return func(o *handleOptions) {
+		o.maxBodySize = maxBytes
+	}
+}
+
+// Handle adapts fn, typically the Execute method of a use case, to an http.HandlerFunc:
+//
+//   - the JSON body is decoded into In, then its `path`, `query` and `header` fields are
+//     bound with request.Bind
+//   - In is validated with the validator of the adapter
+//   - Out is written with response.JSON, inside the {"data": ...} envelope
+//   - errors of any step are written by the error handler of the adapter
+//
+// Use struct{} for handlers without input or output.
+//
+//	server.Handle(http.MethodPost, "/orders", chi.Handle(adapter, createOrder.Execute,
+//		chi.WithStatus(http.StatusCreated)), chi.Operation{Summary: "Create an order"})
+func Handle[In, Out any](
+	adapter *Adapter,
+	fn func(ctx context.Context, in In) (Out, error),
+	opts ...HandleOption,
+) http.HandlerFunc {
+	options := handleOptions{status: http.StatusOK, maxBodySize: request.DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	bindable := reflect.TypeFor[In]().Kind() == reflect.Struct
+
+	var bindOpts []request.BindOption
+	if adapter.validator != nil {
+		bindOpts = append(bindOpts, request.WithValidator(adapter.validator))
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var in In
+		if hasBody(r) {
+			if err := request.ReadJSONWithMaxSize(w, r, &in, options.maxBodySize); err != nil {
+				adapter.errorHandler.ErrorCtx(ctx, w, err)
+				return
+			}
+		}
+		if bindable {
+			if err := request.Bind(r, &in, bindOpts...); err != nil {
+				adapter.errorHandler.ErrorCtx(ctx, w, err)
+				return
+			}
+		}
+
+		out, err := fn(ctx, in)
+		if err != nil {
+			adapter.errorHandler.ErrorCtx(ctx, w, err)
+			return
+		}
+
+		if options.status == http.StatusNoContent {
+			response.NoContent(w)
+			return
+		}
+		if err = response.JSON(w, options.status, out, nil); err != nil {
+			adapter.errorHandler.ErrorCtx(ctx, w, err)
+		}
+	}
+}
+
+// hasBody reports whether the request carries a body to decode.
+func hasBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return false
+	}
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
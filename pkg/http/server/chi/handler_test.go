@@ -0,0 +1,119 @@
+package chi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+	gochi "github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type renameInput struct {
+	ID   uint64 `path:"id"`
+	Name string `json:"name" validate:"required"`
+}
+
+type deleteInput struct {
+	ID uint64 `path:"id" validate:"required"`
+}
+
+type renameOutput struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+type HandleTestSuite struct {
+	suite.Suite
+	adapter *chi.Adapter
+	router  *gochi.Mux
+	calls   int
+}
+
+func TestHandleSuite(t *testing.T) {
+	suite.Run(t, new(HandleTestSuite))
+}
+
+func (s *HandleTestSuite) SetupTest() {
+	v, err := validator.New()
+	s.Require().NoError(err)
+	s.adapter = chi.NewAdapter(nil, v)
+	s.calls = 0
+	s.router = gochi.NewRouter()
+	s.router.Put("/items/{id}", chi.Handle(s.adapter, s.rename))
+	s.router.Delete("/items/{id}", chi.Handle(s.adapter, func(context.Context, deleteInput) (struct{}, error) {
+		return struct{}{}, nil
+	}, chi.WithStatus(http.StatusNoContent)))
+}
+
+func (s *HandleTestSuite) rename(_ context.Context, in renameInput) (renameOutput, error) {
+	s.calls++
+	if in.Name == "taken" {
+		return renameOutput{}, errs.New("NAME_TAKEN", "Name is taken", http.StatusConflict, nil)
+	}
+	return renameOutput(in), nil
+}
+
+func (s *HandleTestSuite) serve(method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body == "" {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *HandleTestSuite) TestHandle_ValidInput_WritesOutputEnvelope() {
+	// Act
+	rr := s.serve(http.MethodPut, "/items/7", `{"name":"lamp"}`)
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+	s.JSONEq(`{"data":{"id":7,"name":"lamp"}}`, rr.Body.String())
+}
+
+func (s *HandleTestSuite) TestHandle_InvalidInput_WritesValidationErrorWithoutCalling() {
+	// Act
+	rr := s.serve(http.MethodPut, "/items/7", `{"name":""}`)
+
+	// Assert
+	s.Equal(http.StatusUnprocessableEntity, rr.Code)
+	s.Zero(s.calls)
+}
+
+func (s *HandleTestSuite) TestHandle_MalformedBody_WritesBadRequest() {
+	// Act
+	rr := s.serve(http.MethodPut, "/items/7", `{"name":`)
+
+	// Assert
+	s.Equal(http.StatusBadRequest, rr.Code)
+	s.Zero(s.calls)
+}
+
+func (s *HandleTestSuite) TestHandle_UseCaseError_WritesErrorEnvelope() {
+	// Act
+	rr := s.serve(http.MethodPut, "/items/7", `{"name":"taken"}`)
+
+	// Assert
+	s.Equal(http.StatusConflict, rr.Code)
+	var body map[string]map[string]any
+	s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &body))
+	s.Equal("NAME_TAKEN", body["error"]["code"])
+}
+
+func (s *HandleTestSuite) TestHandle_NoContentStatus_WritesNoBody() {
+	// Act
+	rr := s.serve(http.MethodDelete, "/items/7", "")
+
+	// Assert
+	s.Equal(http.StatusNoContent, rr.Code)
+	s.Empty(rr.Body.String())
+}
@@ -0,0 +1,231 @@
+package chi
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Networks of the server listeners.
+const (
+	NetworkTCP     = "tcp"
+	NetworkUnix    = "unix"
+	NetworkSystemd = "systemd"
+)
+
+// DefaultListener is the name of the listener of the server, on Port or Listener, among
+// the additional Listeners.
+const DefaultListener = "default"
+
+// listenFDsStart is the first file descriptor passed by systemd socket activation.
+var listenFDsStart = 3
+
+// ListenerConfig selects how the server listens: on Port over TCP, on a unix socket, e.g.
+// behind a sidecar proxy, or on a socket passed by systemd socket activation (LISTEN_FDS).
+// The metrics server always listens on MetricsPort.
+type ListenerConfig struct {
+	Network string // tcp, unix or systemd, default: tcp
+	// Address is the host:port of a tcp listener, e.g. "127.0.0.1:8081", default: 0.0.0.0:Port
+	// for the server listener; required for the additional listeners
+	Address string
+	// Path is the path of the unix socket; a socket left by a previous run is replaced
+	Path string
+	// Mode is the octal permissions of the unix socket, e.g. "0660", default: from the umask
+	Mode string
+	// Name selects the systemd socket by its FileDescriptorName, default: the first one
+	Name string
+	// Paths are the path prefixes served on the listener, e.g. ["/admin"], the others are
+	// answered 404; default: [] (every route)
+	Paths []string
+}
+
+// Validate validates the listener configuration.
+func (c *ListenerConfig) Validate() error {
+	switch c.Network {
+	case "", NetworkTCP, NetworkSystemd:
+	case NetworkUnix:
+		if c.Path == "" {
+			return ErrMissingSocketPath
+		}
+		if _, err := parseSocketMode(c.Mode); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidNetwork, c.Network)
+	}
+	return nil
+}
+
+// network returns the network of the listener, tcp when cfg is nil.
+func (c *ListenerConfig) network() string {
+	if c == nil || c.Network == "" {
+		return NetworkTCP
+	}
+	return c.Network
+}
+
+// listenerKey is the context key of the name of the listener of a request.
+type listenerKey struct{}
+
+// ListenerName returns the name of the listener that received the request of ctx,
+// DefaultListener or the key of an additional listener in Config.Listeners. It returns ""
+// for the requests not received by a listener, e.g. served by Router in tests.
+func ListenerName(ctx context.Context) string {
+	name, _ := ctx.Value(listenerKey{}).(string)
+	return name
+}
+
+// OnListener serves the routes it wraps only on the listeners named names, and answers
+// 404 on the other ones, e.g. for the admin routes of a listener bound to 127.0.0.1.
+//
+//	server.Router().With(chi.OnListener("admin")).Post("/admin/cache/flush", h.Flush)
+func OnListener(names ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !slices.Contains(names, ListenerName(r.Context())) {
+				http.NotFound(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// UseListener adds middlewares to the requests of the listener named name, DefaultListener
+// or an additional listener, e.g. an authentication of the admin listener. They run before
+// the middlewares of the router. Call it before Start.
+func (s *Server) UseListener(name string, middlewares ...func(http.Handler) http.Handler) {
+	s.listenerMiddlewares[name] = append(s.listenerMiddlewares[name], middlewares...)
+}
+
+// serverListener is a listener of the server and the http.Server serving the router on it.
+type serverListener struct {
+	name   string
+	cfg    *ListenerConfig
+	server *http.Server
+}
+
+// listenerHandler returns the handler of the listener: the router behind the middlewares of the
+// listener, restricted to its paths.
+func (s *Server) listenerHandler(l *serverListener) http.Handler {
+	var handler http.Handler = s.router
+	if l.cfg != nil && len(l.cfg.Paths) > 0 {
+		handler = servePaths(l.cfg.Paths, handler)
+	}
+	middlewares := s.listenerMiddlewares[l.name]
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), listenerKey{}, l.name)))
+	})
+}
+
+// servePaths answers 404 to the requests outside paths, except the health check.
+func servePaths(paths []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == healthCheckPath || slices.ContainsFunc(paths, func(prefix string) bool {
+			return hasPathPrefix(r.URL.Path, prefix)
+		}) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
+
+// hasPathPrefix reports whether path is prefix or a path under it: /admin matches /admin
+// and /admin/users but not /administrators.
+func hasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// listen opens the listener l.
+func (l *serverListener) listen() (net.Listener, error) {
+	switch l.cfg.network() {
+	case NetworkUnix:
+		return listenUnix(l.cfg.Path, l.cfg.Mode)
+	case NetworkSystemd:
+		return listenSystemd(l.cfg.Name)
+	default:
+		return net.Listen(NetworkTCP, l.server.Addr)
+	}
+}
+
+// url returns the URL of the listener, for the logs.
+func (l *serverListener) url() string {
+	switch l.cfg.network() {
+	case NetworkUnix:
+		return "unix://" + l.cfg.Path
+	case NetworkSystemd:
+		return "systemd://" + l.cfg.Name
+	default:
+		return "http://" + l.server.Addr
+	}
+}
+
+func listenUnix(path, mode string) (net.Listener, error) {
+	// A socket is not removed when the process is killed, and would fail the listen
+	if info, err := os.Stat(path); err == nil && info.Mode().Type() == fs.ModeSocket {
+		if err = os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket %s: %w", path, err)
+		}
+	}
+	listener, err := net.Listen(NetworkUnix, path)
+	if err != nil {
+		return nil, err
+	}
+	perm, _ := parseSocketMode(mode) // Validated with the config
+	if perm != 0 {
+		if err = os.Chmod(path, perm); err != nil {
+			_ = listener.Close()
+			return nil, fmt.Errorf("chmod socket %s: %w", path, err)
+		}
+	}
+	return listener, nil
+}
+
+// listenSystemd returns the socket named name, or the first one, of the sockets passed by
+// systemd, see sd_listen_fds(3).
+func listenSystemd(name string) (net.Listener, error) {
+	if os.Getenv("LISTEN_PID") != strconv.Itoa(os.Getpid()) {
+		return nil, ErrNoSystemdSocket
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count < 1 {
+		return nil, ErrNoSystemdSocket
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+	for i := range count {
+		if name != "" && (i >= len(names) || names[i] != name) {
+			continue
+		}
+		file := os.NewFile(uintptr(listenFDsStart+i), "systemd:"+name)
+		listener, listenErr := net.FileListener(file)
+		// FileListener duplicates the descriptor
+		_ = file.Close()
+		if listenErr != nil {
+			return nil, fmt.Errorf("systemd socket %d: %w", listenFDsStart+i, listenErr)
+		}
+		return listener, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoSystemdSocket, name)
+}
+
+func parseSocketMode(mode string) (os.FileMode, error) {
+	if mode == "" {
+		return 0, nil
+	}
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || perm > 0o777 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSocketMode, mode)
+	}
+	return os.FileMode(perm), nil
+}
@@ -0,0 +1,245 @@
+package chi_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// start starts a server with listener and returns a client of its health check.
+func start(t *testing.T, listener *chi.ListenerConfig, dial func(context.Context) (net.Conn, error)) func() int {
+	t.Helper()
+	cfg := chi.Default()
+	cfg.MetricsPort = 19190
+	cfg.Listener = listener
+	server, err := chi.New(cfg)
+	require.NoError(t, err)
+	go func() { _ = server.Start() }()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) { return dial(ctx) },
+	}}
+	return func() int {
+		resp, getErr := client.Get("http://server/healthz")
+		if getErr != nil {
+			return 0
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+}
+
+func TestListener_ServesOnAUnixSocket(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "app.sock")
+	var dialer net.Dialer
+
+	// Act
+	healthz := start(t, &chi.ListenerConfig{Network: chi.NetworkUnix, Path: path, Mode: "0660"},
+		func(ctx context.Context) (net.Conn, error) { return dialer.DialContext(ctx, "unix", path) })
+
+	// Assert
+	assert.Eventually(t, func() bool { return healthz() == http.StatusOK }, time.Second, 10*time.Millisecond)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o660), info.Mode().Perm())
+}
+
+func TestListener_ReplacesAStaleUnixSocket(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "app.sock")
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	var dialer net.Dialer
+
+	// Act
+	healthz := start(t, &chi.ListenerConfig{Network: chi.NetworkUnix, Path: path},
+		func(ctx context.Context) (net.Conn, error) { return dialer.DialContext(ctx, "unix", path) })
+
+	// Assert
+	assert.Eventually(t, func() bool { return healthz() == http.StatusOK }, time.Second, 10*time.Millisecond)
+}
+
+func TestListener_ServesOnASystemdSocket(t *testing.T) {
+	// Arrange
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer tcp.Close()
+	file, err := tcp.(*net.TCPListener).File()
+	require.NoError(t, err)
+	// A descriptor owned by no os.File, as the ones passed by systemd
+	fd, err := syscall.Dup(int(file.Fd()))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	t.Cleanup(chi.SetListenFDsStart(fd - 1))
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	t.Setenv("LISTEN_FDS", "2")
+	t.Setenv("LISTEN_FDNAMES", "metrics:http")
+	var dialer net.Dialer
+
+	// Act
+	healthz := start(t, &chi.ListenerConfig{Network: chi.NetworkSystemd, Name: "http"},
+		func(ctx context.Context) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", tcp.Addr().String())
+		})
+
+	// Assert
+	assert.Eventually(t, func() bool { return healthz() == http.StatusOK }, time.Second, 10*time.Millisecond)
+}
+
+func TestListenerConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     chi.ListenerConfig
+		wantErr error
+	}{
+		{"tcp", chi.ListenerConfig{}, nil},
+		{"unix", chi.ListenerConfig{Network: chi.NetworkUnix, Path: "/run/app.sock", Mode: "0660"}, nil},
+		{"systemd", chi.ListenerConfig{Network: chi.NetworkSystemd}, nil},
+		{"unknown network", chi.ListenerConfig{Network: "udp"}, chi.ErrInvalidNetwork},
+		{"unix without path", chi.ListenerConfig{Network: chi.NetworkUnix}, chi.ErrMissingSocketPath},
+		{
+			"invalid mode",
+			chi.ListenerConfig{Network: chi.NetworkUnix, Path: "/run/app.sock", Mode: "rw"},
+			chi.ErrInvalidSocketMode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.cfg.Validate()
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestListener_ReportsAMissingSystemdSocket(t *testing.T) {
+	// Arrange
+	cfg := chi.Default()
+	cfg.MetricsPort = 19191
+	cfg.Listener = &chi.ListenerConfig{Network: chi.NetworkSystemd}
+	t.Setenv("LISTEN_PID", "")
+	server, err := chi.New(cfg)
+	require.NoError(t, err)
+	defer server.Shutdown(context.Background())
+
+	// Act
+	err = server.Start()
+
+	// Assert
+	assert.ErrorIs(t, err, chi.ErrNoSystemdSocket)
+}
+
+// unixClient returns a client of the server listening on the unix socket path.
+func unixClient(path string) *http.Client {
+	var dialer net.Dialer
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}}
+}
+
+func get(t *testing.T, client *http.Client, path string) (int, string) {
+	t.Helper()
+	resp, err := client.Get("http://server" + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, resp.Header.Get("X-Listener") + string(body)
+}
+
+func TestListeners_ServeTheRouterOnEveryListener(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	publicPath, adminPath := filepath.Join(dir, "public.sock"), filepath.Join(dir, "admin.sock")
+	cfg := chi.Default()
+	cfg.MetricsPort = 19192
+	cfg.Listener = &chi.ListenerConfig{Network: chi.NetworkUnix, Path: publicPath}
+	cfg.Listeners = map[string]chi.ListenerConfig{
+		"admin": {Network: chi.NetworkUnix, Path: adminPath, Paths: []string{"/admin"}},
+	}
+	server, err := chi.New(cfg)
+	require.NoError(t, err)
+	server.UseListener("admin", func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Listener", "admin:")
+			next.ServeHTTP(w, r)
+		})
+	})
+	writeListener := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(chi.ListenerName(r.Context())))
+	}
+	server.Router().With(chi.OnListener("admin")).Get("/admin/stats", writeListener)
+	server.Router().Get("/public", writeListener)
+	go func() { _ = server.Start() }()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+	public, admin := unixClient(publicPath), unixClient(adminPath)
+	require.Eventually(t, func() bool {
+		_, err = os.Stat(adminPath)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	// Act
+	adminStatus, adminBody := get(t, admin, "/admin/stats")
+	hiddenStatus, _ := get(t, public, "/admin/stats")
+	publicStatus, publicBody := get(t, public, "/public")
+	outsideStatus, _ := get(t, admin, "/public")
+	healthStatus, _ := get(t, admin, "/healthz")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, adminStatus)
+	assert.Equal(t, "admin:admin", adminBody)
+	assert.Equal(t, http.StatusNotFound, hiddenStatus)
+	assert.Equal(t, http.StatusOK, publicStatus)
+	assert.Equal(t, chi.DefaultListener, publicBody)
+	assert.Equal(t, http.StatusNotFound, outsideStatus)
+	assert.Equal(t, http.StatusOK, healthStatus)
+}
+
+func TestConfig_Validate_Listeners(t *testing.T) {
+	tests := []struct {
+		name      string
+		listeners map[string]chi.ListenerConfig
+		wantErr   error
+	}{
+		{"valid", map[string]chi.ListenerConfig{"admin": {Address: "127.0.0.1:8081"}}, nil},
+		{
+			"reserved name",
+			map[string]chi.ListenerConfig{chi.DefaultListener: {Address: ":8081"}},
+			chi.ErrReservedListenerName,
+		},
+		{"tcp without address", map[string]chi.ListenerConfig{"admin": {}}, chi.ErrMissingListenerAddress},
+		{"invalid listener", map[string]chi.ListenerConfig{"admin": {Network: "udp"}}, chi.ErrInvalidNetwork},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := chi.Default()
+			cfg.Listeners = tt.listeners
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package chi
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+const (
+	maintenancePath                = "/maintenance"
+	defaultMaintenanceCode         = "MAINTENANCE"
+	defaultMaintenanceMessage      = "The service is under maintenance, please retry later"
+	defaultMaintenanceRetryAfter   = 5 * time.Minute
+	defaultMaintenancePollInterval = 5 * time.Second
+)
+
+// MaintenanceConfig configures the maintenance mode, in which the server answers 503 to
+// every route but the health check.
+type MaintenanceConfig struct {
+	Enabled      bool          // Whether the server starts in maintenance mode
+	Code         string        // Error code of the response, default: MAINTENANCE
+	Message      string        // Error message of the response
+	RetryAfter   time.Duration // Retry-After of the response, default: 5m
+	RedisKey     string        // Redis key holding the mode, shared by the instances; empty keeps it local
+	PollInterval time.Duration // Interval of the Redis key reads, default: 5s
+}
+
+// MaintenanceStore holds the maintenance mode shared by the server instances.
+type MaintenanceStore interface {
+	// Get returns whether the maintenance mode is on.
+	Get(ctx context.Context) (bool, error)
+	// Set turns the maintenance mode on or off.
+	Set(ctx context.Context, enabled bool) error
+}
+
+// Maintenance is the maintenance mode of a server. It is switched at runtime with
+// Enable/Disable, the /maintenance admin endpoint of the metrics server, or the
+// MaintenanceStore.
+type Maintenance struct {
+	enabled    atomic.Bool
+	body       []byte
+	retryAfter string
+	interval   time.Duration
+	store      MaintenanceStore
+	logger     *slog.Logger
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+func newMaintenance(cfg *MaintenanceConfig, logger *slog.Logger) *Maintenance {
+	if cfg == nil {
+		cfg = &MaintenanceConfig{}
+	}
+	maintenanceErr := errs.New(
+		cmp.Or(cfg.Code, defaultMaintenanceCode),
+		cmp.Or(cfg.Message, defaultMaintenanceMessage),
+		http.StatusServiceUnavailable,
+		nil,
+	)
+	// Marshaling the envelope of an errs.Error cannot fail
+	body, _ := json.Marshal(map[string]any{"error": maintenanceErr})
+
+	m := &Maintenance{
+		body:       body,
+		retryAfter: strconv.Itoa(int(cmp.Or(cfg.RetryAfter, defaultMaintenanceRetryAfter).Seconds())),
+		interval:   cmp.Or(cfg.PollInterval, defaultMaintenancePollInterval),
+		logger:     logger,
+		stop:       make(chan struct{}),
+	}
+	m.enabled.Store(cfg.Enabled)
+	return m
+}
+
+// Enabled reports whether the maintenance mode is on.
+func (m *Maintenance) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// Enable turns the maintenance mode on, for every instance when there is a store.
+func (m *Maintenance) Enable(ctx context.Context) error {
+	return m.set(ctx, true)
+}
+
+// Disable turns the maintenance mode off, for every instance when there is a store.
+func (m *Maintenance) Disable(ctx context.Context) error {
+	return m.set(ctx, false)
+}
+
+func (m *Maintenance) set(ctx context.Context, enabled bool) error {
+	if m.store != nil {
+		if err := m.store.Set(ctx, enabled); err != nil {
+			return err
+		}
+	}
+	m.enabled.Store(enabled)
+	return nil
+}
+
+// Middleware answers 503 with the maintenance error and Retry-After while the mode is on,
+// except for the health check.
+func (m *Maintenance) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.enabled.Load() || r.URL.Path == healthCheckPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", m.retryAfter)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write(m.body)
+	})
+}
+
+// ServeHTTP is the admin endpoint: GET returns the mode, PUT turns it on and DELETE off.
+// The server serves it behind the admin token; mount it behind authentication too.
+func (m *Maintenance) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		err = m.Enable(r.Context())
+	case http.MethodDelete:
+		err = m.Disable(r.Context())
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		m.logger.Error("failed to switch the maintenance mode", "err", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]bool{"enabled": m.Enabled()})
+}
+
+// watch follows the store until close is called.
+func (m *Maintenance) watch() {
+	if m.store == nil {
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.refresh()
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Maintenance) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), m.interval)
+	defer cancel()
+	enabled, err := m.store.Get(ctx)
+	if err != nil {
+		// The last known mode is kept
+		m.logger.Warn("failed to read the maintenance mode", "err", err)
+		return
+	}
+	if m.enabled.Swap(enabled) != enabled {
+		m.logger.Info("maintenance mode switched", "enabled", enabled)
+	}
+}
+
+func (m *Maintenance) close() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// RedisMaintenanceStore keeps the maintenance mode in a Redis key, "1" meaning on.
+type RedisMaintenanceStore struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisMaintenanceStore creates a RedisMaintenanceStore on key.
+func NewRedisMaintenanceStore(client *redis.Client, key string) *RedisMaintenanceStore {
+	return &RedisMaintenanceStore{client: client, key: key}
+}
+
+// Get implements MaintenanceStore.
+func (s *RedisMaintenanceStore) Get(ctx context.Context) (bool, error) {
+	var get *goredis.StringCmd
+	err := s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		get = p.Get(ctx, s.key)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	value, err := get.Result()
+	if errors.Is(err, goredis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return value == "1", nil
+}
+
+// Set implements MaintenanceStore.
+func (s *RedisMaintenanceStore) Set(ctx context.Context, enabled bool) error {
+	return s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		if enabled {
+			p.Set(ctx, s.key, "1", 0)
+		} else {
+			p.Del(ctx, s.key)
+		}
+		return nil
+	})
+}
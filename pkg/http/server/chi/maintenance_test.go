@@ -0,0 +1,130 @@
+package chi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeMaintenanceStore struct {
+	enabled bool
+}
+
+func (f *fakeMaintenanceStore) Get(context.Context) (bool, error) {
+	return f.enabled, nil
+}
+
+func (f *fakeMaintenanceStore) Set(_ context.Context, enabled bool) error {
+	f.enabled = enabled
+	return nil
+}
+
+type MaintenanceTestSuite struct {
+	suite.Suite
+	sut *chi.Server
+}
+
+func TestMaintenanceSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceTestSuite))
+}
+
+func (s *MaintenanceTestSuite) SetupTest() {
+	cfg := chi.Default()
+	cfg.Maintenance = &chi.MaintenanceConfig{Message: "Back soon", RetryAfter: 2 * time.Minute}
+	cfg.Admin = &chi.AdminConfig{Enabled: true, Token: testAdminToken}
+	var err error
+	s.sut, err = chi.New(cfg)
+	s.Require().NoError(err)
+	s.sut.Router().Get("/orders", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (s *MaintenanceTestSuite) serve(handler http.Handler, method, target string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+	return rr
+}
+
+func (s *MaintenanceTestSuite) TestMaintenance_Disabled_ServesRoutes() {
+	// Act
+	rr := s.serve(s.sut.Router(), http.MethodGet, "/orders")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+}
+
+func (s *MaintenanceTestSuite) TestMaintenance_Enabled_Answers503ExceptHealthCheck() {
+	// Arrange
+	s.Require().NoError(s.sut.Maintenance().Enable(context.Background()))
+
+	// Act
+	orders := s.serve(s.sut.Router(), http.MethodGet, "/orders")
+	health := s.serve(s.sut.Router(), http.MethodGet, "/healthz")
+
+	// Assert
+	s.Equal(http.StatusServiceUnavailable, orders.Code)
+	s.Equal("120", orders.Header().Get("Retry-After"))
+	s.JSONEq(`{"error":{"code":"MAINTENANCE","message":"Back soon"}}`, orders.Body.String())
+	s.Equal(http.StatusOK, health.Code)
+}
+
+func (s *MaintenanceTestSuite) TestMaintenance_AdminEndpoint_SwitchesMode() {
+	// Act
+	enabled := serveAdmin(s.sut, http.MethodPut, "/maintenance", "")
+	whileEnabled := s.serve(s.sut.Router(), http.MethodGet, "/orders")
+	disabled := serveAdmin(s.sut, http.MethodDelete, "/maintenance", "")
+
+	// Assert
+	var body map[string]bool
+	s.Require().NoError(json.Unmarshal(enabled.Body.Bytes(), &body))
+	s.True(body["enabled"])
+	s.Equal(http.StatusServiceUnavailable, whileEnabled.Code)
+	s.Require().NoError(json.Unmarshal(disabled.Body.Bytes(), &body))
+	s.False(body["enabled"])
+	s.False(s.sut.Maintenance().Enabled())
+}
+
+func (s *MaintenanceTestSuite) TestMaintenance_AdminEndpoint_RejectsUnauthenticatedRequests() {
+	// Act
+	rr := s.serve(s.sut.MetricsHandler(), http.MethodPut, "/maintenance")
+
+	// Assert
+	s.Equal(http.StatusUnauthorized, rr.Code)
+	s.False(s.sut.Maintenance().Enabled())
+}
+
+func (s *MaintenanceTestSuite) TestMaintenance_AdminEndpoint_NotServedWithoutAdmin() {
+	// Arrange
+	sut, err := chi.New(chi.Default())
+	s.Require().NoError(err)
+
+	// Act
+	rr := s.serve(sut.MetricsHandler(), http.MethodPut, "/maintenance")
+
+	// Assert
+	s.Equal(http.StatusNotFound, rr.Code)
+	s.False(sut.Maintenance().Enabled())
+}
+
+func (s *MaintenanceTestSuite) TestMaintenance_Store_SharesMode() {
+	// Arrange
+	store := &fakeMaintenanceStore{}
+	s.sut.SetMaintenanceStore(store)
+
+	// Act
+	s.Require().NoError(s.sut.Maintenance().Enable(context.Background()))
+	storedAfterEnable := store.enabled
+	store.enabled = false
+	s.sut.Maintenance().Refresh()
+
+	// Assert
+	s.True(storedAfterEnable)
+	s.False(s.sut.Maintenance().Enabled())
+}
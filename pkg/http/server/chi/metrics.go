@@ -0,0 +1,37 @@
+package chi
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// defaultRegistryGatherer gathers the application gatherer along with
+// prometheus.DefaultGatherer. The application owns the metric families both have, e.g. the
+// Go and process collectors registered by metrics.RuntimeCollector, so the default ones are
+// dropped instead of failing the scrape as duplicates.
+type defaultRegistryGatherer struct {
+	gatherer prometheus.Gatherer
+}
+
+func (g defaultRegistryGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	defaults, defaultErr := prometheus.DefaultGatherer.Gather()
+
+	names := make(map[string]struct{}, len(families))
+	for _, family := range families {
+		names[family.GetName()] = struct{}{}
+	}
+	for _, family := range defaults {
+		if _, ok := names[family.GetName()]; !ok {
+			families = append(families, family)
+		}
+	}
+	slices.SortFunc(families, func(a, b *dto.MetricFamily) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return families, errors.Join(err, defaultErr)
+}
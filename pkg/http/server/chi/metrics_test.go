@@ -0,0 +1,141 @@
+package chi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+// failingCollector fails to collect, like a collector of a broken dependency.
+type failingCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c failingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c failingCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.NewInvalidMetric(c.desc, errors.New("connection refused"))
+}
+
+func serveMetrics(t *testing.T, cfg chi.Config, registry *prometheus.Registry) *httptest.ResponseRecorder {
+	t.Helper()
+	server, err := chi.New(cfg)
+	require.NoError(t, err)
+	server.SetMetricsGatherer(registry)
+	rr := httptest.NewRecorder()
+	server.MetricsHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rr
+}
+
+func TestSetMetricsGatherer_ServesTheGatherer(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_processed_total"}))
+
+	// Act
+	rr := serveMetrics(t, chi.Default(), registry)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "orders_processed_total")
+	assert.NotContains(t, rr.Body.String(), "go_goroutines")
+}
+
+func TestSetMetricsGatherer_WithMetricsDefaultRegistry_ServesTheDefaultRegistryToo(t *testing.T) {
+	// Arrange
+	cfg := chi.Default()
+	cfg.MetricsDefaultRegistry = true
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_processed_total"}))
+
+	// Act
+	rr := serveMetrics(t, cfg, registry)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "orders_processed_total")
+	assert.Contains(t, rr.Body.String(), "go_goroutines")
+	assert.Contains(t, rr.Body.String(), "process_")
+}
+
+func TestSetMetricsGatherer_WithMetricsDefaultRegistry_DropsTheDefaultDuplicates(t *testing.T) {
+	// Arrange
+	cfg := chi.Default()
+	cfg.MetricsDefaultRegistry = true
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "go_goroutines", Help: "Application."}))
+
+	// Act
+	rr := serveMetrics(t, cfg, registry)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "# HELP go_goroutines Application.")
+	assert.Contains(t, rr.Body.String(), "go_memstats_alloc_bytes")
+}
+
+func TestSetMetricsGatherer_ServesTheOtherCollectorsWhenOneFails(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_processed_total"}))
+	registry.MustRegister(failingCollector{
+		desc: prometheus.NewDesc("queue_depth", "Depth of the queue.", nil, nil),
+	})
+
+	// Act
+	rr := serveMetrics(t, chi.Default(), registry)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "orders_processed_total")
+	assert.NotContains(t, rr.Body.String(), "queue_depth")
+}
+
+func TestModule_ServesTheRuntimeCollectorsOfTheMetricsModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{"application registry", "app:\n  http:\n    port: 8080\n    metricsport: 9090\n"},
+		{
+			"with the default registry",
+			"app:\n  http:\n    port: 8080\n    metricsport: 9090\n    metricsdefaultregistry: true\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			configDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(configDir, "base.yaml"), []byte(tt.config), 0o600))
+			t.Setenv("APP_CONFIG_DIR", configDir)
+			t.Setenv("APP_ENV", "test")
+			var server *chi.Server
+			app := fx.New(fx.NopLogger, metrics.Module, metrics.RuntimeCollector, chi.Module, fx.Populate(&server))
+			require.NoError(t, app.Err())
+			rr := httptest.NewRecorder()
+
+			// Act
+			server.MetricsHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			// Assert
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, 1, strings.Count(rr.Body.String(), "# TYPE go_goroutines gauge"))
+			assert.Equal(t, 1, strings.Count(rr.Body.String(), "# TYPE process_cpu_seconds_total counter"))
+			assert.Contains(t, rr.Body.String(), "build_info")
+		})
+	}
+}
@@ -0,0 +1,423 @@
+package chi
+
+import (
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+const (
+	openAPIVersion        = "3.1.0"
+	defaultOpenAPIPath    = "/openapi.json"
+	defaultOpenAPITitle   = "API"
+	defaultOpenAPIVersion = "1.0.0"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// chiParamRe matches the chi path params, e.g. {id} or {id:[0-9]+}.
+var chiParamRe = regexp.MustCompile(`\{([^}:]+)(?::[^}]*)?\}`)
+
+// Operation documents a route in the OpenAPI document.
+//
+//	server.Handle(http.MethodPost, "/orders", h.Create, chi.Operation{
+//		Summary:   "Create an order",
+//		Tags:      []string{"orders"},
+//		Request:   CreateOrderRequest{},
+//		Responses: map[int]any{http.StatusCreated: OrderResponse{}},
+//		Security:  []string{"bearer"},
+//	})
+type Operation struct {
+	// Method and Path are set by Server.Handle; Path uses the chi syntax
+	Method string
+	Path   string
+
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+
+	// Request is a value of the request type. Its fields tagged `path`, `query` and
+	// `header` (see request.Bind) become parameters, the other fields the JSON body.
+	Request any
+	// Responses maps the status codes to a value of the response data, documented
+	// inside the {"data": ...} envelope of response.JSON. A nil value has no body.
+	// Error responses are documented as the errs.Error envelope.
+	Responses map[int]any
+	// Security lists the names of the security schemes accepted by the operation, any of
+	// them being sufficient. Empty means public.
+	Security []string
+	// Timeout replaces the RequestTimeout of the server for the route; negative removes
+	// the deadline (see Server.Timeout). It is not documented.
+	Timeout time.Duration
+}
+
+// OpenAPIConfig configures the OpenAPI document generated from the documented routes.
+type OpenAPIConfig struct {
+	Enabled     bool   // Whether to serve the document
+	Path        string // URL path of the document, default: /openapi.json
+	Title       string // default: API
+	Version     string // default: 1.0.0
+	Description string
+	Servers     []string
+	// SecuritySchemes are referenced by name from Operation.Security
+	SecuritySchemes map[string]SecurityScheme
+}
+
+// SecurityScheme is an OpenAPI security scheme, e.g. {Type: "http", Scheme: "bearer"}
+// or {Type: "apiKey", In: "header", Name: "X-API-Key"}.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       openAPIInfo                            `json:"info"`
+	Servers    []openAPIServer                        `json:"servers,omitempty"`
+	Paths      map[string]map[string]openAPIOperation `json:"paths"`
+	Components openAPIComponents                      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*schema        `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId,omitempty"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *schema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+// GenerateOpenAPI returns the OpenAPI 3.1 document of the operations as JSON.
+func GenerateOpenAPI(cfg OpenAPIConfig, operations []Operation) ([]byte, error) {
+	doc := openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:       cmp.Or(cfg.Title, defaultOpenAPITitle),
+			Version:     cmp.Or(cfg.Version, defaultOpenAPIVersion),
+			Description: cfg.Description,
+		},
+		Paths: map[string]map[string]openAPIOperation{},
+		Components: openAPIComponents{
+			SecuritySchemes: cfg.SecuritySchemes,
+		},
+	}
+	for _, url := range cfg.Servers {
+		doc.Servers = append(doc.Servers, openAPIServer{URL: url})
+	}
+
+	schemas := newSchemaRegistry()
+	errorSchema := schemas.envelope("error", schemas.schemaOf(reflect.TypeFor[errs.Error]()))
+	for _, op := range operations {
+		path := chiParamRe.ReplaceAllString(op.Path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]openAPIOperation{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = buildOperation(schemas, op, errorSchema)
+	}
+	doc.Components.Schemas = schemas.components
+
+	return json.Marshal(doc)
+}
+
+func buildOperation(schemas *schemaRegistry, op Operation, errorSchema *schema) openAPIOperation {
+	result := openAPIOperation{
+		OperationID: op.OperationID,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Deprecated:  op.Deprecated,
+		Responses:   map[string]openAPIResponse{},
+	}
+
+	bound := map[string]bool{}
+	if op.Request != nil {
+		result.Parameters, result.RequestBody = schemas.request(reflect.TypeOf(op.Request), op.Method)
+		for _, param := range result.Parameters {
+			bound[param.In+":"+param.Name] = true
+		}
+	}
+	// Path params missing from the request type are documented as strings
+	for _, match := range chiParamRe.FindAllStringSubmatch(op.Path, -1) {
+		if !bound["path:"+match[1]] {
+			result.Parameters = append(result.Parameters, openAPIParameter{
+				Name: match[1], In: "path", Required: true, Schema: &schema{Type: "string"},
+			})
+		}
+	}
+
+	for status, data := range op.Responses {
+		response := openAPIResponse{Description: cmp.Or(http.StatusText(status), strconv.Itoa(status))}
+		if data != nil {
+			body := errorSchema
+			if status < http.StatusBadRequest {
+				body = schemas.envelope("data", schemas.schemaOf(reflect.TypeOf(data)))
+			}
+			response.Content = jsonContent(body)
+		}
+		result.Responses[strconv.Itoa(status)] = response
+	}
+	result.Responses["default"] = openAPIResponse{
+		Description: "Error",
+		Content:     jsonContent(errorSchema),
+	}
+
+	for _, name := range op.Security {
+		result.Security = append(result.Security, map[string][]string{name: {}})
+	}
+	return result
+}
+
+// schema is the subset of JSON Schema used by the generated document.
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+}
+
+// schemaRegistry builds the schemas of Go types, defining the named structs once in
+// the components.
+type schemaRegistry struct {
+	components map[string]*schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{components: map[string]*schema{}, names: map[reflect.Type]string{}}
+}
+
+// request returns the parameters and the body of a request type.
+func (r *schemaRegistry) request(t reflect.Type, method string) ([]openAPIParameter, *openAPIRequestBody) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, &openAPIRequestBody{Required: true, Content: jsonContent(r.schemaOf(t))}
+	}
+
+	var params []openAPIParameter
+	body := &schema{Type: "object", Properties: map[string]*schema{}}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		if name, in := paramTag(field); in != "" {
+			params = append(params, openAPIParameter{
+				Name:     name,
+				In:       in,
+				Required: in == "path" || isRequired(field),
+				Schema:   r.schemaOf(field.Type),
+			})
+			continue
+		}
+		r.addProperty(body, field)
+	}
+
+	if len(body.Properties) == 0 || method == http.MethodGet || method == http.MethodDelete {
+		return params, nil
+	}
+	return params, &openAPIRequestBody{Required: true, Content: jsonContent(body)}
+}
+
+// envelope returns the schema of {key: inner}.
+func (r *schemaRegistry) envelope(key string, inner *schema) *schema {
+	return &schema{Type: "object", Properties: map[string]*schema{key: inner}, Required: []string{key}}
+}
+
+func (r *schemaRegistry) schemaOf(t reflect.Type) *schema {
+	if t.Kind() == reflect.Pointer {
+		return r.schemaOf(t.Elem())
+	}
+
+	switch {
+	case t == timeType:
+		return &schema{Type: "string", Format: "date-time"}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &schema{Type: "integer", Format: intFormat(t)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &schema{Type: "integer", Format: intFormat(t), Minimum: &zero}
+	case reflect.Float32:
+		return &schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &schema{Type: "string", Format: "byte"}
+		}
+		return &schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return r.structSchema(t)
+	default:
+		// Interfaces and other kinds accept any value
+		return &schema{}
+	}
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *schema {
+	if t.Name() == "" {
+		return r.buildStruct(t)
+	}
+	if name, ok := r.names[t]; ok {
+		return &schema{Ref: "#/components/schemas/" + name}
+	}
+
+	name := t.Name()
+	if _, taken := r.components[name]; taken {
+		// Same name in another package
+		name = strings.ReplaceAll(t.String(), ".", "_")
+	}
+	r.names[t] = name
+	r.components[name] = &schema{} // reserves the name while the fields are built
+	r.components[name] = r.buildStruct(t)
+	return &schema{Ref: "#/components/schemas/" + name}
+}
+
+func (r *schemaRegistry) buildStruct(t reflect.Type) *schema {
+	result := &schema{Type: "object", Properties: map[string]*schema{}}
+	for _, field := range reflect.VisibleFields(t) {
+		if field.IsExported() && !field.Anonymous {
+			r.addProperty(result, field)
+		}
+	}
+	return result
+}
+
+func (r *schemaRegistry) addProperty(object *schema, field reflect.StructField) {
+	name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" && opts == "" {
+		return
+	}
+	if name == "" {
+		name = field.Name
+	}
+
+	property := r.schemaOf(field.Type)
+	if slices.Contains(strings.Split(opts, ","), "string") {
+		property = &schema{Type: "string"}
+	}
+	if enum := oneOf(field); len(enum) > 0 && property.Ref == "" {
+		property.Enum = enum
+	}
+	if field.Type.Kind() == reflect.Pointer && property.Ref == "" {
+		if typeName, ok := property.Type.(string); ok {
+			property.Type = []string{typeName, "null"}
+		}
+	}
+
+	object.Properties[name] = property
+	if isRequired(field) {
+		object.Required = append(object.Required, name)
+	}
+}
+
+func jsonContent(body *schema) map[string]openAPIMediaType {
+	return map[string]openAPIMediaType{"application/json": {Schema: body}}
+}
+
+// paramTag returns the parameter name and location of a field bound by request.Bind.
+func paramTag(field reflect.StructField) (string, string) {
+	for _, in := range []string{"path", "query", "header"} {
+		if name := field.Tag.Get(in); name != "" {
+			return name, in
+		}
+	}
+	return "", ""
+}
+
+func validateRules(field reflect.StructField) []string {
+	return strings.Split(field.Tag.Get("validate"), ",")
+}
+
+func isRequired(field reflect.StructField) bool {
+	return slices.Contains(validateRules(field), "required")
+}
+
+func oneOf(field reflect.StructField) []string {
+	for _, rule := range validateRules(field) {
+		if values, ok := strings.CutPrefix(rule, "oneof="); ok {
+			return strings.Fields(values)
+		}
+	}
+	return nil
+}
+
+func intFormat(t reflect.Type) string {
+	if t.Bits() == 64 {
+		return "int64"
+	}
+	return "int32"
+}
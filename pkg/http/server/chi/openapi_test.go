@@ -0,0 +1,122 @@
+package chi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createOrderRequest struct {
+	CustomerID uint64      `path:"customer_id"`
+	DryRun     bool        `query:"dry_run"`
+	Items      []orderItem `json:"items"  validate:"required"`
+	Channel    string      `json:"channel" validate:"required,oneof=web app"`
+	Note       *string     `json:"note,omitempty"`
+}
+
+type orderItem struct {
+	SKU      string `json:"sku"      validate:"required"`
+	Quantity int32  `json:"quantity"`
+}
+
+type orderResponse struct {
+	ID        uint64      `json:"id"`
+	Items     []orderItem `json:"items"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	// Arrange
+	cfg := chi.OpenAPIConfig{
+		Title:           "Orders",
+		SecuritySchemes: map[string]chi.SecurityScheme{"bearer": {Type: "http", Scheme: "bearer"}},
+	}
+	operations := []chi.Operation{
+		{
+			Method:    http.MethodPost,
+			Path:      "/customers/{customer_id:[0-9]+}/orders",
+			Summary:   "Create an order",
+			Request:   createOrderRequest{},
+			Responses: map[int]any{http.StatusCreated: orderResponse{}, http.StatusConflict: struct{}{}},
+			Security:  []string{"bearer"},
+		},
+		{Method: http.MethodDelete, Path: "/orders/{id}", Responses: map[int]any{http.StatusNoContent: nil}},
+	}
+
+	// Act
+	raw, err := chi.GenerateOpenAPI(cfg, operations)
+
+	// Assert
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	assert.Equal(t, "3.1.0", doc["openapi"])
+	assert.Equal(t, "Orders", lookup(t, doc, "info", "title"))
+
+	create := lookup(t, doc, "paths", "/customers/{customer_id}/orders", "post")
+	assert.Equal(t, "Create an order", lookup(t, create, "summary"))
+	assert.Equal(t, []any{
+		map[string]any{"name": "customer_id", "in": "path", "required": true,
+			"schema": map[string]any{"type": "integer", "format": "int64", "minimum": 0.0}},
+		map[string]any{"name": "dry_run", "in": "query", "schema": map[string]any{"type": "boolean"}},
+	}, lookup(t, create, "parameters"))
+	assert.Equal(t, []any{map[string]any{"bearer": []any{}}}, lookup(t, create, "security"))
+
+	body := lookup(t, create, "requestBody", "content", "application/json", "schema")
+	assert.Equal(t, []any{"items", "channel"}, lookup(t, body, "required"))
+	assert.Equal(t, []any{"web", "app"}, lookup(t, body, "properties", "channel", "enum"))
+	assert.Equal(t, []any{"string", "null"}, lookup(t, body, "properties", "note", "type"))
+	assert.Equal(t, "#/components/schemas/orderItem", lookup(t, body, "properties", "items", "items", "$ref"))
+
+	created := lookup(t, create, "responses", "201", "content", "application/json", "schema")
+	assert.Equal(t, "#/components/schemas/orderResponse", lookup(t, created, "properties", "data", "$ref"))
+	conflict := lookup(t, create, "responses", "409", "content", "application/json", "schema")
+	assert.Equal(t, "#/components/schemas/Error", lookup(t, conflict, "properties", "error", "$ref"))
+	assert.Equal(t, "date-time", lookup(t, doc, "components", "schemas", "orderResponse", "properties",
+		"created_at", "format"))
+
+	remove := lookup(t, doc, "paths", "/orders/{id}", "delete")
+	assert.Equal(t, "id", lookup(t, remove, "parameters").([]any)[0].(map[string]any)["name"])
+	assert.NotContains(t, lookup(t, remove, "responses", "204"), "content")
+}
+
+func TestServer_Handle_ServesOpenAPIDocument(t *testing.T) {
+	// Arrange
+	cfg := chi.Default()
+	cfg.OpenAPI = &chi.OpenAPIConfig{Enabled: true}
+	server, err := chi.New(cfg)
+	require.NoError(t, err)
+	server.Handle(http.MethodGet, "/orders/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, chi.Operation{Summary: "Get an order", Responses: map[int]any{http.StatusOK: orderResponse{}}})
+	server.SetupRoutes()
+	rr := httptest.NewRecorder()
+
+	// Act
+	server.Router().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+	assert.Equal(t, "Get an order", lookup(t, doc, "paths", "/orders/{id}", "get", "summary"))
+}
+
+// lookup returns the value at the path of keys in a decoded JSON object.
+func lookup(t *testing.T, value any, keys ...string) any {
+	t.Helper()
+	for _, key := range keys {
+		object, ok := value.(map[string]any)
+		require.True(t, ok, "%s is not an object", key)
+		value, ok = object[key]
+		require.True(t, ok, "%s is missing", key)
+	}
+	return value
+}
@@ -42,6 +42,13 @@ func WithShutdownTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithRequestTimeout sets the deadline of the request contexts.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.RequestTimeout = timeout
+	}
+}
+
 // WithCORS sets CORS configuration.
 func WithCORS(cors *CORSConfig) Option {
 	return func(c *Config) {
@@ -78,3 +85,19 @@ func WithSwagger(enabled bool, swaggerPath string) Option {
 		}
 	}
 }
+
+// WithOpenAPI serves the OpenAPI document generated from the documented routes.
+func WithOpenAPI(openAPI OpenAPIConfig) Option {
+	return func(c *Config) {
+		openAPI.Enabled = true
+		c.OpenAPI = &openAPI
+	}
+}
+
+// WithStatic serves static assets, from static.Dir or the StaticFS set with SetStaticFS.
+func WithStatic(static StaticConfig) Option {
+	return func(c *Config) {
+		static.Enabled = true
+		c.Static = &static
+	}
+}
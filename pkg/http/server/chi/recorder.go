@@ -0,0 +1,349 @@
+package chi
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	recorderPath              = "/recorder"
+	defaultRecorderSize       = 100
+	defaultRecorderMaxBody    = 4096
+	redactedValue             = "[REDACTED]"
+	truncatedBodySuffix       = "...[TRUNCATED]"
+	maxRecorderRedactionDepth = 32
+)
+
+var (
+	defaultRedactHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization", "X-Api-Key"}
+	defaultRedactFields  = []string{"password", "token", "access_token", "refresh_token", "secret", "api_key"}
+)
+
+// RecorderConfig configures the flight recorder, which keeps the last requests and
+// responses in memory for debugging.
+type RecorderConfig struct {
+	Enabled       bool     // Whether the recorder starts recording
+	Size          int      // Number of exchanges kept, default: 100
+	MaxBodySize   int      // Bytes of each body kept, default: 4096
+	RedactHeaders []string // Headers replaced by [REDACTED] on top of Authorization, Cookie, Set-Cookie, ...
+	RedactFields  []string // JSON fields and query params replaced by [REDACTED], default: password, token, ...
+}
+
+// Exchange is a request and its response, as recorded by the Recorder.
+type Exchange struct {
+	RequestID       string              `json:"request_id,omitempty"`
+	Time            time.Time           `json:"time"`
+	Duration        time.Duration       `json:"duration"`
+	Method          string              `json:"method"`
+	URL             string              `json:"url"`
+	Route           string              `json:"route,omitempty"`
+	RequestHeaders  map[string][]string `json:"request_headers"`
+	RequestBody     string              `json:"request_body,omitempty"`
+	Status          int                 `json:"status"`
+	ResponseHeaders map[string][]string `json:"response_headers"`
+	ResponseBody    string              `json:"response_body,omitempty"`
+}
+
+// Recorder is the flight recorder of a server: a ring buffer of the last exchanges, with
+// capped and redacted bodies. It is switched at runtime with Start/Stop or the
+// /recorder admin endpoint of the metrics server.
+type Recorder struct {
+	enabled       atomic.Bool
+	maxBodySize   int
+	redactHeaders []string
+	redactFields  []string
+	// redactPairs matches the "field": value pairs of the JSON bodies that do not parse
+	redactPairs *regexp.Regexp
+
+	mu        sync.Mutex
+	exchanges []Exchange
+	next      int
+	full      bool
+}
+
+func newRecorder(cfg *RecorderConfig) *Recorder {
+	if cfg == nil {
+		cfg = &RecorderConfig{}
+	}
+	// The credentials headers are always redacted, the configured ones are added to them
+	redactHeaders := slices.Concat(defaultRedactHeaders, cfg.RedactHeaders)
+	redactFields := cfg.RedactFields
+	if len(redactFields) == 0 {
+		redactFields = defaultRedactFields
+	}
+
+	quoted := make([]string, len(redactFields))
+	for i, field := range redactFields {
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+
+	r := &Recorder{
+		maxBodySize:   cmp.Or(max(cfg.MaxBodySize, 0), defaultRecorderMaxBody),
+		redactHeaders: redactHeaders,
+		redactFields:  redactFields,
+		redactPairs: regexp.MustCompile(
+			`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`,
+		),
+		exchanges: make([]Exchange, cmp.Or(max(cfg.Size, 0), defaultRecorderSize)),
+	}
+	r.enabled.Store(cfg.Enabled)
+	return r
+}
+
+// Recording reports whether the recorder records the exchanges.
+func (rec *Recorder) Recording() bool {
+	return rec.enabled.Load()
+}
+
+// Start starts recording the exchanges.
+func (rec *Recorder) Start() {
+	rec.enabled.Store(true)
+}
+
+// Stop stops recording and drops the recorded exchanges, so they are not kept in memory
+// longer than needed.
+func (rec *Recorder) Stop() {
+	rec.enabled.Store(false)
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	clear(rec.exchanges)
+	rec.next = 0
+	rec.full = false
+}
+
+// Exchanges returns the recorded exchanges, newest first.
+func (rec *Recorder) Exchanges() []Exchange {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	count := rec.next
+	if rec.full {
+		count = len(rec.exchanges)
+	}
+	result := make([]Exchange, 0, count)
+	for i := 1; i <= count; i++ {
+		result = append(result, rec.exchanges[(rec.next-i+len(rec.exchanges))%len(rec.exchanges)])
+	}
+	return result
+}
+
+// Middleware records the exchanges while the recorder is recording.
+func (rec *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !rec.enabled.Load() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		requestBody := &cappedBuffer{limit: rec.maxBodySize}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &teeReadCloser{Reader: io.TeeReader(r.Body, requestBody), Closer: r.Body}
+		}
+		rw := &recordingWriter{ResponseWriter: w, body: cappedBuffer{limit: rec.maxBodySize}}
+
+		next.ServeHTTP(rw, r)
+
+		rec.add(rec.exchange(r, rw, requestBody, start))
+	})
+}
+
+// ServeHTTP is the admin endpoint: GET returns the exchanges, PUT starts recording and
+// DELETE stops it. The server mounts it behind the admin token only, as the exchanges
+// carry the request and response payloads.
+func (rec *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		rec.Start()
+	case http.MethodDelete:
+		rec.Stop()
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"recording": rec.Recording(),
+		"exchanges": rec.Exchanges(),
+	})
+}
+
+func (rec *Recorder) add(exchange Exchange) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.exchanges[rec.next] = exchange
+	rec.next = (rec.next + 1) % len(rec.exchanges)
+	if rec.next == 0 {
+		rec.full = true
+	}
+}
+
+func (rec *Recorder) exchange(
+	r *http.Request,
+	rw *recordingWriter,
+	requestBody *cappedBuffer,
+	start time.Time,
+) Exchange {
+	exchange := Exchange{
+		Time:            start,
+		Duration:        time.Since(start),
+		Method:          r.Method,
+		URL:             rec.redactURL(r.URL),
+		RequestHeaders:  rec.redactHeaderValues(r.Header),
+		RequestBody:     rec.redactBody(requestBody),
+		Status:          rw.status,
+		ResponseHeaders: rec.redactHeaderValues(rw.Header()),
+		ResponseBody:    rec.redactBody(&rw.body),
+	}
+	if exchange.Status == 0 {
+		exchange.Status = http.StatusOK
+	}
+	exchange.RequestID, _ = ctxmeta.RequestID(r.Context())
+	if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+		exchange.Route = routeCtx.RoutePattern()
+	}
+	return exchange
+}
+
+func (rec *Recorder) redactHeaderValues(header http.Header) map[string][]string {
+	result := make(map[string][]string, len(header))
+	for name, values := range header {
+		if slices.ContainsFunc(rec.redactHeaders, func(redacted string) bool {
+			return strings.EqualFold(redacted, name)
+		}) {
+			result[name] = []string{redactedValue}
+			continue
+		}
+		result[name] = slices.Clone(values)
+	}
+	return result
+}
+
+func (rec *Recorder) redactURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+	changed := false
+	for name := range query {
+		if rec.isRedactedField(name) {
+			query[name] = []string{redactedValue}
+			changed = true
+		}
+	}
+	if changed {
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.String()
+}
+
+// redactBody returns the body with the redacted fields replaced: through the decoded
+// document when it is complete JSON, and by matching the "field": value pairs otherwise,
+// e.g. for truncated bodies.
+func (rec *Recorder) redactBody(body *cappedBuffer) string {
+	if body.Len() == 0 {
+		return ""
+	}
+	if body.truncated {
+		return rec.redactPairs.ReplaceAllString(body.String(), `${1}"`+redactedValue+`"`) + truncatedBodySuffix
+	}
+
+	var document any
+	if err := json.Unmarshal(body.Bytes(), &document); err == nil {
+		if redacted, marshalErr := json.Marshal(rec.redactValue(document, 0)); marshalErr == nil {
+			return string(redacted)
+		}
+	}
+	return rec.redactPairs.ReplaceAllString(body.String(), `${1}"`+redactedValue+`"`)
+}
+
+func (rec *Recorder) redactValue(value any, depth int) any {
+	if depth > maxRecorderRedactionDepth {
+		return value
+	}
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, nested := range typed {
+			if rec.isRedactedField(key) {
+				typed[key] = redactedValue
+				continue
+			}
+			typed[key] = rec.redactValue(nested, depth+1)
+		}
+	case []any:
+		for i, nested := range typed {
+			typed[i] = rec.redactValue(nested, depth+1)
+		}
+	}
+	return value
+}
+
+func (rec *Recorder) isRedactedField(name string) bool {
+	return slices.ContainsFunc(rec.redactFields, func(redacted string) bool {
+		return strings.EqualFold(redacted, name)
+	})
+}
+
+// cappedBuffer keeps the first limit bytes written to it.
+type cappedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// recordingWriter keeps the status and the beginning of the body of the response.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   cappedBuffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	_, _ = w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush.
+func (w *recordingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
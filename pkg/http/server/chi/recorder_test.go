@@ -0,0 +1,161 @@
+package chi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecorderTestSuite struct {
+	suite.Suite
+	sut *chi.Server
+}
+
+func TestRecorderSuite(t *testing.T) {
+	suite.Run(t, new(RecorderTestSuite))
+}
+
+func (s *RecorderTestSuite) SetupTest() {
+	cfg := chi.Default()
+	cfg.Recorder = &chi.RecorderConfig{Enabled: true, Size: 2, MaxBodySize: 64, RedactHeaders: []string{"X-Tenant"}}
+	cfg.Admin = &chi.AdminConfig{Enabled: true, Token: testAdminToken}
+	var err error
+	s.sut, err = chi.New(cfg)
+	s.Require().NoError(err)
+	s.sut.Router().Post("/sessions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	})
+}
+
+func (s *RecorderTestSuite) post(target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Tenant", "acme")
+	rr := httptest.NewRecorder()
+	s.sut.Router().ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *RecorderTestSuite) TestRecorder_RecordsRedactedExchanges() {
+	// Act
+	rr := s.post("/sessions/1?token=abc&page=2", `{"user":"ana","password":"hunter2"}`)
+
+	// Assert
+	s.JSONEq(`{"user":"ana","password":"hunter2"}`, rr.Body.String())
+	exchanges := s.sut.Recorder().Exchanges()
+	s.Require().Len(exchanges, 1)
+	exchange := exchanges[0]
+	s.Equal(http.MethodPost, exchange.Method)
+	s.Equal("/sessions/{id}", exchange.Route)
+	s.Equal(http.StatusCreated, exchange.Status)
+	s.NotEmpty(exchange.RequestID)
+	s.Contains(exchange.URL, "token=%5BREDACTED%5D")
+	s.Contains(exchange.URL, "page=2")
+	s.Equal([]string{"[REDACTED]"}, exchange.RequestHeaders["Authorization"])
+	s.Equal([]string{"[REDACTED]"}, exchange.RequestHeaders["X-Tenant"])
+	s.Equal([]string{"[REDACTED]"}, exchange.ResponseHeaders["Set-Cookie"])
+	s.JSONEq(`{"user":"ana","password":"[REDACTED]"}`, exchange.RequestBody)
+	s.JSONEq(`{"user":"ana","password":"[REDACTED]"}`, exchange.ResponseBody)
+}
+
+func (s *RecorderTestSuite) TestRecorder_CapsBodies() {
+	// Act
+	rr := s.post("/sessions/1", strings.Repeat("a", 100))
+
+	// Assert
+	s.Len(rr.Body.String(), 100)
+	exchange := s.sut.Recorder().Exchanges()[0]
+	s.Equal(strings.Repeat("a", 64)+"...[TRUNCATED]", exchange.RequestBody)
+	s.Equal(strings.Repeat("a", 64)+"...[TRUNCATED]", exchange.ResponseBody)
+}
+
+func (s *RecorderTestSuite) TestRecorder_RedactsTruncatedJSONBodies() {
+	// Act
+	s.post("/sessions/1", `{"password": "hunter2", "note": "`+strings.Repeat("a", 64)+`"}`)
+
+	// Assert
+	exchange := s.sut.Recorder().Exchanges()[0]
+	s.NotContains(exchange.RequestBody, "hunter2")
+	s.True(strings.HasPrefix(exchange.RequestBody, `{"password": "[REDACTED]", "note": "aaa`))
+	s.True(strings.HasSuffix(exchange.RequestBody, "...[TRUNCATED]"))
+}
+
+func (s *RecorderTestSuite) TestRecorder_KeepsTheLastExchangesNewestFirst() {
+	// Act
+	s.post("/sessions/1", "first")
+	s.post("/sessions/2", "second")
+	s.post("/sessions/3", "third")
+
+	// Assert
+	exchanges := s.sut.Recorder().Exchanges()
+	s.Require().Len(exchanges, 2)
+	s.Equal("third", exchanges[0].RequestBody)
+	s.Equal("second", exchanges[1].RequestBody)
+}
+
+func (s *RecorderTestSuite) TestRecorder_AdminEndpoint_SwitchesRecording() {
+	// Arrange
+	s.post("/sessions/1", "first")
+
+	// Act
+	listed := serveAdmin(s.sut, http.MethodGet, "/recorder", "")
+	stopped := serveAdmin(s.sut, http.MethodDelete, "/recorder", "")
+	s.post("/sessions/2", "second")
+
+	// Assert
+	var body struct {
+		Recording bool           `json:"recording"`
+		Exchanges []chi.Exchange `json:"exchanges"`
+	}
+	s.Require().NoError(json.Unmarshal(listed.Body.Bytes(), &body))
+	s.True(body.Recording)
+	s.Require().Len(body.Exchanges, 1)
+	s.Equal("first", body.Exchanges[0].RequestBody)
+	s.Require().NoError(json.Unmarshal(stopped.Body.Bytes(), &body))
+	s.False(body.Recording)
+	s.Empty(body.Exchanges)
+	s.Empty(s.sut.Recorder().Exchanges())
+}
+
+func (s *RecorderTestSuite) TestRecorder_AdminEndpoint_RequiresTheAdminToken() {
+	// Arrange
+	s.post("/sessions/1", "first")
+
+	// Act
+	listed := httptest.NewRecorder()
+	s.sut.MetricsHandler().ServeHTTP(listed, httptest.NewRequest(http.MethodGet, "/recorder", nil))
+	stopped := httptest.NewRecorder()
+	s.sut.MetricsHandler().ServeHTTP(stopped, httptest.NewRequest(http.MethodDelete, "/recorder", nil))
+
+	// Assert
+	s.Equal(http.StatusUnauthorized, listed.Code)
+	s.NotContains(listed.Body.String(), "first")
+	s.Equal(http.StatusUnauthorized, stopped.Code)
+	s.True(s.sut.Recorder().Recording())
+	s.Len(s.sut.Recorder().Exchanges(), 1)
+}
+
+func TestRecorder_WithoutAdmin_IsNotServed(t *testing.T) {
+	// Arrange
+	server, err := chi.New(chi.Default())
+	require.NoError(t, err)
+
+	// Act
+	rr := httptest.NewRecorder()
+	server.MetricsHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/recorder", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.False(t, server.Recorder().Recording())
+}
@@ -0,0 +1,140 @@
+package chi
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/http/httpguts"
+)
+
+// Recoverer is the panic recovery middleware of the server. It logs the panic with its
+// stack trace and the request metadata, counts it in http_panics_total{method,route} and
+// answers 500 with the errs.ErrInternal envelope through the response error handler.
+type Recoverer struct {
+	errorHandler response.ErrorHandler
+	logger       logger.Logger
+	panics       *prometheus.CounterVec
+	reporter     errreport.Reporter
+}
+
+type recovererOptions struct {
+	registerer prometheus.Registerer
+	reporter   errreport.Reporter
+}
+
+// RecovererOption configures a Recoverer.
+type RecovererOption func(*recovererOptions)
+
+// WithPanicRegisterer sets the Prometheus registerer of the panic counter.
+// Default: prometheus.DefaultRegisterer.
+func WithPanicRegisterer(registerer prometheus.Registerer) RecovererOption {
+	return func(o *recovererOptions) {
+		o.registerer = registerer
+	}
+}
+
+// WithPanicReporter reports the recovered panics with their stack trace and request to an
+// error tracking service, e.g. the errreport.Reporter of errreport.Module.
+func WithPanicReporter(reporter errreport.Reporter) RecovererOption {
+	return func(o *recovererOptions) {
+		o.reporter = reporter
+	}
+}
+
+// NewRecoverer creates a Recoverer. A nil errorHandler writes the envelope without
+// translation; a nil log logs with slog.Default.
+func NewRecoverer(errorHandler response.ErrorHandler, log logger.Logger, opts ...RecovererOption) (*Recoverer, error) {
+	options := recovererOptions{registerer: prometheus.DefaultRegisterer}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if errorHandler == nil {
+		errorHandler = response.NewErrorHandler(nil, log)
+	}
+
+	panics, err := metrics.Register(options.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_panics_total",
+		Help: "Number of panics recovered while handling HTTP requests.",
+	}, []string{"method", "route"}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register panic metric: %w", err)
+	}
+
+	return &Recoverer{errorHandler: errorHandler, logger: log, panics: panics, reporter: options.reporter}, nil
+}
+
+// Middleware recovers the panics of next.
+//
+//	router.Use(recoverer.Middleware)
+func (rc *Recoverer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rc.serve(w, r, next)
+	})
+}
+
+func (rc *Recoverer) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+			// Aborting the response is the documented way to stop a handler
+			panic(recovered)
+		}
+		rc.recovered(w, r, recovered, debug.Stack())
+	}()
+	next.ServeHTTP(w, r)
+}
+
+func (rc *Recoverer) recovered(w http.ResponseWriter, r *http.Request, recovered any, stack []byte) {
+	ctx := r.Context()
+	route := ""
+	if routeCtx := chi.RouteContext(ctx); routeCtx != nil {
+		route = routeCtx.RoutePattern()
+	}
+	rc.panics.WithLabelValues(r.Method, route).Inc()
+
+	panicErr, ok := recovered.(error)
+	if !ok {
+		panicErr = fmt.Errorf("%v", recovered)
+	}
+	if rc.logger != nil {
+		rc.logger.Error("panic recovered", append(logger.ContextFields(ctx),
+			logger.String("method", r.Method),
+			logger.String("path", r.URL.Path),
+			logger.String("route", route),
+			logger.Error(panicErr),
+			logger.String("stack", string(stack)),
+		)...)
+	} else {
+		slog.Default().ErrorContext(ctx, "panic recovered",
+			"method", r.Method, "path", r.URL.Path, "route", route, "err", panicErr, "stack", string(stack))
+	}
+	if rc.reporter != nil {
+		rc.reporter.Report(ctx, errreport.Event{
+			Level:   errreport.LevelFatal,
+			Message: "panic recovered: " + panicErr.Error(),
+			Err:     panicErr,
+			Tags:    map[string]string{"method": r.Method, "route": route},
+			Request: errreport.NewRequest(r),
+		})
+	}
+
+	if httpguts.HeaderValuesContainsToken(r.Header["Connection"], "upgrade") {
+		// The connection was hijacked, there is no response to write
+		return
+	}
+	rc.errorHandler.ErrorCtx(ctx, w, errs.ErrInternal)
+}
@@ -0,0 +1,129 @@
+package chi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	gochi "github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panicReporter struct {
+	events []errreport.Event
+}
+
+func (r *panicReporter) Report(_ context.Context, event errreport.Event) {
+	r.events = append(r.events, event)
+}
+
+func (r *panicReporter) Flush(context.Context) error {
+	return nil
+}
+
+func TestRecoverer(t *testing.T) {
+	t.Run("writes the internal error envelope and counts the panic", func(t *testing.T) {
+		// Arrange
+		registry := prometheus.NewRegistry()
+		log := logger.MustNewWithOptions(logger.WithLevel("fatal"))
+		recoverer, err := chi.NewRecoverer(nil, log, chi.WithPanicRegisterer(registry))
+		require.NoError(t, err)
+
+		router := gochi.NewRouter()
+		router.Use(ctxmeta.Middleware)
+		router.Use(recoverer.Middleware)
+		router.Get("/orders/{id}", func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		})
+		req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+		req.Header.Set(ctxmeta.HeaderRequestID, "req-1")
+		rr := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		var body map[string]map[string]string
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "INTERNAL", body["error"]["code"])
+		assert.Equal(t, "req-1", body["error"]["request_id"])
+
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		require.Len(t, families, 1)
+		assert.Equal(t, "http_panics_total", families[0].GetName())
+		metric := families[0].GetMetric()[0]
+		assert.InDelta(t, 1, metric.GetCounter().GetValue(), 0)
+		assert.Equal(t, "/orders/{id}", metric.GetLabel()[1].GetValue())
+	})
+
+	t.Run("does not write a response on upgraded connections", func(t *testing.T) {
+		for _, connection := range []string{"Upgrade", "upgrade", "keep-alive, Upgrade"} {
+			// Arrange
+			recoverer, err := chi.NewRecoverer(nil, nil, chi.WithPanicRegisterer(prometheus.NewRegistry()))
+			require.NoError(t, err)
+			handler := recoverer.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				panic("boom")
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			req.Header.Set("Connection", connection)
+			rr := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rr, req)
+
+			// Assert
+			assert.Empty(t, rr.Body.String(), connection)
+		}
+	})
+
+	t.Run("reports the panic with its request", func(t *testing.T) {
+		// Arrange
+		reporter := &panicReporter{}
+		recoverer, err := chi.NewRecoverer(nil, nil,
+			chi.WithPanicRegisterer(prometheus.NewRegistry()),
+			chi.WithPanicReporter(reporter),
+		)
+		require.NoError(t, err)
+
+		router := gochi.NewRouter()
+		router.Use(recoverer.Middleware)
+		router.Post("/orders", func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		})
+
+		// Act
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+		// Assert
+		require.Len(t, reporter.events, 1)
+		event := reporter.events[0]
+		assert.Equal(t, errreport.LevelFatal, event.Level)
+		assert.Equal(t, "panic recovered: boom", event.Message)
+		assert.Equal(t, "/orders", event.Tags["route"])
+		assert.Equal(t, http.MethodPost, event.Request.Method)
+	})
+
+	t.Run("re-panics http.ErrAbortHandler", func(t *testing.T) {
+		// Arrange
+		recoverer, err := chi.NewRecoverer(nil, nil, chi.WithPanicRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		handler := recoverer.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		// Act & Assert
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
@@ -7,8 +7,10 @@ type Route interface {
 }
 
 // RouteRegistry manages a collection of routes and sets them up on the server.
+// It also keeps the operations documented in the OpenAPI document.
 type RouteRegistry struct {
-	routes []Route
+	routes     []Route
+	operations []Operation
 }
 
 // NewRouteRegistry creates a new route registry.
@@ -27,3 +29,14 @@ func (r *RouteRegistry) SetupAll(server *Server) {
 		route.Setup(server)
 	}
 }
+
+// AddOperation documents an operation in the OpenAPI document. Server.Handle calls it;
+// call it directly for routes registered on the chi router.
+func (r *RouteRegistry) AddOperation(op Operation) {
+	r.operations = append(r.operations, op)
+}
+
+// Operations returns the documented operations.
+func (r *RouteRegistry) Operations() []Operation {
+	return r.operations
+}
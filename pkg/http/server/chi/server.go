@@ -1,17 +1,31 @@
 package chi
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
+	"maps"
+	"net"
 	"net/http"
+	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/ipfilter"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/samber/lo"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
@@ -27,12 +41,26 @@ const (
 
 // Server wraps an HTTP server with Chi router.
 type Server struct {
-	server        *http.Server
-	router        *chi.Mux
-	metricsServer *http.Server
-	config        Config
-	registry      *RouteRegistry
-	logger        *slog.Logger
+	server           *http.Server
+	router           *chi.Mux
+	metricsServer    *http.Server
+	metricsHandler   http.Handler
+	buildInfoHandler http.Handler
+	config           Config
+	registry         *RouteRegistry
+	logger           *slog.Logger
+	recoverer        *Recoverer
+	staticFS         fs.FS
+	maintenance      *Maintenance
+	recorder         *Recorder
+	cors             *corsPolicies
+	timeouts         *timeouts
+	websockets       *websockets
+	drainer          *drainer
+	admin            *admin
+	// listeners serve the router, the server listener first
+	listeners           []*serverListener
+	listenerMiddlewares map[string][]func(http.Handler) http.Handler
 }
 
 // New creates a new HTTP server with Chi router.
@@ -43,26 +71,55 @@ func New(cfg Config) (*Server, error) {
 
 	logger := slog.Default()
 
+	recoverer, err := NewRecoverer(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	drainer := newDrainer(cfg.DrainTimeout, logger)
+	s := &Server{
+		config:      cfg,
+		registry:    NewRouteRegistry(),
+		logger:      logger,
+		recoverer:   recoverer,
+		maintenance: newMaintenance(cfg.Maintenance, logger),
+		recorder:    newRecorder(cfg.Recorder),
+		cors:        newCORSPolicies(cfg.CORS),
+		timeouts:    newTimeouts(),
+		websockets:  newWebsockets(cfg.Websocket, logger, drainer),
+		drainer:     drainer,
+		admin:       newAdmin(cfg.Admin, logger),
+
+		listenerMiddlewares: make(map[string][]func(http.Handler) http.Handler),
+	}
+
 	router := chi.NewRouter()
 
 	// Default middleware stack
-	router.Use(middleware.RequestID)
-	router.Use(middleware.RealIP)
+	router.Use(markClientClosed)
+	router.Use(ctxmeta.Middleware)
+	router.Use(chiRequestID)
+	if len(cfg.TrustedProxies) > 0 {
+		// Validated above
+		trusted, _ := ipfilter.ParsePrefixes(cfg.TrustedProxies)
+		router.Use(ipfilter.RealIP(trusted))
+	} else {
+		router.Use(middleware.RealIP)
+	}
 	router.Use(middleware.Logger)
-	router.Use(middleware.Recoverer)
+	// Before the recoverer, so the recorded responses include the panics
+	router.Use(s.recorder.Middleware)
+	router.Use(s.recoverPanics)
 
 	// CORS middleware if configured
-	if cfg.CORS != nil {
-		router.Use(cors.Handler(cors.Options{
-			AllowedOrigins:     cfg.CORS.AllowedOrigins,
-			AllowedMethods:     cfg.CORS.AllowedMethods,
-			AllowedHeaders:     cfg.CORS.AllowedHeaders,
-			ExposedHeaders:     cfg.CORS.ExposedHeaders,
-			AllowCredentials:   cfg.CORS.AllowCredentials,
-			MaxAge:             cfg.CORS.MaxAge,
-			OptionsPassthrough: cfg.CORS.OptionsPassthrough,
-			Debug:              cfg.CORS.Debug,
-		}))
+	if s.cors != nil {
+		router.Use(s.cors.Middleware)
+	}
+
+	// After CORS, so browsers can read the maintenance response
+	router.Use(s.maintenance.Middleware)
+
+	if cfg.RequestTimeout > 0 {
+		router.Use(s.timeouts.defaultMiddleware(cfg.RequestTimeout))
 	}
 
 	// Add health check endpoint
@@ -71,34 +128,35 @@ func New(cfg Config) (*Server, error) {
 		_, _ = w.Write([]byte("ok"))
 	})
 
-	srv := &http.Server{
-		Addr:         fmt.Sprintf("0.0.0.0:%d", cfg.Port),
-		Handler:      router,
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
-		IdleTimeout:  cfg.IdleTimeout,
+	addr := fmt.Sprintf("0.0.0.0:%d", cfg.Port)
+	if cfg.Listener != nil && cfg.Listener.Address != "" {
+		addr = cfg.Listener.Address
+	}
+	srv := s.newHTTPServer(addr, router)
+	s.listeners = []*serverListener{{name: DefaultListener, cfg: cfg.Listener, server: srv}}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Listeners)) {
+		listenerCfg := cfg.Listeners[name]
+		s.listeners = append(s.listeners, &serverListener{
+			name:   name,
+			cfg:    &listenerCfg,
+			server: s.newHTTPServer(listenerCfg.Address, router),
+		})
 	}
 
 	// Create metrics server
-	metricsRouter := chi.NewRouter()
-	metricsRouter.Handle(metricsPath, promhttp.Handler())
-
+	s.metricsHandler = promhttp.Handler()
 	metricsServer := &http.Server{
 		Addr:         fmt.Sprintf("0.0.0.0:%d", cfg.MetricsPort),
-		Handler:      metricsRouter,
+		Handler:      s.newMetricsRouter(),
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
 		IdleTimeout:  cfg.IdleTimeout,
 	}
 
-	return &Server{
-		server:        srv,
-		router:        router,
-		metricsServer: metricsServer,
-		config:        cfg,
-		registry:      NewRouteRegistry(),
-		logger:        logger,
-	}, nil
+	s.server = srv
+	s.router = router
+	s.metricsServer = metricsServer
+	return s, nil
 }
 
 // NewWithLifecycleParams contains dependencies for creating a server with lifecycle.
@@ -108,6 +166,29 @@ type NewWithLifecycleParams struct {
 	LC     fx.Lifecycle
 	Routes []Route      `group:"routes"`
 	Logger *slog.Logger `               optional:"true"`
+	// MetricsGatherer is served on the metrics endpoint when provided (e.g. by metrics.Module).
+	MetricsGatherer prometheus.Gatherer `optional:"true"`
+	// BuildInfo is served on the metrics server at /buildinfo when provided (e.g. by metrics.RuntimeCollector).
+	BuildInfo *metrics.BuildInfo `optional:"true"`
+	// ErrorHandler writes the response of recovered panics and timed out requests when provided
+	// (e.g. by response.Module).
+	ErrorHandler response.ErrorHandler `optional:"true"`
+	// AppLogger logs recovered panics with the request metadata when provided (e.g. by logger.Module),
+	// and controls the log level of the /debug/loglevel admin endpoint.
+	AppLogger logger.Logger `optional:"true"`
+	// Registerer registers the panic counter; prometheus.DefaultRegisterer when not provided.
+	Registerer prometheus.Registerer `optional:"true"`
+	// Reporter reports the recovered panics when provided (e.g. by errreport.Module).
+	Reporter errreport.Reporter `optional:"true"`
+	// StaticFS holds the static assets served when the static config has no directory.
+	StaticFS *StaticFS `optional:"true"`
+	// Redis stores the maintenance mode when the maintenance config has a Redis key.
+	Redis *redis.Client `optional:"true"`
+	// CORSOriginFunc validates the CORS origins when provided (see SetCORSOriginFunc).
+	CORSOriginFunc CORSOriginFunc `optional:"true"`
+	// ConfigOptions apply to the load of the /debug/config admin endpoint when supplied with
+	// config.SupplyOptions.
+	ConfigOptions config.Options `optional:"true"`
 }
 
 // NewWithLifecycle creates a new HTTP server with fx.Lifecycle management.
@@ -122,6 +203,60 @@ func NewWithLifecycle(params NewWithLifecycleParams) (*Server, error) {
 	// Use injected logger if available, otherwise use default
 	if params.Logger != nil {
 		server.logger = params.Logger
+		server.maintenance.logger = params.Logger
+		server.websockets.logger = params.Logger
+		server.drainer.logger = params.Logger
+		if server.admin != nil {
+			server.admin.logger = params.Logger
+		}
+	}
+
+	if params.MetricsGatherer != nil {
+		server.SetMetricsGatherer(params.MetricsGatherer)
+	}
+
+	if server.admin != nil {
+		server.admin.configOptions = params.ConfigOptions
+	}
+
+	if params.ErrorHandler != nil {
+		server.timeouts.errorHandler = params.ErrorHandler
+	}
+
+	if params.ErrorHandler != nil || params.AppLogger != nil || params.Registerer != nil || params.Reporter != nil {
+		var recovererOpts []RecovererOption
+		if params.Registerer != nil {
+			recovererOpts = append(recovererOpts, WithPanicRegisterer(params.Registerer))
+		}
+		if params.Reporter != nil {
+			recovererOpts = append(recovererOpts, WithPanicReporter(params.Reporter))
+		}
+		recoverer, recovererErr := NewRecoverer(params.ErrorHandler, params.AppLogger, recovererOpts...)
+		if recovererErr != nil {
+			return nil, recovererErr
+		}
+		server.SetRecoverer(recoverer)
+	}
+
+	if params.BuildInfo != nil {
+		server.SetBuildInfo(*params.BuildInfo)
+	}
+
+	if levels, ok := params.AppLogger.(LogLevelController); ok {
+		server.SetLogLevelController(levels)
+	}
+
+	if params.StaticFS != nil {
+		server.SetStaticFS(params.StaticFS.FS)
+	}
+
+	server.SetCORSOriginFunc(params.CORSOriginFunc)
+
+	if maintenance := server.config.Maintenance; maintenance != nil && maintenance.RedisKey != "" {
+		if params.Redis == nil {
+			return nil, ErrMaintenanceRedisRequired
+		}
+		server.SetMaintenanceStore(NewRedisMaintenanceStore(params.Redis, maintenance.RedisKey))
 	}
 
 	// Register all routes from FX group
@@ -155,11 +290,169 @@ func NewWithLifecycle(params NewWithLifecycleParams) (*Server, error) {
 	return server, nil
 }
 
+// SetRecoverer replaces the panic recovery middleware, e.g. to log with pkg/logger and
+// write the errors through the application error handler. This should be called before Start().
+func (s *Server) SetRecoverer(recoverer *Recoverer) {
+	s.recoverer = recoverer
+}
+
+// recoverPanics delegates to the current Recoverer, which can be replaced after the
+// middleware stack is built.
+func (s *Server) recoverPanics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.recoverer.serve(w, r, next)
+	})
+}
+
 // Router returns the Chi router for registering routes.
 func (s *Server) Router() *chi.Mux {
 	return s.router
 }
 
+// SetMetricsGatherer makes the metrics endpoint serve the given gatherer instead of the
+// Prometheus default registry, or along with it with MetricsDefaultRegistry. A collector
+// failing to gather is logged and left out of the scrape, the others are still served.
+// This should be called before Start().
+func (s *Server) SetMetricsGatherer(gatherer prometheus.Gatherer) {
+	if s.config.MetricsDefaultRegistry && gatherer != prometheus.DefaultGatherer {
+		gatherer = defaultRegistryGatherer{gatherer: gatherer}
+	}
+	s.metricsHandler = promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{
+		ErrorLog:      slog.NewLogLogger(s.logger.Handler(), slog.LevelError),
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+	s.metricsServer.Handler = s.newMetricsRouter()
+}
+
+// SetBuildInfo serves the given build information as JSON on the metrics server at /buildinfo.
+// This should be called before Start().
+func (s *Server) SetBuildInfo(info metrics.BuildInfo) {
+	s.buildInfoHandler = info
+	s.metricsServer.Handler = s.newMetricsRouter()
+}
+
+// newMetricsRouter creates the metrics server router serving the metrics handler and, when
+// set, the build info handler. The maintenance and recorder endpoints are admin endpoints,
+// served behind the admin token only.
+func (s *Server) newMetricsRouter() *chi.Mux {
+	metricsRouter := chi.NewRouter()
+	metricsRouter.Handle(metricsPath, s.metricsHandler)
+	if s.buildInfoHandler != nil {
+		metricsRouter.Method(http.MethodGet, buildInfoPath, s.buildInfoHandler)
+	}
+	if s.admin != nil {
+		s.admin.routes(metricsRouter, s.maintenance, s.recorder)
+	}
+	return metricsRouter
+}
+
+// Maintenance returns the maintenance mode of the server.
+func (s *Server) Maintenance() *Maintenance {
+	return s.maintenance
+}
+
+// Recorder returns the flight recorder of the server.
+func (s *Server) Recorder() *Recorder {
+	return s.recorder
+}
+
+// SetMaintenanceStore shares the maintenance mode between the instances through store,
+// read every poll interval. This should be called before Start().
+func (s *Server) SetMaintenanceStore(store MaintenanceStore) {
+	s.maintenance.store = store
+}
+
+// SetCORSOriginFunc validates the CORS origins with fn, e.g. against the domains of the
+// tenants, for the global policy and the groups without their own AllowOriginFunc.
+// AllowedOrigins is then ignored. It does nothing without CORS configured. This should
+// be called before Start().
+func (s *Server) SetCORSOriginFunc(fn CORSOriginFunc) {
+	if s.cors != nil && fn != nil {
+		s.cors.setOriginFunc(fn)
+	}
+}
+
+// SetStaticFS sets the assets served when static serving is enabled without a directory,
+// e.g. an embed.FS. This should be called before Start().
+func (s *Server) SetStaticFS(fsys fs.FS) {
+	s.staticFS = fsys
+}
+
+// setupStatic mounts the static assets under their prefix.
+func (s *Server) setupStatic() {
+	static := s.config.Static
+	if static == nil || !static.Enabled {
+		return
+	}
+
+	fsys := s.staticFS
+	if static.Dir != "" {
+		fsys = os.DirFS(static.Dir)
+	}
+	if fsys == nil {
+		s.logger.Error("static assets are enabled without a directory or StaticFS")
+		return
+	}
+
+	maxAge := defaultStaticMaxAge
+	if static.MaxAge > 0 {
+		maxAge = static.MaxAge
+	}
+	prefix := strings.TrimSuffix(cmp.Or(static.Prefix, defaultStaticPrefix), "/")
+	handler := http.StripPrefix(prefix, StaticHandler(fsys, static.SPA, maxAge))
+
+	if prefix != "" {
+		s.router.Get(prefix, http.RedirectHandler(prefix+"/", http.StatusMovedPermanently).ServeHTTP)
+	}
+	s.router.Get(prefix+"/*", handler.ServeHTTP)
+	s.router.Head(prefix+"/*", handler.ServeHTTP)
+}
+
+// Handle registers handler for method and pattern on the router and documents it in the
+// OpenAPI document with op. A non-zero op.Timeout replaces the RequestTimeout of the route.
+func (s *Server) Handle(method, pattern string, handler http.HandlerFunc, op Operation) {
+	op.Method = method
+	op.Path = pattern
+	if op.Timeout != 0 {
+		s.router.With(s.Timeout(op.Timeout)).Method(method, pattern, handler)
+	} else {
+		s.router.Method(method, pattern, handler)
+	}
+	s.registry.AddOperation(op)
+}
+
+// Timeout returns a middleware bounding the handling of the requests of a route to timeout,
+// instead of the RequestTimeout, e.g. for a slow export. A negative timeout removes the
+// deadline, e.g. for a stream. The requests still running at the deadline are answered
+// with 504 and the errs.ErrTimeout envelope.
+//
+//	router.With(server.Timeout(2 * time.Minute)).Get("/reports/export", h.Export)
+func (s *Server) Timeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return s.timeouts.middleware(timeout)
+}
+
+// OpenAPI returns the OpenAPI document of the operations documented so far as JSON.
+func (s *Server) OpenAPI() ([]byte, error) {
+	cfg := OpenAPIConfig{}
+	if s.config.OpenAPI != nil {
+		cfg = *s.config.OpenAPI
+	}
+	return GenerateOpenAPI(cfg, s.registry.Operations())
+}
+
+// serveOpenAPI serves the OpenAPI document, generated on each request since it is
+// only fetched by tools.
+func (s *Server) serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	doc, err := s.OpenAPI()
+	if err != nil {
+		s.logger.Error("failed to generate the OpenAPI document", "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(doc)
+}
+
 // RegisterRoute adds a route to the server's registry.
 func (s *Server) RegisterRoute(route Route) {
 	s.registry.Add(route)
@@ -177,7 +470,16 @@ func (s *Server) RegisterRoutes(routes []Route) {
 func (s *Server) SetupRoutes() {
 	s.registry.SetupAll(s)
 
-	// Add swagger after module routes
+	s.setupStatic()
+
+	// Add the documentation after module routes
+	swaggerHandler := httpSwagger.WrapHandler
+	if s.config.OpenAPI != nil && s.config.OpenAPI.Enabled {
+		openAPIPath := cmp.Or(s.config.OpenAPI.Path, defaultOpenAPIPath)
+		s.router.Get(openAPIPath, s.serveOpenAPI)
+		swaggerHandler = httpSwagger.Handler(httpSwagger.URL(openAPIPath))
+	}
+
 	if s.config.Swagger != nil && s.config.Swagger.Enabled {
 		path := defaultSwaggerPath
 		if !lo.IsEmpty(s.config.Swagger.Path) {
@@ -186,7 +488,7 @@ func (s *Server) SetupRoutes() {
 				path += "/*"
 			}
 		}
-		s.router.Get(path, httpSwagger.WrapHandler)
+		s.router.Get(path, swaggerHandler)
 	}
 
 	// Always log routes on startup
@@ -195,19 +497,19 @@ func (s *Server) SetupRoutes() {
 
 // logRoutes logs all registered routes to stdout.
 func (s *Server) logRoutes() {
-	s.logServerRoutes(s.router, "HTTP Server", s.server.Addr)
+	s.logServerRoutes(s.router, "HTTP Server", s.listeners[0].url())
 }
 
 // logMetricsRoutes logs all registered metrics routes to stdout.
 func (s *Server) logMetricsRoutes() {
 	if metricsRouter, ok := s.metricsServer.Handler.(*chi.Mux); ok {
-		s.logServerRoutes(metricsRouter, "Metrics Server", s.metricsServer.Addr)
+		s.logServerRoutes(metricsRouter, "Metrics Server", "http://"+s.metricsServer.Addr)
 	}
 }
 
-// logServerRoutes logs routes for a given router with a custom title and address.
-func (s *Server) logServerRoutes(router *chi.Mux, serverName, addr string) {
-	s.logger.Info(fmt.Sprintf("%s: http://%s", serverName, addr))
+// logServerRoutes logs routes for a given router with a custom title and URL.
+func (s *Server) logServerRoutes(router *chi.Mux, serverName, url string) {
+	s.logger.Info(fmt.Sprintf("%s: %s", serverName, url))
 	s.logger.Info(fmt.Sprintf("%s routes:", serverName))
 	s.logger.Info("==================")
 
@@ -238,12 +540,28 @@ func (s *Server) createRouteWalkFunc() func(method string, route string, _ http.
 	}
 }
 
-// Start begins listening and serving HTTP requests.
+// Start begins listening and serving HTTP requests, on every listener.
 // Starts the metrics server on a separate port.
 func (s *Server) Start() error {
 	// Log metrics server routes
 	s.logMetricsRoutes()
 
+	// Open every listener first, so a failure does not leave some of them serving
+	netListeners := make([]net.Listener, 0, len(s.listeners))
+	for _, l := range s.listeners {
+		netListener, err := l.listen()
+		if err != nil {
+			for _, opened := range netListeners {
+				_ = opened.Close()
+			}
+			return fmt.Errorf("listen %s: %w", l.name, err)
+		}
+		netListeners = append(netListeners, netListener)
+		l.server.Handler = s.listenerHandler(l)
+	}
+
+	go s.maintenance.watch()
+
 	// Start metrics server
 	go func() {
 		if err := s.metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -251,21 +569,56 @@ func (s *Server) Start() error {
 		}
 	}()
 
-	return s.server.ListenAndServe()
+	// Start the additional listeners
+	for i, l := range s.listeners[1:] {
+		s.logger.Info(fmt.Sprintf("HTTP Server (%s): %s", l.name, l.url()))
+		go func() {
+			if err := l.server.Serve(netListeners[i+1]); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.logger.Error("HTTP listener failed", "listener", l.name, "err", err)
+			}
+		}()
+	}
+
+	return s.server.Serve(netListeners[0])
 }
 
-// Shutdown gracefully shuts down the server.
-// Also shuts down the metrics server.
+// Shutdown gracefully shuts down the server and its additional listeners.
+// Also shuts down the metrics server and drains the long-running connections (Streaming
+// routes and WebSockets): they are notified of the shutdown, see ShuttingDown, and closed
+// after DrainTimeout, or when ctx is done.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.maintenance.close()
+
 	// Shutdown metrics server
 	if err := s.metricsServer.Shutdown(ctx); err != nil {
 		_ = err
 	}
 
-	return s.server.Shutdown(ctx)
+	// http.Server.Shutdown stops accepting connections and waits for the requests, the
+	// streams included, while the drainer ends the streams and the WebSockets it leaves open
+	shutdownErrs := make(chan error, len(s.listeners))
+	for _, l := range s.listeners {
+		go func() { shutdownErrs <- l.server.Shutdown(ctx) }()
+	}
+	err := s.drainer.drain(ctx)
+	for range s.listeners {
+		err = errors.Join(err, <-shutdownErrs)
+	}
+	return err
 }
 
-// Addr returns the server address.
+// newHTTPServer creates an http.Server of the router listening on addr.
+func (s *Server) newHTTPServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  s.config.ReadTimeout,
+		WriteTimeout: s.config.WriteTimeout,
+		IdleTimeout:  s.config.IdleTimeout,
+	}
+}
+
+// Addr returns the TCP address of the server, unused with a unix or systemd listener.
 func (s *Server) Addr() string {
 	return s.server.Addr
 }
@@ -274,3 +627,13 @@ func (s *Server) Addr() string {
 func (s *Server) MetricsAddr() string {
 	return s.metricsServer.Addr
 }
+
+// chiRequestID exposes the ctxmeta request ID to chi's middleware.GetReqID, used by
+// the chi request logger.
+func chiRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID, _ := ctxmeta.RequestID(r.Context())
+		ctx := context.WithValue(r.Context(), middleware.RequestIDKey, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,151 @@
+package chi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	defaultStaticPrefix = "/"
+	defaultStaticMaxAge = time.Hour
+	indexFile           = "index.html"
+)
+
+// StaticFS holds the embedded assets served by the server, provided to the FX module.
+//
+//	fx.Supply(chi.StaticFS{FS: assets})
+type StaticFS struct {
+	FS fs.FS
+}
+
+// StaticConfig configures the static assets served by the server.
+type StaticConfig struct {
+	Enabled bool          // Whether to serve the assets
+	Prefix  string        // URL prefix of the assets (e.g. /admin), default: /
+	Dir     string        // Directory of the assets on disk; empty serves the StaticFS
+	SPA     bool          // Serve index.html for unknown GET routes under the prefix
+	MaxAge  time.Duration // Cache-Control max-age of the assets (not index.html), default: 1h
+}
+
+// StaticHandler serves the files of fsys:
+//
+//   - directories serve their index.html; there are no directory listings
+//   - a file.br or file.gz variant is served instead of file when the client accepts it
+//   - index.html is served with Cache-Control: no-cache, the other files with maxAge
+//   - with spa, unknown paths without a file extension serve the root index.html, so the
+//     client-side router handles them
+//
+// The handler expects the URL prefix to be stripped, e.g. with http.StripPrefix.
+func StaticHandler(fsys fs.FS, spa bool, maxAge time.Duration) http.Handler {
+	return &staticHandler{fsys: fsys, spa: spa, maxAge: maxAge}
+}
+
+type staticHandler struct {
+	fsys   fs.FS
+	spa    bool
+	maxAge time.Duration
+}
+
+func (h *staticHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = indexFile
+	}
+	if info, err := fs.Stat(h.fsys, name); err == nil && info.IsDir() {
+		name = path.Join(name, indexFile)
+	}
+
+	if _, err := fs.Stat(h.fsys, name); errors.Is(err, fs.ErrNotExist) {
+		if !h.spa || path.Ext(name) != "" {
+			http.NotFound(w, r)
+			return
+		}
+		name = indexFile
+	}
+
+	if err := h.serveFile(w, r, name); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+func (h *staticHandler) serveFile(w http.ResponseWriter, r *http.Request, name string) error {
+	header := w.Header()
+	header.Add("Vary", "Accept-Encoding")
+	if path.Base(name) == indexFile {
+		header.Set("Cache-Control", "no-cache")
+	} else {
+		header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.maxAge.Seconds())))
+	}
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	served := name
+	for _, variant := range []struct{ encoding, ext string }{{"br", ".br"}, {"gzip", ".gz"}} {
+		if !acceptsEncoding(r, variant.encoding) {
+			continue
+		}
+		if info, err := fs.Stat(h.fsys, name+variant.ext); err == nil && !info.IsDir() {
+			served = name + variant.ext
+			header.Set("Content-Encoding", variant.encoding)
+			if header.Get("Content-Type") == "" {
+				// Sniffing would see the compressed bytes
+				header.Set("Content-Type", "application/octet-stream")
+			}
+			break
+		}
+	}
+
+	file, err := h.fsys.Open(served)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, readErr := io.ReadAll(file)
+		if readErr != nil {
+			return readErr
+		}
+		content = bytes.NewReader(data)
+	}
+	http.ServeContent(w, r, name, info.ModTime(), content)
+	return nil
+}
+
+// acceptsEncoding reports whether the Accept-Encoding header of r lists encoding
+// without q=0.
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	for _, value := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(value), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), encoding) {
+			continue
+		}
+		q := strings.ReplaceAll(params, " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
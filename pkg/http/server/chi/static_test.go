@@ -0,0 +1,112 @@
+package chi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStaticFS() fstest.MapFS {
+	return fstest.MapFS{
+		"index.html":      {Data: []byte("<html>app</html>")},
+		"app.js":          {Data: []byte("console.log(1)")},
+		"app.js.gz":       {Data: []byte("gzipped")},
+		"docs/index.html": {Data: []byte("<html>docs</html>")},
+	}
+}
+
+func TestStaticHandler(t *testing.T) {
+	handler := chi.StaticHandler(newStaticFS(), true, time.Hour)
+	serve := func(target, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("serves assets with cache headers", func(t *testing.T) {
+		// Act
+		rr := serve("/app.js", "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "console.log(1)", rr.Body.String())
+		assert.Equal(t, "public, max-age=3600", rr.Header().Get("Cache-Control"))
+		assert.Contains(t, rr.Header().Get("Content-Type"), "javascript")
+	})
+
+	t.Run("serves the gzip variant when accepted", func(t *testing.T) {
+		// Act
+		rr := serve("/app.js", "br;q=0, gzip")
+
+		// Assert
+		assert.Equal(t, "gzipped", rr.Body.String())
+		assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+		assert.Contains(t, rr.Header().Get("Content-Type"), "javascript")
+		assert.Equal(t, "Accept-Encoding", rr.Header().Get("Vary"))
+	})
+
+	t.Run("serves the index of directories without caching", func(t *testing.T) {
+		// Act
+		rr := serve("/docs/", "")
+
+		// Assert
+		assert.Equal(t, "<html>docs</html>", rr.Body.String())
+		assert.Equal(t, "no-cache", rr.Header().Get("Cache-Control"))
+	})
+
+	t.Run("falls back to index.html for unknown routes", func(t *testing.T) {
+		// Act
+		rr := serve("/users/42", "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "<html>app</html>", rr.Body.String())
+	})
+
+	t.Run("answers 404 for unknown files", func(t *testing.T) {
+		// Act
+		rr := serve("/missing.css", "")
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestServer_Static_MountsUnderPrefix(t *testing.T) {
+	// Arrange
+	cfg := chi.Default()
+	cfg.Static = &chi.StaticConfig{Enabled: true, Prefix: "/admin", SPA: true}
+	server, err := chi.New(cfg)
+	require.NoError(t, err)
+	server.SetStaticFS(newStaticFS())
+	server.SetupRoutes()
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		server.Router().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+		return rr
+	}
+
+	// Act
+	asset := serve("/admin/app.js")
+	fallback := serve("/admin/settings")
+	redirect := serve("/admin")
+	outside := serve("/other")
+
+	// Assert
+	assert.Equal(t, "console.log(1)", asset.Body.String())
+	assert.Equal(t, "<html>app</html>", fallback.Body.String())
+	assert.Equal(t, http.StatusMovedPermanently, redirect.Code)
+	assert.Equal(t, "/admin/", redirect.Header().Get("Location"))
+	assert.Equal(t, http.StatusNotFound, outside.Code)
+}
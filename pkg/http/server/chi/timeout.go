@@ -0,0 +1,87 @@
+package chi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+// timeoutStateKey is the context key of the timeoutState of a request.
+type timeoutStateKey struct{}
+
+// timeoutState lets a route timeout replace the default deadline of a request.
+type timeoutState struct {
+	// base is the request context before the default deadline, canceled when the client leaves
+	base context.Context
+	// overridden is set when a route timeout replaced the default deadline
+	overridden bool
+}
+
+// timeouts bounds the handling of the requests with context deadlines.
+type timeouts struct {
+	errorHandler response.ErrorHandler
+}
+
+func newTimeouts() *timeouts {
+	return &timeouts{errorHandler: response.NewErrorHandler(nil, nil)}
+}
+
+// defaultMiddleware applies the default deadline to every request, unless its route has
+// its own timeout.
+func (t *timeouts) defaultMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state := &timeoutState{base: r.Context()}
+			ctx, cancel := context.WithTimeout(context.WithValue(r.Context(), timeoutStateKey{}, state), timeout)
+			defer cancel()
+			t.serve(w, r.WithContext(ctx), next, state)
+		})
+	}
+}
+
+// middleware applies timeout to the requests of a route, replacing the default deadline:
+// a route may so get more time than the default. A negative timeout removes the deadline.
+func (t *timeouts) middleware(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			base := r.Context()
+			if state, ok := base.Value(timeoutStateKey{}).(*timeoutState); ok {
+				state.overridden = true
+				base = state.base
+			}
+			// Keep the values of the request context but not its deadline, and still stop
+			// when the client leaves
+			ctx, cancel := context.WithCancelCause(context.WithoutCancel(r.Context()))
+			defer cancel(nil)
+			stop := context.AfterFunc(base, func() { cancel(context.Cause(base)) })
+			defer stop()
+			if timeout < 0 {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+			ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+			defer cancelTimeout()
+			t.serve(w, r.WithContext(ctx), next, nil)
+		})
+	}
+}
+
+// serve runs next and, when the deadline passed without a response, answers 504 with the
+// errs.ErrTimeout envelope. The handler is not interrupted: the deadline cancels its
+// database and Redis calls, which return context.DeadlineExceeded.
+func (t *timeouts) serve(w http.ResponseWriter, r *http.Request, next http.Handler, state *timeoutState) {
+	ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+	next.ServeHTTP(ww, r)
+	if state != nil && state.overridden {
+		return
+	}
+	if ww.Status() == 0 && errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+		t.errorHandler.ErrorCtx(r.Context(), w, errs.ErrTimeout)
+	}
+}
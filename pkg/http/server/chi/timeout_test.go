@@ -0,0 +1,125 @@
+package chi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/stretchr/testify/suite"
+)
+
+const testRequestTimeout = 20 * time.Millisecond
+
+type TimeoutTestSuite struct {
+	suite.Suite
+	sut *chi.Server
+}
+
+func TestTimeoutSuite(t *testing.T) {
+	suite.Run(t, new(TimeoutTestSuite))
+}
+
+func (s *TimeoutTestSuite) SetupTest() {
+	cfg := chi.Default()
+	cfg.RequestTimeout = testRequestTimeout
+	var err error
+	s.sut, err = chi.New(cfg)
+	s.Require().NoError(err)
+}
+
+// waitFor answers 200 after d, or returns at the end of the request context.
+func waitFor(d time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(d):
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+		}
+	}
+}
+
+func (s *TimeoutTestSuite) serve(path string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	s.sut.Router().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+	return rr
+}
+
+func (s *TimeoutTestSuite) TestRequestTimeout_AnswersGatewayTimeout() {
+	// Arrange
+	s.sut.Router().Get("/slow", waitFor(time.Second))
+
+	// Act
+	rr := s.serve("/slow")
+
+	// Assert
+	s.Equal(http.StatusGatewayTimeout, rr.Code)
+	var body map[string]map[string]string
+	s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &body))
+	s.Equal("TIMEOUT", body["error"]["code"])
+}
+
+func (s *TimeoutTestSuite) TestRequestTimeout_KeepsTheResponsesInTime() {
+	// Arrange
+	s.sut.Router().Get("/fast", waitFor(0))
+
+	// Act
+	rr := s.serve("/fast")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+}
+
+func (s *TimeoutTestSuite) TestRequestTimeout_KeepsTheResponseWrittenByTheHandler() {
+	// Arrange
+	s.sut.Router().Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	// Act
+	rr := s.serve("/slow")
+
+	// Assert
+	s.Equal(http.StatusServiceUnavailable, rr.Code)
+}
+
+func (s *TimeoutTestSuite) TestOperationTimeout_ExtendsTheDeadlineOfTheRoute() {
+	// Arrange
+	s.sut.Handle(http.MethodGet, "/export", waitFor(5*testRequestTimeout), chi.Operation{Timeout: time.Second})
+
+	// Act
+	rr := s.serve("/export")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+}
+
+func (s *TimeoutTestSuite) TestTimeout_ShortensTheDeadlineOfTheRoute() {
+	// Arrange
+	s.sut.Router().With(s.sut.Timeout(time.Millisecond)).Get("/ping", waitFor(testRequestTimeout/2))
+
+	// Act
+	rr := s.serve("/ping")
+
+	// Assert
+	s.Equal(http.StatusGatewayTimeout, rr.Code)
+}
+
+func (s *TimeoutTestSuite) TestTimeout_Negative_RemovesTheDeadline() {
+	// Arrange
+	var deadlineSet bool
+	s.sut.Router().With(s.sut.Timeout(-1)).Get("/stream", func(w http.ResponseWriter, r *http.Request) {
+		_, deadlineSet = r.Context().Deadline()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Act
+	rr := s.serve("/stream")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+	s.False(deadlineSet)
+}
@@ -0,0 +1,302 @@
+package chi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+const (
+	defaultWebsocketPingInterval = 30 * time.Second
+	defaultWebsocketPongTimeout  = 10 * time.Second
+	defaultWebsocketWriteTimeout = 10 * time.Second
+	defaultWebsocketReadLimit    = 32 << 10
+	defaultWebsocketSendBuffer   = 16
+)
+
+// MessageType is the type of a WebSocket message, MessageText or MessageBinary.
+type MessageType = websocket.MessageType
+
+// Message types of the WebSocket messages.
+const (
+	MessageText   = websocket.MessageText
+	MessageBinary = websocket.MessageBinary
+)
+
+// WebsocketStatusCode is the status code closing a WebSocket connection.
+type WebsocketStatusCode = websocket.StatusCode
+
+// Status codes closing the WebSocket connections.
+const (
+	WebsocketNormalClosure   = websocket.StatusNormalClosure
+	WebsocketGoingAway       = websocket.StatusGoingAway
+	WebsocketPolicyViolation = websocket.StatusPolicyViolation
+	WebsocketInternalError   = websocket.StatusInternalError
+)
+
+// WebsocketConfig configures the WebSocket connections of the server.
+type WebsocketConfig struct {
+	// OriginPatterns are the hosts allowed to open cross origin connections, e.g.
+	// "app.example.com" or "*.example.com"; the host of the server is always allowed
+	OriginPatterns []string
+	PingInterval   time.Duration // Interval of the keepalive pings, default: 30s, negative disables them
+	PongTimeout    time.Duration // Wait of a pong before the connection is closed, default: 10s
+	WriteTimeout   time.Duration // Deadline of each write, default: 10s
+	ReadTimeout    time.Duration // Deadline of each read, default: 0 (none, the pings detect dead peers)
+	ReadLimit      int64         // Maximum size of a read message in bytes, default: 32KB
+	SendBuffer     int           // Messages queued by Send before the connection is closed, default: 16
+}
+
+func (c *WebsocketConfig) setDefaults() {
+	if c.PingInterval == 0 {
+		c.PingInterval = defaultWebsocketPingInterval
+	}
+	if c.PongTimeout <= 0 {
+		c.PongTimeout = defaultWebsocketPongTimeout
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = defaultWebsocketWriteTimeout
+	}
+	if c.ReadLimit <= 0 {
+		c.ReadLimit = defaultWebsocketReadLimit
+	}
+	if c.SendBuffer <= 0 {
+		c.SendBuffer = defaultWebsocketSendBuffer
+	}
+}
+
+// WebsocketHandler serves a WebSocket connection until it returns; the connection is then
+// closed, with WebsocketInternalError when the handler returns an error. ctx carries the
+// values of the upgrade request, e.g. the ctxmeta IDs and the user of the authentication
+// middleware, and is canceled when the server closes the connection.
+type WebsocketHandler func(ctx context.Context, conn *WebsocketConn) error
+
+// WebsocketRoute is a Route serving WebSocket connections on Pattern, behind Middlewares,
+// e.g. for a module registering it in the "routes" FX group.
+type WebsocketRoute struct {
+	Pattern     string
+	Handler     WebsocketHandler
+	Middlewares []func(http.Handler) http.Handler
+}
+
+// Setup implements Route.
+func (r WebsocketRoute) Setup(server *Server) {
+	server.Websocket(r.Pattern, r.Handler, r.Middlewares...)
+}
+
+// Websocket serves WebSocket connections on GET pattern with handler, behind middlewares.
+// The route has no request timeout. On shutdown, ShuttingDown(ctx) is closed, then the
+// connections are closed with WebsocketGoingAway after DrainTimeout.
+//
+//	server.Websocket("/ws/notifications", func(ctx context.Context, conn *chi.WebsocketConn) error {
+//		leave := hub.Join("user:"+userID(ctx), conn)
+//		defer leave()
+//		return conn.Wait()
+//	}, jwt.Middleware(manager, newClaims))
+func (s *Server) Websocket(pattern string, handler WebsocketHandler, middlewares ...func(http.Handler) http.Handler) {
+	s.router.With(s.Timeout(-1)).With(middlewares...).Get(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if s.drainer.isDraining() {
+			s.timeouts.errorHandler.ErrorCtx(r.Context(), w, shuttingDownError)
+			return
+		}
+		s.websockets.serve(w, r, handler)
+	})
+}
+
+// websockets accepts the WebSocket connections of a server and tracks them in the
+// drainer, to close them on shutdown: http.Server.Shutdown leaves the hijacked connections
+// open.
+type websockets struct {
+	cfg     WebsocketConfig
+	logger  *slog.Logger
+	drainer *drainer
+}
+
+func newWebsockets(cfg *WebsocketConfig, logger *slog.Logger, drainer *drainer) *websockets {
+	var config WebsocketConfig
+	if cfg != nil {
+		config = *cfg
+	}
+	config.setDefaults()
+	return &websockets{cfg: config, logger: logger, drainer: drainer}
+}
+
+func (ws *websockets) serve(w http.ResponseWriter, r *http.Request, handler WebsocketHandler) {
+	raw, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: ws.cfg.OriginPatterns})
+	if err != nil {
+		// Accept answered the request
+		ws.logger.DebugContext(r.Context(), "websocket upgrade rejected", "path", r.URL.Path, "err", err)
+		return
+	}
+	raw.SetReadLimit(ws.cfg.ReadLimit)
+
+	// The request context must not be used after the upgrade, keep only its values
+	ctx, cancel := context.WithCancel(context.WithoutCancel(r.Context()))
+	defer cancel()
+	conn := &WebsocketConn{
+		conn:   raw,
+		cfg:    ws.cfg,
+		cancel: cancel,
+		send:   make(chan outgoing, ws.cfg.SendBuffer),
+	}
+	// Closed with WebsocketGoingAway after the drain timeout, so the clients reconnect to
+	// another instance; Close waits for the close handshake of the client
+	ctx, untrack, ok := ws.drainer.track(ctx, &drainConn{
+		close: func() { go func() { _ = conn.Close(WebsocketGoingAway, "server shutting down") }() },
+		abort: conn.closeNow,
+	})
+	if !ok {
+		// The shutdown started during the upgrade
+		_ = conn.Close(WebsocketGoingAway, "server shutting down")
+		return
+	}
+	defer untrack()
+	conn.ctx = ctx
+	go conn.writeLoop()
+	if ws.cfg.PingInterval > 0 {
+		go conn.pingLoop()
+	}
+
+	if err = handler(ctx, conn); err != nil && ctx.Err() == nil {
+		ws.logger.ErrorContext(ctx, "websocket handler failed", "path", r.URL.Path, "err", err)
+		_ = conn.Close(WebsocketInternalError, "internal error")
+		return
+	}
+	_ = conn.Close(WebsocketNormalClosure, "")
+}
+
+// outgoing is a message queued by Send.
+type outgoing struct {
+	typ  MessageType
+	data []byte
+}
+
+// WebsocketConn is a WebSocket connection of the server. Its methods are safe for
+// concurrent use, but a single goroutine must read.
+type WebsocketConn struct {
+	conn   *websocket.Conn
+	cfg    WebsocketConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	send   chan outgoing
+}
+
+// Context returns the context of the connection, the one passed to the handler.
+func (c *WebsocketConn) Context() context.Context {
+	return c.ctx
+}
+
+// Read waits for the next message, for ReadTimeout at most. It fails once the connection
+// is closed; a read failure closes the connection.
+func (c *WebsocketConn) Read(ctx context.Context) (MessageType, []byte, error) {
+	if c.cfg.ReadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.ReadTimeout)
+		defer cancel()
+	}
+	return c.conn.Read(ctx)
+}
+
+// ReadJSON reads the next message into v.
+func (c *WebsocketConn) ReadJSON(ctx context.Context, v any) error {
+	_, data, err := c.Read(ctx)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode websocket message: %w", err)
+	}
+	return nil
+}
+
+// Write writes a message, for WriteTimeout at most.
+func (c *WebsocketConn) Write(ctx context.Context, typ MessageType, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.WriteTimeout)
+	defer cancel()
+	return c.conn.Write(ctx, typ, data)
+}
+
+// WriteJSON writes v as a text message.
+func (c *WebsocketConn) WriteJSON(ctx context.Context, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode websocket message: %w", err)
+	}
+	return c.Write(ctx, MessageText, data)
+}
+
+// Send queues a message, written in the background, without waiting for a slow client.
+// It reports false when the queue of SendBuffer messages is full, in which case the
+// client cannot keep up and the connection is closed, or when the connection is closed.
+func (c *WebsocketConn) Send(typ MessageType, data []byte) bool {
+	if c.ctx.Err() != nil {
+		return false
+	}
+	select {
+	case c.send <- outgoing{typ: typ, data: data}:
+		return true
+	default:
+		go func() { _ = c.Close(WebsocketPolicyViolation, "client too slow") }()
+		return false
+	}
+}
+
+// Wait discards the messages of the client, to process its pongs and close frames, until
+// the connection is closed, for handlers that only write. It returns nil.
+func (c *WebsocketConn) Wait() error {
+	<-c.conn.CloseRead(c.ctx).Done()
+	return nil
+}
+
+// Close closes the connection with code and reason, e.g. WebsocketNormalClosure, waiting
+// for the close handshake of the client.
+func (c *WebsocketConn) Close(code WebsocketStatusCode, reason string) error {
+	defer c.cancel()
+	return c.conn.Close(code, reason)
+}
+
+// closeNow drops the connection without the close handshake.
+func (c *WebsocketConn) closeNow() {
+	c.cancel()
+	_ = c.conn.CloseNow()
+}
+
+func (c *WebsocketConn) writeLoop() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case msg := <-c.send:
+			if err := c.Write(c.ctx, msg.typ, msg.data); err != nil {
+				c.closeNow()
+				return
+			}
+		}
+	}
+}
+
+// pingLoop closes the connection when the client stops answering the pings. The pongs
+// are processed by the reads, see Wait.
+func (c *WebsocketConn) pingLoop() {
+	ticker := time.NewTicker(c.cfg.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(c.ctx, c.cfg.PongTimeout)
+			err := c.conn.Ping(ctx)
+			cancel()
+			if err != nil {
+				c.closeNow()
+				return
+			}
+		}
+	}
+}
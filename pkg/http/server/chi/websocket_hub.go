@@ -0,0 +1,79 @@
+package chi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// WebsocketHub broadcasts messages to groups of WebSocket connections, e.g. the sockets of
+// a user or of a chat room. The messages are queued with WebsocketConn.Send, so a slow
+// client does not hold the others back.
+//
+// The hub is local to the instance: to reach the connections of every instance, broadcast
+// the messages received from a shared channel, e.g. a Redis pub/sub or the event bus.
+type WebsocketHub struct {
+	mu     sync.RWMutex
+	groups map[string]map[*WebsocketConn]struct{}
+}
+
+// NewWebsocketHub creates an empty WebsocketHub.
+func NewWebsocketHub() *WebsocketHub {
+	return &WebsocketHub{groups: make(map[string]map[*WebsocketConn]struct{})}
+}
+
+// Join adds conn to group and returns the function removing it, to defer in the handler.
+//
+//	leave := hub.Join("room:"+roomID, conn)
+//	defer leave()
+func (h *WebsocketHub) Join(group string, conn *WebsocketConn) (leave func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	members, ok := h.groups[group]
+	if !ok {
+		members = make(map[*WebsocketConn]struct{})
+		h.groups[group] = members
+	}
+	members[conn] = struct{}{}
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		// The group is dropped once empty, so read it again
+		if members, ok := h.groups[group]; ok {
+			delete(members, conn)
+			if len(members) == 0 {
+				delete(h.groups, group)
+			}
+		}
+	}
+}
+
+// Broadcast queues the message to the connections of group and returns the number of
+// connections it was queued to.
+func (h *WebsocketHub) Broadcast(group string, typ MessageType, data []byte) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	sent := 0
+	for conn := range h.groups[group] {
+		if conn.Send(typ, data) {
+			sent++
+		}
+	}
+	return sent
+}
+
+// BroadcastJSON queues v as a text message to the connections of group.
+func (h *WebsocketHub) BroadcastJSON(group string, v any) (int, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0, fmt.Errorf("encode websocket message: %w", err)
+	}
+	return h.Broadcast(group, MessageText, data), nil
+}
+
+// Count returns the number of connections of group.
+func (h *WebsocketHub) Count(group string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.groups[group])
+}
@@ -0,0 +1,236 @@
+package chi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	gochi "github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebsocketTestSuite struct {
+	suite.Suite
+	sut    *chi.Server
+	server *httptest.Server
+}
+
+func TestWebsocketSuite(t *testing.T) {
+	suite.Run(t, new(WebsocketTestSuite))
+}
+
+func (s *WebsocketTestSuite) SetupTest() {
+	cfg := chi.Default()
+	cfg.RequestTimeout = testRequestTimeout
+	cfg.DrainTimeout = testDrainTimeout
+	var err error
+	s.sut, err = chi.New(cfg)
+	s.Require().NoError(err)
+	s.server = httptest.NewServer(s.sut.Router())
+}
+
+func (s *WebsocketTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *WebsocketTestSuite) dial(path string) *websocket.Conn {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(s.server.URL, "http")+path, nil)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = conn.CloseNow() })
+	return conn
+}
+
+type message struct {
+	Text      string `json:"text"`
+	RequestID string `json:"request_id"`
+}
+
+func echo(ctx context.Context, conn *chi.WebsocketConn) error {
+	for {
+		var msg message
+		if err := conn.ReadJSON(ctx, &msg); err != nil {
+			return nil
+		}
+		msg.RequestID, _ = ctxmeta.RequestID(ctx)
+		if err := conn.WriteJSON(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *WebsocketTestSuite) TestWebsocket_ServesTheConnectionWithTheRequestContext() {
+	// Arrange
+	s.sut.Websocket("/ws", echo)
+	conn := s.dial("/ws")
+	ctx := context.Background()
+
+	// Act
+	s.Require().NoError(wsjson.Write(ctx, conn, message{Text: "hello"}))
+	var got message
+	err := wsjson.Read(ctx, conn, &got)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("hello", got.Text)
+	s.NotEmpty(got.RequestID)
+}
+
+func (s *WebsocketTestSuite) TestWebsocket_OutlivesTheRequestTimeout() {
+	// Arrange
+	s.sut.Websocket("/ws", echo)
+	conn := s.dial("/ws")
+	ctx := context.Background()
+
+	// Act
+	time.Sleep(5 * testRequestTimeout)
+	s.Require().NoError(wsjson.Write(ctx, conn, message{Text: "late"}))
+	var got message
+	err := wsjson.Read(ctx, conn, &got)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("late", got.Text)
+}
+
+func (s *WebsocketTestSuite) TestWebsocket_ClosesWithInternalErrorWhenTheHandlerFails() {
+	// Arrange
+	s.sut.Websocket("/ws", func(_ context.Context, _ *chi.WebsocketConn) error {
+		return errors.New("boom")
+	})
+	conn := s.dial("/ws")
+
+	// Act
+	_, _, err := conn.Read(context.Background())
+
+	// Assert
+	s.Equal(websocket.StatusInternalError, websocket.CloseStatus(err))
+}
+
+func (s *WebsocketTestSuite) TestWebsocket_RejectsCrossOriginRequests() {
+	// Arrange
+	s.sut.Websocket("/ws", echo)
+	header := http.Header{"Origin": []string{"https://evil.example.com"}}
+
+	// Act
+	_, resp, err := websocket.Dial(context.Background(), "ws"+strings.TrimPrefix(s.server.URL, "http")+"/ws",
+		&websocket.DialOptions{HTTPHeader: header})
+
+	// Assert
+	s.Require().Error(err)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+}
+
+func (s *WebsocketTestSuite) TestWebsocketRoute_RegistersTheRoute() {
+	// Arrange
+	route := chi.WebsocketRoute{Pattern: "/ws", Handler: echo}
+
+	// Act
+	s.sut.RegisterRoute(route)
+	s.sut.SetupRoutes()
+	conn := s.dial("/ws")
+
+	// Assert
+	s.Require().NoError(wsjson.Write(context.Background(), conn, message{Text: "hello"}))
+	var got message
+	s.Require().NoError(wsjson.Read(context.Background(), conn, &got))
+	s.Equal("hello", got.Text)
+}
+
+func (s *WebsocketTestSuite) TestShutdown_NotifiesTheHandlers() {
+	// Arrange
+	started := make(chan struct{})
+	s.sut.Websocket("/ws", func(ctx context.Context, conn *chi.WebsocketConn) error {
+		close(started)
+		<-chi.ShuttingDown(ctx)
+		return conn.WriteJSON(ctx, message{Text: "reconnect"})
+	})
+	conn := s.dial("/ws")
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	got := make(chan message, 1)
+	go func() {
+		var msg message
+		_ = wsjson.Read(ctx, conn, &msg)
+		got <- msg
+		_, _, _ = conn.Read(ctx)
+	}()
+
+	// Act
+	err := s.sut.Shutdown(ctx)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("reconnect", (<-got).Text)
+}
+
+func (s *WebsocketTestSuite) TestShutdown_ClosesTheConnectionsWithGoingAway() {
+	// Arrange
+	started := make(chan struct{})
+	s.sut.Websocket("/ws", func(_ context.Context, conn *chi.WebsocketConn) error {
+		close(started)
+		return conn.Wait()
+	})
+	conn := s.dial("/ws")
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	readErr := make(chan error, 1)
+	go func() {
+		_, _, err := conn.Read(ctx)
+		readErr <- err
+	}()
+
+	// Act
+	err := s.sut.Shutdown(ctx)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(websocket.StatusGoingAway, websocket.CloseStatus(<-readErr))
+	_, resp, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(s.server.URL, "http")+"/ws", nil)
+	s.Require().Error(err)
+	s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func (s *WebsocketTestSuite) TestHub_BroadcastsToTheConnectionsOfTheGroup() {
+	// Arrange
+	hub := chi.NewWebsocketHub()
+	joined := make(chan struct{}, 3)
+	s.sut.Websocket("/ws/{room}", func(ctx context.Context, conn *chi.WebsocketConn) error {
+		leave := hub.Join(gochi.URLParamFromCtx(ctx, "room"), conn)
+		defer leave()
+		joined <- struct{}{}
+		return conn.Wait()
+	})
+	first := s.dial("/ws/a")
+	second := s.dial("/ws/a")
+	other := s.dial("/ws/b")
+	for range 3 {
+		<-joined
+	}
+
+	// Act
+	sent, err := hub.BroadcastJSON("a", message{Text: "news"})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(2, sent)
+	s.Equal(1, hub.Count("b"))
+	for _, conn := range []*websocket.Conn{first, second} {
+		var got message
+		s.Require().NoError(wsjson.Read(context.Background(), conn, &got))
+		s.Equal("news", got.Text)
+	}
+	s.Require().NoError(other.Close(websocket.StatusNormalClosure, ""))
+	s.Eventually(func() bool { return hub.Count("b") == 0 }, time.Second, 5*time.Millisecond)
+}
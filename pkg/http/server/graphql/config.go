@@ -0,0 +1,34 @@
+package graphql
+
+const (
+	defaultPath            = "/graphql"
+	defaultComplexityLimit = 1000
+	defaultQueryCacheSize  = 1000
+)
+
+// Config configures the GraphQL endpoint.
+type Config struct {
+	// Path is the route of the endpoint, default: "/graphql"
+	Path string `config:"path"`
+	// PlaygroundPath serves the GraphQL playground when set, e.g. "/playground"
+	PlaygroundPath string `config:"playground_path"`
+	// Introspection lets the clients query the schema; keep it off in production
+	Introspection bool `config:"introspection"`
+	// ComplexityLimit rejects the operations of a higher complexity; negative for no limit
+	ComplexityLimit int `config:"complexity_limit"`
+	// QueryCacheSize is the number of parsed queries kept in memory
+	QueryCacheSize int `config:"query_cache_size"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Path == "" {
+		c.Path = defaultPath
+	}
+	if c.ComplexityLimit == 0 {
+		c.ComplexityLimit = defaultComplexityLimit
+	}
+	if c.QueryCacheSize <= 0 {
+		c.QueryCacheSize = defaultQueryCacheSize
+	}
+}
@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/99designs/gqlgen/graphql"
+	lib_validator "github.com/go-playground/validator/v10"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// errInvalidArgument answers the validation errors of the resolvers.
+var errInvalidArgument = errs.New("INVALID_ARGUMENT", "request has invalid fields", http.StatusUnprocessableEntity, nil)
+
+// presentError translates the errors of the resolvers into GraphQL errors: an errs.Error,
+// or its counterpart (see errs.Map), gives its message and its code, details and the
+// request ID in the extensions; the other errors are logged and answered with
+// errs.ErrInternal. The errors of the GraphQL layer, e.g. parsing or validation of the
+// query, are kept as is.
+func (h *Handler) presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if gqlErr == nil || gqlErr.Err == nil {
+		return gqlErr
+	}
+
+	rError := h.translate(ctx, gqlErr.Err)
+	presented := *gqlErr
+	presented.Message = rError.Message
+	presented.Extensions = map[string]any{"code": rError.Code}
+	if len(rError.Details) > 0 {
+		presented.Extensions["details"] = rError.Details
+	}
+	if rError = rError.WithRequestID(ctx); rError.RequestID != "" {
+		presented.Extensions["request_id"] = rError.RequestID
+	}
+	return &presented
+}
+
+func (h *Handler) translate(ctx context.Context, err error) *errs.Error {
+	var validationErrors lib_validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		details := make([]errs.Detail, 0, len(validationErrors))
+		for _, e := range validationErrors {
+			detail := errs.Detail{
+				Field:  lowerFirst(e.Field()),
+				Rule:   e.Tag(),
+				Params: strings.Fields(e.Param()),
+			}
+			if h.validator != nil {
+				detail.Message = e.Translate(h.validator.Translator())
+			}
+			details = append(details, detail)
+		}
+		invalid := *errInvalidArgument
+		invalid.Details = details
+		return &invalid
+	}
+
+	rError, ok := errs.Map(err)
+	if !ok {
+		h.logger.ErrorContext(ctx, "graphql resolver failed", "path", graphql.GetPath(ctx).String(), "err", err)
+		return errs.ErrInternal
+	}
+	return rError
+}
+
+// recoverPanic logs the panics of the resolvers with their stack, and answers them with
+// errs.ErrInternal.
+func (h *Handler) recoverPanic(ctx context.Context, recovered any) error {
+	h.logger.ErrorContext(ctx, "graphql resolver panicked",
+		"panic", fmt.Sprint(recovered),
+		"path", graphql.GetPath(ctx).String(),
+		"stack", string(debug.Stack()),
+	)
+	return errs.ErrInternal
+}
+
+// lowerFirst turns a Go field name into the camelCase name of the GraphQL input.
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
@@ -0,0 +1,58 @@
+package graphql
+
+import (
+	"log/slog"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+)
+
+// Module provides the *Handler of the graphql.ExecutableSchema provided by the
+// application and mounts it on the chi server. It loads the config from "app.graphql".
+//
+//	fx.New(
+//	    chi.Module,
+//	    graphql.Module,
+//	    fx.Provide(func(resolver *Resolver) gqlgen.ExecutableSchema {
+//	        return generated.NewExecutableSchema(generated.Config{Resolvers: resolver})
+//	    }),
+//	)
+var Module = fx.Module(
+	"graphql",
+	config.Provide[Config]("app.graphql"),
+	fx.Provide(
+		NewWithParams,
+		fx.Annotate(asRoute, fx.As(new(chi.Route)), fx.ResultTags(`group:"routes"`)),
+	),
+)
+
+// Params for dependency injection
+type Params struct {
+	fx.In
+	Config config.Config[Config]
+	Schema graphql.ExecutableSchema
+	// Options add e.g. the authentication middleware with WithMiddleware
+	Options    []Option              `optional:"true"`
+	Logger     *slog.Logger          `optional:"true"`
+	Registerer prometheus.Registerer `optional:"true"`
+	Validator  validator.Validator   `optional:"true"`
+}
+
+// NewWithParams creates the *Handler from FX dependencies.
+func NewWithParams(params Params) (*Handler, error) {
+	opts := []Option{
+		WithLogger(params.Logger),
+		WithRegisterer(params.Registerer),
+		WithValidator(params.Validator),
+	}
+	return New(params.Schema, params.Config.Get(), append(opts, params.Options...)...)
+}
+
+func asRoute(h *Handler) *Handler {
+	return h
+}
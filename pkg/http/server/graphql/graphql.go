@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+)
+
+// Handler serves a gqlgen schema over HTTP, with the errors of the resolvers translated
+// from errs, a complexity limit and metrics and logs per operation. It implements
+// chi.Route, mounting the endpoint and the playground on the chi server.
+type Handler struct {
+	cfg         Config
+	server      *handler.Server
+	middlewares []func(http.Handler) http.Handler
+	validator   validator.Validator
+	metrics     *operationMetrics
+	logger      *slog.Logger
+}
+
+// New creates the Handler of schema, the ExecutableSchema generated by gqlgen:
+//
+//	h, err := graphql.New(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}), cfg)
+func New(schema graphql.ExecutableSchema, cfg Config, opts ...Option) (*Handler, error) {
+	cfg.SetDefaults()
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	metrics, err := newOperationMetrics(options.registerer)
+	if err != nil {
+		return nil, fmt.Errorf("graphql: register metrics: %w", err)
+	}
+
+	h := &Handler{
+		cfg:         cfg,
+		server:      handler.New(schema),
+		middlewares: options.middlewares,
+		validator:   options.validator,
+		metrics:     metrics,
+		logger:      options.logger,
+	}
+	h.server.AddTransport(transport.GET{})
+	h.server.AddTransport(transport.POST{})
+	h.server.AddTransport(transport.MultipartForm{})
+	h.server.SetQueryCache(lru.New[*ast.QueryDocument](cfg.QueryCacheSize))
+	h.server.SetErrorPresenter(h.presentError)
+	h.server.SetRecoverFunc(h.recoverPanic)
+	if cfg.Introspection {
+		h.server.Use(extension.Introspection{})
+	}
+	if cfg.ComplexityLimit > 0 {
+		h.server.Use(extension.FixedComplexityLimit(cfg.ComplexityLimit))
+	}
+	h.server.Use(observer{handler: h})
+	return h, nil
+}
+
+// Server returns the gqlgen server, e.g. to add a transport or an extension.
+func (h *Handler) Server() *handler.Server {
+	return h.server
+}
+
+// ServeHTTP implements http.Handler, without the middlewares of WithMiddleware.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.server.ServeHTTP(w, r)
+}
+
+// Setup implements chi.Route: it mounts the endpoint, behind the middlewares of
+// WithMiddleware, and the playground when configured.
+func (h *Handler) Setup(server *chi.Server) {
+	router := server.Router().With(h.middlewares...)
+	router.Handle(h.cfg.Path, h.server)
+	if h.cfg.PlaygroundPath != "" {
+		server.Router().Get(h.cfg.PlaygroundPath, playground.Handler("GraphQL", h.cfg.Path))
+	}
+}
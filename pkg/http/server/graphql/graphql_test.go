@@ -0,0 +1,301 @@
+package graphql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gqlgen "github.com/99designs/gqlgen/graphql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/graphql"
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+)
+
+var testSchema = gqlparser.MustLoadSchema(&ast.Source{Input: `
+	type Query {
+		product(id: ID!): String!
+	}
+	type Mutation {
+		createProduct(name: String!): String!
+	}
+`})
+
+type response struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// newSchema returns a schema whose product field resolves with resolve, and whose fields
+// all have a complexity of complexity.
+func newSchema(complexity int, resolve func(ctx context.Context) (any, error)) gqlgen.ExecutableSchema {
+	return &gqlgen.ExecutableSchemaMock{
+		SchemaFunc: func() *ast.Schema { return testSchema },
+		ComplexityFunc: func(context.Context, string, string, int, map[string]any) (int, bool) {
+			return complexity, true
+		},
+		// Like the generated code, the fields are resolved by the response handler
+		ExecFunc: func(context.Context) gqlgen.ResponseHandler {
+			done := false
+			return func(ctx context.Context) *gqlgen.Response {
+				if done {
+					return nil
+				}
+				done = true
+				ctx = gqlgen.WithFieldContext(ctx, &gqlgen.FieldContext{
+					Object: "Query",
+					Field:  gqlgen.CollectedField{Field: &ast.Field{Name: "product", Alias: "product"}},
+				})
+				value, err := resolve(ctx)
+				if err != nil {
+					gqlgen.AddError(ctx, err)
+					return &gqlgen.Response{Data: []byte(`null`)}
+				}
+				data, _ := json.Marshal(map[string]any{"product": value})
+				return &gqlgen.Response{Data: data}
+			}
+		},
+	}
+}
+
+func newHandler(
+	t *testing.T,
+	cfg graphql.Config,
+	resolve func(ctx context.Context) (any, error),
+	opts ...graphql.Option,
+) (*graphql.Handler, *prometheus.Registry) {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	h, err := graphql.New(newSchema(1, resolve), cfg, append([]graphql.Option{
+		graphql.WithRegisterer(registry),
+		graphql.WithLogger(slog.New(slog.DiscardHandler)),
+	}, opts...)...)
+	require.NoError(t, err)
+	return h, registry
+}
+
+func post(t *testing.T, handler http.Handler, ctx context.Context, query string) response {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": query})
+	require.NoError(t, err)
+	req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	var resp response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+	return resp
+}
+
+func TestHandler_ServeHTTP_Success_RecordsOperationMetrics(t *testing.T) {
+	// Arrange
+	h, registry := newHandler(t, graphql.Config{}, func(context.Context) (any, error) {
+		return "Keyboard", nil
+	})
+
+	// Act
+	resp := post(t, h, context.Background(), `query GetProduct { product(id: "1") }`)
+
+	// Assert
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, "Keyboard", resp.Data["product"])
+	expected := `
+		# HELP graphql_operations_total Total GraphQL operations by operation name, type and result (ok, error)
+		# TYPE graphql_operations_total counter
+		graphql_operations_total{operation="GetProduct",result="ok",type="query"} 1
+	`
+	require.NoError(t, testutil.GatherAndCompare(registry, bytes.NewBufferString(expected), "graphql_operations_total"))
+}
+
+func TestHandler_ServeHTTP_ErrsError_TranslatesIntoGraphQLError(t *testing.T) {
+	// Arrange
+	h, registry := newHandler(t, graphql.Config{}, func(context.Context) (any, error) {
+		return nil, errs.NotFound("PRODUCT_NOT_FOUND", "Product not found")
+	})
+	ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+
+	// Act
+	resp := post(t, h, ctx, `{ product(id: "1") }`)
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "Product not found", resp.Errors[0].Message)
+	assert.Equal(t, []any{"product"}, resp.Errors[0].Path)
+	assert.Equal(t, map[string]any{"code": "PRODUCT_NOT_FOUND", "request_id": "req-1"}, resp.Errors[0].Extensions)
+	expected := `
+		# HELP graphql_operations_total Total GraphQL operations by operation name, type and result (ok, error)
+		# TYPE graphql_operations_total counter
+		graphql_operations_total{operation="anonymous",result="error",type="query"} 1
+	`
+	require.NoError(t, testutil.GatherAndCompare(registry, bytes.NewBufferString(expected), "graphql_operations_total"))
+}
+
+func TestHandler_ServeHTTP_UnexpectedError_HidesMessage(t *testing.T) {
+	// Arrange
+	h, _ := newHandler(t, graphql.Config{}, func(context.Context) (any, error) {
+		return nil, errors.New("connection refused to 10.0.0.3")
+	})
+
+	// Act
+	resp := post(t, h, context.Background(), `{ product(id: "1") }`)
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, errs.ErrInternal.Message, resp.Errors[0].Message)
+	assert.Equal(t, errs.ErrInternal.Code, resp.Errors[0].Extensions["code"])
+}
+
+func TestHandler_ServeHTTP_ValidationError_ReturnsDetails(t *testing.T) {
+	// Arrange
+	validate, err := validator.New()
+	require.NoError(t, err)
+	type input struct {
+		Name string `validate:"required"`
+	}
+	h, _ := newHandler(t, graphql.Config{}, func(context.Context) (any, error) {
+		return nil, validate.Validate(input{})
+	}, graphql.WithValidator(validate))
+
+	// Act
+	resp := post(t, h, context.Background(), `{ product(id: "1") }`)
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "INVALID_ARGUMENT", resp.Errors[0].Extensions["code"])
+	details, ok := resp.Errors[0].Extensions["details"].([]any)
+	require.True(t, ok)
+	require.Len(t, details, 1)
+	detail := details[0].(map[string]any)
+	assert.Equal(t, "name", detail["field"])
+	assert.Equal(t, "required", detail["rule"])
+	assert.Equal(t, "Name is a required field", detail["message"])
+}
+
+func TestHandler_ServeHTTP_ResolverPanics_ReturnsInternalError(t *testing.T) {
+	// Arrange
+	h, _ := newHandler(t, graphql.Config{}, func(context.Context) (any, error) {
+		panic("boom")
+	})
+
+	// Act
+	resp := post(t, h, context.Background(), `{ product(id: "1") }`)
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, errs.ErrInternal.Message, resp.Errors[0].Message)
+}
+
+func TestHandler_ServeHTTP_InvalidQuery_KeepsGraphQLError(t *testing.T) {
+	// Arrange
+	h, registry := newHandler(t, graphql.Config{}, func(context.Context) (any, error) {
+		return "Keyboard", nil
+	})
+
+	// Act
+	resp := post(t, h, context.Background(), `{ price }`)
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, "price")
+	assert.Equal(t, "GRAPHQL_VALIDATION_FAILED", resp.Errors[0].Extensions["code"])
+	expected := `
+		# HELP graphql_operations_total Total GraphQL operations by operation name, type and result (ok, error)
+		# TYPE graphql_operations_total counter
+		graphql_operations_total{operation="anonymous",result="error",type="rejected"} 1
+	`
+	require.NoError(t, testutil.GatherAndCompare(registry, bytes.NewBufferString(expected), "graphql_operations_total"))
+}
+
+func TestHandler_ServeHTTP_AboveComplexityLimit_RejectsOperation(t *testing.T) {
+	// Arrange
+	registry := prometheus.NewRegistry()
+	h, err := graphql.New(newSchema(50, func(context.Context) (any, error) {
+		return "Keyboard", nil
+	}), graphql.Config{ComplexityLimit: 10}, graphql.WithRegisterer(registry))
+	require.NoError(t, err)
+
+	// Act
+	resp := post(t, h, context.Background(), `{ product(id: "1") }`)
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "COMPLEXITY_LIMIT_EXCEEDED", resp.Errors[0].Extensions["code"])
+	assert.Nil(t, resp.Data)
+}
+
+func TestHandler_ServeHTTP_Introspection(t *testing.T) {
+	// The generated resolvers of __schema and __type fail when introspection is disabled
+	resolve := func(ctx context.Context) (any, error) {
+		if gqlgen.GetOperationContext(ctx).DisableIntrospection {
+			return nil, gqlerror.Errorf("introspection disabled")
+		}
+		return "Keyboard", nil
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		// Arrange
+		h, _ := newHandler(t, graphql.Config{}, resolve)
+
+		// Act
+		resp := post(t, h, context.Background(), `{ product(id: "1") }`)
+
+		// Assert
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "introspection disabled", resp.Errors[0].Message)
+	})
+
+	t.Run("enabled by the config", func(t *testing.T) {
+		// Arrange
+		h, _ := newHandler(t, graphql.Config{Introspection: true}, resolve)
+
+		// Act
+		resp := post(t, h, context.Background(), `{ product(id: "1") }`)
+
+		// Assert
+		assert.Empty(t, resp.Errors)
+	})
+}
+
+func TestHandler_Setup_MountsEndpointBehindMiddlewares(t *testing.T) {
+	// Arrange
+	server, err := chi.New(chi.Default())
+	require.NoError(t, err)
+	deny := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	}
+	h, _ := newHandler(t, graphql.Config{Path: "/api/graphql", PlaygroundPath: "/playground"},
+		func(context.Context) (any, error) { return "Keyboard", nil }, graphql.WithMiddleware(deny))
+	server.RegisterRoute(h)
+	server.SetupRoutes()
+
+	// Act
+	endpoint := httptest.NewRecorder()
+	server.Router().ServeHTTP(endpoint, httptest.NewRequest(http.MethodPost, "/api/graphql", nil))
+	playground := httptest.NewRecorder()
+	server.Router().ServeHTTP(playground, httptest.NewRequest(http.MethodGet, "/playground", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, endpoint.Code)
+	assert.Equal(t, http.StatusOK, playground.Code)
+	assert.Contains(t, playground.Body.String(), "/api/graphql")
+}
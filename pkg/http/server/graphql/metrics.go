@@ -0,0 +1,55 @@
+package graphql
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	operationsMetricName = "graphql_operations_total"
+	durationMetricName   = "graphql_operation_duration_seconds"
+
+	resultOK    = "ok"
+	resultError = "error"
+)
+
+type operationMetrics struct {
+	operations *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+}
+
+func newOperationMetrics(registerer prometheus.Registerer) (*operationMetrics, error) {
+	operations, err := metrics.Register(registerer, prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: operationsMetricName,
+			Help: "Total GraphQL operations by operation name, type and result (ok, error)",
+		},
+		[]string{"operation", "type", "result"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := metrics.Register(registerer, prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    durationMetricName,
+			Help:    "Duration of the GraphQL operations in seconds, parsing included",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"operation", "type"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &operationMetrics{operations: operations, duration: duration}, nil
+}
+
+func (m *operationMetrics) observe(operation, operationType, result string, elapsed time.Duration) {
+	m.operations.WithLabelValues(operation, operationType, result).Inc()
+	m.duration.WithLabelValues(operation, operationType).Observe(elapsed.Seconds())
+}
+
+// register registers collector, or returns the collector already registered under its
+// name, so several handlers can share a registerer.
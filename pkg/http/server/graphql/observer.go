@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	anonymousOperation = "anonymous"
+	// rejectedOperation is the type of the operations rejected before their execution,
+	// e.g. a query that does not parse or is above the complexity limit
+	rejectedOperation = "rejected"
+)
+
+// observer is the extension recording the metrics and the log of each operation.
+type observer struct {
+	handler *Handler
+}
+
+var (
+	_ graphql.HandlerExtension    = observer{}
+	_ graphql.ResponseInterceptor = observer{}
+)
+
+// ExtensionName implements graphql.HandlerExtension.
+func (observer) ExtensionName() string {
+	return "BricksObserver"
+}
+
+// Validate implements graphql.HandlerExtension.
+func (observer) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptResponse implements graphql.ResponseInterceptor. The events of the
+// subscriptions are not observed, only queries and mutations.
+func (o observer) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	response := next(ctx)
+	if !graphql.HasOperationContext(ctx) {
+		return response
+	}
+	opCtx := graphql.GetOperationContext(ctx)
+	operationType := rejectedOperation
+	if opCtx.Operation != nil {
+		if opCtx.Operation.Operation == ast.Subscription {
+			return response
+		}
+		operationType = string(opCtx.Operation.Operation)
+	}
+	name := opCtx.OperationName
+	if name == "" && opCtx.Operation != nil {
+		name = opCtx.Operation.Name
+	}
+	if name == "" {
+		name = anonymousOperation
+	}
+
+	result := resultOK
+	var errorCount int
+	if response != nil && len(response.Errors) > 0 {
+		result = resultError
+		errorCount = len(response.Errors)
+	}
+	var elapsed time.Duration
+	if !opCtx.Stats.OperationStart.IsZero() {
+		elapsed = time.Since(opCtx.Stats.OperationStart)
+	}
+	o.handler.metrics.observe(name, operationType, result, elapsed)
+	o.handler.logger.InfoContext(ctx, "graphql operation",
+		"operation", name,
+		"type", operationType,
+		"duration", elapsed,
+		"errors", errorCount,
+	)
+	return response
+}
@@ -0,0 +1,63 @@
+package graphql
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+)
+
+type options struct {
+	middlewares []func(http.Handler) http.Handler
+	registerer  prometheus.Registerer
+	logger      *slog.Logger
+	validator   validator.Validator
+}
+
+// Option configures the Handler created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		registerer: prometheus.DefaultRegisterer,
+		logger:     slog.Default(),
+	}
+}
+
+// WithMiddleware adds middlewares to the endpoint route, e.g. the authentication:
+//
+//	graphql.WithMiddleware(jwt.Middleware(manager, newClaims))
+func WithMiddleware(middlewares ...func(http.Handler) http.Handler) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// WithRegisterer sets the registerer the operation metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithLogger sets the logger of the operations and of the unexpected errors.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithValidator translates the messages of the validation errors returned by the
+// resolvers. Without it, their details only carry the field and the rule.
+func WithValidator(validate validator.Validator) Option {
+	return func(o *options) {
+		o.validator = validate
+	}
+}
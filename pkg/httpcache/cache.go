@@ -0,0 +1,193 @@
+package httpcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+// HeaderCache reports whether a response was served from the cache (HIT) or not (MISS).
+const HeaderCache = "X-Cache"
+
+// Cache caches whole GET responses in a ResponseStore, keyed by path, query string and the
+// Vary request headers. Concurrent misses of a key run the handler once and share its
+// response, so an expired popular entry does not send a stampede to the database.
+type Cache struct {
+	store   ResponseStore
+	cfg     Config
+	group   singleflight.Group
+	clock   clock.Clock
+	metrics *cacheMetrics
+	logger  *slog.Logger
+}
+
+// NewCache creates a Cache storing the responses in store.
+func NewCache(store ResponseStore, cfg Config, opts ...Option) (*Cache, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	cacheMetrics, err := newCacheMetrics(options.registerer)
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: register metrics: %w", err)
+	}
+	return &Cache{
+		store:   store,
+		cfg:     cfg,
+		clock:   options.clock,
+		metrics: cacheMetrics,
+		logger:  options.logger,
+	}, nil
+}
+
+// Middleware caches the responses of the routes it wraps for the configured TTL.
+//
+//	router.With(cache.Middleware).Get("/products", h.List)
+func (c *Cache) Middleware(next http.Handler) http.Handler {
+	return c.For(c.cfg.TTL)(next)
+}
+
+// For returns a middleware caching the responses of the routes it wraps for ttl.
+//
+//	router.With(cache.For(10 * time.Minute)).Get("/categories", h.Categories)
+func (c *Cache) For(ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.serve(w, r, next, ttl)
+		})
+	}
+}
+
+// Invalidate deletes the cached responses of path, for every query string and Vary
+// header, e.g. after an update of the resource.
+//
+//	err := cache.Invalidate(ctx, "/products/42")
+func (c *Cache) Invalidate(ctx context.Context, path string) error {
+	if _, err := c.store.DeletePrefix(ctx, c.cfg.Prefix+path+"|"); err != nil {
+		return fmt.Errorf("httpcache: invalidate %s: %w", path, err)
+	}
+	return nil
+}
+
+// InvalidatePrefix deletes the cached responses of the paths starting with prefix, e.g.
+// "/products" for the list and every product.
+func (c *Cache) InvalidatePrefix(ctx context.Context, prefix string) error {
+	if _, err := c.store.DeletePrefix(ctx, c.cfg.Prefix+prefix); err != nil {
+		return fmt.Errorf("httpcache: invalidate %s*: %w", prefix, err)
+	}
+	return nil
+}
+
+func (c *Cache) serve(w http.ResponseWriter, r *http.Request, next http.Handler, ttl time.Duration) {
+	// The responses of authenticated requests belong to their user
+	if r.Method != http.MethodGet || r.Header.Get("Authorization") != "" {
+		c.metrics.observe(resultBypass)
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	key := c.key(r)
+	if cached, ok := c.load(ctx, key); ok {
+		c.metrics.observe(resultHit)
+		c.write(w, cached, ttl, "HIT")
+		return
+	}
+
+	c.metrics.observe(resultMiss)
+	leader := false
+	shared, _, _ := c.group.Do(key, func() (any, error) {
+		leader = true
+		rec := newRecorder()
+		next.ServeHTTP(rec, r)
+		recorded := rec.entry(c.clock.Now())
+		if recorded.cacheable(c.cfg.MaxBodySize) {
+			c.save(ctx, key, recorded, ttl)
+		}
+		return recorded, nil
+	})
+	recorded, _ := shared.(entry)
+	if !leader && !recorded.cacheable(c.cfg.MaxBodySize) {
+		// The response of another request, e.g. an error, is not shared
+		next.ServeHTTP(w, r)
+		return
+	}
+	c.write(w, recorded, ttl, "MISS")
+}
+
+// key returns the key of the response of r: the path, then a hash of the query string
+// and the Vary headers, so Invalidate can delete every response of a path.
+func (c *Cache) key(r *http.Request) string {
+	hash := sha256.New()
+	// Encode sorts the params, so their order in the URL does not matter
+	hash.Write([]byte(r.URL.Query().Encode()))
+	for _, header := range c.cfg.Vary {
+		hash.Write([]byte("\n" + header + ":" + strings.Join(r.Header.Values(header), ",")))
+	}
+	return c.cfg.Prefix + r.URL.Path + "|" + hex.EncodeToString(hash.Sum(nil)[:16])
+}
+
+// load returns the cached response of key. A store failure is logged and treated as a
+// miss, so the cache never takes the route down.
+func (c *Cache) load(ctx context.Context, key string) (entry, bool) {
+	data, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.logger.WarnContext(ctx, "http cache read failed", "key", key, "err", err)
+		return entry{}, false
+	}
+	if !ok {
+		return entry{}, false
+	}
+	var cached entry
+	if err = json.Unmarshal(data, &cached); err != nil {
+		c.logger.WarnContext(ctx, "http cache entry is corrupted", "key", key, "err", err)
+		return entry{}, false
+	}
+	return cached, true
+}
+
+func (c *Cache) save(ctx context.Context, key string, recorded entry, ttl time.Duration) {
+	// Marshaling an entry cannot fail
+	data, _ := json.Marshal(recorded)
+	// The response is complete, store it even when the client left meanwhile
+	if err := c.store.Set(context.WithoutCancel(ctx), key, data, ttl); err != nil {
+		c.logger.WarnContext(ctx, "http cache write failed", "key", key, "err", err)
+	}
+}
+
+func (c *Cache) write(w http.ResponseWriter, response entry, ttl time.Duration, result string) {
+	header := w.Header()
+	for name, values := range response.Header {
+		header[name] = slices.Clone(values)
+	}
+	if response.cacheable(c.cfg.MaxBodySize) {
+		header.Set(HeaderCache, result)
+		if header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(ttl.Seconds())))
+		}
+		if len(c.cfg.Vary) > 0 {
+			header.Set("Vary", strings.Join(c.cfg.Vary, ", "))
+		}
+		if result == "HIT" {
+			header.Set("Age", strconv.Itoa(int(c.clock.Since(response.StoredAt).Seconds())))
+		}
+	}
+	w.WriteHeader(response.Status)
+	_, _ = w.Write(response.Body)
+}
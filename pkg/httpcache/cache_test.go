@@ -0,0 +1,254 @@
+package httpcache_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/httpcache"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+// memoryStore is a ResponseStore in a map, ignoring the TTLs.
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[key]
+	return value, ok, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *memoryStore) DeletePrefix(_ context.Context, prefix string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for key := range s.values {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.values, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+type CacheTestSuite struct {
+	suite.Suite
+	sut     *httpcache.Cache
+	store   *memoryStore
+	clock   *clock.Fake
+	calls   atomic.Int32
+	handler http.Handler
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+func (s *CacheTestSuite) SetupTest() {
+	s.store = &memoryStore{values: make(map[string][]byte)}
+	s.clock = clock.NewFake(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	s.calls.Store(0)
+	var err error
+	s.sut, err = httpcache.NewCache(s.store, httpcache.Config{TTL: time.Minute},
+		httpcache.WithClock(s.clock),
+		httpcache.WithRegisterer(prometheus.NewRegistry()),
+	)
+	s.Require().NoError(err)
+	s.handler = s.sut.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `","q":"` + r.URL.RawQuery + `"}`))
+	}))
+}
+
+func (s *CacheTestSuite) get(target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rr := httptest.NewRecorder()
+	s.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *CacheTestSuite) TestMiddleware_ServesTheSecondRequestFromTheCache() {
+	// Arrange
+	first := s.get("/products?page=1&sort=name", nil)
+	s.clock.Advance(10 * time.Second)
+
+	// Act
+	second := s.get("/products?sort=name&page=1", nil)
+
+	// Assert
+	s.Equal(int32(1), s.calls.Load())
+	s.Equal("MISS", first.Header().Get(httpcache.HeaderCache))
+	s.Equal("HIT", second.Header().Get(httpcache.HeaderCache))
+	s.Equal(first.Body.String(), second.Body.String())
+	s.Equal("application/json", second.Header().Get("Content-Type"))
+	s.Equal("public, max-age=60", second.Header().Get("Cache-Control"))
+	s.Equal("10", second.Header().Get("Age"))
+	s.Equal("Accept, Accept-Encoding, Accept-Language", second.Header().Get("Vary"))
+}
+
+func (s *CacheTestSuite) TestMiddleware_KeysByQueryAndVaryHeaders() {
+	// Arrange
+	s.get("/products?page=1", http.Header{"Accept-Language": {"en"}})
+
+	// Act
+	s.get("/products?page=2", http.Header{"Accept-Language": {"en"}})
+	s.get("/products?page=1", http.Header{"Accept-Language": {"pt-BR"}})
+
+	// Assert
+	s.Equal(int32(3), s.calls.Load())
+}
+
+func (s *CacheTestSuite) TestMiddleware_BypassesAuthenticatedAndNonGetRequests() {
+	// Arrange
+	req := httptest.NewRequest(http.MethodPost, "/products", nil)
+
+	// Act
+	s.handler.ServeHTTP(httptest.NewRecorder(), req)
+	s.handler.ServeHTTP(httptest.NewRecorder(), req)
+	s.get("/products", http.Header{"Authorization": {"Bearer token"}})
+	rr := s.get("/products", http.Header{"Authorization": {"Bearer token"}})
+
+	// Assert
+	s.Equal(int32(4), s.calls.Load())
+	s.Empty(rr.Header().Get(httpcache.HeaderCache))
+	s.Empty(s.store.values)
+}
+
+func (s *CacheTestSuite) TestMiddleware_DoesNotCacheErrorsAndPrivateResponses() {
+	tests := []struct {
+		name  string
+		write func(w http.ResponseWriter)
+	}{
+		{name: "error", write: func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) }},
+		{name: "no-store", write: func(w http.ResponseWriter) { w.Header().Set("Cache-Control", "no-store") }},
+		{name: "private", write: func(w http.ResponseWriter) { w.Header().Set("Cache-Control", "private, max-age=60") }},
+		{name: "cookie", write: func(w http.ResponseWriter) { w.Header().Set("Set-Cookie", "session=1") }},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Arrange
+			var calls int
+			handler := s.sut.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls++
+				tt.write(w)
+			}))
+
+			// Act
+			for range 2 {
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/"+tt.name, nil))
+			}
+
+			// Assert
+			s.Equal(2, calls)
+		})
+	}
+}
+
+func (s *CacheTestSuite) TestMiddleware_ConcurrentMisses_RunTheHandlerOnce() {
+	// Arrange
+	release := make(chan struct{})
+	var calls atomic.Int32
+	handler := s.sut.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte("report"))
+	}))
+	const requests = 10
+	var wg sync.WaitGroup
+	bodies := make([]string, requests)
+
+	// Act
+	for i := range requests {
+		wg.Go(func() {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/report", nil))
+			bodies[i] = rr.Body.String()
+		})
+	}
+	s.Eventually(func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assert
+	s.LessOrEqual(calls.Load(), int32(2))
+	for _, body := range bodies {
+		s.Equal("report", body)
+	}
+}
+
+func (s *CacheTestSuite) TestInvalidate_DeletesTheResponsesOfThePath() {
+	// Arrange
+	s.get("/products/42", nil)
+	s.get("/products/42?fields=name", nil)
+	s.get("/products/420", nil)
+
+	// Act
+	err := s.sut.Invalidate(context.Background(), "/products/42")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Len(s.store.values, 1)
+	s.get("/products/42", nil)
+	s.Equal(int32(4), s.calls.Load())
+}
+
+func (s *CacheTestSuite) TestInvalidatePrefix_DeletesTheResponsesOfThePaths() {
+	// Arrange
+	s.get("/products", nil)
+	s.get("/products/42", nil)
+	s.get("/categories", nil)
+
+	// Act
+	err := s.sut.InvalidatePrefix(context.Background(), "/products")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Len(s.store.values, 1)
+}
+
+func (s *CacheTestSuite) TestMiddleware_StoreFailure_ServesTheHandlerResponse() {
+	// Arrange
+	store := mocks.NewMockResponseStore(s.T())
+	store.On("Get", mock.Anything, mock.Anything).Return(nil, false, errors.New("redis down"))
+	store.On("Set", mock.Anything, mock.Anything, mock.Anything, time.Minute).Return(errors.New("redis down"))
+	sut, err := httpcache.NewCache(store, httpcache.Config{}, httpcache.WithRegisterer(prometheus.NewRegistry()))
+	s.Require().NoError(err)
+	handler := sut.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+	s.Equal("ok", rr.Body.String())
+}
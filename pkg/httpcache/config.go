@@ -0,0 +1,60 @@
+package httpcache
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultPrefix      = "httpcache:"
+	defaultTTL         = time.Minute
+	defaultMaxBodySize = 1 << 20
+)
+
+// defaultVary are the request headers varying the cached responses by default.
+var defaultVary = []string{"Accept", "Accept-Encoding", "Accept-Language"}
+
+// Config configures the Cache.
+type Config struct {
+	// Prefix of the Redis keys, after the redis client namespace
+	Prefix string `config:"prefix"`
+	// TTL is the lifetime of the cached responses of the routes without their own
+	TTL time.Duration `config:"ttl"`
+	// Vary lists the request headers whose values key the cached responses, with the path
+	// and the query string
+	Vary []string `config:"vary"`
+	// MaxBodySize is the size of the largest body cached, in bytes
+	MaxBodySize int64 `config:"max_body_size"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Prefix == "" {
+		c.Prefix = defaultPrefix
+	}
+	if c.TTL == 0 {
+		c.TTL = defaultTTL
+	}
+	if c.Vary == nil {
+		c.Vary = defaultVary
+	}
+	if c.MaxBodySize == 0 {
+		c.MaxBodySize = defaultMaxBodySize
+	}
+	vary := make([]string, len(c.Vary))
+	for i, header := range c.Vary {
+		vary[i] = http.CanonicalHeaderKey(header)
+	}
+	c.Vary = vary
+}
+
+// Validate checks the TTL and the body size.
+func (c *Config) Validate() error {
+	if c.TTL < 0 {
+		return ErrInvalidTTL
+	}
+	if c.MaxBodySize < 0 {
+		return ErrInvalidMaxBodySize
+	}
+	return nil
+}
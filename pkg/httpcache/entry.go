@@ -0,0 +1,64 @@
+package httpcache
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// entry is a cached response.
+type entry struct {
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"stored_at"`
+}
+
+// recorder buffers the response of the handler, so it can be stored and written to every
+// request sharing it.
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{header: make(http.Header)}
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *recorder) Write(p []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	return rec.body.Write(p)
+}
+
+// entry returns the recorded response.
+func (rec *recorder) entry(storedAt time.Time) entry {
+	rec.WriteHeader(http.StatusOK)
+	return entry{Status: rec.status, Header: rec.header, Body: rec.body.Bytes(), StoredAt: storedAt}
+}
+
+// cacheable reports whether the response may be shared: a 200 without cookies, that the
+// handler did not mark private or no-store, and not larger than maxBodySize.
+func (e entry) cacheable(maxBodySize int64) bool {
+	if e.Status != http.StatusOK || int64(len(e.Body)) > maxBodySize || len(e.Header.Values("Set-Cookie")) > 0 {
+		return false
+	}
+	for _, directive := range strings.Split(strings.ToLower(e.Header.Get("Cache-Control")), ",") {
+		switch strings.TrimSpace(directive) {
+		case "no-store", "private", "no-cache":
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,8 @@
+package httpcache
+
+import "errors"
+
+var (
+	ErrInvalidTTL         = errors.New("invalid http cache ttl (must be positive)")
+	ErrInvalidMaxBodySize = errors.New("invalid http cache max body size (must be positive)")
+)
@@ -0,0 +1,45 @@
+package httpcache
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// Module provides the Cache, storing the responses in Redis. It loads the config from
+// "app.httpcache" and requires redis.ClientModule.
+//
+//	fx.New(
+//	    redis.ClientModule,
+//	    httpcache.Module,
+//	    fx.Invoke(func(server *chi.Server, cache *httpcache.Cache) {
+//	        server.Router().With(cache.Middleware).Get("/products", h.List)
+//	    }),
+//	)
+var Module = fx.Module(
+	"httpcache",
+	config.Provide[Config]("app.httpcache"),
+	fx.Provide(NewCacheWithParams),
+)
+
+// CacheParams for dependency injection
+type CacheParams struct {
+	fx.In
+
+	Config     config.Config[Config]
+	Redis      *redis.Client
+	Registerer prometheus.Registerer `optional:"true"`
+	Logger     *slog.Logger          `optional:"true"`
+}
+
+// NewCacheWithParams creates the Cache with a RedisStore.
+func NewCacheWithParams(params CacheParams) (*Cache, error) {
+	return NewCache(NewRedisStore(params.Redis), params.Config.Get(),
+		WithRegisterer(params.Registerer),
+		WithLogger(params.Logger),
+	)
+}
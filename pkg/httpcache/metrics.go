@@ -0,0 +1,37 @@
+package httpcache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	requestsMetricName = "httpcache_requests_total"
+
+	resultHit    = "hit"
+	resultMiss   = "miss"
+	resultBypass = "bypass"
+)
+
+type cacheMetrics struct {
+	requests *prometheus.CounterVec
+}
+
+func newCacheMetrics(registerer prometheus.Registerer) (*cacheMetrics, error) {
+	requests, err := metrics.Register(registerer, prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: requestsMetricName,
+			Help: "Total requests of the cached routes by result (hit, miss, bypass)",
+		},
+		[]string{"result"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &cacheMetrics{requests: requests}, nil
+}
+
+func (m *cacheMetrics) observe(result string) {
+	m.requests.WithLabelValues(result).Inc()
+}
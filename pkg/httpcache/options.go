@@ -0,0 +1,54 @@
+package httpcache
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+type options struct {
+	clock      clock.Clock
+	registerer prometheus.Registerer
+	logger     *slog.Logger
+}
+
+// Option configures the Cache created by NewCache.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		clock:      clock.New(),
+		registerer: prometheus.DefaultRegisterer,
+		logger:     slog.Default(),
+	}
+}
+
+// WithClock sets the clock of the Age header.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithRegisterer sets the registerer the request metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithLogger sets the logger of the store failures. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package httpcache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// ResponseStore holds the cached responses.
+type ResponseStore interface {
+	// Get returns the value of key, and false when there is none.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value in key, expiring after ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix deletes the keys starting with prefix and returns their number.
+	DeletePrefix(ctx context.Context, prefix string) (int64, error)
+}
+
+// RedisStore holds the cached responses in Redis, shared by the instances.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a RedisStore.
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Get implements ResponseStore.
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var get *goredis.StringCmd
+	err := s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		get = p.Get(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	value, err := get.Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements ResponseStore.
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, key, value, ttl)
+		return nil
+	})
+}
+
+// DeletePrefix implements ResponseStore. The keys are found with SCAN (see
+// redis.Client.DeleteKeys), so an invalidation costs a scan of the namespace.
+func (s *RedisStore) DeletePrefix(ctx context.Context, prefix string) (int64, error) {
+	return s.client.DeleteKeys(ctx, escapeGlob(prefix)+"*")
+}
+
+// globEscaper escapes the special characters of the Redis glob patterns.
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+func escapeGlob(s string) string {
+	return globEscaper.Replace(s)
+}
@@ -1,5 +1,29 @@
 package config
 
+import "time"
+
+const (
+	SourceFileSystem = "filesystem"
+	SourceHTTP       = "http"
+	SourceDatabase   = "database"
+)
+
 type Config struct {
 	Language string `config:"language"`
+	// Sources lists the locale sources by descending priority. Defaults to filesystem only.
+	Sources []string `config:"sources"`
+	// CacheTTL is how long remote (http, database) locale data is cached. Defaults to 5m.
+	CacheTTL time.Duration  `config:"cache_ttl"`
+	HTTP     HTTPConfig     `config:"http"`
+	Database DatabaseConfig `config:"database"`
+}
+
+type HTTPConfig struct {
+	BaseURL string        `config:"base_url"` // locale data is fetched from <base_url>/<locale>.json
+	Locales []string      `config:"locales"`  // locales served by the endpoint
+	Timeout time.Duration `config:"timeout"`  // request timeout, defaults to 5s
+}
+
+type DatabaseConfig struct {
+	Table string `config:"table"` // defaults to "translations"
 }
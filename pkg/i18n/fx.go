@@ -3,10 +3,14 @@ package i18n
 import (
 	bricksconfig "github.com/cristiano-pacheco/bricks/pkg/config"
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/config"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/middleware"
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/service"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/source"
 	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
 	"go.uber.org/fx"
+	"gorm.io/gorm"
 )
 
 var Module = fx.Module(
@@ -17,9 +21,12 @@ var Module = fx.Module(
 			return c.Get()
 		},
 		fx.Annotate(
-			service.NewLocaleLoaderService,
+			NewLocaleSource,
+			fx.As(new(ports.LocaleSource)),
+		),
+		fx.Annotate(
+			service.NewLocaleLoaderServiceWithSource,
 			fx.As(new(ports.LocaleLoaderService)),
-			fx.ParamTags(``, `group:"locale_filesystems"`),
 		),
 		fx.Annotate(
 			service.NewTranslationService,
@@ -30,5 +37,24 @@ var Module = fx.Module(
 			fx.As(new(ports.ErrorTranslatorService)),
 			fx.As(new(ucdecorator.ErrorTranslator)),
 		),
+		fx.Annotate(
+			service.NewValidationTranslatorService,
+			fx.As(new(ports.ValidationTranslatorService)),
+		),
+		middleware.NewLocaleMiddleware,
 	),
 )
+
+// NewLocaleSourceParams contains dependencies for creating the configured locale source.
+type NewLocaleSourceParams struct {
+	fx.In
+	Config      config.Config
+	FileSystems []locale.FileSystem `group:"locale_filesystems"`
+	// DB is required only when the database source is configured.
+	DB *gorm.DB `optional:"true"`
+}
+
+// NewLocaleSource creates the composite locale source configured in app.i18n.sources.
+func NewLocaleSource(params NewLocaleSourceParams) (*source.CompositeSource, error) {
+	return source.NewFromConfig(params.Config, params.FileSystems, params.DB)
+}
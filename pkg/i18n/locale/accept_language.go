@@ -0,0 +1,109 @@
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	wildcardTag    = "*"
+	defaultQuality = 1.0
+)
+
+// AcceptedLanguage is a language range parsed from an Accept-Language header.
+type AcceptedLanguage struct {
+	Tag     string
+	Quality float64
+}
+
+// ParseAcceptLanguage parses an Accept-Language header value into language ranges ordered by
+// descending quality. Ranges with q=0 or an invalid q-value are dropped; ties keep header order.
+func ParseAcceptLanguage(header string) []AcceptedLanguage {
+	accepted := make([]AcceptedLanguage, 0)
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		quality, ok := parseQuality(params)
+		if !ok || quality <= 0 {
+			continue
+		}
+
+		accepted = append(accepted, AcceptedLanguage{Tag: tag, Quality: quality})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].Quality > accepted[j].Quality
+	})
+
+	return accepted
+}
+
+// Negotiate returns the supported locale code that best matches the Accept-Language header.
+// Each range is matched exactly first and then by its base language (e.g. "pt-PT" matches "pt_BR").
+// Locale codes are compared case-insensitively, treating "-" and "_" as equivalent.
+func Negotiate(header string, supported []string) (string, bool) {
+	for _, accepted := range ParseAcceptLanguage(header) {
+		if accepted.Tag == wildcardTag {
+			continue
+		}
+
+		if code, ok := Match(accepted.Tag, supported); ok {
+			return code, true
+		}
+	}
+
+	return "", false
+}
+
+// Match returns the supported locale code matching the given code exactly or, failing that,
+// by base language.
+func Match(code string, supported []string) (string, bool) {
+	normalized := Normalize(code)
+	for _, candidate := range supported {
+		if Normalize(candidate) == normalized {
+			return candidate, true
+		}
+	}
+
+	base := Base(code)
+	for _, candidate := range supported {
+		if Base(candidate) == base {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// Normalize lowercases a locale code and uses "-" as the subtag separator.
+func Normalize(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "_", "-"))
+}
+
+// Base returns the normalized primary language subtag of a locale code (e.g. "pt" for "pt_BR").
+func Base(code string) string {
+	base, _, _ := strings.Cut(Normalize(code), "-")
+	return base
+}
+
+func parseQuality(params string) (float64, bool) {
+	for param := range strings.SplitSeq(params, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.TrimSpace(name) != "q" {
+			continue
+		}
+
+		quality, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || quality > 1 {
+			return 0, false
+		}
+		return quality, true
+	}
+
+	return defaultQuality, true
+}
@@ -0,0 +1,79 @@
+package locale_test
+
+import (
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Run("orders ranges by quality keeping header order on ties", func(t *testing.T) {
+		// Act
+		accepted := locale.ParseAcceptLanguage("en;q=0.5, pt-BR, fr;q=0.8, de")
+
+		// Assert
+		require.Equal(t, []locale.AcceptedLanguage{
+			{Tag: "pt-BR", Quality: 1},
+			{Tag: "de", Quality: 1},
+			{Tag: "fr", Quality: 0.8},
+			{Tag: "en", Quality: 0.5},
+		}, accepted)
+	})
+
+	t.Run("drops empty, zero quality and invalid quality ranges", func(t *testing.T) {
+		// Act
+		accepted := locale.ParseAcceptLanguage(" , es;q=0, it;q=abc, nl;q=2, pt")
+
+		// Assert
+		require.Equal(t, []locale.AcceptedLanguage{{Tag: "pt", Quality: 1}}, accepted)
+	})
+
+	t.Run("returns empty slice for empty header", func(t *testing.T) {
+		// Act
+		accepted := locale.ParseAcceptLanguage("")
+
+		// Assert
+		require.Empty(t, accepted)
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"en", "pt_BR", "es"}
+
+	t.Run("matches exactly ignoring case and separator", func(t *testing.T) {
+		// Act
+		code, ok := locale.Negotiate("PT-br", supported)
+
+		// Assert
+		require.True(t, ok)
+		require.Equal(t, "pt_BR", code)
+	})
+
+	t.Run("matches by base language", func(t *testing.T) {
+		// Act
+		code, ok := locale.Negotiate("fr;q=0.9, es-AR;q=0.8", supported)
+
+		// Assert
+		require.True(t, ok)
+		require.Equal(t, "es", code)
+	})
+
+	t.Run("respects quality values", func(t *testing.T) {
+		// Act
+		code, ok := locale.Negotiate("en;q=0.4, pt;q=0.7", supported)
+
+		// Assert
+		require.True(t, ok)
+		require.Equal(t, "pt_BR", code)
+	})
+
+	t.Run("ignores wildcard and unsupported languages", func(t *testing.T) {
+		// Act
+		code, ok := locale.Negotiate("*, fr", supported)
+
+		// Assert
+		require.False(t, ok)
+		require.Empty(t, code)
+	})
+}
@@ -0,0 +1,16 @@
+package locale
+
+import "context"
+
+type contextKey struct{}
+
+// WithLocale returns a copy of ctx carrying the given locale code.
+func WithLocale(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, contextKey{}, code)
+}
+
+// FromContext returns the locale code stored in ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	code, ok := ctx.Value(contextKey{}).(string)
+	return code, ok && code != ""
+}
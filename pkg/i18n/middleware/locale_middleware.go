@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+)
+
+const (
+	acceptLanguageHeader  = "Accept-Language"
+	contentLanguageHeader = "Content-Language"
+	varyHeader            = "Vary"
+)
+
+// LocaleMiddleware negotiates the request locale from the Accept-Language header against the
+// available locale files and stores it in the request context (see locale.FromContext). The
+// locales are listed on each request, so the locales added to the source later are negotiated;
+// wrap a remote source with source.NewCachedSource to avoid a call per request.
+type LocaleMiddleware struct {
+	localeLoaderService ports.LocaleLoaderService
+}
+
+func NewLocaleMiddleware(localeLoaderService ports.LocaleLoaderService) *LocaleMiddleware {
+	return &LocaleMiddleware{localeLoaderService: localeLoaderService}
+}
+
+// Handler is a chi-compatible middleware. Requests without a matching language keep the configured locale.
+func (m *LocaleMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(varyHeader, acceptLanguageHeader)
+
+		code, ok := locale.Negotiate(r.Header.Get(acceptLanguageHeader), m.localeLoaderService.Locales())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set(contentLanguageHeader, code)
+		next.ServeHTTP(w, r.WithContext(locale.WithLocale(r.Context(), code)))
+	})
+}
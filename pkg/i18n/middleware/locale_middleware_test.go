@@ -0,0 +1,85 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/middleware"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocaleMiddlewareTestSuite struct {
+	suite.Suite
+	sut               *middleware.LocaleMiddleware
+	localeLoaderMock  *mocks.MockLocaleLoaderService
+	capturedLocale    string
+	capturedHasLocale bool
+	next              http.Handler
+}
+
+func TestLocaleMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(LocaleMiddlewareTestSuite))
+}
+
+func (s *LocaleMiddlewareTestSuite) SetupTest() {
+	s.localeLoaderMock = mocks.NewMockLocaleLoaderService(s.T())
+	s.localeLoaderMock.EXPECT().Locales().Return([]string{"en", "pt_BR"}).Once()
+	s.sut = middleware.NewLocaleMiddleware(s.localeLoaderMock)
+
+	s.capturedLocale = ""
+	s.capturedHasLocale = false
+	s.next = http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		s.capturedLocale, s.capturedHasLocale = locale.FromContext(r.Context())
+	})
+}
+
+func (s *LocaleMiddlewareTestSuite) TestHandler_NegotiatesLocale() {
+	// Arrange
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept-Language", "fr;q=0.9, pt-BR;q=0.8, en;q=0.5")
+	recorder := httptest.NewRecorder()
+
+	// Act
+	s.sut.Handler(s.next).ServeHTTP(recorder, request)
+
+	// Assert
+	s.True(s.capturedHasLocale)
+	s.Equal("pt_BR", s.capturedLocale)
+	s.Equal("pt_BR", recorder.Header().Get("Content-Language"))
+	s.Equal("Accept-Language", recorder.Header().Get("Vary"))
+}
+
+func (s *LocaleMiddlewareTestSuite) TestHandler_NoMatch_KeepsContextWithoutLocale() {
+	// Arrange
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept-Language", "fr, de;q=0.5")
+	recorder := httptest.NewRecorder()
+
+	// Act
+	s.sut.Handler(s.next).ServeHTTP(recorder, request)
+
+	// Assert
+	s.False(s.capturedHasLocale)
+	s.Empty(recorder.Header().Get("Content-Language"))
+}
+
+func (s *LocaleMiddlewareTestSuite) TestHandler_NegotiatesLocaleAddedAfterSetup() {
+	// Arrange
+	handler := s.sut.Handler(s.next)
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept-Language", "fr, en;q=0.5")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	s.Equal("en", s.capturedLocale)
+	s.localeLoaderMock.EXPECT().Locales().Return([]string{"en", "fr", "pt_BR"}).Once()
+	recorder := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(recorder, request)
+
+	// Assert
+	s.Equal("fr", s.capturedLocale)
+	s.Equal("fr", recorder.Header().Get("Content-Language"))
+}
@@ -1,6 +1,9 @@
 package ports
 
+import "context"
+
 // ErrorTranslatorService translates typed module errors into localized messages.
 type ErrorTranslatorService interface {
 	TranslateError(err error) error
+	TranslateErrorCtx(ctx context.Context, err error) error
 }
@@ -1,6 +1,7 @@
 package ports
 
-// LocaleLoaderService loads locale data for a given locale code.
+// LocaleLoaderService loads locale data for a given locale code and lists the available locale codes.
 type LocaleLoaderService interface {
 	Load(locale string) (map[string]map[string]string, error)
+	Locales() []string
 }
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// LocaleSource provides locale data from a single origin such as a filesystem, an HTTP endpoint or a database.
+// Load returns an error wrapping source.ErrLocaleNotFound when the source has no data for the locale.
+type LocaleSource interface {
+	Load(ctx context.Context, locale string) (map[string]map[string]string, error)
+	Locales(ctx context.Context) ([]string, error)
+}
@@ -1,8 +1,15 @@
 package ports
 
+import "context"
+
 // TranslationService provides translation lookups for configured locale data.
+// The Ctx variants resolve the locale stored in the context (see locale.WithLocale) first.
 type TranslationService interface {
 	Translate(domain, key string) string
 	TranslateWithData(domain, key string, data map[string]string) string
 	GetAllForDomain(domain string) map[string]string
+	TranslateCtx(ctx context.Context, domain, key string) string
+	TranslateWithDataCtx(ctx context.Context, domain, key string, data map[string]string) string
+	TranslatePlural(domain, key string, count int, data map[string]string) string
+	TranslatePluralCtx(ctx context.Context, domain, key string, count int, data map[string]string) string
 }
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	lib_validator "github.com/go-playground/validator/v10"
+)
+
+// ValidationTranslatorService translates validator field errors into localized messages.
+type ValidationTranslatorService interface {
+	TranslateValidationError(ctx context.Context, field string, fieldErr lib_validator.FieldError) (string, bool)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	brickserrs "github.com/cristiano-pacheco/bricks/pkg/errs"
@@ -18,12 +19,17 @@ func NewErrorTranslatorService(translationService ports.TranslationService) *Err
 }
 
 func (s *ErrorTranslatorService) TranslateError(err error) error {
+	return s.TranslateErrorCtx(context.Background(), err)
+}
+
+// TranslateErrorCtx translates the error using the locale stored in ctx, if any.
+func (s *ErrorTranslatorService) TranslateErrorCtx(ctx context.Context, err error) error {
 	var bricksErr *brickserrs.Error
 	if !errors.As(err, &bricksErr) {
 		return err
 	}
 
-	translatedMessage := s.translationService.Translate("errors", bricksErr.Code)
+	translatedMessage := s.translationService.TranslateCtx(ctx, "errors", bricksErr.Code)
 	if translatedMessage == "" || translatedMessage == bricksErr.Code {
 		return err
 	}
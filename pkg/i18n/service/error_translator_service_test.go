@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -78,6 +79,31 @@ func (s *stubTranslationService) TranslateWithData(domain, key string, _ map[str
 	return s.Translate(domain, key)
 }
 
+func (s *stubTranslationService) TranslateCtx(_ context.Context, domain, key string) string {
+	return s.Translate(domain, key)
+}
+
+func (s *stubTranslationService) TranslateWithDataCtx(
+	_ context.Context,
+	domain, key string,
+	data map[string]string,
+) string {
+	return s.TranslateWithData(domain, key, data)
+}
+
+func (s *stubTranslationService) TranslatePlural(domain, key string, _ int, data map[string]string) string {
+	return s.TranslateWithData(domain, key, data)
+}
+
+func (s *stubTranslationService) TranslatePluralCtx(
+	_ context.Context,
+	domain, key string,
+	count int,
+	data map[string]string,
+) string {
+	return s.TranslatePlural(domain, key, count, data)
+}
+
 func (s *stubTranslationService) GetAllForDomain(domain string) map[string]string {
 	domainMap := s.translations[domain]
 	output := make(map[string]string, len(domainMap))
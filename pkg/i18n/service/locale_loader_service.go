@@ -1,27 +1,34 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/fs"
 	"strings"
 
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/source"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 )
 
 const defaultLocaleCode = "en"
 
 type LocaleLoaderService struct {
-	logger      logger.Logger
-	FileSystems []locale.FileSystem
+	logger logger.Logger
+	source ports.LocaleSource
 }
 
 var _ ports.LocaleLoaderService = (*LocaleLoaderService)(nil)
 
+// NewLocaleLoaderService creates a loader reading locale files from the given filesystems.
 func NewLocaleLoaderService(log logger.Logger, fss []locale.FileSystem) *LocaleLoaderService {
-	return &LocaleLoaderService{logger: log, FileSystems: fss}
+	return NewLocaleLoaderServiceWithSource(log, source.NewFileSystemSource(fss...))
+}
+
+// NewLocaleLoaderServiceWithSource creates a loader reading locale data from the given source
+// (e.g. a source.CompositeSource combining files, HTTP and database translations).
+func NewLocaleLoaderServiceWithSource(log logger.Logger, localeSource ports.LocaleSource) *LocaleLoaderService {
+	return &LocaleLoaderService{logger: log, source: localeSource}
 }
 
 func (s *LocaleLoaderService) Load(locale string) (map[string]map[string]string, error) {
@@ -30,9 +37,9 @@ func (s *LocaleLoaderService) Load(locale string) (map[string]map[string]string,
 		requestedLocale = defaultLocaleCode
 	}
 
-	merged, found := s.loadAndMergeAll(requestedLocale)
+	translations, found := s.load(requestedLocale)
 	if found {
-		return merged, nil
+		return translations, nil
 	}
 
 	if requestedLocale == defaultLocaleCode {
@@ -45,7 +52,7 @@ func (s *LocaleLoaderService) Load(locale string) (map[string]map[string]string,
 		logger.String("fallback_locale", defaultLocaleCode),
 	)
 
-	fallback, fallbackFound := s.loadAndMergeAll(defaultLocaleCode)
+	fallback, fallbackFound := s.load(defaultLocaleCode)
 	if !fallbackFound {
 		return nil, fmt.Errorf("no locale files found for fallback locale %q", defaultLocaleCode)
 	}
@@ -53,49 +60,27 @@ func (s *LocaleLoaderService) Load(locale string) (map[string]map[string]string,
 	return fallback, nil
 }
 
-func (s *LocaleLoaderService) loadAndMergeAll(locale string) (map[string]map[string]string, bool) {
-	merged := make(map[string]map[string]string)
-	found := false
-	for _, fileSystem := range s.FileSystems {
-		translations, err := s.loadLocaleFile(fileSystem, locale)
-		if err != nil {
-			s.logger.Debug(
-				"locale file not found in filesystem, skipping",
-				logger.String("locale", locale),
-				logger.Error(err),
-			)
-			continue
-		}
-		mergeInto(merged, translations)
-		found = true
+// Locales returns the sorted locale codes available in the source.
+func (s *LocaleLoaderService) Locales() []string {
+	locales, err := s.source.Locales(context.Background())
+	if err != nil {
+		s.logger.Warn("failed to list available locales", logger.Error(err))
+		return []string{}
 	}
-	return merged, found
-}
 
-func mergeInto(dst, src map[string]map[string]string) {
-	for domain, keys := range src {
-		if dst[domain] == nil {
-			dst[domain] = make(map[string]string)
-		}
-		for k, v := range keys {
-			dst[domain][k] = v
-		}
-	}
+	return locales
 }
 
-func (s *LocaleLoaderService) loadLocaleFile(
-	fileSystem locale.FileSystem,
-	locale string,
-) (map[string]map[string]string, error) {
-	content, err := fs.ReadFile(fileSystem.FS, locale+".json")
+func (s *LocaleLoaderService) load(locale string) (map[string]map[string]string, bool) {
+	translations, err := s.source.Load(context.Background(), locale)
 	if err != nil {
-		return nil, fmt.Errorf("read locale %q: %w", locale, err)
-	}
-
-	translations := make(map[string]map[string]string)
-	if unmarshalErr := json.Unmarshal(content, &translations); unmarshalErr != nil {
-		return nil, fmt.Errorf("parse locale %q: %w", locale, unmarshalErr)
+		s.logger.Debug(
+			"locale not found in source, skipping",
+			logger.String("locale", locale),
+			logger.Error(err),
+		)
+		return nil, false
 	}
 
-	return translations, nil
+	return translations, true
 }
@@ -70,6 +70,21 @@ func (s *LocaleLoaderServiceTestSuite) TestParseJSONStructure() {
 	s.Equal("Número de telefone é obrigatório", translations["errors"]["EXPORT_01"])
 }
 
+func (s *LocaleLoaderServiceTestSuite) TestLocalesListsAvailableLocales() {
+	other := locale.FileSystem{FS: fstest.MapFS{
+		"es.json": &fstest.MapFile{Data: []byte(`{}`)},
+		"en.json": &fstest.MapFile{Data: []byte(`{}`)},
+	}}
+	sut := service.NewLocaleLoaderService(
+		logger.MustNew(logger.DefaultConfig()),
+		[]locale.FileSystem{s.FileSystem, other},
+	)
+
+	locales := sut.Locales()
+
+	s.Equal([]string{"en", "es", "pt_BR"}, locales)
+}
+
 func (s *LocaleLoaderServiceTestSuite) TestMergeFromMultipleFileSystems() {
 	fs1 := fstest.MapFS{
 		"en.json": &fstest.MapFile{
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"maps"
+	"strconv"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+const (
+	pluralSeparator     = "_"
+	pluralCountDataKey  = "count"
+	pluralCategoryOther = "other"
+)
+
+// pluralCategory returns the CLDR cardinal plural category (zero, one, two, few, many or other)
+// of count in the given locale.
+func pluralCategory(code string, count int) string {
+	tag, err := language.Parse(locale.Normalize(code))
+	if err != nil {
+		return pluralCategoryOther
+	}
+
+	if count < 0 {
+		count = -count
+	}
+
+	switch plural.Cardinal.MatchPlural(tag, count, 0, 0, 0, 0) {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	default:
+		return pluralCategoryOther
+	}
+}
+
+// pluralKeys returns the keys to try for the plural category, most specific first (e.g. "items_few", "items_other").
+func pluralKeys(key, category string) []string {
+	keys := []string{key + pluralSeparator + category}
+	if category != pluralCategoryOther {
+		keys = append(keys, key+pluralSeparator+pluralCategoryOther)
+	}
+	return keys
+}
+
+// withCount returns a copy of data with the count set, unless data already defines it.
+func withCount(data map[string]string, count int) map[string]string {
+	result := make(map[string]string, len(data)+1)
+	result[pluralCountDataKey] = strconv.Itoa(count)
+	maps.Copy(result, data)
+	return result
+}
@@ -2,20 +2,28 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/config"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 )
 
 type TranslationService struct {
 	logger               logger.Logger
+	localeLoaderService  ports.LocaleLoaderService
 	locale               string
 	translations         map[string]map[string]string
 	fallbackTranslations map[string]map[string]string
+	availableLocales     []string
+	localeCache          map[string]map[string]map[string]string
+	localeCacheMutex     sync.RWMutex
 }
 
 var _ ports.TranslationService = (*TranslationService)(nil)
@@ -42,9 +50,12 @@ func NewTranslationService(
 
 	return &TranslationService{
 		logger:               log,
+		localeLoaderService:  localeLoaderService,
 		locale:               configuredLocale,
 		translations:         translations,
 		fallbackTranslations: fallbackTranslations,
+		availableLocales:     localeLoaderService.Locales(),
+		localeCache:          make(map[string]map[string]map[string]string),
 	}, nil
 }
 
@@ -80,8 +91,78 @@ func (s *TranslationService) Translate(domain, key string) string {
 	return resolvedKey
 }
 
+// TranslateCtx translates using the locale stored in ctx, falling back to its base language,
+// then to the configured locale and finally to the default locale.
+// Without a locale in ctx it behaves like Translate.
+func (s *TranslationService) TranslateCtx(ctx context.Context, domain, key string) string {
+	requestedLocale, ok := locale.FromContext(ctx)
+	if !ok {
+		return s.Translate(domain, key)
+	}
+
+	resolvedDomain := strings.TrimSpace(domain)
+	resolvedKey := strings.TrimSpace(key)
+	if resolvedDomain == "" || resolvedKey == "" {
+		return resolvedKey
+	}
+
+	for _, code := range s.fallbackChain(requestedLocale) {
+		if value, found := s.findTranslationValue(s.localeTranslations(code), resolvedDomain, resolvedKey); found {
+			return value
+		}
+	}
+
+	return s.Translate(resolvedDomain, resolvedKey)
+}
+
 func (s *TranslationService) TranslateWithData(domain, key string, data map[string]string) string {
-	value := s.Translate(domain, key)
+	return s.render(domain, key, s.Translate(domain, key), data)
+}
+
+// TranslateWithDataCtx is the TranslateCtx counterpart of TranslateWithData.
+func (s *TranslationService) TranslateWithDataCtx(
+	ctx context.Context,
+	domain, key string,
+	data map[string]string,
+) string {
+	return s.render(domain, key, s.TranslateCtx(ctx, domain, key), data)
+}
+
+// TranslatePlural translates the plural form of key that matches count under the CLDR plural rules of the
+// locale providing the translation. Forms are stored as key_zero, key_one, key_two, key_few, key_many and
+// key_other; key_other is used when the matching form is missing and key itself when no form exists.
+// The count is available to the template as {{.count}} unless data defines it.
+func (s *TranslationService) TranslatePlural(domain, key string, count int, data map[string]string) string {
+	return s.TranslatePluralCtx(context.Background(), domain, key, count, data)
+}
+
+// TranslatePluralCtx is the TranslateCtx counterpart of TranslatePlural.
+func (s *TranslationService) TranslatePluralCtx(
+	ctx context.Context,
+	domain, key string,
+	count int,
+	data map[string]string,
+) string {
+	resolvedDomain := strings.TrimSpace(domain)
+	resolvedKey := strings.TrimSpace(key)
+	if resolvedDomain == "" || resolvedKey == "" {
+		return resolvedKey
+	}
+
+	pluralData := withCount(data, count)
+	for _, code := range s.lookupChain(ctx) {
+		translations := s.localeTranslations(code)
+		for _, pluralKey := range pluralKeys(resolvedKey, pluralCategory(code, count)) {
+			if value, ok := s.findTranslationValue(translations, resolvedDomain, pluralKey); ok {
+				return s.render(domain, key, value, pluralData)
+			}
+		}
+	}
+
+	return s.render(domain, key, s.TranslateCtx(ctx, resolvedDomain, resolvedKey), pluralData)
+}
+
+func (s *TranslationService) render(domain, key, value string, data map[string]string) string {
 	if len(data) == 0 {
 		return value
 	}
@@ -128,6 +209,82 @@ func (s *TranslationService) GetAllForDomain(domain string) map[string]string {
 	return result
 }
 
+// fallbackChain returns the available locale codes to try for the requested locale before the configured one:
+// the requested locale itself and its base language (e.g. "pt_BR" then "pt").
+func (s *TranslationService) fallbackChain(requestedLocale string) []string {
+	chain := make([]string, 0, 2)
+	if code, ok := s.availableLocale(requestedLocale); ok {
+		chain = append(chain, code)
+	}
+
+	base := locale.Base(requestedLocale)
+	if code, ok := s.availableLocale(base); ok && (len(chain) == 0 || chain[0] != code) {
+		chain = append(chain, code)
+	}
+
+	return chain
+}
+
+// lookupChain returns the locale codes to try in order: the request locale chain, the configured locale
+// and the default locale.
+func (s *TranslationService) lookupChain(ctx context.Context) []string {
+	chain := make([]string, 0, 4)
+	if requestedLocale, ok := locale.FromContext(ctx); ok {
+		chain = append(chain, s.fallbackChain(requestedLocale)...)
+	}
+
+	for _, code := range []string{s.locale, defaultLocaleCode} {
+		if !slices.Contains(chain, code) {
+			chain = append(chain, code)
+		}
+	}
+
+	return chain
+}
+
+func (s *TranslationService) availableLocale(code string) (string, bool) {
+	normalized := locale.Normalize(code)
+	for _, available := range s.availableLocales {
+		if locale.Normalize(available) == normalized {
+			return available, true
+		}
+	}
+	return "", false
+}
+
+// localeTranslations returns the translations of an available locale, loading and caching them on first use.
+func (s *TranslationService) localeTranslations(code string) map[string]map[string]string {
+	switch code {
+	case s.locale:
+		return s.translations
+	case defaultLocaleCode:
+		return s.fallbackTranslations
+	}
+
+	s.localeCacheMutex.RLock()
+	translations, ok := s.localeCache[code]
+	s.localeCacheMutex.RUnlock()
+	if ok {
+		return translations
+	}
+
+	translations, err := s.localeLoaderService.Load(code)
+	if err != nil {
+		s.logger.Warn(
+			"failed to load requested locale",
+			logger.String("locale", code),
+			logger.Error(err),
+		)
+		return nil
+	}
+
+	s.localeCacheMutex.Lock()
+	s.localeCache[code] = translations
+	s.localeCacheMutex.Unlock()
+
+	return translations
+}
+
 func (s *TranslationService) findTranslationValue(all map[string]map[string]string, domain, key string) (string, bool) {
 	domainMap, ok := all[domain]
 	if !ok {
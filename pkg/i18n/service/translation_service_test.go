@@ -1,10 +1,13 @@
 package service_test
 
 import (
+	"context"
 	"errors"
+	"sort"
 	"testing"
 
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/config"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
 	"github.com/cristiano-pacheco/bricks/pkg/i18n/service"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 	"github.com/stretchr/testify/suite"
@@ -24,15 +27,39 @@ func (s *TranslationServiceTestSuite) SetupTest() {
 		locales: map[string]map[string]map[string]string{
 			"en": {
 				"admin": {
-					"products.title": "Products",
-					"welcome":        "Hello {{.Name}}",
-					"only_en":        "English fallback",
+					"products.title":       "Products",
+					"welcome":              "Hello {{.Name}}",
+					"only_en":              "English fallback",
+					"items_one":            "{{.count}} item",
+					"items_other":          "{{.count}} items",
+					"only_en_plural_one":   "one in English",
+					"only_en_plural_other": "many in English",
 				},
 			},
 			"pt_BR": {
 				"admin": {
 					"products.title": "Produtos",
 					"welcome":        "Ola {{.Name}}",
+					"items_one":      "{{.count}} item",
+					"items_other":    "{{.count}} itens de {{.Owner}}",
+				},
+			},
+			"es": {
+				"admin": {
+					"products.title": "Productos",
+					"welcome":        "Hola {{.Name}}",
+				},
+			},
+			"ru": {
+				"admin": {
+					"items_one":  "{{.count}} товар",
+					"items_few":  "{{.count}} товара",
+					"items_many": "{{.count}} товаров",
+				},
+			},
+			"es_AR": {
+				"admin": {
+					"products.title": "Productos AR",
 				},
 			},
 		},
@@ -73,6 +100,66 @@ func (s *TranslationServiceTestSuite) TestGetAllForDomain() {
 	s.Equal("Ola {{.Name}}", values["welcome"])
 }
 
+func (s *TranslationServiceTestSuite) TestTranslateCtxUsesRequestLocale() {
+	ctx := locale.WithLocale(context.Background(), "es")
+
+	value := s.sut.TranslateCtx(ctx, "admin", "products.title")
+
+	s.Equal("Productos", value)
+}
+
+func (s *TranslationServiceTestSuite) TestTranslateCtxFallsBackToBaseLanguage() {
+	ctx := locale.WithLocale(context.Background(), "es-AR")
+
+	s.Equal("Productos AR", s.sut.TranslateCtx(ctx, "admin", "products.title"))
+	s.Equal("Hola Carla", s.sut.TranslateWithDataCtx(ctx, "admin", "welcome", map[string]string{"Name": "Carla"}))
+}
+
+func (s *TranslationServiceTestSuite) TestTranslateCtxFallsBackToConfiguredThenDefaultLocale() {
+	ctx := locale.WithLocale(context.Background(), "es")
+
+	s.Equal("English fallback", s.sut.TranslateCtx(ctx, "admin", "only_en"))
+	s.Equal("missing", s.sut.TranslateCtx(ctx, "admin", "missing"))
+}
+
+func (s *TranslationServiceTestSuite) TestTranslateCtxWithUnavailableLocaleUsesConfiguredLocale() {
+	ctx := locale.WithLocale(context.Background(), "fr")
+
+	value := s.sut.TranslateCtx(ctx, "admin", "products.title")
+
+	s.Equal("Produtos", value)
+}
+
+func (s *TranslationServiceTestSuite) TestTranslateCtxWithoutLocaleUsesConfiguredLocale() {
+	value := s.sut.TranslateCtx(context.Background(), "admin", "products.title")
+
+	s.Equal("Produtos", value)
+}
+
+func (s *TranslationServiceTestSuite) TestTranslatePluralUsesConfiguredLocaleRules() {
+	s.Equal("1 item", s.sut.TranslatePlural("admin", "items", 1, nil))
+	s.Equal("0 item", s.sut.TranslatePlural("admin", "items", 0, nil)) // CLDR "one" covers 0 and 1 in Portuguese
+	s.Equal("2 itens de Ana", s.sut.TranslatePlural("admin", "items", 2, map[string]string{"Owner": "Ana"}))
+}
+
+func (s *TranslationServiceTestSuite) TestTranslatePluralCtxUsesSlavicRules() {
+	ctx := locale.WithLocale(context.Background(), "ru")
+
+	s.Equal("1 товар", s.sut.TranslatePluralCtx(ctx, "admin", "items", 1, nil))
+	s.Equal("3 товара", s.sut.TranslatePluralCtx(ctx, "admin", "items", 3, nil))
+	s.Equal("5 товаров", s.sut.TranslatePluralCtx(ctx, "admin", "items", 5, nil))
+	s.Equal("21 товар", s.sut.TranslatePluralCtx(ctx, "admin", "items", 21, nil))
+}
+
+func (s *TranslationServiceTestSuite) TestTranslatePluralFallsBackToDefaultLocale() {
+	s.Equal("many in English", s.sut.TranslatePlural("admin", "only_en_plural", 3, nil))
+}
+
+func (s *TranslationServiceTestSuite) TestTranslatePluralWithoutFormsUsesKey() {
+	s.Equal("Produtos", s.sut.TranslatePlural("admin", "products.title", 2, nil))
+	s.Equal("missing", s.sut.TranslatePlural("admin", "missing", 2, nil))
+}
+
 type stubLocaleLoaderService struct {
 	locales map[string]map[string]map[string]string
 }
@@ -86,6 +173,16 @@ func (s *stubLocaleLoaderService) Load(locale string) (map[string]map[string]str
 	return cloneTranslations(translations), nil
 }
 
+func (s *stubLocaleLoaderService) Locales() []string {
+	codes := make([]string, 0, len(s.locales))
+	for code := range s.locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
 func cloneTranslations(input map[string]map[string]string) map[string]map[string]string {
 	copied := make(map[string]map[string]string, len(input))
 	for domain, values := range input {
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+	lib_validator "github.com/go-playground/validator/v10"
+)
+
+const validationDomain = "validation"
+
+// ValidationTranslatorService translates validation errors using the "validation" domain, keyed by
+// validator tag (e.g. "required", "max"). Templates receive {{.field}}, {{.param}}, {{.value}} and the
+// tag itself set to the param (e.g. {{.max}}).
+type ValidationTranslatorService struct {
+	translationService ports.TranslationService
+}
+
+var _ ports.ValidationTranslatorService = (*ValidationTranslatorService)(nil)
+
+func NewValidationTranslatorService(translationService ports.TranslationService) *ValidationTranslatorService {
+	return &ValidationTranslatorService{translationService: translationService}
+}
+
+func (s *ValidationTranslatorService) TranslateValidationError(
+	ctx context.Context,
+	field string,
+	fieldErr lib_validator.FieldError,
+) (string, bool) {
+	tag := fieldErr.Tag()
+
+	data := map[string]string{
+		"field": field,
+		"param": fieldErr.Param(),
+		"value": fmt.Sprint(fieldErr.Value()),
+		tag:     fieldErr.Param(),
+	}
+
+	message := s.translationService.TranslateWithDataCtx(ctx, validationDomain, tag, data)
+	if message == "" || message == tag {
+		return "", false
+	}
+
+	return message, true
+}
@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/service"
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	lib_validator "github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidationTranslatorServiceTestSuite struct {
+	suite.Suite
+	sut                    *service.ValidationTranslatorService
+	translationServiceMock *mocks.MockTranslationService
+	fieldErr               lib_validator.FieldError
+}
+
+func TestValidationTranslatorServiceSuite(t *testing.T) {
+	suite.Run(t, new(ValidationTranslatorServiceTestSuite))
+}
+
+func (s *ValidationTranslatorServiceTestSuite) SetupTest() {
+	s.translationServiceMock = mocks.NewMockTranslationService(s.T())
+	s.sut = service.NewValidationTranslatorService(s.translationServiceMock)
+
+	v, err := validator.New()
+	s.Require().NoError(err)
+	type input struct {
+		Name string `validate:"max=3"`
+	}
+	var validationErrors lib_validator.ValidationErrors
+	s.Require().ErrorAs(v.Validate(input{Name: "Carla"}), &validationErrors)
+	s.fieldErr = validationErrors[0]
+}
+
+func (s *ValidationTranslatorServiceTestSuite) TestTranslateValidationError_TranslatesTagWithData() {
+	// Arrange
+	ctx := context.Background()
+	expectedData := map[string]string{"field": "name", "param": "3", "value": "Carla", "max": "3"}
+	s.translationServiceMock.EXPECT().TranslateWithDataCtx(ctx, "validation", "max", expectedData).
+		Return("O campo name deve ter no máximo 3 caracteres")
+
+	// Act
+	message, ok := s.sut.TranslateValidationError(ctx, "name", s.fieldErr)
+
+	// Assert
+	s.True(ok)
+	s.Equal("O campo name deve ter no máximo 3 caracteres", message)
+}
+
+func (s *ValidationTranslatorServiceTestSuite) TestTranslateValidationError_MissingTranslation_ReturnsFalse() {
+	// Arrange
+	ctx := context.Background()
+	s.translationServiceMock.EXPECT().
+		TranslateWithDataCtx(ctx, "validation", "max", map[string]string{
+			"field": "name", "param": "3", "value": "Carla", "max": "3",
+		}).
+		Return("max")
+
+	// Act
+	message, ok := s.sut.TranslateValidationError(ctx, "name", s.fieldErr)
+
+	// Assert
+	s.False(ok)
+	s.Empty(message)
+}
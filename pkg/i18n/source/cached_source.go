@@ -0,0 +1,88 @@
+package source
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+)
+
+type cacheEntry struct {
+	translations map[string]map[string]string
+	expiresAt    time.Time
+}
+
+// CachedSource caches the locale data and locale list of another source for a fixed TTL.
+// Lookup errors are not cached.
+type CachedSource struct {
+	source          ports.LocaleSource
+	ttl             time.Duration
+	now             func() time.Time
+	mutex           sync.RWMutex
+	entries         map[string]cacheEntry
+	locales         []string
+	localesExpireAt time.Time
+}
+
+var _ ports.LocaleSource = (*CachedSource)(nil)
+
+func NewCachedSource(source ports.LocaleSource, ttl time.Duration) *CachedSource {
+	return &CachedSource{
+		source:  source,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (s *CachedSource) Load(ctx context.Context, code string) (map[string]map[string]string, error) {
+	s.mutex.RLock()
+	entry, ok := s.entries[code]
+	s.mutex.RUnlock()
+	if ok && s.now().Before(entry.expiresAt) {
+		return entry.translations, nil
+	}
+
+	translations, err := s.source.Load(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	s.entries[code] = cacheEntry{translations: translations, expiresAt: s.now().Add(s.ttl)}
+	s.mutex.Unlock()
+
+	return translations, nil
+}
+
+func (s *CachedSource) Locales(ctx context.Context) ([]string, error) {
+	s.mutex.RLock()
+	locales, expiresAt := s.locales, s.localesExpireAt
+	s.mutex.RUnlock()
+	if locales != nil && s.now().Before(expiresAt) {
+		return slices.Clone(locales), nil
+	}
+
+	locales, err := s.source.Locales(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	s.locales = slices.Clone(locales)
+	s.localesExpireAt = s.now().Add(s.ttl)
+	s.mutex.Unlock()
+
+	return locales, nil
+}
+
+// Invalidate drops all cached data so the next lookups hit the underlying source.
+func (s *CachedSource) Invalidate() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.entries = make(map[string]cacheEntry)
+	s.locales = nil
+}
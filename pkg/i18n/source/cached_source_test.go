@@ -0,0 +1,58 @@
+package source_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/source"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedSource(t *testing.T) {
+	t.Run("serves cached data until invalidated", func(t *testing.T) {
+		// Arrange
+		underlying := mocks.NewMockLocaleSource(t)
+		underlying.EXPECT().Load(mock.Anything, "en").
+			Return(map[string]map[string]string{"admin": {"title": "Products"}}, nil).Times(2)
+		underlying.EXPECT().Locales(mock.Anything).Return([]string{"en"}, nil).Once()
+		sut := source.NewCachedSource(underlying, time.Hour)
+
+		// Act
+		first, firstErr := sut.Load(context.Background(), "en")
+		second, secondErr := sut.Load(context.Background(), "en")
+		_, _ = sut.Locales(context.Background())
+		locales, localesErr := sut.Locales(context.Background())
+		sut.Invalidate()
+		_, thirdErr := sut.Load(context.Background(), "en")
+
+		// Assert
+		require.NoError(t, firstErr)
+		require.NoError(t, secondErr)
+		require.NoError(t, localesErr)
+		require.NoError(t, thirdErr)
+		require.Equal(t, first, second)
+		require.Equal(t, []string{"en"}, locales)
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		// Arrange
+		underlying := mocks.NewMockLocaleSource(t)
+		underlying.EXPECT().Load(mock.Anything, "en").Return(nil, errors.New("timeout")).Once()
+		underlying.EXPECT().Load(mock.Anything, "en").
+			Return(map[string]map[string]string{"admin": {"title": "Products"}}, nil).Once()
+		sut := source.NewCachedSource(underlying, time.Hour)
+
+		// Act
+		_, firstErr := sut.Load(context.Background(), "en")
+		translations, secondErr := sut.Load(context.Background(), "en")
+
+		// Assert
+		require.Error(t, firstErr)
+		require.NoError(t, secondErr)
+		require.Equal(t, "Products", translations["admin"]["title"])
+	})
+}
@@ -0,0 +1,75 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+)
+
+// CompositeSource merges several sources ordered by descending priority:
+// when two sources define the same domain and key, the earlier source wins.
+// A failing source does not prevent the others from being used.
+type CompositeSource struct {
+	sources []ports.LocaleSource
+}
+
+var _ ports.LocaleSource = (*CompositeSource)(nil)
+
+func NewCompositeSource(sources ...ports.LocaleSource) *CompositeSource {
+	return &CompositeSource{sources: sources}
+}
+
+func (s *CompositeSource) Load(ctx context.Context, code string) (map[string]map[string]string, error) {
+	merged := make(map[string]map[string]string)
+	found := false
+	var loadErrs []error
+
+	for _, source := range slices.Backward(s.sources) {
+		translations, err := source.Load(ctx, code)
+		if err != nil {
+			if !errors.Is(err, ErrLocaleNotFound) {
+				loadErrs = append(loadErrs, err)
+			}
+			continue
+		}
+
+		Merge(merged, translations)
+		found = true
+	}
+
+	if !found {
+		return nil, errors.Join(append([]error{fmt.Errorf("%w: %q", ErrLocaleNotFound, code)}, loadErrs...)...)
+	}
+
+	return merged, nil
+}
+
+// Locales returns the sorted union of the locales of all sources, skipping sources that fail
+// unless all of them do.
+func (s *CompositeSource) Locales(ctx context.Context) ([]string, error) {
+	locales := make([]string, 0)
+	var localeErrs []error
+
+	for _, source := range s.sources {
+		sourceLocales, err := source.Locales(ctx)
+		if err != nil {
+			localeErrs = append(localeErrs, err)
+			continue
+		}
+		for _, code := range sourceLocales {
+			if !slices.Contains(locales, code) {
+				locales = append(locales, code)
+			}
+		}
+	}
+
+	if len(s.sources) > 0 && len(localeErrs) == len(s.sources) {
+		return nil, errors.Join(localeErrs...)
+	}
+
+	slices.Sort(locales)
+	return locales, nil
+}
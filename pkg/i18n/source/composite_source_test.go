@@ -0,0 +1,84 @@
+package source_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/source"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeSource_Load(t *testing.T) {
+	t.Run("merges sources with earlier sources taking priority", func(t *testing.T) {
+		// Arrange
+		high := mocks.NewMockLocaleSource(t)
+		low := mocks.NewMockLocaleSource(t)
+		high.EXPECT().Load(mock.Anything, "en").Return(map[string]map[string]string{"admin": {"title": "Override"}}, nil)
+		low.EXPECT().Load(mock.Anything, "en").
+			Return(map[string]map[string]string{"admin": {"title": "Products", "menu": "Menu"}}, nil)
+		sut := source.NewCompositeSource(high, low)
+
+		// Act
+		translations, err := sut.Load(context.Background(), "en")
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, map[string]map[string]string{"admin": {"title": "Override", "menu": "Menu"}}, translations)
+	})
+
+	t.Run("skips failing sources", func(t *testing.T) {
+		// Arrange
+		remote := mocks.NewMockLocaleSource(t)
+		local := mocks.NewMockLocaleSource(t)
+		remote.EXPECT().Load(mock.Anything, "en").Return(nil, errors.New("connection refused"))
+		local.EXPECT().Load(mock.Anything, "en").Return(map[string]map[string]string{"admin": {"title": "Products"}}, nil)
+		sut := source.NewCompositeSource(remote, local)
+
+		// Act
+		translations, err := sut.Load(context.Background(), "en")
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, "Products", translations["admin"]["title"])
+	})
+
+	t.Run("returns ErrLocaleNotFound with source errors when no source has the locale", func(t *testing.T) {
+		// Arrange
+		remoteErr := errors.New("connection refused")
+		remote := mocks.NewMockLocaleSource(t)
+		local := mocks.NewMockLocaleSource(t)
+		remote.EXPECT().Load(mock.Anything, "fr").Return(nil, remoteErr)
+		local.EXPECT().Load(mock.Anything, "fr").Return(nil, source.ErrLocaleNotFound)
+		sut := source.NewCompositeSource(remote, local)
+
+		// Act
+		_, err := sut.Load(context.Background(), "fr")
+
+		// Assert
+		require.ErrorIs(t, err, source.ErrLocaleNotFound)
+		require.ErrorIs(t, err, remoteErr)
+	})
+}
+
+func TestCompositeSource_Locales(t *testing.T) {
+	t.Run("returns the union of all source locales", func(t *testing.T) {
+		// Arrange
+		first := mocks.NewMockLocaleSource(t)
+		second := mocks.NewMockLocaleSource(t)
+		failing := mocks.NewMockLocaleSource(t)
+		first.EXPECT().Locales(mock.Anything).Return([]string{"pt_BR", "en"}, nil)
+		second.EXPECT().Locales(mock.Anything).Return([]string{"en", "es"}, nil)
+		failing.EXPECT().Locales(mock.Anything).Return(nil, errors.New("timeout"))
+		sut := source.NewCompositeSource(first, second, failing)
+
+		// Act
+		locales, err := sut.Locales(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, []string{"en", "es", "pt_BR"}, locales)
+	})
+}
@@ -0,0 +1,59 @@
+package source
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/config"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultCacheTTL    = 5 * time.Minute
+	defaultHTTPTimeout = 5 * time.Second
+)
+
+// NewFromConfig builds a CompositeSource from the sources configured in cfg.Sources, in priority order.
+// Remote sources (http, database) are wrapped in a CachedSource. db may be nil unless the database source is used.
+func NewFromConfig(cfg config.Config, fileSystems []locale.FileSystem, db *gorm.DB) (*CompositeSource, error) {
+	sourceNames := cfg.Sources
+	if len(sourceNames) == 0 {
+		sourceNames = []string{config.SourceFileSystem}
+	}
+
+	cacheTTL := cfg.CacheTTL
+	if cacheTTL == 0 {
+		cacheTTL = defaultCacheTTL
+	}
+
+	sources := make([]ports.LocaleSource, 0, len(sourceNames))
+	for _, name := range sourceNames {
+		switch name {
+		case config.SourceFileSystem:
+			sources = append(sources, NewFileSystemSource(fileSystems...))
+		case config.SourceHTTP:
+			timeout := cfg.HTTP.Timeout
+			if timeout == 0 {
+				timeout = defaultHTTPTimeout
+			}
+			httpSource, err := NewHTTPSource(cfg.HTTP.BaseURL, cfg.HTTP.Locales, &http.Client{Timeout: timeout})
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, NewCachedSource(httpSource, cacheTTL))
+		case config.SourceDatabase:
+			databaseSource, err := NewDatabaseSource(db, cfg.Database.Table)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, NewCachedSource(databaseSource, cacheTTL))
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSource, name)
+		}
+	}
+
+	return NewCompositeSource(sources...), nil
+}
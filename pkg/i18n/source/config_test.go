@@ -0,0 +1,44 @@
+package source_test
+
+import (
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/config"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/source"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	t.Run("defaults to the filesystem source", func(t *testing.T) {
+		// Act
+		sut, err := source.NewFromConfig(config.Config{}, nil, nil)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, sut)
+	})
+
+	t.Run("rejects unknown sources", func(t *testing.T) {
+		// Act
+		_, err := source.NewFromConfig(config.Config{Sources: []string{"s3"}}, nil, nil)
+
+		// Assert
+		require.ErrorIs(t, err, source.ErrUnknownSource)
+	})
+
+	t.Run("requires a database for the database source", func(t *testing.T) {
+		// Act
+		_, err := source.NewFromConfig(config.Config{Sources: []string{config.SourceDatabase}}, nil, nil)
+
+		// Assert
+		require.ErrorIs(t, err, source.ErrDatabaseRequired)
+	})
+
+	t.Run("requires a base URL for the http source", func(t *testing.T) {
+		// Act
+		_, err := source.NewFromConfig(config.Config{Sources: []string{config.SourceHTTP}}, nil, nil)
+
+		// Assert
+		require.ErrorIs(t, err, source.ErrHTTPBaseURLRequired)
+	})
+}
@@ -0,0 +1,78 @@
+package source
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+	"gorm.io/gorm"
+)
+
+const defaultTranslationsTable = "translations"
+
+// Translation is a row of the translations table used by DatabaseSource.
+type Translation struct {
+	Locale string `gorm:"column:locale"`
+	Domain string `gorm:"column:domain"`
+	Key    string `gorm:"column:key"`
+	Value  string `gorm:"column:value"`
+}
+
+// DatabaseSource loads translations from a table with locale, domain, key and value columns.
+type DatabaseSource struct {
+	db    *gorm.DB
+	table string
+}
+
+var _ ports.LocaleSource = (*DatabaseSource)(nil)
+
+// NewDatabaseSource creates a source reading from the given table, "translations" when empty.
+func NewDatabaseSource(db *gorm.DB, table string) (*DatabaseSource, error) {
+	if db == nil {
+		return nil, ErrDatabaseRequired
+	}
+	if table == "" {
+		table = defaultTranslationsTable
+	}
+
+	return &DatabaseSource{db: db, table: table}, nil
+}
+
+func (s *DatabaseSource) Load(ctx context.Context, code string) (map[string]map[string]string, error) {
+	var rows []Translation
+	err := s.db.WithContext(ctx).
+		Table(s.table).
+		Where("locale = ?", code).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("query translations for locale %q: %w", code, err)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrLocaleNotFound, code)
+	}
+
+	translations := make(map[string]map[string]string)
+	for _, row := range rows {
+		if translations[row.Domain] == nil {
+			translations[row.Domain] = make(map[string]string)
+		}
+		translations[row.Domain][row.Key] = row.Value
+	}
+
+	return translations, nil
+}
+
+func (s *DatabaseSource) Locales(ctx context.Context) ([]string, error) {
+	var locales []string
+	err := s.db.WithContext(ctx).
+		Table(s.table).
+		Distinct("locale").
+		Order("locale").
+		Pluck("locale", &locales).Error
+	if err != nil {
+		return nil, fmt.Errorf("query translation locales: %w", err)
+	}
+
+	return locales, nil
+}
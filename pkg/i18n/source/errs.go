@@ -0,0 +1,23 @@
+package source
+
+import "errors"
+
+var (
+	// ErrLocaleNotFound indicates that a source has no data for the requested locale
+	ErrLocaleNotFound = errors.New("locale not found")
+
+	// ErrUnknownSource indicates that a configured source name is not supported
+	ErrUnknownSource = errors.New("unknown locale source (must be 'filesystem', 'http' or 'database')")
+
+	// ErrHTTPBaseURLRequired indicates that the http source is enabled without a base URL
+	ErrHTTPBaseURLRequired = errors.New("i18n http source base_url is required")
+
+	// ErrDatabaseRequired indicates that the database source is enabled without a *gorm.DB
+	ErrDatabaseRequired = errors.New("i18n database source requires a *gorm.DB")
+
+	// ErrUnexpectedStatus indicates that the remote endpoint answered with a non-success status
+	ErrUnexpectedStatus = errors.New("unexpected status code from locale endpoint")
+
+	// ErrDecodeLocale indicates that locale data could not be decoded
+	ErrDecodeLocale = errors.New("failed to decode locale data")
+)
@@ -0,0 +1,93 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+)
+
+const localeFileExtension = ".json"
+
+// FileSystemSource loads <locale>.json files from one or more filesystems (embed.FS, os.DirFS, ...).
+// Files for the same locale are merged, later filesystems overriding earlier ones. A malformed
+// file is logged and skipped, so it does not hide the files of the other filesystems.
+type FileSystemSource struct {
+	fileSystems []locale.FileSystem
+}
+
+var _ ports.LocaleSource = (*FileSystemSource)(nil)
+
+func NewFileSystemSource(fileSystems ...locale.FileSystem) *FileSystemSource {
+	return &FileSystemSource{fileSystems: fileSystems}
+}
+
+func (s *FileSystemSource) Load(_ context.Context, code string) (map[string]map[string]string, error) {
+	merged := make(map[string]map[string]string)
+	found := false
+	var decodeErr error
+	for _, fileSystem := range s.fileSystems {
+		content, err := fs.ReadFile(fileSystem.FS, code+localeFileExtension)
+		if err != nil {
+			continue
+		}
+
+		translations := make(map[string]map[string]string)
+		if unmarshalErr := json.Unmarshal(content, &translations); unmarshalErr != nil {
+			decodeErr = fmt.Errorf("%w %q: %w", ErrDecodeLocale, code, unmarshalErr)
+			slog.Default().Warn("skipping malformed locale file", "locale", code, "err", unmarshalErr)
+			continue
+		}
+
+		Merge(merged, translations)
+		found = true
+	}
+
+	if !found {
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		return nil, fmt.Errorf("%w: %q", ErrLocaleNotFound, code)
+	}
+
+	return merged, nil
+}
+
+// Locales returns the sorted locale codes that have a locale file in at least one filesystem.
+func (s *FileSystemSource) Locales(_ context.Context) ([]string, error) {
+	locales := make([]string, 0)
+	for _, fileSystem := range s.fileSystems {
+		files, err := fs.Glob(fileSystem.FS, "*"+localeFileExtension)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			code := strings.TrimSuffix(path.Base(file), localeFileExtension)
+			if !slices.Contains(locales, code) {
+				locales = append(locales, code)
+			}
+		}
+	}
+
+	slices.Sort(locales)
+	return locales, nil
+}
+
+// Merge copies all translations from src into dst, overriding existing keys.
+func Merge(dst, src map[string]map[string]string) {
+	for domain, keys := range src {
+		if dst[domain] == nil {
+			dst[domain] = make(map[string]string)
+		}
+		for k, v := range keys {
+			dst[domain][k] = v
+		}
+	}
+}
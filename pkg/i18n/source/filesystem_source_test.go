@@ -0,0 +1,67 @@
+package source_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/source"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystemSource(t *testing.T) {
+	first := locale.New(fstest.MapFS{
+		"en.json":    &fstest.MapFile{Data: []byte(`{"admin": {"title": "Products", "menu": "Menu"}}`)},
+		"pt_BR.json": &fstest.MapFile{Data: []byte(`{"admin": {"title": "Produtos"}}`)},
+	})
+	second := locale.New(fstest.MapFS{
+		"en.json":     &fstest.MapFile{Data: []byte(`{"admin": {"title": "Catalog"}}`)},
+		"pt_BR.json":  &fstest.MapFile{Data: []byte(`{"admin": `)},
+		"broken.json": &fstest.MapFile{Data: []byte(`{`)},
+	})
+	sut := source.NewFileSystemSource(first, second)
+
+	t.Run("merges locale files with later filesystems overriding", func(t *testing.T) {
+		// Act
+		translations, err := sut.Load(context.Background(), "en")
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, map[string]map[string]string{"admin": {"title": "Catalog", "menu": "Menu"}}, translations)
+	})
+
+	t.Run("returns ErrLocaleNotFound for missing locale", func(t *testing.T) {
+		// Act
+		_, err := sut.Load(context.Background(), "fr")
+
+		// Assert
+		require.ErrorIs(t, err, source.ErrLocaleNotFound)
+	})
+
+	t.Run("skips a malformed file and keeps the other filesystems", func(t *testing.T) {
+		// Act
+		translations, err := sut.Load(context.Background(), "pt_BR")
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, map[string]map[string]string{"admin": {"title": "Produtos"}}, translations)
+	})
+
+	t.Run("returns ErrDecodeLocale when every file of the locale is malformed", func(t *testing.T) {
+		// Act
+		_, err := sut.Load(context.Background(), "broken")
+
+		// Assert
+		require.ErrorIs(t, err, source.ErrDecodeLocale)
+	})
+
+	t.Run("lists locales of all filesystems", func(t *testing.T) {
+		// Act
+		locales, err := sut.Locales(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		require.Equal(t, []string{"broken", "en", "pt_BR"}, locales)
+	})
+}
@@ -0,0 +1,71 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+)
+
+// HTTPSource fetches locale data as JSON from <baseURL>/<locale>.json, using the same format as locale files.
+// The available locales are the ones configured, since HTTP has no listing.
+type HTTPSource struct {
+	baseURL string
+	locales []string
+	client  *http.Client
+}
+
+var _ ports.LocaleSource = (*HTTPSource)(nil)
+
+func NewHTTPSource(baseURL string, locales []string, client *http.Client) (*HTTPSource, error) {
+	if strings.TrimSpace(baseURL) == "" {
+		return nil, ErrHTTPBaseURLRequired
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &HTTPSource{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		locales: slices.Clone(locales),
+		client:  client,
+	}, nil
+}
+
+func (s *HTTPSource) Load(ctx context.Context, code string) (map[string]map[string]string, error) {
+	endpoint := s.baseURL + "/" + url.PathEscape(code) + localeFileExtension
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch locale %q: %w", code, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %q", ErrLocaleNotFound, code)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w %d for locale %q", ErrUnexpectedStatus, resp.StatusCode, code)
+	}
+
+	translations := make(map[string]map[string]string)
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&translations); decodeErr != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrDecodeLocale, code, decodeErr)
+	}
+
+	return translations, nil
+}
+
+func (s *HTTPSource) Locales(_ context.Context) ([]string, error) {
+	return slices.Clone(s.locales), nil
+}
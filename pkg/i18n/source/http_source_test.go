@@ -0,0 +1,70 @@
+package source_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/source"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/locales/en.json":
+			_, _ = w.Write([]byte(`{"admin": {"title": "Products"}}`))
+		case "/locales/es.json":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	sut, err := source.NewHTTPSource(server.URL+"/locales/", []string{"en", "es"}, server.Client())
+	require.NoError(t, err)
+
+	t.Run("fetches locale data", func(t *testing.T) {
+		// Act
+		translations, loadErr := sut.Load(context.Background(), "en")
+
+		// Assert
+		require.NoError(t, loadErr)
+		require.Equal(t, "Products", translations["admin"]["title"])
+	})
+
+	t.Run("maps 404 to ErrLocaleNotFound", func(t *testing.T) {
+		// Act
+		_, loadErr := sut.Load(context.Background(), "fr")
+
+		// Assert
+		require.ErrorIs(t, loadErr, source.ErrLocaleNotFound)
+	})
+
+	t.Run("returns ErrUnexpectedStatus for server errors", func(t *testing.T) {
+		// Act
+		_, loadErr := sut.Load(context.Background(), "es")
+
+		// Assert
+		require.ErrorIs(t, loadErr, source.ErrUnexpectedStatus)
+	})
+
+	t.Run("lists configured locales", func(t *testing.T) {
+		// Act
+		locales, localesErr := sut.Locales(context.Background())
+
+		// Assert
+		require.NoError(t, localesErr)
+		require.Equal(t, []string{"en", "es"}, locales)
+	})
+
+	t.Run("requires base URL", func(t *testing.T) {
+		// Act
+		_, newErr := source.NewHTTPSource("", nil, nil)
+
+		// Assert
+		require.ErrorIs(t, newErr, source.ErrHTTPBaseURLRequired)
+	})
+}
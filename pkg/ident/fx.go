@@ -0,0 +1,11 @@
+package ident
+
+import "go.uber.org/fx"
+
+// Module provides the UUIDv7 Generator. Tests replace it with a Sequence:
+//
+//	fx.Replace(fx.Annotate(ident.NewSequence(), fx.As(new(ident.Generator))))
+var Module = fx.Module(
+	"ident",
+	fx.Provide(New),
+)
@@ -0,0 +1,58 @@
+package ident
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Generator generates the IDs of new entities. Components take a Generator instead of
+// calling the uuid package, so tests can predict the IDs with a Sequence.
+type Generator interface {
+	// NewID returns a new, unique ID.
+	NewID() uuid.UUID
+}
+
+// New returns the Generator of UUIDv7s: time-ordered, so they index well as primary keys.
+func New() Generator {
+	return v7Generator{}
+}
+
+type v7Generator struct{}
+
+func (v7Generator) NewID() uuid.UUID {
+	// NewV7 only fails when the random source fails, as uuid.New
+	return uuid.Must(uuid.NewV7())
+}
+
+// Sequence is a deterministic Generator for tests: it returns version 7 UUIDs numbered
+// from 1, 00000000-0000-7000-8000-000000000001, 00000000-0000-7000-8000-000000000002, ...
+type Sequence struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+// NewSequence returns a Sequence starting at 1.
+func NewSequence() *Sequence {
+	return &Sequence{}
+}
+
+// NewID returns the next ID of the sequence.
+func (s *Sequence) NewID() uuid.UUID {
+	s.mu.Lock()
+	s.next++
+	n := s.next
+	s.mu.Unlock()
+	return SequenceID(n)
+}
+
+// SequenceID returns the nth ID of a Sequence, e.g. to assert the ID of the second
+// entity created by a test.
+func SequenceID(n uint64) uuid.UUID {
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[8:], n)
+	id[6] = 0x70  // version 7
+	id[8] |= 0x80 // RFC 4122 variant
+	return id
+}
@@ -0,0 +1,63 @@
+package ident_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("generates unique version 7 UUIDs in time order", func(t *testing.T) {
+		// Arrange
+		sut := ident.New()
+
+		// Act
+		first := sut.NewID()
+		second := sut.NewID()
+
+		// Assert
+		assert.Equal(t, uuid.Version(7), first.Version())
+		assert.Equal(t, uuid.RFC4122, first.Variant())
+		assert.NotEqual(t, first, second)
+		assert.Less(t, first.String(), second.String())
+	})
+}
+
+func TestSequence(t *testing.T) {
+	t.Run("generates numbered version 7 UUIDs", func(t *testing.T) {
+		// Arrange
+		sut := ident.NewSequence()
+
+		// Act
+		first := sut.NewID()
+		second := sut.NewID()
+
+		// Assert
+		assert.Equal(t, "00000000-0000-7000-8000-000000000001", first.String())
+		assert.Equal(t, ident.SequenceID(2), second)
+		assert.Equal(t, uuid.Version(7), second.Version())
+		assert.Equal(t, uuid.RFC4122, second.Variant())
+	})
+
+	t.Run("replaces the generator of the module", func(t *testing.T) {
+		// Arrange
+		var generator ident.Generator
+
+		// Act
+		app := fx.New(
+			fx.NopLogger,
+			ident.Module,
+			fx.Replace(fx.Annotate(ident.NewSequence(), fx.As(new(ident.Generator)))),
+			fx.Populate(&generator),
+		)
+
+		// Assert
+		require.NoError(t, app.Err())
+		assert.Equal(t, ident.SequenceID(1), generator.NewID())
+	})
+}
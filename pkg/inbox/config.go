@@ -0,0 +1,59 @@
+package inbox
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	BackendRedis    = "redis"
+	BackendDatabase = "database"
+
+	defaultRetention = 7 * 24 * time.Hour
+	defaultLease     = 5 * time.Minute
+	defaultPrefix    = "inbox:"
+	defaultTable     = "inbox_messages"
+)
+
+// Config configures the Inbox and its Store.
+type Config struct {
+	// Backend is the store of the processed messages: database or redis
+	Backend string `config:"backend"`
+	// Retention is how long a processed message ID is remembered, longer than the
+	// redelivery window of the broker, default: 168h
+	Retention time.Duration `config:"retention"`
+	// Lease is how long a delivery being processed holds its message, longer than the
+	// longest handler: a redelivery within the lease is rejected, default: 5m
+	Lease time.Duration `config:"lease"`
+	// Prefix is the key prefix of the redis backend
+	Prefix string `config:"prefix"`
+	// Table is the table of the database backend
+	Table string `config:"table"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Backend == "" {
+		c.Backend = BackendDatabase
+	}
+	if c.Retention <= 0 {
+		c.Retention = defaultRetention
+	}
+	if c.Lease <= 0 {
+		c.Lease = defaultLease
+	}
+	if c.Prefix == "" {
+		c.Prefix = defaultPrefix
+	}
+	if c.Table == "" {
+		c.Table = defaultTable
+	}
+}
+
+// Validate checks the configured backend.
+func (c *Config) Validate() error {
+	if c.Backend != BackendRedis && c.Backend != BackendDatabase {
+		return fmt.Errorf("%w: %s", ErrInvalidBackend, c.Backend)
+	}
+	return nil
+}
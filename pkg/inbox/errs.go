@@ -0,0 +1,16 @@
+package inbox
+
+import "errors"
+
+var (
+	// ErrDuplicate is returned by Store.Claim when the message was already processed.
+	ErrDuplicate = errors.New("message already processed")
+	// ErrInFlight is returned when another delivery of the message is being processed: the
+	// message is to be redelivered later.
+	ErrInFlight = errors.New("message being processed by another delivery")
+
+	ErrMissingMessageID = errors.New("message ID is required")
+	ErrInvalidBackend   = errors.New("invalid inbox backend (must be 'database' or 'redis')")
+	ErrMissingRedis     = errors.New("the redis backend requires a *redis.Client")
+	ErrMissingDatabase  = errors.New("the database backend requires a *gorm.DB")
+)
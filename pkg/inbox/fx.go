@@ -0,0 +1,79 @@
+package inbox
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// Module provides the Inbox with the Store of the configured backend. It loads the config
+// from "app.inbox"; the database backend requires database.Module, and runs each delivery
+// in a transaction with its TxManager, the redis backend requires redis.ClientModule. The
+// inbox_messages migration joins the "migration_filesystems" group, and the GormStore is
+// provided to schedule DeleteExpired.
+//
+//	fx.New(
+//	    database.Module,
+//	    inbox.Module,
+//	    fx.Provide(consumer.NewBillingConsumer), // takes an *inbox.Inbox
+//	)
+var Module = fx.Module(
+	"inbox",
+	config.Provide[Config]("app.inbox"),
+	fx.Provide(
+		NewInboxWithParams,
+		NewGormStoreWithConfig,
+		fx.Annotate(Migrations, fx.ResultTags(`group:"migration_filesystems"`)),
+	),
+)
+
+// InboxParams for dependency injection
+type InboxParams struct {
+	fx.In
+
+	Config     config.Config[Config]
+	Redis      *redis.Client         `optional:"true"`
+	DB         *gorm.DB              `optional:"true"`
+	TxManager  database.TxManager    `optional:"true"`
+	Clock      clock.Clock           `optional:"true"`
+	Registerer prometheus.Registerer `optional:"true"`
+}
+
+// NewInboxWithParams creates the Inbox with the Store of the configured backend.
+func NewInboxWithParams(params InboxParams) (*Inbox, error) {
+	cfg := params.Config.Get()
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts := []Option{WithClock(params.Clock), WithRegisterer(params.Registerer)}
+	var store Store
+	switch cfg.Backend {
+	case BackendRedis:
+		if params.Redis == nil {
+			return nil, ErrMissingRedis
+		}
+		store = NewRedisStore(params.Redis, cfg.Prefix)
+	case BackendDatabase:
+		if params.DB == nil {
+			return nil, ErrMissingDatabase
+		}
+		store = NewGormStore(params.DB, cfg.Table)
+		opts = append(opts, WithTxManager(params.TxManager))
+	}
+	return New(cfg, store, opts...)
+}
+
+// NewGormStoreWithConfig creates the GormStore of the configured table, to delete the
+// expired messages of the database backend.
+func NewGormStoreWithConfig(db *gorm.DB, cfg config.Config[Config]) *GormStore {
+	inboxConfig := cfg.Get()
+	inboxConfig.SetDefaults()
+	return NewGormStore(db, inboxConfig.Table)
+}
@@ -0,0 +1,106 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+// Record is the GORM model of a message in the inbox_messages table (see Migrations).
+type Record struct {
+	Consumer  string    `gorm:"primaryKey"`
+	MessageID string    `gorm:"primaryKey"`
+	Status    string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+// GormStore is the Store keeping the messages in the database. It joins the transaction of
+// the context: run the claim and the handler in one transaction, as the Inbox does with a
+// TxManager, and the message is recorded processed only when the changes of the handler
+// are committed.
+type GormStore struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormStore creates a GormStore keeping the messages in table.
+func NewGormStore(db *gorm.DB, table string) *GormStore {
+	return &GormStore{db: db, table: table}
+}
+
+// Claim implements Store. The claim of an expired message, a lease or a retention, is taken
+// over.
+func (s *GormStore) Claim(ctx context.Context, consumer, messageID string, now, leaseUntil time.Time) error {
+	record := Record{
+		Consumer:  consumer,
+		MessageID: messageID,
+		Status:    statusProcessing,
+		CreatedAt: now,
+		ExpiresAt: leaseUntil,
+	}
+	result := database.DB(ctx, s.db).Table(s.table).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "consumer"}, {Name: "message_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "created_at", "expires_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: s.table + ".expires_at <= ?", Vars: []any{now}},
+			}},
+		}).
+		Create(&record)
+	if result.Error != nil {
+		return fmt.Errorf("inbox: claim message: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var statuses []string
+	err := database.DB(ctx, s.db).Table(s.table).
+		Where("consumer = ? AND message_id = ?", consumer, messageID).
+		Pluck("status", &statuses).Error
+	if err != nil {
+		return fmt.Errorf("inbox: claim message: %w", err)
+	}
+	if len(statuses) > 0 && statuses[0] == statusProcessed {
+		return ErrDuplicate
+	}
+	return ErrInFlight
+}
+
+// Complete implements Store.
+func (s *GormStore) Complete(ctx context.Context, consumer, messageID string, _, expiresAt time.Time) error {
+	err := database.DB(ctx, s.db).Table(s.table).
+		Where("consumer = ? AND message_id = ?", consumer, messageID).
+		Updates(map[string]any{"status": statusProcessed, "expires_at": expiresAt}).Error
+	if err != nil {
+		return fmt.Errorf("inbox: complete message: %w", err)
+	}
+	return nil
+}
+
+// Release implements Store.
+func (s *GormStore) Release(ctx context.Context, consumer, messageID string) error {
+	err := database.DB(ctx, s.db).Table(s.table).
+		Where("consumer = ? AND message_id = ? AND status = ?", consumer, messageID, statusProcessing).
+		Delete(&Record{}).Error
+	if err != nil {
+		return fmt.Errorf("inbox: release message: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired deletes the messages whose retention or lease expired at now, returning how
+// many were deleted. Schedule it, e.g. hourly with the scheduler package.
+func (s *GormStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := database.DB(ctx, s.db).Table(s.table).Where("expires_at <= ?", now).Delete(&Record{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("inbox: delete expired messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
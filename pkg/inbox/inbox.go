@@ -0,0 +1,116 @@
+// Package inbox deduplicates the deliveries of the messaging consumers by message ID: each
+// message is processed once per consumer, however many times the broker delivers it, which
+// makes an at-least-once broker effectively-once.
+package inbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+// Inbox processes the messages of the consumers once, remembering their IDs in a Store.
+type Inbox struct {
+	cfg       Config
+	store     Store
+	clock     clock.Clock
+	txManager database.TxManager
+	metrics   *inboxMetrics
+}
+
+// New creates an Inbox remembering the processed messages in store.
+func New(cfg Config, store Store, opts ...Option) (*Inbox, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	inboxOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&inboxOptions)
+	}
+	metrics, err := newInboxMetrics(inboxOptions.registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &Inbox{
+		cfg:       cfg,
+		store:     store,
+		clock:     inboxOptions.clock,
+		txManager: inboxOptions.txManager,
+		metrics:   metrics,
+	}, nil
+}
+
+// Process runs handle unless consumer already processed the message. A duplicate delivery
+// returns nil, to be acknowledged; a delivery of a message being processed by another one
+// returns ErrInFlight, to be redelivered later. When handle fails, the message is released
+// for its redelivery and the error returned.
+func (i *Inbox) Process(ctx context.Context, consumer, messageID string, handle func(ctx context.Context) error) error {
+	if messageID == "" {
+		return ErrMissingMessageID
+	}
+
+	var err error
+	if i.txManager != nil {
+		// A failure rolls the claim back with the changes of the handler
+		err = i.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			return i.process(ctx, consumer, messageID, handle)
+		})
+	} else {
+		err = i.process(ctx, consumer, messageID, handle)
+	}
+
+	switch {
+	case err == nil:
+		i.metrics.messages.WithLabelValues(consumer, resultProcessed).Inc()
+	case errors.Is(err, ErrDuplicate):
+		i.metrics.messages.WithLabelValues(consumer, resultDuplicate).Inc()
+		return nil
+	case errors.Is(err, ErrInFlight):
+		i.metrics.messages.WithLabelValues(consumer, resultInFlight).Inc()
+	default:
+		i.metrics.messages.WithLabelValues(consumer, resultFailed).Inc()
+	}
+	return err
+}
+
+func (i *Inbox) process(ctx context.Context, consumer, messageID string, handle func(ctx context.Context) error) error {
+	now := i.clock.Now()
+	if err := i.store.Claim(ctx, consumer, messageID, now, now.Add(i.cfg.Lease)); err != nil {
+		return err
+	}
+	if err := handle(ctx); err != nil {
+		if i.txManager == nil {
+			// Released even when ctx is canceled, otherwise the redeliveries wait for the lease
+			if errRelease := i.store.Release(context.WithoutCancel(ctx), consumer, messageID); errRelease != nil {
+				return errors.Join(err, errRelease)
+			}
+		}
+		return err
+	}
+	now = i.clock.Now()
+	if err := i.store.Complete(ctx, consumer, messageID, now, now.Add(i.cfg.Retention)); err != nil {
+		return fmt.Errorf("message %s processed but not recorded: %w", messageID, err)
+	}
+	return nil
+}
+
+// Wrap returns handle deduplicating the messages of consumer by the ID messageID returns,
+// to wrap the handler of a broker consumer:
+//
+//	handler := inbox.Wrap(box, "billing", func(m Message) string { return m.ID }, h.Handle)
+func Wrap[M any](
+	inbox *Inbox,
+	consumer string,
+	messageID func(message M) string,
+	handle func(ctx context.Context, message M) error,
+) func(context.Context, M) error {
+	return func(ctx context.Context, message M) error {
+		return inbox.Process(ctx, consumer, messageID(message), func(ctx context.Context) error {
+			return handle(ctx, message)
+		})
+	}
+}
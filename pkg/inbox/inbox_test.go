@@ -0,0 +1,258 @@
+package inbox_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/inbox"
+)
+
+var errHandler = errors.New("handler failed")
+
+type memoryMessage struct {
+	processed bool
+	expiresAt time.Time
+}
+
+// memoryStore is a Store in memory, recording its calls.
+type memoryStore struct {
+	mu       sync.Mutex
+	messages map[string]memoryMessage
+	calls    []string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{messages: make(map[string]memoryMessage)}
+}
+
+func (s *memoryStore) Claim(_ context.Context, consumer, messageID string, now, leaseUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, "claim")
+	key := consumer + ":" + messageID
+	if message, found := s.messages[key]; found && message.expiresAt.After(now) {
+		if message.processed {
+			return inbox.ErrDuplicate
+		}
+		return inbox.ErrInFlight
+	}
+	s.messages[key] = memoryMessage{expiresAt: leaseUntil}
+	return nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, consumer, messageID string, _, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, "complete")
+	s.messages[consumer+":"+messageID] = memoryMessage{processed: true, expiresAt: expiresAt}
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, consumer, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, "release")
+	delete(s.messages, consumer+":"+messageID)
+	return nil
+}
+
+// fakeTxManager records the transactions, without a database.
+type fakeTxManager struct {
+	transactions int
+}
+
+func (m *fakeTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.transactions++
+	return fn(ctx)
+}
+
+type fixture struct {
+	inbox    *inbox.Inbox
+	store    *memoryStore
+	clock    *clock.Fake
+	registry *prometheus.Registry
+}
+
+func newFixture(t *testing.T, opts ...inbox.Option) fixture {
+	t.Helper()
+	f := fixture{
+		store:    newMemoryStore(),
+		clock:    clock.NewFake(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)),
+		registry: prometheus.NewRegistry(),
+	}
+	box, err := inbox.New(inbox.Config{Lease: time.Minute, Retention: time.Hour}, f.store,
+		append([]inbox.Option{inbox.WithClock(f.clock), inbox.WithRegisterer(f.registry)}, opts...)...)
+	require.NoError(t, err)
+	f.inbox = box
+	return f
+}
+
+func (f fixture) count(t *testing.T, result string) float64 {
+	t.Helper()
+	families, err := f.registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "result" && label.GetValue() == result {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestNew_RejectsAnInvalidBackend(t *testing.T) {
+	// Act
+	_, err := inbox.New(inbox.Config{Backend: "memcache"}, newMemoryStore())
+
+	// Assert
+	require.ErrorIs(t, err, inbox.ErrInvalidBackend)
+}
+
+func TestInbox_Process(t *testing.T) {
+	t.Run("processes a message once", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t)
+		handled := 0
+		handle := func(context.Context) error {
+			handled++
+			return nil
+		}
+
+		// Act
+		err := f.inbox.Process(context.Background(), "billing", "message-1", handle)
+		errDuplicate := f.inbox.Process(context.Background(), "billing", "message-1", handle)
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, errDuplicate)
+		assert.Equal(t, 1, handled)
+		assert.InDelta(t, 1, f.count(t, "processed"), 0)
+		assert.InDelta(t, 1, f.count(t, "duplicate"), 0)
+	})
+
+	t.Run("processes the message of each consumer", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t)
+		handled := 0
+		handle := func(context.Context) error {
+			handled++
+			return nil
+		}
+
+		// Act
+		require.NoError(t, f.inbox.Process(context.Background(), "billing", "message-1", handle))
+		require.NoError(t, f.inbox.Process(context.Background(), "shipping", "message-1", handle))
+
+		// Assert
+		assert.Equal(t, 2, handled)
+	})
+
+	t.Run("releases a failed message for its redelivery", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t)
+		failing := func(context.Context) error { return errHandler }
+		require.ErrorIs(t, f.inbox.Process(context.Background(), "billing", "message-1", failing), errHandler)
+
+		// Act
+		err := f.inbox.Process(context.Background(), "billing", "message-1", func(context.Context) error { return nil })
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"claim", "release", "claim", "complete"}, f.store.calls)
+		assert.InDelta(t, 1, f.count(t, "failed"), 0)
+	})
+
+	t.Run("rejects a delivery while the message is processed", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t)
+		require.NoError(t, f.store.Claim(context.Background(), "billing", "message-1", f.clock.Now(),
+			f.clock.Now().Add(time.Minute)))
+
+		// Act
+		err := f.inbox.Process(context.Background(), "billing", "message-1", func(context.Context) error { return nil })
+
+		// Assert
+		require.ErrorIs(t, err, inbox.ErrInFlight)
+		assert.InDelta(t, 1, f.count(t, "in_flight"), 0)
+	})
+
+	t.Run("processes the message again once its retention expired", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t)
+		handled := 0
+		handle := func(context.Context) error {
+			handled++
+			return nil
+		}
+		require.NoError(t, f.inbox.Process(context.Background(), "billing", "message-1", handle))
+		f.clock.Advance(time.Hour)
+
+		// Act
+		err := f.inbox.Process(context.Background(), "billing", "message-1", handle)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 2, handled)
+	})
+
+	t.Run("rolls a failed message back in its transaction", func(t *testing.T) {
+		// Arrange
+		txManager := &fakeTxManager{}
+		f := newFixture(t, inbox.WithTxManager(txManager))
+
+		// Act
+		err := f.inbox.Process(context.Background(), "billing", "message-1", func(context.Context) error {
+			return errHandler
+		})
+
+		// Assert
+		require.ErrorIs(t, err, errHandler)
+		assert.Equal(t, 1, txManager.transactions)
+		assert.Equal(t, []string{"claim"}, f.store.calls)
+	})
+
+	t.Run("requires a message ID", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t)
+
+		// Act
+		err := f.inbox.Process(context.Background(), "billing", "", func(context.Context) error { return nil })
+
+		// Assert
+		require.ErrorIs(t, err, inbox.ErrMissingMessageID)
+	})
+}
+
+func TestWrap(t *testing.T) {
+	// Arrange
+	type message struct {
+		ID     string
+		Amount int
+	}
+	f := newFixture(t)
+	total := 0
+	handle := inbox.Wrap(f.inbox, "billing", func(m message) string { return m.ID },
+		func(_ context.Context, m message) error {
+			total += m.Amount
+			return nil
+		})
+
+	// Act
+	for _, m := range []message{{ID: "1", Amount: 10}, {ID: "2", Amount: 5}, {ID: "1", Amount: 10}} {
+		require.NoError(t, handle(context.Background(), m))
+	}
+
+	// Assert
+	assert.Equal(t, 15, total)
+}
@@ -0,0 +1,35 @@
+package inbox
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	messagesMetricName = "inbox_messages_total"
+
+	resultProcessed = "processed"
+	resultDuplicate = "duplicate"
+	resultInFlight  = "in_flight"
+	resultFailed    = "failed"
+)
+
+type inboxMetrics struct {
+	messages *prometheus.CounterVec
+}
+
+func newInboxMetrics(registerer prometheus.Registerer) (*inboxMetrics, error) {
+	messages := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: messagesMetricName,
+			Help: "Total deliveries by consumer and result (processed, duplicate, in_flight, failed)",
+		},
+		[]string{"consumer", "result"},
+	)
+	messages, err := metrics.Register(registerer, messages)
+	if err != nil {
+		return nil, err
+	}
+	return &inboxMetrics{messages: messages}, nil
+}
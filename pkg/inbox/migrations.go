@@ -0,0 +1,18 @@
+package inbox
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/cristiano-pacheco/bricks/pkg/migration"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations returns the migration creating the inbox_messages table, to run with the
+// migrations of the application.
+func Migrations() migration.FileSystem {
+	files, _ := fs.Sub(migrationFiles, "migrations")
+	return migration.New(files)
+}
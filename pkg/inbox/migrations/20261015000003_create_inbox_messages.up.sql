@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS inbox_messages (
+    consumer TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (consumer, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS inbox_messages_expires_at_idx ON inbox_messages (expires_at);
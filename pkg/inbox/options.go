@@ -0,0 +1,55 @@
+package inbox
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+type options struct {
+	registerer prometheus.Registerer
+	clock      clock.Clock
+	txManager  database.TxManager
+}
+
+// Option configures the Inbox created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		registerer: prometheus.DefaultRegisterer,
+		clock:      clock.New(),
+	}
+}
+
+// WithRegisterer sets the registerer the inbox metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithClock sets the clock of the leases and retentions, e.g. a clock.Fake in tests.
+// Defaults to the time package when not provided.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithTxManager runs the claim, the handler and the completion of each delivery in one
+// transaction, for the GormStore: the message is recorded processed with the changes of
+// the handler, or not at all.
+func WithTxManager(txManager database.TxManager) Option {
+	return func(o *options) {
+		if txManager != nil {
+			o.txManager = txManager
+		}
+	}
+}
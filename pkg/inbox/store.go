@@ -0,0 +1,82 @@
+package inbox
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// Store remembers the messages processed by each consumer.
+type Store interface {
+	// Claim claims the message of consumer for processing until leaseUntil. It returns
+	// ErrDuplicate when the message was processed, and ErrInFlight when another delivery
+	// holds an unexpired claim.
+	Claim(ctx context.Context, consumer, messageID string, now, leaseUntil time.Time) error
+	// Complete marks the claimed message processed, remembered until expiresAt.
+	Complete(ctx context.Context, consumer, messageID string, now, expiresAt time.Time) error
+	// Release removes the claim of a failed delivery, so a redelivery processes the message.
+	Release(ctx context.Context, consumer, messageID string) error
+}
+
+const (
+	statusProcessing = "processing"
+	statusProcessed  = "processed"
+)
+
+// RedisStore keeps the messages as keys expiring with their lease, then their retention,
+// through the bricks client, so the keys are namespaced and the commands recorded in the
+// client metrics.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore creates a RedisStore keeping the messages under prefix + consumer:ID.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Claim implements Store.
+func (s *RedisStore) Claim(ctx context.Context, consumer, messageID string, now, leaseUntil time.Time) error {
+	key := s.key(consumer, messageID)
+	var claim *goredis.BoolCmd
+	var status *goredis.StringCmd
+	err := s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		claim = p.SetNX(ctx, key, statusProcessing, leaseUntil.Sub(now))
+		status = p.Get(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if claim.Val() {
+		return nil
+	}
+	if status.Val() == statusProcessed {
+		return ErrDuplicate
+	}
+	return ErrInFlight
+}
+
+// Complete implements Store.
+func (s *RedisStore) Complete(ctx context.Context, consumer, messageID string, now, expiresAt time.Time) error {
+	return s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, s.key(consumer, messageID), statusProcessed, expiresAt.Sub(now))
+		return nil
+	})
+}
+
+// Release implements Store.
+func (s *RedisStore) Release(ctx context.Context, consumer, messageID string) error {
+	return s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Del(ctx, s.key(consumer, messageID))
+		return nil
+	})
+}
+
+func (s *RedisStore) key(consumer, messageID string) string {
+	return s.prefix + consumer + ":" + messageID
+}
@@ -0,0 +1,56 @@
+package ipfilter
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	SourceStatic = "static"
+	SourceConfig = "config"
+	SourceRedis  = "redis"
+
+	defaultRedisKey       = "ipfilter"
+	defaultReloadInterval = 30 * time.Second
+)
+
+// Config configures the Filter.
+type Config struct {
+	// Allow lists the allowed addresses and CIDR ranges; empty allows every address not denied
+	Allow []string `config:"allow"`
+	// Deny lists the denied addresses and CIDR ranges, denied even when allowed
+	Deny []string `config:"deny"`
+	// Source reloads the lists while the application runs: static (never), config or redis
+	Source string `config:"source"`
+	// RedisKey is the key of the lists of the redis source, as JSON, after the redis client namespace
+	RedisKey string `config:"redis_key"`
+	// ReloadInterval is the interval of the reloads of the config and redis sources
+	ReloadInterval time.Duration `config:"reload_interval"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Source == "" {
+		c.Source = SourceStatic
+	}
+	if c.RedisKey == "" {
+		c.RedisKey = defaultRedisKey
+	}
+	if c.ReloadInterval <= 0 {
+		c.ReloadInterval = defaultReloadInterval
+	}
+}
+
+// Validate checks the source and the lists.
+func (c *Config) Validate() error {
+	if c.Source != SourceStatic && c.Source != SourceConfig && c.Source != SourceRedis {
+		return fmt.Errorf("%w: %s", ErrInvalidSource, c.Source)
+	}
+	_, err := c.Lists().compile()
+	return err
+}
+
+// Lists returns the configured lists.
+func (c *Config) Lists() Lists {
+	return Lists{Allow: c.Allow, Deny: c.Deny}
+}
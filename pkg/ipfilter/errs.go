@@ -0,0 +1,17 @@
+package ipfilter
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+var (
+	ErrInvalidEntry  = errors.New("invalid ip filter entry (must be an IP address or a CIDR range)")
+	ErrInvalidSource = errors.New("invalid ip filter source (must be 'static', 'config' or 'redis')")
+	ErrMissingRedis  = errors.New("the redis source requires a *redis.Client")
+
+	// ErrForbidden is returned when the client address is denied or not allowed
+	ErrForbidden = errs.New("IP_FORBIDDEN", "Access from this address is not allowed", http.StatusForbidden, nil)
+)
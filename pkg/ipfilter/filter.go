@@ -0,0 +1,157 @@
+package ipfilter
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+// Lists are the allow and deny lists of a Filter: IP addresses and CIDR ranges.
+type Lists struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+type compiledLists struct {
+	allow []netip.Prefix
+	deny  []netip.Prefix
+}
+
+func (l Lists) compile() (*compiledLists, error) {
+	allow, err := ParsePrefixes(l.Allow)
+	if err != nil {
+		return nil, err
+	}
+	deny, err := ParsePrefixes(l.Deny)
+	if err != nil {
+		return nil, err
+	}
+	return &compiledLists{allow: allow, deny: deny}, nil
+}
+
+// Filter restricts the requests to the client addresses allowed by its lists. The lists
+// can be replaced at runtime with Update, or reloaded from a ListSource.
+type Filter struct {
+	lists   atomic.Pointer[compiledLists]
+	options options
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewFilter creates a Filter with lists.
+//
+//	filter, err := ipfilter.NewFilter(ipfilter.Lists{Allow: []string{"10.8.0.0/16"}})
+//	router.With(filter.Middleware).Mount("/admin", adminRouter)
+func NewFilter(lists Lists, opts ...Option) (*Filter, error) {
+	compiled, err := lists.compile()
+	if err != nil {
+		return nil, err
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	f := &Filter{options: options, stop: make(chan struct{}), done: make(chan struct{})}
+	f.lists.Store(compiled)
+	return f, nil
+}
+
+// Update replaces the lists. Invalid lists return ErrInvalidEntry and keep the current ones.
+func (f *Filter) Update(lists Lists) error {
+	compiled, err := lists.compile()
+	if err != nil {
+		return err
+	}
+	f.lists.Store(compiled)
+	return nil
+}
+
+// Allowed reports whether addr is allowed: not denied, and allowed when the allow list is
+// not empty.
+func (f *Filter) Allowed(addr netip.Addr) bool {
+	lists := f.lists.Load()
+	addr = addr.Unmap()
+	if contains(lists.deny, addr) {
+		return false
+	}
+	return len(lists.allow) == 0 || contains(lists.allow, addr)
+}
+
+// Middleware answers ErrForbidden, status 403, to the clients that are not allowed, and
+// logs them. The client address is the RemoteAddr of the request: set the TrustedProxies
+// of the chi server behind a proxy, so it is resolved with ClientIP.
+func (f *Filter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := RemoteIP(r)
+		if ok && f.Allowed(addr) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		requestID, _ := ctxmeta.RequestID(r.Context())
+		f.options.logger.WarnContext(r.Context(), "request rejected by the ip filter",
+			"ip", r.RemoteAddr,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"request_id", requestID,
+		)
+		f.options.errorHandler(w, r, ErrForbidden)
+	})
+}
+
+// Start reloads the lists from the source every interval until Stop. It does nothing
+// without a source.
+func (f *Filter) Start() {
+	if f.options.source == nil || !f.started.CompareAndSwap(false, true) {
+		return
+	}
+	go f.watch()
+}
+
+// Stop stops the reloads started by Start and waits for the running one.
+func (f *Filter) Stop() {
+	f.stopOnce.Do(func() {
+		close(f.stop)
+	})
+	if f.started.Load() {
+		<-f.done
+	}
+}
+
+func (f *Filter) watch() {
+	defer close(f.done)
+	ticker := time.NewTicker(f.options.interval)
+	defer ticker.Stop()
+	for {
+		f.Reload(context.Background())
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reload reads the lists of the source now. The current lists are kept when the source
+// fails, has no lists, or returns invalid ones.
+func (f *Filter) Reload(ctx context.Context) {
+	if f.options.source == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, f.options.interval)
+	defer cancel()
+	lists, ok, err := f.options.source.Load(ctx)
+	if err == nil && ok {
+		err = f.Update(lists)
+	}
+	if err != nil {
+		f.options.logger.Warn("failed to reload the ip filter lists", "err", err)
+	}
+}
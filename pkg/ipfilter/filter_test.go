@@ -0,0 +1,125 @@
+package ipfilter_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ipfilter"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+type FilterTestSuite struct {
+	suite.Suite
+	sut     *ipfilter.Filter
+	source  *mocks.MockListSource
+	handler http.Handler
+}
+
+func TestFilterSuite(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
+
+func (s *FilterTestSuite) SetupTest() {
+	s.source = mocks.NewMockListSource(s.T())
+	var err error
+	s.sut, err = ipfilter.NewFilter(
+		ipfilter.Lists{Allow: []string{"10.8.0.0/16", "203.0.113.7"}, Deny: []string{"10.8.9.0/24"}},
+		ipfilter.WithSource(s.source, 0),
+		ipfilter.WithLogger(slog.New(slog.DiscardHandler)),
+	)
+	s.Require().NoError(err)
+	s.handler = s.sut.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func (s *FilterTestSuite) serve(remoteAddr string) int {
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.RemoteAddr = remoteAddr
+	rr := httptest.NewRecorder()
+	s.handler.ServeHTTP(rr, req)
+	return rr.Code
+}
+
+func (s *FilterTestSuite) TestMiddleware_AppliesTheLists() {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		status     int
+	}{
+		{name: "allowed range", remoteAddr: "10.8.1.2:4000", status: http.StatusOK},
+		{name: "allowed address", remoteAddr: "203.0.113.7", status: http.StatusOK},
+		{name: "denied within an allowed range", remoteAddr: "10.8.9.1:4000", status: http.StatusForbidden},
+		{name: "not allowed", remoteAddr: "198.51.100.1:4000", status: http.StatusForbidden},
+		{name: "unparsable address", remoteAddr: "unknown", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// Act
+			status := s.serve(tt.remoteAddr)
+
+			// Assert
+			s.Equal(tt.status, status)
+		})
+	}
+}
+
+func (s *FilterTestSuite) TestAllowed_EmptyAllowList_AllowsEveryAddressNotDenied() {
+	// Arrange
+	s.Require().NoError(s.sut.Update(ipfilter.Lists{Deny: []string{"198.51.100.0/24"}}))
+
+	// Act & Assert
+	s.True(s.sut.Allowed(netip.MustParseAddr("203.0.113.99")))
+	s.False(s.sut.Allowed(netip.MustParseAddr("198.51.100.1")))
+}
+
+func (s *FilterTestSuite) TestUpdate_InvalidLists_KeepsTheCurrentOnes() {
+	// Act
+	err := s.sut.Update(ipfilter.Lists{Allow: []string{"10.0.0.0/33"}})
+
+	// Assert
+	s.Require().ErrorIs(err, ipfilter.ErrInvalidEntry)
+	s.Equal(http.StatusOK, s.serve("10.8.1.2:4000"))
+}
+
+func (s *FilterTestSuite) TestReload_ReplacesTheListsWithTheSourceOnes() {
+	// Arrange
+	s.source.On("Load", mock.Anything).Return(ipfilter.Lists{Allow: []string{"198.51.100.0/24"}}, true, nil)
+
+	// Act
+	s.sut.Reload(context.Background())
+
+	// Assert
+	s.Equal(http.StatusOK, s.serve("198.51.100.1:4000"))
+	s.Equal(http.StatusForbidden, s.serve("10.8.1.2:4000"))
+}
+
+func (s *FilterTestSuite) TestReload_SourceFailure_KeepsTheLists() {
+	// Arrange
+	s.source.On("Load", mock.Anything).Return(ipfilter.Lists{}, false, errors.New("redis down"))
+
+	// Act
+	s.sut.Reload(context.Background())
+
+	// Assert
+	s.Equal(http.StatusOK, s.serve("10.8.1.2:4000"))
+}
+
+func (s *FilterTestSuite) TestReload_SourceWithoutLists_KeepsTheLists() {
+	// Arrange
+	s.source.On("Load", mock.Anything).Return(ipfilter.Lists{}, false, nil)
+
+	// Act
+	s.sut.Reload(context.Background())
+
+	// Assert
+	s.Equal(http.StatusOK, s.serve("10.8.1.2:4000"))
+}
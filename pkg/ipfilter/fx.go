@@ -0,0 +1,79 @@
+package ipfilter
+
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+const configPath = "app.ipfilter"
+
+// Module provides the Filter. It loads the config from "app.ipfilter"; the redis source
+// requires redis.ClientModule.
+//
+//	fx.New(
+//	    ipfilter.Module,
+//	    fx.Invoke(func(server *chi.Server, filter *ipfilter.Filter) {
+//	        server.Router().With(filter.Middleware).Mount("/admin", adminRouter)
+//	    }),
+//	)
+var Module = fx.Module(
+	"ipfilter",
+	config.Provide[Config](configPath),
+	fx.Provide(NewFilterWithLifecycle),
+)
+
+// FilterParams for dependency injection
+type FilterParams struct {
+	fx.In
+
+	Lifecycle    fx.Lifecycle
+	Config       config.Config[Config]
+	Redis        *redis.Client         `optional:"true"`
+	ErrorHandler response.ErrorHandler `optional:"true"`
+	Logger       *slog.Logger          `optional:"true"`
+	// ConfigOptions apply to the reloads of the config source when supplied with
+	// config.SupplyOptions.
+	ConfigOptions config.Options `optional:"true"`
+}
+
+// NewFilterWithLifecycle creates the Filter with the configured lists, reloaded from the
+// configured source while the application runs.
+func NewFilterWithLifecycle(params FilterParams) (*Filter, error) {
+	cfg := params.Config.Get()
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts := []Option{WithErrorHandler(params.ErrorHandler), WithLogger(params.Logger)}
+	switch cfg.Source {
+	case SourceConfig:
+		opts = append(opts, WithSource(NewConfigSource(configPath, params.ConfigOptions...), cfg.ReloadInterval))
+	case SourceRedis:
+		if params.Redis == nil {
+			return nil, ErrMissingRedis
+		}
+		opts = append(opts, WithSource(NewRedisSource(params.Redis, cfg.RedisKey), cfg.ReloadInterval))
+	}
+	filter, err := NewFilter(cfg.Lists(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			filter.Start()
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			filter.Stop()
+			return nil
+		},
+	})
+	return filter, nil
+}
@@ -0,0 +1,103 @@
+package ipfilter
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// ParsePrefixes parses IP addresses and CIDR ranges, e.g. "10.8.0.0/16" or "203.0.113.7".
+// An address is the range of that address alone.
+func ParsePrefixes(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidEntry, entry)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidEntry, entry)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ClientIP returns the address of the client of r. The X-Forwarded-For and X-Real-IP
+// headers are only read when the peer is one of the trusted proxies: the client is then
+// the rightmost forwarded address that is not a trusted proxy, so a client cannot spoof
+// its address by sending the headers itself.
+func ClientIP(r *http.Request, trusted []netip.Prefix) (netip.Addr, bool) {
+	peer, ok := RemoteIP(r)
+	if !ok || !contains(trusted, peer) {
+		return peer, ok
+	}
+
+	var forwarded []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(value, ",")...)
+	}
+	client := peer
+	for _, value := range slices.Backward(forwarded) {
+		addr, err := netip.ParseAddr(strings.TrimSpace(value))
+		if err != nil {
+			break
+		}
+		client = addr.Unmap()
+		if !contains(trusted, client) {
+			return client, true
+		}
+	}
+	if len(forwarded) == 0 {
+		if addr, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+			return addr.Unmap(), true
+		}
+	}
+	return client, true
+}
+
+// RemoteIP returns the address of RemoteAddr, with or without its port.
+func RemoteIP(r *http.Request) (netip.Addr, bool) {
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// RealIP sets the RemoteAddr of the requests to their ClientIP, like the RealIP
+// middleware of chi but only trusting the headers set by the trusted proxies. The chi
+// server uses it when its TrustedProxies are set.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if addr, ok := ClientIP(r, trusted); ok {
+				r.RemoteAddr = addr.String()
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func contains(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package ipfilter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ipfilter"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted, err := ipfilter.ParsePrefixes([]string{"10.0.0.0/8", "192.0.2.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		realIP     string
+		want       string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.7:1234", want: "203.0.113.7"},
+		{name: "untrusted peer ignores the headers", remoteAddr: "203.0.113.7:1234", forwarded: "10.8.0.1",
+			want: "203.0.113.7"},
+		{name: "trusted proxy", remoteAddr: "10.0.0.5:1234", forwarded: "198.51.100.9", want: "198.51.100.9"},
+		{name: "spoofed leftmost address", remoteAddr: "10.0.0.5:1234", forwarded: "10.8.0.1, 198.51.100.9",
+			want: "198.51.100.9"},
+		{name: "chain of trusted proxies", remoteAddr: "10.0.0.5:1234", forwarded: "198.51.100.9, 192.0.2.1, 10.0.0.7",
+			want: "198.51.100.9"},
+		{name: "real ip header", remoteAddr: "10.0.0.5:1234", realIP: "198.51.100.9", want: "198.51.100.9"},
+		{name: "ipv4 mapped address", remoteAddr: "[::ffff:203.0.113.7]:1234", want: "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			// Act
+			addr, ok := ipfilter.ClientIP(req, trusted)
+
+			// Assert
+			require.True(t, ok)
+			assert.Equal(t, netip.MustParseAddr(tt.want), addr)
+		})
+	}
+}
+
+func TestParsePrefixes_InvalidEntry_ReturnsAnError(t *testing.T) {
+	// Act
+	_, err := ipfilter.ParsePrefixes([]string{"10.0.0.0/8", "vpn"})
+
+	// Assert
+	require.ErrorIs(t, err, ipfilter.ErrInvalidEntry)
+}
+
+func TestRealIP_SetsTheRemoteAddrToTheClientIP(t *testing.T) {
+	// Arrange
+	trusted, err := ipfilter.ParsePrefixes([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	var remoteAddr string
+	handler := ipfilter.RealIP(trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		remoteAddr = r.RemoteAddr
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.5:1234"
+	req.Header.Set("X-Forwarded-For", "198.51.100.9")
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	assert.Equal(t, "198.51.100.9", remoteAddr)
+}
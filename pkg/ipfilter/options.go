@@ -0,0 +1,57 @@
+package ipfilter
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+type options struct {
+	source       ListSource
+	interval     time.Duration
+	logger       *slog.Logger
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Option configures the Filter created by NewFilter.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{interval: defaultReloadInterval, logger: slog.Default(), errorHandler: response.WriteError}
+}
+
+// WithSource reloads the lists from source every interval once Start is called.
+func WithSource(source ListSource, interval time.Duration) Option {
+	return func(o *options) {
+		if source != nil {
+			o.source = source
+		}
+		if interval > 0 {
+			o.interval = interval
+		}
+	}
+}
+
+// WithLogger sets the logger of the rejected requests and of the reload failures.
+// Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithErrorHandler writes the rejections with the response.ErrorHandler.
+// Defaults to response.WriteError, the errs envelope with the status of the error.
+func WithErrorHandler(handler response.ErrorHandler) Option {
+	return func(o *options) {
+		if handler != nil {
+			o.errorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+				handler.ErrorCtx(r.Context(), w, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package ipfilter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// ListSource holds the lists reloaded by a Filter.
+type ListSource interface {
+	// Load returns the current lists, and false when the source has none.
+	Load(ctx context.Context) (Lists, bool, error)
+}
+
+// ConfigSource reads the lists from the configuration files again, so an edit of the
+// allow and deny lists applies without a restart.
+type ConfigSource struct {
+	path    string
+	options []config.Option
+}
+
+// NewConfigSource creates a ConfigSource reading the Config under path, e.g. "app.ipfilter",
+// with the config options, e.g. the ones supplied with config.SupplyOptions.
+func NewConfigSource(path string, options ...config.Option) *ConfigSource {
+	return &ConfigSource{path: path, options: options}
+}
+
+// Load implements ListSource.
+func (s *ConfigSource) Load(context.Context) (Lists, bool, error) {
+	cfg, err := config.New[Config](append(slices.Clone(s.options), config.WithPath(s.path))...)
+	if err != nil {
+		return Lists{}, false, err
+	}
+	c := cfg.Get()
+	return c.Lists(), true, nil
+}
+
+// RedisSource reads the lists from a Redis key holding them as JSON, shared by the
+// instances: {"allow": ["10.8.0.0/16"], "deny": []}.
+type RedisSource struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisSource creates a RedisSource on key.
+func NewRedisSource(client *redis.Client, key string) *RedisSource {
+	return &RedisSource{client: client, key: key}
+}
+
+// Load implements ListSource. A missing key has no lists.
+func (s *RedisSource) Load(ctx context.Context) (Lists, bool, error) {
+	var get *goredis.StringCmd
+	err := s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		get = p.Get(ctx, s.key)
+		return nil
+	})
+	if err != nil {
+		return Lists{}, false, err
+	}
+	data, err := get.Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return Lists{}, false, nil
+	}
+	if err != nil {
+		return Lists{}, false, err
+	}
+	var lists Lists
+	if err = json.Unmarshal(data, &lists); err != nil {
+		return Lists{}, false, fmt.Errorf("ipfilter: decode lists: %w", err)
+	}
+	return lists, true, nil
+}
+
+// Save stores lists in the key, applied by the instances at their next reload.
+func (s *RedisSource) Save(ctx context.Context, lists Lists) error {
+	if _, err := lists.compile(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(lists)
+	if err != nil {
+		return fmt.Errorf("ipfilter: encode lists: %w", err)
+	}
+	return s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, s.key, data, 0)
+		return nil
+	})
+}
@@ -2,22 +2,61 @@ package itestkit
 
 // Config holds configuration for test containers.
 type Config struct {
-	PostgresImage  string
-	RedisImage     string
-	MigrationsPath string
-	Database       string
-	User           string
-	Password       string
+	PostgresImage   string
+	RedisImage      string
+	KafkaImage      string
+	RabbitMQImage   string
+	NATSImage       string
+	LocalstackImage string
+	MailhogImage    string
+	MinIOImage      string
+	MigrationsPath  string
+	Database        string
+	User            string
+	Password        string
+	// Reuse keeps the PostgreSQL and Redis containers running after the tests and
+	// reconnects to them on the next run. Enabled by ITESTKIT_REUSE=1.
+	Reuse bool
 }
 
 // DefaultConfig returns sensible defaults.
 func DefaultConfig() Config {
 	return Config{
-		PostgresImage:  "postgres:16-alpine",
-		RedisImage:     "redis:7-alpine",
-		MigrationsPath: "file://migrations",
-		Database:       "itest",
-		User:           "itest",
-		Password:       "itest",
+		PostgresImage:   "postgres:16-alpine",
+		RedisImage:      "redis:7-alpine",
+		KafkaImage:      "apache/kafka:3.8.0",
+		RabbitMQImage:   "rabbitmq:3-management-alpine",
+		NATSImage:       "nats:2.10-alpine",
+		LocalstackImage: "localstack/localstack:3.8",
+		MailhogImage:    "mailhog/mailhog:v1.0.1",
+		MinIOImage:      "minio/minio:RELEASE.2024-10-13T13-34-11Z",
+		MigrationsPath:  "file://migrations",
+		Database:        "itest",
+		User:            "itest",
+		Password:        "itest",
 	}
 }
+
+// withServiceDefaults fills the images of the optional services left empty.
+func (c Config) withServiceDefaults() Config {
+	defaults := DefaultConfig()
+	if c.KafkaImage == "" {
+		c.KafkaImage = defaults.KafkaImage
+	}
+	if c.RabbitMQImage == "" {
+		c.RabbitMQImage = defaults.RabbitMQImage
+	}
+	if c.NATSImage == "" {
+		c.NATSImage = defaults.NATSImage
+	}
+	if c.LocalstackImage == "" {
+		c.LocalstackImage = defaults.LocalstackImage
+	}
+	if c.MailhogImage == "" {
+		c.MailhogImage = defaults.MailhogImage
+	}
+	if c.MinIOImage == "" {
+		c.MinIOImage = defaults.MinIOImage
+	}
+	return c
+}
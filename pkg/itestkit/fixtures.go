@@ -0,0 +1,278 @@
+package itestkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+	"gorm.io/gorm"
+)
+
+// LoadFixtures loads the fixture files in dir into the kit database.
+// Must call StartPostgres() first. See LoadFixturesInto.
+func (k *ITestKit) LoadFixtures(t *testing.T, dir string) {
+	t.Helper()
+	LoadFixturesInto(t, k.db, dir)
+}
+
+// LoadFixturesInto loads the fixture files in dir into db, e.g. a database
+// created by NewTestDatabase.
+//
+// Each YAML file (.yml or .yaml) holds the rows of the table named after the file:
+//
+//	# users.yml
+//	- id: 1
+//	  email: alice@example.com
+//	  external_id: '{{ uuid }}'
+//	  created_at: '{{ ago "24h" }}'
+//
+// The tables are truncated first, then filled parents first following their foreign keys,
+// and their serial sequences are moved past the inserted ids. SQL files (.sql) run afterwards
+// in name order. Every file is a text/template with the functions now, ago, fromNow and uuid;
+// now is the same instant for the whole call.
+func LoadFixturesInto(t *testing.T, db *gorm.DB, dir string) {
+	t.Helper()
+	require.NoError(t, loadFixtures(db, dir, time.Now().UTC()))
+}
+
+// Seed inserts the models into the kit database with GORM, in the given order.
+// Must call StartPostgres() first.
+func (k *ITestKit) Seed(t *testing.T, models ...any) {
+	t.Helper()
+	SeedInto(t, k.db, models...)
+}
+
+// SeedInto inserts the models into db with GORM, in the given order.
+// Models are pointers to structs or slices of structs; generated keys are set on them.
+func SeedInto(t *testing.T, db *gorm.DB, models ...any) {
+	t.Helper()
+	for _, model := range models {
+		require.NoError(t, db.Create(model).Error, "seed %T", model)
+	}
+}
+
+type fixtureTable struct {
+	name string
+	rows []map[string]any
+}
+
+func loadFixtures(db *gorm.DB, dir string, now time.Time) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read fixtures dir: %w", err)
+	}
+
+	funcs := fixtureFuncs(now)
+	tables := map[string]fixtureTable{}
+	var scripts []string
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yml" && ext != ".yaml" && ext != ".sql" {
+			continue
+		}
+
+		content, renderErr := renderFixture(filepath.Join(dir, entry.Name()), funcs)
+		if renderErr != nil {
+			return renderErr
+		}
+
+		if ext == ".sql" {
+			scripts = append(scripts, content)
+			continue
+		}
+
+		table := fixtureTable{name: strings.TrimSuffix(entry.Name(), ext)}
+		if err = yaml.Unmarshal([]byte(content), &table.rows); err != nil {
+			return fmt.Errorf("parse fixture %s: %w", entry.Name(), err)
+		}
+		tables[table.name] = table
+	}
+
+	order, err := insertOrder(db, tables)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if len(order) > 0 {
+			quoted := make([]string, 0, len(order))
+			for _, name := range order {
+				quoted = append(quoted, quoteIdent(name))
+			}
+			truncate := fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(quoted, ", "))
+			if err := tx.Exec(truncate).Error; err != nil {
+				return fmt.Errorf("truncate fixture tables: %w", err)
+			}
+		}
+
+		for _, name := range order {
+			if err := insertFixture(tx, tables[name]); err != nil {
+				return err
+			}
+		}
+
+		for _, script := range scripts {
+			if err := tx.Exec(script).Error; err != nil {
+				return fmt.Errorf("run fixture script: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func fixtureFuncs(now time.Time) template.FuncMap {
+	offset := func(sign time.Duration) func(string) (string, error) {
+		return func(duration string) (string, error) {
+			d, err := time.ParseDuration(duration)
+			if err != nil {
+				return "", err
+			}
+			return now.Add(sign * d).Format(time.RFC3339Nano), nil
+		}
+	}
+
+	return template.FuncMap{
+		"now":     func() string { return now.Format(time.RFC3339Nano) },
+		"ago":     offset(-1),
+		"fromNow": offset(1),
+		"uuid":    func() string { return uuid.NewString() },
+	}
+}
+
+func renderFixture(path string, funcs template.FuncMap) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read fixture: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(funcs).Option("missingkey=error").Parse(string(raw))
+	if err != nil {
+		return "", fmt.Errorf("parse fixture template %s: %w", filepath.Base(path), err)
+	}
+
+	var out bytes.Buffer
+	if err = tmpl.Execute(&out, nil); err != nil {
+		return "", fmt.Errorf("render fixture %s: %w", filepath.Base(path), err)
+	}
+	return out.String(), nil
+}
+
+// insertOrder sorts the tables so that every table comes after the tables it references.
+func insertOrder(db *gorm.DB, tables map[string]fixtureTable) ([]string, error) {
+	type reference struct {
+		Child  string
+		Parent string
+	}
+	var references []reference
+	err := db.Raw(`
+		SELECT conrelid::regclass::text AS child, confrelid::regclass::text AS parent
+		FROM pg_constraint WHERE contype = 'f'
+	`).Scan(&references).Error
+	if err != nil {
+		return nil, fmt.Errorf("list foreign keys: %w", err)
+	}
+
+	parents := map[string][]string{}
+	for _, ref := range references {
+		child, parent := strings.Trim(ref.Child, `"`), strings.Trim(ref.Parent, `"`)
+		_, childLoaded := tables[child]
+		_, parentLoaded := tables[parent]
+		if childLoaded && parentLoaded && child != parent {
+			parents[child] = append(parents[child], parent)
+		}
+	}
+
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	order := make([]string, 0, len(names))
+	state := map[string]int{} // 1 visiting, 2 done
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("fixture tables have a foreign key cycle through %s", name)
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		for _, parent := range parents[name] {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[name] = 2
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func insertFixture(tx *gorm.DB, table fixtureTable) error {
+	if len(table.rows) == 0 {
+		return nil
+	}
+
+	columns := map[string]struct{}{}
+	for _, row := range table.rows {
+		for column, value := range row {
+			columns[column] = struct{}{}
+			// Nested values are stored as JSON, e.g. in json or jsonb columns
+			switch value.(type) {
+			case map[string]any, []any:
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return fmt.Errorf("encode fixture %s.%s: %w", table.name, column, err)
+				}
+				row[column] = string(encoded)
+			}
+		}
+	}
+
+	if err := tx.Table(table.name).Create(table.rows).Error; err != nil {
+		return fmt.Errorf("insert fixture %s: %w", table.name, err)
+	}
+
+	// Explicit ids leave serial sequences behind, so later inserts would collide
+	for column := range columns {
+		var sequence *string
+		if err := tx.Raw("SELECT pg_get_serial_sequence(?, ?)", table.name, column).Scan(&sequence).Error; err != nil {
+			return fmt.Errorf("get sequence of %s.%s: %w", table.name, column, err)
+		}
+		if sequence == nil {
+			continue
+		}
+		setval := fmt.Sprintf("SELECT setval(?, COALESCE((SELECT MAX(%s) FROM %s), 1))",
+			quoteIdent(column), quoteIdent(table.name))
+		if err := tx.Exec(setval, *sequence).Error; err != nil {
+			return fmt.Errorf("reset sequence of %s.%s: %w", table.name, column, err)
+		}
+	}
+	return nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
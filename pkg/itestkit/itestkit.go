@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -27,6 +28,8 @@ import (
 	"github.com/stretchr/testify/require"
 	testcontainers "github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+
+	bricksredis "github.com/cristiano-pacheco/bricks/pkg/redis"
 )
 
 const (
@@ -45,23 +48,52 @@ type ITestKit struct {
 	redis          redis.UniversalClient
 	dsn            string
 	migrateDSN     string
+	pgHost         string
+	pgPort         string
 	pgContainer    testcontainers.Container
 	redisContainer testcontainers.Container
 	pgOnce         *sync.Once
 	redisOnce      *sync.Once
 	migrateOnce    *sync.Once
+	templateOnce   *sync.Once
+	templateErr    error
+	templateDB     string
+	snapshotDB     string
+	adminDB        *gorm.DB
+	createDBMu     sync.Mutex
+	testDBSeq      atomic.Int64
+
+	kafka      service
+	rabbitmq   service
+	nats       service
+	localstack service
+	mailhog    service
+	minio      service
+
+	redisCluster        service
+	redisClusterConfig  *bricksredis.Config
+	redisSentinel       service
+	redisSentinelConfig *bricksredis.Config
 }
 
 // New creates an ITestKit with the given configuration.
+// Setting ITESTKIT_REUSE=1 enables Config.Reuse.
 func New(config Config) *ITestKit {
 	if config.PostgresImage == "" {
 		config = DefaultConfig()
 	}
+	if reuseEnabled() {
+		config.Reuse = true
+	}
+	if config.Reuse {
+		disableReaper()
+	}
 	return &ITestKit{
-		config:      config,
-		pgOnce:      &sync.Once{},
-		redisOnce:   &sync.Once{},
-		migrateOnce: &sync.Once{},
+		config:       config.withServiceDefaults(),
+		pgOnce:       &sync.Once{},
+		redisOnce:    &sync.Once{},
+		migrateOnce:  &sync.Once{},
+		templateOnce: &sync.Once{},
 	}
 }
 
@@ -73,6 +105,8 @@ func (k *ITestKit) StartPostgres() error {
 		ctx := context.Background()
 		c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 			ContainerRequest: testcontainers.ContainerRequest{
+				Name:         k.containerName("postgres", k.config.PostgresImage),
+				Labels:       k.containerLabels(),
 				Image:        k.config.PostgresImage,
 				ExposedPorts: []string{"5432/tcp"},
 				Env: map[string]string{
@@ -84,6 +118,7 @@ func (k *ITestKit) StartPostgres() error {
 					WithOccurrence(postgresReadyLogCount).WithStartupTimeout(containerStartupTimeout),
 			},
 			Started: true,
+			Reuse:   k.config.Reuse,
 		})
 		if err != nil {
 			initErr = fmt.Errorf("start postgres container: %w", err)
@@ -101,17 +136,10 @@ func (k *ITestKit) StartPostgres() error {
 			initErr = fmt.Errorf("get container port: %w", portErr)
 			return
 		}
-		k.dsn = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-			host, port.Port(), k.config.User, k.config.Password, k.config.Database)
-		k.migrateDSN = (&url.URL{
-			Scheme: "postgres",
-			User:   url.UserPassword(k.config.User, k.config.Password),
-			Host:   net.JoinHostPort(host, port.Port()),
-			Path:   "/" + k.config.Database,
-			RawQuery: (&url.Values{
-				"sslmode": []string{"disable"},
-			}).Encode(),
-		}).String()
+		k.pgHost = host
+		k.pgPort = port.Port()
+		k.dsn = k.postgresDSN(k.config.Database)
+		k.migrateDSN = k.migrateURL(k.config.Database)
 
 		for range connectionRetryAttempts {
 			k.db, initErr = gorm.Open(postgres.Open(k.dsn), &gorm.Config{})
@@ -122,6 +150,10 @@ func (k *ITestKit) StartPostgres() error {
 		}
 		if initErr != nil {
 			initErr = fmt.Errorf("connect to database: %w", initErr)
+			return
+		}
+		if k.config.Reuse {
+			initErr = k.resetReusedPostgres()
 		}
 	})
 	return initErr
@@ -135,11 +167,14 @@ func (k *ITestKit) StartRedis() error {
 		ctx := context.Background()
 		c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 			ContainerRequest: testcontainers.ContainerRequest{
+				Name:         k.containerName("redis", k.config.RedisImage),
+				Labels:       k.containerLabels(),
 				Image:        k.config.RedisImage,
 				ExposedPorts: []string{"6379/tcp"},
 				WaitingFor:   wait.ForLog("Ready to accept connections"),
 			},
 			Started: true,
+			Reuse:   k.config.Reuse,
 		})
 		if err != nil {
 			initErr = fmt.Errorf("start redis container: %w", err)
@@ -169,6 +204,12 @@ func (k *ITestKit) StartRedis() error {
 		}
 		if initErr != nil {
 			initErr = fmt.Errorf("connect to redis: %w", initErr)
+			return
+		}
+		if k.config.Reuse {
+			if err = k.redis.FlushAll(ctx).Err(); err != nil {
+				initErr = fmt.Errorf("flush reused redis: %w", err)
+			}
 		}
 	})
 	return initErr
@@ -177,37 +218,58 @@ func (k *ITestKit) StartRedis() error {
 // RunMigrations applies migrations from the configured path.
 // Must call StartPostgres() first.
 func (k *ITestKit) RunMigrations() error {
-	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	var initErr error
 	k.migrateOnce.Do(func() {
-		migrationsPath := k.config.MigrationsPath
-		if strings.HasPrefix(migrationsPath, "file://") {
-			relativePath := strings.TrimPrefix(migrationsPath, "file://")
-			if !filepath.IsAbs(relativePath) {
-				absolutePath := filepath.Join(getProjectRoot(), relativePath)
-				migrationsPath = "file://" + absolutePath
-			}
+		initErr = k.migrate(k.migrateDSN)
+	})
+	return initErr
+}
+
+func (k *ITestKit) migrate(databaseURL string) error {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	migrationsPath := k.config.MigrationsPath
+	if strings.HasPrefix(migrationsPath, "file://") {
+		relativePath := strings.TrimPrefix(migrationsPath, "file://")
+		if !filepath.IsAbs(relativePath) {
+			absolutePath := filepath.Join(getProjectRoot(), relativePath)
+			migrationsPath = "file://" + absolutePath
 		}
+	}
 
-		m, err := migrate.New(migrationsPath, k.migrateDSN)
-		if err != nil {
-			initErr = fmt.Errorf("create migrate instance: %w", err)
-			return
+	m, err := migrate.New(migrationsPath, databaseURL)
+	if err != nil {
+		return fmt.Errorf("create migrate instance: %w", err)
+	}
+	defer func() {
+		srcErr, dbErr := m.Close()
+		if srcErr != nil {
+			logger.Warn("[itestkit] close migration source", "error", srcErr)
 		}
-		defer func() {
-			srcErr, dbErr := m.Close()
-			if srcErr != nil {
-				logger.Warn("[itestkit] close migration source", "error", srcErr)
-			}
-			if dbErr != nil {
-				logger.Warn("[itestkit] close migration database", "error", dbErr)
-			}
-		}()
-		if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-			initErr = fmt.Errorf("run migrations: %w", err)
+		if dbErr != nil {
+			logger.Warn("[itestkit] close migration database", "error", dbErr)
 		}
-	})
-	return initErr
+	}()
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+	return nil
+}
+
+func (k *ITestKit) postgresDSN(database string) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		k.pgHost, k.pgPort, k.config.User, k.config.Password, database)
+}
+
+func (k *ITestKit) migrateURL(database string) string {
+	return (&url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(k.config.User, k.config.Password),
+		Host:   net.JoinHostPort(k.pgHost, k.pgPort),
+		Path:   "/" + database,
+		RawQuery: (&url.Values{
+			"sslmode": []string{"disable"},
+		}).Encode(),
+	}).String()
 }
 
 func getProjectRoot() string {
@@ -235,38 +297,46 @@ func getProjectRoot() string {
 // Must call StartPostgres() first.
 func (k *ITestKit) TruncateTables(t *testing.T) {
 	t.Helper()
-	rows, err := k.db.Raw(`
-		SELECT table_name FROM information_schema.tables
-		WHERE table_schema = 'public' AND table_type = 'BASE TABLE' AND table_name != 'schema_migrations'
-	`).Rows()
-	require.NoError(t, err)
-	defer rows.Close()
+	require.NoError(t, k.truncateTables())
+}
 
+func (k *ITestKit) truncateTables() error {
 	var tables []string
-	for rows.Next() {
-		var name string
-		require.NoError(t, rows.Scan(&name))
-		tables = append(tables, name)
+	err := k.db.Raw(`
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE' AND table_name != 'schema_migrations'
+	`).Scan(&tables).Error
+	if err != nil {
+		return fmt.Errorf("list tables: %w", err)
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	for i, table := range tables {
+		tables[i] = quoteIdent(table)
 	}
-	if len(tables) > 0 {
-		require.NoError(
-			t,
-			k.db.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))).Error,
-		)
+	truncate := fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))
+	if err = k.db.Exec(truncate).Error; err != nil {
+		return fmt.Errorf("truncate tables: %w", err)
 	}
+	return nil
 }
 
 // StopPostgres stops the PostgreSQL container.
+// With Config.Reuse only the connection is closed and the container keeps running.
 func (k *ITestKit) StopPostgres() {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
-	if k.db != nil {
-		if sqlDB, _ := k.db.DB(); sqlDB != nil {
+	for _, db := range []*gorm.DB{k.db, k.adminDB} {
+		if db == nil {
+			continue
+		}
+		if sqlDB, _ := db.DB(); sqlDB != nil {
 			if err := sqlDB.Close(); err != nil {
 				logger.Warn("[itestkit] close postgres sql db", "error", err)
 			}
 		}
 	}
-	if k.pgContainer != nil {
+	if k.pgContainer != nil && !k.config.Reuse {
 		if err := testcontainers.TerminateContainer(k.pgContainer); err != nil {
 			logger.Warn("[itestkit] terminate postgres container", "error", err)
 		}
@@ -274,6 +344,7 @@ func (k *ITestKit) StopPostgres() {
 }
 
 // StopRedis stops the Redis container.
+// With Config.Reuse only the client is closed and the container keeps running.
 func (k *ITestKit) StopRedis() {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	if k.redis != nil {
@@ -281,7 +352,7 @@ func (k *ITestKit) StopRedis() {
 			logger.Warn("[itestkit] close redis client", "error", err)
 		}
 	}
-	if k.redisContainer != nil {
+	if k.redisContainer != nil && !k.config.Reuse {
 		if err := testcontainers.TerminateContainer(k.redisContainer); err != nil {
 			logger.Warn("[itestkit] terminate redis container", "error", err)
 		}
@@ -289,17 +360,20 @@ func (k *ITestKit) StopRedis() {
 }
 
 // Cleanup stops all containers and cleans up resources.
-// This is a convenience method that calls StopPostgres and StopRedis.
+// This is a convenience method that calls every Stop method.
 func (k *ITestKit) Cleanup() {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
-	if k.db != nil {
-		if sqlDB, _ := k.db.DB(); sqlDB != nil {
+	for _, db := range []*gorm.DB{k.db, k.adminDB} {
+		if db == nil {
+			continue
+		}
+		if sqlDB, _ := db.DB(); sqlDB != nil {
 			if err := sqlDB.Close(); err != nil {
 				logger.Warn("[itestkit] close postgres sql db", "error", err)
 			}
 		}
 	}
-	if k.pgContainer != nil {
+	if k.pgContainer != nil && !k.config.Reuse {
 		if err := testcontainers.TerminateContainer(k.pgContainer); err != nil {
 			logger.Warn("[itestkit] terminate postgres container", "error", err)
 		}
@@ -309,16 +383,25 @@ func (k *ITestKit) Cleanup() {
 			logger.Warn("[itestkit] close redis client", "error", err)
 		}
 	}
-	if k.redisContainer != nil {
+	if k.redisContainer != nil && !k.config.Reuse {
 		if err := testcontainers.TerminateContainer(k.redisContainer); err != nil {
 			logger.Warn("[itestkit] terminate redis container", "error", err)
 		}
 	}
+	k.StopKafka()
+	k.StopRabbitMQ()
+	k.StopNATS()
+	k.StopLocalstack()
+	k.StopMailhog()
+	k.StopMinIO()
+	k.StopRedisCluster()
+	k.StopRedisSentinel()
 }
 
 // CleanupAll removes all testcontainers Docker containers.
 // This should be called in TestMain as a safety net to ensure no orphaned containers remain.
-// It finds and removes any containers created by the testcontainers library.
+// It finds and removes any containers created by the testcontainers library,
+// except the reused ones when ITESTKIT_REUSE=1.
 func CleanupAll() {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	logger.Info("[itestkit] Running cleanup for orphaned Docker containers...")
@@ -340,6 +423,13 @@ func CleanupAll() {
 	}
 
 	ids := strings.Fields(containerIDs)
+	if reuseEnabled() {
+		ids = withoutReusedContainers(ctx, ids)
+		if len(ids) == 0 {
+			logger.Info("[itestkit] Only reused testcontainers found, cleanup complete")
+			return
+		}
+	}
 
 	// Stop containers
 	logger.Info("[itestkit] Stopping testcontainers", "count", len(ids))
@@ -395,6 +485,7 @@ func TestMain(m *testing.M) {
 
 	// Run cleanup BEFORE calling os.Exit
 	logger.Info("[itestkit] Running cleanup for testcontainers...")
+	stopShared()
 	CleanupAll()
 
 	os.Exit(code)
@@ -0,0 +1,83 @@
+package itestkit
+
+import (
+	"context"
+	"fmt"
+
+	testcontainers "github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	kafkaPort         = "9092/tcp"
+	kafkaStarterPath  = "/tmp/itestkit_kafka_start.sh"
+	kafkaStarterMode  = 0o755
+	kafkaStartCommand = "/etc/kafka/docker/run"
+)
+
+// StartKafka starts a single node Kafka broker in KRaft mode.
+// Returns error if container fails to start.
+func (k *ITestKit) StartKafka() error {
+	return k.kafka.start("kafka", testcontainers.ContainerRequest{
+		Image:        k.config.KafkaImage,
+		ExposedPorts: []string{kafkaPort},
+		Env: map[string]string{
+			"KAFKA_NODE_ID":                                  "1",
+			"KAFKA_PROCESS_ROLES":                            "broker,controller",
+			"KAFKA_LISTENERS":                                "PLAINTEXT://0.0.0.0:9092,BROKER://0.0.0.0:9093,CONTROLLER://0.0.0.0:9094",
+			"KAFKA_LISTENER_SECURITY_PROTOCOL_MAP":           "PLAINTEXT:PLAINTEXT,BROKER:PLAINTEXT,CONTROLLER:PLAINTEXT",
+			"KAFKA_INTER_BROKER_LISTENER_NAME":               "BROKER",
+			"KAFKA_CONTROLLER_LISTENER_NAMES":                "CONTROLLER",
+			"KAFKA_CONTROLLER_QUORUM_VOTERS":                 "1@localhost:9094",
+			"KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR":         "1",
+			"KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR": "1",
+			"KAFKA_TRANSACTION_STATE_LOG_MIN_ISR":            "1",
+			"KAFKA_GROUP_INITIAL_REBALANCE_DELAY_MS":         "0",
+			"KAFKA_AUTO_CREATE_TOPICS_ENABLE":                "true",
+		},
+		// The advertised listener needs the mapped port, which is only known once the
+		// container runs, so the broker starts when the starter script is copied in.
+		Entrypoint: []string{"sh", "-c"},
+		Cmd: []string{
+			fmt.Sprintf("while [ ! -f %[1]s ]; do sleep 0.1; done; %[1]s", kafkaStarterPath),
+		},
+		LifecycleHooks: []testcontainers.ContainerLifecycleHooks{{
+			PostStarts: []testcontainers.ContainerHook{copyKafkaStarter},
+		}},
+		WaitingFor: wait.ForLog("Kafka Server started").WithStartupTimeout(containerStartupTimeout),
+	})
+}
+
+func copyKafkaStarter(ctx context.Context, c testcontainers.Container) error {
+	host, err := c.Host(ctx)
+	if err != nil {
+		return fmt.Errorf("get kafka host: %w", err)
+	}
+	port, err := c.MappedPort(ctx, kafkaPort)
+	if err != nil {
+		return fmt.Errorf("get kafka port: %w", err)
+	}
+
+	script := fmt.Sprintf(
+		"#!/bin/sh\nexport KAFKA_ADVERTISED_LISTENERS=PLAINTEXT://%s:%s,BROKER://localhost:9093\nexec %s\n",
+		host, port.Port(), kafkaStartCommand,
+	)
+	if err := c.CopyToContainer(ctx, []byte(script), kafkaStarterPath, kafkaStarterMode); err != nil {
+		return fmt.Errorf("copy kafka starter: %w", err)
+	}
+	return nil
+}
+
+// KafkaBrokers returns the bootstrap broker addresses reachable from the tests.
+func (k *ITestKit) KafkaBrokers() []string {
+	addr := k.kafka.addr(kafkaPort)
+	if addr == "" {
+		return nil
+	}
+	return []string{addr}
+}
+
+// StopKafka stops the Kafka container.
+func (k *ITestKit) StopKafka() {
+	k.kafka.stop("kafka")
+}
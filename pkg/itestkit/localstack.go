@@ -0,0 +1,139 @@
+package itestkit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	testcontainers "github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	localstackPort   = "4566/tcp"
+	localstackRegion = "us-east-1"
+)
+
+// localstackCredentials are accepted by Localstack, like any others
+var localstackCredentials = credentials.NewStaticCredentialsProvider("test", "test", "")
+
+// StartLocalstack starts Localstack with the given AWS services enabled, e.g. "s3", "sqs".
+// Every service is available when none is given.
+// Returns error if container fails to start.
+func (k *ITestKit) StartLocalstack(services ...string) error {
+	env := map[string]string{
+		"AWS_DEFAULT_REGION": localstackRegion,
+	}
+	if len(services) > 0 {
+		env["SERVICES"] = strings.Join(services, ",")
+	}
+
+	return k.localstack.start("localstack", testcontainers.ContainerRequest{
+		Image:        k.config.LocalstackImage,
+		ExposedPorts: []string{localstackPort},
+		Env:          env,
+		WaitingFor: wait.ForHTTP("/_localstack/health").
+			WithPort(localstackPort).
+			WithStatusCodeMatcher(func(status int) bool { return status == http.StatusOK }).
+			WithStartupTimeout(containerStartupTimeout),
+	})
+}
+
+// LocalstackEndpoint returns the URL to use as the AWS endpoint, e.g. "http://localhost:32768".
+// Any access key is accepted; the region is "us-east-1".
+func (k *ITestKit) LocalstackEndpoint() string {
+	addr := k.localstack.addr(localstackPort)
+	if addr == "" {
+		return ""
+	}
+	return "http://" + addr
+}
+
+// LocalstackRegion returns the AWS region Localstack is configured with.
+func (k *ITestKit) LocalstackRegion() string {
+	return localstackRegion
+}
+
+// CreateLocalstackQueue creates the SQS queue name with the given attributes, e.g.
+// "FifoQueue" or "RedrivePolicy", and returns its URL. A name ending with .fifo needs the
+// "FifoQueue" attribute set to "true".
+func (k *ITestKit) CreateLocalstackQueue(
+	ctx context.Context, name string, attributes map[string]string,
+) (string, error) {
+	output, err := k.localstackSQS().CreateQueue(ctx, &sqs.CreateQueueInput{
+		QueueName:  awssdk.String(name),
+		Attributes: attributes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create localstack queue %s: %w", name, err)
+	}
+	return awssdk.ToString(output.QueueUrl), nil
+}
+
+// CreateLocalstackTopic creates the SNS topic name with the given attributes, e.g.
+// "FifoTopic", and returns its ARN.
+func (k *ITestKit) CreateLocalstackTopic(
+	ctx context.Context, name string, attributes map[string]string,
+) (string, error) {
+	output, err := k.localstackSNS().CreateTopic(ctx, &sns.CreateTopicInput{
+		Name:       awssdk.String(name),
+		Attributes: attributes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create localstack topic %s: %w", name, err)
+	}
+	return awssdk.ToString(output.TopicArn), nil
+}
+
+// SubscribeLocalstackQueue subscribes the queue of queueURL to the SNS topic of topicARN,
+// delivering the messages without the SNS envelope when raw.
+func (k *ITestKit) SubscribeLocalstackQueue(ctx context.Context, topicARN, queueURL string, raw bool) error {
+	queue, err := k.localstackSQS().GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       awssdk.String(queueURL),
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameQueueArn},
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe localstack queue %s: %w", queueURL, err)
+	}
+	_, err = k.localstackSNS().Subscribe(ctx, &sns.SubscribeInput{
+		TopicArn:              awssdk.String(topicARN),
+		Protocol:              awssdk.String("sqs"),
+		Endpoint:              awssdk.String(queue.Attributes[string(sqstypes.QueueAttributeNameQueueArn)]),
+		Attributes:            map[string]string{"RawMessageDelivery": strconv.FormatBool(raw)},
+		ReturnSubscriptionArn: true,
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe localstack queue %s: %w", queueURL, err)
+	}
+	return nil
+}
+
+// localstackSQS returns an SQS client of Localstack.
+func (k *ITestKit) localstackSQS() *sqs.Client {
+	return sqs.New(sqs.Options{
+		Region:       localstackRegion,
+		BaseEndpoint: awssdk.String(k.LocalstackEndpoint()),
+		Credentials:  localstackCredentials,
+	})
+}
+
+// localstackSNS returns an SNS client of Localstack.
+func (k *ITestKit) localstackSNS() *sns.Client {
+	return sns.New(sns.Options{
+		Region:       localstackRegion,
+		BaseEndpoint: awssdk.String(k.LocalstackEndpoint()),
+		Credentials:  localstackCredentials,
+	})
+}
+
+// StopLocalstack stops the Localstack container.
+func (k *ITestKit) StopLocalstack() {
+	k.localstack.stop("localstack")
+}
@@ -0,0 +1,130 @@
+package itestkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	testcontainers "github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	mailhogSMTPPort = "1025/tcp"
+	mailhogHTTPPort = "8025/tcp"
+)
+
+// MailhogMessage is an email captured by Mailhog.
+type MailhogMessage struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+type mailhogAddress struct {
+	Mailbox string `json:"Mailbox"`
+	Domain  string `json:"Domain"`
+}
+
+func (a mailhogAddress) String() string {
+	return a.Mailbox + "@" + a.Domain
+}
+
+type mailhogMessages struct {
+	Items []struct {
+		From    mailhogAddress   `json:"From"`
+		To      []mailhogAddress `json:"To"`
+		Content struct {
+			Headers map[string][]string `json:"Headers"`
+			Body    string              `json:"Body"`
+		} `json:"Content"`
+	} `json:"items"`
+}
+
+// StartMailhog starts a Mailhog SMTP server that captures every email sent to it.
+// Returns error if container fails to start.
+func (k *ITestKit) StartMailhog() error {
+	return k.mailhog.start("mailhog", testcontainers.ContainerRequest{
+		Image:        k.config.MailhogImage,
+		ExposedPorts: []string{mailhogSMTPPort, mailhogHTTPPort},
+		WaitingFor: wait.ForAll(
+			wait.ForListeningPort(mailhogSMTPPort),
+			wait.ForHTTP("/api/v2/messages").WithPort(mailhogHTTPPort),
+		).WithDeadline(containerStartupTimeout),
+	})
+}
+
+// MailhogSMTPAddr returns the SMTP address, e.g. "localhost:32768".
+func (k *ITestKit) MailhogSMTPAddr() string {
+	return k.mailhog.addr(mailhogSMTPPort)
+}
+
+// MailhogAPIURL returns the base URL of the Mailhog HTTP API.
+func (k *ITestKit) MailhogAPIURL() string {
+	addr := k.mailhog.addr(mailhogHTTPPort)
+	if addr == "" {
+		return ""
+	}
+	return "http://" + addr
+}
+
+// MailhogMessages returns the captured emails, newest first.
+func (k *ITestKit) MailhogMessages(ctx context.Context) ([]MailhogMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.MailhogAPIURL()+"/api/v2/messages", nil)
+	if err != nil {
+		return nil, fmt.Errorf("create mailhog request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list mailhog messages: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("list mailhog messages: unexpected status %d", resp.StatusCode)
+	}
+
+	var payload mailhogMessages
+	if err = json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode mailhog messages: %w", err)
+	}
+
+	messages := make([]MailhogMessage, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		message := MailhogMessage{
+			From: item.From.String(),
+			Body: item.Content.Body,
+		}
+		for _, to := range item.To {
+			message.To = append(message.To, to.String())
+		}
+		if subject := item.Content.Headers["Subject"]; len(subject) > 0 {
+			message.Subject = subject[0]
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// DeleteMailhogMessages removes every captured email, e.g. between tests.
+func (k *ITestKit) DeleteMailhogMessages(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, k.MailhogAPIURL()+"/api/v1/messages", nil)
+	if err != nil {
+		return fmt.Errorf("create mailhog request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete mailhog messages: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("delete mailhog messages: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// StopMailhog stops the Mailhog container.
+func (k *ITestKit) StopMailhog() {
+	k.mailhog.stop("mailhog")
+}
@@ -0,0 +1,89 @@
+package itestkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	testcontainers "github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	minioPort      = "9000/tcp"
+	minioAccessKey = "minioadmin"
+	minioSecretKey = "minioadmin"
+	minioRegion    = "us-east-1"
+)
+
+// StartMinIO starts a MinIO S3 compatible server and creates the given buckets.
+// Returns error if container fails to start or a bucket cannot be created.
+func (k *ITestKit) StartMinIO(buckets ...string) error {
+	err := k.minio.start("minio", testcontainers.ContainerRequest{
+		Image:        k.config.MinIOImage,
+		ExposedPorts: []string{minioPort},
+		Cmd:          []string{"server", "/data"},
+		Env: map[string]string{
+			"MINIO_ROOT_USER":     minioAccessKey,
+			"MINIO_ROOT_PASSWORD": minioSecretKey,
+		},
+		WaitingFor: wait.ForHTTP("/minio/health/live").
+			WithPort(minioPort).
+			WithStartupTimeout(containerStartupTimeout),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range buckets {
+		if err = k.CreateMinIOBucket(context.Background(), bucket); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateMinIOBucket creates a bucket; an existing bucket is not an error.
+func (k *ITestKit) CreateMinIOBucket(ctx context.Context, bucket string) error {
+	client := s3.New(s3.Options{
+		Region:       minioRegion,
+		BaseEndpoint: awssdk.String(k.MinIOEndpoint()),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider(minioAccessKey, minioSecretKey, ""),
+	})
+	_, err := client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: awssdk.String(bucket)})
+	var owned *s3types.BucketAlreadyOwnedByYou
+	if err != nil && !errors.As(err, &owned) {
+		return fmt.Errorf("create minio bucket %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// MinIOEndpoint returns the URL to use as the S3 endpoint, with path style addressing,
+// e.g. "http://localhost:32768".
+func (k *ITestKit) MinIOEndpoint() string {
+	addr := k.minio.addr(minioPort)
+	if addr == "" {
+		return ""
+	}
+	return "http://" + addr
+}
+
+// MinIOCredentials returns the access key and the secret key of the MinIO root user.
+func (k *ITestKit) MinIOCredentials() (string, string) {
+	return minioAccessKey, minioSecretKey
+}
+
+// MinIORegion returns the region MinIO accepts in the signatures.
+func (k *ITestKit) MinIORegion() string {
+	return minioRegion
+}
+
+// StopMinIO stops the MinIO container.
+func (k *ITestKit) StopMinIO() {
+	k.minio.stop("minio")
+}
@@ -0,0 +1,36 @@
+package itestkit
+
+import (
+	testcontainers "github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const natsPort = "4222/tcp"
+
+// StartNATS starts a NATS server with JetStream enabled.
+// Returns error if container fails to start.
+func (k *ITestKit) StartNATS() error {
+	return k.nats.start("nats", testcontainers.ContainerRequest{
+		Image:        k.config.NATSImage,
+		ExposedPorts: []string{natsPort},
+		Cmd:          []string{"--jetstream"},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("Server is ready"),
+			wait.ForListeningPort(natsPort),
+		).WithDeadline(containerStartupTimeout),
+	})
+}
+
+// NATSURL returns the client URL, e.g. "nats://localhost:32768".
+func (k *ITestKit) NATSURL() string {
+	addr := k.nats.addr(natsPort)
+	if addr == "" {
+		return ""
+	}
+	return "nats://" + addr
+}
+
+// StopNATS stops the NATS container.
+func (k *ITestKit) StopNATS() {
+	k.nats.stop("nats")
+}
@@ -0,0 +1,160 @@
+package itestkit
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	testcontainers "github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	bricksredis "github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+const (
+	minRedisClusterNodes = 3
+	redisSentinelMaster  = "mymaster"
+	redisTopologyReady   = "itestkit redis topology ready"
+	// redisAnnounceIP is announced by the nodes to each other and to clients.
+	// It is reachable from every node since they share the container, and from
+	// the tests since each port is published on the same host port.
+	redisAnnounceIP = "127.0.0.1"
+)
+
+// StartRedisCluster starts a Redis cluster with the given number of master nodes (at least 3)
+// and returns a config ready for redis.NewClient. Later calls return the first config.
+// The nodes run in a single container and announce 127.0.0.1, so Docker must run locally.
+// Returns error if container fails to start.
+func (k *ITestKit) StartRedisCluster(nodes int) (bricksredis.Config, error) {
+	if k.redisClusterConfig != nil {
+		return *k.redisClusterConfig, k.redisCluster.err
+	}
+	if nodes < minRedisClusterNodes {
+		return bricksredis.Config{}, fmt.Errorf("redis cluster needs at least %d nodes, got %d",
+			minRedisClusterNodes, nodes)
+	}
+
+	// Bus ports are only used inside the container, but must not clash with the client ports
+	ports, err := freePorts(nodes * 2)
+	if err != nil {
+		return bricksredis.Config{}, err
+	}
+	clientPorts, busPorts := ports[:nodes], ports[nodes:]
+
+	var script strings.Builder
+	addrs := make([]string, 0, nodes)
+	for i, port := range clientPorts {
+		fmt.Fprintf(&script, "redis-server --port %d --cluster-enabled yes --cluster-port %d "+
+			"--cluster-config-file /tmp/nodes-%d.conf --cluster-announce-ip %s --cluster-node-timeout 5000 "+
+			"--save '' --appendonly no --protected-mode no --daemonize yes\n",
+			port, busPorts[i], port, redisAnnounceIP)
+		fmt.Fprintf(&script, "until redis-cli -p %d ping; do sleep 0.1; done\n", port)
+		addrs = append(addrs, net.JoinHostPort(redisAnnounceIP, strconv.Itoa(port)))
+	}
+	fmt.Fprintf(&script, "redis-cli --cluster create %s --cluster-replicas 0 --cluster-yes\n", strings.Join(addrs, " "))
+	fmt.Fprintf(&script, "until redis-cli -p %d cluster info | grep -q cluster_state:ok; do sleep 0.1; done\n",
+		clientPorts[0])
+
+	k.redisClusterConfig = &bricksredis.Config{
+		Type:         bricksredis.ClientTypeCluster,
+		ClusterAddrs: addrs,
+	}
+	err = k.redisCluster.start("redis cluster", k.redisTopologyRequest(script.String(), clientPorts))
+	return *k.redisClusterConfig, err
+}
+
+// StopRedisCluster stops the Redis cluster container.
+func (k *ITestKit) StopRedisCluster() {
+	k.redisCluster.stop("redis cluster")
+}
+
+// StartRedisSentinel starts a Redis master with one replica monitored by a sentinel
+// as "mymaster", and returns a config ready for redis.NewClient. Later calls return the first config.
+// The processes run in a single container and announce 127.0.0.1, so Docker must run locally.
+// Returns error if container fails to start.
+func (k *ITestKit) StartRedisSentinel() (bricksredis.Config, error) {
+	if k.redisSentinelConfig != nil {
+		return *k.redisSentinelConfig, k.redisSentinel.err
+	}
+	ports, err := freePorts(3)
+	if err != nil {
+		return bricksredis.Config{}, err
+	}
+	masterPort, replicaPort, sentinelPort := ports[0], ports[1], ports[2]
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "redis-server --port %d --save '' --appendonly no --protected-mode no --daemonize yes\n",
+		masterPort)
+	fmt.Fprintf(&script, "redis-server --port %d --replicaof %s %d --replica-announce-ip %s "+
+		"--save '' --appendonly no --protected-mode no --daemonize yes\n",
+		replicaPort, redisAnnounceIP, masterPort, redisAnnounceIP)
+	fmt.Fprintf(&script, "printf 'port %d\\nsentinel announce-ip %s\\nsentinel monitor %s %s %d 1\\n"+
+		"sentinel down-after-milliseconds %s 5000\\nsentinel failover-timeout %s 10000\\n' > /tmp/sentinel.conf\n",
+		sentinelPort, redisAnnounceIP, redisSentinelMaster, redisAnnounceIP, masterPort,
+		redisSentinelMaster, redisSentinelMaster)
+	script.WriteString("redis-sentinel /tmp/sentinel.conf --daemonize yes\n")
+	fmt.Fprintf(&script, "until redis-cli -p %d info replication | grep -q connected_slaves:1; do sleep 0.1; done\n",
+		masterPort)
+	fmt.Fprintf(&script, "until redis-cli -p %d sentinel replicas %s | grep -q %d; do sleep 0.1; done\n",
+		sentinelPort, redisSentinelMaster, replicaPort)
+
+	k.redisSentinelConfig = &bricksredis.Config{
+		Type:          bricksredis.ClientTypeSentinel,
+		SentinelAddrs: []string{net.JoinHostPort(redisAnnounceIP, strconv.Itoa(sentinelPort))},
+		MasterName:    redisSentinelMaster,
+	}
+	err = k.redisSentinel.start("redis sentinel", k.redisTopologyRequest(script.String(), ports))
+	return *k.redisSentinelConfig, err
+}
+
+// StopRedisSentinel stops the Redis sentinel container.
+func (k *ITestKit) StopRedisSentinel() {
+	k.redisSentinel.stop("redis sentinel")
+}
+
+// redisTopologyRequest runs script in a Redis container, publishing each port on the same host port.
+func (k *ITestKit) redisTopologyRequest(script string, ports []int) testcontainers.ContainerRequest {
+	exposed := make([]string, 0, len(ports))
+	bindings := network.PortMap{}
+	for _, port := range ports {
+		spec := fmt.Sprintf("%d/tcp", port)
+		exposed = append(exposed, spec)
+		bindings[network.MustParsePort(spec)] = []network.PortBinding{{HostPort: strconv.Itoa(port)}}
+	}
+
+	return testcontainers.ContainerRequest{
+		Image:        k.config.RedisImage,
+		ExposedPorts: exposed,
+		Entrypoint:   []string{"sh", "-c"},
+		Cmd:          []string{fmt.Sprintf("set -e\n%secho %q\nexec tail -f /dev/null", script, redisTopologyReady)},
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.PortBindings = bindings
+		},
+		WaitingFor: wait.ForLog(redisTopologyReady).WithStartupTimeout(containerStartupTimeout),
+	}
+}
+
+// freePorts returns n distinct ports that are free on the host.
+func freePorts(n int) ([]int, error) {
+	listeners := make([]net.Listener, 0, n)
+	defer func() {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+	}()
+
+	ports := make([]int, 0, n)
+	for range n {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, fmt.Errorf("find free port: %w", err)
+		}
+		listeners = append(listeners, l)
+		addr, _ := l.Addr().(*net.TCPAddr)
+		ports = append(ports, addr.Port)
+	}
+	return ports, nil
+}
@@ -0,0 +1,91 @@
+package itestkit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+const (
+	reuseEnv       = "ITESTKIT_REUSE"
+	reuseLabel     = "itestkit.reuse"
+	ryukDisableEnv = "TESTCONTAINERS_RYUK_DISABLED"
+	nameHashLength = 12
+)
+
+func reuseEnabled() bool {
+	return os.Getenv(reuseEnv) == "1"
+}
+
+// disableReaper stops Ryuk from removing the reused containers when the test binary exits.
+// It must run before the first container is created, as testcontainers reads it once.
+func disableReaper() {
+	if _, ok := os.LookupEnv(ryukDisableEnv); !ok {
+		_ = os.Setenv(ryukDisableEnv, "true")
+	}
+}
+
+// containerName returns the name a reused container is looked up by.
+// The name depends on the package directory, so test binaries of different packages
+// running at the same time never share, and truncate, the same container.
+func (k *ITestKit) containerName(service, image string) string {
+	if !k.config.Reuse {
+		return ""
+	}
+	dir, _ := os.Getwd()
+	sum := sha256.Sum256([]byte(strings.Join([]string{dir, image, k.config.Database, k.config.User}, "|")))
+	return fmt.Sprintf("itestkit-%s-%s", service, hex.EncodeToString(sum[:])[:nameHashLength])
+}
+
+func (k *ITestKit) containerLabels() map[string]string {
+	if !k.config.Reuse {
+		return nil
+	}
+	return map[string]string{reuseLabel: "true"}
+}
+
+// resetReusedPostgres removes the data left by the previous run: table rows, the snapshot,
+// the template database and the per-test databases of NewTestDatabase.
+func (k *ITestKit) resetReusedPostgres() error {
+	if err := k.truncateTables(); err != nil {
+		return fmt.Errorf("reset reused postgres: %w", err)
+	}
+
+	var databases []string
+	if err := k.db.Raw("SELECT datname FROM pg_database").Scan(&databases).Error; err != nil {
+		return fmt.Errorf("list databases: %w", err)
+	}
+	for _, name := range databases {
+		leftover := name == k.config.Database+templateSuffix ||
+			name == k.config.Database+snapshotSuffix ||
+			strings.HasPrefix(name, k.config.Database+"_test_")
+		if !leftover {
+			continue
+		}
+		if err := k.db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", quoteIdent(name))).Error; err != nil {
+			return fmt.Errorf("drop leftover database %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// withoutReusedContainers filters out the containers labeled for reuse.
+func withoutReusedContainers(ctx context.Context, ids []string) []string {
+	cmd := exec.CommandContext(ctx, "docker", "ps", "-a", "-q", "--no-trunc", "--filter", "label="+reuseLabel+"=true")
+	output, err := cmd.Output()
+	if err != nil {
+		return ids
+	}
+	reused := strings.Fields(string(output))
+
+	return slices.DeleteFunc(ids, func(id string) bool {
+		return slices.ContainsFunc(reused, func(reusedID string) bool {
+			return strings.HasPrefix(reusedID, id)
+		})
+	})
+}
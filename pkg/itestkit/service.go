@@ -0,0 +1,69 @@
+package itestkit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+
+	testcontainers "github.com/testcontainers/testcontainers-go"
+)
+
+// service is an optional container started at most once per kit.
+type service struct {
+	once      sync.Once
+	err       error
+	container testcontainers.Container
+	host      string
+}
+
+// start runs the container request once and remembers its outcome for later calls.
+func (s *service) start(name string, req testcontainers.ContainerRequest) error {
+	s.once.Do(func() {
+		ctx := context.Background()
+		c, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+			ContainerRequest: req,
+			Started:          true,
+		})
+		if c != nil {
+			s.container = c
+		}
+		if err != nil {
+			s.err = fmt.Errorf("start %s container: %w", name, err)
+			return
+		}
+
+		host, err := c.Host(ctx)
+		if err != nil {
+			s.err = fmt.Errorf("get %s host: %w", name, err)
+			return
+		}
+		s.host = host
+	})
+	return s.err
+}
+
+// addr returns the host address mapped to the container port, e.g. "localhost:32768".
+func (s *service) addr(port string) string {
+	if s.container == nil || s.host == "" {
+		return ""
+	}
+	mapped, err := s.container.MappedPort(context.Background(), port)
+	if err != nil {
+		return ""
+	}
+	return net.JoinHostPort(s.host, mapped.Port())
+}
+
+func (s *service) stop(name string) {
+	if s.container == nil {
+		return
+	}
+	if err := testcontainers.TerminateContainer(s.container); err != nil {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+		logger.Warn("[itestkit] terminate "+name+" container", "error", err)
+	}
+	s.container = nil
+}
@@ -0,0 +1,110 @@
+package itestkit
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const templateSuffix = "_template"
+
+var shared struct {
+	once sync.Once
+	kit  *ITestKit
+	err  error
+}
+
+// Shared returns the ITestKit shared by the whole test binary, starting its PostgreSQL
+// container on the first call. Later calls return the same kit and ignore config.
+// Use NewTestDatabase to give each test its own database, so tests using the shared
+// container can run with t.Parallel(). The container is stopped by TestMain.
+func Shared(config Config) (*ITestKit, error) {
+	shared.once.Do(func() {
+		kit := New(config)
+		if err := kit.StartPostgres(); err != nil {
+			shared.err = err
+			return
+		}
+		shared.kit = kit
+	})
+	return shared.kit, shared.err
+}
+
+// NewTestDatabase creates a database for the test, cloned from a template database
+// that has the migrations applied, and drops it when the test finishes.
+// The template is migrated once per kit. Must call StartPostgres() first.
+func (k *ITestKit) NewTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	require.NoError(t, k.prepareTemplate())
+
+	name := fmt.Sprintf("%s_test_%d", k.config.Database, k.testDBSeq.Add(1))
+
+	// CREATE DATABASE fails when another session is cloning the same template
+	k.createDBMu.Lock()
+	err := k.db.Exec(fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", quoteIdent(name), quoteIdent(k.templateDB))).Error
+	k.createDBMu.Unlock()
+	require.NoError(t, err, "create test database")
+
+	var db *gorm.DB
+	for range connectionRetryAttempts {
+		db, err = gorm.Open(postgres.Open(k.postgresDSN(name)), &gorm.Config{})
+		if err == nil {
+			break
+		}
+		time.Sleep(retryDelay)
+	}
+	require.NoError(t, err, "connect to test database")
+
+	t.Cleanup(func() {
+		if sqlDB, _ := db.DB(); sqlDB != nil {
+			if closeErr := sqlDB.Close(); closeErr != nil {
+				t.Logf("[itestkit] close test database %s: %v", name, closeErr)
+			}
+		}
+		drop := fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", quoteIdent(name))
+		if dropErr := k.db.Exec(drop).Error; dropErr != nil {
+			t.Logf("[itestkit] drop test database %s: %v", name, dropErr)
+		}
+	})
+
+	return db
+}
+
+// prepareTemplate creates the template database and applies the migrations to it.
+// No connection is kept open to the template, since PostgreSQL refuses to clone
+// a database that has active sessions.
+func (k *ITestKit) prepareTemplate() error {
+	k.templateOnce.Do(func() {
+		if k.db == nil {
+			k.templateErr = fmt.Errorf("prepare template database: postgres is not started")
+			return
+		}
+
+		name := k.config.Database + templateSuffix
+		if err := k.db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", quoteIdent(name))).Error; err != nil {
+			k.templateErr = fmt.Errorf("drop template database: %w", err)
+			return
+		}
+		if err := k.db.Exec(fmt.Sprintf("CREATE DATABASE %s", quoteIdent(name))).Error; err != nil {
+			k.templateErr = fmt.Errorf("create template database: %w", err)
+			return
+		}
+		if err := k.migrate(k.migrateURL(name)); err != nil {
+			k.templateErr = fmt.Errorf("migrate template database: %w", err)
+			return
+		}
+		k.templateDB = name
+	})
+	return k.templateErr
+}
+
+func stopShared() {
+	if shared.kit != nil {
+		shared.kit.Cleanup()
+	}
+}
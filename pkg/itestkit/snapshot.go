@@ -0,0 +1,104 @@
+package itestkit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	snapshotSuffix      = "_snapshot"
+	maintenanceDatabase = "postgres"
+	// defaultMaxIdleConns is the database/sql default, restored after a snapshot or restore
+	defaultMaxIdleConns = 2
+)
+
+// Snapshot saves the current state of the kit database, e.g. after migrations and
+// expensive fixtures, so Restore can bring it back between tests. A later Snapshot
+// replaces the previous one. The snapshot is dropped when t finishes.
+// Must call StartPostgres() first.
+func (k *ITestKit) Snapshot(t *testing.T) {
+	t.Helper()
+	snapshot := k.config.Database + snapshotSuffix
+
+	require.NoError(t, k.cloneDatabase(k.config.Database, snapshot), "snapshot database")
+	k.snapshotDB = snapshot
+
+	t.Cleanup(func() {
+		k.snapshotDB = ""
+		admin, err := k.maintenanceDB()
+		if err != nil {
+			t.Logf("[itestkit] drop snapshot %s: %v", snapshot, err)
+			return
+		}
+		drop := fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", quoteIdent(snapshot))
+		if err = admin.Exec(drop).Error; err != nil {
+			t.Logf("[itestkit] drop snapshot %s: %v", snapshot, err)
+		}
+	})
+}
+
+// Restore brings the kit database back to the state saved by the last Snapshot.
+// DB() keeps working; it reconnects to the restored database.
+// Open transactions on the kit database are aborted.
+func (k *ITestKit) Restore(t *testing.T) {
+	t.Helper()
+	require.NotEmpty(t, k.snapshotDB, "restore database: no snapshot, call Snapshot first")
+	require.NoError(t, k.cloneDatabase(k.snapshotDB, k.config.Database), "restore database")
+}
+
+// cloneDatabase replaces target with a copy of source. PostgreSQL only clones databases
+// without sessions, so the idle connections of the kit pool are released first and the
+// statements run on a connection to the maintenance database.
+func (k *ITestKit) cloneDatabase(source, target string) error {
+	if k.db == nil {
+		return fmt.Errorf("postgres is not started")
+	}
+	admin, err := k.maintenanceDB()
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := k.db.DB()
+	if err != nil {
+		return fmt.Errorf("get sql db: %w", err)
+	}
+	sqlDB.SetMaxIdleConns(0)
+	defer sqlDB.SetMaxIdleConns(defaultMaxIdleConns)
+
+	if err = admin.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", quoteIdent(target))).Error; err != nil {
+		return fmt.Errorf("drop %s: %w", target, err)
+	}
+	// Sessions on source opened since the pool was drained would make the clone fail
+	terminate := "SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = ? AND pid <> pg_backend_pid()"
+	if err = admin.Exec(terminate, source).Error; err != nil {
+		return fmt.Errorf("terminate sessions on %s: %w", source, err)
+	}
+	create := fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", quoteIdent(target), quoteIdent(source))
+	if err = admin.Exec(create).Error; err != nil {
+		return fmt.Errorf("create %s from %s: %w", target, source, err)
+	}
+	return nil
+}
+
+// maintenanceDB returns a connection to the postgres database, used to create and
+// drop the databases the kit connection points to.
+func (k *ITestKit) maintenanceDB() (*gorm.DB, error) {
+	if k.adminDB != nil {
+		return k.adminDB, nil
+	}
+
+	var err error
+	for range connectionRetryAttempts {
+		k.adminDB, err = gorm.Open(postgres.Open(k.postgresDSN(maintenanceDatabase)), &gorm.Config{})
+		if err == nil {
+			return k.adminDB, nil
+		}
+		time.Sleep(retryDelay)
+	}
+	return nil, fmt.Errorf("connect to maintenance database: %w", err)
+}
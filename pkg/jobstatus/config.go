@@ -0,0 +1,63 @@
+package jobstatus
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	BackendRedis    = "redis"
+	BackendDatabase = "database"
+
+	defaultTTL        = 24 * time.Hour
+	defaultPrefix     = "jobstatus:"
+	defaultTable      = "job_statuses"
+	defaultPath       = "/jobs"
+	defaultRetryAfter = 2 * time.Second
+)
+
+// Config configures the Tracker, its JobStore and its status route.
+type Config struct {
+	// Backend is the store of the jobs: redis or database
+	Backend string `config:"backend"`
+	// TTL is how long a job is kept after its last update
+	TTL time.Duration `config:"ttl"`
+	// Prefix is the key prefix of the redis backend
+	Prefix string `config:"prefix"`
+	// Table is the table of the database backend
+	Table string `config:"table"`
+	// Path is the path of the status route, serving GET {path}/{id}
+	Path string `config:"path"`
+	// RetryAfter is the polling interval suggested to the clients of the unfinished jobs
+	RetryAfter time.Duration `config:"retry_after"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Backend == "" {
+		c.Backend = BackendRedis
+	}
+	if c.TTL <= 0 {
+		c.TTL = defaultTTL
+	}
+	if c.Prefix == "" {
+		c.Prefix = defaultPrefix
+	}
+	if c.Table == "" {
+		c.Table = defaultTable
+	}
+	if c.Path == "" {
+		c.Path = defaultPath
+	}
+	if c.RetryAfter <= 0 {
+		c.RetryAfter = defaultRetryAfter
+	}
+}
+
+// Validate checks the configured backend.
+func (c *Config) Validate() error {
+	if c.Backend != BackendRedis && c.Backend != BackendDatabase {
+		return fmt.Errorf("%w: %s", ErrInvalidBackend, c.Backend)
+	}
+	return nil
+}
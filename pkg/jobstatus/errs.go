@@ -0,0 +1,17 @@
+package jobstatus
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+var (
+	ErrJobNotFound     = errs.New("JOB_NOT_FOUND", "The job was not found", http.StatusNotFound, nil)
+	ErrJobFinished     = errors.New("the job has already finished")
+	ErrInvalidProgress = errors.New("job progress must be between 0 and 100")
+	ErrInvalidBackend  = errors.New("invalid job status backend (must be 'redis' or 'database')")
+	ErrMissingRedis    = errors.New("the redis backend requires a *redis.Client")
+	ErrMissingDatabase = errors.New("the database backend requires a *gorm.DB")
+)
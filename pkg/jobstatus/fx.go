@@ -0,0 +1,83 @@
+package jobstatus
+
+import (
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// Module provides the Tracker with the configured JobStore and mounts the status route on
+// the chi server. It loads the config from "app.jobstatus"; the redis backend requires
+// redis.ClientModule and the database backend database.Module. The job_statuses
+// migration joins the "migration_filesystems" group, and the GormStore is provided to
+// schedule DeleteExpired.
+//
+//	fx.New(
+//	    chi.Module,
+//	    redis.ClientModule,
+//	    jobstatus.Module,
+//	)
+var Module = fx.Module(
+	"jobstatus",
+	config.Provide[Config]("app.jobstatus"),
+	fx.Provide(
+		NewTrackerWithParams,
+		NewGormStoreWithConfig,
+		fx.Annotate(NewStatusRoute, fx.As(new(chi.Route)), fx.ResultTags(`group:"routes"`)),
+		fx.Annotate(Migrations, fx.ResultTags(`group:"migration_filesystems"`)),
+	),
+)
+
+// TrackerParams for dependency injection
+type TrackerParams struct {
+	fx.In
+
+	Config       config.Config[Config]
+	Redis        *redis.Client         `optional:"true"`
+	DB           *gorm.DB              `optional:"true"`
+	Clock        clock.Clock           `optional:"true"`
+	IDs          ident.Generator       `optional:"true"`
+	ErrorHandler response.ErrorHandler `optional:"true"`
+}
+
+// NewTrackerWithParams creates the Tracker with the JobStore of the configured backend.
+func NewTrackerWithParams(params TrackerParams) (*Tracker, error) {
+	cfg := params.Config.Get()
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var store JobStore
+	switch cfg.Backend {
+	case BackendRedis:
+		if params.Redis == nil {
+			return nil, ErrMissingRedis
+		}
+		store = NewRedisStore(params.Redis, cfg.Prefix)
+	case BackendDatabase:
+		if params.DB == nil {
+			return nil, ErrMissingDatabase
+		}
+		store = NewGormStore(params.DB, cfg.Table)
+	}
+	return NewTracker(store, cfg,
+		WithClock(params.Clock),
+		WithIDGenerator(params.IDs),
+		WithErrorHandler(params.ErrorHandler),
+	), nil
+}
+
+// NewGormStoreWithConfig creates the GormStore of the configured table, to delete the
+// expired jobs of the database backend.
+func NewGormStoreWithConfig(db *gorm.DB, cfg config.Config[Config]) *GormStore {
+	jobConfig := cfg.Get()
+	jobConfig.SetDefaults()
+	return NewGormStore(db, jobConfig.Table)
+}
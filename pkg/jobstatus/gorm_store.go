@@ -0,0 +1,105 @@
+package jobstatus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+// Record is the GORM model of a Job in the job_statuses table (see Migrations).
+type Record struct {
+	ID        string          `gorm:"primaryKey"`
+	Type      string          `gorm:"not null"`
+	State     string          `gorm:"not null"`
+	Progress  int             `gorm:"not null"`
+	Message   string          `gorm:"not null"`
+	Result    json.RawMessage `gorm:"type:jsonb"`
+	Error     string          `gorm:"not null"`
+	TenantID  string          `gorm:"not null"`
+	OwnerID   string          `gorm:"not null"`
+	CreatedAt time.Time       `gorm:"not null"`
+	UpdatedAt time.Time       `gorm:"not null"`
+	ExpiresAt time.Time       `gorm:"not null"`
+}
+
+// GormStore is the JobStore keeping the jobs in the database. The expired jobs are not
+// served by the Tracker, and removed by DeleteExpired.
+type GormStore struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormStore creates a GormStore keeping the jobs in table.
+func NewGormStore(db *gorm.DB, table string) *GormStore {
+	return &GormStore{db: db, table: table}
+}
+
+// Save implements JobStore. The job joins the transaction of the context, so a job created
+// with the change that enqueues it is committed, or rolled back, with it. The expiration
+// is the ExpiresAt of the job, the ttl is not used.
+func (s *GormStore) Save(ctx context.Context, job Job, _ time.Duration) error {
+	record := Record{
+		ID:        job.ID,
+		Type:      job.Type,
+		State:     string(job.State),
+		Progress:  job.Progress,
+		Message:   job.Message,
+		Result:    job.Result,
+		Error:     job.Error,
+		TenantID:  job.TenantID,
+		OwnerID:   job.OwnerID,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+		ExpiresAt: job.ExpiresAt,
+	}
+	err := database.DB(ctx, s.db).Table(s.table).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&record).Error
+	if err != nil {
+		return fmt.Errorf("jobstatus: save job: %w", err)
+	}
+	return nil
+}
+
+// Get implements JobStore.
+func (s *GormStore) Get(ctx context.Context, id string) (Job, bool, error) {
+	var record Record
+	err := database.DB(ctx, s.db).Table(s.table).Where("id = ?", id).Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Job{}, false, nil
+	}
+	if err != nil {
+		return Job{}, false, fmt.Errorf("jobstatus: get job: %w", err)
+	}
+	return Job{
+		ID:        record.ID,
+		Type:      record.Type,
+		State:     State(record.State),
+		Progress:  record.Progress,
+		Message:   record.Message,
+		Result:    record.Result,
+		Error:     record.Error,
+		TenantID:  record.TenantID,
+		OwnerID:   record.OwnerID,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		ExpiresAt: record.ExpiresAt,
+	}, true, nil
+}
+
+// DeleteExpired deletes the jobs expired at now, returning how many were deleted.
+// Schedule it, e.g. hourly with the scheduler package.
+func (s *GormStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := database.DB(ctx, s.db).Table(s.table).Where("expires_at <= ?", now).Delete(&Record{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("jobstatus: delete expired jobs: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,79 @@
+package jobstatus
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	chirequest "github.com/cristiano-pacheco/bricks/pkg/http/server/chi/request"
+)
+
+// Accepted answers 202 Accepted for job, with the status route in the Location header
+// and the job in the {"data": ...} envelope, for the endpoints starting the job.
+func (t *Tracker) Accepted(w http.ResponseWriter, job Job) error {
+	return response.JSON(w, http.StatusAccepted, job, http.Header{
+		"Location":    {t.StatusURL(job.ID)},
+		"Retry-After": {t.retryAfter()},
+	})
+}
+
+// Handler serves the status of the job of the {id} route parameter. The unfinished
+// jobs come with a Retry-After header suggesting when to poll again. A job of another
+// tenant or user answers 404, like an unknown job.
+func (t *Tracker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		job, err := t.Get(r.Context(), chirequest.Param(r, "id"))
+		if err == nil && !t.visible(r, job) {
+			err = ErrJobNotFound
+		}
+		if err != nil {
+			t.options.errorHandler(w, r, err)
+			return
+		}
+
+		headers := http.Header{"Cache-Control": {"no-store"}}
+		if !job.State.Finished() {
+			headers.Set("Retry-After", t.retryAfter())
+		}
+		_ = response.JSON(w, http.StatusOK, job, headers)
+	})
+}
+
+func (t *Tracker) visible(r *http.Request, job Job) bool {
+	tenantID, _ := ctxmeta.TenantID(r.Context())
+	userID, _ := ctxmeta.UserID(r.Context())
+	return job.TenantID == tenantID && job.OwnerID == userID
+}
+
+// retryAfter returns the RetryAfter of the config in whole seconds, at least one.
+func (t *Tracker) retryAfter() string {
+	return strconv.Itoa(int(max(t.cfg.RetryAfter.Round(time.Second), time.Second) / time.Second))
+}
+
+// StatusRoute mounts the status handler on GET {path}/{id}.
+type StatusRoute struct {
+	tracker *Tracker
+}
+
+// NewStatusRoute creates the StatusRoute of tracker.
+func NewStatusRoute(tracker *Tracker) *StatusRoute {
+	return &StatusRoute{tracker: tracker}
+}
+
+// Setup implements chi.Route.
+func (r *StatusRoute) Setup(server *chi.Server) {
+	server.Handle(http.MethodGet, r.tracker.cfg.Path+"/{id}", r.tracker.Handler().ServeHTTP, chi.Operation{
+		OperationID: "getJobStatus",
+		Summary:     "Get the status of an asynchronous job",
+		Tags:        []string{"jobs"},
+		Request:     statusRequest{},
+		Responses:   map[int]any{http.StatusOK: Job{}},
+	})
+}
+
+type statusRequest struct {
+	ID string `path:"id"`
+}
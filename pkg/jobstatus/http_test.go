@@ -0,0 +1,101 @@
+package jobstatus_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gochi "github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/jobstatus"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+func TestTracker_Handler(t *testing.T) {
+	running := jobstatus.Job{
+		ID:        "job-1",
+		State:     jobstatus.StateRunning,
+		Progress:  40,
+		TenantID:  "acme",
+		OwnerID:   "user-1",
+		ExpiresAt: testNow.Add(time.Hour),
+	}
+	serve := func(t *testing.T, job jobstatus.Job, userID string) *httptest.ResponseRecorder {
+		store := mocks.NewMockJobStore(t)
+		store.On("Get", mock.Anything, "job-1").Return(job, true, nil).Once()
+		tracker := jobstatus.NewTracker(store, testCfg, jobstatus.WithClock(clock.NewFake(testNow)))
+		router := gochi.NewRouter()
+		router.Get("/jobs/{id}", tracker.Handler().ServeHTTP)
+
+		ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+		ctx = ctxmeta.WithUserID(ctx, userID)
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/jobs/job-1", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("serves the job with a Retry-After header while it runs", func(t *testing.T) {
+		// Act
+		rec := serve(t, running, "user-1")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		var body struct {
+			Data map[string]any `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "running", body.Data["state"])
+		assert.InDelta(t, 40, body.Data["progress"], 0)
+		assert.NotContains(t, body.Data, "owner_id")
+	})
+
+	t.Run("serves a finished job without Retry-After", func(t *testing.T) {
+		// Arrange
+		succeeded := running
+		succeeded.State = jobstatus.StateSucceeded
+
+		// Act
+		rec := serve(t, succeeded, "user-1")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("answers 404 for the job of another user", func(t *testing.T) {
+		// Act
+		rec := serve(t, running, "user-2")
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestTracker_Accepted(t *testing.T) {
+	t.Run("answers 202 with the status route in the Location header", func(t *testing.T) {
+		// Arrange
+		tracker := jobstatus.NewTracker(mocks.NewMockJobStore(t), jobstatus.Config{Path: "/api/jobs"})
+		rec := httptest.NewRecorder()
+
+		// Act
+		err := tracker.Accepted(rec, jobstatus.Job{ID: "job-1", State: jobstatus.StatePending})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Equal(t, "/api/jobs/job-1", rec.Header().Get("Location"))
+		assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+		assert.Contains(t, rec.Body.String(), `"state":"pending"`)
+	})
+}
@@ -0,0 +1,45 @@
+package jobstatus
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// State is the lifecycle state of a Job.
+type State string
+
+const (
+	// StatePending is the state of a created job not yet picked up by a worker
+	StatePending State = "pending"
+	// StateRunning is the state of a started job
+	StateRunning State = "running"
+	// StateSucceeded is the final state of a job with a result
+	StateSucceeded State = "succeeded"
+	// StateFailed is the final state of a job with an error
+	StateFailed State = "failed"
+)
+
+// Finished reports whether s is a final state.
+func (s State) Finished() bool {
+	return s == StateSucceeded || s == StateFailed
+}
+
+// Job is the status of an asynchronous operation, as served to the clients polling it.
+type Job struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	State State  `json:"state"`
+	// Progress is the completion percentage, from 0 to 100
+	Progress int    `json:"progress"`
+	Message  string `json:"message,omitempty"`
+	// Result is the JSON result of a succeeded job
+	Result json.RawMessage `json:"result,omitempty"`
+	// Error is the message of a failed job
+	Error string `json:"error,omitempty"`
+	// TenantID and OwnerID scope the job to the tenant and user who created it
+	TenantID  string    `json:"-"`
+	OwnerID   string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
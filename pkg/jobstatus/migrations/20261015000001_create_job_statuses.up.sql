@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS job_statuses (
+    id TEXT PRIMARY KEY,
+    type TEXT NOT NULL DEFAULT '',
+    state TEXT NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    message TEXT NOT NULL DEFAULT '',
+    result JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    tenant_id TEXT NOT NULL DEFAULT '',
+    owner_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS job_statuses_expires_at_idx ON job_statuses (expires_at);
@@ -0,0 +1,53 @@
+package jobstatus
+
+import (
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+type options struct {
+	clock        clock.Clock
+	ids          ident.Generator
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Option configures the Tracker created by NewTracker.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{clock: clock.New(), ids: ident.New(), errorHandler: response.WriteError}
+}
+
+// WithClock sets the clock of the job times, e.g. a clock.Fake in tests. Defaults to the
+// time package when not provided.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithIDGenerator sets the generator of the job IDs. Defaults to UUIDv7.
+func WithIDGenerator(ids ident.Generator) Option {
+	return func(o *options) {
+		if ids != nil {
+			o.ids = ids
+		}
+	}
+}
+
+// WithErrorHandler writes the errors of the status handler with the response.ErrorHandler.
+// Defaults to response.WriteError, the errs envelope with the status of the error.
+func WithErrorHandler(handler response.ErrorHandler) Option {
+	return func(o *options) {
+		if handler != nil {
+			o.errorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+				handler.ErrorCtx(r.Context(), w, err)
+			}
+		}
+	}
+}
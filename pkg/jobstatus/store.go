@@ -0,0 +1,79 @@
+package jobstatus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// JobStore persists the jobs by ID until they expire.
+type JobStore interface {
+	// Save creates or replaces the job, expiring after ttl.
+	Save(ctx context.Context, job Job, ttl time.Duration) error
+	// Get returns the job and whether it exists and has not expired.
+	Get(ctx context.Context, id string) (Job, bool, error)
+}
+
+// RedisStore stores the jobs as JSON in Redis through the bricks client, so the keys are
+// namespaced and the commands recorded in the client metrics.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore creates a RedisStore keeping the jobs under prefix + ID.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Save implements JobStore.
+func (s *RedisStore) Save(ctx context.Context, job Job, ttl time.Duration) error {
+	data, err := json.Marshal(redisJob{Job: job, TenantID: job.TenantID, OwnerID: job.OwnerID})
+	if err != nil {
+		return fmt.Errorf("jobstatus: encode job: %w", err)
+	}
+	return s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, s.prefix+job.ID, data, ttl)
+		return nil
+	})
+}
+
+// Get implements JobStore.
+func (s *RedisStore) Get(ctx context.Context, id string) (Job, bool, error) {
+	var get *goredis.StringCmd
+	err := s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		get = p.Get(ctx, s.prefix+id)
+		return nil
+	})
+	if err != nil {
+		return Job{}, false, err
+	}
+
+	data, err := get.Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return Job{}, false, nil
+	}
+	if err != nil {
+		return Job{}, false, err
+	}
+	var stored redisJob
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return Job{}, false, fmt.Errorf("jobstatus: decode job: %w", err)
+	}
+	stored.Job.TenantID, stored.Job.OwnerID = stored.TenantID, stored.OwnerID
+	return stored.Job, true, nil
+}
+
+// redisJob keeps the scope of the job, hidden from the JSON of the clients.
+type redisJob struct {
+	Job
+
+	TenantID string `json:"tenant_id,omitempty"`
+	OwnerID  string `json:"owner_id,omitempty"`
+}
@@ -0,0 +1,138 @@
+package jobstatus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+// failedMessage is the Error of the jobs failed with an error that is not an errs.Error,
+// whose message may leak internal details to the clients.
+const failedMessage = "The job failed"
+
+// Tracker records the progress of the asynchronous jobs in its JobStore, for the clients
+// polling their status.
+type Tracker struct {
+	store   JobStore
+	cfg     Config
+	options options
+}
+
+// NewTracker creates a Tracker keeping the jobs in store.
+func NewTracker(store JobStore, cfg Config, opts ...Option) *Tracker {
+	cfg.SetDefaults()
+	trackerOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&trackerOptions)
+	}
+	return &Tracker{store: store, cfg: cfg, options: trackerOptions}
+}
+
+// Create creates a pending job of jobType, scoped to the tenant and user of the context:
+// only them can poll its status.
+func (t *Tracker) Create(ctx context.Context, jobType string) (Job, error) {
+	now := t.options.clock.Now().UTC()
+	job := Job{
+		ID:        t.options.ids.NewID().String(),
+		Type:      jobType,
+		State:     StatePending,
+		CreatedAt: now,
+	}
+	job.TenantID, _ = ctxmeta.TenantID(ctx)
+	job.OwnerID, _ = ctxmeta.UserID(ctx)
+	if err := t.save(ctx, &job); err != nil {
+		return Job{}, err
+	}
+	return job, nil
+}
+
+// Get returns the job of ID id, or ErrJobNotFound when it does not exist or has expired.
+func (t *Tracker) Get(ctx context.Context, id string) (Job, error) {
+	job, found, err := t.store.Get(ctx, id)
+	if err != nil {
+		return Job{}, err
+	}
+	if !found || !t.options.clock.Now().Before(job.ExpiresAt) {
+		return Job{}, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// Start moves the job to the running state.
+func (t *Tracker) Start(ctx context.Context, id string) error {
+	return t.update(ctx, id, func(job *Job) {
+		job.State = StateRunning
+	})
+}
+
+// Progress records the completion percentage of the job, from 0 to 100, and an optional
+// message describing the current step. It starts the job when pending.
+func (t *Tracker) Progress(ctx context.Context, id string, percent int, message string) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("%w: %d", ErrInvalidProgress, percent)
+	}
+	return t.update(ctx, id, func(job *Job) {
+		job.State = StateRunning
+		job.Progress = percent
+		job.Message = message
+	})
+}
+
+// Succeed finishes the job with result, encoded as JSON.
+func (t *Tracker) Succeed(ctx context.Context, id string, result any) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("jobstatus: encode result: %w", err)
+	}
+	return t.update(ctx, id, func(job *Job) {
+		job.State = StateSucceeded
+		job.Progress = 100
+		job.Result = data
+	})
+}
+
+// Fail finishes the job with cause. The message of an errs.Error is served to the
+// clients; any other error is served as a generic message.
+func (t *Tracker) Fail(ctx context.Context, id string, cause error) error {
+	message := failedMessage
+	var rError *errs.Error
+	if errors.As(cause, &rError) {
+		message = rError.Message
+	}
+	return t.update(ctx, id, func(job *Job) {
+		job.State = StateFailed
+		job.Error = message
+	})
+}
+
+// StatusURL returns the path of the status route of the job of ID id.
+func (t *Tracker) StatusURL(id string) string {
+	return t.cfg.Path + "/" + url.PathEscape(id)
+}
+
+// update applies change to the job, unless it has already finished. The updates of a job
+// are expected from a single worker: concurrent updates overwrite each other.
+func (t *Tracker) update(ctx context.Context, id string, change func(job *Job)) error {
+	job, err := t.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job.State.Finished() {
+		return fmt.Errorf("%w: %s", ErrJobFinished, id)
+	}
+	change(&job)
+	return t.save(ctx, &job)
+}
+
+// save stores the job, keeping it for the TTL after this update.
+func (t *Tracker) save(ctx context.Context, job *Job) error {
+	now := t.options.clock.Now().UTC()
+	job.UpdatedAt = now
+	job.ExpiresAt = now.Add(t.cfg.TTL)
+	return t.store.Save(ctx, *job, t.cfg.TTL)
+}
@@ -0,0 +1,176 @@
+package jobstatus_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/jobstatus"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+var (
+	testNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	testCfg = jobstatus.Config{TTL: time.Hour}
+)
+
+type TrackerTestSuite struct {
+	suite.Suite
+	sut       *jobstatus.Tracker
+	clock     *clock.Fake
+	storeMock *mocks.MockJobStore
+	saved     []jobstatus.Job
+}
+
+func TestTrackerSuite(t *testing.T) {
+	suite.Run(t, new(TrackerTestSuite))
+}
+
+func (s *TrackerTestSuite) SetupTest() {
+	s.clock = clock.NewFake(testNow)
+	s.storeMock = mocks.NewMockJobStore(s.T())
+	s.saved = nil
+	s.sut = jobstatus.NewTracker(s.storeMock, testCfg,
+		jobstatus.WithClock(s.clock),
+		jobstatus.WithIDGenerator(ident.NewSequence()),
+	)
+}
+
+func (s *TrackerTestSuite) capture(args mock.Arguments) {
+	s.saved = append(s.saved, args.Get(1).(jobstatus.Job))
+}
+
+func (s *TrackerTestSuite) stored(state jobstatus.State) jobstatus.Job {
+	return jobstatus.Job{ID: "job-1", Type: "export", State: state, CreatedAt: testNow, ExpiresAt: testNow.Add(time.Hour)}
+}
+
+func (s *TrackerTestSuite) TestCreate_SavesAPendingJobScopedToTheContext() {
+	// Arrange
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+	ctx = ctxmeta.WithUserID(ctx, "user-1")
+	s.storeMock.On("Save", ctx, mock.Anything, time.Hour).Run(s.capture).Return(nil).Once()
+
+	// Act
+	job, err := s.sut.Create(ctx, "export")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(ident.SequenceID(1).String(), job.ID)
+	s.Equal(jobstatus.StatePending, job.State)
+	s.Equal("acme", job.TenantID)
+	s.Equal("user-1", job.OwnerID)
+	s.Equal(testNow, job.CreatedAt)
+	s.Equal(testNow.Add(time.Hour), job.ExpiresAt)
+	s.Equal([]jobstatus.Job{job}, s.saved)
+}
+
+func (s *TrackerTestSuite) TestProgress_RunsTheJobAndExtendsItsExpiration() {
+	// Arrange
+	ctx := context.Background()
+	s.clock.Advance(time.Minute)
+	s.storeMock.On("Get", ctx, "job-1").Return(s.stored(jobstatus.StatePending), true, nil).Once()
+	s.storeMock.On("Save", ctx, mock.Anything, time.Hour).Run(s.capture).Return(nil).Once()
+
+	// Act
+	err := s.sut.Progress(ctx, "job-1", 40, "Exporting the orders")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(s.saved, 1)
+	s.Equal(jobstatus.StateRunning, s.saved[0].State)
+	s.Equal(40, s.saved[0].Progress)
+	s.Equal("Exporting the orders", s.saved[0].Message)
+	s.Equal(testNow.Add(time.Minute), s.saved[0].UpdatedAt)
+	s.Equal(testNow.Add(time.Minute+time.Hour), s.saved[0].ExpiresAt)
+}
+
+func (s *TrackerTestSuite) TestProgress_RejectsAPercentageOutOfRange() {
+	// Act
+	err := s.sut.Progress(context.Background(), "job-1", 101, "")
+
+	// Assert
+	s.Require().ErrorIs(err, jobstatus.ErrInvalidProgress)
+}
+
+func (s *TrackerTestSuite) TestSucceed_StoresTheResultAsJSON() {
+	// Arrange
+	ctx := context.Background()
+	s.storeMock.On("Get", ctx, "job-1").Return(s.stored(jobstatus.StateRunning), true, nil).Once()
+	s.storeMock.On("Save", ctx, mock.Anything, time.Hour).Run(s.capture).Return(nil).Once()
+
+	// Act
+	err := s.sut.Succeed(ctx, "job-1", map[string]string{"url": "/exports/1.csv"})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(s.saved, 1)
+	s.Equal(jobstatus.StateSucceeded, s.saved[0].State)
+	s.Equal(100, s.saved[0].Progress)
+	s.JSONEq(`{"url":"/exports/1.csv"}`, string(s.saved[0].Result))
+}
+
+func (s *TrackerTestSuite) TestFail_ServesOnlyTheMessageOfAnErrsError() {
+	// Arrange
+	ctx := context.Background()
+	s.storeMock.On("Get", ctx, "job-1").Return(s.stored(jobstatus.StateRunning), true, nil).Twice()
+	s.storeMock.On("Save", ctx, mock.Anything, time.Hour).Run(s.capture).Return(nil).Twice()
+	invalid := errs.New("EXPORT_TOO_LARGE", "The export is too large", http.StatusUnprocessableEntity, nil)
+
+	// Act
+	errsErr := s.sut.Fail(ctx, "job-1", invalid)
+	internalErr := s.sut.Fail(ctx, "job-1", errors.New("dial tcp 10.0.0.3:5432: connection refused"))
+
+	// Assert
+	s.Require().NoError(errsErr)
+	s.Require().NoError(internalErr)
+	s.Require().Len(s.saved, 2)
+	s.Equal(jobstatus.StateFailed, s.saved[0].State)
+	s.Equal("The export is too large", s.saved[0].Error)
+	s.Equal("The job failed", s.saved[1].Error)
+}
+
+func (s *TrackerTestSuite) TestUpdate_RejectsAFinishedJob() {
+	// Arrange
+	ctx := context.Background()
+	s.storeMock.On("Get", ctx, "job-1").Return(s.stored(jobstatus.StateSucceeded), true, nil).Once()
+
+	// Act
+	err := s.sut.Progress(ctx, "job-1", 50, "")
+
+	// Assert
+	s.Require().ErrorIs(err, jobstatus.ErrJobFinished)
+}
+
+func (s *TrackerTestSuite) TestGet_DoesNotReturnAnExpiredJob() {
+	// Arrange
+	ctx := context.Background()
+	s.clock.Advance(time.Hour)
+	s.storeMock.On("Get", ctx, "job-1").Return(s.stored(jobstatus.StateRunning), true, nil).Once()
+
+	// Act
+	_, err := s.sut.Get(ctx, "job-1")
+
+	// Assert
+	s.Require().ErrorIs(err, jobstatus.ErrJobNotFound)
+}
+
+func (s *TrackerTestSuite) TestGet_ReturnsNotFoundForAnUnknownJob() {
+	// Arrange
+	ctx := context.Background()
+	s.storeMock.On("Get", ctx, "missing").Return(jobstatus.Job{}, false, nil).Once()
+
+	// Act
+	_, err := s.sut.Get(ctx, "missing")
+
+	// Assert
+	s.Require().ErrorIs(err, jobstatus.ErrJobNotFound)
+}
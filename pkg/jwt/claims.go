@@ -0,0 +1,91 @@
+package jwt
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Claims is implemented by the claims of a token. Embed RegisteredClaims in a struct to
+// add the claims of the application:
+//
+//	type AccessClaims struct {
+//	    jwt.RegisteredClaims
+//	    TenantID string   `json:"tenant_id"`
+//	    Roles    []string `json:"roles"`
+//	}
+type Claims interface {
+	Registered() *RegisteredClaims
+}
+
+// RegisteredClaims are the claims of RFC 7519 used by the Manager.
+type RegisteredClaims struct {
+	Issuer    string       `json:"iss,omitempty"`
+	Subject   string       `json:"sub,omitempty"`
+	Audience  Audience     `json:"aud,omitempty"`
+	ExpiresAt *NumericDate `json:"exp,omitempty"`
+	NotBefore *NumericDate `json:"nbf,omitempty"`
+	IssuedAt  *NumericDate `json:"iat,omitempty"`
+	ID        string       `json:"jti,omitempty"`
+}
+
+// Registered implements Claims.
+func (c *RegisteredClaims) Registered() *RegisteredClaims {
+	return c
+}
+
+// Audience is the aud claim: a single string or an array of strings in the token.
+type Audience []string
+
+// Contains reports whether the audience has value.
+func (a Audience) Contains(value string) bool {
+	return slices.Contains(a, value)
+}
+
+// MarshalJSON writes a single audience as a string.
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON reads a string or an array of strings.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*a = values
+	return nil
+}
+
+// NumericDate is a time written as seconds since the Unix epoch.
+type NumericDate struct {
+	time.Time
+}
+
+// NewNumericDate truncates t to the second.
+func NewNumericDate(t time.Time) *NumericDate {
+	return &NumericDate{Time: t.Truncate(time.Second)}
+}
+
+// MarshalJSON writes the seconds since the Unix epoch.
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Unix())
+}
+
+// UnmarshalJSON reads the seconds since the Unix epoch, fractions included.
+func (d *NumericDate) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return err
+	}
+	d.Time = time.Unix(0, int64(seconds*float64(time.Second)))
+	return nil
+}
@@ -0,0 +1,71 @@
+package jwt
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultAccessTTL        = 15 * time.Minute
+	defaultRefreshTTL       = 30 * 24 * time.Hour
+	defaultJWKSPath         = "/.well-known/jwks.json"
+	defaultRevocationPrefix = "jwt:revoked:"
+)
+
+// Config configures the tokens and the loading of their keys.
+type Config struct {
+	// Issuer is the iss claim of the issued tokens, and the only one accepted when set
+	Issuer string `config:"issuer"`
+	// Audience is the aud claim of the issued tokens, and required in the verified ones when set
+	Audience string `config:"audience"`
+	// AccessTTL is the lifetime of the access tokens
+	AccessTTL time.Duration `config:"access_ttl"`
+	// RefreshTTL is the lifetime of the refresh tokens
+	RefreshTTL time.Duration `config:"refresh_ttl"`
+	// Leeway tolerates the clock skew between the issuer and the verifiers
+	Leeway time.Duration `config:"leeway"`
+	// SigningKey is the ID of the key signing new tokens; defaults to the first key
+	SigningKey string `config:"signing_key"`
+	// Keys is the keyring: the signing key and the older keys still verifying
+	Keys []KeyConfig `config:"keys"`
+	// JWKSPath is the route publishing the public keys
+	JWKSPath string `config:"jwks_path"`
+	// RevocationPrefix is prepended to the revoked token IDs in Redis, after the redis client namespace
+	RevocationPrefix string `config:"revocation_prefix"`
+}
+
+// KeyConfig locates the material of a key, read from exactly one source.
+type KeyConfig struct {
+	// ID is the kid header of the tokens signed with the key, e.g. "2026-10"
+	ID string `config:"id"`
+	// Algorithm is HS256, HS384, HS512, RS256, RS384, RS512, ES256, ES384 or ES512
+	Algorithm string `config:"algorithm"`
+	// Env is the environment variable holding the HMAC secret or the PEM private key
+	Env string `config:"env"`
+	// File is the file holding the HMAC secret or the PEM private key, e.g. a mounted secret
+	File string `config:"file"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.AccessTTL <= 0 {
+		c.AccessTTL = defaultAccessTTL
+	}
+	if c.RefreshTTL <= 0 {
+		c.RefreshTTL = defaultRefreshTTL
+	}
+	if c.JWKSPath == "" {
+		c.JWKSPath = defaultJWKSPath
+	}
+	if c.RevocationPrefix == "" {
+		c.RevocationPrefix = defaultRevocationPrefix
+	}
+}
+
+// Validate checks the configured lifetimes.
+func (c *Config) Validate() error {
+	if c.Leeway < 0 {
+		return fmt.Errorf("jwt: leeway must not be negative, got %s", c.Leeway)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package jwt
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+var (
+	ErrNoKeys               = errors.New("jwt: at least one key is required")
+	ErrInvalidKey           = errors.New("jwt: invalid key")
+	ErrDuplicateKey         = errors.New("jwt: duplicate key ID")
+	ErrUnknownSigningKey    = errors.New("jwt: unknown signing key")
+	ErrUnsupportedAlgorithm = errors.New("jwt: unsupported algorithm")
+	ErrInvalidKeySource     = errors.New("jwt: a key must be read from exactly one of env or file")
+	ErrMissingKeyMaterial   = errors.New("jwt: missing key material")
+	ErrNoRevocationStore    = errors.New("jwt: refresh tokens require a revocation store")
+
+	ErrMalformedToken   = errors.New("jwt: malformed token")
+	ErrUnknownKey       = errors.New("jwt: token signed with an unknown key")
+	ErrInvalidSignature = errors.New("jwt: invalid token signature")
+	ErrInvalidType      = errors.New("jwt: unexpected token type")
+	ErrTokenExpired     = errors.New("jwt: token expired")
+	ErrTokenNotYetValid = errors.New("jwt: token not valid yet")
+	ErrInvalidIssuer    = errors.New("jwt: invalid token issuer")
+	ErrInvalidAudience  = errors.New("jwt: invalid token audience")
+	ErrTokenRevoked     = errors.New("jwt: token revoked")
+
+	// ErrUnauthorized is written by the middleware for a missing or invalid bearer token
+	ErrUnauthorized = errs.New("UNAUTHORIZED", "Missing or invalid access token", http.StatusUnauthorized, nil)
+)
@@ -0,0 +1,75 @@
+package jwt
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+	"github.com/cristiano-pacheco/bricks/pkg/signing"
+)
+
+// Module provides the token Manager and mounts the JWKS on the chi server. It loads the
+// config from "app.jwt"; the refresh tokens also require RevocationModule. With a
+// signing.Signer provided, e.g. by signing.Module, the tokens are signed with it.
+//
+//	fx.New(
+//	    chi.Module,
+//	    redis.ClientModule,
+//	    jwt.Module,
+//	    jwt.RevocationModule,
+//	)
+var Module = fx.Module(
+	"jwt",
+	config.Provide[Config]("app.jwt"),
+	fx.Provide(
+		NewManagerWithParams,
+		fx.Annotate(NewJWKSRoute, fx.As(new(chi.Route)), fx.ResultTags(`group:"routes"`)),
+	),
+)
+
+// RevocationModule provides the RedisRevocationStore of the refresh tokens and requires
+// redis.ClientModule.
+var RevocationModule = fx.Provide(
+	fx.Annotate(NewRedisRevocationStoreWithConfig, fx.As(new(RevocationStore))),
+)
+
+// NewRedisRevocationStoreWithConfig creates the RedisRevocationStore with the key prefix of the config.
+func NewRedisRevocationStoreWithConfig(client *redis.Client, cfg config.Config[Config]) *RedisRevocationStore {
+	jwtConfig := cfg.Get()
+	jwtConfig.SetDefaults()
+	return NewRedisRevocationStore(client, jwtConfig.RevocationPrefix)
+}
+
+// ManagerParams for dependency injection
+type ManagerParams struct {
+	fx.In
+
+	Config       config.Config[Config]
+	Signer       signing.Signer        `optional:"true"`
+	Revocations  RevocationStore       `optional:"true"`
+	Clock        clock.Clock           `optional:"true"`
+	ErrorHandler response.ErrorHandler `optional:"true"`
+}
+
+// NewManagerWithParams loads the keys of the config and creates the Manager. The key of
+// the Signer comes first, so it signs unless the config names another signing key, and the
+// configured keys keep verifying their tokens.
+func NewManagerWithParams(params ManagerParams) (*Manager, error) {
+	cfg := params.Config.Get()
+	keys, err := LoadKeys(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if params.Signer != nil {
+		keys = append([]Key{SignerKey(params.Signer)}, keys...)
+	}
+	return NewManager(keys, cfg,
+		WithSigningKey(cfg.SigningKey),
+		WithRevocationStore(params.Revocations),
+		WithClock(params.Clock),
+		WithErrorHandler(params.ErrorHandler),
+	)
+}
@@ -0,0 +1,52 @@
+package jwt
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+)
+
+// JWKS returns the public keys of the RSA and EC keys, for the verifiers of the tokens.
+// HMAC keys are secret and never published.
+func (m *Manager) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	// The signing key comes first, then the older keys still verifying by ID
+	if jwk, ok := m.signing.jwk(); ok {
+		set.Keys = append(set.Keys, jwk)
+	}
+	for _, id := range slices.Sorted(maps.Keys(m.keys)) {
+		if id == m.signing.ID {
+			continue
+		}
+		if jwk, ok := m.keys[id].jwk(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// JWKSHandler serves the JWKS, cached for five minutes so the verifiers pick up a new key
+// shortly after a rotation.
+func (m *Manager) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = response.JSONRaw(w, http.StatusOK, m.JWKS(), http.Header{"Cache-Control": {"public, max-age=300"}})
+	})
+}
+
+// JWKSRoute mounts the JWKS handler on the JWKSPath of the config.
+type JWKSRoute struct {
+	manager *Manager
+}
+
+// NewJWKSRoute creates the JWKSRoute of manager.
+func NewJWKSRoute(manager *Manager) *JWKSRoute {
+	return &JWKSRoute{manager: manager}
+}
+
+// Setup implements chi.Route.
+func (r *JWKSRoute) Setup(server *chi.Server) {
+	server.Router().Method(http.MethodGet, r.manager.cfg.JWKSPath, r.manager.JWKSHandler())
+}
@@ -0,0 +1,303 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/signing"
+)
+
+// Signing algorithms supported by the Manager.
+const (
+	HS256 = "HS256"
+	HS384 = "HS384"
+	HS512 = "HS512"
+	RS256 = "RS256"
+	RS384 = "RS384"
+	RS512 = "RS512"
+	ES256 = "ES256"
+	ES384 = "ES384"
+	ES512 = "ES512"
+
+	// MinSecretSize is the minimum size, in bytes, of the HMAC secrets
+	MinSecretSize = 32
+	// MinRSAKeySize is the minimum size, in bits, of the RSA keys
+	MinRSAKeySize = 2048
+)
+
+// Key signs and verifies the tokens carrying its ID in the kid header. HMAC keys have a
+// Secret, RSA and EC keys a PrivateKey (*rsa.PrivateKey or *ecdsa.PrivateKey) or a Signer,
+// e.g. of a KMS key whose private key never leaves the KMS.
+type Key struct {
+	ID         string
+	Algorithm  string
+	Secret     []byte
+	PrivateKey crypto.Signer
+	Signer     signing.Signer
+}
+
+// SignerKey returns the Key of signer, with its key ID and algorithm.
+func SignerKey(signer signing.Signer) Key {
+	return Key{ID: signer.KeyID(), Algorithm: signer.Algorithm(), Signer: signer}
+}
+
+type algorithm struct {
+	hash  crypto.Hash
+	newFn func() hash.Hash
+	curve elliptic.Curve
+}
+
+var algorithms = map[string]algorithm{
+	HS256: {hash: crypto.SHA256, newFn: sha256.New},
+	HS384: {hash: crypto.SHA384, newFn: sha512.New384},
+	HS512: {hash: crypto.SHA512, newFn: sha512.New},
+	RS256: {hash: crypto.SHA256, newFn: sha256.New},
+	RS384: {hash: crypto.SHA384, newFn: sha512.New384},
+	RS512: {hash: crypto.SHA512, newFn: sha512.New},
+	ES256: {hash: crypto.SHA256, newFn: sha256.New, curve: elliptic.P256()},
+	ES384: {hash: crypto.SHA384, newFn: sha512.New384, curve: elliptic.P384()},
+	ES512: {hash: crypto.SHA512, newFn: sha512.New, curve: elliptic.P521()},
+}
+
+func (k Key) validate() error {
+	if k.ID == "" {
+		return fmt.Errorf("%w: missing ID", ErrInvalidKey)
+	}
+	// The signer checked its key, and supports the PS algorithms too
+	if k.Signer != nil {
+		if k.Algorithm != k.Signer.Algorithm() {
+			return fmt.Errorf("%w: %s signer signs with %s", ErrInvalidKey, k.ID, k.Signer.Algorithm())
+		}
+		return nil
+	}
+	alg, ok := algorithms[k.Algorithm]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, k.Algorithm)
+	}
+
+	switch k.Algorithm[:2] {
+	case "HS":
+		if len(k.Secret) < MinSecretSize {
+			return fmt.Errorf("%w: %s secret must be at least %d bytes", ErrInvalidKey, k.ID, MinSecretSize)
+		}
+	case "RS":
+		rsaKey, isRSA := k.PrivateKey.(*rsa.PrivateKey)
+		if !isRSA {
+			return fmt.Errorf("%w: %s requires an RSA private key", ErrInvalidKey, k.ID)
+		}
+		if rsaKey.N.BitLen() < MinRSAKeySize {
+			return fmt.Errorf("%w: %s RSA key must be at least %d bits", ErrInvalidKey, k.ID, MinRSAKeySize)
+		}
+	default:
+		ecKey, isEC := k.PrivateKey.(*ecdsa.PrivateKey)
+		if !isEC || ecKey.Curve != alg.curve {
+			return fmt.Errorf("%w: %s requires an EC private key on %s", ErrInvalidKey, k.ID, alg.curve.Params().Name)
+		}
+	}
+	return nil
+}
+
+// sign signs input; a Signer is bounded by its own timeout, e.g. the one of its KMS.
+func (k Key) sign(input []byte) ([]byte, error) {
+	if k.Signer != nil {
+		return k.Signer.Sign(context.Background(), input)
+	}
+	alg := algorithms[k.Algorithm]
+	if k.Secret != nil {
+		mac := hmac.New(alg.newFn, k.Secret)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	}
+
+	digest := alg.newFn()
+	digest.Write(input)
+	if ecKey, isEC := k.PrivateKey.(*ecdsa.PrivateKey); isEC {
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest.Sum(nil))
+		if err != nil {
+			return nil, err
+		}
+		size := curveSize(alg.curve)
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+		return signature, nil
+	}
+	return k.PrivateKey.Sign(rand.Reader, digest.Sum(nil), alg.hash)
+}
+
+func (k Key) verify(input, signature []byte) bool {
+	if k.Signer != nil {
+		return signing.Verify(k.Signer.Public(), k.Algorithm, input, signature) == nil
+	}
+	alg := algorithms[k.Algorithm]
+	if k.Secret != nil {
+		mac := hmac.New(alg.newFn, k.Secret)
+		mac.Write(input)
+		return hmac.Equal(signature, mac.Sum(nil))
+	}
+
+	digest := alg.newFn()
+	digest.Write(input)
+	switch publicKey := k.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(publicKey, alg.hash, digest.Sum(nil), signature) == nil
+	case *ecdsa.PublicKey:
+		size := curveSize(alg.curve)
+		if len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(publicKey, digest.Sum(nil), r, s)
+	default:
+		return false
+	}
+}
+
+func curveSize(curve elliptic.Curve) int {
+	return (curve.Params().BitSize + 7) / 8
+}
+
+// LoadKeys reads the material of the configured keys: the secret of the HMAC keys, the
+// PEM encoded private key (PKCS #8, PKCS #1 or SEC 1) of the RSA and EC keys.
+func LoadKeys(configs []KeyConfig) ([]Key, error) {
+	keys := make([]Key, 0, len(configs))
+	for _, cfg := range configs {
+		key, err := loadKey(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", cfg.ID, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func loadKey(cfg KeyConfig) (Key, error) {
+	if (cfg.Env == "") == (cfg.File == "") {
+		return Key{}, ErrInvalidKeySource
+	}
+
+	var material string
+	if cfg.Env != "" {
+		value, ok := os.LookupEnv(cfg.Env)
+		if !ok || value == "" {
+			return Key{}, fmt.Errorf("%w: environment variable %s", ErrMissingKeyMaterial, cfg.Env)
+		}
+		material = value
+	} else {
+		content, err := os.ReadFile(cfg.File)
+		if err != nil {
+			return Key{}, fmt.Errorf("%w: %w", ErrMissingKeyMaterial, err)
+		}
+		material = string(content)
+	}
+
+	key := Key{ID: cfg.ID, Algorithm: cfg.Algorithm}
+	if strings.HasPrefix(cfg.Algorithm, "HS") {
+		key.Secret = []byte(strings.TrimSpace(material))
+		return key, nil
+	}
+	privateKey, err := ParsePrivateKey([]byte(material))
+	if err != nil {
+		return Key{}, err
+	}
+	key.PrivateKey = privateKey
+	return key, nil
+}
+
+// ParsePrivateKey parses a PEM encoded RSA or EC private key.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block", ErrInvalidKey)
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, isSigner := key.(crypto.Signer)
+		if !isSigner {
+			return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, key)
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported PEM block %q", ErrInvalidKey, block.Type)
+}
+
+// JWK is a public key of the JSON Web Key Set (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKS is the JSON Web Key Set published to the token verifiers.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// publicKey returns the public key of the RSA and EC keys, nil for the HMAC keys.
+func (k Key) publicKey() crypto.PublicKey {
+	switch {
+	case k.Signer != nil:
+		return k.Signer.Public()
+	case k.PrivateKey != nil:
+		return k.PrivateKey.Public()
+	default:
+		return nil
+	}
+}
+
+// jwk returns the public key of k; HMAC keys are secret and have none.
+func (k Key) jwk() (JWK, bool) {
+	encode := base64.RawURLEncoding.EncodeToString
+	switch publicKey := k.publicKey().(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType:   "RSA",
+			KeyID:     k.ID,
+			Use:       "sig",
+			Algorithm: k.Algorithm,
+			N:         encode(publicKey.N.Bytes()),
+			E:         encode(big.NewInt(int64(publicKey.E)).Bytes()),
+		}, true
+	case *ecdsa.PublicKey:
+		size := curveSize(publicKey.Curve)
+		return JWK{
+			KeyType:   "EC",
+			KeyID:     k.ID,
+			Use:       "sig",
+			Algorithm: k.Algorithm,
+			Curve:     publicKey.Curve.Params().Name,
+			X:         encode(publicKey.X.FillBytes(make([]byte, size))),
+			Y:         encode(publicKey.Y.FillBytes(make([]byte, size))),
+		}, true
+	default:
+		return JWK{}, false
+	}
+}
@@ -0,0 +1,61 @@
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/jwt"
+)
+
+func TestLoadKeys(t *testing.T) {
+	t.Run("reads an HMAC secret from the environment and a PEM key from a file", func(t *testing.T) {
+		// Arrange
+		t.Setenv("TEST_JWT_SECRET", strings.Repeat("s", jwt.MinSecretSize))
+		ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(ecPrivate)
+		require.NoError(t, err)
+		file := filepath.Join(t.TempDir(), "ec.pem")
+		require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+		// Act
+		keys, err := jwt.LoadKeys([]jwt.KeyConfig{
+			{ID: "hmac-1", Algorithm: jwt.HS256, Env: "TEST_JWT_SECRET"},
+			{ID: "ec-1", Algorithm: jwt.ES256, File: file},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Len(t, keys[0].Secret, jwt.MinSecretSize)
+		assert.True(t, ecPrivate.Equal(keys[1].PrivateKey))
+		_, err = jwt.NewManager(keys, jwt.Config{})
+		require.NoError(t, err)
+	})
+
+	t.Run("requires exactly one source", func(t *testing.T) {
+		// Act
+		_, err := jwt.LoadKeys([]jwt.KeyConfig{{ID: "k", Algorithm: jwt.HS256, Env: "A", File: "b"}})
+
+		// Assert
+		require.ErrorIs(t, err, jwt.ErrInvalidKeySource)
+	})
+
+	t.Run("reports a missing environment variable", func(t *testing.T) {
+		// Act
+		_, err := jwt.LoadKeys([]jwt.KeyConfig{{ID: "k", Algorithm: jwt.HS256, Env: "TEST_JWT_MISSING"}})
+
+		// Assert
+		require.ErrorIs(t, err, jwt.ErrMissingKeyMaterial)
+	})
+}
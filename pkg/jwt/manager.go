@@ -0,0 +1,238 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+const (
+	// TypeAccess is the typ header of the access tokens (RFC 9068)
+	TypeAccess = "at+jwt"
+	// TypeRefresh is the typ header of the refresh tokens, rejected where an access token is expected
+	TypeRefresh = "rt+jwt"
+)
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// Manager issues and verifies the tokens signed with its keyring.
+type Manager struct {
+	cfg     Config
+	keys    map[string]Key
+	signing Key
+	ids     ident.Generator
+	options options
+}
+
+// NewManager creates a Manager signing with the first key, or the WithSigningKey one, and
+// verifying with all of them, so older keys keep verifying their tokens after a rotation.
+func NewManager(keys []Key, cfg Config, opts ...Option) (*Manager, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	managerOptions := defaultOptions()
+	managerOptions.signingKey = keys[0].ID
+	for _, opt := range opts {
+		opt(&managerOptions)
+	}
+
+	keyring := make(map[string]Key, len(keys))
+	for _, key := range keys {
+		if err := key.validate(); err != nil {
+			return nil, err
+		}
+		if _, exists := keyring[key.ID]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateKey, key.ID)
+		}
+		keyring[key.ID] = key
+	}
+	signing, ok := keyring[managerOptions.signingKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSigningKey, managerOptions.signingKey)
+	}
+
+	return &Manager{cfg: cfg, keys: keyring, signing: signing, ids: ident.New(), options: managerOptions}, nil
+}
+
+// SigningKeyID returns the ID of the key signing new tokens.
+func (m *Manager) SigningKeyID() string {
+	return m.signing.ID
+}
+
+// Issue signs an access token with claims. The issuer, audience, issued at, expiration
+// and ID claims are filled in when empty.
+func (m *Manager) Issue(claims Claims) (string, error) {
+	m.fill(claims.Registered(), m.cfg.AccessTTL)
+	return m.sign(TypeAccess, claims)
+}
+
+// Verify checks the signature, type and registered claims of an access token and
+// decodes its claims into claims.
+func (m *Manager) Verify(token string, claims Claims) error {
+	return m.verify(token, TypeAccess, claims)
+}
+
+// IssueRefresh signs a single use refresh token of subject, valid for the RefreshTTL.
+func (m *Manager) IssueRefresh(subject string) (string, error) {
+	claims := &RegisteredClaims{Subject: subject}
+	m.fill(claims, m.cfg.RefreshTTL)
+	return m.sign(TypeRefresh, claims)
+}
+
+// Refresh verifies a refresh token and revokes it, returning its claims to issue the new
+// access and refresh tokens. A refresh token is accepted once: a revoked or already
+// used token returns ErrTokenRevoked, even when two requests race with it.
+func (m *Manager) Refresh(ctx context.Context, token string) (*RegisteredClaims, error) {
+	if m.options.revocations == nil {
+		return nil, ErrNoRevocationStore
+	}
+	claims := &RegisteredClaims{}
+	if err := m.verify(token, TypeRefresh, claims); err != nil {
+		return nil, err
+	}
+
+	revoked, err := m.options.revocations.Revoke(ctx, claims.ID, m.remaining(claims))
+	if err != nil {
+		return nil, fmt.Errorf("jwt: revoke refresh token: %w", err)
+	}
+	if !revoked {
+		return nil, ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+// Revoke revokes a refresh token until it expires, e.g. on logout. Revoking a revoked
+// token is not an error.
+func (m *Manager) Revoke(ctx context.Context, token string) error {
+	if m.options.revocations == nil {
+		return ErrNoRevocationStore
+	}
+	claims := &RegisteredClaims{}
+	if err := m.verify(token, TypeRefresh, claims); err != nil {
+		return err
+	}
+	if _, err := m.options.revocations.Revoke(ctx, claims.ID, m.remaining(claims)); err != nil {
+		return fmt.Errorf("jwt: revoke refresh token: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) fill(claims *RegisteredClaims, ttl time.Duration) {
+	now := m.options.clock.Now()
+	if claims.Issuer == "" {
+		claims.Issuer = m.cfg.Issuer
+	}
+	if len(claims.Audience) == 0 && m.cfg.Audience != "" {
+		claims.Audience = Audience{m.cfg.Audience}
+	}
+	if claims.IssuedAt == nil {
+		claims.IssuedAt = NewNumericDate(now)
+	}
+	if claims.ExpiresAt == nil {
+		claims.ExpiresAt = NewNumericDate(now.Add(ttl))
+	}
+	if claims.ID == "" {
+		claims.ID = m.ids.NewID().String()
+	}
+}
+
+// remaining returns how long the revocation of claims must be kept: until the token
+// would have expired anyway.
+func (m *Manager) remaining(claims *RegisteredClaims) time.Duration {
+	if claims.ExpiresAt == nil {
+		return m.cfg.RefreshTTL
+	}
+	return claims.ExpiresAt.Sub(m.options.clock.Now()) + m.cfg.Leeway
+}
+
+func (m *Manager) sign(typ string, claims any) (string, error) {
+	headerJSON, err := json.Marshal(header{Algorithm: m.signing.Algorithm, Type: typ, KeyID: m.signing.ID})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("jwt: encode claims: %w", err)
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+	input := encode(headerJSON) + "." + encode(claimsJSON)
+	signature, err := m.signing.sign([]byte(input))
+	if err != nil {
+		return "", fmt.Errorf("jwt: sign token: %w", err)
+	}
+	return input + "." + encode(signature), nil
+}
+
+func (m *Manager) verify(token, typ string, claims Claims) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrMalformedToken
+	}
+	decode := base64.RawURLEncoding.DecodeString
+	headerJSON, err := decode(parts[0])
+	if err != nil {
+		return ErrMalformedToken
+	}
+	var h header
+	if err = json.Unmarshal(headerJSON, &h); err != nil {
+		return ErrMalformedToken
+	}
+
+	// The algorithm is the one of the key, never the one of the header, so a token
+	// cannot downgrade to "none" or sign with the public key as an HMAC secret
+	key, ok := m.keys[h.KeyID]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownKey, h.KeyID)
+	}
+	signature, err := decode(parts[2])
+	if err != nil || h.Algorithm != key.Algorithm || !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return ErrInvalidSignature
+	}
+	if h.Type != typ {
+		return fmt.Errorf("%w: %q", ErrInvalidType, h.Type)
+	}
+
+	claimsJSON, err := decode(parts[1])
+	if err != nil {
+		return ErrMalformedToken
+	}
+	decoder := json.NewDecoder(bytes.NewReader(claimsJSON))
+	decoder.UseNumber()
+	if err = decoder.Decode(claims); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformedToken, err)
+	}
+	return m.validate(claims.Registered())
+}
+
+func (m *Manager) validate(claims *RegisteredClaims) error {
+	now := m.options.clock.Now()
+	if claims.ExpiresAt != nil && !now.Before(claims.ExpiresAt.Add(m.cfg.Leeway)) {
+		return ErrTokenExpired
+	}
+	if claims.NotBefore != nil && now.Add(m.cfg.Leeway).Before(claims.NotBefore.Time) {
+		return ErrTokenNotYetValid
+	}
+	if m.cfg.Issuer != "" && claims.Issuer != m.cfg.Issuer {
+		return ErrInvalidIssuer
+	}
+	if m.cfg.Audience != "" && !claims.Audience.Contains(m.cfg.Audience) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package jwt_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/jwt"
+	"github.com/cristiano-pacheco/bricks/pkg/signing"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+var (
+	testNow   = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	hmacKey   = jwt.Key{ID: "hmac-1", Algorithm: jwt.HS256, Secret: bytes.Repeat([]byte{1}, jwt.MinSecretSize)}
+	testCfg   = jwt.Config{Issuer: "https://auth.example.com", Audience: "orders-api"}
+	revokedID = mock.AnythingOfType("string")
+)
+
+type AccessClaims struct {
+	jwt.RegisteredClaims
+
+	TenantID string   `json:"tenant_id"`
+	Roles    []string `json:"roles"`
+}
+
+type ManagerTestSuite struct {
+	suite.Suite
+	sut         *jwt.Manager
+	clock       *clock.Fake
+	revocations *mocks.MockRevocationStore
+}
+
+func TestManagerSuite(t *testing.T) {
+	suite.Run(t, new(ManagerTestSuite))
+}
+
+func (s *ManagerTestSuite) SetupTest() {
+	s.clock = clock.NewFake(testNow)
+	s.revocations = mocks.NewMockRevocationStore(s.T())
+	var err error
+	s.sut, err = jwt.NewManager([]jwt.Key{hmacKey}, testCfg,
+		jwt.WithClock(s.clock), jwt.WithRevocationStore(s.revocations))
+	s.Require().NoError(err)
+}
+
+func (s *ManagerTestSuite) TestIssue_RoundTripsTheClaims() {
+	// Arrange
+	claims := &AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+		TenantID:         "acme",
+		Roles:            []string{"admin"},
+	}
+
+	// Act
+	token, err := s.sut.Issue(claims)
+	s.Require().NoError(err)
+	verified := &AccessClaims{}
+	err = s.sut.Verify(token, verified)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("user-1", verified.Subject)
+	s.Equal("acme", verified.TenantID)
+	s.Equal([]string{"admin"}, verified.Roles)
+	s.Equal("https://auth.example.com", verified.Issuer)
+	s.Equal(jwt.Audience{"orders-api"}, verified.Audience)
+	s.True(testNow.Equal(verified.IssuedAt.Time))
+	s.True(testNow.Add(15 * time.Minute).Equal(verified.ExpiresAt.Time))
+	s.NotEmpty(verified.ID)
+}
+
+func (s *ManagerTestSuite) TestVerify_RejectsAnExpiredToken() {
+	// Arrange
+	token, err := s.sut.Issue(&AccessClaims{})
+	s.Require().NoError(err)
+	s.clock.Advance(15 * time.Minute)
+
+	// Act
+	err = s.sut.Verify(token, &AccessClaims{})
+
+	// Assert
+	s.Require().ErrorIs(err, jwt.ErrTokenExpired)
+}
+
+func (s *ManagerTestSuite) TestVerify_RejectsATamperedToken() {
+	// Arrange
+	token, err := s.sut.Issue(&AccessClaims{TenantID: "acme"})
+	s.Require().NoError(err)
+	parts := strings.Split(token, ".")
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"tenant_id":"other"}`))
+
+	// Act
+	err = s.sut.Verify(strings.Join(parts, "."), &AccessClaims{})
+
+	// Assert
+	s.Require().ErrorIs(err, jwt.ErrInvalidSignature)
+}
+
+func (s *ManagerTestSuite) TestVerify_RejectsTheNoneAlgorithm() {
+	// Arrange
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"at+jwt","kid":"hmac-1"}`))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1"}`))
+
+	// Act
+	err := s.sut.Verify(header+"."+claims+".", &AccessClaims{})
+
+	// Assert
+	s.Require().ErrorIs(err, jwt.ErrInvalidSignature)
+}
+
+func (s *ManagerTestSuite) TestVerify_RejectsAnotherIssuerOrAudience() {
+	// Arrange
+	otherIssuer, err := s.sut.Issue(&AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Issuer: "evil"}})
+	s.Require().NoError(err)
+	otherAudience, err := s.sut.Issue(&AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Audience: jwt.Audience{"x"}}})
+	s.Require().NoError(err)
+
+	// Act
+	issuerErr := s.sut.Verify(otherIssuer, &AccessClaims{})
+	audienceErr := s.sut.Verify(otherAudience, &AccessClaims{})
+
+	// Assert
+	s.Require().ErrorIs(issuerErr, jwt.ErrInvalidIssuer)
+	s.Require().ErrorIs(audienceErr, jwt.ErrInvalidAudience)
+}
+
+func (s *ManagerTestSuite) TestVerify_RejectsARefreshToken() {
+	// Arrange
+	token, err := s.sut.IssueRefresh("user-1")
+	s.Require().NoError(err)
+
+	// Act
+	err = s.sut.Verify(token, &AccessClaims{})
+
+	// Assert
+	s.Require().ErrorIs(err, jwt.ErrInvalidType)
+}
+
+func (s *ManagerTestSuite) TestRefresh_RevokesTheTokenUntilItExpires() {
+	// Arrange
+	token, err := s.sut.IssueRefresh("user-1")
+	s.Require().NoError(err)
+	s.clock.Advance(time.Hour)
+	s.revocations.On("Revoke", mock.Anything, revokedID, 30*24*time.Hour-time.Hour).Return(true, nil).Once()
+
+	// Act
+	claims, err := s.sut.Refresh(context.Background(), token)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("user-1", claims.Subject)
+}
+
+func (s *ManagerTestSuite) TestRefresh_RejectsAReusedToken() {
+	// Arrange
+	token, err := s.sut.IssueRefresh("user-1")
+	s.Require().NoError(err)
+	s.revocations.On("Revoke", mock.Anything, revokedID, mock.Anything).Return(false, nil).Once()
+
+	// Act
+	_, err = s.sut.Refresh(context.Background(), token)
+
+	// Assert
+	s.Require().ErrorIs(err, jwt.ErrTokenRevoked)
+}
+
+func (s *ManagerTestSuite) TestRefresh_RejectsAnAccessToken() {
+	// Arrange
+	token, err := s.sut.Issue(&AccessClaims{})
+	s.Require().NoError(err)
+
+	// Act
+	_, err = s.sut.Refresh(context.Background(), token)
+
+	// Assert
+	s.Require().ErrorIs(err, jwt.ErrInvalidType)
+}
+
+func (s *ManagerTestSuite) TestRefresh_RequiresARevocationStore() {
+	// Arrange
+	manager, err := jwt.NewManager([]jwt.Key{hmacKey}, testCfg)
+	s.Require().NoError(err)
+	token, err := manager.IssueRefresh("user-1")
+	s.Require().NoError(err)
+
+	// Act
+	_, err = manager.Refresh(context.Background(), token)
+
+	// Assert
+	s.Require().ErrorIs(err, jwt.ErrNoRevocationStore)
+}
+
+func TestManager_Rotation(t *testing.T) {
+	t.Run("verifies the tokens of the older keys after a rotation", func(t *testing.T) {
+		// Arrange
+		ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		ecKey := jwt.Key{ID: "ec-1", Algorithm: jwt.ES256, PrivateKey: ecPrivate}
+		before, err := jwt.NewManager([]jwt.Key{hmacKey}, testCfg)
+		require.NoError(t, err)
+		token, err := before.Issue(&AccessClaims{})
+		require.NoError(t, err)
+
+		// Act
+		after, err := jwt.NewManager([]jwt.Key{hmacKey, ecKey}, testCfg, jwt.WithSigningKey("ec-1"))
+		require.NoError(t, err)
+		rotated, issueErr := after.Issue(&AccessClaims{})
+
+		// Assert
+		require.NoError(t, issueErr)
+		require.NoError(t, after.Verify(token, &AccessClaims{}))
+		require.NoError(t, after.Verify(rotated, &AccessClaims{}))
+		require.ErrorIs(t, before.Verify(rotated, &AccessClaims{}), jwt.ErrUnknownKey)
+	})
+
+	t.Run("signs and verifies with RSA keys", func(t *testing.T) {
+		// Arrange
+		rsaPrivate, err := rsa.GenerateKey(rand.Reader, jwt.MinRSAKeySize)
+		require.NoError(t, err)
+		manager, err := jwt.NewManager([]jwt.Key{{ID: "rsa-1", Algorithm: jwt.RS256, PrivateKey: rsaPrivate}}, testCfg)
+		require.NoError(t, err)
+
+		// Act
+		token, err := manager.Issue(&AccessClaims{})
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, manager.Verify(token, &AccessClaims{}))
+	})
+
+	t.Run("signs with a signer and publishes its public key", func(t *testing.T) {
+		// Arrange
+		rsaPrivate, err := rsa.GenerateKey(rand.Reader, jwt.MinRSAKeySize)
+		require.NoError(t, err)
+		signer, err := signing.NewLocalSigner("kms-1", signing.PS256, rsaPrivate)
+		require.NoError(t, err)
+		manager, err := jwt.NewManager([]jwt.Key{jwt.SignerKey(signer), hmacKey}, testCfg)
+		require.NoError(t, err)
+
+		// Act
+		token, err := manager.Issue(&AccessClaims{})
+
+		// Assert
+		require.NoError(t, err)
+		require.NoError(t, manager.Verify(token, &AccessClaims{}))
+		header, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[0])
+		require.NoError(t, err)
+		require.JSONEq(t, `{"alg":"PS256","typ":"at+jwt","kid":"kms-1"}`, string(header))
+		jwks := manager.JWKS()
+		require.Len(t, jwks.Keys, 1)
+		require.Equal(t, "kms-1", jwks.Keys[0].KeyID)
+		require.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	})
+}
+
+func TestNewManager(t *testing.T) {
+	t.Run("rejects a short HMAC secret", func(t *testing.T) {
+		// Act
+		_, err := jwt.NewManager([]jwt.Key{{ID: "k", Algorithm: jwt.HS256, Secret: []byte("short")}}, testCfg)
+
+		// Assert
+		require.ErrorIs(t, err, jwt.ErrInvalidKey)
+	})
+
+	t.Run("rejects an EC key of another curve", func(t *testing.T) {
+		// Arrange
+		ecPrivate, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		// Act
+		_, err = jwt.NewManager([]jwt.Key{{ID: "k", Algorithm: jwt.ES256, PrivateKey: ecPrivate}}, testCfg)
+
+		// Assert
+		require.ErrorIs(t, err, jwt.ErrInvalidKey)
+	})
+
+	t.Run("rejects an unknown signing key", func(t *testing.T) {
+		// Act
+		_, err := jwt.NewManager([]jwt.Key{hmacKey}, testCfg, jwt.WithSigningKey("missing"))
+
+		// Assert
+		require.ErrorIs(t, err, jwt.ErrUnknownSigningKey)
+	})
+
+	t.Run("rejects a signer key of another algorithm", func(t *testing.T) {
+		// Arrange
+		ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		signer, err := signing.NewLocalSigner("kms-1", signing.ES256, ecPrivate)
+		require.NoError(t, err)
+
+		// Act
+		_, err = jwt.NewManager([]jwt.Key{{ID: "kms-1", Algorithm: jwt.ES384, Signer: signer}}, testCfg)
+
+		// Assert
+		require.ErrorIs(t, err, jwt.ErrInvalidKey)
+	})
+
+	t.Run("rejects duplicate key IDs", func(t *testing.T) {
+		// Act
+		_, err := jwt.NewManager([]jwt.Key{hmacKey, hmacKey}, testCfg)
+
+		// Assert
+		require.ErrorIs(t, err, jwt.ErrDuplicateKey)
+	})
+}
@@ -0,0 +1,46 @@
+package jwt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+type contextKey struct{}
+
+// Middleware verifies the bearer access token of the Authorization header, decoding its
+// claims with newClaims, and makes them available with FromContext. The subject becomes
+// the user ID of ctxmeta. Requests without a valid token answer ErrUnauthorized.
+//
+//	router.Use(jwt.Middleware(manager, func() *AccessClaims { return &AccessClaims{} }))
+func Middleware[C Claims](manager *Manager, newClaims func() C) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				manager.options.errorHandler(w, r, ErrUnauthorized)
+				return
+			}
+
+			claims := newClaims()
+			if err := manager.Verify(token, claims); err != nil {
+				manager.options.errorHandler(w, r, ErrUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), contextKey{}, claims)
+			if subject := claims.Registered().Subject; subject != "" {
+				ctx = ctxmeta.WithUserID(ctx, subject)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// FromContext returns the claims of the access token verified by the Middleware.
+func FromContext[C Claims](ctx context.Context) (C, bool) {
+	claims, ok := ctx.Value(contextKey{}).(C)
+	return claims, ok
+}
@@ -0,0 +1,93 @@
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/jwt"
+)
+
+func newAccessClaims() *AccessClaims {
+	return &AccessClaims{}
+}
+
+func TestMiddleware(t *testing.T) {
+	manager, err := jwt.NewManager([]jwt.Key{hmacKey}, testCfg)
+	require.NoError(t, err)
+	var tenantID, userID string
+	handler := jwt.Middleware(manager, newAccessClaims)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := jwt.FromContext[*AccessClaims](r.Context())
+		tenantID = claims.TenantID
+		userID, _ = ctxmeta.UserID(r.Context())
+	}))
+
+	t.Run("puts the claims of a valid token in the context", func(t *testing.T) {
+		// Arrange
+		token, err := manager.Issue(&AccessClaims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+			TenantID:         "acme",
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "acme", tenantID)
+		assert.Equal(t, "user-1", userID)
+	})
+
+	t.Run("answers 401 without a valid bearer token", func(t *testing.T) {
+		for _, authorization := range []string{"", "Basic dXNlcjpwYXNz", "Bearer invalid"} {
+			// Arrange
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			req.Header.Set("Authorization", authorization)
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			assert.Equal(t, http.StatusUnauthorized, rec.Code, authorization)
+		}
+	})
+}
+
+func TestJWKS(t *testing.T) {
+	t.Run("publishes the public keys but not the HMAC secrets", func(t *testing.T) {
+		// Arrange
+		ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		keys := []jwt.Key{{ID: "ec-1", Algorithm: jwt.ES256, PrivateKey: ecPrivate}, hmacKey}
+		manager, err := jwt.NewManager(keys, testCfg)
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+
+		// Act
+		manager.JWKSHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+		var set jwt.JWKS
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+		require.Len(t, set.Keys, 1)
+		assert.Equal(t, "EC", set.Keys[0].KeyType)
+		assert.Equal(t, "ec-1", set.Keys[0].KeyID)
+		assert.Equal(t, "P-256", set.Keys[0].Curve)
+		assert.Len(t, set.Keys[0].X, 43)
+	})
+}
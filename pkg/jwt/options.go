@@ -0,0 +1,63 @@
+package jwt
+
+import (
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+type options struct {
+	signingKey   string
+	revocations  RevocationStore
+	clock        clock.Clock
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Option configures the Manager created by NewManager.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{clock: clock.New(), errorHandler: response.WriteError}
+}
+
+// WithSigningKey signs the new tokens with the key of ID id. Defaults to the first key.
+func WithSigningKey(id string) Option {
+	return func(o *options) {
+		if id != "" {
+			o.signingKey = id
+		}
+	}
+}
+
+// WithRevocationStore keeps the revoked token IDs in store. It is required by the
+// refresh tokens, which are single use.
+func WithRevocationStore(store RevocationStore) Option {
+	return func(o *options) {
+		if store != nil {
+			o.revocations = store
+		}
+	}
+}
+
+// WithClock sets the clock of the issued and verified times, e.g. a clock.Fake in
+// tests. Defaults to the time package when not provided.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithErrorHandler writes the middleware errors with the response.ErrorHandler.
+// Defaults to response.WriteError, the errs envelope with the status of the error.
+func WithErrorHandler(handler response.ErrorHandler) Option {
+	return func(o *options) {
+		if handler != nil {
+			o.errorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+				handler.ErrorCtx(r.Context(), w, err)
+			}
+		}
+	}
+}
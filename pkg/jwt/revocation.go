@@ -0,0 +1,61 @@
+package jwt
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// RevocationStore keeps the IDs of the revoked tokens until they expire.
+type RevocationStore interface {
+	// Revoke revokes the token of ID id for ttl, reporting false when it was already revoked.
+	Revoke(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// IsRevoked reports whether the token of ID id is revoked.
+	IsRevoked(ctx context.Context, id string) (bool, error)
+}
+
+// RedisRevocationStore is the RevocationStore of the redis Client: the keys are
+// namespaced and the commands recorded in the client metrics.
+type RedisRevocationStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisRevocationStore creates a RedisRevocationStore keeping the revoked IDs under prefix + ID.
+func NewRedisRevocationStore(client *redis.Client, prefix string) *RedisRevocationStore {
+	return &RedisRevocationStore{client: client, prefix: prefix}
+}
+
+// Revoke implements RevocationStore. SETNX makes the revocation atomic, so only one of
+// concurrent refreshes with the same token succeeds.
+func (s *RedisRevocationStore) Revoke(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	if ttl <= 0 {
+		// The token has expired, it cannot be used anymore
+		return true, nil
+	}
+	var set *goredis.BoolCmd
+	err := s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		set = p.SetNX(ctx, s.prefix+id, 1, ttl)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return set.Val(), nil
+}
+
+// IsRevoked implements RevocationStore.
+func (s *RedisRevocationStore) IsRevoked(ctx context.Context, id string) (bool, error) {
+	var exists *goredis.IntCmd
+	err := s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		exists = p.Exists(ctx, s.prefix+id)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return exists.Val() > 0, nil
+}
@@ -0,0 +1,8 @@
+package logger
+
+import "errors"
+
+var (
+	// ErrInvalidLevel is returned by SetLevel for an unknown level
+	ErrInvalidLevel = errors.New("logger: invalid level")
+)
@@ -3,12 +3,23 @@ package logger
 import (
 	"github.com/cristiano-pacheco/bricks/pkg/config"
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
 )
 
+// Module provides the Logger configured at "app.logger" and a *slog.Logger writing to it.
 var Module = fx.Module(
 	"logger",
 	config.Provide[Config]("app.logger"),
 	fx.Provide(
 		fx.Annotate(NewWithLifecycle, fx.As(new(Logger))),
+		NewSlog,
 	),
 )
+
+// FxLogger logs the fx events with the Logger, named "fx". It must be given to the root
+// fx.New next to Module, as fx.WithLogger only applies to the module it is declared in:
+//
+//	fx.New(logger.Module, logger.FxLogger, ...)
+var FxLogger = fx.WithLogger(func(log Logger) fxevent.Logger {
+	return &fxevent.ZapLogger{Logger: log.GetZapLogger().Named("fx")}
+})
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -43,6 +44,10 @@ type Logger interface {
 	// WithError adds an error field to the logger
 	WithError(err error) Logger
 
+	// WithContext creates a child logger with the request metadata of ctx
+	// (request, correlation, tenant and user ID)
+	WithContext(ctx context.Context) Logger
+
 	// Sync flushes any buffered log entries
 	Sync() error
 
@@ -56,14 +61,17 @@ type Field = zap.Field
 // ZapLogger implements Logger interface
 type ZapLogger struct {
 	logger *zap.Logger
+	// level is shared with the child loggers, so SetLevel applies to every one of them
+	level zap.AtomicLevel
 }
 
 var _ Logger = (*ZapLogger)(nil) // Ensure ZapLogger implements Logger
 
 // New creates a new logger instance from config
 func New(config Config) (*ZapLogger, error) {
+	level := zap.NewAtomicLevelAt(parseLevel(config.Level))
 	zapConfig := zap.Config{
-		Level:             zap.NewAtomicLevelAt(parseLevel(config.Level)),
+		Level:             level,
 		Development:       config.Development,
 		DisableCaller:     config.DisableCaller,
 		DisableStacktrace: config.DisableStacktrace,
@@ -88,7 +96,7 @@ func New(config Config) (*ZapLogger, error) {
 		return nil, err
 	}
 
-	return &ZapLogger{logger: logger}, nil
+	return &ZapLogger{logger: logger, level: level}, nil
 }
 
 func NewWithLifecycle(lc fx.Lifecycle, cfg config.Config[Config]) (*ZapLogger, error) {
@@ -182,15 +190,23 @@ func (l *ZapLogger) Fatal(msg string, fields ...Field) {
 }
 
 func (l *ZapLogger) Named(name string) Logger {
-	return &ZapLogger{logger: l.logger.Named(name)}
+	return &ZapLogger{logger: l.logger.Named(name), level: l.level}
 }
 
 func (l *ZapLogger) With(fields ...Field) Logger {
-	return &ZapLogger{logger: l.logger.With(fields...)}
+	return &ZapLogger{logger: l.logger.With(fields...), level: l.level}
 }
 
 func (l *ZapLogger) WithError(err error) Logger {
-	return &ZapLogger{logger: l.logger.With(zap.Error(err))}
+	return &ZapLogger{logger: l.logger.With(zap.Error(err)), level: l.level}
+}
+
+func (l *ZapLogger) WithContext(ctx context.Context) Logger {
+	fields := ContextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return &ZapLogger{logger: l.logger.With(fields...), level: l.level}
 }
 
 func (l *ZapLogger) Sync() error {
@@ -201,6 +217,29 @@ func (l *ZapLogger) GetZapLogger() *zap.Logger {
 	return l.logger
 }
 
+// WrapCore returns a child logger writing its entries through wrap, e.g. a zapcore.NewTee
+// forwarding them to another destination. The child shares the level of l.
+func (l *ZapLogger) WrapCore(wrap func(zapcore.Core) zapcore.Core) *ZapLogger {
+	return &ZapLogger{logger: l.logger.WithOptions(zap.WrapCore(wrap)), level: l.level}
+}
+
+// Level returns the minimum level of the logger, e.g. "info".
+func (l *ZapLogger) Level() string {
+	return l.level.String()
+}
+
+// SetLevel changes the minimum level of the logger and of its child loggers at runtime,
+// e.g. to "debug" while investigating an incident. It fails with ErrInvalidLevel for an
+// unknown level.
+func (l *ZapLogger) SetLevel(level string) error {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidLevel, level)
+	}
+	l.level.SetLevel(parsed)
+	return nil
+}
+
 // Common field helpers
 
 // String creates a string field
@@ -267,3 +306,22 @@ func Uint64(key string, val uint64) Field {
 func Int32(key string, val int32) Field {
 	return zap.Int32(key, val)
 }
+
+// ContextFields returns the request metadata stored in ctx with ctxmeta as log fields:
+// request_id, correlation_id, tenant_id and user_id, omitting the missing ones.
+func ContextFields(ctx context.Context) []Field {
+	var fields []Field
+	if requestID, ok := ctxmeta.RequestID(ctx); ok {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	if correlationID, ok := ctxmeta.CorrelationID(ctx); ok {
+		fields = append(fields, zap.String("correlation_id", correlationID))
+	}
+	if tenantID, ok := ctxmeta.TenantID(ctx); ok {
+		fields = append(fields, zap.String("tenant_id", tenantID))
+	}
+	if userID, ok := ctxmeta.UserID(ctx); ok {
+		fields = append(fields, zap.String("user_id", userID))
+	}
+	return fields
+}
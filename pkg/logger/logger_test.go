@@ -1,10 +1,12 @@
 package logger_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -79,6 +81,38 @@ func TestLogger_WithContext(t *testing.T) {
 	contextLog.Info("Request completed")
 }
 
+func TestLogger_WithContext_RequestMetadata(t *testing.T) {
+	t.Run("returns the logger itself when ctx has no metadata", func(t *testing.T) {
+		// Arrange
+		log := logger.MustNew(logger.DefaultConfig())
+
+		// Act
+		contextLog := log.WithContext(context.Background())
+
+		// Assert
+		assert.Same(t, log, contextLog)
+	})
+
+	t.Run("returns the metadata of ctx as fields", func(t *testing.T) {
+		// Arrange
+		ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+		ctx = ctxmeta.WithCorrelationID(ctx, "corr-1")
+		ctx = ctxmeta.WithUserID(ctx, "user-1")
+
+		// Act
+		fields := logger.ContextFields(ctx)
+
+		// Assert
+		require.Len(t, fields, 3)
+		assert.Equal(t, "request_id", fields[0].Key)
+		assert.Equal(t, "req-1", fields[0].String)
+		assert.Equal(t, "correlation_id", fields[1].Key)
+		assert.Equal(t, "corr-1", fields[1].String)
+		assert.Equal(t, "user_id", fields[2].Key)
+		assert.Equal(t, "user-1", fields[2].String)
+	})
+}
+
 func TestLogger_WithError(t *testing.T) {
 	// Arrange
 	config := logger.DevelopmentConfig()
@@ -141,3 +175,29 @@ func TestNewWithOptions_FromDevelopmentConfig(t *testing.T) {
 
 	log.Debug("debug from development config")
 }
+
+func TestLogger_SetLevel_AppliesToTheChildLoggers(t *testing.T) {
+	// Arrange
+	log := logger.MustNewWithOptions(logger.WithLevel("info"))
+	child := log.Named("orders")
+
+	// Act
+	err := log.SetLevel("debug")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "debug", log.Level())
+	assert.True(t, child.GetZapLogger().Core().Enabled(zapcore.DebugLevel))
+}
+
+func TestLogger_SetLevel_RejectsAnUnknownLevel(t *testing.T) {
+	// Arrange
+	log := logger.MustNewWithOptions(logger.WithLevel("info"))
+
+	// Act
+	err := log.SetLevel("verbose")
+
+	// Assert
+	require.ErrorIs(t, err, logger.ErrInvalidLevel)
+	assert.Equal(t, "info", log.Level())
+}
@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewSlog returns a *slog.Logger writing to the zap core of log, for components taking a
+// *slog.Logger such as the chi server. Records logged with a context (InfoContext, ...)
+// carry its request metadata, like WithContext.
+func NewSlog(log Logger) *slog.Logger {
+	return slog.New(NewSlogHandler(log))
+}
+
+// NewSlogHandler returns a slog.Handler writing to the zap core of log. slog groups become
+// nested objects and levels map to the closest zap level.
+func NewSlogHandler(log Logger) slog.Handler {
+	return &slogHandler{core: log.GetZapLogger().Core()}
+}
+
+type slogHandler struct {
+	core zapcore.Core
+}
+
+var _ slog.Handler = (*slogHandler)(nil)
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.core.Enabled(zapLevel(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	entry := zapcore.Entry{
+		Level:   zapLevel(record.Level),
+		Time:    record.Time,
+		Message: record.Message,
+	}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		entry.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+	}
+
+	checked := h.core.Check(entry, nil)
+	if checked == nil {
+		return nil
+	}
+	fields := ContextFields(ctx)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendAttr(fields, attr)
+		return true
+	})
+	checked.Write(fields...)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &slogHandler{core: h.core.With(appendAttrs(attrs))}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{core: h.core.With([]Field{zap.Namespace(name)})}
+}
+
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
+
+func appendAttr(fields []Field, attr slog.Attr) []Field {
+	value := attr.Value.Resolve()
+	if attr.Key == "" && value.Kind() != slog.KindGroup {
+		// slog drops attributes without a key
+		return fields
+	}
+
+	switch value.Kind() {
+	case slog.KindString:
+		return append(fields, zap.String(attr.Key, value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(attr.Key, value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(attr.Key, value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(attr.Key, value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(attr.Key, value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(attr.Key, value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(attr.Key, value.Time()))
+	case slog.KindGroup:
+		group := groupMarshaler(value.Group())
+		if attr.Key == "" {
+			// Groups without a key are inlined, as slog does
+			return append(fields, zap.Inline(group))
+		}
+		return append(fields, zap.Object(attr.Key, group))
+	default:
+		if err, ok := value.Any().(error); ok {
+			return append(fields, zap.NamedError(attr.Key, err))
+		}
+		return append(fields, zap.Any(attr.Key, value.Any()))
+	}
+}
+
+type groupMarshaler []slog.Attr
+
+func (g groupMarshaler) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	for _, field := range appendAttrs(g) {
+		field.AddTo(encoder)
+	}
+	return nil
+}
+
+func appendAttrs(attrs []slog.Attr) []Field {
+	fields := make([]Field, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = appendAttr(fields, attr)
+	}
+	return fields
+}
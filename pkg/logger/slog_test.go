@@ -0,0 +1,86 @@
+package logger_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFileSlog(t *testing.T, level string) (*slog.Logger, func() []map[string]any) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "log.json")
+	log := logger.MustNewWithOptions(
+		logger.WithLevel(level),
+		logger.WithEncoding("json"),
+		logger.WithOutputPaths(path),
+	)
+	read := func() []map[string]any {
+		_ = log.Sync()
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+		var lines []map[string]any
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var line map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		return lines
+	}
+	return logger.NewSlog(log), read
+}
+
+func TestNewSlog(t *testing.T) {
+	t.Run("writes records with attributes, groups and request metadata", func(t *testing.T) {
+		// Arrange
+		slogger, read := newFileSlog(t, "info")
+		ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+
+		// Act
+		slogger.With("service", "orders").WithGroup("http").InfoContext(ctx, "request served",
+			"status", 200,
+			slog.Group("client", "ip", "10.0.0.1"),
+			"err", errors.New("boom"),
+		)
+
+		// Assert
+		lines := read()
+		require.Len(t, lines, 1)
+		line := lines[0]
+		assert.Equal(t, "request served", line["message"])
+		assert.Equal(t, "info", line["level"])
+		assert.Equal(t, "orders", line["service"])
+		httpGroup, ok := line["http"].(map[string]any)
+		require.True(t, ok)
+		assert.InDelta(t, 200, httpGroup["status"], 0)
+		assert.Equal(t, map[string]any{"ip": "10.0.0.1"}, httpGroup["client"])
+		assert.Equal(t, "boom", httpGroup["err"])
+		assert.Equal(t, "req-1", httpGroup["request_id"])
+	})
+
+	t.Run("follows the level of the logger", func(t *testing.T) {
+		// Arrange
+		slogger, read := newFileSlog(t, "warn")
+
+		// Act
+		slogger.Info("dropped")
+		slogger.Warn("kept")
+
+		// Assert
+		lines := read()
+		require.Len(t, lines, 1)
+		assert.Equal(t, "kept", lines[0]["message"])
+		assert.False(t, slogger.Enabled(context.Background(), slog.LevelInfo))
+	})
+}
@@ -0,0 +1,169 @@
+package mailer_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+	"github.com/cristiano-pacheco/bricks/pkg/mailer"
+)
+
+// recordingServer answers status to every request and records the last one.
+type recordingServer struct {
+	*httptest.Server
+	request *http.Request
+	body    []byte
+}
+
+func newRecordingServer(t *testing.T, status int) *recordingServer {
+	t.Helper()
+
+	s := &recordingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.request = r
+		s.body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"invalid"}]}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func testMessage() *mailer.Message {
+	return &mailer.Message{
+		From:        "Shop <no-reply@shop.com>",
+		To:          []string{"Ana <ana@example.com>"},
+		Bcc:         []string{"audit@shop.com"},
+		ReplyTo:     "support@shop.com",
+		Subject:     "Invoice",
+		Text:        "See the invoice",
+		HTML:        "<p>See the invoice</p>",
+		Attachments: []mailer.Attachment{{Filename: "invoice.pdf", Data: []byte("%PDF")}},
+	}
+}
+
+func TestSendGridMailer_Send(t *testing.T) {
+	t.Run("posts the message to the mail send API", func(t *testing.T) {
+		// Arrange
+		server := newRecordingServer(t, http.StatusAccepted)
+		sut, err := mailer.NewSendGridMailer(mailer.SendGridConfig{APIKey: "key", Endpoint: server.URL}, nil)
+		require.NoError(t, err)
+
+		// Act
+		err = sut.Send(context.Background(), testMessage())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "/v3/mail/send", server.request.URL.Path)
+		assert.Equal(t, "Bearer key", server.request.Header.Get("Authorization"))
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(server.body, &payload))
+		assert.Equal(t, map[string]any{"email": "no-reply@shop.com", "name": "Shop"}, payload["from"])
+		assert.Equal(t, []any{map[string]any{
+			"to":  []any{map[string]any{"email": "ana@example.com", "name": "Ana"}},
+			"bcc": []any{map[string]any{"email": "audit@shop.com"}},
+		}}, payload["personalizations"])
+		content := payload["content"].([]any)
+		assert.Equal(t, "text/plain", content[0].(map[string]any)["type"])
+		attachment := payload["attachments"].([]any)[0].(map[string]any)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("%PDF")), attachment["content"])
+		assert.Equal(t, "attachment", attachment["disposition"])
+	})
+
+	t.Run("returns ErrRejected on a client error", func(t *testing.T) {
+		// Arrange
+		server := newRecordingServer(t, http.StatusBadRequest)
+		sut, err := mailer.NewSendGridMailer(mailer.SendGridConfig{APIKey: "key", Endpoint: server.URL}, nil)
+		require.NoError(t, err)
+
+		// Act
+		err = sut.Send(context.Background(), testMessage())
+
+		// Assert
+		require.ErrorIs(t, err, mailer.ErrRejected)
+		assert.Contains(t, err.Error(), "invalid")
+	})
+
+	t.Run("returns a retryable error when throttled", func(t *testing.T) {
+		// Arrange
+		server := newRecordingServer(t, http.StatusTooManyRequests)
+		sut, err := mailer.NewSendGridMailer(mailer.SendGridConfig{APIKey: "key", Endpoint: server.URL}, nil)
+		require.NoError(t, err)
+
+		// Act
+		err = sut.Send(context.Background(), testMessage())
+
+		// Assert
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, mailer.ErrRejected)
+	})
+}
+
+func newSESClient(t *testing.T, endpoint string) *sesv2.Client {
+	t.Helper()
+	session, err := aws.NewSession(aws.Config{
+		Region:          "us-east-1",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		Endpoint:        endpoint,
+		Retry:           aws.RetryConfig{MaxAttempts: 1},
+	}, aws.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	return aws.NewSES(session)
+}
+
+func TestSESMailer_Send(t *testing.T) {
+	t.Run("posts the signed raw message to the SendEmail API", func(t *testing.T) {
+		// Arrange
+		server := newRecordingServer(t, http.StatusOK)
+		sut, err := mailer.NewSESMailer(mailer.SESConfig{ConfigurationSet: "transactional"},
+			newSESClient(t, server.URL))
+		require.NoError(t, err)
+
+		// Act
+		err = sut.Send(context.Background(), testMessage())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "/v2/email/outbound-emails", server.request.URL.Path)
+		authorization := server.request.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Contains(t, authorization, "/us-east-1/ses/aws4_request")
+		var payload struct {
+			FromEmailAddress     string
+			Destination          struct{ ToAddresses, BccAddresses []string }
+			ReplyToAddresses     []string
+			Content              struct{ Raw struct{ Data []byte } }
+			ConfigurationSetName string
+		}
+		require.NoError(t, json.Unmarshal(server.body, &payload))
+		assert.Equal(t, []string{"audit@shop.com"}, payload.Destination.BccAddresses)
+		assert.Equal(t, []string{"support@shop.com"}, payload.ReplyToAddresses)
+		assert.Equal(t, "transactional", payload.ConfigurationSetName)
+		assert.Contains(t, string(payload.Content.Raw.Data), "Subject: Invoice\r\n")
+	})
+
+	t.Run("returns ErrRejected on a client error", func(t *testing.T) {
+		// Arrange
+		server := newRecordingServer(t, http.StatusBadRequest)
+		sut, err := mailer.NewSESMailer(mailer.SESConfig{}, newSESClient(t, server.URL))
+		require.NoError(t, err)
+
+		// Act
+		err = sut.Send(context.Background(), testMessage())
+
+		// Assert
+		require.ErrorIs(t, err, mailer.ErrRejected)
+	})
+}
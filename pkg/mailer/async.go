@@ -0,0 +1,110 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// AsyncMailer queues the messages and sends them with a pool of workers, so requests do
+// not wait for the provider. Send returns once the message is queued; delivery errors go
+// to the error handler (see WithErrorHandler).
+type AsyncMailer struct {
+	next    Mailer
+	onError func(ctx context.Context, msg *Message, err error)
+
+	mu     sync.RWMutex
+	closed bool
+
+	queue   chan asyncJob
+	workers sync.WaitGroup
+	pending sync.WaitGroup
+}
+
+type asyncJob struct {
+	ctx context.Context
+	msg *Message
+}
+
+// NewAsyncMailer creates an AsyncMailer sending with next and starts its workers.
+// Call Shutdown to stop them.
+func NewAsyncMailer(next Mailer, cfg AsyncConfig, opts ...Option) *AsyncMailer {
+	cfg.SetDefaults()
+	mailerOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&mailerOptions)
+	}
+
+	m := &AsyncMailer{
+		next:    next,
+		onError: mailerOptions.onError,
+		queue:   make(chan asyncJob, cfg.QueueSize),
+	}
+	for range cfg.Workers {
+		m.workers.Add(1)
+		go m.work()
+	}
+	return m
+}
+
+// Send validates and queues msg. It blocks while the queue is full until ctx is done.
+// The message is sent with a context that is not canceled with ctx.
+func (m *AsyncMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	if m.closed {
+		m.mu.RUnlock()
+		return ErrMailerClosed
+	}
+	// Track the message before releasing the lock so Shutdown waits for it
+	m.pending.Add(1)
+	m.mu.RUnlock()
+
+	select {
+	case m.queue <- asyncJob{ctx: context.WithoutCancel(ctx), msg: msg}:
+		return nil
+	case <-ctx.Done():
+		m.pending.Done()
+		return fmt.Errorf("failed to queue message: %w", ctx.Err())
+	}
+}
+
+// Shutdown stops accepting messages and waits until the queued ones are sent or ctx is done.
+func (m *AsyncMailer) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	m.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		m.pending.Wait()
+		close(m.queue)
+		m.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("shutdown mailer: %w", ctx.Err())
+	}
+}
+
+func (m *AsyncMailer) work() {
+	defer m.workers.Done()
+
+	for job := range m.queue {
+		if err := m.next.Send(job.ctx, job.msg); err != nil {
+			m.onError(job.ctx, job.msg, err)
+		}
+		m.pending.Done()
+	}
+}
@@ -0,0 +1,82 @@
+package mailer_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/mailer"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+func TestAsyncMailer(t *testing.T) {
+	msg := &mailer.Message{From: "no-reply@shop.com", To: []string{"ana@example.com"}, Text: "Hello"}
+
+	t.Run("sends the queued messages before shutting down", func(t *testing.T) {
+		// Arrange
+		var sent atomic.Int32
+		next := mocks.NewMockMailer(t)
+		next.EXPECT().Send(mock.Anything, msg).RunAndReturn(func(context.Context, *mailer.Message) error {
+			time.Sleep(time.Millisecond)
+			sent.Add(1)
+			return nil
+		}).Times(5)
+		sut := mailer.NewAsyncMailer(next, mailer.AsyncConfig{Workers: 2, QueueSize: 10})
+
+		// Act
+		for range 5 {
+			require.NoError(t, sut.Send(context.Background(), msg))
+		}
+		err := sut.Shutdown(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int32(5), sent.Load())
+	})
+
+	t.Run("reports the delivery errors to the error handler", func(t *testing.T) {
+		// Arrange
+		next := mocks.NewMockMailer(t)
+		next.EXPECT().Send(mock.Anything, msg).Return(errConnection).Once()
+		var reported error
+		sut := mailer.NewAsyncMailer(next, mailer.AsyncConfig{}, mailer.WithErrorHandler(
+			func(_ context.Context, _ *mailer.Message, err error) { reported = err },
+		))
+
+		// Act
+		require.NoError(t, sut.Send(context.Background(), msg))
+		require.NoError(t, sut.Shutdown(context.Background()))
+
+		// Assert
+		assert.ErrorIs(t, reported, errConnection)
+	})
+
+	t.Run("rejects invalid messages without queuing them", func(t *testing.T) {
+		// Arrange
+		sut := mailer.NewAsyncMailer(mocks.NewMockMailer(t), mailer.AsyncConfig{})
+		defer func() { _ = sut.Shutdown(context.Background()) }()
+
+		// Act
+		err := sut.Send(context.Background(), &mailer.Message{From: "no-reply@shop.com", Text: "Hello"})
+
+		// Assert
+		require.ErrorIs(t, err, mailer.ErrNoRecipients)
+	})
+
+	t.Run("returns ErrMailerClosed after shutdown", func(t *testing.T) {
+		// Arrange
+		sut := mailer.NewAsyncMailer(mocks.NewMockMailer(t), mailer.AsyncConfig{})
+		require.NoError(t, sut.Shutdown(context.Background()))
+
+		// Act
+		err := sut.Send(context.Background(), msg)
+
+		// Assert
+		require.ErrorIs(t, err, mailer.ErrMailerClosed)
+	})
+}
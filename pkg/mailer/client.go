@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Client is the Mailer configured by Config: the driver with retries, the default sender
+// and, when enabled, the async mode.
+type Client struct {
+	mailer Mailer
+	async  *AsyncMailer
+}
+
+// New creates the Client configured by cfg.
+//
+//	client, err := mailer.New(mailer.Config{
+//	    Driver: mailer.DriverSMTP,
+//	    From:   "Shop <no-reply@shop.com>",
+//	    SMTP:   mailer.SMTPConfig{Host: "smtp.shop.com", Username: "shop", Password: "secret"},
+//	})
+//	err = client.Send(ctx, &mailer.Message{To: []string{"ana@example.com"}, Subject: "Hi", Text: "Hello"})
+func New(cfg Config, opts ...Option) (*Client, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, cfg.Driver)
+	}
+	mailerOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&mailerOptions)
+	}
+
+	driver, err := newDriver(cfg, mailerOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{mailer: Retry(driver, cfg.Retry)}
+	if cfg.Async.Enabled {
+		client.async = NewAsyncMailer(client.mailer, cfg.Async, WithErrorHandler(asyncErrorHandler(mailerOptions)))
+		client.mailer = client.async
+	}
+	client.mailer = DefaultFrom(client.mailer, cfg.From)
+	return client, nil
+}
+
+func newDriver(cfg Config, o options) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSES:
+		return NewSESMailer(cfg.SES, o.sesClient)
+	case DriverSendGrid:
+		return NewSendGridMailer(cfg.SendGrid, o.httpClient)
+	case DriverLog:
+		if o.log == nil {
+			return nil, ErrMissingLogger
+		}
+		return NewLogMailer(o.log), nil
+	default:
+		return NewSMTPMailer(cfg.SMTP)
+	}
+}
+
+// asyncErrorHandler logs the async errors, when a logger is set, before calling the
+// error handler.
+func asyncErrorHandler(o options) func(ctx context.Context, msg *Message, err error) {
+	return func(ctx context.Context, msg *Message, err error) {
+		if o.log != nil {
+			o.log.Error("failed to send mail",
+				logger.Error(err),
+				logger.String("to", strings.Join(msg.To, ", ")),
+				logger.String("subject", msg.Subject),
+			)
+		}
+		o.onError(ctx, msg, err)
+	}
+}
+
+// Send implements Mailer.
+func (c *Client) Send(ctx context.Context, msg *Message) error {
+	return c.mailer.Send(ctx, msg)
+}
+
+// Shutdown waits until the queued messages of the async mode are sent or ctx is done.
+func (c *Client) Shutdown(ctx context.Context) error {
+	if c.async == nil {
+		return nil
+	}
+	return c.async.Shutdown(ctx)
+}
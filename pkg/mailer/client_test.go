@@ -0,0 +1,81 @@
+package mailer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/mailer"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("returns an error for an invalid config", func(t *testing.T) {
+		tests := []struct {
+			name string
+			cfg  mailer.Config
+			err  error
+		}{
+			{name: "unknown driver", cfg: mailer.Config{Driver: "pigeon"}, err: mailer.ErrInvalidDriver},
+			{name: "smtp without host", cfg: mailer.Config{Driver: mailer.DriverSMTP}, err: mailer.ErrMissingHost},
+			{
+				name: "smtp with unknown tls mode",
+				cfg:  mailer.Config{SMTP: mailer.SMTPConfig{Host: "localhost", TLS: "ssl"}},
+				err:  mailer.ErrInvalidTLSMode,
+			},
+			{name: "ses without client", cfg: mailer.Config{Driver: mailer.DriverSES}, err: mailer.ErrMissingSESClient},
+			{name: "sendgrid without key", cfg: mailer.Config{Driver: mailer.DriverSendGrid}, err: mailer.ErrMissingAPIKey},
+			{name: "log without logger", cfg: mailer.Config{Driver: mailer.DriverLog}, err: mailer.ErrMissingLogger},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Act
+				_, err := mailer.New(tt.cfg)
+
+				// Assert
+				require.ErrorIs(t, err, tt.err)
+			})
+		}
+	})
+
+	t.Run("sends with the default sender", func(t *testing.T) {
+		// Arrange
+		log := mocks.NewMockLogger(t)
+		log.EXPECT().Info("mail sent to log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return()
+		sut, err := mailer.New(mailer.Config{Driver: mailer.DriverLog, From: "no-reply@shop.com"}, mailer.WithLogger(log))
+		require.NoError(t, err)
+		msg := &mailer.Message{To: []string{"ana@example.com"}, Text: "Hello"}
+
+		// Act
+		err = sut.Send(context.Background(), msg)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, msg.From, "the message of the caller is not modified")
+	})
+
+	t.Run("queues the messages in async mode", func(t *testing.T) {
+		// Arrange
+		log := mocks.NewMockLogger(t)
+		log.EXPECT().Info("mail sent to log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return().Once()
+		sut, err := mailer.New(mailer.Config{
+			Driver: mailer.DriverLog,
+			From:   "no-reply@shop.com",
+			Async:  mailer.AsyncConfig{Enabled: true},
+		}, mailer.WithLogger(log))
+		require.NoError(t, err)
+
+		// Act
+		err = sut.Send(context.Background(), &mailer.Message{To: []string{"ana@example.com"}, Text: "Hello"})
+		require.NoError(t, err)
+		err = sut.Shutdown(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,172 @@
+package mailer
+
+import "time"
+
+const (
+	DriverSMTP     = "smtp"
+	DriverSES      = "ses"
+	DriverSendGrid = "sendgrid"
+	// DriverLog logs the messages instead of sending them, e.g. for local development
+	DriverLog = "log"
+
+	// TLSModeSTARTTLS upgrades the connection with STARTTLS, usually on port 587
+	TLSModeSTARTTLS = "starttls"
+	// TLSModeImplicit connects with TLS, usually on port 465
+	TLSModeImplicit = "tls"
+	// TLSModeNone sends in plain text, e.g. to a local relay or a test server
+	TLSModeNone = "none"
+
+	defaultSMTPPort         = 587
+	defaultTimeout          = 10 * time.Second
+	defaultSendGridEndpoint = "https://api.sendgrid.com"
+	defaultMaxAttempts      = 3
+	defaultInitialBackoff   = time.Second
+	defaultMaxBackoff       = 30 * time.Second
+	defaultAsyncWorkers     = 2
+	defaultAsyncQueueSize   = 256
+)
+
+// Config configures the Mailer created by the FX module.
+type Config struct {
+	// Driver is smtp, ses, sendgrid or log
+	Driver string `config:"driver"`
+	// From is the sender of the messages without one, e.g. "Shop <no-reply@shop.com>"
+	From     string         `config:"from"`
+	SMTP     SMTPConfig     `config:"smtp"`
+	SES      SESConfig      `config:"ses"`
+	SendGrid SendGridConfig `config:"sendgrid"`
+	Retry    RetryConfig    `config:"retry"`
+	Async    AsyncConfig    `config:"async"`
+}
+
+// SMTPConfig configures the SMTPMailer.
+type SMTPConfig struct {
+	Host     string `config:"host"`
+	Port     int    `config:"port"`
+	Username string `config:"username"`
+	Password string `config:"password"`
+	// TLS is starttls, tls or none
+	TLS string `config:"tls"`
+	// Timeout bounds the delivery of a message when the context has no deadline
+	Timeout time.Duration `config:"timeout"`
+}
+
+// SESConfig configures the SESMailer. The region, credentials, endpoint and timeout are
+// the ones of the SES client, e.g. aws.NewSES of the aws.Session.
+type SESConfig struct {
+	// ConfigurationSet is the SES configuration set of the messages, e.g. for event publishing
+	ConfigurationSet string `config:"configuration_set"`
+}
+
+// SendGridConfig configures the SendGridMailer.
+type SendGridConfig struct {
+	APIKey string `config:"api_key"`
+	// Endpoint overrides the API endpoint, e.g. for the EU region
+	Endpoint string        `config:"endpoint"`
+	Timeout  time.Duration `config:"timeout"`
+}
+
+// RetryConfig configures the retries of failed sends. Rejected messages are not retried.
+type RetryConfig struct {
+	// MaxAttempts is the number of sends, including the first one; 1 disables retries
+	MaxAttempts    int           `config:"max_attempts"`
+	InitialBackoff time.Duration `config:"initial_backoff"`
+	MaxBackoff     time.Duration `config:"max_backoff"`
+}
+
+// AsyncConfig configures the asynchronous mode, where Send queues the message.
+type AsyncConfig struct {
+	Enabled   bool `config:"enabled"`
+	Workers   int  `config:"workers"`
+	QueueSize int  `config:"queue_size"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = DriverSMTP
+	}
+	c.SMTP.SetDefaults()
+	c.SendGrid.SetDefaults()
+	c.Retry.SetDefaults()
+	c.Async.SetDefaults()
+}
+
+// Validate checks the driver and its settings.
+func (c *Config) Validate() error {
+	switch c.Driver {
+	case DriverSMTP:
+		return c.SMTP.Validate()
+	case DriverSendGrid:
+		return c.SendGrid.Validate()
+	case DriverSES, DriverLog:
+		return nil
+	default:
+		return ErrInvalidDriver
+	}
+}
+
+// SetDefaults fills in the optional fields.
+func (c *SMTPConfig) SetDefaults() {
+	if c.Port == 0 {
+		c.Port = defaultSMTPPort
+	}
+	if c.TLS == "" {
+		c.TLS = TLSModeSTARTTLS
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+}
+
+// Validate checks the host and the TLS mode.
+func (c *SMTPConfig) Validate() error {
+	if c.Host == "" {
+		return ErrMissingHost
+	}
+	if c.TLS != TLSModeSTARTTLS && c.TLS != TLSModeImplicit && c.TLS != TLSModeNone {
+		return ErrInvalidTLSMode
+	}
+	return nil
+}
+
+// SetDefaults fills in the optional fields.
+func (c *SendGridConfig) SetDefaults() {
+	if c.Endpoint == "" {
+		c.Endpoint = defaultSendGridEndpoint
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+}
+
+// Validate checks the API key.
+func (c *SendGridConfig) Validate() error {
+	if c.APIKey == "" {
+		return ErrMissingAPIKey
+	}
+	return nil
+}
+
+// SetDefaults fills in the optional fields.
+func (c *RetryConfig) SetDefaults() {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = defaultInitialBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+}
+
+// SetDefaults fills in the optional fields.
+func (c *AsyncConfig) SetDefaults() {
+	if c.Workers <= 0 {
+		c.Workers = defaultAsyncWorkers
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = defaultAsyncQueueSize
+	}
+}
@@ -0,0 +1,20 @@
+package mailer
+
+import "errors"
+
+var (
+	ErrInvalidDriver    = errors.New("invalid mailer driver (must be 'smtp', 'ses', 'sendgrid' or 'log')")
+	ErrInvalidTLSMode   = errors.New("invalid smtp tls mode (must be 'starttls', 'tls' or 'none')")
+	ErrMissingHost      = errors.New("smtp host is required")
+	ErrMissingAPIKey    = errors.New("sendgrid api key is required")
+	ErrMissingSESClient = errors.New("ses driver requires an SES client (see WithSESClient)")
+	ErrMissingFrom      = errors.New("message has no sender")
+	ErrNoRecipients     = errors.New("message has no recipients")
+	ErrEmptyBody        = errors.New("message has no text or html body")
+	ErrTemplateMissing  = errors.New("mail template not found")
+	ErrMailerClosed     = errors.New("mailer is closed")
+	ErrMissingLogger    = errors.New("log driver requires a logger (see WithLogger)")
+	// ErrRejected wraps the errors of messages refused by the provider, e.g. an invalid
+	// address; they are not retried
+	ErrRejected = errors.New("message rejected")
+)
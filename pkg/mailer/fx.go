@@ -0,0 +1,70 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Module provides the Mailer configured from app.mailer, draining the async queue on
+// stop, and the *Templates parsed from the provided TemplateFS, translated with the i18n
+// TranslationService when i18n.Module is installed. The ses driver takes the SES client
+// of aws.SESModule.
+//
+//	//go:embed templates
+//	var templatesFS embed.FS
+//
+//	fx.New(
+//	    logger.Module,
+//	    mailer.Module,
+//	    fx.Supply(mailer.TemplateFS{FS: templatesFS}),
+//	)
+var Module = fx.Module(
+	"mailer",
+	config.Provide[Config]("app.mailer"),
+	fx.Provide(
+		fx.Annotate(NewWithLifecycle, fx.As(new(Mailer))),
+		NewTemplatesWithParams,
+	),
+)
+
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    config.Config[Config]
+	Logger    logger.Logger
+	SES       *sesv2.Client `optional:"true"`
+}
+
+// NewWithLifecycle creates the Client and shuts it down on stop.
+func NewWithLifecycle(p NewWithLifecycleParams) (*Client, error) {
+	client, err := New(p.Config.Get(), WithLogger(p.Logger), WithSESClient(p.SES))
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return client.Shutdown(ctx)
+		},
+	})
+	return client, nil
+}
+
+type NewTemplatesParams struct {
+	fx.In
+
+	FS         TemplateFS
+	Translator ports.TranslationService `optional:"true"`
+}
+
+// NewTemplatesWithParams parses the templates of the provided TemplateFS.
+func NewTemplatesWithParams(p NewTemplatesParams) (*Templates, error) {
+	return NewTemplates(p.FS, p.Translator)
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// LogMailer logs the messages instead of sending them, e.g. for local development.
+type LogMailer struct {
+	log logger.Logger
+}
+
+// NewLogMailer creates a LogMailer.
+func NewLogMailer(log logger.Logger) *LogMailer {
+	return &LogMailer{log: log}
+}
+
+// Send implements Mailer.
+func (m *LogMailer) Send(_ context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	m.log.Info("mail sent to log",
+		logger.String("from", msg.From),
+		logger.String("to", strings.Join(msg.recipients(), ", ")),
+		logger.String("subject", msg.Subject),
+		logger.Int("attachments", len(msg.Attachments)),
+		logger.String("text", msg.Text),
+	)
+	return nil
+}
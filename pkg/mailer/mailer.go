@@ -0,0 +1,93 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+)
+
+// Mailer sends email messages.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Message is an email. At least one recipient and a text or HTML body are required;
+// with both bodies, clients choose the best one they can display.
+type Message struct {
+	// From is the sender, e.g. "Shop <no-reply@shop.com>"; defaults to the configured sender
+	From    string
+	To      []string
+	Cc      []string
+	Bcc     []string
+	ReplyTo string
+	Subject string
+	Text    string
+	HTML    string
+	// Headers are added to the message, e.g. "List-Unsubscribe"
+	Headers     map[string]string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a Message. Inline attachments are referenced from the
+// HTML body by their content ID, e.g. <img src="cid:logo">.
+type Attachment struct {
+	Filename string
+	// ContentType defaults to the type of the file extension, or application/octet-stream
+	ContentType string
+	Data        []byte
+	Inline      bool
+	// ContentID defaults to the file name for inline attachments
+	ContentID string
+}
+
+// Validate checks the sender, the recipients and the body, and that every address parses.
+func (m *Message) Validate() error {
+	if m.From == "" {
+		return ErrMissingFrom
+	}
+	if len(m.To)+len(m.Cc)+len(m.Bcc) == 0 {
+		return ErrNoRecipients
+	}
+	if m.Text == "" && m.HTML == "" {
+		return ErrEmptyBody
+	}
+
+	addresses := append([]string{m.From}, m.recipients()...)
+	if m.ReplyTo != "" {
+		addresses = append(addresses, m.ReplyTo)
+	}
+	for _, address := range addresses {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrRejected, address, err)
+		}
+	}
+	return nil
+}
+
+// recipients returns the To, Cc and Bcc addresses.
+func (m *Message) recipients() []string {
+	recipients := make([]string, 0, len(m.To)+len(m.Cc)+len(m.Bcc))
+	recipients = append(recipients, m.To...)
+	recipients = append(recipients, m.Cc...)
+	return append(recipients, m.Bcc...)
+}
+
+// DefaultFrom returns a Mailer setting the sender of the messages without one to from.
+func DefaultFrom(next Mailer, from string) Mailer {
+	return MailerFunc(func(ctx context.Context, msg *Message) error {
+		if msg.From == "" && from != "" {
+			withFrom := *msg
+			withFrom.From = from
+			msg = &withFrom
+		}
+		return next.Send(ctx, msg)
+	})
+}
+
+// MailerFunc adapts a function to the Mailer interface.
+type MailerFunc func(ctx context.Context, msg *Message) error
+
+// Send implements Mailer.
+func (f MailerFunc) Send(ctx context.Context, msg *Message) error {
+	return f(ctx, msg)
+}
@@ -0,0 +1,269 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// base64LineLength is the line length of base64 encoded attachments (RFC 2045)
+const base64LineLength = 76
+
+// Build encodes msg as an RFC 5322 message, e.g. for SMTP or raw provider APIs.
+// Bcc recipients are not written in the headers.
+func Build(msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", formatAddress(msg.From))
+	header.Set("To", formatAddresses(msg.To))
+	if len(msg.Cc) > 0 {
+		header.Set("Cc", formatAddresses(msg.Cc))
+	}
+	if msg.ReplyTo != "" {
+		header.Set("Reply-To", formatAddress(msg.ReplyTo))
+	}
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	messageID, err := newMessageID(msg.From)
+	if err != nil {
+		return nil, err
+	}
+	header.Set("Message-ID", messageID)
+	header.Set("MIME-Version", "1.0")
+	for key, value := range msg.Headers {
+		header.Set(key, mime.QEncoding.Encode("utf-8", value))
+	}
+
+	partHeader, body, err := bodyPart(msg)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range partHeader {
+		header[key] = values
+	}
+	if err = writeHeader(&buf, header); err != nil {
+		return nil, err
+	}
+	buf.Write(body)
+	return buf.Bytes(), nil
+}
+
+// bodyPart returns the body as multipart/mixed (attachments) around multipart/related
+// (inline attachments) around multipart/alternative (text and HTML), leaving out the
+// levels the message does not need.
+func bodyPart(msg *Message) (textproto.MIMEHeader, []byte, error) {
+	var inline, attached []Attachment
+	for _, attachment := range msg.Attachments {
+		if attachment.Inline {
+			inline = append(inline, attachment)
+		} else {
+			attached = append(attached, attachment)
+		}
+	}
+
+	header, body, err := alternativePart(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inline) > 0 {
+		header, body, err = multipartPart("related", header, body, inline)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(attached) > 0 {
+		return multipartPart("mixed", header, body, attached)
+	}
+	return header, body, nil
+}
+
+func alternativePart(msg *Message) (textproto.MIMEHeader, []byte, error) {
+	if msg.Text == "" || msg.HTML == "" {
+		if msg.HTML != "" {
+			return textPart("text/html", msg.HTML)
+		}
+		return textPart("text/plain", msg.Text)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, alternative := range []struct{ contentType, content string }{
+		{"text/plain", msg.Text},
+		{"text/html", msg.HTML},
+	} {
+		header, content, err := textPart(alternative.contentType, alternative.content)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = writePart(mw, header, content); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, nil, err
+	}
+	return multipartHeader("alternative", mw), body.Bytes(), nil
+}
+
+// multipartPart returns a multipart of the given subtype with the first part followed by
+// the attachments.
+func multipartPart(
+	subtype string,
+	firstHeader textproto.MIMEHeader,
+	firstBody []byte,
+	attachments []Attachment,
+) (textproto.MIMEHeader, []byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := writePart(mw, firstHeader, firstBody); err != nil {
+		return nil, nil, err
+	}
+	if err := writeAttachments(mw, attachments); err != nil {
+		return nil, nil, err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, nil, err
+	}
+	return multipartHeader(subtype, mw), body.Bytes(), nil
+}
+
+func multipartHeader(subtype string, mw *multipart.Writer) textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/%s; boundary=%s", subtype, mw.Boundary())},
+	}
+}
+
+func textPart(contentType, content string) (textproto.MIMEHeader, []byte, error) {
+	var body bytes.Buffer
+	qw := quotedprintable.NewWriter(&body)
+	if _, err := qw.Write([]byte(content)); err != nil {
+		return nil, nil, err
+	}
+	if err := qw.Close(); err != nil {
+		return nil, nil, err
+	}
+	return textproto.MIMEHeader{
+		"Content-Type":              {contentType + "; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	}, body.Bytes(), nil
+}
+
+func writePart(mw *multipart.Writer, header textproto.MIMEHeader, body []byte) error {
+	w, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+func writeAttachments(mw *multipart.Writer, attachments []Attachment) error {
+	for _, attachment := range attachments {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", attachmentContentType(attachment))
+		header.Set("Content-Transfer-Encoding", "base64")
+		filename := mime.QEncoding.Encode("utf-8", attachment.Filename)
+		if attachment.Inline {
+			header.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+			header.Set("Content-ID", "<"+attachmentContentID(attachment)+">")
+		} else {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		}
+
+		w, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+		for len(encoded) > base64LineLength {
+			if _, err = io.WriteString(w, encoded[:base64LineLength]+"\r\n"); err != nil {
+				return err
+			}
+			encoded = encoded[base64LineLength:]
+		}
+		if _, err = io.WriteString(w, encoded+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeHeader writes the header sorted by key, followed by the blank line.
+func writeHeader(w io.Writer, header textproto.MIMEHeader) error {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		for _, value := range header[key] {
+			fmt.Fprintf(&b, "%s: %s\r\n", key, value)
+		}
+	}
+	b.WriteString("\r\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func attachmentContentType(attachment Attachment) string {
+	if attachment.ContentType != "" {
+		return attachment.ContentType
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(attachment.Filename)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+func attachmentContentID(attachment Attachment) string {
+	if attachment.ContentID != "" {
+		return attachment.ContentID
+	}
+	return attachment.Filename
+}
+
+func formatAddress(address string) string {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return address
+	}
+	return parsed.String()
+}
+
+func formatAddresses(addresses []string) string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = formatAddress(address)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// newMessageID returns a unique Message-ID in the domain of the sender.
+func newMessageID(from string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate message id: %w", err)
+	}
+	domain := "localhost"
+	if parsed, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(parsed.Address, "@"); at >= 0 {
+			domain = parsed.Address[at+1:]
+		}
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
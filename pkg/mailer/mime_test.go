@@ -0,0 +1,110 @@
+package mailer_test
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/mailer"
+)
+
+func TestBuild(t *testing.T) {
+	t.Run("writes a single text part", func(t *testing.T) {
+		// Arrange
+		msg := &mailer.Message{
+			From:    "Shop <no-reply@shop.com>",
+			To:      []string{"ana@example.com"},
+			Bcc:     []string{"audit@shop.com"},
+			Subject: "Olá",
+			Text:    "Hello",
+		}
+
+		// Act
+		raw, err := mailer.Build(msg)
+
+		// Assert
+		require.NoError(t, err)
+		parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, `"Shop" <no-reply@shop.com>`, parsed.Header.Get("From"))
+		assert.Equal(t, "<ana@example.com>", parsed.Header.Get("To"))
+		assert.Empty(t, parsed.Header.Get("Bcc"))
+		subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Olá", subject)
+		assert.True(t, strings.HasSuffix(parsed.Header.Get("Message-ID"), "@shop.com>"))
+		assert.Equal(t, "text/plain; charset=utf-8", parsed.Header.Get("Content-Type"))
+		body, err := io.ReadAll(parsed.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", string(body))
+	})
+
+	t.Run("nests the bodies and the attachments", func(t *testing.T) {
+		// Arrange
+		msg := &mailer.Message{
+			From:    "no-reply@shop.com",
+			To:      []string{"ana@example.com"},
+			Subject: "Invoice",
+			Text:    "See the invoice",
+			HTML:    `<img src="cid:logo.png">`,
+			Attachments: []mailer.Attachment{
+				{Filename: "invoice.pdf", Data: []byte("%PDF")},
+				{Filename: "logo.png", Data: []byte("png"), Inline: true},
+			},
+		}
+
+		// Act
+		raw, err := mailer.Build(msg)
+
+		// Assert
+		require.NoError(t, err)
+		parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+		require.NoError(t, err)
+		mixed := readParts(t, parsed.Header.Get("Content-Type"), parsed.Body)
+		require.Len(t, mixed, 2)
+		assert.Equal(t, `attachment; filename="invoice.pdf"`, mixed[1].Header.Get("Content-Disposition"))
+		assert.Equal(t, "application/pdf", mixed[1].Header.Get("Content-Type"))
+
+		related := readParts(t, mixed[0].Header.Get("Content-Type"), bytes.NewReader(mixed[0].body))
+		require.Len(t, related, 2)
+		assert.Equal(t, "<logo.png>", related[1].Header.Get("Content-Id"))
+
+		alternative := readParts(t, related[0].Header.Get("Content-Type"), bytes.NewReader(related[0].body))
+		require.Len(t, alternative, 2)
+		assert.Equal(t, "See the invoice", string(alternative[0].body))
+		assert.Equal(t, `<img src="cid:logo.png">`, string(alternative[1].body))
+	})
+}
+
+type part struct {
+	*multipart.Part
+	body []byte
+}
+
+func readParts(t *testing.T, contentType string, body io.Reader) []part {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(mediaType, "multipart/"), mediaType)
+
+	var parts []part
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		p, errPart := reader.NextPart()
+		if errPart == io.EOF {
+			return parts
+		}
+		require.NoError(t, errPart)
+		data, errRead := io.ReadAll(p)
+		require.NoError(t, errRead)
+		parts = append(parts, part{Part: p, body: data})
+	}
+}
@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+type options struct {
+	log        logger.Logger
+	httpClient *http.Client
+	sesClient  *sesv2.Client
+	onError    func(ctx context.Context, msg *Message, err error)
+}
+
+// Option configures the mailers created by New and NewAsyncMailer.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{onError: func(context.Context, *Message, error) {}}
+}
+
+// WithLogger logs the messages of the log driver and the errors of the async mode.
+func WithLogger(log logger.Logger) Option {
+	return func(o *options) {
+		o.log = log
+	}
+}
+
+// WithHTTPClient sets the client of the sendgrid driver. Defaults to a client with the
+// configured timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithSESClient sets the SES v2 client of the ses driver, e.g. aws.NewSES of the
+// aws.Session. The ses driver requires it.
+func WithSESClient(client *sesv2.Client) Option {
+	return func(o *options) {
+		o.sesClient = client
+	}
+}
+
+// WithErrorHandler receives the errors of the messages sent in async mode, which cannot
+// be returned by Send. It runs after the retries.
+func WithErrorHandler(fn func(ctx context.Context, msg *Message, err error)) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.onError = fn
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Retry returns a Mailer retrying the failed sends of next with exponential backoff.
+// Rejected and invalid messages are not retried, nor sends whose context is done.
+func Retry(next Mailer, cfg RetryConfig) Mailer {
+	cfg.SetDefaults()
+	return MailerFunc(func(ctx context.Context, msg *Message) error {
+		backoff := cfg.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			err := next.Send(ctx, msg)
+			if err == nil || attempt >= cfg.MaxAttempts || isPermanent(err) {
+				return err
+			}
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return errors.Join(err, ctx.Err())
+			case <-timer.C:
+			}
+			backoff = min(backoff*2, cfg.MaxBackoff)
+		}
+	})
+}
+
+func isPermanent(err error) bool {
+	for _, permanent := range []error{
+		ErrRejected, ErrMissingFrom, ErrNoRecipients, ErrEmptyBody, context.Canceled, context.DeadlineExceeded,
+	} {
+		if errors.Is(err, permanent) {
+			return true
+		}
+	}
+	return false
+}
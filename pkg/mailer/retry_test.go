@@ -0,0 +1,80 @@
+package mailer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/mailer"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+var errConnection = errors.New("connection refused")
+
+func TestRetry(t *testing.T) {
+	cfg := mailer.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	msg := &mailer.Message{From: "no-reply@shop.com", To: []string{"ana@example.com"}, Text: "Hello"}
+
+	t.Run("retries until the send succeeds", func(t *testing.T) {
+		// Arrange
+		next := mocks.NewMockMailer(t)
+		next.EXPECT().Send(mock.Anything, msg).Return(errConnection).Twice()
+		next.EXPECT().Send(mock.Anything, msg).Return(nil).Once()
+		sut := mailer.Retry(next, cfg)
+
+		// Act
+		err := sut.Send(context.Background(), msg)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("returns the last error after the max attempts", func(t *testing.T) {
+		// Arrange
+		next := mocks.NewMockMailer(t)
+		next.EXPECT().Send(mock.Anything, msg).Return(errConnection).Times(3)
+		sut := mailer.Retry(next, cfg)
+
+		// Act
+		err := sut.Send(context.Background(), msg)
+
+		// Assert
+		require.ErrorIs(t, err, errConnection)
+	})
+
+	t.Run("does not retry rejected messages", func(t *testing.T) {
+		// Arrange
+		next := mocks.NewMockMailer(t)
+		next.EXPECT().Send(mock.Anything, msg).Return(mailer.ErrRejected).Once()
+		sut := mailer.Retry(next, cfg)
+
+		// Act
+		err := sut.Send(context.Background(), msg)
+
+		// Assert
+		require.ErrorIs(t, err, mailer.ErrRejected)
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithCancel(context.Background())
+		next := mocks.NewMockMailer(t)
+		next.EXPECT().Send(mock.Anything, msg).RunAndReturn(func(context.Context, *mailer.Message) error {
+			cancel()
+			return errConnection
+		}).Once()
+		sut := mailer.Retry(next, mailer.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Hour})
+
+		// Act
+		err := sut.Send(ctx, msg)
+
+		// Assert
+		require.ErrorIs(t, err, errConnection)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
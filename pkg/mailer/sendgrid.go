@@ -0,0 +1,132 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+// sendGridSendPath is the mail send operation of the SendGrid v3 API
+const sendGridSendPath = "/v3/mail/send"
+
+// SendGridMailer sends messages with the SendGrid v3 API.
+type SendGridMailer struct {
+	cfg    SendGridConfig
+	client *http.Client
+}
+
+// NewSendGridMailer creates a SendGridMailer. A nil client uses a client with the
+// configured timeout.
+func NewSendGridMailer(cfg SendGridConfig, client *http.Client) (*SendGridMailer, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = &http.Client{Timeout: cfg.Timeout}
+	}
+	return &SendGridMailer{cfg: cfg, client: client}, nil
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	ReplyTo          *sendGridAddress          `json:"reply_to,omitempty"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+	Headers          map[string]string         `json:"headers,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To  []sendGridAddress `json:"to,omitempty"`
+	Cc  []sendGridAddress `json:"cc,omitempty"`
+	Bcc []sendGridAddress `json:"bcc,omitempty"`
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	// Content is base64 encoded by encoding/json
+	Content     []byte `json:"content"`
+	Type        string `json:"type"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+// Send implements Mailer.
+func (m *SendGridMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	payload := sendGridRequest{
+		Personalizations: []sendGridPersonalization{{
+			To:  sendGridAddresses(msg.To),
+			Cc:  sendGridAddresses(msg.Cc),
+			Bcc: sendGridAddresses(msg.Bcc),
+		}},
+		From:    sendGridAddresses([]string{msg.From})[0],
+		Subject: msg.Subject,
+		Headers: msg.Headers,
+	}
+	if msg.ReplyTo != "" {
+		payload.ReplyTo = &sendGridAddresses([]string{msg.ReplyTo})[0]
+	}
+	// SendGrid requires text/plain before text/html
+	if msg.Text != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/plain", Value: msg.Text})
+	}
+	if msg.HTML != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+	for _, attachment := range msg.Attachments {
+		converted := sendGridAttachment{
+			Content:     attachment.Data,
+			Type:        attachmentContentType(attachment),
+			Filename:    attachment.Filename,
+			Disposition: "attachment",
+		}
+		if attachment.Inline {
+			converted.Disposition = "inline"
+			converted.ContentID = attachmentContentID(attachment)
+		}
+		payload.Attachments = append(payload.Attachments, converted)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := newRequest(ctx, strings.TrimSuffix(m.cfg.Endpoint, "/")+sendGridSendPath, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.cfg.APIKey)
+	return postJSON(m.client, req, "sendgrid")
+}
+
+// sendGridAddresses converts validated addresses.
+func sendGridAddresses(addresses []string) []sendGridAddress {
+	converted := make([]sendGridAddress, 0, len(addresses))
+	for _, address := range addresses {
+		parsed, err := mail.ParseAddress(address)
+		if err != nil {
+			converted = append(converted, sendGridAddress{Email: address})
+			continue
+		}
+		converted = append(converted, sendGridAddress{Email: parsed.Address, Name: parsed.Name})
+	}
+	return converted
+}
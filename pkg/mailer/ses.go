@@ -0,0 +1,73 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sestypes "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+)
+
+// SESMailer sends messages with the SES v2 client of the AWS SDK. Messages are sent as raw
+// MIME, so attachments and custom headers are supported.
+type SESMailer struct {
+	cfg    SESConfig
+	client *sesv2.Client
+}
+
+// NewSESMailer creates an SESMailer sending with client, e.g. aws.NewSES of the aws.Session.
+func NewSESMailer(cfg SESConfig, client *sesv2.Client) (*SESMailer, error) {
+	if client == nil {
+		return nil, ErrMissingSESClient
+	}
+	return &SESMailer{cfg: cfg, client: client}, nil
+}
+
+// Send implements Mailer.
+func (m *SESMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	raw, err := Build(msg)
+	if err != nil {
+		return err
+	}
+
+	input := &sesv2.SendEmailInput{
+		FromEmailAddress: awssdk.String(msg.From),
+		Destination: &sestypes.Destination{
+			ToAddresses:  msg.To,
+			CcAddresses:  msg.Cc,
+			BccAddresses: msg.Bcc,
+		},
+		Content: &sestypes.EmailContent{Raw: &sestypes.RawMessage{Data: raw}},
+	}
+	if msg.ReplyTo != "" {
+		input.ReplyToAddresses = []string{msg.ReplyTo}
+	}
+	if m.cfg.ConfigurationSet != "" {
+		input.ConfigurationSetName = awssdk.String(m.cfg.ConfigurationSet)
+	}
+	if _, err = m.client.SendEmail(ctx, input); err != nil {
+		return sesError(err)
+	}
+	return nil
+}
+
+// sesError wraps the client errors other than 429 with ErrRejected, since sending the same
+// message again cannot succeed.
+func sesError(err error) error {
+	var responseErr *awshttp.ResponseError
+	if !errors.As(err, &responseErr) {
+		return err
+	}
+	status := responseErr.HTTPStatusCode()
+	if status >= 400 && status < 500 && status != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %w", ErrRejected, err)
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// SMTPMailer sends messages through an SMTP server, opening a connection per message.
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer creates an SMTPMailer.
+func NewSMTPMailer(cfg SMTPConfig) (*SMTPMailer, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &SMTPMailer{cfg: cfg}, nil
+}
+
+// Send implements Mailer. Replies with a 5xx code return an ErrRejected error.
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	data, err := Build(msg)
+	if err != nil {
+		return err
+	}
+
+	err = m.send(ctx, msg, data)
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return fmt.Errorf("%w: %w", ErrRejected, err)
+	}
+	return err
+}
+
+func (m *SMTPMailer) send(ctx context.Context, msg *Message, data []byte) error {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: m.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server %s: %w", addr, err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(m.cfg.Timeout)
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	tlsConfig := &tls.Config{ServerName: m.cfg.Host, MinVersion: tls.VersionTLS12}
+	if m.cfg.TLS == TLSModeImplicit {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to greet smtp server %s: %w", addr, err)
+	}
+	defer client.Close()
+
+	if m.cfg.TLS == TLSModeSTARTTLS {
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if m.cfg.Username != "" {
+		auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+		if err = client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return err
+	}
+	if err = client.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, recipient := range msg.recipients() {
+		to, errParse := mail.ParseAddress(recipient)
+		if errParse != nil {
+			return errParse
+		}
+		if err = client.Rcpt(to.Address); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(data); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
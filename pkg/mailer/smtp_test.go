@@ -0,0 +1,177 @@
+package mailer_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/mailer"
+)
+
+// smtpServer is a minimal SMTP server recording the envelope and the data of one message.
+type smtpServer struct {
+	listener net.Listener
+	// rejectRcpt answers 550 to RCPT TO
+	rejectRcpt bool
+
+	mu   sync.Mutex
+	from string
+	rcpt []string
+	data string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpServer{listener: listener}
+	t.Cleanup(func() { _ = listener.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *smtpServer) config() mailer.SMTPConfig {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return mailer.SMTPConfig{Host: host, Port: portNumber, TLS: mailer.TLSModeNone}
+}
+
+func (s *smtpServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		s.mu.Lock()
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			s.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			if s.rejectRcpt {
+				reply("550 mailbox unavailable")
+				break
+			}
+			s.rcpt = append(s.rcpt, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				dataLine, errData := reader.ReadString('\n')
+				if errData != nil || dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.data = data.String()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("250 OK")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	t.Run("sends the message to every recipient", func(t *testing.T) {
+		// Arrange
+		server := newSMTPServer(t)
+		sut, err := mailer.NewSMTPMailer(server.config())
+		require.NoError(t, err)
+		msg := &mailer.Message{
+			From:    "Shop <no-reply@shop.com>",
+			To:      []string{"Ana <ana@example.com>"},
+			Bcc:     []string{"audit@shop.com"},
+			Subject: "Welcome",
+			Text:    "Hello",
+		}
+
+		// Act
+		err = sut.Send(context.Background(), msg)
+
+		// Assert
+		require.NoError(t, err)
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, "no-reply@shop.com", server.from)
+		assert.Equal(t, []string{"ana@example.com", "audit@shop.com"}, server.rcpt)
+		assert.Contains(t, server.data, "Subject: Welcome\r\n")
+		assert.NotContains(t, server.data, "audit@shop.com")
+	})
+
+	t.Run("returns ErrRejected when the server refuses a recipient", func(t *testing.T) {
+		// Arrange
+		server := newSMTPServer(t)
+		server.rejectRcpt = true
+		sut, err := mailer.NewSMTPMailer(server.config())
+		require.NoError(t, err)
+		msg := &mailer.Message{From: "no-reply@shop.com", To: []string{"ana@example.com"}, Text: "Hello"}
+
+		// Act
+		err = sut.Send(context.Background(), msg)
+
+		// Assert
+		require.ErrorIs(t, err, mailer.ErrRejected)
+	})
+
+	t.Run("returns a retryable error when the server is down", func(t *testing.T) {
+		// Arrange
+		server := newSMTPServer(t)
+		cfg := server.config()
+		require.NoError(t, server.listener.Close())
+		sut, err := mailer.NewSMTPMailer(cfg)
+		require.NoError(t, err)
+		msg := &mailer.Message{From: "no-reply@shop.com", To: []string{"ana@example.com"}, Text: "Hello"}
+
+		// Act
+		err = sut.Send(context.Background(), msg)
+
+		// Assert
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, mailer.ErrRejected)
+	})
+
+	t.Run("validates the message", func(t *testing.T) {
+		// Arrange
+		sut, err := mailer.NewSMTPMailer(mailer.SMTPConfig{Host: "localhost"})
+		require.NoError(t, err)
+
+		// Act
+		err = sut.Send(context.Background(), &mailer.Message{From: "no-reply@shop.com", Text: "Hello"})
+
+		// Assert
+		require.ErrorIs(t, err, mailer.ErrNoRecipients)
+	})
+}
@@ -0,0 +1,157 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/ports"
+)
+
+const (
+	htmlTemplateSuffix    = ".html.tmpl"
+	textTemplateSuffix    = ".txt.tmpl"
+	subjectTemplateSuffix = ".subject.tmpl"
+)
+
+// TemplateFS holds the mail templates provided to the FX module.
+type TemplateFS struct {
+	fs.FS
+}
+
+// Templates renders messages from the templates of a file system. A message named
+// "welcome" is made of welcome.subject.tmpl, welcome.html.tmpl and welcome.txt.tmpl; the
+// subject is optional, and at least one body is required. Templates of the same kind share
+// their definitions, so a layout can be defined once:
+//
+//	{{/* layout.html.tmpl */}}
+//	{{define "layout"}}<html><body>{{template "content" .}}</body></html>{{end}}
+//
+//	{{/* welcome.html.tmpl */}}
+//	{{define "content"}}<h1>{{t "mail" "welcome.title" "name" .Name}}</h1>{{end}}
+//	{{template "layout" .}}
+//
+// The t and tn functions translate with the i18n TranslationService, in the locale of the
+// render context (see locale.WithLocale): {{t domain key [name value]...}} and
+// {{tn domain key count [name value]...}} for plurals, where count is available as .count.
+type Templates struct {
+	html       *htmltemplate.Template
+	text       *texttemplate.Template
+	translator ports.TranslationService
+}
+
+// NewTemplates parses the templates of fsys. A nil translator renders the translation
+// keys as is.
+func NewTemplates(fsys fs.FS, translator ports.TranslationService) (*Templates, error) {
+	t := &Templates{
+		html:       htmltemplate.New("").Funcs(translateFuncs(context.Background(), nil)),
+		text:       texttemplate.New("").Funcs(translateFuncs(context.Background(), nil)),
+		translator: translator,
+	}
+
+	err := fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		isHTML := strings.HasSuffix(path, htmlTemplateSuffix)
+		if !isHTML && !strings.HasSuffix(path, textTemplateSuffix) && !strings.HasSuffix(path, subjectTemplateSuffix) {
+			return nil
+		}
+
+		src, errRead := fs.ReadFile(fsys, path)
+		if errRead != nil {
+			return errRead
+		}
+		var errParse error
+		if isHTML {
+			_, errParse = t.html.New(path).Parse(string(src))
+		} else {
+			_, errParse = t.text.New(path).Parse(string(src))
+		}
+		if errParse != nil {
+			return fmt.Errorf("failed to parse mail template %s: %w", path, errParse)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Render returns a message with the subject and bodies of the named templates rendered
+// with data. Set the recipients before sending it.
+//
+//	msg, err := templates.Render(ctx, "welcome", map[string]any{"Name": user.Name})
+//	msg.To = []string{user.Email}
+//	err = mailer.Send(ctx, msg)
+func (t *Templates) Render(ctx context.Context, name string, data any) (*Message, error) {
+	funcs := translateFuncs(ctx, t.translator)
+	html, err := t.html.Clone()
+	if err != nil {
+		return nil, err
+	}
+	html.Funcs(funcs)
+	text, err := t.text.Clone()
+	if err != nil {
+		return nil, err
+	}
+	text.Funcs(funcs)
+
+	msg := &Message{}
+	if tmpl := html.Lookup(name + htmlTemplateSuffix); tmpl != nil {
+		var buf bytes.Buffer
+		if err = tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render mail template %s: %w", tmpl.Name(), err)
+		}
+		msg.HTML = buf.String()
+	}
+	for suffix, target := range map[string]*string{textTemplateSuffix: &msg.Text, subjectTemplateSuffix: &msg.Subject} {
+		tmpl := text.Lookup(name + suffix)
+		if tmpl == nil {
+			continue
+		}
+		var buf bytes.Buffer
+		if err = tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render mail template %s: %w", tmpl.Name(), err)
+		}
+		*target = buf.String()
+	}
+	msg.Subject = strings.TrimSpace(msg.Subject)
+
+	if msg.HTML == "" && msg.Text == "" {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateMissing, name)
+	}
+	return msg, nil
+}
+
+// translateFuncs returns the t and tn template functions translating in the locale of ctx.
+func translateFuncs(ctx context.Context, translator ports.TranslationService) map[string]any {
+	return map[string]any{
+		"t": func(domain, key string, pairs ...any) string {
+			if translator == nil {
+				return key
+			}
+			return translator.TranslateWithDataCtx(ctx, domain, key, pairsToMap(pairs))
+		},
+		"tn": func(domain, key string, count int, pairs ...any) string {
+			if translator == nil {
+				return key
+			}
+			return translator.TranslatePluralCtx(ctx, domain, key, count, pairsToMap(pairs))
+		},
+	}
+}
+
+// pairsToMap converts the name value pairs of t and tn to translation data.
+func pairsToMap(pairs []any) map[string]string {
+	data := make(map[string]string, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		data[fmt.Sprint(pairs[i])] = fmt.Sprint(pairs[i+1])
+	}
+	return data
+}
@@ -0,0 +1,101 @@
+package mailer_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/i18n/locale"
+	"github.com/cristiano-pacheco/bricks/pkg/mailer"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layout.html.tmpl": {Data: []byte(
+			`{{define "layout"}}<html><body>{{template "content" .}}</body></html>{{end}}`,
+		)},
+		"welcome/welcome.html.tmpl": {Data: []byte(
+			`{{define "content"}}<h1>{{t "mail" "welcome.title" "name" .Name}}</h1>{{end}}{{template "layout" .}}`,
+		)},
+		"welcome/welcome.txt.tmpl":     {Data: []byte(`Hello {{.Name}}, you have {{tn "mail" "cart.items" .Items}}`)},
+		"welcome/welcome.subject.tmpl": {Data: []byte("  Welcome, {{.Name}}\n")},
+		"receipt.txt.tmpl":             {Data: []byte(`Receipt`)},
+		"README.md":                    {Data: []byte(`ignored`)},
+	}
+	data := map[string]any{"Name": "Ana & Bob", "Items": 2}
+
+	t.Run("renders the subject and both bodies", func(t *testing.T) {
+		// Arrange
+		sut, err := mailer.NewTemplates(fsys, nil)
+		require.NoError(t, err)
+
+		// Act
+		msg, err := sut.Render(context.Background(), "welcome/welcome", data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome, Ana & Bob", msg.Subject)
+		assert.Equal(t, "<html><body><h1>welcome.title</h1></body></html>", msg.HTML)
+		assert.Equal(t, "Hello Ana & Bob, you have cart.items", msg.Text)
+	})
+
+	t.Run("translates in the locale of the context", func(t *testing.T) {
+		// Arrange
+		ctx := locale.WithLocale(context.Background(), "pt_BR")
+		translator := mocks.NewMockTranslationService(t)
+		translator.EXPECT().
+			TranslateWithDataCtx(ctx, "mail", "welcome.title", map[string]string{"name": "Ana & Bob"}).
+			Return("Bem-vinda, Ana & Bob")
+		translator.EXPECT().
+			TranslatePluralCtx(ctx, "mail", "cart.items", 2, map[string]string{}).
+			Return("2 itens")
+		sut, err := mailer.NewTemplates(fsys, translator)
+		require.NoError(t, err)
+
+		// Act
+		msg, err := sut.Render(ctx, "welcome/welcome", data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "<html><body><h1>Bem-vinda, Ana &amp; Bob</h1></body></html>", msg.HTML)
+		assert.Equal(t, "Hello Ana & Bob, you have 2 itens", msg.Text)
+	})
+
+	t.Run("renders a text only message", func(t *testing.T) {
+		// Arrange
+		sut, err := mailer.NewTemplates(fsys, nil)
+		require.NoError(t, err)
+
+		// Act
+		msg, err := sut.Render(context.Background(), "receipt", nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Receipt", msg.Text)
+		assert.Empty(t, msg.HTML)
+	})
+
+	t.Run("returns ErrTemplateMissing for an unknown template", func(t *testing.T) {
+		// Arrange
+		sut, err := mailer.NewTemplates(fsys, nil)
+		require.NoError(t, err)
+
+		// Act
+		_, err = sut.Render(context.Background(), "unknown", nil)
+
+		// Assert
+		require.ErrorIs(t, err, mailer.ErrTemplateMissing)
+	})
+
+	t.Run("returns an error for an invalid template", func(t *testing.T) {
+		// Act
+		_, err := mailer.NewTemplates(fstest.MapFS{"broken.txt.tmpl": {Data: []byte("{{.Name")}}, nil)
+
+		// Assert
+		require.ErrorContains(t, err, "broken.txt.tmpl")
+	})
+}
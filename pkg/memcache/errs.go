@@ -0,0 +1,12 @@
+package memcache
+
+import "errors"
+
+var (
+	// ErrMissingName is returned by NewCache without a cache name
+	ErrMissingName = errors.New("memcache: cache name is required")
+	// ErrInvalidMaxSize is returned by NewCache when the max size is not positive
+	ErrInvalidMaxSize = errors.New("memcache: max size must be positive")
+	// ErrInvalidSizeFunc is returned by NewCache when the WithSizeFunc type does not match the values
+	ErrInvalidSizeFunc = errors.New("memcache: size func does not match the cached values")
+)
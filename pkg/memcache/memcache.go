@@ -0,0 +1,188 @@
+package memcache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"hash/maphash"
+	"sync"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+// Cache is a sharded in-process cache of V values. Each shard evicts its least recently
+// used entries beyond its share of the max size, and drops the entries read after their
+// TTL. A Cache is safe for concurrent use.
+type Cache[V any] struct {
+	name     string
+	ttl      time.Duration
+	sizeFunc func(V) int64
+	clock    clock.Clock
+	metrics  *cacheMetrics
+	seed     maphash.Seed
+	shards   []*shard[V]
+}
+
+// shard is a part of the entries, guarded by its own lock.
+type shard[V any] struct {
+	mu      sync.Mutex
+	items   map[string]*list.Element
+	lru     *list.List // Front is the most recently used entry
+	size    int64
+	maxSize int64
+}
+
+// item is a cached entry, the value of a list.Element.
+type item[V any] struct {
+	key       string
+	value     V
+	size      int64
+	expiresAt time.Time // Zero when the entry does not expire
+}
+
+var _ Store[any] = (*Cache[any])(nil)
+
+// NewCache creates a Cache; name labels its metrics, e.g. "products".
+func NewCache[V any](name string, opts ...Option) (*Cache[V], error) {
+	if name == "" {
+		return nil, ErrMissingName
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.maxSize <= 0 {
+		return nil, ErrInvalidMaxSize
+	}
+	sizeFunc := func(V) int64 { return 1 }
+	if options.sizeFunc != nil {
+		fn, ok := options.sizeFunc.(func(V) int64)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrInvalidSizeFunc, options.sizeFunc)
+		}
+		sizeFunc = fn
+	}
+	metrics, err := newCacheMetrics(options.registerer)
+	if err != nil {
+		return nil, fmt.Errorf("memcache: register metrics: %w", err)
+	}
+
+	// Round up, so a max size below the number of shards still holds entries
+	shardMaxSize := (options.maxSize + int64(options.shards) - 1) / int64(options.shards)
+	shards := make([]*shard[V], options.shards)
+	for i := range shards {
+		shards[i] = &shard[V]{items: make(map[string]*list.Element), lru: list.New(), maxSize: shardMaxSize}
+	}
+	return &Cache[V]{
+		name:     name,
+		ttl:      options.ttl,
+		sizeFunc: sizeFunc,
+		clock:    options.clock,
+		metrics:  metrics,
+		seed:     maphash.MakeSeed(),
+		shards:   shards,
+	}, nil
+}
+
+// Get implements Store. It never fails.
+func (c *Cache[V]) Get(_ context.Context, key string) (V, bool, error) {
+	s := c.shard(key)
+	s.mu.Lock()
+	elem, ok := s.items[key]
+	if ok {
+		cached := elem.Value.(*item[V])
+		if cached.expiresAt.IsZero() || c.clock.Now().Before(cached.expiresAt) {
+			s.lru.MoveToFront(elem)
+			s.mu.Unlock()
+			c.metrics.observe(c.name, resultHit)
+			return cached.value, true, nil
+		}
+		s.remove(elem)
+	}
+	s.mu.Unlock()
+
+	if ok {
+		c.metrics.evicted(c.name, evictExpired, 1)
+	}
+	c.metrics.observe(c.name, resultMiss)
+	var zero V
+	return zero, false, nil
+}
+
+// Set implements Store; a zero ttl uses WithTTL. It never fails. A value larger than the
+// share of a shard of the max size is not cached.
+func (c *Cache[V]) Set(_ context.Context, key string, value V, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+	cached := &item[V]{key: key, value: value, size: c.sizeFunc(value)}
+	if ttl > 0 {
+		cached.expiresAt = c.clock.Now().Add(ttl)
+	}
+
+	s := c.shard(key)
+	s.mu.Lock()
+	if elem, ok := s.items[key]; ok {
+		s.remove(elem)
+	}
+	if cached.size > s.maxSize {
+		s.mu.Unlock()
+		return nil
+	}
+	s.items[key] = s.lru.PushFront(cached)
+	s.size += cached.size
+	evicted := 0
+	for s.size > s.maxSize {
+		s.remove(s.lru.Back())
+		evicted++
+	}
+	s.mu.Unlock()
+
+	c.metrics.evicted(c.name, evictSize, evicted)
+	return nil
+}
+
+// Delete implements Store. It never fails.
+func (c *Cache[V]) Delete(_ context.Context, key string) error {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.items[key]; ok {
+		s.remove(elem)
+	}
+	return nil
+}
+
+// Len returns the number of entries, including the expired entries not yet dropped.
+func (c *Cache[V]) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		s.mu.Lock()
+		n += len(s.items)
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// Clear deletes every entry.
+func (c *Cache[V]) Clear() {
+	for _, s := range c.shards {
+		s.mu.Lock()
+		clear(s.items)
+		s.lru.Init()
+		s.size = 0
+		s.mu.Unlock()
+	}
+}
+
+func (c *Cache[V]) shard(key string) *shard[V] {
+	return c.shards[maphash.String(c.seed, key)%uint64(len(c.shards))]
+}
+
+// remove removes the entry of elem; s.mu must be held.
+func (s *shard[V]) remove(elem *list.Element) {
+	cached := s.lru.Remove(elem).(*item[V])
+	delete(s.items, cached.key)
+	s.size -= cached.size
+}
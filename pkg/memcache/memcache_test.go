@@ -0,0 +1,271 @@
+package memcache_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/memcache"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+	sut      *memcache.Cache[string]
+	clock    *clock.Fake
+	registry *prometheus.Registry
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+func (s *CacheTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	s.registry = prometheus.NewRegistry()
+	s.sut = s.newCache(memcache.WithShards(1), memcache.WithMaxSize(3))
+}
+
+func (s *CacheTestSuite) newCache(opts ...memcache.Option) *memcache.Cache[string] {
+	opts = append(opts, memcache.WithClock(s.clock), memcache.WithRegisterer(s.registry))
+	cache, err := memcache.NewCache[string]("products", opts...)
+	s.Require().NoError(err)
+	return cache
+}
+
+func (s *CacheTestSuite) count(metric, label string) float64 {
+	names := map[string]string{"memcache_requests_total": "result", "memcache_evictions_total": "reason"}
+	families, err := s.registry.Gather()
+	s.Require().NoError(err)
+	for _, family := range families {
+		if family.GetName() != metric {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, pair := range m.GetLabel() {
+				if pair.GetName() == names[metric] && pair.GetValue() == label {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func (s *CacheTestSuite) TestGet_StoredValue_ReturnsIt() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", 0))
+
+	// Act
+	value, ok, err := s.sut.Get(ctx, "sku-1")
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal("Keyboard", value)
+	s.InDelta(1, s.count("memcache_requests_total", "hit"), 0)
+}
+
+func (s *CacheTestSuite) TestGet_MissingKey_ReturnsFalse() {
+	// Act
+	value, ok, err := s.sut.Get(context.Background(), "sku-1")
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(ok)
+	s.Empty(value)
+	s.InDelta(1, s.count("memcache_requests_total", "miss"), 0)
+}
+
+func (s *CacheTestSuite) TestGet_AfterTheTTL_DropsTheEntry() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", time.Minute))
+	s.clock.Advance(time.Minute)
+
+	// Act
+	_, ok, err := s.sut.Get(ctx, "sku-1")
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(ok)
+	s.Zero(s.sut.Len())
+	s.InDelta(1, s.count("memcache_evictions_total", "expired"), 0)
+}
+
+func (s *CacheTestSuite) TestSet_WithoutTTL_UsesTheDefaultTTL() {
+	// Arrange
+	ctx := context.Background()
+	s.sut = s.newCache(memcache.WithTTL(time.Hour))
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", 0))
+
+	// Act
+	s.clock.Advance(59 * time.Minute)
+	_, beforeTTL, _ := s.sut.Get(ctx, "sku-1")
+	s.clock.Advance(time.Minute)
+	_, afterTTL, _ := s.sut.Get(ctx, "sku-1")
+
+	// Assert
+	s.True(beforeTTL)
+	s.False(afterTTL)
+}
+
+func (s *CacheTestSuite) TestSet_BeyondTheMaxSize_EvictsTheLeastRecentlyUsedEntry() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", 0))
+	s.Require().NoError(s.sut.Set(ctx, "sku-2", "Mouse", 0))
+	s.Require().NoError(s.sut.Set(ctx, "sku-3", "Monitor", 0))
+	_, _, _ = s.sut.Get(ctx, "sku-1")
+
+	// Act
+	err := s.sut.Set(ctx, "sku-4", "Headset", 0)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(3, s.sut.Len())
+	_, ok, _ := s.sut.Get(ctx, "sku-2")
+	s.False(ok)
+	_, ok, _ = s.sut.Get(ctx, "sku-1")
+	s.True(ok)
+	s.InDelta(1, s.count("memcache_evictions_total", "size"), 0)
+}
+
+func (s *CacheTestSuite) TestSet_ExistingKey_ReplacesTheValue() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", 0))
+
+	// Act
+	err := s.sut.Set(ctx, "sku-1", "Mechanical keyboard", 0)
+
+	// Assert
+	s.Require().NoError(err)
+	value, _, _ := s.sut.Get(ctx, "sku-1")
+	s.Equal("Mechanical keyboard", value)
+	s.Equal(1, s.sut.Len())
+}
+
+func (s *CacheTestSuite) TestSet_WithSizeFunc_BoundsTheTotalSize() {
+	// Arrange
+	ctx := context.Background()
+	s.sut = s.newCache(
+		memcache.WithShards(1),
+		memcache.WithMaxSize(10),
+		memcache.WithSizeFunc(func(value string) int64 { return int64(len(value)) }),
+	)
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Mouse", 0))
+	s.Require().NoError(s.sut.Set(ctx, "sku-2", "Pad", 0))
+
+	// Act
+	err := s.sut.Set(ctx, "sku-3", "Cable", 0)
+
+	// Assert
+	s.Require().NoError(err)
+	_, ok, _ := s.sut.Get(ctx, "sku-1")
+	s.False(ok)
+	s.Equal(2, s.sut.Len())
+}
+
+func (s *CacheTestSuite) TestSet_ValueLargerThanTheMaxSize_IsNotCached() {
+	// Arrange
+	ctx := context.Background()
+	s.sut = s.newCache(
+		memcache.WithShards(1),
+		memcache.WithMaxSize(4),
+		memcache.WithSizeFunc(func(value string) int64 { return int64(len(value)) }),
+	)
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Pad", 0))
+
+	// Act
+	err := s.sut.Set(ctx, "sku-1", "Keyboard", 0)
+
+	// Assert
+	s.Require().NoError(err)
+	_, ok, _ := s.sut.Get(ctx, "sku-1")
+	s.False(ok)
+}
+
+func (s *CacheTestSuite) TestDelete_RemovesTheEntry() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", 0))
+
+	// Act
+	err := s.sut.Delete(ctx, "sku-1")
+
+	// Assert
+	s.Require().NoError(err)
+	_, ok, _ := s.sut.Get(ctx, "sku-1")
+	s.False(ok)
+}
+
+func (s *CacheTestSuite) TestClear_RemovesEveryEntry() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", 0))
+	s.Require().NoError(s.sut.Set(ctx, "sku-2", "Mouse", 0))
+
+	// Act
+	s.sut.Clear()
+
+	// Assert
+	s.Zero(s.sut.Len())
+}
+
+func (s *CacheTestSuite) TestConcurrentUse_KeepsTheEntriesWithinTheMaxSize() {
+	// Arrange
+	ctx := context.Background()
+	s.sut = s.newCache(memcache.WithShards(4), memcache.WithMaxSize(100))
+	var wg sync.WaitGroup
+
+	// Act
+	for worker := range 8 {
+		wg.Go(func() {
+			for i := range 500 {
+				key := fmt.Sprintf("sku-%d", (worker*500+i)%300)
+				_ = s.sut.Set(ctx, key, "value", 0)
+				_, _, _ = s.sut.Get(ctx, key)
+			}
+		})
+	}
+	wg.Wait()
+
+	// Assert
+	s.LessOrEqual(s.sut.Len(), 100)
+}
+
+func TestNewCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		cache   string
+		opts    []memcache.Option
+		wantErr error
+	}{
+		{name: "missing name", cache: "", wantErr: memcache.ErrMissingName},
+		{name: "zero max size", cache: "products", opts: []memcache.Option{memcache.WithMaxSize(0)},
+			wantErr: memcache.ErrInvalidMaxSize},
+		{name: "size func of another type", cache: "products",
+			opts:    []memcache.Option{memcache.WithSizeFunc(func(value []byte) int64 { return int64(len(value)) })},
+			wantErr: memcache.ErrInvalidSizeFunc},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			opts := append(tt.opts, memcache.WithRegisterer(prometheus.NewRegistry()))
+
+			// Act
+			_, err := memcache.NewCache[string](tt.cache, opts...)
+
+			// Assert
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package memcache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	requestsMetricName  = "memcache_requests_total"
+	evictionsMetricName = "memcache_evictions_total"
+
+	resultHit  = "hit"
+	resultMiss = "miss"
+
+	// evictSize means the entry was evicted to make room for another
+	evictSize = "size"
+	// evictExpired means the entry was dropped after its TTL
+	evictExpired = "expired"
+)
+
+type cacheMetrics struct {
+	requests  *prometheus.CounterVec
+	evictions *prometheus.CounterVec
+}
+
+func newCacheMetrics(registerer prometheus.Registerer) (*cacheMetrics, error) {
+	requests := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: requestsMetricName,
+			Help: "Total in-process cache reads by cache and result (hit, miss)",
+		},
+		[]string{"cache", "result"},
+	)
+	evictions := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: evictionsMetricName,
+			Help: "Total in-process cache evictions by cache and reason (size, expired)",
+		},
+		[]string{"cache", "reason"},
+	)
+
+	requests, err := metrics.Register(registerer, requests)
+	if err != nil {
+		return nil, err
+	}
+	if evictions, err = metrics.Register(registerer, evictions); err != nil {
+		return nil, err
+	}
+	return &cacheMetrics{requests: requests, evictions: evictions}, nil
+}
+
+func (m *cacheMetrics) observe(cache, result string) {
+	m.requests.WithLabelValues(cache, result).Inc()
+}
+
+func (m *cacheMetrics) evicted(cache, reason string, count int) {
+	if count > 0 {
+		m.evictions.WithLabelValues(cache, reason).Add(float64(count))
+	}
+}
@@ -0,0 +1,100 @@
+package memcache
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+const (
+	defaultShards  = 16
+	defaultMaxSize = 10_000
+	defaultTTL     = 5 * time.Minute
+)
+
+type options struct {
+	shards     int
+	maxSize    int64
+	ttl        time.Duration
+	sizeFunc   any
+	clock      clock.Clock
+	registerer prometheus.Registerer
+}
+
+// Option configures the Cache created by NewCache.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		shards:     defaultShards,
+		maxSize:    defaultMaxSize,
+		ttl:        defaultTTL,
+		clock:      clock.New(),
+		registerer: prometheus.DefaultRegisterer,
+	}
+}
+
+// WithShards sets the number of shards, each with its own lock and LRU list. More shards
+// mean less contention between the goroutines; the max size is split evenly between them.
+// Default: 16.
+func WithShards(shards int) Option {
+	return func(o *options) {
+		if shards > 0 {
+			o.shards = shards
+		}
+	}
+}
+
+// WithMaxSize bounds the total size of the entries: their number by default, or the sum of
+// their sizes with WithSizeFunc. The least recently used entries are evicted beyond it.
+// Default: 10000.
+func WithMaxSize(maxSize int64) Option {
+	return func(o *options) {
+		o.maxSize = maxSize
+	}
+}
+
+// WithTTL sets the TTL of the entries set without one; 0 keeps them until they are
+// evicted. Default: 5 minutes.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithSizeFunc sizes the entries with fn, e.g. the length of a []byte, so WithMaxSize
+// bounds the memory rather than the number of entries. V must be the value type of the
+// cache, NewCache returns ErrInvalidSizeFunc otherwise.
+//
+//	cache, err := memcache.NewCache[[]byte]("pages",
+//	    memcache.WithMaxSize(64<<20), // 64 MB
+//	    memcache.WithSizeFunc(func(page []byte) int64 { return int64(len(page)) }),
+//	)
+func WithSizeFunc[V any](fn func(value V) int64) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.sizeFunc = fn
+		}
+	}
+}
+
+// WithClock sets the clock of the TTLs.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithRegisterer sets the registerer the cache metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package memcache
+
+import (
+	"context"
+	"time"
+)
+
+// Store is a cache of V values. Cache implements it in process and redis.Cache in Redis,
+// so either can be used where the other is, and Tiered composes the two.
+type Store[V any] interface {
+	// Get returns the value of key, and false when there is none.
+	Get(ctx context.Context, key string) (V, bool, error)
+	// Set stores value in key, expiring after ttl. A zero ttl uses the default of the store:
+	// the TTL of a Cache, no expiration in Redis.
+	Set(ctx context.Context, key string, value V, ttl time.Duration) error
+	// Delete deletes key; a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,64 @@
+package memcache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Tiered is a two-tier cache: reads are served by the local tier, usually a Cache, and
+// fall back to the remote tier, usually a redis.Cache shared by the instances, whose
+// values are then kept locally. Writes go to both tiers.
+//
+// The local copies of the other instances are not invalidated by a write: they are served
+// until their local TTL, which so bounds how stale a read may be.
+type Tiered[V any] struct {
+	local    Store[V]
+	remote   Store[V]
+	localTTL time.Duration
+}
+
+var _ Store[any] = (*Tiered[any])(nil)
+
+// NewTiered creates a Tiered cache keeping the remote values locally for localTTL.
+//
+//	local, err := memcache.NewCache[Product]("products")
+//	products := memcache.NewTiered[Product](local, redis.NewCache[Product](client), 30*time.Second)
+func NewTiered[V any](local, remote Store[V], localTTL time.Duration) *Tiered[V] {
+	return &Tiered[V]{local: local, remote: remote, localTTL: localTTL}
+}
+
+// Get implements Store.
+func (t *Tiered[V]) Get(ctx context.Context, key string) (V, bool, error) {
+	var zero V
+	value, ok, err := t.local.Get(ctx, key)
+	if err != nil || ok {
+		return value, ok, err
+	}
+	value, ok, err = t.remote.Get(ctx, key)
+	if err != nil || !ok {
+		return zero, false, err
+	}
+	if err = t.local.Set(ctx, key, value, t.localTTL); err != nil {
+		return zero, false, err
+	}
+	return value, true, nil
+}
+
+// Set implements Store. The value is kept locally for the shortest of ttl and the local
+// TTL; it is not kept locally when the remote write fails.
+func (t *Tiered[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	if err := t.remote.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	localTTL := t.localTTL
+	if ttl > 0 && (localTTL <= 0 || ttl < localTTL) {
+		localTTL = ttl
+	}
+	return t.local.Set(ctx, key, value, localTTL)
+}
+
+// Delete implements Store, deleting key from both tiers.
+func (t *Tiered[V]) Delete(ctx context.Context, key string) error {
+	return errors.Join(t.local.Delete(ctx, key), t.remote.Delete(ctx, key))
+}
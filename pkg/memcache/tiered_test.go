@@ -0,0 +1,177 @@
+package memcache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/memcache"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+// remoteStore is an in-memory remote tier counting its reads.
+type remoteStore struct {
+	values map[string]string
+	ttls   map[string]time.Duration
+	gets   int
+	err    error
+}
+
+func newRemoteStore() *remoteStore {
+	return &remoteStore{values: make(map[string]string), ttls: make(map[string]time.Duration)}
+}
+
+func (r *remoteStore) Get(_ context.Context, key string) (string, bool, error) {
+	r.gets++
+	if r.err != nil {
+		return "", false, r.err
+	}
+	value, ok := r.values[key]
+	return value, ok, nil
+}
+
+func (r *remoteStore) Set(_ context.Context, key string, value string, ttl time.Duration) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.values[key] = value
+	r.ttls[key] = ttl
+	return nil
+}
+
+func (r *remoteStore) Delete(_ context.Context, key string) error {
+	if r.err != nil {
+		return r.err
+	}
+	delete(r.values, key)
+	return nil
+}
+
+type TieredTestSuite struct {
+	suite.Suite
+	sut    *memcache.Tiered[string]
+	local  *memcache.Cache[string]
+	remote *remoteStore
+	clock  *clock.Fake
+}
+
+func TestTieredSuite(t *testing.T) {
+	suite.Run(t, new(TieredTestSuite))
+}
+
+func (s *TieredTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	var err error
+	s.local, err = memcache.NewCache[string]("products",
+		memcache.WithClock(s.clock),
+		memcache.WithRegisterer(prometheus.NewRegistry()),
+	)
+	s.Require().NoError(err)
+	s.remote = newRemoteStore()
+	s.sut = memcache.NewTiered[string](s.local, s.remote, 30*time.Second)
+}
+
+func (s *TieredTestSuite) TestGet_RemoteValue_IsKeptLocallyForTheLocalTTL() {
+	// Arrange
+	ctx := context.Background()
+	s.remote.values["sku-1"] = "Keyboard"
+
+	// Act
+	first, ok, err := s.sut.Get(ctx, "sku-1")
+	second, _, _ := s.sut.Get(ctx, "sku-1")
+	s.clock.Advance(30 * time.Second)
+	_, _, _ = s.sut.Get(ctx, "sku-1")
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal("Keyboard", first)
+	s.Equal("Keyboard", second)
+	s.Equal(2, s.remote.gets)
+}
+
+func (s *TieredTestSuite) TestGet_MissingKey_ReturnsFalse() {
+	// Act
+	_, ok, err := s.sut.Get(context.Background(), "sku-1")
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(ok)
+	s.Zero(s.local.Len())
+}
+
+func (s *TieredTestSuite) TestGet_RemoteFailure_ReturnsTheError() {
+	// Arrange
+	s.remote.err = errUnavailable
+
+	// Act
+	_, ok, err := s.sut.Get(context.Background(), "sku-1")
+
+	// Assert
+	s.Require().ErrorIs(err, errUnavailable)
+	s.False(ok)
+}
+
+func (s *TieredTestSuite) TestSet_WritesBothTiers() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	err := s.sut.Set(ctx, "sku-1", "Keyboard", time.Hour)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("Keyboard", s.remote.values["sku-1"])
+	s.Equal(time.Hour, s.remote.ttls["sku-1"])
+	value, ok, _ := s.local.Get(ctx, "sku-1")
+	s.True(ok)
+	s.Equal("Keyboard", value)
+}
+
+func (s *TieredTestSuite) TestSet_TTLShorterThanTheLocalTTL_BoundsTheLocalCopy() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", 10*time.Second))
+
+	// Act
+	s.clock.Advance(10 * time.Second)
+	_, ok, _ := s.local.Get(ctx, "sku-1")
+
+	// Assert
+	s.False(ok)
+}
+
+func (s *TieredTestSuite) TestSet_RemoteFailure_SkipsTheLocalTier() {
+	// Arrange
+	ctx := context.Background()
+	s.remote.err = errUnavailable
+
+	// Act
+	err := s.sut.Set(ctx, "sku-1", "Keyboard", time.Hour)
+
+	// Assert
+	s.Require().ErrorIs(err, errUnavailable)
+	_, ok, _ := s.local.Get(ctx, "sku-1")
+	s.False(ok)
+}
+
+func (s *TieredTestSuite) TestDelete_DeletesBothTiers() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Set(ctx, "sku-1", "Keyboard", time.Hour))
+
+	// Act
+	err := s.sut.Delete(ctx, "sku-1")
+
+	// Assert
+	s.Require().NoError(err)
+	s.NotContains(s.remote.values, "sku-1")
+	_, ok, _ := s.local.Get(ctx, "sku-1")
+	s.False(ok)
+}
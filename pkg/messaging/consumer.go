@@ -0,0 +1,321 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Consumer fetches the messages of a Source and handles them on a pool of workers, acking
+// the handled ones and nacking the failed ones. At most WithMaxInFlight messages are
+// fetched and not settled at a time: the consumer stops fetching until the workers catch up.
+type Consumer struct {
+	name      string
+	source    Source
+	handle    BatchHandler
+	batchSize int
+	log       logger.Logger
+	options   options
+	metrics   *consumerMetrics
+
+	// slots holds a token per message in flight
+	slots   chan struct{}
+	next    atomic.Uint64
+	running atomic.Bool
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewConsumer creates a Consumer handling the messages one at a time per worker.
+func NewConsumer(name string, source Source, handler Handler, log logger.Logger, opts ...Option) (*Consumer, error) {
+	if handler == nil {
+		return nil, ErrMissingHandler
+	}
+	c, err := newConsumer(name, source, log, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.batchSize = 1
+	c.handle = func(ctx context.Context, messages []Message) error {
+		return handler(ctx, messages[0])
+	}
+	return c, nil
+}
+
+// NewBatchConsumer creates a Consumer handling the messages in batches, see WithBatch. Each
+// worker accumulates its own batch; with WithOrdering, a batch holds the messages of the
+// keys of its worker, in the fetch order.
+func NewBatchConsumer(
+	name string,
+	source Source,
+	handler BatchHandler,
+	log logger.Logger,
+	opts ...Option,
+) (*Consumer, error) {
+	if handler == nil {
+		return nil, ErrMissingHandler
+	}
+	c, err := newConsumer(name, source, log, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.batchSize = c.options.batchSize
+	c.handle = handler
+	return c, nil
+}
+
+func newConsumer(name string, source Source, log logger.Logger, opts []Option) (*Consumer, error) {
+	if name == "" {
+		return nil, ErrMissingName
+	}
+	if source == nil {
+		return nil, ErrMissingSource
+	}
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	metrics, err := newConsumerMetrics(o.registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &Consumer{
+		name:    name,
+		source:  source,
+		log:     log,
+		options: o,
+		metrics: metrics,
+		slots:   make(chan struct{}, o.maxInFlight),
+	}, nil
+}
+
+// Start runs the consumer in the background until Stop.
+func (c *Consumer) Start() error {
+	if !c.running.CompareAndSwap(false, true) {
+		return ErrConsumerStarted
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	c.mu.Lock()
+	c.cancel, c.done = cancel, done
+	c.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		defer c.running.Store(false)
+		c.run(ctx)
+	}()
+	return nil
+}
+
+// Stop stops fetching, and waits for the fetched messages to be handled or ctx to be done.
+func (c *Consumer) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel, c.done = nil, nil
+	c.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run runs the consumer until ctx is done, then returns once the fetched messages are
+// handled. The handlers receive a context that is not canceled with ctx.
+func (c *Consumer) Run(ctx context.Context) error {
+	if !c.running.CompareAndSwap(false, true) {
+		return ErrConsumerStarted
+	}
+	defer c.running.Store(false)
+	c.run(ctx)
+	return nil
+}
+
+func (c *Consumer) run(ctx context.Context) {
+	// Each queue holds at most the messages in flight, so the fetch loop never blocks on them
+	queues := make([]chan Delivery, 1)
+	if c.options.ordering {
+		queues = make([]chan Delivery, c.options.workers)
+	}
+	for i := range queues {
+		queues[i] = make(chan Delivery, c.options.maxInFlight)
+	}
+
+	handleCtx := context.WithoutCancel(ctx)
+	var workers sync.WaitGroup
+	for i := range c.options.workers {
+		queue := queues[i%len(queues)]
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			c.work(handleCtx, queue)
+		}()
+	}
+
+	c.fetch(ctx, queues)
+	for _, queue := range queues {
+		close(queue)
+	}
+	workers.Wait()
+}
+
+// fetch fetches the messages into the queues until ctx is done, as many at a time as the
+// free slots allow.
+func (c *Consumer) fetch(ctx context.Context, queues []chan Delivery) {
+	for {
+		select {
+		case c.slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		acquired := 1
+		for acquired < c.options.fetchSize && c.tryAcquire() {
+			acquired++
+		}
+
+		deliveries, err := c.source.Fetch(ctx, acquired)
+		if len(deliveries) < acquired {
+			c.release(acquired - len(deliveries))
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.log.Warn("failed to fetch the messages", logger.String("consumer", c.name), logger.Error(err))
+			select {
+			case <-c.options.clock.After(c.options.retryDelay):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		c.metrics.inFlight.WithLabelValues(c.name).Add(float64(len(deliveries)))
+		for _, delivery := range deliveries {
+			queues[c.route(delivery, len(queues))] <- delivery
+		}
+	}
+}
+
+func (c *Consumer) tryAcquire() bool {
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Consumer) release(n int) {
+	for range n {
+		<-c.slots
+	}
+}
+
+// route returns the queue of delivery: the queue of its key with WithOrdering, the next one
+// for the messages without a key.
+func (c *Consumer) route(delivery Delivery, queues int) int {
+	if queues == 1 {
+		return 0
+	}
+	key := delivery.Message().Key
+	if key == "" {
+		return int(c.next.Add(1) % uint64(queues))
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(queues))
+}
+
+func (c *Consumer) work(ctx context.Context, queue <-chan Delivery) {
+	for first := range queue {
+		c.process(ctx, c.collect(first, queue))
+	}
+}
+
+// collect returns the batch starting with first: once it holds the batch size, when the
+// batch wait is over or when the queue is closed.
+func (c *Consumer) collect(first Delivery, queue <-chan Delivery) []Delivery {
+	batch := []Delivery{first}
+	if c.batchSize == 1 {
+		return batch
+	}
+	timer := c.options.clock.NewTimer(c.options.batchWait)
+	defer timer.Stop()
+	for len(batch) < c.batchSize {
+		select {
+		case delivery, ok := <-queue:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, delivery)
+		case <-timer.C():
+			return batch
+		}
+	}
+	return batch
+}
+
+// process handles batch, then acks or nacks its messages. The handler context carries the
+// request and correlation IDs of the headers of the first message.
+func (c *Consumer) process(ctx context.Context, batch []Delivery) {
+	messages := make([]Message, len(batch))
+	for i, delivery := range batch {
+		messages[i] = delivery.Message()
+	}
+
+	handlerCtx := ctxmeta.Extract(ctx, ctxmeta.MapCarrier(messages[0].Headers))
+	start := c.options.clock.Now()
+	err := c.call(handlerCtx, messages)
+	c.metrics.duration.WithLabelValues(c.name).Observe(c.options.clock.Since(start).Seconds())
+
+	result, settle := resultAcked, Delivery.Ack
+	if err != nil {
+		result, settle = resultNacked, Delivery.Nack
+		c.log.Error("message handler failed",
+			logger.String("consumer", c.name),
+			logger.String("message_id", messages[0].ID),
+			logger.Int("messages", len(messages)),
+			logger.Error(err),
+		)
+	}
+	for _, delivery := range batch {
+		if settleErr := settle(delivery, ctx); settleErr != nil {
+			c.log.Warn("failed to settle the message",
+				logger.String("consumer", c.name),
+				logger.String("message_id", delivery.Message().ID),
+				logger.String("result", result),
+				logger.Error(settleErr),
+			)
+		}
+	}
+
+	c.metrics.messages.WithLabelValues(c.name, result).Add(float64(len(batch)))
+	c.metrics.inFlight.WithLabelValues(c.name).Sub(float64(len(batch)))
+	c.release(len(batch))
+}
+
+// call runs the handler, turning its panic into an error wrapping ErrHandlerPanic: a panic
+// nacks the messages of the call without stopping the worker.
+func (c *Consumer) call(ctx context.Context, messages []Message) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, recovered)
+		}
+	}()
+	return c.handle(ctx, messages)
+}
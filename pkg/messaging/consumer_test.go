@@ -0,0 +1,394 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging"
+)
+
+var testLogger = logger.MustNewWithOptions(logger.WithLevel("fatal"))
+
+// channelSource is a Source fetching the messages sent to it, recording how they are settled.
+type channelSource struct {
+	messages chan messaging.Message
+
+	mu      sync.Mutex
+	fetches []int
+	acked   []string
+	nacked  []string
+	settled chan struct{}
+}
+
+func newChannelSource() *channelSource {
+	return &channelSource{messages: make(chan messaging.Message, 100), settled: make(chan struct{}, 100)}
+}
+
+func (s *channelSource) send(messages ...messaging.Message) {
+	for _, message := range messages {
+		s.messages <- message
+	}
+}
+
+func (s *channelSource) Fetch(ctx context.Context, limit int) ([]messaging.Delivery, error) {
+	s.mu.Lock()
+	s.fetches = append(s.fetches, limit)
+	s.mu.Unlock()
+
+	var deliveries []messaging.Delivery
+	select {
+	case message := <-s.messages:
+		deliveries = append(deliveries, &delivery{source: s, message: message})
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	for len(deliveries) < limit {
+		select {
+		case message := <-s.messages:
+			deliveries = append(deliveries, &delivery{source: s, message: message})
+		default:
+			return deliveries, nil
+		}
+	}
+	return deliveries, nil
+}
+
+// wait waits until n messages are settled.
+func (s *channelSource) wait(t *testing.T, n int) {
+	t.Helper()
+	for range n {
+		select {
+		case <-s.settled:
+		case <-time.After(time.Second):
+			t.Fatal("messages not settled in time")
+		}
+	}
+}
+
+func (s *channelSource) results() ([]string, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.acked...), append([]string(nil), s.nacked...)
+}
+
+type delivery struct {
+	source  *channelSource
+	message messaging.Message
+}
+
+func (d *delivery) Message() messaging.Message { return d.message }
+
+func (d *delivery) Ack(context.Context) error {
+	d.source.mu.Lock()
+	d.source.acked = append(d.source.acked, d.message.ID)
+	d.source.mu.Unlock()
+	d.source.settled <- struct{}{}
+	return nil
+}
+
+func (d *delivery) Nack(context.Context) error {
+	d.source.mu.Lock()
+	d.source.nacked = append(d.source.nacked, d.message.ID)
+	d.source.mu.Unlock()
+	d.source.settled <- struct{}{}
+	return nil
+}
+
+func start(t *testing.T, consumer *messaging.Consumer) {
+	t.Helper()
+	require.NoError(t, consumer.Start())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, consumer.Stop(ctx))
+	})
+}
+
+func messages(key string, ids ...string) []messaging.Message {
+	result := make([]messaging.Message, len(ids))
+	for i, id := range ids {
+		result[i] = messaging.Message{ID: id, Key: key}
+	}
+	return result
+}
+
+func TestNewConsumer(t *testing.T) {
+	handler := func(context.Context, messaging.Message) error { return nil }
+	tests := []struct {
+		name     string
+		consumer string
+		source   messaging.Source
+		handler  messaging.Handler
+		wantErr  error
+	}{
+		{name: "missing name", source: newChannelSource(), handler: handler, wantErr: messaging.ErrMissingName},
+		{name: "missing source", consumer: "billing", handler: handler, wantErr: messaging.ErrMissingSource},
+		{name: "missing handler", consumer: "billing", source: newChannelSource(), wantErr: messaging.ErrMissingHandler},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := messaging.NewConsumer(tt.consumer, tt.source, tt.handler, testLogger)
+
+			// Assert
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConsumer_Handle(t *testing.T) {
+	t.Run("acks the handled messages and nacks the failed and panicking ones", func(t *testing.T) {
+		// Arrange
+		source := newChannelSource()
+		registry := prometheus.NewRegistry()
+		consumer, err := messaging.NewConsumer("billing", source, func(_ context.Context, m messaging.Message) error {
+			switch m.ID {
+			case "fail":
+				return errors.New("card declined")
+			case "panic":
+				panic("nil charge")
+			}
+			return nil
+		}, testLogger, messaging.WithRegisterer(registry))
+		require.NoError(t, err)
+		start(t, consumer)
+
+		// Act
+		source.send(messages("", "1", "fail", "panic", "2")...)
+		source.wait(t, 4)
+
+		// Assert
+		acked, nacked := source.results()
+		assert.ElementsMatch(t, []string{"1", "2"}, acked)
+		assert.ElementsMatch(t, []string{"fail", "panic"}, nacked)
+		expected := `
+# HELP messaging_messages_total Total messages handled by consumer and result (acked, nacked)
+# TYPE messaging_messages_total counter
+messaging_messages_total{consumer="billing",result="acked"} 2
+messaging_messages_total{consumer="billing",result="nacked"} 2
+`
+		assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "messaging_messages_total"))
+	})
+
+	t.Run("handles the messages of a key one at a time in order", func(t *testing.T) {
+		// Arrange
+		source := newChannelSource()
+		var mu sync.Mutex
+		handled := map[string][]string{}
+		running := map[string]bool{}
+		overlapped := false
+		consumer, err := messaging.NewConsumer("orders", source, func(_ context.Context, m messaging.Message) error {
+			mu.Lock()
+			overlapped = overlapped || running[m.Key]
+			running[m.Key] = true
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running[m.Key] = false
+			handled[m.Key] = append(handled[m.Key], m.ID)
+			mu.Unlock()
+			return nil
+		}, testLogger, messaging.WithOrdering(), messaging.WithWorkers(4),
+			messaging.WithRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		start(t, consumer)
+
+		// Act
+		for i := range 10 {
+			source.send(messaging.Message{ID: fmt.Sprint(i), Key: "order-a"})
+			source.send(messaging.Message{ID: fmt.Sprint(i), Key: "order-b"})
+		}
+		source.wait(t, 20)
+
+		// Assert
+		mu.Lock()
+		defer mu.Unlock()
+		want := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+		assert.Equal(t, want, handled["order-a"])
+		assert.Equal(t, want, handled["order-b"])
+		assert.False(t, overlapped)
+	})
+}
+
+func TestConsumer_HandlerContext(t *testing.T) {
+	t.Run("carries the request and correlation IDs of the message headers", func(t *testing.T) {
+		// Arrange
+		source := newChannelSource()
+		type ids struct{ request, correlation string }
+		got := make(chan ids, 1)
+		consumer, err := messaging.NewConsumer("billing", source, func(ctx context.Context, _ messaging.Message) error {
+			requestID, _ := ctxmeta.RequestID(ctx)
+			correlationID, _ := ctxmeta.CorrelationID(ctx)
+			got <- ids{request: requestID, correlation: correlationID}
+			return nil
+		}, testLogger, messaging.WithRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		start(t, consumer)
+
+		// Act
+		source.send(messaging.Message{ID: "1", Headers: map[string]string{
+			ctxmeta.HeaderRequestID:     "req-1",
+			ctxmeta.HeaderCorrelationID: "corr-1",
+		}})
+		source.wait(t, 1)
+
+		// Assert
+		assert.Equal(t, ids{request: "req-1", correlation: "corr-1"}, <-got)
+	})
+
+	t.Run("measures the handler duration with the clock", func(t *testing.T) {
+		// Arrange
+		source := newChannelSource()
+		registry := prometheus.NewRegistry()
+		clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		consumer, err := messaging.NewConsumer("billing", source, func(context.Context, messaging.Message) error {
+			clk.Advance(2 * time.Second)
+			return nil
+		}, testLogger, messaging.WithRegisterer(registry), messaging.WithClock(clk))
+		require.NoError(t, err)
+		start(t, consumer)
+
+		// Act
+		source.send(messaging.Message{ID: "1"})
+		source.wait(t, 1)
+
+		// Assert
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		var sum float64
+		for _, family := range families {
+			if family.GetName() == "messaging_handler_duration_seconds" {
+				sum = family.GetMetric()[0].GetHistogram().GetSampleSum()
+			}
+		}
+		assert.InDelta(t, 2.0, sum, 0.001)
+	})
+}
+
+func TestConsumer_Backpressure(t *testing.T) {
+	t.Run("stops fetching while the max in flight messages are handled", func(t *testing.T) {
+		// Arrange
+		source := newChannelSource()
+		release := make(chan struct{})
+		consumer, err := messaging.NewConsumer("billing", source, func(context.Context, messaging.Message) error {
+			<-release
+			return nil
+		}, testLogger, messaging.WithMaxInFlight(2), messaging.WithFetchSize(10),
+			messaging.WithRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		source.send(messages("", "1", "2", "3")...)
+
+		// Act
+		start(t, consumer)
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert
+		source.mu.Lock()
+		assert.Equal(t, []int{2}, source.fetches)
+		source.mu.Unlock()
+		assert.Len(t, source.messages, 1)
+
+		close(release)
+		source.wait(t, 3)
+	})
+}
+
+func TestBatchConsumer(t *testing.T) {
+	t.Run("handles full batches and the partial batch after the wait", func(t *testing.T) {
+		// Arrange
+		source := newChannelSource()
+		batches := make(chan []string, 10)
+		consumer, err := messaging.NewBatchConsumer("analytics", source,
+			func(_ context.Context, messages []messaging.Message) error {
+				ids := make([]string, len(messages))
+				for i, m := range messages {
+					ids[i] = m.ID
+				}
+				batches <- ids
+				return nil
+			}, testLogger, messaging.WithWorkers(1), messaging.WithBatch(3, 50*time.Millisecond),
+			messaging.WithRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		start(t, consumer)
+
+		// Act
+		source.send(messages("", "1", "2", "3", "4")...)
+		source.wait(t, 4)
+
+		// Assert
+		assert.Equal(t, []string{"1", "2", "3"}, <-batches)
+		assert.Equal(t, []string{"4"}, <-batches)
+	})
+
+	t.Run("nacks the whole batch on error", func(t *testing.T) {
+		// Arrange
+		source := newChannelSource()
+		consumer, err := messaging.NewBatchConsumer("analytics", source,
+			func(context.Context, []messaging.Message) error {
+				return errors.New("warehouse unavailable")
+			}, testLogger, messaging.WithWorkers(1), messaging.WithBatch(2, time.Second),
+			messaging.WithRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		start(t, consumer)
+
+		// Act
+		source.send(messages("", "1", "2")...)
+		source.wait(t, 2)
+
+		// Assert
+		acked, nacked := source.results()
+		assert.Empty(t, acked)
+		assert.Equal(t, []string{"1", "2"}, nacked)
+	})
+}
+
+func TestConsumer_Run(t *testing.T) {
+	t.Run("handles the fetched messages before returning", func(t *testing.T) {
+		// Arrange
+		source := newChannelSource()
+		ctx, cancel := context.WithCancel(context.Background())
+		consumer, err := messaging.NewConsumer("billing", source, func(context.Context, messaging.Message) error {
+			cancel()
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}, testLogger, messaging.WithRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		source.send(messages("", "1")...)
+
+		// Act
+		err = consumer.Run(ctx)
+
+		// Assert
+		require.NoError(t, err)
+		acked, _ := source.results()
+		assert.Equal(t, []string{"1"}, acked)
+	})
+
+	t.Run("rejects a consumer already started", func(t *testing.T) {
+		// Arrange
+		consumer, err := messaging.NewConsumer("billing", newChannelSource(),
+			func(context.Context, messaging.Message) error { return nil },
+			testLogger, messaging.WithRegisterer(prometheus.NewRegistry()))
+		require.NoError(t, err)
+		start(t, consumer)
+
+		// Act
+		err = consumer.Run(context.Background())
+
+		// Assert
+		require.ErrorIs(t, err, messaging.ErrConsumerStarted)
+	})
+}
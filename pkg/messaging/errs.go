@@ -0,0 +1,11 @@
+package messaging
+
+import "errors"
+
+var (
+	ErrHandlerPanic    = errors.New("message handler panicked")
+	ErrMissingName     = errors.New("consumer name is required")
+	ErrMissingSource   = errors.New("consumer source is required")
+	ErrMissingHandler  = errors.New("consumer handler is required")
+	ErrConsumerStarted = errors.New("consumer already started")
+)
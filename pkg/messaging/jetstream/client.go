@@ -0,0 +1,222 @@
+// Package jetstream is the NATS JetStream driver of the messaging package: the Client
+// publishes to the streams with deduplicated message IDs, and its Sources fetch the messages
+// of durable pull consumers, acked and nacked by the messaging.Consumer.
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+const (
+	// MsgIDHeader is the header deduplicating the published messages within the duplicate
+	// window of the stream, and the ID of the consumed messages
+	MsgIDHeader = jetstream.MsgIDHeader
+	// KeyHeader is the header of the message key
+	KeyHeader = "Message-Key"
+)
+
+// Client is a connection to NATS JetStream. nats.go reconnects it after a failure; once
+// closed, e.g. out of reconnect attempts, it is dialed again on its next use.
+type Client struct {
+	cfg Config
+	ids ident.Generator
+
+	mu sync.Mutex
+	nc *nats.Conn
+	js jetstream.JetStream
+}
+
+// New creates a Client of cfg. It connects on Connect or on its first use.
+func New(cfg Config, opts ...Option) (*Client, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Client{cfg: cfg, ids: o.ids}, nil
+}
+
+// Connect connects to the server and creates or updates the configured streams.
+func (c *Client) Connect(ctx context.Context) error {
+	if _, err := c.connection(ctx); err != nil {
+		return err
+	}
+	for name, stream := range c.cfg.Streams {
+		if err := c.EnsureStream(ctx, name, stream); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nc != nil {
+		c.nc.Close()
+		c.nc, c.js = nil, nil
+	}
+	return nil
+}
+
+// Publish publishes payload to the subject topic and waits for the stream to store it. The
+// message gets a new ID in MsgIDHeader, and key in KeyHeader when not empty.
+func (c *Client) Publish(ctx context.Context, topic, key string, payload []byte) error {
+	header := map[string]string{MsgIDHeader: c.ids.NewID().String()}
+	if key != "" {
+		header[KeyHeader] = key
+	}
+	return c.PublishMsg(ctx, topic, header, payload)
+}
+
+// PublishMsg publishes payload with header to the subject topic and waits for the stream to
+// store it. A message with the MsgIDHeader of a message stored within the duplicate window
+// is acknowledged without being stored again. The request and correlation IDs of ctx are
+// added to the headers not set in header.
+func (c *Client) PublishMsg(ctx context.Context, topic string, header map[string]string, payload []byte) error {
+	js, err := c.connection(ctx)
+	if err != nil {
+		return err
+	}
+	carrier := make(ctxmeta.MapCarrier, len(header)+2)
+	maps.Copy(carrier, header)
+	ctxmeta.Inject(ctx, carrier)
+	msg := nats.NewMsg(topic)
+	for key, value := range carrier {
+		msg.Header.Set(key, value)
+	}
+	msg.Data = payload
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+	defer cancel()
+	if _, err = js.PublishMsg(ctx, msg); err != nil {
+		if errors.Is(err, jetstream.ErrNoStreamResponse) || errors.Is(err, nats.ErrNoResponders) {
+			return fmt.Errorf("publish to %s: %w", topic, ErrNoResponders)
+		}
+		return fmt.Errorf("publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// EnsureStream creates the stream name, or updates it when it exists.
+func (c *Client) EnsureStream(ctx context.Context, name string, cfg StreamConfig) error {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("stream %s: %w", name, err)
+	}
+	js, err := c.connection(ctx)
+	if err != nil {
+		return err
+	}
+	storage := jetstream.FileStorage
+	if cfg.Storage == StorageMemory {
+		storage = jetstream.MemoryStorage
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+	defer cancel()
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       name,
+		Subjects:   cfg.Subjects,
+		Retention:  jetstream.LimitsPolicy,
+		Discard:    jetstream.DiscardOld,
+		Storage:    storage,
+		Replicas:   cfg.Replicas,
+		MaxAge:     cfg.MaxAge,
+		Duplicates: cfg.DuplicateWindow,
+		MaxMsgs:    -1,
+		MaxBytes:   -1,
+	})
+	if err != nil {
+		return fmt.Errorf("ensure stream %s: %w", name, err)
+	}
+	return nil
+}
+
+// EnsureConsumer creates the durable consumer of cfg, or updates it when it exists.
+func (c *Client) EnsureConsumer(ctx context.Context, cfg ConsumerConfig) error {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	_, _, err := c.consumer(ctx, cfg)
+	return err
+}
+
+// Source returns the Source of the consumer configured under name.
+func (c *Client) Source(name string) (*Source, error) {
+	cfg, ok := c.cfg.Consumers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownConsumer, name)
+	}
+	return NewSource(c, cfg)
+}
+
+// consumer creates or updates the durable consumer of cfg, and returns it with the
+// JetStream context it is bound to.
+func (c *Client) consumer(ctx context.Context, cfg ConsumerConfig) (jetstream.Consumer, jetstream.JetStream, error) {
+	js, err := c.connection(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+	defer cancel()
+	consumer, err := js.CreateOrUpdateConsumer(ctx, cfg.Stream, jetstream.ConsumerConfig{
+		Durable:       cfg.Durable,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       cfg.AckWait,
+		MaxDeliver:    cfg.MaxDeliver,
+		MaxAckPending: cfg.MaxAckPending,
+		FilterSubject: cfg.FilterSubject,
+		ReplayPolicy:  jetstream.ReplayInstantPolicy,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("ensure consumer %s of stream %s: %w", cfg.Durable, cfg.Stream, err)
+	}
+	return consumer, js, nil
+}
+
+// connection returns the JetStream context of the connection, dialing it when there is none
+// or it is closed.
+func (c *Client) connection(ctx context.Context) (jetstream.JetStream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nc != nil && !c.nc.IsClosed() {
+		return c.js, nil
+	}
+	options := []nats.Option{nats.Timeout(c.cfg.ConnectTimeout)}
+	if c.cfg.Name != "" {
+		options = append(options, nats.Name(c.cfg.Name))
+	}
+	if c.cfg.Token != "" {
+		options = append(options, nats.Token(c.cfg.Token))
+	}
+	nc, err := nats.Connect(c.cfg.URL, options...)
+	if err != nil {
+		return nil, fmt.Errorf("connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+	c.nc, c.js = nc, js
+	return js, nil
+}
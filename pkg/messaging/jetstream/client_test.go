@@ -0,0 +1,317 @@
+package jetstream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	natsjetstream "github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging/jetstream"
+)
+
+// startServer starts an embedded NATS server with JetStream, shut down with the test.
+func startServer(t *testing.T, configure ...func(*server.Options)) *server.Server {
+	t.Helper()
+	opts := &server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	}
+	for _, c := range configure {
+		c(opts)
+	}
+	ns, err := server.NewServer(opts)
+	require.NoError(t, err)
+	go ns.Start()
+	require.True(t, ns.ReadyForConnections(5*time.Second), "NATS server not ready")
+	t.Cleanup(ns.Shutdown)
+	return ns
+}
+
+func newClient(t *testing.T, ns *server.Server, cfg jetstream.Config) *jetstream.Client {
+	t.Helper()
+	cfg.URL = ns.ClientURL()
+	client, err := jetstream.New(cfg, jetstream.WithIDGenerator(ident.NewSequence()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// inspect returns a JetStream context of its own connection to ns, to check the server state.
+func inspect(t *testing.T, ns *server.Server) natsjetstream.JetStream {
+	t.Helper()
+	nc, err := nats.Connect(ns.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+	js, err := natsjetstream.New(nc)
+	require.NoError(t, err)
+	return js
+}
+
+var ordersStream = map[string]jetstream.StreamConfig{
+	"ORDERS": {Subjects: []string{"orders.>"}, Storage: jetstream.StorageMemory},
+}
+
+func TestClient_Publish(t *testing.T) {
+	t.Run("publishes with a message ID and the key, and waits for the ack", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{Streams: ordersStream})
+		require.NoError(t, client.Connect(context.Background()))
+
+		// Act
+		err := client.Publish(context.Background(), "orders.placed", "order-1", []byte(`{"id":1}`))
+
+		// Assert
+		require.NoError(t, err)
+		stream, err := inspect(t, ns).Stream(context.Background(), "ORDERS")
+		require.NoError(t, err)
+		stored, err := stream.GetMsg(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "orders.placed", stored.Subject)
+		assert.Equal(t, ident.SequenceID(1).String(), stored.Header.Get(jetstream.MsgIDHeader))
+		assert.Equal(t, "order-1", stored.Header.Get(jetstream.KeyHeader))
+		assert.JSONEq(t, `{"id":1}`, string(stored.Data))
+	})
+
+	t.Run("propagates the request and correlation IDs of the context", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{Streams: ordersStream})
+		require.NoError(t, client.Connect(context.Background()))
+		ctx := ctxmeta.WithCorrelationID(ctxmeta.WithRequestID(context.Background(), "req-1"), "corr-1")
+
+		// Act
+		err := client.Publish(ctx, "orders.placed", "order-1", []byte(`{"id":1}`))
+
+		// Assert
+		require.NoError(t, err)
+		stream, err := inspect(t, ns).Stream(context.Background(), "ORDERS")
+		require.NoError(t, err)
+		stored, err := stream.GetMsg(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "req-1", stored.Header.Get(ctxmeta.HeaderRequestID))
+		assert.Equal(t, "corr-1", stored.Header.Get(ctxmeta.HeaderCorrelationID))
+	})
+
+	t.Run("stores a message ID once within the duplicate window", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{Streams: ordersStream})
+		require.NoError(t, client.Connect(context.Background()))
+		header := map[string]string{jetstream.MsgIDHeader: "payment-42"}
+
+		// Act
+		firstErr := client.PublishMsg(context.Background(), "orders.paid", header, []byte("first"))
+		retryErr := client.PublishMsg(context.Background(), "orders.paid", header, []byte("retry"))
+
+		// Assert
+		require.NoError(t, firstErr)
+		require.NoError(t, retryErr)
+		stream, err := inspect(t, ns).Stream(context.Background(), "ORDERS")
+		require.NoError(t, err)
+		info, err := stream.Info(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), info.State.Msgs)
+	})
+
+	t.Run("returns ErrNoResponders without a stream for the subject", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{})
+
+		// Act
+		err := client.Publish(context.Background(), "unknown", "", []byte(`{}`))
+
+		// Assert
+		require.ErrorIs(t, err, jetstream.ErrNoResponders)
+	})
+}
+
+func TestClient_Connect(t *testing.T) {
+	t.Run("updates a stream that already exists", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		first := newClient(t, ns, jetstream.Config{Streams: ordersStream})
+		require.NoError(t, first.Connect(context.Background()))
+		client := newClient(t, ns, jetstream.Config{Streams: map[string]jetstream.StreamConfig{
+			"ORDERS": {Subjects: []string{"orders.>"}, Storage: jetstream.StorageMemory, MaxAge: time.Hour},
+		}})
+
+		// Act
+		err := client.Connect(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		stream, err := inspect(t, ns).Stream(context.Background(), "ORDERS")
+		require.NoError(t, err)
+		info, err := stream.Info(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"orders.>"}, info.Config.Subjects)
+		assert.Equal(t, time.Hour, info.Config.MaxAge)
+	})
+
+	t.Run("returns the API error of a stream with overlapping subjects", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{Streams: ordersStream})
+		require.NoError(t, client.Connect(context.Background()))
+
+		// Act
+		err := client.EnsureStream(context.Background(), "PAYMENTS", jetstream.StreamConfig{
+			Subjects: []string{"orders.paid"}, Storage: jetstream.StorageMemory,
+		})
+
+		// Assert
+		var apiErr *jetstream.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, natsjetstream.ErrorCode(10065), apiErr.ErrorCode) // subjects overlap with an existing stream
+	})
+
+	t.Run("returns the authorization error of the server", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t, func(o *server.Options) { o.Authorization = "s3cr3t" })
+		client := newClient(t, ns, jetstream.Config{})
+
+		// Act
+		err := client.Connect(context.Background())
+
+		// Assert
+		require.ErrorIs(t, err, nats.ErrAuthorization)
+	})
+
+	t.Run("authenticates with the token", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t, func(o *server.Options) { o.Authorization = "s3cr3t" })
+		client := newClient(t, ns, jetstream.Config{Token: "s3cr3t", Streams: ordersStream})
+
+		// Act
+		err := client.Connect(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
+
+func TestSource_Fetch(t *testing.T) {
+	t.Run("creates the consumer and returns the available messages", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{
+			Streams: ordersStream,
+			Consumers: map[string]jetstream.ConsumerConfig{
+				"billing": {Stream: "ORDERS", FilterSubject: "orders.placed", AckWait: time.Minute},
+			},
+		})
+		require.NoError(t, client.Connect(context.Background()))
+		require.NoError(t, client.Publish(context.Background(), "orders.placed", "order-1", []byte(`{"id":1}`)))
+		require.NoError(t, client.Publish(context.Background(), "orders.paid", "order-1", []byte(`{"id":1}`)))
+		source, err := client.Source("billing")
+		require.NoError(t, err)
+
+		// Act
+		deliveries, err := source.Fetch(context.Background(), 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		message := deliveries[0].Message()
+		assert.Equal(t, ident.SequenceID(1).String(), message.ID)
+		assert.Equal(t, "orders.placed", message.Topic)
+		assert.Equal(t, "order-1", message.Key)
+		assert.Equal(t, 1, message.Attempt)
+		assert.JSONEq(t, `{"id":1}`, string(message.Payload))
+		consumer, err := inspect(t, ns).Consumer(context.Background(), "ORDERS", "billing")
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, consumer.CachedInfo().Config.AckWait)
+	})
+
+	t.Run("waits for the next message when none is available", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{Streams: ordersStream})
+		require.NoError(t, client.Connect(context.Background()))
+		source, err := jetstream.NewSource(client, jetstream.ConsumerConfig{Stream: "ORDERS", Durable: "billing"})
+		require.NoError(t, err)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			_ = client.Publish(context.Background(), "orders.placed", "", []byte("late"))
+		}()
+
+		// Act
+		deliveries, err := source.Fetch(context.Background(), 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "late", string(deliveries[0].Message().Payload))
+	})
+
+	t.Run("delivers a nacked message again after the nack delay", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{Streams: ordersStream})
+		require.NoError(t, client.Connect(context.Background()))
+		require.NoError(t, client.Publish(context.Background(), "orders.placed", "", []byte("order-1")))
+		require.NoError(t, client.Publish(context.Background(), "orders.placed", "", []byte("order-2")))
+		source, err := jetstream.NewSource(client, jetstream.ConsumerConfig{
+			Stream: "ORDERS", Durable: "billing", NackDelay: 50 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		deliveries, err := source.Fetch(context.Background(), 2)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+
+		// Act
+		ackErr := deliveries[0].Ack(context.Background())
+		nackErr := deliveries[1].Nack(context.Background())
+		redelivered, err := source.Fetch(context.Background(), 2)
+
+		// Assert
+		require.NoError(t, ackErr)
+		require.NoError(t, nackErr)
+		require.NoError(t, err)
+		require.Len(t, redelivered, 1)
+		assert.Equal(t, "order-2", string(redelivered[0].Message().Payload))
+		assert.Equal(t, 2, redelivered[0].Message().Attempt)
+	})
+
+	t.Run("returns the error of a done context", func(t *testing.T) {
+		// Arrange
+		ns := startServer(t)
+		client := newClient(t, ns, jetstream.Config{Streams: ordersStream})
+		require.NoError(t, client.Connect(context.Background()))
+		source, err := jetstream.NewSource(client, jetstream.ConsumerConfig{Stream: "ORDERS", Durable: "billing"})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// Act
+		_, err = source.Fetch(ctx, 10)
+
+		// Assert
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("returns ErrUnknownConsumer for a consumer not configured", func(t *testing.T) {
+		// Arrange
+		client, err := jetstream.New(jetstream.Config{})
+		require.NoError(t, err)
+
+		// Act
+		_, err = client.Source("billing")
+
+		// Assert
+		require.ErrorIs(t, err, jetstream.ErrUnknownConsumer)
+	})
+}
@@ -0,0 +1,86 @@
+package jetstream_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/messaging/jetstream"
+)
+
+func TestConfig_SetDefaults(t *testing.T) {
+	// Arrange
+	cfg := jetstream.Config{
+		Streams:   map[string]jetstream.StreamConfig{"ORDERS": {Subjects: []string{"orders.>"}}},
+		Consumers: map[string]jetstream.ConsumerConfig{"billing": {Stream: "ORDERS"}},
+	}
+
+	// Act
+	cfg.SetDefaults()
+
+	// Assert
+	assert.Equal(t, "nats://localhost:4222", cfg.URL)
+	assert.Equal(t, 5*time.Second, cfg.FetchWait)
+	assert.Equal(t, jetstream.StorageFile, cfg.Streams["ORDERS"].Storage)
+	assert.Equal(t, 2*time.Minute, cfg.Streams["ORDERS"].DuplicateWindow)
+	assert.Equal(t, jetstream.ConsumerConfig{
+		Stream:        "ORDERS",
+		Durable:       "billing",
+		AckWait:       30 * time.Second,
+		MaxDeliver:    5,
+		MaxAckPending: 1000,
+	}, cfg.Consumers["billing"])
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     jetstream.Config
+		wantErr error
+	}{
+		{
+			name: "valid",
+			cfg:  jetstream.Config{URL: "tls://nats.internal:4222"},
+		},
+		{
+			name:    "invalid URL scheme",
+			cfg:     jetstream.Config{URL: "http://nats.internal:4222"},
+			wantErr: jetstream.ErrInvalidURL,
+		},
+		{
+			name:    "stream without subjects",
+			cfg:     jetstream.Config{Streams: map[string]jetstream.StreamConfig{"ORDERS": {}}},
+			wantErr: jetstream.ErrMissingSubjects,
+		},
+		{
+			name: "invalid storage",
+			cfg: jetstream.Config{Streams: map[string]jetstream.StreamConfig{
+				"ORDERS": {Subjects: []string{"orders.>"}, Storage: "disk"},
+			}},
+			wantErr: jetstream.ErrInvalidStorage,
+		},
+		{
+			name:    "consumer without stream",
+			cfg:     jetstream.Config{Consumers: map[string]jetstream.ConsumerConfig{"billing": {}}},
+			wantErr: jetstream.ErrMissingStream,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.cfg.SetDefaults()
+
+			// Act
+			err := tt.cfg.Validate()
+
+			// Assert
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package jetstream
+
+import (
+	"errors"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+var (
+	ErrInvalidURL      = errors.New("invalid NATS URL (must be nats://host:port or tls://host:port)")
+	ErrMissingSubjects = errors.New("stream requires at least one subject")
+	ErrInvalidStorage  = errors.New("invalid stream storage (must be 'file' or 'memory')")
+	ErrMissingStream   = errors.New("consumer stream is required")
+	ErrMissingDurable  = errors.New("consumer durable name is required")
+	ErrUnknownConsumer = errors.New("consumer not configured")
+	ErrNoResponders    = errors.New("no responders: JetStream disabled or no stream for the subject")
+)
+
+// APIError is an error returned by the JetStream API, e.g. stream not found: its Code is the
+// HTTP like status, e.g. 404, and its ErrorCode the JetStream error code, e.g. 10059.
+type APIError = jetstream.APIError
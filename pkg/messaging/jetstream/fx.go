@@ -0,0 +1,57 @@
+package jetstream
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging"
+)
+
+// Module provides the *Client configured under "app.messaging.jetstream", connected with
+// its streams created on start and closed on stop, and the client as a messaging.Publisher.
+//
+// Usage in your application:
+//
+//	fx.New(
+//	    jetstream.Module,
+//	    fx.Provide(func(client *jetstream.Client, log logger.Logger) (*messaging.Consumer, error) {
+//	        source, err := client.Source("billing")
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        return messaging.NewConsumer("billing", source, handler.Handle, log)
+//	    }),
+//	)
+var Module = fx.Module("jetstream",
+	config.Provide[Config]("app.messaging.jetstream"),
+	fx.Provide(
+		NewWithLifecycle,
+		func(client *Client) messaging.Publisher { return client },
+	),
+)
+
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    config.Config[Config]
+	IDs       ident.Generator `optional:"true"`
+}
+
+// NewWithLifecycle creates the Client, connects it on start and closes it on stop.
+func NewWithLifecycle(p NewWithLifecycleParams) (*Client, error) {
+	client, err := New(p.Config.Get(), WithIDGenerator(p.IDs))
+	if err != nil {
+		return nil, err
+	}
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: client.Connect,
+		OnStop: func(context.Context) error {
+			return client.Close()
+		},
+	})
+	return client, nil
+}
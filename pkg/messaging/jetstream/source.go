@@ -0,0 +1,143 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/cristiano-pacheco/bricks/pkg/messaging"
+)
+
+// Source is the messaging.Source of a durable pull consumer, created or updated on its first
+// fetch.
+type Source struct {
+	client *Client
+	cfg    ConsumerConfig
+
+	mu       sync.Mutex
+	consumer jetstream.Consumer
+	// js is the JetStream context of consumer, to create it again on a new connection
+	js jetstream.JetStream
+}
+
+// NewSource creates the Source of the durable consumer of cfg.
+func NewSource(client *Client, cfg ConsumerConfig) (*Source, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Source{client: client, cfg: cfg}, nil
+}
+
+// Fetch returns the messages available, up to max, or waits for the next one. A message
+// fetched while ctx is done is delivered again after the ack wait.
+func (s *Source) Fetch(ctx context.Context, max int) ([]messaging.Delivery, error) {
+	consumer, err := s.ensure(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the backlog without waiting, then wait for a single message: a pull request
+	// waiting for a full batch would hold the first messages until the batch fills up
+	batch, err := consumer.FetchNoWait(max)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.collect(batch)
+	for err == nil && len(deliveries) == 0 {
+		deliveries, err = s.wait(ctx, consumer)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	return deliveries, err
+}
+
+// wait fetches the next message, waiting for it up to the fetch wait.
+func (s *Source) wait(ctx context.Context, consumer jetstream.Consumer) ([]messaging.Delivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.client.cfg.FetchWait)
+	defer cancel()
+	batch, err := consumer.Fetch(1, jetstream.FetchContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.collect(batch)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return deliveries, nil
+	}
+	return deliveries, err
+}
+
+func (s *Source) ensure(ctx context.Context) (jetstream.Consumer, error) {
+	js, err := s.client.connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.consumer != nil && s.js == js {
+		return s.consumer, nil
+	}
+	consumer, js, err := s.client.consumer(ctx, s.cfg)
+	if err != nil {
+		return nil, err
+	}
+	s.consumer, s.js = consumer, js
+	return consumer, nil
+}
+
+// collect returns the deliveries of the messages of batch, once it is over.
+func (s *Source) collect(batch jetstream.MessageBatch) ([]messaging.Delivery, error) {
+	var deliveries []messaging.Delivery
+	for msg := range batch.Messages() {
+		deliveries = append(deliveries, s.newDelivery(msg))
+	}
+	return deliveries, batch.Error()
+}
+
+func (s *Source) newDelivery(msg jetstream.Msg) *delivery {
+	header := make(map[string]string, len(msg.Headers()))
+	for key := range msg.Headers() {
+		header[key] = msg.Headers().Get(key)
+	}
+	message := messaging.Message{
+		ID:      header[MsgIDHeader],
+		Topic:   msg.Subject(),
+		Key:     header[KeyHeader],
+		Headers: header,
+		Payload: msg.Data(),
+	}
+	if metadata, err := msg.Metadata(); err == nil {
+		if message.ID == "" {
+			message.ID = metadata.Stream + ":" + strconv.FormatUint(metadata.Sequence.Stream, 10)
+		}
+		message.Attempt = int(metadata.NumDelivered)
+	}
+	return &delivery{msg: msg, nackDelay: s.cfg.NackDelay, message: message}
+}
+
+// delivery acks and nacks a message of a pull consumer.
+type delivery struct {
+	msg       jetstream.Msg
+	nackDelay time.Duration
+	message   messaging.Message
+}
+
+func (d *delivery) Message() messaging.Message {
+	return d.message
+}
+
+func (d *delivery) Ack(context.Context) error {
+	return d.msg.Ack()
+}
+
+func (d *delivery) Nack(context.Context) error {
+	if d.nackDelay > 0 {
+		return d.msg.NakWithDelay(d.nackDelay)
+	}
+	return d.msg.Nak()
+}
@@ -0,0 +1,48 @@
+// Package messaging is the broker agnostic abstraction of the message brokers (NATS, SQS,
+// ...): the drivers publish with a Publisher and fetch Deliveries from a Source, and a
+// Consumer runs the handlers of the fetched messages, in batches, on a bounded worker pool,
+// ordered by key and with backpressure.
+package messaging
+
+import "context"
+
+// Message is a message received from a broker.
+type Message struct {
+	// ID identifies the message on the broker, e.g. to deduplicate it with the inbox package
+	ID    string
+	Topic string
+	// Key orders the messages of the same entity, see WithOrdering
+	Key     string
+	Headers map[string]string
+	Payload []byte
+	// Attempt is the number of deliveries of the message, 1 the first time, 0 when the broker
+	// does not count them
+	Attempt int
+}
+
+// Publisher sends a message to a topic of a broker. The key orders the messages of the same
+// entity on the brokers partitioning by key. It is the eventschema.Publisher.
+type Publisher interface {
+	Publish(ctx context.Context, topic, key string, payload []byte) error
+}
+
+// Delivery is a fetched message waiting to be settled: Ack removes it from the broker, Nack
+// lets the broker deliver it again.
+type Delivery interface {
+	Message() Message
+	Ack(ctx context.Context) error
+	Nack(ctx context.Context) error
+}
+
+// Source fetches the messages of a broker subscription.
+type Source interface {
+	// Fetch blocks until at least one message is available or ctx is done, and returns up
+	// to max deliveries
+	Fetch(ctx context.Context, max int) ([]Delivery, error)
+}
+
+// Handler handles a message: nil acks it, an error nacks it.
+type Handler func(ctx context.Context, message Message) error
+
+// BatchHandler handles a batch of messages: nil acks them all, an error nacks them all.
+type BatchHandler func(ctx context.Context, messages []Message) error
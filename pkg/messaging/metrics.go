@@ -0,0 +1,59 @@
+package messaging
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	messagesMetricName = "messaging_messages_total"
+	inFlightMetricName = "messaging_in_flight"
+	durationMetricName = "messaging_handler_duration_seconds"
+
+	resultAcked  = "acked"
+	resultNacked = "nacked"
+)
+
+type consumerMetrics struct {
+	messages *prometheus.CounterVec
+	inFlight *prometheus.GaugeVec
+	duration *prometheus.HistogramVec
+}
+
+func newConsumerMetrics(registerer prometheus.Registerer) (*consumerMetrics, error) {
+	messages := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: messagesMetricName,
+			Help: "Total messages handled by consumer and result (acked, nacked)",
+		},
+		[]string{"consumer", "result"},
+	)
+	inFlight := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: inFlightMetricName,
+			Help: "Messages fetched and not settled yet by consumer",
+		},
+		[]string{"consumer"},
+	)
+	duration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    durationMetricName,
+			Help:    "Duration of the handler calls in seconds by consumer",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"consumer"},
+	)
+
+	messages, err := metrics.Register(registerer, messages)
+	if err != nil {
+		return nil, err
+	}
+	if inFlight, err = metrics.Register(registerer, inFlight); err != nil {
+		return nil, err
+	}
+	if duration, err = metrics.Register(registerer, duration); err != nil {
+		return nil, err
+	}
+	return &consumerMetrics{messages: messages, inFlight: inFlight, duration: duration}, nil
+}
@@ -0,0 +1,125 @@
+package messaging
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+const (
+	defaultWorkers     = 4
+	defaultMaxInFlight = 64
+	defaultFetchSize   = 10
+	defaultBatchSize   = 100
+	defaultBatchWait   = time.Second
+	defaultRetryDelay  = time.Second
+)
+
+type options struct {
+	workers     int
+	maxInFlight int
+	fetchSize   int
+	ordering    bool
+	batchSize   int
+	batchWait   time.Duration
+	retryDelay  time.Duration
+	registerer  prometheus.Registerer
+	clock       clock.Clock
+}
+
+// Option configures the Consumer created by NewConsumer or NewBatchConsumer.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		workers:     defaultWorkers,
+		maxInFlight: defaultMaxInFlight,
+		fetchSize:   defaultFetchSize,
+		batchSize:   defaultBatchSize,
+		batchWait:   defaultBatchWait,
+		retryDelay:  defaultRetryDelay,
+		registerer:  prometheus.DefaultRegisterer,
+		clock:       clock.New(),
+	}
+}
+
+// WithWorkers sets the number of goroutines running the handler. Defaults to 4.
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		if workers > 0 {
+			o.workers = workers
+		}
+	}
+}
+
+// WithMaxInFlight sets the number of messages fetched and not settled yet: the consumer stops
+// fetching while they are being handled. Defaults to 64.
+func WithMaxInFlight(maxInFlight int) Option {
+	return func(o *options) {
+		if maxInFlight > 0 {
+			o.maxInFlight = maxInFlight
+		}
+	}
+}
+
+// WithFetchSize sets the maximum number of messages fetched at once. Defaults to 10.
+func WithFetchSize(size int) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.fetchSize = size
+		}
+	}
+}
+
+// WithOrdering handles the messages of the same key one at a time, in the fetch order: each
+// key is assigned to a worker. The messages without a key are spread over the workers.
+func WithOrdering() Option {
+	return func(o *options) {
+		o.ordering = true
+	}
+}
+
+// WithBatch sets when a batch of the BatchHandler is handled: once it holds size messages,
+// or wait after its first message. Defaults to 100 messages or 1s. It has no effect on the
+// Handler of NewConsumer.
+func WithBatch(size int, wait time.Duration) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.batchSize = size
+		}
+		if wait > 0 {
+			o.batchWait = wait
+		}
+	}
+}
+
+// WithRetryDelay sets the delay before fetching again after a failed fetch. Defaults to 1s.
+func WithRetryDelay(delay time.Duration) Option {
+	return func(o *options) {
+		if delay > 0 {
+			o.retryDelay = delay
+		}
+	}
+}
+
+// WithRegisterer sets the registerer the consumer metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithClock sets the clock of the batch wait, of the retry delay and of the handler
+// duration, e.g. a clock.Fake in tests. Defaults to the time package when not provided.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Package sqs is the AWS SQS and SNS driver of the messaging package: the Client publishes
+// to SNS topics and SQS queues, FIFO ones included, and its Sources long poll the messages of
+// the queues, acked and nacked by the messaging.Consumer. It calls the APIs with the AWS SDK
+// clients of the aws package.
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+const (
+	// IDAttribute is the message attribute of the message ID, also the deduplication ID of
+	// the messages of the FIFO queues and topics
+	IDAttribute = "Message-Id"
+	// KeyAttribute is the message attribute of the message key, also the message group of
+	// the messages of the FIFO queues and topics
+	KeyAttribute = "Message-Key"
+
+	fifoSuffix = ".fifo"
+)
+
+// Client publishes and receives with the SQS and SNS clients of the AWS SDK, e.g. the ones
+// of aws.NewSQS and aws.NewSNS.
+type Client struct {
+	cfg Config
+	ids ident.Generator
+	sqs *awssqs.Client
+	sns *sns.Client
+}
+
+// New creates a Client of cfg calling SQS with sqsClient and SNS with snsClient, which may
+// be nil without topics.
+func New(cfg Config, sqsClient *awssqs.Client, snsClient *sns.Client, opts ...Option) (*Client, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if sqsClient == nil {
+		return nil, ErrMissingSQSClient
+	}
+	if snsClient == nil && len(cfg.Topics) > 0 {
+		return nil, ErrMissingSNSClient
+	}
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Client{cfg: cfg, ids: o.ids, sqs: sqsClient, sns: snsClient}, nil
+}
+
+// Publish publishes payload to topic, the name of a configured topic or queue. The message
+// gets a new ID in IDAttribute, and key in KeyAttribute when not empty.
+func (c *Client) Publish(ctx context.Context, topic, key string, payload []byte) error {
+	attributes := map[string]string{IDAttribute: c.ids.NewID().String()}
+	if key != "" {
+		attributes[KeyAttribute] = key
+	}
+	return c.PublishMsg(ctx, topic, attributes, payload)
+}
+
+// PublishMsg publishes payload with the string message attributes to topic, the name of a
+// configured topic or queue. The payload is the message body, and must be text, e.g. JSON.
+// To a FIFO topic or queue, the message is grouped by KeyAttribute, or by topic without
+// one, and deduplicated for 5 minutes by IDAttribute: without one, the content based
+// deduplication of the topic or queue must be enabled. The request and correlation IDs of
+// ctx are added to the attributes not set.
+func (c *Client) PublishMsg(ctx context.Context, topic string, attributes map[string]string, payload []byte) error {
+	carrier := make(ctxmeta.MapCarrier, len(attributes)+2)
+	maps.Copy(carrier, attributes)
+	ctxmeta.Inject(ctx, carrier)
+	attributes = carrier
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	var err error
+	if t, ok := c.cfg.Topics[topic]; ok {
+		err = c.publishSNS(ctx, t.ARN, topic, attributes, payload)
+	} else if q, ok := c.cfg.Queues[topic]; ok {
+		err = c.sendSQS(ctx, q.URL, topic, attributes, payload)
+	} else {
+		err = ErrUnknownTopic
+	}
+	if err != nil {
+		return fmt.Errorf("publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+func (c *Client) sendSQS(
+	ctx context.Context, queueURL, topic string, attributes map[string]string, payload []byte,
+) error {
+	input := &awssqs.SendMessageInput{
+		QueueUrl:          awssdk.String(queueURL),
+		MessageBody:       awssdk.String(string(payload)),
+		MessageAttributes: make(map[string]sqstypes.MessageAttributeValue, len(attributes)),
+	}
+	for name, value := range attributes {
+		input.MessageAttributes[name] = sqstypes.MessageAttributeValue{
+			DataType:    awssdk.String("String"),
+			StringValue: awssdk.String(value),
+		}
+	}
+	if strings.HasSuffix(queueURL, fifoSuffix) {
+		group, deduplication := fifoGroup(topic, attributes)
+		input.MessageGroupId = awssdk.String(group)
+		if deduplication != "" {
+			input.MessageDeduplicationId = awssdk.String(deduplication)
+		}
+	}
+	_, err := c.sqs.SendMessage(ctx, input)
+	return err
+}
+
+func (c *Client) publishSNS(
+	ctx context.Context, arn, topic string, attributes map[string]string, payload []byte,
+) error {
+	input := &sns.PublishInput{
+		TopicArn:          awssdk.String(arn),
+		Message:           awssdk.String(string(payload)),
+		MessageAttributes: make(map[string]snstypes.MessageAttributeValue, len(attributes)),
+	}
+	for name, value := range attributes {
+		input.MessageAttributes[name] = snstypes.MessageAttributeValue{
+			DataType:    awssdk.String("String"),
+			StringValue: awssdk.String(value),
+		}
+	}
+	if strings.HasSuffix(arn, fifoSuffix) {
+		group, deduplication := fifoGroup(topic, attributes)
+		input.MessageGroupId = awssdk.String(group)
+		if deduplication != "" {
+			input.MessageDeduplicationId = awssdk.String(deduplication)
+		}
+	}
+	_, err := c.sns.Publish(ctx, input)
+	return err
+}
+
+// fifoGroup returns the message group and the deduplication ID of a message to a FIFO topic
+// or queue.
+func fifoGroup(topic string, attributes map[string]string) (string, string) {
+	group := attributes[KeyAttribute]
+	if group == "" {
+		group = topic
+	}
+	return group, attributes[IDAttribute]
+}
+
+// Source returns the Source of the queue configured under name.
+func (c *Client) Source(name string) (*Source, error) {
+	cfg, ok := c.cfg.Queues[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownQueue, name)
+	}
+	return NewSource(c, cfg)
+}
+
+// Redrive moves the messages of the dead-letter queue configured under name back to their
+// source queues, and returns the handle of the move task.
+func (c *Client) Redrive(ctx context.Context, name string) (string, error) {
+	cfg, ok := c.cfg.Queues[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownQueue, name)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	attributes, err := c.queueAttributes(ctx, cfg.URL, sqstypes.QueueAttributeNameQueueArn)
+	if err != nil {
+		return "", fmt.Errorf("redrive %s: %w", name, err)
+	}
+	output, err := c.sqs.StartMessageMoveTask(ctx, &awssqs.StartMessageMoveTaskInput{
+		SourceArn: awssdk.String(attributes[string(sqstypes.QueueAttributeNameQueueArn)]),
+	})
+	if err != nil {
+		return "", fmt.Errorf("redrive %s: %w", name, err)
+	}
+	return awssdk.ToString(output.TaskHandle), nil
+}
+
+func (c *Client) queueAttributes(
+	ctx context.Context,
+	queueURL string,
+	names ...sqstypes.QueueAttributeName,
+) (map[string]string, error) {
+	output, err := c.sqs.GetQueueAttributes(ctx, &awssqs.GetQueueAttributesInput{
+		QueueUrl:       awssdk.String(queueURL),
+		AttributeNames: names,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Attributes, nil
+}
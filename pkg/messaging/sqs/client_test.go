@@ -0,0 +1,457 @@
+package sqs_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging/sqs"
+)
+
+const (
+	queueURL     = "http://sqs.local/000000000000/orders"
+	fifoQueueURL = "http://sqs.local/000000000000/payments.fifo"
+	topicARN     = "arn:aws:sns:us-east-1:000000000000:events"
+)
+
+// call is a call received by the fakeAPI: the SQS action and its JSON request, or the SNS
+// action and its form.
+type call struct {
+	action  string
+	request map[string]any
+	form    url.Values
+}
+
+// fakeAPI answers the SQS and SNS calls with respond, a 200 with an empty JSON object when
+// it returns no body.
+type fakeAPI struct {
+	*httptest.Server
+	respond func(c call) (int, string)
+
+	mu    sync.Mutex
+	calls []call
+}
+
+func newFakeAPI(t *testing.T, respond func(c call) (int, string)) *fakeAPI {
+	t.Helper()
+	api := &fakeAPI{respond: respond}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var c call
+		if target := r.Header.Get("X-Amz-Target"); target != "" {
+			c.action = strings.TrimPrefix(target, "AmazonSQS.")
+			_ = json.Unmarshal(body, &c.request)
+		} else {
+			c.form, _ = url.ParseQuery(string(body))
+			c.action = c.form.Get("Action")
+		}
+		api.mu.Lock()
+		api.calls = append(api.calls, c)
+		api.mu.Unlock()
+
+		status, response := http.StatusOK, "{}"
+		if api.respond != nil {
+			if s, b := api.respond(c); b != "" {
+				status, response = s, b
+			}
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func (a *fakeAPI) received(action string) []call {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var calls []call
+	for _, c := range a.calls {
+		if c.action == action {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+func newClient(t *testing.T, api *fakeAPI, queues map[string]sqs.QueueConfig) *sqs.Client {
+	t.Helper()
+	session, err := aws.NewSession(aws.Config{
+		Region:          "us-east-1",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		Endpoint:        api.URL,
+		Retry:           aws.RetryConfig{MaxAttempts: 1},
+	}, aws.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	client, err := sqs.New(sqs.Config{
+		WaitTime: time.Second,
+		Queues:   queues,
+		Topics:   map[string]sqs.TopicConfig{"events": {ARN: topicARN}, "events.fifo": {ARN: topicARN + ".fifo"}},
+	}, aws.NewSQS(session), aws.NewSNS(session), sqs.WithIDGenerator(ident.NewSequence()))
+	require.NoError(t, err)
+	return client
+}
+
+func TestNew_RequiresTheClients(t *testing.T) {
+	session, err := aws.NewSession(aws.Config{Region: "us-east-1"}, aws.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	topics := map[string]sqs.TopicConfig{"events": {ARN: topicARN}}
+
+	t.Run("requires the SQS client", func(t *testing.T) {
+		// Act
+		_, err := sqs.New(sqs.Config{}, nil, aws.NewSNS(session))
+
+		// Assert
+		require.ErrorIs(t, err, sqs.ErrMissingSQSClient)
+	})
+
+	t.Run("requires the SNS client with topics", func(t *testing.T) {
+		// Act
+		_, err := sqs.New(sqs.Config{Topics: topics}, aws.NewSQS(session), nil)
+
+		// Assert
+		require.ErrorIs(t, err, sqs.ErrMissingSNSClient)
+	})
+
+	t.Run("does not require the SNS client without topics", func(t *testing.T) {
+		// Act
+		_, err := sqs.New(sqs.Config{}, aws.NewSQS(session), nil)
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
+
+func TestClient_Publish(t *testing.T) {
+	t.Run("sends to a queue with the ID and the key attributes", func(t *testing.T) {
+		// Arrange
+		api := newFakeAPI(t, nil)
+		client := newClient(t, api, map[string]sqs.QueueConfig{"orders": {URL: queueURL}})
+
+		// Act
+		err := client.Publish(context.Background(), "orders", "order-1", []byte(`{"id":1}`))
+
+		// Assert
+		require.NoError(t, err)
+		sent := api.received("SendMessage")
+		require.Len(t, sent, 1)
+		assert.Equal(t, queueURL, sent[0].request["QueueUrl"])
+		assert.JSONEq(t, `{"id":1}`, sent[0].request["MessageBody"].(string))
+		assert.Equal(t, map[string]any{
+			"Message-Id":  map[string]any{"DataType": "String", "StringValue": ident.SequenceID(1).String()},
+			"Message-Key": map[string]any{"DataType": "String", "StringValue": "order-1"},
+		}, sent[0].request["MessageAttributes"])
+		assert.NotContains(t, sent[0].request, "MessageGroupId")
+	})
+
+	t.Run("propagates the request and correlation IDs of the context", func(t *testing.T) {
+		// Arrange
+		api := newFakeAPI(t, nil)
+		client := newClient(t, api, map[string]sqs.QueueConfig{"orders": {URL: queueURL}})
+		ctx := ctxmeta.WithCorrelationID(ctxmeta.WithRequestID(context.Background(), "req-1"), "corr-1")
+
+		// Act
+		err := client.Publish(ctx, "orders", "", []byte(`{"id":1}`))
+
+		// Assert
+		require.NoError(t, err)
+		sent := api.received("SendMessage")
+		require.Len(t, sent, 1)
+		attributes := sent[0].request["MessageAttributes"].(map[string]any)
+		assert.Equal(t, map[string]any{"DataType": "String", "StringValue": "req-1"}, attributes[ctxmeta.HeaderRequestID])
+		assert.Equal(t, map[string]any{"DataType": "String", "StringValue": "corr-1"},
+			attributes[ctxmeta.HeaderCorrelationID])
+	})
+
+	t.Run("groups by key and deduplicates by ID on a FIFO queue", func(t *testing.T) {
+		// Arrange
+		api := newFakeAPI(t, nil)
+		client := newClient(t, api, map[string]sqs.QueueConfig{"payments": {URL: fifoQueueURL}})
+
+		// Act
+		err := client.Publish(context.Background(), "payments", "order-1", []byte(`{}`))
+
+		// Assert
+		require.NoError(t, err)
+		sent := api.received("SendMessage")
+		require.Len(t, sent, 1)
+		assert.Equal(t, "order-1", sent[0].request["MessageGroupId"])
+		assert.Equal(t, ident.SequenceID(1).String(), sent[0].request["MessageDeduplicationId"])
+	})
+
+	t.Run("publishes to an SNS topic, grouped by topic without a key when FIFO", func(t *testing.T) {
+		// Arrange
+		api := newFakeAPI(t, nil)
+		client := newClient(t, api, nil)
+
+		// Act
+		err := client.Publish(context.Background(), "events.fifo", "", []byte(`{"id":1}`))
+
+		// Assert
+		require.NoError(t, err)
+		published := api.received("Publish")
+		require.Len(t, published, 1)
+		form := published[0].form
+		assert.Equal(t, topicARN+".fifo", form.Get("TopicArn"))
+		assert.JSONEq(t, `{"id":1}`, form.Get("Message"))
+		assert.Equal(t, "Message-Id", form.Get("MessageAttributes.entry.1.Name"))
+		assert.Equal(t, ident.SequenceID(1).String(), form.Get("MessageAttributes.entry.1.Value.StringValue"))
+		assert.Equal(t, "events.fifo", form.Get("MessageGroupId"))
+		assert.Equal(t, ident.SequenceID(1).String(), form.Get("MessageDeduplicationId"))
+	})
+
+	t.Run("returns the API error", func(t *testing.T) {
+		// Arrange
+		api := newFakeAPI(t, func(call) (int, string) {
+			return http.StatusNotFound, `<ErrorResponse><Error><Type>Sender</Type><Code>NotFound</Code>` +
+				`<Message>Topic does not exist</Message></Error></ErrorResponse>`
+		})
+		client := newClient(t, api, nil)
+
+		// Act
+		err := client.Publish(context.Background(), "events", "", []byte(`{}`))
+
+		// Assert
+		var apiErr smithy.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "NotFound", apiErr.ErrorCode())
+		assert.Equal(t, "Topic does not exist", apiErr.ErrorMessage())
+	})
+
+	t.Run("returns ErrUnknownTopic for a name not configured", func(t *testing.T) {
+		// Arrange
+		client := newClient(t, newFakeAPI(t, nil), nil)
+
+		// Act
+		err := client.Publish(context.Background(), "unknown", "", []byte(`{}`))
+
+		// Assert
+		require.ErrorIs(t, err, sqs.ErrUnknownTopic)
+	})
+}
+
+func TestSource_Fetch(t *testing.T) {
+	const message = `{"MessageId":"m1","ReceiptHandle":"r1","Body":"{\"id\":1}",` +
+		`"Attributes":{"ApproximateReceiveCount":"3"},` +
+		`"MessageAttributes":{"Message-Key":{"DataType":"String","StringValue":"order-1"}}}`
+
+	newAPI := func(t *testing.T, redrivePolicy string) *fakeAPI {
+		empty := true
+		return newFakeAPI(t, func(c call) (int, string) {
+			switch c.action {
+			case "GetQueueAttributes":
+				attributes, _ := json.Marshal(map[string]any{"Attributes": map[string]string{"RedrivePolicy": redrivePolicy}})
+				return http.StatusOK, string(attributes)
+			case "ReceiveMessage":
+				// The first long poll ends without a message
+				if empty {
+					empty = false
+					return http.StatusOK, `{}`
+				}
+				return http.StatusOK, `{"Messages":[` + message + `]}`
+			}
+			return http.StatusOK, ""
+		})
+	}
+
+	t.Run("long polls until a message is received", func(t *testing.T) {
+		// Arrange
+		api := newAPI(t, `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:000000000000:orders-dlq","maxReceiveCount":5}`)
+		client := newClient(t, api, map[string]sqs.QueueConfig{"orders": {URL: queueURL, VisibilityTimeout: time.Minute}})
+		source, err := client.Source("orders")
+		require.NoError(t, err)
+
+		// Act
+		deliveries, err := source.Fetch(context.Background(), 20)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		m := deliveries[0].Message()
+		assert.Equal(t, "m1", m.ID)
+		assert.Equal(t, "orders", m.Topic)
+		assert.Equal(t, "order-1", m.Key)
+		assert.Equal(t, 3, m.Attempt)
+		assert.JSONEq(t, `{"id":1}`, string(m.Payload))
+		assert.NotContains(t, m.Headers, sqs.LastAttemptHeader)
+		assert.Equal(t, 5, source.MaxReceiveCount())
+
+		received := api.received("ReceiveMessage")
+		require.Len(t, received, 2)
+		assert.InDelta(t, 10, received[0].request["MaxNumberOfMessages"], 0)
+		assert.InDelta(t, 1, received[0].request["WaitTimeSeconds"], 0)
+		assert.InDelta(t, 60, received[0].request["VisibilityTimeout"], 0)
+	})
+
+	t.Run("marks the last attempt before the dead-letter queue", func(t *testing.T) {
+		// Arrange
+		api := newAPI(t, `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:000000000000:orders-dlq","maxReceiveCount":"3"}`)
+		client := newClient(t, api, map[string]sqs.QueueConfig{"orders": {URL: queueURL}})
+		source, err := client.Source("orders")
+		require.NoError(t, err)
+
+		// Act
+		deliveries, err := source.Fetch(context.Background(), 1)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "true", deliveries[0].Message().Headers[sqs.LastAttemptHeader])
+	})
+
+	t.Run("acks by deleting and nacks by changing the visibility", func(t *testing.T) {
+		// Arrange
+		api := newAPI(t, "")
+		client := newClient(t, api, map[string]sqs.QueueConfig{"orders": {URL: queueURL, NackDelay: 30 * time.Second}})
+		source, err := client.Source("orders")
+		require.NoError(t, err)
+		deliveries, err := source.Fetch(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+
+		// Act
+		ackErr := deliveries[0].Ack(context.Background())
+		nackErr := deliveries[0].Nack(context.Background())
+
+		// Assert
+		require.NoError(t, ackErr)
+		require.NoError(t, nackErr)
+		deleted := api.received("DeleteMessage")
+		require.Len(t, deleted, 1)
+		assert.Equal(t, map[string]any{"QueueUrl": queueURL, "ReceiptHandle": "r1"}, deleted[0].request)
+		changed := api.received("ChangeMessageVisibility")
+		require.Len(t, changed, 1)
+		assert.InDelta(t, 30, changed[0].request["VisibilityTimeout"], 0)
+	})
+
+	t.Run("extends the visibility until the message is acked", func(t *testing.T) {
+		// Arrange
+		api := newAPI(t, "")
+		client := newClient(t, api, map[string]sqs.QueueConfig{
+			"orders": {URL: queueURL, VisibilityTimeout: 2 * time.Second, ExtendVisibility: true},
+		})
+		source, err := client.Source("orders")
+		require.NoError(t, err)
+
+		// Act
+		deliveries, err := source.Fetch(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Eventually(t, func() bool {
+			return len(api.received("ChangeMessageVisibility")) > 0
+		}, 3*time.Second, 10*time.Millisecond)
+		require.NoError(t, deliveries[0].Ack(context.Background()))
+
+		// Assert
+		extended := api.received("ChangeMessageVisibility")
+		assert.InDelta(t, 2, extended[0].request["VisibilityTimeout"], 0)
+		time.Sleep(1500 * time.Millisecond)
+		assert.Len(t, api.received("ChangeMessageVisibility"), len(extended))
+	})
+
+	t.Run("unwraps the SNS notifications", func(t *testing.T) {
+		// Arrange
+		envelope, _ := json.Marshal(map[string]any{
+			"Type":      "Notification",
+			"MessageId": "n1",
+			"TopicArn":  topicARN,
+			"Message":   `{"id":1}`,
+			"MessageAttributes": map[string]any{
+				"Message-Key": map[string]string{"Type": "String", "Value": "order-1"},
+			},
+		})
+		body, _ := json.Marshal(string(envelope))
+		api := newFakeAPI(t, func(c call) (int, string) {
+			if c.action == "ReceiveMessage" {
+				return http.StatusOK, `{"Messages":[{"MessageId":"m1","ReceiptHandle":"r1","Body":` + string(body) + `}]}`
+			}
+			return http.StatusOK, ""
+		})
+		client := newClient(t, api, map[string]sqs.QueueConfig{"orders": {URL: queueURL, SNSEnvelope: true}})
+		source, err := client.Source("orders")
+		require.NoError(t, err)
+
+		// Act
+		deliveries, err := source.Fetch(context.Background(), 1)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		m := deliveries[0].Message()
+		assert.Equal(t, "n1", m.ID)
+		assert.Equal(t, "events", m.Topic)
+		assert.Equal(t, "order-1", m.Key)
+		assert.JSONEq(t, `{"id":1}`, string(m.Payload))
+	})
+
+	t.Run("returns the API error of the queue", func(t *testing.T) {
+		// Arrange
+		api := newFakeAPI(t, func(call) (int, string) {
+			return http.StatusBadRequest,
+				`{"__type":"com.amazonaws.sqs#QueueDoesNotExist","message":"The specified queue does not exist."}`
+		})
+		client := newClient(t, api, map[string]sqs.QueueConfig{"orders": {URL: queueURL}})
+		source, err := client.Source("orders")
+		require.NoError(t, err)
+
+		// Act
+		_, err = source.Fetch(context.Background(), 1)
+
+		// Assert
+		var notExist *sqstypes.QueueDoesNotExist
+		require.ErrorAs(t, err, &notExist)
+		assert.Equal(t, "QueueDoesNotExist", notExist.ErrorCode())
+	})
+
+	t.Run("returns ErrUnknownQueue for a queue not configured", func(t *testing.T) {
+		// Arrange
+		client := newClient(t, newFakeAPI(t, nil), nil)
+
+		// Act
+		_, err := client.Source("orders")
+
+		// Assert
+		require.ErrorIs(t, err, sqs.ErrUnknownQueue)
+	})
+}
+
+func TestClient_Redrive(t *testing.T) {
+	// Arrange
+	api := newFakeAPI(t, func(c call) (int, string) {
+		switch c.action {
+		case "GetQueueAttributes":
+			return http.StatusOK, `{"Attributes":{"QueueArn":"arn:aws:sqs:us-east-1:000000000000:orders-dlq"}}`
+		case "StartMessageMoveTask":
+			return http.StatusOK, `{"TaskHandle":"task-1"}`
+		}
+		return http.StatusOK, ""
+	})
+	client := newClient(t, api, map[string]sqs.QueueConfig{"orders-dlq": {URL: queueURL + "-dlq"}})
+
+	// Act
+	handle, err := client.Redrive(context.Background(), "orders-dlq")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "task-1", handle)
+	started := api.received("StartMessageMoveTask")
+	require.Len(t, started, 1)
+	assert.Equal(t, map[string]any{"SourceArn": "arn:aws:sqs:us-east-1:000000000000:orders-dlq"}, started[0].request)
+}
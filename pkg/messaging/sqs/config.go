@@ -0,0 +1,95 @@
+package sqs
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultTimeout  = 10 * time.Second
+	defaultWaitTime = 20 * time.Second
+
+	// maxWaitTime is the longest long poll of ReceiveMessage
+	maxWaitTime = 20 * time.Second
+	// maxVisibilityTimeout is the longest visibility timeout of a message
+	maxVisibilityTimeout = 12 * time.Hour
+)
+
+// Config configures the queues and topics of the application. The region, the
+// credentials and the endpoints are the ones of the aws.Session of the SQS and SNS clients.
+type Config struct {
+	// Timeout bounds the API calls, on top of WaitTime for the receives
+	Timeout time.Duration `config:"timeout"`
+	// WaitTime is how long a receive waits for a message before asking again, up to 20s
+	WaitTime time.Duration `config:"wait_time"`
+	// Queues are the queues by name, consumed with Client.Source and published to directly
+	Queues map[string]QueueConfig `config:"queues"`
+	// Topics are the SNS topics by name, published to
+	Topics map[string]TopicConfig `config:"topics"`
+}
+
+// QueueConfig configures a queue. A queue URL ending with .fifo is a FIFO queue.
+type QueueConfig struct {
+	// URL is the queue URL, e.g. "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	URL string `config:"url"`
+	// VisibilityTimeout hides a received message from the other receives until it is acked;
+	// 0 keeps the visibility timeout of the queue
+	VisibilityTimeout time.Duration `config:"visibility_timeout"`
+	// ExtendVisibility extends the visibility timeout of a message every half of it while it
+	// is handled, so handlers may run longer than VisibilityTimeout
+	ExtendVisibility bool `config:"extend_visibility"`
+	// NackDelay is the visibility timeout of a nacked message; 0 receives it again at once
+	NackDelay time.Duration `config:"nack_delay"`
+	// SNSEnvelope unwraps the notifications of an SNS subscription without raw message
+	// delivery
+	SNSEnvelope bool `config:"sns_envelope"`
+}
+
+// TopicConfig configures an SNS topic. A topic ARN ending with .fifo is a FIFO topic.
+type TopicConfig struct {
+	// ARN is the topic ARN, e.g. "arn:aws:sns:us-east-1:123456789012:orders"
+	ARN string `config:"arn"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.WaitTime <= 0 {
+		c.WaitTime = defaultWaitTime
+	}
+}
+
+// Validate checks the wait time, the queues and the topics.
+func (c *Config) Validate() error {
+	if c.WaitTime > maxWaitTime {
+		return ErrInvalidWaitTime
+	}
+	for name, queue := range c.Queues {
+		if err := queue.Validate(); err != nil {
+			return fmt.Errorf("queue %s: %w", name, err)
+		}
+	}
+	for name, topic := range c.Topics {
+		if topic.ARN == "" {
+			return fmt.Errorf("topic %s: %w", name, ErrMissingTopicARN)
+		}
+	}
+	return nil
+}
+
+// Validate checks the URL and the visibility timeouts.
+func (c *QueueConfig) Validate() error {
+	if c.URL == "" {
+		return ErrMissingQueueURL
+	}
+	if c.VisibilityTimeout < 0 || c.VisibilityTimeout > maxVisibilityTimeout ||
+		c.NackDelay < 0 || c.NackDelay > maxVisibilityTimeout {
+		return ErrInvalidVisibilityTimeout
+	}
+	if c.ExtendVisibility && c.VisibilityTimeout < 2*time.Second {
+		return ErrMissingVisibilityTimeout
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package sqs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/messaging/sqs"
+)
+
+func TestConfig_SetDefaults(t *testing.T) {
+	// Arrange
+	cfg := sqs.Config{}
+
+	// Act
+	cfg.SetDefaults()
+
+	// Assert
+	assert.Equal(t, 10*time.Second, cfg.Timeout)
+	assert.Equal(t, 20*time.Second, cfg.WaitTime)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+
+	tests := []struct {
+		name    string
+		cfg     sqs.Config
+		wantErr error
+	}{
+		{
+			name: "valid",
+			cfg: sqs.Config{
+				Queues: map[string]sqs.QueueConfig{
+					"orders": {URL: queueURL, VisibilityTimeout: time.Minute, ExtendVisibility: true},
+				},
+				Topics: map[string]sqs.TopicConfig{"events": {ARN: "arn:aws:sns:us-east-1:123456789012:events"}},
+			},
+		},
+		{
+			name:    "wait time above 20 seconds",
+			cfg:     sqs.Config{WaitTime: time.Minute},
+			wantErr: sqs.ErrInvalidWaitTime,
+		},
+		{
+			name:    "queue without URL",
+			cfg:     sqs.Config{Queues: map[string]sqs.QueueConfig{"orders": {}}},
+			wantErr: sqs.ErrMissingQueueURL,
+		},
+		{
+			name: "visibility timeout above 12 hours",
+			cfg: sqs.Config{Queues: map[string]sqs.QueueConfig{
+				"orders": {URL: queueURL, VisibilityTimeout: 13 * time.Hour},
+			}},
+			wantErr: sqs.ErrInvalidVisibilityTimeout,
+		},
+		{
+			name: "extended visibility without visibility timeout",
+			cfg: sqs.Config{Queues: map[string]sqs.QueueConfig{
+				"orders": {URL: queueURL, ExtendVisibility: true},
+			}},
+			wantErr: sqs.ErrMissingVisibilityTimeout,
+		},
+		{
+			name:    "topic without ARN",
+			cfg:     sqs.Config{Topics: map[string]sqs.TopicConfig{"events": {}}},
+			wantErr: sqs.ErrMissingTopicARN,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.cfg.SetDefaults()
+
+			// Act
+			err := tt.cfg.Validate()
+
+			// Assert
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
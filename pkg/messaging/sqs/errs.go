@@ -0,0 +1,17 @@
+package sqs
+
+import (
+	"errors"
+)
+
+var (
+	ErrMissingSQSClient         = errors.New("SQS client is required")
+	ErrMissingSNSClient         = errors.New("SNS client is required to publish to topics")
+	ErrInvalidWaitTime          = errors.New("wait time cannot exceed 20 seconds")
+	ErrMissingQueueURL          = errors.New("queue URL is required")
+	ErrMissingTopicARN          = errors.New("topic ARN is required")
+	ErrInvalidVisibilityTimeout = errors.New("visibility timeout must be between 0 and 12 hours")
+	ErrMissingVisibilityTimeout = errors.New("extending the visibility requires a visibility timeout of 2 seconds or more")
+	ErrUnknownQueue             = errors.New("queue not configured")
+	ErrUnknownTopic             = errors.New("topic or queue not configured")
+)
@@ -0,0 +1,52 @@
+package sqs
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging"
+)
+
+// Module provides the *Client configured under "app.messaging.sqs", and the client as a
+// messaging.Publisher. It takes the SQS client of aws.SQSModule, and the SNS client of
+// aws.SNSModule to publish to topics.
+//
+// Usage in your application:
+//
+//	fx.New(
+//	    aws.Module,
+//	    aws.SQSModule,
+//	    aws.SNSModule,
+//	    sqs.Module,
+//	    fx.Provide(func(client *sqs.Client, log logger.Logger) (*messaging.Consumer, error) {
+//	        source, err := client.Source("billing")
+//	        if err != nil {
+//	            return nil, err
+//	        }
+//	        return messaging.NewConsumer("billing", source, handler.Handle, log)
+//	    }),
+//	)
+var Module = fx.Module("sqs",
+	config.Provide[Config]("app.messaging.sqs"),
+	fx.Provide(
+		NewWithParams,
+		func(client *Client) messaging.Publisher { return client },
+	),
+)
+
+type Params struct {
+	fx.In
+
+	Config config.Config[Config]
+	SQS    *awssqs.Client
+	SNS    *sns.Client     `optional:"true"`
+	IDs    ident.Generator `optional:"true"`
+}
+
+// NewWithParams creates the Client of the loaded configuration.
+func NewWithParams(p Params) (*Client, error) {
+	return New(p.Config.Get(), p.SQS, p.SNS, WithIDGenerator(p.IDs))
+}
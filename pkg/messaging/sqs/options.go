@@ -0,0 +1,26 @@
+package sqs
+
+import (
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+type options struct {
+	ids ident.Generator
+}
+
+// Option configures the Client created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{ids: ident.New()}
+}
+
+// WithIDGenerator sets the generator of the IDs of the published messages, e.g. an
+// ident.Sequence in tests. Defaults to UUIDv7 when not provided.
+func WithIDGenerator(ids ident.Generator) Option {
+	return func(o *options) {
+		if ids != nil {
+			o.ids = ids
+		}
+	}
+}
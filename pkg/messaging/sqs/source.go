@@ -0,0 +1,267 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/cristiano-pacheco/bricks/pkg/messaging"
+)
+
+const (
+	// LastAttemptHeader is "true" in the headers of a message received for the last time
+	// before the redrive policy of its queue moves it to the dead-letter queue
+	LastAttemptHeader = "Last-Attempt"
+
+	// maxReceiveMessages is the most messages returned by a receive
+	maxReceiveMessages = 10
+)
+
+// Source is the messaging.Source of a queue. On its first fetch it reads the redrive policy
+// of the queue, to mark the last attempt of the messages with LastAttemptHeader.
+type Source struct {
+	client *Client
+	cfg    QueueConfig
+	name   string
+
+	mu              sync.Mutex
+	inspected       bool
+	maxReceiveCount int
+}
+
+// NewSource creates the Source of the queue of cfg.
+func NewSource(client *Client, cfg QueueConfig) (*Source, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	name := cfg.URL[strings.LastIndex(cfg.URL, "/")+1:]
+	return &Source{client: client, cfg: cfg, name: name}, nil
+}
+
+// MaxReceiveCount returns the receives of a message before the redrive policy of the queue
+// moves it to its dead-letter queue, or 0 without a redrive policy. It is read on the first
+// fetch.
+func (s *Source) MaxReceiveCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxReceiveCount
+}
+
+// Fetch returns the messages available, up to max and at most 10, waiting for them up to
+// the wait time and asking again until there is one. A message received while ctx is done is
+// received again after its visibility timeout.
+func (s *Source) Fetch(ctx context.Context, max int) ([]messaging.Delivery, error) {
+	if err := s.inspect(ctx); err != nil {
+		return nil, err
+	}
+	for {
+		deliveries, err := s.receive(ctx, min(max, maxReceiveMessages))
+		if err != nil || len(deliveries) > 0 {
+			return deliveries, err
+		}
+	}
+}
+
+// inspect reads the maxReceiveCount of the redrive policy of the queue, once.
+func (s *Source) inspect(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inspected {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.client.cfg.Timeout)
+	defer cancel()
+	attributes, err := s.client.queueAttributes(ctx, s.cfg.URL, sqstypes.QueueAttributeNameRedrivePolicy)
+	if err != nil {
+		return fmt.Errorf("read the attributes of %s: %w", s.name, err)
+	}
+	if policy := attributes[string(sqstypes.QueueAttributeNameRedrivePolicy)]; policy != "" {
+		var redrive struct {
+			// MaxReceiveCount is a number, or a string when set as one
+			MaxReceiveCount json.RawMessage `json:"maxReceiveCount"`
+		}
+		if err = json.Unmarshal([]byte(policy), &redrive); err != nil {
+			return fmt.Errorf("invalid redrive policy of %s: %w", s.name, err)
+		}
+		s.maxReceiveCount, _ = strconv.Atoi(strings.Trim(string(redrive.MaxReceiveCount), `"`))
+	}
+	s.inspected = true
+	return nil
+}
+
+func (s *Source) receive(ctx context.Context, max int) ([]messaging.Delivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.client.cfg.WaitTime+s.client.cfg.Timeout)
+	defer cancel()
+	output, err := s.client.sqs.ReceiveMessage(ctx, &awssqs.ReceiveMessageInput{
+		QueueUrl:            awssdk.String(s.cfg.URL),
+		MaxNumberOfMessages: int32(max), //nolint:gosec // at most maxReceiveMessages
+		WaitTimeSeconds:     seconds(s.client.cfg.WaitTime),
+		VisibilityTimeout:   seconds(s.cfg.VisibilityTimeout),
+		// AttributeNames is deprecated by MessageSystemAttributeNames, both are sent for the
+		// emulators knowing only the former
+		AttributeNames:              []sqstypes.QueueAttributeName{"All"}, //nolint:staticcheck // deprecated
+		MessageSystemAttributeNames: []sqstypes.MessageSystemAttributeName{sqstypes.MessageSystemAttributeNameAll},
+		MessageAttributeNames:       []string{"All"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("receive from %s: %w", s.name, err)
+	}
+
+	deliveries := make([]messaging.Delivery, 0, len(output.Messages))
+	for _, m := range output.Messages {
+		d := &delivery{source: s, receipt: awssdk.ToString(m.ReceiptHandle), message: s.newMessage(m)}
+		if s.cfg.ExtendVisibility {
+			d.done = make(chan struct{})
+			go d.extendVisibility()
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+func (s *Source) newMessage(m sqstypes.Message) messaging.Message {
+	headers := make(map[string]string, len(m.MessageAttributes))
+	for name, attribute := range m.MessageAttributes {
+		// Binary attributes have no string value
+		if !strings.HasPrefix(awssdk.ToString(attribute.DataType), "Binary") {
+			headers[name] = awssdk.ToString(attribute.StringValue)
+		}
+	}
+	message := messaging.Message{Topic: s.name, Headers: headers, Payload: []byte(awssdk.ToString(m.Body))}
+	if s.cfg.SNSEnvelope {
+		unwrapNotification(&message)
+	}
+
+	message.ID = message.Headers[IDAttribute]
+	if message.ID == "" {
+		message.ID = awssdk.ToString(m.MessageId)
+	}
+	message.Key = m.Attributes["MessageGroupId"]
+	if message.Key == "" {
+		message.Key = message.Headers[KeyAttribute]
+	}
+	message.Attempt, _ = strconv.Atoi(m.Attributes["ApproximateReceiveCount"])
+	if s.maxReceiveCount > 0 && message.Attempt >= s.maxReceiveCount {
+		message.Headers[LastAttemptHeader] = "true"
+	}
+	return message
+}
+
+// notification is the envelope of the messages of an SNS subscription without raw message
+// delivery.
+type notification struct {
+	Type              string `json:"Type"`
+	MessageID         string `json:"MessageId"`
+	TopicArn          string `json:"TopicArn"`
+	Message           string `json:"Message"`
+	MessageAttributes map[string]struct {
+		Type  string `json:"Type"`
+		Value string `json:"Value"`
+	} `json:"MessageAttributes"`
+}
+
+// unwrapNotification replaces the payload of message by the message of its SNS notification,
+// its topic by the name of the SNS topic, and adds the attributes of the notification to its
+// headers. A payload other than a notification is left as is.
+func unwrapNotification(message *messaging.Message) {
+	var n notification
+	if json.Unmarshal(message.Payload, &n) != nil || n.Type != "Notification" {
+		return
+	}
+	message.Payload = []byte(n.Message)
+	message.Topic = n.TopicArn[strings.LastIndex(n.TopicArn, ":")+1:]
+	for name, attribute := range n.MessageAttributes {
+		if !strings.HasPrefix(attribute.Type, "Binary") {
+			message.Headers[name] = attribute.Value
+		}
+	}
+	if message.Headers[IDAttribute] == "" {
+		message.Headers[IDAttribute] = n.MessageID
+	}
+}
+
+// seconds rounds d up to whole seconds, the unit of the API.
+func seconds(d time.Duration) int32 {
+	return int32((d + time.Second - 1) / time.Second) //nolint:gosec // at most 12 hours
+}
+
+// delivery acks a message by deleting it, and nacks it by changing its visibility timeout.
+type delivery struct {
+	source  *Source
+	receipt string
+	message messaging.Message
+
+	// done stops extending the visibility timeout when ExtendVisibility is set
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func (d *delivery) Message() messaging.Message {
+	return d.message
+}
+
+func (d *delivery) Ack(ctx context.Context) error {
+	d.stopExtending()
+	ctx, cancel := context.WithTimeout(ctx, d.source.client.cfg.Timeout)
+	defer cancel()
+	_, err := d.source.client.sqs.DeleteMessage(ctx, &awssqs.DeleteMessageInput{
+		QueueUrl:      awssdk.String(d.source.cfg.URL),
+		ReceiptHandle: awssdk.String(d.receipt),
+	})
+	return err
+}
+
+func (d *delivery) Nack(ctx context.Context) error {
+	d.stopExtending()
+	ctx, cancel := context.WithTimeout(ctx, d.source.client.cfg.Timeout)
+	defer cancel()
+	return d.changeVisibility(ctx, d.source.cfg.NackDelay)
+}
+
+func (d *delivery) changeVisibility(ctx context.Context, timeout time.Duration) error {
+	_, err := d.source.client.sqs.ChangeMessageVisibility(ctx, &awssqs.ChangeMessageVisibilityInput{
+		QueueUrl:          awssdk.String(d.source.cfg.URL),
+		ReceiptHandle:     awssdk.String(d.receipt),
+		VisibilityTimeout: seconds(timeout),
+	})
+	return err
+}
+
+func (d *delivery) stopExtending() {
+	if d.done != nil {
+		d.stopOnce.Do(func() { close(d.done) })
+	}
+}
+
+// extendVisibility extends the visibility timeout of the message every half of it until it
+// is acked or nacked. It gives up once the API rejects the extension, e.g. past the 12 hours
+// a message may stay invisible.
+func (d *delivery) extendVisibility() {
+	timeout := d.source.cfg.VisibilityTimeout
+	ticker := time.NewTicker(timeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), d.source.client.cfg.Timeout)
+			err := d.changeVisibility(ctx, timeout)
+			cancel()
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package metrics
+
+const (
+	BackendPrometheus = "prometheus"
+	BackendOTel       = "otel"
+)
+
+// Config selects the backend used for the UseCaseMetrics provided by Module
+// and the build information reported by RuntimeCollector.
+type Config struct {
+	Backend string `config:"backend"` // prometheus or otel, default prometheus
+	Version string `config:"version"` // build version reported by RuntimeCollector, default module version
+	Commit  string `config:"commit"`  // build commit reported by RuntimeCollector, default VCS revision
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Backend == "" {
+		c.Backend = BackendPrometheus
+	}
+}
+
+// Validate checks if the configuration is valid
+func (c *Config) Validate() error {
+	switch c.Backend {
+	case "", BackendPrometheus, BackendOTel:
+		return nil
+	default:
+		return ErrInvalidBackend
+	}
+}
@@ -0,0 +1,8 @@
+package metrics
+
+import "errors"
+
+var (
+	ErrInvalidBackend        = errors.New("invalid metrics backend (must be 'prometheus' or 'otel')")
+	ErrCreateOTelInstruments = errors.New("failed to create OpenTelemetry instruments")
+)
@@ -1,15 +1,49 @@
 package metrics
 
 import (
+	"fmt"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
 	"go.uber.org/fx"
 )
 
+// Module provides an application-scoped Prometheus registry and the UseCaseMetrics selected by the
+// configured backend (app.metrics.backend), prometheus when app.metrics is missing.
+// The registry is exposed as *prometheus.Registry, prometheus.Registerer and prometheus.Gatherer,
+// so the chi metrics server serves it and other components can register their own collectors.
 var Module = fx.Module(
 	"metrics",
+	config.ProvideOptional[Config]("app.metrics"),
 	fx.Provide(
 		fx.Annotate(
-			NewPrometheusUseCaseMetrics,
-			fx.As(new(UseCaseMetrics)),
+			NewRegistry,
+			fx.As(fx.Self()),
+			fx.As(new(prometheus.Registerer)),
+			fx.As(new(prometheus.Gatherer)),
 		),
+		NewUseCaseMetricsWithLifecycle,
 	),
 )
+
+// NewUseCaseMetricsWithLifecycle creates the UseCaseMetrics for the configured backend.
+// The otel backend uses the global meter provider, so instruments are exported once
+// otel/metric is initialized.
+func NewUseCaseMetricsWithLifecycle(
+	lc fx.Lifecycle,
+	cfg config.Config[Config],
+	registerer prometheus.Registerer,
+) (UseCaseMetrics, error) {
+	metricsConfig := cfg.Get()
+	metricsConfig.SetDefaults()
+	if err := metricsConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, metricsConfig.Backend)
+	}
+
+	if metricsConfig.Backend == BackendOTel {
+		return NewOTelUseCaseMetrics(otel.GetMeterProvider().Meter(meterName))
+	}
+
+	return NewPrometheusUseCaseMetricsWithLifecycle(lc, registerer)
+}
@@ -0,0 +1,32 @@
+package metrics_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+func TestModule(t *testing.T) {
+	t.Run("starts with the prometheus backend without app.metrics", func(t *testing.T) {
+		// Arrange
+		configDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, "base.yaml"), []byte("app:\n  name: orders\n"), 0o600))
+		t.Setenv("APP_CONFIG_DIR", configDir)
+		t.Setenv("APP_ENV", "test")
+		var useCaseMetrics metrics.UseCaseMetrics
+
+		// Act
+		app := fx.New(fx.NopLogger, metrics.Module, fx.Populate(&useCaseMetrics))
+
+		// Assert
+		require.NoError(t, app.Err())
+		_, isPrometheus := useCaseMetrics.(*metrics.PrometheusUseCaseMetrics)
+		assert.True(t, isPrometheus)
+	})
+}
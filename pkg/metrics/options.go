@@ -0,0 +1,28 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// options holds internal configuration options
+type options struct {
+	registerer prometheus.Registerer
+}
+
+// Option is a functional option for configuring the Prometheus use case metrics
+type Option func(*options)
+
+// defaultOptions returns the default options
+func defaultOptions() options {
+	return options{
+		registerer: prometheus.DefaultRegisterer,
+	}
+}
+
+// WithRegisterer sets the registerer the collectors are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+const (
+	meterName = "github.com/cristiano-pacheco/bricks/pkg/metrics"
+
+	otelUsecaseDurationMetricName = "usecase.duration"
+	otelUsecaseSuccessMetricName  = "usecase.success"
+	otelUsecaseErrorMetricName    = "usecase.error"
+)
+
+type OTelUseCaseMetrics struct {
+	duration otelmetric.Float64Histogram
+	success  otelmetric.Int64Counter
+	error    otelmetric.Int64Counter
+}
+
+var _ UseCaseMetrics = &OTelUseCaseMetrics{}
+
+// NewOTelUseCaseMetrics creates the use case instruments from the given meter.
+// The duration histogram uses the same bucket boundaries as the Prometheus implementation.
+func NewOTelUseCaseMetrics(meter otelmetric.Meter) (*OTelUseCaseMetrics, error) {
+	duration, err := meter.Float64Histogram(
+		otelUsecaseDurationMetricName,
+		otelmetric.WithDescription("Duration of use case execution in seconds"),
+		otelmetric.WithUnit("s"),
+		otelmetric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateOTelInstruments, err)
+	}
+
+	successCounter, err := meter.Int64Counter(
+		otelUsecaseSuccessMetricName,
+		otelmetric.WithDescription("Total successful use case executions"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateOTelInstruments, err)
+	}
+
+	errorCounter, err := meter.Int64Counter(
+		otelUsecaseErrorMetricName,
+		otelmetric.WithDescription("Total failed use case executions"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateOTelInstruments, err)
+	}
+
+	return &OTelUseCaseMetrics{
+		duration: duration,
+		success:  successCounter,
+		error:    errorCounter,
+	}, nil
+}
+
+func (o *OTelUseCaseMetrics) ObserveDuration(name string, duration time.Duration) {
+	o.duration.Record(context.Background(), duration.Seconds(), nameAttribute(name))
+}
+
+func (o *OTelUseCaseMetrics) IncSuccess(name string) {
+	o.success.Add(context.Background(), 1, nameAttribute(name))
+}
+
+func (o *OTelUseCaseMetrics) IncError(name string) {
+	o.error.Add(context.Background(), 1, nameAttribute(name))
+}
+
+func nameAttribute(name string) otelmetric.MeasurementOption {
+	return otelmetric.WithAttributes(attribute.String("name", name))
+}
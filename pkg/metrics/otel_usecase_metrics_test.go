@@ -0,0 +1,88 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestOTelUseCaseMetrics(t *testing.T) {
+	t.Run("records duration, success and error with the use case name", func(t *testing.T) {
+		// Arrange
+		reader := sdkmetric.NewManualReader()
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		sut, err := metrics.NewOTelUseCaseMetrics(provider.Meter("test"))
+		require.NoError(t, err)
+
+		// Act
+		sut.ObserveDuration("create_user", 10*time.Millisecond)
+		sut.IncSuccess("create_user")
+		sut.IncSuccess("create_user")
+		sut.IncError("create_user")
+
+		// Assert
+		collected := collectMetrics(t, reader)
+		require.Len(t, collected, 3)
+
+		duration, ok := collected["usecase.duration"].Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, duration.DataPoints, 1)
+		require.Equal(t, uint64(1), duration.DataPoints[0].Count)
+		name, _ := duration.DataPoints[0].Attributes.Value("name")
+		require.Equal(t, "create_user", name.AsString())
+
+		success, ok := collected["usecase.success"].Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Equal(t, int64(2), success.DataPoints[0].Value)
+
+		failures, ok := collected["usecase.error"].Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Equal(t, int64(1), failures.DataPoints[0].Value)
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Run("accepts supported backends", func(t *testing.T) {
+		for _, backend := range []string{"", metrics.BackendPrometheus, metrics.BackendOTel} {
+			// Arrange
+			cfg := metrics.Config{Backend: backend}
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("rejects unknown backend", func(t *testing.T) {
+		// Arrange
+		cfg := metrics.Config{Backend: "statsd"}
+
+		// Act
+		err := cfg.Validate()
+
+		// Assert
+		require.ErrorIs(t, err, metrics.ErrInvalidBackend)
+	})
+}
+
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	collected := make(map[string]metricdata.Metrics)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			collected[m.Name] = m
+		}
+	}
+	return collected
+}
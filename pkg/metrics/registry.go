@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewRegistry creates a dedicated Prometheus registry for the application.
+// Registering against an application-owned registry instead of prometheus.DefaultRegisterer
+// avoids duplicate registration errors in tests and when several app instances share a process.
+// The chi metrics server serves it instead of prometheus.DefaultGatherer.
+func NewRegistry() *prometheus.Registry {
+	return prometheus.NewRegistry()
+}
+
+// Register registers the collector, reusing the existing collector when an identical one is already registered,
+// so the components created more than once against a registry (e.g. in tests) share their collectors.
+func Register[C prometheus.Collector](registerer prometheus.Registerer, collector C) (C, error) {
+	err := registerer.Register(collector)
+	if err == nil {
+		return collector, nil
+	}
+
+	var alreadyRegisteredErr prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegisteredErr) {
+		if existing, ok := alreadyRegisteredErr.ExistingCollector.(C); ok {
+			return existing, nil
+		}
+	}
+
+	return collector, err
+}
@@ -0,0 +1,59 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+func TestRegister(t *testing.T) {
+	t.Run("registers the collector", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+		counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_processed_total"})
+
+		// Act
+		registered, err := metrics.Register(registry, counter)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Same(t, counter, registered)
+		assert.Contains(t, gatheredNames(t, registry), "orders_processed_total")
+	})
+
+	t.Run("reuses the identical collector already registered", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+		existing := prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_processed_total"})
+		registry.MustRegister(existing)
+
+		// Act
+		registered, err := metrics.Register(
+			registry,
+			prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_processed_total"}),
+		)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Same(t, existing, registered)
+	})
+
+	t.Run("fails when the registered collector has another type", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+		registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "orders_processed_total"}))
+
+		// Act
+		_, err := metrics.Register(
+			registry,
+			prometheus.NewCounterVec(prometheus.CounterOpts{Name: "orders_processed_total"}, []string{"status"}),
+		)
+
+		// Assert
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/buildinfo"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/fx"
+)
+
+// RuntimeCollector registers Go runtime, process and build info metrics on the registry provided by Module
+// and provides the *BuildInfo served by the chi metrics server on /buildinfo.
+var RuntimeCollector = fx.Options(
+	fx.Provide(NewBuildInfoFromConfig),
+	fx.Invoke(RegisterRuntimeCollectors),
+)
+
+// BuildInfo describes the running binary.
+// It implements http.Handler and writes itself as JSON.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	GoVersion string `json:"go_version"`
+}
+
+// NewBuildInfo creates a BuildInfo with the given version and commit.
+// Empty values fall back to the ones of buildinfo.Get: set with -ldflags, or embedded by the Go toolchain.
+func NewBuildInfo(version, commit string) BuildInfo {
+	embedded := buildinfo.Get()
+	info := BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		GoVersion: embedded.GoVersion,
+	}
+	if info.Version == "" {
+		info.Version = embedded.Version
+	}
+	if info.Commit == "" {
+		info.Commit = embedded.Commit
+	}
+	return info
+}
+
+// NewBuildInfoFromConfig creates the BuildInfo from the version and commit configured in app.metrics.
+func NewBuildInfoFromConfig(cfg config.Config[Config]) *BuildInfo {
+	metricsConfig := cfg.Get()
+	info := NewBuildInfo(metricsConfig.Version, metricsConfig.Commit)
+	return &info
+}
+
+func (b BuildInfo) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(b)
+}
+
+// RegisterRuntimeCollectors registers the Go runtime (GC, goroutines, memstats) and process collectors,
+// plus a build_info gauge labeled with version, commit and go_version.
+// Collectors that are already registered are reused. The registry owns the Go and process
+// collectors: the chi metrics server serving prometheus.DefaultGatherer too drops its copies.
+func RegisterRuntimeCollectors(registerer prometheus.Registerer, info *BuildInfo) error {
+	if _, err := Register(registerer, collectors.NewGoCollector()); err != nil {
+		return err
+	}
+
+	processCollector := collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})
+	if _, err := Register(registerer, processCollector); err != nil {
+		return err
+	}
+
+	buildInfo := buildinfo.NewGauge(buildinfo.Info{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		GoVersion: info.GoVersion,
+	})
+	if _, err := Register(registerer, buildInfo); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package metrics_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBuildInfo(t *testing.T) {
+	t.Run("uses the given version and commit", func(t *testing.T) {
+		// Act
+		info := metrics.NewBuildInfo("v1.2.3", "abc123")
+
+		// Assert
+		require.Equal(t, metrics.BuildInfo{Version: "v1.2.3", Commit: "abc123", GoVersion: runtime.Version()}, info)
+	})
+}
+
+func TestBuildInfo_ServeHTTP(t *testing.T) {
+	t.Run("writes build info as JSON", func(t *testing.T) {
+		// Arrange
+		info := metrics.BuildInfo{Version: "v1.2.3", Commit: "abc123", GoVersion: "go1.26.2"}
+		recorder := httptest.NewRecorder()
+
+		// Act
+		info.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/buildinfo", nil))
+
+		// Assert
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		require.Equal(t, map[string]string{"version": "v1.2.3", "commit": "abc123", "go_version": "go1.26.2"}, body)
+	})
+}
+
+func TestRegisterRuntimeCollectors(t *testing.T) {
+	t.Run("registers runtime, process and build info metrics", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+		info := metrics.BuildInfo{Version: "v1.2.3", Commit: "abc123", GoVersion: "go1.26.2"}
+
+		// Act
+		err := metrics.RegisterRuntimeCollectors(registry, &info)
+
+		// Assert
+		require.NoError(t, err)
+		names := gatheredNames(t, registry)
+		require.Contains(t, names, "go_goroutines")
+		require.Contains(t, names, "go_memstats_alloc_bytes")
+		require.Contains(t, names, "process_cpu_seconds_total")
+		require.Contains(t, names, "build_info")
+		require.InDelta(t, 1, gaugeValue(t, registry, "build_info"), 0)
+	})
+
+	t.Run("can be called twice on the same registry", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+		info := metrics.NewBuildInfo("v1.2.3", "abc123")
+		require.NoError(t, metrics.RegisterRuntimeCollectors(registry, &info))
+
+		// Act
+		err := metrics.RegisterRuntimeCollectors(registry, &info)
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
+
+func gaugeValue(t *testing.T, gatherer prometheus.Gatherer, name string) float64 {
+	t.Helper()
+	families, err := gatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("metric %q not gathered", name)
+	return 0
+}
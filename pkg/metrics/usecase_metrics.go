@@ -1,9 +1,11 @@
 package metrics
 
 import (
+	"context"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
 )
 
 const (
@@ -21,14 +23,23 @@ type UseCaseMetrics interface {
 var durationBuckets = []float64{0.005, 0.025, 0.05, 0.1, 0.15, 0.2, 0.3, 0.4, 0.5, 1, 1.5, 2, 3, 4, 5}
 
 type PrometheusUseCaseMetrics struct {
-	duration *prometheus.HistogramVec
-	success  *prometheus.CounterVec
-	error    *prometheus.CounterVec
+	registerer prometheus.Registerer
+	duration   *prometheus.HistogramVec
+	success    *prometheus.CounterVec
+	error      *prometheus.CounterVec
 }
 
 var _ UseCaseMetrics = &PrometheusUseCaseMetrics{}
 
-func NewPrometheusUseCaseMetrics() (*PrometheusUseCaseMetrics, error) {
+// NewPrometheusUseCaseMetrics creates the use case collectors and registers them.
+// Collectors are registered against prometheus.DefaultRegisterer unless WithRegisterer is provided.
+// If identical collectors are already registered, the existing ones are reused.
+func NewPrometheusUseCaseMetrics(opts ...Option) (*PrometheusUseCaseMetrics, error) {
+	metricsOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&metricsOptions)
+	}
+
 	duration := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    usecaseDurationMetricName,
@@ -52,23 +63,49 @@ func NewPrometheusUseCaseMetrics() (*PrometheusUseCaseMetrics, error) {
 		[]string{"name"},
 	)
 
-	if err := prometheus.Register(duration); err != nil {
+	registerer := metricsOptions.registerer
+	duration, err := Register(registerer, duration)
+	if err != nil {
 		return nil, err
 	}
-	if err := prometheus.Register(successCounter); err != nil {
+	successCounter, err = Register(registerer, successCounter)
+	if err != nil {
 		return nil, err
 	}
-	if err := prometheus.Register(errorCounter); err != nil {
+	errorCounter, err = Register(registerer, errorCounter)
+	if err != nil {
 		return nil, err
 	}
 
 	return &PrometheusUseCaseMetrics{
-		duration: duration,
-		success:  successCounter,
-		error:    errorCounter,
+		registerer: registerer,
+		duration:   duration,
+		success:    successCounter,
+		error:      errorCounter,
 	}, nil
 }
 
+// NewPrometheusUseCaseMetricsWithLifecycle creates the use case collectors on the given registerer
+// and unregisters them when the application stops.
+func NewPrometheusUseCaseMetricsWithLifecycle(
+	lc fx.Lifecycle,
+	registerer prometheus.Registerer,
+) (*PrometheusUseCaseMetrics, error) {
+	useCaseMetrics, err := NewPrometheusUseCaseMetrics(WithRegisterer(registerer))
+	if err != nil {
+		return nil, err
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(_ context.Context) error {
+			useCaseMetrics.Unregister()
+			return nil
+		},
+	})
+
+	return useCaseMetrics, nil
+}
+
 func (p *PrometheusUseCaseMetrics) ObserveDuration(name string, duration time.Duration) {
 	p.duration.WithLabelValues(name).Observe(duration.Seconds())
 }
@@ -80,3 +117,19 @@ func (p *PrometheusUseCaseMetrics) IncSuccess(name string) {
 func (p *PrometheusUseCaseMetrics) IncError(name string) {
 	p.error.WithLabelValues(name).Inc()
 }
+
+// Unregister removes the use case collectors from the registerer they were registered with.
+// It returns false if any of the collectors was not registered.
+func (p *PrometheusUseCaseMetrics) Unregister() bool {
+	durationRemoved := p.registerer.Unregister(p.duration)
+	successRemoved := p.registerer.Unregister(p.success)
+	errorRemoved := p.registerer.Unregister(p.error)
+	return durationRemoved && successRemoved && errorRemoved
+}
+
+// Reset deletes all recorded series while keeping the collectors registered.
+func (p *PrometheusUseCaseMetrics) Reset() {
+	p.duration.Reset()
+	p.success.Reset()
+	p.error.Reset()
+}
@@ -0,0 +1,106 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrometheusUseCaseMetrics_WithRegisterer(t *testing.T) {
+	t.Run("registers collectors on the provided registry", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+
+		// Act
+		sut, err := metrics.NewPrometheusUseCaseMetrics(metrics.WithRegisterer(registry))
+
+		// Assert
+		require.NoError(t, err)
+		sut.ObserveDuration("create_user", 10*time.Millisecond)
+		sut.IncSuccess("create_user")
+		sut.IncError("create_user")
+		require.ElementsMatch(t, []string{
+			"usecase_duration_seconds",
+			"usecase_success_total",
+			"usecase_error_total",
+		}, gatheredNames(t, registry))
+	})
+
+	t.Run("reuses collectors already registered on the same registry", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+		first, err := metrics.NewPrometheusUseCaseMetrics(metrics.WithRegisterer(registry))
+		require.NoError(t, err)
+
+		// Act
+		second, err := metrics.NewPrometheusUseCaseMetrics(metrics.WithRegisterer(registry))
+
+		// Assert
+		require.NoError(t, err)
+		first.IncSuccess("create_user")
+		second.IncSuccess("create_user")
+		require.InDelta(t, 2, counterValue(t, registry, "usecase_success_total"), 0)
+	})
+}
+
+func TestPrometheusUseCaseMetrics_Unregister(t *testing.T) {
+	t.Run("removes collectors so they can be registered again", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+		sut, err := metrics.NewPrometheusUseCaseMetrics(metrics.WithRegisterer(registry))
+		require.NoError(t, err)
+		sut.IncSuccess("create_user")
+
+		// Act
+		removed := sut.Unregister()
+
+		// Assert
+		require.True(t, removed)
+		require.Empty(t, gatheredNames(t, registry))
+		require.False(t, sut.Unregister())
+	})
+}
+
+func TestPrometheusUseCaseMetrics_Reset(t *testing.T) {
+	t.Run("deletes recorded series", func(t *testing.T) {
+		// Arrange
+		registry := metrics.NewRegistry()
+		sut, err := metrics.NewPrometheusUseCaseMetrics(metrics.WithRegisterer(registry))
+		require.NoError(t, err)
+		sut.IncSuccess("create_user")
+		sut.IncError("create_user")
+
+		// Act
+		sut.Reset()
+
+		// Assert
+		require.Empty(t, gatheredNames(t, registry))
+	})
+}
+
+func gatheredNames(t *testing.T, gatherer prometheus.Gatherer) []string {
+	t.Helper()
+	families, err := gatherer.Gather()
+	require.NoError(t, err)
+	names := make([]string, 0, len(families))
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	return names
+}
+
+func counterValue(t *testing.T, gatherer prometheus.Gatherer, name string) float64 {
+	t.Helper()
+	families, err := gatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	t.Fatalf("metric %q not gathered", name)
+	return 0
+}
@@ -0,0 +1,44 @@
+package metric
+
+import "time"
+
+const (
+	ExporterTypeGRPC = "grpc"
+	ExporterTypeHTTP = "http"
+)
+
+const defaultExportInterval = 60 * time.Second
+
+type MeterConfig struct {
+	AppName        string        `config:"app_name"`               // Application name
+	AppVersion     string        `config:"app_version"`            // Application version
+	MetricURL      string        `config:"metric_url"`             // OTLP endpoint URL (without http/https prefix)
+	MetricEnabled  bool          `config:"metric_enabled"`         // Enable/disable metric export
+	ExportInterval time.Duration `config:"metric_export_interval"` // Interval between periodic exports
+	Insecure       bool          `config:"insecure"`               // Use insecure connection (no TLS)
+	ExporterType   string        `config:"exporter_type"`          // GRPC or HTTP, default GRPC
+}
+
+// Validate checks if the configuration is valid
+func (c *MeterConfig) Validate() error {
+	if c.AppName == "" {
+		return ErrAppNameRequired
+	}
+	if c.MetricEnabled && c.MetricURL == "" {
+		return ErrMetricURLRequired
+	}
+	if c.ExporterType != "" && c.ExporterType != ExporterTypeGRPC && c.ExporterType != ExporterTypeHTTP {
+		return ErrInvalidExporterType
+	}
+	return nil
+}
+
+// setDefaults sets default values for optional configuration fields
+func (c *MeterConfig) setDefaults() {
+	if c.ExportInterval == 0 {
+		c.ExportInterval = defaultExportInterval
+	}
+	if c.ExporterType == "" {
+		c.ExporterType = ExporterTypeGRPC
+	}
+}
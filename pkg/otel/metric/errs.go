@@ -0,0 +1,18 @@
+package metric
+
+import "errors"
+
+var (
+	ErrAppNameRequired     = errors.New("AppName is required")
+	ErrMetricURLRequired   = errors.New("MetricURL is required when metric export is enabled")
+	ErrInvalidExporterType = errors.New("invalid exporter type (must be 'grpc' or 'http')")
+
+	ErrAlreadyInitialized  = errors.New("meter already initialized")
+	ErrNotInitialized      = errors.New("meter not initialized")
+	ErrCreateMeterProvider = errors.New("failed to create meter provider")
+
+	ErrCreateGRPCExporter = errors.New("failed to create OTLP gRPC metric exporter")
+	ErrCreateHTTPExporter = errors.New("failed to create OTLP HTTP metric exporter")
+
+	ErrMeterProviderShutdown = errors.New("meter provider shutdown failed")
+)
@@ -0,0 +1,29 @@
+package metric
+
+import (
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"go.uber.org/fx"
+)
+
+// Module is the fx module for OpenTelemetry metrics integration.
+// Add this to your fx.App to export metrics via OTLP.
+var Module = fx.Module(
+	"otel.metric",
+	config.Provide[MeterConfig]("app.open-telemetry"),
+	fx.Invoke(InitializeWithLifecycle),
+)
+
+// InitializeWithLifecycle configures and initializes the OpenTelemetry meter provider with fx.Lifecycle management.
+// The meter provider is flushed and shut down when the application stops.
+func InitializeWithLifecycle(lc fx.Lifecycle, cfg config.Config[MeterConfig]) error {
+	err := Initialize(cfg.Get())
+	if err != nil {
+		return err
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: Shutdown,
+	})
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+)
+
+var (
+	globalMeterProvider *sdkmetric.MeterProvider
+	globalAppName       string
+	globalMutex         sync.RWMutex
+	initialized         bool
+)
+
+// Initialize configures the global meter provider. Must be called before using Meter.
+// Returns an error if initialization fails.
+func Initialize(config MeterConfig) error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if initialized {
+		return ErrAlreadyInitialized
+	}
+
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	config.setDefaults()
+
+	mp, err := newMeterProvider(config, createResource(config))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCreateMeterProvider, err)
+	}
+
+	otel.SetMeterProvider(mp)
+
+	globalMeterProvider = mp
+	globalAppName = config.AppName
+	initialized = true
+
+	return nil
+}
+
+// MustInitialize initializes the global meter provider and panics if it fails.
+func MustInitialize(config MeterConfig) {
+	if err := Initialize(config); err != nil {
+		panic(fmt.Sprintf("failed to initialize meter: %v", err))
+	}
+}
+
+// createResource creates and configures the OpenTelemetry resource
+func createResource(config MeterConfig) *resource.Resource {
+	return resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.AppName),
+		semconv.ServiceVersion(config.AppVersion),
+	)
+}
+
+// newMeterProvider creates a new meter provider with the given configuration
+func newMeterProvider(config MeterConfig, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
+	if !config.MetricEnabled {
+		return sdkmetric.NewMeterProvider(sdkmetric.WithResource(res)), nil
+	}
+
+	exp, err := newExporter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := sdkmetric.NewPeriodicReader(exp, sdkmetric.WithInterval(config.ExportInterval))
+
+	return sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithResource(res),
+	), nil
+}
+
+// newExporter creates a new OTLP metric exporter (gRPC or HTTP based on config)
+func newExporter(config MeterConfig) (sdkmetric.Exporter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.ExportInterval)
+	defer cancel()
+
+	switch config.ExporterType {
+	case ExporterTypeGRPC:
+		return newGRPCExporter(ctx, config)
+	case ExporterTypeHTTP:
+		return newHTTPExporter(ctx, config)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidExporterType, config.ExporterType)
+	}
+}
+
+// newGRPCExporter creates a new OTLP gRPC metric exporter
+func newGRPCExporter(ctx context.Context, config MeterConfig) (sdkmetric.Exporter, error) {
+	options := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(config.MetricURL),
+	}
+
+	if config.Insecure {
+		options = append(options, otlpmetricgrpc.WithInsecure())
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateGRPCExporter, err)
+	}
+
+	return exporter, nil
+}
+
+// newHTTPExporter creates a new OTLP HTTP metric exporter
+func newHTTPExporter(ctx context.Context, config MeterConfig) (sdkmetric.Exporter, error) {
+	options := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(config.MetricURL),
+	}
+
+	if config.Insecure {
+		options = append(options, otlpmetrichttp.WithInsecure())
+	}
+
+	exporter, err := otlpmetrichttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCreateHTTPExporter, err)
+	}
+
+	return exporter, nil
+}
+
+// Meter returns a meter from the global meter provider.
+// When name is empty, the configured application name is used.
+// A no-op meter is returned if the provider has not been initialized.
+func Meter(name string, opts ...otelmetric.MeterOption) otelmetric.Meter {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+
+	if !initialized || globalMeterProvider == nil {
+		return noop.NewMeterProvider().Meter(name, opts...)
+	}
+
+	if name == "" {
+		name = globalAppName
+	}
+
+	return globalMeterProvider.Meter(name, opts...)
+}
+
+// Shutdown flushes pending metrics and shuts down the meter provider.
+// Should be called during application shutdown.
+func Shutdown(ctx context.Context) error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if !initialized {
+		return ErrNotInitialized
+	}
+
+	logger := slog.Default()
+	var shutdownErr error
+
+	if globalMeterProvider != nil {
+		if err := globalMeterProvider.Shutdown(ctx); err != nil {
+			logger.ErrorContext(ctx, "Failed to shutdown meter provider", "error", err)
+			shutdownErr = fmt.Errorf("%w: %w", ErrMeterProviderShutdown, err)
+		} else {
+			logger.InfoContext(ctx, "Meter provider shutdown successfully...")
+		}
+	}
+
+	// Reset global state
+	globalMeterProvider = nil
+	globalAppName = ""
+	initialized = false
+
+	return shutdownErr
+}
+
+// IsInitialized returns true if the meter provider has been initialized.
+func IsInitialized() bool {
+	globalMutex.RLock()
+	defer globalMutex.RUnlock()
+	return initialized
+}
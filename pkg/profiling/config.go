@@ -0,0 +1,88 @@
+package profiling
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ProviderPyroscope = "pyroscope"
+	ProviderParca     = "parca"
+
+	ProfileCPU       = "cpu"
+	ProfileHeap      = "heap"
+	ProfileAllocs    = "allocs"
+	ProfileGoroutine = "goroutine"
+	ProfileMutex     = "mutex"
+	ProfileBlock     = "block"
+
+	defaultInterval = 15 * time.Second
+	defaultTimeout  = 10 * time.Second
+)
+
+// defaultProfiles are the profiles collected when Config.Profiles is empty.
+var defaultProfiles = []string{ProfileCPU, ProfileHeap, ProfileGoroutine}
+
+// Config configures the continuous profiling of the application.
+type Config struct {
+	Enabled bool `config:"enabled"` // Enable/disable the profiling
+	// Provider is pyroscope or parca, the server receiving the profiles
+	Provider  string `config:"provider"`
+	ServerURL string `config:"server_url"` // Server URL, e.g. "http://pyroscope:4040"
+	// AppName names the profiles of the application, labeled service
+	AppName    string `config:"app_name"`
+	AppVersion string `config:"app_version"` // Application version, labeled version
+	// Interval is the period of the profiles: the CPU is profiled over the whole interval,
+	// the other profiles are taken at its end
+	Interval time.Duration `config:"interval"`
+	Timeout  time.Duration `config:"timeout"` // Timeout of the push of a profile
+	// Profiles are the profiles pushed: cpu, heap, allocs, goroutine, mutex and block
+	Profiles []string          `config:"profiles"`
+	Labels   map[string]string `config:"labels"` // Labels of every profile, e.g. env: production
+	// AuthToken is sent as a bearer token, e.g. env://PROFILING_TOKEN
+	AuthToken         string `config:"auth_token"`
+	BasicAuthUser     string `config:"basic_auth_user"`     // Basic auth user, e.g. of Grafana Cloud
+	BasicAuthPassword string `config:"basic_auth_password"` // Basic auth password
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Provider == "" {
+		c.Provider = ProviderPyroscope
+	}
+	if c.Interval == 0 {
+		c.Interval = defaultInterval
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+	if len(c.Profiles) == 0 {
+		c.Profiles = defaultProfiles
+	}
+}
+
+// Validate checks the provider, the server URL, the application name and the profiles.
+func (c *Config) Validate() error {
+	if c.AppName == "" {
+		return ErrAppNameRequired
+	}
+	switch c.Provider {
+	case ProviderPyroscope, ProviderParca:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidProvider, c.Provider)
+	}
+	if c.ServerURL == "" {
+		return ErrServerURLRequired
+	}
+	if c.Interval < 0 || c.Timeout < 0 {
+		return ErrInvalidInterval
+	}
+	for _, profile := range c.Profiles {
+		switch profile {
+		case ProfileCPU, ProfileHeap, ProfileAllocs, ProfileGoroutine, ProfileMutex, ProfileBlock:
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidProfile, profile)
+		}
+	}
+	return nil
+}
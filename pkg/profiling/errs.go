@@ -0,0 +1,14 @@
+package profiling
+
+import "errors"
+
+var (
+	ErrAppNameRequired   = errors.New("AppName is required")
+	ErrServerURLRequired = errors.New("ServerURL is required when profiling is enabled")
+	ErrInvalidProvider   = errors.New("invalid profiling provider (must be 'pyroscope' or 'parca')")
+	ErrInvalidProfile    = errors.New("invalid profile (must be cpu, heap, allocs, goroutine, mutex or block)")
+	ErrInvalidInterval   = errors.New("profiling interval and timeout must not be negative")
+
+	ErrProfilerStarted = errors.New("profiler already started")
+	ErrExport          = errors.New("failed to export profile")
+)
@@ -0,0 +1,157 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// cpuSampleRate is the sampling frequency of the CPU profile of the runtime, in Hz.
+const cpuSampleRate = 100
+
+// NewExporter creates the exporter of the configured provider.
+func NewExporter(cfg Config) Exporter {
+	if cfg.Provider == ProviderParca {
+		return NewParcaExporter(cfg, http.DefaultClient)
+	}
+	return NewPyroscopeExporter(cfg, http.DefaultClient)
+}
+
+// PyroscopeExporter pushes the profiles to the ingest API of Pyroscope.
+type PyroscopeExporter struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewPyroscopeExporter creates a PyroscopeExporter pushing to cfg.ServerURL.
+func NewPyroscopeExporter(cfg Config, client *http.Client) *PyroscopeExporter {
+	return &PyroscopeExporter{cfg: cfg, client: client}
+}
+
+// Export pushes profile to /ingest, named after the service label and labeled with the
+// other ones, e.g. orders{env=production,version=1.4.0}.
+func (e *PyroscopeExporter) Export(ctx context.Context, profile Profile) error {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("profile", "profile.pprof")
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(profile.Data); err != nil {
+		return err
+	}
+	if err = form.Close(); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("name", pyroscopeName(profile.Labels))
+	query.Set("from", strconv.FormatInt(profile.Start.Unix(), 10))
+	query.Set("until", strconv.FormatInt(profile.End.Unix(), 10))
+	query.Set("format", "pprof")
+	query.Set("spyName", "gospy")
+	if profile.Type == ProfileCPU {
+		query.Set("sampleRate", strconv.Itoa(cpuSampleRate))
+	}
+	endpoint := strings.TrimSuffix(e.cfg.ServerURL, "/") + "/ingest?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return send(e.client, e.cfg, req)
+}
+
+func pyroscopeName(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if key != "service" {
+			pairs = append(pairs, key+"="+labels[key])
+		}
+	}
+	return labels["service"] + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// ParcaExporter pushes the profiles to the WriteRaw API of Parca, over its HTTP gateway.
+type ParcaExporter struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewParcaExporter creates a ParcaExporter pushing to cfg.ServerURL.
+func NewParcaExporter(cfg Config, client *http.Client) *ParcaExporter {
+	return &ParcaExporter{cfg: cfg, client: client}
+}
+
+type parcaLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type parcaWriteRawRequest struct {
+	Series []parcaSeries `json:"series"`
+}
+
+type parcaSeries struct {
+	Labels struct {
+		Labels []parcaLabel `json:"labels"`
+	} `json:"labels"`
+	Samples []parcaSample `json:"samples"`
+}
+
+type parcaSample struct {
+	RawProfile []byte `json:"raw_profile"`
+}
+
+// Export pushes profile to /profiles/writeraw, named after its type with the __name__ label.
+func (e *ParcaExporter) Export(ctx context.Context, profile Profile) error {
+	series := parcaSeries{Samples: []parcaSample{{RawProfile: profile.Data}}}
+	series.Labels.Labels = append(series.Labels.Labels, parcaLabel{Name: "__name__", Value: profile.Type})
+	for _, key := range slices.Sorted(maps.Keys(profile.Labels)) {
+		series.Labels.Labels = append(series.Labels.Labels, parcaLabel{Name: key, Value: profile.Labels[key]})
+	}
+	body, err := json.Marshal(parcaWriteRawRequest{Series: []parcaSeries{series}})
+	if err != nil {
+		return err
+	}
+
+	endpoint := strings.TrimSuffix(e.cfg.ServerURL, "/") + "/profiles/writeraw"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return send(e.client, e.cfg, req)
+}
+
+// send authenticates req and sends it, failing on a non 2xx answer.
+func send(client *http.Client, cfg Config, req *http.Request) error {
+	switch {
+	case cfg.AuthToken != "":
+		req.Header.Set("Authorization", "Bearer "+cfg.AuthToken)
+	case cfg.BasicAuthUser != "":
+		req.SetBasicAuth(cfg.BasicAuthUser, cfg.BasicAuthPassword)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrExport, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: %s: %s", ErrExport, resp.Status, bytes.TrimSpace(message))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
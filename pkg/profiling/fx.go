@@ -0,0 +1,51 @@
+package profiling
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Module starts the continuous profiling configured from app.profiling with the application
+// and stops it, pushing the last profiles, on stop. An Exporter provided to the graph
+// replaces the exporter of the configured provider, e.g. for another profiling server.
+var Module = fx.Module(
+	"profiling",
+	config.Provide[Config]("app.profiling"),
+	fx.Provide(NewWithLifecycle),
+	fx.Invoke(func(*Profiler) {}),
+)
+
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    config.Config[Config]
+	Logger    logger.Logger
+	Exporter  Exporter `optional:"true"`
+}
+
+// NewWithLifecycle creates the Profiler, starts it on start and stops it on stop. It returns
+// nil when the profiling is disabled.
+func NewWithLifecycle(p NewWithLifecycleParams) (*Profiler, error) {
+	cfg := p.Config.Get()
+	if !cfg.Enabled {
+		return nil, nil //nolint:nilnil // the profiling is disabled
+	}
+
+	profiler, err := New(cfg, p.Exporter, p.Logger.Named("profiling"))
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			return profiler.Start()
+		},
+		OnStop: profiler.Stop,
+	})
+	return profiler, nil
+}
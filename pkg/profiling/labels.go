@@ -0,0 +1,20 @@
+package profiling
+
+import (
+	"context"
+	"runtime/pprof"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+// Do calls f with the pprof labels of the metadata of ctx, so the CPU samples of f are
+// labeled with the tenant from ctxmeta, e.g. to find the tenant burning the CPU. The request
+// and correlation IDs are left out, their cardinality is unbounded.
+func Do(ctx context.Context, f func(context.Context)) {
+	tenantID, ok := ctxmeta.TenantID(ctx)
+	if !ok {
+		f(ctx)
+		return
+	}
+	pprof.Do(ctx, pprof.Labels("tenant_id", tenantID), f)
+}
@@ -0,0 +1,195 @@
+// Package profiling pushes the pprof profiles of the application to a continuous profiling
+// server, Pyroscope or Parca, on an interval.
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+const (
+	// mutexProfileFraction samples one mutex contention event out of 5 when the mutex
+	// profile is pushed
+	mutexProfileFraction = 5
+	// blockProfileRate samples one blocking event per millisecond blocked when the block
+	// profile is pushed
+	blockProfileRate = int(time.Millisecond)
+)
+
+// Profile is a pprof profile covering [Start, End].
+type Profile struct {
+	Type   string // cpu, heap, allocs, goroutine, mutex or block
+	Data   []byte // gzipped pprof protobuf
+	Start  time.Time
+	End    time.Time
+	Labels map[string]string
+}
+
+// Exporter pushes the profiles to a profiling server.
+type Exporter interface {
+	Export(ctx context.Context, profile Profile) error
+}
+
+// Profiler collects the profiles every interval and pushes them with an Exporter.
+type Profiler struct {
+	cfg      Config
+	exporter Exporter
+	log      logger.Logger
+	labels   map[string]string
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New creates a Profiler pushing the profiles with exporter, or with the exporter of the
+// configured provider when exporter is nil.
+func New(cfg Config, exporter Exporter, log logger.Logger) (*Profiler, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		exporter = NewExporter(cfg)
+	}
+
+	labels := maps.Clone(cfg.Labels)
+	if labels == nil {
+		labels = make(map[string]string, 2)
+	}
+	labels["service"] = cfg.AppName
+	if cfg.AppVersion != "" {
+		labels["version"] = cfg.AppVersion
+	}
+
+	return &Profiler{cfg: cfg, exporter: exporter, log: log, labels: labels}, nil
+}
+
+// Start starts the profiling in the background.
+func (p *Profiler) Start() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		return ErrProfilerStarted
+	}
+
+	if slices.Contains(p.cfg.Profiles, ProfileMutex) {
+		runtime.SetMutexProfileFraction(mutexProfileFraction)
+	}
+	if slices.Contains(p.cfg.Profiles, ProfileBlock) {
+		runtime.SetBlockProfileRate(blockProfileRate)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.run(ctx)
+	return nil
+}
+
+// Stop stops the profiling and pushes the profiles of the current interval, waiting for
+// them until ctx is done.
+func (p *Profiler) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.cancel = nil
+	p.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if slices.Contains(p.cfg.Profiles, ProfileMutex) {
+		runtime.SetMutexProfileFraction(0)
+	}
+	if slices.Contains(p.cfg.Profiles, ProfileBlock) {
+		runtime.SetBlockProfileRate(0)
+	}
+	return nil
+}
+
+func (p *Profiler) run(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		cpu := p.startCPUProfile()
+
+		stopping := false
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			stopping = true
+		}
+
+		p.export(p.collect(start, cpu))
+		if stopping {
+			return
+		}
+	}
+}
+
+// startCPUProfile starts the CPU profile of the interval, and returns its buffer, or nil
+// when the CPU is not profiled or already profiled, e.g. by /debug/pprof/profile.
+func (p *Profiler) startCPUProfile() *bytes.Buffer {
+	if !slices.Contains(p.cfg.Profiles, ProfileCPU) {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		p.log.Warn("failed to start the CPU profile", logger.Error(err))
+		return nil
+	}
+	return &buf
+}
+
+// collect stops the CPU profile and takes the other profiles.
+func (p *Profiler) collect(start time.Time, cpu *bytes.Buffer) []Profile {
+	end := time.Now()
+	profiles := make([]Profile, 0, len(p.cfg.Profiles))
+	for _, profileType := range p.cfg.Profiles {
+		var data []byte
+		if profileType == ProfileCPU {
+			if cpu == nil {
+				continue
+			}
+			pprof.StopCPUProfile()
+			data = cpu.Bytes()
+		} else {
+			var buf bytes.Buffer
+			if err := pprof.Lookup(profileType).WriteTo(&buf, 0); err != nil {
+				p.log.Warn("failed to take the profile", logger.String("profile", profileType), logger.Error(err))
+				continue
+			}
+			data = buf.Bytes()
+		}
+		profiles = append(profiles, Profile{Type: profileType, Data: data, Start: start, End: end, Labels: p.labels})
+	}
+	return profiles
+}
+
+func (p *Profiler) export(profiles []Profile) {
+	for _, profile := range profiles {
+		ctx, cancel := context.WithTimeout(context.Background(), p.cfg.Timeout)
+		if err := p.exporter.Export(ctx, profile); err != nil {
+			p.log.Warn("failed to export the profile", logger.String("profile", profile.Type), logger.Error(err))
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,229 @@
+package profiling_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime/pprof"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/profiling"
+)
+
+const testInterval = 50 * time.Millisecond
+
+var testLogger = logger.MustNewWithOptions(logger.WithLevel("fatal"))
+
+// recordingExporter records the exported profiles.
+type recordingExporter struct {
+	mu       sync.Mutex
+	profiles []profiling.Profile
+}
+
+func (e *recordingExporter) Export(_ context.Context, profile profiling.Profile) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.profiles = append(e.profiles, profile)
+	return nil
+}
+
+func (e *recordingExporter) types() map[string]int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	types := make(map[string]int)
+	for _, profile := range e.profiles {
+		types[profile.Type]++
+	}
+	return types
+}
+
+func newConfig(serverURL string) profiling.Config {
+	return profiling.Config{
+		Enabled:    true,
+		ServerURL:  serverURL,
+		AppName:    "orders",
+		AppVersion: "1.4.0",
+		Interval:   testInterval,
+		Labels:     map[string]string{"env": "production"},
+	}
+}
+
+func TestProfiler_PushesTheProfilesEveryInterval(t *testing.T) {
+	// Arrange
+	exporter := &recordingExporter{}
+	profiler, err := profiling.New(newConfig("http://pyroscope:4040"), exporter, testLogger)
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, profiler.Start())
+	pushed := func() bool { return exporter.types()[profiling.ProfileCPU] >= 2 }
+	assert.Eventually(t, pushed, time.Second, 10*time.Millisecond)
+	require.NoError(t, profiler.Stop(context.Background()))
+
+	// Assert
+	types := exporter.types()
+	assert.Equal(t, types[profiling.ProfileCPU], types[profiling.ProfileHeap])
+	assert.Equal(t, types[profiling.ProfileCPU], types[profiling.ProfileGoroutine])
+	profile := exporter.profiles[0]
+	assert.NotEmpty(t, profile.Data)
+	assert.True(t, profile.End.After(profile.Start))
+	assert.Equal(t, map[string]string{"service": "orders", "version": "1.4.0", "env": "production"}, profile.Labels)
+}
+
+func TestProfiler_Start_FailsWhenStarted(t *testing.T) {
+	// Arrange
+	profiler, err := profiling.New(newConfig("http://pyroscope:4040"), &recordingExporter{}, testLogger)
+	require.NoError(t, err)
+	require.NoError(t, profiler.Start())
+	defer profiler.Stop(context.Background())
+
+	// Act
+	err = profiler.Start()
+
+	// Assert
+	assert.ErrorIs(t, err, profiling.ErrProfilerStarted)
+}
+
+func TestPyroscopeExporter_PushesToTheIngestAPI(t *testing.T) {
+	// Arrange
+	var req *http.Request
+	var profile []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		file, _, err := r.FormFile("profile")
+		if assert.NoError(t, err) {
+			profile, _ = io.ReadAll(file)
+		}
+	}))
+	defer server.Close()
+	cfg := newConfig(server.URL)
+	cfg.AuthToken = "token"
+	exporter := profiling.NewPyroscopeExporter(cfg, server.Client())
+	start := time.Unix(1760000000, 0)
+
+	// Act
+	err := exporter.Export(context.Background(), profiling.Profile{
+		Type:   profiling.ProfileCPU,
+		Data:   []byte("pprof"),
+		Start:  start,
+		End:    start.Add(15 * time.Second),
+		Labels: map[string]string{"service": "orders", "version": "1.4.0", "env": "production"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "/ingest", req.URL.Path)
+	query := req.URL.Query()
+	assert.Equal(t, "orders{env=production,version=1.4.0}", query.Get("name"))
+	assert.Equal(t, "1760000000", query.Get("from"))
+	assert.Equal(t, "1760000015", query.Get("until"))
+	assert.Equal(t, "pprof", query.Get("format"))
+	assert.Equal(t, "100", query.Get("sampleRate"))
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+	assert.Equal(t, []byte("pprof"), profile)
+}
+
+func TestParcaExporter_PushesToTheWriteRawAPI(t *testing.T) {
+	// Arrange
+	var path, user, password string
+	var body map[string][]map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		user, password, _ = r.BasicAuth()
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+	cfg := newConfig(server.URL)
+	cfg.BasicAuthUser, cfg.BasicAuthPassword = "user", "password"
+	exporter := profiling.NewParcaExporter(cfg, server.Client())
+
+	// Act
+	err := exporter.Export(context.Background(), profiling.Profile{
+		Type:   profiling.ProfileHeap,
+		Data:   []byte("pprof"),
+		Labels: map[string]string{"service": "orders"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "/profiles/writeraw", path)
+	assert.Equal(t, "user", user)
+	assert.Equal(t, "password", password)
+	series := body["series"][0]
+	assert.Equal(t, map[string]any{"labels": []any{
+		map[string]any{"name": "__name__", "value": "heap"},
+		map[string]any{"name": "service", "value": "orders"},
+	}}, series["labels"])
+	samples := series["samples"].([]any)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("pprof")), samples[0].(map[string]any)["raw_profile"])
+}
+
+func TestExporter_FailsOnAnErrorStatus(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid profile", http.StatusBadRequest)
+	}))
+	defer server.Close()
+	exporter := profiling.NewPyroscopeExporter(newConfig(server.URL), server.Client())
+
+	// Act
+	err := exporter.Export(context.Background(), profiling.Profile{Type: profiling.ProfileHeap})
+
+	// Assert
+	require.ErrorIs(t, err, profiling.ErrExport)
+	assert.Contains(t, err.Error(), "invalid profile")
+}
+
+func TestDo_LabelsTheSamplesWithTheTenant(t *testing.T) {
+	// Arrange
+	ctx := ctxmeta.WithTenantID(context.Background(), "acme")
+	var tenantID string
+
+	// Act
+	profiling.Do(ctx, func(ctx context.Context) {
+		tenantID, _ = pprof.Label(ctx, "tenant_id")
+	})
+
+	// Assert
+	assert.Equal(t, "acme", tenantID)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*profiling.Config)
+		wantErr error
+	}{
+		{"valid", func(*profiling.Config) {}, nil},
+		{"parca", func(c *profiling.Config) { c.Provider = profiling.ProviderParca }, nil},
+		{"missing app name", func(c *profiling.Config) { c.AppName = "" }, profiling.ErrAppNameRequired},
+		{"missing server url", func(c *profiling.Config) { c.ServerURL = "" }, profiling.ErrServerURLRequired},
+		{"invalid provider", func(c *profiling.Config) { c.Provider = "datadog" }, profiling.ErrInvalidProvider},
+		{"invalid profile", func(c *profiling.Config) { c.Profiles = []string{"threadcreate"} }, profiling.ErrInvalidProfile},
+		{"negative interval", func(c *profiling.Config) { c.Interval = -time.Second }, profiling.ErrInvalidInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cfg := newConfig("http://pyroscope:4040")
+			tt.mutate(&cfg)
+			cfg.SetDefaults()
+
+			// Act
+			err := cfg.Validate()
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
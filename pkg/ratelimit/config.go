@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+
+	defaultPrefix = "ratelimit:"
+	defaultTier   = "default"
+	defaultLimit  = 100
+	defaultWindow = time.Minute
+)
+
+// Config configures the Limiter.
+type Config struct {
+	// Backend selects where the requests are counted: memory (per instance) or redis (shared)
+	Backend string `config:"backend"`
+	// Prefix is prepended to the counter keys, after the redis client namespace
+	Prefix string `config:"prefix"`
+	// DefaultTier is the tier of the clients without one
+	DefaultTier string `config:"default_tier"`
+	// Tiers are the limits by tier name, e.g. free and pro
+	Tiers map[string]TierConfig `config:"tiers"`
+}
+
+// TierConfig is the limit of the clients of a tier: Limit requests per Window.
+type TierConfig struct {
+	Limit  int64         `config:"limit"`
+	Window time.Duration `config:"window"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Backend == "" {
+		c.Backend = BackendMemory
+	}
+	if c.Prefix == "" {
+		c.Prefix = defaultPrefix
+	}
+	if c.DefaultTier == "" {
+		c.DefaultTier = defaultTier
+	}
+	if len(c.Tiers) == 0 {
+		c.Tiers = map[string]TierConfig{c.DefaultTier: {Limit: defaultLimit, Window: defaultWindow}}
+	}
+}
+
+// Validate checks the backend and the tiers.
+func (c *Config) Validate() error {
+	if c.Backend != BackendMemory && c.Backend != BackendRedis {
+		return fmt.Errorf("%w: %s", ErrInvalidBackend, c.Backend)
+	}
+	for name, tier := range c.Tiers {
+		if tier.Limit <= 0 || tier.Window <= 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidTier, name)
+		}
+	}
+	if _, ok := c.Tiers[c.DefaultTier]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownTier, c.DefaultTier)
+	}
+	return nil
+}
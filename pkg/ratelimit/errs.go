@@ -0,0 +1,18 @@
+package ratelimit
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+var (
+	ErrInvalidBackend = errors.New("invalid rate limit backend (must be 'memory' or 'redis')")
+	ErrInvalidTier    = errors.New("invalid rate limit tier (limit and window must be positive)")
+	ErrUnknownTier    = errors.New("unknown rate limit tier")
+	ErrMissingRedis   = errors.New("the redis backend requires a *redis.Client")
+
+	// ErrRateLimited is returned when the client exceeded the limit of its tier
+	ErrRateLimited = errs.New("RATE_LIMITED", "Too many requests, please retry later", http.StatusTooManyRequests, nil)
+)
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// Module provides the Limiter. It loads the config from "app.ratelimit"; the redis
+// backend requires redis.ClientModule.
+//
+//	fx.New(
+//	    ratelimit.Module,
+//	    fx.Invoke(func(server *chi.Server, limiter *ratelimit.Limiter) {
+//	        server.Router().With(limiter.Middleware).Get("/search", search)
+//	    }),
+//	)
+var Module = fx.Module(
+	"ratelimit",
+	config.Provide[Config]("app.ratelimit"),
+	fx.Provide(NewLimiterWithParams),
+)
+
+// LimiterParams for dependency injection
+type LimiterParams struct {
+	fx.In
+
+	Config       config.Config[Config]
+	Redis        *redis.Client         `optional:"true"`
+	ErrorHandler response.ErrorHandler `optional:"true"`
+	Registerer   prometheus.Registerer `optional:"true"`
+	Logger       *slog.Logger          `optional:"true"`
+	// TierFunc sets the tiers of the clients when provided (see WithTierFunc).
+	TierFunc TierFunc `optional:"true"`
+}
+
+// NewLimiterWithParams creates the Limiter with the configured backend.
+func NewLimiterWithParams(params LimiterParams) (*Limiter, error) {
+	cfg := params.Config.Get()
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var store CounterStore = NewMemoryStore(nil)
+	if cfg.Backend == BackendRedis {
+		if params.Redis == nil {
+			return nil, ErrMissingRedis
+		}
+		store = NewRedisStore(params.Redis, cfg.Prefix)
+	}
+	return NewLimiter(store, cfg,
+		WithErrorHandler(params.ErrorHandler),
+		WithRegisterer(params.Registerer),
+		WithLogger(params.Logger),
+		WithTierFunc(params.TierFunc),
+	)
+}
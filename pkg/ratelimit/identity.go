@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+)
+
+// Identity is the client a request is counted against.
+type Identity struct {
+	// Key identifies the client, e.g. "user:42"
+	Key string
+	// Tier names the limit of the client; empty, or unknown, uses the default tier
+	Tier string
+}
+
+// IdentifyFunc returns the Identity of the client of r.
+type IdentifyFunc func(r *http.Request) Identity
+
+// TierFunc returns the tier of the client of r, e.g. from the plan of its tenant.
+type TierFunc func(r *http.Request) string
+
+// identify returns the default identity of r: its authenticated user, else its
+// authenticated API key (see ctxmeta), else its IP address, so the clients behind a shared
+// NAT are told apart once authenticated. An API key header alone is not trusted: a client
+// sending a new key with each request would get a new counter each time.
+func identify(r *http.Request, tier TierFunc) Identity {
+	identity := Identity{Key: "ip:" + clientIP(r)}
+	if userID, ok := ctxmeta.UserID(r.Context()); ok {
+		identity.Key = "user:" + userID
+	} else if apiKeyID, ok := ctxmeta.APIKeyID(r.Context()); ok {
+		identity.Key = "key:" + apiKeyID
+	}
+	if tier != nil {
+		identity.Tier = tier(r)
+	}
+	return identity
+}
+
+// clientIP returns the host of RemoteAddr, set from the proxy headers by the RealIP
+// middleware of the chi server.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
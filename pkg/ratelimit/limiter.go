@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Decision is the outcome of a request counted by Allow.
+type Decision struct {
+	// Allowed reports whether the request is within the limit
+	Allowed bool
+	// Tier is the tier applied to the client
+	Tier string
+	// Limit is the number of requests allowed per window
+	Limit int64
+	// Remaining is the number of requests left in the window
+	Remaining int64
+	// ResetAt is the end of the window
+	ResetAt time.Time
+}
+
+// Limiter throttles the clients by identity, with the limits of their tier, in fixed
+// windows: a client may send Limit requests per Window, counted from the start of the
+// window.
+type Limiter struct {
+	store       CounterStore
+	tiers       map[string]TierConfig
+	defaultTier string
+	options     options
+	metrics     *limiterMetrics
+}
+
+// NewLimiter creates a Limiter counting the requests in store with the tiers of cfg.
+//
+//	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(nil), cfg,
+//	    ratelimit.WithTierFunc(func(r *http.Request) string { return plans.Of(r.Context()) }),
+//	)
+//	router.Use(limiter.Middleware)
+func NewLimiter(store CounterStore, cfg Config, opts ...Option) (*Limiter, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.identify == nil {
+		tier := options.tier
+		options.identify = func(r *http.Request) Identity {
+			return identify(r, tier)
+		}
+	}
+	metrics, err := newLimiterMetrics(options.registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &Limiter{
+		store:       store,
+		tiers:       cfg.Tiers,
+		defaultTier: cfg.DefaultTier,
+		options:     options,
+		metrics:     metrics,
+	}, nil
+}
+
+// Allow counts a request of identity in the current window of its tier.
+func (l *Limiter) Allow(ctx context.Context, identity Identity) (Decision, error) {
+	name := identity.Tier
+	tier, ok := l.tiers[name]
+	if !ok {
+		name, tier = l.defaultTier, l.tiers[l.defaultTier]
+	}
+
+	now := l.options.clock.Now()
+	start := now.Truncate(tier.Window)
+	decision := Decision{Tier: name, Limit: tier.Limit, ResetAt: start.Add(tier.Window)}
+	key := name + ":" + identity.Key + ":" + strconv.FormatInt(start.Unix(), 10)
+	count, err := l.store.Increment(ctx, key, tier.Window)
+	if err != nil {
+		return decision, err
+	}
+	decision.Allowed = count <= tier.Limit
+	decision.Remaining = max(tier.Limit-count, 0)
+	return decision, nil
+}
+
+// Middleware throttles the requests by the identity of their client. Every response
+// carries the X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (Unix time)
+// headers; the rejected requests get ErrRateLimited, status 429, with Retry-After. The
+// requests are let through when the store fails, so an outage of Redis does not take
+// the API down.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		decision, err := l.Allow(r.Context(), l.options.identify(r))
+		if err != nil {
+			l.metrics.observe(decision.Tier, resultError)
+			l.options.logger.WarnContext(r.Context(), "rate limit unavailable, request allowed",
+				"tier", decision.Tier, "err", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Set("X-RateLimit-Limit", strconv.FormatInt(decision.Limit, 10))
+		header.Set("X-RateLimit-Remaining", strconv.FormatInt(decision.Remaining, 10))
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(decision.ResetAt.Unix(), 10))
+		if !decision.Allowed {
+			l.metrics.observe(decision.Tier, resultLimited)
+			retryAfter := math.Ceil(decision.ResetAt.Sub(l.options.clock.Now()).Seconds())
+			header.Set("Retry-After", strconv.Itoa(max(int(retryAfter), 1)))
+			l.options.errorHandler(w, r, ErrRateLimited)
+			return
+		}
+		l.metrics.observe(decision.Tier, resultAllowed)
+		next.ServeHTTP(w, r)
+	})
+}
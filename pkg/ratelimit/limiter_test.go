@@ -0,0 +1,284 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/ratelimit"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+var testNow = time.Date(2026, 10, 15, 12, 0, 10, 0, time.UTC)
+
+type LimiterTestSuite struct {
+	suite.Suite
+	sut        *ratelimit.Limiter
+	clock      *clock.Fake
+	registerer *prometheus.Registry
+	handler    http.Handler
+}
+
+func TestLimiterSuite(t *testing.T) {
+	suite.Run(t, new(LimiterTestSuite))
+}
+
+func (s *LimiterTestSuite) SetupTest() {
+	s.clock = clock.NewFake(testNow)
+	s.registerer = prometheus.NewRegistry()
+	s.sut = s.newLimiter(ratelimit.NewMemoryStore(s.clock))
+}
+
+func (s *LimiterTestSuite) newLimiter(store ratelimit.CounterStore) *ratelimit.Limiter {
+	limiter, err := ratelimit.NewLimiter(store, ratelimit.Config{
+		DefaultTier: "free",
+		Tiers: map[string]ratelimit.TierConfig{
+			"free": {Limit: 2, Window: time.Minute},
+			"pro":  {Limit: 5, Window: time.Minute},
+		},
+	},
+		ratelimit.WithClock(s.clock),
+		ratelimit.WithRegisterer(s.registerer),
+		ratelimit.WithLogger(slog.New(slog.DiscardHandler)),
+		ratelimit.WithTierFunc(func(r *http.Request) string { return r.Header.Get("X-Plan") }),
+	)
+	s.Require().NoError(err)
+	s.handler = limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	return limiter
+}
+
+func (s *LimiterTestSuite) serve(userID, plan, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/search", nil)
+	if userID != "" {
+		req = req.WithContext(ctxmeta.WithUserID(req.Context(), userID))
+	}
+	if plan != "" {
+		req.Header.Set("X-Plan", plan)
+	}
+	if remoteAddr != "" {
+		req.RemoteAddr = remoteAddr
+	}
+	rr := httptest.NewRecorder()
+	s.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *LimiterTestSuite) TestMiddleware_OverTheLimit_Returns429WithRetryAfter() {
+	// Arrange
+	s.serve("user-1", "", "")
+	s.serve("user-1", "", "")
+
+	// Act
+	rr := s.serve("user-1", "", "")
+
+	// Assert
+	s.Equal(http.StatusTooManyRequests, rr.Code)
+	s.Equal("50", rr.Header().Get("Retry-After"))
+	s.Equal("2", rr.Header().Get("X-RateLimit-Limit"))
+	s.Equal("0", rr.Header().Get("X-RateLimit-Remaining"))
+	windowEnd := time.Date(2026, 10, 15, 12, 1, 0, 0, time.UTC)
+	s.Equal(strconv.FormatInt(windowEnd.Unix(), 10), rr.Header().Get("X-RateLimit-Reset"))
+}
+
+func (s *LimiterTestSuite) TestMiddleware_WithinTheLimit_ReportsTheRemainingRequests() {
+	// Act
+	rr := s.serve("user-1", "", "")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+	s.Equal("1", rr.Header().Get("X-RateLimit-Remaining"))
+	s.Empty(rr.Header().Get("Retry-After"))
+}
+
+func (s *LimiterTestSuite) TestMiddleware_UsersBehindTheSameIP_HaveTheirOwnLimits() {
+	// Arrange
+	s.serve("user-1", "", "203.0.113.7:1234")
+	s.serve("user-1", "", "203.0.113.7:1234")
+
+	// Act
+	rr := s.serve("user-2", "", "203.0.113.7:1234")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+}
+
+func (s *LimiterTestSuite) TestMiddleware_AnonymousClients_AreLimitedByIP() {
+	// Arrange
+	s.serve("", "", "203.0.113.7:1234")
+	s.serve("", "", "203.0.113.7:5678")
+
+	// Act
+	sameIP := s.serve("", "", "203.0.113.7:9999")
+	otherIP := s.serve("", "", "198.51.100.1:1234")
+
+	// Assert
+	s.Equal(http.StatusTooManyRequests, sameIP.Code)
+	s.Equal(http.StatusOK, otherIP.Code)
+}
+
+func (s *LimiterTestSuite) TestMiddleware_RotatingAPIKeysFromOneIP_AreLimitedByIP() {
+	// Arrange
+	serve := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/search", nil)
+		req.Header.Set("X-Api-Key", apiKey)
+		req.RemoteAddr = "203.0.113.7:1234"
+		rr := httptest.NewRecorder()
+		s.handler.ServeHTTP(rr, req)
+		return rr
+	}
+	serve("random-key-1")
+	serve("random-key-2")
+
+	// Act
+	rr := serve("random-key-3")
+
+	// Assert
+	s.Equal(http.StatusTooManyRequests, rr.Code)
+}
+
+func (s *LimiterTestSuite) TestMiddleware_AuthenticatedAPIKeys_HaveTheirOwnLimits() {
+	// Arrange
+	serve := func(apiKeyID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/search", nil)
+		req = req.WithContext(ctxmeta.WithAPIKeyID(req.Context(), apiKeyID))
+		req.RemoteAddr = "203.0.113.7:1234"
+		rr := httptest.NewRecorder()
+		s.handler.ServeHTTP(rr, req)
+		return rr
+	}
+	serve("key-1")
+	serve("key-1")
+
+	// Act
+	sameKey := serve("key-1")
+	otherKey := serve("key-2")
+
+	// Assert
+	s.Equal(http.StatusTooManyRequests, sameKey.Code)
+	s.Equal(http.StatusOK, otherKey.Code)
+}
+
+func (s *LimiterTestSuite) TestMiddleware_Tier_AppliesItsLimit() {
+	// Arrange
+	for range 4 {
+		s.serve("user-1", "pro", "")
+	}
+
+	// Act
+	rr := s.serve("user-1", "pro", "")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+	s.Equal("5", rr.Header().Get("X-RateLimit-Limit"))
+}
+
+func (s *LimiterTestSuite) TestMiddleware_UnknownTier_UsesTheDefaultTier() {
+	// Act
+	rr := s.serve("user-1", "enterprise", "")
+
+	// Assert
+	s.Equal("2", rr.Header().Get("X-RateLimit-Limit"))
+}
+
+func (s *LimiterTestSuite) TestMiddleware_NextWindow_ResetsTheCount() {
+	// Arrange
+	for range 3 {
+		s.serve("user-1", "", "")
+	}
+	s.clock.Advance(50 * time.Second)
+
+	// Act
+	rr := s.serve("user-1", "", "")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+}
+
+func (s *LimiterTestSuite) TestMiddleware_RecordsTheRequestsByTierAndResult() {
+	// Arrange
+	for range 3 {
+		s.serve("user-1", "", "")
+	}
+	s.serve("user-2", "pro", "")
+
+	// Assert
+	s.Equal(2.0, s.requests("free", "allowed"))
+	s.Equal(1.0, s.requests("free", "limited"))
+	s.Equal(1.0, s.requests("pro", "allowed"))
+}
+
+func (s *LimiterTestSuite) TestMiddleware_StoreFailure_LetsTheRequestThrough() {
+	// Arrange
+	store := mocks.NewMockCounterStore(s.T())
+	store.On("Increment", mock.Anything, mock.Anything, time.Minute).Return(int64(0), errors.New("redis down"))
+	s.newLimiter(store)
+
+	// Act
+	rr := s.serve("user-1", "", "")
+
+	// Assert
+	s.Equal(http.StatusOK, rr.Code)
+	s.Equal(1.0, s.requests("free", "error"))
+}
+
+func (s *LimiterTestSuite) TestAllow_CountsTheRequestsOfTheIdentity() {
+	// Arrange
+	ctx := context.Background()
+	identity := ratelimit.Identity{Key: "key:abc", Tier: "pro"}
+	_, err := s.sut.Allow(ctx, identity)
+	s.Require().NoError(err)
+
+	// Act
+	decision, err := s.sut.Allow(ctx, identity)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(ratelimit.Decision{
+		Allowed:   true,
+		Tier:      "pro",
+		Limit:     5,
+		Remaining: 3,
+		ResetAt:   testNow.Truncate(time.Minute).Add(time.Minute),
+	}, decision)
+}
+
+func (s *LimiterTestSuite) TestNewLimiter_InvalidConfig_ReturnsAnError() {
+	// Act
+	_, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(nil), ratelimit.Config{
+		DefaultTier: "free",
+		Tiers:       map[string]ratelimit.TierConfig{"pro": {Limit: 5, Window: time.Minute}},
+	}, ratelimit.WithRegisterer(s.registerer))
+
+	// Assert
+	s.Require().ErrorIs(err, ratelimit.ErrUnknownTier)
+}
+
+func (s *LimiterTestSuite) requests(tier, result string) float64 {
+	families, err := s.registerer.Gather()
+	s.Require().NoError(err)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["tier"] == tier && labels["result"] == result {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	requestsMetricName = "ratelimit_requests_total"
+
+	resultAllowed = "allowed"
+	resultLimited = "limited"
+	resultError   = "error"
+)
+
+type limiterMetrics struct {
+	requests *prometheus.CounterVec
+}
+
+func newLimiterMetrics(registerer prometheus.Registerer) (*limiterMetrics, error) {
+	requests, err := metrics.Register(registerer, prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: requestsMetricName,
+			Help: "Total rate limited requests by tier and result (allowed, limited, error)",
+		},
+		[]string{"tier", "result"},
+	))
+	if err != nil {
+		return nil, err
+	}
+	return &limiterMetrics{requests: requests}, nil
+}
+
+func (m *limiterMetrics) observe(tier, result string) {
+	m.requests.WithLabelValues(tier, result).Inc()
+}
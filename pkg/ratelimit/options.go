@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+type options struct {
+	clock        clock.Clock
+	identify     IdentifyFunc
+	tier         TierFunc
+	registerer   prometheus.Registerer
+	logger       *slog.Logger
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Option configures the Limiter created by NewLimiter.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		clock:        clock.New(),
+		registerer:   prometheus.DefaultRegisterer,
+		logger:       slog.Default(),
+		errorHandler: response.WriteError,
+	}
+}
+
+// WithClock sets the clock of the windows.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithIdentifyFunc replaces the identification of the clients: by default the ctxmeta
+// user, the authenticated API key, then the IP address, in the tier of WithTierFunc.
+func WithIdentifyFunc(fn IdentifyFunc) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.identify = fn
+		}
+	}
+}
+
+// WithTierFunc sets the tier of the clients identified by default. Without it, every
+// client is in the default tier.
+func WithTierFunc(fn TierFunc) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.tier = fn
+		}
+	}
+}
+
+// WithRegisterer sets the registerer the request metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithLogger sets the logger of the store failures. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithErrorHandler writes the rate limited responses with the response.ErrorHandler.
+// Defaults to response.WriteError, the errs envelope with the status of the error.
+func WithErrorHandler(handler response.ErrorHandler) Option {
+	return func(o *options) {
+		if handler != nil {
+			o.errorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+				handler.ErrorCtx(r.Context(), w, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// CounterStore counts the requests of each window.
+type CounterStore interface {
+	// Increment adds a request to the counter key, created expiring after ttl, and
+	// returns the count.
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
+
+// MemoryStore counts the requests in memory, so each instance applies the limits on its own.
+type MemoryStore struct {
+	clock     clock.Clock
+	mu        sync.Mutex
+	counters  map[string]memoryCounter
+	nextSweep time.Time
+}
+
+type memoryCounter struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// sweepInterval is how often the expired counters are dropped.
+const sweepInterval = time.Minute
+
+// NewMemoryStore creates an empty MemoryStore; a nil clk uses the time package.
+func NewMemoryStore(clk clock.Clock) *MemoryStore {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &MemoryStore{clock: clk, counters: make(map[string]memoryCounter)}
+}
+
+// Increment implements CounterStore.
+func (s *MemoryStore) Increment(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	now := s.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.After(s.nextSweep) {
+		for k, counter := range s.counters {
+			if !now.Before(counter.expiresAt) {
+				delete(s.counters, k)
+			}
+		}
+		s.nextSweep = now.Add(sweepInterval)
+	}
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.expiresAt) {
+		counter = memoryCounter{expiresAt: now.Add(ttl)}
+	}
+	counter.count++
+	s.counters[key] = counter
+	return counter.count, nil
+}
+
+// RedisStore counts the requests in Redis, so the instances share the limits.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore creates a RedisStore keeping the counters under prefix + key.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Increment implements CounterStore. The window is part of the key, so resetting the
+// expiration on each request never extends a window.
+func (s *RedisStore) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var incr *goredis.IntCmd
+	err := s.client.TxPipeline(ctx, func(p redis.Pipeliner) error {
+		incr = p.Incr(ctx, s.prefix+key)
+		p.Expire(ctx, s.prefix+key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("ratelimit: increment counter: %w", err)
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,15 @@
+package redact
+
+import "github.com/cristiano-pacheco/bricks/pkg/logger"
+
+// Any returns a log field of the redacted copy of value, e.g.
+//
+//	log.Info("customer created", redact.Any("customer", customer))
+func Any(key string, value any) logger.Field {
+	return logger.Any(key, defaultRedactor.Redact(value))
+}
+
+// Any returns a log field of the copy of value redacted by r.
+func (r *Redactor) Any(key string, value any) logger.Field {
+	return logger.Any(key, r.Redact(value))
+}
@@ -0,0 +1,261 @@
+// Package redact masks the personal and secret data of values before they are logged,
+// audited or returned: the struct fields tagged `redact:"..."` and the map entries of
+// secret keys are masked in a deep copy, the original value is left untouched.
+//
+//	type Customer struct {
+//	    Name  string
+//	    Email string `redact:"email"` // j***@example.com
+//	    Card  string `redact:"last4"` // ************1111
+//	    SSN   string `redact:"full"`  // [REDACTED]
+//	}
+package redact
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Strategies of the redact tag.
+const (
+	Full  = "full"  // the whole value: Mask for the strings and the any values, zero otherwise
+	Email = "email" // the local part of an email address but its first letter
+	Last4 = "last4" // every character but the last 4
+)
+
+const (
+	// Mask replaces the values redacted in full.
+	Mask = "[REDACTED]"
+
+	tagName  = "redact"
+	maxDepth = 32
+)
+
+// defaultKeys are the key fragments whose map entries are redacted in full.
+var defaultKeys = []string{
+	"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey",
+	"credential", "private_key",
+}
+
+var (
+	defaultRedactor = New()
+	stringType      = reflect.TypeFor[string]()
+)
+
+// Strategy masks a string value.
+type Strategy func(value string) string
+
+// Redactor masks the values by their struct tags and map keys.
+type Redactor struct {
+	strategies map[string]Strategy
+	keys       []string
+}
+
+// Option configures the Redactor created by New.
+type Option func(*Redactor)
+
+// WithStrategy adds the strategy name of the redact tag, e.g. `redact:"phone"`, or replaces
+// a built-in one.
+func WithStrategy(name string, strategy Strategy) Option {
+	return func(r *Redactor) {
+		if strategy != nil {
+			r.strategies[name] = strategy
+		}
+	}
+}
+
+// WithKeys adds key fragments to the default ones (password, secret, token, ...): the map
+// entries whose key contains one of them, case insensitive, are redacted in full.
+func WithKeys(keys ...string) Option {
+	return func(r *Redactor) {
+		for _, key := range keys {
+			r.keys = append(r.keys, strings.ToLower(key))
+		}
+	}
+}
+
+// New creates a Redactor with the built-in strategies and the default secret keys.
+func New(opts ...Option) *Redactor {
+	r := &Redactor{
+		strategies: map[string]Strategy{
+			Full:  func(string) string { return Mask },
+			Email: maskEmail,
+			Last4: maskLast4,
+		},
+		keys: append([]string(nil), defaultKeys...),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Value returns a redacted deep copy of value with the default Redactor.
+func Value[T any](value T) T {
+	redacted, _ := defaultRedactor.Redact(value).(T)
+	return redacted
+}
+
+// Redact returns a deep copy of value, of the same type, with the struct fields tagged
+// `redact:"<strategy>"` and the map entries of secret keys masked. An unknown strategy
+// redacts in full. The unexported fields are copied as they are, shared when they are
+// pointers, slices or maps; the values nested deeper than 32 levels are zeroed.
+func (r *Redactor) Redact(value any) any {
+	if value == nil {
+		return nil
+	}
+	return r.copy(reflect.ValueOf(value), 0).Interface()
+}
+
+func (r *Redactor) copy(v reflect.Value, depth int) reflect.Value {
+	if depth > maxDepth {
+		return reflect.Zero(v.Type())
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(r.copy(v.Elem(), depth+1))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(r.copy(v.Elem(), depth+1))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if strategy, ok := field.Tag.Lookup(tagName); ok {
+				out.Field(i).Set(r.mask(v.Field(i), strategy, depth+1))
+				continue
+			}
+			out.Field(i).Set(r.copy(v.Field(i), depth+1))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			reflect.Copy(out, v)
+			return out
+		}
+		for i := range v.Len() {
+			out.Index(i).Set(r.copy(v.Index(i), depth+1))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(r.copy(v.Index(i), depth+1))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, value := iter.Key(), iter.Value()
+			if key.Kind() == reflect.String && r.isSecret(key.String()) {
+				out.SetMapIndex(key, r.mask(value, Full, depth+1))
+				continue
+			}
+			out.SetMapIndex(key, r.copy(value, depth+1))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// mask applies strategy to the strings of v, and zeroes its other values.
+func (r *Redactor) mask(v reflect.Value, strategy string, depth int) reflect.Value {
+	if depth > maxDepth {
+		return reflect.Zero(v.Type())
+	}
+	switch v.Kind() {
+	case reflect.String:
+		apply, ok := r.strategies[strategy]
+		if !ok {
+			apply = r.strategies[Full]
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.SetString(apply(v.String()))
+		return out
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(r.mask(v.Elem(), strategy, depth+1))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		switch v.Elem().Kind() {
+		case reflect.String, reflect.Pointer, reflect.Slice:
+			out.Set(r.mask(v.Elem(), strategy, depth+1))
+		default:
+			// An any holds the Mask rather than a zero value, e.g. a number of a map[string]any
+			if stringType.AssignableTo(v.Type()) {
+				out.Set(reflect.ValueOf(Mask))
+			}
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(r.mask(v.Index(i), strategy, depth+1))
+		}
+		return out
+	default:
+		return reflect.Zero(v.Type())
+	}
+}
+
+func (r *Redactor) isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range r.keys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskEmail keeps the first letter of the local part and the domain of an email address,
+// e.g. john.doe@example.com becomes j***@example.com. A value without @ is masked in full.
+func maskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return Mask
+	}
+	first := []rune(value[:at])[0]
+	return string(first) + "***" + value[at:]
+}
+
+// maskLast4 masks every character of value but the last 4, e.g. 4111111111111111 becomes
+// ************1111. A value of 4 characters or less is masked whole.
+func maskLast4(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
@@ -0,0 +1,158 @@
+package redact_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redact"
+)
+
+type card struct {
+	Number string `json:"number" redact:"last4"`
+	Holder string `json:"holder"`
+}
+
+type customer struct {
+	Name     string            `json:"name"`
+	Email    string            `json:"email" redact:"email"`
+	SSN      string            `json:"ssn" redact:"full"`
+	Age      int               `json:"age" redact:"full"`
+	Phone    *string           `json:"phone" redact:"phone"`
+	Aliases  []string          `json:"aliases" redact:"email"`
+	Cards    []card            `json:"cards"`
+	Settings map[string]string `json:"settings"`
+	note     string
+}
+
+func TestValue(t *testing.T) {
+	t.Run("masks the tagged fields of a deep copy", func(t *testing.T) {
+		// Arrange
+		phone := "+1 555 0100"
+		original := customer{
+			Name:     "Jane",
+			Email:    "jane.doe@example.com",
+			SSN:      "123-45-6789",
+			Age:      42,
+			Phone:    &phone,
+			Aliases:  []string{"jd@example.org"},
+			Cards:    []card{{Number: "4111111111111111", Holder: "Jane Doe"}},
+			Settings: map[string]string{"theme": "dark", "api_token": "abc"},
+			note:     "kept",
+		}
+
+		// Act
+		redacted := redact.Value(original)
+
+		// Assert
+		assert.Equal(t, "Jane", redacted.Name)
+		assert.Equal(t, "j***@example.com", redacted.Email)
+		assert.Equal(t, redact.Mask, redacted.SSN)
+		assert.Zero(t, redacted.Age)
+		require.NotNil(t, redacted.Phone)
+		assert.Equal(t, redact.Mask, *redacted.Phone)
+		assert.Equal(t, []string{"j***@example.org"}, redacted.Aliases)
+		assert.Equal(t, []card{{Number: "************1111", Holder: "Jane Doe"}}, redacted.Cards)
+		assert.Equal(t, map[string]string{"theme": "dark", "api_token": redact.Mask}, redacted.Settings)
+		assert.Equal(t, "kept", redacted.note)
+
+		assert.Equal(t, "jane.doe@example.com", original.Email)
+		assert.Equal(t, "+1 555 0100", phone)
+		assert.Equal(t, "4111111111111111", original.Cards[0].Number)
+		assert.Equal(t, "abc", original.Settings["api_token"])
+	})
+
+	t.Run("masks the secret keys of nested maps", func(t *testing.T) {
+		// Arrange
+		original := map[string]any{
+			"user":    map[string]any{"password": "hunter2", "pin_code": 1234},
+			"orders":  []any{&card{Number: "5500000000000004"}},
+			"Api_Key": 42,
+		}
+
+		// Act
+		redacted := redact.Value(original)
+
+		// Assert
+		assert.Equal(t, map[string]any{"password": redact.Mask, "pin_code": 1234}, redacted["user"])
+		assert.Equal(t, "************0004", redacted["orders"].([]any)[0].(*card).Number)
+		assert.Equal(t, redact.Mask, redacted["Api_Key"])
+		assert.Equal(t, "5500000000000004", original["orders"].([]any)[0].(*card).Number)
+	})
+
+	t.Run("returns the nil values", func(t *testing.T) {
+		// Act & Assert
+		assert.Nil(t, redact.Value[*customer](nil))
+		assert.Nil(t, redact.Value[any](nil))
+	})
+}
+
+func TestRedactor_Redact(t *testing.T) {
+	t.Run("applies the custom strategies and keys", func(t *testing.T) {
+		// Arrange
+		redactor := redact.New(
+			redact.WithStrategy("phone", func(value string) string { return value[:3] + "..." }),
+			redact.WithKeys("iban"),
+		)
+		phone := "+1 555 0100"
+		original := map[string]any{"customer": customer{Phone: &phone}, "IBAN": "DE89370400440532013000"}
+
+		// Act
+		redacted := redactor.Redact(original).(map[string]any)
+
+		// Assert
+		assert.Equal(t, "+1 ...", *redacted["customer"].(customer).Phone)
+		assert.Equal(t, redact.Mask, redacted["IBAN"])
+	})
+
+	t.Run("masks a short or malformed value whole", func(t *testing.T) {
+		// Arrange
+		type account struct {
+			Email string `redact:"email"`
+			PIN   string `redact:"last4"`
+		}
+
+		// Act
+		redacted := redact.Value(account{Email: "not-an-email", PIN: "1234"})
+
+		// Assert
+		assert.Equal(t, redact.Mask, redacted.Email)
+		assert.Equal(t, "****", redacted.PIN)
+	})
+
+	t.Run("zeroes the values nested too deep", func(t *testing.T) {
+		// Arrange
+		type node struct {
+			Name string
+			Next *node
+		}
+		root := &node{Name: "0"}
+		current := root
+		for i := range 40 {
+			current.Next = &node{Name: strings.Repeat("x", i)}
+			current = current.Next
+		}
+
+		// Act
+		redacted := redact.Value(root)
+
+		// Assert
+		depth := 0
+		for current = redacted; current != nil; current = current.Next {
+			depth++
+		}
+		assert.Less(t, depth, 41)
+		assert.Positive(t, depth)
+	})
+}
+
+func TestAny(t *testing.T) {
+	// Act
+	field := redact.Any("customer", customer{Name: "Jane", Email: "jane@example.com"})
+
+	// Assert
+	assert.Equal(t, "customer", field.Key)
+	assert.Equal(t, "j***@example.com", field.Interface.(customer).Email)
+}
@@ -0,0 +1,43 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is a typed cache of JSON values in Redis, built on MGetJSON and MSetJSON. It
+// implements memcache.Store, e.g. as the remote tier of a memcache.Tiered cache.
+type Cache[V any] struct {
+	client *Client
+}
+
+// NewCache creates a Cache storing its values in the namespace of client.
+func NewCache[V any](client *Client) *Cache[V] {
+	return &Cache[V]{client: client}
+}
+
+// Get returns the value of key, and false when there is none. A value that cannot be
+// decoded into V returns an ErrDecodeValue error.
+func (c *Cache[V]) Get(ctx context.Context, key string) (V, bool, error) {
+	values, err := MGetJSON[V](ctx, c.client, key)
+	if err != nil {
+		var zero V
+		return zero, false, err
+	}
+	value, ok := values[key]
+	return value, ok, nil
+}
+
+// Set stores the JSON encoding of value in key, expiring after ttl. A zero ttl keeps the
+// key without expiration.
+func (c *Cache[V]) Set(ctx context.Context, key string, value V, ttl time.Duration) error {
+	return MSetJSON(ctx, c.client, map[string]V{key: value}, ttl)
+}
+
+// Delete deletes key; a missing key is not an error.
+func (c *Cache[V]) Delete(ctx context.Context, key string) error {
+	return c.client.Pipeline(ctx, func(p Pipeliner) error {
+		p.Del(ctx, key)
+		return nil
+	})
+}
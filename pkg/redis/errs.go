@@ -29,6 +29,39 @@ var (
 
 	// ErrClientClosed indicates that the client is already closed
 	ErrClientClosed = errors.New("redis client is closed")
+
+	// ErrMissingNamespace indicates that a namespace operation runs on a client without namespace
+	ErrMissingNamespace = errors.New("redis namespace is required")
+
+	// ErrEncodeValue indicates that a value cannot be encoded to JSON
+	ErrEncodeValue = errors.New("failed to encode redis value")
+
+	// ErrDecodeValue indicates that a stored value cannot be decoded from JSON
+	ErrDecodeValue = errors.New("failed to decode redis value")
+
+	// ErrScriptNotFound indicates that no Lua script is registered under the given name
+	ErrScriptNotFound = errors.New("redis script not found")
+
+	// ErrDuplicateScript indicates that a Lua script is already registered under the given name
+	ErrDuplicateScript = errors.New("redis script already registered")
+
+	// ErrUnexpectedScriptResult indicates that a Lua script reply cannot be converted to the requested type
+	ErrUnexpectedScriptResult = errors.New("unexpected redis script result")
+
+	// ErrLockNotAcquired indicates that the lock is held by another holder
+	ErrLockNotAcquired = errors.New("redis lock not acquired")
+
+	// ErrLockNotHeld indicates that the lock expired or was acquired by another holder
+	ErrLockNotHeld = errors.New("redis lock not held")
+
+	// ErrInvalidPoint indicates that a latitude or longitude is out of range
+	ErrInvalidPoint = errors.New("invalid geographic point")
+
+	// ErrInvalidGeoQuery indicates that a geo search has no center or no area
+	ErrInvalidGeoQuery = errors.New("invalid redis geo query")
+
+	// ErrModuleUnavailable indicates that the command of a Redis module, e.g. RedisBloom, is unknown to the server
+	ErrModuleUnavailable = errors.New("redis module not available")
 )
 
 // ConnectionError wraps connection errors with additional context
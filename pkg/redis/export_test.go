@@ -0,0 +1,9 @@
+package redis
+
+func NewTestClient(client UniversalClient, cfg Config) *Client {
+	c := &Client{client: client, config: cfg, opts: defaultOptions(), namespace: cfg.Namespace}
+	if cfg.EnableMetrics {
+		c.metrics = newMetricsCollector()
+	}
+	return c
+}
@@ -50,7 +50,7 @@ var ClientModule = fx.Module("redis-client",
 // Params for dependency injection
 type Params struct {
 	fx.In
-	Config  Config
+	Config  config.Config[Config]
 	Options []Option `optional:"true"`
 }
 
@@ -59,7 +59,7 @@ func NewWithFx(lc fx.Lifecycle, params Params) (*Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultFxTimeout)
 	defer cancel()
 
-	client, err := NewClient(ctx, params.Config, params.Options...)
+	client, err := NewClient(ctx, params.Config.Get(), params.Options...)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +81,7 @@ func NewUniversalClientWithFx(lc fx.Lifecycle, params Params) (UniversalClient,
 	ctx, cancel := context.WithTimeout(context.Background(), defaultFxTimeout)
 	defer cancel()
 
-	client, err := NewClient(ctx, params.Config, params.Options...)
+	client, err := NewClient(ctx, params.Config.Get(), params.Options...)
 	if err != nil {
 		return nil, err
 	}
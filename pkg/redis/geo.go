@@ -0,0 +1,235 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// MaxGeoLatitude is the largest latitude Redis indexes: the poles are out of reach of its
+	// Web Mercator projection
+	MaxGeoLatitude = 85.05112878
+
+	// earthRadiusMeters is the Earth radius used by Redis for its distances
+	earthRadiusMeters = 6372797.560856
+)
+
+// GeoUnit is the unit of a distance.
+type GeoUnit string
+
+const (
+	Meters     GeoUnit = "m"
+	Kilometers GeoUnit = "km"
+	Miles      GeoUnit = "mi"
+	Feet       GeoUnit = "ft"
+)
+
+// meters returns the meters of one unit; an empty unit is meters.
+func (u GeoUnit) meters() (float64, error) {
+	switch u {
+	case Meters, "":
+		return 1, nil
+	case Kilometers:
+		return 1000, nil
+	case Miles:
+		return 1609.34, nil
+	case Feet:
+		return 0.3048, nil
+	default:
+		return 0, fmt.Errorf("%w: unknown unit %q", ErrInvalidGeoQuery, u)
+	}
+}
+
+// Point is a geographic position in degrees.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Validate returns an ErrInvalidPoint error when the latitude is outside ±85.05112878
+// (see MaxGeoLatitude) or the longitude outside ±180.
+func (p Point) Validate() error {
+	if math.IsNaN(p.Lat) || p.Lat < -MaxGeoLatitude || p.Lat > MaxGeoLatitude {
+		return fmt.Errorf("%w: latitude %v", ErrInvalidPoint, p.Lat)
+	}
+	if math.IsNaN(p.Lon) || p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("%w: longitude %v", ErrInvalidPoint, p.Lon)
+	}
+	return nil
+}
+
+// Distance returns the distance to q in unit, computed as Redis does with the haversine
+// formula, without a round trip. An unknown unit is meters.
+func (p Point) Distance(q Point, unit GeoUnit) float64 {
+	lat1, lat2 := p.Lat*math.Pi/180, q.Lat*math.Pi/180
+	u := math.Sin((lat2 - lat1) / 2)
+	v := math.Sin((q.Lon - p.Lon) * math.Pi / 180 / 2)
+	meters := 2 * earthRadiusMeters * math.Asin(math.Sqrt(u*u+math.Cos(lat1)*math.Cos(lat2)*v*v))
+	perUnit, err := unit.meters()
+	if err != nil {
+		return meters
+	}
+	return meters / perUnit
+}
+
+// GeoLocation is a member of a geo index and its position.
+type GeoLocation struct {
+	Member string
+	Point  Point
+}
+
+// GeoResult is a location found by GeoSearch, with its distance to the search center.
+type GeoResult struct {
+	Member   string
+	Point    Point
+	Distance float64 // In the unit of the query
+}
+
+// GeoQuery selects the members of a geo index around a center: Center, or the position
+// of the member FromMember. The area is the circle of Radius, or the Width by Height box.
+type GeoQuery struct {
+	Center     Point
+	FromMember string
+	Radius     float64
+	Width      float64
+	Height     float64
+	// Unit of Radius, Width, Height and the result distances; empty for meters
+	Unit GeoUnit
+	// Limit bounds the number of results, nearest first; 0 returns them all
+	Limit int
+	// Descending sorts the results farthest first
+	Descending bool
+}
+
+// GeoAdd adds the locations to the geo index key, or moves the existing members, and
+// returns the number of added members. No location is added when one of them is invalid.
+//
+//	_, err := client.GeoAdd(ctx, "couriers", redis.GeoLocation{
+//	    Member: courierID,
+//	    Point:  redis.Point{Lat: -23.5614, Lon: -46.6559},
+//	})
+func (c *Client) GeoAdd(ctx context.Context, key string, locations ...GeoLocation) (int64, error) {
+	args := make([]*redis.GeoLocation, len(locations))
+	for i, location := range locations {
+		if err := location.Point.Validate(); err != nil {
+			return 0, fmt.Errorf("member %s: %w", location.Member, err)
+		}
+		args[i] = &redis.GeoLocation{
+			Name:      location.Member,
+			Latitude:  location.Point.Lat,
+			Longitude: location.Point.Lon,
+		}
+	}
+	return runCommand(c, func() (int64, error) {
+		return c.client.GeoAdd(ctx, c.WithNamespace(key), args...).Result()
+	})
+}
+
+// GeoRemove removes members from the geo index key and returns the number of removed members.
+func (c *Client) GeoRemove(ctx context.Context, key string, members ...string) (int64, error) {
+	return runCommand(c, func() (int64, error) {
+		return c.client.ZRem(ctx, c.WithNamespace(key), toArgs(members)...).Result()
+	})
+}
+
+// GeoPosition returns the positions of members in the geo index key. Missing members are
+// left out of the result.
+func (c *Client) GeoPosition(ctx context.Context, key string, members ...string) (map[string]Point, error) {
+	positions, err := runCommand(c, func() ([]*redis.GeoPos, error) {
+		return c.client.GeoPos(ctx, c.WithNamespace(key), members...).Result()
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]Point, len(positions))
+	for i, position := range positions {
+		if position != nil {
+			result[members[i]] = Point{Lat: position.Latitude, Lon: position.Longitude}
+		}
+	}
+	return result, nil
+}
+
+// GeoDistance returns the distance between the members a and b of the geo index key in
+// unit, and false when one of them is missing.
+func (c *Client) GeoDistance(ctx context.Context, key, a, b string, unit GeoUnit) (float64, bool, error) {
+	if _, err := unit.meters(); err != nil {
+		return 0, false, err
+	}
+	if unit == "" {
+		unit = Meters
+	}
+	distance, err := runCommand(c, func() (float64, error) {
+		return c.client.GeoDist(ctx, c.WithNamespace(key), a, b, string(unit)).Result()
+	})
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return distance, true, nil
+}
+
+// GeoSearch returns the members of the geo index key in the area of query, nearest first.
+//
+//	nearby, err := client.GeoSearch(ctx, "couriers", redis.GeoQuery{
+//	    Center: pickup,
+//	    Radius: 3,
+//	    Unit:   redis.Kilometers,
+//	    Limit:  10,
+//	})
+func (c *Client) GeoSearch(ctx context.Context, key string, query GeoQuery) ([]GeoResult, error) {
+	q, err := query.toRedis()
+	if err != nil {
+		return nil, err
+	}
+	locations, err := runCommand(c, func() ([]redis.GeoLocation, error) {
+		return c.client.GeoSearchLocation(ctx, c.WithNamespace(key), q).Result()
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]GeoResult, len(locations))
+	for i, location := range locations {
+		results[i] = GeoResult{
+			Member:   location.Name,
+			Point:    Point{Lat: location.Latitude, Lon: location.Longitude},
+			Distance: location.Dist,
+		}
+	}
+	return results, nil
+}
+
+func (q GeoQuery) toRedis() (*redis.GeoSearchLocationQuery, error) {
+	if _, err := q.Unit.meters(); err != nil {
+		return nil, err
+	}
+	unit := string(q.Unit)
+	if unit == "" {
+		unit = string(Meters)
+	}
+	search := redis.GeoSearchQuery{Member: q.FromMember, Sort: "ASC", Count: q.Limit}
+	if q.Descending {
+		search.Sort = "DESC"
+	}
+	if q.FromMember == "" {
+		if err := q.Center.Validate(); err != nil {
+			return nil, err
+		}
+		search.Latitude, search.Longitude = q.Center.Lat, q.Center.Lon
+	}
+	switch {
+	case q.Radius > 0:
+		search.Radius, search.RadiusUnit = q.Radius, unit
+	case q.Width > 0 && q.Height > 0:
+		search.BoxWidth, search.BoxHeight, search.BoxUnit = q.Width, q.Height, unit
+	default:
+		return nil, fmt.Errorf("%w: a radius or a width and height are required", ErrInvalidGeoQuery)
+	}
+	return &redis.GeoSearchLocationQuery{GeoSearchQuery: search, WithCoord: true, WithDist: true}, nil
+}
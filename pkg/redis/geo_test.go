@@ -0,0 +1,93 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestPoint_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		point   redis.Point
+		wantErr bool
+	}{
+		{name: "valid point", point: redis.Point{Lat: -23.5614, Lon: -46.6559}},
+		{name: "highest indexed latitude", point: redis.Point{Lat: redis.MaxGeoLatitude, Lon: 180}},
+		{name: "latitude beyond the indexed range", point: redis.Point{Lat: 89, Lon: 0}, wantErr: true},
+		{name: "longitude out of range", point: redis.Point{Lat: 0, Lon: -181}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.point.Validate()
+
+			// Assert
+			if tt.wantErr {
+				require.ErrorIs(t, err, redis.ErrInvalidPoint)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPoint_Distance(t *testing.T) {
+	// Arrange
+	paulista := redis.Point{Lat: -23.5614, Lon: -46.6559}
+	se := redis.Point{Lat: -23.5505, Lon: -46.6333}
+
+	// Act
+	meters := paulista.Distance(se, redis.Meters)
+	kilometers := paulista.Distance(se, redis.Kilometers)
+
+	// Assert
+	assert.InDelta(t, 2599, meters, 5)
+	assert.InDelta(t, meters/1000, kilometers, 1e-9)
+	assert.Zero(t, paulista.Distance(paulista, redis.Meters))
+}
+
+func TestClient_GeoAdd(t *testing.T) {
+	t.Run("rejects an invalid point without sending the command", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+
+		// Act
+		_, err := sut.GeoAdd(context.Background(), "couriers",
+			redis.GeoLocation{Member: "c-1", Point: redis.Point{Lat: -23.5, Lon: -46.6}},
+			redis.GeoLocation{Member: "c-2", Point: redis.Point{Lat: 91, Lon: 0}},
+		)
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrInvalidPoint)
+		assert.Contains(t, err.Error(), "c-2")
+		assert.Zero(t, sut.GetMetrics().CommandsExecuted)
+	})
+}
+
+func TestClient_GeoSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		query redis.GeoQuery
+	}{
+		{name: "without area", query: redis.GeoQuery{Center: redis.Point{Lat: -23.5, Lon: -46.6}}},
+		{name: "unknown unit", query: redis.GeoQuery{Radius: 3, Unit: "league"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			sut := newUnreachableClient(t)
+
+			// Act
+			_, err := sut.GeoSearch(context.Background(), "couriers", tt.query)
+
+			// Assert
+			require.ErrorIs(t, err, redis.ErrInvalidGeoQuery)
+			assert.Zero(t, sut.GetMetrics().CommandsExecuted)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// scanCount is the COUNT hint of SCAN, and so the size of the key batches
+	scanCount = 500
+	// ttlAuditSampleSize caps the keys without expiration listed by AuditTTL
+	ttlAuditSampleSize = 100
+)
+
+// TTLAudit summarizes the expiration of the keys matched by AuditTTL.
+type TTLAudit struct {
+	Keys             int64         // Number of keys scanned
+	Persistent       int64         // Number of keys without expiration
+	PersistentSample []string      // Up to 100 keys without expiration, without the namespace
+	MinTTL           time.Duration // Shortest remaining TTL of the expiring keys
+	MaxTTL           time.Duration // Longest remaining TTL of the expiring keys
+}
+
+// ScanKeys calls fn with every key of the namespace matching pattern, without the
+// namespace prefix. Keys are iterated with SCAN, so the server is never blocked, and on
+// a cluster every master is scanned. fn is never called concurrently; an error returned
+// by fn stops the scan. As with SCAN, a key modified during the scan may be seen twice.
+//
+//	err := client.ScanKeys(ctx, "session:*", func(key string) error {
+//	    fmt.Println(key) // session:42
+//	    return nil
+//	})
+func (c *Client) ScanKeys(ctx context.Context, pattern string, fn func(key string) error) error {
+	return c.scan(ctx, pattern, func(_ context.Context, _ redis.Cmdable, keys []string) error {
+		for _, key := range keys {
+			if err := fn(c.WithoutNamespace(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteKeys deletes the keys of the namespace matching pattern, e.g. "tenant:42:*",
+// and returns the number of deleted keys. Keys are found with SCAN and removed in
+// batches with UNLINK, which frees the memory in the background.
+func (c *Client) DeleteKeys(ctx context.Context, pattern string) (int64, error) {
+	var deleted int64
+	err := c.scan(ctx, pattern, func(ctx context.Context, node redis.Cmdable, keys []string) error {
+		// One UNLINK per key, since the keys of a batch may live in different cluster slots
+		start := time.Now()
+		cmds, err := node.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				pipe.Unlink(ctx, key)
+			}
+			return nil
+		})
+		elapsed := time.Since(start)
+		for _, cmd := range cmds {
+			c.recordCommand(elapsed/time.Duration(len(cmds)), cmd.Err())
+			if intCmd, ok := cmd.(*redis.IntCmd); ok {
+				deleted += intCmd.Val()
+			}
+		}
+		return err
+	})
+	return deleted, err
+}
+
+// DeleteNamespace deletes every key of the client namespace and returns the number of
+// deleted keys. It returns ErrMissingNamespace when the client has no namespace, rather
+// than deleting the whole database.
+func (c *Client) DeleteNamespace(ctx context.Context) (int64, error) {
+	if c.namespace == "" {
+		return 0, ErrMissingNamespace
+	}
+	return c.DeleteKeys(ctx, "*")
+}
+
+// AuditTTL reports the expiration of the keys of the namespace matching pattern,
+// e.g. to find cache keys written without a TTL.
+func (c *Client) AuditTTL(ctx context.Context, pattern string) (*TTLAudit, error) {
+	audit := &TTLAudit{}
+	err := c.scan(ctx, pattern, func(ctx context.Context, node redis.Cmdable, keys []string) error {
+		start := time.Now()
+		cmds, err := node.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				pipe.TTL(ctx, key)
+			}
+			return nil
+		})
+		elapsed := time.Since(start)
+		if err != nil {
+			c.recordCommand(elapsed, err)
+			return err
+		}
+
+		for i, cmd := range cmds {
+			c.recordCommand(elapsed/time.Duration(len(cmds)), nil)
+			ttl := cmd.(*redis.DurationCmd).Val()
+			audit.record(c.WithoutNamespace(keys[i]), ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+
+func (a *TTLAudit) record(key string, ttl time.Duration) {
+	switch {
+	case ttl == -1:
+		// go-redis reports -1 for a key without expiration
+		a.Keys++
+		a.Persistent++
+		if len(a.PersistentSample) < ttlAuditSampleSize {
+			a.PersistentSample = append(a.PersistentSample, key)
+		}
+	case ttl >= 0:
+		a.Keys++
+		if a.MinTTL == 0 || ttl < a.MinTTL {
+			a.MinTTL = ttl
+		}
+		if ttl > a.MaxTTL {
+			a.MaxTTL = ttl
+		}
+	}
+	// -2 means the key expired since the scan found it
+}
+
+// scan iterates the namespaced keys matching pattern with SCAN and calls fn with each
+// batch and the node holding it. fn is never called concurrently.
+func (c *Client) scan(
+	ctx context.Context,
+	pattern string,
+	fn func(ctx context.Context, node redis.Cmdable, keys []string) error,
+) error {
+	if c.isClosed {
+		return ErrClientClosed
+	}
+
+	match := c.WithNamespace(pattern)
+	var mu sync.Mutex
+	scanNode := func(ctx context.Context, node redis.Cmdable) error {
+		var cursor uint64
+		for {
+			start := time.Now()
+			keys, next, err := node.Scan(ctx, cursor, match, scanCount).Result()
+			c.recordCommand(time.Since(start), err)
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				mu.Lock()
+				err = fn(ctx, node, keys)
+				mu.Unlock()
+				if err != nil {
+					return err
+				}
+			}
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	}
+
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scanNode(ctx, node)
+		})
+	}
+	return scanNode(ctx, c.client)
+}
@@ -0,0 +1,60 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestClient_DeleteNamespace(t *testing.T) {
+	t.Run("returns ErrMissingNamespace when the client has no namespace", func(t *testing.T) {
+		// Arrange
+		universal := goredis.NewClient(&goredis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+		t.Cleanup(func() { _ = universal.Close() })
+		sut := redis.NewTestClient(universal, redis.Config{EnableMetrics: true})
+
+		// Act
+		deleted, err := sut.DeleteNamespace(context.Background())
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrMissingNamespace)
+		assert.Zero(t, deleted)
+		assert.Zero(t, sut.GetMetrics().CommandsExecuted)
+	})
+}
+
+func TestClient_ScanKeys(t *testing.T) {
+	t.Run("returns the scan error and records it", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+		var called bool
+
+		// Act
+		err := sut.ScanKeys(context.Background(), "*", func(string) error {
+			called = true
+			return nil
+		})
+
+		// Assert
+		require.Error(t, err)
+		assert.False(t, called)
+		assert.Equal(t, uint64(1), sut.GetMetrics().CommandsFailed)
+	})
+
+	t.Run("returns ErrClientClosed when the client is closed", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+		require.NoError(t, sut.Close())
+
+		// Act
+		_, err := sut.AuditTTL(context.Background(), "*")
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrClientClosed)
+	})
+}
@@ -0,0 +1,39 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestClient_Lock(t *testing.T) {
+	t.Run("returns the connection error and records the failed command", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+
+		// Act
+		lock, err := sut.Lock(context.Background(), "lock:1", time.Second)
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, lock)
+		assert.Equal(t, uint64(1), sut.GetMetrics().CommandsFailed)
+	})
+
+	t.Run("returns ErrClientClosed when the client is closed", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+		require.NoError(t, sut.Close())
+
+		// Act
+		_, err := sut.Lock(context.Background(), "lock:1", time.Second)
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrClientClosed)
+	})
+}
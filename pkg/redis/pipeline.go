@@ -0,0 +1,233 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Pipeliner queues commands that Client.Pipeline sends in a single round trip.
+// Keys are namespaced like the other Client helpers, and each command returns its
+// go-redis Cmd, whose result is available once the pipeline has been executed.
+type Pipeliner interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, ttl time.Duration) *redis.StatusCmd
+	SetNX(ctx context.Context, key string, value any, ttl time.Duration) *redis.BoolCmd
+	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+	Exists(ctx context.Context, keys ...string) *redis.IntCmd
+	Expire(ctx context.Context, key string, ttl time.Duration) *redis.BoolCmd
+	Incr(ctx context.Context, key string) *redis.IntCmd
+	IncrBy(ctx context.Context, key string, value int64) *redis.IntCmd
+	HGet(ctx context.Context, key, field string) *redis.StringCmd
+	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
+	HSet(ctx context.Context, key string, values ...any) *redis.IntCmd
+	HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd
+	// Len returns the number of queued commands
+	Len() int
+}
+
+type pipeliner struct {
+	pipe   redis.Pipeliner
+	client *Client
+}
+
+func (p *pipeliner) Get(ctx context.Context, key string) *redis.StringCmd {
+	return p.pipe.Get(ctx, p.client.WithNamespace(key))
+}
+
+func (p *pipeliner) Set(ctx context.Context, key string, value any, ttl time.Duration) *redis.StatusCmd {
+	return p.pipe.Set(ctx, p.client.WithNamespace(key), value, ttl)
+}
+
+func (p *pipeliner) SetNX(ctx context.Context, key string, value any, ttl time.Duration) *redis.BoolCmd {
+	return p.pipe.SetNX(ctx, p.client.WithNamespace(key), value, ttl)
+}
+
+func (p *pipeliner) MGet(ctx context.Context, keys ...string) *redis.SliceCmd {
+	return p.pipe.MGet(ctx, p.client.withNamespaces(keys)...)
+}
+
+func (p *pipeliner) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return p.pipe.Del(ctx, p.client.withNamespaces(keys)...)
+}
+
+func (p *pipeliner) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
+	return p.pipe.Exists(ctx, p.client.withNamespaces(keys)...)
+}
+
+func (p *pipeliner) Expire(ctx context.Context, key string, ttl time.Duration) *redis.BoolCmd {
+	return p.pipe.Expire(ctx, p.client.WithNamespace(key), ttl)
+}
+
+func (p *pipeliner) Incr(ctx context.Context, key string) *redis.IntCmd {
+	return p.pipe.Incr(ctx, p.client.WithNamespace(key))
+}
+
+func (p *pipeliner) IncrBy(ctx context.Context, key string, value int64) *redis.IntCmd {
+	return p.pipe.IncrBy(ctx, p.client.WithNamespace(key), value)
+}
+
+func (p *pipeliner) HGet(ctx context.Context, key, field string) *redis.StringCmd {
+	return p.pipe.HGet(ctx, p.client.WithNamespace(key), field)
+}
+
+func (p *pipeliner) HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd {
+	return p.pipe.HGetAll(ctx, p.client.WithNamespace(key))
+}
+
+func (p *pipeliner) HSet(ctx context.Context, key string, values ...any) *redis.IntCmd {
+	return p.pipe.HSet(ctx, p.client.WithNamespace(key), values...)
+}
+
+func (p *pipeliner) HDel(ctx context.Context, key string, fields ...string) *redis.IntCmd {
+	return p.pipe.HDel(ctx, p.client.WithNamespace(key), fields...)
+}
+
+func (p *pipeliner) Len() int {
+	return p.pipe.Len()
+}
+
+// Pipeline queues the commands of fn and sends them in a single round trip.
+// Nothing is sent when fn returns an error. The returned error is the first command
+// error other than redis.Nil; missing keys are reported by the Cmd of each command.
+//
+//	var views *redis.IntCmd
+//	err := client.Pipeline(ctx, func(p redis.Pipeliner) error {
+//	    views = p.Incr(ctx, "views:42")
+//	    p.Expire(ctx, "views:42", time.Hour)
+//	    return nil
+//	})
+func (c *Client) Pipeline(ctx context.Context, fn func(p Pipeliner) error) error {
+	if c.isClosed {
+		return ErrClientClosed
+	}
+	return c.execPipeline(ctx, c.client.Pipeline(), fn)
+}
+
+// TxPipeline is Pipeline wrapped in MULTI/EXEC, so the commands run atomically.
+func (c *Client) TxPipeline(ctx context.Context, fn func(p Pipeliner) error) error {
+	if c.isClosed {
+		return ErrClientClosed
+	}
+	return c.execPipeline(ctx, c.client.TxPipeline(), fn)
+}
+
+func (c *Client) execPipeline(ctx context.Context, pipe redis.Pipeliner, fn func(p Pipeliner) error) error {
+	if err := fn(&pipeliner{pipe: pipe, client: c}); err != nil {
+		pipe.Discard()
+		return err
+	}
+	if pipe.Len() == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	cmds, execErr := pipe.Exec(ctx)
+	elapsed := time.Since(start)
+
+	// A connection failure fails the whole pipeline without setting the command errors
+	failed := commandErr(execErr) != nil && !hasCommandErr(cmds)
+
+	var firstErr error
+	for _, cmd := range cmds {
+		err := commandErr(cmd.Err())
+		if failed {
+			err = execErr
+		}
+		c.recordCommand(elapsed/time.Duration(len(cmds)), err)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// MGetJSON gets the JSON values of keys in a single MGET, keyed by the given keys.
+// Missing keys are left out of the result. A value that cannot be decoded into T
+// returns an ErrDecodeValue error.
+func MGetJSON[T any](ctx context.Context, c *Client, keys ...string) (map[string]T, error) {
+	if c.isClosed {
+		return nil, ErrClientClosed
+	}
+	result := make(map[string]T, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	start := time.Now()
+	values, err := c.client.MGet(ctx, c.withNamespaces(keys)...).Result()
+	c.recordCommand(time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var decoded T
+		if errDecode := json.Unmarshal([]byte(raw), &decoded); errDecode != nil {
+			return nil, fmt.Errorf("%w: key %s: %w", ErrDecodeValue, keys[i], errDecode)
+		}
+		result[keys[i]] = decoded
+	}
+	return result, nil
+}
+
+// MSetJSON sets the JSON encoding of values in a single pipeline, each key expiring
+// after ttl. A zero ttl keeps the keys without expiration. A value that cannot be
+// encoded returns an ErrEncodeValue error and nothing is set.
+func MSetJSON[T any](ctx context.Context, c *Client, values map[string]T, ttl time.Duration) error {
+	encoded := make(map[string][]byte, len(values))
+	for key, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("%w: key %s: %w", ErrEncodeValue, key, err)
+		}
+		encoded[key] = raw
+	}
+
+	return c.Pipeline(ctx, func(p Pipeliner) error {
+		for key, raw := range encoded {
+			p.Set(ctx, key, raw, ttl)
+		}
+		return nil
+	})
+}
+
+func (c *Client) withNamespaces(keys []string) []string {
+	namespaced := make([]string, len(keys))
+	for i, key := range keys {
+		namespaced[i] = c.WithNamespace(key)
+	}
+	return namespaced
+}
+
+func (c *Client) recordCommand(duration time.Duration, err error) {
+	if c.metrics != nil {
+		c.metrics.recordCommand(duration, commandErr(err))
+	}
+}
+
+func hasCommandErr(cmds []redis.Cmder) bool {
+	for _, cmd := range cmds {
+		if cmd.Err() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// commandErr drops redis.Nil, which reports a missing key rather than a failure.
+func commandErr(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+var errAbort = errors.New("abort")
+
+// newUnreachableClient returns a client whose commands fail to connect.
+func newUnreachableClient(t *testing.T) *redis.Client {
+	t.Helper()
+
+	universal := goredis.NewClient(&goredis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: time.Second})
+	c := redis.NewTestClient(universal, redis.Config{Namespace: "orders", EnableMetrics: true})
+	t.Cleanup(func() { _ = universal.Close() })
+	return c
+}
+
+func TestClient_Pipeline(t *testing.T) {
+	t.Run("namespaces the keys of the queued commands", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+		var get *goredis.StringCmd
+		var del *goredis.IntCmd
+
+		// Act
+		err := sut.Pipeline(context.Background(), func(p redis.Pipeliner) error {
+			get = p.Get(context.Background(), "order:1")
+			del = p.Del(context.Background(), "order:2", "order:3")
+			return errAbort
+		})
+
+		// Assert
+		require.ErrorIs(t, err, errAbort)
+		assert.Equal(t, []any{"get", "orders:order:1"}, get.Args())
+		assert.Equal(t, []any{"del", "orders:order:2", "orders:order:3"}, del.Args())
+	})
+
+	t.Run("sends nothing when the function fails", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+
+		// Act
+		err := sut.Pipeline(context.Background(), func(p redis.Pipeliner) error {
+			p.Incr(context.Background(), "views")
+			return errAbort
+		})
+
+		// Assert
+		require.ErrorIs(t, err, errAbort)
+		assert.Zero(t, sut.GetMetrics().CommandsExecuted)
+	})
+
+	t.Run("records every command of a failed pipeline", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+
+		// Act
+		err := sut.Pipeline(context.Background(), func(p redis.Pipeliner) error {
+			p.Incr(context.Background(), "views")
+			p.Expire(context.Background(), "views", time.Hour)
+			return nil
+		})
+
+		// Assert
+		require.Error(t, err)
+		metrics := sut.GetMetrics()
+		assert.Equal(t, uint64(2), metrics.CommandsExecuted)
+		assert.Equal(t, uint64(2), metrics.CommandsFailed)
+	})
+
+	t.Run("returns ErrClientClosed when the client is closed", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+		require.NoError(t, sut.Close())
+
+		// Act
+		err := sut.TxPipeline(context.Background(), func(redis.Pipeliner) error { return nil })
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrClientClosed)
+	})
+}
+
+func TestMGetJSON(t *testing.T) {
+	t.Run("returns an empty map without keys", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+
+		// Act
+		values, err := redis.MGetJSON[int](context.Background(), sut)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+}
+
+func TestMSetJSON(t *testing.T) {
+	t.Run("returns ErrEncodeValue when a value cannot be encoded", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+
+		// Act
+		err := redis.MSetJSON(context.Background(), sut, map[string]func(){"handler": func() {}}, 0)
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrEncodeValue)
+		assert.Zero(t, sut.GetMetrics().CommandsExecuted)
+	})
+}
@@ -0,0 +1,182 @@
+package redis
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TopKItem is an item of a top-k list with its estimated count.
+type TopKItem struct {
+	Item  string
+	Count int64
+}
+
+// HasCommand reports whether the server knows command, e.g. "BF.ADD" to detect RedisBloom
+// (built into Redis 8 and Redis Stack) before relying on a module.
+//
+//	bloom, err := client.HasCommand(ctx, "BF.ADD")
+func (c *Client) HasCommand(ctx context.Context, command string) (bool, error) {
+	info, err := runCommand(c, func() ([]any, error) {
+		return c.client.Do(ctx, "COMMAND", "INFO", command).Slice()
+	})
+	if err != nil {
+		return false, err
+	}
+	// An unknown command has a nil entry
+	return len(info) == 1 && info[0] != nil, nil
+}
+
+// BloomReserve creates the Bloom filter key for capacity items with the given false
+// positive rate, e.g. 0.001. An existing filter is kept, so it can run at every startup.
+// Without it, BloomAdd creates a filter with the server defaults (100 items, 0.01).
+func (c *Client) BloomReserve(ctx context.Context, key string, errorRate float64, capacity int64) error {
+	_, err := runCommand(c, func() (string, error) {
+		return c.client.BFReserve(ctx, c.WithNamespace(key), errorRate, capacity).Result()
+	})
+	if err != nil && strings.Contains(err.Error(), "item exists") {
+		return nil
+	}
+	return err
+}
+
+// BloomAdd adds item to the Bloom filter key and returns false when it may already have
+// been added, e.g. to skip a duplicate event.
+//
+//	added, err := client.BloomAdd(ctx, "events:seen", event.ID)
+//	if err == nil && !added {
+//	    return nil // most likely a duplicate
+//	}
+func (c *Client) BloomAdd(ctx context.Context, key, item string) (bool, error) {
+	return runCommand(c, func() (bool, error) {
+		return c.client.BFAdd(ctx, c.WithNamespace(key), item).Result()
+	})
+}
+
+// BloomAddMulti adds items to the Bloom filter key in a single command and returns, for
+// each item, whether it was added.
+func (c *Client) BloomAddMulti(ctx context.Context, key string, items ...string) ([]bool, error) {
+	return runCommand(c, func() ([]bool, error) {
+		return c.client.BFMAdd(ctx, c.WithNamespace(key), toArgs(items)...).Result()
+	})
+}
+
+// BloomExists reports whether item may have been added to the Bloom filter key. False is
+// certain; true is wrong at the false positive rate of the filter.
+func (c *Client) BloomExists(ctx context.Context, key, item string) (bool, error) {
+	return runCommand(c, func() (bool, error) {
+		return c.client.BFExists(ctx, c.WithNamespace(key), item).Result()
+	})
+}
+
+// BloomExistsMulti reports, for each item, whether it may have been added to the Bloom
+// filter key, in a single command.
+func (c *Client) BloomExistsMulti(ctx context.Context, key string, items ...string) ([]bool, error) {
+	return runCommand(c, func() ([]bool, error) {
+		return c.client.BFMExists(ctx, c.WithNamespace(key), toArgs(items)...).Result()
+	})
+}
+
+// HLLAdd adds items to the HyperLogLog key and returns whether its estimated
+// cardinality changed. HyperLogLogs are built into Redis, no module is needed.
+//
+//	_, err := client.HLLAdd(ctx, "visitors:2026-10-15", userID)
+func (c *Client) HLLAdd(ctx context.Context, key string, items ...string) (bool, error) {
+	changed, err := runCommand(c, func() (int64, error) {
+		return c.client.PFAdd(ctx, c.WithNamespace(key), toArgs(items)...).Result()
+	})
+	return changed == 1, err
+}
+
+// HLLCount returns the estimated number of distinct items added to the HyperLogLogs keys,
+// counted once across the keys, with a standard error of 0.81%.
+func (c *Client) HLLCount(ctx context.Context, keys ...string) (int64, error) {
+	return runCommand(c, func() (int64, error) {
+		return c.client.PFCount(ctx, c.withNamespaces(keys)...).Result()
+	})
+}
+
+// HLLMerge merges the HyperLogLogs keys into dest, e.g. the days into a week.
+func (c *Client) HLLMerge(ctx context.Context, dest string, keys ...string) error {
+	_, err := runCommand(c, func() (string, error) {
+		return c.client.PFMerge(ctx, c.WithNamespace(dest), c.withNamespaces(keys)...).Result()
+	})
+	return err
+}
+
+// TopKReserve creates the top-k filter key keeping the k most frequent items. An
+// existing filter is kept, so it can run at every startup.
+func (c *Client) TopKReserve(ctx context.Context, key string, k int64) error {
+	_, err := runCommand(c, func() (string, error) {
+		return c.client.TopKReserve(ctx, c.WithNamespace(key), k).Result()
+	})
+	if err != nil && strings.Contains(err.Error(), "key already exists") {
+		return nil
+	}
+	return err
+}
+
+// TopKAdd counts an occurrence of each item in the top-k filter key and returns the items
+// the added ones pushed out of the top k.
+func (c *Client) TopKAdd(ctx context.Context, key string, items ...string) ([]string, error) {
+	dropped, err := runCommand(c, func() ([]string, error) {
+		return c.client.TopKAdd(ctx, c.WithNamespace(key), toArgs(items)...).Result()
+	})
+	return slices.DeleteFunc(dropped, func(item string) bool { return item == "" }), err
+}
+
+// TopKIncrBy counts increment occurrences of item in the top-k filter key.
+func (c *Client) TopKIncrBy(ctx context.Context, key, item string, increment int64) error {
+	_, err := runCommand(c, func() ([]string, error) {
+		return c.client.TopKIncrBy(ctx, c.WithNamespace(key), item, increment).Result()
+	})
+	return err
+}
+
+// TopKList returns the top k items of the top-k filter key, most frequent first.
+func (c *Client) TopKList(ctx context.Context, key string) ([]TopKItem, error) {
+	counts, err := runCommand(c, func() (map[string]int64, error) {
+		return c.client.TopKListWithCount(ctx, c.WithNamespace(key)).Result()
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]TopKItem, 0, len(counts))
+	for item, count := range counts {
+		items = append(items, TopKItem{Item: item, Count: count})
+	}
+	slices.SortFunc(items, func(a, b TopKItem) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Item, b.Item))
+	})
+	return items, nil
+}
+
+// runCommand runs a single command, recording it in the metrics. An unknown command, i.e.
+// a module missing on the server, returns an ErrModuleUnavailable error.
+func runCommand[T any](c *Client, fn func() (T, error)) (T, error) {
+	var zero T
+	if c.isClosed {
+		return zero, ErrClientClosed
+	}
+	start := time.Now()
+	result, err := fn()
+	c.recordCommand(time.Since(start), err)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "unknown command") {
+			return zero, fmt.Errorf("%w: %w", ErrModuleUnavailable, err)
+		}
+		return zero, err
+	}
+	return result, nil
+}
+
+func toArgs(items []string) []any {
+	args := make([]any, len(items))
+	for i, item := range items {
+		args[i] = item
+	}
+	return args
+}
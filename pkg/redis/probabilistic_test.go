@@ -0,0 +1,53 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestClient_BloomAdd(t *testing.T) {
+	t.Run("returns the connection error and records the failed command", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+
+		// Act
+		added, err := sut.BloomAdd(context.Background(), "events:seen", "evt-1")
+
+		// Assert
+		require.Error(t, err)
+		require.NotErrorIs(t, err, redis.ErrModuleUnavailable)
+		assert.False(t, added)
+		assert.Equal(t, uint64(1), sut.GetMetrics().CommandsFailed)
+	})
+
+	t.Run("returns ErrClientClosed when the client is closed", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+		require.NoError(t, sut.Close())
+
+		// Act
+		_, err := sut.BloomAdd(context.Background(), "events:seen", "evt-1")
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrClientClosed)
+	})
+}
+
+func TestClient_HLLCount(t *testing.T) {
+	t.Run("returns ErrClientClosed when the client is closed", func(t *testing.T) {
+		// Arrange
+		sut := newUnreachableClient(t)
+		require.NoError(t, sut.Close())
+
+		// Act
+		_, err := sut.HLLCount(context.Background(), "visitors")
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrClientClosed)
+	})
+}
@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	// Maps redis.Nil to errs.ErrRecordNotFound
+	_ "github.com/cristiano-pacheco/bricks/pkg/errs/errsredis"
 )
 
 const backoffBase = 2
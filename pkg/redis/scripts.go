@@ -0,0 +1,201 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ScriptResult lists the Go types a Lua script reply can be converted to by RunScript.
+type ScriptResult interface {
+	int64 | string | bool | float64 | []any | []string | []int64
+}
+
+// Scripts is a registry of named Lua scripts. Scripts run with EVALSHA, falling back to
+// EVAL when the server answers NOSCRIPT, e.g. after a restart or a failover, so callers
+// never handle SHAs. Keys are namespaced like the other Client helpers.
+//
+//	scripts := redis.NewScripts(client)
+//	err := scripts.Register("release_lock", `
+//	    if redis.call("GET", KEYS[1]) == ARGV[1] then
+//	        return redis.call("DEL", KEYS[1])
+//	    end
+//	    return 0`)
+//	released, err := redis.RunScript[int64](ctx, scripts, "release_lock", []string{"lock:order:1"}, token)
+type Scripts struct {
+	client  *Client
+	mu      sync.RWMutex
+	scripts map[string]*redis.Script
+}
+
+// NewScripts creates an empty registry of the scripts run by client.
+func NewScripts(client *Client) *Scripts {
+	return &Scripts{client: client, scripts: make(map[string]*redis.Script)}
+}
+
+// Register adds the script src under name. Registering a name twice returns ErrDuplicateScript.
+func (s *Scripts) Register(name, src string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.scripts[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateScript, name)
+	}
+	s.scripts[name] = redis.NewScript(src)
+	return nil
+}
+
+// RegisterFS adds the scripts of fsys matching pattern, e.g. "scripts/*.lua" of an
+// embed.FS. Each script is named after its file name without the extension.
+func (s *Scripts) RegisterFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return fmt.Errorf("failed to list scripts %s: %w", pattern, err)
+	}
+	for _, file := range files {
+		src, errRead := fs.ReadFile(fsys, file)
+		if errRead != nil {
+			return fmt.Errorf("failed to read script %s: %w", file, errRead)
+		}
+		name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		if errRegister := s.Register(name, string(src)); errRegister != nil {
+			return errRegister
+		}
+	}
+	return nil
+}
+
+// Names returns the names of the registered scripts, sorted.
+func (s *Scripts) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.scripts))
+	for name := range s.scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SHA returns the SHA1 digest of the script, as used by EVALSHA.
+func (s *Scripts) SHA(name string) (string, error) {
+	script, err := s.script(name)
+	if err != nil {
+		return "", err
+	}
+	return script.Hash(), nil
+}
+
+// Load loads every registered script into the script cache of the server with
+// SCRIPT LOAD, e.g. on application start, so the first runs do not fall back to EVAL.
+func (s *Scripts) Load(ctx context.Context) error {
+	if s.client.isClosed {
+		return ErrClientClosed
+	}
+	for _, name := range s.Names() {
+		script, err := s.script(name)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+		err = script.Load(ctx, s.client.client).Err()
+		s.client.recordCommand(time.Since(start), err)
+		if err != nil {
+			return fmt.Errorf("failed to load script %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Run runs the script with EVALSHA, falling back to EVAL when it is not cached on the server.
+func (s *Scripts) Run(ctx context.Context, name string, keys []string, args ...any) *redis.Cmd {
+	return s.run(ctx, name, false, keys, args)
+}
+
+// RunRO runs the script with EVALSHA_RO, so it can be served by read-only replicas.
+func (s *Scripts) RunRO(ctx context.Context, name string, keys []string, args ...any) *redis.Cmd {
+	return s.run(ctx, name, true, keys, args)
+}
+
+func (s *Scripts) run(ctx context.Context, name string, readOnly bool, keys []string, args []any) *redis.Cmd {
+	if s.client.isClosed {
+		return failedCmd(ctx, ErrClientClosed)
+	}
+	script, err := s.script(name)
+	if err != nil {
+		return failedCmd(ctx, err)
+	}
+
+	start := time.Now()
+	var cmd *redis.Cmd
+	if readOnly {
+		cmd = script.RunRO(ctx, s.client.client, s.client.withNamespaces(keys), args...)
+	} else {
+		cmd = script.Run(ctx, s.client.client, s.client.withNamespaces(keys), args...)
+	}
+	s.client.recordCommand(time.Since(start), cmd.Err())
+	return cmd
+}
+
+func (s *Scripts) script(name string) (*redis.Script, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	script, ok := s.scripts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrScriptNotFound, name)
+	}
+	return script, nil
+}
+
+// RunScript runs the script and converts its reply to T. A nil reply returns redis.Nil,
+// and a reply of another type returns an ErrUnexpectedScriptResult error.
+func RunScript[T ScriptResult](
+	ctx context.Context,
+	scripts *Scripts,
+	name string,
+	keys []string,
+	args ...any,
+) (T, error) {
+	var result T
+	cmd := scripts.Run(ctx, name, keys, args...)
+	if err := cmd.Err(); err != nil {
+		return result, err
+	}
+
+	var err error
+	switch typed := any(&result).(type) {
+	case *int64:
+		*typed, err = cmd.Int64()
+	case *string:
+		*typed, err = cmd.Text()
+	case *bool:
+		*typed, err = cmd.Bool()
+	case *float64:
+		*typed, err = cmd.Float64()
+	case *[]any:
+		*typed, err = cmd.Slice()
+	case *[]string:
+		*typed, err = cmd.StringSlice()
+	case *[]int64:
+		*typed, err = cmd.Int64Slice()
+	}
+	if err != nil {
+		return result, fmt.Errorf("%w: script %s: %w", ErrUnexpectedScriptResult, name, err)
+	}
+	return result, nil
+}
+
+func failedCmd(ctx context.Context, err error) *redis.Cmd {
+	cmd := redis.NewCmd(ctx)
+	cmd.SetErr(err)
+	return cmd
+}
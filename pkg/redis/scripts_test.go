@@ -0,0 +1,105 @@
+package redis_test
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // EVALSHA digests are SHA1
+	"encoding/hex"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+const incrScript = `return redis.call("INCR", KEYS[1])`
+
+func TestScripts_Register(t *testing.T) {
+	t.Run("registers the script under its name", func(t *testing.T) {
+		// Arrange
+		sut := redis.NewScripts(newUnreachableClient(t))
+		digest := sha1.Sum([]byte(incrScript)) //nolint:gosec // EVALSHA digests are SHA1
+
+		// Act
+		err := sut.Register("incr", incrScript)
+
+		// Assert
+		require.NoError(t, err)
+		sha, err := sut.SHA("incr")
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(digest[:]), sha)
+	})
+
+	t.Run("returns ErrDuplicateScript when the name is taken", func(t *testing.T) {
+		// Arrange
+		sut := redis.NewScripts(newUnreachableClient(t))
+		require.NoError(t, sut.Register("incr", incrScript))
+
+		// Act
+		err := sut.Register("incr", incrScript)
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrDuplicateScript)
+	})
+}
+
+func TestScripts_RegisterFS(t *testing.T) {
+	t.Run("registers the matching files named after their file name", func(t *testing.T) {
+		// Arrange
+		sut := redis.NewScripts(newUnreachableClient(t))
+		fsys := fstest.MapFS{
+			"scripts/rate_limit.lua":   {Data: []byte(incrScript)},
+			"scripts/release_lock.lua": {Data: []byte(`return redis.call("DEL", KEYS[1])`)},
+			"scripts/README.md":        {Data: []byte("# scripts")},
+		}
+
+		// Act
+		err := sut.RegisterFS(fsys, "scripts/*.lua")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"rate_limit", "release_lock"}, sut.Names())
+	})
+}
+
+func TestScripts_Run(t *testing.T) {
+	t.Run("returns ErrScriptNotFound when the script is not registered", func(t *testing.T) {
+		// Arrange
+		sut := redis.NewScripts(newUnreachableClient(t))
+
+		// Act
+		err := sut.Run(context.Background(), "missing", []string{"views"}).Err()
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrScriptNotFound)
+	})
+
+	t.Run("returns ErrClientClosed when the client is closed", func(t *testing.T) {
+		// Arrange
+		client := newUnreachableClient(t)
+		sut := redis.NewScripts(client)
+		require.NoError(t, sut.Register("incr", incrScript))
+		require.NoError(t, client.Close())
+
+		// Act
+		_, err := redis.RunScript[int64](context.Background(), sut, "incr", []string{"views"})
+
+		// Assert
+		require.ErrorIs(t, err, redis.ErrClientClosed)
+	})
+
+	t.Run("records the failed run in the metrics", func(t *testing.T) {
+		// Arrange
+		client := newUnreachableClient(t)
+		sut := redis.NewScripts(client)
+		require.NoError(t, sut.Register("incr", incrScript))
+
+		// Act
+		err := sut.Run(context.Background(), "incr", []string{"views"}).Err()
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, uint64(1), client.GetMetrics().CommandsFailed)
+	})
+}
@@ -0,0 +1,46 @@
+package saga
+
+import "time"
+
+const (
+	defaultTable                   = "saga_instances"
+	defaultResumeInterval          = 30 * time.Second
+	defaultStaleAfter              = time.Minute
+	defaultMaxCompensationAttempts = 5
+	defaultResumeBatchSize         = 100
+)
+
+// Config configures the Coordinator and its store.
+type Config struct {
+	// Table is the table of the saga instances, default: saga_instances
+	Table string `config:"table"`
+	// ResumeInterval is the period of the search for the interrupted sagas, default: 30s
+	ResumeInterval time.Duration `config:"resume_interval"`
+	// StaleAfter is the time without progress after which a saga is resumed by any instance,
+	// longer than the longest step, default: 1m
+	StaleAfter time.Duration `config:"stale_after"`
+	// MaxCompensationAttempts is the number of runs of a failing compensation before the saga
+	// is failed for a manual intervention, default: 5
+	MaxCompensationAttempts int `config:"max_compensation_attempts"`
+	// ResumeBatchSize is the number of sagas resumed per search, default: 100
+	ResumeBatchSize int `config:"resume_batch_size"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Table == "" {
+		c.Table = defaultTable
+	}
+	if c.ResumeInterval <= 0 {
+		c.ResumeInterval = defaultResumeInterval
+	}
+	if c.StaleAfter <= 0 {
+		c.StaleAfter = defaultStaleAfter
+	}
+	if c.MaxCompensationAttempts <= 0 {
+		c.MaxCompensationAttempts = defaultMaxCompensationAttempts
+	}
+	if c.ResumeBatchSize <= 0 {
+		c.ResumeBatchSize = defaultResumeBatchSize
+	}
+}
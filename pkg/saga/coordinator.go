@@ -0,0 +1,181 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// runner runs a persisted execution of a registered saga.
+type runner interface {
+	run(ctx context.Context, instance *Instance) error
+}
+
+// Coordinator persists the progress of the sagas registered with it, and resumes the
+// executions interrupted by a crash or a deployment. Every instance of the application runs
+// a Coordinator: an execution without progress for stale_after is resumed by the first one
+// that claims it.
+type Coordinator struct {
+	cfg     Config
+	store   Store
+	log     logger.Logger
+	metrics *sagaMetrics
+	clock   clock.Clock
+	ids     ident.Generator
+
+	mu      sync.Mutex
+	sagas   map[string]runner
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// New creates a Coordinator persisting the progress in store.
+//
+//	coordinator, err := saga.New(cfg, saga.NewGormStore(db, "saga_instances"), log)
+//	checkout, err := saga.Register(coordinator, checkoutDefinition)
+//	err = coordinator.Start()
+//	defer coordinator.Stop(ctx)
+func New(cfg Config, store Store, log logger.Logger, opts ...Option) (*Coordinator, error) {
+	cfg.SetDefaults()
+	coordinatorOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&coordinatorOptions)
+	}
+	metrics, err := newSagaMetrics(coordinatorOptions.registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &Coordinator{
+		cfg:     cfg,
+		store:   store,
+		log:     log.Named("saga"),
+		metrics: metrics,
+		clock:   coordinatorOptions.clock,
+		ids:     coordinatorOptions.ids,
+		sagas:   make(map[string]runner),
+	}, nil
+}
+
+func (c *Coordinator) register(name string, saga runner) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, found := c.sagas[name]; found {
+		return fmt.Errorf("%w: %s", ErrDuplicateSaga, name)
+	}
+	c.sagas[name] = saga
+	return nil
+}
+
+// Start resumes the interrupted sagas every resume_interval, in the background.
+func (c *Coordinator) Start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.started {
+		return ErrCoordinatorStarted
+	}
+	c.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go c.loop(ctx)
+	return nil
+}
+
+// Stop stops resuming the sagas, and waits for the resumed one to stop or ctx to be done.
+// A saga stopped in the middle is resumed later, by any instance.
+func (c *Coordinator) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.started {
+		c.mu.Unlock()
+		return nil
+	}
+	c.started = false
+	cancel, done := c.cancel, c.done
+	c.mu.Unlock()
+
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Coordinator) loop(ctx context.Context) {
+	defer close(c.done)
+	ticker := c.clock.NewTicker(c.cfg.ResumeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Resume(ctx); err != nil && ctx.Err() == nil {
+			c.log.Warn("failed to resume the sagas", logger.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// Resume runs the unfinished sagas without progress for stale_after to their end, and
+// returns how many it claimed. A saga claimed concurrently by another instance is skipped.
+func (c *Coordinator) Resume(ctx context.Context) (int, error) {
+	now := c.clock.Now().UTC()
+	instances, err := c.store.ListStale(ctx, now.Add(-c.cfg.StaleAfter), c.cfg.ResumeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	resumed := 0
+	for i := range instances {
+		if ctx.Err() != nil {
+			return resumed, ctx.Err()
+		}
+		instance := instances[i]
+		c.mu.Lock()
+		saga, found := c.sagas[instance.Name]
+		c.mu.Unlock()
+		if !found {
+			c.log.Warn("saga to resume is not registered",
+				logger.String("saga", instance.Name), logger.Stringer("saga_id", instance.ID))
+			continue
+		}
+
+		// The claim is an update of the version: it fails when another instance claimed it
+		instance.UpdatedAt = now
+		if err = c.store.Update(ctx, &instance); errors.Is(err, ErrConflict) {
+			continue
+		} else if err != nil {
+			return resumed, err
+		}
+		resumed++
+		c.metrics.resumed.WithLabelValues(instance.Name).Inc()
+
+		if err = saga.run(ctx, &instance); err != nil && !errors.Is(err, ErrCompensated) {
+			c.log.Warn("resumed saga interrupted",
+				logger.String("saga", instance.Name), logger.Stringer("saga_id", instance.ID), logger.Error(err))
+		}
+	}
+	return resumed, nil
+}
+
+// save persists the progress of instance and data.
+func (c *Coordinator) save(ctx context.Context, instance *Instance, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("saga: encode data: %w", err)
+	}
+	instance.Data = payload
+	instance.UpdatedAt = c.clock.Now().UTC()
+	return c.store.Update(ctx, instance)
+}
@@ -0,0 +1,21 @@
+package saga
+
+import "errors"
+
+var (
+	ErrMissingSagaName    = errors.New("saga name is required")
+	ErrMissingSteps       = errors.New("saga requires at least one step")
+	ErrMissingStepAction  = errors.New("saga step requires a name and an action")
+	ErrDuplicateSaga      = errors.New("saga already registered")
+	ErrCoordinatorStarted = errors.New("saga coordinator already started")
+	ErrMissingDatabase    = errors.New("the saga coordinator requires a *gorm.DB")
+
+	// ErrCompensated is returned by Execute when a step failed and the completed steps
+	// were compensated.
+	ErrCompensated = errors.New("saga compensated")
+	// ErrCompensationFailed is returned when a compensation failed: the saga is resumed to
+	// retry it, then failed after max_compensation_attempts.
+	ErrCompensationFailed = errors.New("saga compensation failed")
+	// ErrConflict is returned when another instance made progress on the saga meanwhile.
+	ErrConflict = errors.New("saga updated concurrently")
+)
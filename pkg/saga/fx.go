@@ -0,0 +1,77 @@
+package saga
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Module provides the Coordinator persisting the sagas in the database, and resuming the
+// interrupted ones while the application runs. It loads the config from "app.saga" and
+// requires database.Module; the saga_instances migration joins the "migration_filesystems"
+// group. The sagas are registered by the constructors taking the Coordinator.
+//
+//	fx.New(
+//	    database.Module,
+//	    saga.Module,
+//	    fx.Provide(checkout.NewCheckoutSaga), // calls saga.Register
+//	)
+var Module = fx.Module(
+	"saga",
+	config.Provide[Config]("app.saga"),
+	fx.Provide(
+		NewWithLifecycle,
+		fx.Annotate(Migrations, fx.ResultTags(`group:"migration_filesystems"`)),
+	),
+)
+
+// NewWithLifecycleParams for dependency injection
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle  fx.Lifecycle
+	Config     config.Config[Config]
+	Logger     logger.Logger
+	DB         *gorm.DB              `optional:"true"`
+	Store      Store                 `optional:"true"`
+	Registerer prometheus.Registerer `optional:"true"`
+	Clock      clock.Clock           `optional:"true"`
+	IDs        ident.Generator       `optional:"true"`
+}
+
+// NewWithLifecycle creates the Coordinator, resuming the sagas from start to stop. A Store
+// provided to the graph replaces the GormStore.
+func NewWithLifecycle(p NewWithLifecycleParams) (*Coordinator, error) {
+	cfg := p.Config.Get()
+	cfg.SetDefaults()
+
+	store := p.Store
+	if store == nil {
+		if p.DB == nil {
+			return nil, ErrMissingDatabase
+		}
+		store = NewGormStore(p.DB, cfg.Table)
+	}
+	coordinator, err := New(cfg, store, p.Logger,
+		WithRegisterer(p.Registerer),
+		WithClock(p.Clock),
+		WithIDGenerator(p.IDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			return coordinator.Start()
+		},
+		OnStop: coordinator.Stop,
+	})
+	return coordinator, nil
+}
@@ -0,0 +1,132 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Record is the GORM model of an Instance in the saga_instances table (see Migrations).
+type Record struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Name      string          `gorm:"not null"`
+	Status    string          `gorm:"not null"`
+	Step      int             `gorm:"not null"`
+	Data      json.RawMessage `gorm:"type:jsonb"`
+	Error     string          `gorm:"not null"`
+	Attempts  int             `gorm:"not null"`
+	Version   int             `gorm:"not null"`
+	CreatedAt time.Time       `gorm:"not null"`
+	UpdatedAt time.Time       `gorm:"not null"`
+}
+
+// GormStore is the Store keeping the saga instances in the database. The progress does not
+// join the transaction of the context: it is committed as the steps run, and survives the
+// rollback of the caller.
+type GormStore struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormStore creates a GormStore keeping the saga instances in table.
+func NewGormStore(db *gorm.DB, table string) *GormStore {
+	return &GormStore{db: db, table: table}
+}
+
+// Create implements Store.
+func (s *GormStore) Create(ctx context.Context, instance Instance) error {
+	record := newRecord(instance)
+	if err := s.db.WithContext(ctx).Table(s.table).Create(&record).Error; err != nil {
+		return fmt.Errorf("saga: create instance: %w", err)
+	}
+	return nil
+}
+
+// Update implements Store, with an update conditional on the version.
+func (s *GormStore) Update(ctx context.Context, instance *Instance) error {
+	result := s.db.WithContext(ctx).Table(s.table).
+		Where("id = ? AND version = ?", instance.ID, instance.Version).
+		Updates(map[string]any{
+			"status":     string(instance.Status),
+			"step":       instance.Step,
+			"data":       instance.Data,
+			"error":      instance.Error,
+			"attempts":   instance.Attempts,
+			"version":    instance.Version + 1,
+			"updated_at": instance.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("saga: update instance: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", ErrConflict, instance.ID)
+	}
+	instance.Version++
+	return nil
+}
+
+// Get implements Store.
+func (s *GormStore) Get(ctx context.Context, id uuid.UUID) (Instance, bool, error) {
+	var record Record
+	err := s.db.WithContext(ctx).Table(s.table).Where("id = ?", id).Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Instance{}, false, nil
+	}
+	if err != nil {
+		return Instance{}, false, fmt.Errorf("saga: get instance: %w", err)
+	}
+	return record.instance(), true, nil
+}
+
+// ListStale implements Store.
+func (s *GormStore) ListStale(ctx context.Context, before time.Time, limit int) ([]Instance, error) {
+	var records []Record
+	err := s.db.WithContext(ctx).Table(s.table).
+		Where("status IN ? AND updated_at < ?", []string{string(StatusRunning), string(StatusCompensating)}, before).
+		Order("updated_at").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("saga: list stale instances: %w", err)
+	}
+	instances := make([]Instance, 0, len(records))
+	for _, record := range records {
+		instances = append(instances, record.instance())
+	}
+	return instances, nil
+}
+
+func newRecord(instance Instance) Record {
+	return Record{
+		ID:        instance.ID,
+		Name:      instance.Name,
+		Status:    string(instance.Status),
+		Step:      instance.Step,
+		Data:      instance.Data,
+		Error:     instance.Error,
+		Attempts:  instance.Attempts,
+		Version:   instance.Version,
+		CreatedAt: instance.CreatedAt,
+		UpdatedAt: instance.UpdatedAt,
+	}
+}
+
+func (r Record) instance() Instance {
+	return Instance{
+		ID:        r.ID,
+		Name:      r.Name,
+		Status:    Status(r.Status),
+		Step:      r.Step,
+		Data:      r.Data,
+		Error:     r.Error,
+		Attempts:  r.Attempts,
+		Version:   r.Version,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
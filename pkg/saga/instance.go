@@ -0,0 +1,63 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the lifecycle status of a saga Instance.
+type Status string
+
+const (
+	// StatusRunning is the status of a saga executing its steps
+	StatusRunning Status = "running"
+	// StatusCompensating is the status of a saga compensating its completed steps
+	StatusCompensating Status = "compensating"
+	// StatusCompleted is the final status of a saga whose steps all succeeded
+	StatusCompleted Status = "completed"
+	// StatusCompensated is the final status of a saga whose completed steps were compensated
+	StatusCompensated Status = "compensated"
+	// StatusFailed is the final status of a saga whose compensation failed, to be fixed by hand
+	StatusFailed Status = "failed"
+)
+
+// Finished reports whether s is a final status.
+func (s Status) Finished() bool {
+	return s == StatusCompleted || s == StatusCompensated || s == StatusFailed
+}
+
+// Instance is the persisted progress of an execution of a saga.
+type Instance struct {
+	ID   uuid.UUID
+	Name string
+	// Status and Step are the progress: the next step to run while running, the number of
+	// steps left to compensate while compensating
+	Status Status
+	Step   int
+	// Data is the JSON of the saga data, as of the last completed step
+	Data json.RawMessage
+	// Error is the message of the failed step, or of the last failed compensation
+	Error string
+	// Attempts counts the failed runs of the current compensation
+	Attempts int
+	// Version is incremented by each update, so one instance progresses the saga at a time
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Store persists the saga instances.
+type Store interface {
+	// Create stores a new instance.
+	Create(ctx context.Context, instance Instance) error
+	// Update replaces the instance stored with instance.Version, and increments the version;
+	// it returns ErrConflict when the stored version differs.
+	Update(ctx context.Context, instance *Instance) error
+	// Get returns the instance and whether it exists.
+	Get(ctx context.Context, id uuid.UUID) (Instance, bool, error)
+	// ListStale returns up to limit unfinished instances not updated since before, oldest first.
+	ListStale(ctx context.Context, before time.Time, limit int) ([]Instance, error)
+}
@@ -0,0 +1,73 @@
+package saga
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	finishedMetricName             = "saga_finished_total"
+	stepFailuresMetricName         = "saga_step_failures_total"
+	compensationFailuresMetricName = "saga_compensation_failures_total"
+	resumedMetricName              = "saga_resumed_total"
+)
+
+type sagaMetrics struct {
+	finished             *prometheus.CounterVec
+	stepFailures         *prometheus.CounterVec
+	compensationFailures *prometheus.CounterVec
+	resumed              *prometheus.CounterVec
+}
+
+func newSagaMetrics(registerer prometheus.Registerer) (*sagaMetrics, error) {
+	finished := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: finishedMetricName,
+			Help: "Total finished sagas by saga and status (completed, compensated, failed)",
+		},
+		[]string{"saga", "status"},
+	)
+	stepFailures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: stepFailuresMetricName,
+			Help: "Total failed saga steps, each starting a compensation, by saga and step",
+		},
+		[]string{"saga", "step"},
+	)
+	compensationFailures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: compensationFailuresMetricName,
+			Help: "Total failed runs of saga compensations by saga and step",
+		},
+		[]string{"saga", "step"},
+	)
+	resumed := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: resumedMetricName,
+			Help: "Total sagas resumed after an interruption, by saga",
+		},
+		[]string{"saga"},
+	)
+
+	finished, err := metrics.Register(registerer, finished)
+	if err != nil {
+		return nil, err
+	}
+	if stepFailures, err = metrics.Register(registerer, stepFailures); err != nil {
+		return nil, err
+	}
+	if compensationFailures, err = metrics.Register(registerer, compensationFailures); err != nil {
+		return nil, err
+	}
+	if resumed, err = metrics.Register(registerer, resumed); err != nil {
+		return nil, err
+	}
+
+	return &sagaMetrics{
+		finished:             finished,
+		stepFailures:         stepFailures,
+		compensationFailures: compensationFailures,
+		resumed:              resumed,
+	}, nil
+}
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS saga_instances (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    status TEXT NOT NULL,
+    step INTEGER NOT NULL DEFAULT 0,
+    data JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    version INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS saga_instances_status_updated_at_idx ON saga_instances (status, updated_at);
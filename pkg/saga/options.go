@@ -0,0 +1,55 @@
+package saga
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+)
+
+type options struct {
+	registerer prometheus.Registerer
+	clock      clock.Clock
+	ids        ident.Generator
+}
+
+// Option configures the Coordinator created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		registerer: prometheus.DefaultRegisterer,
+		clock:      clock.New(),
+		ids:        ident.New(),
+	}
+}
+
+// WithRegisterer sets the registerer the saga metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithClock sets the clock of the progress times and of the resume interval, e.g. a
+// clock.Fake in tests. Defaults to the time package when not provided.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
+
+// WithIDGenerator sets the generator of the saga instance IDs, e.g. an ident.Sequence in
+// tests. Defaults to UUIDv7 when not provided.
+func WithIDGenerator(ids ident.Generator) Option {
+	return func(o *options) {
+		if ids != nil {
+			o.ids = ids
+		}
+	}
+}
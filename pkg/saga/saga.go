@@ -0,0 +1,175 @@
+// Package saga coordinates the writes spanning several services without a distributed
+// transaction: the steps of a saga run in order, and when one fails the completed ones are
+// compensated in reverse order. The progress is persisted after each step, so a saga
+// interrupted by a crash is resumed by any instance.
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// Step is a step of a saga, acting on the saga data. Action and Compensate are run again
+// when the saga is resumed in the middle of them, so they are idempotent.
+type Step[T any] struct {
+	Name string
+	// Action performs the step; its changes to data are persisted once it succeeds
+	Action func(ctx context.Context, data *T) error
+	// Compensate undoes a completed Action, e.g. refunds a charge; optional for the steps
+	// with nothing to undo
+	Compensate func(ctx context.Context, data *T) error
+}
+
+// Definition is a named sequence of steps acting on data of type T, persisted as JSON.
+type Definition[T any] struct {
+	Name  string
+	Steps []Step[T]
+}
+
+func (d Definition[T]) validate() error {
+	if d.Name == "" {
+		return ErrMissingSagaName
+	}
+	if len(d.Steps) == 0 {
+		return fmt.Errorf("%w: %s", ErrMissingSteps, d.Name)
+	}
+	for i, step := range d.Steps {
+		if step.Name == "" || step.Action == nil {
+			return fmt.Errorf("%w: %s step %d", ErrMissingStepAction, d.Name, i)
+		}
+	}
+	return nil
+}
+
+// Saga executes the Definition it was registered with.
+type Saga[T any] struct {
+	def         Definition[T]
+	coordinator *Coordinator
+}
+
+// Register registers def with coordinator, so its interrupted executions are resumed, and
+// returns the Saga executing it. The definitions are registered before the coordinator starts.
+func Register[T any](coordinator *Coordinator, def Definition[T]) (*Saga[T], error) {
+	if err := def.validate(); err != nil {
+		return nil, err
+	}
+	s := &Saga[T]{def: def, coordinator: coordinator}
+	if err := coordinator.register(def.Name, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Execute persists a new execution of the saga with data, and runs it to its end. It
+// returns the ID of the execution, and ErrCompensated wrapping the error of the failed step
+// when the saga was compensated. The saga is not canceled with ctx: it runs to its end even
+// when the caller gives up, keeping the values of ctx.
+func (s *Saga[T]) Execute(ctx context.Context, data T) (uuid.UUID, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("saga: encode data: %w", err)
+	}
+	c := s.coordinator
+	now := c.clock.Now().UTC()
+	instance := Instance{
+		ID:        c.ids.NewID(),
+		Name:      s.def.Name,
+		Status:    StatusRunning,
+		Data:      payload,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err = c.store.Create(ctx, instance); err != nil {
+		return uuid.Nil, err
+	}
+	return instance.ID, s.run(context.WithoutCancel(ctx), &instance)
+}
+
+// run runs the steps of instance from its progress, then compensates them when one failed.
+func (s *Saga[T]) run(ctx context.Context, instance *Instance) error {
+	var data T
+	if err := json.Unmarshal(instance.Data, &data); err != nil {
+		return fmt.Errorf("saga: decode data: %w", err)
+	}
+	c := s.coordinator
+
+	var stepErr error
+	for instance.Status == StatusRunning && instance.Step < len(s.def.Steps) {
+		step := s.def.Steps[instance.Step]
+		if err := step.Action(ctx, &data); err != nil {
+			// The failed step made no change: the steps completed before it are compensated
+			stepErr = err
+			instance.Status = StatusCompensating
+			instance.Error = step.Name + ": " + err.Error()
+			c.metrics.stepFailures.WithLabelValues(s.def.Name, step.Name).Inc()
+		} else {
+			instance.Step++
+		}
+		if err := c.save(ctx, instance, data); err != nil {
+			return err
+		}
+	}
+	if instance.Status == StatusRunning {
+		instance.Status = StatusCompleted
+		if err := c.save(ctx, instance, data); err != nil {
+			return err
+		}
+		c.metrics.finished.WithLabelValues(s.def.Name, string(StatusCompleted)).Inc()
+		return nil
+	}
+
+	for instance.Step > 0 {
+		step := s.def.Steps[instance.Step-1]
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx, &data); err != nil {
+				return s.compensationFailed(ctx, instance, data, step.Name, err)
+			}
+		}
+		instance.Step--
+		instance.Attempts = 0
+		if err := c.save(ctx, instance, data); err != nil {
+			return err
+		}
+	}
+	instance.Status = StatusCompensated
+	if err := c.save(ctx, instance, data); err != nil {
+		return err
+	}
+	c.metrics.finished.WithLabelValues(s.def.Name, string(StatusCompensated)).Inc()
+	if stepErr == nil {
+		// Resumed: the error of the step is only known by its message
+		stepErr = errors.New(instance.Error)
+	}
+	return fmt.Errorf("%w: %w", ErrCompensated, stepErr)
+}
+
+// compensationFailed records a failed compensation, to be retried when the saga is resumed,
+// and fails the saga after max_compensation_attempts.
+func (s *Saga[T]) compensationFailed(ctx context.Context, instance *Instance, data T, step string, err error) error {
+	c := s.coordinator
+	c.metrics.compensationFailures.WithLabelValues(s.def.Name, step).Inc()
+	instance.Attempts++
+	instance.Error = "compensate " + step + ": " + err.Error()
+	if instance.Attempts >= c.cfg.MaxCompensationAttempts {
+		instance.Status = StatusFailed
+	}
+	if errSave := c.save(ctx, instance, data); errSave != nil {
+		return errSave
+	}
+	if instance.Status == StatusFailed {
+		c.metrics.finished.WithLabelValues(s.def.Name, string(StatusFailed)).Inc()
+		c.log.Error("saga failed, compensation to be done by hand",
+			logger.String("saga", s.def.Name),
+			logger.Stringer("saga_id", instance.ID),
+			logger.String("step", step),
+			logger.Error(err),
+		)
+	}
+	return fmt.Errorf("%w: %s: %w", ErrCompensationFailed, step, err)
+}
@@ -0,0 +1,347 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/ident"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/saga"
+)
+
+var (
+	testLogger  = logger.MustNewWithOptions(logger.WithLevel("fatal"))
+	errDeclined = errors.New("card declined")
+)
+
+// memoryStore is a Store honouring the versions, in memory.
+type memoryStore struct {
+	mu        sync.Mutex
+	instances map[uuid.UUID]saga.Instance
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{instances: make(map[uuid.UUID]saga.Instance)}
+}
+
+func (s *memoryStore) Create(_ context.Context, instance saga.Instance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.instances[instance.ID] = instance
+	return nil
+}
+
+func (s *memoryStore) Update(_ context.Context, instance *saga.Instance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.instances[instance.ID].Version != instance.Version {
+		return saga.ErrConflict
+	}
+	instance.Version++
+	s.instances[instance.ID] = *instance
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, id uuid.UUID) (saga.Instance, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	instance, found := s.instances[id]
+	return instance, found, nil
+}
+
+func (s *memoryStore) ListStale(_ context.Context, before time.Time, limit int) ([]saga.Instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var stale []saga.Instance
+	for _, instance := range s.instances {
+		if !instance.Status.Finished() && instance.UpdatedAt.Before(before) {
+			stale = append(stale, instance)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].UpdatedAt.Before(stale[j].UpdatedAt) })
+	return stale[:min(limit, len(stale))], nil
+}
+
+type order struct {
+	Steps     []string
+	PaymentID string
+}
+
+// checkout records its steps and compensations in the order data, and in calls.
+type checkout struct {
+	mu    sync.Mutex
+	calls []string
+	fail  map[string]int
+}
+
+func (c *checkout) step(name string, change func(*order)) func(context.Context, *order) error {
+	return func(_ context.Context, data *order) error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.calls = append(c.calls, name)
+		if c.fail[name] > 0 {
+			c.fail[name]--
+			return errDeclined
+		}
+		data.Steps = append(data.Steps, name)
+		if change != nil {
+			change(data)
+		}
+		return nil
+	}
+}
+
+func (c *checkout) definition() saga.Definition[order] {
+	return saga.Definition[order]{
+		Name: "checkout",
+		Steps: []saga.Step[order]{
+			{Name: "reserve", Action: c.step("reserve", nil), Compensate: c.step("release", nil)},
+			{
+				Name:       "charge",
+				Action:     c.step("charge", func(o *order) { o.PaymentID = "pay-1" }),
+				Compensate: c.step("refund", nil),
+			},
+			{Name: "ship", Action: c.step("ship", nil)},
+		},
+	}
+}
+
+func (c *checkout) Calls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.calls...)
+}
+
+type fixture struct {
+	coordinator *saga.Coordinator
+	store       *memoryStore
+	registry    *prometheus.Registry
+	clock       *clock.Fake
+}
+
+func newFixture(t *testing.T, cfg saga.Config) fixture {
+	t.Helper()
+	f := fixture{
+		store:    newMemoryStore(),
+		registry: prometheus.NewRegistry(),
+		clock:    clock.NewFake(time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)),
+	}
+	coordinator, err := saga.New(cfg, f.store, testLogger,
+		saga.WithRegisterer(f.registry),
+		saga.WithClock(f.clock),
+		saga.WithIDGenerator(ident.NewSequence()),
+	)
+	require.NoError(t, err)
+	f.coordinator = coordinator
+	return f
+}
+
+func (f fixture) instance(t *testing.T, id uuid.UUID) saga.Instance {
+	t.Helper()
+	instance, found, err := f.store.Get(context.Background(), id)
+	require.NoError(t, err)
+	require.True(t, found)
+	return instance
+}
+
+func (f fixture) assertFinished(t *testing.T, status saga.Status) {
+	t.Helper()
+	expected := fmt.Sprintf(`
+# HELP saga_finished_total Total finished sagas by saga and status (completed, compensated, failed)
+# TYPE saga_finished_total counter
+saga_finished_total{saga="checkout",status="%s"} 1
+`, status)
+	assert.NoError(t, testutil.GatherAndCompare(f.registry, strings.NewReader(expected), "saga_finished_total"))
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("rejects an invalid definition", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, saga.Config{})
+
+		// Act
+		_, errName := saga.Register(f.coordinator, saga.Definition[order]{})
+		_, errSteps := saga.Register(f.coordinator, saga.Definition[order]{Name: "checkout"})
+		_, errAction := saga.Register(f.coordinator, saga.Definition[order]{
+			Name:  "checkout",
+			Steps: []saga.Step[order]{{Name: "reserve"}},
+		})
+
+		// Assert
+		require.ErrorIs(t, errName, saga.ErrMissingSagaName)
+		require.ErrorIs(t, errSteps, saga.ErrMissingSteps)
+		require.ErrorIs(t, errAction, saga.ErrMissingStepAction)
+	})
+
+	t.Run("rejects a duplicate name", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, saga.Config{})
+		_, err := saga.Register(f.coordinator, (&checkout{}).definition())
+		require.NoError(t, err)
+
+		// Act
+		_, err = saga.Register(f.coordinator, (&checkout{}).definition())
+
+		// Assert
+		require.ErrorIs(t, err, saga.ErrDuplicateSaga)
+	})
+}
+
+func TestSaga_Execute(t *testing.T) {
+	t.Run("completes the steps in order", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, saga.Config{})
+		steps := &checkout{}
+		checkoutSaga, err := saga.Register(f.coordinator, steps.definition())
+		require.NoError(t, err)
+
+		// Act
+		id, err := checkoutSaga.Execute(context.Background(), order{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"reserve", "charge", "ship"}, steps.Calls())
+		instance := f.instance(t, id)
+		assert.Equal(t, saga.StatusCompleted, instance.Status)
+		assert.Equal(t, 3, instance.Step)
+		assert.JSONEq(t, `{"Steps":["reserve","charge","ship"],"PaymentID":"pay-1"}`, string(instance.Data))
+		f.assertFinished(t, saga.StatusCompleted)
+	})
+
+	t.Run("compensates the completed steps in reverse order", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, saga.Config{})
+		steps := &checkout{fail: map[string]int{"ship": 1}}
+		checkoutSaga, err := saga.Register(f.coordinator, steps.definition())
+		require.NoError(t, err)
+
+		// Act
+		id, err := checkoutSaga.Execute(context.Background(), order{})
+
+		// Assert
+		require.ErrorIs(t, err, saga.ErrCompensated)
+		require.ErrorIs(t, err, errDeclined)
+		assert.Equal(t, []string{"reserve", "charge", "ship", "refund", "release"}, steps.Calls())
+		instance := f.instance(t, id)
+		assert.Equal(t, saga.StatusCompensated, instance.Status)
+		assert.Equal(t, 0, instance.Step)
+		assert.Equal(t, "ship: card declined", instance.Error)
+		f.assertFinished(t, saga.StatusCompensated)
+	})
+
+	t.Run("fails after the compensation attempts", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, saga.Config{MaxCompensationAttempts: 2})
+		steps := &checkout{fail: map[string]int{"ship": 1, "refund": 2}}
+		checkoutSaga, err := saga.Register(f.coordinator, steps.definition())
+		require.NoError(t, err)
+		id, err := checkoutSaga.Execute(context.Background(), order{})
+		require.ErrorIs(t, err, saga.ErrCompensationFailed)
+		assert.Equal(t, saga.StatusCompensating, f.instance(t, id).Status)
+		f.clock.Advance(time.Hour)
+
+		// Act
+		resumed, err := f.coordinator.Resume(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, resumed)
+		assert.Equal(t, []string{"reserve", "charge", "ship", "refund", "refund"}, steps.Calls())
+		instance := f.instance(t, id)
+		assert.Equal(t, saga.StatusFailed, instance.Status)
+		assert.Equal(t, 2, instance.Step)
+		assert.Equal(t, 2, instance.Attempts)
+		assert.Equal(t, "compensate charge: card declined", instance.Error)
+		f.assertFinished(t, saga.StatusFailed)
+	})
+}
+
+func TestCoordinator_Resume(t *testing.T) {
+	t.Run("resumes a stale saga from its progress", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, saga.Config{StaleAfter: time.Minute})
+		steps := &checkout{}
+		_, err := saga.Register(f.coordinator, steps.definition())
+		require.NoError(t, err)
+		id := uuid.New()
+		require.NoError(t, f.store.Create(context.Background(), saga.Instance{
+			ID:        id,
+			Name:      "checkout",
+			Status:    saga.StatusRunning,
+			Step:      2,
+			Data:      []byte(`{"Steps":["reserve","charge"],"PaymentID":"pay-1"}`),
+			UpdatedAt: f.clock.Now().Add(-2 * time.Minute),
+		}))
+
+		// Act
+		resumed, err := f.coordinator.Resume(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, resumed)
+		assert.Equal(t, []string{"ship"}, steps.Calls())
+		instance := f.instance(t, id)
+		assert.Equal(t, saga.StatusCompleted, instance.Status)
+		assert.JSONEq(t, `{"Steps":["reserve","charge","ship"],"PaymentID":"pay-1"}`, string(instance.Data))
+		count, err := testutil.GatherAndCount(f.registry, "saga_resumed_total")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("leaves the recent and unknown sagas", func(t *testing.T) {
+		// Arrange
+		f := newFixture(t, saga.Config{StaleAfter: time.Minute})
+		steps := &checkout{}
+		_, err := saga.Register(f.coordinator, steps.definition())
+		require.NoError(t, err)
+		for _, instance := range []saga.Instance{
+			{ID: uuid.New(), Name: "checkout", Status: saga.StatusRunning, Data: []byte(`{}`), UpdatedAt: f.clock.Now()},
+			{ID: uuid.New(), Name: "refund", Status: saga.StatusRunning, Data: []byte(`{}`), UpdatedAt: time.Time{}},
+		} {
+			require.NoError(t, f.store.Create(context.Background(), instance))
+		}
+
+		// Act
+		resumed, err := f.coordinator.Resume(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0, resumed)
+		assert.Empty(t, steps.Calls())
+	})
+}
+
+func TestCoordinator_Start(t *testing.T) {
+	// Arrange
+	f := newFixture(t, saga.Config{ResumeInterval: time.Second})
+	steps := &checkout{}
+	_, err := saga.Register(f.coordinator, steps.definition())
+	require.NoError(t, err)
+	require.NoError(t, f.store.Create(context.Background(), saga.Instance{
+		ID: uuid.New(), Name: "checkout", Status: saga.StatusRunning, Data: []byte(`{}`), UpdatedAt: f.clock.Now(),
+	}))
+
+	// Act
+	require.NoError(t, f.coordinator.Start())
+	defer func() { require.NoError(t, f.coordinator.Stop(context.Background())) }()
+
+	// Assert
+	require.ErrorIs(t, f.coordinator.Start(), saga.ErrCoordinatorStarted)
+	assert.Eventually(t, func() bool {
+		f.clock.Advance(time.Minute)
+		return len(steps.Calls()) == 3
+	}, time.Second, time.Millisecond)
+}
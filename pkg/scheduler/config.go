@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	CoordinatorRedis = "redis"
+	CoordinatorLocal = "local"
+
+	defaultKey      = "scheduler"
+	defaultLeaseTTL = 15 * time.Second
+	// minLeaseTTL leaves room for the renewals, made every third of the lease
+	minLeaseTTL     = 3 * time.Second
+	defaultTimezone = "UTC"
+)
+
+// Config configures the Scheduler.
+type Config struct {
+	// Coordinator is redis, electing a leader among the instances, or local, for a
+	// single instance
+	Coordinator string `config:"coordinator"`
+	// Key prefixes the Redis keys of the leader lock and the runs
+	Key string `config:"key"`
+	// LeaseTTL is the leadership lease; a crashed leader is replaced after at most LeaseTTL
+	LeaseTTL time.Duration `config:"lease_ttl"`
+	// Timezone is the IANA timezone of the schedules, e.g. "Europe/Lisbon"
+	Timezone string `config:"timezone"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Coordinator == "" {
+		c.Coordinator = CoordinatorRedis
+	}
+	if c.Key == "" {
+		c.Key = defaultKey
+	}
+	if c.LeaseTTL == 0 {
+		c.LeaseTTL = defaultLeaseTTL
+	}
+	if c.Timezone == "" {
+		c.Timezone = defaultTimezone
+	}
+}
+
+// Validate checks the coordinator, the lease and the timezone.
+func (c *Config) Validate() error {
+	if c.Coordinator != CoordinatorRedis && c.Coordinator != CoordinatorLocal {
+		return ErrInvalidCoordinator
+	}
+	if c.LeaseTTL < minLeaseTTL {
+		return ErrInvalidLeaseTTL
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTimezone, c.Timezone)
+	}
+	return nil
+}
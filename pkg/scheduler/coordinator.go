@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+const claimScript = `
+local last = tonumber(redis.call("GET", KEYS[1]) or "0")
+if last >= tonumber(ARGV[1]) then
+    return 0
+end
+redis.call("SET", KEYS[1], ARGV[1])
+return 1`
+
+// Coordinator elects the instance running the jobs and records their runs, shared by
+// every instance of the application.
+type Coordinator interface {
+	// Lead acquires or renews the leadership for ttl, and reports whether this instance leads.
+	Lead(ctx context.Context, ttl time.Duration) (bool, error)
+	// Resign gives up the leadership, so another instance takes over without waiting
+	// for the lease to expire.
+	Resign(ctx context.Context) error
+	// Claim records the run of job due at due. It reports false when the run, or a later
+	// one, was already claimed, e.g. by the previous leader.
+	Claim(ctx context.Context, job string, due time.Time) (bool, error)
+	// LastRun returns the due time of the last claimed run of job, and whether there is one.
+	LastRun(ctx context.Context, job string) (time.Time, bool, error)
+}
+
+// RedisCoordinator elects the leader with a redis.Lock renewed while the instance runs,
+// and records the runs in Redis, so each run happens once even across a leader change.
+type RedisCoordinator struct {
+	client  *redis.Client
+	key     string
+	scripts *redis.Scripts
+	mu      sync.Mutex
+	lock    *redis.Lock
+}
+
+// NewRedisCoordinator creates a RedisCoordinator keeping the leader lock at key + ":leader"
+// and the runs at key + ":last_run:" + job name, in the client namespace.
+func NewRedisCoordinator(client *redis.Client, key string) (*RedisCoordinator, error) {
+	scripts := redis.NewScripts(client)
+	if err := scripts.Register("claim", claimScript); err != nil {
+		return nil, err
+	}
+	return &RedisCoordinator{client: client, key: key, scripts: scripts}, nil
+}
+
+// Lead implements Coordinator.
+func (c *RedisCoordinator) Lead(ctx context.Context, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lock != nil {
+		err := c.lock.Extend(ctx, ttl)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, redis.ErrLockNotHeld) {
+			return false, err
+		}
+		// The lease expired, e.g. after a long pause; compete again
+		c.lock = nil
+	}
+
+	lock, err := c.client.Lock(ctx, c.key+":leader", ttl)
+	if errors.Is(err, redis.ErrLockNotAcquired) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	c.lock = lock
+	return true, nil
+}
+
+// Resign implements Coordinator.
+func (c *RedisCoordinator) Resign(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lock == nil {
+		return nil
+	}
+	err := c.lock.Release(ctx)
+	c.lock = nil
+	if errors.Is(err, redis.ErrLockNotHeld) {
+		return nil
+	}
+	return err
+}
+
+// Claim implements Coordinator.
+func (c *RedisCoordinator) Claim(ctx context.Context, job string, due time.Time) (bool, error) {
+	claimed, err := redis.RunScript[int64](ctx, c.scripts, "claim", []string{c.lastRunKey(job)}, due.UnixMilli())
+	if err != nil {
+		return false, err
+	}
+	return claimed == 1, nil
+}
+
+// LastRun implements Coordinator.
+func (c *RedisCoordinator) LastRun(ctx context.Context, job string) (time.Time, bool, error) {
+	var get *goredis.StringCmd
+	err := c.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		get = p.Get(ctx, c.lastRunKey(job))
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	millis, err := get.Int64()
+	if errors.Is(err, goredis.Nil) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return time.UnixMilli(millis), true, nil
+}
+
+func (c *RedisCoordinator) lastRunKey(job string) string {
+	return c.key + ":last_run:" + job
+}
+
+// LocalCoordinator always leads and records the runs in memory, for applications running
+// a single instance, development and tests.
+type LocalCoordinator struct {
+	mu   sync.Mutex
+	runs map[string]time.Time
+}
+
+// NewLocalCoordinator creates a LocalCoordinator.
+func NewLocalCoordinator() *LocalCoordinator {
+	return &LocalCoordinator{runs: make(map[string]time.Time)}
+}
+
+// Lead implements Coordinator.
+func (c *LocalCoordinator) Lead(context.Context, time.Duration) (bool, error) {
+	return true, nil
+}
+
+// Resign implements Coordinator.
+func (c *LocalCoordinator) Resign(context.Context) error {
+	return nil
+}
+
+// Claim implements Coordinator.
+func (c *LocalCoordinator) Claim(_ context.Context, job string, due time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if last, ok := c.runs[job]; ok && !last.Before(due) {
+		return false, nil
+	}
+	c.runs[job] = due
+	return true, nil
+}
+
+// LastRun implements Coordinator.
+func (c *LocalCoordinator) LastRun(_ context.Context, job string) (time.Time, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last, ok := c.runs[job]
+	return last, ok, nil
+}
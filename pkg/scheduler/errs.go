@@ -0,0 +1,18 @@
+package scheduler
+
+import "errors"
+
+var (
+	ErrInvalidSchedule    = errors.New("invalid job schedule")
+	ErrInvalidMissedRuns  = errors.New("invalid missed runs policy (must be 'skip' or 'run_once')")
+	ErrMissingJobName     = errors.New("job name is required")
+	ErrMissingJobRun      = errors.New("job run function is required")
+	ErrDuplicateJob       = errors.New("job already registered")
+	ErrInvalidTimezone    = errors.New("invalid scheduler timezone")
+	ErrInvalidLeaseTTL    = errors.New("scheduler lease ttl must be at least 3s")
+	ErrSchedulerStarted   = errors.New("scheduler already started")
+	ErrMissingCoordinator = errors.New("scheduler coordinator is required")
+	ErrInvalidCoordinator = errors.New("invalid scheduler coordinator (must be 'redis' or 'local')")
+	ErrMissingRedisClient = errors.New("redis coordinator requires the redis *Client (redis.ClientModule)")
+	ErrJobPanic           = errors.New("scheduled job panicked")
+)
@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// SetJitter sets the random delay of the jobs with a jitter.
+func (s *Scheduler) SetJitter(jitter func(limit time.Duration) time.Duration) {
+	s.jitter = jitter
+}
+
+// Elect runs one leadership election.
+func (s *Scheduler) Elect(ctx context.Context) {
+	s.elect(ctx)
+}
+
+// RunDue runs the jobs due at the current time.
+func (s *Scheduler) RunDue(ctx context.Context) {
+	s.runDue(ctx)
+}
+
+// Wait waits for the running jobs.
+func (s *Scheduler) Wait() {
+	s.runs.Wait()
+}
@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// Module provides the *Scheduler configured from app.scheduler, running the jobs of the
+// "scheduler_jobs" group between the application start and stop. The redis coordinator
+// requires redis.ClientModule; job metrics are registered on the prometheus.Registerer
+// from metrics.Module and the time is read from the clock.Clock of clock.Module when
+// available.
+//
+// Register a job:
+//
+//	fx.Provide(
+//	    fx.Annotate(
+//	        NewPurgeExpiredCartsJob, // func(...) scheduler.Job
+//	        fx.ResultTags(`group:"scheduler_jobs"`),
+//	    ),
+//	)
+var Module = fx.Module(
+	"scheduler",
+	config.Provide[Config]("app.scheduler"),
+	fx.Provide(NewWithLifecycle),
+)
+
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle  fx.Lifecycle
+	Config     config.Config[Config]
+	Logger     logger.Logger
+	Redis      *redis.Client         `optional:"true"`
+	Registerer prometheus.Registerer `optional:"true"`
+	Clock      clock.Clock           `optional:"true"`
+	Jobs       []Job                 `group:"scheduler_jobs"`
+}
+
+// NewWithLifecycle creates the Scheduler, registers the grouped jobs, starts it on start
+// and stops it on stop.
+func NewWithLifecycle(p NewWithLifecycleParams) (*Scheduler, error) {
+	cfg := p.Config.Get()
+	cfg.SetDefaults()
+
+	var coordinator Coordinator = NewLocalCoordinator()
+	if cfg.Coordinator == CoordinatorRedis {
+		if p.Redis == nil {
+			return nil, ErrMissingRedisClient
+		}
+		redisCoordinator, err := NewRedisCoordinator(p.Redis, cfg.Key)
+		if err != nil {
+			return nil, err
+		}
+		coordinator = redisCoordinator
+	}
+
+	s, err := New(cfg, coordinator, p.Logger, WithRegisterer(p.Registerer), WithClock(p.Clock))
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range p.Jobs {
+		if err = s.Register(job); err != nil {
+			return nil, err
+		}
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			return s.Start()
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+	return s, nil
+}
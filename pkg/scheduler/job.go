@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// MissedRunPolicy decides what happens to the runs of a job missed while no instance
+// was leading, e.g. during a deploy or an outage.
+type MissedRunPolicy string
+
+const (
+	// MissedRunsSkip ignores the missed runs; the job runs at its next scheduled time
+	MissedRunsSkip MissedRunPolicy = "skip"
+	// MissedRunsRunOnce runs the job once as soon as a leader is elected, whatever the
+	// number of missed runs
+	MissedRunsRunOnce MissedRunPolicy = "run_once"
+)
+
+// Job is a function run on a schedule by a single instance.
+//
+//	scheduler.Job{
+//	    Name:     "purge_expired_carts",
+//	    Schedule: "*/15 * * * *",
+//	    Run:      uc.Execute,
+//	    Jitter:   30 * time.Second,
+//	}
+type Job struct {
+	// Name identifies the job in the logs, the metrics and the coordinator; it must be
+	// stable across deploys
+	Name string
+	// Schedule is a cron expression or a descriptor, see ParseSchedule
+	Schedule string
+	// Run runs the job; its context is canceled on timeout, and when the scheduler stops
+	// without the job finishing in time
+	Run func(ctx context.Context) error
+	// MissedRuns defaults to MissedRunsSkip
+	MissedRuns MissedRunPolicy
+	// Jitter delays each run by a random duration up to Jitter, spreading the load of the
+	// jobs sharing a schedule
+	Jitter time.Duration
+	// Timeout cancels the context of the runs lasting longer; no timeout when zero
+	Timeout time.Duration
+}
+
+func (j *Job) validate() error {
+	if j.Name == "" {
+		return ErrMissingJobName
+	}
+	if j.Run == nil {
+		return ErrMissingJobRun
+	}
+	if j.MissedRuns == "" {
+		j.MissedRuns = MissedRunsSkip
+	}
+	if j.MissedRuns != MissedRunsSkip && j.MissedRuns != MissedRunsRunOnce {
+		return ErrInvalidMissedRuns
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
+)
+
+const (
+	runsMetricName        = "scheduler_job_runs_total"
+	durationMetricName    = "scheduler_job_duration_seconds"
+	skippedMetricName     = "scheduler_job_skipped_total"
+	lastSuccessMetricName = "scheduler_job_last_success_timestamp_seconds"
+	leaderMetricName      = "scheduler_leader"
+
+	resultSuccess = "success"
+	resultError   = "error"
+
+	// skipOverlap means the previous run was still running
+	skipOverlap = "overlap"
+	// skipClaimed means another instance already ran the job
+	skipClaimed = "claimed"
+)
+
+type jobMetrics struct {
+	runs        *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	skipped     *prometheus.CounterVec
+	lastSuccess *prometheus.GaugeVec
+	leader      prometheus.Gauge
+}
+
+func newJobMetrics(registerer prometheus.Registerer) (*jobMetrics, error) {
+	runs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: runsMetricName,
+			Help: "Total scheduled job runs by job and result (success, error)",
+		},
+		[]string{"job", "result"},
+	)
+	duration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    durationMetricName,
+			Help:    "Duration of scheduled job runs in seconds",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 3600},
+		},
+		[]string{"job"},
+	)
+	skipped := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: skippedMetricName,
+			Help: "Total skipped scheduled job runs by job and reason (overlap, claimed)",
+		},
+		[]string{"job", "reason"},
+	)
+	lastSuccess := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: lastSuccessMetricName,
+			Help: "Unix time of the last successful run of the scheduled job",
+		},
+		[]string{"job"},
+	)
+	leader := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: leaderMetricName,
+		Help: "Whether this instance leads the scheduler and runs the jobs (1) or not (0)",
+	})
+
+	runs, err := metrics.Register(registerer, runs)
+	if err != nil {
+		return nil, err
+	}
+	if duration, err = metrics.Register(registerer, duration); err != nil {
+		return nil, err
+	}
+	if skipped, err = metrics.Register(registerer, skipped); err != nil {
+		return nil, err
+	}
+	if lastSuccess, err = metrics.Register(registerer, lastSuccess); err != nil {
+		return nil, err
+	}
+	if leader, err = metrics.Register(registerer, leader); err != nil {
+		return nil, err
+	}
+
+	return &jobMetrics{runs: runs, duration: duration, skipped: skipped, lastSuccess: lastSuccess, leader: leader}, nil
+}
+
+func (m *jobMetrics) observeRun(job string, duration time.Duration, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	} else {
+		m.lastSuccess.WithLabelValues(job).SetToCurrentTime()
+	}
+	m.runs.WithLabelValues(job, result).Inc()
+	m.duration.WithLabelValues(job).Observe(duration.Seconds())
+}
+
+func (m *jobMetrics) incSkipped(job, reason string) {
+	m.skipped.WithLabelValues(job, reason).Inc()
+}
+
+func (m *jobMetrics) setLeader(leader bool) {
+	if leader {
+		m.leader.Set(1)
+		return
+	}
+	m.leader.Set(0)
+}
@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+)
+
+type options struct {
+	registerer prometheus.Registerer
+	clock      clock.Clock
+}
+
+// Option configures the Scheduler created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		registerer: prometheus.DefaultRegisterer,
+		clock:      clock.New(),
+	}
+}
+
+// WithRegisterer sets the registerer the job metrics are registered against.
+// Defaults to prometheus.DefaultRegisterer when not provided.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		if registerer != nil {
+			o.registerer = registerer
+		}
+	}
+}
+
+// WithClock sets the clock of the schedules, timers and run durations, e.g. a
+// clock.Fake in tests. Defaults to the time package when not provided.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) {
+		if clk != nil {
+			o.clock = clk
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleYears bounds the search of the next run of schedules that never match,
+// e.g. "0 0 30 2 *"
+const maxScheduleYears = 5
+
+// Schedule returns the next run of a job.
+type Schedule interface {
+	// Next returns the first run strictly after t, or the zero time when there is none.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a cron expression with five fields (minute, hour, day of month,
+// month, day of week), or a descriptor:
+//
+//	"*/15 * * * *"     every 15 minutes
+//	"30 2 * * MON-FRI" at 02:30 on weekdays
+//	"0 0 1 */3 *"      at midnight on the first day of each quarter
+//	"@daily"           @yearly, @monthly, @weekly, @daily and @hourly are supported
+//	"@every 90s"       at a fixed interval
+//
+// Fields accept lists (1,15), ranges (1-5), steps (*/5, 10-40/10) and the names of
+// months and days. When both the day of month and the day of week are restricted, a
+// day matching either runs the job, as in cron.
+func ParseSchedule(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if interval, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || d < time.Second {
+			return nil, fmt.Errorf("%w: %s (the interval must be at least 1s)", ErrInvalidSchedule, expr)
+		}
+		return everySchedule(d), nil
+	}
+
+	if descriptor, ok := descriptors[expr]; ok {
+		expr = descriptor
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %s (expected 5 fields)", ErrInvalidSchedule, expr)
+	}
+
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidSchedule, expr, err)
+		}
+		sets[i] = set
+	}
+	// 7 is Sunday too
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &cronSchedule{
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type fieldBounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []fieldBounds{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// parseField returns the set of the values of a field, as bits.
+func parseField(field string, bounds fieldBounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", bounds.name, part)
+			}
+		}
+
+		low, high := bounds.min, bounds.max
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseValue(lowPart, bounds); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = parseValue(highPart, bounds); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// "5/15" means from 5 to the maximum, every 15
+				high = bounds.max
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid %s range %q", bounds.name, part)
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << value
+		}
+	}
+	return set, nil
+}
+
+func parseValue(value string, bounds fieldBounds) (int, error) {
+	if n, ok := bounds.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < bounds.min || n > bounds.max {
+		return 0, fmt.Errorf("invalid %s %q (must be %d-%d)", bounds.name, value, bounds.min, bounds.max)
+	}
+	return n, nil
+}
+
+// cronSchedule matches the times whose fields are in the sets.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// Next implements Schedule, in the location of t.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxScheduleYears, 0, 0)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// A daylight saving transition repeated the hour
+				next = t.Add(time.Hour).Truncate(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<value) != 0
+}
+
+// everySchedule runs at a fixed interval, on the multiples of the interval since the
+// zero time, so every instance computes the same runs.
+type everySchedule time.Duration
+
+// Next implements Schedule.
+func (s everySchedule) Next(t time.Time) time.Time {
+	interval := time.Duration(s)
+	return t.Truncate(interval).Add(interval)
+}
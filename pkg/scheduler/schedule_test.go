@@ -0,0 +1,101 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/scheduler"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// Thursday
+	from := time.Date(2026, 10, 15, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		next time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2026, 10, 15, 10, 8, 0, 0, time.UTC)},
+		{"step", "*/15 * * * *", time.Date(2026, 10, 15, 10, 15, 0, 0, time.UTC)},
+		{"range with step", "10-40/10 * * * *", time.Date(2026, 10, 15, 10, 10, 0, 0, time.UTC)},
+		{"start with step", "50/5 9 * * *", time.Date(2026, 10, 16, 9, 50, 0, 0, time.UTC)},
+		{"list", "0 9,18 * * *", time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC)},
+		{"day names", "30 2 * * MON-FRI", time.Date(2026, 10, 16, 2, 30, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"month names", "0 0 1 jan *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"day of month or day of week", "0 0 1 * SUN", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"day of month and month", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"descriptor", "@daily", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{"hourly", "@hourly", time.Date(2026, 10, 15, 11, 0, 0, 0, time.UTC)},
+		{"interval", "@every 90s", time.Date(2026, 10, 15, 10, 9, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			schedule, err := scheduler.ParseSchedule(tt.expr)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseSchedule_Timezone(t *testing.T) {
+	t.Run("computes the runs in the location of the time", func(t *testing.T) {
+		// Arrange
+		lisbon, err := time.LoadLocation("Europe/Lisbon")
+		require.NoError(t, err)
+		schedule, err := scheduler.ParseSchedule("0 8 * * *")
+		require.NoError(t, err)
+
+		// Act
+		next := schedule.Next(time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC).In(lisbon))
+
+		// Assert
+		assert.Equal(t, time.Date(2026, 7, 2, 7, 0, 0, 0, time.UTC), next.UTC())
+	})
+
+	t.Run("skips the hour removed by daylight saving time", func(t *testing.T) {
+		// Arrange
+		lisbon, err := time.LoadLocation("Europe/Lisbon")
+		require.NoError(t, err)
+		schedule, err := scheduler.ParseSchedule("30 1 * * *")
+		require.NoError(t, err)
+
+		// Act: clocks go from 01:00 to 02:00 on 2026-03-29
+		next := schedule.Next(time.Date(2026, 3, 29, 0, 0, 0, 0, lisbon))
+
+		// Assert
+		assert.Equal(t, time.Date(2026, 3, 30, 1, 30, 0, 0, lisbon), next)
+	})
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * * MON-",
+		"@every 10ms",
+		"@weekdays",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			// Act
+			_, err := scheduler.ParseSchedule(expr)
+
+			// Assert
+			require.ErrorIs(t, err, scheduler.ErrInvalidSchedule)
+		})
+	}
+}
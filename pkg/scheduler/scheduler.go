@@ -0,0 +1,364 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+)
+
+// resignTimeout bounds the release of the leadership on stop
+const resignTimeout = 5 * time.Second
+
+// Scheduler runs the registered jobs on their schedule, on the instance elected by the
+// Coordinator only. Every instance keeps competing for the leadership, renewed every
+// third of the lease, so a crashed leader is replaced within the lease TTL. Each run is
+// claimed in the Coordinator before it starts, so a run never happens twice, even when
+// the leadership changes hands. A run still running at the next scheduled time skips
+// that time rather than overlapping.
+type Scheduler struct {
+	cfg         Config
+	coordinator Coordinator
+	log         logger.Logger
+	metrics     *jobMetrics
+	location    *time.Location
+	clock       clock.Clock
+	jitter      func(limit time.Duration) time.Duration
+
+	mu      sync.Mutex
+	entries []*entry
+	leader  bool
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	runs       sync.WaitGroup
+	runCtx     context.Context
+	cancelRuns context.CancelFunc
+}
+
+type entry struct {
+	job      Job
+	schedule Schedule
+	next     time.Time
+	running  atomic.Bool
+}
+
+// New creates a Scheduler electing its leader with coordinator.
+//
+//	s, err := scheduler.New(cfg, scheduler.NewLocalCoordinator(), log)
+//	err = s.Register(scheduler.Job{Name: "send_digest", Schedule: "0 8 * * MON", Run: uc.Execute})
+//	err = s.Start()
+//	defer s.Stop(ctx)
+func New(cfg Config, coordinator Coordinator, log logger.Logger, opts ...Option) (*Scheduler, error) {
+	if coordinator == nil {
+		return nil, ErrMissingCoordinator
+	}
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, cfg.Timezone)
+	}
+
+	schedulerOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&schedulerOptions)
+	}
+	metrics, err := newJobMetrics(schedulerOptions.registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, cancelRuns := context.WithCancel(context.Background())
+	return &Scheduler{
+		cfg:         cfg,
+		coordinator: coordinator,
+		log:         log.Named("scheduler"),
+		metrics:     metrics,
+		location:    location,
+		clock:       schedulerOptions.clock,
+		jitter:      func(limit time.Duration) time.Duration { return rand.N(limit) },
+		runCtx:      runCtx,
+		cancelRuns:  cancelRuns,
+	}, nil
+}
+
+// Register adds a job. Jobs are registered before Start.
+func (s *Scheduler) Register(job Job) error {
+	if err := job.validate(); err != nil {
+		return err
+	}
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return ErrSchedulerStarted
+	}
+	for _, e := range s.entries {
+		if e.job.Name == job.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicateJob, job.Name)
+		}
+	}
+	s.entries = append(s.entries, &entry{
+		job:      job,
+		schedule: schedule,
+		next:     schedule.Next(s.clock.Now().In(s.location)),
+	})
+	return nil
+}
+
+// Start starts competing for the leadership and running the jobs, in the background.
+func (s *Scheduler) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return ErrSchedulerStarted
+	}
+	s.started = true
+
+	now := s.clock.Now().In(s.location)
+	for _, e := range s.entries {
+		e.next = e.schedule.Next(now)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.loop(ctx)
+	return nil
+}
+
+// Stop stops scheduling, waits for the running jobs and gives up the leadership. The
+// jobs still running when ctx is done are canceled, and ctx's error is returned.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return nil
+	}
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+
+	cancel()
+	<-done
+
+	drained := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(drained)
+	}()
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		s.cancelRuns()
+		err = ctx.Err()
+	}
+
+	resignCtx, cancelResign := context.WithTimeout(context.WithoutCancel(ctx), resignTimeout)
+	defer cancelResign()
+	if errResign := s.coordinator.Resign(resignCtx); errResign != nil {
+		s.log.Warn("failed to resign scheduler leadership", logger.Error(errResign))
+	}
+	s.setLeader(false)
+	return err
+}
+
+// IsLeader reports whether this instance leads and runs the jobs.
+func (s *Scheduler) IsLeader() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leader
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	defer close(s.done)
+
+	s.elect(ctx)
+	renew := s.clock.NewTicker(s.cfg.LeaseTTL / 3)
+	defer renew.Stop()
+	timer := s.clock.NewTimer(s.untilNext())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-renew.C():
+			s.elect(ctx)
+		case <-timer.C():
+			s.runDue(ctx)
+		}
+		timer.Reset(s.untilNext())
+	}
+}
+
+// elect acquires or renews the leadership. A failed renewal gives up the leadership,
+// since the lease may expire before the next one.
+func (s *Scheduler) elect(ctx context.Context) {
+	leading, err := s.coordinator.Lead(ctx, s.cfg.LeaseTTL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Warn("failed to renew scheduler leadership", logger.Error(err))
+		leading = false
+	}
+
+	if wasLeader := s.setLeader(leading); leading == wasLeader {
+		return
+	}
+	if leading {
+		s.log.Info("acquired scheduler leadership")
+		s.catchUp(ctx)
+		return
+	}
+	s.log.Info("lost scheduler leadership")
+}
+
+// setLeader sets the leadership and returns the previous one.
+func (s *Scheduler) setLeader(leading bool) bool {
+	s.mu.Lock()
+	wasLeader := s.leader
+	s.leader = leading
+	s.mu.Unlock()
+	s.metrics.setLeader(leading)
+	return wasLeader
+}
+
+// catchUp runs once the jobs with the MissedRunsRunOnce policy whose last run is older
+// than their previous scheduled time, on a new leader.
+func (s *Scheduler) catchUp(ctx context.Context) {
+	now := s.clock.Now().In(s.location)
+	for _, e := range s.entries {
+		if e.job.MissedRuns != MissedRunsRunOnce {
+			continue
+		}
+		last, found, err := s.coordinator.LastRun(ctx, e.job.Name)
+		if err != nil {
+			s.log.Error("failed to read the last run of the job", logger.String("job", e.job.Name), logger.Error(err))
+			continue
+		}
+		if !found {
+			// Never run, e.g. a new job: nothing was missed
+			continue
+		}
+		if missed := e.schedule.Next(last.In(s.location)); !missed.IsZero() && !missed.After(now) {
+			s.log.Info("running missed job",
+				logger.String("job", e.job.Name),
+				logger.Time("missed", missed),
+			)
+			s.dispatch(ctx, e, missed)
+		}
+	}
+}
+
+// runDue runs the jobs whose scheduled time has come, when this instance leads.
+func (s *Scheduler) runDue(ctx context.Context) {
+	now := s.clock.Now().In(s.location)
+	leading := s.IsLeader()
+	for _, e := range s.entries {
+		if e.next.IsZero() || e.next.After(now) {
+			continue
+		}
+		due := e.next
+		e.next = e.schedule.Next(now)
+		if leading {
+			s.dispatch(ctx, e, due)
+		}
+	}
+}
+
+// untilNext returns the time until the next scheduled run.
+func (s *Scheduler) untilNext() time.Duration {
+	wait := s.cfg.LeaseTTL
+	now := s.clock.Now()
+	for _, e := range s.entries {
+		if !e.next.IsZero() && e.next.Sub(now) < wait {
+			wait = e.next.Sub(now)
+		}
+	}
+	return max(wait, 0)
+}
+
+// dispatch claims the run due at due and starts it.
+func (s *Scheduler) dispatch(ctx context.Context, e *entry, due time.Time) {
+	if !e.running.CompareAndSwap(false, true) {
+		s.metrics.incSkipped(e.job.Name, skipOverlap)
+		s.log.Warn("skipping job run, the previous run is still running",
+			logger.String("job", e.job.Name),
+			logger.Time("due", due),
+		)
+		return
+	}
+
+	claimed, err := s.coordinator.Claim(ctx, e.job.Name, due)
+	if err != nil || !claimed {
+		e.running.Store(false)
+		if err != nil {
+			s.log.Error("failed to claim job run", logger.String("job", e.job.Name), logger.Error(err))
+			return
+		}
+		s.metrics.incSkipped(e.job.Name, skipClaimed)
+		return
+	}
+
+	s.runs.Add(1)
+	go s.run(e, due)
+}
+
+func (s *Scheduler) run(e *entry, due time.Time) {
+	defer s.runs.Done()
+	defer e.running.Store(false)
+
+	ctx := s.runCtx
+	if e.job.Jitter > 0 {
+		select {
+		case <-s.clock.After(s.jitter(e.job.Jitter)):
+		case <-ctx.Done():
+			return
+		}
+	}
+	if e.job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.job.Timeout)
+		defer cancel()
+	}
+
+	start := s.clock.Now()
+	err := safeRun(ctx, e.job)
+	elapsed := s.clock.Since(start)
+	s.metrics.observeRun(e.job.Name, elapsed, err)
+
+	fields := []logger.Field{
+		logger.String("job", e.job.Name),
+		logger.Time("due", due),
+		logger.Duration("duration", elapsed),
+	}
+	if err != nil {
+		s.log.Error("scheduled job failed", append(fields, logger.Error(err))...)
+		return
+	}
+	s.log.Debug("scheduled job completed", fields...)
+}
+
+func safeRun(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrJobPanic, r)
+		}
+	}()
+	return job.Run(ctx)
+}
@@ -0,0 +1,315 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/scheduler"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	clock       *clock.Fake
+	coordinator *scheduler.LocalCoordinator
+	registry    *prometheus.Registry
+	sut         *scheduler.Scheduler
+}
+
+func TestSchedulerSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
+
+func (s *SchedulerTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Date(2026, 10, 15, 10, 2, 0, 0, time.UTC))
+	s.coordinator = scheduler.NewLocalCoordinator()
+	s.sut = s.newScheduler(s.coordinator)
+}
+
+func (s *SchedulerTestSuite) newScheduler(coordinator scheduler.Coordinator) *scheduler.Scheduler {
+	s.registry = prometheus.NewRegistry()
+	sut, err := scheduler.New(
+		scheduler.Config{Coordinator: scheduler.CoordinatorLocal},
+		coordinator,
+		logger.MustNewWithOptions(logger.WithLevel("fatal")),
+		scheduler.WithRegisterer(s.registry),
+		scheduler.WithClock(s.clock),
+	)
+	s.Require().NoError(err)
+	sut.SetJitter(func(time.Duration) time.Duration { return 0 })
+	return sut
+}
+
+// countingJob registers a job counting its runs.
+func (s *SchedulerTestSuite) countingJob(name, schedule string, policy scheduler.MissedRunPolicy) *atomic.Int64 {
+	var runs atomic.Int64
+	s.Require().NoError(s.sut.Register(scheduler.Job{
+		Name:       name,
+		Schedule:   schedule,
+		MissedRuns: policy,
+		Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	}))
+	return &runs
+}
+
+func (s *SchedulerTestSuite) TestRunDue_Leader_RunsTheDueJobsOnce() {
+	// Arrange
+	runs := s.countingJob("purge", "*/5 * * * *", "")
+	later := s.countingJob("report", "0 * * * *", "")
+	s.sut.Elect(context.Background())
+	s.clock.Advance(3 * time.Minute)
+
+	// Act
+	s.sut.RunDue(context.Background())
+	s.sut.RunDue(context.Background())
+	s.sut.Wait()
+
+	// Assert
+	s.Equal(int64(1), runs.Load())
+	s.Zero(later.Load())
+	s.Equal(1.0, s.metric("scheduler_job_runs_total", "purge", "success"))
+	last, found, err := s.coordinator.LastRun(context.Background(), "purge")
+	s.Require().NoError(err)
+	s.True(found)
+	s.Equal(time.Date(2026, 10, 15, 10, 5, 0, 0, time.UTC), last)
+}
+
+func (s *SchedulerTestSuite) TestRunDue_NotLeader_DoesNotRun() {
+	// Arrange
+	coordinator := mocks.NewMockCoordinator(s.T())
+	coordinator.EXPECT().Lead(mock.Anything, 15*time.Second).Return(false, nil)
+	s.sut = s.newScheduler(coordinator)
+	runs := s.countingJob("purge", "*/5 * * * *", "")
+	s.sut.Elect(context.Background())
+	s.clock.Advance(3 * time.Minute)
+
+	// Act
+	s.sut.RunDue(context.Background())
+	s.sut.Wait()
+
+	// Assert
+	s.False(s.sut.IsLeader())
+	s.Zero(runs.Load())
+}
+
+func (s *SchedulerTestSuite) TestRunDue_RunClaimedByAnotherInstance_IsSkipped() {
+	// Arrange
+	runs := s.countingJob("purge", "*/5 * * * *", "")
+	s.sut.Elect(context.Background())
+	_, err := s.coordinator.Claim(context.Background(), "purge", time.Date(2026, 10, 15, 10, 5, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.clock.Advance(3 * time.Minute)
+
+	// Act
+	s.sut.RunDue(context.Background())
+	s.sut.Wait()
+
+	// Assert
+	s.Zero(runs.Load())
+	s.Equal(1.0, s.metric("scheduler_job_skipped_total", "purge", "claimed"))
+}
+
+func (s *SchedulerTestSuite) TestRunDue_PreviousRunStillRunning_SkipsTheRun() {
+	// Arrange
+	release := make(chan struct{})
+	var runs atomic.Int64
+	s.Require().NoError(s.sut.Register(scheduler.Job{
+		Name:     "export",
+		Schedule: "*/5 * * * *",
+		Run: func(context.Context) error {
+			runs.Add(1)
+			<-release
+			return nil
+		},
+	}))
+	s.sut.Elect(context.Background())
+	s.clock.Advance(3 * time.Minute)
+	s.sut.RunDue(context.Background())
+	s.clock.Advance(5 * time.Minute)
+
+	// Act
+	s.sut.RunDue(context.Background())
+	close(release)
+	s.sut.Wait()
+
+	// Assert
+	s.Equal(int64(1), runs.Load())
+	s.Equal(1.0, s.metric("scheduler_job_skipped_total", "export", "overlap"))
+}
+
+func (s *SchedulerTestSuite) TestElect_NewLeader_RunsTheMissedJobsOnce() {
+	// Arrange
+	ctx := context.Background()
+	catchUp := s.countingJob("invoices", "0 * * * *", scheduler.MissedRunsRunOnce)
+	skip := s.countingJob("digest", "0 * * * *", scheduler.MissedRunsSkip)
+	fresh := s.countingJob("new", "0 * * * *", scheduler.MissedRunsRunOnce)
+	for _, job := range []string{"invoices", "digest"} {
+		_, err := s.coordinator.Claim(ctx, job, time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC))
+		s.Require().NoError(err)
+	}
+
+	// Act
+	s.sut.Elect(ctx)
+	s.sut.Wait()
+
+	// Assert
+	s.Equal(int64(1), catchUp.Load())
+	s.Zero(skip.Load())
+	s.Zero(fresh.Load())
+	last, _, err := s.coordinator.LastRun(ctx, "invoices")
+	s.Require().NoError(err)
+	s.Equal(time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC), last)
+}
+
+func (s *SchedulerTestSuite) TestElect_LeadError_GivesUpTheLeadership() {
+	// Arrange
+	coordinator := mocks.NewMockCoordinator(s.T())
+	coordinator.EXPECT().Lead(mock.Anything, mock.Anything).Return(true, nil).Once()
+	coordinator.EXPECT().Lead(mock.Anything, mock.Anything).Return(false, errors.New("connection refused")).Once()
+	s.sut = s.newScheduler(coordinator)
+	s.sut.Elect(context.Background())
+
+	// Act
+	s.sut.Elect(context.Background())
+
+	// Assert
+	s.False(s.sut.IsLeader())
+	s.Zero(s.metric("scheduler_leader"))
+}
+
+func (s *SchedulerTestSuite) TestRun_FailingJobs_AreRecordedAsErrors() {
+	// Arrange
+	s.Require().NoError(s.sut.Register(scheduler.Job{
+		Name:     "failing",
+		Schedule: "*/5 * * * *",
+		Run:      func(context.Context) error { return errors.New("boom") },
+	}))
+	s.Require().NoError(s.sut.Register(scheduler.Job{
+		Name:     "panicking",
+		Schedule: "*/5 * * * *",
+		Run:      func(context.Context) error { panic("boom") },
+	}))
+	s.sut.Elect(context.Background())
+	s.clock.Advance(3 * time.Minute)
+
+	// Act
+	s.sut.RunDue(context.Background())
+	s.sut.Wait()
+
+	// Assert
+	s.Equal(1.0, s.metric("scheduler_job_runs_total", "failing", "error"))
+	s.Equal(1.0, s.metric("scheduler_job_runs_total", "panicking", "error"))
+}
+
+func (s *SchedulerTestSuite) TestRun_Timeout_CancelsTheJobContext() {
+	// Arrange
+	done := make(chan error, 1)
+	s.Require().NoError(s.sut.Register(scheduler.Job{
+		Name:     "slow",
+		Schedule: "*/5 * * * *",
+		Timeout:  10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			done <- ctx.Err()
+			return ctx.Err()
+		},
+	}))
+	s.sut.Elect(context.Background())
+	s.clock.Advance(3 * time.Minute)
+
+	// Act
+	s.sut.RunDue(context.Background())
+	s.sut.Wait()
+
+	// Assert
+	s.ErrorIs(<-done, context.DeadlineExceeded)
+}
+
+func (s *SchedulerTestSuite) TestStop_ResignsTheLeadership() {
+	// Arrange
+	coordinator := mocks.NewMockCoordinator(s.T())
+	coordinator.EXPECT().Lead(mock.Anything, mock.Anything).Return(true, nil).Maybe()
+	coordinator.EXPECT().Resign(mock.Anything).Return(nil).Once()
+	s.sut = s.newScheduler(coordinator)
+	s.Require().NoError(s.sut.Start())
+
+	// Act
+	err := s.sut.Stop(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(s.sut.IsLeader())
+	s.ErrorIs(s.sut.Start(), scheduler.ErrSchedulerStarted)
+}
+
+func (s *SchedulerTestSuite) TestRegister_InvalidJobs_ReturnErrors() {
+	// Arrange
+	run := func(context.Context) error { return nil }
+	s.Require().NoError(s.sut.Register(scheduler.Job{Name: "purge", Schedule: "@hourly", Run: run}))
+
+	// Act & Assert
+	s.ErrorIs(s.sut.Register(scheduler.Job{Name: "purge", Schedule: "@hourly", Run: run}), scheduler.ErrDuplicateJob)
+	s.ErrorIs(s.sut.Register(scheduler.Job{Name: "bad", Schedule: "@never", Run: run}), scheduler.ErrInvalidSchedule)
+	s.ErrorIs(s.sut.Register(scheduler.Job{Schedule: "@hourly", Run: run}), scheduler.ErrMissingJobName)
+	s.ErrorIs(s.sut.Register(scheduler.Job{Name: "norun", Schedule: "@hourly"}), scheduler.ErrMissingJobRun)
+	s.ErrorIs(s.sut.Register(scheduler.Job{Name: "policy", Schedule: "@hourly", Run: run, MissedRuns: "all"}),
+		scheduler.ErrInvalidMissedRuns)
+}
+
+// metric returns the value of the counter or gauge with the label values, sorted by label
+// name, or -1 when it is missing.
+func (s *SchedulerTestSuite) metric(name string, labelValues ...string) float64 {
+	families, err := s.registry.Gather()
+	s.Require().NoError(err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			var values []string
+			for _, label := range metric.GetLabel() {
+				values = append(values, label.GetValue())
+			}
+			if slices.Equal(values, labelValues) {
+				if metric.GetCounter() != nil {
+					return metric.GetCounter().GetValue()
+				}
+				return metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return -1
+}
+
+func TestNew(t *testing.T) {
+	t.Run("validates the configuration", func(t *testing.T) {
+		// Arrange
+		log := logger.MustNewWithOptions(logger.WithLevel("fatal"))
+		coordinator := scheduler.NewLocalCoordinator()
+
+		// Act
+		_, errCoordinator := scheduler.New(scheduler.Config{}, nil, log)
+		_, errTimezone := scheduler.New(scheduler.Config{Timezone: "Mars/Olympus"}, coordinator, log)
+		_, errLease := scheduler.New(scheduler.Config{LeaseTTL: time.Second}, coordinator, log)
+
+		// Assert
+		assert.ErrorIs(t, errCoordinator, scheduler.ErrMissingCoordinator)
+		assert.ErrorIs(t, errTimezone, scheduler.ErrInvalidTimezone)
+		require.ErrorIs(t, errLease, scheduler.ErrInvalidLeaseTTL)
+	})
+}
@@ -0,0 +1,91 @@
+package secure
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+
+	// The argon2id defaults follow the OWASP recommendation: 19 MiB, 2 iterations, 1 lane
+	defaultArgon2Memory      = 19 * 1024
+	defaultArgon2Iterations  = 2
+	defaultArgon2Parallelism = 1
+	defaultArgon2SaltLength  = 16
+	defaultArgon2KeyLength   = 32
+	minArgon2SaltLength      = 8
+	minArgon2KeyLength       = 16
+	defaultBcryptCost        = 12
+)
+
+// Config configures the Hasher. Changing the algorithm or its parameters does not
+// invalidate the stored hashes: they still verify, and are rehashed with the new
+// parameters on the next successful Verify.
+type Config struct {
+	// Algorithm hashes the new passwords: argon2id or bcrypt
+	Algorithm string `config:"algorithm"`
+	// Argon2 holds the parameters of argon2id
+	Argon2 Argon2Config `config:"argon2"`
+	// BcryptCost is the log2 of the bcrypt iterations, between 4 and 31
+	BcryptCost int `config:"bcrypt_cost"`
+}
+
+// Argon2Config holds the parameters of argon2id.
+type Argon2Config struct {
+	// Memory is the memory used, in KiB
+	Memory uint32 `config:"memory"`
+	// Iterations is the number of passes over the memory
+	Iterations uint32 `config:"iterations"`
+	// Parallelism is the number of lanes
+	Parallelism uint8 `config:"parallelism"`
+	// SaltLength is the length of the random salt, in bytes
+	SaltLength uint32 `config:"salt_length"`
+	// KeyLength is the length of the derived key, in bytes
+	KeyLength uint32 `config:"key_length"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Algorithm == "" {
+		c.Algorithm = AlgorithmArgon2id
+	}
+	if c.Argon2.Memory == 0 {
+		c.Argon2.Memory = defaultArgon2Memory
+	}
+	if c.Argon2.Iterations == 0 {
+		c.Argon2.Iterations = defaultArgon2Iterations
+	}
+	if c.Argon2.Parallelism == 0 {
+		c.Argon2.Parallelism = defaultArgon2Parallelism
+	}
+	if c.Argon2.SaltLength == 0 {
+		c.Argon2.SaltLength = defaultArgon2SaltLength
+	}
+	if c.Argon2.KeyLength == 0 {
+		c.Argon2.KeyLength = defaultArgon2KeyLength
+	}
+	if c.BcryptCost == 0 {
+		c.BcryptCost = defaultBcryptCost
+	}
+}
+
+// Validate checks the algorithm and its parameters.
+func (c *Config) Validate() error {
+	if c.Algorithm != AlgorithmArgon2id && c.Algorithm != AlgorithmBcrypt {
+		return fmt.Errorf("%w: %s", ErrInvalidAlgorithm, c.Algorithm)
+	}
+	if c.Argon2.SaltLength < minArgon2SaltLength || c.Argon2.KeyLength < minArgon2KeyLength {
+		return fmt.Errorf("%w: salt of at least %d bytes and key of at least %d bytes required",
+			ErrInvalidArgon2Params, minArgon2SaltLength, minArgon2KeyLength)
+	}
+	if c.Argon2.Memory < 8*uint32(c.Argon2.Parallelism) {
+		return fmt.Errorf("%w: memory must be at least 8 KiB per lane", ErrInvalidArgon2Params)
+	}
+	if c.BcryptCost < bcrypt.MinCost || c.BcryptCost > bcrypt.MaxCost {
+		return fmt.Errorf("%w: %d", ErrInvalidBcryptCost, c.BcryptCost)
+	}
+	return nil
+}
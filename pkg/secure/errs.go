@@ -0,0 +1,14 @@
+package secure
+
+import "errors"
+
+var (
+	ErrPasswordMismatch     = errors.New("password does not match")
+	ErrPasswordTooLong      = errors.New("password exceeds 72 bytes, the bcrypt limit")
+	ErrInvalidHash          = errors.New("invalid password hash")
+	ErrUnsupportedAlgorithm = errors.New("unsupported password hash algorithm")
+	ErrInvalidAlgorithm     = errors.New("invalid password hash algorithm (must be 'argon2id' or 'bcrypt')")
+	ErrInvalidArgon2Params  = errors.New("invalid argon2id parameters")
+	ErrInvalidBcryptCost    = errors.New("invalid bcrypt cost (must be between 4 and 31)")
+	ErrInvalidTokenLength   = errors.New("token length must be at least 16 bytes")
+)
@@ -0,0 +1,24 @@
+package secure
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+// Module provides the password Hasher configured from app.secure.
+//
+//	fx.New(
+//	    secure.Module,
+//	    fx.Provide(usecase.NewLoginUseCase), // takes a secure.Hasher
+//	)
+var Module = fx.Module(
+	"secure",
+	config.Provide[Config]("app.secure"),
+	fx.Provide(NewHasherWithConfig),
+)
+
+// NewHasherWithConfig creates the Hasher from the loaded config.
+func NewHasherWithConfig(cfg config.Config[Config]) (Hasher, error) {
+	return NewHasher(cfg.Get())
+}
@@ -0,0 +1,150 @@
+package secure
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Hasher hashes passwords into self-describing strings, carrying the algorithm, its
+// version and parameters, so hashes made with older parameters keep verifying.
+type Hasher interface {
+	// Hash returns the encoded hash of password, e.g.
+	// $argon2id$v=19$m=19456,t=2,p=1$<salt>$<key>.
+	Hash(password string) (string, error)
+	// Verify checks password against the encoded hash. It returns ErrPasswordMismatch
+	// when the password does not match. When the hash was made with another algorithm or
+	// other parameters than the configured ones, it also returns rehashed, the hash of
+	// password with the configured parameters, to be stored in place of encoded.
+	Verify(password, encoded string) (rehashed string, err error)
+}
+
+type hasher struct {
+	cfg Config
+}
+
+// NewHasher creates the Hasher hashing with the algorithm of cfg. It verifies argon2id
+// and bcrypt hashes whatever the configured algorithm.
+//
+//	rehashed, err := hasher.Verify(input.Password, user.PasswordHash)
+//	if errors.Is(err, secure.ErrPasswordMismatch) { ... }
+//	if rehashed != "" {
+//	    user.PasswordHash = rehashed // persist it
+//	}
+func NewHasher(cfg Config) (Hasher, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &hasher{cfg: cfg}, nil
+}
+
+func (h *hasher) Hash(password string) (string, error) {
+	if h.cfg.Algorithm == AlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cfg.BcryptCost)
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", ErrPasswordTooLong
+		}
+		if err != nil {
+			return "", fmt.Errorf("hash password: %w", err)
+		}
+		return string(hash), nil
+	}
+
+	params := h.cfg.Argon2
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return encodeArgon2(params, salt, key), nil
+}
+
+func (h *hasher) Verify(password, encoded string) (string, error) {
+	var current bool
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		params, salt, key, err := decodeArgon2(encoded)
+		if err != nil {
+			return "", err
+		}
+		computed := argon2.IDKey(
+			[]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength,
+		)
+		if subtle.ConstantTimeCompare(key, computed) != 1 {
+			return "", ErrPasswordMismatch
+		}
+		current = h.cfg.Algorithm == AlgorithmArgon2id && params == h.cfg.Argon2
+	case isBcrypt(encoded):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return "", ErrPasswordMismatch
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidHash, err)
+		}
+		cost, _ := bcrypt.Cost([]byte(encoded))
+		current = h.cfg.Algorithm == AlgorithmBcrypt && cost == h.cfg.BcryptCost
+	default:
+		return "", ErrUnsupportedAlgorithm
+	}
+
+	if current {
+		return "", nil
+	}
+	return h.Hash(password)
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+// encodeArgon2 returns the hash in the PHC string format of the reference implementation.
+func encodeArgon2(params Argon2Config, salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func decodeArgon2(encoded string) (Argon2Config, []byte, []byte, error) {
+	var params Argon2Config
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: argon2 version %d", ErrUnsupportedAlgorithm, version)
+	}
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+	if params.Iterations == 0 || params.Parallelism == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %w", ErrInvalidHash, err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+	params.SaltLength = uint32(len(salt)) //nolint:gosec // bounded by the encoded string
+	params.KeyLength = uint32(len(key))   //nolint:gosec // bounded by the encoded string
+	return params, salt, key, nil
+}
@@ -0,0 +1,173 @@
+package secure_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/cristiano-pacheco/bricks/pkg/secure"
+)
+
+// fastArgon2 keeps the tests fast; the defaults cost about 20ms per hash
+var fastArgon2 = secure.Argon2Config{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func newHasher(t *testing.T, cfg secure.Config) secure.Hasher {
+	t.Helper()
+	hasher, err := secure.NewHasher(cfg)
+	require.NoError(t, err)
+	return hasher
+}
+
+func TestHasher_Argon2id(t *testing.T) {
+	t.Run("hashes with a random salt in the PHC format", func(t *testing.T) {
+		// Arrange
+		sut := newHasher(t, secure.Config{Argon2: fastArgon2})
+
+		// Act
+		first, err := sut.Hash("correct horse")
+		require.NoError(t, err)
+		second, err := sut.Hash("correct horse")
+		require.NoError(t, err)
+
+		// Assert
+		assert.True(t, strings.HasPrefix(first, "$argon2id$v=19$m=64,t=1,p=1$"))
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("verifies the password", func(t *testing.T) {
+		// Arrange
+		sut := newHasher(t, secure.Config{Argon2: fastArgon2})
+		encoded, err := sut.Hash("correct horse")
+		require.NoError(t, err)
+
+		// Act
+		rehashed, verifyErr := sut.Verify("correct horse", encoded)
+		_, mismatchErr := sut.Verify("battery staple", encoded)
+
+		// Assert
+		require.NoError(t, verifyErr)
+		assert.Empty(t, rehashed)
+		require.ErrorIs(t, mismatchErr, secure.ErrPasswordMismatch)
+	})
+
+	t.Run("rehashes a hash made with other parameters", func(t *testing.T) {
+		// Arrange
+		old := newHasher(t, secure.Config{Argon2: fastArgon2})
+		encoded, err := old.Hash("correct horse")
+		require.NoError(t, err)
+		stronger := fastArgon2
+		stronger.Iterations = 2
+		sut := newHasher(t, secure.Config{Argon2: stronger})
+
+		// Act
+		rehashed, verifyErr := sut.Verify("correct horse", encoded)
+
+		// Assert
+		require.NoError(t, verifyErr)
+		assert.True(t, strings.HasPrefix(rehashed, "$argon2id$v=19$m=64,t=2,p=1$"))
+		again, err := sut.Verify("correct horse", rehashed)
+		require.NoError(t, err)
+		assert.Empty(t, again)
+	})
+
+	t.Run("rejects invalid hashes", func(t *testing.T) {
+		// Arrange
+		sut := newHasher(t, secure.Config{Argon2: fastArgon2})
+
+		for _, encoded := range []string{
+			"$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+			"$argon2id$v=19$m=64,t=x,p=1$c2FsdHNhbHQ$a2V5",
+			"$argon2id$v=19$m=64,t=1,p=1$!!$a2V5",
+		} {
+			// Act
+			_, err := sut.Verify("correct horse", encoded)
+
+			// Assert
+			require.ErrorIs(t, err, secure.ErrInvalidHash, encoded)
+		}
+	})
+
+	t.Run("rejects unknown algorithms", func(t *testing.T) {
+		// Arrange
+		sut := newHasher(t, secure.Config{Argon2: fastArgon2})
+
+		// Act
+		_, err := sut.Verify("correct horse", "$argon2i$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5")
+
+		// Assert
+		require.ErrorIs(t, err, secure.ErrUnsupportedAlgorithm)
+	})
+}
+
+func TestHasher_Bcrypt(t *testing.T) {
+	t.Run("hashes and verifies with bcrypt", func(t *testing.T) {
+		// Arrange
+		sut := newHasher(t, secure.Config{Algorithm: secure.AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+
+		// Act
+		encoded, err := sut.Hash("correct horse")
+		require.NoError(t, err)
+		rehashed, verifyErr := sut.Verify("correct horse", encoded)
+		_, mismatchErr := sut.Verify("battery staple", encoded)
+
+		// Assert
+		assert.True(t, strings.HasPrefix(encoded, "$2a$04$"))
+		require.NoError(t, verifyErr)
+		assert.Empty(t, rehashed)
+		require.ErrorIs(t, mismatchErr, secure.ErrPasswordMismatch)
+	})
+
+	t.Run("rehashes bcrypt hashes when migrating to argon2id", func(t *testing.T) {
+		// Arrange
+		legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+		require.NoError(t, err)
+		sut := newHasher(t, secure.Config{Argon2: fastArgon2})
+
+		// Act
+		rehashed, verifyErr := sut.Verify("correct horse", string(legacy))
+
+		// Assert
+		require.NoError(t, verifyErr)
+		assert.True(t, strings.HasPrefix(rehashed, "$argon2id$"))
+	})
+
+	t.Run("rejects passwords longer than 72 bytes", func(t *testing.T) {
+		// Arrange
+		sut := newHasher(t, secure.Config{Algorithm: secure.AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+
+		// Act
+		_, err := sut.Hash(strings.Repeat("a", 73))
+
+		// Assert
+		require.ErrorIs(t, err, secure.ErrPasswordTooLong)
+	})
+}
+
+func TestNewHasher(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  secure.Config
+		want error
+	}{
+		{"unknown algorithm", secure.Config{Algorithm: "md5"}, secure.ErrInvalidAlgorithm},
+		{"short salt", secure.Config{Argon2: secure.Argon2Config{SaltLength: 4}}, secure.ErrInvalidArgon2Params},
+		{
+			"little memory",
+			secure.Config{Argon2: secure.Argon2Config{Memory: 8, Parallelism: 2}},
+			secure.ErrInvalidArgon2Params,
+		},
+		{"bcrypt cost", secure.Config{BcryptCost: 40}, secure.ErrInvalidBcryptCost},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			// Act
+			_, err := secure.NewHasher(tt.cfg)
+
+			// Assert
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
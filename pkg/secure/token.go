@@ -0,0 +1,76 @@
+package secure
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+)
+
+const (
+	// DefaultTokenLength is the number of random bytes of the tokens, 256 bits
+	DefaultTokenLength = 32
+	minTokenLength     = 16
+	// totpSecretLength is the length of the TOTP secrets, 160 bits as RFC 4226 recommends
+	totpSecretLength = 20
+)
+
+// Equal reports whether a and b are equal in constant time, e.g. to compare a token or
+// an API key received in a request with the expected one. Both are hashed first, so the
+// time does not reveal the length of the expected value either.
+func Equal(a, b string) bool {
+	hashA := sha256.Sum256([]byte(a))
+	hashB := sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(hashA[:], hashB[:]) == 1
+}
+
+// NewToken returns an opaque token of length random bytes (at least 16), encoded in
+// unpadded base64url so it fits in URLs and headers, e.g. for password reset links,
+// email confirmations and API keys. Store its HashToken, not the token itself.
+func NewToken(length int) (string, error) {
+	if length < minTokenLength {
+		return "", fmt.Errorf("%w: %d", ErrInvalidTokenLength, length)
+	}
+	token := make([]byte, length)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// HashToken returns the hex SHA-256 of token, to store and look up the tokens made by
+// NewToken without keeping them readable. Tokens are random, so they need no salt or
+// slow hash, unlike passwords.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// NewTOTPSecret returns a random TOTP secret of 160 bits, encoded in unpadded base32 as
+// the authenticator apps expect.
+func NewTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generate totp secret: %w", err)
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret), nil
+}
+
+// TOTPURI returns the otpauth:// URI of secret for account, shown as a QR code to add
+// the account to an authenticator app with the default parameters (SHA1, 6 digits, 30s).
+func TOTPURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return uri.String()
+}
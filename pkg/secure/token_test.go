@@ -0,0 +1,78 @@
+package secure_test
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/secure"
+)
+
+func TestEqual(t *testing.T) {
+	t.Run("compares the values", func(t *testing.T) {
+		// Act & Assert
+		assert.True(t, secure.Equal("s3cr3t", "s3cr3t"))
+		assert.False(t, secure.Equal("s3cr3t", "s3cr3T"))
+		assert.False(t, secure.Equal("s3cr3t", "s3cr3t-longer"))
+	})
+}
+
+func TestNewToken(t *testing.T) {
+	t.Run("returns random base64url tokens", func(t *testing.T) {
+		// Act
+		first, err := secure.NewToken(secure.DefaultTokenLength)
+		require.NoError(t, err)
+		second, err := secure.NewToken(secure.DefaultTokenLength)
+		require.NoError(t, err)
+
+		// Assert
+		decoded, err := base64.RawURLEncoding.DecodeString(first)
+		require.NoError(t, err)
+		assert.Len(t, decoded, secure.DefaultTokenLength)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("rejects short tokens", func(t *testing.T) {
+		// Act
+		_, err := secure.NewToken(8)
+
+		// Assert
+		require.ErrorIs(t, err, secure.ErrInvalidTokenLength)
+	})
+}
+
+func TestHashToken(t *testing.T) {
+	t.Run("returns the hex SHA-256 of the token", func(t *testing.T) {
+		// Act
+		hash := secure.HashToken("abc")
+
+		// Assert
+		assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", hash)
+	})
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	t.Run("returns a 160-bit base32 secret", func(t *testing.T) {
+		// Act
+		secret, err := secure.NewTOTPSecret()
+
+		// Assert
+		require.NoError(t, err)
+		decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+		require.NoError(t, err)
+		assert.Len(t, decoded, 20)
+	})
+}
+
+func TestTOTPURI(t *testing.T) {
+	t.Run("returns the otpauth URI of the account", func(t *testing.T) {
+		// Act
+		uri := secure.TOTPURI("Acme Shop", "ana@example.com", "JBSWY3DPEHPK3PXP")
+
+		// Assert
+		assert.Equal(t, "otpauth://totp/Acme%20Shop:ana@example.com?issuer=Acme+Shop&secret=JBSWY3DPEHPK3PXP", uri)
+	})
+}
@@ -0,0 +1,99 @@
+package session
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// ExpirationSliding extends the session lifetime by the TTL on every request
+	ExpirationSliding = "sliding"
+	// ExpirationAbsolute ends the session TTL after its creation, whatever the activity
+	ExpirationAbsolute = "absolute"
+
+	defaultCookieName = "session_id"
+	defaultCookiePath = "/"
+	defaultSameSite   = "lax"
+	defaultTTL        = 24 * time.Hour
+	defaultKeyPrefix  = "session:"
+	defaultCSRFHeader = "X-CSRF-Token"
+	defaultCSRFField  = "csrf_token"
+)
+
+// Config configures the sessions.
+type Config struct {
+	// CookieName is the name of the cookie carrying the session ID
+	CookieName string `config:"cookie_name"`
+	// CookieDomain restricts the cookie to a domain; empty uses the request host
+	CookieDomain string `config:"cookie_domain"`
+	// CookiePath restricts the cookie to a path
+	CookiePath string `config:"cookie_path"`
+	// Insecure allows the cookie over plain HTTP, e.g. for local development
+	Insecure bool `config:"insecure"`
+	// SameSite is the SameSite attribute of the cookie: lax, strict or none
+	SameSite string `config:"same_site"`
+	// TTL is the lifetime of a session
+	TTL time.Duration `config:"ttl"`
+	// Expiration is sliding (TTL after the last request) or absolute (TTL after creation)
+	Expiration string `config:"expiration"`
+	// KeyPrefix is prepended to the session ID in the store, after the redis client namespace
+	KeyPrefix string `config:"key_prefix"`
+	// CSRFHeader is the request header carrying the CSRF token
+	CSRFHeader string `config:"csrf_header"`
+	// CSRFField is the form field carrying the CSRF token when the header is missing
+	CSRFField string `config:"csrf_field"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.CookieName == "" {
+		c.CookieName = defaultCookieName
+	}
+	if c.CookiePath == "" {
+		c.CookiePath = defaultCookiePath
+	}
+	if c.SameSite == "" {
+		c.SameSite = defaultSameSite
+	}
+	if c.TTL <= 0 {
+		c.TTL = defaultTTL
+	}
+	if c.Expiration == "" {
+		c.Expiration = ExpirationSliding
+	}
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = defaultKeyPrefix
+	}
+	if c.CSRFHeader == "" {
+		c.CSRFHeader = defaultCSRFHeader
+	}
+	if c.CSRFField == "" {
+		c.CSRFField = defaultCSRFField
+	}
+}
+
+// Validate checks the expiration mode and the SameSite attribute.
+func (c *Config) Validate() error {
+	if c.Expiration != ExpirationSliding && c.Expiration != ExpirationAbsolute {
+		return ErrInvalidExpiration
+	}
+	if _, ok := sameSiteModes[strings.ToLower(c.SameSite)]; !ok {
+		return ErrInvalidSameSite
+	}
+	if c.sameSite() == http.SameSiteNoneMode && c.Insecure {
+		// Browsers reject SameSite=None cookies without the Secure attribute
+		return ErrInvalidSameSite
+	}
+	return nil
+}
+
+var sameSiteModes = map[string]http.SameSite{
+	"lax":    http.SameSiteLaxMode,
+	"strict": http.SameSiteStrictMode,
+	"none":   http.SameSiteNoneMode,
+}
+
+func (c *Config) sameSite() http.SameSite {
+	return sameSiteModes[strings.ToLower(c.SameSite)]
+}
@@ -0,0 +1,41 @@
+package session
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// CSRF rejects the unsafe requests (all but GET, HEAD, OPTIONS and TRACE) that do not
+// send the CSRF token of their session, in the CSRFHeader header or the CSRFField form
+// field, with ErrInvalidCSRFToken. It must run after Middleware.
+//
+//	router.Use(manager.Middleware, manager.CSRF)
+func (m *Manager) CSRF(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		s, ok := FromContext(r.Context())
+		if !ok {
+			m.options.errorHandler(w, r, ErrNoSession)
+			return
+		}
+
+		s.mu.Lock()
+		expected := s.csrfToken
+		s.mu.Unlock()
+
+		token := r.Header.Get(m.cfg.CSRFHeader)
+		if token == "" {
+			token = r.PostFormValue(m.cfg.CSRFField)
+		}
+		if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			m.options.errorHandler(w, r, ErrInvalidCSRFToken)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,94 @@
+package session_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/session"
+)
+
+func TestManager_CSRF(t *testing.T) {
+	manager, err := session.NewManager(newMemoryStore(), session.Config{})
+	require.NoError(t, err)
+
+	// serve sends req through the session and CSRF middlewares, with a session whose token is returned
+	serve := func(req *http.Request, token func(string) string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		protected := manager.CSRF(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		handler := manager.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			expected, errToken := session.MustFromContext(r.Context()).CSRFToken()
+			require.NoError(t, errToken)
+			if value := token(expected); value != "" {
+				r.Header.Set("X-CSRF-Token", value)
+			}
+			protected.ServeHTTP(w, r)
+		}))
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("lets safe requests through", func(t *testing.T) {
+		// Act
+		rec := serve(httptest.NewRequest(http.MethodGet, "/", nil), func(string) string { return "" })
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("rejects unsafe requests without the token", func(t *testing.T) {
+		// Act
+		rec := serve(httptest.NewRequest(http.MethodPost, "/", nil), func(string) string { return "" })
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("rejects unsafe requests with another token", func(t *testing.T) {
+		// Act
+		rec := serve(httptest.NewRequest(http.MethodDelete, "/", nil), func(string) string { return "forged" })
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("accepts the token in the header", func(t *testing.T) {
+		// Act
+		rec := serve(httptest.NewRequest(http.MethodPost, "/", nil), func(expected string) string { return expected })
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("accepts the token in the form field", func(t *testing.T) {
+		// Arrange
+		var formToken string
+		handler := manager.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			formToken, _ = session.MustFromContext(r.Context()).CSRFToken()
+			w.WriteHeader(http.StatusOK)
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		cookie := rec.Result().Cookies()[0]
+		form := url.Values{"csrf_token": {formToken}}
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(cookie)
+		rec = httptest.NewRecorder()
+
+		// Act
+		manager.Middleware(manager.CSRF(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))).ServeHTTP(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+}
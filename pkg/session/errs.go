@@ -0,0 +1,20 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+var (
+	ErrInvalidExpiration = errors.New("invalid session expiration (must be 'sliding' or 'absolute')")
+	ErrInvalidSameSite   = errors.New("invalid session cookie SameSite (must be 'lax', 'strict' or secure 'none')")
+	ErrNoSession         = errors.New("no session in context, is the session middleware installed?")
+	ErrDecodeValue       = errors.New("failed to decode session value")
+	ErrEncodeValue       = errors.New("failed to encode session value")
+	ErrSessionCorrupted  = errors.New("stored session cannot be decoded")
+
+	// ErrInvalidCSRFToken is returned to unsafe requests without the CSRF token of their session
+	ErrInvalidCSRFToken = errs.New("INVALID_CSRF_TOKEN", "Invalid CSRF token", http.StatusForbidden, nil)
+)
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// Module provides the session Manager, storing the sessions in Redis.
+// It loads the config from "app.session" and requires redis.ClientModule; the middleware
+// errors are written with the response.ErrorHandler when one is provided.
+//
+//	fx.New(
+//	    redis.ClientModule,
+//	    session.Module,
+//	    fx.Invoke(func(server *chi.Server, manager *session.Manager) {
+//	        server.Router().Use(manager.Middleware, manager.CSRF)
+//	    }),
+//	)
+var Module = fx.Module(
+	"session",
+	config.Provide[Config]("app.session"),
+	fx.Provide(
+		fx.Annotate(NewRedisStoreWithConfig, fx.As(new(Store))),
+		NewManagerWithParams,
+	),
+)
+
+// NewRedisStoreWithConfig creates the RedisStore with the key prefix of the config.
+func NewRedisStoreWithConfig(client *redis.Client, cfg config.Config[Config]) *RedisStore {
+	sessionConfig := cfg.Get()
+	sessionConfig.SetDefaults()
+	return NewRedisStore(client, sessionConfig.KeyPrefix)
+}
+
+// ManagerParams for dependency injection
+type ManagerParams struct {
+	fx.In
+
+	Store        Store
+	Config       config.Config[Config]
+	ErrorHandler response.ErrorHandler `optional:"true"`
+}
+
+// NewManagerWithParams creates the Manager from the loaded config.
+func NewManagerWithParams(params ManagerParams) (*Manager, error) {
+	return NewManager(params.Store, params.Config.Get(), WithErrorHandler(params.ErrorHandler))
+}
@@ -0,0 +1,202 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Manager loads the session of each request and saves it with the response.
+type Manager struct {
+	store   Store
+	cfg     Config
+	options options
+}
+
+// NewManager creates a Manager storing the sessions in store.
+func NewManager(store Store, cfg Config, opts ...Option) (*Manager, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	managerOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&managerOptions)
+	}
+	return &Manager{store: store, cfg: cfg, options: managerOptions}, nil
+}
+
+// Middleware loads the session of the request cookie, or starts a new one, and makes
+// it available with FromContext. The session is saved, and its cookie set, right
+// before the response header is written. New sessions are only stored once modified.
+//
+//	router.Use(manager.Middleware)
+func (m *Manager) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, err := m.Load(r.Context(), r)
+		if err != nil {
+			m.options.errorHandler(w, r, err)
+			return
+		}
+
+		sw := &sessionWriter{ResponseWriter: w, commit: func() error {
+			return m.Save(r.Context(), w, s)
+		}}
+		next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), contextKey{}, s)))
+
+		// Handlers that write nothing still need the session saved
+		if err = sw.commitOnce(); err != nil {
+			m.options.errorHandler(w, r, err)
+		}
+	})
+}
+
+// Load returns the session of the request cookie, or a new session when the cookie is
+// missing or its session has expired.
+func (m *Manager) Load(ctx context.Context, r *http.Request) (*Session, error) {
+	cookie, err := r.Cookie(m.cfg.CookieName)
+	if err != nil || cookie.Value == "" {
+		return newSession()
+	}
+
+	var touch time.Duration
+	if m.cfg.Expiration == ExpirationSliding {
+		touch = m.cfg.TTL
+	}
+	data, found, err := m.store.Load(ctx, cookie.Value, touch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if !found {
+		return newSession()
+	}
+
+	s, err := decodeSession(cookie.Value, data)
+	if errors.Is(err, ErrSessionCorrupted) {
+		// An unreadable session is dropped rather than failing every request of the client
+		return newSession()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m.cfg.Expiration == ExpirationAbsolute && m.remaining(s) <= 0 {
+		return newSession()
+	}
+	return s, nil
+}
+
+// Save stores the changes of the session and sets its cookie. It is called by the
+// Middleware; call it directly when loading sessions with Load.
+func (m *Manager) Save(ctx context.Context, w http.ResponseWriter, s *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.destroyed {
+		for _, id := range []string{s.id, s.previousID} {
+			if id == "" || (id == s.id && !s.stored) {
+				continue
+			}
+			if err := m.store.Delete(ctx, id); err != nil {
+				return fmt.Errorf("failed to delete session: %w", err)
+			}
+		}
+		http.SetCookie(w, m.cookie("", -1))
+		return nil
+	}
+
+	if !s.modified {
+		if s.stored && m.cfg.Expiration == ExpirationSliding {
+			// The store was touched on load, the cookie follows
+			http.SetCookie(w, m.cookie(s.id, m.cfg.TTL))
+		}
+		return nil
+	}
+
+	ttl := m.cfg.TTL
+	if m.cfg.Expiration == ExpirationAbsolute {
+		ttl = m.remaining(s)
+	}
+	data, err := s.encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err = m.store.Save(ctx, s.id, data, ttl); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	if s.previousID != "" {
+		if err = m.store.Delete(ctx, s.previousID); err != nil {
+			return fmt.Errorf("failed to delete renewed session: %w", err)
+		}
+		s.previousID = ""
+	}
+	s.stored = true
+	s.modified = false
+
+	http.SetCookie(w, m.cookie(s.id, ttl))
+	return nil
+}
+
+func (m *Manager) remaining(s *Session) time.Duration {
+	return time.Until(s.createdAt.Add(m.cfg.TTL))
+}
+
+// cookie returns the session cookie; a negative maxAge expires it.
+func (m *Manager) cookie(id string, maxAge time.Duration) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     m.cfg.CookieName,
+		Value:    id,
+		Path:     m.cfg.CookiePath,
+		Domain:   m.cfg.CookieDomain,
+		Secure:   !m.cfg.Insecure,
+		HttpOnly: true,
+		SameSite: m.cfg.sameSite(),
+	}
+	if maxAge < 0 {
+		cookie.MaxAge = -1
+		cookie.Expires = time.Unix(0, 0)
+		return cookie
+	}
+	cookie.MaxAge = int(maxAge.Seconds())
+	cookie.Expires = time.Now().Add(maxAge)
+	return cookie
+}
+
+// sessionWriter saves the session before the response header is written, since the
+// cookie cannot be set afterwards.
+type sessionWriter struct {
+	http.ResponseWriter
+	commit func() error
+	once   sync.Once
+	err    error
+}
+
+func (w *sessionWriter) commitOnce() error {
+	w.once.Do(func() {
+		w.err = w.commit()
+	})
+	return w.err
+}
+
+func (w *sessionWriter) WriteHeader(status int) {
+	if w.commitOnce() != nil {
+		// The middleware writes the error response once the handler returns
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *sessionWriter) Write(b []byte) (int, error) {
+	if err := w.commitOnce(); err != nil {
+		return 0, err
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush.
+func (w *sessionWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
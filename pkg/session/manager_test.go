@@ -0,0 +1,290 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/session"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+)
+
+// memoryStore is a Store keeping the sessions in memory, recording the touches.
+type memoryStore struct {
+	mu      sync.Mutex
+	data    map[string][]byte
+	ttls    map[string]time.Duration
+	touches []time.Duration
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{data: make(map[string][]byte), ttls: make(map[string]time.Duration)}
+}
+
+func (s *memoryStore) Load(_ context.Context, id string, touch time.Duration) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.data[id]
+	if ok && touch > 0 {
+		s.touches = append(s.touches, touch)
+		s.ttls[id] = touch
+	}
+	return data, ok, nil
+}
+
+func (s *memoryStore) Save(_ context.Context, id string, data []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[id] = data
+	s.ttls[id] = ttl
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, id)
+	delete(s.ttls, id)
+	return nil
+}
+
+func newTestSession(t *testing.T) *session.Session {
+	t.Helper()
+
+	manager, err := session.NewManager(newMemoryStore(), session.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := manager.Load(context.Background(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+type ManagerTestSuite struct {
+	suite.Suite
+	store *memoryStore
+	sut   *session.Manager
+}
+
+func TestManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(ManagerTestSuite))
+}
+
+func (s *ManagerTestSuite) SetupTest() {
+	s.store = newMemoryStore()
+	var err error
+	s.sut, err = session.NewManager(s.store, session.Config{TTL: time.Hour})
+	s.Require().NoError(err)
+}
+
+// serve runs handler behind the session middleware, sending cookie when not nil.
+func (s *ManagerTestSuite) serve(handler http.HandlerFunc, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	s.sut.Middleware(handler).ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *ManagerTestSuite) sessionCookie(rec *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == "session_id" {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func (s *ManagerTestSuite) login() *http.Cookie {
+	rec := s.serve(func(_ http.ResponseWriter, r *http.Request) {
+		s.Require().NoError(session.MustFromContext(r.Context()).Set("user_id", 42))
+	}, nil)
+	cookie := s.sessionCookie(rec)
+	s.Require().NotNil(cookie)
+	return cookie
+}
+
+func (s *ManagerTestSuite) TestMiddleware_UnmodifiedNewSession_IsNotStored() {
+	// Act
+	rec := s.serve(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}, nil)
+
+	// Assert
+	s.Equal(http.StatusNoContent, rec.Code)
+	s.Nil(s.sessionCookie(rec))
+	s.Empty(s.store.data)
+}
+
+func (s *ManagerTestSuite) TestMiddleware_ModifiedSession_IsStoredWithASecureCookie() {
+	// Act
+	rec := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().NoError(session.MustFromContext(r.Context()).Set("user_id", 42))
+		_, _ = w.Write([]byte("ok"))
+	}, nil)
+
+	// Assert
+	s.Equal("ok", rec.Body.String())
+	cookie := s.sessionCookie(rec)
+	s.Require().NotNil(cookie)
+	s.True(cookie.Secure)
+	s.True(cookie.HttpOnly)
+	s.Equal(http.SameSiteLaxMode, cookie.SameSite)
+	s.Equal(3600, cookie.MaxAge)
+	s.Contains(s.store.data, cookie.Value)
+	s.Equal(time.Hour, s.store.ttls[cookie.Value])
+}
+
+func (s *ManagerTestSuite) TestMiddleware_StoredSession_IsLoadedAndTouched() {
+	// Arrange
+	cookie := s.login()
+	var userID int64
+
+	// Act
+	rec := s.serve(func(_ http.ResponseWriter, r *http.Request) {
+		userID, _, _ = session.Get[int64](session.MustFromContext(r.Context()), "user_id")
+	}, cookie)
+
+	// Assert
+	s.Equal(int64(42), userID)
+	s.Equal([]time.Duration{time.Hour}, s.store.touches)
+	refreshed := s.sessionCookie(rec)
+	s.Require().NotNil(refreshed)
+	s.Equal(cookie.Value, refreshed.Value)
+}
+
+func (s *ManagerTestSuite) TestMiddleware_AbsoluteExpiration_IsNotTouched() {
+	// Arrange
+	var err error
+	s.sut, err = session.NewManager(s.store, session.Config{TTL: time.Hour, Expiration: session.ExpirationAbsolute})
+	s.Require().NoError(err)
+	cookie := s.login()
+
+	// Act
+	rec := s.serve(func(http.ResponseWriter, *http.Request) {}, cookie)
+
+	// Assert
+	s.Empty(s.store.touches)
+	s.Nil(s.sessionCookie(rec))
+}
+
+func (s *ManagerTestSuite) TestMiddleware_UnknownSession_StartsANewOne() {
+	// Arrange
+	var isNew bool
+	var id string
+
+	// Act
+	s.serve(func(_ http.ResponseWriter, r *http.Request) {
+		current := session.MustFromContext(r.Context())
+		isNew, id = current.IsNew(), current.ID()
+	}, &http.Cookie{Name: "session_id", Value: "forged"})
+
+	// Assert
+	s.True(isNew)
+	s.NotEqual("forged", id)
+}
+
+func (s *ManagerTestSuite) TestMiddleware_CorruptedSession_StartsANewOne() {
+	// Arrange
+	s.store.data["corrupted"] = []byte("{")
+	var isNew bool
+
+	// Act
+	rec := s.serve(func(_ http.ResponseWriter, r *http.Request) {
+		isNew = session.MustFromContext(r.Context()).IsNew()
+	}, &http.Cookie{Name: "session_id", Value: "corrupted"})
+
+	// Assert
+	s.Equal(http.StatusOK, rec.Code)
+	s.True(isNew)
+}
+
+func (s *ManagerTestSuite) TestMiddleware_DestroyedSession_IsDeletedAndTheCookieExpired() {
+	// Arrange
+	cookie := s.login()
+
+	// Act
+	rec := s.serve(func(_ http.ResponseWriter, r *http.Request) {
+		session.MustFromContext(r.Context()).Destroy()
+	}, cookie)
+
+	// Assert
+	s.NotContains(s.store.data, cookie.Value)
+	expired := s.sessionCookie(rec)
+	s.Require().NotNil(expired)
+	s.Equal(-1, expired.MaxAge)
+}
+
+func (s *ManagerTestSuite) TestMiddleware_RenewedSession_ReplacesTheStoredOne() {
+	// Arrange
+	cookie := s.login()
+
+	// Act
+	rec := s.serve(func(_ http.ResponseWriter, r *http.Request) {
+		s.Require().NoError(session.MustFromContext(r.Context()).Renew())
+	}, cookie)
+
+	// Assert
+	renewed := s.sessionCookie(rec)
+	s.Require().NotNil(renewed)
+	s.NotEqual(cookie.Value, renewed.Value)
+	s.NotContains(s.store.data, cookie.Value)
+	s.Contains(s.store.data, renewed.Value)
+}
+
+func (s *ManagerTestSuite) TestMiddleware_StoreFails_RespondsInternalServerError() {
+	// Arrange
+	store := mocks.NewMockStore(s.T())
+	store.EXPECT().Load(mock.Anything, "abc", time.Hour).Return(nil, false, errors.New("connection refused"))
+	var err error
+	s.sut, err = session.NewManager(store, session.Config{TTL: time.Hour})
+	s.Require().NoError(err)
+	var called bool
+
+	// Act
+	rec := s.serve(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}, &http.Cookie{Name: "session_id", Value: "abc"})
+
+	// Assert
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.False(called)
+}
+
+func (s *ManagerTestSuite) TestMiddleware_SaveFails_RespondsInternalServerError() {
+	// Arrange
+	store := mocks.NewMockStore(s.T())
+	store.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything, time.Hour).Return(errors.New("connection refused"))
+	var err error
+	s.sut, err = session.NewManager(store, session.Config{TTL: time.Hour})
+	s.Require().NoError(err)
+
+	// Act
+	rec := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().NoError(session.MustFromContext(r.Context()).Set("user_id", 42))
+		w.WriteHeader(http.StatusCreated)
+	}, nil)
+
+	// Assert
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Nil(s.sessionCookie(rec))
+}
+
+func (s *ManagerTestSuite) TestNewManager_InvalidConfig_ReturnsError() {
+	// Act
+	_, err := session.NewManager(s.store, session.Config{Expiration: "forever"})
+
+	// Assert
+	s.Require().ErrorIs(err, session.ErrInvalidExpiration)
+}
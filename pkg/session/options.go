@@ -0,0 +1,31 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/response"
+)
+
+type options struct {
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Option configures the Manager created by NewManager.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{errorHandler: response.WriteError}
+}
+
+// WithErrorHandler writes the middleware errors, e.g. a store failure or an invalid CSRF
+// token, with the response.ErrorHandler. Defaults to response.WriteError, the errs
+// envelope with the status of the error.
+func WithErrorHandler(handler response.ErrorHandler) Option {
+	return func(o *options) {
+		if handler != nil {
+			o.errorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+				handler.ErrorCtx(r.Context(), w, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// idBytes is the entropy of session IDs and CSRF tokens: 256 bits
+const idBytes = 32
+
+type contextKey struct{}
+
+// Session is the session of a request, loaded by the Manager middleware.
+// Values are stored as JSON; read them with Get. Changes are saved when the
+// response is written.
+type Session struct {
+	mu        sync.Mutex
+	id        string
+	values    map[string]json.RawMessage
+	csrfToken string
+	createdAt time.Time
+	stored    bool
+	modified  bool
+	destroyed bool
+	// previousID is deleted from the store on save after Renew
+	previousID string
+}
+
+// record is the stored form of a Session.
+type record struct {
+	Values    map[string]json.RawMessage `json:"values"`
+	CSRFToken string                     `json:"csrf_token,omitempty"`
+	CreatedAt time.Time                  `json:"created_at"`
+}
+
+func newSession() (*Session, error) {
+	id, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	return &Session{id: id, values: make(map[string]json.RawMessage), createdAt: time.Now()}, nil
+}
+
+func decodeSession(id string, data []byte) (*Session, error) {
+	var stored record
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSessionCorrupted, err)
+	}
+	if stored.Values == nil {
+		stored.Values = make(map[string]json.RawMessage)
+	}
+	return &Session{
+		id:        id,
+		values:    stored.Values,
+		csrfToken: stored.CSRFToken,
+		createdAt: stored.CreatedAt,
+		stored:    true,
+	}, nil
+}
+
+func (s *Session) encode() ([]byte, error) {
+	return json.Marshal(record{Values: s.values, CSRFToken: s.csrfToken, CreatedAt: s.createdAt})
+}
+
+// FromContext returns the session loaded by the Manager middleware.
+func FromContext(ctx context.Context) (*Session, bool) {
+	s, ok := ctx.Value(contextKey{}).(*Session)
+	return s, ok
+}
+
+// MustFromContext returns the session loaded by the Manager middleware, or panics
+// when the middleware is not installed.
+func MustFromContext(ctx context.Context) *Session {
+	s, ok := FromContext(ctx)
+	if !ok {
+		panic(ErrNoSession)
+	}
+	return s
+}
+
+// ID returns the session ID carried by the cookie.
+func (s *Session) ID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id
+}
+
+// CreatedAt returns the creation time of the session.
+func (s *Session) CreatedAt() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createdAt
+}
+
+// IsNew reports whether the session is not stored yet, e.g. on the first visit.
+func (s *Session) IsNew() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.stored
+}
+
+// Set stores the JSON encoding of value under key.
+func (s *Session) Set(key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrEncodeValue, key, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = raw
+	s.modified = true
+	return nil
+}
+
+// Delete removes key from the session.
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.values[key]; ok {
+		delete(s.values, key)
+		s.modified = true
+	}
+}
+
+// Has reports whether the session has a value under key.
+func (s *Session) Has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.values[key]
+	return ok
+}
+
+// Get decodes the value stored under key into T, and reports whether the key exists.
+//
+//	userID, ok, err := session.Get[int64](s, "user_id")
+func Get[T any](s *Session, key string) (T, bool, error) {
+	var value T
+	s.mu.Lock()
+	raw, ok := s.values[key]
+	s.mu.Unlock()
+	if !ok {
+		return value, false, nil
+	}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return value, true, fmt.Errorf("%w: %s: %w", ErrDecodeValue, key, err)
+	}
+	return value, true, nil
+}
+
+// Renew gives the session a new ID and CSRF token, keeping its values. Call it when
+// the privileges change, e.g. on login, so a session ID set by an attacker before the
+// login is worthless (session fixation).
+func (s *Session) Renew() error {
+	id, err := randomToken()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stored && s.previousID == "" {
+		s.previousID = s.id
+	}
+	s.id = id
+	s.csrfToken = ""
+	s.modified = true
+	return nil
+}
+
+// Destroy deletes the session from the store and expires the cookie, e.g. on logout.
+func (s *Session) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = make(map[string]json.RawMessage)
+	s.destroyed = true
+}
+
+// CSRFToken returns the CSRF token of the session, creating it on first use.
+// Render it in forms or expose it to scripts; unsafe requests must send it back.
+func (s *Session) CSRFToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.csrfToken == "" {
+		token, err := randomToken()
+		if err != nil {
+			return "", err
+		}
+		s.csrfToken = token
+		s.modified = true
+	}
+	return s.csrfToken, nil
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, idBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
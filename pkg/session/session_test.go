@@ -0,0 +1,88 @@
+package session_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/session"
+)
+
+type cart struct {
+	Items []string `json:"items"`
+}
+
+func TestGet(t *testing.T) {
+	t.Run("decodes the stored value", func(t *testing.T) {
+		// Arrange
+		sut := newTestSession(t)
+		require.NoError(t, sut.Set("cart", cart{Items: []string{"book"}}))
+
+		// Act
+		value, ok, err := session.Get[cart](sut, "cart")
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, cart{Items: []string{"book"}}, value)
+	})
+
+	t.Run("reports a missing key", func(t *testing.T) {
+		// Arrange
+		sut := newTestSession(t)
+
+		// Act
+		value, ok, err := session.Get[int64](sut, "user_id")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Zero(t, value)
+	})
+
+	t.Run("returns ErrDecodeValue when the value has another type", func(t *testing.T) {
+		// Arrange
+		sut := newTestSession(t)
+		require.NoError(t, sut.Set("user_id", "not a number"))
+
+		// Act
+		_, ok, err := session.Get[int64](sut, "user_id")
+
+		// Assert
+		require.ErrorIs(t, err, session.ErrDecodeValue)
+		assert.True(t, ok)
+	})
+}
+
+func TestSession_Renew(t *testing.T) {
+	t.Run("changes the ID and the CSRF token and keeps the values", func(t *testing.T) {
+		// Arrange
+		sut := newTestSession(t)
+		require.NoError(t, sut.Set("user_id", 42))
+		id := sut.ID()
+		token, err := sut.CSRFToken()
+		require.NoError(t, err)
+
+		// Act
+		err = sut.Renew()
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEqual(t, id, sut.ID())
+		renewedToken, err := sut.CSRFToken()
+		require.NoError(t, err)
+		assert.NotEqual(t, token, renewedToken)
+		assert.True(t, sut.Has("user_id"))
+	})
+}
+
+func TestMustFromContext(t *testing.T) {
+	t.Run("panics without a session", func(t *testing.T) {
+		// Act & Assert
+		assert.PanicsWithValue(t, session.ErrNoSession, func() {
+			session.MustFromContext(context.Background())
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// Store persists the encoded sessions by ID.
+type Store interface {
+	// Load returns the session data and whether it exists. A positive touch extends the
+	// session lifetime to touch, for the sliding expiration.
+	Load(ctx context.Context, id string, touch time.Duration) ([]byte, bool, error)
+	// Save stores the session data, expiring after ttl.
+	Save(ctx context.Context, id string, data []byte, ttl time.Duration) error
+	// Delete removes the session.
+	Delete(ctx context.Context, id string) error
+}
+
+// RedisStore stores the sessions in Redis through the bricks client, so the keys are
+// namespaced and the commands recorded in the client metrics.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore creates a RedisStore keeping the sessions under prefix + ID.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Load implements Store. Reading and touching the session take a single round trip.
+func (s *RedisStore) Load(ctx context.Context, id string, touch time.Duration) ([]byte, bool, error) {
+	var get *goredis.StringCmd
+	err := s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		get = p.Get(ctx, s.prefix+id)
+		if touch > 0 {
+			p.Expire(ctx, s.prefix+id, touch)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := get.Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Save implements Store.
+func (s *RedisStore) Save(ctx context.Context, id string, data []byte, ttl time.Duration) error {
+	return s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, s.prefix+id, data, ttl)
+		return nil
+	})
+}
+
+// Delete implements Store.
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	return s.client.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Del(ctx, s.prefix+id)
+		return nil
+	})
+}
@@ -0,0 +1,128 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"slices"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// awsSigningAlgorithms are the signing algorithms of AWS KMS.
+var awsSigningAlgorithms = map[string]kmstypes.SigningAlgorithmSpec{
+	RS256: kmstypes.SigningAlgorithmSpecRsassaPkcs1V15Sha256,
+	RS384: kmstypes.SigningAlgorithmSpecRsassaPkcs1V15Sha384,
+	RS512: kmstypes.SigningAlgorithmSpecRsassaPkcs1V15Sha512,
+	PS256: kmstypes.SigningAlgorithmSpecRsassaPssSha256,
+	PS384: kmstypes.SigningAlgorithmSpecRsassaPssSha384,
+	PS512: kmstypes.SigningAlgorithmSpecRsassaPssSha512,
+	ES256: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	ES384: kmstypes.SigningAlgorithmSpecEcdsaSha384,
+	ES512: kmstypes.SigningAlgorithmSpecEcdsaSha512,
+}
+
+// AWSKMSSigner signs with an asymmetric AWS KMS key of the SIGN_VERIFY usage, through the
+// KMS client of the AWS SDK. Only the digests leave the application.
+type AWSKMSSigner struct {
+	cfg       AWSKMSConfig
+	keyID     string
+	algorithm string
+	alg       algorithm
+	public    crypto.PublicKey
+	client    *kms.Client
+}
+
+// NewAWSKMSSigner creates an AWSKMSSigner of the key of cfg, called with client, e.g.
+// aws.NewKMS of the aws.Session. It reads the public key and checks that the key signs
+// with the algorithm. An empty keyID defaults to the key of cfg.
+func NewAWSKMSSigner(
+	ctx context.Context,
+	keyID, algorithm string,
+	cfg AWSKMSConfig,
+	client *kms.Client,
+) (*AWSKMSSigner, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrMissingKMSClient
+	}
+	alg, err := lookup(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if keyID == "" {
+		keyID = cfg.Key
+	}
+	s := &AWSKMSSigner{
+		cfg:       cfg,
+		keyID:     keyID,
+		algorithm: algorithm,
+		alg:       alg,
+		client:    client,
+	}
+	if err = s.loadPublicKey(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *AWSKMSSigner) loadPublicKey(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	response, err := s.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: awssdk.String(s.cfg.Key)})
+	if err != nil {
+		return fmt.Errorf("get the public key of %s: %w", s.cfg.Key, err)
+	}
+	if !slices.Contains(response.SigningAlgorithms, awsSigningAlgorithms[s.algorithm]) {
+		return fmt.Errorf("%w: %s does not sign with %s", ErrAlgorithmMismatch, s.cfg.Key, s.algorithm)
+	}
+	public, err := x509.ParsePKIXPublicKey(response.PublicKey)
+	if err != nil {
+		return fmt.Errorf("%w: public key of %s: %w", ErrInvalidKey, s.cfg.Key, err)
+	}
+	if err = s.alg.checkPublicKey(public); err != nil {
+		return err
+	}
+	s.public = public
+	return nil
+}
+
+// KeyID implements Signer.
+func (s *AWSKMSSigner) KeyID() string {
+	return s.keyID
+}
+
+// Algorithm implements Signer.
+func (s *AWSKMSSigner) Algorithm() string {
+	return s.algorithm
+}
+
+// Public implements Signer.
+func (s *AWSKMSSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign implements Signer, sending the digest of message to KMS.
+func (s *AWSKMSSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	response, err := s.client.Sign(ctx, &kms.SignInput{
+		KeyId:            awssdk.String(s.cfg.Key),
+		Message:          s.alg.digest(message),
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: awsSigningAlgorithms[s.algorithm],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign with %s: %w", s.cfg.Key, err)
+	}
+	if s.alg.curve != nil {
+		return rawECDSA(response.Signature, s.alg.curve)
+	}
+	return response.Signature, nil
+}
@@ -0,0 +1,128 @@
+package signing_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+	"github.com/cristiano-pacheco/bricks/pkg/signing"
+)
+
+// newFakeAWSKMS answers GetPublicKey and Sign for an ECDSA P-256 key, signing the digests
+// with key as KMS does.
+func newFakeAWSKMS(t *testing.T, key *ecdsa.PrivateKey, algorithms []string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			KeyID            string `json:"KeyId"`
+			Message          []byte `json:"Message"`
+			MessageType      string `json:"MessageType"`
+			SigningAlgorithm string `json:"SigningAlgorithm"`
+		}
+		_ = json.Unmarshal(body, &request)
+		if request.KeyID != "alias/jwt" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"NotFoundException","message":"Alias alias/other is not found."}`))
+			return
+		}
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			_ = json.NewEncoder(w).Encode(map[string]any{"PublicKey": der, "SigningAlgorithms": algorithms})
+		case "TrentService.Sign":
+			if request.MessageType != "DIGEST" || request.SigningAlgorithm != "ECDSA_SHA_256" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			signature, _ := ecdsa.SignASN1(rand.Reader, key, request.Message)
+			_ = json.NewEncoder(w).Encode(map[string]any{"Signature": signature})
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newKMSClient(t *testing.T, endpoint string) *kms.Client {
+	t.Helper()
+	session, err := aws.NewSession(aws.Config{
+		Region:          "us-east-1",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		Endpoint:        endpoint,
+		Retry:           aws.RetryConfig{MaxAttempts: 1},
+	}, aws.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	return aws.NewKMS(session)
+}
+
+func TestAWSKMSSigner_Sign(t *testing.T) {
+	// Arrange
+	key := mustECKey(t, elliptic.P256())
+	server := newFakeAWSKMS(t, key, []string{"ECDSA_SHA_256"})
+	cfg := signing.AWSKMSConfig{Key: "alias/jwt"}
+	sut, err := signing.NewAWSKMSSigner(context.Background(), "", signing.ES256, cfg, newKMSClient(t, server.URL))
+	require.NoError(t, err)
+
+	// Act
+	signature, err := sut.Sign(context.Background(), []byte("payload"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "alias/jwt", sut.KeyID())
+	assert.True(t, key.PublicKey.Equal(sut.Public()))
+	assert.Len(t, signature, 64)
+	require.NoError(t, signing.Verify(sut.Public(), signing.ES256, []byte("payload"), signature))
+}
+
+func TestNewAWSKMSSigner(t *testing.T) {
+	t.Run("returns ErrAlgorithmMismatch for a key of other algorithms", func(t *testing.T) {
+		// Arrange
+		server := newFakeAWSKMS(t, mustECKey(t, elliptic.P256()), []string{"ECDSA_SHA_256"})
+
+		// Act
+		_, err := signing.NewAWSKMSSigner(context.Background(), "", signing.ES384,
+			signing.AWSKMSConfig{Key: "alias/jwt"}, newKMSClient(t, server.URL))
+
+		// Assert
+		require.ErrorIs(t, err, signing.ErrAlgorithmMismatch)
+	})
+
+	t.Run("returns the API error", func(t *testing.T) {
+		// Arrange
+		server := newFakeAWSKMS(t, mustECKey(t, elliptic.P256()), nil)
+
+		// Act
+		_, err := signing.NewAWSKMSSigner(context.Background(), "", signing.ES256,
+			signing.AWSKMSConfig{Key: "alias/other"}, newKMSClient(t, server.URL))
+
+		// Assert
+		var notFound *kmstypes.NotFoundException
+		require.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "Alias alias/other is not found.", notFound.ErrorMessage())
+	})
+
+	t.Run("requires a KMS client", func(t *testing.T) {
+		// Act
+		_, err := signing.NewAWSKMSSigner(context.Background(), "", signing.ES256,
+			signing.AWSKMSConfig{Key: "alias/jwt"}, nil)
+
+		// Assert
+		require.ErrorIs(t, err, signing.ErrMissingKMSClient)
+	})
+}
@@ -0,0 +1,137 @@
+package signing
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DriverLocal  = "local"
+	DriverAWSKMS = "aws_kms"
+	DriverGCPKMS = "gcp_kms"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// Config configures the Signer created by New and the FX module.
+type Config struct {
+	// Driver is local, aws_kms or gcp_kms
+	Driver string `config:"driver"`
+	// KeyID identifies the key in the signatures, e.g. the kid of the JWTs; defaults to the
+	// KMS key of the KMS drivers
+	KeyID string `config:"key_id"`
+	// Algorithm is RS256, RS384, RS512, PS256, PS384, PS512, ES256, ES384 or ES512
+	Algorithm string       `config:"algorithm"`
+	Local     LocalConfig  `config:"local"`
+	AWSKMS    AWSKMSConfig `config:"aws_kms"`
+	GCPKMS    GCPKMSConfig `config:"gcp_kms"`
+}
+
+// LocalConfig locates the PEM private key of the LocalSigner, read from exactly one source.
+type LocalConfig struct {
+	// Env is the environment variable holding the PEM private key
+	Env string `config:"env"`
+	// File is the file holding the PEM private key, e.g. a mounted secret
+	File string `config:"file"`
+}
+
+// AWSKMSConfig configures the AWSKMSSigner. The region, credentials and endpoint are the
+// ones of the KMS client, e.g. aws.NewKMS of the aws.Session.
+type AWSKMSConfig struct {
+	// Key is the ID, ARN, alias name or alias ARN of the key, e.g. "alias/jwt"
+	Key     string        `config:"key"`
+	Timeout time.Duration `config:"timeout"`
+}
+
+// GCPKMSConfig configures the GCPKMSSigner.
+type GCPKMSConfig struct {
+	// Key is the resource name of the key version, e.g.
+	// "projects/p/locations/global/keyRings/r/cryptoKeys/jwt/cryptoKeyVersions/1"
+	Key string `config:"key"`
+	// Endpoint overrides the Cloud KMS endpoint
+	Endpoint string        `config:"endpoint"`
+	Timeout  time.Duration `config:"timeout"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = DriverLocal
+	}
+	c.AWSKMS.SetDefaults()
+	c.GCPKMS.SetDefaults()
+}
+
+// Validate checks the driver, the algorithm and the settings of the driver.
+func (c *Config) Validate() error {
+	if _, err := lookup(c.Algorithm); err != nil {
+		return err
+	}
+	switch c.Driver {
+	case DriverLocal:
+		if (c.Local.Env == "") == (c.Local.File == "") {
+			return ErrInvalidKeySource
+		}
+		return nil
+	case DriverAWSKMS:
+		return c.AWSKMS.Validate()
+	case DriverGCPKMS:
+		return c.GCPKMS.Validate()
+	default:
+		return ErrInvalidDriver
+	}
+}
+
+// SetDefaults fills in the optional fields.
+func (c *AWSKMSConfig) SetDefaults() {
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+}
+
+// Validate checks the key.
+func (c *AWSKMSConfig) Validate() error {
+	if c.Key == "" {
+		return ErrMissingKey
+	}
+	return nil
+}
+
+// SetDefaults fills in the optional fields.
+func (c *GCPKMSConfig) SetDefaults() {
+	if c.Endpoint == "" {
+		c.Endpoint = defaultGCPKMSEndpoint
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+}
+
+// Validate checks the key.
+func (c *GCPKMSConfig) Validate() error {
+	if c.Key == "" {
+		return ErrMissingKey
+	}
+	return nil
+}
+
+// New creates the Signer of the driver of cfg. The KMS signers read their public key with
+// ctx.
+func New(ctx context.Context, cfg Config, opts ...Option) (Signer, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Driver {
+	case DriverAWSKMS:
+		o := defaultOptions()
+		for _, opt := range opts {
+			opt(&o)
+		}
+		return NewAWSKMSSigner(ctx, cfg.KeyID, cfg.Algorithm, cfg.AWSKMS, o.kmsClient)
+	case DriverGCPKMS:
+		return NewGCPKMSSigner(ctx, cfg.KeyID, cfg.Algorithm, cfg.GCPKMS, opts...)
+	default:
+		return LoadLocalSigner(cfg.KeyID, cfg.Algorithm, cfg.Local)
+	}
+}
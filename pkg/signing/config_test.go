@@ -0,0 +1,74 @@
+package signing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/signing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     signing.Config
+		wantErr error
+	}{
+		{
+			name: "valid local",
+			cfg:  signing.Config{Algorithm: signing.ES256, Local: signing.LocalConfig{Env: "SIGNING_KEY"}},
+		},
+		{
+			name: "valid AWS KMS",
+			cfg: signing.Config{
+				Driver:    signing.DriverAWSKMS,
+				Algorithm: signing.RS256,
+				AWSKMS:    signing.AWSKMSConfig{Key: "alias/jwt"},
+			},
+		},
+		{
+			name:    "unsupported algorithm",
+			cfg:     signing.Config{Algorithm: "HS256", Local: signing.LocalConfig{Env: "SIGNING_KEY"}},
+			wantErr: signing.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:    "invalid driver",
+			cfg:     signing.Config{Driver: "vault", Algorithm: signing.ES256},
+			wantErr: signing.ErrInvalidDriver,
+		},
+		{
+			name: "local key from two sources",
+			cfg: signing.Config{
+				Algorithm: signing.ES256,
+				Local:     signing.LocalConfig{Env: "SIGNING_KEY", File: "/run/secrets/signing.pem"},
+			},
+			wantErr: signing.ErrInvalidKeySource,
+		},
+		{
+			name:    "AWS KMS without key",
+			cfg:     signing.Config{Driver: signing.DriverAWSKMS, Algorithm: signing.ES256},
+			wantErr: signing.ErrMissingKey,
+		},
+		{
+			name:    "GCP KMS without key",
+			cfg:     signing.Config{Driver: signing.DriverGCPKMS, Algorithm: signing.ES256},
+			wantErr: signing.ErrMissingKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.cfg.SetDefaults()
+
+			// Act
+			err := tt.cfg.Validate()
+
+			// Assert
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package signing
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidDriver        = errors.New("invalid signing driver (must be 'local', 'aws_kms' or 'gcp_kms')")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrInvalidKey           = errors.New("invalid signing key")
+	ErrMissingKey           = errors.New("signing key is required")
+	ErrMissingKMSClient     = errors.New("the aws_kms driver requires a KMS client")
+	ErrInvalidKeySource     = errors.New("a local key must be read from exactly one of env or file")
+	ErrMissingKeyMaterial   = errors.New("missing signing key material")
+	ErrAlgorithmMismatch    = errors.New("the key does not sign with the algorithm")
+	ErrInvalidSignature     = errors.New("invalid signature")
+
+	ErrMalformedWebhookSignature = errors.New("malformed webhook signature")
+	ErrWebhookSignatureExpired   = errors.New("webhook signature expired")
+	ErrUnknownKey                = errors.New("signed with an unknown key")
+)
+
+// APIError is an error returned by the Cloud KMS API, e.g. a key that does not exist or a
+// missing permission. The errors of AWS KMS are the smithy.APIError of the AWS SDK.
+type APIError struct {
+	// Service is cloudkms
+	Service    string
+	StatusCode int
+	// Code is the error code of the API, e.g. NotFoundException or PERMISSION_DENIED
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s: %s (%d)", e.Service, e.Code, e.Message, e.StatusCode)
+}
@@ -0,0 +1,48 @@
+package signing
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+// Module provides the Signer configured under "app.signing". jwt.Module signs the tokens
+// with it when provided. The aws_kms driver takes the KMS client of aws.KMSModule.
+//
+// Usage in your application:
+//
+//	fx.New(
+//	    aws.Module,
+//	    aws.KMSModule,
+//	    signing.Module,
+//	    jwt.Module,
+//	)
+var Module = fx.Module("signing",
+	config.Provide[Config]("app.signing"),
+	fx.Provide(NewWithParams),
+)
+
+type Params struct {
+	fx.In
+
+	Config      config.Config[Config]
+	TokenSource TokenSource `optional:"true"`
+	KMS         *kms.Client `optional:"true"`
+}
+
+// NewWithParams creates the Signer of the loaded configuration, reading the public key of
+// a KMS key within its timeout.
+func NewWithParams(p Params) (Signer, error) {
+	cfg := p.Config.Get()
+	cfg.SetDefaults()
+	timeout := cfg.AWSKMS.Timeout
+	if cfg.Driver == DriverGCPKMS {
+		timeout = cfg.GCPKMS.Timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return New(ctx, cfg, WithTokenSource(p.TokenSource), WithKMSClient(p.KMS))
+}
@@ -0,0 +1,279 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultGCPKMSEndpoint = "https://cloudkms.googleapis.com"
+	defaultMetadataHost   = "metadata.google.internal"
+	// tokenExpiryMargin renews the access tokens this long before they expire
+	tokenExpiryMargin = time.Minute
+	// maxErrorBody caps the error responses read into errors
+	maxErrorBody = 4 << 10
+)
+
+// TokenSource returns the OAuth 2.0 access tokens authorizing the calls to the GCP APIs.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// GCPKMSSigner signs with an asymmetric signing key version of Cloud KMS, through its REST
+// API. Only the digests leave the application.
+type GCPKMSSigner struct {
+	cfg       GCPKMSConfig
+	keyID     string
+	algorithm string
+	alg       algorithm
+	public    crypto.PublicKey
+	tokens    TokenSource
+	client    *http.Client
+}
+
+// NewGCPKMSSigner creates a GCPKMSSigner of the key version of cfg, reading its public key
+// and checking that it signs with the algorithm. An empty keyID defaults to the key version
+// name. Without WithTokenSource, the access tokens of the service account of the instance
+// are read from the metadata server, e.g. on GKE with Workload Identity or on Cloud Run.
+func NewGCPKMSSigner(
+	ctx context.Context,
+	keyID, algorithm string,
+	cfg GCPKMSConfig,
+	opts ...Option,
+) (*GCPKMSSigner, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	alg, err := lookup(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if keyID == "" {
+		keyID = cfg.Key
+	}
+	tokens := o.tokens
+	if tokens == nil {
+		tokens = NewMetadataTokenSource(o.httpClient)
+	}
+	s := &GCPKMSSigner{
+		cfg:       cfg,
+		keyID:     keyID,
+		algorithm: algorithm,
+		alg:       alg,
+		tokens:    tokens,
+		client:    o.httpClient,
+	}
+	if err = s.loadPublicKey(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *GCPKMSSigner) loadPublicKey(ctx context.Context) error {
+	var response struct {
+		PEM       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := s.call(ctx, http.MethodGet, "/publicKey", nil, &response); err != nil {
+		return fmt.Errorf("get the public key of %s: %w", s.cfg.Key, err)
+	}
+	if !strings.HasPrefix(response.Algorithm, s.gcpAlgorithmPrefix()) ||
+		!strings.HasSuffix(response.Algorithm, "_"+hashName(s.alg.hash)) {
+		return fmt.Errorf("%w: %s signs with %s", ErrAlgorithmMismatch, s.cfg.Key, response.Algorithm)
+	}
+	block, _ := pem.Decode([]byte(response.PEM))
+	if block == nil {
+		return fmt.Errorf("%w: public key of %s: no PEM block", ErrInvalidKey, s.cfg.Key)
+	}
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("%w: public key of %s: %w", ErrInvalidKey, s.cfg.Key, err)
+	}
+	if err = s.alg.checkPublicKey(public); err != nil {
+		return err
+	}
+	s.public = public
+	return nil
+}
+
+// gcpAlgorithmPrefix returns the prefix of the Cloud KMS algorithms of the algorithm, e.g.
+// EC_SIGN_P256_ for ES256, before the hash suffix.
+func (s *GCPKMSSigner) gcpAlgorithmPrefix() string {
+	switch {
+	case s.alg.curve != nil:
+		return "EC_SIGN_P" + strconv.Itoa(s.alg.curve.Params().BitSize) + "_"
+	case s.alg.pss:
+		return "RSA_SIGN_PSS_"
+	default:
+		return "RSA_SIGN_PKCS1_"
+	}
+}
+
+// KeyID implements Signer.
+func (s *GCPKMSSigner) KeyID() string {
+	return s.keyID
+}
+
+// Algorithm implements Signer.
+func (s *GCPKMSSigner) Algorithm() string {
+	return s.algorithm
+}
+
+// Public implements Signer.
+func (s *GCPKMSSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign implements Signer, sending the digest of message to Cloud KMS.
+func (s *GCPKMSSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	request := map[string]map[string][]byte{
+		"digest": {strings.ToLower(hashName(s.alg.hash)): s.alg.digest(message)},
+	}
+	var response struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := s.call(ctx, http.MethodPost, ":asymmetricSign", request, &response); err != nil {
+		return nil, fmt.Errorf("sign with %s: %w", s.cfg.Key, err)
+	}
+	if s.alg.curve != nil {
+		return rawECDSA(response.Signature, s.alg.curve)
+	}
+	return response.Signature, nil
+}
+
+// call calls the method of the key version, e.g. ":asymmetricSign", decoding the result
+// into response.
+func (s *GCPKMSSigner) call(ctx context.Context, httpMethod, method string, request, response any) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	token, err := s.tokens.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("get an access token: %w", err)
+	}
+	var body io.Reader
+	if request != nil {
+		payload, marshalErr := json.Marshal(request)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, httpMethod, s.cfg.Endpoint+"/v1/"+s.cfg.Key+method, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cloudkms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var result struct {
+			Error struct {
+				Message string `json:"message"`
+				Status  string `json:"status"`
+			} `json:"error"`
+		}
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		if json.Unmarshal(raw, &result) != nil || result.Error.Status == "" {
+			result.Error.Status, result.Error.Message = http.StatusText(resp.StatusCode), string(bytes.TrimSpace(raw))
+		}
+		return &APIError{
+			Service:    "cloudkms",
+			StatusCode: resp.StatusCode,
+			Code:       result.Error.Status,
+			Message:    result.Error.Message,
+		}
+	}
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("cloudkms: invalid response: %w", err)
+	}
+	return nil
+}
+
+func hashName(hash crypto.Hash) string {
+	return strings.ReplaceAll(hash.String(), "-", "")
+}
+
+// MetadataTokenSource reads the access tokens of the service account of the instance from
+// the metadata server of GCP, and caches them until shortly before they expire. The
+// GCE_METADATA_HOST environment variable overrides the metadata server.
+type MetadataTokenSource struct {
+	client *http.Client
+	host   string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewMetadataTokenSource creates a MetadataTokenSource. A nil client uses
+// http.DefaultClient.
+func NewMetadataTokenSource(client *http.Client) *MetadataTokenSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	host := os.Getenv("GCE_METADATA_HOST")
+	if host == "" {
+		host = defaultMetadataHost
+	}
+	return &MetadataTokenSource{client: client, host: host}
+}
+
+// Token implements TokenSource.
+func (m *MetadataTokenSource) Token(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token != "" && time.Now().Before(m.expires) {
+		return m.token, nil
+	}
+
+	url := "http://" + m.host + "/computeMetadata/v1/instance/service-accounts/default/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("metadata server: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return "", fmt.Errorf("metadata server responded %d: %s", resp.StatusCode, bytes.TrimSpace(raw))
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("metadata server: invalid token: %w", err)
+	}
+	m.token = token.AccessToken
+	m.expires = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return m.token, nil
+}
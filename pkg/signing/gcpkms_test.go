@@ -0,0 +1,135 @@
+package signing_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/signing"
+)
+
+const gcpKeyVersion = "projects/shop/locations/global/keyRings/auth/cryptoKeys/jwt/cryptoKeyVersions/1"
+
+type staticTokens string
+
+func (s staticTokens) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// newFakeGCPKMS answers publicKey and asymmetricSign for an ECDSA P-384 key version,
+// signing the digests with key as Cloud KMS does.
+func newFakeGCPKMS(t *testing.T, key *ecdsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":401,"message":"invalid credentials","status":"UNAUTHENTICATED"}}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/" + gcpKeyVersion + "/publicKey":
+			der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"pem":       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+				"algorithm": "EC_SIGN_P384_SHA384",
+			})
+		case "/v1/" + gcpKeyVersion + ":asymmetricSign":
+			var request struct {
+				Digest map[string][]byte `json:"digest"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			signature, _ := ecdsa.SignASN1(rand.Reader, key, request.Digest["sha384"])
+			_ = json.NewEncoder(w).Encode(map[string]any{"signature": signature})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGCPKMSSigner_Sign(t *testing.T) {
+	// Arrange
+	key := mustECKey(t, elliptic.P384())
+	server := newFakeGCPKMS(t, key)
+	cfg := signing.GCPKMSConfig{Key: gcpKeyVersion, Endpoint: server.URL}
+	sut, err := signing.NewGCPKMSSigner(context.Background(), "jwt-1", signing.ES384, cfg,
+		signing.WithTokenSource(staticTokens("token-1")))
+	require.NoError(t, err)
+
+	// Act
+	signature, err := sut.Sign(context.Background(), []byte("payload"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "jwt-1", sut.KeyID())
+	assert.Len(t, signature, 96)
+	require.NoError(t, signing.Verify(sut.Public(), signing.ES384, []byte("payload"), signature))
+}
+
+func TestNewGCPKMSSigner(t *testing.T) {
+	t.Run("returns ErrAlgorithmMismatch for a key version of another algorithm", func(t *testing.T) {
+		// Arrange
+		server := newFakeGCPKMS(t, mustECKey(t, elliptic.P384()))
+		cfg := signing.GCPKMSConfig{Key: gcpKeyVersion, Endpoint: server.URL}
+
+		// Act
+		_, err := signing.NewGCPKMSSigner(context.Background(), "", signing.ES256, cfg,
+			signing.WithTokenSource(staticTokens("token-1")))
+
+		// Assert
+		require.ErrorIs(t, err, signing.ErrAlgorithmMismatch)
+	})
+
+	t.Run("returns the API error", func(t *testing.T) {
+		// Arrange
+		server := newFakeGCPKMS(t, mustECKey(t, elliptic.P384()))
+		cfg := signing.GCPKMSConfig{Key: gcpKeyVersion, Endpoint: server.URL}
+
+		// Act
+		_, err := signing.NewGCPKMSSigner(context.Background(), "", signing.ES384, cfg,
+			signing.WithTokenSource(staticTokens("expired")))
+
+		// Assert
+		var apiErr *signing.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "UNAUTHENTICATED", apiErr.Code)
+	})
+}
+
+func TestMetadataTokenSource_Token(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		assert.Equal(t, "/computeMetadata/v1/instance/service-accounts/default/token", r.URL.Path)
+		_, _ = w.Write([]byte(`{"access_token":"token-1","expires_in":3599,"token_type":"Bearer"}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("GCE_METADATA_HOST", strings.TrimPrefix(server.URL, "http://"))
+	sut := signing.NewMetadataTokenSource(nil)
+
+	// Act
+	first, firstErr := sut.Token(context.Background())
+	second, secondErr := sut.Token(context.Background())
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, "token-1", first)
+	assert.Equal(t, "token-1", second)
+	assert.Equal(t, int32(1), calls.Load())
+}
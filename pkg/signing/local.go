@@ -0,0 +1,118 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// LocalSigner signs with a private key held in memory, e.g. in development or tests.
+type LocalSigner struct {
+	keyID     string
+	algorithm string
+	alg       algorithm
+	key       crypto.Signer
+}
+
+// NewLocalSigner creates a LocalSigner of key, an *rsa.PrivateKey or an *ecdsa.PrivateKey
+// of the algorithm.
+func NewLocalSigner(keyID, algorithm string, key crypto.Signer) (*LocalSigner, error) {
+	alg, err := lookup(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, ErrMissingKey
+	}
+	if err = alg.checkPublicKey(key.Public()); err != nil {
+		return nil, err
+	}
+	return &LocalSigner{keyID: keyID, algorithm: algorithm, alg: alg, key: key}, nil
+}
+
+// LoadLocalSigner creates a LocalSigner of the PEM private key read from the env or the
+// file of cfg.
+func LoadLocalSigner(keyID, algorithm string, cfg LocalConfig) (*LocalSigner, error) {
+	if (cfg.Env == "") == (cfg.File == "") {
+		return nil, ErrInvalidKeySource
+	}
+	var material []byte
+	if cfg.Env != "" {
+		value, ok := os.LookupEnv(cfg.Env)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: environment variable %s", ErrMissingKeyMaterial, cfg.Env)
+		}
+		material = []byte(value)
+	} else {
+		content, err := os.ReadFile(cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMissingKeyMaterial, err)
+		}
+		material = content
+	}
+	key, err := ParsePrivateKey(material)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalSigner(keyID, algorithm, key)
+}
+
+// KeyID implements Signer.
+func (s *LocalSigner) KeyID() string {
+	return s.keyID
+}
+
+// Algorithm implements Signer.
+func (s *LocalSigner) Algorithm() string {
+	return s.algorithm
+}
+
+// Public implements Signer.
+func (s *LocalSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+// Sign implements Signer.
+func (s *LocalSigner) Sign(_ context.Context, message []byte) ([]byte, error) {
+	digest := s.alg.digest(message)
+	if key, isEC := s.key.(*ecdsa.PrivateKey); isEC {
+		der, err := ecdsa.SignASN1(rand.Reader, key, digest)
+		if err != nil {
+			return nil, err
+		}
+		return rawECDSA(der, s.alg.curve)
+	}
+	var opts crypto.SignerOpts = s.alg.hash
+	if s.alg.pss {
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: s.alg.hash}
+	}
+	return s.key.Sign(rand.Reader, digest, opts)
+}
+
+// ParsePrivateKey parses a PEM encoded RSA or EC private key (PKCS #8, PKCS #1 or SEC 1).
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block", ErrInvalidKey)
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, isSigner := key.(crypto.Signer)
+		if !isSigner {
+			return nil, fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, key)
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported PEM block %q", ErrInvalidKey, block.Type)
+}
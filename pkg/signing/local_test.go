@@ -0,0 +1,125 @@
+package signing_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/signing"
+)
+
+func TestLocalSigner_Sign(t *testing.T) {
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, signing.MinRSAKeySize)
+	require.NoError(t, err)
+
+	tests := []struct {
+		algorithm string
+		key       crypto.Signer
+	}{
+		{algorithm: signing.RS256, key: rsaPrivate},
+		{algorithm: signing.PS384, key: rsaPrivate},
+		{algorithm: signing.ES256, key: mustECKey(t, elliptic.P256())},
+		{algorithm: signing.ES384, key: mustECKey(t, elliptic.P384())},
+		{algorithm: signing.ES512, key: mustECKey(t, elliptic.P521())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			// Arrange
+			sut, err := signing.NewLocalSigner("key-1", tt.algorithm, tt.key)
+			require.NoError(t, err)
+
+			// Act
+			signature, err := sut.Sign(context.Background(), []byte("payload"))
+
+			// Assert
+			require.NoError(t, err)
+			require.NoError(t, signing.Verify(sut.Public(), tt.algorithm, []byte("payload"), signature))
+			require.ErrorIs(t, signing.Verify(sut.Public(), tt.algorithm, []byte("tampered"), signature),
+				signing.ErrInvalidSignature)
+		})
+	}
+}
+
+func TestNewLocalSigner(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		key       crypto.Signer
+		wantErr   error
+	}{
+		{
+			name:      "unknown algorithm",
+			algorithm: "HS256",
+			key:       mustECKey(t, elliptic.P256()),
+			wantErr:   signing.ErrUnsupportedAlgorithm,
+		},
+		{
+			name:      "EC key of another curve",
+			algorithm: signing.ES256,
+			key:       mustECKey(t, elliptic.P384()),
+			wantErr:   signing.ErrInvalidKey,
+		},
+		{
+			name:      "EC key for an RSA algorithm",
+			algorithm: signing.RS256,
+			key:       mustECKey(t, elliptic.P256()),
+			wantErr:   signing.ErrInvalidKey,
+		},
+		{
+			name:      "missing key",
+			algorithm: signing.ES256,
+			wantErr:   signing.ErrMissingKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := signing.NewLocalSigner("key-1", tt.algorithm, tt.key)
+
+			// Assert
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadLocalSigner(t *testing.T) {
+	t.Run("loads the PEM private key of the environment variable", func(t *testing.T) {
+		// Arrange
+		key := mustECKey(t, elliptic.P256())
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		t.Setenv("SIGNING_KEY", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+
+		// Act
+		sut, err := signing.LoadLocalSigner("key-1", signing.ES256, signing.LocalConfig{Env: "SIGNING_KEY"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", sut.KeyID())
+		assert.True(t, key.PublicKey.Equal(sut.Public()))
+	})
+
+	t.Run("returns ErrMissingKeyMaterial for an unset environment variable", func(t *testing.T) {
+		// Act
+		_, err := signing.LoadLocalSigner("key-1", signing.ES256, signing.LocalConfig{Env: "UNSET_SIGNING_KEY"})
+
+		// Assert
+		require.ErrorIs(t, err, signing.ErrMissingKeyMaterial)
+	})
+}
+
+func mustECKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return key
+}
@@ -0,0 +1,48 @@
+package signing
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+type options struct {
+	httpClient *http.Client
+	tokens     TokenSource
+	kmsClient  *kms.Client
+}
+
+// Option configures the KMS signers.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{httpClient: &http.Client{}}
+}
+
+// WithHTTPClient sets the HTTP client calling Cloud KMS and the metadata server. The calls
+// are bounded by the configured timeout, not by the timeout of the client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		if client != nil {
+			o.httpClient = client
+		}
+	}
+}
+
+// WithTokenSource sets the source of the access tokens of GCP KMS. Defaults to a
+// MetadataTokenSource when not provided.
+func WithTokenSource(tokens TokenSource) Option {
+	return func(o *options) {
+		if tokens != nil {
+			o.tokens = tokens
+		}
+	}
+}
+
+// WithKMSClient sets the AWS KMS client of the aws_kms driver, e.g. aws.NewKMS of the
+// aws.Session. The aws_kms driver requires it.
+func WithKMSClient(client *kms.Client) Option {
+	return func(o *options) {
+		o.kmsClient = client
+	}
+}
@@ -0,0 +1,172 @@
+// Package signing signs with keys the application may never hold: a Signer signs with a
+// local private key, or asks AWS KMS or GCP KMS to sign, so private keys never live on disk
+// in production. The JWT package signs its tokens with a Signer, and SignWebhook the
+// payloads of outgoing webhooks.
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers SHA-256 for crypto.Hash
+	_ "crypto/sha512" // registers SHA-384 and SHA-512 for crypto.Hash
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+)
+
+// Signing algorithms, named as in JWS (RFC 7518).
+const (
+	RS256 = "RS256"
+	RS384 = "RS384"
+	RS512 = "RS512"
+	PS256 = "PS256"
+	PS384 = "PS384"
+	PS512 = "PS512"
+	ES256 = "ES256"
+	ES384 = "ES384"
+	ES512 = "ES512"
+
+	// MinRSAKeySize is the minimum size, in bits, of the RSA keys
+	MinRSAKeySize = 2048
+)
+
+// Signer signs messages with a private key it may not expose.
+type Signer interface {
+	// KeyID identifies the key, e.g. the kid header of the JWTs it signs
+	KeyID() string
+	// Algorithm is the algorithm of the signatures, e.g. ES256
+	Algorithm() string
+	// Public returns the *rsa.PublicKey or *ecdsa.PublicKey verifying the signatures
+	Public() crypto.PublicKey
+	// Sign hashes message with the hash of the algorithm and signs the digest. ECDSA
+	// signatures are the fixed size r || s of JWS, not ASN.1.
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// PublicKey is the public key of a Signer, verifying its signatures.
+type PublicKey struct {
+	KeyID     string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// PublicKeyOf returns the public key of signer.
+func PublicKeyOf(signer Signer) PublicKey {
+	return PublicKey{KeyID: signer.KeyID(), Algorithm: signer.Algorithm(), Key: signer.Public()}
+}
+
+type algorithm struct {
+	hash crypto.Hash
+	// pss signs RSA with PSS, salted with the size of the hash, rather than PKCS #1 v1.5
+	pss   bool
+	curve elliptic.Curve
+}
+
+var algorithms = map[string]algorithm{
+	RS256: {hash: crypto.SHA256},
+	RS384: {hash: crypto.SHA384},
+	RS512: {hash: crypto.SHA512},
+	PS256: {hash: crypto.SHA256, pss: true},
+	PS384: {hash: crypto.SHA384, pss: true},
+	PS512: {hash: crypto.SHA512, pss: true},
+	ES256: {hash: crypto.SHA256, curve: elliptic.P256()},
+	ES384: {hash: crypto.SHA384, curve: elliptic.P384()},
+	ES512: {hash: crypto.SHA512, curve: elliptic.P521()},
+}
+
+func lookup(name string) (algorithm, error) {
+	alg, ok := algorithms[name]
+	if !ok {
+		return algorithm{}, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, name)
+	}
+	return alg, nil
+}
+
+func (a algorithm) digest(message []byte) []byte {
+	h := a.hash.New()
+	h.Write(message)
+	return h.Sum(nil)
+}
+
+// checkPublicKey checks that publicKey is a key of the algorithm: an RSA key of at least
+// MinRSAKeySize bits, or an EC key on its curve.
+func (a algorithm) checkPublicKey(publicKey crypto.PublicKey) error {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if a.curve != nil {
+			return fmt.Errorf("%w: an EC key on %s is required", ErrInvalidKey, a.curve.Params().Name)
+		}
+		if key.N.BitLen() < MinRSAKeySize {
+			return fmt.Errorf("%w: RSA key must be at least %d bits", ErrInvalidKey, MinRSAKeySize)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if a.curve == nil {
+			return fmt.Errorf("%w: an RSA key is required", ErrInvalidKey)
+		}
+		if key.Curve != a.curve {
+			return fmt.Errorf("%w: an EC key on %s is required", ErrInvalidKey, a.curve.Params().Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported key type %T", ErrInvalidKey, publicKey)
+	}
+}
+
+// Verify checks the signature of message with publicKey, an *rsa.PublicKey or an
+// *ecdsa.PublicKey, for the algorithm.
+func Verify(publicKey crypto.PublicKey, algorithm string, message, signature []byte) error {
+	alg, err := lookup(algorithm)
+	if err != nil {
+		return err
+	}
+	if err = alg.checkPublicKey(publicKey); err != nil {
+		return err
+	}
+	digest := alg.digest(message)
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if alg.pss {
+			err = rsa.VerifyPSS(key, alg.hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			err = rsa.VerifyPKCS1v15(key, alg.hash, digest, signature)
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+	case *ecdsa.PublicKey:
+		size := curveSize(alg.curve)
+		if len(signature) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func curveSize(curve elliptic.Curve) int {
+	return (curve.Params().BitSize + 7) / 8
+}
+
+// rawECDSA converts the ASN.1 ECDSA signature returned by the KMS to r || s.
+func rawECDSA(der []byte, curve elliptic.Curve) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &sig)
+	size := curveSize(curve)
+	if err != nil || len(rest) > 0 || sig.R.BitLen() > 8*size || sig.S.BitLen() > 8*size {
+		return nil, fmt.Errorf("%w: malformed ECDSA signature", ErrInvalidSignature)
+	}
+	signature := make([]byte, 2*size)
+	sig.R.FillBytes(signature[:size])
+	sig.S.FillBytes(signature[size:])
+	return signature, nil
+}
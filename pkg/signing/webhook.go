@@ -0,0 +1,68 @@
+package signing
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader is the HTTP header carrying the signature of a webhook payload.
+const WebhookSignatureHeader = "Webhook-Signature"
+
+// SignWebhook signs payload, sent at t, and returns the value of its
+// WebhookSignatureHeader: "t=<unix seconds>,kid=<key ID>,sig=<base64url signature>". The
+// signature covers "<unix seconds>.<payload>", so a receiver can reject replayed payloads.
+func SignWebhook(ctx context.Context, signer Signer, payload []byte, t time.Time) (string, error) {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	signature, err := signer.Sign(ctx, webhookInput(timestamp, payload))
+	if err != nil {
+		return "", err
+	}
+	return "t=" + timestamp + ",kid=" + signer.KeyID() + ",sig=" + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// VerifyWebhook checks the WebhookSignatureHeader value header of payload with the public key
+// of its key ID among keys, and rejects signatures made more than tolerance away from now.
+// Keep the former public keys in keys until their last webhooks are delivered.
+func VerifyWebhook(header string, payload []byte, keys []PublicKey, tolerance time.Duration, now time.Time) error {
+	var timestamp, keyID, encoded string
+	for field := range strings.SplitSeq(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch name {
+		case "t":
+			timestamp = value
+		case "kid":
+			keyID = value
+		case "sig":
+			encoded = value
+		}
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || encoded == "" {
+		return ErrMalformedWebhookSignature
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrMalformedWebhookSignature
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return ErrWebhookSignatureExpired
+	}
+
+	for _, key := range keys {
+		if key.KeyID == keyID {
+			return Verify(key.Key, key.Algorithm, webhookInput(timestamp, payload), signature)
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownKey, keyID)
+}
+
+func webhookInput(timestamp string, payload []byte) []byte {
+	input := make([]byte, 0, len(timestamp)+1+len(payload))
+	input = append(input, timestamp...)
+	input = append(input, '.')
+	return append(input, payload...)
+}
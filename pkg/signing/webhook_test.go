@@ -0,0 +1,93 @@
+package signing_test
+
+import (
+	"context"
+	"crypto/elliptic"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/signing"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	sentAt := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	payload := []byte(`{"event":"order.paid"}`)
+	signer, err := signing.NewLocalSigner("webhooks-1", signing.ES256, mustECKey(t, elliptic.P256()))
+	require.NoError(t, err)
+	header, err := signing.SignWebhook(context.Background(), signer, payload, sentAt)
+	require.NoError(t, err)
+	keys := []signing.PublicKey{signing.PublicKeyOf(signer)}
+
+	tests := []struct {
+		name    string
+		header  string
+		payload []byte
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			header:  header,
+			payload: payload,
+			now:     sentAt.Add(time.Minute),
+		},
+		{
+			name:    "tampered payload",
+			header:  header,
+			payload: []byte(`{"event":"order.refunded"}`),
+			now:     sentAt,
+			wantErr: signing.ErrInvalidSignature,
+		},
+		{
+			name:    "replayed after the tolerance",
+			header:  header,
+			payload: payload,
+			now:     sentAt.Add(6 * time.Minute),
+			wantErr: signing.ErrWebhookSignatureExpired,
+		},
+		{
+			name:    "unknown key",
+			header:  strings.Replace(header, "kid=webhooks-1", "kid=webhooks-0", 1),
+			payload: payload,
+			now:     sentAt,
+			wantErr: signing.ErrUnknownKey,
+		},
+		{
+			name:    "malformed",
+			header:  "sig=abc",
+			payload: payload,
+			now:     sentAt,
+			wantErr: signing.ErrMalformedWebhookSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := signing.VerifyWebhook(tt.header, tt.payload, keys, 5*time.Minute, tt.now)
+
+			// Assert
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// Arrange
+	signer, err := signing.NewLocalSigner("webhooks-1", signing.ES256, mustECKey(t, elliptic.P256()))
+	require.NoError(t, err)
+
+	// Act
+	header, err := signing.SignWebhook(context.Background(), signer, []byte(`{}`), time.Unix(1700000000, 0))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(header, "t=1700000000,kid=webhooks-1,sig="))
+}
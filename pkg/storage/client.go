@@ -0,0 +1,30 @@
+package storage
+
+import "fmt"
+
+// New creates the Storage of the configured driver.
+//
+//	store, err := storage.New(storage.Config{
+//	    Driver: storage.DriverS3,
+//	    S3:     storage.S3Config{Bucket: "shop-invoices"},
+//	}, storage.WithS3Client(aws.NewS3(session)))
+//	err = store.Put(ctx, "invoices/42.pdf", file)
+func New(cfg Config, opts ...Option) (Storage, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, cfg.Driver)
+	}
+	storageOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&storageOptions)
+	}
+
+	switch cfg.Driver {
+	case DriverS3:
+		return NewS3Storage(cfg.S3, storageOptions.s3Client)
+	case DriverGCS:
+		return NewGCSStorage(cfg.GCS, storageOptions.httpClient)
+	default:
+		return NewLocalStorage(cfg.Local)
+	}
+}
@@ -0,0 +1,46 @@
+package storage_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cristiano-pacheco/bricks/pkg/storage"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("defaults to the local driver", func(t *testing.T) {
+		// Act
+		sut, err := storage.New(storage.Config{Local: storage.LocalConfig{Root: t.TempDir()}})
+
+		// Assert
+		require.NoError(t, err)
+		assert.IsType(t, &storage.LocalStorage{}, sut)
+		require.NoError(t, sut.(io.Closer).Close())
+	})
+
+	t.Run("creates the gcs driver over the s3 API", func(t *testing.T) {
+		// Act
+		sut, err := storage.New(storage.Config{Driver: storage.DriverGCS, GCS: storage.GCSConfig{Bucket: "assets"}})
+
+		// Assert
+		require.NoError(t, err)
+		assert.IsType(t, &storage.S3Storage{}, sut)
+	})
+
+	t.Run("rejects invalid configurations", func(t *testing.T) {
+		// Act
+		_, errDriver := storage.New(storage.Config{Driver: "ftp"})
+		_, errRoot := storage.New(storage.Config{})
+		_, errBucket := storage.New(storage.Config{Driver: storage.DriverS3})
+		_, errClient := storage.New(storage.Config{Driver: storage.DriverS3, S3: storage.S3Config{Bucket: "invoices"}})
+
+		// Assert
+		assert.ErrorIs(t, errDriver, storage.ErrInvalidDriver)
+		assert.ErrorIs(t, errRoot, storage.ErrMissingRoot)
+		assert.ErrorIs(t, errBucket, storage.ErrMissingBucket)
+		assert.ErrorIs(t, errClient, storage.ErrMissingS3Client)
+	})
+}
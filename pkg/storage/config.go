@@ -0,0 +1,113 @@
+package storage
+
+import "time"
+
+const (
+	DriverS3    = "s3"
+	DriverGCS   = "gcs"
+	DriverLocal = "local"
+
+	defaultPartSize = 8 << 20 // 8 MiB
+	// minPartSize is the smallest part S3 accepts, but for the last one
+	minPartSize    = 5 << 20
+	defaultTimeout = 30 * time.Second
+	gcsEndpoint    = "https://storage.googleapis.com"
+	// gcsRegion is the region of the signatures of the GCS XML API
+	gcsRegion = "auto"
+)
+
+// Config configures the Storage created by the FX module.
+type Config struct {
+	// Driver is s3, gcs or local
+	Driver string      `config:"driver"`
+	S3     S3Config    `config:"s3"`
+	GCS    GCSConfig   `config:"gcs"`
+	Local  LocalConfig `config:"local"`
+}
+
+// S3Config configures the S3Storage, for AWS S3 and S3 compatible services (MinIO,
+// Localstack, Cloudflare R2...). The region, credentials and endpoint are the ones of the
+// S3 client, e.g. aws.NewS3 of the aws.Session.
+type S3Config struct {
+	Bucket string `config:"bucket"`
+	// PartSize is the size of the parts of multipart uploads; bodies up to this size
+	// are uploaded in one request
+	PartSize int64 `config:"part_size"`
+	// Timeout bounds the abort of a failed multipart upload
+	Timeout time.Duration `config:"timeout"`
+}
+
+// GCSConfig configures the Google Cloud Storage driver, which uses the S3 compatible
+// XML API with the HMAC keys of a service account.
+type GCSConfig struct {
+	Bucket    string        `config:"bucket"`
+	AccessKey string        `config:"access_key"`
+	Secret    string        `config:"secret"`
+	PartSize  int64         `config:"part_size"`
+	Timeout   time.Duration `config:"timeout"`
+}
+
+// LocalConfig configures the LocalStorage.
+type LocalConfig struct {
+	// Root is the directory holding the objects
+	Root string `config:"root"`
+	// BaseURL is the URL LocalStorage.Handler is mounted at, for presigned URLs
+	BaseURL string `config:"base_url"`
+	// Secret signs the presigned URLs
+	Secret string `config:"secret"`
+}
+
+// SetDefaults fills in the optional fields.
+func (c *Config) SetDefaults() {
+	if c.Driver == "" {
+		c.Driver = DriverLocal
+	}
+	c.S3.SetDefaults()
+}
+
+// Validate checks the driver and its settings.
+func (c *Config) Validate() error {
+	switch c.Driver {
+	case DriverS3:
+		return c.S3.Validate()
+	case DriverGCS:
+		if c.GCS.Bucket == "" {
+			return ErrMissingBucket
+		}
+		return nil
+	case DriverLocal:
+		if c.Local.Root == "" {
+			return ErrMissingRoot
+		}
+		return nil
+	default:
+		return ErrInvalidDriver
+	}
+}
+
+// SetDefaults fills in the optional fields.
+func (c *S3Config) SetDefaults() {
+	if c.PartSize < minPartSize {
+		c.PartSize = defaultPartSize
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+}
+
+// Validate checks the bucket.
+func (c *S3Config) Validate() error {
+	if c.Bucket == "" {
+		return ErrMissingBucket
+	}
+	return nil
+}
+
+// s3Config returns the S3Config of the GCS XML API.
+func (c GCSConfig) s3Config() S3Config {
+	return S3Config{
+		Bucket:   c.Bucket,
+		PartSize: c.PartSize,
+		Timeout:  c.Timeout,
+	}
+}
@@ -0,0 +1,15 @@
+package storage
+
+import "errors"
+
+var (
+	ErrInvalidDriver      = errors.New("invalid storage driver (must be 's3', 'gcs' or 'local')")
+	ErrMissingBucket      = errors.New("storage bucket is required")
+	ErrMissingS3Client    = errors.New("s3 storage requires an s3 client")
+	ErrMissingRoot        = errors.New("local storage root is required")
+	ErrInvalidKey         = errors.New("invalid object key")
+	ErrNotFound           = errors.New("object not found")
+	ErrUnsupportedMethod  = errors.New("presigned URLs support GET and PUT only")
+	ErrPresignUnavailable = errors.New("local storage presigning requires a base url and a secret")
+	ErrInvalidSignature   = errors.New("invalid or expired presigned URL")
+)
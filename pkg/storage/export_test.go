@@ -0,0 +1,8 @@
+package storage
+
+import "time"
+
+// SetNow sets the clock used to sign and verify the presigned URLs.
+func (s *LocalStorage) SetNow(now func() time.Time) {
+	s.now = now
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+)
+
+// Module provides the Storage configured from app.storage. The s3 driver takes the S3
+// client of aws.S3Module. With the local driver, the Storage is a *LocalStorage, whose
+// Handler serves the presigned URLs.
+var Module = fx.Module(
+	"storage",
+	config.Provide[Config]("app.storage"),
+	fx.Provide(NewWithLifecycle),
+)
+
+type NewWithLifecycleParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Config    config.Config[Config]
+	S3        *s3.Client `optional:"true"`
+}
+
+// NewWithLifecycle creates the Storage and closes it on stop, when it has resources
+// to release.
+func NewWithLifecycle(p NewWithLifecycleParams) (Storage, error) {
+	store, err := New(p.Config.Get(), WithS3Client(p.S3))
+	if err != nil {
+		return nil, err
+	}
+
+	if closer, ok := store.(io.Closer); ok {
+		p.Lifecycle.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				return closer.Close()
+			},
+		})
+	}
+	return store, nil
+}
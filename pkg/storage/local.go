@@ -0,0 +1,308 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tempPrefix marks the files of the uploads in progress, hidden from List
+const tempPrefix = ".tmp-"
+
+// LocalStorage stores the objects as files under a root directory, e.g. for development
+// and single node deployments. Keys cannot escape the root, symbolic links included.
+// Content types are not stored: they are derived from the key extension or the content.
+type LocalStorage struct {
+	root *os.Root
+	cfg  LocalConfig
+	now  func() time.Time
+}
+
+// NewLocalStorage creates a LocalStorage, creating the root directory when missing.
+// Close it to release the root directory.
+func NewLocalStorage(cfg LocalConfig) (*LocalStorage, error) {
+	if cfg.Root == "" {
+		return nil, ErrMissingRoot
+	}
+	if err := os.MkdirAll(cfg.Root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage root: %w", err)
+	}
+	root, err := os.OpenRoot(cfg.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage root: %w", err)
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	return &LocalStorage{root: root, cfg: cfg, now: time.Now}, nil
+}
+
+// Close releases the root directory.
+func (s *LocalStorage) Close() error {
+	return s.root.Close()
+}
+
+// Put implements Storage. The object is written to a temporary file renamed once
+// complete, so readers never see a partial object.
+func (s *LocalStorage) Put(_ context.Context, key string, body io.Reader, _ ...PutOption) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	dir := path.Dir(key)
+	if err := s.root.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	temp := path.Join(dir, tempPrefix+hex.EncodeToString(suffix))
+	file, err := s.root.OpenFile(temp, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to create object file: %w", err)
+	}
+	_, err = io.Copy(file, body)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = s.root.Rename(temp, key)
+	}
+	if err != nil {
+		_ = s.root.Remove(temp)
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	return nil
+}
+
+// Get implements Storage. The returned reader is an *os.File, so it also implements
+// io.Seeker.
+func (s *LocalStorage) Get(_ context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, nil, err
+	}
+	file, err := s.root.Open(key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open object: %w", err)
+	}
+
+	stat, err := file.Stat()
+	if err == nil && stat.IsDir() {
+		err = ErrNotFound
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+	contentType, err := fileContentType(key, file)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+	info := objectInfo(key, stat)
+	info.ContentType = contentType
+	return file, &info, nil
+}
+
+// Delete implements Storage.
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := s.root.Remove(key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// List implements Storage. The listing has no content types.
+func (s *LocalStorage) List(ctx context.Context, prefix string, fn func(info ObjectInfo) error) error {
+	// Only the directory of the prefix is walked
+	start := "."
+	if dir := path.Dir(prefix); strings.Contains(prefix, "/") && dir != "." {
+		start = dir
+	}
+
+	var objects []ObjectInfo
+	err := fs.WalkDir(s.root.FS(), start, func(name string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && name == start {
+			return fs.SkipAll
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), tempPrefix) || !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		stat, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// Deleted during the walk
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		objects = append(objects, objectInfo(name, stat))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	// The walk is ordered by path segment, which differs from the key order
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	for _, object := range objects {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if err = fn(object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Presign implements Storage. The URL points to the Handler, mounted at the configured
+// base URL, and is signed with the configured secret.
+func (s *LocalStorage) Presign(_ context.Context, method, key string, expires time.Duration) (string, error) {
+	if method != http.MethodGet && method != http.MethodPut {
+		return "", ErrUnsupportedMethod
+	}
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if s.cfg.BaseURL == "" || s.cfg.Secret == "" {
+		return "", ErrPresignUnavailable
+	}
+
+	expiresAt := strconv.FormatInt(s.now().Add(expires).Unix(), 10)
+	query := url.Values{
+		"expires":   {expiresAt},
+		"signature": {s.signature(method, key, expiresAt)},
+	}
+	return s.cfg.BaseURL + (&url.URL{Path: "/" + key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+// Handler serves the presigned URLs: GET downloads the object, with range support, and
+// PUT uploads it. Mount it at the base URL with the path prefix stripped:
+//
+//	mux.Handle("/files/", http.StripPrefix("/files", local.Handler()))
+func (s *LocalStorage) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		if err := s.verify(r.Method, key, r.URL.Query()); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			s.serveGet(w, r, key)
+		case http.MethodPut:
+			var opts []PutOption
+			if contentType := r.Header.Get("Content-Type"); contentType != "" {
+				opts = append(opts, WithContentType(contentType))
+			}
+			if err := s.Put(r.Context(), key, r.Body, opts...); err != nil {
+				writeHandlerError(w, err)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, ErrUnsupportedMethod.Error(), http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (s *LocalStorage) serveGet(w http.ResponseWriter, r *http.Request, key string) {
+	body, info, err := s.Get(r.Context(), key)
+	if err != nil {
+		writeHandlerError(w, err)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", info.ContentType)
+	w.Header().Set("ETag", `"`+info.ETag+`"`)
+	http.ServeContent(w, r, path.Base(key), info.LastModified, body.(io.ReadSeeker))
+}
+
+func writeHandlerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrInvalidKey):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// verify checks the signature and the expiration of a presigned URL.
+func (s *LocalStorage) verify(method, key string, query url.Values) error {
+	if s.cfg.Secret == "" {
+		return ErrPresignUnavailable
+	}
+	expiresAt := query.Get("expires")
+	unix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil || s.now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+	expected := s.signature(method, key, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("signature"))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStorage) signature(method, key, expiresAt string) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Secret))
+	mac.Write([]byte(method + "\n" + key + "\n" + expiresAt))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// fileContentType returns the content type of the key extension, or else the one
+// sniffed from the file, rewound afterwards.
+func fileContentType(key string, file *os.File) (string, error) {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType, nil
+	}
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read object: %w", err)
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read object: %w", err)
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+// objectInfo returns the info of a file; the ETag is derived from its modification
+// time and size, as the ETags of static file servers.
+func objectInfo(key string, stat fs.FileInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ETag:         fmt.Sprintf("%x-%x", stat.ModTime().UnixNano(), stat.Size()),
+		LastModified: stat.ModTime(),
+	}
+}
@@ -0,0 +1,213 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/storage"
+)
+
+type LocalStorageTestSuite struct {
+	suite.Suite
+	now time.Time
+	sut *storage.LocalStorage
+}
+
+func TestLocalStorageSuite(t *testing.T) {
+	suite.Run(t, new(LocalStorageTestSuite))
+}
+
+func (s *LocalStorageTestSuite) SetupTest() {
+	sut, err := storage.NewLocalStorage(storage.LocalConfig{
+		Root:    s.T().TempDir(),
+		BaseURL: "http://localhost/files/",
+		Secret:  "secret",
+	})
+	s.Require().NoError(err)
+	s.now = time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	sut.SetNow(func() time.Time { return s.now })
+	s.sut = sut
+	s.T().Cleanup(func() { _ = sut.Close() })
+}
+
+func (s *LocalStorageTestSuite) put(key, content string) {
+	s.Require().NoError(s.sut.Put(context.Background(), key, strings.NewReader(content)))
+}
+
+func (s *LocalStorageTestSuite) TestPutAndGet_StoresObject() {
+	// Arrange
+	s.put("invoices/2026/42.pdf", "%PDF-1.7")
+
+	// Act
+	body, info, err := s.sut.Get(context.Background(), "invoices/2026/42.pdf")
+
+	// Assert
+	s.Require().NoError(err)
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	s.Equal("%PDF-1.7", string(content))
+	s.Equal("invoices/2026/42.pdf", info.Key)
+	s.Equal(int64(8), info.Size)
+	s.Equal("application/pdf", info.ContentType)
+	s.NotEmpty(info.ETag)
+}
+
+func (s *LocalStorageTestSuite) TestGet_KeyWithoutExtension_SniffsContentType() {
+	// Arrange
+	s.put("images/avatar", "\x89PNG\r\n\x1a\n0000")
+
+	// Act
+	body, info, err := s.sut.Get(context.Background(), "images/avatar")
+
+	// Assert
+	s.Require().NoError(err)
+	defer body.Close()
+	s.Equal("image/png", info.ContentType)
+	content, _ := io.ReadAll(body)
+	s.Equal("\x89PNG\r\n\x1a\n0000", string(content))
+}
+
+func (s *LocalStorageTestSuite) TestGet_MissingObject_ReturnsErrNotFound() {
+	// Act
+	_, _, err := s.sut.Get(context.Background(), "missing.txt")
+
+	// Assert
+	s.ErrorIs(err, storage.ErrNotFound)
+}
+
+func (s *LocalStorageTestSuite) TestPut_InvalidKey_ReturnsErrInvalidKey() {
+	for _, key := range []string{"", "/etc/passwd", "../escape.txt", "a/./b"} {
+		// Act
+		err := s.sut.Put(context.Background(), key, strings.NewReader("x"))
+
+		// Assert
+		s.ErrorIs(err, storage.ErrInvalidKey, key)
+	}
+}
+
+func (s *LocalStorageTestSuite) TestDelete_RemovesObject() {
+	// Arrange
+	s.put("notes.txt", "hello")
+
+	// Act
+	err := s.sut.Delete(context.Background(), "notes.txt")
+	errMissing := s.sut.Delete(context.Background(), "notes.txt")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().NoError(errMissing)
+	_, _, err = s.sut.Get(context.Background(), "notes.txt")
+	s.ErrorIs(err, storage.ErrNotFound)
+}
+
+func (s *LocalStorageTestSuite) TestList_ReturnsObjectsWithPrefixByKeyOrder() {
+	// Arrange
+	for _, key := range []string{"a/2", "a/1", "a-b", "b/1", "a/sub/3"} {
+		s.put(key, key)
+	}
+
+	// Act
+	var keys []string
+	err := s.sut.List(context.Background(), "a", func(info storage.ObjectInfo) error {
+		keys = append(keys, info.Key)
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{"a-b", "a/1", "a/2", "a/sub/3"}, keys)
+}
+
+func (s *LocalStorageTestSuite) TestList_MissingDirectory_ReturnsNothing() {
+	// Act
+	var calls int
+	err := s.sut.List(context.Background(), "missing/", func(storage.ObjectInfo) error {
+		calls++
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Zero(calls)
+}
+
+func (s *LocalStorageTestSuite) TestHandler_PresignedURLs_ServeUploadsAndDownloads() {
+	// Arrange
+	handler := http.StripPrefix("/files", s.sut.Handler())
+	putURL, err := s.sut.Presign(context.Background(), http.MethodPut, "docs/readme.txt", time.Hour)
+	s.Require().NoError(err)
+	getURL, err := s.sut.Presign(context.Background(), http.MethodGet, "docs/readme.txt", time.Hour)
+	s.Require().NoError(err)
+
+	// Act
+	put := httptest.NewRecorder()
+	handler.ServeHTTP(put, httptest.NewRequest(http.MethodPut, putURL, strings.NewReader("read me")))
+	get := httptest.NewRecorder()
+	handler.ServeHTTP(get, httptest.NewRequest(http.MethodGet, getURL, nil))
+
+	// Assert
+	s.Equal(http.StatusOK, put.Code)
+	s.Equal(http.StatusOK, get.Code)
+	s.Equal("read me", get.Body.String())
+	s.Equal("text/plain; charset=utf-8", get.Header().Get("Content-Type"))
+}
+
+func (s *LocalStorageTestSuite) TestHandler_InvalidSignature_ReturnsForbidden() {
+	// Arrange
+	s.put("docs/readme.txt", "read me")
+	handler := http.StripPrefix("/files", s.sut.Handler())
+	getURL, err := s.sut.Presign(context.Background(), http.MethodGet, "docs/readme.txt", time.Hour)
+	s.Require().NoError(err)
+	otherKey := strings.Replace(getURL, "readme", "secret", 1)
+
+	// Act
+	tampered := httptest.NewRecorder()
+	handler.ServeHTTP(tampered, httptest.NewRequest(http.MethodGet, otherKey, nil))
+	wrongMethod := httptest.NewRecorder()
+	handler.ServeHTTP(wrongMethod, httptest.NewRequest(http.MethodPut, getURL, strings.NewReader("x")))
+	s.now = s.now.Add(2 * time.Hour)
+	expired := httptest.NewRecorder()
+	handler.ServeHTTP(expired, httptest.NewRequest(http.MethodGet, getURL, nil))
+
+	// Assert
+	s.Equal(http.StatusForbidden, tampered.Code)
+	s.Equal(http.StatusForbidden, wrongMethod.Code)
+	s.Equal(http.StatusForbidden, expired.Code)
+}
+
+func (s *LocalStorageTestSuite) TestPresign_URLIsRelativeToBaseURL() {
+	// Act
+	signed, err := s.sut.Presign(context.Background(), http.MethodGet, "a b/c.txt", time.Minute)
+
+	// Assert
+	s.Require().NoError(err)
+	u, err := url.Parse(signed)
+	s.Require().NoError(err)
+	s.Equal("/files/a%20b/c.txt", u.EscapedPath())
+	s.Equal("1792058460", u.Query().Get("expires"))
+}
+
+func TestLocalStorage_Presign(t *testing.T) {
+	t.Run("requires a base url and a secret", func(t *testing.T) {
+		// Arrange
+		sut, err := storage.NewLocalStorage(storage.LocalConfig{Root: t.TempDir()})
+		require.NoError(t, err)
+		defer sut.Close()
+
+		// Act
+		_, err = sut.Presign(context.Background(), http.MethodGet, "a.txt", time.Minute)
+
+		// Assert
+		assert.ErrorIs(t, err, storage.ErrPresignUnavailable)
+	})
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type options struct {
+	s3Client   *s3.Client
+	httpClient *http.Client
+}
+
+// Option configures the Storage created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{}
+}
+
+// WithS3Client sets the S3 client of the s3 driver, e.g. aws.NewS3 of the aws.Session.
+// The s3 driver requires it.
+func WithS3Client(client *s3.Client) Option {
+	return func(o *options) {
+		o.s3Client = client
+	}
+}
+
+// WithHTTPClient sets the HTTP client of the gcs driver. Defaults to a client with the
+// configured timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
@@ -0,0 +1,311 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// S3Storage stores the objects in an S3 bucket through the S3 client of the AWS SDK, which
+// AWS S3, S3 compatible services and the GCS XML API serve. Bodies larger than the part
+// size are streamed with a multipart upload, holding one part in memory at a time.
+type S3Storage struct {
+	cfg     S3Config
+	client  *s3.Client
+	presign *s3.PresignClient
+}
+
+// NewS3Storage creates an S3Storage of the bucket, called with client.
+func NewS3Storage(cfg S3Config, client *s3.Client) (*S3Storage, error) {
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrMissingS3Client
+	}
+	return &S3Storage{cfg: cfg, client: client, presign: s3.NewPresignClient(client)}, nil
+}
+
+// NewGCSStorage creates a Storage for a Google Cloud Storage bucket, through its S3
+// compatible XML API authenticated with HMAC keys. A nil client uses a client with the
+// configured timeout.
+func NewGCSStorage(cfg GCSConfig, client *http.Client) (*S3Storage, error) {
+	if cfg.Bucket == "" {
+		return nil, ErrMissingBucket
+	}
+	s3Config := cfg.s3Config()
+	s3Config.SetDefaults()
+	var httpClient s3.HTTPClient = awshttp.NewBuildableClient().WithTimeout(s3Config.Timeout)
+	if client != nil {
+		httpClient = client
+	}
+	return NewS3Storage(s3Config, s3.New(s3.Options{
+		Region:       gcsRegion,
+		BaseEndpoint: awssdk.String(gcsEndpoint),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.Secret, ""),
+		HTTPClient:   httpClient,
+		// The XML API rejects the checksums the SDK adds by default
+		RequestChecksumCalculation:                awssdk.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation:                awssdk.ResponseChecksumValidationWhenRequired,
+		DisableLogOutputChecksumValidationSkipped: true,
+	}))
+}
+
+// Put implements Storage.
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, opts ...PutOption) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	o := applyPutOptions(opts)
+	if o.contentType == "" {
+		o.contentType, body = detectContentType(key, body)
+	}
+
+	first, err := readPart(body, s.cfg.PartSize)
+	if err != nil {
+		return err
+	}
+	var second []byte
+	if int64(len(first)) == s.cfg.PartSize {
+		if second, err = readPart(body, s.cfg.PartSize); err != nil {
+			return err
+		}
+	}
+	if len(second) == 0 {
+		_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:        awssdk.String(s.cfg.Bucket),
+			Key:           awssdk.String(key),
+			Body:          bytes.NewReader(first),
+			ContentLength: awssdk.Int64(int64(len(first))),
+			ContentType:   awssdk.String(o.contentType),
+			CacheControl:  optionalString(o.cacheControl),
+			Metadata:      o.metadata,
+		})
+		return err
+	}
+	return s.putMultipart(ctx, key, o, body, first, second)
+}
+
+func (s *S3Storage) putMultipart(
+	ctx context.Context,
+	key string,
+	o putOptions,
+	body io.Reader,
+	parts ...[]byte,
+) error {
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:       awssdk.String(s.cfg.Bucket),
+		Key:          awssdk.String(key),
+		ContentType:  awssdk.String(o.contentType),
+		CacheControl: optionalString(o.cacheControl),
+		Metadata:     o.metadata,
+	}
+	// The parts of an upload carry the checksum the upload is created with
+	if s.client.Options().RequestChecksumCalculation != awssdk.RequestChecksumCalculationWhenRequired {
+		input.ChecksumAlgorithm = s3types.ChecksumAlgorithmCrc32
+	}
+	upload, err := s.client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	err = s.uploadParts(ctx, key, upload, body, parts)
+	if err != nil {
+		// Abort with a fresh context, so a canceled upload does not leave its parts billed
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.cfg.Timeout)
+		defer cancel()
+		_, _ = s.client.AbortMultipartUpload(abortCtx, &s3.AbortMultipartUploadInput{
+			Bucket:   awssdk.String(s.cfg.Bucket),
+			Key:      awssdk.String(key),
+			UploadId: upload.UploadId,
+		})
+	}
+	return err
+}
+
+func (s *S3Storage) uploadParts(
+	ctx context.Context,
+	key string,
+	upload *s3.CreateMultipartUploadOutput,
+	body io.Reader,
+	parts [][]byte,
+) error {
+	var completed []s3types.CompletedPart
+	for number := int32(1); ; number++ {
+		var part []byte
+		if len(parts) > 0 {
+			part, parts = parts[0], parts[1:]
+		} else {
+			var err error
+			if part, err = readPart(body, s.cfg.PartSize); err != nil {
+				return err
+			}
+			if len(part) == 0 {
+				break
+			}
+		}
+
+		uploaded, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:            awssdk.String(s.cfg.Bucket),
+			Key:               awssdk.String(key),
+			UploadId:          upload.UploadId,
+			PartNumber:        awssdk.Int32(number),
+			Body:              bytes.NewReader(part),
+			ContentLength:     awssdk.Int64(int64(len(part))),
+			ChecksumAlgorithm: upload.ChecksumAlgorithm,
+		})
+		if err != nil {
+			return err
+		}
+		completed = append(completed, s3types.CompletedPart{
+			PartNumber:    awssdk.Int32(number),
+			ETag:          uploaded.ETag,
+			ChecksumCRC32: uploaded.ChecksumCRC32,
+		})
+	}
+
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          awssdk.String(s.cfg.Bucket),
+		Key:             awssdk.String(key),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &s3types.CompletedMultipartUpload{Parts: completed},
+	})
+	return err
+}
+
+// Get implements Storage.
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, nil, err
+	}
+	object, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: awssdk.String(s.cfg.Bucket),
+		Key:    awssdk.String(key),
+	})
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+
+	return object.Body, &ObjectInfo{
+		Key:          key,
+		Size:         awssdk.ToInt64(object.ContentLength),
+		ContentType:  awssdk.ToString(object.ContentType),
+		ETag:         strings.Trim(awssdk.ToString(object.ETag), `"`),
+		LastModified: awssdk.ToTime(object.LastModified),
+	}, nil
+}
+
+// Delete implements Storage.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: awssdk.String(s.cfg.Bucket),
+		Key:    awssdk.String(key),
+	})
+	if err = notFound(err); errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// List implements Storage. The listing has no content types.
+func (s *S3Storage) List(ctx context.Context, prefix string, fn func(info ObjectInfo) error) error {
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: awssdk.String(s.cfg.Bucket),
+		Prefix: awssdk.String(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, object := range page.Contents {
+			err = fn(ObjectInfo{
+				Key:          awssdk.ToString(object.Key),
+				Size:         awssdk.ToInt64(object.Size),
+				ETag:         strings.Trim(awssdk.ToString(object.ETag), `"`),
+				LastModified: awssdk.ToTime(object.LastModified),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Presign implements Storage. S3 accepts expirations up to 7 days.
+func (s *S3Storage) Presign(ctx context.Context, method, key string, expires time.Duration) (string, error) {
+	if method != http.MethodGet && method != http.MethodPut {
+		return "", ErrUnsupportedMethod
+	}
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	var request *v4.PresignedHTTPRequest
+	var err error
+	if method == http.MethodGet {
+		request, err = s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: awssdk.String(s.cfg.Bucket),
+			Key:    awssdk.String(key),
+		}, s3.WithPresignExpires(expires))
+	} else {
+		request, err = s.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: awssdk.String(s.cfg.Bucket),
+			Key:    awssdk.String(key),
+		}, s3.WithPresignExpires(expires))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to presign %s %s: %w", method, key, err)
+	}
+	return request.URL, nil
+}
+
+// notFound returns ErrNotFound for the 404 responses of a missing object, and err for the
+// others, e.g. of a missing bucket.
+func notFound(err error) error {
+	var responseErr *awshttp.ResponseError
+	if !errors.As(err, &responseErr) || responseErr.HTTPStatusCode() != http.StatusNotFound {
+		return err
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucket" {
+		return err
+	}
+	return ErrNotFound
+}
+
+// optionalString returns nil for an empty value, which the SDK leaves out of the request.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// readPart reads up to size bytes of body.
+func readPart(body io.Reader, size int64) ([]byte, error) {
+	var part bytes.Buffer
+	if _, err := part.ReadFrom(io.LimitReader(body, size)); err != nil {
+		return nil, fmt.Errorf("failed to read object body: %w", err)
+	}
+	return part.Bytes(), nil
+}
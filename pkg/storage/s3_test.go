@@ -0,0 +1,343 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+	"github.com/cristiano-pacheco/bricks/pkg/storage"
+)
+
+const partSize = 5 << 20
+
+// fakeS3 is an in-memory S3 bucket served with path style addressing, listing two
+// objects per page.
+type fakeS3 struct {
+	*httptest.Server
+	mu       sync.Mutex
+	objects  map[string][]byte
+	headers  map[string]http.Header
+	uploads  map[string]map[int][]byte
+	requests []string
+}
+
+func newFakeS3(t *testing.T) *fakeS3 {
+	t.Helper()
+
+	f := &fakeS3{
+		objects: make(map[string][]byte),
+		headers: make(map[string]http.Header),
+		uploads: make(map[string]map[int][]byte),
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeS3) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/bucket"), "/")
+	query := r.URL.Query()
+	// The SDK names the operation in x-id
+	query.Del("x-id")
+	f.requests = append(f.requests, strings.TrimSuffix(r.Method+" "+r.URL.Path+"?"+query.Encode(), "?"))
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		f.list(w, query)
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.uploads["upload-1"] = make(map[int][]byte)
+		f.headers[key] = r.Header.Clone()
+		_, _ = w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`))
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		f.uploads[query.Get("uploadId")][number] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		parts := f.uploads[query.Get("uploadId")]
+		var object []byte
+		for number := 1; number <= len(parts); number++ {
+			object = append(object, parts[number]...)
+		}
+		f.objects[key] = object
+		_, _ = w.Write([]byte(`<CompleteMultipartUploadResult><Key>` + key + `</Key></CompleteMultipartUploadResult>`))
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(f.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+		f.headers[key] = r.Header.Clone()
+	case r.Method == http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>missing</Message></Error>`))
+			return
+		}
+		w.Header().Set("Content-Type", f.headers[key].Get("Content-Type"))
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Last-Modified", "Wed, 14 Oct 2026 10:00:00 GMT")
+		_, _ = w.Write(object)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, query url.Values) {
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, query.Get("prefix")) && key > query.Get("continuation-token") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var page bytes.Buffer
+	page.WriteString("<ListBucketResult>")
+	if len(keys) > 2 {
+		keys = keys[:2]
+		page.WriteString("<IsTruncated>true</IsTruncated><NextContinuationToken>" + keys[1] + "</NextContinuationToken>")
+	}
+	for _, key := range keys {
+		page.WriteString("<Contents><Key>")
+		_ = xml.EscapeText(&page, []byte(key))
+		fmt.Fprintf(&page, "</Key><Size>%d</Size><ETag>&quot;abc&quot;</ETag>", len(f.objects[key]))
+		page.WriteString("<LastModified>2026-10-14T10:00:00.000Z</LastModified></Contents>")
+	}
+	page.WriteString("</ListBucketResult>")
+	_, _ = w.Write(page.Bytes())
+}
+
+func newS3Client(t *testing.T, endpoint string) *s3.Client {
+	t.Helper()
+	session, err := aws.NewSession(aws.Config{
+		Region:          "eu-west-1",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		Endpoint:        endpoint,
+		Retry:           aws.RetryConfig{MaxAttempts: 1},
+	}, aws.WithRegisterer(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	return aws.NewS3(session)
+}
+
+type S3StorageTestSuite struct {
+	suite.Suite
+	server *fakeS3
+	sut    *storage.S3Storage
+}
+
+func TestS3StorageSuite(t *testing.T) {
+	suite.Run(t, new(S3StorageTestSuite))
+}
+
+func (s *S3StorageTestSuite) SetupTest() {
+	s.server = newFakeS3(s.T())
+	sut, err := storage.NewS3Storage(storage.S3Config{
+		Bucket:   "bucket",
+		PartSize: partSize,
+	}, newS3Client(s.T(), s.server.URL))
+	s.Require().NoError(err)
+	s.sut = sut
+}
+
+func (s *S3StorageTestSuite) TestPut_SmallBody_UploadsInOneRequest() {
+	// Arrange
+	body := strings.NewReader("<html><body>hi</body></html>")
+
+	// Act
+	err := s.sut.Put(context.Background(), "pages/index", body,
+		storage.WithCacheControl("max-age=60"),
+		storage.WithMetadata(map[string]string{"owner": "ana"}),
+	)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{"PUT /bucket/pages/index"}, s.server.requests)
+	s.Equal("<html><body>hi</body></html>", string(s.server.objects["pages/index"]))
+	header := s.server.headers["pages/index"]
+	s.Equal("text/html; charset=utf-8", header.Get("Content-Type"))
+	s.Equal("max-age=60", header.Get("Cache-Control"))
+	s.Equal("ana", header.Get("X-Amz-Meta-Owner"))
+	s.Contains(header.Get("Authorization"), "/eu-west-1/s3/aws4_request")
+}
+
+func (s *S3StorageTestSuite) TestPut_LargeBody_UploadsInParts() {
+	// Arrange
+	body := bytes.Repeat([]byte("a"), 2*partSize+10)
+
+	// Act
+	err := s.sut.Put(context.Background(), "backups/db.bin", bytes.NewReader(body))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{
+		"POST /bucket/backups/db.bin?uploads=",
+		"PUT /bucket/backups/db.bin?partNumber=1&uploadId=upload-1",
+		"PUT /bucket/backups/db.bin?partNumber=2&uploadId=upload-1",
+		"PUT /bucket/backups/db.bin?partNumber=3&uploadId=upload-1",
+		"POST /bucket/backups/db.bin?uploadId=upload-1",
+	}, s.server.requests)
+	s.Equal(body, s.server.objects["backups/db.bin"])
+	s.Equal("application/octet-stream", s.server.headers["backups/db.bin"].Get("Content-Type"))
+}
+
+func (s *S3StorageTestSuite) TestPut_BodyOfOnePart_UploadsInOneRequest() {
+	// Arrange
+	body := bytes.Repeat([]byte("a"), partSize)
+
+	// Act
+	err := s.sut.Put(context.Background(), "exact.bin", bytes.NewReader(body))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{"PUT /bucket/exact.bin"}, s.server.requests)
+}
+
+func (s *S3StorageTestSuite) TestPut_ReadError_AbortsUpload() {
+	// Arrange
+	body := io.MultiReader(bytes.NewReader(bytes.Repeat([]byte("a"), 2*partSize+1)), failingReader{})
+
+	// Act
+	err := s.sut.Put(context.Background(), "broken.bin", body)
+
+	// Assert
+	s.Require().Error(err)
+	s.Contains(s.server.requests, "DELETE /bucket/broken.bin?uploadId=upload-1")
+	s.Empty(s.server.uploads)
+}
+
+func (s *S3StorageTestSuite) TestGet_ExistingObject_ReturnsContentAndInfo() {
+	// Arrange
+	s.Require().NoError(s.sut.Put(context.Background(), "notes.txt", strings.NewReader("hello")))
+
+	// Act
+	body, info, err := s.sut.Get(context.Background(), "notes.txt")
+
+	// Assert
+	s.Require().NoError(err)
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	s.Equal("hello", string(content))
+	s.Equal("notes.txt", info.Key)
+	s.Equal(int64(5), info.Size)
+	s.Equal("text/plain; charset=utf-8", info.ContentType)
+	s.Equal("abc", info.ETag)
+	s.Equal(2026, info.LastModified.Year())
+}
+
+func (s *S3StorageTestSuite) TestGet_MissingObject_ReturnsErrNotFound() {
+	// Act
+	_, _, err := s.sut.Get(context.Background(), "missing.txt")
+
+	// Assert
+	s.ErrorIs(err, storage.ErrNotFound)
+}
+
+func (s *S3StorageTestSuite) TestDelete_RemovesObject() {
+	// Arrange
+	s.Require().NoError(s.sut.Put(context.Background(), "notes.txt", strings.NewReader("hello")))
+
+	// Act
+	err := s.sut.Delete(context.Background(), "notes.txt")
+
+	// Assert
+	s.Require().NoError(err)
+	s.NotContains(s.server.objects, "notes.txt")
+}
+
+func (s *S3StorageTestSuite) TestList_FollowsContinuationTokens() {
+	// Arrange
+	for _, key := range []string{"a/1", "a/2", "a/3", "b/1"} {
+		s.Require().NoError(s.sut.Put(context.Background(), key, strings.NewReader(key)))
+	}
+
+	// Act
+	var keys []string
+	err := s.sut.List(context.Background(), "a/", func(info storage.ObjectInfo) error {
+		keys = append(keys, info.Key)
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{"a/1", "a/2", "a/3"}, keys)
+}
+
+func (s *S3StorageTestSuite) TestPresign_ReturnsSignedURL() {
+	// Act
+	signed, err := s.sut.Presign(context.Background(), http.MethodGet, "invoices/42.pdf", time.Hour)
+
+	// Assert
+	s.Require().NoError(err)
+	u, err := url.Parse(signed)
+	s.Require().NoError(err)
+	s.Equal("/bucket/invoices/42.pdf", u.Path)
+	s.Equal("3600", u.Query().Get("X-Amz-Expires"))
+	s.NotEmpty(u.Query().Get("X-Amz-Signature"))
+}
+
+func (s *S3StorageTestSuite) TestPresign_UnsupportedMethod_ReturnsError() {
+	// Act
+	_, err := s.sut.Presign(context.Background(), http.MethodDelete, "invoices/42.pdf", time.Hour)
+
+	// Assert
+	s.ErrorIs(err, storage.ErrUnsupportedMethod)
+}
+
+func TestNewS3Storage(t *testing.T) {
+	t.Run("requires a bucket and an s3 client", func(t *testing.T) {
+		// Act
+		_, errBucket := storage.NewS3Storage(storage.S3Config{}, newS3Client(t, ""))
+		_, errClient := storage.NewS3Storage(storage.S3Config{Bucket: "bucket"}, nil)
+
+		// Assert
+		assert.ErrorIs(t, errBucket, storage.ErrMissingBucket)
+		assert.ErrorIs(t, errClient, storage.ErrMissingS3Client)
+	})
+
+	t.Run("addresses the bucket in the host without an endpoint override", func(t *testing.T) {
+		// Arrange
+		sut, err := storage.NewS3Storage(storage.S3Config{Bucket: "bucket"}, newS3Client(t, ""))
+		require.NoError(t, err)
+
+		// Act
+		signed, err := sut.Presign(context.Background(), http.MethodPut, "a.txt", time.Minute)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(signed, "https://bucket.s3.eu-west-1.amazonaws.com/a.txt?"))
+	})
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// sniffLen is the number of bytes http.DetectContentType looks at
+const sniffLen = 512
+
+// Storage stores objects by key, e.g. "invoices/2026/42.pdf". Keys use slashes as
+// separators and must not be empty or contain ".." segments.
+type Storage interface {
+	// Put stores the content of body under key, streaming it. The content type is
+	// detected when not set with WithContentType.
+	Put(ctx context.Context, key string, body io.Reader, opts ...PutOption) error
+	// Get returns the content of the object, to be closed by the caller, and its info.
+	// It returns ErrNotFound when the object does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	// Delete removes the object; deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// List calls fn with the objects whose key starts with prefix, by key order.
+	// An error returned by fn stops the listing.
+	List(ctx context.Context, prefix string, fn func(info ObjectInfo) error) error
+	// Presign returns a URL granting method (GET or PUT) on the object for expires,
+	// without credentials, e.g. for browser uploads and downloads.
+	Presign(ctx context.Context, method, key string, expires time.Duration) (string, error)
+}
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+type putOptions struct {
+	contentType  string
+	cacheControl string
+	metadata     map[string]string
+}
+
+// PutOption configures a Put.
+type PutOption func(*putOptions)
+
+// WithContentType sets the content type of the object instead of detecting it.
+func WithContentType(contentType string) PutOption {
+	return func(o *putOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithCacheControl sets the Cache-Control header served with the object (s3 and gcs).
+func WithCacheControl(cacheControl string) PutOption {
+	return func(o *putOptions) {
+		o.cacheControl = cacheControl
+	}
+}
+
+// WithMetadata stores user metadata with the object (s3 and gcs).
+func WithMetadata(metadata map[string]string) PutOption {
+	return func(o *putOptions) {
+		o.metadata = metadata
+	}
+}
+
+func applyPutOptions(opts []PutOption) putOptions {
+	var o putOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// detectContentType returns the content type of the object: the one of the key
+// extension, or else the one sniffed from the first bytes of body. The returned reader
+// replays the sniffed bytes.
+func detectContentType(key string, body io.Reader) (string, io.Reader) {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType, body
+	}
+	buffered := bufio.NewReaderSize(body, sniffLen)
+	head, _ := buffered.Peek(sniffLen)
+	return http.DetectContentType(head), buffered
+}
+
+// validateKey rejects the keys that are empty, absolute or escape their prefix.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." || segment == "." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
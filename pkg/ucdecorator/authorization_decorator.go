@@ -0,0 +1,36 @@
+package ucdecorator
+
+import "context"
+
+type authorizationDecorator[T any, R any] struct {
+	base       UseCase[T, R]
+	authorizer Authorizer
+	action     string
+	resource   string
+}
+
+func withAuthorization[T any, R any](
+	base UseCase[T, R],
+	authorizer Authorizer,
+	action, resource string,
+) UseCase[T, R] {
+	return &authorizationDecorator[T, R]{
+		base:       base,
+		authorizer: authorizer,
+		action:     action,
+		resource:   resource,
+	}
+}
+
+// Execute runs the use case only when the subject of the context is allowed.
+func (decorator *authorizationDecorator[T, R]) Execute(ctx context.Context, input T) (R, error) {
+	if decorator.authorizer == nil {
+		var zero R
+		return zero, ErrMissingAuthorizer
+	}
+	if err := decorator.authorizer.Authorize(ctx, decorator.action, decorator.resource); err != nil {
+		var zero R
+		return zero, err
+	}
+	return decorator.base.Execute(ctx, input)
+}
@@ -0,0 +1,83 @@
+package ucdecorator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorizationDecoratorTestSuite struct {
+	suite.Suite
+	sut            ucdecorator.UseCase[string, string]
+	baseMock       *mocks.MockUseCase[string, string]
+	authorizerMock *mocks.MockAuthorizer
+}
+
+func (s *AuthorizationDecoratorTestSuite) SetupTest() {
+	s.baseMock = mocks.NewMockUseCase[string, string](s.T())
+	s.authorizerMock = mocks.NewMockAuthorizer(s.T())
+	s.sut = ucdecorator.WithAuthorization(s.baseMock, s.authorizerMock, "update", "orders")
+}
+
+func TestAuthorizationDecoratorSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizationDecoratorTestSuite))
+}
+
+func (s *AuthorizationDecoratorTestSuite) TestExecute_Allowed_RunsBase() {
+	// Arrange
+	ctx := context.Background()
+	s.authorizerMock.On("Authorize", ctx, "update", "orders").Return(nil)
+	s.baseMock.On("Execute", ctx, "input").Return("output", nil)
+
+	// Act
+	result, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("output", result)
+}
+
+func (s *AuthorizationDecoratorTestSuite) TestExecute_Denied_ReturnsErrorWithoutRunningBase() {
+	// Arrange
+	ctx := context.Background()
+	deniedErr := errors.New("forbidden")
+	s.authorizerMock.On("Authorize", ctx, "update", "orders").Return(deniedErr)
+
+	// Act
+	result, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().ErrorIs(err, deniedErr)
+	s.Empty(result)
+	s.baseMock.AssertNotCalled(s.T(), "Execute")
+}
+
+func (s *AuthorizationDecoratorTestSuite) TestExecute_WithoutAuthorizer_Denies() {
+	// Arrange
+	sut := ucdecorator.WithAuthorization(s.baseMock, nil, "update", "orders")
+
+	// Act
+	_, err := sut.Execute(context.Background(), "input")
+
+	// Assert
+	s.Require().ErrorIs(err, ucdecorator.ErrMissingAuthorizer)
+}
+
+func (s *AuthorizationDecoratorTestSuite) TestWrap_DecoratorsDisabled_StillAuthorizes() {
+	// Arrange
+	ctx := context.Background()
+	factory := ucdecorator.NewTestFactoryWithAuthorizer(ucdecorator.Config{Enabled: false}, s.authorizerMock)
+	sut := ucdecorator.Wrap(factory, ucdecorator.UseCase[string, string](s.baseMock),
+		ucdecorator.WithPermission("update", "orders"))
+	s.authorizerMock.On("Authorize", ctx, "update", "orders").Return(errors.New("forbidden"))
+
+	// Act
+	_, err := sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().EqualError(err, "forbidden")
+}
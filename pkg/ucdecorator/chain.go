@@ -1,6 +1,7 @@
 package ucdecorator
 
 import (
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 	"github.com/cristiano-pacheco/bricks/pkg/metrics"
 )
@@ -22,6 +23,7 @@ func Chain[T any, R any](
 			),
 			useCaseMetrics,
 			metricName,
+			clock.New(),
 		),
 		log,
 		useCaseName,
@@ -21,9 +21,18 @@ type Config struct {
 
 	// Translation controls whether the error translation decorator is applied.
 	Translation bool `config:"translation"`
+
+	// ErrorReporting controls whether the error reporting decorator is applied.
+	// Requires an errreport.Reporter; only the server errors (5xx) are reported.
+	ErrorReporting bool `config:"error_reporting"`
+
+	// Transactional controls whether the transaction decorator is applied.
+	// Requires a database.TxManager; use cases opt out with WithoutTransaction.
+	Transactional bool `config:"transactional"`
 }
 
-// DefaultConfig returns a configuration with all decorators enabled.
+// DefaultConfig returns a configuration with all decorators enabled except Transactional,
+// which holds a database connection for the whole use case.
 func DefaultConfig() Config {
 	return Config{
 		Enabled:     true,
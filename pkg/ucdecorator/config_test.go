@@ -8,7 +8,7 @@ import (
 )
 
 func TestDefaultConfig(t *testing.T) {
-	t.Run("returns config with all decorators enabled", func(t *testing.T) {
+	t.Run("returns config with all decorators enabled except transactional", func(t *testing.T) {
 		// Arrange & Act
 		cfg := ucdecorator.DefaultConfig()
 
@@ -19,5 +19,6 @@ func TestDefaultConfig(t *testing.T) {
 		require.True(t, cfg.Metrics)
 		require.True(t, cfg.Tracing)
 		require.True(t, cfg.Translation)
+		require.False(t, cfg.Transactional)
 	})
 }
@@ -0,0 +1,40 @@
+package ucdecorator
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+)
+
+type errorReportingDecorator[T any, R any] struct {
+	base     UseCase[T, R]
+	reporter errreport.Reporter
+	name     string
+}
+
+func withErrorReporting[T any, R any](base UseCase[T, R], reporter errreport.Reporter, name string) UseCase[T, R] {
+	if reporter == nil {
+		return base
+	}
+	return &errorReportingDecorator[T, R]{
+		base:     base,
+		reporter: reporter,
+		name:     name,
+	}
+}
+
+func (decorator *errorReportingDecorator[T, R]) Execute(ctx context.Context, input T) (R, error) {
+	output, err := decorator.base.Execute(ctx, input)
+	// Only the unexpected errors are reported: the client errors (4xx) are part of the
+	// normal flow of the use case
+	if err != nil && !errs.IsClientClosed(ctx, err) && errs.StatusOf(err) >= http.StatusInternalServerError {
+		decorator.reporter.Report(ctx, errreport.Event{
+			Message: decorator.name + " failed: " + err.Error(),
+			Err:     err,
+			Tags:    map[string]string{"use_case": decorator.name},
+		})
+	}
+	return output, err
+}
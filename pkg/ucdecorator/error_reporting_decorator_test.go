@@ -0,0 +1,108 @@
+package ucdecorator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type recordingReporter struct {
+	events []errreport.Event
+}
+
+func (r *recordingReporter) Report(_ context.Context, event errreport.Event) {
+	r.events = append(r.events, event)
+}
+
+func (r *recordingReporter) Flush(context.Context) error {
+	return nil
+}
+
+type ErrorReportingDecoratorTestSuite struct {
+	suite.Suite
+	sut      ucdecorator.UseCase[string, string]
+	baseMock *mocks.MockUseCase[string, string]
+	reporter *recordingReporter
+}
+
+func (s *ErrorReportingDecoratorTestSuite) SetupTest() {
+	s.baseMock = mocks.NewMockUseCase[string, string](s.T())
+	s.reporter = &recordingReporter{}
+	s.sut = ucdecorator.WithErrorReporting(s.baseMock, s.reporter, "CreateOrderUseCase.Execute")
+}
+
+func TestErrorReportingDecoratorSuite(t *testing.T) {
+	suite.Run(t, new(ErrorReportingDecoratorTestSuite))
+}
+
+func (s *ErrorReportingDecoratorTestSuite) TestWithErrorReporting_NilReporter_ReturnsBaseUnchanged() {
+	// Arrange
+	baseMock := mocks.NewMockUseCase[string, string](s.T())
+
+	// Act
+	result := ucdecorator.WithErrorReporting(baseMock, nil, "UseCase.Execute")
+
+	// Assert
+	s.Same(baseMock, result)
+}
+
+func (s *ErrorReportingDecoratorTestSuite) TestExecute_Success_DoesNotReport() {
+	// Arrange
+	s.baseMock.On("Execute", mock.Anything, "input").Return("output", nil)
+
+	// Act
+	result, err := s.sut.Execute(context.Background(), "input")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("output", result)
+	s.Empty(s.reporter.events)
+}
+
+func (s *ErrorReportingDecoratorTestSuite) TestExecute_UnexpectedError_ReportsError() {
+	// Arrange
+	expectedErr := errors.New("connection refused")
+	s.baseMock.On("Execute", mock.Anything, "input").Return("", expectedErr)
+
+	// Act
+	_, err := s.sut.Execute(context.Background(), "input")
+
+	// Assert
+	s.Require().ErrorIs(err, expectedErr)
+	s.Require().Len(s.reporter.events, 1)
+	s.Equal(expectedErr, s.reporter.events[0].Err)
+	s.Equal("CreateOrderUseCase.Execute", s.reporter.events[0].Tags["use_case"])
+}
+
+func (s *ErrorReportingDecoratorTestSuite) TestExecute_ClientError_DoesNotReport() {
+	// Arrange
+	s.baseMock.On("Execute", mock.Anything, "input").Return("", errs.NotFound("ORDER_NOT_FOUND", "Order not found"))
+
+	// Act
+	_, err := s.sut.Execute(context.Background(), "input")
+
+	// Assert
+	s.Require().Error(err)
+	s.Empty(s.reporter.events)
+}
+
+func (s *ErrorReportingDecoratorTestSuite) TestExecute_ClientClosed_DoesNotReport() {
+	// Arrange
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errs.ErrClientClosed)
+	s.baseMock.On("Execute", mock.Anything, "input").Return("", context.Canceled)
+
+	// Act
+	_, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().ErrorIs(err, context.Canceled)
+	s.Empty(s.reporter.events)
+}
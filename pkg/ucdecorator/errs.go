@@ -0,0 +1,7 @@
+package ucdecorator
+
+import "errors"
+
+// ErrMissingAuthorizer is returned by the use cases wrapped WithPermission when the
+// Factory has no Authorizer, so a missing dependency denies instead of allowing.
+var ErrMissingAuthorizer = errors.New("ucdecorator: use case requires a permission but no authorizer is configured")
@@ -1,12 +1,23 @@
 package ucdecorator
 
 import (
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 	"github.com/cristiano-pacheco/bricks/pkg/metrics"
 )
 
 func NewTestFactory(cfg Config, m metrics.UseCaseMetrics, log logger.Logger, t ErrorTranslator) *Factory {
-	return &Factory{cfg: cfg, metrics: m, logger: log, translator: t}
+	return &Factory{cfg: cfg, metrics: m, logger: log, translator: t, clock: clock.New()}
+}
+
+func NewTestFactoryWithTxManager(cfg Config, log logger.Logger, tx database.TxManager) *Factory {
+	return &Factory{cfg: cfg, logger: log, txManager: tx, clock: clock.New()}
+}
+
+func NewTestFactoryWithAuthorizer(cfg Config, a Authorizer) *Factory {
+	return &Factory{cfg: cfg, authorizer: a, clock: clock.New()}
 }
 
 func (f *Factory) InferUseCaseName(handler any) string {
@@ -26,7 +37,16 @@ func WithLogging[T, R any](handler UseCase[T, R], log logger.Logger, name string
 }
 
 func WithMetrics[T, R any](handler UseCase[T, R], m metrics.UseCaseMetrics, name string) UseCase[T, R] {
-	return withMetrics(handler, m, name)
+	return withMetrics(handler, m, name, clock.New())
+}
+
+func WithMetricsClock[T, R any](
+	handler UseCase[T, R],
+	m metrics.UseCaseMetrics,
+	name string,
+	clk clock.Clock,
+) UseCase[T, R] {
+	return withMetrics(handler, m, name, clk)
 }
 
 func WithTracing[T, R any](handler UseCase[T, R], name string) UseCase[T, R] {
@@ -40,3 +60,15 @@ func WithTranslation[T, R any](handler UseCase[T, R], t ErrorTranslator) UseCase
 func WithDebug[T, R any](handler UseCase[T, R], log logger.Logger, useCaseName, decoratorName string) UseCase[T, R] {
 	return withDebug(handler, log, useCaseName, decoratorName)
 }
+
+func WithTransaction[T, R any](handler UseCase[T, R], tx database.TxManager) UseCase[T, R] {
+	return withTransaction(handler, tx)
+}
+
+func WithAuthorization[T, R any](handler UseCase[T, R], a Authorizer, action, resource string) UseCase[T, R] {
+	return withAuthorization(handler, a, action, resource)
+}
+
+func WithErrorReporting[T, R any](handler UseCase[T, R], reporter errreport.Reporter, name string) UseCase[T, R] {
+	return withErrorReporting(handler, reporter, name)
+}
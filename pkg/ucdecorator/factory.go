@@ -5,7 +5,10 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
 	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 	"github.com/cristiano-pacheco/bricks/pkg/metrics"
 )
@@ -15,28 +18,114 @@ type Factory struct {
 	metrics    metrics.UseCaseMetrics
 	logger     logger.Logger
 	translator ErrorTranslator
+	txManager  database.TxManager
+	clock      clock.Clock
+	authorizer Authorizer
+	reporter   errreport.Reporter
 }
 
+// FactoryOption configures the Factory created by NewFactory.
+type FactoryOption func(*Factory)
+
+// WithClock sets the clock measuring the use case durations of the metrics decorator,
+// e.g. a clock.Fake in tests. Defaults to the time package when not provided.
+func WithClock(clk clock.Clock) FactoryOption {
+	return func(f *Factory) {
+		if clk != nil {
+			f.clock = clk
+		}
+	}
+}
+
+// WithAuthorizer sets the Authorizer checking the permissions of the use cases wrapped
+// WithPermission.
+func WithAuthorizer(authorizer Authorizer) FactoryOption {
+	return func(f *Factory) {
+		if authorizer != nil {
+			f.authorizer = authorizer
+		}
+	}
+}
+
+// WithReporter sets the errreport.Reporter the error reporting decorator reports the failed
+// use cases to.
+func WithReporter(reporter errreport.Reporter) FactoryOption {
+	return func(f *Factory) {
+		if reporter != nil {
+			f.reporter = reporter
+		}
+	}
+}
+
+// NewFactory creates the decorator factory. The txManager is optional: without it the
+// transaction decorator is not applied.
 func NewFactory(
 	cfg config.Config[Config],
 	useCaseMetrics metrics.UseCaseMetrics,
 	log logger.Logger,
 	translator ErrorTranslator,
+	txManager database.TxManager,
+	opts ...FactoryOption,
 ) *Factory {
-	return &Factory{
+	factory := &Factory{
 		cfg:        cfg.Get(),
 		metrics:    useCaseMetrics,
 		logger:     log,
 		translator: translator,
+		txManager:  txManager,
+		clock:      clock.New(),
+	}
+	for _, opt := range opts {
+		opt(factory)
+	}
+	return factory
+}
+
+type wrapOptions struct {
+	withoutTransaction bool
+	permission         *permission
+}
+
+type permission struct {
+	action   string
+	resource string
+}
+
+// WrapOption customizes the decorators applied by Wrap to a single use case.
+type WrapOption func(*wrapOptions)
+
+// WithoutTransaction opts the use case out of the transaction decorator, e.g. a read-only
+// use case or one that calls external services.
+func WithoutTransaction() WrapOption {
+	return func(o *wrapOptions) {
+		o.withoutTransaction = true
+	}
+}
+
+// WithPermission runs the use case only when the Authorizer of the Factory allows the
+// subject of the context to perform action on resource. It applies even when the
+// decorators are disabled, and denies with ErrMissingAuthorizer without an Authorizer.
+func WithPermission(action, resource string) WrapOption {
+	return func(o *wrapOptions) {
+		o.permission = &permission{action: action, resource: resource}
 	}
 }
 
 func Wrap[T any, R any](
 	factory *Factory,
 	handler UseCase[T, R],
+	opts ...WrapOption,
 ) UseCase[T, R] {
+	var options wrapOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	cfg := factory.cfg
 	if !cfg.Enabled {
+		if options.permission != nil {
+			return withAuthorization(handler, factory.authorizer, options.permission.action, options.permission.resource)
+		}
 		return handler
 	}
 
@@ -45,6 +134,22 @@ func Wrap[T any, R any](
 
 	result := handler
 
+	if cfg.Transactional && !options.withoutTransaction && factory.txManager != nil {
+		result = withTransaction(result, factory.txManager)
+		if cfg.DebugMode {
+			result = withDebug(result, factory.logger, useCaseName, "transaction")
+			factory.logger.Debug("applying transaction decorator", logger.String("use_case", useCaseName))
+		}
+	}
+
+	if options.permission != nil {
+		result = withAuthorization(result, factory.authorizer, options.permission.action, options.permission.resource)
+		if cfg.DebugMode {
+			result = withDebug(result, factory.logger, useCaseName, "authorization")
+			factory.logger.Debug("applying authorization decorator", logger.String("use_case", useCaseName))
+		}
+	}
+
 	if cfg.Translation {
 		result = withTranslation(result, factory.translator)
 		if cfg.DebugMode {
@@ -60,12 +165,19 @@ func Wrap[T any, R any](
 		}
 	}
 	if cfg.Metrics {
-		result = withMetrics(result, factory.metrics, metricName)
+		result = withMetrics(result, factory.metrics, metricName, factory.clock)
 		if cfg.DebugMode {
 			result = withDebug(result, factory.logger, useCaseName, "metrics")
 			factory.logger.Debug("applying metrics decorator", logger.String("use_case", useCaseName))
 		}
 	}
+	if cfg.ErrorReporting {
+		result = withErrorReporting(result, factory.reporter, useCaseName)
+		if cfg.DebugMode {
+			result = withDebug(result, factory.logger, useCaseName, "error_reporting")
+			factory.logger.Debug("applying error reporting decorator", logger.String("use_case", useCaseName))
+		}
+	}
 	if cfg.Logging {
 		result = withLogging(result, factory.logger, useCaseName)
 		if cfg.DebugMode {
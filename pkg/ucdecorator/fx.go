@@ -1,17 +1,38 @@
 package ucdecorator
 
 import (
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
 	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/errreport"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/metrics"
 	"go.uber.org/fx"
 )
 
 var Module = fx.Module(
 	"ucdecorator",
 	config.Provide[Config]("app.ucdecorator"),
-	fx.Provide(
-		fx.Annotate(
-			NewFactory,
-			fx.ParamTags(``, ``, ``, ``),
-		),
-	),
+	fx.Provide(newFactoryWithParams),
 )
+
+type factoryParams struct {
+	fx.In
+
+	Config     config.Config[Config]
+	Metrics    metrics.UseCaseMetrics
+	Logger     logger.Logger
+	Translator ErrorTranslator
+	TxManager  database.TxManager `optional:"true"`
+	Clock      clock.Clock        `optional:"true"`
+	Authorizer Authorizer         `optional:"true"`
+	Reporter   errreport.Reporter `optional:"true"`
+}
+
+func newFactoryWithParams(p factoryParams) *Factory {
+	return NewFactory(p.Config, p.Metrics, p.Logger, p.Translator, p.TxManager,
+		WithClock(p.Clock),
+		WithAuthorizer(p.Authorizer),
+		WithReporter(p.Reporter),
+	)
+}
@@ -11,3 +11,17 @@ type UseCase[T any, R any] interface {
 type ErrorTranslator interface {
 	TranslateError(err error) error
 }
+
+// ContextErrorTranslator is an ErrorTranslator that can resolve the locale from the request context.
+// The translation decorator prefers TranslateErrorCtx when the translator implements it.
+type ContextErrorTranslator interface {
+	ErrorTranslator
+	TranslateErrorCtx(ctx context.Context, err error) error
+}
+
+// Authorizer checks that the subject of the context may perform action on resource,
+// returning the error of the use case otherwise, e.g. an errs.Error with status 403.
+// authz.Authorizer implements it.
+type Authorizer interface {
+	Authorize(ctx context.Context, action, resource string) error
+}
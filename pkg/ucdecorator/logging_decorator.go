@@ -3,6 +3,7 @@ package ucdecorator
 import (
 	"context"
 
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
 	"github.com/cristiano-pacheco/bricks/pkg/logger"
 )
 
@@ -28,10 +29,15 @@ func (decorator *loggingDecorator[T, R]) Execute(ctx context.Context, input T) (
 	var err error
 
 	defer func() {
-		if err != nil {
-			decorator.logger.Error(decorator.name+" failed", logger.Error(err))
-		} else {
-			decorator.logger.Info(decorator.name + " succeeded")
+		fields := logger.ContextFields(ctx)
+		switch {
+		case errs.IsClientClosed(ctx, err):
+			// Not a failure of the use case: the client left before the response
+			decorator.logger.Info(decorator.name+" canceled by the client", append(fields, logger.Error(err))...)
+		case err != nil:
+			decorator.logger.Error(decorator.name+" failed", append(fields, logger.Error(err))...)
+		default:
+			decorator.logger.Info(decorator.name+" succeeded", fields...)
 		}
 	}()
 
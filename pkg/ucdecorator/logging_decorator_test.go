@@ -5,6 +5,9 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
 	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
 	"github.com/cristiano-pacheco/bricks/test/mocks"
 	"github.com/stretchr/testify/mock"
@@ -68,3 +71,44 @@ func (s *LoggingDecoratorTestSuite) TestExecute_Error_LogsErrorWithUseCaseName()
 	s.Require().ErrorIs(err, expectedErr)
 	s.Empty(result)
 }
+
+func (s *LoggingDecoratorTestSuite) TestExecute_ClientClosed_LogsInfoInsteadOfError() {
+	// Arrange
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errs.ErrClientClosed)
+	s.baseMock.On("Execute", mock.Anything, "input").Return("", context.Canceled)
+	s.loggerMock.On("Info", "TestUseCase.Execute canceled by the client", mock.Anything).Return()
+
+	// Act
+	_, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().ErrorIs(err, context.Canceled)
+}
+
+func (s *LoggingDecoratorTestSuite) TestExecute_CanceledWithoutClient_LogsError() {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.baseMock.On("Execute", mock.Anything, "input").Return("", context.Canceled)
+	s.loggerMock.On("Error", "TestUseCase.Execute failed", mock.Anything).Return()
+
+	// Act
+	_, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().ErrorIs(err, context.Canceled)
+}
+
+func (s *LoggingDecoratorTestSuite) TestExecute_WithRequestMetadata_LogsContextFields() {
+	// Arrange
+	ctx := ctxmeta.WithRequestID(context.Background(), "req-1")
+	s.baseMock.On("Execute", mock.Anything, "input").Return("output", nil)
+	s.loggerMock.On("Info", "TestUseCase.Execute succeeded", logger.String("request_id", "req-1")).Return()
+
+	// Act
+	_, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().NoError(err)
+}
@@ -2,8 +2,9 @@ package ucdecorator
 
 import (
 	"context"
-	"time"
 
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
 	"github.com/cristiano-pacheco/bricks/pkg/metrics"
 )
 
@@ -11,12 +12,14 @@ type metricsDecorator[T any, R any] struct {
 	base       UseCase[T, R]
 	metrics    metrics.UseCaseMetrics
 	metricName string
+	clock      clock.Clock
 }
 
 func withMetrics[T any, R any](
 	base UseCase[T, R],
 	useCaseMetrics metrics.UseCaseMetrics,
 	metricName string,
+	clk clock.Clock,
 ) UseCase[T, R] {
 	if useCaseMetrics == nil {
 		return base
@@ -25,14 +28,20 @@ func withMetrics[T any, R any](
 		base:       base,
 		metrics:    useCaseMetrics,
 		metricName: metricName,
+		clock:      clk,
 	}
 }
 
 func (decorator *metricsDecorator[T, R]) Execute(ctx context.Context, input T) (R, error) {
-	start := time.Now()
+	start := decorator.clock.Now()
 	output, err := decorator.base.Execute(ctx, input)
 
-	decorator.metrics.ObserveDuration(decorator.metricName, time.Since(start))
+	decorator.metrics.ObserveDuration(decorator.metricName, decorator.clock.Since(start))
+	if errs.IsClientClosed(ctx, err) {
+		// Neither a success nor an error of the use case, so the error rate is not
+		// polluted by the clients closing their requests
+		return output, err
+	}
 	if err != nil {
 		decorator.metrics.IncError(decorator.metricName)
 		return output, err
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
 	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
 	"github.com/cristiano-pacheco/bricks/test/mocks"
 	"github.com/stretchr/testify/mock"
@@ -69,3 +72,36 @@ func (s *MetricsDecoratorTestSuite) TestExecute_Error_ObservesDurationAndIncreme
 	s.Require().ErrorIs(err, expectedErr)
 	s.Empty(result)
 }
+
+func (s *MetricsDecoratorTestSuite) TestExecute_ClientClosed_ObservesDurationWithoutError() {
+	// Arrange
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errs.ErrClientClosed)
+	s.baseMock.On("Execute", mock.Anything, "input").Return("", context.Canceled)
+	s.metricsMock.On("ObserveDuration", "create_user", mock.Anything).Return()
+	// No IncError or IncSuccess expectation: any call fails the test
+
+	// Act
+	_, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().ErrorIs(err, context.Canceled)
+}
+
+func (s *MetricsDecoratorTestSuite) TestExecute_Clock_ObservesTheDurationOfTheClock() {
+	// Arrange
+	ctx := context.Background()
+	clk := clock.NewFake(time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC))
+	sut := ucdecorator.WithMetricsClock(s.baseMock, s.metricsMock, "create_user", clk)
+	s.baseMock.On("Execute", mock.Anything, "input").
+		Run(func(mock.Arguments) { clk.Advance(250 * time.Millisecond) }).
+		Return("output", nil)
+	s.metricsMock.On("ObserveDuration", "create_user", 250*time.Millisecond).Return()
+	s.metricsMock.On("IncSuccess", "create_user").Return()
+
+	// Act
+	_, err := sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().NoError(err)
+}
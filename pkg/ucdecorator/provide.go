@@ -0,0 +1,93 @@
+package ucdecorator
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.uber.org/fx"
+)
+
+var (
+	factoryType = reflect.TypeFor[*Factory]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+// Provide provides the use case built by constructor as a UseCase[In, Out] wrapped by the
+// Factory, so modules do not call Wrap for each use case. The constructor takes any
+// dependencies and returns the concrete use case, optionally with an error:
+//
+//	ucdecorator.Provide[usecase.CategoryListInput, usecase.CategoryListOutput](
+//	    usecase.NewCategoryListUseCase,
+//	    ucdecorator.WithoutTransaction(),
+//	)
+//
+// The concrete type is not provided, so the use case name of the metrics and logs is
+// still inferred from it.
+func Provide[In any, Out any](constructor any, opts ...WrapOption) fx.Option {
+	provider, err := wrapConstructor[In, Out](constructor, opts)
+	if err != nil {
+		return fx.Error(err)
+	}
+	return fx.Provide(provider)
+}
+
+// Decorate wraps the UseCase[In, Out] provided elsewhere, e.g. with fx.As, with the Factory.
+// As any fx decorator, it applies to the module it is used in and its children.
+func Decorate[In any, Out any](opts ...WrapOption) fx.Option {
+	return fx.Decorate(func(factory *Factory, useCase UseCase[In, Out]) UseCase[In, Out] {
+		return Wrap(factory, useCase, opts...)
+	})
+}
+
+// wrapConstructor returns a function with the parameters of constructor and the *Factory,
+// which returns the wrapped use case instead of the concrete one.
+func wrapConstructor[In any, Out any](constructor any, opts []WrapOption) (any, error) {
+	useCaseType := reflect.TypeFor[UseCase[In, Out]]()
+	fn := reflect.ValueOf(constructor)
+	fnType := fn.Type()
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return nil, fmt.Errorf("ucdecorator: constructor must be a function, got %T", constructor)
+	}
+
+	returnsError := fnType.NumOut() == 2 && fnType.Out(1) == errorType
+	if (fnType.NumOut() != 1 && !returnsError) || !fnType.Out(0).Implements(useCaseType) {
+		return nil, fmt.Errorf(
+			"ucdecorator: constructor %s must return a %s, optionally with an error",
+			fnType, useCaseType,
+		)
+	}
+
+	in := make([]reflect.Type, 0, fnType.NumIn()+1)
+	in = append(in, factoryType)
+	for i := range fnType.NumIn() {
+		in = append(in, fnType.In(i))
+	}
+	out := []reflect.Type{useCaseType}
+	if returnsError {
+		out = append(out, errorType)
+	}
+
+	providerType := reflect.FuncOf(in, out, fnType.IsVariadic())
+	provider := reflect.MakeFunc(providerType, func(args []reflect.Value) []reflect.Value {
+		factory, _ := args[0].Interface().(*Factory)
+		var results []reflect.Value
+		if fnType.IsVariadic() {
+			results = fn.CallSlice(args[1:])
+		} else {
+			results = fn.Call(args[1:])
+		}
+
+		wrapped := reflect.Zero(useCaseType)
+		if !returnsError || results[1].IsNil() {
+			useCase, _ := results[0].Interface().(UseCase[In, Out])
+			wrapped = reflect.ValueOf(Wrap(factory, useCase, opts...))
+			// ValueOf returns the dynamic type of the wrapper, the result is the interface
+			wrapped = wrapped.Convert(useCaseType)
+		}
+		if returnsError {
+			return []reflect.Value{wrapped, results[1]}
+		}
+		return []reflect.Value{wrapped}
+	})
+	return provider.Interface(), nil
+}
@@ -0,0 +1,120 @@
+package ucdecorator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+type greeting struct {
+	prefix string
+}
+
+type GreetUseCase struct {
+	greeting greeting
+}
+
+func NewGreetUseCase(g greeting) *GreetUseCase {
+	return &GreetUseCase{greeting: g}
+}
+
+func (uc *GreetUseCase) Execute(_ context.Context, name string) (string, error) {
+	return uc.greeting.prefix + name, nil
+}
+
+func newFailingGreetUseCase(greeting) (*GreetUseCase, error) {
+	return nil, errors.New("constructor failed")
+}
+
+func TestProvide(t *testing.T) {
+	t.Run("provides the use case wrapped by the factory", func(t *testing.T) {
+		// Arrange
+		metricsMock := mocks.NewMockUseCaseMetrics(t)
+		metricsMock.On("ObserveDuration", "greet", mock.Anything).Return()
+		metricsMock.On("IncSuccess", "greet").Return()
+		factory := ucdecorator.NewTestFactory(ucdecorator.Config{Enabled: true, Metrics: true}, metricsMock, nil, nil)
+		var useCase ucdecorator.UseCase[string, string]
+
+		// Act
+		app := fx.New(
+			fx.NopLogger,
+			fx.Supply(factory, greeting{prefix: "hello "}),
+			ucdecorator.Provide[string, string](NewGreetUseCase),
+			fx.Populate(&useCase),
+		)
+
+		// Assert
+		require.NoError(t, app.Err())
+		_, isConcrete := useCase.(*GreetUseCase)
+		assert.False(t, isConcrete)
+		result, err := useCase.Execute(context.Background(), "ana")
+		require.NoError(t, err)
+		assert.Equal(t, "hello ana", result)
+	})
+
+	t.Run("returns the error of the constructor", func(t *testing.T) {
+		// Arrange
+		factory := ucdecorator.NewTestFactory(ucdecorator.Config{Enabled: true}, nil, nil, nil)
+
+		// Act
+		app := fx.New(
+			fx.NopLogger,
+			fx.Supply(factory, greeting{}),
+			ucdecorator.Provide[string, string](newFailingGreetUseCase),
+			fx.Invoke(func(ucdecorator.UseCase[string, string]) {}),
+		)
+
+		// Assert
+		require.ErrorContains(t, app.Err(), "constructor failed")
+	})
+
+	t.Run("rejects a constructor of another use case", func(t *testing.T) {
+		// Act
+		app := fx.New(fx.NopLogger, ucdecorator.Provide[int, string](NewGreetUseCase))
+
+		// Assert
+		require.ErrorContains(t, app.Err(), "must return a")
+	})
+
+	t.Run("rejects a value that is not a function", func(t *testing.T) {
+		// Act
+		app := fx.New(fx.NopLogger, ucdecorator.Provide[string, string](&GreetUseCase{}))
+
+		// Assert
+		require.ErrorContains(t, app.Err(), "must be a function")
+	})
+}
+
+func TestDecorate(t *testing.T) {
+	t.Run("wraps the provided use case", func(t *testing.T) {
+		// Arrange
+		handlerMock := mocks.NewMockUseCase[string, string](t)
+		handlerMock.On("Execute", mock.Anything, "ana").Return("", errors.New("failed"))
+		translatorMock := mocks.NewMockErrorTranslator(t)
+		translatorMock.On("TranslateError", mock.Anything).Return(errors.New("translated"))
+		factory := ucdecorator.NewTestFactory(
+			ucdecorator.Config{Enabled: true, Translation: true}, nil, nil, translatorMock,
+		)
+		var useCase ucdecorator.UseCase[string, string]
+
+		// Act
+		app := fx.New(
+			fx.NopLogger,
+			fx.Supply(factory, fx.Annotate(handlerMock, fx.As(new(ucdecorator.UseCase[string, string])))),
+			ucdecorator.Decorate[string, string](),
+			fx.Populate(&useCase),
+		)
+
+		// Assert
+		require.NoError(t, app.Err())
+		_, err := useCase.Execute(context.Background(), "ana")
+		require.EqualError(t, err, "translated")
+	})
+}
@@ -0,0 +1,36 @@
+package ucdecorator
+
+import (
+	"context"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+)
+
+type transactionDecorator[T any, R any] struct {
+	base      UseCase[T, R]
+	txManager database.TxManager
+}
+
+func withTransaction[T any, R any](base UseCase[T, R], txManager database.TxManager) UseCase[T, R] {
+	if txManager == nil {
+		return base
+	}
+
+	return &transactionDecorator[T, R]{
+		base:      base,
+		txManager: txManager,
+	}
+}
+
+// Execute runs the use case in a transaction carried by its context, committed on success
+// and rolled back by the TxManager on error or panic.
+func (decorator *transactionDecorator[T, R]) Execute(ctx context.Context, input T) (R, error) {
+	var output R
+	err := decorator.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var errExecute error
+		output, errExecute = decorator.base.Execute(txCtx, input)
+		return errExecute
+	})
+
+	return output, err
+}
@@ -0,0 +1,144 @@
+package ucdecorator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+	"github.com/cristiano-pacheco/bricks/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type txContextKey struct{}
+
+type TransactionDecoratorTestSuite struct {
+	suite.Suite
+	sut           ucdecorator.UseCase[string, string]
+	baseMock      *mocks.MockUseCase[string, string]
+	txManagerMock *mocks.MockTxManager
+}
+
+func (s *TransactionDecoratorTestSuite) SetupTest() {
+	s.baseMock = mocks.NewMockUseCase[string, string](s.T())
+	s.txManagerMock = mocks.NewMockTxManager(s.T())
+	s.sut = ucdecorator.WithTransaction(s.baseMock, s.txManagerMock)
+}
+
+func TestTransactionDecoratorSuite(t *testing.T) {
+	suite.Run(t, new(TransactionDecoratorTestSuite))
+}
+
+// runInTx mimics a TxManager that carries the transaction in the context
+func (s *TransactionDecoratorTestSuite) runInTx() func(context.Context, func(context.Context) error) error {
+	return func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(context.WithValue(ctx, txContextKey{}, "tx"))
+	}
+}
+
+func (s *TransactionDecoratorTestSuite) TestExecute_Success_RunsBaseWithinTransaction() {
+	// Arrange
+	ctx := context.Background()
+	s.txManagerMock.On("WithinTransaction", ctx, mock.Anything).Return(s.runInTx())
+	s.baseMock.On("Execute", mock.MatchedBy(func(txCtx context.Context) bool {
+		return txCtx.Value(txContextKey{}) == "tx"
+	}), "input").Return("output", nil)
+
+	// Act
+	result, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("output", result)
+}
+
+func (s *TransactionDecoratorTestSuite) TestExecute_Error_ReturnsErrorToTxManagerAndCaller() {
+	// Arrange
+	ctx := context.Background()
+	expectedErr := errors.New("use case failed")
+	var txErr error
+	s.txManagerMock.On("WithinTransaction", ctx, mock.Anything).Return(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			txErr = fn(ctx)
+			return txErr
+		},
+	)
+	s.baseMock.On("Execute", mock.Anything, "input").Return("", expectedErr)
+
+	// Act
+	_, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().ErrorIs(err, expectedErr)
+	s.Require().ErrorIs(txErr, expectedErr)
+}
+
+func (s *TransactionDecoratorTestSuite) TestExecute_CommitFails_ReturnsCommitError() {
+	// Arrange
+	ctx := context.Background()
+	commitErr := errors.New("commit failed")
+	s.txManagerMock.On("WithinTransaction", ctx, mock.Anything).Return(
+		func(ctx context.Context, fn func(context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return commitErr
+		},
+	)
+	s.baseMock.On("Execute", mock.Anything, "input").Return("output", nil)
+
+	// Act
+	_, err := s.sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().ErrorIs(err, commitErr)
+}
+
+func (s *TransactionDecoratorTestSuite) TestWithTransaction_NilTxManager_ReturnsBase() {
+	// Act
+	result := ucdecorator.WithTransaction[string, string](s.baseMock, nil)
+
+	// Assert
+	s.Same(s.baseMock, result)
+}
+
+func (s *TransactionDecoratorTestSuite) TestWrap_Transactional_AppliesTransaction() {
+	// Arrange
+	ctx := context.Background()
+	factory := ucdecorator.NewTestFactoryWithTxManager(
+		ucdecorator.Config{Enabled: true, Transactional: true}, nil, s.txManagerMock)
+	s.txManagerMock.On("WithinTransaction", ctx, mock.Anything).Return(s.runInTx())
+	s.baseMock.On("Execute", mock.Anything, "input").Return("output", nil)
+	sut := ucdecorator.Wrap(factory, s.baseMock)
+
+	// Act
+	result, err := sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("output", result)
+}
+
+func (s *TransactionDecoratorTestSuite) TestWrap_WithoutTransaction_SkipsTransaction() {
+	// Arrange
+	factory := ucdecorator.NewTestFactoryWithTxManager(
+		ucdecorator.Config{Enabled: true, Transactional: true}, nil, s.txManagerMock)
+
+	// Act
+	result := ucdecorator.Wrap(factory, s.baseMock, ucdecorator.WithoutTransaction())
+
+	// Assert
+	s.Same(s.baseMock, result)
+}
+
+func (s *TransactionDecoratorTestSuite) TestWrap_TransactionalDisabled_SkipsTransaction() {
+	// Arrange
+	factory := ucdecorator.NewTestFactoryWithTxManager(ucdecorator.Config{Enabled: true}, nil, s.txManagerMock)
+
+	// Act
+	result := ucdecorator.Wrap(factory, s.baseMock)
+
+	// Assert
+	s.Same(s.baseMock, result)
+}
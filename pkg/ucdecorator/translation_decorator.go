@@ -21,6 +21,9 @@ func withTranslation[T any, R any](base UseCase[T, R], translator ErrorTranslato
 func (decorator *translationDecorator[T, R]) Execute(ctx context.Context, input T) (R, error) {
 	output, err := decorator.base.Execute(ctx, input)
 	if err != nil {
+		if contextTranslator, ok := decorator.translator.(ContextErrorTranslator); ok {
+			return output, contextTranslator.TranslateErrorCtx(ctx, err)
+		}
 		return output, decorator.translator.TranslateError(err)
 	}
 
@@ -68,3 +68,23 @@ func (s *TranslationDecoratorTestSuite) TestExecute_Error_TranslatesError() {
 	s.Require().ErrorIs(err, translatedErr)
 	s.Empty(result)
 }
+
+func (s *TranslationDecoratorTestSuite) TestExecute_Error_ContextTranslator_TranslatesWithContext() {
+	// Arrange
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "pt_BR")
+	originalErr := errors.New("original error")
+	translatedErr := errors.New("erro traduzido")
+	baseMock := mocks.NewMockUseCase[string, string](s.T())
+	translatorMock := mocks.NewMockContextErrorTranslator(s.T())
+	baseMock.On("Execute", ctx, "input").Return("", originalErr)
+	translatorMock.On("TranslateErrorCtx", ctx, originalErr).Return(translatedErr)
+	sut := ucdecorator.WithTranslation(baseMock, translatorMock)
+
+	// Act
+	result, err := sut.Execute(ctx, "input")
+
+	// Assert
+	s.Require().ErrorIs(err, translatedErr)
+	s.Empty(result)
+}
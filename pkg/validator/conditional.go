@@ -0,0 +1,54 @@
+package validator
+
+import "strings"
+
+// conditionalRules replaces the generic library messages of the conditional tags
+// with messages that name the fields the condition depends on,
+// e.g. "Reason is required when Status is rejected".
+func conditionalRules() []Rule {
+	return []Rule{
+		{Tag: "required_if", Message: "{0} is required when {1}", FormatParam: formatFieldValues},
+		{Tag: "required_unless", Message: "{0} is required unless {1}", FormatParam: formatFieldValues},
+		{Tag: "excluded_if", Message: "{0} must be empty when {1}", FormatParam: formatFieldValues},
+		{Tag: "excluded_unless", Message: "{0} must be empty unless {1}", FormatParam: formatFieldValues},
+		{Tag: "required_with", Message: "{0} is required when {1}", FormatParam: formatPresent(" or ")},
+		{Tag: "required_with_all", Message: "{0} is required when {1}", FormatParam: formatPresent(" and ")},
+		{Tag: "required_without", Message: "{0} is required when {1}", FormatParam: formatMissing(" or ")},
+		{Tag: "required_without_all", Message: "{0} is required when {1}", FormatParam: formatMissing(" and ")},
+	}
+}
+
+// formatFieldValues turns "Status rejected Kind manual" into "Status is rejected and Kind is manual".
+func formatFieldValues(param string) string {
+	parts := strings.Fields(param)
+	conditions := make([]string, 0, len(parts)/2)
+	for i := 0; i+1 < len(parts); i += 2 {
+		conditions = append(conditions, parts[i]+" is "+parts[i+1])
+	}
+	return strings.Join(conditions, " and ")
+}
+
+// formatPresent turns "Phone Email" into "Phone or Email is present".
+func formatPresent(sep string) func(string) string {
+	return func(param string) string {
+		return joinFields(param, sep) + " present"
+	}
+}
+
+// formatMissing turns "Phone Email" into "Phone or Email is missing".
+func formatMissing(sep string) func(string) string {
+	return func(param string) string {
+		return joinFields(param, sep) + " missing"
+	}
+}
+
+// joinFields joins the field names with sep followed by the matching verb,
+// so that "A and B" reads "A and B are" while "A or B" reads "A or B is".
+func joinFields(param, sep string) string {
+	fields := strings.Fields(param)
+	verb := " is"
+	if len(fields) > 1 && sep == " and " {
+		verb = " are"
+	}
+	return strings.Join(fields, sep) + verb
+}
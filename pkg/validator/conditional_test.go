@@ -0,0 +1,148 @@
+package validator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	lib_validator "github.com/go-playground/validator/v10"
+
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+)
+
+func translatedErrors(t *testing.T, v validator.Validator, err error) map[string]string {
+	t.Helper()
+
+	var validationErrs lib_validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("error = %v, want ValidationErrors", err)
+	}
+
+	messages := make(map[string]string, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		messages[fieldErr.Field()] = fieldErr.Translate(v.Translator())
+	}
+	return messages
+}
+
+func TestConditionalRuleTranslations(t *testing.T) {
+	v, err := validator.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	type Review struct {
+		Status string
+		Kind   string
+		Phone  string
+		Email  string
+		Reason string `validate:"required_if=Status rejected Kind manual"`
+		Note   string `validate:"excluded_unless=Status rejected"`
+		Notify string `validate:"required_with=Phone Email"`
+		Backup string `validate:"required_without_all=Phone Email"`
+	}
+
+	err = v.Validate(Review{Status: "approved", Kind: "manual", Note: "n"})
+	got := translatedErrors(t, v, err)
+
+	want := map[string]string{
+		"Note":   "Note must be empty unless Status is rejected",
+		"Backup": "Backup is required when Phone and Email are missing",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("errors = %v, want %v", got, want)
+	}
+	for field, message := range want {
+		if got[field] != message {
+			t.Errorf("message for %s = %q, want %q", field, got[field], message)
+		}
+	}
+
+	err = v.Validate(Review{Status: "rejected", Kind: "manual", Phone: "1", Backup: "b"})
+	got = translatedErrors(t, v, err)
+
+	want = map[string]string{
+		"Reason": "Reason is required when Status is rejected and Kind is manual",
+		"Notify": "Notify is required when Phone or Email is present",
+	}
+	for field, message := range want {
+		if got[field] != message {
+			t.Errorf("message for %s = %q, want %q", field, got[field], message)
+		}
+	}
+}
+
+type tenantKey struct{}
+
+func TestValidateCtx(t *testing.T) {
+	v, err := validator.New(validator.WithRules(validator.Rule{
+		Tag: "tenant_plan",
+		FuncCtx: func(ctx context.Context, fl lib_validator.FieldLevel) bool {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			return tenant == "premium" || fl.Field().String() == "basic"
+		},
+		Message: "{0} is not available for this tenant",
+	}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	type Subscription struct {
+		Plan string `validate:"tenant_plan"`
+	}
+
+	t.Run("premium tenant", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), tenantKey{}, "premium")
+		if err := v.ValidateCtx(ctx, Subscription{Plan: "enterprise"}); err != nil {
+			t.Errorf("ValidateCtx() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("free tenant", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), tenantKey{}, "free")
+		err := v.ValidateCtx(ctx, Subscription{Plan: "enterprise"})
+		got := translatedErrors(t, v, err)
+		if got["Plan"] != "Plan is not available for this tenant" {
+			t.Errorf("message = %q", got["Plan"])
+		}
+	})
+}
+
+func TestWithStructRules(t *testing.T) {
+	type Period struct {
+		StartsAt time.Time
+		EndsAt   time.Time
+	}
+
+	v, err := validator.New(
+		validator.WithStructRules(validator.StructRule{
+			Type: Period{},
+			Func: func(_ context.Context, sl lib_validator.StructLevel) {
+				period := sl.Current().Interface().(Period)
+				if !period.EndsAt.After(period.StartsAt) {
+					sl.ReportError(period.EndsAt, "EndsAt", "EndsAt", "after_start", "StartsAt")
+				}
+			},
+		}),
+		validator.WithRules(validator.Rule{
+			Tag:     "after_start",
+			Message: "{0} must be after {1}",
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	now := time.Now()
+
+	if err := v.Validate(Period{StartsAt: now, EndsAt: now.Add(time.Hour)}); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	err = v.Validate(Period{StartsAt: now, EndsAt: now.Add(-time.Hour)})
+	got := translatedErrors(t, v, err)
+	if got["EndsAt"] != "EndsAt must be after StartsAt" {
+		t.Errorf("message = %q, want %q", got["EndsAt"], "EndsAt must be after StartsAt")
+	}
+}
@@ -0,0 +1,38 @@
+package validator
+
+type options struct {
+	rules          []Rule
+	structRules    []StructRule
+	passwordPolicy PasswordPolicy
+}
+
+// Option configures the Validator created by New.
+type Option func(*options)
+
+func defaultOptions() options {
+	return options{
+		passwordPolicy: DefaultPasswordPolicy(),
+	}
+}
+
+// WithRules registers custom rules in addition to the built-in ones.
+// A rule with the same tag as a built-in rule replaces it.
+func WithRules(rules ...Rule) Option {
+	return func(o *options) {
+		o.rules = append(o.rules, rules...)
+	}
+}
+
+// WithStructRules registers struct-level rules, e.g. to validate that one field is after another.
+func WithStructRules(rules ...StructRule) Option {
+	return func(o *options) {
+		o.structRules = append(o.structRules, rules...)
+	}
+}
+
+// WithPasswordPolicy sets the policy enforced by the strong_password rule.
+func WithPasswordPolicy(policy PasswordPolicy) Option {
+	return func(o *options) {
+		o.passwordPolicy = policy
+	}
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	lib_validator "github.com/go-playground/validator/v10"
+)
+
+const defaultPasswordMinLength = 8
+
+// PasswordPolicy configures the strong_password rule.
+type PasswordPolicy struct {
+	MinLength     int  `config:"min_length"`
+	RequireUpper  bool `config:"require_upper"`
+	RequireLower  bool `config:"require_lower"`
+	RequireDigit  bool `config:"require_digit"`
+	RequireSymbol bool `config:"require_symbol"`
+}
+
+// DefaultPasswordPolicy requires at least 8 characters with upper and lower case letters, a digit and a symbol.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:     defaultPasswordMinLength,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+}
+
+// IsSatisfiedBy reports whether the password complies with the policy.
+func (p PasswordPolicy) IsSatisfiedBy(password string) bool {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return false
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	return (!p.RequireUpper || hasUpper) &&
+		(!p.RequireLower || hasLower) &&
+		(!p.RequireDigit || hasDigit) &&
+		(!p.RequireSymbol || hasSymbol)
+}
+
+// message describes the policy, e.g. "{0} must be at least 8 characters long and contain an uppercase letter".
+func (p PasswordPolicy) message() string {
+	requirements := make([]string, 0, 4)
+	if p.RequireUpper {
+		requirements = append(requirements, "an uppercase letter")
+	}
+	if p.RequireLower {
+		requirements = append(requirements, "a lowercase letter")
+	}
+	if p.RequireDigit {
+		requirements = append(requirements, "a digit")
+	}
+	if p.RequireSymbol {
+		requirements = append(requirements, "a symbol")
+	}
+
+	message := fmt.Sprintf("{0} must be at least %d characters long", p.MinLength)
+	if len(requirements) == 0 {
+		return message
+	}
+
+	last := requirements[len(requirements)-1]
+	if len(requirements) == 1 {
+		return message + " and contain " + last
+	}
+	return message + " and contain " + strings.Join(requirements[:len(requirements)-1], ", ") + " and " + last
+}
+
+func (p PasswordPolicy) rule() Rule {
+	return Rule{
+		Tag: StrongPasswordTag,
+		Func: func(fl lib_validator.FieldLevel) bool {
+			return p.IsSatisfiedBy(fl.Field().String())
+		},
+		Message: p.message(),
+	}
+}
@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"fmt"
+
+	ut "github.com/go-playground/universal-translator"
+	lib_validator "github.com/go-playground/validator/v10"
+)
+
+// Rule is a custom validation tag with its English message.
+// In Message, {0} is replaced by the field name and {1} by the tag param,
+// optionally rewritten by FormatParam (e.g. to describe the fields a conditional rule depends on).
+// FuncCtx receives the context passed to ValidateCtx and takes precedence over Func.
+// A rule without Func and FuncCtx only sets the message of an existing tag,
+// such as tags reported by struct-level rules.
+type Rule struct {
+	Tag            string
+	Func           lib_validator.Func
+	FuncCtx        lib_validator.FuncCtx
+	Message        string
+	FormatParam    func(param string) string
+	CallEvenIfNull bool
+}
+
+// StructRule validates a whole struct of the same type as Type, typically to compare fields.
+// Errors are reported through StructLevel.ReportError and translated by a Rule with the reported tag.
+type StructRule struct {
+	Type any
+	Func lib_validator.StructLevelFuncCtx
+}
+
+// registerRule registers the rule function (when set) and its translation.
+func registerRule(engine *lib_validator.Validate, trans ut.Translator, rule Rule) error {
+	var err error
+	switch {
+	case rule.FuncCtx != nil:
+		err = engine.RegisterValidationCtx(rule.Tag, rule.FuncCtx, rule.CallEvenIfNull)
+	case rule.Func != nil:
+		err = engine.RegisterValidation(rule.Tag, rule.Func, rule.CallEvenIfNull)
+	}
+	if err != nil {
+		return fmt.Errorf("register rule %q: %w", rule.Tag, err)
+	}
+
+	if rule.Message == "" {
+		return nil
+	}
+
+	err = engine.RegisterTranslation(
+		rule.Tag,
+		trans,
+		func(t ut.Translator) error {
+			return t.Add(rule.Tag, rule.Message, true)
+		},
+		func(t ut.Translator, fe lib_validator.FieldError) string {
+			param := fe.Param()
+			if rule.FormatParam != nil {
+				param = rule.FormatParam(param)
+			}
+			message, err := t.T(rule.Tag, fe.Field(), param)
+			if err != nil {
+				return fe.Error()
+			}
+			return message
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("register translation for rule %q: %w", rule.Tag, err)
+	}
+
+	return nil
+}
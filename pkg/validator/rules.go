@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"regexp"
+
+	lib_validator "github.com/go-playground/validator/v10"
+)
+
+// Tags of the built-in rules registered by New.
+const (
+	UUID4Tag          = "uuid4"
+	E164Tag           = "e164"
+	ISO4217Tag        = "iso4217"
+	CronTag           = "cron"
+	SlugTag           = "slug"
+	StrongPasswordTag = "strong_password"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// builtinRules returns the common business rules registered by New.
+// uuid4, e164, iso4217 and cron are validated by the library; only their messages are set here.
+func builtinRules(policy PasswordPolicy) []Rule {
+	return []Rule{
+		{Tag: UUID4Tag, Message: "{0} must be a valid UUID v4"},
+		{Tag: E164Tag, Message: "{0} must be a valid E.164 phone number"},
+		{Tag: ISO4217Tag, Message: "{0} must be a valid ISO 4217 currency code"},
+		{Tag: CronTag, Message: "{0} must be a valid cron expression"},
+		{
+			Tag: SlugTag,
+			Func: func(fl lib_validator.FieldLevel) bool {
+				return slugRegex.MatchString(fl.Field().String())
+			},
+			Message: "{0} must be a valid slug",
+		},
+		policy.rule(),
+	}
+}
@@ -0,0 +1,149 @@
+package validator_test
+
+import (
+	"errors"
+	"testing"
+
+	lib_validator "github.com/go-playground/validator/v10"
+
+	"github.com/cristiano-pacheco/bricks/pkg/validator"
+)
+
+func TestBuiltinRules(t *testing.T) {
+	v, err := validator.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		field   string
+		tag     string
+		wantErr bool
+	}{
+		{name: "valid uuid4", field: "3f1c2a9e-8b7d-4c6f-9a2e-1d5b7c9e0f3a", tag: "uuid4"},
+		{name: "invalid uuid4", field: "not-a-uuid", tag: "uuid4", wantErr: true},
+		{name: "valid e164", field: "+5511999999999", tag: "e164"},
+		{name: "invalid e164", field: "+0551199", tag: "e164", wantErr: true},
+		{name: "valid iso4217", field: "BRL", tag: "iso4217"},
+		{name: "invalid iso4217", field: "XYZ", tag: "iso4217", wantErr: true},
+		{name: "valid cron", field: "*/5 * * * *", tag: "cron"},
+		{name: "invalid cron", field: "every minute", tag: "cron", wantErr: true},
+		{name: "valid slug", field: "my-first-post", tag: "slug"},
+		{name: "invalid slug with uppercase", field: "My-Post", tag: "slug", wantErr: true},
+		{name: "invalid slug with trailing dash", field: "my-post-", tag: "slug", wantErr: true},
+		{name: "valid strong password", field: "S3cure!pass", tag: "strong_password"},
+		{name: "weak password", field: "password", tag: "strong_password", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateVar(tt.field, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVar(%q, %q) error = %v, wantErr %v", tt.field, tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuiltinRuleTranslations(t *testing.T) {
+	v, err := validator.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	type Account struct {
+		Slug     string `validate:"slug"`
+		Currency string `validate:"iso4217"`
+		Password string `validate:"strong_password"`
+	}
+
+	err = v.Validate(Account{Slug: "Bad Slug", Currency: "XYZ", Password: "weak"})
+
+	var validationErrs lib_validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+
+	want := map[string]string{
+		"Slug":     "Slug must be a valid slug",
+		"Currency": "Currency must be a valid ISO 4217 currency code",
+		"Password": "Password must be at least 8 characters long and contain an uppercase letter, " +
+			"a lowercase letter, a digit and a symbol",
+	}
+	for _, fieldErr := range validationErrs {
+		if got := fieldErr.Translate(v.Translator()); got != want[fieldErr.Field()] {
+			t.Errorf("Translate() for %s = %q, want %q", fieldErr.Field(), got, want[fieldErr.Field()])
+		}
+	}
+}
+
+func TestWithRules(t *testing.T) {
+	v, err := validator.New(validator.WithRules(validator.Rule{
+		Tag: "is_awesome",
+		Func: func(fl lib_validator.FieldLevel) bool {
+			return fl.Field().String() == "awesome"
+		},
+		Message: "{0} must be awesome",
+	}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := v.ValidateVar("awesome", "is_awesome"); err != nil {
+		t.Errorf("ValidateVar() error = %v, want nil", err)
+	}
+
+	type Thing struct {
+		Value string `validate:"is_awesome"`
+	}
+
+	err = v.Validate(Thing{Value: "boring"})
+
+	var validationErrs lib_validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+	if got := validationErrs[0].Translate(v.Translator()); got != "Value must be awesome" {
+		t.Errorf("Translate() = %q, want %q", got, "Value must be awesome")
+	}
+}
+
+func TestWithRules_InvalidTag(t *testing.T) {
+	_, err := validator.New(validator.WithRules(validator.Rule{
+		Tag:  "",
+		Func: func(fl lib_validator.FieldLevel) bool { return true },
+	}))
+	if err == nil {
+		t.Fatal("New() error = nil, want error for empty tag")
+	}
+}
+
+func TestWithPasswordPolicy(t *testing.T) {
+	v, err := validator.New(validator.WithPasswordPolicy(validator.PasswordPolicy{
+		MinLength:    12,
+		RequireDigit: true,
+	}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{name: "long with digit", password: "correcthorse1"},
+		{name: "too short", password: "short1", wantErr: true},
+		{name: "missing digit", password: "correcthorsebattery", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateVar(tt.password, "strong_password")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVar(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+			}
+		})
+	}
+}
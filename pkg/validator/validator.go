@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"context"
 	"errors"
 
 	"github.com/go-playground/locales/en"
@@ -13,6 +14,8 @@ import (
 type Validator interface {
 	// Validate validates a struct
 	Validate(s any) error
+	// ValidateCtx validates a struct, passing ctx to context-aware rules
+	ValidateCtx(ctx context.Context, s any) error
 	// ValidateVar validates a single variable
 	ValidateVar(field any, tag string) error
 	// Struct validates a struct (alias for Validate)
@@ -30,8 +33,14 @@ type validator struct {
 	translator ut.Translator
 }
 
-// New creates a new Validator with English translations pre-configured
-func New() (Validator, error) {
+// New creates a new Validator with English translations and the built-in rules
+// (uuid4, e164, iso4217, cron, slug, strong_password) pre-configured.
+func New(opts ...Option) (Validator, error) {
+	validatorOptions := defaultOptions()
+	for _, opt := range opts {
+		opt(&validatorOptions)
+	}
+
 	// Create validator instance
 	v := lib_validator.New(lib_validator.WithRequiredStructEnabled())
 
@@ -48,6 +57,18 @@ func New() (Validator, error) {
 		return nil, err
 	}
 
+	rules := append(builtinRules(validatorOptions.passwordPolicy), conditionalRules()...)
+	rules = append(rules, validatorOptions.rules...)
+	for _, rule := range rules {
+		if err := registerRule(v, trans, rule); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, rule := range validatorOptions.structRules {
+		v.RegisterStructValidationCtx(rule.Func, rule.Type)
+	}
+
 	return &validator{
 		engine:     v,
 		translator: trans,
@@ -59,6 +80,11 @@ func (v *validator) Validate(s any) error {
 	return v.engine.Struct(s)
 }
 
+// ValidateCtx validates a struct passing ctx to context-aware rules (FuncCtx and struct rules)
+func (v *validator) ValidateCtx(ctx context.Context, s any) error {
+	return v.engine.StructCtx(ctx, s)
+}
+
 // ValidateVar validates a single variable with translated error messages
 func (v *validator) ValidateVar(field any, tag string) error {
 	return v.engine.Var(field, tag)
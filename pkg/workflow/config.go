@@ -0,0 +1,17 @@
+package workflow
+
+// Config configures the worker and its task queue. The connection to Temporal is the one of
+// the Worker the application provides.
+type Config struct {
+	Enabled bool `config:"enabled"` // Enable/disable the worker
+	// TaskQueue is the task queue polled by the worker (required when enabled)
+	TaskQueue string `config:"task_queue"`
+}
+
+// Validate checks the task queue.
+func (c *Config) Validate() error {
+	if c.TaskQueue == "" {
+		return ErrTaskQueueRequired
+	}
+	return nil
+}
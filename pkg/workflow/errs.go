@@ -0,0 +1,10 @@
+package workflow
+
+import "errors"
+
+var (
+	ErrTaskQueueRequired = errors.New("task queue is required when the workflow worker is enabled")
+	ErrMissingWorker     = errors.New("the enabled workflow module requires a workflow.Worker")
+	ErrNameRequired      = errors.New("workflow or activity name is required")
+	ErrDuplicateName     = errors.New("workflow or activity registered twice")
+)
@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+)
+
+// Module registers the workflows of the "workflow_workflows" group and the activities of the
+// "workflow_activities" group with the Worker of the graph, and runs it between the
+// application start and stop. It loads the config from "app.workflow" and does nothing when
+// disabled. The application provides the Worker, dialed with the Temporal SDK:
+//
+//	fx.New(
+//	    workflow.Module,
+//	    fx.Provide(temporal.NewClient, temporal.NewWorker), // the client.Client and the workflow.Worker
+//	    ucdecorator.Provide[ChargeInput, ChargeOutput](usecase.NewChargeUseCase),
+//	    workflow.ProvideActivity[ChargeInput, ChargeOutput]("ChargePayment"),
+//	    workflow.ProvideWorkflow("Checkout", CheckoutWorkflow),
+//	)
+var Module = fx.Module(
+	"workflow",
+	config.Provide[Config]("app.workflow"),
+	fx.Invoke(RegisterWithLifecycle),
+)
+
+// ProvideActivity provides the UseCase[In, Out] of the graph as the activity name, in the
+// "workflow_activities" group. Provide the use case with ucdecorator.Provide, so the
+// decorator chain applies to the activity.
+func ProvideActivity[In any, Out any](name string) fx.Option {
+	return fx.Provide(fx.Annotate(
+		func(useCase ucdecorator.UseCase[In, Out]) Activity {
+			return NewActivity(name, useCase)
+		},
+		fx.ResultTags(`group:"workflow_activities"`),
+	))
+}
+
+// ProvideWorkflow provides fn as the workflow name, in the "workflow_workflows" group.
+func ProvideWorkflow(name string, fn any) fx.Option {
+	return fx.Provide(fx.Annotate(
+		func() Workflow {
+			return NewWorkflow(name, fn)
+		},
+		fx.ResultTags(`group:"workflow_workflows"`),
+	))
+}
+
+type RegisterParams struct {
+	fx.In
+
+	Lifecycle  fx.Lifecycle
+	Config     config.Config[Config]
+	Logger     logger.Logger
+	Worker     Worker     `optional:"true"`
+	Workflows  []Workflow `group:"workflow_workflows"`
+	Activities []Activity `group:"workflow_activities"`
+}
+
+// RegisterWithLifecycle registers the grouped workflows and activities with the Worker,
+// starts it on start and stops it on stop.
+func RegisterWithLifecycle(p RegisterParams) error {
+	cfg := p.Config.Get()
+	if !cfg.Enabled {
+		return nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if p.Worker == nil {
+		return ErrMissingWorker
+	}
+	if err := Register(p.Worker, p.Workflows, p.Activities); err != nil {
+		return err
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			if err := p.Worker.Start(); err != nil {
+				return err
+			}
+			p.Logger.Info("workflow worker started",
+				logger.String("task_queue", cfg.TaskQueue),
+				logger.Int("workflows", len(p.Workflows)),
+				logger.Int("activities", len(p.Activities)),
+			)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			p.Worker.Stop()
+			return nil
+		},
+	})
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package workflow registers the use cases of the application as the activities of a durable
+// workflow engine, Temporal, next to its workflows, and runs the worker with the application.
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+)
+
+// Registry registers the workflows and activities of a worker under their names.
+type Registry interface {
+	RegisterWorkflow(name string, workflow any)
+	RegisterActivity(name string, activity any)
+}
+
+// Worker is a worker polling a task queue, e.g. a Temporal worker.Worker adapted to register
+// by name (see the README).
+type Worker interface {
+	Registry
+	// Start starts polling the task queue, without blocking
+	Start() error
+	// Stop stops polling and waits for the running tasks
+	Stop()
+}
+
+// Workflow is a named workflow function, e.g. func(ctx workflow.Context, input T) (R, error)
+// of the Temporal SDK.
+type Workflow struct {
+	Name string
+	Func any
+}
+
+// Activity is a named activity function, func(ctx context.Context, input T) (R, error).
+type Activity struct {
+	Name string
+	Func any
+}
+
+// NewWorkflow returns the Workflow fn registered as name.
+func NewWorkflow(name string, fn any) Workflow {
+	return Workflow{Name: name, Func: fn}
+}
+
+// NewActivity returns the Activity executing useCase, registered as name. The use case is
+// called as it is: wrap it with the ucdecorator chain first, or use WrapActivity.
+func NewActivity[T any, R any](name string, useCase ucdecorator.UseCase[T, R]) Activity {
+	return Activity{
+		Name: name,
+		Func: func(ctx context.Context, input T) (R, error) {
+			return useCase.Execute(ctx, input)
+		},
+	}
+}
+
+// WrapActivity returns the Activity executing useCase wrapped by factory, so every attempt
+// is logged, measured, traced and has its errors translated.
+func WrapActivity[T any, R any](
+	factory *ucdecorator.Factory,
+	name string,
+	useCase ucdecorator.UseCase[T, R],
+	opts ...ucdecorator.WrapOption,
+) Activity {
+	return NewActivity(name, ucdecorator.Wrap(factory, useCase, opts...))
+}
+
+// Register registers workflows and activities with registry, checking their names are set
+// and unique.
+func Register(registry Registry, workflows []Workflow, activities []Activity) error {
+	names := make(map[string]struct{}, len(workflows)+len(activities))
+	check := func(name string) error {
+		if name == "" {
+			return ErrNameRequired
+		}
+		if _, found := names[name]; found {
+			return fmt.Errorf("%w: %s", ErrDuplicateName, name)
+		}
+		names[name] = struct{}{}
+		return nil
+	}
+
+	for _, w := range workflows {
+		if err := check(w.Name); err != nil {
+			return err
+		}
+		registry.RegisterWorkflow(w.Name, w.Func)
+	}
+	for _, a := range activities {
+		if err := check(a.Name); err != nil {
+			return err
+		}
+		registry.RegisterActivity(a.Name, a.Func)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/cristiano-pacheco/bricks/pkg/config"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/ucdecorator"
+	"github.com/cristiano-pacheco/bricks/pkg/workflow"
+)
+
+var testLogger = logger.MustNewWithOptions(logger.WithLevel("fatal"))
+
+type ChargeUseCase struct {
+	err error
+}
+
+func (uc *ChargeUseCase) Execute(context.Context, int) (string, error) {
+	if uc.err != nil {
+		return "", uc.err
+	}
+	return "charged", nil
+}
+
+type fakeWorker struct {
+	workflows  map[string]any
+	activities map[string]any
+	started    bool
+	stopped    bool
+}
+
+func newFakeWorker() *fakeWorker {
+	return &fakeWorker{workflows: map[string]any{}, activities: map[string]any{}}
+}
+
+func (w *fakeWorker) RegisterWorkflow(name string, fn any) { w.workflows[name] = fn }
+
+func (w *fakeWorker) RegisterActivity(name string, fn any) { w.activities[name] = fn }
+
+func (w *fakeWorker) Start() error {
+	w.started = true
+	return nil
+}
+
+func (w *fakeWorker) Stop() { w.stopped = true }
+
+func checkoutWorkflow(context.Context, string) error { return nil }
+
+func TestNewActivity(t *testing.T) {
+	// Arrange
+	activity := workflow.NewActivity[int, string]("ChargePayment", &ChargeUseCase{})
+
+	// Act
+	result, err := activity.Func.(func(context.Context, int) (string, error))(context.Background(), 42)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "ChargePayment", activity.Name)
+	assert.Equal(t, "charged", result)
+}
+
+func TestWrapActivity(t *testing.T) {
+	// Arrange
+	translated := errors.New("translated")
+	factory := ucdecorator.NewFactory(
+		config.Of(ucdecorator.Config{Enabled: true, Translation: true}), nil, testLogger,
+		translatorFunc(func(error) error { return translated }), nil,
+	)
+	activity := workflow.WrapActivity[int, string](factory, "ChargePayment", &ChargeUseCase{err: errors.New("declined")})
+
+	// Act
+	_, err := activity.Func.(func(context.Context, int) (string, error))(context.Background(), 42)
+
+	// Assert
+	require.ErrorIs(t, err, translated)
+}
+
+type translatorFunc func(error) error
+
+func (f translatorFunc) TranslateError(err error) error { return f(err) }
+
+func TestRegister(t *testing.T) {
+	t.Run("registers the workflows and activities by name", func(t *testing.T) {
+		// Arrange
+		worker := newFakeWorker()
+
+		// Act
+		err := workflow.Register(worker,
+			[]workflow.Workflow{workflow.NewWorkflow("Checkout", checkoutWorkflow)},
+			[]workflow.Activity{workflow.NewActivity[int, string]("ChargePayment", &ChargeUseCase{})},
+		)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, worker.workflows, "Checkout")
+		assert.Contains(t, worker.activities, "ChargePayment")
+	})
+
+	t.Run("rejects a name registered twice", func(t *testing.T) {
+		// Act
+		err := workflow.Register(newFakeWorker(),
+			[]workflow.Workflow{workflow.NewWorkflow("Charge", checkoutWorkflow)},
+			[]workflow.Activity{workflow.NewActivity[int, string]("Charge", &ChargeUseCase{})},
+		)
+
+		// Assert
+		require.ErrorIs(t, err, workflow.ErrDuplicateName)
+	})
+
+	t.Run("rejects an empty name", func(t *testing.T) {
+		// Act
+		err := workflow.Register(newFakeWorker(), []workflow.Workflow{workflow.NewWorkflow("", checkoutWorkflow)}, nil)
+
+		// Assert
+		require.ErrorIs(t, err, workflow.ErrNameRequired)
+	})
+}
+
+func TestRegisterWithLifecycle(t *testing.T) {
+	t.Run("runs the worker with the application", func(t *testing.T) {
+		// Arrange
+		worker := newFakeWorker()
+		lifecycle := fxtest.NewLifecycle(t)
+		err := workflow.RegisterWithLifecycle(workflow.RegisterParams{
+			Lifecycle:  lifecycle,
+			Config:     config.Of(workflow.Config{Enabled: true, TaskQueue: "orders"}),
+			Logger:     testLogger,
+			Worker:     worker,
+			Activities: []workflow.Activity{workflow.NewActivity[int, string]("ChargePayment", &ChargeUseCase{})},
+		})
+		require.NoError(t, err)
+
+		// Act
+		lifecycle.RequireStart()
+		lifecycle.RequireStop()
+
+		// Assert
+		assert.True(t, worker.started)
+		assert.True(t, worker.stopped)
+		assert.Contains(t, worker.activities, "ChargePayment")
+	})
+
+	t.Run("does nothing when disabled", func(t *testing.T) {
+		// Act
+		err := workflow.RegisterWithLifecycle(workflow.RegisterParams{
+			Lifecycle: fxtest.NewLifecycle(t),
+			Config:    config.Of(workflow.Config{}),
+		})
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("requires a worker when enabled", func(t *testing.T) {
+		// Act
+		err := workflow.RegisterWithLifecycle(workflow.RegisterParams{
+			Lifecycle: fxtest.NewLifecycle(t),
+			Config:    config.Of(workflow.Config{Enabled: true, TaskQueue: "orders"}),
+		})
+
+		// Assert
+		require.ErrorIs(t, err, workflow.ErrMissingWorker)
+	})
+}
+
+func TestProvideActivity(t *testing.T) {
+	// Arrange
+	var activities struct {
+		fx.In
+
+		All []workflow.Activity `group:"workflow_activities"`
+	}
+
+	// Act
+	app := fx.New(
+		fx.NopLogger,
+		fx.Provide(func() ucdecorator.UseCase[int, string] { return &ChargeUseCase{} }),
+		workflow.ProvideActivity[int, string]("ChargePayment"),
+		fx.Populate(&activities),
+	)
+
+	// Assert
+	require.NoError(t, app.Err())
+	require.Len(t, activities.All, 1)
+	assert.Equal(t, "ChargePayment", activities.All[0].Name)
+}
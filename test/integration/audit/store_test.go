@@ -0,0 +1,123 @@
+//go:build integration
+
+package audit_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/audit"
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/paginator"
+)
+
+type GormStoreIntegrationSuite struct {
+	suite.Suite
+	kit      *itestkit.ITestKit
+	sut      *audit.GormStore
+	recorder audit.Recorder
+}
+
+func TestGormStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(GormStoreIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *GormStoreIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.Config{
+		PostgresImage:  itestkit.DefaultConfig().PostgresImage,
+		MigrationsPath: "file://" + s.migrationsPath(),
+		Database:       "audit_integration",
+		User:           "itest",
+		Password:       "itest",
+	})
+	s.Require().NoError(s.kit.StartPostgres())
+	s.Require().NoError(s.kit.RunMigrations())
+}
+
+func (s *GormStoreIntegrationSuite) TearDownSuite() {
+	s.kit.StopPostgres()
+}
+
+func (s *GormStoreIntegrationSuite) SetupTest() {
+	s.kit.TruncateTables(s.T())
+	s.sut = audit.NewGormStore(s.kit.DB(), "audit_entries")
+	s.recorder = audit.NewRecorder([]audit.Sink{s.sut})
+}
+
+func (s *GormStoreIntegrationSuite) TestList_FiltersAndPaginatesNewestFirst() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-1")
+	start := time.Now().UTC().Truncate(time.Millisecond)
+	for i, action := range []string{"order.created", "order.paid", "order.refunded"} {
+		entry, err := audit.Entry{
+			Action:     action,
+			EntityType: "order",
+			EntityID:   "42",
+			OccurredAt: start.Add(time.Duration(i) * time.Second),
+		}.WithDiff(nil, map[string]string{"status": action})
+		s.Require().NoError(err)
+		s.Require().NoError(s.recorder.Record(ctx, entry))
+	}
+	s.Require().NoError(s.recorder.Record(ctx, audit.Entry{Action: "customer.created", EntityType: "customer"}))
+
+	// Act
+	entries, meta, err := s.sut.List(
+		context.Background(),
+		audit.Filter{EntityType: "order", EntityID: "42"},
+		paginator.Params{Page: 1, PerPage: 2},
+	)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(3), meta.TotalCount)
+	s.Equal(2, meta.TotalPages)
+	s.Require().Len(entries, 2)
+	s.Equal("order.refunded", entries[0].Action)
+	s.Equal("order.paid", entries[1].Action)
+	s.Equal("user-1", entries[0].ActorID)
+	s.Equal([]string{"status"}, entries[0].Fields)
+	s.JSONEq(`{"status":"order.refunded"}`, string(entries[0].After))
+}
+
+func (s *GormStoreIntegrationSuite) TestWrite_RollsBackWithTheTransaction() {
+	// Arrange
+	txManager := database.NewTxManager(s.kit.DB())
+
+	// Act
+	_ = txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		s.Require().NoError(s.recorder.Record(ctx, audit.Entry{Action: "order.refunded"}))
+		return context.Canceled
+	})
+
+	// Assert
+	_, meta, err := s.sut.List(context.Background(), audit.Filter{}, paginator.Params{})
+	s.Require().NoError(err)
+	s.Zero(meta.TotalCount)
+}
+
+func (s *GormStoreIntegrationSuite) migrationsPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	s.Require().True(ok)
+	migrationsDir := filepath.Join(filepath.Dir(filename), "..", "..", "..", "pkg", "audit", "migrations")
+	_, err := os.Stat(filepath.Join(migrationsDir, "20261015000000_create_audit_entries.up.sql"))
+	s.Require().NoError(err)
+
+	return migrationsDir
+}
@@ -0,0 +1,134 @@
+//go:build integration
+
+package authz_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/authz"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+type AuthzIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *redis.Client
+	sut    *authz.Authorizer
+}
+
+func TestAuthzIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(AuthzIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *AuthzIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.Config{
+		PostgresImage:  itestkit.DefaultConfig().PostgresImage,
+		RedisImage:     itestkit.DefaultConfig().RedisImage,
+		MigrationsPath: "file://" + s.migrationsPath(),
+		Database:       "authz_integration",
+		User:           "itest",
+		Password:       "itest",
+	})
+	s.Require().NoError(s.kit.StartPostgres())
+	s.Require().NoError(s.kit.RunMigrations())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+}
+
+func (s *AuthzIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+	s.kit.StopPostgres()
+}
+
+func (s *AuthzIntegrationSuite) SetupTest() {
+	s.kit.TruncateTables(s.T())
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+	s.Require().NoError(s.kit.DB().Exec(
+		"INSERT INTO role_permissions (role, resource, action) VALUES ('viewer', 'orders', 'read')",
+	).Error)
+
+	cache := authz.NewRedisDecisionCache(s.client, "authz:")
+	s.sut = authz.NewAuthorizer(
+		authz.NewGormSource(s.kit.DB(), "role_permissions"),
+		authz.WithDecisionCache(cache, time.Minute),
+	)
+}
+
+func (s *AuthzIntegrationSuite) TestCan_ReadsThePermissionsOfTheDatabase() {
+	// Arrange
+	ctx := context.Background()
+	viewer := authz.Subject{ID: "user-1", Roles: []string{"viewer"}}
+
+	// Act
+	canRead, readErr := s.sut.Can(ctx, viewer, "read", "orders")
+	canDelete, deleteErr := s.sut.Can(ctx, viewer, "delete", "orders")
+
+	// Assert
+	s.Require().NoError(readErr)
+	s.Require().NoError(deleteErr)
+	s.True(canRead)
+	s.False(canDelete)
+}
+
+func (s *AuthzIntegrationSuite) TestCan_CachesTheDecisionUntilInvalidated() {
+	// Arrange
+	ctx := context.Background()
+	viewer := authz.Subject{ID: "user-1", Roles: []string{"viewer"}}
+	_, err := s.sut.Can(ctx, viewer, "delete", "orders")
+	s.Require().NoError(err)
+	s.Require().NoError(s.kit.DB().Exec(
+		"INSERT INTO role_permissions (role, resource, action) VALUES ('viewer', 'orders', 'delete')",
+	).Error)
+
+	// Act
+	cached, cachedErr := s.sut.Can(ctx, viewer, "delete", "orders")
+	s.Require().NoError(s.sut.InvalidateCache(ctx))
+	fresh, freshErr := s.sut.Can(ctx, viewer, "delete", "orders")
+
+	// Assert
+	s.Require().NoError(cachedErr)
+	s.Require().NoError(freshErr)
+	s.False(cached)
+	s.True(fresh)
+	keys, err := s.kit.Redis().Keys(ctx, "shop:authz:*").Result()
+	s.Require().NoError(err)
+	s.Len(keys, 1)
+}
+
+func (s *AuthzIntegrationSuite) migrationsPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	s.Require().True(ok)
+	migrationsDir := filepath.Join(filepath.Dir(filename), "migrations")
+	_, err := os.Stat(filepath.Join(migrationsDir, "000001_create_role_permissions.up.sql"))
+	s.Require().NoError(err)
+
+	return migrationsDir
+}
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role TEXT NOT NULL,
+    resource TEXT NOT NULL,
+    action TEXT NOT NULL,
+    PRIMARY KEY (role, resource, action)
+);
@@ -0,0 +1,159 @@
+//go:build integration
+
+package aws_test
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func requireDocker(s *suite.Suite) {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+}
+
+type SessionIntegrationSuite struct {
+	suite.Suite
+	kit     *itestkit.ITestKit
+	session *aws.Session
+}
+
+func TestSessionIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(SessionIntegrationSuite))
+}
+
+func (s *SessionIntegrationSuite) SetupSuite() {
+	requireDocker(&s.Suite)
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartLocalstack("s3", "sqs", "secretsmanager", "sts"))
+
+	var err error
+	s.session, err = aws.NewSession(aws.Config{
+		Region:          s.kit.LocalstackRegion(),
+		AccessKeyID:     "test",
+		SecretAccessKey: "test",
+		Endpoint:        s.kit.LocalstackEndpoint(),
+		AssumeRole:      aws.AssumeRoleConfig{RoleARN: "arn:aws:iam::000000000000:role/orders"},
+	}, aws.WithRegisterer(prometheus.NewRegistry()))
+	s.Require().NoError(err)
+}
+
+func (s *SessionIntegrationSuite) TearDownSuite() {
+	if s.kit != nil {
+		s.kit.Cleanup()
+	}
+}
+
+func (s *SessionIntegrationSuite) TestCredentials_AssumesTheRole() {
+	// Act
+	creds, err := s.session.Config().Credentials.Retrieve(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.NotEqual("test", creds.AccessKeyID)
+	s.NotEmpty(creds.SessionToken)
+	s.True(creds.Expires.After(time.Now()))
+}
+
+func (s *SessionIntegrationSuite) TestS3_PutsAndGetsObjects() {
+	// Arrange
+	ctx := context.Background()
+	sut := aws.NewS3(s.session)
+	_, err := sut.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: awssdk.String("invoices")})
+	s.Require().NoError(err)
+
+	// Act
+	_, err = sut.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: awssdk.String("invoices"),
+		Key:    awssdk.String("2026/10/42.txt"),
+		Body:   strings.NewReader("invoice 42"),
+	})
+	s.Require().NoError(err)
+	output, err := sut.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: awssdk.String("invoices"),
+		Key:    awssdk.String("2026/10/42.txt"),
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	defer output.Body.Close()
+	object, err := io.ReadAll(output.Body)
+	s.Require().NoError(err)
+	s.Equal("invoice 42", string(object))
+}
+
+func (s *SessionIntegrationSuite) TestSQS_SendsReceivesAndDeletesMessages() {
+	// Arrange
+	ctx := context.Background()
+	queueURL, err := s.kit.CreateLocalstackQueue(ctx, "billing", nil)
+	s.Require().NoError(err)
+	sut := aws.NewSQS(s.session)
+
+	// Act
+	sent, err := sut.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    awssdk.String(queueURL),
+		MessageBody: awssdk.String("invoice 42"),
+		MessageAttributes: map[string]sqstypes.MessageAttributeValue{
+			"Tenant": {DataType: awssdk.String("String"), StringValue: awssdk.String("acme")},
+		},
+	})
+	s.Require().NoError(err)
+	received, err := sut.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:              awssdk.String(queueURL),
+		MaxNumberOfMessages:   10,
+		WaitTimeSeconds:       1,
+		MessageAttributeNames: []string{"All"},
+	})
+	s.Require().NoError(err)
+
+	// Assert
+	s.Require().Len(received.Messages, 1)
+	message := received.Messages[0]
+	s.Equal(awssdk.ToString(sent.MessageId), awssdk.ToString(message.MessageId))
+	s.Equal("invoice 42", awssdk.ToString(message.Body))
+	s.Equal("acme", awssdk.ToString(message.MessageAttributes["Tenant"].StringValue))
+	_, err = sut.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      awssdk.String(queueURL),
+		ReceiptHandle: message.ReceiptHandle,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *SessionIntegrationSuite) TestSecretsManager_GetsTheSecretValue() {
+	// Arrange
+	ctx := context.Background()
+	sut := aws.NewSecretsManager(s.session)
+	_, err := sut.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+		Name:         awssdk.String("orders/db"),
+		SecretString: awssdk.String(`{"password":"s3cr3t"}`),
+	})
+	s.Require().NoError(err)
+
+	// Act
+	secret, err := sut.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: awssdk.String("orders/db")})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("orders/db", awssdk.ToString(secret.Name))
+	s.JSONEq(`{"password":"s3cr3t"}`, awssdk.ToString(secret.SecretString))
+}
@@ -0,0 +1,86 @@
+//go:build integration
+
+package chi_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/http/server/chi"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+type RedisMaintenanceStoreIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *redis.Client
+	sut    *chi.RedisMaintenanceStore
+}
+
+func TestRedisMaintenanceStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RedisMaintenanceStoreIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *RedisMaintenanceStoreIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+	s.sut = chi.NewRedisMaintenanceStore(s.client, "maintenance")
+}
+
+func (s *RedisMaintenanceStoreIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *RedisMaintenanceStoreIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+}
+
+func (s *RedisMaintenanceStoreIntegrationSuite) TestGet_MissingKey_ReturnsDisabled() {
+	// Act
+	enabled, err := s.sut.Get(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(enabled)
+}
+
+func (s *RedisMaintenanceStoreIntegrationSuite) TestSet_SwitchesTheNamespacedKey() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	s.Require().NoError(s.sut.Set(ctx, true))
+	enabledValue := s.kit.Redis().Get(ctx, "shop:maintenance").Val()
+	enabled, err := s.sut.Get(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(s.sut.Set(ctx, false))
+
+	// Assert
+	s.Equal("1", enabledValue)
+	s.True(enabled)
+	s.Zero(s.kit.Redis().Exists(ctx, "shop:maintenance").Val())
+}
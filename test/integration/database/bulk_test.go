@@ -0,0 +1,216 @@
+//go:build integration
+
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+)
+
+type product struct {
+	ID    int64
+	SKU   string
+	Name  string
+	Price int64
+}
+
+type BulkIntegrationSuite struct {
+	suite.Suite
+	kit *itestkit.ITestKit
+}
+
+func TestBulkIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(BulkIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *BulkIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.Config{
+		PostgresImage:  itestkit.DefaultConfig().PostgresImage,
+		MigrationsPath: "file://" + s.migrationsPath(),
+		Database:       "bulk_integration",
+		User:           "itest",
+		Password:       "itest",
+	})
+	s.Require().NoError(s.kit.StartPostgres())
+	s.Require().NoError(s.kit.RunMigrations())
+}
+
+func (s *BulkIntegrationSuite) TearDownSuite() {
+	s.kit.StopPostgres()
+}
+
+func (s *BulkIntegrationSuite) SetupTest() {
+	s.kit.TruncateTables(s.T())
+}
+
+func (s *BulkIntegrationSuite) products(from, n int, price int64) []product {
+	rows := make([]product, n)
+	for i := range rows {
+		id := from + i
+		rows[i] = product{ID: int64(id), SKU: fmt.Sprintf("sku-%d", id), Name: fmt.Sprintf("product %d", id), Price: price}
+	}
+	return rows
+}
+
+func (s *BulkIntegrationSuite) count() int64 {
+	var count int64
+	s.Require().NoError(s.kit.DB().Model(&product{}).Count(&count).Error)
+	return count
+}
+
+func (s *BulkIntegrationSuite) TestBulkInsert_CountsOnlyTheInsertedRows() {
+	// Arrange
+	ctx := context.Background()
+	_, err := database.BulkInsert(ctx, s.kit.DB(), s.products(1, 10, 100), 3)
+	s.Require().NoError(err)
+
+	// Act
+	inserted, err := database.BulkInsert(ctx, s.kit.DB(), s.products(6, 10, 100), 3, database.OnConflictDoNothing("sku"))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(5), inserted)
+	s.Equal(int64(15), s.count())
+}
+
+func (s *BulkIntegrationSuite) TestBulkUpsert_UpdatesTheExistingRows() {
+	// Arrange
+	ctx := context.Background()
+	_, err := database.BulkInsert(ctx, s.kit.DB(), s.products(1, 5, 100), 0)
+	s.Require().NoError(err)
+
+	// Act
+	_, err = database.BulkUpsert(ctx, s.kit.DB(), s.products(1, 10, 200), 4, []string{"id"})
+
+	// Assert
+	s.Require().NoError(err)
+	var total int64
+	s.Require().NoError(s.kit.DB().Model(&product{}).Select("SUM(price)").Scan(&total).Error)
+	s.Equal(int64(10*200), total)
+}
+
+func (s *BulkIntegrationSuite) TestBulkInsert_RollsBackWithTheTransaction() {
+	// Arrange
+	txManager := database.NewTxManager(s.kit.DB())
+	rows := append(s.products(1, 5, 100), product{ID: 6, SKU: "sku-1", Name: "duplicate"})
+
+	// Act
+	err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := database.BulkInsert(ctx, s.kit.DB(), rows, 2)
+		return err
+	})
+
+	// Assert
+	s.Require().Error(err)
+	s.Zero(s.count())
+}
+
+func (s *BulkIntegrationSuite) TestCopyFrom_LoadsTheRows() {
+	// Arrange
+	rows := s.products(1, 2500, 100)
+	var reported []int64
+
+	// Act
+	copied, err := database.CopyFrom(context.Background(), s.kit.DB(), "products",
+		[]string{"id", "sku", "name", "price"},
+		database.CopyFromSlice(rows, func(p product) ([]any, error) {
+			return []any{p.ID, p.SKU, p.Name, p.Price}, nil
+		}),
+		database.WithProgress(func(done, _ int64) { reported = append(reported, done) }),
+	)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(2500), copied)
+	s.Equal(int64(2500), s.count())
+	s.Equal([]int64{1000, 2000, 2500}, reported)
+}
+
+func (s *BulkIntegrationSuite) TestCopyFrom_RejectsATransaction() {
+	// Arrange
+	txManager := database.NewTxManager(s.kit.DB())
+
+	// Act
+	err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := database.CopyFrom(ctx, s.kit.DB(), "products", []string{"id"}, database.CopyFromSlice([]int64{1},
+			func(id int64) ([]any, error) { return []any{id}, nil }))
+		return err
+	})
+
+	// Assert
+	s.Require().ErrorIs(err, database.ErrCopyInTransaction)
+}
+
+func (s *BulkIntegrationSuite) TestIterate_StreamsTheMatchingRowsInBatches() {
+	// Arrange
+	ctx := context.Background()
+	_, err := database.BulkInsert(ctx, s.kit.DB(), s.products(1, 25, 100), 0)
+	s.Require().NoError(err)
+	_, err = database.BulkInsert(ctx, s.kit.DB(), s.products(26, 5, 900), 0)
+	s.Require().NoError(err)
+	var batches [][]product
+
+	// Act
+	processed, err := database.Iterate(ctx, s.kit.DB(),
+		func(db *gorm.DB) *gorm.DB { return db.Where("price < ?", 500) },
+		10,
+		func(_ context.Context, batch []product) error {
+			batches = append(batches, batch)
+			return nil
+		})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(25), processed)
+	s.Require().Len(batches, 3)
+	s.Equal(int64(1), batches[0][0].ID)
+	s.Equal(int64(11), batches[1][0].ID)
+	s.Equal(int64(25), batches[2][4].ID)
+}
+
+func (s *BulkIntegrationSuite) TestIterate_StopsWhenTheContextIsCanceled() {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := database.BulkInsert(ctx, s.kit.DB(), s.products(1, 30, 100), 0)
+	s.Require().NoError(err)
+
+	// Act
+	processed, err := database.Iterate(ctx, s.kit.DB(), nil, 10, func(context.Context, []product) error {
+		cancel()
+		return nil
+	})
+
+	// Assert
+	s.Require().ErrorIs(err, context.Canceled)
+	s.Equal(int64(10), processed)
+}
+
+func (s *BulkIntegrationSuite) migrationsPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	s.Require().True(ok)
+	migrationsDir := filepath.Join(filepath.Dir(filename), "migrations")
+	_, err := os.Stat(filepath.Join(migrationsDir, "000001_create_products.up.sql"))
+	s.Require().NoError(err)
+
+	return migrationsDir
+}
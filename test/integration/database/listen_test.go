@@ -0,0 +1,157 @@
+//go:build integration
+
+package database_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+)
+
+type ListenerIntegrationSuite struct {
+	suite.Suite
+	kit        *itestkit.ITestKit
+	sut        *database.Listener
+	received   chan database.Notification
+	reconnects chan struct{}
+}
+
+func TestListenerIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(ListenerIntegrationSuite))
+}
+
+func (s *ListenerIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.Config{
+		PostgresImage: itestkit.DefaultConfig().PostgresImage,
+		Database:      "listen_integration",
+		User:          "itest",
+		Password:      "itest",
+	})
+	s.Require().NoError(s.kit.StartPostgres())
+}
+
+func (s *ListenerIntegrationSuite) TearDownSuite() {
+	s.kit.StopPostgres()
+}
+
+func (s *ListenerIntegrationSuite) SetupTest() {
+	s.received = make(chan database.Notification, 10)
+	s.reconnects = make(chan struct{}, 1)
+	s.sut = database.NewListener(s.config(),
+		database.WithReconnectDelay(100*time.Millisecond),
+		database.WithOnReconnect(func(context.Context) { s.reconnects <- struct{}{} }),
+	)
+	s.sut.Listen("orders", s.handle)
+	s.sut.Start()
+	s.waitListening("orders")
+}
+
+func (s *ListenerIntegrationSuite) TearDownTest() {
+	s.Require().NoError(s.sut.Stop(context.Background()))
+}
+
+func (s *ListenerIntegrationSuite) handle(_ context.Context, notification database.Notification) error {
+	s.received <- notification
+	return nil
+}
+
+func (s *ListenerIntegrationSuite) TestNotify_IsDeliveredWhenTheTransactionCommits() {
+	// Arrange
+	txManager := database.NewTxManager(s.kit.DB())
+
+	// Act
+	err := txManager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := database.Notify(ctx, s.kit.DB(), "orders", "42"); err != nil {
+			return err
+		}
+		s.Never(func() bool { return len(s.received) > 0 }, 200*time.Millisecond, 50*time.Millisecond)
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("42", s.next().Payload)
+}
+
+func (s *ListenerIntegrationSuite) TestListen_SubscribesWhileRunning() {
+	// Arrange
+	s.sut.Listen("customers", s.handle)
+	s.waitListening("customers")
+
+	// Act
+	err := database.Notify(context.Background(), s.kit.DB(), "customers", "7")
+
+	// Assert
+	s.Require().NoError(err)
+	notification := s.next()
+	s.Equal("customers", notification.Channel)
+	s.Equal("7", notification.Payload)
+}
+
+func (s *ListenerIntegrationSuite) TestRun_ReconnectsAndListensAgain() {
+	// Arrange
+	err := s.kit.DB().Exec(
+		"SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE query LIKE 'LISTEN%' AND pid <> pg_backend_pid()",
+	).Error
+	s.Require().NoError(err)
+
+	// Act
+	select {
+	case <-s.reconnects:
+	case <-time.After(10 * time.Second):
+		s.FailNow("the listener did not reconnect")
+	}
+	s.waitListening("orders")
+	err = database.Notify(context.Background(), s.kit.DB(), "orders", "43")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("43", s.next().Payload)
+}
+
+func (s *ListenerIntegrationSuite) next() database.Notification {
+	select {
+	case notification := <-s.received:
+		return notification
+	case <-time.After(5 * time.Second):
+		s.FailNow("no notification received")
+		return database.Notification{}
+	}
+}
+
+// waitListening waits for the listener connection to listen to channel.
+func (s *ListenerIntegrationSuite) waitListening(channel string) {
+	s.Eventually(func() bool {
+		var listening bool
+		err := s.kit.DB().Raw(
+			"SELECT EXISTS (SELECT 1 FROM pg_stat_activity WHERE query = ?)", `LISTEN "`+channel+`"`,
+		).Scan(&listening).Error
+		return err == nil && listening
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+// config returns the database config of the itestkit container.
+func (s *ListenerIntegrationSuite) config() database.Config {
+	dsn := s.kit.DB().Dialector.(*postgres.Dialector).Config.DSN
+	pgConfig, err := pgconn.ParseConfig(dsn)
+	s.Require().NoError(err)
+	return database.Config{
+		Host:     pgConfig.Host,
+		Port:     uint(pgConfig.Port),
+		User:     pgConfig.User,
+		Password: pgConfig.Password,
+		Name:     pgConfig.Database,
+	}
+}
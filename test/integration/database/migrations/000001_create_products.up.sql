@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS products (
+    id BIGINT PRIMARY KEY,
+    sku TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    price BIGINT NOT NULL
+);
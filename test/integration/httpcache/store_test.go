@@ -0,0 +1,106 @@
+//go:build integration
+
+package httpcache_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/httpcache"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+type RedisStoreIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *redis.Client
+	sut    *httpcache.RedisStore
+}
+
+func TestRedisStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RedisStoreIntegrationSuite))
+}
+
+func (s *RedisStoreIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+	s.sut = httpcache.NewRedisStore(s.client)
+}
+
+func (s *RedisStoreIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *RedisStoreIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+}
+
+func (s *RedisStoreIntegrationSuite) TestSet_StoresTheValueWithItsTTL() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	err := s.sut.Set(ctx, "httpcache:/products|abc", []byte("response"), time.Minute)
+
+	// Assert
+	s.Require().NoError(err)
+	value, ok, err := s.sut.Get(ctx, "httpcache:/products|abc")
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal([]byte("response"), value)
+	ttl, err := s.kit.Redis().TTL(ctx, "shop:httpcache:/products|abc").Result()
+	s.Require().NoError(err)
+	s.InDelta(time.Minute.Seconds(), ttl.Seconds(), 5)
+}
+
+func (s *RedisStoreIntegrationSuite) TestGet_MissingKey_ReturnsFalse() {
+	// Act
+	_, ok, err := s.sut.Get(context.Background(), "httpcache:/missing|abc")
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(ok)
+}
+
+func (s *RedisStoreIntegrationSuite) TestDeletePrefix_EscapesTheGlobCharacters() {
+	// Arrange
+	ctx := context.Background()
+	for _, key := range []string{"httpcache:/search*|a", "httpcache:/search*|b", "httpcache:/searches|a"} {
+		s.Require().NoError(s.sut.Set(ctx, key, []byte("response"), time.Minute))
+	}
+
+	// Act
+	deleted, err := s.sut.DeletePrefix(ctx, "httpcache:/search*|")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(2), deleted)
+	_, ok, err := s.sut.Get(ctx, "httpcache:/searches|a")
+	s.Require().NoError(err)
+	s.True(ok)
+}
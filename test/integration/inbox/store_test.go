@@ -0,0 +1,190 @@
+//go:build integration
+
+package inbox_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/inbox"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func requireDocker(s *suite.Suite) {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+}
+
+type RedisStoreIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *redis.Client
+	sut    *inbox.RedisStore
+}
+
+func TestRedisStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RedisStoreIntegrationSuite))
+}
+
+func (s *RedisStoreIntegrationSuite) SetupSuite() {
+	requireDocker(&s.Suite)
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	var err error
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+	s.sut = inbox.NewRedisStore(s.client, "inbox:")
+}
+
+func (s *RedisStoreIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *RedisStoreIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+}
+
+func (s *RedisStoreIntegrationSuite) TestClaim_RejectsTheClaimedAndProcessedMessages() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now()
+	s.Require().NoError(s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute)))
+
+	// Act
+	errInFlight := s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute))
+	s.Require().NoError(s.sut.Complete(ctx, "billing", "message-1", now, now.Add(time.Hour)))
+	errDuplicate := s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute))
+
+	// Assert
+	s.Require().ErrorIs(errInFlight, inbox.ErrInFlight)
+	s.Require().ErrorIs(errDuplicate, inbox.ErrDuplicate)
+	ttl, err := s.kit.Redis().TTL(ctx, "shop:inbox:billing:message-1").Result()
+	s.Require().NoError(err)
+	s.InDelta(time.Hour.Seconds(), ttl.Seconds(), 5)
+}
+
+func (s *RedisStoreIntegrationSuite) TestRelease_LetsTheMessageBeClaimedAgain() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now()
+	s.Require().NoError(s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute)))
+
+	// Act
+	s.Require().NoError(s.sut.Release(ctx, "billing", "message-1"))
+
+	// Assert
+	s.Require().NoError(s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute)))
+}
+
+type GormStoreIntegrationSuite struct {
+	suite.Suite
+	kit *itestkit.ITestKit
+	sut *inbox.GormStore
+}
+
+func TestGormStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(GormStoreIntegrationSuite))
+}
+
+func (s *GormStoreIntegrationSuite) SetupSuite() {
+	requireDocker(&s.Suite)
+	s.kit = itestkit.New(itestkit.Config{
+		PostgresImage:  itestkit.DefaultConfig().PostgresImage,
+		MigrationsPath: "file://" + s.migrationsPath(),
+		Database:       "inbox_integration",
+		User:           "itest",
+		Password:       "itest",
+	})
+	s.Require().NoError(s.kit.StartPostgres())
+	s.Require().NoError(s.kit.RunMigrations())
+}
+
+func (s *GormStoreIntegrationSuite) TearDownSuite() {
+	s.kit.StopPostgres()
+}
+
+func (s *GormStoreIntegrationSuite) SetupTest() {
+	s.kit.TruncateTables(s.T())
+	s.sut = inbox.NewGormStore(s.kit.DB(), "inbox_messages")
+}
+
+func (s *GormStoreIntegrationSuite) TestClaim_RejectsTheClaimedAndProcessedMessages() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now().UTC()
+	s.Require().NoError(s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute)))
+
+	// Act
+	errInFlight := s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute))
+	s.Require().NoError(s.sut.Complete(ctx, "billing", "message-1", now, now.Add(time.Hour)))
+	errDuplicate := s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute))
+
+	// Assert
+	s.Require().ErrorIs(errInFlight, inbox.ErrInFlight)
+	s.Require().ErrorIs(errDuplicate, inbox.ErrDuplicate)
+	s.Require().NoError(s.sut.Claim(ctx, "shipping", "message-1", now, now.Add(time.Minute)))
+}
+
+func (s *GormStoreIntegrationSuite) TestClaim_TakesAnExpiredLeaseOver() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now().UTC()
+	s.Require().NoError(s.sut.Claim(ctx, "billing", "message-1", now, now.Add(time.Minute)))
+
+	// Act
+	err := s.sut.Claim(ctx, "billing", "message-1", now.Add(2*time.Minute), now.Add(3*time.Minute))
+
+	// Assert
+	s.Require().NoError(err)
+}
+
+func (s *GormStoreIntegrationSuite) TestDeleteExpired_KeepsTheRetainedMessages() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now().UTC()
+	for id, expiresAt := range map[string]time.Time{"expired": now.Add(-time.Minute), "retained": now.Add(time.Hour)} {
+		s.Require().NoError(s.sut.Claim(ctx, "billing", id, now.Add(-time.Hour), expiresAt))
+		s.Require().NoError(s.sut.Complete(ctx, "billing", id, now.Add(-time.Hour), expiresAt))
+	}
+
+	// Act
+	deleted, err := s.sut.DeleteExpired(ctx, now)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(1), deleted)
+	s.Require().ErrorIs(s.sut.Claim(ctx, "billing", "retained", now, now.Add(time.Minute)), inbox.ErrDuplicate)
+}
+
+func (s *GormStoreIntegrationSuite) migrationsPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	s.Require().True(ok)
+	migrationsDir := filepath.Join(filepath.Dir(filename), "..", "..", "..", "pkg", "inbox", "migrations")
+	_, err := os.Stat(filepath.Join(migrationsDir, "20261015000003_create_inbox_messages.up.sql"))
+	s.Require().NoError(err)
+
+	return migrationsDir
+}
@@ -0,0 +1,93 @@
+//go:build integration
+
+package ipfilter_test
+
+import (
+	"context"
+	"net/netip"
+	"os/exec"
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ipfilter"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+type RedisSourceIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *redis.Client
+	sut    *ipfilter.RedisSource
+}
+
+func TestRedisSourceIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RedisSourceIntegrationSuite))
+}
+
+func (s *RedisSourceIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+	s.sut = ipfilter.NewRedisSource(s.client, "ipfilter")
+}
+
+func (s *RedisSourceIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *RedisSourceIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+}
+
+func (s *RedisSourceIntegrationSuite) TestLoad_MissingKey_HasNoLists() {
+	// Act
+	_, ok, err := s.sut.Load(context.Background())
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(ok)
+}
+
+func (s *RedisSourceIntegrationSuite) TestReload_AppliesTheSavedLists() {
+	// Arrange
+	ctx := context.Background()
+	filter, err := ipfilter.NewFilter(ipfilter.Lists{Allow: []string{"10.8.0.0/16"}}, ipfilter.WithSource(s.sut, 0))
+	s.Require().NoError(err)
+	s.Require().NoError(s.sut.Save(ctx, ipfilter.Lists{Allow: []string{"198.51.100.0/24"}}))
+
+	// Act
+	filter.Reload(ctx)
+
+	// Assert
+	s.True(filter.Allowed(netip.MustParseAddr("198.51.100.1")))
+	s.False(filter.Allowed(netip.MustParseAddr("10.8.0.1")))
+}
+
+func (s *RedisSourceIntegrationSuite) TestSave_InvalidLists_ReturnsAnError() {
+	// Act
+	err := s.sut.Save(context.Background(), ipfilter.Lists{Deny: []string{"not-an-ip"}})
+
+	// Assert
+	s.Require().ErrorIs(err, ipfilter.ErrInvalidEntry)
+}
@@ -0,0 +1 @@
+UPDATE posts SET title = title || ' world';
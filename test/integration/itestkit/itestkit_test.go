@@ -4,13 +4,19 @@ package itestkit_test
 
 import (
 	"context"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -22,6 +28,10 @@ func TestITestKitIntegrationSuite(t *testing.T) {
 	suite.Run(t, new(ITestKitIntegrationSuite))
 }
 
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
 func (s *ITestKitIntegrationSuite) TestRunMigrations() {
 	// Arrange
 	kit := s.setupTestKit()
@@ -163,18 +173,314 @@ func (s *ITestKitIntegrationSuite) TestRedisStartAndStopLifecycle() {
 	s.Error(errAfterStop)
 }
 
+func (s *ITestKitIntegrationSuite) TestNewTestDatabaseIsIsolated() {
+	// Arrange
+	s.ensureDocker()
+	kit, err := itestkit.Shared(s.config())
+	s.Require().NoError(err)
+	first := kit.NewTestDatabase(s.T())
+	second := kit.NewTestDatabase(s.T())
+
+	// Act
+	s.Require().NoError(first.Exec("INSERT INTO users (name) VALUES (?)", "alice").Error)
+
+	// Assert
+	type countResult struct {
+		Total int
+	}
+	var inFirst, inSecond countResult
+	s.Require().NoError(first.Raw("SELECT COUNT(*) AS total FROM users").Scan(&inFirst).Error)
+	s.Require().NoError(second.Raw("SELECT COUNT(*) AS total FROM users").Scan(&inSecond).Error)
+	s.Equal(1, inFirst.Total)
+	s.Equal(0, inSecond.Total)
+}
+
+func (s *ITestKitIntegrationSuite) TestNewTestDatabaseIsDroppedOnCleanup() {
+	// Arrange
+	s.ensureDocker()
+	kit, err := itestkit.Shared(s.config())
+	s.Require().NoError(err)
+	var name string
+
+	// Act
+	s.Run("create", func() {
+		db := kit.NewTestDatabase(s.T())
+		s.Require().NoError(db.Raw("SELECT current_database()").Scan(&name).Error)
+	})
+
+	// Assert
+	s.Require().NotEmpty(name)
+	var exists bool
+	s.Require().NoError(
+		kit.DB().Raw("SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = ?)", name).Scan(&exists).Error,
+	)
+	s.False(exists)
+}
+
+func (s *ITestKitIntegrationSuite) TestLoadFixturesInsertsParentsFirst() {
+	// Arrange
+	s.ensureDocker()
+	kit, err := itestkit.Shared(s.config())
+	s.Require().NoError(err)
+	db := kit.NewTestDatabase(s.T())
+
+	// Act
+	itestkit.LoadFixturesInto(s.T(), db, s.fixturesPath())
+
+	// Assert
+	type postRow struct {
+		UserID      int
+		ExternalID  string
+		Title       string
+		PublishedAt time.Time
+	}
+	var post postRow
+	s.Require().NoError(
+		db.Raw("SELECT user_id, external_id, title, published_at FROM posts WHERE id = 10").Scan(&post).Error,
+	)
+	s.Equal(1, post.UserID)
+	s.Len(post.ExternalID, 36)
+	s.Equal("Hello world", post.Title)
+	s.WithinDuration(time.Now().Add(-24*time.Hour), post.PublishedAt, time.Minute)
+
+	var nextID int
+	s.Require().NoError(db.Raw("INSERT INTO users (name) VALUES ('charlie') RETURNING id").Scan(&nextID).Error)
+	s.Equal(3, nextID)
+}
+
+func (s *ITestKitIntegrationSuite) TestLoadFixturesReplacesExistingRows() {
+	// Arrange
+	s.ensureDocker()
+	kit, err := itestkit.Shared(s.config())
+	s.Require().NoError(err)
+	db := kit.NewTestDatabase(s.T())
+	s.Require().NoError(db.Exec("INSERT INTO users (name) VALUES ('mallory')").Error)
+
+	// Act
+	itestkit.LoadFixturesInto(s.T(), db, s.fixturesPath())
+
+	// Assert
+	var names []string
+	s.Require().NoError(db.Raw("SELECT name FROM users ORDER BY id").Scan(&names).Error)
+	s.Equal([]string{"alice", "bob"}, names)
+}
+
+func (s *ITestKitIntegrationSuite) TestSeedSetsGeneratedKeys() {
+	// Arrange
+	s.ensureDocker()
+	kit, err := itestkit.Shared(s.config())
+	s.Require().NoError(err)
+	db := kit.NewTestDatabase(s.T())
+	type user struct {
+		ID   int
+		Name string
+	}
+	alice := &user{Name: "alice"}
+	others := &[]user{{Name: "bob"}, {Name: "charlie"}}
+
+	// Act
+	itestkit.SeedInto(s.T(), db, alice, others)
+
+	// Assert
+	s.Equal(1, alice.ID)
+	s.Equal(2, (*others)[0].ID)
+	s.Equal(3, (*others)[1].ID)
+}
+
+func (s *ITestKitIntegrationSuite) TestRestoreBringsBackSnapshot() {
+	// Arrange
+	kit := s.setupTestKit()
+	s.Require().NoError(kit.StartPostgres())
+	s.T().Cleanup(kit.StopPostgres)
+	s.Require().NoError(kit.RunMigrations())
+	kit.LoadFixtures(s.T(), s.fixturesPath())
+	kit.Snapshot(s.T())
+	s.Require().NoError(kit.DB().Exec("DELETE FROM posts").Error)
+	s.Require().NoError(kit.DB().Exec("INSERT INTO users (name) VALUES ('mallory')").Error)
+
+	// Act
+	kit.Restore(s.T())
+
+	// Assert
+	var names []string
+	s.Require().NoError(kit.DB().Raw("SELECT name FROM users ORDER BY id").Scan(&names).Error)
+	s.Equal([]string{"alice", "bob"}, names)
+	var posts int
+	s.Require().NoError(kit.DB().Raw("SELECT COUNT(*) FROM posts").Scan(&posts).Error)
+	s.Equal(1, posts)
+}
+
+func (s *ITestKitIntegrationSuite) TestRedisClusterServesKeysAcrossSlots() {
+	// Arrange
+	kit := s.setupTestKit()
+	cfg, err := kit.StartRedisCluster(3)
+	s.Require().NoError(err)
+	s.T().Cleanup(kit.StopRedisCluster)
+	ctx := context.Background()
+
+	// Act
+	client, err := redis.NewClient(ctx, cfg)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = client.Close() })
+	keys := []string{"alpha", "bravo", "charlie", "delta"}
+	for _, key := range keys {
+		s.Require().NoError(client.UniversalClient().Set(ctx, key, key, 0).Err())
+	}
+
+	// Assert
+	s.Len(cfg.ClusterAddrs, 3)
+	for _, key := range keys {
+		value, getErr := client.UniversalClient().Get(ctx, key).Result()
+		s.Require().NoError(getErr)
+		s.Equal(key, value)
+	}
+}
+
+func (s *ITestKitIntegrationSuite) TestRedisSentinelResolvesMaster() {
+	// Arrange
+	kit := s.setupTestKit()
+	cfg, err := kit.StartRedisSentinel()
+	s.Require().NoError(err)
+	s.T().Cleanup(kit.StopRedisSentinel)
+	ctx := context.Background()
+
+	// Act
+	client, err := redis.NewClient(ctx, cfg)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = client.Close() })
+	setErr := client.UniversalClient().Set(ctx, "itestkit:sentinel", "ok", 0).Err()
+
+	// Assert
+	s.Require().NoError(setErr)
+	value, err := client.UniversalClient().Get(ctx, "itestkit:sentinel").Result()
+	s.Require().NoError(err)
+	s.Equal("ok", value)
+}
+
+func (s *ITestKitIntegrationSuite) TestKafkaAcceptsConnections() {
+	// Arrange
+	kit := s.setupTestKit()
+
+	// Act
+	s.Require().NoError(kit.StartKafka())
+	s.T().Cleanup(kit.StopKafka)
+
+	// Assert
+	brokers := kit.KafkaBrokers()
+	s.Require().Len(brokers, 1)
+	conn, err := net.Dial("tcp", brokers[0])
+	s.Require().NoError(err)
+	s.NoError(conn.Close())
+}
+
+func (s *ITestKitIntegrationSuite) TestRabbitMQAcceptsConnections() {
+	// Arrange
+	kit := s.setupTestKit()
+
+	// Act
+	s.Require().NoError(kit.StartRabbitMQ())
+	s.T().Cleanup(kit.StopRabbitMQ)
+
+	// Assert
+	amqpURL, err := url.Parse(kit.RabbitMQURL())
+	s.Require().NoError(err)
+	s.Equal("amqp", amqpURL.Scheme)
+	conn, err := net.Dial("tcp", amqpURL.Host)
+	s.Require().NoError(err)
+	s.NoError(conn.Close())
+}
+
+func (s *ITestKitIntegrationSuite) TestNATSAcceptsConnections() {
+	// Arrange
+	kit := s.setupTestKit()
+
+	// Act
+	s.Require().NoError(kit.StartNATS())
+	s.T().Cleanup(kit.StopNATS)
+
+	// Assert
+	natsURL, err := url.Parse(kit.NATSURL())
+	s.Require().NoError(err)
+	s.Equal("nats", natsURL.Scheme)
+	conn, err := net.Dial("tcp", natsURL.Host)
+	s.Require().NoError(err)
+	s.NoError(conn.Close())
+}
+
+func (s *ITestKitIntegrationSuite) TestLocalstackIsHealthy() {
+	// Arrange
+	kit := s.setupTestKit()
+
+	// Act
+	s.Require().NoError(kit.StartLocalstack("s3"))
+	s.T().Cleanup(kit.StopLocalstack)
+
+	// Assert
+	resp, err := http.Get(kit.LocalstackEndpoint() + "/_localstack/health")
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = resp.Body.Close() })
+	s.Equal(http.StatusOK, resp.StatusCode)
+}
+
+func (s *ITestKitIntegrationSuite) TestLocalstackSubscribesQueueToTopic() {
+	// Arrange
+	kit := s.setupTestKit()
+	ctx := context.Background()
+	s.Require().NoError(kit.StartLocalstack("sqs", "sns"))
+	s.T().Cleanup(kit.StopLocalstack)
+
+	// Act
+	queueURL, queueErr := kit.CreateLocalstackQueue(ctx, "orders", nil)
+	topicARN, topicErr := kit.CreateLocalstackTopic(ctx, "events", nil)
+
+	// Assert
+	s.Require().NoError(queueErr)
+	s.Require().NoError(topicErr)
+	s.Contains(queueURL, "/orders")
+	s.Equal("arn:aws:sns:us-east-1:000000000000:events", topicARN)
+	s.NoError(kit.SubscribeLocalstackQueue(ctx, topicARN, queueURL, true))
+}
+
+func (s *ITestKitIntegrationSuite) TestMailhogCapturesEmails() {
+	// Arrange
+	kit := s.setupTestKit()
+	s.Require().NoError(kit.StartMailhog())
+	s.T().Cleanup(kit.StopMailhog)
+	message := []byte("Subject: Welcome\r\n\r\nHello Alice\r\n")
+
+	// Act
+	s.Require().NoError(
+		smtp.SendMail(kit.MailhogSMTPAddr(), nil, "noreply@example.com", []string{"alice@example.com"}, message),
+	)
+
+	// Assert
+	messages, err := kit.MailhogMessages(context.Background())
+	s.Require().NoError(err)
+	s.Require().Len(messages, 1)
+	s.Equal("noreply@example.com", messages[0].From)
+	s.Equal([]string{"alice@example.com"}, messages[0].To)
+	s.Equal("Welcome", messages[0].Subject)
+	s.Require().NoError(kit.DeleteMailhogMessages(context.Background()))
+	messages, err = kit.MailhogMessages(context.Background())
+	s.Require().NoError(err)
+	s.Empty(messages)
+}
+
 func (s *ITestKitIntegrationSuite) setupTestKit() *itestkit.ITestKit {
 	s.ensureDocker()
-	migrationsDir := s.migrationsPath()
 
-	return itestkit.New(itestkit.Config{
+	return itestkit.New(s.config())
+}
+
+func (s *ITestKitIntegrationSuite) config() itestkit.Config {
+	return itestkit.Config{
 		PostgresImage:  itestkit.DefaultConfig().PostgresImage,
 		RedisImage:     itestkit.DefaultConfig().RedisImage,
-		MigrationsPath: "file://" + migrationsDir,
+		MigrationsPath: "file://" + s.migrationsPath(),
 		Database:       "itest_integration",
 		User:           "itest",
 		Password:       "itest",
-	})
+	}
 }
 
 func (s *ITestKitIntegrationSuite) ensureDocker() {
@@ -183,6 +489,13 @@ func (s *ITestKitIntegrationSuite) ensureDocker() {
 	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
 }
 
+func (s *ITestKitIntegrationSuite) fixturesPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	s.Require().True(ok)
+
+	return filepath.Join(filepath.Dir(filename), "fixtures")
+}
+
 func (s *ITestKitIntegrationSuite) migrationsPath() string {
 	_, filename, _, ok := runtime.Caller(0)
 	s.Require().True(ok)
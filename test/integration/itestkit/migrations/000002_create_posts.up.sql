@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS posts (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    external_id UUID NOT NULL,
+    title TEXT NOT NULL,
+    published_at TIMESTAMPTZ NOT NULL
+);
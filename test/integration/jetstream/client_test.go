@@ -0,0 +1,128 @@
+//go:build integration
+
+package jetstream_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging/jetstream"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func requireDocker(s *suite.Suite) {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+}
+
+type ClientIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *jetstream.Client
+}
+
+func TestClientIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(ClientIntegrationSuite))
+}
+
+func (s *ClientIntegrationSuite) SetupSuite() {
+	requireDocker(&s.Suite)
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartNATS())
+
+	var err error
+	s.client, err = jetstream.New(jetstream.Config{
+		URL: s.kit.NATSURL(),
+		Streams: map[string]jetstream.StreamConfig{
+			"ORDERS": {Subjects: []string{"orders.>"}, Storage: jetstream.StorageMemory},
+		},
+		Consumers: map[string]jetstream.ConsumerConfig{
+			"billing":  {Stream: "ORDERS", FilterSubject: "orders.placed", AckWait: 5 * time.Second},
+			"shipping": {Stream: "ORDERS", FilterSubject: "orders.paid"},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.client.Connect(context.Background()))
+}
+
+func (s *ClientIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		s.NoError(s.client.Close())
+	}
+	if s.kit != nil {
+		s.kit.Cleanup()
+	}
+}
+
+func (s *ClientIntegrationSuite) TestConsumer_AcksAndRedeliversNacked() {
+	// Arrange
+	ctx := context.Background()
+	source, err := s.client.Source("billing")
+	s.Require().NoError(err)
+
+	var mu sync.Mutex
+	attempts := map[string][]int{}
+	consumer, err := messaging.NewConsumer("billing", source, func(_ context.Context, m messaging.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[string(m.Payload)] = append(attempts[string(m.Payload)], m.Attempt)
+		if string(m.Payload) == "order-2" && m.Attempt == 1 {
+			return errors.New("payment gateway unavailable")
+		}
+		return nil
+	}, logger.MustNewWithOptions(logger.WithLevel("fatal")), messaging.WithRegisterer(prometheus.NewRegistry()))
+	s.Require().NoError(err)
+
+	// Act
+	for _, order := range []string{"order-1", "order-2", "order-3"} {
+		s.Require().NoError(s.client.Publish(ctx, "orders.placed", order, []byte(order)))
+	}
+	s.Require().NoError(consumer.Start())
+	defer func() { s.NoError(consumer.Stop(ctx)) }()
+
+	// Assert
+	s.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(attempts["order-2"]) == 2
+	}, 10*time.Second, 50*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	s.Equal([]int{1}, attempts["order-1"])
+	s.Equal([]int{1, 2}, attempts["order-2"])
+	s.Equal([]int{1}, attempts["order-3"])
+}
+
+func (s *ClientIntegrationSuite) TestPublishMsg_DeduplicatesTheMessageID() {
+	// Arrange
+	ctx := context.Background()
+	header := map[string]string{jetstream.MsgIDHeader: "payment-42"}
+	source, err := s.client.Source("shipping")
+	s.Require().NoError(err)
+
+	// Act
+	s.Require().NoError(s.client.PublishMsg(ctx, "orders.paid", header, []byte("first")))
+	s.Require().NoError(s.client.PublishMsg(ctx, "orders.paid", header, []byte("retry")))
+
+	// Assert
+	deliveries, err := source.Fetch(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+	s.Equal("payment-42", deliveries[0].Message().ID)
+	s.Equal("first", string(deliveries[0].Message().Payload))
+	s.NoError(deliveries[0].Ack(ctx))
+}
@@ -0,0 +1,192 @@
+//go:build integration
+
+package jobstatus_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/ctxmeta"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/jobstatus"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func requireDocker(s *suite.Suite) {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+}
+
+type RedisStoreIntegrationSuite struct {
+	suite.Suite
+	kit     *itestkit.ITestKit
+	client  *redis.Client
+	tracker *jobstatus.Tracker
+}
+
+func TestRedisStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RedisStoreIntegrationSuite))
+}
+
+func (s *RedisStoreIntegrationSuite) SetupSuite() {
+	requireDocker(&s.Suite)
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	var err error
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+	s.tracker = jobstatus.NewTracker(jobstatus.NewRedisStore(s.client, "jobstatus:"), jobstatus.Config{TTL: time.Hour})
+}
+
+func (s *RedisStoreIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *RedisStoreIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+}
+
+func (s *RedisStoreIntegrationSuite) TestTracker_TracksAJobUntilItSucceeds() {
+	// Arrange
+	ctx := ctxmeta.WithUserID(context.Background(), "user-1")
+	job, err := s.tracker.Create(ctx, "export")
+	s.Require().NoError(err)
+
+	// Act
+	s.Require().NoError(s.tracker.Progress(context.Background(), job.ID, 50, "Halfway"))
+	s.Require().NoError(s.tracker.Succeed(context.Background(), job.ID, map[string]int{"rows": 10}))
+	got, err := s.tracker.Get(ctx, job.ID)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(jobstatus.StateSucceeded, got.State)
+	s.Equal("user-1", got.OwnerID)
+	s.JSONEq(`{"rows":10}`, string(got.Result))
+	ttl, err := s.kit.Redis().TTL(context.Background(), "shop:jobstatus:"+job.ID).Result()
+	s.Require().NoError(err)
+	s.InDelta(time.Hour.Seconds(), ttl.Seconds(), 5)
+}
+
+func (s *RedisStoreIntegrationSuite) TestGet_ReportsAMissingJob() {
+	// Act
+	_, found, err := jobstatus.NewRedisStore(s.client, "jobstatus:").Get(context.Background(), "missing")
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(found)
+}
+
+type GormStoreIntegrationSuite struct {
+	suite.Suite
+	kit *itestkit.ITestKit
+	sut *jobstatus.GormStore
+}
+
+func TestGormStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(GormStoreIntegrationSuite))
+}
+
+func (s *GormStoreIntegrationSuite) SetupSuite() {
+	requireDocker(&s.Suite)
+	s.kit = itestkit.New(itestkit.Config{
+		PostgresImage:  itestkit.DefaultConfig().PostgresImage,
+		MigrationsPath: "file://" + s.migrationsPath(),
+		Database:       "jobstatus_integration",
+		User:           "itest",
+		Password:       "itest",
+	})
+	s.Require().NoError(s.kit.StartPostgres())
+	s.Require().NoError(s.kit.RunMigrations())
+}
+
+func (s *GormStoreIntegrationSuite) TearDownSuite() {
+	s.kit.StopPostgres()
+}
+
+func (s *GormStoreIntegrationSuite) SetupTest() {
+	s.kit.TruncateTables(s.T())
+	s.sut = jobstatus.NewGormStore(s.kit.DB(), "job_statuses")
+}
+
+func (s *GormStoreIntegrationSuite) TestSave_ReplacesTheJob() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	job := jobstatus.Job{
+		ID:        "job-1",
+		Type:      "export",
+		State:     jobstatus.StatePending,
+		OwnerID:   "user-1",
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	s.Require().NoError(s.sut.Save(ctx, job, time.Hour))
+	job.State = jobstatus.StateFailed
+	job.Error = "The job failed"
+
+	// Act
+	err := s.sut.Save(ctx, job, time.Hour)
+
+	// Assert
+	s.Require().NoError(err)
+	got, found, err := s.sut.Get(ctx, "job-1")
+	s.Require().NoError(err)
+	s.Require().True(found)
+	s.Equal(jobstatus.StateFailed, got.State)
+	s.Equal("The job failed", got.Error)
+	s.Equal("user-1", got.OwnerID)
+	s.True(now.Add(time.Hour).Equal(got.ExpiresAt))
+}
+
+func (s *GormStoreIntegrationSuite) TestDeleteExpired_KeepsTheLiveJobs() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now().UTC()
+	for id, expiresAt := range map[string]time.Time{"expired": now.Add(-time.Minute), "live": now.Add(time.Hour)} {
+		job := jobstatus.Job{ID: id, State: jobstatus.StateSucceeded, CreatedAt: now, UpdatedAt: now, ExpiresAt: expiresAt}
+		s.Require().NoError(s.sut.Save(ctx, job, time.Hour))
+	}
+
+	// Act
+	deleted, err := s.sut.DeleteExpired(ctx, now)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(1), deleted)
+	_, found, err := s.sut.Get(ctx, "live")
+	s.Require().NoError(err)
+	s.True(found)
+}
+
+func (s *GormStoreIntegrationSuite) migrationsPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	s.Require().True(ok)
+	migrationsDir := filepath.Join(filepath.Dir(filename), "..", "..", "..", "pkg", "jobstatus", "migrations")
+	_, err := os.Stat(filepath.Join(migrationsDir, "20261015000001_create_job_statuses.up.sql"))
+	s.Require().NoError(err)
+
+	return migrationsDir
+}
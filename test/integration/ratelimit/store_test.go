@@ -0,0 +1,101 @@
+//go:build integration
+
+package ratelimit_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/clock"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/ratelimit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+type RedisStoreIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *redis.Client
+	sut    *ratelimit.RedisStore
+}
+
+func TestRedisStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RedisStoreIntegrationSuite))
+}
+
+func (s *RedisStoreIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+	s.sut = ratelimit.NewRedisStore(s.client, "ratelimit:")
+}
+
+func (s *RedisStoreIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *RedisStoreIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+}
+
+func (s *RedisStoreIntegrationSuite) TestIncrement_CountsAndExpiresTheWindow() {
+	// Arrange
+	ctx := context.Background()
+	_, err := s.sut.Increment(ctx, "free:user:1:1760529600", time.Minute)
+	s.Require().NoError(err)
+
+	// Act
+	count, err := s.sut.Increment(ctx, "free:user:1:1760529600", time.Minute)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(2), count)
+	ttl, err := s.kit.Redis().TTL(ctx, "shop:ratelimit:free:user:1:1760529600").Result()
+	s.Require().NoError(err)
+	s.InDelta(time.Minute.Seconds(), ttl.Seconds(), 5)
+}
+
+func (s *RedisStoreIntegrationSuite) TestLimiter_SharesTheCountsBetweenInstances() {
+	// Arrange
+	cfg := ratelimit.Config{Tiers: map[string]ratelimit.TierConfig{"default": {Limit: 3, Window: time.Minute}}}
+	clk := clock.NewFake(time.Now())
+	first, err := ratelimit.NewLimiter(s.sut, cfg, ratelimit.WithClock(clk))
+	s.Require().NoError(err)
+	second, err := ratelimit.NewLimiter(ratelimit.NewRedisStore(s.client, "ratelimit:"), cfg, ratelimit.WithClock(clk))
+	s.Require().NoError(err)
+	identity := ratelimit.Identity{Key: "user:1"}
+	for range 3 {
+		_, err = first.Allow(context.Background(), identity)
+		s.Require().NoError(err)
+	}
+
+	// Act
+	decision, err := second.Allow(context.Background(), identity)
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(decision.Allowed)
+}
@@ -0,0 +1,70 @@
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cristiano-pacheco/bricks/pkg/memcache"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func (s *ClientIntegrationSuite) TestCache_RoundTripsTheValues() {
+	// Arrange
+	ctx := context.Background()
+	sut := redis.NewCache[product](s.sut)
+
+	// Act
+	s.Require().NoError(sut.Set(ctx, "product:1", product{SKU: "A-1", Price: 10}, time.Minute))
+	found, ok, err := sut.Get(ctx, "product:1")
+	_, missing, missingErr := sut.Get(ctx, "product:2")
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal(product{SKU: "A-1", Price: 10}, found)
+	s.Require().NoError(missingErr)
+	s.False(missing)
+	ttl, err := s.kit.Redis().TTL(ctx, "catalog:product:1").Result()
+	s.Require().NoError(err)
+	s.Positive(ttl)
+}
+
+func (s *ClientIntegrationSuite) TestCache_Delete_RemovesTheKey() {
+	// Arrange
+	ctx := context.Background()
+	sut := redis.NewCache[product](s.sut)
+	s.Require().NoError(sut.Set(ctx, "product:1", product{SKU: "A-1"}, 0))
+
+	// Act
+	err := sut.Delete(ctx, "product:1")
+
+	// Assert
+	s.Require().NoError(err)
+	exists, err := s.kit.Redis().Exists(ctx, "catalog:product:1").Result()
+	s.Require().NoError(err)
+	s.Zero(exists)
+}
+
+func (s *ClientIntegrationSuite) TestCache_AsTheRemoteTierOfATieredCache() {
+	// Arrange
+	ctx := context.Background()
+	local, err := memcache.NewCache[product]("products", memcache.WithRegisterer(prometheus.NewRegistry()))
+	s.Require().NoError(err)
+	sut := memcache.NewTiered[product](local, redis.NewCache[product](s.sut), time.Minute)
+	s.Require().NoError(redis.MSetJSON(ctx, s.sut, map[string]product{"product:1": {SKU: "A-1"}}, 0))
+
+	// Act
+	found, ok, err := sut.Get(ctx, "product:1")
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal(product{SKU: "A-1"}, found)
+	cached, ok, _ := local.Get(ctx, "product:1")
+	s.True(ok)
+	s.Equal(product{SKU: "A-1"}, cached)
+}
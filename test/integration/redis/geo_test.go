@@ -0,0 +1,102 @@
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+var (
+	paulista = redis.Point{Lat: -23.5614, Lon: -46.6559}
+	se       = redis.Point{Lat: -23.5505, Lon: -46.6333}
+	santos   = redis.Point{Lat: -23.9608, Lon: -46.3336}
+)
+
+func (s *ClientIntegrationSuite) addCouriers() {
+	added, err := s.sut.GeoAdd(context.Background(), "couriers",
+		redis.GeoLocation{Member: "c-1", Point: paulista},
+		redis.GeoLocation{Member: "c-2", Point: se},
+		redis.GeoLocation{Member: "c-3", Point: santos},
+	)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(3), added)
+}
+
+func (s *ClientIntegrationSuite) TestGeoSearch_ReturnsTheMembersInTheRadiusNearestFirst() {
+	// Arrange
+	s.addCouriers()
+
+	// Act
+	found, err := s.sut.GeoSearch(context.Background(), "couriers", redis.GeoQuery{
+		Center: paulista,
+		Radius: 5,
+		Unit:   redis.Kilometers,
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(found, 2)
+	s.Equal("c-1", found[0].Member)
+	s.Equal("c-2", found[1].Member)
+	s.InDelta(2.6, found[1].Distance, 0.05)
+	s.InDelta(se.Lat, found[1].Point.Lat, 1e-4)
+}
+
+func (s *ClientIntegrationSuite) TestGeoSearch_FromMemberWithLimit() {
+	// Arrange
+	s.addCouriers()
+
+	// Act
+	found, err := s.sut.GeoSearch(context.Background(), "couriers", redis.GeoQuery{
+		FromMember: "c-3",
+		Width:      200,
+		Height:     200,
+		Unit:       redis.Kilometers,
+		Limit:      2,
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(found, 2)
+	s.Equal("c-3", found[0].Member)
+}
+
+func (s *ClientIntegrationSuite) TestGeoPositionAndDistance() {
+	// Arrange
+	ctx := context.Background()
+	s.addCouriers()
+
+	// Act
+	positions, err := s.sut.GeoPosition(ctx, "couriers", "c-1", "c-9")
+	distance, ok, distErr := s.sut.GeoDistance(ctx, "couriers", "c-1", "c-2", redis.Meters)
+	_, missing, missingErr := s.sut.GeoDistance(ctx, "couriers", "c-1", "c-9", redis.Meters)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Contains(positions, "c-1")
+	s.NotContains(positions, "c-9")
+	s.InDelta(paulista.Lon, positions["c-1"].Lon, 1e-4)
+	s.Require().NoError(distErr)
+	s.True(ok)
+	s.InDelta(paulista.Distance(se, redis.Meters), distance, 1)
+	s.Require().NoError(missingErr)
+	s.False(missing)
+}
+
+func (s *ClientIntegrationSuite) TestGeoRemove_RemovesTheMembers() {
+	// Arrange
+	ctx := context.Background()
+	s.addCouriers()
+
+	// Act
+	removed, err := s.sut.GeoRemove(ctx, "couriers", "c-1", "c-9")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(1), removed)
+	positions, err := s.sut.GeoPosition(ctx, "couriers", "c-1")
+	s.Require().NoError(err)
+	s.Empty(positions)
+}
@@ -0,0 +1,107 @@
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var errStop = errors.New("stop")
+
+func (s *ClientIntegrationSuite) seedKeys(ctx context.Context) {
+	for i := range 3 {
+		s.Require().NoError(s.kit.Redis().Set(ctx, fmt.Sprintf("catalog:tenant:1:item:%d", i), i, 0).Err())
+	}
+	s.Require().NoError(s.kit.Redis().Set(ctx, "catalog:tenant:2:item:0", 0, time.Hour).Err())
+	s.Require().NoError(s.kit.Redis().Set(ctx, "billing:tenant:1:item:0", 0, 0).Err())
+}
+
+func (s *ClientIntegrationSuite) TestScanKeys_IteratesTheNamespacedKeys() {
+	// Arrange
+	ctx := context.Background()
+	s.seedKeys(ctx)
+	var keys []string
+
+	// Act
+	err := s.sut.ScanKeys(ctx, "tenant:1:*", func(key string) error {
+		keys = append(keys, key)
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	sort.Strings(keys)
+	s.Equal([]string{"tenant:1:item:0", "tenant:1:item:1", "tenant:1:item:2"}, keys)
+}
+
+func (s *ClientIntegrationSuite) TestScanKeys_CallbackError_StopsTheScan() {
+	// Arrange
+	ctx := context.Background()
+	s.seedKeys(ctx)
+	var calls int
+
+	// Act
+	err := s.sut.ScanKeys(ctx, "*", func(string) error {
+		calls++
+		return errStop
+	})
+
+	// Assert
+	s.Require().ErrorIs(err, errStop)
+	s.Equal(1, calls)
+}
+
+func (s *ClientIntegrationSuite) TestDeleteKeys_DeletesTheMatchingKeys() {
+	// Arrange
+	ctx := context.Background()
+	s.seedKeys(ctx)
+
+	// Act
+	deleted, err := s.sut.DeleteKeys(ctx, "tenant:1:*")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(3), deleted)
+	remaining, err := s.kit.Redis().Keys(ctx, "*").Result()
+	s.Require().NoError(err)
+	sort.Strings(remaining)
+	s.Equal([]string{"billing:tenant:1:item:0", "catalog:tenant:2:item:0"}, remaining)
+}
+
+func (s *ClientIntegrationSuite) TestDeleteNamespace_KeepsTheOtherNamespaces() {
+	// Arrange
+	ctx := context.Background()
+	s.seedKeys(ctx)
+
+	// Act
+	deleted, err := s.sut.DeleteNamespace(ctx)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(4), deleted)
+	remaining, err := s.kit.Redis().Keys(ctx, "*").Result()
+	s.Require().NoError(err)
+	s.Equal([]string{"billing:tenant:1:item:0"}, remaining)
+}
+
+func (s *ClientIntegrationSuite) TestAuditTTL_ReportsTheKeysWithoutExpiration() {
+	// Arrange
+	ctx := context.Background()
+	s.seedKeys(ctx)
+
+	// Act
+	audit, err := s.sut.AuditTTL(ctx, "tenant:*")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(4), audit.Keys)
+	s.Equal(int64(3), audit.Persistent)
+	sort.Strings(audit.PersistentSample)
+	s.Equal([]string{"tenant:1:item:0", "tenant:1:item:1", "tenant:1:item:2"}, audit.PersistentSample)
+	s.Greater(audit.MaxTTL, 59*time.Minute)
+	s.Equal(audit.MinTTL, audit.MaxTTL)
+}
@@ -0,0 +1,77 @@
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+func (s *ClientIntegrationSuite) TestLock_HeldLock_IsNotAcquiredAgain() {
+	// Arrange
+	ctx := context.Background()
+	lock, err := s.sut.Lock(ctx, "lock:report", time.Minute)
+	s.Require().NoError(err)
+
+	// Act
+	_, err = s.sut.Lock(ctx, "lock:report", time.Minute)
+
+	// Assert
+	s.Require().ErrorIs(err, redis.ErrLockNotAcquired)
+	ttl, err := s.kit.Redis().PTTL(ctx, "catalog:lock:report").Result()
+	s.Require().NoError(err)
+	s.Greater(ttl, 59*time.Second)
+	s.Require().NoError(lock.Release(ctx))
+}
+
+func (s *ClientIntegrationSuite) TestLock_Release_FreesTheKey() {
+	// Arrange
+	ctx := context.Background()
+	lock, err := s.sut.Lock(ctx, "lock:report", time.Minute)
+	s.Require().NoError(err)
+
+	// Act
+	err = lock.Release(ctx)
+
+	// Assert
+	s.Require().NoError(err)
+	_, err = s.sut.Lock(ctx, "lock:report", time.Minute)
+	s.Require().NoError(err)
+}
+
+func (s *ClientIntegrationSuite) TestLock_Extend_ResetsTheTTL() {
+	// Arrange
+	ctx := context.Background()
+	lock, err := s.sut.Lock(ctx, "lock:report", time.Second)
+	s.Require().NoError(err)
+
+	// Act
+	err = lock.Extend(ctx, time.Minute)
+
+	// Assert
+	s.Require().NoError(err)
+	ttl, err := s.kit.Redis().PTTL(ctx, "catalog:lock:report").Result()
+	s.Require().NoError(err)
+	s.Greater(ttl, 59*time.Second)
+}
+
+func (s *ClientIntegrationSuite) TestLock_ExpiredLock_IsNotReleasedByItsPreviousHolder() {
+	// Arrange
+	ctx := context.Background()
+	lock, err := s.sut.Lock(ctx, "lock:report", time.Minute)
+	s.Require().NoError(err)
+	s.Require().NoError(s.kit.Redis().Set(ctx, "catalog:lock:report", "other-holder", time.Minute).Err())
+
+	// Act
+	errRelease := lock.Release(ctx)
+	errExtend := lock.Extend(ctx, time.Minute)
+
+	// Assert
+	s.Require().ErrorIs(errRelease, redis.ErrLockNotHeld)
+	s.Require().ErrorIs(errExtend, redis.ErrLockNotHeld)
+	holder, err := s.kit.Redis().Get(ctx, "catalog:lock:report").Result()
+	s.Require().NoError(err)
+	s.Equal("other-holder", holder)
+}
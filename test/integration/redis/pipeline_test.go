@@ -0,0 +1,141 @@
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+type product struct {
+	SKU   string `json:"sku"`
+	Price int    `json:"price"`
+}
+
+type ClientIntegrationSuite struct {
+	suite.Suite
+	kit *itestkit.ITestKit
+	sut *redis.Client
+}
+
+func TestClientIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(ClientIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *ClientIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	s.sut, err = redis.NewClient(context.Background(), redis.Config{
+		URL:           "redis://" + addr,
+		Type:          redis.ClientTypeSingleNode,
+		Namespace:     "catalog",
+		EnableMetrics: true,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ClientIntegrationSuite) TearDownSuite() {
+	if s.sut != nil {
+		_ = s.sut.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *ClientIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+	s.sut.ResetMetrics()
+}
+
+func (s *ClientIntegrationSuite) TestPipeline_RunsTheCommandsOnNamespacedKeys() {
+	// Arrange
+	ctx := context.Background()
+	var views *goredis.IntCmd
+	var missing *goredis.StringCmd
+
+	// Act
+	err := s.sut.Pipeline(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, "views", 41, time.Minute)
+		views = p.Incr(ctx, "views")
+		missing = p.Get(ctx, "missing")
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(42), views.Val())
+	s.ErrorIs(missing.Err(), goredis.Nil)
+	stored, err := s.kit.Redis().Get(ctx, "catalog:views").Result()
+	s.Require().NoError(err)
+	s.Equal("42", stored)
+	metrics := s.sut.GetMetrics()
+	s.Equal(uint64(3), metrics.CommandsExecuted)
+	s.Zero(metrics.CommandsFailed)
+}
+
+func (s *ClientIntegrationSuite) TestTxPipeline_RunsTheCommandsAtomically() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	err := s.sut.TxPipeline(ctx, func(p redis.Pipeliner) error {
+		p.HSet(ctx, "product:1", "sku", "A-1", "price", 10)
+		p.Expire(ctx, "product:1", time.Minute)
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	stored, err := s.kit.Redis().HGetAll(ctx, "catalog:product:1").Result()
+	s.Require().NoError(err)
+	s.Equal(map[string]string{"sku": "A-1", "price": "10"}, stored)
+}
+
+func (s *ClientIntegrationSuite) TestMSetJSONAndMGetJSON_RoundTripTheValues() {
+	// Arrange
+	ctx := context.Background()
+	values := map[string]product{
+		"product:1": {SKU: "A-1", Price: 10},
+		"product:2": {SKU: "B-2", Price: 20},
+	}
+
+	// Act
+	s.Require().NoError(redis.MSetJSON(ctx, s.sut, values, time.Minute))
+	found, err := redis.MGetJSON[product](ctx, s.sut, "product:1", "product:2", "product:3")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(values, found)
+	ttl, err := s.kit.Redis().TTL(ctx, "catalog:product:1").Result()
+	s.Require().NoError(err)
+	s.Positive(ttl)
+}
+
+func (s *ClientIntegrationSuite) TestMGetJSON_InvalidValue_ReturnsErrDecodeValue() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.kit.Redis().Set(ctx, "catalog:product:1", "not json", 0).Err())
+
+	// Act
+	_, err := redis.MGetJSON[product](ctx, s.sut, "product:1")
+
+	// Assert
+	s.Require().ErrorIs(err, redis.ErrDecodeValue)
+}
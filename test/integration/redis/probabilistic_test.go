@@ -0,0 +1,109 @@
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+// requireCommand skips the test when the server lacks command, e.g. a Redis without RedisBloom.
+func (s *ClientIntegrationSuite) requireCommand(command string) {
+	available, err := s.sut.HasCommand(context.Background(), command)
+	s.Require().NoError(err)
+	if !available {
+		s.T().Skipf("%s is not available on the test server", command)
+	}
+}
+
+func (s *ClientIntegrationSuite) TestHasCommand_ReportsTheKnownCommands() {
+	// Act
+	known, err := s.sut.HasCommand(context.Background(), "PFADD")
+	unknown, unknownErr := s.sut.HasCommand(context.Background(), "NOPE.ADD")
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(known)
+	s.Require().NoError(unknownErr)
+	s.False(unknown)
+}
+
+func (s *ClientIntegrationSuite) TestBloomAdd_WithoutRedisBloom_ReturnsErrModuleUnavailable() {
+	// Arrange
+	ctx := context.Background()
+	available, err := s.sut.HasCommand(ctx, "BF.ADD")
+	s.Require().NoError(err)
+	if available {
+		s.T().Skip("BF.ADD is available on the test server")
+	}
+
+	// Act
+	_, err = s.sut.BloomAdd(ctx, "events:seen", "evt-1")
+
+	// Assert
+	s.Require().ErrorIs(err, redis.ErrModuleUnavailable)
+}
+
+func (s *ClientIntegrationSuite) TestBloom_ReportsTheAddedItems() {
+	// Arrange
+	s.requireCommand("BF.ADD")
+	ctx := context.Background()
+	s.Require().NoError(s.sut.BloomReserve(ctx, "events:seen", 0.001, 1000))
+	s.Require().NoError(s.sut.BloomReserve(ctx, "events:seen", 0.001, 1000))
+
+	// Act
+	added, err := s.sut.BloomAdd(ctx, "events:seen", "evt-1")
+	again, _ := s.sut.BloomAdd(ctx, "events:seen", "evt-1")
+	multi, multiErr := s.sut.BloomAddMulti(ctx, "events:seen", "evt-1", "evt-2")
+	exists, _ := s.sut.BloomExistsMulti(ctx, "events:seen", "evt-2", "evt-3")
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(added)
+	s.False(again)
+	s.Require().NoError(multiErr)
+	s.Equal([]bool{false, true}, multi)
+	s.Equal([]bool{true, false}, exists)
+	count, err := s.kit.Redis().Exists(ctx, "catalog:events:seen").Result()
+	s.Require().NoError(err)
+	s.Equal(int64(1), count)
+}
+
+func (s *ClientIntegrationSuite) TestHLL_CountsTheDistinctItems() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	changed, err := s.sut.HLLAdd(ctx, "visitors:mon", "ana", "bob", "ana")
+	_, _ = s.sut.HLLAdd(ctx, "visitors:tue", "bob", "caio")
+	mergeErr := s.sut.HLLMerge(ctx, "visitors:week", "visitors:mon", "visitors:tue")
+	count, countErr := s.sut.HLLCount(ctx, "visitors:week")
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(changed)
+	s.Require().NoError(mergeErr)
+	s.Require().NoError(countErr)
+	s.Equal(int64(3), count)
+}
+
+func (s *ClientIntegrationSuite) TestTopK_ListsTheMostFrequentItems() {
+	// Arrange
+	s.requireCommand("TOPK.ADD")
+	ctx := context.Background()
+	s.Require().NoError(s.sut.TopKReserve(ctx, "searches", 2))
+	s.Require().NoError(s.sut.TopKReserve(ctx, "searches", 2))
+
+	// Act
+	_, err := s.sut.TopKAdd(ctx, "searches", "shoes", "shoes", "hat")
+	s.Require().NoError(s.sut.TopKIncrBy(ctx, "searches", "shoes", 3))
+	top, listErr := s.sut.TopKList(ctx, "searches")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().NoError(listErr)
+	s.Require().Len(top, 2)
+	s.Equal(redis.TopKItem{Item: "shoes", Count: 5}, top[0])
+	s.Equal("hat", top[1].Item)
+}
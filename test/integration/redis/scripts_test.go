@@ -0,0 +1,85 @@
+//go:build integration
+
+package redis_test
+
+import (
+	"context"
+
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+)
+
+const releaseLockScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("DEL", KEYS[1])
+end
+return 0`
+
+func (s *ClientIntegrationSuite) newScripts() *redis.Scripts {
+	scripts := redis.NewScripts(s.sut)
+	s.Require().NoError(scripts.Register("release_lock", releaseLockScript))
+	s.Require().NoError(scripts.Register("members", `return redis.call("SMEMBERS", KEYS[1])`))
+	return scripts
+}
+
+func (s *ClientIntegrationSuite) TestScripts_RunsOnNamespacedKeys() {
+	// Arrange
+	ctx := context.Background()
+	sut := s.newScripts()
+	s.Require().NoError(s.kit.Redis().Set(ctx, "catalog:lock:1", "token", 0).Err())
+
+	// Act
+	released, err := redis.RunScript[int64](ctx, sut, "release_lock", []string{"lock:1"}, "token")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(1), released)
+	exists, err := s.kit.Redis().Exists(ctx, "catalog:lock:1").Result()
+	s.Require().NoError(err)
+	s.Zero(exists)
+}
+
+func (s *ClientIntegrationSuite) TestScripts_NotCachedOnTheServer_FallsBackToEval() {
+	// Arrange
+	ctx := context.Background()
+	sut := s.newScripts()
+	s.Require().NoError(sut.Load(ctx))
+	s.Require().NoError(s.kit.Redis().ScriptFlush(ctx).Err())
+	s.Require().NoError(s.kit.Redis().SAdd(ctx, "catalog:tags", "new").Err())
+
+	// Act
+	members, err := redis.RunScript[[]string](ctx, sut, "members", []string{"tags"})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{"new"}, members)
+}
+
+func (s *ClientIntegrationSuite) TestScripts_Load_CachesTheScriptsOnTheServer() {
+	// Arrange
+	ctx := context.Background()
+	sut := s.newScripts()
+	sha, err := sut.SHA("release_lock")
+	s.Require().NoError(err)
+
+	// Act
+	err = sut.Load(ctx)
+
+	// Assert
+	s.Require().NoError(err)
+	exists, err := s.kit.Redis().ScriptExists(ctx, sha).Result()
+	s.Require().NoError(err)
+	s.Equal([]bool{true}, exists)
+}
+
+func (s *ClientIntegrationSuite) TestRunScript_UnexpectedReply_ReturnsErrUnexpectedScriptResult() {
+	// Arrange
+	ctx := context.Background()
+	sut := s.newScripts()
+	s.Require().NoError(s.kit.Redis().SAdd(ctx, "catalog:tags", "new").Err())
+
+	// Act
+	_, err := redis.RunScript[int64](ctx, sut, "members", []string{"tags"})
+
+	// Assert
+	s.Require().ErrorIs(err, redis.ErrUnexpectedScriptResult)
+}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS widgets (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    deleted_at TIMESTAMPTZ
+);
@@ -0,0 +1,242 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/cristiano-pacheco/bricks/pkg/database"
+	"github.com/cristiano-pacheco/bricks/pkg/database/repository"
+	"github.com/cristiano-pacheco/bricks/pkg/errs"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/paginator"
+)
+
+type widget struct {
+	ID        int64
+	Name      string
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+type RepositoryIntegrationSuite struct {
+	suite.Suite
+	kit *itestkit.ITestKit
+	sut *repository.Repository[widget, int64]
+}
+
+func TestRepositoryIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RepositoryIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *RepositoryIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.Config{
+		PostgresImage:  itestkit.DefaultConfig().PostgresImage,
+		MigrationsPath: "file://" + s.migrationsPath(),
+		Database:       "repository_integration",
+		User:           "itest",
+		Password:       "itest",
+	})
+	s.Require().NoError(s.kit.StartPostgres())
+	s.Require().NoError(s.kit.RunMigrations())
+}
+
+func (s *RepositoryIntegrationSuite) TearDownSuite() {
+	s.kit.StopPostgres()
+}
+
+func (s *RepositoryIntegrationSuite) SetupTest() {
+	s.kit.TruncateTables(s.T())
+
+	var err error
+	s.sut, err = repository.New[widget, int64](s.kit.DB())
+	s.Require().NoError(err)
+}
+
+func (s *RepositoryIntegrationSuite) TestCreateAndGet() {
+	// Arrange
+	ctx := context.Background()
+	entity := &widget{Name: "bolt"}
+
+	// Act
+	s.Require().NoError(s.sut.Create(ctx, entity))
+	found, err := s.sut.Get(ctx, entity.ID)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal("bolt", found.Name)
+	s.Equal(1, found.Version)
+}
+
+func (s *RepositoryIntegrationSuite) TestUpdate_IncrementsVersion() {
+	// Arrange
+	ctx := context.Background()
+	entity := &widget{Name: "bolt"}
+	s.Require().NoError(s.sut.Create(ctx, entity))
+	entity.Name = "nut"
+
+	// Act
+	err := s.sut.Update(ctx, entity)
+
+	// Assert
+	s.Require().NoError(err)
+	found, err := s.sut.Get(ctx, entity.ID)
+	s.Require().NoError(err)
+	s.Equal("nut", found.Name)
+	s.Equal(2, found.Version)
+	s.Equal(2, entity.Version)
+}
+
+func (s *RepositoryIntegrationSuite) TestUpdate_StaleVersion_ReturnsPreconditionFailed() {
+	// Arrange
+	ctx := context.Background()
+	entity := &widget{Name: "bolt"}
+	s.Require().NoError(s.sut.Create(ctx, entity))
+	stale := *entity
+	s.Require().NoError(s.sut.Update(ctx, entity))
+	stale.Name = "nut"
+
+	// Act
+	err := s.sut.Update(ctx, &stale)
+
+	// Assert
+	s.Require().ErrorIs(err, errs.ErrPreconditionFailed)
+	s.Equal(1, stale.Version)
+}
+
+func (s *RepositoryIntegrationSuite) TestUpdate_MissingRow_ReturnsNotFound() {
+	// Arrange
+	entity := &widget{ID: 404, Name: "bolt", Version: 1}
+
+	// Act
+	err := s.sut.Update(context.Background(), entity)
+
+	// Assert
+	s.Require().ErrorIs(err, errs.ErrRecordNotFound)
+}
+
+func (s *RepositoryIntegrationSuite) TestDeleteAndRestore() {
+	// Arrange
+	ctx := context.Background()
+	entity := &widget{Name: "bolt"}
+	s.Require().NoError(s.sut.Create(ctx, entity))
+
+	// Act
+	deleteErr := s.sut.Delete(ctx, entity.ID)
+	_, getDeletedErr := s.sut.Get(ctx, entity.ID)
+	deleted, _, listErr := s.sut.List(ctx, paginator.Params{}, repository.OnlyDeleted())
+	restoreErr := s.sut.Restore(ctx, entity.ID)
+	_, getRestoredErr := s.sut.Get(ctx, entity.ID)
+
+	// Assert
+	s.Require().NoError(deleteErr)
+	s.Require().ErrorIs(getDeletedErr, errs.ErrRecordNotFound)
+	s.Require().NoError(listErr)
+	s.Len(deleted, 1)
+	s.Require().NoError(restoreErr)
+	s.Require().NoError(getRestoredErr)
+}
+
+func (s *RepositoryIntegrationSuite) TestHardDelete() {
+	// Arrange
+	ctx := context.Background()
+	entity := &widget{Name: "bolt"}
+	s.Require().NoError(s.sut.Create(ctx, entity))
+
+	// Act
+	err := s.sut.HardDelete(ctx, entity.ID)
+
+	// Assert
+	s.Require().NoError(err)
+	_, _, listErr := s.sut.List(ctx, paginator.Params{}, repository.IncludeDeleted())
+	s.Require().NoError(listErr)
+	s.Require().ErrorIs(s.sut.HardDelete(ctx, entity.ID), errs.ErrRecordNotFound)
+}
+
+func (s *RepositoryIntegrationSuite) TestList_PaginatesSortsAndFilters() {
+	// Arrange
+	ctx := context.Background()
+	for _, name := range []string{"bolt", "nut", "bracket", "screw"} {
+		s.Require().NoError(s.sut.Create(ctx, &widget{Name: name}))
+	}
+
+	// Act
+	widgets, meta, err := s.sut.List(ctx, paginator.Params{Page: 1, PerPage: 2},
+		repository.OrderBy(repository.ParseSort("-name")...),
+		repository.Filter(func(db *gorm.DB) *gorm.DB { return db.Where("name <> ?", "nut") }),
+	)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(widgets, 2)
+	s.Equal("screw", widgets[0].Name)
+	s.Equal("bracket", widgets[1].Name)
+	s.Equal(int64(3), meta.TotalCount)
+	s.Equal(2, meta.TotalPages)
+}
+
+func (s *RepositoryIntegrationSuite) TestWithinTransaction_RollsBackOnError() {
+	// Arrange
+	ctx := context.Background()
+	txManager := database.NewTxManager(s.kit.DB())
+	rollback := errors.New("rollback")
+
+	// Act
+	err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		s.Require().NoError(s.sut.Create(ctx, &widget{Name: "bolt"}))
+		return rollback
+	})
+
+	// Assert
+	s.Require().ErrorIs(err, rollback)
+	widgets, _, listErr := s.sut.List(ctx, paginator.Params{})
+	s.Require().NoError(listErr)
+	s.Empty(widgets)
+}
+
+func (s *RepositoryIntegrationSuite) TestWithinTransaction_CommitsOnSuccess() {
+	// Arrange
+	ctx := context.Background()
+	txManager := database.NewTxManager(s.kit.DB())
+	entity := &widget{Name: "bolt"}
+
+	// Act
+	err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.sut.Create(ctx, entity)
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	_, getErr := s.sut.Get(ctx, entity.ID)
+	s.Require().NoError(getErr)
+}
+
+func (s *RepositoryIntegrationSuite) migrationsPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	s.Require().True(ok)
+	migrationsDir := filepath.Join(filepath.Dir(filename), "migrations")
+	_, err := os.Stat(filepath.Join(migrationsDir, "000001_create_widgets.up.sql"))
+	s.Require().NoError(err)
+
+	return migrationsDir
+}
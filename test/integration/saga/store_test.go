@@ -0,0 +1,122 @@
+//go:build integration
+
+package saga_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/saga"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+type GormStoreIntegrationSuite struct {
+	suite.Suite
+	kit *itestkit.ITestKit
+	sut *saga.GormStore
+}
+
+func TestGormStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(GormStoreIntegrationSuite))
+}
+
+func (s *GormStoreIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.Config{
+		PostgresImage:  itestkit.DefaultConfig().PostgresImage,
+		MigrationsPath: "file://" + s.migrationsPath(),
+		Database:       "saga_integration",
+		User:           "itest",
+		Password:       "itest",
+	})
+	s.Require().NoError(s.kit.StartPostgres())
+	s.Require().NoError(s.kit.RunMigrations())
+}
+
+func (s *GormStoreIntegrationSuite) TearDownSuite() {
+	s.kit.StopPostgres()
+}
+
+func (s *GormStoreIntegrationSuite) SetupTest() {
+	s.kit.TruncateTables(s.T())
+	s.sut = saga.NewGormStore(s.kit.DB(), "saga_instances")
+}
+
+func (s *GormStoreIntegrationSuite) TestUpdate_RejectsAStaleVersion() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	instance := saga.Instance{
+		ID:        uuid.New(),
+		Name:      "checkout",
+		Status:    saga.StatusRunning,
+		Data:      []byte(`{"order_id":"order-1"}`),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.Require().NoError(s.sut.Create(ctx, instance))
+	concurrent := instance
+	instance.Step = 1
+	s.Require().NoError(s.sut.Update(ctx, &instance))
+
+	// Act
+	err := s.sut.Update(ctx, &concurrent)
+
+	// Assert
+	s.Require().ErrorIs(err, saga.ErrConflict)
+	got, found, err := s.sut.Get(ctx, instance.ID)
+	s.Require().NoError(err)
+	s.Require().True(found)
+	s.Equal(1, got.Step)
+	s.Equal(1, got.Version)
+	s.JSONEq(`{"order_id":"order-1"}`, string(got.Data))
+}
+
+func (s *GormStoreIntegrationSuite) TestListStale_ReturnsTheUnfinishedInstances() {
+	// Arrange
+	ctx := context.Background()
+	now := time.Now().UTC()
+	stale := now.Add(-time.Hour)
+	for status, updatedAt := range map[saga.Status]time.Time{
+		saga.StatusRunning:      now,
+		saga.StatusCompensating: stale,
+		saga.StatusCompleted:    stale,
+	} {
+		s.Require().NoError(s.sut.Create(ctx, saga.Instance{
+			ID: uuid.New(), Name: "checkout", Status: status, CreatedAt: updatedAt, UpdatedAt: updatedAt,
+		}))
+	}
+
+	// Act
+	instances, err := s.sut.ListStale(ctx, now.Add(-time.Minute), 10)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(instances, 1)
+	s.Equal(saga.StatusCompensating, instances[0].Status)
+}
+
+func (s *GormStoreIntegrationSuite) migrationsPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	s.Require().True(ok)
+	migrationsDir := filepath.Join(filepath.Dir(filename), "..", "..", "..", "pkg", "saga", "migrations")
+	_, err := os.Stat(filepath.Join(migrationsDir, "20261015000002_create_saga_instances.up.sql"))
+	s.Require().NoError(err)
+
+	return migrationsDir
+}
@@ -0,0 +1,137 @@
+//go:build integration
+
+package scheduler_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+	"github.com/cristiano-pacheco/bricks/pkg/scheduler"
+)
+
+type RedisCoordinatorIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *redis.Client
+}
+
+func TestRedisCoordinatorIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RedisCoordinatorIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *RedisCoordinatorIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+}
+
+func (s *RedisCoordinatorIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *RedisCoordinatorIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+}
+
+func (s *RedisCoordinatorIntegrationSuite) newCoordinator() *scheduler.RedisCoordinator {
+	coordinator, err := scheduler.NewRedisCoordinator(s.client, "scheduler")
+	s.Require().NoError(err)
+	return coordinator
+}
+
+func (s *RedisCoordinatorIntegrationSuite) TestLead_ElectsASingleLeader() {
+	// Arrange
+	ctx := context.Background()
+	first, second := s.newCoordinator(), s.newCoordinator()
+
+	// Act
+	firstLeads, errFirst := first.Lead(ctx, time.Minute)
+	secondLeads, errSecond := second.Lead(ctx, time.Minute)
+	renewed, errRenew := first.Lead(ctx, time.Minute)
+
+	// Assert
+	s.Require().NoError(errFirst)
+	s.Require().NoError(errSecond)
+	s.Require().NoError(errRenew)
+	s.True(firstLeads)
+	s.False(secondLeads)
+	s.True(renewed)
+	exists, err := s.kit.Redis().Exists(ctx, "shop:scheduler:leader").Result()
+	s.Require().NoError(err)
+	s.Equal(int64(1), exists)
+}
+
+func (s *RedisCoordinatorIntegrationSuite) TestResign_LetsAnotherInstanceLead() {
+	// Arrange
+	ctx := context.Background()
+	first, second := s.newCoordinator(), s.newCoordinator()
+	_, err := first.Lead(ctx, time.Minute)
+	s.Require().NoError(err)
+
+	// Act
+	err = first.Resign(ctx)
+
+	// Assert
+	s.Require().NoError(err)
+	leads, err := second.Lead(ctx, time.Minute)
+	s.Require().NoError(err)
+	s.True(leads)
+}
+
+func (s *RedisCoordinatorIntegrationSuite) TestClaim_ClaimsEachRunOnce() {
+	// Arrange
+	ctx := context.Background()
+	first, second := s.newCoordinator(), s.newCoordinator()
+	due := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+
+	// Act
+	claimed, errFirst := first.Claim(ctx, "purge", due)
+	claimedAgain, errSecond := second.Claim(ctx, "purge", due)
+	claimedEarlier, errEarlier := second.Claim(ctx, "purge", due.Add(-time.Hour))
+
+	// Assert
+	s.Require().NoError(errFirst)
+	s.Require().NoError(errSecond)
+	s.Require().NoError(errEarlier)
+	s.True(claimed)
+	s.False(claimedAgain)
+	s.False(claimedEarlier)
+	last, found, err := second.LastRun(ctx, "purge")
+	s.Require().NoError(err)
+	s.True(found)
+	s.True(due.Equal(last))
+}
+
+func (s *RedisCoordinatorIntegrationSuite) TestLastRun_NeverRun_ReportsNotFound() {
+	// Act
+	_, found, err := s.newCoordinator().LastRun(context.Background(), "purge")
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(found)
+}
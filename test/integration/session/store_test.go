@@ -0,0 +1,112 @@
+//go:build integration
+
+package session_test
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/redis"
+	"github.com/cristiano-pacheco/bricks/pkg/session"
+)
+
+type RedisStoreIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *redis.Client
+	sut    *session.RedisStore
+}
+
+func TestRedisStoreIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(RedisStoreIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *RedisStoreIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartRedis())
+
+	addr := s.kit.Redis().(*goredis.Client).Options().Addr
+	s.client, err = redis.NewClient(context.Background(), redis.Config{
+		URL:       "redis://" + addr,
+		Type:      redis.ClientTypeSingleNode,
+		Namespace: "shop",
+	})
+	s.Require().NoError(err)
+	s.sut = session.NewRedisStore(s.client, "session:")
+}
+
+func (s *RedisStoreIntegrationSuite) TearDownSuite() {
+	if s.client != nil {
+		_ = s.client.Close()
+	}
+	s.kit.StopRedis()
+}
+
+func (s *RedisStoreIntegrationSuite) SetupTest() {
+	s.Require().NoError(s.kit.Redis().FlushAll(context.Background()).Err())
+}
+
+func (s *RedisStoreIntegrationSuite) TestSave_StoresUnderTheNamespacedKey() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	err := s.sut.Save(ctx, "abc", []byte(`{"values":{}}`), time.Hour)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(`{"values":{}}`, s.kit.Redis().Get(ctx, "shop:session:abc").Val())
+	s.InDelta(time.Hour.Seconds(), s.kit.Redis().TTL(ctx, "shop:session:abc").Val().Seconds(), 1)
+}
+
+func (s *RedisStoreIntegrationSuite) TestLoad_Touch_ExtendsTheTTL() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Save(ctx, "abc", []byte("data"), time.Minute))
+
+	// Act
+	data, found, err := s.sut.Load(ctx, "abc", time.Hour)
+
+	// Assert
+	s.Require().NoError(err)
+	s.True(found)
+	s.Equal([]byte("data"), data)
+	s.InDelta(time.Hour.Seconds(), s.kit.Redis().TTL(ctx, "shop:session:abc").Val().Seconds(), 1)
+}
+
+func (s *RedisStoreIntegrationSuite) TestLoad_MissingSession_ReportsNotFound() {
+	// Act
+	data, found, err := s.sut.Load(context.Background(), "missing", time.Hour)
+
+	// Assert
+	s.Require().NoError(err)
+	s.False(found)
+	s.Nil(data)
+}
+
+func (s *RedisStoreIntegrationSuite) TestDelete_RemovesTheSession() {
+	// Arrange
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Save(ctx, "abc", []byte("data"), time.Hour))
+
+	// Act
+	err := s.sut.Delete(ctx, "abc")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Zero(s.kit.Redis().Exists(ctx, "shop:session:abc").Val())
+}
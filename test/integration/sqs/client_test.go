@@ -0,0 +1,175 @@
+//go:build integration
+
+package sqs_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/logger"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging"
+	"github.com/cristiano-pacheco/bricks/pkg/messaging/sqs"
+)
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func requireDocker(s *suite.Suite) {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+}
+
+type ClientIntegrationSuite struct {
+	suite.Suite
+	kit    *itestkit.ITestKit
+	client *sqs.Client
+}
+
+func TestClientIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(ClientIntegrationSuite))
+}
+
+func (s *ClientIntegrationSuite) SetupSuite() {
+	requireDocker(&s.Suite)
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartLocalstack("sqs", "sns"))
+
+	ctx := context.Background()
+	dlqURL, err := s.kit.CreateLocalstackQueue(ctx, "orders-dlq", nil)
+	s.Require().NoError(err)
+	ordersURL, err := s.kit.CreateLocalstackQueue(ctx, "orders", map[string]string{
+		"RedrivePolicy": `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:000000000000:orders-dlq","maxReceiveCount":"2"}`,
+	})
+	s.Require().NoError(err)
+	paymentsURL, err := s.kit.CreateLocalstackQueue(ctx, "payments.fifo", map[string]string{"FifoQueue": "true"})
+	s.Require().NoError(err)
+	notificationsURL, err := s.kit.CreateLocalstackQueue(ctx, "notifications", nil)
+	s.Require().NoError(err)
+	topicARN, err := s.kit.CreateLocalstackTopic(ctx, "events", nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.kit.SubscribeLocalstackQueue(ctx, topicARN, notificationsURL, false))
+
+	session, err := aws.NewSession(aws.Config{
+		Region:          s.kit.LocalstackRegion(),
+		AccessKeyID:     "test",
+		SecretAccessKey: "test",
+		Endpoint:        s.kit.LocalstackEndpoint(),
+	}, aws.WithRegisterer(prometheus.NewRegistry()))
+	s.Require().NoError(err)
+	s.client, err = sqs.New(sqs.Config{
+		WaitTime: time.Second,
+		Queues: map[string]sqs.QueueConfig{
+			"orders":        {URL: ordersURL, VisibilityTimeout: 5 * time.Second},
+			"orders-dlq":    {URL: dlqURL},
+			"payments":      {URL: paymentsURL},
+			"notifications": {URL: notificationsURL, SNSEnvelope: true},
+		},
+		Topics: map[string]sqs.TopicConfig{"events": {ARN: topicARN}},
+	}, aws.NewSQS(session), aws.NewSNS(session))
+	s.Require().NoError(err)
+}
+
+func (s *ClientIntegrationSuite) TearDownSuite() {
+	if s.kit != nil {
+		s.kit.Cleanup()
+	}
+}
+
+func (s *ClientIntegrationSuite) TestConsumer_RedeliversNackedUntilTheDeadLetterQueue() {
+	// Arrange
+	ctx := context.Background()
+	source, err := s.client.Source("orders")
+	s.Require().NoError(err)
+
+	var mu sync.Mutex
+	attempts := map[string][]int{}
+	lastAttempts := map[string]bool{}
+	consumer, err := messaging.NewConsumer("orders", source, func(_ context.Context, m messaging.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[string(m.Payload)] = append(attempts[string(m.Payload)], m.Attempt)
+		lastAttempts[string(m.Payload)] = m.Headers[sqs.LastAttemptHeader] == "true"
+		if string(m.Payload) == "order-2" {
+			return errors.New("payment gateway unavailable")
+		}
+		return nil
+	}, logger.MustNewWithOptions(logger.WithLevel("fatal")), messaging.WithRegisterer(prometheus.NewRegistry()))
+	s.Require().NoError(err)
+
+	// Act
+	for _, order := range []string{"order-1", "order-2"} {
+		s.Require().NoError(s.client.Publish(ctx, "orders", order, []byte(order)))
+	}
+	s.Require().NoError(consumer.Start())
+	defer func() { s.NoError(consumer.Stop(ctx)) }()
+
+	// Assert
+	s.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(attempts["order-2"]) == 2
+	}, 15*time.Second, 50*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	s.Equal([]int{1}, attempts["order-1"])
+	s.Equal([]int{1, 2}, attempts["order-2"])
+	s.True(lastAttempts["order-2"])
+	s.Equal(2, source.MaxReceiveCount())
+}
+
+func (s *ClientIntegrationSuite) TestSource_FetchesFIFOGroupInOrder() {
+	// Arrange
+	ctx := context.Background()
+	source, err := s.client.Source("payments")
+	s.Require().NoError(err)
+
+	// Act
+	for _, payment := range []string{"payment-1", "payment-2", "payment-3"} {
+		s.Require().NoError(s.client.Publish(ctx, "payments", "order-1", []byte(payment)))
+	}
+	deliveries, err := source.Fetch(ctx, 10)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 3)
+	for i, payment := range []string{"payment-1", "payment-2", "payment-3"} {
+		s.Equal(payment, string(deliveries[i].Message().Payload))
+		s.Equal("order-1", deliveries[i].Message().Key)
+		s.NoError(deliveries[i].Ack(ctx))
+	}
+}
+
+func (s *ClientIntegrationSuite) TestPublish_DeliversTheTopicToItsQueues() {
+	// Arrange
+	ctx := context.Background()
+	source, err := s.client.Source("notifications")
+	s.Require().NoError(err)
+
+	// Act
+	s.Require().NoError(s.client.PublishMsg(ctx, "events", map[string]string{
+		sqs.IDAttribute:  "event-42",
+		sqs.KeyAttribute: "order-1",
+	}, []byte(`{"id":42}`)))
+	deliveries, err := source.Fetch(ctx, 10)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+	message := deliveries[0].Message()
+	s.Equal("event-42", message.ID)
+	s.Equal("events", message.Topic)
+	s.Equal("order-1", message.Key)
+	s.JSONEq(`{"id":42}`, string(message.Payload))
+	s.NoError(deliveries[0].Ack(ctx))
+}
@@ -0,0 +1,147 @@
+//go:build integration
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cristiano-pacheco/bricks/pkg/aws"
+	"github.com/cristiano-pacheco/bricks/pkg/itestkit"
+	"github.com/cristiano-pacheco/bricks/pkg/storage"
+)
+
+const bucket = "invoices"
+
+type S3StorageIntegrationSuite struct {
+	suite.Suite
+	kit *itestkit.ITestKit
+	sut *storage.S3Storage
+}
+
+func TestS3StorageIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(S3StorageIntegrationSuite))
+}
+
+func TestMain(m *testing.M) {
+	itestkit.TestMain(m)
+}
+
+func (s *S3StorageIntegrationSuite) SetupSuite() {
+	_, err := exec.LookPath("docker")
+	s.Require().NoError(err, "docker CLI must be installed for integration tests")
+	s.Require().NoError(exec.Command("docker", "info").Run(), "docker daemon must be available for integration tests")
+
+	s.kit = itestkit.New(itestkit.DefaultConfig())
+	s.Require().NoError(s.kit.StartMinIO(bucket))
+
+	accessKey, secretKey := s.kit.MinIOCredentials()
+	session, err := aws.NewSession(aws.Config{
+		Region:          s.kit.MinIORegion(),
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+		Endpoint:        s.kit.MinIOEndpoint(),
+	}, aws.WithRegisterer(prometheus.NewRegistry()))
+	s.Require().NoError(err)
+	s.sut, err = storage.NewS3Storage(storage.S3Config{Bucket: bucket}, aws.NewS3(session))
+	s.Require().NoError(err)
+}
+
+func (s *S3StorageIntegrationSuite) TearDownSuite() {
+	s.kit.StopMinIO()
+}
+
+func (s *S3StorageIntegrationSuite) TestPutGetDelete_RoundTripsObject() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	err := s.sut.Put(ctx, "2026/round trip.txt", strings.NewReader("hello"), storage.WithMetadata(map[string]string{
+		"owner": "ana",
+	}))
+	s.Require().NoError(err)
+	body, info, err := s.sut.Get(ctx, "2026/round trip.txt")
+
+	// Assert
+	s.Require().NoError(err)
+	content, _ := io.ReadAll(body)
+	_ = body.Close()
+	s.Equal("hello", string(content))
+	s.Equal("text/plain; charset=utf-8", info.ContentType)
+	s.Equal(int64(5), info.Size)
+
+	s.Require().NoError(s.sut.Delete(ctx, "2026/round trip.txt"))
+	_, _, err = s.sut.Get(ctx, "2026/round trip.txt")
+	s.ErrorIs(err, storage.ErrNotFound)
+}
+
+func (s *S3StorageIntegrationSuite) TestPut_LargeBody_UploadsInParts() {
+	// Arrange
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("0123456789"), 1<<20+1)
+
+	// Act
+	err := s.sut.Put(ctx, "backups/large.bin", bytes.NewReader(data))
+
+	// Assert
+	s.Require().NoError(err)
+	body, info, err := s.sut.Get(ctx, "backups/large.bin")
+	s.Require().NoError(err)
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	s.Equal(int64(len(data)), info.Size)
+	s.Equal(data, content)
+}
+
+func (s *S3StorageIntegrationSuite) TestList_ReturnsObjectsWithPrefix() {
+	// Arrange
+	ctx := context.Background()
+	for _, key := range []string{"list/a.txt", "list/b.txt", "other/c.txt"} {
+		s.Require().NoError(s.sut.Put(ctx, key, strings.NewReader(key)))
+	}
+
+	// Act
+	var keys []string
+	err := s.sut.List(ctx, "list/", func(info storage.ObjectInfo) error {
+		keys = append(keys, info.Key)
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal([]string{"list/a.txt", "list/b.txt"}, keys)
+}
+
+func (s *S3StorageIntegrationSuite) TestPresign_GrantsUploadAndDownload() {
+	// Arrange
+	ctx := context.Background()
+	putURL, err := s.sut.Presign(ctx, http.MethodPut, "presigned/note.txt", time.Minute)
+	s.Require().NoError(err)
+	getURL, err := s.sut.Presign(ctx, http.MethodGet, "presigned/note.txt", time.Minute)
+	s.Require().NoError(err)
+
+	// Act
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, strings.NewReader("presigned"))
+	s.Require().NoError(err)
+	putResp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	_ = putResp.Body.Close()
+	getResp, err := http.Get(getURL)
+	s.Require().NoError(err)
+	defer getResp.Body.Close()
+
+	// Assert
+	s.Equal(http.StatusOK, putResp.StatusCode)
+	s.Equal(http.StatusOK, getResp.StatusCode)
+	content, _ := io.ReadAll(getResp.Body)
+	s.Equal("presigned", string(content))
+}
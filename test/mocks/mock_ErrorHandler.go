@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	http "net/http"
 
 	mock "github.com/stretchr/testify/mock"
@@ -55,6 +56,41 @@ func (_c *MockErrorHandler_Error_Call) RunAndReturn(run func(http.ResponseWriter
 	return _c
 }
 
+// ErrorCtx provides a mock function with given fields: ctx, w, err
+func (_m *MockErrorHandler) ErrorCtx(ctx context.Context, w http.ResponseWriter, err error) {
+	_m.Called(ctx, w, err)
+}
+
+// MockErrorHandler_ErrorCtx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ErrorCtx'
+type MockErrorHandler_ErrorCtx_Call struct {
+	*mock.Call
+}
+
+// ErrorCtx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - w http.ResponseWriter
+//   - err error
+func (_e *MockErrorHandler_Expecter) ErrorCtx(ctx interface{}, w interface{}, err interface{}) *MockErrorHandler_ErrorCtx_Call {
+	return &MockErrorHandler_ErrorCtx_Call{Call: _e.mock.On("ErrorCtx", ctx, w, err)}
+}
+
+func (_c *MockErrorHandler_ErrorCtx_Call) Run(run func(ctx context.Context, w http.ResponseWriter, err error)) *MockErrorHandler_ErrorCtx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(http.ResponseWriter), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *MockErrorHandler_ErrorCtx_Call) Return() *MockErrorHandler_ErrorCtx_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockErrorHandler_ErrorCtx_Call) RunAndReturn(run func(context.Context, http.ResponseWriter, error)) *MockErrorHandler_ErrorCtx_Call {
+	_c.Run(run)
+	return _c
+}
+
 // NewMockErrorHandler creates a new instance of MockErrorHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockErrorHandler(t interface {
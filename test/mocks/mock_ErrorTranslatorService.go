@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockErrorTranslatorService is an autogenerated mock type for the ErrorTranslatorService type
 type MockErrorTranslatorService struct {
@@ -63,6 +67,53 @@ func (_c *MockErrorTranslatorService_TranslateError_Call) RunAndReturn(run func(
 	return _c
 }
 
+// TranslateErrorCtx provides a mock function with given fields: ctx, err
+func (_m *MockErrorTranslatorService) TranslateErrorCtx(ctx context.Context, err error) error {
+	ret := _m.Called(ctx, err)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TranslateErrorCtx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, error) error); ok {
+		r0 = rf(ctx, err)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockErrorTranslatorService_TranslateErrorCtx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TranslateErrorCtx'
+type MockErrorTranslatorService_TranslateErrorCtx_Call struct {
+	*mock.Call
+}
+
+// TranslateErrorCtx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - err error
+func (_e *MockErrorTranslatorService_Expecter) TranslateErrorCtx(ctx interface{}, err interface{}) *MockErrorTranslatorService_TranslateErrorCtx_Call {
+	return &MockErrorTranslatorService_TranslateErrorCtx_Call{Call: _e.mock.On("TranslateErrorCtx", ctx, err)}
+}
+
+func (_c *MockErrorTranslatorService_TranslateErrorCtx_Call) Run(run func(ctx context.Context, err error)) *MockErrorTranslatorService_TranslateErrorCtx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(error))
+	})
+	return _c
+}
+
+func (_c *MockErrorTranslatorService_TranslateErrorCtx_Call) Return(_a0 error) *MockErrorTranslatorService_TranslateErrorCtx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockErrorTranslatorService_TranslateErrorCtx_Call) RunAndReturn(run func(context.Context, error) error) *MockErrorTranslatorService_TranslateErrorCtx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockErrorTranslatorService creates a new instance of MockErrorTranslatorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockErrorTranslatorService(t interface {
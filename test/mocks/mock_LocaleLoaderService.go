@@ -75,6 +75,53 @@ func (_c *MockLocaleLoaderService_Load_Call) RunAndReturn(run func(string) (map[
 	return _c
 }
 
+// Locales provides a mock function with no fields
+func (_m *MockLocaleLoaderService) Locales() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Locales")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// MockLocaleLoaderService_Locales_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Locales'
+type MockLocaleLoaderService_Locales_Call struct {
+	*mock.Call
+}
+
+// Locales is a helper method to define mock.On call
+func (_e *MockLocaleLoaderService_Expecter) Locales() *MockLocaleLoaderService_Locales_Call {
+	return &MockLocaleLoaderService_Locales_Call{Call: _e.mock.On("Locales")}
+}
+
+func (_c *MockLocaleLoaderService_Locales_Call) Run(run func()) *MockLocaleLoaderService_Locales_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockLocaleLoaderService_Locales_Call) Return(_a0 []string) *MockLocaleLoaderService_Locales_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLocaleLoaderService_Locales_Call) RunAndReturn(run func() []string) *MockLocaleLoaderService_Locales_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockLocaleLoaderService creates a new instance of MockLocaleLoaderService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockLocaleLoaderService(t interface {
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	logger "github.com/cristiano-pacheco/bricks/pkg/logger"
 	mock "github.com/stretchr/testify/mock"
 
@@ -561,6 +563,54 @@ func (_c *MockLogger_With_Call) RunAndReturn(run func(...zapcore.Field) logger.L
 	return _c
 }
 
+// WithContext provides a mock function with given fields: ctx
+func (_m *MockLogger) WithContext(ctx context.Context) logger.Logger {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithContext")
+	}
+
+	var r0 logger.Logger
+	if rf, ok := ret.Get(0).(func(context.Context) logger.Logger); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(logger.Logger)
+		}
+	}
+
+	return r0
+}
+
+// MockLogger_WithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithContext'
+type MockLogger_WithContext_Call struct {
+	*mock.Call
+}
+
+// WithContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLogger_Expecter) WithContext(ctx interface{}) *MockLogger_WithContext_Call {
+	return &MockLogger_WithContext_Call{Call: _e.mock.On("WithContext", ctx)}
+}
+
+func (_c *MockLogger_WithContext_Call) Run(run func(ctx context.Context)) *MockLogger_WithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockLogger_WithContext_Call) Return(_a0 logger.Logger) *MockLogger_WithContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLogger_WithContext_Call) RunAndReturn(run func(context.Context) logger.Logger) *MockLogger_WithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithError provides a mock function with given fields: err
 func (_m *MockLogger) WithError(err error) logger.Logger {
 	ret := _m.Called(err)
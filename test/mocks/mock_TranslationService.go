@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockTranslationService is an autogenerated mock type for the TranslationService type
 type MockTranslationService struct {
@@ -112,6 +116,153 @@ func (_c *MockTranslationService_Translate_Call) RunAndReturn(run func(string, s
 	return _c
 }
 
+// TranslateCtx provides a mock function with given fields: ctx, domain, key
+func (_m *MockTranslationService) TranslateCtx(ctx context.Context, domain string, key string) string {
+	ret := _m.Called(ctx, domain, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TranslateCtx")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, domain, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockTranslationService_TranslateCtx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TranslateCtx'
+type MockTranslationService_TranslateCtx_Call struct {
+	*mock.Call
+}
+
+// TranslateCtx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+//   - key string
+func (_e *MockTranslationService_Expecter) TranslateCtx(ctx interface{}, domain interface{}, key interface{}) *MockTranslationService_TranslateCtx_Call {
+	return &MockTranslationService_TranslateCtx_Call{Call: _e.mock.On("TranslateCtx", ctx, domain, key)}
+}
+
+func (_c *MockTranslationService_TranslateCtx_Call) Run(run func(ctx context.Context, domain string, key string)) *MockTranslationService_TranslateCtx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTranslationService_TranslateCtx_Call) Return(_a0 string) *MockTranslationService_TranslateCtx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTranslationService_TranslateCtx_Call) RunAndReturn(run func(context.Context, string, string) string) *MockTranslationService_TranslateCtx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TranslatePlural provides a mock function with given fields: domain, key, count, data
+func (_m *MockTranslationService) TranslatePlural(domain string, key string, count int, data map[string]string) string {
+	ret := _m.Called(domain, key, count, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TranslatePlural")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, int, map[string]string) string); ok {
+		r0 = rf(domain, key, count, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockTranslationService_TranslatePlural_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TranslatePlural'
+type MockTranslationService_TranslatePlural_Call struct {
+	*mock.Call
+}
+
+// TranslatePlural is a helper method to define mock.On call
+//   - domain string
+//   - key string
+//   - count int
+//   - data map[string]string
+func (_e *MockTranslationService_Expecter) TranslatePlural(domain interface{}, key interface{}, count interface{}, data interface{}) *MockTranslationService_TranslatePlural_Call {
+	return &MockTranslationService_TranslatePlural_Call{Call: _e.mock.On("TranslatePlural", domain, key, count, data)}
+}
+
+func (_c *MockTranslationService_TranslatePlural_Call) Run(run func(domain string, key string, count int, data map[string]string)) *MockTranslationService_TranslatePlural_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(int), args[3].(map[string]string))
+	})
+	return _c
+}
+
+func (_c *MockTranslationService_TranslatePlural_Call) Return(_a0 string) *MockTranslationService_TranslatePlural_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTranslationService_TranslatePlural_Call) RunAndReturn(run func(string, string, int, map[string]string) string) *MockTranslationService_TranslatePlural_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TranslatePluralCtx provides a mock function with given fields: ctx, domain, key, count, data
+func (_m *MockTranslationService) TranslatePluralCtx(ctx context.Context, domain string, key string, count int, data map[string]string) string {
+	ret := _m.Called(ctx, domain, key, count, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TranslatePluralCtx")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, map[string]string) string); ok {
+		r0 = rf(ctx, domain, key, count, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockTranslationService_TranslatePluralCtx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TranslatePluralCtx'
+type MockTranslationService_TranslatePluralCtx_Call struct {
+	*mock.Call
+}
+
+// TranslatePluralCtx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+//   - key string
+//   - count int
+//   - data map[string]string
+func (_e *MockTranslationService_Expecter) TranslatePluralCtx(ctx interface{}, domain interface{}, key interface{}, count interface{}, data interface{}) *MockTranslationService_TranslatePluralCtx_Call {
+	return &MockTranslationService_TranslatePluralCtx_Call{Call: _e.mock.On("TranslatePluralCtx", ctx, domain, key, count, data)}
+}
+
+func (_c *MockTranslationService_TranslatePluralCtx_Call) Run(run func(ctx context.Context, domain string, key string, count int, data map[string]string)) *MockTranslationService_TranslatePluralCtx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].(map[string]string))
+	})
+	return _c
+}
+
+func (_c *MockTranslationService_TranslatePluralCtx_Call) Return(_a0 string) *MockTranslationService_TranslatePluralCtx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTranslationService_TranslatePluralCtx_Call) RunAndReturn(run func(context.Context, string, string, int, map[string]string) string) *MockTranslationService_TranslatePluralCtx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TranslateWithData provides a mock function with given fields: domain, key, data
 func (_m *MockTranslationService) TranslateWithData(domain string, key string, data map[string]string) string {
 	ret := _m.Called(domain, key, data)
@@ -160,6 +311,55 @@ func (_c *MockTranslationService_TranslateWithData_Call) RunAndReturn(run func(s
 	return _c
 }
 
+// TranslateWithDataCtx provides a mock function with given fields: ctx, domain, key, data
+func (_m *MockTranslationService) TranslateWithDataCtx(ctx context.Context, domain string, key string, data map[string]string) string {
+	ret := _m.Called(ctx, domain, key, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TranslateWithDataCtx")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, map[string]string) string); ok {
+		r0 = rf(ctx, domain, key, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockTranslationService_TranslateWithDataCtx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TranslateWithDataCtx'
+type MockTranslationService_TranslateWithDataCtx_Call struct {
+	*mock.Call
+}
+
+// TranslateWithDataCtx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+//   - key string
+//   - data map[string]string
+func (_e *MockTranslationService_Expecter) TranslateWithDataCtx(ctx interface{}, domain interface{}, key interface{}, data interface{}) *MockTranslationService_TranslateWithDataCtx_Call {
+	return &MockTranslationService_TranslateWithDataCtx_Call{Call: _e.mock.On("TranslateWithDataCtx", ctx, domain, key, data)}
+}
+
+func (_c *MockTranslationService_TranslateWithDataCtx_Call) Run(run func(ctx context.Context, domain string, key string, data map[string]string)) *MockTranslationService_TranslateWithDataCtx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(map[string]string))
+	})
+	return _c
+}
+
+func (_c *MockTranslationService_TranslateWithDataCtx_Call) Return(_a0 string) *MockTranslationService_TranslateWithDataCtx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTranslationService_TranslateWithDataCtx_Call) RunAndReturn(run func(context.Context, string, string, map[string]string) string) *MockTranslationService_TranslateWithDataCtx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockTranslationService creates a new instance of MockTranslationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockTranslationService(t interface {
@@ -3,11 +3,11 @@
 package mocks
 
 import (
-	mock "github.com/stretchr/testify/mock"
+	context "context"
 
 	ut "github.com/go-playground/universal-translator"
-
 	validator "github.com/go-playground/validator/v10"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // MockValidator is an autogenerated mock type for the Validator type
@@ -209,6 +209,53 @@ func (_c *MockValidator_Validate_Call) RunAndReturn(run func(interface{}) error)
 	return _c
 }
 
+// ValidateCtx provides a mock function with given fields: ctx, s
+func (_m *MockValidator) ValidateCtx(ctx context.Context, s interface{}) error {
+	ret := _m.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateCtx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) error); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockValidator_ValidateCtx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateCtx'
+type MockValidator_ValidateCtx_Call struct {
+	*mock.Call
+}
+
+// ValidateCtx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - s interface{}
+func (_e *MockValidator_Expecter) ValidateCtx(ctx interface{}, s interface{}) *MockValidator_ValidateCtx_Call {
+	return &MockValidator_ValidateCtx_Call{Call: _e.mock.On("ValidateCtx", ctx, s)}
+}
+
+func (_c *MockValidator_ValidateCtx_Call) Run(run func(ctx context.Context, s interface{})) *MockValidator_ValidateCtx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockValidator_ValidateCtx_Call) Return(_a0 error) *MockValidator_ValidateCtx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockValidator_ValidateCtx_Call) RunAndReturn(run func(context.Context, interface{}) error) *MockValidator_ValidateCtx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateVar provides a mock function with given fields: field, tag
 func (_m *MockValidator) ValidateVar(field interface{}, tag string) error {
 	ret := _m.Called(field, tag)